@@ -1,6 +1,7 @@
 package ansi
 
 import (
+	"encoding/base64"
 	"fmt"
 	"io"
 	"os"
@@ -32,8 +33,9 @@ var ForceColors = false
 // DisableColors disables all colors and other ANSI sequences.
 var DisableColors = false
 
-// EnvironmentOverrideColors overs coloring based on `CLICOLOR` and
-// `CLICOLOR_FORCE`. Cf. https://bixense.com/clicolors/
+// EnvironmentOverrideColors overs coloring based on `CLICOLOR`,
+// `CLICOLOR_FORCE` and `NO_COLOR`. Cf. https://bixense.com/clicolors/ and
+// https://no-color.org/
 var EnvironmentOverrideColors = true
 
 //
@@ -47,9 +49,18 @@ func Bold(text string) string {
 }
 
 // Color returns an aurora.Aurora instance with colors enabled or disabled
-// depending on whether the writer supports colors.
+// depending on whether the writer supports colors. Colors are shown with the
+// current theme.
 func Color(w io.Writer) aurora.Aurora {
-	return aurora.NewAurora(shouldUseColors(w))
+	if !shouldUseColors(w) {
+		return aurora.NewAurora(false)
+	}
+
+	if currentTheme.Name == DefaultThemeName {
+		return aurora.NewAurora(true)
+	}
+
+	return themedAurora{Aurora: aurora.NewAurora(true), theme: currentTheme}
 }
 
 // ColorizeJSON returns a colorized version of the input JSON, if the writer
@@ -59,17 +70,18 @@ func ColorizeJSON(json string, darkStyle bool, w io.Writer) string {
 		return json
 	}
 
-	style := (*pretty.Style)(nil)
-	if darkStyle {
-		style = darkTerminalStyle
-	}
-
-	return string(pretty.Color([]byte(json), style))
+	return string(pretty.Color([]byte(json), currentTheme.jsonStyle(darkStyle)))
 }
 
 // ColorizeStatus returns a colorized number for HTTP status code
 func ColorizeStatus(status int) aurora.Value {
-	color := Color(os.Stdout)
+	return ColorizeStatusFor(os.Stdout, status)
+}
+
+// ColorizeStatusFor returns a colorized number for HTTP status code, if the
+// writer supports colors
+func ColorizeStatusFor(w io.Writer, status int) aurora.Value {
+	color := Color(w)
 
 	switch {
 	case status >= 500:
@@ -105,6 +117,12 @@ func Linkify(text, url string, w io.Writer) string {
 	return fmt.Sprintf("\x1b]8;;%s\x1b\\%s\x1b]8;;\x1b\\", url, text)
 }
 
+// CopyToClipboard asks the terminal to place s on the system clipboard using
+// the OSC 52 escape sequence. Terminals that don't support it ignore it.
+func CopyToClipboard(w io.Writer, s string) {
+	fmt.Fprintf(w, "\x1b]52;c;%s\a", base64.StdEncoding.EncodeToString([]byte(s)))
+}
+
 type charset = []string
 
 func getCharset() charset {
@@ -175,6 +193,12 @@ func StrikeThrough(text string) string {
 	return color.Sprintf(color.StrikeThrough(text))
 }
 
+// NoColor returns true if the NO_COLOR environment variable asks for output
+// without colors
+func NoColor() bool {
+	return EnvironmentOverrideColors && os.Getenv("NO_COLOR") != ""
+}
+
 //
 // Private functions
 //
@@ -204,6 +228,9 @@ func shouldUseColors(w io.Writer) bool {
 			useColors = true
 		case ok && force == "0":
 			useColors = false
+		case NoColor() && !ForceColors:
+			// --color always takes precedence over NO_COLOR
+			useColors = false
 		case os.Getenv("CLICOLOR") == "0":
 			useColors = false
 		}
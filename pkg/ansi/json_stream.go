@@ -0,0 +1,214 @@
+package ansi
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/tidwall/pretty"
+)
+
+// jsonStreamBufferSize is how much JSON is read and colorized at a time
+const jsonStreamBufferSize = 32 * 1024
+
+// ColorizeJSONStream copies the JSON read from r to w as it arrives,
+// colorized like ColorizeJSON, so documents of any size can be printed
+// without holding them in memory.
+func ColorizeJSONStream(w io.Writer, r io.Reader, darkStyle bool) error {
+	if !shouldUseColors(w) {
+		_, err := io.Copy(w, r)
+		return err
+	}
+
+	style := currentTheme.jsonStyle(darkStyle)
+
+	out := bufio.NewWriterSize(w, jsonStreamBufferSize)
+	c := &jsonColorizer{style: style}
+
+	buf := make([]byte, jsonStreamBufferSize)
+	var dst []byte
+
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			dst = c.colorize(dst[:0], buf[:n])
+			if _, werr := out.Write(dst); werr != nil {
+				return werr
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := out.Write(c.finish(dst[:0])); err != nil {
+		return err
+	}
+
+	return out.Flush()
+}
+
+// jsonColorizer colorizes JSON one chunk at a time. It produces the same
+// output as pretty.Color on the whole document, keeping the state it needs
+// between chunks.
+type jsonColorizer struct {
+	style *pretty.Style
+
+	// stack holds the objects and arrays being read. key is true when the
+	// next string of an object is a key.
+	stack []jsonFrame
+
+	inString bool
+	key      bool
+	// escaped is true inside an escape sequence, which lasts escapeLeft more
+	// bytes, or an undetermined number if escapeLeft is 0
+	escaped    bool
+	escapeLeft int
+	// backslashes counts the backslashes right before the current byte of a
+	// string
+	backslashes int
+
+	// literal is the kind of number, boolean or null being read, if any
+	literal byte
+}
+
+type jsonFrame struct {
+	kind byte
+	key  bool
+}
+
+func (c *jsonColorizer) colorize(dst, src []byte) []byte {
+	for _, b := range src {
+		dst = c.colorizeByte(dst, b)
+	}
+
+	return dst
+}
+
+func (c *jsonColorizer) colorizeByte(dst []byte, b byte) []byte {
+	if c.inString {
+		return c.colorizeStringByte(dst, b)
+	}
+
+	if c.literal != 0 {
+		if b > ' ' && b != ',' && b != ':' && b != ']' && b != '}' {
+			return c.appendByte(dst, b)
+		}
+
+		dst = append(dst, c.literalStyle()[1]...)
+		c.literal = 0
+	}
+
+	switch {
+	case b == '"':
+		c.inString = true
+		c.key = len(c.stack) > 0 && c.stack[len(c.stack)-1].key
+		c.escaped = false
+		c.backslashes = 0
+		dst = append(dst, c.stringStyle()[0]...)
+		return c.appendByte(dst, b)
+	case b == '{' || b == '[':
+		c.stack = append(c.stack, jsonFrame{kind: b, key: b == '{'})
+	case (b == '}' || b == ']') && len(c.stack) > 0:
+		c.stack = c.stack[:len(c.stack)-1]
+	case (b == ':' || b == ',') && len(c.stack) > 0 && c.stack[len(c.stack)-1].kind == '{':
+		c.stack[len(c.stack)-1].key = !c.stack[len(c.stack)-1].key
+	case (b >= '0' && b <= '9') || b == '-' || b == 't' || b == 'f' || b == 'n':
+		c.literal = b
+		dst = append(dst, c.literalStyle()[0]...)
+	}
+
+	return c.appendByte(dst, b)
+}
+
+func (c *jsonColorizer) colorizeStringByte(dst []byte, b byte) []byte {
+	switch {
+	case b == '\\':
+		dst = append(dst, c.stringStyle()[1]...)
+		dst = append(dst, c.style.Escape[0]...)
+		dst = c.appendByte(dst, b)
+		c.escaped = true
+		c.escapeLeft = 0
+	case c.escaped:
+		if c.escapeLeft == 0 {
+			// \uXXXX escapes are 5 bytes long, the others 1
+			c.escapeLeft = 1
+			if b == 'u' {
+				c.escapeLeft = 5
+			}
+		}
+
+		dst = c.appendByte(dst, b)
+		if c.escapeLeft == 1 {
+			c.escaped = false
+			dst = append(dst, c.style.Escape[1]...)
+			dst = append(dst, c.stringStyle()[0]...)
+		} else {
+			c.escapeLeft--
+		}
+	default:
+		dst = c.appendByte(dst, b)
+	}
+
+	if b == '"' && c.backslashes%2 == 0 {
+		c.inString = false
+		if c.escaped {
+			dst = append(dst, c.style.Escape[1]...)
+		} else {
+			dst = append(dst, c.stringStyle()[1]...)
+		}
+	}
+
+	if b == '\\' {
+		c.backslashes++
+	} else {
+		c.backslashes = 0
+	}
+
+	return dst
+}
+
+// finish closes the string or literal the document ended with.
+func (c *jsonColorizer) finish(dst []byte) []byte {
+	switch {
+	case c.inString && c.escaped:
+		dst = append(dst, c.style.Escape[1]...)
+	case c.inString:
+		dst = append(dst, c.stringStyle()[1]...)
+	case c.literal != 0:
+		dst = append(dst, c.literalStyle()[1]...)
+	}
+
+	return dst
+}
+
+func (c *jsonColorizer) appendByte(dst []byte, b byte) []byte {
+	if c.style.Append != nil {
+		return c.style.Append(dst, b)
+	}
+
+	return append(dst, b)
+}
+
+func (c *jsonColorizer) stringStyle() [2]string {
+	if c.key {
+		return c.style.Key
+	}
+
+	return c.style.String
+}
+
+func (c *jsonColorizer) literalStyle() [2]string {
+	switch c.literal {
+	case 't':
+		return c.style.True
+	case 'f':
+		return c.style.False
+	case 'n':
+		return c.style.Null
+	default:
+		return c.style.Number
+	}
+}
@@ -0,0 +1,62 @@
+package ansi
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/pretty"
+)
+
+var jsonStreamDocuments = []string{
+	`{"id": "cus_123", "object": "customer", "balance": -100, "delinquent": false, "email": null}`,
+	"{\n  \"object\": \"list\",\n  \"data\": [\n    {\"id\": \"ch_1\", \"paid\": true, \"amount\": 2000},\n    \"value\",\n    [1, 2.5e3, null]\n  ],\n  \"has_more\": false\n}\n",
+	`{"description": "quotes \" and \\ backslashes \\\" é \n newlines", "key \"quoted\"": "v"}`,
+	`[true, false, null, 42, "trailing`,
+	`12345`,
+}
+
+func TestColorizeJSONStreamMatchesColorizeJSON(t *testing.T) {
+	for _, style := range []*pretty.Style{pretty.TerminalStyle, darkTerminalStyle} {
+		for _, doc := range jsonStreamDocuments {
+			expected := string(pretty.Color([]byte(doc), style))
+
+			// The output doesn't depend on how the document is split
+			for size := 1; size <= len(doc); size++ {
+				c := &jsonColorizer{style: style}
+
+				var out []byte
+				for i := 0; i < len(doc); i += size {
+					end := i + size
+					if end > len(doc) {
+						end = len(doc)
+					}
+					out = c.colorize(out, []byte(doc[i:end]))
+				}
+				out = c.finish(out)
+
+				require.Equal(t, expected, string(out), "chunks of %d bytes of %s", size, doc)
+			}
+		}
+	}
+}
+
+func TestColorizeJSONStream(t *testing.T) {
+	ForceColors = true
+	defer func() { ForceColors = false }()
+
+	doc := jsonStreamDocuments[1]
+
+	var out bytes.Buffer
+	require.NoError(t, ColorizeJSONStream(&out, strings.NewReader(doc), false))
+	require.Equal(t, ColorizeJSON(doc, false, &out), out.String())
+}
+
+func TestColorizeJSONStreamWithoutColors(t *testing.T) {
+	doc := jsonStreamDocuments[1]
+
+	var out bytes.Buffer
+	require.NoError(t, ColorizeJSONStream(&out, strings.NewReader(doc), false))
+	require.Equal(t, doc, out.String())
+}
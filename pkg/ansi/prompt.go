@@ -0,0 +1,71 @@
+package ansi
+
+import (
+	"os"
+	"regexp"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/manifoldco/promptui"
+)
+
+// promptBackgrounds are the background colors of promptui, restored when
+// colors are enabled again
+var promptBackgrounds = map[string]interface{}{}
+
+func init() {
+	for _, name := range []string{"bgBlack", "bgRed", "bgGreen", "bgYellow", "bgBlue", "bgMagenta", "bgCyan", "bgWhite"} {
+		promptBackgrounds[name] = promptui.FuncMap[name]
+	}
+}
+
+// sgrPattern matches the ANSI sequences setting colors and formats
+var sgrPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// ConfigurePrompts makes the prompts of promptui show the colors of the
+// theme, and no colors when they're disabled, e.g. with NO_COLOR or --color
+// never, since promptui always colors them otherwise.
+func ConfigurePrompts() {
+	color := Color(os.Stdout)
+
+	styles := map[string]func(interface{}) aurora.Value{
+		"black":     color.Black,
+		"red":       color.Red,
+		"green":     color.Green,
+		"yellow":    color.Yellow,
+		"blue":      color.Blue,
+		"magenta":   color.Magenta,
+		"cyan":      color.Cyan,
+		"white":     color.White,
+		"bold":      color.Bold,
+		"faint":     color.Faint,
+		"italic":    color.Italic,
+		"underline": color.Underline,
+	}
+	for name, style := range styles {
+		style := style
+		promptui.FuncMap[name] = func(v interface{}) string {
+			return style(v).String()
+		}
+	}
+
+	for name, background := range promptBackgrounds {
+		if shouldUseColors(os.Stdout) {
+			promptui.FuncMap[name] = background
+		} else {
+			promptui.FuncMap[name] = func(v interface{}) string {
+				return color.Reset(v).String()
+			}
+		}
+	}
+
+	promptui.IconInitial = color.Blue(plain(promptui.IconInitial)).String()
+	promptui.IconGood = color.Green(plain(promptui.IconGood)).String()
+	promptui.IconWarn = color.Yellow(plain(promptui.IconWarn)).String()
+	promptui.IconBad = color.Red(plain(promptui.IconBad)).String()
+	promptui.IconSelect = color.Bold(plain(promptui.IconSelect)).String()
+}
+
+// plain returns s without colors and formats
+func plain(s string) string {
+	return sgrPattern.ReplaceAllString(s, "")
+}
@@ -0,0 +1,250 @@
+package ansi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/tidwall/pretty"
+	prefixed "github.com/x-cray/logrus-prefixed-formatter"
+)
+
+// Indexes of the base colors in the 256 colors palette, which are the colors
+// the CLI asks aurora for and that themes replace
+const (
+	black uint8 = iota
+	red
+	green
+	yellow
+	blue
+	magenta
+	cyan
+	white
+)
+
+// themeColor is a color of the 256 colors palette, optionally in bold
+type themeColor struct {
+	index uint8
+	bold  bool
+}
+
+// Theme is a palette the colors of the CLI are shown with
+type Theme struct {
+	Name        string
+	Description string
+
+	// colors replaces the base colors, the ones missing are kept
+	colors map[uint8]themeColor
+
+	// noFaint shows faint text with the normal intensity, since it's
+	// hard to read on some terminals
+	noFaint bool
+
+	// json is the style of colorized JSON, pretty.TerminalStyle if nil
+	json *pretty.Style
+
+	// log is the color scheme of log messages, in the format of
+	// github.com/mgutz/ansi, the default of the formatter if nil
+	log *prefixed.ColorScheme
+}
+
+// DefaultThemeName is the name of the theme used unless another one is set
+// with the `color.theme` setting
+const DefaultThemeName = "default"
+
+var themes = map[string]*Theme{
+	DefaultThemeName: {
+		Name:        DefaultThemeName,
+		Description: "The standard colors of the terminal",
+	},
+	"light": {
+		Name:        "light",
+		Description: "Darker colors, readable on light backgrounds",
+		colors: map[uint8]themeColor{
+			red:     {index: 124},
+			green:   {index: 28},
+			yellow:  {index: 130},
+			blue:    {index: 25},
+			magenta: {index: 90},
+			cyan:    {index: 30},
+			white:   {index: black},
+		},
+		json: darkTerminalStyle,
+		log: &prefixed.ColorScheme{
+			InfoLevelStyle:  "28",
+			WarnLevelStyle:  "130",
+			ErrorLevelStyle: "124",
+			FatalLevelStyle: "124",
+			PanicLevelStyle: "124",
+			DebugLevelStyle: "25",
+			PrefixStyle:     "30",
+			TimestampStyle:  "240",
+		},
+	},
+	"high-contrast": {
+		Name:        "high-contrast",
+		Description: "Bright and bold colors, without faint text",
+		colors: map[uint8]themeColor{
+			red:     {index: 9, bold: true},
+			green:   {index: 10, bold: true},
+			yellow:  {index: 11, bold: true},
+			blue:    {index: 12, bold: true},
+			magenta: {index: 13, bold: true},
+			cyan:    {index: 14, bold: true},
+			white:   {index: 15, bold: true},
+		},
+		noFaint: true,
+		json: &pretty.Style{
+			Key:    [2]string{"\x1B[1;94m", "\x1B[0m"},
+			String: [2]string{"\x1B[1;92m", "\x1B[0m"},
+			Number: [2]string{"\x1B[1;93m", "\x1B[0m"},
+			True:   [2]string{"\x1B[1;96m", "\x1B[0m"},
+			False:  [2]string{"\x1B[1;96m", "\x1B[0m"},
+			Null:   [2]string{"\x1B[1;91m", "\x1B[0m"},
+		},
+		log: &prefixed.ColorScheme{
+			InfoLevelStyle:  "green+bh",
+			WarnLevelStyle:  "yellow+bh",
+			ErrorLevelStyle: "red+bh",
+			FatalLevelStyle: "red+bh",
+			PanicLevelStyle: "red+bh",
+			DebugLevelStyle: "blue+bh",
+			PrefixStyle:     "cyan+bh",
+			TimestampStyle:  "white+h",
+		},
+	},
+	"colorblind": {
+		Name:        "colorblind",
+		Description: "The Okabe-Ito palette, where successes and failures are blue and vermillion rather than green and red",
+		colors: map[uint8]themeColor{
+			red:     {index: 166},
+			green:   {index: 74},
+			yellow:  {index: 220},
+			blue:    {index: 25},
+			magenta: {index: 175},
+			cyan:    {index: 36},
+		},
+		json: &pretty.Style{
+			Key:    [2]string{"\x1B[38;5;74m", "\x1B[0m"},
+			String: [2]string{"\x1B[38;5;36m", "\x1B[0m"},
+			Number: [2]string{"\x1B[38;5;220m", "\x1B[0m"},
+			True:   [2]string{"\x1B[38;5;175m", "\x1B[0m"},
+			False:  [2]string{"\x1B[38;5;175m", "\x1B[0m"},
+			Null:   [2]string{"\x1B[38;5;166m", "\x1B[0m"},
+		},
+		log: &prefixed.ColorScheme{
+			InfoLevelStyle:  "74",
+			WarnLevelStyle:  "220",
+			ErrorLevelStyle: "166",
+			FatalLevelStyle: "166",
+			PanicLevelStyle: "166",
+			DebugLevelStyle: "25",
+			PrefixStyle:     "36",
+			TimestampStyle:  "black+h",
+		},
+	},
+}
+
+var currentTheme = themes[DefaultThemeName]
+
+// Themes returns the themes, sorted by name
+func Themes() []*Theme {
+	list := make([]*Theme, 0, len(themes))
+	for _, theme := range themes {
+		list = append(list, theme)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	return list
+}
+
+// SetTheme sets the theme colors are shown with. The empty name is the
+// default theme.
+func SetTheme(name string) error {
+	if name == "" {
+		name = DefaultThemeName
+	}
+
+	theme, ok := themes[strings.ToLower(name)]
+	if !ok {
+		names := make([]string, 0, len(themes))
+		for _, theme := range Themes() {
+			names = append(names, theme.Name)
+		}
+
+		return fmt.Errorf("unknown color theme ‘%s’, expected one of %s", name, strings.Join(names, ", "))
+	}
+
+	currentTheme = theme
+
+	return nil
+}
+
+// CurrentTheme returns the theme colors are shown with
+func CurrentTheme() *Theme {
+	return currentTheme
+}
+
+// LogColorScheme returns the color scheme of log messages with the theme, or
+// nil for the default scheme of the formatter
+func (t *Theme) LogColorScheme() *prefixed.ColorScheme {
+	return t.log
+}
+
+// jsonStyle returns the style of colorized JSON. The dark style, set with
+// --dark-style, takes precedence over the theme.
+func (t *Theme) jsonStyle(darkStyle bool) *pretty.Style {
+	switch {
+	case darkStyle:
+		return darkTerminalStyle
+	case t.json != nil:
+		return t.json
+	default:
+		return pretty.TerminalStyle
+	}
+}
+
+// themedAurora shows the colors asked for with the ones of a theme
+type themedAurora struct {
+	aurora.Aurora
+	theme *Theme
+}
+
+func (a themedAurora) colorize(arg interface{}, base uint8) aurora.Value {
+	c, ok := a.theme.colors[base]
+	if !ok {
+		c = themeColor{index: base}
+	}
+
+	value := aurora.Index(c.index, arg)
+	if c.bold {
+		value = value.Bold()
+	}
+
+	return value
+}
+
+func (a themedAurora) Black(arg interface{}) aurora.Value   { return a.colorize(arg, black) }
+func (a themedAurora) Red(arg interface{}) aurora.Value     { return a.colorize(arg, red) }
+func (a themedAurora) Green(arg interface{}) aurora.Value   { return a.colorize(arg, green) }
+func (a themedAurora) Yellow(arg interface{}) aurora.Value  { return a.colorize(arg, yellow) }
+func (a themedAurora) Brown(arg interface{}) aurora.Value   { return a.colorize(arg, yellow) }
+func (a themedAurora) Blue(arg interface{}) aurora.Value    { return a.colorize(arg, blue) }
+func (a themedAurora) Magenta(arg interface{}) aurora.Value { return a.colorize(arg, magenta) }
+func (a themedAurora) Cyan(arg interface{}) aurora.Value    { return a.colorize(arg, cyan) }
+func (a themedAurora) White(arg interface{}) aurora.Value   { return a.colorize(arg, white) }
+
+func (a themedAurora) Faint(arg interface{}) aurora.Value {
+	if a.theme.noFaint {
+		if value, ok := arg.(aurora.Value); ok {
+			return value
+		}
+		return a.Aurora.Reset(arg)
+	}
+
+	return a.Aurora.Faint(arg)
+}
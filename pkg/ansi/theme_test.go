@@ -0,0 +1,87 @@
+package ansi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/manifoldco/promptui"
+	"github.com/stretchr/testify/require"
+)
+
+// useTheme sets the theme and forces colors for the duration of the test
+func useTheme(t *testing.T, name string) {
+	t.Helper()
+
+	previous := currentTheme
+	ForceColors = true
+	t.Cleanup(func() {
+		currentTheme = previous
+		ForceColors = false
+	})
+
+	require.NoError(t, SetTheme(name))
+}
+
+func TestSetTheme(t *testing.T) {
+	useTheme(t, "Light")
+	require.Equal(t, "light", CurrentTheme().Name)
+
+	require.NoError(t, SetTheme(""))
+	require.Equal(t, DefaultThemeName, CurrentTheme().Name)
+
+	err := SetTheme("sepia")
+	require.EqualError(t, err, "unknown color theme ‘sepia’, expected one of colorblind, default, high-contrast, light")
+	require.Equal(t, DefaultThemeName, CurrentTheme().Name)
+}
+
+func TestThemeColors(t *testing.T) {
+	var out bytes.Buffer
+
+	useTheme(t, DefaultThemeName)
+	require.Equal(t, "\x1b[31mfailed\x1b[0m", Color(&out).Red("failed").String())
+
+	useTheme(t, "colorblind")
+	require.Equal(t, "\x1b[38;5;166mfailed\x1b[0m", Color(&out).Red("failed").String())
+	require.Equal(t, "\x1b[38;5;74msucceeded\x1b[0m", Color(&out).Green("succeeded").String())
+	require.Equal(t, "\x1b[2mfaint\x1b[0m", Faint("faint"))
+
+	useTheme(t, "high-contrast")
+	require.Equal(t, "\x1b[1;93mwarning\x1b[0m", Color(&out).Yellow("warning").String())
+	require.Equal(t, "faint", Faint("faint"))
+
+	require.Contains(t, ColorizeJSON(`{"id": "cus_123"}`, false, &out), "\x1b[1;94m\"id\"")
+	require.Contains(t, ColorizeJSON(`{"id": "cus_123"}`, true, &out), darkTerminalStyle.Key[0]+"\"id\"")
+}
+
+func TestNoColor(t *testing.T) {
+	var out bytes.Buffer
+
+	t.Setenv("NO_COLOR", "1")
+	require.True(t, NoColor())
+	require.False(t, shouldUseColors(&out))
+
+	// --color always takes precedence
+	useTheme(t, DefaultThemeName)
+	require.True(t, shouldUseColors(&out))
+
+	t.Setenv("NO_COLOR", "")
+	require.False(t, NoColor())
+}
+
+func TestConfigurePrompts(t *testing.T) {
+	icon := promptui.IconGood
+	t.Cleanup(func() {
+		promptui.IconGood = icon
+		DisableColors = false
+		ConfigurePrompts()
+	})
+
+	DisableColors = true
+	ConfigurePrompts()
+
+	red := promptui.FuncMap["red"].(func(interface{}) string)
+	bgRed := promptui.FuncMap["bgRed"].(func(interface{}) string)
+	require.Equal(t, "failed", red("failed"))
+	require.Equal(t, "failed", bgRed("failed"))
+	require.Equal(t, "✔", promptui.IconGood)
+}
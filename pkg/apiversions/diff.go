@@ -0,0 +1,190 @@
+// Package apiversions analyzes the impact of upgrading between Stripe API
+// versions.
+package apiversions
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed" // for the bundled changelog
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Changelog lists the changes introduced by each API version
+type Changelog struct {
+	Versions []Version `json:"versions"`
+}
+
+// Version is an API version and the changes it introduced
+type Version struct {
+	Version string   `json:"version"`
+	Changes []Change `json:"changes"`
+}
+
+// Change is a single change introduced by an API version
+type Change struct {
+	Description string `json:"description"`
+	Breaking    bool   `json:"breaking"`
+	// Endpoints affected by the change, as `METHOD /path` or `/path` for all
+	// methods. Path parameters are written as `{name}`.
+	Endpoints []string `json:"endpoints"`
+}
+
+// Impact is a change between two API versions along with the endpoints the
+// user called that it affects
+type Impact struct {
+	Version string
+	Change  Change
+	// Affected maps the used endpoints affected by the change to the number
+	// of requests made to them
+	Affected map[string]int
+}
+
+// bundledChangelog lists the major breaking changes of the API versions
+// released since the version the CLI uses. It's maintained by hand from the
+// API changelog at https://docs.stripe.com/changelog: when a new API version
+// is released, append it to changelog.json with its breaking changes and the
+// endpoints they affect, paths written like in the OpenAPI spec.
+//
+//go:embed changelog.json
+var bundledChangelog []byte
+
+// DefaultChangelog returns the changelog bundled with the CLI.
+func DefaultChangelog() (*Changelog, error) {
+	return ParseChangelog(bytes.NewReader(bundledChangelog))
+}
+
+// ParseChangelog decodes a changelog in JSON.
+func ParseChangelog(r io.Reader) (*Changelog, error) {
+	var changelog Changelog
+
+	if err := json.NewDecoder(r).Decode(&changelog); err != nil {
+		return nil, fmt.Errorf("could not parse changelog: %v", err)
+	}
+
+	return &changelog, nil
+}
+
+// Diff returns the changes introduced after from, up to and including to,
+// oldest first. usage maps used endpoints, as returned by ParseRequestLogs, to
+// their number of requests; it may be nil.
+func (c *Changelog) Diff(from, to string, usage map[string]int) ([]Impact, error) {
+	if from >= to {
+		return nil, fmt.Errorf("%s must be older than %s", from, to)
+	}
+
+	versions := make([]Version, len(c.Versions))
+	copy(versions, c.Versions)
+	sort.SliceStable(versions, func(i, j int) bool { return versions[i].Version < versions[j].Version })
+
+	var impacts []Impact
+
+	for _, v := range versions {
+		if v.Version <= from || v.Version > to {
+			continue
+		}
+
+		for _, change := range v.Changes {
+			impacts = append(impacts, Impact{
+				Version:  v.Version,
+				Change:   change,
+				Affected: affectedEndpoints(change, usage),
+			})
+		}
+	}
+
+	return impacts, nil
+}
+
+// ParseRequestLogs reads request logs in the JSON format printed by
+// `stripe logs tail --format JSON`, one per line, and counts the requests made
+// to each endpoint. Endpoints are returned as `METHOD /path` with object IDs
+// replaced by `{id}`.
+func ParseRequestLogs(r io.Reader) (map[string]int, error) {
+	usage := make(map[string]int)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var entry struct {
+			Method string `json:"method"`
+			URL    string `json:"url"`
+		}
+
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			return nil, fmt.Errorf("could not parse request log: %v", err)
+		}
+
+		if entry.URL == "" {
+			continue
+		}
+
+		CountRequest(usage, entry.Method, entry.URL)
+	}
+
+	return usage, scanner.Err()
+}
+
+// CountRequest counts a request made with method to url in usage, under its
+// endpoint as returned by ParseRequestLogs.
+func CountRequest(usage map[string]int, method, url string) {
+	usage[strings.ToUpper(method)+" "+normalizePath(url)]++
+}
+
+//
+// Private functions
+//
+
+// objectIDRe matches Stripe object IDs such as `cus_J8x2Zs9RxH3v` in a path,
+// but not resource names such as `payment_intents`.
+var objectIDRe = regexp.MustCompile(`^[a-z]+(?:_[a-z]+)*_[a-zA-Z0-9]*[A-Z0-9][a-zA-Z0-9]*$`)
+
+var pathParamRe = regexp.MustCompile(`\{[^}]*\}`)
+
+func normalizePath(rawURL string) string {
+	path := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		path = u.Path
+	}
+
+	segments := strings.Split(strings.TrimSuffix(path, "/"), "/")
+	for i, segment := range segments {
+		if objectIDRe.MatchString(segment) {
+			segments[i] = "{id}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+func affectedEndpoints(change Change, usage map[string]int) map[string]int {
+	affected := make(map[string]int)
+
+	for _, endpoint := range change.Endpoints {
+		method, path := "", endpoint
+		if parts := strings.SplitN(endpoint, " ", 2); len(parts) == 2 {
+			method, path = strings.ToUpper(parts[0]), parts[1]
+		}
+		path = pathParamRe.ReplaceAllString(strings.TrimSuffix(path, "/"), "{id}")
+
+		for used, count := range usage {
+			parts := strings.SplitN(used, " ", 2)
+			if parts[1] == path && (method == "" || parts[0] == method) {
+				affected[used] = count
+			}
+		}
+	}
+
+	return affected
+}
@@ -0,0 +1,76 @@
+package apiversions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testChangelog = `{"versions": [
+  {"version": "2024-06-20", "changes": [
+    {"description": "Removes the sources property on customers", "breaking": true, "endpoints": ["GET /v1/customers/{customer}"]}
+  ]},
+  {"version": "2023-10-16", "changes": [
+    {"description": "Too old to matter", "breaking": true, "endpoints": ["/v1/charges"]}
+  ]},
+  {"version": "2024-04-10", "changes": [
+    {"description": "Renames features on products", "breaking": true, "endpoints": ["/v1/products"]},
+    {"description": "Adds a new field", "breaking": false}
+  ]}
+]}`
+
+const testRequestLogs = `{"method":"GET","url":"/v1/customers/cus_J8x2Zs9RxH3v","status":200}
+{"method":"GET","url":"/v1/customers/cus_Kd82ms0Qpa1x","status":200}
+{"method":"POST","url":"/v1/payment_intents","status":200}
+
+{"method":"post","url":"/v1/products?expand[]=data","status":400}
+`
+
+func TestParseRequestLogs(t *testing.T) {
+	usage, err := ParseRequestLogs(strings.NewReader(testRequestLogs))
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{
+		"GET /v1/customers/{id}":   2,
+		"POST /v1/payment_intents": 1,
+		"POST /v1/products":        1,
+	}, usage)
+}
+
+func TestDiff(t *testing.T) {
+	changelog, err := ParseChangelog(strings.NewReader(testChangelog))
+	require.NoError(t, err)
+
+	usage, err := ParseRequestLogs(strings.NewReader(testRequestLogs))
+	require.NoError(t, err)
+
+	impacts, err := changelog.Diff("2023-10-16", "2024-06-20", usage)
+	require.NoError(t, err)
+	require.Len(t, impacts, 3)
+
+	require.Equal(t, "2024-04-10", impacts[0].Version)
+	require.Equal(t, map[string]int{"POST /v1/products": 1}, impacts[0].Affected)
+	require.Empty(t, impacts[1].Affected)
+	require.Equal(t, "2024-06-20", impacts[2].Version)
+	require.Equal(t, map[string]int{"GET /v1/customers/{id}": 2}, impacts[2].Affected)
+}
+
+func TestDiffRejectsReversedRange(t *testing.T) {
+	changelog, err := ParseChangelog(strings.NewReader(testChangelog))
+	require.NoError(t, err)
+
+	_, err = changelog.Diff("2024-06-20", "2023-10-16", nil)
+	require.EqualError(t, err, "2024-06-20 must be older than 2023-10-16")
+}
+
+func TestDefaultChangelog(t *testing.T) {
+	changelog, err := DefaultChangelog()
+	require.NoError(t, err)
+	require.NotEmpty(t, changelog.Versions)
+
+	impacts, err := changelog.Diff("2022-08-01", "2023-08-16", map[string]int{"GET /v1/payment_intents/{id}": 3})
+	require.NoError(t, err)
+	require.NotEmpty(t, impacts)
+	require.Equal(t, "2022-11-15", impacts[0].Version)
+	require.Equal(t, map[string]int{"GET /v1/payment_intents/{id}": 3}, impacts[0].Affected)
+}
@@ -0,0 +1,250 @@
+// Package audit reports on the Stripe SDKs used by a project.
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v28/github"
+
+	"github.com/stripe/stripe-cli/pkg/version"
+)
+
+const (
+	defaultOSVURL = "https://api.osv.dev/v1/query"
+	defaultRawURL = "https://raw.githubusercontent.com"
+)
+
+// sdk describes where to find information about one of Stripe's SDKs
+type sdk struct {
+	repo      string
+	ecosystem string
+	// apiVersionFiles are the files of the SDK's source, by age, declaring
+	// the API version it pins
+	apiVersionFiles []string
+	apiVersionRe    *regexp.Regexp
+}
+
+var sdks = map[string]sdk{
+	"go": {
+		repo:            "stripe-go",
+		ecosystem:       "Go",
+		apiVersionFiles: []string{"stripe.go"},
+		apiVersionRe:    regexp.MustCompile(`APIVersion\s*(?:string\s*)?=\s*"([^"]+)"`),
+	},
+	"node": {
+		repo:            "stripe-node",
+		ecosystem:       "npm",
+		apiVersionFiles: []string{"src/apiVersion.ts", "lib/apiVersion.js"},
+		apiVersionRe:    regexp.MustCompile(`ApiVersion\s*=\s*['"]([^'"]+)['"]`),
+	},
+	"python": {
+		repo:            "stripe-python",
+		ecosystem:       "PyPI",
+		apiVersionFiles: []string{"stripe/_api_version.py", "stripe/api_version.py"},
+		apiVersionRe:    regexp.MustCompile(`CURRENT\s*=\s*['"]([^'"]+)['"]`),
+	},
+	"ruby": {
+		repo:            "stripe-ruby",
+		ecosystem:       "RubyGems",
+		apiVersionFiles: []string{"lib/stripe/api_version.rb"},
+		apiVersionRe:    regexp.MustCompile(`CURRENT\s*=\s*['"]([^'"]+)['"]`),
+	},
+}
+
+// Report is the result of auditing a Dependency
+type Report struct {
+	Dependency Dependency
+	// Latest is the latest released version of the SDK
+	Latest string
+	// APIVersion is the Stripe API version pinned by the SDK version in use,
+	// or empty if the SDK doesn't pin one and requests use the account's
+	// default API version
+	APIVersion string
+	// Vulnerabilities are the IDs of known vulnerabilities affecting the SDK
+	// version in use
+	Vulnerabilities []string
+	// Errors are problems encountered while auditing the dependency
+	Errors []string
+}
+
+// Outdated returns whether a newer version of the SDK is available.
+func (r Report) Outdated() bool {
+	return r.Dependency.Version != "" && r.Latest != "" && version.Compare(r.Dependency.Version, r.Latest) < 0
+}
+
+// MajorUpgrade returns whether upgrading to the latest version crosses a major
+// version, which may contain breaking changes.
+func (r Report) MajorUpgrade() bool {
+	return r.Outdated() && majorVersion(r.Dependency.Version) < majorVersion(r.Latest)
+}
+
+// ChangelogURL returns the URL of the SDK's changelog.
+func (r Report) ChangelogURL() string {
+	return fmt.Sprintf("https://github.com/stripe/%s/blob/master/CHANGELOG.md", sdks[r.Dependency.Language].repo)
+}
+
+// Auditor looks up release, API version and vulnerability information for
+// Stripe SDKs
+type Auditor struct {
+	GitHub     *github.Client
+	HTTPClient *http.Client
+	// OSVURL is the endpoint of the OSV vulnerability database query API
+	OSVURL string
+	// RawURL is the base URL raw files of GitHub repositories are served from
+	RawURL string
+}
+
+// NewAuditor returns an Auditor using the public GitHub and OSV APIs.
+func NewAuditor() *Auditor {
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+
+	return &Auditor{
+		GitHub:     github.NewClient(httpClient),
+		HTTPClient: httpClient,
+		OSVURL:     defaultOSVURL,
+		RawURL:     defaultRawURL,
+	}
+}
+
+// Audit audits each dependency. Lookup failures are recorded in the reports
+// rather than aborting the audit.
+func (a *Auditor) Audit(ctx context.Context, deps []Dependency) []Report {
+	reports := make([]Report, 0, len(deps))
+
+	for _, dep := range deps {
+		reports = append(reports, a.audit(ctx, dep))
+	}
+
+	return reports
+}
+
+func (a *Auditor) audit(ctx context.Context, dep Dependency) Report {
+	report := Report{Dependency: dep}
+	s := sdks[dep.Language]
+
+	release, _, err := a.GitHub.Repositories.GetLatestRelease(ctx, "stripe", s.repo)
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("could not fetch the latest release: %v", err))
+	} else {
+		report.Latest = strings.TrimPrefix(release.GetTagName(), "v")
+	}
+
+	if dep.Version == "" {
+		return report
+	}
+
+	apiVersion, err := a.apiVersion(ctx, s, dep.Version)
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("could not fetch the pinned API version: %v", err))
+	}
+	report.APIVersion = apiVersion
+
+	vulns, err := a.vulnerabilities(ctx, s, dep)
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("could not fetch vulnerabilities: %v", err))
+	}
+	report.Vulnerabilities = vulns
+
+	return report
+}
+
+// apiVersion reads the API version pinned by version of the SDK from its
+// source. It returns an empty string if that version doesn't pin one.
+func (a *Auditor) apiVersion(ctx context.Context, s sdk, version string) (string, error) {
+	for _, file := range s.apiVersionFiles {
+		fileURL := fmt.Sprintf("%s/stripe/%s/v%s/%s", a.RawURL, s.repo, version, file)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+		if err != nil {
+			return "", err
+		}
+
+		resp, err := a.HTTPClient.Do(req)
+		if err != nil {
+			return "", err
+		}
+
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if err != nil {
+			return "", err
+		}
+
+		if resp.StatusCode == http.StatusNotFound {
+			continue
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("unexpected status %d from %s", resp.StatusCode, fileURL)
+		}
+
+		if matches := s.apiVersionRe.FindSubmatch(body); matches != nil {
+			return string(matches[1]), nil
+		}
+	}
+
+	return "", nil
+}
+
+// vulnerabilities queries the OSV database for advisories affecting the
+// dependency.
+func (a *Auditor) vulnerabilities(ctx context.Context, s sdk, dep Dependency) ([]string, error) {
+	query, err := json.Marshal(map[string]interface{}{
+		"version": dep.Version,
+		"package": map[string]string{
+			"name":      dep.Package,
+			"ecosystem": s.ecosystem,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.OSVURL, bytes.NewReader(query))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, a.OSVURL)
+	}
+
+	var result struct {
+		Vulns []struct {
+			ID string `json:"id"`
+		} `json:"vulns"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(result.Vulns))
+	for _, v := range result.Vulns {
+		ids = append(ids, v.ID)
+	}
+
+	return ids, nil
+}
+
+func majorVersion(version string) int {
+	major, _ := strconv.Atoi(strings.SplitN(strings.TrimPrefix(version, "v"), ".", 2)[0])
+	return major
+}
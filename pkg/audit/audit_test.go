@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v28/github"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAuditor(t *testing.T) *Auditor {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/stripe/stripe-go/releases/latest", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"tag_name": "v76.5.0"}`))
+	})
+	mux.HandleFunc("/raw/stripe/stripe-go/v72.50.0/stripe.go", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("const (\n\tAPIVersion string = \"2020-08-27\"\n)\n"))
+	})
+	mux.HandleFunc("/osv", func(w http.ResponseWriter, r *http.Request) {
+		var query struct {
+			Version string `json:"version"`
+			Package struct {
+				Name      string `json:"name"`
+				Ecosystem string `json:"ecosystem"`
+			} `json:"package"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&query))
+		require.Equal(t, "Go", query.Package.Ecosystem)
+		require.Equal(t, "github.com/stripe/stripe-go/v72", query.Package.Name)
+
+		w.Write([]byte(`{"vulns": [{"id": "GHSA-xxxx-yyyy-zzzz"}]}`))
+	})
+
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(ts.URL + "/")
+
+	return &Auditor{
+		GitHub:     client,
+		HTTPClient: ts.Client(),
+		OSVURL:     ts.URL + "/osv",
+		RawURL:     ts.URL + "/raw",
+	}
+}
+
+func TestAudit(t *testing.T) {
+	auditor := newTestAuditor(t)
+
+	reports := auditor.Audit(context.Background(), []Dependency{
+		{Language: "go", Package: "github.com/stripe/stripe-go/v72", Constraint: "v72.50.0", Version: "72.50.0"},
+	})
+
+	require.Len(t, reports, 1)
+	r := reports[0]
+	require.Empty(t, r.Errors)
+	require.Equal(t, "76.5.0", r.Latest)
+	require.Equal(t, "2020-08-27", r.APIVersion)
+	require.Equal(t, []string{"GHSA-xxxx-yyyy-zzzz"}, r.Vulnerabilities)
+	require.True(t, r.Outdated())
+	require.True(t, r.MajorUpgrade())
+	require.Equal(t, "https://github.com/stripe/stripe-go/blob/master/CHANGELOG.md", r.ChangelogURL())
+}
+
+func TestAuditRecordsErrors(t *testing.T) {
+	auditor := newTestAuditor(t)
+
+	reports := auditor.Audit(context.Background(), []Dependency{
+		{Language: "ruby", Package: "stripe", Constraint: "", Version: ""},
+	})
+
+	require.Len(t, reports, 1)
+	require.Len(t, reports[0].Errors, 1)
+	require.Contains(t, reports[0].Errors[0], "could not fetch the latest release")
+}
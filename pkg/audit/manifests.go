@@ -0,0 +1,172 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// Dependency is a Stripe SDK declared in a project's manifest
+type Dependency struct {
+	// Language of the SDK: go, node, python or ruby
+	Language string
+	// Package is the name of the SDK in its package registry, e.g. the Go
+	// module path
+	Package string
+	// Constraint is the version requirement as written in the manifest
+	Constraint string
+	// Version is the lowest version satisfying Constraint, or empty if the
+	// manifest doesn't constrain the version
+	Version string
+	// Manifest is the path of the file the dependency was found in
+	Manifest string
+}
+
+var (
+	goModRe        = regexp.MustCompile(`^\s*(?:require\s+)?(github\.com/stripe/stripe-go(?:/v\d+)?)\s+(v\S+)`)
+	requirementsRe = regexp.MustCompile(`(?i)^\s*stripe\s*(?:\[[^\]]*\])?\s*([=~<>!]=?.*)?$`)
+	gemfileRe      = regexp.MustCompile(`^\s*gem\s+['"]stripe['"]\s*(?:,\s*(.*))?$`)
+	versionRe      = regexp.MustCompile(`\d+(?:\.\d+)*`)
+)
+
+// Detect looks for Stripe SDKs in the manifests found in dir: go.mod,
+// package.json, requirements.txt and Gemfile.
+func Detect(fs afero.Fs, dir string) ([]Dependency, error) {
+	parsers := []struct {
+		file  string
+		parse func([]byte) []Dependency
+	}{
+		{"go.mod", parseGoMod},
+		{"package.json", parsePackageJSON},
+		{"requirements.txt", parseRequirements},
+		{"Gemfile", parseGemfile},
+	}
+
+	var deps []Dependency
+
+	for _, p := range parsers {
+		path := filepath.Join(dir, p.file)
+
+		content, err := afero.ReadFile(fs, path)
+		if err != nil {
+			exists, _ := afero.Exists(fs, path)
+			if !exists {
+				continue
+			}
+			return nil, err
+		}
+
+		for _, dep := range p.parse(content) {
+			dep.Manifest = path
+			deps = append(deps, dep)
+		}
+	}
+
+	return deps, nil
+}
+
+func parseGoMod(content []byte) []Dependency {
+	var deps []Dependency
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		matches := goModRe.FindStringSubmatch(scanner.Text())
+		if matches == nil {
+			continue
+		}
+
+		deps = append(deps, Dependency{
+			Language:   "go",
+			Package:    matches[1],
+			Constraint: matches[2],
+			Version:    baseVersion(matches[2]),
+		})
+	}
+
+	return deps
+}
+
+func parsePackageJSON(content []byte) []Dependency {
+	var manifest struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil
+	}
+
+	constraint, ok := manifest.Dependencies["stripe"]
+	if !ok {
+		constraint, ok = manifest.DevDependencies["stripe"]
+	}
+	if !ok {
+		return nil
+	}
+
+	return []Dependency{{
+		Language:   "node",
+		Package:    "stripe",
+		Constraint: constraint,
+		Version:    baseVersion(constraint),
+	}}
+}
+
+func parseRequirements(content []byte) []Dependency {
+	var deps []Dependency
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.SplitN(scanner.Text(), "#", 2)[0])
+
+		matches := requirementsRe.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+
+		deps = append(deps, Dependency{
+			Language:   "python",
+			Package:    "stripe",
+			Constraint: strings.TrimSpace(matches[1]),
+			Version:    baseVersion(matches[1]),
+		})
+	}
+
+	return deps
+}
+
+func parseGemfile(content []byte) []Dependency {
+	var deps []Dependency
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.SplitN(scanner.Text(), "#", 2)[0])
+
+		matches := gemfileRe.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+
+		constraint := strings.Trim(strings.TrimSpace(matches[1]), `'"`)
+
+		deps = append(deps, Dependency{
+			Language:   "ruby",
+			Package:    "stripe",
+			Constraint: constraint,
+			Version:    baseVersion(constraint),
+		})
+	}
+
+	return deps
+}
+
+// baseVersion extracts the version a constraint such as `^8.1.0` or
+// `~> 5.0` is anchored on.
+func baseVersion(constraint string) string {
+	return versionRe.FindString(constraint)
+}
@@ -0,0 +1,50 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetect(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	afero.WriteFile(fs, "app/go.mod", []byte(`module example.com/app
+
+go 1.17
+
+require (
+	github.com/sirupsen/logrus v1.8.1
+	github.com/stripe/stripe-go/v72 v72.50.0
+)
+`), 0644)
+	afero.WriteFile(fs, "app/package.json", []byte(`{"dependencies": {"express": "^4.17.1", "stripe": "^8.191.0"}}`), 0644)
+	afero.WriteFile(fs, "app/requirements.txt", []byte("flask==2.0.2\nstripe>=2.60.0,<3 # payments\nstripe-mock\n"), 0644)
+	afero.WriteFile(fs, "app/Gemfile", []byte("source 'https://rubygems.org'\ngem 'sinatra'\ngem 'stripe', '~> 5.38'\n"), 0644)
+
+	deps, err := Detect(fs, "app")
+	require.NoError(t, err)
+	require.Equal(t, []Dependency{
+		{Language: "go", Package: "github.com/stripe/stripe-go/v72", Constraint: "v72.50.0", Version: "72.50.0", Manifest: "app/go.mod"},
+		{Language: "node", Package: "stripe", Constraint: "^8.191.0", Version: "8.191.0", Manifest: "app/package.json"},
+		{Language: "python", Package: "stripe", Constraint: ">=2.60.0,<3", Version: "2.60.0", Manifest: "app/requirements.txt"},
+		{Language: "ruby", Package: "stripe", Constraint: "~> 5.38", Version: "5.38", Manifest: "app/Gemfile"},
+	}, deps)
+}
+
+func TestDetectUnpinned(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "requirements.txt", []byte("stripe\n"), 0644)
+
+	deps, err := Detect(fs, ".")
+	require.NoError(t, err)
+	require.Len(t, deps, 1)
+	require.Equal(t, "", deps[0].Version)
+}
+
+func TestDetectNothing(t *testing.T) {
+	deps, err := Detect(afero.NewMemMapFs(), ".")
+	require.NoError(t, err)
+	require.Empty(t, deps)
+}
@@ -0,0 +1,491 @@
+// Package browse implements an interactive browser of the objects of a
+// Stripe account in the terminal.
+package browse
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+	"github.com/tidwall/gjson"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/explorer"
+	"github.com/stripe/stripe-cli/pkg/requests"
+)
+
+// Resource is a type of object that can be browsed
+type Resource struct {
+	// Name is the name of the resource, like its resource command
+	Name string
+	// Path is the path objects of the resource are listed from
+	Path string
+	// Prefix is the prefix of the IDs of the objects of the resource
+	Prefix string
+}
+
+// Resources are the resources that can be browsed, in the order they're
+// listed
+var Resources = []Resource{
+	{Name: "customers", Path: "/v1/customers", Prefix: "cus_"},
+	{Name: "subscriptions", Path: "/v1/subscriptions", Prefix: "sub_"},
+	{Name: "invoices", Path: "/v1/invoices", Prefix: "in_"},
+	{Name: "payment_intents", Path: "/v1/payment_intents", Prefix: "pi_"},
+	{Name: "charges", Path: "/v1/charges", Prefix: "ch_"},
+	{Name: "refunds", Path: "/v1/refunds", Prefix: "re_"},
+	{Name: "disputes", Path: "/v1/disputes", Prefix: "dp_"},
+	{Name: "products", Path: "/v1/products", Prefix: "prod_"},
+	{Name: "prices", Path: "/v1/prices", Prefix: "price_"},
+	{Name: "checkout_sessions", Path: "/v1/checkout/sessions", Prefix: "cs_"},
+	{Name: "payouts", Path: "/v1/payouts", Prefix: "po_"},
+	{Name: "balance_transactions", Path: "/v1/balance_transactions", Prefix: "txn_"},
+	{Name: "events", Path: "/v1/events", Prefix: "evt_"},
+}
+
+// Link is a link from an object to another object it references, or to the
+// list of the objects related to it
+type Link struct {
+	Label string
+	Path  string
+	// Data are the parameters of the list of related objects
+	Data []string
+	List bool
+}
+
+// Browser browses the objects of an account. It starts in test mode, and can
+// be switched to live mode once confirmed.
+type Browser struct {
+	APIBaseURL  string
+	TestModeKey string
+	LiveModeKey string
+	Profile     *config.Profile
+	DarkStyle   bool
+
+	live bool
+}
+
+// ResourceForID returns the resource of the object with an ID, if it can be
+// browsed
+func ResourceForID(id string) (Resource, bool) {
+	for _, resource := range Resources {
+		if strings.HasPrefix(id, resource.Prefix) && len(id) > len(resource.Prefix) && isID(id) {
+			return resource, true
+		}
+	}
+
+	return Resource{}, false
+}
+
+// Links returns the links of an object: the objects its fields reference,
+// expanded or not, in the order of the fields, and the lists of the objects
+// related to it.
+func Links(object string) []Link {
+	var links []Link
+
+	ownID := gjson.Get(object, "id").String()
+	seen := map[string]bool{ownID: true}
+
+	var walk func(path string)
+	walk = func(path string) {
+		for _, node := range explorer.Children(object, path) {
+			if node.IsContainer() {
+				walk(node.Path)
+				continue
+			}
+
+			id := node.Value.String()
+			if node.Value.Type != gjson.String || seen[id] {
+				continue
+			}
+			resource, ok := ResourceForID(id)
+			if !ok {
+				continue
+			}
+			seen[id] = true
+
+			// Label expanded objects by their field, not by their ID
+			field := strings.TrimSuffix(node.Path, ".id")
+			links = append(links, Link{
+				Label: fmt.Sprintf("%s → %s", field, id),
+				Path:  resource.Path + "/" + id,
+			})
+		}
+	}
+	walk("")
+
+	for _, related := range relatedLists[gjson.Get(object, "object").String()] {
+		resource := resourceByName(related.resource)
+		links = append(links, Link{
+			Label: fmt.Sprintf("%s of %s", strings.ReplaceAll(resource.Name, "_", " "), ownID),
+			Path:  resource.Path,
+			Data:  []string{fmt.Sprintf("%s=%s", related.param, ownID)},
+			List:  true,
+		})
+	}
+
+	return links
+}
+
+// Summary returns a one-line description of an object, from the fields that
+// usually describe it
+func Summary(object gjson.Result) string {
+	var parts []string
+
+	if amount := object.Get("amount"); amount.Exists() && object.Get("currency").Exists() {
+		parts = append(parts, fmt.Sprintf("%s %s", amount.Raw, object.Get("currency").String()))
+	}
+
+	for _, field := range []string{"email", "name", "nickname", "description", "type", "status"} {
+		if value := object.Get(field); value.Type == gjson.String && value.String() != "" {
+			parts = append(parts, value.String())
+		}
+	}
+
+	return strings.Join(parts, " · ")
+}
+
+// Run opens the browser in the terminal. It returns once the user exits.
+func (b *Browser) Run(ctx context.Context) error {
+	return b.run(ctx, os.Stdin, os.Stdout)
+}
+
+//
+// Private types
+//
+
+// relatedList is a list of objects that can be filtered by an object
+type relatedList struct {
+	resource string
+	param    string
+}
+
+var relatedLists = map[string][]relatedList{
+	"customer": {
+		{resource: "subscriptions", param: "customer"},
+		{resource: "invoices", param: "customer"},
+		{resource: "payment_intents", param: "customer"},
+		{resource: "charges", param: "customer"},
+		{resource: "checkout_sessions", param: "customer"},
+	},
+	"subscription":   {{resource: "invoices", param: "subscription"}},
+	"payment_intent": {{resource: "charges", param: "payment_intent"}, {resource: "refunds", param: "payment_intent"}},
+	"charge":         {{resource: "refunds", param: "charge"}},
+	"product":        {{resource: "prices", param: "product"}},
+	"payout":         {{resource: "balance_transactions", param: "payout"}},
+}
+
+const pageSize = 20
+
+const (
+	itemUp       = ".."
+	itemNextPage = "Next page"
+	itemPrevPage = "Previous page"
+	itemExplore  = "Explore fields…"
+	itemExit     = "Exit"
+)
+
+// action is what to do after a view of the browser was shown
+type action int
+
+const (
+	actionOpen action = iota
+	actionBack
+	actionStay
+	actionReset
+	actionExit
+)
+
+// view is a list of objects, or an object, in the navigation stack of the
+// browser
+type view struct {
+	title string
+	path  string
+	data  []string
+	list  bool
+
+	// pages are the starting_after cursors of the pages of a list before the
+	// current one
+	pages  []string
+	cursor string
+}
+
+type item struct {
+	Label   string
+	Summary string
+	view    *view
+	mode    bool
+}
+
+//
+// Private functions
+//
+
+func (b *Browser) run(ctx context.Context, stdin io.ReadCloser, stdout io.WriteCloser) error {
+	if b.TestModeKey == "" {
+		if !b.confirmLive(stdin, stdout) {
+			return nil
+		}
+		b.live = true
+	}
+
+	var stack []*view
+
+	for {
+		var next *view
+		var act action
+		var err error
+
+		switch {
+		case len(stack) == 0:
+			next, act, err = b.chooseResource(stdin, stdout)
+		case stack[len(stack)-1].list:
+			next, act, err = b.showList(ctx, stack[len(stack)-1], stdin, stdout)
+		default:
+			next, act, err = b.showObject(ctx, stack[len(stack)-1], stdin, stdout)
+		}
+
+		if err == promptui.ErrInterrupt || err == promptui.ErrEOF {
+			return nil
+		}
+		if err != nil {
+			// Requests that fail, like those of deleted objects, go back to
+			// the previous view
+			fmt.Fprintf(stdout, "%s %v\n", ansi.Color(stdout).Red("✘"), err)
+			act = actionBack
+		}
+
+		switch act {
+		case actionOpen:
+			stack = append(stack, next)
+		case actionBack:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case actionReset:
+			stack = nil
+		case actionExit:
+			return nil
+		}
+	}
+}
+
+func (b *Browser) chooseResource(stdin io.ReadCloser, stdout io.WriteCloser) (*view, action, error) {
+	items := make([]item, 0, len(Resources)+2)
+	for _, resource := range Resources {
+		items = append(items, item{
+			Label:   resource.Name,
+			Summary: resource.Path,
+			view:    &view{title: resource.Name, path: resource.Path, list: true},
+		})
+	}
+	if b.live {
+		items = append(items, item{Label: "Switch to test mode", mode: true})
+	} else {
+		items = append(items, item{Label: "Switch to live mode", mode: true})
+	}
+	items = append(items, item{Label: itemExit})
+
+	selected, err := b.selectItem("Resources", items, stdin, stdout)
+	if err != nil {
+		return nil, actionStay, err
+	}
+
+	switch {
+	case selected.mode:
+		if !b.live && !b.confirmLive(stdin, stdout) {
+			return nil, actionStay, nil
+		}
+		if b.live && b.TestModeKey == "" {
+			fmt.Fprintln(stdout, "No test mode key is configured. Run `stripe login` to configure one")
+			return nil, actionStay, nil
+		}
+		b.live = !b.live
+		return nil, actionReset, nil
+	case selected.view != nil:
+		return selected.view, actionOpen, nil
+	default:
+		return nil, actionExit, nil
+	}
+}
+
+func (b *Browser) showList(ctx context.Context, v *view, stdin io.ReadCloser, stdout io.WriteCloser) (*view, action, error) {
+	data := append([]string{fmt.Sprintf("limit=%d", pageSize)}, v.data...)
+	if v.cursor != "" {
+		data = append(data, "starting_after="+v.cursor)
+	}
+
+	page, err := b.get(ctx, v.path, data)
+	if err != nil {
+		return nil, actionStay, err
+	}
+
+	objects := page.Get("data").Array()
+
+	items := []item{{Label: itemUp}}
+	for _, object := range objects {
+		id := object.Get("id").String()
+		items = append(items, item{
+			Label:   id,
+			Summary: Summary(object),
+			view:    &view{title: id, path: v.path + "/" + id},
+		})
+	}
+	if len(objects) == 0 {
+		fmt.Fprintf(stdout, "No %s found\n", strings.ReplaceAll(v.title, "_", " "))
+	}
+	if page.Get("has_more").Bool() && len(objects) > 0 {
+		items = append(items, item{Label: itemNextPage})
+	}
+	if len(v.pages) > 0 {
+		items = append(items, item{Label: itemPrevPage})
+	}
+	items = append(items, item{Label: itemExit})
+
+	selected, err := b.selectItem(fmt.Sprintf("%s (page %d)", v.title, len(v.pages)+1), items, stdin, stdout)
+	if err != nil {
+		return nil, actionStay, err
+	}
+
+	switch {
+	case selected.view != nil:
+		return selected.view, actionOpen, nil
+	case selected.Label == itemUp:
+		return nil, actionBack, nil
+	case selected.Label == itemNextPage:
+		v.pages = append(v.pages, v.cursor)
+		v.cursor = objects[len(objects)-1].Get("id").String()
+		return nil, actionStay, nil
+	case selected.Label == itemPrevPage:
+		v.cursor = v.pages[len(v.pages)-1]
+		v.pages = v.pages[:len(v.pages)-1]
+		return nil, actionStay, nil
+	default:
+		return nil, actionExit, nil
+	}
+}
+
+func (b *Browser) showObject(ctx context.Context, v *view, stdin io.ReadCloser, stdout io.WriteCloser) (*view, action, error) {
+	object, err := b.get(ctx, v.path, nil)
+	if err != nil {
+		return nil, actionStay, err
+	}
+
+	fmt.Fprintln(stdout, ansi.ColorizeJSON(object.Raw, b.DarkStyle, stdout))
+
+	items := []item{{Label: itemUp}}
+	for _, link := range Links(object.Raw) {
+		title := link.Label
+		if !link.List {
+			title = link.Path[strings.LastIndex(link.Path, "/")+1:]
+		}
+		items = append(items, item{
+			Label: link.Label,
+			view:  &view{title: title, path: link.Path, data: link.Data, list: link.List},
+		})
+	}
+	items = append(items, item{Label: itemExplore}, item{Label: itemExit})
+
+	selected, err := b.selectItem(v.title, items, stdin, stdout)
+	if err != nil {
+		return nil, actionStay, err
+	}
+
+	switch {
+	case selected.view != nil:
+		return selected.view, actionOpen, nil
+	case selected.Label == itemUp:
+		return nil, actionBack, nil
+	case selected.Label == itemExplore:
+		if _, err := explorer.Run(object.Raw); err != nil {
+			return nil, actionStay, err
+		}
+		return nil, actionStay, nil
+	default:
+		return nil, actionExit, nil
+	}
+}
+
+func (b *Browser) selectItem(title string, items []item, stdin io.ReadCloser, stdout io.WriteCloser) (item, error) {
+	mode := ansi.Color(stdout).Faint("[test]")
+	if b.live {
+		mode = ansi.Color(stdout).Red("[live]").Bold()
+	}
+
+	prompt := promptui.Select{
+		Label: fmt.Sprintf("%s %s", mode, title),
+		Items: items,
+		Size:  15,
+		Templates: &promptui.SelectTemplates{
+			Active:   "▸ {{ .Label | bold }} {{ .Summary | faint }}",
+			Inactive: "  {{ .Label }} {{ .Summary | faint }}",
+			Selected: ansi.Faint("{{ .Label }}"),
+		},
+		Searcher: func(input string, index int) bool {
+			text := strings.ToLower(items[index].Label + " " + items[index].Summary)
+			return strings.Contains(text, strings.ToLower(input))
+		},
+		HideSelected: true,
+		Stdin:        stdin,
+		Stdout:       stdout,
+	}
+
+	i, _, err := prompt.Run()
+	if err != nil {
+		return item{}, err
+	}
+
+	return items[i], nil
+}
+
+// confirmLive asks to confirm browsing live mode data, and returns whether it
+// was confirmed
+func (b *Browser) confirmLive(stdin io.ReadCloser, stdout io.WriteCloser) bool {
+	if b.LiveModeKey == "" {
+		fmt.Fprintln(stdout, "No live mode key is configured. Run `stripe login`, or `stripe config --set live_mode_api_key <key>`, to configure one")
+		return false
+	}
+
+	prompt := promptui.Prompt{
+		Label:     "Browse the live mode data of your account",
+		IsConfirm: true,
+		Stdin:     stdin,
+		Stdout:    stdout,
+	}
+	_, err := prompt.Run()
+
+	return err == nil
+}
+
+func (b *Browser) get(ctx context.Context, path string, data []string) (gjson.Result, error) {
+	apiKey := b.TestModeKey
+	if b.live {
+		apiKey = b.LiveModeKey
+	}
+
+	return requests.Do(ctx, http.MethodGet, b.APIBaseURL, apiKey, path, data, b.Profile)
+}
+
+func isID(s string) bool {
+	for _, c := range s {
+		if c != '_' && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+
+	return true
+}
+
+func resourceByName(name string) Resource {
+	for _, resource := range Resources {
+		if resource.Name == name {
+			return resource
+		}
+	}
+
+	return Resource{}
+}
@@ -0,0 +1,85 @@
+package browse
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+const testInvoice = `{
+  "id": "in_123",
+  "object": "invoice",
+  "customer": {"id": "cus_123", "object": "customer", "email": "jenny@example.com"},
+  "subscription": "sub_123",
+  "payment_intent": "pi_123",
+  "lines": {"data": [{"id": "il_1", "subscription": "sub_123", "price": {"id": "price_1", "product": "prod_1"}}]},
+  "description": "pi_ is not an ID",
+  "amount_due": 2000
+}`
+
+func TestResourceForID(t *testing.T) {
+	resource, ok := ResourceForID("cus_123")
+	require.True(t, ok)
+	require.Equal(t, "/v1/customers", resource.Path)
+
+	resource, ok = ResourceForID("cs_test_123")
+	require.True(t, ok)
+	require.Equal(t, "/v1/checkout/sessions", resource.Path)
+
+	_, ok = ResourceForID("cus_")
+	require.False(t, ok)
+	_, ok = ResourceForID("il_123")
+	require.False(t, ok)
+}
+
+func TestLinks(t *testing.T) {
+	require.Equal(t, []Link{
+		{Label: "customer → cus_123", Path: "/v1/customers/cus_123"},
+		{Label: "subscription → sub_123", Path: "/v1/subscriptions/sub_123"},
+		{Label: "payment_intent → pi_123", Path: "/v1/payment_intents/pi_123"},
+		{Label: "lines.data.0.price → price_1", Path: "/v1/prices/price_1"},
+		{Label: "lines.data.0.price.product → prod_1", Path: "/v1/products/prod_1"},
+	}, Links(testInvoice))
+
+	links := Links(`{"id": "cus_123", "object": "customer", "email": "jenny@example.com"}`)
+	require.Len(t, links, 5)
+	require.Equal(t, Link{
+		Label: "subscriptions of cus_123",
+		Path:  "/v1/subscriptions",
+		Data:  []string{"customer=cus_123"},
+		List:  true,
+	}, links[0])
+}
+
+func TestSummary(t *testing.T) {
+	require.Equal(t, "2000 usd · Order 42 · succeeded", Summary(gjson.Parse(`{"amount": 2000, "currency": "usd", "description": "Order 42", "status": "succeeded", "customer": null}`)))
+	require.Equal(t, "jenny@example.com · Jenny Rosen", Summary(gjson.Parse(`{"email": "jenny@example.com", "name": "Jenny Rosen", "description": ""}`)))
+	require.Equal(t, "", Summary(gjson.Parse(`{"id": "cus_123"}`)))
+}
+
+func TestGetUsesModeKey(t *testing.T) {
+	var keys []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Authorization"))
+		require.Equal(t, "/v1/customers", r.URL.Path)
+		require.Equal(t, "20", r.URL.Query().Get("limit"))
+		w.Write([]byte(`{"object": "list", "data": [{"id": "cus_123"}], "has_more": false}`))
+	}))
+	defer ts.Close()
+
+	b := &Browser{APIBaseURL: ts.URL, TestModeKey: "sk_test_123", LiveModeKey: "sk_live_123"}
+
+	page, err := b.get(context.Background(), "/v1/customers", []string{"limit=20"})
+	require.NoError(t, err)
+	require.Equal(t, "cus_123", page.Get("data.0.id").String())
+
+	b.live = true
+	_, err = b.get(context.Background(), "/v1/customers", []string{"limit=20"})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"Bearer sk_test_123", "Bearer sk_live_123"}, keys)
+}
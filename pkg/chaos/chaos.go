@@ -0,0 +1,251 @@
+// Package chaos is a reverse proxy injecting faults into the webhook events
+// forwarded to a local endpoint: dropped and duplicated deliveries, latency
+// and reordering. It helps verify that webhook handlers are idempotent and
+// safe to retry.
+package chaos
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultReorderDelay is how long reordered deliveries are held back, so
+// that the deliveries received after them are forwarded first
+const DefaultReorderDelay = 2 * time.Second
+
+// duplicateDelay is how long after the original delivery a duplicate is sent
+const duplicateDelay = 500 * time.Millisecond
+
+// Config is the configuration of a chaos Proxy
+type Config struct {
+	// Target is the URL deliveries are forwarded to, like localhost:4242 or
+	// http://localhost:4242/webhooks
+	Target string
+
+	// Drop, Duplicate and Reorder are the rates, between 0 and 1, of the
+	// deliveries that are dropped, sent twice, or held back by ReorderDelay
+	Drop      float64
+	Duplicate float64
+	Reorder   float64
+
+	// Delay is the latency added to every delivery
+	Delay time.Duration
+
+	// ReorderDelay is how long reordered deliveries are held back. It
+	// defaults to DefaultReorderDelay.
+	ReorderDelay time.Duration
+
+	// Out is where the injected faults and forwarded deliveries are printed
+	Out io.Writer
+
+	// Rand is the source of the faults, seeded with the time if nil
+	Rand *rand.Rand
+}
+
+// Proxy is an http.Handler forwarding deliveries to the target, injecting
+// faults at the configured rates
+type Proxy struct {
+	cfg    *Config
+	target *url.URL
+	client *http.Client
+
+	mu   sync.Mutex // guards rand, which isn't safe for concurrent use
+	rand *rand.Rand
+	wg   sync.WaitGroup
+}
+
+// New returns a Proxy for the configuration
+func New(cfg *Config) (*Proxy, error) {
+	target, err := url.Parse(normalizeTarget(cfg.Target))
+	if err != nil || target.Host == "" {
+		return nil, fmt.Errorf("invalid target ‘%s’, expected a URL like localhost:4242/webhooks", cfg.Target)
+	}
+
+	for name, rate := range map[string]float64{"drop": cfg.Drop, "duplicate": cfg.Duplicate, "reorder": cfg.Reorder} {
+		if rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("the %s rate must be between 0%% and 100%%", name)
+		}
+	}
+
+	if cfg.ReorderDelay == 0 {
+		cfg.ReorderDelay = DefaultReorderDelay
+	}
+
+	if cfg.Out == nil {
+		cfg.Out = ioutil.Discard
+	}
+
+	r := cfg.Rand
+	if r == nil {
+		r = rand.New(rand.NewSource(time.Now().UnixNano())) // #nosec G404
+	}
+
+	return &Proxy{
+		cfg:    cfg,
+		target: target,
+		client: &http.Client{Timeout: 30 * time.Second},
+		rand:   r,
+	}, nil
+}
+
+// Target returns the URL deliveries are forwarded to
+func (p *Proxy) Target() string {
+	return p.target.String()
+}
+
+// ServeHTTP forwards a delivery to the target, unless it's dropped. The
+// response of the target is returned to the sender.
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	name := deliveryName(r, body)
+
+	if p.roll(p.cfg.Drop) {
+		fmt.Fprintf(p.cfg.Out, "dropped %s\n", name)
+		http.Error(w, "dropped by stripe chaos", http.StatusServiceUnavailable)
+		return
+	}
+
+	delay := p.cfg.Delay
+	if p.roll(p.cfg.Reorder) {
+		fmt.Fprintf(p.cfg.Out, "holding back %s by %s\n", name, p.cfg.ReorderDelay)
+		delay += p.cfg.ReorderDelay
+	}
+
+	select {
+	case <-time.After(delay):
+	case <-r.Context().Done():
+		return
+	}
+
+	resp, err := p.forward(r, body)
+	if err != nil {
+		fmt.Fprintf(p.cfg.Out, "failed to forward %s: %v\n", name, err)
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	fmt.Fprintf(p.cfg.Out, "forwarded %s [%d]\n", name, resp.StatusCode)
+
+	if p.roll(p.cfg.Duplicate) {
+		p.duplicate(r, body, name)
+	}
+
+	for k, values := range resp.Header {
+		for _, v := range values {
+			w.Header().Add(k, v)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	io.Copy(w, resp.Body) // #nosec G104
+}
+
+// Wait waits for the duplicates being sent
+func (p *Proxy) Wait() {
+	p.wg.Wait()
+}
+
+// duplicate forwards a delivery again, shortly after the original one
+func (p *Proxy) duplicate(r *http.Request, body []byte, name string) {
+	p.wg.Add(1)
+
+	go func() {
+		defer p.wg.Done()
+
+		time.Sleep(duplicateDelay)
+
+		resp, err := p.forward(r, body)
+		if err != nil {
+			fmt.Fprintf(p.cfg.Out, "failed to forward the duplicate of %s: %v\n", name, err)
+			return
+		}
+		resp.Body.Close()
+
+		fmt.Fprintf(p.cfg.Out, "duplicated %s [%d]\n", name, resp.StatusCode)
+	}()
+}
+
+// forward sends a delivery to the target, with the same method, headers and
+// body. The path and query of the delivery are appended to the target's.
+func (p *Proxy) forward(r *http.Request, body []byte) (*http.Response, error) {
+	u := *p.target
+	if r.URL.Path != "" && r.URL.Path != "/" {
+		u.Path = strings.TrimSuffix(u.Path, "/") + r.URL.Path
+	}
+	if r.URL.RawQuery != "" {
+		u.RawQuery = r.URL.RawQuery
+	}
+
+	req, err := http.NewRequest(r.Method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header = r.Header.Clone()
+
+	return p.client.Do(req)
+}
+
+// roll returns true with the probability rate
+func (p *Proxy) roll(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.rand.Float64() < rate
+}
+
+// deliveryName names a delivery in the output, by the ID and type of its
+// event when it's a Stripe event
+func deliveryName(r *http.Request, body []byte) string {
+	var evt struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+	}
+
+	if json.Unmarshal(body, &evt) == nil && evt.ID != "" {
+		return fmt.Sprintf("%s (%s)", evt.ID, evt.Type)
+	}
+
+	return fmt.Sprintf("%s %s", r.Method, r.URL.Path)
+}
+
+// ParseRate parses a rate like 5% or 0.5%
+func ParseRate(rate string) (float64, error) {
+	n, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(rate), "%"), 64)
+	if err != nil || n < 0 || n > 100 {
+		return 0, fmt.Errorf("invalid rate ‘%s’, expected a percentage like 5%%", rate)
+	}
+
+	return n / 100, nil
+}
+
+// normalizeTarget completes a target like 4242 or localhost:4242 into a URL
+func normalizeTarget(target string) string {
+	if _, err := strconv.Atoi(target); err == nil {
+		target = "localhost:" + target
+	}
+
+	if !strings.HasPrefix(target, "http://") && !strings.HasPrefix(target, "https://") {
+		target = "http://" + target
+	}
+
+	return target
+}
@@ -0,0 +1,116 @@
+package chaos
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testEvent = `{"id": "evt_123", "type": "customer.created"}`
+
+// target records the paths of the deliveries it receives
+type target struct {
+	mu    sync.Mutex
+	paths []string
+}
+
+func (tg *target) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	tg.mu.Lock()
+	defer tg.mu.Unlock()
+
+	tg.paths = append(tg.paths, r.URL.Path)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (tg *target) received() []string {
+	tg.mu.Lock()
+	defer tg.mu.Unlock()
+
+	return append([]string(nil), tg.paths...)
+}
+
+func newTestProxy(t *testing.T, cfg *Config) (*Proxy, *target) {
+	tg := &target{}
+	ts := httptest.NewServer(tg)
+	t.Cleanup(ts.Close)
+
+	cfg.Target = ts.URL + "/webhooks"
+
+	p, err := New(cfg)
+	require.NoError(t, err)
+
+	return p, tg
+}
+
+func deliver(p *Proxy, path string) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	p.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, path, strings.NewReader(testEvent)))
+
+	return rr
+}
+
+func TestForward(t *testing.T) {
+	var out bytes.Buffer
+	p, tg := newTestProxy(t, &Config{Out: &out})
+
+	require.Equal(t, http.StatusOK, deliver(p, "/").Code)
+	require.Equal(t, http.StatusOK, deliver(p, "/connect").Code)
+	require.Equal(t, []string{"/webhooks", "/webhooks/connect"}, tg.received())
+	require.Contains(t, out.String(), "forwarded evt_123 (customer.created) [200]\n")
+}
+
+func TestDrop(t *testing.T) {
+	var out bytes.Buffer
+	p, tg := newTestProxy(t, &Config{Drop: 1, Out: &out})
+
+	require.Equal(t, http.StatusServiceUnavailable, deliver(p, "/").Code)
+	require.Empty(t, tg.received())
+	require.Equal(t, "dropped evt_123 (customer.created)\n", out.String())
+}
+
+func TestDuplicate(t *testing.T) {
+	p, tg := newTestProxy(t, &Config{Duplicate: 1})
+
+	require.Equal(t, http.StatusOK, deliver(p, "/").Code)
+	p.Wait()
+	require.Equal(t, []string{"/webhooks", "/webhooks"}, tg.received())
+}
+
+func TestDelay(t *testing.T) {
+	p, _ := newTestProxy(t, &Config{Delay: 50 * time.Millisecond, Reorder: 1, ReorderDelay: 50 * time.Millisecond})
+
+	start := time.Now()
+	require.Equal(t, http.StatusOK, deliver(p, "/").Code)
+	require.GreaterOrEqual(t, int64(time.Since(start)), int64(100*time.Millisecond))
+}
+
+func TestNewInvalid(t *testing.T) {
+	_, err := New(&Config{Target: "http://"})
+	require.EqualError(t, err, "invalid target ‘http://’, expected a URL like localhost:4242/webhooks")
+
+	_, err = New(&Config{Target: "4242", Drop: 2})
+	require.EqualError(t, err, "the drop rate must be between 0% and 100%")
+
+	p, err := New(&Config{Target: "4242"})
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:4242", p.Target())
+}
+
+func TestParseRate(t *testing.T) {
+	rate, err := ParseRate("5%")
+	require.NoError(t, err)
+	require.InDelta(t, 0.05, rate, 1e-9)
+
+	rate, err = ParseRate("0.5")
+	require.NoError(t, err)
+	require.InDelta(t, 0.005, rate, 1e-9)
+
+	_, err = ParseRate("150%")
+	require.EqualError(t, err, "invalid rate ‘150%’, expected a percentage like 5%")
+}
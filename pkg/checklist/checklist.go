@@ -0,0 +1,298 @@
+// Package checklist walks developers through the launch checklist of a
+// Stripe product, checking the items it can on their account.
+package checklist
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/requests"
+)
+
+// Status is the state of an item of a checklist
+type Status int
+
+const (
+	// Complete means the item is done, as checked on the account or confirmed
+	Complete Status = iota
+
+	// Incomplete means the item was checked on the account and isn't done
+	Incomplete
+
+	// Manual means the item can't be checked on the account, and has to be
+	// confirmed
+	Manual
+
+	// Unknown means the item couldn't be checked, e.g. because of an API error
+	Unknown
+)
+
+// Item is an item of a checklist
+type Item struct {
+	// Name identifies the item, e.g. to remember the items that were
+	// confirmed
+	Name  string
+	Title string
+	// Hint explains how to complete the item
+	Hint string
+
+	// check returns whether the item is done on the account, with the
+	// details of what was found. Items without one are Manual.
+	check func(ctx context.Context, s *session) (bool, string, error)
+}
+
+// Result is the state of an item of a checklist
+type Result struct {
+	Item   Item
+	Status Status
+	Detail string
+}
+
+// Checklist is the launch checklist of a product
+type Checklist struct {
+	Product string
+	Title   string
+	Items   []Item
+}
+
+// Env is the account a checklist is checked on
+type Env struct {
+	APIBaseURL  string
+	TestModeKey string
+	// LiveModeKey is the live mode key of the profile, if one is configured.
+	// Without one, webhook endpoints are checked in test mode.
+	LiveModeKey string
+	// Profile sets the API version and preview features of the requests
+	Profile *config.Profile
+	// Confirmed are the names of the Manual items that were confirmed
+	Confirmed map[string]bool
+}
+
+// Checklists are the checklists, by product
+var Checklists = map[string]*Checklist{
+	"payments": paymentsChecklist,
+}
+
+// Products returns the products that have a checklist, sorted
+func Products() []string {
+	products := make([]string, 0, len(Checklists))
+	for product := range Checklists {
+		products = append(products, product)
+	}
+	sort.Strings(products)
+
+	return products
+}
+
+// Run checks the items of the checklist on the account of env.
+func (c *Checklist) Run(ctx context.Context, env Env) []Result {
+	s := &session{env: env}
+
+	results := make([]Result, 0, len(c.Items))
+	for _, item := range c.Items {
+		result := Result{Item: item}
+
+		switch {
+		case item.check == nil && env.Confirmed[item.Name]:
+			result.Status = Complete
+			result.Detail = "confirmed"
+		case item.check == nil:
+			result.Status = Manual
+		default:
+			done, detail, err := item.check(ctx, s)
+			switch {
+			case err != nil:
+				result.Status = Unknown
+				result.Detail = err.Error()
+			case done:
+				result.Status = Complete
+				result.Detail = detail
+			default:
+				result.Status = Incomplete
+				result.Detail = detail
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// session caches the objects of the account that several items are checked
+// on
+type session struct {
+	env Env
+
+	endpoints []gjson.Result
+	fetched   bool
+}
+
+// webhookKey returns the key webhook endpoints are checked with, and the mode
+// it's in
+func (s *session) webhookKey() (string, string) {
+	if s.env.LiveModeKey != "" {
+		return s.env.LiveModeKey, "live mode"
+	}
+
+	return s.env.TestModeKey, "test mode"
+}
+
+// enabledEndpoints returns the enabled webhook endpoints of the account
+func (s *session) enabledEndpoints(ctx context.Context) ([]gjson.Result, error) {
+	if s.fetched {
+		return s.endpoints, nil
+	}
+
+	apiKey, _ := s.webhookKey()
+
+	startingAfter := ""
+	for {
+		params := []string{"limit=100"}
+		if startingAfter != "" {
+			params = append(params, "starting_after="+startingAfter)
+		}
+
+		list, err := requests.Do(ctx, http.MethodGet, s.env.APIBaseURL, apiKey, "/v1/webhook_endpoints", params, s.env.Profile)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, endpoint := range list.Get("data").Array() {
+			if endpoint.Get("status").String() == "enabled" {
+				s.endpoints = append(s.endpoints, endpoint)
+			}
+			startingAfter = endpoint.Get("id").String()
+		}
+
+		if !list.Get("has_more").Bool() || startingAfter == "" {
+			break
+		}
+	}
+
+	s.fetched = true
+
+	return s.endpoints, nil
+}
+
+// checkTestModeKey checks that the test mode key of the profile works
+func checkTestModeKey(ctx context.Context, s *session) (bool, string, error) {
+	if s.env.TestModeKey == "" {
+		return false, "no test mode key is configured", nil
+	}
+
+	account, err := requests.Do(ctx, http.MethodGet, s.env.APIBaseURL, s.env.TestModeKey, "/v1/account", nil, s.env.Profile)
+	if err != nil {
+		if reqErr, ok := err.(requests.RequestError); ok && reqErr.StatusCode == http.StatusUnauthorized {
+			return false, "the test mode key was rejected, it may have expired", nil
+		}
+		return false, "", err
+	}
+
+	return true, fmt.Sprintf("the test mode key works for %s", account.Get("id").String()), nil
+}
+
+// checkLiveModeKey checks that a live mode key is configured
+func checkLiveModeKey(ctx context.Context, s *session) (bool, string, error) {
+	if s.env.LiveModeKey == "" {
+		return false, "no live mode key is configured", nil
+	}
+
+	return true, "a live mode key is configured", nil
+}
+
+// checkChargesEnabled checks that the account was activated and can accept
+// live payments
+func checkChargesEnabled(ctx context.Context, s *session) (bool, string, error) {
+	apiKey := s.env.LiveModeKey
+	if apiKey == "" {
+		apiKey = s.env.TestModeKey
+	}
+
+	account, err := requests.Do(ctx, http.MethodGet, s.env.APIBaseURL, apiKey, "/v1/account", nil, s.env.Profile)
+	if err != nil {
+		return false, "", err
+	}
+
+	if !account.Get("details_submitted").Bool() {
+		return false, "the account details haven't been submitted", nil
+	}
+	if !account.Get("charges_enabled").Bool() {
+		return false, "the account can't accept payments yet", nil
+	}
+
+	return true, "the account can accept payments", nil
+}
+
+// checkWebhookEndpoint checks that the account has an enabled webhook
+// endpoint
+func checkWebhookEndpoint(ctx context.Context, s *session) (bool, string, error) {
+	endpoints, err := s.enabledEndpoints(ctx)
+	if err != nil {
+		return false, "", err
+	}
+
+	_, mode := s.webhookKey()
+	if len(endpoints) == 0 {
+		return false, fmt.Sprintf("no webhook endpoint is enabled in %s", mode), nil
+	}
+
+	urls := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		urls = append(urls, endpoint.Get("url").String())
+	}
+
+	return true, fmt.Sprintf("%s enabled in %s", strings.Join(urls, ", "), mode), nil
+}
+
+// checkEventsHandled returns a check that the enabled webhook endpoints
+// receive every one of events, and that the recent deliveries of those
+// events didn't fail
+func checkEventsHandled(events ...string) func(ctx context.Context, s *session) (bool, string, error) {
+	return func(ctx context.Context, s *session) (bool, string, error) {
+		endpoints, err := s.enabledEndpoints(ctx)
+		if err != nil {
+			return false, "", err
+		}
+
+		subscribed := make(map[string]bool)
+		for _, endpoint := range endpoints {
+			for _, event := range endpoint.Get("enabled_events").Array() {
+				subscribed[event.String()] = true
+			}
+		}
+
+		var missing []string
+		for _, event := range events {
+			if !subscribed[event] && !subscribed["*"] {
+				missing = append(missing, event)
+			}
+		}
+		if len(missing) > 0 {
+			return false, fmt.Sprintf("no enabled webhook endpoint receives %s", strings.Join(missing, ", ")), nil
+		}
+
+		apiKey, mode := s.webhookKey()
+		params := []string{"delivery_success=false", "limit=100"}
+		for _, event := range events {
+			params = append(params, "types[]="+event)
+		}
+
+		failed, err := requests.Do(ctx, http.MethodGet, s.env.APIBaseURL, apiKey, "/v1/events", params, s.env.Profile)
+		if err != nil {
+			return false, "", err
+		}
+
+		if count := len(failed.Get("data").Array()); count > 0 {
+			return false, fmt.Sprintf("the delivery of %d recent event(s) failed in %s, e.g. %s", count, mode, failed.Get("data.0.id").String()), nil
+		}
+
+		return true, fmt.Sprintf("%s are received, and their recent deliveries succeeded", strings.Join(events, ", ")), nil
+	}
+}
@@ -0,0 +1,90 @@
+package checklist
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunPayments(t *testing.T) {
+	var eventsQuery string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/account":
+			if r.Header.Get("Authorization") == "Bearer sk_test_expired" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"error": {"type": "invalid_request_error"}}`))
+				return
+			}
+			w.Write([]byte(`{"id": "acct_123", "details_submitted": true, "charges_enabled": false}`))
+		case "/v1/webhook_endpoints":
+			w.Write([]byte(`{"data": [
+				{"id": "we_1", "url": "https://example.com/old", "status": "disabled", "enabled_events": ["*"]},
+				{"id": "we_2", "url": "https://example.com/webhooks", "status": "enabled", "enabled_events": ["payment_intent.succeeded", "payment_intent.payment_failed"]}
+			], "has_more": false}`))
+		case "/v1/events":
+			eventsQuery = r.URL.RawQuery
+			w.Write([]byte(`{"data": [{"id": "evt_failed"}], "has_more": false}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	results := paymentsChecklist.Run(context.Background(), Env{
+		APIBaseURL:  ts.URL,
+		TestModeKey: "sk_test_1234",
+		Confirmed:   map[string]bool{"declines_tested": true},
+	})
+
+	statuses := make(map[string]Status)
+	details := make(map[string]string)
+	for _, result := range results {
+		statuses[result.Item.Name] = result.Status
+		details[result.Item.Name] = result.Detail
+	}
+
+	require.Equal(t, Complete, statuses["test_mode_key"])
+	require.Equal(t, "the test mode key works for acct_123", details["test_mode_key"])
+	require.Equal(t, Incomplete, statuses["live_mode_key"])
+	require.Equal(t, Incomplete, statuses["account_activated"])
+	require.Equal(t, "the account can't accept payments yet", details["account_activated"])
+	require.Equal(t, Complete, statuses["webhook_endpoint"])
+	require.Equal(t, "https://example.com/webhooks enabled in test mode", details["webhook_endpoint"])
+	require.Equal(t, Incomplete, statuses["events_handled"])
+	require.Equal(t, "the delivery of 1 recent event(s) failed in test mode, e.g. evt_failed", details["events_handled"])
+	require.Contains(t, eventsQuery, "delivery_success=false")
+	require.Equal(t, Complete, statuses["declines_tested"])
+	require.Equal(t, Manual, statuses["idempotent_requests"])
+
+	results = paymentsChecklist.Run(context.Background(), Env{
+		APIBaseURL:  ts.URL,
+		TestModeKey: "sk_test_expired",
+	})
+	require.Equal(t, Incomplete, results[0].Status)
+	require.Equal(t, "the test mode key was rejected, it may have expired", results[0].Detail)
+}
+
+func TestCheckEventsHandledMissing(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/webhook_endpoints", r.URL.Path)
+		require.Equal(t, "Bearer sk_live_1234", r.Header.Get("Authorization"))
+		w.Write([]byte(`{"data": [{"id": "we_1", "url": "https://example.com/webhooks", "status": "enabled", "enabled_events": ["charge.succeeded"]}], "has_more": false}`))
+	}))
+	defer ts.Close()
+
+	s := &session{env: Env{APIBaseURL: ts.URL, TestModeKey: "sk_test_1234", LiveModeKey: "sk_live_1234"}}
+
+	done, detail, err := checkEventsHandled("payment_intent.succeeded", "charge.succeeded")(context.Background(), s)
+	require.NoError(t, err)
+	require.False(t, done)
+	require.Equal(t, "no enabled webhook endpoint receives payment_intent.succeeded", detail)
+}
+
+func TestProducts(t *testing.T) {
+	require.Equal(t, []string{"payments"}, Products())
+}
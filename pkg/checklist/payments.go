@@ -0,0 +1,53 @@
+package checklist
+
+var paymentsChecklist = &Checklist{
+	Product: "payments",
+	Title:   "Payments launch checklist",
+	Items: []Item{
+		{
+			Name:  "test_mode_key",
+			Title: "Test mode API key",
+			Hint:  "Run `stripe login` to configure a test mode key",
+			check: checkTestModeKey,
+		},
+		{
+			Name:  "live_mode_key",
+			Title: "Live mode API key",
+			Hint:  "Run `stripe login`, or `stripe config --set live_mode_api_key <key>`, to configure a live mode key",
+			check: checkLiveModeKey,
+		},
+		{
+			Name:  "account_activated",
+			Title: "Account activated",
+			Hint:  "Activate your account at https://dashboard.stripe.com/account/onboarding",
+			check: checkChargesEnabled,
+		},
+		{
+			Name:  "webhook_endpoint",
+			Title: "Webhook endpoint",
+			Hint:  "Add the endpoint of your server at https://dashboard.stripe.com/webhooks",
+			check: checkWebhookEndpoint,
+		},
+		{
+			Name:  "events_handled",
+			Title: "Payment events handled",
+			Hint:  "Enable the events on your webhook endpoint, and check the failed deliveries at https://dashboard.stripe.com/webhooks",
+			check: checkEventsHandled("payment_intent.succeeded", "payment_intent.payment_failed"),
+		},
+		{
+			Name:  "declines_tested",
+			Title: "Declines tested",
+			Hint:  "Test declined payments with the test cards of https://stripe.com/docs/testing#declined-payments",
+		},
+		{
+			Name:  "idempotent_requests",
+			Title: "Idempotent requests",
+			Hint:  "Send an idempotency key with the requests creating payments, see https://stripe.com/docs/api/idempotent_requests",
+		},
+		{
+			Name:  "secret_key_stored",
+			Title: "Secret key stored securely",
+			Hint:  "Keep the live mode secret key out of your code and repository, e.g. in environment variables",
+		},
+	},
+}
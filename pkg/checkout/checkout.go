@@ -0,0 +1,103 @@
+// Package checkout creates Checkout Sessions with test defaults, to try out
+// prices and payment flows without writing an integration first.
+package checkout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/requests"
+)
+
+// Modes of Checkout Sessions
+const (
+	ModePayment      = "payment"
+	ModeSubscription = "subscription"
+)
+
+// Default URLs customers are sent to after the payment. The pages don't need
+// to exist to test the payment itself.
+const (
+	DefaultSuccessURL = "https://example.com/success?session_id={CHECKOUT_SESSION_ID}"
+	DefaultCancelURL  = "https://example.com/cancel"
+)
+
+// Options are the options of a Checkout Session
+type Options struct {
+	// Prices are the prices of the line items
+	Prices []string
+	// Quantity is the quantity of each line item
+	Quantity int64
+	// Mode is ModePayment or ModeSubscription. It's guessed from the type
+	// of the first price when it's empty.
+	Mode       string
+	SuccessURL string
+	CancelURL  string
+}
+
+// Session is a Checkout Session
+type Session struct {
+	ID   string
+	URL  string
+	Mode string
+}
+
+// CreateSession creates a Checkout Session for the prices of opts
+func CreateSession(ctx context.Context, baseURL, apiKey string, opts Options, profile *config.Profile) (*Session, error) {
+	if len(opts.Prices) == 0 {
+		return nil, errors.New("at least one price is required")
+	}
+
+	if opts.Quantity == 0 {
+		opts.Quantity = 1
+	}
+	if opts.SuccessURL == "" {
+		opts.SuccessURL = DefaultSuccessURL
+	}
+	if opts.CancelURL == "" {
+		opts.CancelURL = DefaultCancelURL
+	}
+
+	mode := opts.Mode
+	if mode == "" {
+		price, err := requests.Do(ctx, http.MethodGet, baseURL, apiKey, "/v1/prices/"+opts.Prices[0], nil, profile)
+		if err != nil {
+			return nil, err
+		}
+
+		mode = ModePayment
+		if price.Get("type").String() == "recurring" {
+			mode = ModeSubscription
+		}
+	}
+
+	if mode != ModePayment && mode != ModeSubscription {
+		return nil, fmt.Errorf("invalid mode ‘%s’, expected %s or %s", mode, ModePayment, ModeSubscription)
+	}
+
+	data := []string{
+		"mode=" + mode,
+		"success_url=" + opts.SuccessURL,
+		"cancel_url=" + opts.CancelURL,
+	}
+	for i, price := range opts.Prices {
+		data = append(data,
+			fmt.Sprintf("line_items[%d][price]=%s", i, price),
+			fmt.Sprintf("line_items[%d][quantity]=%d", i, opts.Quantity),
+		)
+	}
+
+	session, err := requests.Do(ctx, http.MethodPost, baseURL, apiKey, "/v1/checkout/sessions", data, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Session{
+		ID:   session.Get("id").String(),
+		URL:  session.Get("url").String(),
+		Mode: mode,
+	}, nil
+}
@@ -0,0 +1,71 @@
+package checkout
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T, priceType string, created *url.Values) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		params, err := url.ParseQuery(string(body))
+		require.NoError(t, err)
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v1/prices/price_123":
+			w.Write([]byte(`{"id": "price_123", "type": "` + priceType + `"}`))
+		case "POST /v1/checkout/sessions":
+			*created = params
+			w.Write([]byte(`{"id": "cs_test_123", "url": "https://checkout.stripe.com/c/pay/cs_test_123"}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+}
+
+func TestCreateSession(t *testing.T) {
+	var params url.Values
+	ts := newTestServer(t, "one_time", &params)
+	defer ts.Close()
+
+	session, err := CreateSession(context.Background(), ts.URL, "sk_test_123", Options{
+		Prices:   []string{"price_123", "price_456"},
+		Quantity: 2,
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, &Session{ID: "cs_test_123", URL: "https://checkout.stripe.com/c/pay/cs_test_123", Mode: ModePayment}, session)
+
+	require.Equal(t, ModePayment, params.Get("mode"))
+	require.Equal(t, "price_123", params.Get("line_items[0][price]"))
+	require.Equal(t, "2", params.Get("line_items[0][quantity]"))
+	require.Equal(t, "price_456", params.Get("line_items[1][price]"))
+	require.Equal(t, DefaultSuccessURL, params.Get("success_url"))
+	require.Equal(t, DefaultCancelURL, params.Get("cancel_url"))
+}
+
+func TestCreateSessionGuessesSubscriptionMode(t *testing.T) {
+	var params url.Values
+	ts := newTestServer(t, "recurring", &params)
+	defer ts.Close()
+
+	session, err := CreateSession(context.Background(), ts.URL, "sk_test_123", Options{Prices: []string{"price_123"}}, nil)
+	require.NoError(t, err)
+	require.Equal(t, ModeSubscription, session.Mode)
+	require.Equal(t, ModeSubscription, params.Get("mode"))
+	require.Equal(t, "1", params.Get("line_items[0][quantity]"))
+}
+
+func TestCreateSessionInvalidMode(t *testing.T) {
+	_, err := CreateSession(context.Background(), "http://localhost", "sk_test_123", Options{Prices: []string{"price_123"}, Mode: "setup"}, nil)
+	require.EqualError(t, err, "invalid mode ‘setup’, expected payment or subscription")
+
+	_, err = CreateSession(context.Background(), "http://localhost", "sk_test_123", Options{}, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/snapshot"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type accountCmd struct {
+	cmd    *cobra.Command
+	config *config.Config
+	fs     afero.Fs
+
+	out        string
+	apiBaseURL string
+}
+
+func newAccountCmd(cfg *config.Config) *accountCmd {
+	ac := &accountCmd{
+		config: cfg,
+		fs:     afero.NewOsFs(),
+	}
+
+	ac.cmd = &cobra.Command{
+		Use:   "account",
+		Args:  validators.NoArgs,
+		Short: "Snapshot and compare the settings of your account",
+	}
+
+	snapshotCmd := &cobra.Command{
+		Use:   "snapshot",
+		Args:  validators.NoArgs,
+		Short: "Save the settings of your account to a file",
+		Long: `Save the webhook endpoints, branding, payout schedule and enabled payment
+methods of your account to a file, so they can be compared later or with
+another account with ` + "`stripe account diff`" + `.`,
+		Example: `stripe account snapshot --out acct.json
+  stripe account snapshot --project-name staging --out staging.json`,
+		RunE: ac.runSnapshotCmd,
+	}
+	snapshotCmd.Flags().StringVar(&ac.out, "out", "", "File to save the snapshot to, or - to print it")
+	snapshotCmd.MarkFlagRequired("out") // #nosec G104
+
+	diffCmd := &cobra.Command{
+		Use:   "diff <snapshot> [other-snapshot]",
+		Args:  validators.RangeArgs(1, 2),
+		Short: "Compare the settings of your account with a snapshot",
+		Long: `Compare the settings of your account with a snapshot taken with
+` + "`stripe account snapshot`" + `, or compare two snapshots. The command exits with a
+non-zero code when the settings differ, so configuration drift between
+accounts can be caught in CI.`,
+		Example: `stripe account diff acct.json
+  stripe account diff --project-name staging acct.json
+  stripe account diff test.json staging.json`,
+		RunE: ac.runDiffCmd,
+	}
+
+	ac.cmd.AddCommand(snapshotCmd)
+	ac.cmd.AddCommand(diffCmd)
+
+	// Hidden configuration flags, useful for dev/debugging
+	ac.cmd.PersistentFlags().StringVar(&ac.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	ac.cmd.PersistentFlags().MarkHidden("api-base") // #nosec G104
+
+	return ac
+}
+
+func (ac *accountCmd) runSnapshotCmd(cmd *cobra.Command, args []string) error {
+	current, err := ac.take(cmd)
+	if err != nil {
+		return err
+	}
+
+	data, err := current.Marshal()
+	if err != nil {
+		return err
+	}
+
+	if ac.out == "-" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+
+	if err := afero.WriteFile(ac.fs, ac.out, data, 0644); err != nil {
+		return err
+	}
+
+	fmt.Printf("Saved the settings of %s to %s\n", current.Account, ac.out)
+
+	return nil
+}
+
+func (ac *accountCmd) runDiffCmd(cmd *cobra.Command, args []string) error {
+	from, err := snapshot.Load(ac.fs, args[0])
+	if err != nil {
+		return err
+	}
+
+	var to *snapshot.Snapshot
+	if len(args) == 2 {
+		to, err = snapshot.Load(ac.fs, args[1])
+	} else {
+		to, err = ac.take(cmd)
+	}
+	if err != nil {
+		return err
+	}
+
+	changes := snapshot.Diff(from, to)
+	printAccountDiff(os.Stdout, from, to, changes)
+
+	if len(changes) > 0 {
+		return fmt.Errorf("%d setting(s) differ", len(changes))
+	}
+
+	return nil
+}
+
+func (ac *accountCmd) take(cmd *cobra.Command) (*snapshot.Snapshot, error) {
+	apiKey, err := ac.config.Profile.GetAPIKey(false)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot.Take(cmd.Context(), ac.apiBaseURL, apiKey, &ac.config.Profile)
+}
+
+// printAccountDiff prints the settings that differ between two snapshots.
+func printAccountDiff(out io.Writer, from, to *snapshot.Snapshot, changes []snapshot.Change) {
+	color := ansi.Color(out)
+
+	fmt.Fprintf(out, "Comparing %s (%s) with %s (%s)\n\n",
+		from.Account, from.TakenAt.Local().Format("2006-01-02 15:04"),
+		to.Account, to.TakenAt.Local().Format("2006-01-02 15:04"))
+
+	if len(changes) == 0 {
+		fmt.Fprintln(out, "The settings are the same.")
+		return
+	}
+
+	for _, change := range changes {
+		switch {
+		case change.Old == "":
+			fmt.Fprintf(out, "%s %s: %s\n", color.Green("+"), change.Setting, change.New)
+		case change.New == "":
+			fmt.Fprintf(out, "%s %s: %s\n", color.Red("-"), change.Setting, change.Old)
+		default:
+			fmt.Fprintf(out, "%s %s: %s → %s\n", color.Yellow("~"), change.Setting, change.Old, change.New)
+		}
+	}
+}
@@ -0,0 +1,334 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/audit"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type auditCmd struct {
+	cmd *cobra.Command
+}
+
+func newAuditCmd(cfg *config.Config) *auditCmd {
+	ac := &auditCmd{
+		cmd: &cobra.Command{
+			Use:   "audit",
+			Args:  validators.NoArgs,
+			Short: "Audit your integration",
+		},
+	}
+
+	ac.cmd.AddCommand(newAuditSDKCmd().cmd)
+	ac.cmd.AddCommand(newAuditShowCmd(cfg).cmd)
+
+	return ac
+}
+
+type auditSDKCmd struct {
+	cmd *cobra.Command
+	fs  afero.Fs
+}
+
+func newAuditSDKCmd() *auditSDKCmd {
+	ac := &auditSDKCmd{
+		fs: afero.NewOsFs(),
+	}
+
+	ac.cmd = &cobra.Command{
+		Use:   "sdk [path]",
+		Args:  validators.MaximumNArgs(1),
+		Short: "Check the Stripe SDKs used by a project for updates and known vulnerabilities",
+		Long: `Scan a project's go.mod, package.json, requirements.txt and Gemfile for Stripe
+SDKs, and report for each one the latest available version, the Stripe API
+version it pins and any known vulnerabilities affecting it.`,
+		Example: `stripe audit sdk
+  stripe audit sdk ./my-app`,
+		RunE: ac.runAuditSDKCmd,
+	}
+
+	return ac
+}
+
+func (ac *auditSDKCmd) runAuditSDKCmd(cmd *cobra.Command, args []string) error {
+	dir := "."
+	if len(args) == 1 {
+		dir = args[0]
+	}
+
+	deps, err := audit.Detect(ac.fs, dir)
+	if err != nil {
+		return err
+	}
+
+	if len(deps) == 0 {
+		fmt.Printf("No Stripe SDKs found in %s\n", dir)
+		return nil
+	}
+
+	spinner := ansi.StartNewSpinner("Checking SDK versions...", os.Stdout)
+	reports := audit.NewAuditor().Audit(cmd.Context(), deps)
+	ansi.StopSpinner(spinner, "", os.Stdout)
+
+	color := ansi.Color(os.Stdout)
+
+	for _, r := range reports {
+		dep := r.Dependency
+		constraint := dep.Constraint
+		if constraint == "" {
+			constraint = "(any version)"
+		}
+
+		fmt.Printf("%s: %s %s\n", dep.Manifest, ansi.Bold(dep.Package), constraint)
+
+		switch {
+		case r.Latest == "":
+			fmt.Println("  Latest version:  unknown")
+		case r.MajorUpgrade():
+			fmt.Printf("  Latest version:  %s %s\n", color.Yellow(r.Latest), ansi.Faint("major upgrade, review breaking changes in "+r.ChangelogURL()))
+		case r.Outdated():
+			fmt.Printf("  Latest version:  %s %s\n", color.Yellow(r.Latest), ansi.Faint("no breaking changes expected"))
+		default:
+			fmt.Printf("  Latest version:  %s %s\n", r.Latest, ansi.Faint("up to date"))
+		}
+
+		switch {
+		case dep.Version == "":
+			fmt.Println("  API version:     unknown, pin a version of the SDK to find out")
+		case r.APIVersion == "":
+			fmt.Println("  API version:     not pinned, requests use your account's default API version")
+		default:
+			fmt.Printf("  API version:     %s\n", r.APIVersion)
+		}
+
+		if len(r.Vulnerabilities) > 0 {
+			fmt.Printf("  Vulnerabilities: %s\n", color.Red(strings.Join(r.Vulnerabilities, ", ")))
+		} else if dep.Version != "" {
+			fmt.Println("  Vulnerabilities: none known")
+		}
+
+		for _, e := range r.Errors {
+			fmt.Printf("  %s %s\n", color.Red("Warning:"), e)
+		}
+
+		fmt.Println()
+	}
+
+	return nil
+}
+
+type auditShowCmd struct {
+	cmd    *cobra.Command
+	config *config.Config
+	fs     afero.Fs
+	out    io.Writer
+
+	since  string
+	output string
+	live   bool
+}
+
+func newAuditShowCmd(cfg *config.Config) *auditShowCmd {
+	asc := &auditShowCmd{
+		config: cfg,
+		fs:     fs,
+		out:    os.Stdout,
+	}
+
+	asc.cmd = &cobra.Command{
+		Use:   "show",
+		Args:  validators.NoArgs,
+		Short: "Show the API requests recorded in the audit log",
+		Long: `Show the API requests the CLI sent, as recorded in the audit log of the
+profile. Enable the audit log with ` + "`stripe config --set audit_log true`" + ` to
+append each request, with its command, params, status and request ID, to
+audit_log.ndjson in the config folder, or set audit_log to the path of
+another file. Sensitive params, like card numbers, are redacted.`,
+		Example: `stripe audit show
+  stripe audit show --since 7d --live
+  stripe audit show --since 12h --output json`,
+		RunE: asc.runAuditShowCmd,
+	}
+
+	asc.cmd.Flags().StringVar(&asc.since, "since", "1d", "Show the requests sent during this duration, like 1d, 12h or 2w")
+	asc.cmd.Flags().StringVar(&asc.output, "output", "table", "The format to print the requests as (either 'table' or 'json')")
+	asc.cmd.Flags().BoolVar(&asc.live, "live", false, "Only show live mode requests")
+
+	return asc
+}
+
+func (asc *auditShowCmd) runAuditShowCmd(cmd *cobra.Command, args []string) error {
+	if asc.output != "table" && asc.output != "json" {
+		return fmt.Errorf("invalid output ‘%s’, expected table or json", asc.output)
+	}
+
+	since, err := parseAuditSince(asc.since)
+	if err != nil {
+		return err
+	}
+
+	path, enabled, err := auditLogPath(asc.config)
+	if err != nil {
+		return err
+	}
+
+	log := &stripe.AuditLog{Fs: config.StateFs(asc.fs), Path: path}
+
+	entries, err := log.Since(time.Now().Add(-since))
+	if err != nil {
+		return err
+	}
+
+	if asc.live {
+		filtered := entries[:0]
+		for _, entry := range entries {
+			if entry.Livemode {
+				filtered = append(filtered, entry)
+			}
+		}
+		entries = filtered
+	}
+
+	if asc.output == "json" {
+		encoder := json.NewEncoder(asc.out)
+		for _, entry := range entries {
+			if err := encoder.Encode(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(entries) == 0 {
+		if !enabled {
+			fmt.Fprintln(asc.out, "The audit log is disabled, enable it with `stripe config --set audit_log true`")
+		} else {
+			fmt.Fprintf(asc.out, "No requests recorded in the last %s\n", asc.since)
+		}
+		return nil
+	}
+
+	printAuditEntries(asc.out, entries)
+
+	return nil
+}
+
+func printAuditEntries(out io.Writer, entries []stripe.AuditEntry) {
+	color := ansi.Color(out)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tCOMMAND\tMETHOD\tPATH\tSTATUS\tREQUEST ID\tMODE")
+
+	for _, entry := range entries {
+		status := strconv.Itoa(entry.Status)
+		if entry.Status == 0 {
+			status = "-"
+		}
+
+		mode := "test"
+		if entry.Livemode {
+			mode = color.Red("live").String()
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			entry.Time.Local().Format(time.RFC3339),
+			entry.Command,
+			entry.Method,
+			entry.Path,
+			status,
+			entry.RequestID,
+			mode,
+		)
+	}
+
+	w.Flush()
+}
+
+// auditSincePattern matches a part of a --since value, like `12h`
+var auditSincePattern = regexp.MustCompile(`(\d+)([wdhm])`)
+
+var auditSinceUnits = map[string]time.Duration{
+	"w": 7 * 24 * time.Hour,
+	"d": 24 * time.Hour,
+	"h": time.Hour,
+	"m": time.Minute,
+}
+
+// parseAuditSince returns the duration of a --since value, like `1d` or
+// `1d12h`
+func parseAuditSince(since string) (time.Duration, error) {
+	value := strings.TrimSpace(since)
+
+	var duration time.Duration
+	matched := 0
+	for _, match := range auditSincePattern.FindAllStringSubmatch(value, -1) {
+		n, err := strconv.Atoi(match[1])
+		if err != nil {
+			return 0, err
+		}
+		duration += time.Duration(n) * auditSinceUnits[match[2]]
+		matched += len(match[0])
+	}
+
+	if matched == 0 || matched != len(value) || duration <= 0 {
+		return 0, fmt.Errorf("invalid --since ‘%s’, expected a duration like 1d, 12h or 2w", since)
+	}
+
+	return duration, nil
+}
+
+// auditLogPath returns the path of the audit log of the profile, and whether
+// it's enabled. The audit_log setting is either a boolean, to record the
+// requests in the config folder, or a path.
+func auditLogPath(cfg *config.Config) (string, bool, error) {
+	defaultPath := filepath.Join(cfg.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")), "audit_log.ndjson")
+
+	setting := strings.TrimSpace(cfg.Profile.GetAuditLog())
+	if setting == "" {
+		return defaultPath, false, nil
+	}
+
+	if enabled, err := strconv.ParseBool(setting); err == nil {
+		return defaultPath, enabled, nil
+	}
+
+	path, err := homedir.Expand(setting)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid audit_log ‘%s’: %v", setting, err)
+	}
+
+	return path, true, nil
+}
+
+// auditLog returns the audit log the API requests of cmd are recorded to, or
+// nil if the audit log of the profile is disabled
+func auditLog(fs afero.Fs, cmd *cobra.Command, cfg *config.Config) (*stripe.AuditLog, error) {
+	path, enabled, err := auditLogPath(cfg)
+	if err != nil || !enabled {
+		return nil, err
+	}
+
+	return &stripe.AuditLog{
+		Fs:      config.StateFs(fs),
+		Path:    path,
+		Profile: cfg.Profile.ProfileName,
+		Command: strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" "),
+	}, nil
+}
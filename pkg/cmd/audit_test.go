@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAuditSince(t *testing.T) {
+	for value, expected := range map[string]time.Duration{
+		"1d":    24 * time.Hour,
+		"12h":   12 * time.Hour,
+		"2w":    14 * 24 * time.Hour,
+		"1d12h": 36 * time.Hour,
+		"30m":   30 * time.Minute,
+	} {
+		duration, err := parseAuditSince(value)
+		require.NoError(t, err, value)
+		require.Equal(t, expected, duration, value)
+	}
+
+	for _, value := range []string{"", "1", "1x", "0d", "d1"} {
+		_, err := parseAuditSince(value)
+		require.Error(t, err, value)
+	}
+}
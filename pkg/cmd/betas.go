@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+// betaPattern matches beta version headers like feature_beta=v1
+var betaPattern = regexp.MustCompile(`^[a-z0-9_]+=v\d+$`)
+
+type betasCmd struct {
+	cmd    *cobra.Command
+	config *config.Config
+}
+
+func newBetasCmd(cfg *config.Config) *betasCmd {
+	bc := &betasCmd{
+		config: cfg,
+	}
+
+	bc.cmd = &cobra.Command{
+		Use:   "betas",
+		Args:  validators.NoArgs,
+		Short: "Manage the API betas your requests opt into",
+		Long: `Manage the beta version headers of the current profile. The betas you enable
+are appended to the Stripe-Version header of the requests sent by the API
+commands, like ` + "`stripe get`" + ` or ` + "`stripe customers create`" + `, after the version of
+--api-version and the preview features of --preview-feature if they're set.
+
+Requests without --api-version use the api_version of the profile, set with
+` + "`stripe config --set api_version <version>`" + `, if it's set.`,
+	}
+
+	bc.cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Args:  validators.NoArgs,
+		Short: "List the betas enabled for the current profile",
+		RunE:  bc.runListCmd,
+	})
+
+	bc.cmd.AddCommand(&cobra.Command{
+		Use:     "enable <beta_header>",
+		Args:    validators.ExactArgs(1),
+		Short:   "Opt the requests of the current profile into a beta",
+		Example: `stripe betas enable feature_beta=v1`,
+		RunE:    bc.runEnableCmd,
+	})
+
+	bc.cmd.AddCommand(&cobra.Command{
+		Use:   "disable <beta>",
+		Args:  validators.ExactArgs(1),
+		Short: "Stop opting the requests of the current profile into a beta",
+		Example: `stripe betas disable feature_beta
+  stripe betas disable feature_beta=v1`,
+		RunE: bc.runDisableCmd,
+	})
+
+	return bc
+}
+
+func (bc *betasCmd) runListCmd(cmd *cobra.Command, args []string) error {
+	betas := bc.config.Profile.GetBetas()
+	if len(betas) == 0 {
+		fmt.Println("No betas are enabled, enable one with `stripe betas enable <beta_header>`")
+		return nil
+	}
+
+	for _, beta := range betas {
+		fmt.Println(beta)
+	}
+
+	return nil
+}
+
+func (bc *betasCmd) runEnableCmd(cmd *cobra.Command, args []string) error {
+	beta := args[0]
+	if !betaPattern.MatchString(beta) {
+		return fmt.Errorf("invalid beta header ‘%s’, expected a beta name and version like feature_beta=v1", beta)
+	}
+
+	name := betaName(beta)
+
+	betas := []string{}
+	for _, enabled := range bc.config.Profile.GetBetas() {
+		if enabled == beta {
+			fmt.Printf("The beta %s is already enabled\n", beta)
+			return nil
+		}
+
+		// Enabling another version of a beta replaces it
+		if betaName(enabled) != name {
+			betas = append(betas, enabled)
+		}
+	}
+
+	if err := bc.config.Profile.WriteBetas(append(betas, beta)); err != nil {
+		return err
+	}
+
+	color := ansi.Color(os.Stdout)
+	fmt.Printf("Enabled the beta %s, the API requests of this profile now send it with the Stripe-Version header\n", color.Bold(beta))
+
+	return nil
+}
+
+func (bc *betasCmd) runDisableCmd(cmd *cobra.Command, args []string) error {
+	betas := []string{}
+	disabled := ""
+	for _, enabled := range bc.config.Profile.GetBetas() {
+		if enabled == args[0] || betaName(enabled) == args[0] {
+			disabled = enabled
+			continue
+		}
+		betas = append(betas, enabled)
+	}
+
+	if disabled == "" {
+		return fmt.Errorf("the beta ‘%s’ isn't enabled, see the enabled betas with `stripe betas list`", args[0])
+	}
+
+	if err := bc.config.Profile.WriteBetas(betas); err != nil {
+		return err
+	}
+
+	fmt.Printf("Disabled the beta %s\n", disabled)
+
+	return nil
+}
+
+// betaName returns the name of a beta header, like feature_beta of
+// feature_beta=v1
+func betaName(beta string) string {
+	return strings.SplitN(beta, "=", 2)[0]
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/stripe/stripe-cli/pkg/browse"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type browseCmd struct {
+	cmd    *cobra.Command
+	config *config.Config
+
+	darkStyle  bool
+	apiBaseURL string
+}
+
+func newBrowseCmd(cfg *config.Config) *browseCmd {
+	bc := &browseCmd{
+		config: cfg,
+	}
+
+	bc.cmd = &cobra.Command{
+		Use:   "browse",
+		Args:  validators.NoArgs,
+		Short: "Browse the objects of your account in the terminal",
+		Long: `Browse the objects of your account in the terminal. Pick a resource to page
+through its objects, select one to view it, and jump to the objects it
+references, like its customer, or to the objects related to it, like the
+subscriptions of a customer.
+
+Objects are browsed in test mode. You can switch to live mode from the list of
+resources, once confirmed, if a live mode key is configured.`,
+		Example: `stripe browse
+  stripe browse --project-name my-project`,
+		RunE: bc.runBrowseCmd,
+	}
+
+	bc.cmd.Flags().BoolVar(&bc.darkStyle, "dark-style", false, "Use a darker color scheme better suited for lighter command-lines")
+
+	// Hidden configuration flags, useful for dev/debugging
+	bc.cmd.Flags().StringVar(&bc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	bc.cmd.Flags().MarkHidden("api-base") // #nosec G104
+
+	return bc
+}
+
+func (bc *browseCmd) runBrowseCmd(cmd *cobra.Command, args []string) error {
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return fmt.Errorf("stripe browse requires a terminal")
+	}
+
+	b := &browse.Browser{
+		APIBaseURL: bc.apiBaseURL,
+		Profile:    &bc.config.Profile,
+		DarkStyle:  bc.darkStyle,
+	}
+
+	key, err := bc.config.Profile.GetAPIKey(false)
+	if err != nil {
+		return err
+	}
+	if strings.Contains(key, "_live_") {
+		b.LiveModeKey = key
+	} else {
+		b.TestModeKey = key
+	}
+	if key, err := bc.config.Profile.GetAPIKey(true); err == nil && strings.Contains(key, "_live_") {
+		b.LiveModeKey = key
+	}
+
+	return b.Run(cmd.Context())
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/chaos"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type chaosCmd struct {
+	cmd *cobra.Command
+
+	target       string
+	port         int
+	drop         string
+	duplicate    string
+	reorder      string
+	delay        time.Duration
+	reorderDelay time.Duration
+}
+
+func newChaosCmd() *chaosCmd {
+	cc := &chaosCmd{}
+
+	cc.cmd = &cobra.Command{
+		Use:   "chaos",
+		Args:  validators.NoArgs,
+		Short: "Inject faults into the webhook events forwarded to your local endpoint",
+		Long: `Start a local proxy between stripe listen and your webhook endpoint, which drops,
+duplicates, delays and reorders the events forwarded through it, at the rates
+you set. Stripe may deliver an event more than once, late or out of order, and
+your webhook handlers must handle it: the chaos proxy makes these cases
+happen locally so you can verify your handlers are idempotent and retry-safe.
+
+Dropped events are answered with a 503 and never reach your endpoint.
+Duplicates are sent shortly after the original event, and reordered events are
+held back so that the events received after them are forwarded first.`,
+		Example: `stripe chaos --target localhost:4242 --drop 5% --delay 300ms --duplicate 2%
+  stripe listen --forward-to localhost:4243/webhooks`,
+		RunE: cc.runChaosCmd,
+	}
+
+	cc.cmd.Flags().StringVar(&cc.target, "target", "", "The URL of your webhook endpoint, e.g. localhost:4242")
+	cc.cmd.Flags().IntVar(&cc.port, "port", 4243, "The port the chaos proxy listens to, which stripe listen forwards to")
+	cc.cmd.Flags().StringVar(&cc.drop, "drop", "0%", "Percentage of the events dropped")
+	cc.cmd.Flags().StringVar(&cc.duplicate, "duplicate", "0%", "Percentage of the events sent twice")
+	cc.cmd.Flags().StringVar(&cc.reorder, "reorder", "0%", "Percentage of the events held back, so that later events arrive first")
+	cc.cmd.Flags().DurationVar(&cc.delay, "delay", 0, "Latency added to every event, e.g. 300ms")
+	cc.cmd.Flags().DurationVar(&cc.reorderDelay, "reorder-delay", chaos.DefaultReorderDelay, "How long the events picked by --reorder are held back")
+
+	cc.cmd.MarkFlagRequired("target") // #nosec G104
+
+	return cc
+}
+
+func (cc *chaosCmd) runChaosCmd(cmd *cobra.Command, args []string) error {
+	rates := make(map[string]float64)
+	for flag, value := range map[string]string{"drop": cc.drop, "duplicate": cc.duplicate, "reorder": cc.reorder} {
+		rate, err := chaos.ParseRate(value)
+		if err != nil {
+			return fmt.Errorf("--%s: %v", flag, err)
+		}
+		rates[flag] = rate
+	}
+
+	if cc.delay < 0 || cc.reorderDelay < 0 {
+		return fmt.Errorf("--delay and --reorder-delay must be positive durations")
+	}
+
+	proxy, err := chaos.New(&chaos.Config{
+		Target:       cc.target,
+		Drop:         rates["drop"],
+		Duplicate:    rates["duplicate"],
+		Reorder:      rates["reorder"],
+		Delay:        cc.delay,
+		ReorderDelay: cc.reorderDelay,
+		Out:          os.Stdout,
+	})
+	if err != nil {
+		return err
+	}
+
+	ln, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", cc.port))
+	if err != nil {
+		return err
+	}
+
+	srv := &http.Server{Handler: proxy}
+
+	ctx := withSIGTERMCancel(cmd.Context(), func() {
+		log.WithFields(log.Fields{
+			"prefix": "cmd.chaosCmd.runChaosCmd",
+		}).Debug("Ctrl+C received, cleaning up...")
+	})
+
+	go func() {
+		<-ctx.Done()
+		srv.Close() // #nosec G104
+	}()
+
+	color := ansi.Color(os.Stdout)
+	fmt.Printf("Forwarding the events received on %s to %s %s\n",
+		color.Bold(fmt.Sprintf("http://%s", ln.Addr())),
+		color.Bold(proxy.Target()),
+		ansi.Faint(fmt.Sprintf("(drop %s, duplicate %s, reorder %s, delay %s)", cc.drop, cc.duplicate, cc.reorder, cc.delay)),
+	)
+	fmt.Printf("Run %s to send events through it (^C to quit)\n", color.Bold(fmt.Sprintf("stripe listen --forward-to localhost:%d", cc.port)))
+
+	err = srv.Serve(ln)
+	if err != nil && err != http.ErrServerClosed {
+		return err
+	}
+
+	proxy.Wait()
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/checklist"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type checklistCmd struct {
+	cmd    *cobra.Command
+	config *config.Config
+
+	reset      bool
+	apiBaseURL string
+}
+
+func newChecklistCmd(cfg *config.Config) *checklistCmd {
+	cc := &checklistCmd{
+		config: cfg,
+	}
+
+	cc.cmd = &cobra.Command{
+		Use:   "checklist",
+		Args:  validators.NoArgs,
+		Short: "Walk through the launch checklist of a Stripe product",
+		Long: `Walk through the launch checklist of a Stripe product. The items that can be
+checked on your account, like your API keys and webhook endpoints, are checked
+automatically, and you're asked to confirm the other ones, which are
+remembered for the profile.`,
+	}
+
+	for _, product := range checklist.Products() {
+		c := checklist.Checklists[product]
+		productCmd := &cobra.Command{
+			Use:   product,
+			Args:  validators.NoArgs,
+			Short: fmt.Sprintf("Walk through the %s", strings.ToLower(c.Title)),
+			Example: fmt.Sprintf(`stripe checklist %s
+  stripe checklist %s --reset`, product, product),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return cc.runChecklist(cmd, c)
+			},
+		}
+		productCmd.Flags().BoolVar(&cc.reset, "reset", false, "Forget the items that were confirmed, and ask for them again")
+
+		cc.cmd.AddCommand(productCmd)
+	}
+
+	// Hidden configuration flags, useful for dev/debugging
+	cc.cmd.PersistentFlags().StringVar(&cc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	cc.cmd.PersistentFlags().MarkHidden("api-base") // #nosec G104
+
+	return cc
+}
+
+func (cc *checklistCmd) runChecklist(cmd *cobra.Command, c *checklist.Checklist) error {
+	profile := &cc.config.Profile
+	field := "checklist_" + c.Product
+
+	confirmed := make(map[string]bool)
+	if !cc.reset {
+		if err := viper.ReadInConfig(); err == nil {
+			for _, name := range strings.Split(viper.GetString(profile.GetConfigField(field)), ",") {
+				if name != "" {
+					confirmed[name] = true
+				}
+			}
+		}
+	}
+
+	env := checklist.Env{
+		APIBaseURL: cc.apiBaseURL,
+		Profile:    profile,
+		Confirmed:  confirmed,
+	}
+	if key, err := profile.GetAPIKey(false); err == nil {
+		env.TestModeKey = key
+	}
+	if key, err := profile.GetAPIKey(true); err == nil && strings.Contains(key, "_live_") {
+		env.LiveModeKey = key
+	}
+
+	spinner := ansi.StartNewSpinner("Checking your account...", os.Stdout)
+	results := c.Run(cmd.Context(), env)
+	ansi.StopSpinner(spinner, "", os.Stdout)
+
+	var in *bufio.Reader
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		in = bufio.NewReader(os.Stdin)
+	}
+
+	fmt.Printf("%s\n\n", c.Title)
+
+	left := printChecklist(os.Stdout, in, results, confirmed)
+
+	if in != nil || cc.reset {
+		names := make([]string, 0, len(confirmed))
+		for name := range confirmed {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		if err := profile.WriteConfigField(field, strings.Join(names, ",")); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("\n%d of %d items complete\n", len(results)-left, len(results))
+
+	if left > 0 {
+		return fmt.Errorf("%d item(s) left", left)
+	}
+
+	return nil
+}
+
+// printChecklist prints the results of a checklist, asking to confirm the
+// Manual items if in isn't nil, and returns the number of items that aren't
+// complete. The items that are confirmed are added to confirmed.
+func printChecklist(out io.Writer, in *bufio.Reader, results []checklist.Result, confirmed map[string]bool) int {
+	color := ansi.Color(out)
+
+	left := 0
+	for _, result := range results {
+		item := result.Item
+
+		switch result.Status {
+		case checklist.Complete:
+			fmt.Fprintf(out, "%s %s: %s\n", color.Green("✔"), item.Title, result.Detail)
+			continue
+		case checklist.Incomplete:
+			fmt.Fprintf(out, "%s %s: %s\n", color.Red("✘"), item.Title, result.Detail)
+		case checklist.Unknown:
+			fmt.Fprintf(out, "%s %s: could not check: %s\n", color.Yellow("!"), item.Title, result.Detail)
+		case checklist.Manual:
+			fmt.Fprintf(out, "%s %s\n", color.Yellow("?"), item.Title)
+		}
+		fmt.Fprintf(out, "    %s\n", item.Hint)
+
+		if result.Status == checklist.Manual && in != nil {
+			fmt.Fprint(out, "    Done? [y/N] ")
+			answer, _ := in.ReadString('\n')
+			if strings.EqualFold(strings.TrimSpace(answer), "y") || strings.EqualFold(strings.TrimSpace(answer), "yes") {
+				confirmed[item.Name] = true
+				continue
+			}
+		}
+
+		left++
+	}
+
+	return left
+}
@@ -9,6 +9,7 @@ import (
 
 	"runtime"
 
+	"github.com/stripe/stripe-cli/pkg/cmd/resource"
 	"github.com/stripe/stripe-cli/pkg/validators"
 )
 
@@ -118,6 +119,9 @@ func genZsh(writeToStdout bool) error {
 }
 
 func genBash(writeToStdout bool) error {
+	// Bash completion scripts list every command
+	resource.LoadOperations(rootCmd)
+
 	if writeToStdout {
 		return rootCmd.GenBashCompletion(os.Stdout)
 	}
@@ -4,6 +4,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/validators"
 )
 
 type configCmd struct {
@@ -24,29 +25,66 @@ func newConfigCmd() *configCmd {
 		Use:   "config",
 		Short: "Manually change the config values for the CLI",
 		Long: `config lets you set and unset specific configuration values for your profile if
-you need more granular control over the configuration.`,
+you need more granular control over the configuration.
+
+Setting key_storage to keyring moves the API keys of your profile to the
+keychain of your OS (macOS Keychain, Windows Credential Manager, or libsecret
+on Linux), with the config file only holding references to them. Setting it
+to encrypted encrypts them in the config file with a passphrase, prompted once
+per command or read from STRIPE_CONFIG_PASSPHRASE, or from the key file at
+STRIPE_CONFIG_PASSPHRASE_FILE. Setting it back to file, or unsetting it, moves
+them back to the config file in plain text.
+
+Fields under defaults set the default values of the flags of commands, which
+flags on the command line override: defaults.<command>.<flag> for a command
+and its subcommands, or defaults.<flag> for every command with that flag.`,
 		Example: `stripe config --list
   stripe config --set color off
-  stripe config --unset color`,
+  stripe config --unset color
+  stripe config --set key_storage keyring
+  stripe config --set key_storage encrypted
+  stripe config set defaults.listen.forward-to http://localhost:4242/webhook
+  stripe config set defaults.logs.tail.format JSON
+  stripe config report`,
 		RunE: cc.runConfigCmd,
 	}
 
-	cc.cmd.Flags().BoolVar(&cc.list, "list", false, "List configs")
+	cc.cmd.Flags().BoolVar(&cc.list, "list", false, "List configs and the scopes of the API keys")
 	cc.cmd.Flags().BoolVarP(&cc.edit, "edit", "e", false, "Open an editor to the config file")
 	cc.cmd.Flags().StringVar(&cc.unset, "unset", "", "Unset a specific config field")
 	cc.cmd.Flags().BoolVar(&cc.set, "set", false, "Set a config field to some value")
 
 	cc.cmd.Flags().SetInterspersed(false) // allow args to happen after flags to enable 2 arguments to --set
 
+	cc.cmd.AddCommand(newConfigExportCmd(cc.config).cmd)
+	cc.cmd.AddCommand(newConfigImportCmd(cc.config).cmd)
+	cc.cmd.AddCommand(newConfigReportCmd(cc.config).cmd)
+	cc.cmd.AddCommand(&cobra.Command{
+		Use:   "set <field> <value>",
+		Args:  validators.ExactArgs(2),
+		Short: "Set a config field to some value, like --set",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cc.setField(args[0], args[1])
+		},
+	})
+	cc.cmd.AddCommand(&cobra.Command{
+		Use:   "unset <field>",
+		Args:  validators.ExactArgs(1),
+		Short: "Unset a config field, like --unset",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cc.unsetField(args[0])
+		},
+	})
+
 	return cc
 }
 
 func (cc *configCmd) runConfigCmd(cmd *cobra.Command, args []string) error {
 	switch ok := true; ok {
 	case cc.set && len(args) == 2:
-		return cc.config.Profile.WriteConfigField(args[0], args[1])
+		return cc.setField(args[0], args[1])
 	case cc.unset != "":
-		return cc.config.Profile.DeleteConfigField(cc.unset)
+		return cc.unsetField(cc.unset)
 	case cc.list:
 		return cc.config.PrintConfig()
 	case cc.edit:
@@ -56,3 +94,29 @@ func (cc *configCmd) runConfigCmd(cmd *cobra.Command, args []string) error {
 		return cc.cmd.Help()
 	}
 }
+
+func (cc *configCmd) setField(field, value string) error {
+	if field == "key_storage" {
+		return cc.config.Profile.SetKeyStorage(value)
+	}
+
+	if field == config.ColorThemeField {
+		return cc.config.Profile.SetColorTheme(value)
+	}
+
+	return cc.config.Profile.WriteConfigField(field, value)
+}
+
+func (cc *configCmd) unsetField(field string) error {
+	if field == config.ColorThemeField {
+		return cc.config.Profile.UnsetColorTheme()
+	}
+
+	if field == "key_storage" {
+		if err := cc.config.Profile.SetKeyStorage(config.KeyStorageFile); err != nil {
+			return err
+		}
+	}
+
+	return cc.config.Profile.DeleteConfigField(field)
+}
@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/spf13/cobra"
+	exec "golang.org/x/sys/execabs"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+const (
+	pgpArmorHeader = "-----BEGIN PGP MESSAGE-----"
+	ageArmorHeader = "-----BEGIN AGE ENCRYPTED FILE-----"
+)
+
+type configExportCmd struct {
+	cmd    *cobra.Command
+	config *config.Config
+
+	all            bool
+	includeSecrets bool
+	output         string
+	gpgRecipients  []string
+	ageRecipients  []string
+}
+
+func newConfigExportCmd(cfg *config.Config) *configExportCmd {
+	cec := &configExportCmd{
+		config: cfg,
+	}
+
+	cec.cmd = &cobra.Command{
+		Use:   "export",
+		Args:  validators.NoArgs,
+		Short: "Export profiles to provision them on another machine",
+		Long: `Export the current profile, or every profile with --all, to a single document
+that ` + "`stripe config import`" + ` provisions on another machine, like a CI job.
+
+API keys are only exported with --include-secrets. Encrypt the export for the
+machine that imports it with --gpg-recipient or --age-recipient, which run gpg
+or age.`,
+		Example: `stripe config export > profile.toml
+  stripe config export --all --include-secrets --age-recipient age1... -o profiles.age
+  stripe config export --include-secrets --gpg-recipient ci@example.com -o profile.asc`,
+		RunE: cec.runConfigExportCmd,
+	}
+
+	cec.cmd.Flags().BoolVar(&cec.all, "all", false, "Export every profile rather than the current one")
+	cec.cmd.Flags().BoolVar(&cec.includeSecrets, "include-secrets", false, "Include the API keys of the profiles")
+	cec.cmd.Flags().StringVarP(&cec.output, "output", "o", "", "Write the export to a file rather than stdout")
+	cec.cmd.Flags().StringArrayVar(&cec.gpgRecipients, "gpg-recipient", []string{}, "Encrypt the export with gpg for a recipient (repeatable)")
+	cec.cmd.Flags().StringArrayVar(&cec.ageRecipients, "age-recipient", []string{}, "Encrypt the export with age for a recipient (repeatable)")
+
+	return cec
+}
+
+func (cec *configExportCmd) runConfigExportCmd(cmd *cobra.Command, args []string) error {
+	if len(cec.gpgRecipients) > 0 && len(cec.ageRecipients) > 0 {
+		return errors.New("--gpg-recipient and --age-recipient cannot be used together")
+	}
+
+	names := []string{cec.config.Profile.ProfileName}
+	if cec.all {
+		names = cec.config.ProfileNames()
+	}
+
+	export, err := cec.config.ExportProfiles(names, cec.includeSecrets)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(export); err != nil {
+		return err
+	}
+
+	data := buf.Bytes()
+	switch {
+	case len(cec.gpgRecipients) > 0:
+		args := []string{"--batch", "--yes", "--armor", "--encrypt"}
+		for _, recipient := range cec.gpgRecipients {
+			args = append(args, "--recipient", recipient)
+		}
+		data, err = runCrypto(data, "gpg", args...)
+	case len(cec.ageRecipients) > 0:
+		args := []string{"--encrypt", "--armor"}
+		for _, recipient := range cec.ageRecipients {
+			args = append(args, "--recipient", recipient)
+		}
+		data, err = runCrypto(data, "age", args...)
+	case cec.includeSecrets:
+		fmt.Fprintln(os.Stderr, "Warning: the export includes API keys in plain text, keep it secret or encrypt it with --gpg-recipient or --age-recipient")
+	}
+	if err != nil {
+		return err
+	}
+
+	if cec.output == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+
+	return ioutil.WriteFile(cec.output, data, 0600)
+}
+
+type configImportCmd struct {
+	cmd    *cobra.Command
+	config *config.Config
+
+	force       bool
+	ageIdentity string
+}
+
+func newConfigImportCmd(cfg *config.Config) *configImportCmd {
+	cic := &configImportCmd{
+		config: cfg,
+	}
+
+	cic.cmd = &cobra.Command{
+		Use:   "import [file]",
+		Args:  validators.MaximumNArgs(1),
+		Short: "Import profiles exported with `stripe config export`",
+		Long: `Import the profiles of a document exported with ` + "`stripe config export`" + `, read from
+a file or stdin. Exports encrypted with gpg or age are decrypted by running gpg,
+or age with the identity of --age-identity.
+
+Existing profiles are only replaced with --force. Imported API keys are stored
+like the keys written by ` + "`stripe login`" + `, following the key_storage setting.`,
+		Example: `stripe config import profile.toml
+  stripe config import --age-identity key.txt profiles.age
+  echo "$STRIPE_PROFILE" | stripe config import --force`,
+		RunE: cic.runConfigImportCmd,
+	}
+
+	cic.cmd.Flags().BoolVar(&cic.force, "force", false, "Replace the profiles that already exist")
+	cic.cmd.Flags().StringVar(&cic.ageIdentity, "age-identity", "", "Identity file to decrypt an export encrypted with age")
+
+	return cic
+}
+
+func (cic *configImportCmd) runConfigImportCmd(cmd *cobra.Command, args []string) error {
+	var input io.Reader = os.Stdin
+	if len(args) > 0 && args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		input = f
+	}
+
+	data, err := ioutil.ReadAll(input)
+	if err != nil {
+		return err
+	}
+
+	switch trimmed := strings.TrimSpace(string(data)); {
+	case strings.HasPrefix(trimmed, pgpArmorHeader):
+		data, err = runCrypto(data, "gpg", "--batch", "--quiet", "--decrypt")
+	case strings.HasPrefix(trimmed, ageArmorHeader):
+		if cic.ageIdentity == "" {
+			return errors.New("the export is encrypted with age, set its identity with --age-identity")
+		}
+		data, err = runCrypto(data, "age", "--decrypt", "--identity", cic.ageIdentity)
+	}
+	if err != nil {
+		return err
+	}
+
+	var export config.Export
+	if _, err := toml.Decode(string(data), &export); err != nil {
+		return fmt.Errorf("could not read the export: %w", err)
+	}
+
+	if err := cic.config.ImportProfiles(&export, cic.force); err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported %d profile(s)\n", len(export.Profiles))
+
+	return nil
+}
+
+// runCrypto pipes data through gpg or age
+func runCrypto(data []byte, name string, args ...string) ([]byte, error) {
+	if _, err := exec.LookPath(name); err != nil {
+		return nil, fmt.Errorf("%s is not installed", name)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s failed: %s", name, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type configReportCmd struct {
+	cmd    *cobra.Command
+	config *config.Config
+
+	days int
+}
+
+func newConfigReportCmd(cfg *config.Config) *configReportCmd {
+	crc := &configReportCmd{
+		config: cfg,
+	}
+
+	crc.cmd = &cobra.Command{
+		Use:   "report",
+		Args:  validators.NoArgs,
+		Short: "Report which profiles and keys were used recently",
+		Long: `Summarize which profiles and API keys were used by which commands in the last
+days, from the usage history the CLI keeps in its config folder. Profiles and
+keys that weren't used are listed so they can be cleaned up.`,
+		Example: `stripe config report
+  stripe config report --days 90`,
+		RunE: crc.runConfigReportCmd,
+	}
+
+	crc.cmd.Flags().IntVar(&crc.days, "days", 30, "Number of days to report on")
+
+	return crc
+}
+
+func (crc *configReportCmd) runConfigReportCmd(cmd *cobra.Command, args []string) error {
+	if crc.days < 1 {
+		return fmt.Errorf("--days must be at least 1")
+	}
+
+	since := time.Now().AddDate(0, 0, -crc.days)
+
+	entries, err := usageLog(fs, crc.config).Since(since)
+	if err != nil {
+		return err
+	}
+
+	printUsageReport(os.Stdout, crc.days, config.SummarizeUsage(entries), crc.configuredProfiles())
+
+	return nil
+}
+
+// configuredProfiles returns the redacted keys of each profile of the config
+// file, by field
+func (crc *configReportCmd) configuredProfiles() map[string]map[string]string {
+	profiles := make(map[string]map[string]string)
+
+	for _, name := range crc.config.ProfileNames() {
+		profile := config.Profile{ProfileName: name}
+
+		keys := make(map[string]string)
+		for field, key := range profile.GetConfiguredKeys() {
+			keys[field] = config.RedactKey(key)
+		}
+		profiles[name] = keys
+	}
+
+	return profiles
+}
+
+// printUsageReport prints the usage of each profile, then the profiles and
+// keys that weren't used.
+func printUsageReport(out io.Writer, days int, usage map[string]*config.ProfileUsage, profiles map[string]map[string]string) {
+	color := ansi.Color(out)
+
+	names := make([]string, 0, len(usage))
+	for name := range usage {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(out, "%s\n\n", color.Bold(fmt.Sprintf("Usage in the last %d days", days)))
+
+	if len(names) == 0 {
+		fmt.Fprintln(out, "No commands were recorded.")
+	} else {
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "PROFILE\tRUNS\tLAST USED\tKEYS\tTOP COMMANDS")
+		for _, name := range names {
+			u := usage[name]
+
+			keys := make([]string, 0, len(u.Keys))
+			for key, runs := range u.Keys {
+				keys = append(keys, fmt.Sprintf("%s (%d)", key, runs))
+			}
+			sort.Strings(keys)
+
+			commands := u.TopCommands(3)
+			for i, command := range commands {
+				commands[i] = fmt.Sprintf("%s (%d)", command, u.Commands[command])
+			}
+
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", name, u.Runs, u.LastUsed.Local().Format("2006-01-02 15:04"), strings.Join(keys, ", "), strings.Join(commands, ", "))
+		}
+		w.Flush()
+	}
+
+	var staleProfiles, staleKeys []string
+
+	configured := make([]string, 0, len(profiles))
+	for name := range profiles {
+		configured = append(configured, name)
+	}
+	sort.Strings(configured)
+
+	for _, name := range configured {
+		u, used := usage[name]
+		if !used {
+			staleProfiles = append(staleProfiles, name)
+			continue
+		}
+
+		fields := make([]string, 0, len(profiles[name]))
+		for field := range profiles[name] {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		seen := make(map[string]bool)
+		for _, field := range fields {
+			key := profiles[name][field]
+			if u.Keys[key] == 0 && !seen[key] {
+				staleKeys = append(staleKeys, fmt.Sprintf("%s %s (%s)", name, field, key))
+			}
+			seen[key] = true
+		}
+	}
+
+	if len(staleProfiles) > 0 {
+		fmt.Fprintf(out, "\n%s\n", color.Bold("Profiles not used"))
+		for _, name := range staleProfiles {
+			fmt.Fprintf(out, "  %s  %s\n", name, color.Faint(fmt.Sprintf("remove with `stripe logout --project-name %s`", name)))
+		}
+	}
+
+	if len(staleKeys) > 0 {
+		fmt.Fprintf(out, "\n%s\n", color.Bold("Keys not used"))
+		for _, key := range staleKeys {
+			fmt.Fprintf(out, "  %s\n", key)
+		}
+	}
+}
+
+// recordUsage adds the command about to run to the usage history. Failing to
+// record it doesn't stop the command.
+func recordUsage(cmd *cobra.Command, cfg *config.Config) {
+	if strings.HasPrefix(cmd.Name(), "__") || cmd.Name() == "completion" {
+		return
+	}
+
+	entry := config.UsageEntry{
+		Profile: cfg.Profile.ProfileName,
+		Command: strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" "),
+	}
+
+	live := false
+	if flag := cmd.Flags().Lookup("live"); flag != nil {
+		live = flag.Value.String() == "true"
+	}
+
+	if key, err := cfg.Profile.GetAPIKey(live); err == nil {
+		entry.Key = config.RedactKey(key)
+
+		switch {
+		case os.Getenv("STRIPE_API_KEY") != "":
+			entry.KeySource = "env"
+		case cfg.Profile.APIKey != "":
+			entry.KeySource = "flag"
+		default:
+			entry.KeySource = "profile"
+		}
+	}
+
+	usageLog(fs, cfg).Record(entry) // #nosec G104
+}
+
+// usageLog is the usage history of the profiles, kept in the config folder
+func usageLog(fs afero.Fs, cfg *config.Config) *config.UsageLog {
+	return &config.UsageLog{
+		Fs:   config.StateFs(fs),
+		Path: filepath.Join(cfg.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")), "usage_history.ndjson"),
+	}
+}
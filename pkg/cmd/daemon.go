@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"time"
+
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
@@ -11,9 +13,18 @@ import (
 )
 
 type daemonCmd struct {
-	cmd  *cobra.Command
-	port int
-	cfg  *config.Config
+	cmd           *cobra.Command
+	port          int
+	pipe          string
+	tlsCert       string
+	tlsKey        string
+	tlsSelfSigned bool
+	httpGateway   bool
+	httpPort      int
+	maxClients    int
+	maxStreams    int
+	timeouts      map[string]string
+	cfg           *config.Config
 }
 
 func newDaemonCmd(cfg *config.Config) *daemonCmd {
@@ -28,21 +39,80 @@ func newDaemonCmd(cfg *config.Config) *daemonCmd {
 		Long: `Start a local gRPC server, enabling you to invoke Stripe CLI commands programmatically from a gRPC
 client.
 
+The server is plaintext on localhost, unless it's started with --tls-cert and --tls-key, or with
+--tls-self-signed whose certificate is printed with the config of the server for clients to trust.
+
+With --http-gateway, the methods of the rpc.v1 service can also be called with a POST of their
+request as JSON to /v1/<method> on the port printed as gateway_port, with the same
+sec-x-stripe-cli header. Streaming methods respond with one JSON message per line.
+
+On SIGTERM or Ctrl+C, the daemon stops accepting calls and waits for the running ones to finish.
+Running streams end with an UNAVAILABLE status, after a final DONE state for Listen and LogsTail.
+
 Currently, stripe daemon only supports a subset of CLI commands. Documentation is not yet available.`,
 		Run:    dc.runDaemonCmd,
 		Hidden: true,
 	}
 	dc.cmd.Flags().IntVar(&dc.port, "port", 0, "The TCP port the daemon will listen to (default: an available port)")
+	dc.cmd.Flags().StringVar(&dc.pipe, "pipe", "", `Windows only: the named pipe the daemon will listen to instead of a TCP port, e.g. \\.\pipe\stripe-cli.
+Only the current user can connect to it`)
+	dc.cmd.Flags().BoolVar(&dc.httpGateway, "http-gateway", false, "Also serve an HTTP/JSON gateway of the gRPC server, for clients without a gRPC stack")
+	dc.cmd.Flags().IntVar(&dc.httpPort, "http-port", 0, "The TCP port of --http-gateway (default: an available port)")
+	dc.cmd.Flags().StringVar(&dc.tlsCert, "tls-cert", "", "PEM file of the certificate to serve TLS with, instead of plaintext")
+	dc.cmd.Flags().StringVar(&dc.tlsKey, "tls-key", "", "PEM file of the private key of --tls-cert")
+	dc.cmd.Flags().BoolVar(&dc.tlsSelfSigned, "tls-self-signed", false, "Serve TLS with a self-signed certificate generated on startup, printed for clients to trust")
+	dc.cmd.Flags().IntVar(&dc.maxClients, "max-clients", 0, "The number of clients that can be connected at once (default: no limit)")
+	dc.cmd.Flags().IntVar(&dc.maxStreams, "max-streams", 0, "The number of streams, like Listen, that can run at once (default: no limit)")
+	dc.cmd.Flags().StringToStringVar(&dc.timeouts, "method-timeout", nil, `The timeout of a method instead of the default one, like LoginStatus=5m, or 0 for none.
+Unary methods time out after 1m by default, and streaming methods don't`)
 
 	return dc
 }
 
 func (dc *daemonCmd) runDaemonCmd(cmd *cobra.Command, args []string) {
+	if dc.pipe != "" && dc.port != 0 {
+		log.Fatal("--pipe cannot be used with --port")
+	}
+
+	if (dc.tlsCert == "") != (dc.tlsKey == "") {
+		log.Fatal("--tls-cert and --tls-key must be used together")
+	}
+
+	if dc.httpPort != 0 && !dc.httpGateway {
+		log.Fatal("--http-port can only be used with --http-gateway")
+	}
+
+	if dc.tlsSelfSigned && dc.tlsCert != "" {
+		log.Fatal("--tls-self-signed cannot be used with --tls-cert")
+	}
+
+	if dc.maxClients < 0 || dc.maxStreams < 0 {
+		log.Fatal("--max-clients and --max-streams cannot be negative")
+	}
+
+	timeouts := make(map[string]time.Duration, len(dc.timeouts))
+	for method, value := range dc.timeouts {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			log.Fatalf("Invalid --method-timeout for %s: %v", method, err)
+		}
+		timeouts[method] = timeout
+	}
+
 	telemetryClient := stripe.GetTelemetryClient(cmd.Context())
 	srv := rpcservice.New(&rpcservice.Config{
-		Port:    dc.port,
-		Log:     log.StandardLogger(),
-		UserCfg: dc.cfg,
+		Port:           dc.port,
+		Pipe:           dc.pipe,
+		TLSCert:        dc.tlsCert,
+		TLSKey:         dc.tlsKey,
+		TLSSelfSigned:  dc.tlsSelfSigned,
+		Gateway:        dc.httpGateway,
+		GatewayPort:    dc.httpPort,
+		MaxClients:     dc.maxClients,
+		MaxStreams:     dc.maxStreams,
+		MethodTimeouts: timeouts,
+		Log:            log.StandardLogger(),
+		UserCfg:        dc.cfg,
 	}, telemetryClient)
 
 	ctx := withSIGTERMCancel(cmd.Context(), func() {
@@ -51,7 +121,5 @@ func (dc *daemonCmd) runDaemonCmd(cmd *cobra.Command, args []string) {
 		}).Debug("Ctrl+C received, cleaning up...")
 	})
 
-	go srv.Run(ctx)
-
-	<-ctx.Done()
+	srv.Run(ctx)
 }
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/open"
+	"github.com/stripe/stripe-cli/pkg/rpcservice"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+	"github.com/stripe/stripe-cli/rpc"
+)
+
+type dashboardCmd struct {
+	cmd *cobra.Command
+	cfg *config.Config
+
+	local             bool
+	port              int
+	forwardURL        string
+	forwardConnectURL string
+	events            []string
+	latestAPIVersion  bool
+	skipVerify        bool
+	noOpen            bool
+}
+
+func newDashboardCmd(cfg *config.Config) *dashboardCmd {
+	dc := &dashboardCmd{
+		cfg: cfg,
+	}
+
+	dc.cmd = &cobra.Command{
+		Use:   "dashboard",
+		Args:  validators.NoArgs,
+		Short: "Serve a local web UI of the events you receive and your request logs",
+		Long: `Serve a local web UI showing the events received like with stripe listen, and the
+request logs of your account like with stripe logs tail, live. Search them, inspect
+the payloads and deliveries of events, and resend events to your local endpoints
+in one click.
+
+The UI is served by a local stripe daemon, and calls its HTTP/JSON gateway. Use
+stripe open dashboard to open the Stripe Dashboard instead.`,
+		Example: `stripe dashboard --local
+  stripe dashboard --local --forward-to localhost:4242/webhooks --events payment_intent.succeeded`,
+		RunE: dc.runDashboardCmd,
+	}
+
+	dc.cmd.Flags().BoolVar(&dc.local, "local", false, "Serve the local web UI")
+	dc.cmd.Flags().IntVar(&dc.port, "port", 0, "The port to serve the UI on (default: an available port)")
+	dc.cmd.Flags().StringVarP(&dc.forwardURL, "forward-to", "f", "", "The URL to forward webhook events to")
+	dc.cmd.Flags().StringVarP(&dc.forwardConnectURL, "forward-connect-to", "c", "", "The URL to forward Connect webhook events to (default: same as normal events)")
+	dc.cmd.Flags().StringSliceVarP(&dc.events, "events", "e", []string{}, "A comma-separated list of specific events to listen for (default: all events)")
+	dc.cmd.Flags().BoolVarP(&dc.latestAPIVersion, "latest", "l", false, "Receive events formatted with the latest API version (default: your account's default API version)")
+	dc.cmd.Flags().BoolVar(&dc.skipVerify, "skip-verify", false, "Skip certificate verification when forwarding to HTTPS endpoints")
+	dc.cmd.Flags().BoolVar(&dc.noOpen, "no-open", false, "Don't open the UI in the browser")
+
+	return dc
+}
+
+func (dc *dashboardCmd) runDashboardCmd(cmd *cobra.Command, args []string) error {
+	if !dc.local {
+		return errors.New("only the local web UI is supported, run stripe dashboard --local, or stripe open dashboard to open the Stripe Dashboard")
+	}
+
+	if dc.port < 0 {
+		return fmt.Errorf("invalid port %d", dc.port)
+	}
+
+	srv := rpcservice.New(&rpcservice.Config{
+		Dashboard:   true,
+		GatewayPort: dc.port,
+		DashboardListen: &rpc.ListenRequest{
+			ForwardTo:        dc.forwardURL,
+			ForwardConnectTo: dc.forwardConnectURL,
+			Events:           dc.events,
+			Latest:           dc.latestAPIVersion,
+			SkipVerify:       dc.skipVerify,
+		},
+		Ready: func(output rpcservice.ConfigOutput) {
+			url := fmt.Sprintf("http://localhost:%d/", output.GatewayPort)
+
+			fmt.Printf("Serving the dashboard at %s (^C to quit)\n", ansi.Bold(url))
+
+			if !dc.noOpen && open.CanOpenBrowser() {
+				if err := open.Browser(url); err != nil {
+					fmt.Printf("Failed to open the browser, visit %s\n", url)
+				}
+			}
+		},
+		Log:     log.StandardLogger(),
+		UserCfg: dc.cfg,
+	}, stripe.GetTelemetryClient(cmd.Context()))
+
+	ctx := withSIGTERMCancel(cmd.Context(), func() {
+		log.WithFields(log.Fields{
+			"prefix": "cmd.dashboardCmd.runDashboardCmd",
+		}).Debug("Ctrl+C received, cleaning up...")
+	})
+
+	srv.Run(ctx)
+
+	return nil
+}
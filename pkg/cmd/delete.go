@@ -18,6 +18,7 @@ func newDeleteCmd() *deleteCmd {
 
 	gc.reqs.Method = http.MethodDelete
 	gc.reqs.Profile = &Config.Profile
+	gc.reqs.OnResponse = recordResponse
 	gc.reqs.Cmd = &cobra.Command{
 		Use:   "delete <path>",
 		Args:  validators.ExactArgs(1),
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/docs"
+	"github.com/stripe/stripe-cli/pkg/fixtures"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+const eventTypesDocsURL = "https://stripe.com/docs/api/events/types"
+
+type docsCmd struct {
+	cmd *cobra.Command
+}
+
+func newDocsCmd() *docsCmd {
+	dc := &docsCmd{}
+
+	dc.cmd = &cobra.Command{
+		Use:   "docs",
+		Args:  validators.NoArgs,
+		Short: "Read the reference documentation of events and resources",
+		Long: `Read the reference documentation of event types and API resources in the
+terminal. The documentation is bundled with the CLI, and doesn't need network
+access.`,
+		Example: `stripe docs event payment_intent.succeeded
+  stripe docs resource subscription`,
+	}
+
+	eventCmd := &cobra.Command{
+		Use:   "event [type]",
+		Args:  validators.MaximumNArgs(1),
+		Short: "Show when an event is sent and the fields of its object",
+		Long: `Show when an event type is sent and the fields of its data.object. Without
+an event type, list the documented event types.`,
+		Example: `stripe docs event customer.subscription.updated`,
+		RunE:    dc.runEventCmd,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return completeDocs(args, func(ref *docs.Reference) []string { return ref.EventTypes() })
+		},
+	}
+
+	resourceCmd := &cobra.Command{
+		Use:   "resource [name]",
+		Args:  validators.MaximumNArgs(1),
+		Short: "Show the fields of an API resource",
+		Long: `Show the description and the fields of an API resource. Without a name,
+list the documented resources.`,
+		Example: `stripe docs resource checkout.session`,
+		RunE:    dc.runResourceCmd,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return completeDocs(args, func(ref *docs.Reference) []string { return ref.ResourceNames() })
+		},
+	}
+
+	dc.cmd.AddCommand(eventCmd)
+	dc.cmd.AddCommand(resourceCmd)
+
+	return dc
+}
+
+func (dc *docsCmd) runEventCmd(cmd *cobra.Command, args []string) error {
+	ref, err := docs.Load()
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+
+	if len(args) == 0 {
+		printNames(out, ref.EventTypes())
+		return nil
+	}
+
+	event, err := ref.Event(args[0])
+	if err != nil {
+		return err
+	}
+
+	ref.RenderEvent(out, event)
+
+	fmt.Fprintln(out)
+	for _, name := range fixtures.EventNames() {
+		if name == event.Type {
+			fmt.Fprintf(out, "Trigger it with %s\n", ansi.Bold("stripe trigger "+event.Type))
+			break
+		}
+	}
+	fmt.Fprintf(out, "More at %s#event_types-%s\n", eventTypesDocsURL, event.Type)
+
+	return nil
+}
+
+func (dc *docsCmd) runResourceCmd(cmd *cobra.Command, args []string) error {
+	ref, err := docs.Load()
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+
+	if len(args) == 0 {
+		printNames(out, ref.ResourceNames())
+		return nil
+	}
+
+	resource, err := ref.Resource(args[0])
+	if err != nil {
+		return err
+	}
+
+	ref.RenderResource(out, resource)
+
+	return nil
+}
+
+func printNames(out io.Writer, names []string) {
+	fmt.Fprintln(out, strings.Join(names, "\n"))
+}
+
+func completeDocs(args []string, names func(*docs.Reference) []string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	ref, err := docs.Load()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	return names(ref), cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/requests"
+	"github.com/stripe/stripe-cli/pkg/stripeauth"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+// Exit codes of the CLI, so that scripts can tell failures apart without
+// parsing error messages
+const (
+	exitError          = 1
+	exitUsage          = 2
+	exitAuthentication = 3
+	exitRateLimited    = 4
+	exitValidation     = 5
+	exitAPI            = 6
+	exitNetwork        = 7
+)
+
+// Formats of the errors, set with --error-format
+const (
+	errorFormatText = "text"
+	errorFormatJSON = "json"
+)
+
+// errorFormat returns the format of the errors. Flags aren't parsed when the
+// command or one of its flags is unknown, so --error-format is then looked up
+// in the arguments.
+func errorFormat(format string, args []string) string {
+	if format != "" {
+		return format
+	}
+
+	for i, arg := range args {
+		switch {
+		case arg == "--":
+			return errorFormatText
+		case strings.HasPrefix(arg, "--error-format="):
+			return strings.TrimPrefix(arg, "--error-format=")
+		case arg == "--error-format" && i+1 < len(args):
+			return args[i+1]
+		}
+	}
+
+	return errorFormatText
+}
+
+// validateErrorFormat returns an error if --error-format isn't supported
+func validateErrorFormat(format string) error {
+	if format != errorFormatText && format != errorFormatJSON {
+		return usageError{err: fmt.Errorf("invalid --error-format ‘%s’, expected text or json", format)}
+	}
+
+	return nil
+}
+
+// cliError describes an error for scripts, as printed by --error-format json
+type cliError struct {
+	// Category is the kind of failure, which determines the exit code:
+	// usage, authentication, rate_limit, validation, api, network or error
+	Category  string `json:"category"`
+	Type      string `json:"type,omitempty"`
+	Code      string `json:"code,omitempty"`
+	Message   string `json:"message"`
+	Status    int    `json:"status,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+	DocURL    string `json:"doc_url,omitempty"`
+	ExitCode  int    `json:"exit_code"`
+}
+
+// usageError is returned when a command is run with invalid flags
+type usageError struct {
+	err error
+}
+
+func (e usageError) Error() string {
+	return e.err.Error()
+}
+
+func (e usageError) Unwrap() error {
+	return e.err
+}
+
+// flagUsageError makes cobra return flag parsing errors as usage errors
+func flagUsageError(cmd *cobra.Command, err error) error {
+	return usageError{err: err}
+}
+
+// printedError is returned when a command printed its error already, like
+// listen logging a fatal error, so that it isn't printed again
+type printedError struct {
+	err error
+}
+
+func (e printedError) Error() string {
+	return e.err.Error()
+}
+
+func (e printedError) Unwrap() error {
+	return e.err
+}
+
+// errorWasPrinted returns true if the command printed err already
+func errorWasPrinted(err error) bool {
+	var printed printedError
+	if errors.As(err, &printed) {
+		return true
+	}
+
+	var reqErr requests.RequestError
+	return errors.As(err, &reqErr) && reqErr.Printed
+}
+
+// isLoginRequiredError returns true if err is about the CLI not being logged
+// in yet
+func isLoginRequiredError(err error) bool {
+	errString := err.Error()
+
+	return errString == validators.ErrAPIKeyNotConfigured.Error() || errString == validators.ErrDeviceNameNotConfigured.Error()
+}
+
+// describeError classifies err, the error a command failed with
+func describeError(err error) cliError {
+	described := cliError{
+		Category: "error",
+		Message:  strings.TrimSpace(err.Error()),
+		ExitCode: exitError,
+	}
+
+	var reqErr requests.RequestError
+	var authErr stripeauth.AuthorizationError
+	var featureErr stripeauth.FeatureNotAuthorizedError
+	var argsErr validators.ArgsError
+	var usageErr usageError
+	var urlErr *url.Error
+	var netErr net.Error
+
+	switch {
+	case errors.As(err, &reqErr):
+		described.Type = reqErr.ErrorType
+		described.Code = reqErr.ErrorCode
+		described.Status = reqErr.StatusCode
+		described.RequestID = reqErr.RequestID
+		described.DocURL = reqErr.DocURL
+		if reqErr.Message != "" {
+			described.Message = reqErr.Message
+		}
+		described.Category, described.ExitCode = categorizeStatus(reqErr.StatusCode, reqErr.ErrorType)
+	case errors.As(err, &authErr):
+		described.Status = authErr.StatusCode
+		described.Category, described.ExitCode = categorizeStatus(authErr.StatusCode, "")
+	case errors.As(err, &featureErr):
+		described.Status = http.StatusForbidden
+		described.Category, described.ExitCode = "authentication", exitAuthentication
+	case isLoginRequiredError(err), requests.IsAPIKeyExpiredError(err):
+		described.Category, described.ExitCode = "authentication", exitAuthentication
+	case errors.As(err, &argsErr), errors.As(err, &usageErr), strings.Contains(err.Error(), "unknown command"):
+		described.Category, described.ExitCode = "usage", exitUsage
+	case errors.As(err, &urlErr), errors.As(err, &netErr):
+		described.Category, described.ExitCode = "network", exitNetwork
+	}
+
+	return described
+}
+
+// categorizeStatus returns the category and exit code of an API error from
+// its HTTP status and Stripe error type
+func categorizeStatus(status int, errorType string) (string, int) {
+	switch {
+	case status == http.StatusUnauthorized, status == http.StatusForbidden:
+		return "authentication", exitAuthentication
+	case status == http.StatusTooManyRequests, errorType == "rate_limit_error":
+		return "rate_limit", exitRateLimited
+	case status >= 500:
+		return "api", exitAPI
+	case status >= 400:
+		return "validation", exitValidation
+	default:
+		return "error", exitError
+	}
+}
+
+// printJSONError prints err as JSON, like
+// {"error":{"category":"validation","code":"resource_missing",...}}
+func printJSONError(w io.Writer, described cliError) {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(map[string]cliError{"error": described}); err != nil {
+		fmt.Fprintln(w, described.Message)
+	}
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/requests"
+	"github.com/stripe/stripe-cli/pkg/stripeauth"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+func TestDescribeError(t *testing.T) {
+	for _, tt := range []struct {
+		err      error
+		category string
+		exitCode int
+	}{
+		{errors.New("something failed"), "error", exitError},
+		{requests.RequestError{StatusCode: http.StatusUnauthorized}, "authentication", exitAuthentication},
+		{requests.RequestError{StatusCode: http.StatusForbidden}, "authentication", exitAuthentication},
+		{requests.RequestError{StatusCode: http.StatusTooManyRequests, ErrorType: "invalid_request_error"}, "rate_limit", exitRateLimited},
+		{requests.RequestError{StatusCode: http.StatusBadRequest}, "validation", exitValidation},
+		{requests.RequestError{StatusCode: http.StatusPaymentRequired, ErrorType: "card_error"}, "validation", exitValidation},
+		{requests.RequestError{StatusCode: http.StatusInternalServerError}, "api", exitAPI},
+		{fmt.Errorf("Trigger failed: %w\n", requests.RequestError{StatusCode: http.StatusBadRequest}), "validation", exitValidation},
+		{stripeauth.AuthorizationError{StatusCode: http.StatusUnauthorized}, "authentication", exitAuthentication},
+		{fmt.Errorf("Error while authenticating with Stripe: %w", stripeauth.FeatureNotAuthorizedError{Feature: "webhooks"}), "authentication", exitAuthentication},
+		{validators.ErrAPIKeyNotConfigured, "authentication", exitAuthentication},
+		{validators.ArgsError{Message: "`stripe get` requires exactly 1 positional argument"}, "usage", exitUsage},
+		{usageError{err: errors.New("unknown flag: --bogus")}, "usage", exitUsage},
+		{&url.Error{Op: "Get", URL: "https://api.stripe.com", Err: errors.New("connection refused")}, "network", exitNetwork},
+		{printedError{err: &url.Error{Op: "Get", URL: "https://api.stripe.com", Err: errors.New("connection refused")}}, "network", exitNetwork},
+	} {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			described := describeError(tt.err)
+			require.Equal(t, tt.category, described.Category)
+			require.Equal(t, tt.exitCode, described.ExitCode)
+		})
+	}
+}
+
+func TestPrintJSONError(t *testing.T) {
+	var out bytes.Buffer
+	printJSONError(&out, describeError(requests.RequestError{
+		StatusCode: http.StatusNotFound,
+		ErrorType:  "invalid_request_error",
+		ErrorCode:  "resource_missing",
+		Message:    "No such customer: 'cus_123'",
+		DocURL:     "https://stripe.com/docs/error-codes/resource-missing",
+		RequestID:  "req_123",
+	}))
+
+	require.JSONEq(t, `{"error": {
+		"category": "validation",
+		"type": "invalid_request_error",
+		"code": "resource_missing",
+		"message": "No such customer: 'cus_123'",
+		"status": 404,
+		"request_id": "req_123",
+		"doc_url": "https://stripe.com/docs/error-codes/resource-missing",
+		"exit_code": 5
+	}}`, out.String())
+}
+
+func TestErrorWasPrinted(t *testing.T) {
+	require.False(t, errorWasPrinted(errors.New("failed")))
+	require.False(t, errorWasPrinted(requests.RequestError{StatusCode: http.StatusBadRequest}))
+	require.True(t, errorWasPrinted(requests.RequestError{StatusCode: http.StatusBadRequest, Printed: true}))
+	require.True(t, errorWasPrinted(printedError{err: errors.New("failed")}))
+}
+
+func TestErrorFormat(t *testing.T) {
+	require.Equal(t, errorFormatJSON, errorFormat(errorFormatJSON, nil))
+	require.Equal(t, errorFormatText, errorFormat("", []string{"get", "--bogus"}))
+	require.Equal(t, errorFormatJSON, errorFormat("", []string{"get", "--bogus", "--error-format", "json"}))
+	require.Equal(t, errorFormatJSON, errorFormat("", []string{"nope", "--error-format=json"}))
+	require.Equal(t, errorFormatText, errorFormat("", []string{"plugin", "--", "--error-format=json"}))
+
+	require.NoError(t, validateErrorFormat(errorFormatText))
+	require.NoError(t, validateErrorFormat(errorFormatJSON))
+	require.Error(t, validateErrorFormat("yaml"))
+}
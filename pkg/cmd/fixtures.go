@@ -1,9 +1,18 @@
 package cmd
 
 import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 
+	"github.com/stripe/stripe-cli/pkg/ansi"
 	"github.com/stripe/stripe-cli/pkg/config"
 	"github.com/stripe/stripe-cli/pkg/fixtures"
 	"github.com/stripe/stripe-cli/pkg/stripe"
@@ -22,6 +31,13 @@ type FixturesCmd struct {
 	override      []string
 	add           []string
 	remove        []string
+	teardownAll   bool
+	dryRun        bool
+	concurrency   int
+	recordOut     string
+	recordStop    bool
+	recordDiscard bool
+	indexURL      string
 }
 
 func newFixturesCmd(cfg *config.Config) *FixturesCmd {
@@ -33,8 +49,23 @@ func newFixturesCmd(cfg *config.Config) *FixturesCmd {
 		Use:   "fixtures",
 		Args:  validators.ExactArgs(1),
 		Short: "Run fixtures to populate your account with data",
-		Long:  `Run fixtures to populate your account with data`,
-		RunE:  fixturesCmd.runFixturesCmd,
+		Long: `Run fixtures to populate your account with data.
+
+Fixture steps can declare expectations on their response, like
+"expect": {"status": "succeeded", "amount": 2000}. The run stops with a
+non-zero exit code when a response doesn't meet them, so fixtures can be used
+as integration tests in CI.
+
+Setting "test_clock": true in the _meta of a fixture runs it on a test clock:
+the customers it creates are attached to the clock, and a step like
+{"name": "renewal", "advance_clock": "+35d"} advances the clock and waits for
+it to be ready before the next step runs. The clock is deleted with the
+teardown of the fixture.
+
+A step with an "account" field, like "account": "${connected_account:id}", is
+sent to that connected account with the Stripe-Account header, so direct
+charges and other Connect flows can be set up in a single fixture.`,
+		RunE: fixturesCmd.runFixturesCmd,
 	}
 
 	fixturesCmd.Cmd.Flags().StringVar(&fixturesCmd.stripeAccount, "stripe-account", "", "Set a header identifying the connected account")
@@ -42,13 +73,349 @@ func newFixturesCmd(cfg *config.Config) *FixturesCmd {
 	fixturesCmd.Cmd.Flags().StringArrayVar(&fixturesCmd.override, "override", []string{}, "Override parameters in the fixture")
 	fixturesCmd.Cmd.Flags().StringArrayVar(&fixturesCmd.add, "add", []string{}, "Add parameters in the fixture")
 	fixturesCmd.Cmd.Flags().StringArrayVar(&fixturesCmd.remove, "remove", []string{}, "Remove parameters from the fixture")
+	fixturesCmd.Cmd.Flags().BoolVar(&fixturesCmd.dryRun, "dry-run", false, "Print the requests the fixture would send, without sending them")
+	fixturesCmd.Cmd.Flags().IntVar(&fixturesCmd.concurrency, "concurrency", 1, "Number of steps to run in parallel. Steps referencing other steps wait for them")
+
+	installCmd := &cobra.Command{
+		Use:   "install <path-url-or-name>",
+		Args:  validators.ExactArgs(1),
+		Short: "Install a pack of fixtures as trigger events",
+		Long: `Install a directory of fixture files as trigger events of the current profile.
+Every fixture file of the pack can then be triggered by its name, so
+invoice.overdue.json is triggered with ` + "`stripe trigger invoice.overdue`" + `.
+
+The pack is either a local directory, the URL of a git repository or of a
+.tar.gz archive, or the name of a scenario of the fixtures index, found with
+` + "`stripe fixtures search`" + `. Remote packs are downloaded to the config folder, and
+the checksums of the scenarios of the index are verified before they're
+installed.`,
+		Example: `stripe fixtures install ./fixtures
+  stripe fixtures install https://github.com/acme/stripe-fixtures.git
+  stripe fixtures install https://example.com/stripe-fixtures.tar.gz
+  stripe fixtures install dunning-retries`,
+		RunE: fixturesCmd.runInstallCmd,
+	}
+	fixturesCmd.Cmd.AddCommand(installCmd)
+
+	searchCmd := &cobra.Command{
+		Use:   "search [query]",
+		Args:  cobra.ArbitraryArgs,
+		Short: "Search the index of fixture scenarios",
+		Long: `Search the curated index of official and community fixture scenarios by name,
+description and tags. Install a scenario with ` + "`stripe fixtures install <name>`" + `.`,
+		Example: `stripe fixtures search dunning
+  stripe fixtures search connect payouts`,
+		RunE: fixturesCmd.runSearchCmd,
+	}
+	fixturesCmd.Cmd.AddCommand(searchCmd)
+
+	// Hidden configuration flags, useful for dev/debugging
+	for _, c := range []*cobra.Command{installCmd, searchCmd} {
+		c.Flags().StringVar(&fixturesCmd.indexURL, "index", fixtures.DefaultIndexURL, "The URL of the fixtures index")
+		c.Flags().MarkHidden("index") // #nosec G104
+	}
+
+	fixturesCmd.Cmd.AddCommand(&cobra.Command{
+		Use:   "uninstall <name-or-path>",
+		Args:  validators.ExactArgs(1),
+		Short: "Uninstall a pack of fixtures",
+		RunE:  fixturesCmd.runUninstallCmd,
+	})
+
+	teardownCmd := &cobra.Command{
+		Use:   "teardown [run-id]...",
+		Args:  cobra.ArbitraryArgs,
+		Short: "Delete the objects created by fixtures and triggers",
+		Long: `Send the teardown requests of fixture runs, deleting the objects they
+created. Runs of fixtures declaring teardown requests are recorded by
+` + "`stripe fixtures`" + ` and ` + "`stripe trigger`" + ` (unless it's run with --cleanup).
+
+Without a run ID, list the recorded runs.`,
+		Example: `stripe fixtures teardown
+  stripe fixtures teardown 20221015-093012-4f2a
+  stripe fixtures teardown --all`,
+		RunE: fixturesCmd.runTeardownCmd,
+	}
+	teardownCmd.Flags().BoolVar(&fixturesCmd.teardownAll, "all", false, "Tear down every recorded run")
+	fixturesCmd.Cmd.AddCommand(teardownCmd)
+
+	recordCmd := &cobra.Command{
+		Use:   "record",
+		Args:  validators.NoArgs,
+		Short: "Record API requests into a fixture",
+		Long: `Record the requests sent with ` + "`stripe get`" + `, ` + "`stripe post`" + ` and ` + "`stripe delete`" + `
+into a fixture file that replays them. Start recording with --out, send the
+requests, then stop with --stop to write the fixture.
+
+The IDs of objects returned by earlier requests are replaced with references
+to their responses, like ${customer:id}, so the fixture creates its own
+objects when it runs. Requests that fail aren't recorded.`,
+		Example: `stripe fixtures record --out my_scenario.json
+  stripe post /v1/customers -d name="Jenny Rosen"
+  stripe post /v1/subscriptions -d customer=cus_MxYz1234 -d "items[0][price]=price_1Mx2"
+  stripe fixtures record --stop
+  stripe fixtures my_scenario.json`,
+		RunE: fixturesCmd.runRecordCmd,
+	}
+	recordCmd.Flags().StringVar(&fixturesCmd.recordOut, "out", "", "Start recording into this fixture file")
+	recordCmd.Flags().BoolVar(&fixturesCmd.recordStop, "stop", false, "Stop recording and write the fixture file")
+	recordCmd.Flags().BoolVar(&fixturesCmd.recordDiscard, "discard", false, "Stop recording without writing the fixture file")
+	fixturesCmd.Cmd.AddCommand(recordCmd)
 
 	return fixturesCmd
 }
 
+func (fc *FixturesCmd) runInstallCmd(cmd *cobra.Command, args []string) error {
+	fs := afero.NewOsFs()
+
+	var dir string
+	var err error
+	if fixtures.IsIndexName(fs, args[0]) {
+		dir, err = fc.installFromIndex(cmd, fs, args[0])
+	} else {
+		dir, err = fixtures.InstallPack(fs, args[0], fc.packsDir(), fc.Cfg.Profile.GetFixturePacks())
+	}
+	if err != nil {
+		return err
+	}
+
+	events, err := fixtures.LoadPack(fs, dir)
+	if err != nil {
+		return err
+	}
+
+	if err := fc.Cfg.Profile.WriteFixturePacks(append(fc.Cfg.Profile.GetFixturePacks(), dir)); err != nil {
+		return err
+	}
+
+	fmt.Printf("Installed the fixture pack %s with %d events, list them with `stripe trigger --list`\n", dir, len(events))
+
+	return nil
+}
+
+// installFromIndex installs the scenario of the fixtures index named name
+func (fc *FixturesCmd) installFromIndex(cmd *cobra.Command, fs afero.Fs, name string) (string, error) {
+	index, err := fixtures.FetchIndex(cmd.Context(), fc.indexURL)
+	if err != nil {
+		return "", err
+	}
+
+	entry, err := index.Lookup(name)
+	if err != nil {
+		return "", err
+	}
+
+	return fixtures.InstallIndexEntry(cmd.Context(), fs, entry, fc.packsDir(), fc.Cfg.Profile.GetFixturePacks())
+}
+
+func (fc *FixturesCmd) runSearchCmd(cmd *cobra.Command, args []string) error {
+	index, err := fixtures.FetchIndex(cmd.Context(), fc.indexURL)
+	if err != nil {
+		return err
+	}
+
+	matches := index.Search(strings.Join(args, " "))
+	if len(matches) == 0 {
+		fmt.Println("No fixture scenarios found.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSOURCE\tDESCRIPTION")
+	for _, entry := range matches {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", entry.Name, entry.Source(), entry.Description)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Println("\nInstall a scenario with `stripe fixtures install <name>`")
+
+	return nil
+}
+
+func (fc *FixturesCmd) runUninstallCmd(cmd *cobra.Command, args []string) error {
+	remaining, err := fixtures.UninstallPack(afero.NewOsFs(), args[0], fc.packsDir(), fc.Cfg.Profile.GetFixturePacks())
+	if err != nil {
+		return err
+	}
+
+	if err := fc.Cfg.Profile.WriteFixturePacks(remaining); err != nil {
+		return err
+	}
+
+	fmt.Printf("Uninstalled the fixture pack %s\n", args[0])
+
+	return nil
+}
+
+func (fc *FixturesCmd) runTeardownCmd(cmd *cobra.Command, args []string) error {
+	if config.IsStateless() {
+		return config.ErrStateless
+	}
+
+	store := fixtureRunStore(afero.NewOsFs(), fc.Cfg)
+
+	runs, err := store.List()
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 && !fc.teardownAll {
+		if len(runs) == 0 {
+			fmt.Println("No fixture runs to tear down.")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "RUN ID\tFIXTURE\tCREATED\tREQUESTS")
+		for _, run := range runs {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\n", run.ID, run.Fixture, run.CreatedAt.Local().Format("2006-01-02 15:04:05"), len(run.Requests))
+		}
+		return w.Flush()
+	}
+
+	if len(args) > 0 {
+		runs = runs[:0]
+		for _, id := range args {
+			run, err := store.Load(id)
+			if err != nil {
+				return err
+			}
+			runs = append(runs, run)
+		}
+	}
+
+	apiKey, err := fc.Cfg.Profile.GetAPIKey(false)
+	if err != nil {
+		return err
+	}
+
+	for _, run := range runs {
+		// Keep the runs that failed so they can be torn down again
+		if err := fixtures.Teardown(cmd.Context(), apiKey, run); err != nil {
+			return fmt.Errorf("%s: %v", run.ID, err)
+		}
+
+		if err := store.Delete(run.ID); err != nil {
+			return err
+		}
+
+		fmt.Printf("Tore down the fixture run %s (%s)\n", run.ID, run.Fixture)
+	}
+
+	return nil
+}
+
+func (fc *FixturesCmd) runRecordCmd(cmd *cobra.Command, args []string) error {
+	if config.IsStateless() {
+		return config.ErrStateless
+	}
+
+	store := fixtureRecording(afero.NewOsFs(), fc.Cfg)
+
+	switch {
+	case fc.recordOut != "" && (fc.recordStop || fc.recordDiscard), fc.recordStop && fc.recordDiscard:
+		return fmt.Errorf("only one of --out, --stop and --discard can be set")
+	case fc.recordStop:
+		recording, err := store.Stop()
+		if err != nil {
+			return err
+		}
+
+		data, err := recording.Fixture()
+		if err != nil {
+			return err
+		}
+
+		if err := afero.WriteFile(afero.NewOsFs(), recording.Out, data, 0644); err != nil {
+			return err
+		}
+
+		fmt.Printf("Wrote %d recorded requests to %s. Run it with `stripe fixtures %s`\n", len(recording.Steps), recording.Out, recording.Out)
+	case fc.recordDiscard:
+		if err := store.Discard(); err != nil {
+			return err
+		}
+
+		fmt.Println("Discarded the recording.")
+	case fc.recordOut != "":
+		out, err := filepath.Abs(fc.recordOut)
+		if err != nil {
+			return err
+		}
+
+		if _, err := os.Stat(out); err == nil {
+			return fmt.Errorf("%s already exists", fc.recordOut)
+		}
+
+		if err := store.Start(out, time.Now()); err != nil {
+			return err
+		}
+
+		fmt.Printf("Recording the requests of `stripe get`, `stripe post` and `stripe delete` into %s.\n", fc.recordOut)
+		fmt.Println("Run `stripe fixtures record --stop` when done.")
+	default:
+		recording, err := store.Active()
+		if err != nil {
+			return err
+		}
+
+		if recording == nil {
+			fmt.Println("No recording in progress. Start one with `stripe fixtures record --out <file>`")
+		} else {
+			fmt.Printf("Recording into %s since %s, %d requests recorded.\n", recording.Out, recording.StartedAt.Local().Format("2006-01-02 15:04:05"), len(recording.Steps))
+		}
+	}
+
+	return nil
+}
+
+// recordResponse adds the requests of `stripe get`, `stripe post` and
+// `stripe delete` to the fixture being recorded, if any. Failing to record
+// them doesn't fail the request.
+func recordResponse(method, path, data string, statusCode int, body []byte) {
+	store := fixtureRecording(fs, &Config)
+
+	if statusCode >= 300 {
+		if recording, err := store.Active(); err == nil && recording != nil {
+			fmt.Fprintln(os.Stderr, "\nThe request failed, so it wasn't recorded.")
+		}
+		return
+	}
+
+	recorded, err := store.Append(fixtures.NewRecordedStep(method, path, data, body))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\nFailed to record the request: %v\n", err)
+		return
+	}
+
+	if recorded {
+		fmt.Fprintln(os.Stderr, "\nRecorded the request. Run `stripe fixtures record --stop` when done.")
+	}
+}
+
+// packsDir is where the fixture packs downloaded from a URL are kept
+func (fc *FixturesCmd) packsDir() string {
+	return filepath.Join(fc.Cfg.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")), "fixture_packs")
+}
+
 func (fc *FixturesCmd) runFixturesCmd(cmd *cobra.Command, args []string) error {
 	version.CheckLatestVersion()
 
+	if fc.concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	if fc.dryRun {
+		fixture, err := fixtures.NewFixtureFromFile(afero.NewOsFs(), "", fc.stripeAccount, stripe.DefaultAPIBaseURL, args[0], fc.skip, fc.override, fc.add, fc.remove)
+		if err != nil {
+			return err
+		}
+
+		return printPlan(os.Stdout, fixture)
+	}
+
 	apiKey, err := fc.Cfg.Profile.GetAPIKey(false)
 	if err != nil {
 		return err
@@ -73,6 +440,8 @@ func (fc *FixturesCmd) runFixturesCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	fixture.Concurrency = fc.concurrency
+
 	_, err = fixture.Execute(cmd.Context())
 
 	if err != nil {
@@ -84,5 +453,56 @@ func (fc *FixturesCmd) runFixturesCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Runs can't be torn down later without the config folder
+	if fixture.HasTeardown() && !config.IsStateless() {
+		run := fixture.PrepareTeardown(args[0])
+		if len(run.Requests) > 0 {
+			if err := fixtureRunStore(afero.NewOsFs(), fc.Cfg).Save(run); err != nil {
+				return err
+			}
+
+			fmt.Printf("Run `stripe fixtures teardown %s` to delete the objects it created.\n", run.ID)
+		}
+	}
+
+	return nil
+}
+
+// printPlan prints the requests fixture would send, with their form data.
+func printPlan(out io.Writer, fixture *fixtures.Fixture) error {
+	planned, err := fixture.Plan()
+	if err != nil {
+		return err
+	}
+
+	color := ansi.Color(out)
+
+	for _, req := range planned {
+		fmt.Fprintf(out, "%s %s %s\n", color.Faint(req.Name+":"), color.Bold(req.Method), req.Path)
+		if req.Account != "" {
+			fmt.Fprintf(out, "    Stripe-Account: %s\n", req.Account)
+		}
+		for _, param := range req.Params {
+			fmt.Fprintf(out, "    %s\n", param)
+		}
+	}
+
 	return nil
 }
+
+// fixtureRecording keeps the recording session in progress in the config
+// folder
+func fixtureRecording(fs afero.Fs, cfg *config.Config) *fixtures.RecordingStore {
+	return &fixtures.RecordingStore{
+		Fs:   config.StateFs(fs),
+		Path: filepath.Join(cfg.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")), "fixture_recording.json"),
+	}
+}
+
+// fixtureRunStore keeps the fixture runs to tear down in the config folder
+func fixtureRunStore(fs afero.Fs, cfg *config.Config) *fixtures.TeardownStore {
+	return &fixtures.TeardownStore{
+		Fs:  config.StateFs(fs),
+		Dir: filepath.Join(cfg.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")), "fixture_runs"),
+	}
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+)
+
+// flagsWithoutDefaults are the flags that are read before the profile, or
+// that would make no sense as defaults
+var flagsWithoutDefaults = map[string]bool{
+	"config":       true,
+	"help":         true,
+	"project-name": true,
+	"version":      true,
+}
+
+// applyFlagDefaults sets the flags of cmd that aren't set on the command line
+// to their defaults in the profile, and returns the names of the flags it set.
+func applyFlagDefaults(cmd *cobra.Command, profile *config.Profile) ([]string, error) {
+	commandPath := strings.Fields(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()))
+
+	// The config commands write the bound global flags like --color to the
+	// config file, which mustn't persist their defaults
+	if len(commandPath) > 0 && commandPath[0] == "config" {
+		return nil, nil
+	}
+
+	var applied []string
+	var err error
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if err != nil || flag.Changed || flagsWithoutDefaults[flag.Name] {
+			return
+		}
+
+		value, ok := profile.GetFlagDefault(commandPath, flag.Name)
+		if !ok {
+			return
+		}
+
+		// Lists set flags that can be repeated, like --events
+		values := []interface{}{value}
+		if list, ok := value.([]interface{}); ok {
+			values = list
+		}
+
+		for _, v := range values {
+			if setErr := cmd.Flags().Set(flag.Name, fmt.Sprint(v)); setErr != nil {
+				err = fmt.Errorf("invalid default for --%s in the profile: %v", flag.Name, setErr)
+				return
+			}
+		}
+
+		applied = append(applied, flag.Name)
+	})
+
+	return applied, err
+}
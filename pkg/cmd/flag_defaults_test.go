@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+)
+
+func TestApplyFlagDefaults(t *testing.T) {
+	profilesFile := filepath.Join(t.TempDir(), "config.toml")
+	err := ioutil.WriteFile(profilesFile, []byte(`
+[default.defaults]
+  color = "off"
+
+[default.defaults.listen]
+  forward-to = "http://localhost:4242/webhook"
+  events = ["charge.succeeded", "charge.failed"]
+
+[default.defaults.logs]
+  format = "JSON"
+`), 0600)
+	require.NoError(t, err)
+
+	viper.Reset()
+	viper.SetConfigFile(profilesFile)
+	require.NoError(t, viper.ReadInConfig())
+	defer viper.Reset()
+
+	profile := &config.Profile{ProfileName: "default"}
+
+	newCmds := func() (*cobra.Command, *cobra.Command, *cobra.Command) {
+		root := &cobra.Command{Use: "stripe"}
+		root.PersistentFlags().String("color", "", "")
+		listen := &cobra.Command{Use: "listen"}
+		listen.Flags().String("forward-to", "", "")
+		listen.Flags().StringSlice("events", []string{"*"}, "")
+		logs := &cobra.Command{Use: "logs"}
+		tail := &cobra.Command{Use: "tail"}
+		tail.Flags().String("format", "", "")
+		tail.Flags().String("filter-http-method", "", "")
+		logs.AddCommand(tail)
+		root.AddCommand(listen, logs)
+		return root, listen, tail
+	}
+
+	t.Run("command defaults", func(t *testing.T) {
+		root, listen, _ := newCmds()
+		require.NoError(t, root.ParseFlags(nil))
+		require.NoError(t, listen.ParseFlags(nil))
+
+		applied, err := applyFlagDefaults(listen, profile)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"color", "events", "forward-to"}, applied)
+
+		forwardTo, _ := listen.Flags().GetString("forward-to")
+		require.Equal(t, "http://localhost:4242/webhook", forwardTo)
+		events, _ := listen.Flags().GetStringSlice("events")
+		require.Equal(t, []string{"charge.succeeded", "charge.failed"}, events)
+		color, _ := listen.Flags().GetString("color")
+		require.Equal(t, "off", color)
+	})
+
+	t.Run("parent command defaults", func(t *testing.T) {
+		_, _, tail := newCmds()
+		require.NoError(t, tail.ParseFlags(nil))
+
+		_, err := applyFlagDefaults(tail, profile)
+		require.NoError(t, err)
+
+		format, _ := tail.Flags().GetString("format")
+		require.Equal(t, "JSON", format)
+		method, _ := tail.Flags().GetString("filter-http-method")
+		require.Empty(t, method)
+	})
+
+	t.Run("command line flags take precedence", func(t *testing.T) {
+		_, listen, _ := newCmds()
+		require.NoError(t, listen.ParseFlags([]string{"--forward-to", "localhost:3000", "--color", "on"}))
+
+		applied, err := applyFlagDefaults(listen, profile)
+		require.NoError(t, err)
+		require.Equal(t, []string{"events"}, applied)
+
+		forwardTo, _ := listen.Flags().GetString("forward-to")
+		require.Equal(t, "localhost:3000", forwardTo)
+		color, _ := listen.Flags().GetString("color")
+		require.Equal(t, "on", color)
+	})
+}
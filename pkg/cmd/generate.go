@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/proxy"
+	"github.com/stripe/stripe-cli/pkg/scaffold"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type generateCmd struct {
+	cmd *cobra.Command
+}
+
+func newGenerateCmd() *generateCmd {
+	gc := &generateCmd{
+		cmd: &cobra.Command{
+			Use:   "generate",
+			Args:  validators.NoArgs,
+			Short: "Generate starter code for your integration",
+		},
+	}
+
+	gc.cmd.AddCommand(newGenerateWebhookHandlerCmd().cmd)
+
+	return gc
+}
+
+type generateWebhookHandlerCmd struct {
+	cmd *cobra.Command
+
+	lang   string
+	events []string
+	out    string
+	pkg    string
+	force  bool
+	fs     afero.Fs
+}
+
+func newGenerateWebhookHandlerCmd() *generateWebhookHandlerCmd {
+	gc := &generateWebhookHandlerCmd{
+		fs: afero.NewOsFs(),
+	}
+
+	gc.cmd = &cobra.Command{
+		Use:   "webhook-handler",
+		Args:  validators.NoArgs,
+		Short: "Generate a webhook handler skeleton with signature verification",
+		Long: `Generate a webhook handler that verifies the signature of incoming events
+and dispatches them to one function per event type, ready to be wired into an
+existing application.`,
+		Example: `stripe generate webhook-handler --lang go --events payment_intent.succeeded,invoice.paid --out ./webhooks/`,
+		RunE:    gc.runGenerateWebhookHandlerCmd,
+	}
+
+	gc.cmd.Flags().StringVar(&gc.lang, "lang", "", fmt.Sprintf("Language to generate the handler in (%s)", strings.Join(scaffold.WebhookHandlerLanguages(), ", ")))
+	gc.cmd.Flags().StringSliceVar(&gc.events, "events", []string{}, "A comma-separated list of the event types to handle")
+	gc.cmd.Flags().StringVar(&gc.out, "out", ".", "Directory to write the handler to")
+	gc.cmd.Flags().StringVar(&gc.pkg, "package", "webhooks", "Package name of the generated handler (Go only)")
+	gc.cmd.Flags().BoolVar(&gc.force, "force", false, "Overwrite the handler if it already exists")
+
+	gc.cmd.MarkFlagRequired("lang")
+	gc.cmd.MarkFlagRequired("events")
+
+	return gc
+}
+
+func (gc *generateWebhookHandlerCmd) runGenerateWebhookHandlerCmd(cmd *cobra.Command, args []string) error {
+	for _, event := range gc.events {
+		if event == "*" {
+			return fmt.Errorf("handlers must list the event types they handle explicitly")
+		}
+		if !proxy.IsValidEvent(event) {
+			fmt.Printf("Warning: \"%s\" isn't a valid event\n", event)
+		}
+	}
+
+	handler := scaffold.WebhookHandler{
+		Lang:    gc.lang,
+		Events:  gc.events,
+		Package: gc.pkg,
+	}
+
+	path, err := handler.Generate(gc.fs, gc.out, gc.force)
+	if err != nil {
+		return err
+	}
+
+	color := ansi.Color(os.Stdout)
+	fmt.Printf("%s Created %s\n", color.Green("✔"), ansi.Bold(path))
+	fmt.Println("Set STRIPE_WEBHOOK_SECRET to your endpoint's signing secret, e.g. the one printed by `stripe listen`.")
+
+	return nil
+}
@@ -18,6 +18,7 @@ func newGetCmd() *getCmd {
 
 	gc.reqs.Method = http.MethodGet
 	gc.reqs.Profile = &Config.Profile
+	gc.reqs.OnResponse = recordResponse
 	gc.reqs.Cmd = &cobra.Command{
 		Use:   "get <id or path>",
 		Args:  validators.ExactArgs(1),
@@ -27,7 +28,17 @@ id. You can also make normal HTTP GET requests to the Stripe API by providing
 the API path.`,
 		Example: `stripe get ch_1EGYgUByst5pquEtjb0EkYha
   stripe get cus_G6GQwbr1dWXt9O
-  stripe get /v1/charges --limit 50`,
+  stripe get /v1/charges --limit 50
+  stripe get /v1/customers --all --output ndjson
+  stripe get /v1/charges --limit 500
+  stripe get /v1/customers --output table
+  stripe get /v1/invoices --all --output csv > invoices.csv
+  stripe get /v1/customers --query 'data[].{id:id,email:email}'
+  stripe get /v1/charges --all --query "[?status=='failed'].id"
+  stripe get /v1/customers --limit 3 --as-curl
+  stripe get /v1/balance --cache
+  stripe get /v2/core/events -d object_id=mtr_123 --all
+  stripe get /v1/payment_intents/pi_123 --watch --interval 2s`,
 		RunE: gc.reqs.RunRequestsCmd,
 	}
 
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/importer"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type importCmd struct {
+	cmd    *cobra.Command
+	config *config.Config
+	fs     afero.Fs
+
+	mapping     []string
+	results     string
+	concurrency int
+	dryRun      bool
+	autoConfirm bool
+	livemode    bool
+	apiBaseURL  string
+
+	in  io.Reader
+	out io.Writer
+}
+
+func newImportCmd(cfg *config.Config) *importCmd {
+	ic := &importCmd{
+		config: cfg,
+		fs:     afero.NewOsFs(),
+		in:     os.Stdin,
+		out:    os.Stdout,
+	}
+
+	ic.cmd = &cobra.Command{
+		Use:   "import",
+		Args:  validators.NoArgs,
+		Short: "Create prices, coupons or promotion codes from a CSV file",
+		Long: `Create prices, coupons or promotion codes from a CSV file, to migrate a
+catalog. The header line of the file has the names of the API parameters,
+like unit_amount or metadata[sku], or columns renamed to them with --map.
+
+The objects are previewed before they're created, and the outcome of every
+line is written to a results file. Each object is sent with an idempotency
+key derived from its line, so importing the same file again within 24 hours
+doesn't create objects twice.`,
+	}
+
+	for _, name := range importer.KindNames() {
+		kind := importer.Kinds[name]
+		ic.cmd.AddCommand(&cobra.Command{
+			Use:   fmt.Sprintf("%s <file>", kind.Name),
+			Args:  validators.ExactArgs(1),
+			Short: fmt.Sprintf("Create %s from a CSV file", strings.ReplaceAll(kind.Name, "_", " ")),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return ic.runImportCmd(cmd, kind, args[0])
+			},
+		})
+	}
+
+	ic.cmd.Example = `stripe import prices pricing.csv --dry-run
+  stripe import prices pricing.csv --map "Price=unit_amount" --map "SKU=metadata[sku]"
+  stripe import coupons coupons.csv --concurrency 8 --confirm
+  stripe import promotion_codes codes.csv --results codes-results.csv`
+
+	ic.cmd.PersistentFlags().StringArrayVar(&ic.mapping, "map", []string{}, "Map a column to a parameter, like \"Price=unit_amount\", or to nothing to ignore it (repeatable)")
+	ic.cmd.PersistentFlags().StringVar(&ic.results, "results", "", "File to write the results to (default: the CSV file with a .results.csv extension)")
+	ic.cmd.PersistentFlags().IntVar(&ic.concurrency, "concurrency", 4, "Number of objects created at the same time")
+	ic.cmd.PersistentFlags().BoolVar(&ic.dryRun, "dry-run", false, "Preview the objects without creating them")
+	ic.cmd.PersistentFlags().BoolVarP(&ic.autoConfirm, "confirm", "c", false, "Skip the preview prompt and create the objects")
+	ic.cmd.PersistentFlags().BoolVar(&ic.livemode, "live", false, "Create the objects in live mode (default: test)")
+
+	// Hidden configuration flags, useful for dev/debugging
+	ic.cmd.PersistentFlags().StringVar(&ic.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	ic.cmd.PersistentFlags().MarkHidden("api-base") // #nosec G104
+
+	return ic
+}
+
+func (ic *importCmd) runImportCmd(cmd *cobra.Command, kind importer.Kind, path string) error {
+	if ic.concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	mapping, err := importer.ParseMapping(ic.mapping)
+	if err != nil {
+		return err
+	}
+
+	f, err := ic.fs.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	rows, err := importer.ReadCSV(f, kind, mapping)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	printImportPreview(ic.out, kind, rows, ic.livemode)
+
+	if ic.dryRun {
+		return nil
+	}
+
+	if !ic.autoConfirm {
+		fmt.Fprintf(ic.out, "\nCreate these %s? Enter 'yes' to confirm: ", strings.ReplaceAll(kind.Name, "_", " "))
+
+		input, err := bufio.NewReader(ic.in).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+
+		if strings.ToLower(strings.TrimSpace(input)) != "yes" {
+			fmt.Fprintln(ic.out, "Exiting without importing.")
+			return nil
+		}
+	}
+
+	apiKey, err := ic.config.Profile.GetAPIKey(ic.livemode)
+	if err != nil {
+		return err
+	}
+
+	resultsPath := ic.results
+	if resultsPath == "" {
+		resultsPath = strings.TrimSuffix(path, filepath.Ext(path)) + ".results.csv"
+	}
+
+	color := ansi.Color(ic.out)
+
+	im := &importer.Importer{
+		BaseURL:     ic.apiBaseURL,
+		APIKey:      apiKey,
+		Kind:        kind,
+		Concurrency: ic.concurrency,
+		OnResult: func(result importer.Result) {
+			if result.Err != nil {
+				fmt.Fprintf(ic.out, "%s line %d: %s\n", color.Red("✘"), result.Row.Line, result.Err)
+				return
+			}
+			fmt.Fprintf(ic.out, "%s line %d: %s\n", color.Green("✔"), result.Row.Line, result.ID)
+		},
+	}
+
+	fmt.Fprintln(ic.out)
+	results := im.Run(cmd.Context(), rows)
+
+	resultsFile, err := ic.fs.Create(resultsPath)
+	if err != nil {
+		return err
+	}
+	defer resultsFile.Close()
+
+	if err := importer.WriteResults(resultsFile, results); err != nil {
+		return fmt.Errorf("could not write %s: %w", resultsPath, err)
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+
+	fmt.Fprintf(ic.out, "\n%d of %d lines imported, results saved to %s\n", len(results)-failed, len(results), resultsPath)
+
+	if err := cmd.Context().Err(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d line(s) failed, fix them and run the command again", failed)
+	}
+
+	return nil
+}
+
+// printImportPreview prints the objects of rows to create.
+func printImportPreview(out io.Writer, kind importer.Kind, rows []importer.Row, livemode bool) {
+	mode := "test"
+	if livemode {
+		mode = "live"
+	}
+
+	fmt.Fprintf(out, "%d %s to create in %s mode\n\n", len(rows), strings.ReplaceAll(kind.Name, "_", " "), mode)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LINE\tPARAMETERS")
+	for _, row := range rows {
+		fmt.Fprintf(w, "%d\t%s\n", row.Line, strings.Join(row.Data, " "))
+	}
+	w.Flush()
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/proxy"
+	"github.com/stripe/stripe-cli/pkg/websocket"
+)
+
+func TestNDJSONVisitor(t *testing.T) {
+	var out bytes.Buffer
+	visitor := createNDJSONVisitor(log.StandardLogger(), &out)
+
+	evt := proxy.StripeEvent{ID: "evt_123", Type: "customer.created"}
+	reqURL, _ := url.Parse("http://localhost:4242/webhook")
+
+	require.NoError(t, websocket.DataElement{Data: evt}.Accept(visitor))
+	require.NoError(t, websocket.DataElement{Data: proxy.EndpointResponse{
+		Event:   &evt,
+		Resp:    &http.Response{StatusCode: 200, Request: &http.Request{Method: "POST", URL: reqURL}},
+		Latency: 42 * time.Millisecond,
+	}}.Accept(visitor))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var event, response map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &event))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &response))
+
+	require.Equal(t, "event", event["kind"])
+	require.Equal(t, "evt_123", event["event_id"])
+	require.Equal(t, "customer.created", event["event_type"])
+
+	require.Equal(t, "response", response["kind"])
+	require.Equal(t, "evt_123", response["event_id"])
+	require.Equal(t, "http://localhost:4242/webhook", response["url"])
+	require.EqualValues(t, 200, response["status"])
+	require.EqualValues(t, 42, response["latency_ms"])
+}
+
+func TestFormatObjectLink(t *testing.T) {
+	var out bytes.Buffer
+
+	// Buffers aren't terminals, so the URL is printed in plain text
+	require.Equal(t,
+		"→ customer cus_123 https://dashboard.stripe.com/test/customers/cus_123",
+		formatObjectLink("customer", "cus_123", "https://dashboard.stripe.com/test/customers/cus_123", &out),
+	)
+}
+
+func TestServiceFlagArgs(t *testing.T) {
+	lc := newListenCmd()
+
+	err := lc.cmd.ParseFlags([]string{
+		"--forward-to", "localhost:3000/webhook",
+		"--events", "charge.captured,charge.updated",
+		"--filter", "data.object.metadata.tenant==acme",
+		"--filter-metadata", "source=webstore",
+		"--live",
+		"--install-service",
+		"--service-name", "my-listen",
+		"--log-file", "listen.log",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		"--events=charge.captured",
+		"--events=charge.updated",
+		"--filter=data.object.metadata.tenant==acme",
+		"--filter-metadata=source=webstore",
+		"--forward-to=localhost:3000/webhook",
+		"--live=true",
+	}, serviceFlagArgs(lc.cmd.Flags()))
+}
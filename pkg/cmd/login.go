@@ -1,10 +1,18 @@
 package cmd
 
 import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
 	"github.com/stripe/stripe-cli/pkg/login"
 	"github.com/stripe/stripe-cli/pkg/stripe"
 	"github.com/stripe/stripe-cli/pkg/validators"
@@ -13,9 +21,19 @@ import (
 type loginCmd struct {
 	cmd              *cobra.Command
 	interactive      bool
+	refresh          bool
+	alias            string
+	withKey          string
+	validate         bool
+	sandbox          string
+	apiBaseURL       string
 	dashboardBaseURL string
 }
 
+// keyExpirationWarning is how long before the keys obtained with `stripe
+// login` expire commands start warning about it
+const keyExpirationWarning = 7 * 24 * time.Hour
+
 func newLoginCmd() *loginCmd {
 	lc := &loginCmd{}
 
@@ -23,22 +41,155 @@ func newLoginCmd() *loginCmd {
 		Use:   "login",
 		Args:  validators.NoArgs,
 		Short: "Login to your Stripe account",
-		Long:  `Login to your Stripe account to setup the CLI`,
-		RunE:  lc.runLoginCmd,
+		Long: `Login to your Stripe account to setup the CLI.
+
+The keys obtained by logging in expire after 90 days. Renew them before they
+expire with ` + "`stripe login --refresh`" + `, which opens the browser right away so that
+you only have to confirm the pairing code if you're still signed in to the
+Dashboard.
+
+Log in to several accounts by naming the profile of each with --alias, then
+switch between them with ` + "`stripe switch <alias>`" + `.
+
+On machines without a browser, like CI jobs, configure the profile with an API
+key with --with-key, reading it from stdin with ` + "`--with-key -`" + `. --validate checks
+the key against the API first, and that restricted keys have the permissions
+that listen, logs tail and trigger need.
+
+If the account has sandboxes, you're prompted for the one whose keys the
+profile uses, unless --sandbox names it. ` + "`stripe login --refresh`" + ` keeps the sandbox.`,
+		Example: `stripe login
+  stripe login --alias acme-staging
+  stripe switch acme-staging
+  stripe login --sandbox staging
+  echo "$STRIPE_KEY" | stripe login --with-key - --validate`,
+		RunE: lc.runLoginCmd,
 	}
 	lc.cmd.Flags().BoolVarP(&lc.interactive, "interactive", "i", false, "Run interactive configuration mode if you cannot open a browser")
+	lc.cmd.Flags().BoolVar(&lc.refresh, "refresh", false, "Renew the keys of the profile before they expire")
+	lc.cmd.Flags().StringVar(&lc.alias, "alias", "", "Name the profile of the account you log in to")
+	lc.cmd.Flags().StringVar(&lc.withKey, "with-key", "", "Configure the profile with an API key, or - to read it from stdin, without the browser")
+	lc.cmd.Flags().BoolVar(&lc.validate, "validate", false, "Check the key of --with-key against the API before configuring it")
+	lc.cmd.Flags().StringVar(&lc.sandbox, "sandbox", "", "Use the test mode keys of the sandbox of this name or ID")
 
 	// Hidden configuration flags, useful for dev/debugging
 	lc.cmd.Flags().StringVar(&lc.dashboardBaseURL, "dashboard-base", stripe.DefaultDashboardBaseURL, "Sets the dashboard base URL")
 	lc.cmd.Flags().MarkHidden("dashboard-base") // #nosec G104
+	lc.cmd.Flags().StringVar(&lc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	lc.cmd.Flags().MarkHidden("api-base") // #nosec G104
 
 	return lc
 }
 
 func (lc *loginCmd) runLoginCmd(cmd *cobra.Command, args []string) error {
-	if lc.interactive {
-		return login.InteractiveLogin(cmd.Context(), &Config)
+	if config.IsStateless() {
+		return config.ErrStateless
+	}
+
+	if lc.alias != "" {
+		if cmd.Flags().Changed("project-name") {
+			return errors.New("--alias and --project-name cannot be used together")
+		}
+
+		if err := config.ValidateProfileName(lc.alias); err != nil {
+			return err
+		}
+
+		Config.Profile.ProfileName = lc.alias
+	}
+
+	if lc.validate && lc.withKey == "" {
+		return errors.New("--validate can only be used with --with-key")
+	}
+
+	if lc.withKey != "" && (lc.interactive || lc.refresh) {
+		return errors.New("--with-key cannot be used with --interactive or --refresh")
+	}
+
+	if lc.sandbox != "" && (lc.withKey != "" || lc.interactive) {
+		return errors.New("--sandbox cannot be used with --with-key or --interactive, whose key selects the sandbox")
+	}
+	Config.Profile.SandboxName = lc.sandbox
+
+	var err error
+	switch {
+	case lc.withKey != "":
+		var apiKey string
+		apiKey, err = lc.readKey()
+		if err == nil {
+			err = login.KeyLogin(cmd.Context(), lc.apiBaseURL, &Config, apiKey, lc.validate)
+		}
+	case lc.interactive:
+		err = login.InteractiveLogin(cmd.Context(), &Config)
+	case lc.refresh:
+		err = login.Refresh(cmd.Context(), lc.dashboardBaseURL, &Config)
+	default:
+		err = login.Login(cmd.Context(), lc.dashboardBaseURL, &Config, os.Stdin)
+	}
+	if err != nil {
+		return err
+	}
+
+	if lc.alias != "" && lc.alias != Config.GetDefaultProfile() {
+		fmt.Printf("Run `stripe switch %s` to use this profile by default, or pass --project-name %s\n", lc.alias, lc.alias)
 	}
 
-	return login.Login(cmd.Context(), lc.dashboardBaseURL, &Config, os.Stdin)
+	return nil
+}
+
+// readKey returns the key of --with-key, read from stdin if it's -
+func (lc *loginCmd) readKey() (string, error) {
+	if lc.withKey != "-" {
+		return lc.withKey, nil
+	}
+
+	key, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+
+	return strings.TrimSpace(key), nil
+}
+
+// warnKeyExpiration warns when the keys of the profile obtained with `stripe
+// login` expire soon or have expired, unless the command is given a key
+func warnKeyExpiration(w io.Writer, cmd *cobra.Command, profile *config.Profile, now time.Time) {
+	switch cmd.Name() {
+	case "login", "logout", "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return
+	}
+
+	if profile.APIKey != "" || os.Getenv("STRIPE_API_KEY") != "" {
+		return
+	}
+
+	expiresAt, ok := profile.GetKeyExpiration()
+	if !ok || expiresAt.Sub(now) > keyExpirationWarning {
+		return
+	}
+
+	color := ansi.Color(w)
+	if !expiresAt.After(now) {
+		fmt.Fprintf(w, "%s the API keys of this profile expired on %s, run `stripe login` to get new keys\n",
+			color.Red("Error:"), expiresAt.Local().Format("January 2, 2006"))
+		return
+	}
+
+	fmt.Fprintf(w, "%s the API keys of this profile expire in %s, run `stripe login --refresh` to renew them\n",
+		color.Yellow("Warning:"), formatRemaining(expiresAt.Sub(now)))
+}
+
+// formatRemaining formats the time left before keys expire in days, or in
+// hours on the last day
+func formatRemaining(d time.Duration) string {
+	switch days := int(d.Hours() / 24); {
+	case days > 1:
+		return fmt.Sprintf("%d days", days)
+	case days == 1:
+		return "1 day"
+	case d >= 2*time.Hour:
+		return fmt.Sprintf("%d hours", int(d.Hours()))
+	default:
+		return "less than 2 hours"
+	}
 }
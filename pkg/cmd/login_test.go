@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+)
+
+func TestWarnKeyExpiration(t *testing.T) {
+	t.Setenv("STRIPE_API_KEY", "")
+
+	profilesFile := filepath.Join(t.TempDir(), "config.toml")
+	err := ioutil.WriteFile(profilesFile, []byte(`
+[default]
+  keys_expire_at = "2026-01-10T00:00:00Z"
+  test_mode_api_key = "sk_test_1234567890abcdefghij"
+`), 0600)
+	require.NoError(t, err)
+
+	viper.Reset()
+	viper.SetConfigFile(profilesFile)
+	defer viper.Reset()
+
+	profile := &config.Profile{ProfileName: "default"}
+	cmd := &cobra.Command{Use: "get"}
+	expiresAt := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected string
+	}{
+		{"far from expiry", expiresAt.Add(-30 * 24 * time.Hour), ""},
+		{"days before expiry", expiresAt.Add(-3*24*time.Hour - time.Hour), "expire in 3 days, run `stripe login --refresh`"},
+		{"hours before expiry", expiresAt.Add(-5 * time.Hour), "expire in 5 hours"},
+		{"expired", expiresAt.Add(time.Hour), "expired on"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var out bytes.Buffer
+			warnKeyExpiration(&out, cmd, profile, test.now)
+
+			if test.expected == "" {
+				require.Empty(t, out.String())
+			} else {
+				require.Contains(t, out.String(), test.expected)
+			}
+		})
+	}
+
+	// Commands given a key, and login itself, don't warn
+	var out bytes.Buffer
+	warnKeyExpiration(&out, &cobra.Command{Use: "login"}, profile, expiresAt)
+	warnKeyExpiration(&out, cmd, &config.Profile{ProfileName: "default", APIKey: "sk_test_other"}, expiresAt)
+	require.Empty(t, out.String())
+}
@@ -1,16 +1,20 @@
 package logs
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"reflect"
 	"strings"
 	"syscall"
+	"text/template"
 	"time"
 
 	"github.com/briandowns/spinner"
 	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 
 	"context"
@@ -25,6 +29,7 @@ import (
 )
 
 const outputFormatJSON = "JSON"
+const outputJSON = "json"
 
 // TailCmd wraps the configuration for the tail command
 type TailCmd struct {
@@ -32,8 +37,19 @@ type TailCmd struct {
 	cfg        *config.Config
 	Cmd        *cobra.Command
 	format     string
+	output     string
+	logFile    string
+	rotateSize string
+	rotateKeep int
+	export     []string
 	LogFilters *logTailing.LogFilters
 	noWSS      bool
+	since      time.Duration
+	last       int
+
+	alertThreshold string
+	alertCmd       string
+	alertWebhook   string
 }
 
 // NewTailCmd creates and initializes the tail command for the logs package
@@ -49,10 +65,29 @@ func NewTailCmd(config *config.Config) *TailCmd {
 		Short: "Tail API request logs from your Stripe requests.",
 		Long: `View API request logs in real-time as they are made to your Stripe account.
 Log tailing allows you to filter data similarly to the Stripe Dashboard; filter
-HTTP methods, IP addresses, paths, response status, and more.`,
+HTTP methods, IP addresses, paths, response status, and more.
+
+Platforms can also see the requests they make on behalf of their connected
+accounts with --account or --all-connected-accounts. The account of these
+requests is shown on each line.
+
+With --since or --last, the recent request logs are printed first, before
+the new ones, so you can see the requests that led to an issue. If your
+account can't list its recent request logs, a warning is printed and only
+the new ones are tailed.`,
 		Example: `stripe logs tail
   stripe logs tail --filter-http-methods GET
-  stripe logs tail --filter-status-code-type 4XX`,
+  stripe logs tail --filter-status-code-type 4XX
+  stripe logs tail --filter-request-path '/v1/payment_intents.*'
+  stripe logs tail --format '{{.Status}} {{.Method}} {{.Path}} {{.RequestID}} {{.Latency}}'
+  stripe logs tail --output json | jq .path
+  stripe logs tail --log-file requests.log --log-rotate-size 50MB --log-rotate-keep 5
+  stripe logs tail --export syslog://localhost:514 --export otlp://collector:4318
+  stripe logs tail --alert-threshold "5xx>10/min" --alert-cmd ./notify.sh
+  stripe logs tail --since 1h --filter-request-status FAILED
+  stripe logs tail --last 200
+  stripe logs tail --account acct_123 --account acct_456
+  stripe logs tail --all-connected-accounts`,
 		RunE: tailCmd.runTailCmd,
 	}
 
@@ -62,9 +97,40 @@ HTTP methods, IP addresses, paths, response status, and more.`,
 		"",
 		`Specifies the output format of request logs
 Acceptable values:
-	'JSON' - Output logs in JSON format`,
+	'JSON'     - Output logs in JSON format
+	a template - Output logs with a Go template, e.g. '{{.Status}} {{.Method}} {{.Path}}'.
+	             The fields are CreatedAt, Livemode, Account, Method, Path, RequestID, Status, Latency and Error`,
+	)
+	tailCmd.Cmd.Flags().StringVar(
+		&tailCmd.output,
+		"output",
+		"",
+		`Specifies how request logs are printed
+Acceptable values:
+	'json' - Print one JSON object per line, for consumption by other programs`,
 	)
 
+	tailCmd.Cmd.Flags().DurationVar(&tailCmd.since, "since", 0, "Print the request logs made during this duration before tailing, like 10m or 1h")
+	tailCmd.Cmd.Flags().IntVar(&tailCmd.last, "last", 0, fmt.Sprintf("Print this number of the most recent request logs before tailing, up to %d", logTailing.MaxBackfill))
+
+	tailCmd.Cmd.Flags().StringVar(&tailCmd.logFile, "log-file", "", "Also append the request logs to this file, without colors")
+	tailCmd.Cmd.Flags().StringVar(&tailCmd.rotateSize, "log-rotate-size", "", "Rotate --log-file when it reaches this size, e.g. 50MB (default: never rotate)")
+	tailCmd.Cmd.Flags().IntVar(&tailCmd.rotateKeep, "log-rotate-keep", 5, "Number of rotated files of --log-file to keep, named <log file>.1 (the most recent) to <log file>.N")
+
+	tailCmd.Cmd.Flags().StringSliceVar(&tailCmd.LogFilters.ConnectedAccounts, "account", []string{}, "*CONNECT ONLY* Also tail the request logs made on behalf of this connected account")
+	tailCmd.Cmd.Flags().BoolVar(&tailCmd.LogFilters.AllConnectedAccounts, "all-connected-accounts", false, "*CONNECT ONLY* Also tail the request logs made on behalf of all connected accounts")
+
+	tailCmd.Cmd.Flags().StringArrayVar(&tailCmd.export, "export", []string{}, `Also send the request logs in structured form to this destination, can be repeated
+Acceptable values:
+	'syslog://host:514'       - RFC 5424 messages over UDP, or over TCP with syslog+tcp://
+	'otlp://host:4318'        - OpenTelemetry logs with OTLP/HTTP in JSON, or over HTTPS with otlps://
+	'datadog://datadoghq.com' - The Datadog logs intake of the site, with the API key in DD_API_KEY`)
+
+	tailCmd.Cmd.Flags().StringVar(&tailCmd.alertThreshold, "alert-threshold", "", `Alert when more request logs than a count have a status during a sliding window, e.g. "5xx>10/min"
+or "402>3/30s". Requires --alert-cmd or --alert-webhook`)
+	tailCmd.Cmd.Flags().StringVar(&tailCmd.alertCmd, "alert-cmd", "", "Shell command run when --alert-threshold is breached, with the alert as JSON on its stdin")
+	tailCmd.Cmd.Flags().StringVar(&tailCmd.alertWebhook, "alert-webhook", "", "URL the alert is posted to as JSON when --alert-threshold is breached")
+
 	// Log filters
 	tailCmd.Cmd.Flags().StringSliceVar(
 		&tailCmd.LogFilters.FilterAccount,
@@ -87,7 +153,8 @@ Acceptable values:
 	'POST'   - HTTP post requests
 	'DELETE' - HTTP delete requests`,
 	)
-	tailCmd.Cmd.Flags().StringSliceVar(&tailCmd.LogFilters.FilterRequestPath, "filter-request-path", []string{}, "Filter request logs by request path")
+	tailCmd.Cmd.Flags().StringSliceVar(&tailCmd.LogFilters.FilterRequestPath, "filter-request-path", []string{}, "Filter request logs by request path, or by a regular expression matching the whole path like '/v1/payment_intents.*'")
+	tailCmd.Cmd.Flags().StringSliceVar(&tailCmd.LogFilters.FilterRequestID, "filter-request-id", []string{}, "Filter request logs by request ID")
 	tailCmd.Cmd.Flags().StringSliceVar(
 		&tailCmd.LogFilters.FilterRequestStatus,
 		"filter-request-status",
@@ -144,6 +211,26 @@ func withSIGTERMCancel(ctx context.Context, onCancel func()) context.Context {
 }
 
 func (tailCmd *TailCmd) runTailCmd(cmd *cobra.Command, args []string) error {
+	if tailCmd.output != "" && strings.ToLower(tailCmd.output) != outputJSON {
+		return fmt.Errorf("unsupported output %q, the only supported value is %q", tailCmd.output, outputJSON)
+	}
+
+	jsonOutput := strings.ToLower(tailCmd.output) == outputJSON
+	if jsonOutput && tailCmd.format != "" {
+		return fmt.Errorf("--output cannot be used with --format")
+	}
+
+	var tmpl *template.Template
+	if logTailing.IsTemplate(tailCmd.format) {
+		var err error
+		tmpl, err = logTailing.ParseTemplate(tailCmd.format)
+		if err != nil {
+			return err
+		}
+	} else if tailCmd.format != "" && strings.ToUpper(tailCmd.format) != outputFormatJSON {
+		return fmt.Errorf("unsupported format %q, expected %q or a template like '{{.Status}} {{.Method}} {{.Path}}'", tailCmd.format, outputFormatJSON)
+	}
+
 	err := tailCmd.validateArgs()
 	if err != nil {
 		return err
@@ -168,7 +255,67 @@ func (tailCmd *TailCmd) runTailCmd(cmd *cobra.Command, args []string) error {
 
 	logger := log.StandardLogger()
 
-	logtailingVisitor := createVisitor(logger, tailCmd.format)
+	var logFile io.Writer
+	if tailCmd.logFile != "" {
+		f, err := tailCmd.openLogFile()
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		logFile = f
+	}
+
+	exporters := make([]logTailing.Exporter, 0, len(tailCmd.export))
+	for _, destination := range tailCmd.export {
+		exporter, err := logTailing.NewExporter(destination)
+		if err != nil {
+			return err
+		}
+		defer exporter.Close()
+
+		exporters = append(exporters, exporter)
+	}
+
+	var alerter *logTailing.Alerter
+	if tailCmd.alertThreshold != "" {
+		threshold, err := logTailing.ParseThreshold(tailCmd.alertThreshold)
+		if err != nil {
+			return err
+		}
+
+		alerter = logTailing.NewAlerter(threshold, tailCmd.alertCmd, tailCmd.alertWebhook, func(err error) {
+			color := ansi.Color(os.Stderr)
+			fmt.Fprintf(os.Stderr, "%s %v\n", color.Yellow("Warning"), err)
+		})
+		defer alerter.Wait()
+	}
+
+	logtailingVisitor := createVisitor(logger, tailCmd.format, tmpl, jsonOutput, logFile, exporters, alerter)
+
+	if tailCmd.since > 0 || tailCmd.last > 0 {
+		backfill := &logTailing.Backfill{
+			APIBaseURL: tailCmd.apiBaseURL,
+			Key:        key,
+			Filters:    tailCmd.LogFilters,
+			Since:      tailCmd.since,
+			Last:       tailCmd.last,
+		}
+
+		elements, err := backfill.Fetch(cmd.Context())
+		if errors.Is(err, logTailing.ErrBackfillUnavailable) {
+			color := ansi.Color(os.Stderr)
+			fmt.Fprintf(os.Stderr, "%s --since and --last are unavailable: %v. Only the new request logs are tailed\n", color.Yellow("Warning"), err)
+		} else if err != nil {
+			return fmt.Errorf("Error while fetching the recent request logs: %v", err)
+		}
+
+		for _, el := range elements {
+			if err := el.Accept(logtailingVisitor); err != nil {
+				return err
+			}
+		}
+	}
 
 	logtailingOutCh := make(chan websocket.IElement)
 
@@ -200,7 +347,54 @@ func (tailCmd *TailCmd) runTailCmd(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// openLogFile opens --log-file, rotated as configured
+func (tailCmd *TailCmd) openLogFile() (*logTailing.RotatingFile, error) {
+	var maxSize int64
+	if tailCmd.rotateSize != "" {
+		var err error
+		maxSize, err = logTailing.ParseSize(tailCmd.rotateSize)
+		if err != nil {
+			return nil, fmt.Errorf("--log-rotate-size: %v", err)
+		}
+	}
+
+	return logTailing.OpenRotatingFile(afero.NewOsFs(), tailCmd.logFile, maxSize, tailCmd.rotateKeep)
+}
+
 func (tailCmd *TailCmd) validateArgs() error {
+	if tailCmd.logFile == "" && (tailCmd.rotateSize != "" || tailCmd.Cmd.Flags().Changed("log-rotate-keep")) {
+		return fmt.Errorf("--log-rotate-size and --log-rotate-keep require --log-file")
+	}
+
+	if tailCmd.rotateKeep < 0 {
+		return fmt.Errorf("--log-rotate-keep must be 0 or more")
+	}
+
+	if tailCmd.rotateSize != "" {
+		if _, err := logTailing.ParseSize(tailCmd.rotateSize); err != nil {
+			return fmt.Errorf("--log-rotate-size: %v", err)
+		}
+	}
+
+	if tailCmd.alertThreshold != "" {
+		if tailCmd.alertCmd == "" && tailCmd.alertWebhook == "" {
+			return fmt.Errorf("--alert-threshold requires --alert-cmd or --alert-webhook")
+		}
+		if _, err := logTailing.ParseThreshold(tailCmd.alertThreshold); err != nil {
+			return err
+		}
+	} else if tailCmd.alertCmd != "" || tailCmd.alertWebhook != "" {
+		return fmt.Errorf("--alert-cmd and --alert-webhook require --alert-threshold")
+	}
+
+	if tailCmd.since < 0 {
+		return fmt.Errorf("--since must be a positive duration")
+	}
+
+	if tailCmd.last < 0 || tailCmd.last > logTailing.MaxBackfill {
+		return fmt.Errorf("--last must be between 1 and %d", logTailing.MaxBackfill)
+	}
+
 	err := validators.CallNonEmptyArray(validators.Account, tailCmd.LogFilters.FilterAccount)
 	if err != nil {
 		return err
@@ -231,6 +425,11 @@ func (tailCmd *TailCmd) validateArgs() error {
 		return err
 	}
 
+	err = logTailing.ValidateFilters(tailCmd.LogFilters)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -245,17 +444,27 @@ func (tailCmd *TailCmd) convertArgs() error {
 	return nil
 }
 
-func createVisitor(logger *log.Logger, format string) *websocket.Visitor {
+// createVisitor returns the visitor printing request logs: with tmpl if it's
+// set, as JSON lines with jsonOutput, and in format otherwise. They're also
+// written to logFile if it's set, sent to the exporters, and observed by
+// alerter if it's set.
+func createVisitor(logger *log.Logger, format string, tmpl *template.Template, jsonOutput bool, logFile io.Writer, exporters []logtailing.Exporter, alerter *logtailing.Alerter) *websocket.Visitor {
 	var s *spinner.Spinner
 
+	// Warnings go to stderr with --output json so stdout stays parseable
+	warningOut := os.Stdout
+	if jsonOutput {
+		warningOut = os.Stderr
+	}
+
 	return &websocket.Visitor{
 		VisitError: func(ee websocket.ErrorElement) error {
 			ansi.StopSpinner(s, "", logger.Out)
 			return ee.Error
 		},
 		VisitWarning: func(we websocket.WarningElement) error {
-			color := ansi.Color(os.Stdout)
-			fmt.Printf("%s %s\n", color.Yellow("Warning"), we.Warning)
+			color := ansi.Color(warningOut)
+			fmt.Fprintf(warningOut, "%s %s\n", color.Yellow("Warning"), we.Warning)
 			return nil
 		},
 		VisitStatus: func(se websocket.StateElement) error {
@@ -277,46 +486,89 @@ func createVisitor(logger *log.Logger, format string) *websocket.Visitor {
 				return fmt.Errorf("VisitData received unexpected type for DataElement, got %T expected %T", de, logtailing.EventPayload{})
 			}
 
-			if strings.ToUpper(format) == outputFormatJSON {
-				fmt.Println(ansi.ColorizeJSON(de.Marshaled, false, os.Stdout))
-				return nil
+			if err := printLog(os.Stdout, de, log, format, tmpl, jsonOutput); err != nil {
+				return err
 			}
 
-			coloredStatus := ansi.ColorizeStatus(log.Status)
-
-			url := urlForRequestID(&log)
-			requestLink := ansi.Linkify(log.RequestID, url, os.Stdout)
-
-			if log.URL == "" {
-				log.URL = "[View path in dashboard]"
+			if alerter != nil && alerter.Observe(logtailing.NewLog(log)) {
+				color := ansi.Color(os.Stderr)
+				fmt.Fprintf(os.Stderr, "%s threshold breached, the last request was %s\n", color.Red("Alert"), log.RequestID)
 			}
 
-			exampleLayout := "2006-01-02 15:04:05"
-			localTime := time.Unix(int64(log.CreatedAt), 0).Format(exampleLayout)
-
-			color := ansi.Color(os.Stdout)
-			outputStr := fmt.Sprintf("%s [%d] %s %s [%s]", color.Faint(localTime), coloredStatus, log.Method, log.URL, requestLink)
-			fmt.Println(outputStr)
-
-			errorValues := reflect.ValueOf(&log.Error).Elem()
-			errType := errorValues.Type()
-
-			for i := 0; i < errorValues.NumField(); i++ {
-				fieldValue := errorValues.Field(i).Interface()
-				if fieldValue != "" {
-					fieldName := errType.Field(i).Name
-					if fieldName == "ErrorInsight" {
-						fieldName = fmt.Sprintf("%s%s", color.Bold("!!"), color.Bold(fieldName))
-						fieldValue = color.Bold(fieldValue)
-					}
-					fmt.Printf("%s: %s\n", fieldName, fieldValue)
+			// Failing to export a log doesn't stop the tailing
+			for _, exporter := range exporters {
+				if err := exporter.Export(context.Background(), logtailing.NewLog(log)); err != nil {
+					color := ansi.Color(os.Stderr)
+					fmt.Fprintf(os.Stderr, "%s failed to export the request log %s: %v\n", color.Yellow("Warning"), log.RequestID, err)
 				}
 			}
+
+			if logFile != nil {
+				return printLog(logFile, de, log, format, tmpl, jsonOutput)
+			}
+
 			return nil
 		},
 	}
 }
 
+// printLog prints a request log to out, in the output format. Colors are only
+// used if out supports them.
+func printLog(out io.Writer, de websocket.DataElement, log logtailing.EventPayload, format string, tmpl *template.Template, jsonOutput bool) error {
+	if jsonOutput {
+		return logtailing.WriteJSON(out, log)
+	}
+
+	if tmpl != nil {
+		return logtailing.WriteTemplate(out, tmpl, log)
+	}
+
+	if strings.ToUpper(format) == outputFormatJSON {
+		_, err := fmt.Fprintln(out, ansi.ColorizeJSON(de.Marshaled, false, out))
+		return err
+	}
+
+	coloredStatus := ansi.ColorizeStatusFor(out, log.Status)
+
+	url := urlForRequestID(&log)
+	requestLink := ansi.Linkify(log.RequestID, url, out)
+
+	if log.URL == "" {
+		log.URL = "[View path in dashboard]"
+	}
+
+	exampleLayout := "2006-01-02 15:04:05"
+	localTime := time.Unix(int64(log.CreatedAt), 0).Format(exampleLayout)
+
+	color := ansi.Color(out)
+
+	// Requests made on behalf of connected accounts are prefixed with the account
+	account := ""
+	if log.Account != "" {
+		account = fmt.Sprintf("%s ", color.Cyan(log.Account))
+	}
+
+	outputStr := fmt.Sprintf("%s [%d] %s%s %s [%s]", color.Faint(localTime), coloredStatus, account, log.Method, log.URL, requestLink)
+	fmt.Fprintln(out, outputStr)
+
+	errorValues := reflect.ValueOf(&log.Error).Elem()
+	errType := errorValues.Type()
+
+	for i := 0; i < errorValues.NumField(); i++ {
+		fieldValue := errorValues.Field(i).Interface()
+		if fieldValue != "" {
+			fieldName := errType.Field(i).Name
+			if fieldName == "ErrorInsight" {
+				fieldName = fmt.Sprintf("%s%s", color.Bold("!!"), color.Bold(fieldName))
+				fieldValue = color.Bold(fieldValue)
+			}
+			fmt.Fprintf(out, "%s: %s\n", fieldName, fieldValue)
+		}
+	}
+
+	return nil
+}
+
 func urlForRequestID(payload *logtailing.EventPayload) string {
 	maybeTest := ""
 	if !payload.Livemode {
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/stripemock"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type mockCmd struct {
+	cmd *cobra.Command
+}
+
+func newMockCmd(cfg *config.Config) *mockCmd {
+	mc := &mockCmd{
+		cmd: &cobra.Command{
+			Use:   "mock",
+			Args:  validators.NoArgs,
+			Short: "Run stripe-mock in the background for offline development",
+			Long: fmt.Sprintf(`Run stripe-mock %s, a mock of the Stripe API, in the background.
+
+Commands run with the global --mock flag send their API requests, like those
+of stripe get, stripe post, stripe trigger and stripe fixtures, to it instead
+of Stripe. It responds like the API without storing anything, for offline
+development and deterministic CI runs. An API key isn't needed.`, stripemock.Version),
+			Example: `stripe mock start
+  stripe customers create --mock
+  stripe mock stop`,
+		},
+	}
+
+	mc.cmd.AddCommand(newMockStartCmd(cfg).cmd)
+	mc.cmd.AddCommand(newMockStopCmd(cfg).cmd)
+	mc.cmd.AddCommand(newMockStatusCmd(cfg).cmd)
+
+	return mc
+}
+
+// mockDir returns the folder stripe-mock is installed in, which also keeps
+// the state of the running server
+func mockDir(cfg *config.Config) string {
+	return filepath.Join(cfg.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")), "stripe-mock")
+}
+
+type mockStartCmd struct {
+	cmd *cobra.Command
+	cfg *config.Config
+
+	port   int
+	docker bool
+}
+
+func newMockStartCmd(cfg *config.Config) *mockStartCmd {
+	msc := &mockStartCmd{cfg: cfg}
+
+	msc.cmd = &cobra.Command{
+		Use:   "start",
+		Args:  validators.NoArgs,
+		Short: "Download and start stripe-mock in the background",
+		Example: `stripe mock start
+  stripe mock start --port 12111 --docker`,
+		RunE: msc.runMockStartCmd,
+	}
+
+	msc.cmd.Flags().IntVar(&msc.port, "port", stripemock.DefaultPort, "Port to run stripe-mock on")
+	msc.cmd.Flags().BoolVar(&msc.docker, "docker", false, "Run the stripe/stripe-mock container with Docker instead of downloading the binary")
+
+	return msc
+}
+
+func (msc *mockStartCmd) runMockStartCmd(cmd *cobra.Command, args []string) error {
+	state, err := stripemock.Start(cmd.Context(), mockDir(msc.cfg), stripemock.StartOptions{
+		Port:   msc.port,
+		Docker: msc.docker,
+	})
+	if err != nil {
+		return err
+	}
+
+	color := ansi.Color(os.Stdout)
+	fmt.Printf("%s stripe-mock %s is running at %s\n", color.Green("✔"), state.Version, ansi.Bold(state.URL()))
+	fmt.Println("Run commands with --mock to send their API requests to it, and `stripe mock stop` to stop it.")
+
+	return nil
+}
+
+type mockStopCmd struct {
+	cmd *cobra.Command
+	cfg *config.Config
+}
+
+func newMockStopCmd(cfg *config.Config) *mockStopCmd {
+	msc := &mockStopCmd{cfg: cfg}
+
+	msc.cmd = &cobra.Command{
+		Use:   "stop",
+		Args:  validators.NoArgs,
+		Short: "Stop stripe-mock",
+		RunE:  msc.runMockStopCmd,
+	}
+
+	return msc
+}
+
+func (msc *mockStopCmd) runMockStopCmd(cmd *cobra.Command, args []string) error {
+	if err := stripemock.Stop(mockDir(msc.cfg)); err != nil {
+		return err
+	}
+
+	fmt.Println("Stopped stripe-mock")
+
+	return nil
+}
+
+type mockStatusCmd struct {
+	cmd *cobra.Command
+	cfg *config.Config
+}
+
+func newMockStatusCmd(cfg *config.Config) *mockStatusCmd {
+	msc := &mockStatusCmd{cfg: cfg}
+
+	msc.cmd = &cobra.Command{
+		Use:   "status",
+		Args:  validators.NoArgs,
+		Short: "Show whether stripe-mock is running",
+		RunE:  msc.runMockStatusCmd,
+	}
+
+	return msc
+}
+
+func (msc *mockStatusCmd) runMockStatusCmd(cmd *cobra.Command, args []string) error {
+	state, err := stripemock.Status(mockDir(msc.cfg))
+	if err == stripemock.ErrNotRunning {
+		fmt.Println("stripe-mock isn't running")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	runsIn := fmt.Sprintf("process %d", state.PID)
+	if state.Container != "" {
+		runsIn = "container " + state.Container
+	}
+
+	fmt.Printf("stripe-mock %s is running at %s (%s), since %s\n", state.Version, ansi.Bold(state.URL()), runsIn, state.StartedAt.Format(timeLayout))
+
+	return nil
+}
+
+// useMock sends the API requests of the command to the running stripe-mock
+// server, with a test mode key unless one is given with --api-key
+func useMock(cmd *cobra.Command) error {
+	state, err := stripemock.Status(mockDir(&Config))
+	if err != nil {
+		return err
+	}
+
+	mockURL, err := url.Parse(state.URL())
+	if err != nil {
+		return err
+	}
+
+	stripe.SetMockBaseURL(mockURL)
+
+	if !cmd.Flags().Changed("api-key") {
+		Config.Profile.APIKey = stripemock.APIKey
+	}
+
+	return nil
+}
@@ -3,10 +3,12 @@ package cmd
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/stripe/stripe-cli/pkg/config"
 	"github.com/stripe/stripe-cli/pkg/open"
 	"github.com/stripe/stripe-cli/pkg/version"
 )
@@ -86,6 +88,32 @@ func padName(name string, length int) string {
 	return b.String()
 }
 
+// expandCustomShortcut replaces the variables of a shortcut defined in the
+// config file: {{account}} with the ID of the account of the profile,
+// {{livemode}} with true or false, and {{test}} with /test in test mode,
+// for the Dashboard paths that have it, like the built-in shortcuts
+func expandCustomShortcut(name, url string, profile *config.Profile, livemode bool) (string, error) {
+	account := ""
+	if strings.Contains(url, "{{account}}") {
+		var err error
+		account, err = profile.GetAccountID()
+		if err != nil || account == "" {
+			return "", fmt.Errorf("the shortcut ‘%s’ links to the account, but the profile has none. Run `stripe login` first", name)
+		}
+	}
+
+	maybeTestMode := "/test"
+	if livemode {
+		maybeTestMode = ""
+	}
+
+	return strings.NewReplacer(
+		"{{account}}", account,
+		"{{livemode}}", strconv.FormatBool(livemode),
+		"{{test}}", maybeTestMode,
+	).Replace(url), nil
+}
+
 type openCmd struct {
 	cmd *cobra.Command
 }
@@ -97,7 +125,20 @@ func newOpenCmd() *openCmd {
 		ValidArgs: openNames(),
 		Short:     "Quickly open Stripe pages",
 		Long: `The open command provices shortcuts to quickly let you open pages to Stripe with
-in your browser. A full list of support shortcuts can be seen with 'stripe open --list'`,
+in your browser. A full list of support shortcuts can be seen with 'stripe open --list'
+
+More shortcuts can be defined in the open.shortcuts table of the config file,
+for every profile or for one, e.g. to link to runbooks or Dashboard pages:
+
+  [open.shortcuts]
+    runbook = "https://wiki.example.com/runbooks/payments"
+
+  [default.open.shortcuts]
+    payouts = "https://dashboard.stripe.com/{{account}}{{test}}/payouts"
+
+{{account}} is replaced with the ID of the account of the profile, {{livemode}}
+with true when --live is set and false otherwise, and {{test}} with /test in
+test mode.`,
 		Example: `stripe open --list
   stripe open api
   stripe open docs
@@ -123,12 +164,24 @@ func (oc *openCmd) runOpenCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	maybeTestMode := ""
+	if !livemode {
+		maybeTestMode = "/test"
+	}
+
+	custom := Config.Profile.GetOpenShortcuts()
+
 	if list || len(args) == 0 {
 		fmt.Println("open quickly opens Stripe pages. To use, run 'stripe open <shortcut>'.")
 		fmt.Println("open supports the following shortcuts:")
 		fmt.Println()
 
 		shortcuts := openNames()
+		for name := range custom {
+			if _, ok := nameURLmap[name]; !ok {
+				shortcuts = append(shortcuts, name)
+			}
+		}
 		sort.Strings(shortcuts)
 
 		longest := getLongestShortcut(shortcuts)
@@ -137,14 +190,19 @@ func (oc *openCmd) runOpenCmd(cmd *cobra.Command, args []string) error {
 		fmt.Printf("%s%s\n", padName("--------", longest), "    ---------")
 
 		for _, shortcut := range shortcuts {
-			maybeTestMode := ""
-			if !livemode {
-				maybeTestMode = "/test"
-			}
-
-			url := nameURLmap[shortcut]
-			if strings.Contains(url, "%s") {
-				url = fmt.Sprintf(url, maybeTestMode)
+			var url string
+			if template, ok := custom[shortcut]; ok {
+				// Shortcuts linking to the account are listed as
+				// defined when the profile has no account
+				url, err = expandCustomShortcut(shortcut, template, &Config.Profile, livemode)
+				if err != nil {
+					url = template
+				}
+			} else {
+				url = nameURLmap[shortcut]
+				if strings.Contains(url, "%s") {
+					url = fmt.Sprintf(url, maybeTestMode)
+				}
 			}
 
 			paddedName := padName(shortcut, longest)
@@ -156,17 +214,18 @@ func (oc *openCmd) runOpenCmd(cmd *cobra.Command, args []string) error {
 
 	version.CheckLatestVersion()
 
-	if url, ok := nameURLmap[args[0]]; ok {
-		livemode, err := cmd.Flags().GetBool("live")
+	// The shortcuts of the config file take precedence over the built-in
+	// ones
+	if template, ok := custom[args[0]]; ok {
+		url, err := expandCustomShortcut(args[0], template, &Config.Profile, livemode)
 		if err != nil {
 			return err
 		}
 
-		maybeTestMode := ""
-		if !livemode {
-			maybeTestMode = "/test"
-		}
+		return open.Browser(url)
+	}
 
+	if url, ok := nameURLmap[args[0]]; ok {
 		if strings.Contains(url, "%s") {
 			err = open.Browser(fmt.Sprintf(url, maybeTestMode))
 		} else {
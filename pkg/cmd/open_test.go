@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/config"
 )
 
 func TestGetLongestShortcut(t *testing.T) {
@@ -17,3 +19,19 @@ func TestNamePadding(t *testing.T) {
 	require.Equal(t, padName("leela", 6), "leela ")
 	require.Equal(t, padName("bender", 6), "bender")
 }
+
+func TestExpandCustomShortcut(t *testing.T) {
+	profile := &config.Profile{ProfileName: "tests", AccountID: "acct_123"}
+
+	url, err := expandCustomShortcut("payouts", "https://dashboard.stripe.com/{{account}}{{test}}/payouts", profile, false)
+	require.NoError(t, err)
+	require.Equal(t, "https://dashboard.stripe.com/acct_123/test/payouts", url)
+
+	url, err = expandCustomShortcut("payouts", "https://dashboard.stripe.com/{{account}}{{test}}/payouts", profile, true)
+	require.NoError(t, err)
+	require.Equal(t, "https://dashboard.stripe.com/acct_123/payouts", url)
+
+	url, err = expandCustomShortcut("runbook", "https://wiki.example.com/runbook?live={{livemode}}", &config.Profile{}, true)
+	require.NoError(t, err)
+	require.Equal(t, "https://wiki.example.com/runbook?live=true", url)
+}
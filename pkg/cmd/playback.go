@@ -15,6 +15,7 @@ import (
 
 	"github.com/stripe/stripe-cli/pkg/playback"
 	"github.com/stripe/stripe-cli/pkg/proxy"
+	"github.com/stripe/stripe-cli/pkg/stripeauth"
 	"github.com/stripe/stripe-cli/pkg/validators"
 	"github.com/stripe/stripe-cli/pkg/version"
 	"github.com/stripe/stripe-cli/pkg/websocket"
@@ -245,7 +246,7 @@ func runListen(cmd *cobra.Command, address string, wg *sync.WaitGroup) error {
 	})
 
 	logger := log.StandardLogger()
-	proxyVisitor := createVisitor(logger, "", false)
+	proxyVisitor := createVisitor(logger, "", false, false)
 	proxyOutCh := make(chan websocket.IElement)
 
 	p, err := proxy.Init(ctx, &proxy.Config{
@@ -257,7 +258,7 @@ func runListen(cmd *cobra.Command, address string, wg *sync.WaitGroup) error {
 		ForwardConnectHeaders: []string{},
 		UseConfiguredWebhooks: false,
 		APIBaseURL:            "",
-		WebSocketFeature:      webhooksWebSocketFeature,
+		WebSocketFeature:      stripeauth.FeatureWebhooks,
 		PrintJSON:             false,
 		UseLatestAPIVersion:   false,
 		SkipVerify:            false,
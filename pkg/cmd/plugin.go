@@ -15,14 +15,19 @@ func newPluginCmd() *pluginCmd {
 	pc := &pluginCmd{}
 
 	pc.cmd = &cobra.Command{
-		Use:    "plugin",
-		Hidden: true,
-		Args:   validators.ExactArgs(1),
-		Short:  "Interact with Stripe CLI plugins",
-		Long:   "Interact with Stripe CLI plugins.",
+		Use:   "plugin",
+		Args:  validators.ExactArgs(1),
+		Short: "Interact with Stripe CLI plugins",
+		Long: `Interact with Stripe CLI plugins. Installed plugins run as stripe <plugin>.
+
+Besides Stripe's plugins, plugins of third parties can be installed from the
+URL of their manifest, to share the workflows of a team. They run without the
+credentials of the CLI: the API requests their manifest declares are proxied
+through the CLI, once you grant them on their first run.`,
 	}
 
 	pc.cmd.AddCommand(plugin.NewInstallCmd(&Config).Cmd)
+	pc.cmd.AddCommand(plugin.NewListCmd(&Config).Cmd)
 	pc.cmd.AddCommand(plugin.NewUpgradeCmd(&Config).Cmd)
 	pc.cmd.AddCommand(plugin.NewUninstallCmd(&Config).Cmd)
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"runtime"
 	"strings"
 	"syscall"
 
@@ -37,7 +38,17 @@ func NewInstallCmd(config *config.Config) *InstallCmd {
 		Args:  validators.ExactArgs(1),
 		Short: "Install a Stripe CLI plugin",
 		Long: `Install a Stripe CLI plugin. To download a specific version, run stripe install [plugin_name]@[version].
-			By default, the most recent version will be installed.`,
+			By default, the most recent version will be installed.
+
+Installing a specific version pins the plugin to it, so that upgrade keeps it
+at that version, until the plugin is installed again without a version.
+
+Plugins of third parties are installed from the URL of their manifest, which
+has the format of Stripe's manifest with the URL of each release. The binaries
+of the plugins are verified against the checksums of the manifest.`,
+		Example: `stripe plugin install apps
+  stripe plugin install apps@1.2.3
+  stripe plugin install https://example.com/stripe-plugins.toml`,
 		RunE: ic.runInstallCmd,
 	}
 
@@ -58,33 +69,49 @@ func parseInstallArg(arg string) (string, string) {
 	return plugin, version
 }
 
+// isManifestURL returns whether the argument of install is the URL of the
+// manifest of a third party, rather than the name of a plugin
+func isManifestURL(arg string) bool {
+	return strings.HasPrefix(arg, "https://") || strings.HasPrefix(arg, "http://")
+}
+
 func (ic *InstallCmd) runInstallCmd(cmd *cobra.Command, args []string) error {
-	// Refresh the plugin before proceeding
-	err := plugins.RefreshPluginManifest(cmd.Context(), ic.cfg, ic.fs, stripe.DefaultAPIBaseURL)
+	ctx := withSIGTERMCancel(cmd.Context(), func() {
+		log.WithFields(log.Fields{
+			"prefix": "cmd.installCmd.runInstallCmd",
+		}).Debug("Ctrl+C received, cleaning up...")
+	})
 
-	if err != nil {
-		return err
+	if isManifestURL(args[0]) {
+		return ic.installManifest(ctx, args[0])
 	}
 
+	// Refresh the plugin before proceeding
+	refreshErr := plugins.RefreshPluginManifest(cmd.Context(), ic.cfg, ic.fs, stripe.DefaultAPIBaseURL)
+
 	pluginName, version := parseInstallArg(args[0])
 	plugin, err := plugins.LookUpPlugin(cmd.Context(), ic.cfg, ic.fs, pluginName)
 
+	// The plugins of third parties don't need Stripe's manifest
+	if refreshErr != nil && (err != nil || !plugin.IsThirdParty()) {
+		return refreshErr
+	}
+
 	if err != nil {
 		return err
 	}
 
-	if len(version) == 0 {
+	pinned := len(version) > 0
+	if !pinned {
 		version = plugin.LookUpLatestVersion()
 	}
 
-	ctx := withSIGTERMCancel(cmd.Context(), func() {
-		log.WithFields(log.Fields{
-			"prefix": "cmd.installCmd.runInstallCmd",
-		}).Debug("Ctrl+C received, cleaning up...")
-	})
-
 	err = plugin.Install(ctx, ic.cfg, ic.fs, version, stripe.DefaultAPIBaseURL)
 
+	if err == nil {
+		err = setPinnedVersion(ic.cfg, plugin.Shortname, version, pinned)
+	}
+
 	if err == nil {
 		color := ansi.Color(os.Stdout)
 		fmt.Println(color.Green("✔ installation complete."))
@@ -93,6 +120,53 @@ func (ic *InstallCmd) runInstallCmd(cmd *cobra.Command, args []string) error {
 	return err
 }
 
+// installManifest adds the plugins of the manifest of a third party, and
+// installs their latest version
+func (ic *InstallCmd) installManifest(ctx context.Context, url string) error {
+	added, err := plugins.AddThirdPartyManifest(ic.cfg, ic.fs, url)
+	if err != nil {
+		return err
+	}
+
+	color := ansi.Color(os.Stdout)
+
+	for _, plugin := range added {
+		version := plugin.LookUpLatestVersion()
+		if version == "" {
+			return fmt.Errorf("the plugin %s has no release for %s/%s", plugin.Shortname, runtime.GOOS, runtime.GOARCH)
+		}
+
+		if err := plugin.Install(ctx, ic.cfg, ic.fs, version, stripe.DefaultAPIBaseURL); err != nil {
+			return err
+		}
+
+		if err := setPinnedVersion(ic.cfg, plugin.Shortname, version, false); err != nil {
+			return err
+		}
+
+		fmt.Println(color.Green(fmt.Sprintf("✔ installed %s v%s, run it with `stripe %s`.", plugin.Shortname, version, plugin.Shortname)))
+	}
+
+	return nil
+}
+
+// setPinnedVersion pins a plugin to a version, or unpins it
+func setPinnedVersion(cfg *config.Config, name, version string, pin bool) error {
+	pins := cfg.GetPinnedPlugins()
+
+	if _, ok := pins[name]; !ok && !pin {
+		return nil
+	}
+
+	if pin {
+		pins[name] = version
+	} else {
+		delete(pins, name)
+	}
+
+	return cfg.WriteConfigField("pinned_plugins", pins)
+}
+
 func withSIGTERMCancel(ctx context.Context, onCancel func()) context.Context {
 	// Create a context that will be canceled when Ctrl+C is pressed
 	ctx, cancel := context.WithCancel(ctx)
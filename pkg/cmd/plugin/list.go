@@ -0,0 +1,77 @@
+package plugin
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/plugins"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+// ListCmd is the struct used for configuring the plugin list command
+type ListCmd struct {
+	cfg *config.Config
+	Cmd *cobra.Command
+	fs  afero.Fs
+}
+
+// NewListCmd creates a new command for listing plugins
+func NewListCmd(config *config.Config) *ListCmd {
+	lc := &ListCmd{}
+	lc.fs = afero.NewOsFs()
+	lc.cfg = config
+
+	lc.Cmd = &cobra.Command{
+		Use:   "list",
+		Args:  validators.NoArgs,
+		Short: "List the Stripe CLI plugins",
+		Long:  "List the plugins that can be installed, with their installed and latest versions.",
+		RunE:  lc.runListCmd,
+	}
+
+	return lc
+}
+
+func (lc *ListCmd) runListCmd(cmd *cobra.Command, args []string) error {
+	// The local manifest is used when it can't be refreshed
+	plugins.RefreshPluginManifest(cmd.Context(), lc.cfg, lc.fs, stripe.DefaultAPIBaseURL) // #nosec G104
+
+	pluginList, err := plugins.GetPluginList(cmd.Context(), lc.cfg, lc.fs)
+	if err != nil {
+		return err
+	}
+
+	printPluginList(os.Stdout, pluginList.Plugins, lc.cfg, lc.fs, lc.cfg.GetPinnedPlugins())
+
+	return nil
+}
+
+// printPluginList prints a table of plugins, with their versions and where
+// they come from
+func printPluginList(out io.Writer, list []plugins.Plugin, cfg config.IConfig, fs afero.Fs, pins map[string]string) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tINSTALLED\tLATEST\tSOURCE\tDESCRIPTION")
+	for _, plugin := range list {
+		installed := plugin.InstalledVersion(cfg, fs)
+		if installed == "" {
+			installed = "-"
+		} else if _, ok := pins[plugin.Shortname]; ok {
+			installed += " (pinned)"
+		}
+
+		source := "stripe"
+		if plugin.IsThirdParty() {
+			source = plugin.ManifestURL
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", plugin.Shortname, installed, plugin.LookUpLatestVersion(), source, plugin.Shortdesc)
+	}
+	w.Flush()
+}
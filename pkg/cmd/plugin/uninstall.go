@@ -29,11 +29,12 @@ func NewUninstallCmd(config *config.Config) *UninstallCmd {
 	uc.cfg = config
 
 	uc.Cmd = &cobra.Command{
-		Use:   "uninstall",
-		Args:  validators.ExactArgs(1),
-		Short: "Uninstall a Stripe CLI plugin",
-		Long:  "Uninstall a Stripe CLI plugin.",
-		RunE:  uc.runUninstallCmd,
+		Use:     "uninstall",
+		Aliases: []string{"remove"},
+		Args:    validators.ExactArgs(1),
+		Short:   "Uninstall a Stripe CLI plugin",
+		Long:    "Uninstall a Stripe CLI plugin.",
+		RunE:    uc.runUninstallCmd,
 	}
 
 	return uc
@@ -54,6 +55,10 @@ func (uc *UninstallCmd) runUninstallCmd(cmd *cobra.Command, args []string) error
 
 	err = plugin.Uninstall(ctx, uc.cfg, uc.fs)
 
+	if err == nil {
+		err = setPinnedVersion(uc.cfg, plugin.Shortname, "", false)
+	}
+
 	if err == nil {
 		color := ansi.Color(os.Stdout)
 		successMsg := fmt.Sprintf("✔ %s has been uninstalled.", plugin.Shortname)
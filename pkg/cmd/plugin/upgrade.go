@@ -20,6 +20,8 @@ type UpgradeCmd struct {
 	cfg *config.Config
 	Cmd *cobra.Command
 	fs  afero.Fs
+
+	unpin bool
 }
 
 // NewUpgradeCmd creates a new command for upgrading plugins
@@ -36,6 +38,8 @@ func NewUpgradeCmd(config *config.Config) *UpgradeCmd {
 		RunE:  uc.runUpgradeCmd,
 	}
 
+	uc.Cmd.Flags().BoolVar(&uc.unpin, "unpin", false, "Upgrade the plugin even if it's pinned to a version, and unpin it")
+
 	return uc
 }
 
@@ -55,10 +59,30 @@ func (uc *UpgradeCmd) runUpgradeCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if pinned, ok := uc.cfg.GetPinnedPlugins()[plugin.Shortname]; ok && !uc.unpin {
+		return fmt.Errorf("%s is pinned to v%s. Run `stripe plugin upgrade %s --unpin` to upgrade it anyway", plugin.Shortname, pinned, plugin.Shortname)
+	}
+
+	// Refresh the manifest of the third party the plugin comes from
+	if plugin.IsThirdParty() {
+		if _, err := plugins.AddThirdPartyManifest(uc.cfg, uc.fs, plugin.ManifestURL); err != nil {
+			return err
+		}
+
+		plugin, err = plugins.LookUpPlugin(cmd.Context(), uc.cfg, uc.fs, args[0])
+		if err != nil {
+			return err
+		}
+	}
+
 	version := plugin.LookUpLatestVersion()
 
 	err = plugin.Install(ctx, uc.cfg, uc.fs, version, stripe.DefaultAPIBaseURL)
 
+	if err == nil {
+		err = setPinnedVersion(uc.cfg, plugin.Shortname, version, false)
+	}
+
 	if err == nil {
 		color := ansi.Color(os.Stdout)
 		successMsg := fmt.Sprintf("✔ upgrade to v%s complete.", version)
@@ -76,6 +76,10 @@ func (ptc *pluginTemplateCmd) runPluginCmd(cmd *cobra.Command, args []string) er
 			return errors.New("Install failed due to API key not configured. Please run `stripe login` or specify the `--api-key`")
 		}
 
+		if errors.Is(err, plugins.ErrPermissionsNotGranted) {
+			return err
+		}
+
 		log.WithFields(log.Fields{
 			"prefix": "pluginTemplateCmd.runPluginCmd",
 		}).Debug(fmt.Sprintf("Plugin command '%s' exited with error: %s", plugin.Shortname, err))
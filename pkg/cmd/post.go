@@ -18,6 +18,7 @@ func newPostCmd() *postCmd {
 
 	gc.reqs.Method = http.MethodPost
 	gc.reqs.Profile = &Config.Profile
+	gc.reqs.OnResponse = recordResponse
 	gc.reqs.Cmd = &cobra.Command{
 		Use:   "post <path>",
 		Args:  validators.ExactArgs(1),
@@ -32,7 +33,16 @@ https://stripe.com/docs/api
 		Example: `stripe post /payment_intents \
     -d amount=2000 \
     -d currency=usd \
-    -d "payment_method_types[]=card"`,
+    -d "payment_method_types[]=card"
+  stripe post /v1/customers -H "Stripe-Context: acct_123"
+  stripe post /v1/customers --json-body '{"metadata": {"order_id": "6735"}}'
+  stripe post /v2/billing/meter_events --json-body @meter_event.json
+  stripe post billing/meter_events --api-version-family v2 -d event_name=api_call -d "payload[value]=1"
+  stripe post /v1/customers -d email=jenny@example.com --auto-idempotency
+  stripe post /v1/payment_intents --api-version 2024-04-10 --preview-feature feature_beta -d amount=2000 -d currency=usd
+  stripe post /v1/files --file purpose=dispute_evidence --file-path ./evidence.pdf
+  stripe post /v1/customers --bulk customers.csv --concurrency 8
+  stripe post /v1/customers -d email=jenny@example.com --as-code python`,
 		RunE: gc.reqs.RunRequestsCmd,
 	}
 
@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type profileCmd struct {
+	cmd    *cobra.Command
+	config *config.Config
+}
+
+func newProfileCmd(cfg *config.Config) *profileCmd {
+	pc := &profileCmd{
+		config: cfg,
+	}
+
+	pc.cmd = &cobra.Command{
+		Use:   "profile",
+		Args:  validators.NoArgs,
+		Short: "Manage the profiles of your config file",
+		Long: `Manage the profiles of your config file, one for each account or project you
+logged into with ` + "`stripe login --alias <name>`" + `.
+
+Commands use the profile selected with ` + "`stripe profile use <name>`" + ` or
+` + "`stripe switch <name>`" + `, or the
+` + "`default`" + ` profile, unless --project-name selects another one.`,
+	}
+
+	pc.cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Args:  validators.NoArgs,
+		Short: "List the profiles with their accounts, marking the one in use",
+		RunE:  pc.runListCmd,
+	})
+
+	pc.cmd.AddCommand(&cobra.Command{
+		Use:               "use <name>",
+		Args:              validators.ExactArgs(1),
+		Short:             "Use a profile when --project-name isn't set",
+		Example:           `stripe profile use acme`,
+		RunE:              pc.runUseCmd,
+		ValidArgsFunction: pc.completeProfileName,
+	})
+
+	pc.cmd.AddCommand(&cobra.Command{
+		Use:               "rename <name> <new_name>",
+		Args:              validators.ExactArgs(2),
+		Short:             "Rename a profile",
+		Example:           `stripe profile rename project-name acme`,
+		RunE:              pc.runRenameCmd,
+		ValidArgsFunction: pc.completeProfileName,
+	})
+
+	pc.cmd.AddCommand(&cobra.Command{
+		Use:   "remove <name>",
+		Args:  validators.ExactArgs(1),
+		Short: "Remove a profile and its credentials",
+		Long: `Remove a profile and its credentials from the config file. The ` + "`default`" + ` profile
+is used again if the removed profile was in use.`,
+		Example:           `stripe profile remove acme`,
+		RunE:              pc.runRemoveCmd,
+		ValidArgsFunction: pc.completeProfileName,
+	})
+
+	pc.cmd.AddCommand(&cobra.Command{
+		Use:   "show [name]",
+		Args:  validators.MaximumNArgs(1),
+		Short: "Show the settings of a profile, with its keys redacted",
+		Long: `Show the settings of a profile, the one in use if no name is given. API keys
+are redacted.`,
+		RunE:              pc.runShowCmd,
+		ValidArgsFunction: pc.completeProfileName,
+	})
+
+	return pc
+}
+
+func (pc *profileCmd) runListCmd(cmd *cobra.Command, args []string) error {
+	names := pc.config.ProfileNames()
+	if len(names) == 0 {
+		fmt.Println("No profiles are configured, create one with `stripe login`")
+		return nil
+	}
+
+	color := ansi.Color(os.Stdout)
+	for _, name := range names {
+		line := "  " + name
+		if name == pc.config.Profile.ProfileName {
+			line = color.Green("* " + name).String()
+		}
+
+		profile := config.Profile{ProfileName: name}
+		account := []string{}
+		if displayName := profile.GetDisplayName(); displayName != "" {
+			account = append(account, displayName)
+		}
+		if accountID := viper.GetString(profile.GetConfigField("account_id")); accountID != "" {
+			account = append(account, accountID)
+		}
+		if _, sandboxName := profile.GetSandbox(); sandboxName != "" {
+			account = append(account, "sandbox "+sandboxName)
+		}
+		if len(account) > 0 {
+			line += fmt.Sprintf(" (%s)", strings.Join(account, ", "))
+		}
+
+		fmt.Println(line)
+	}
+
+	return nil
+}
+
+func (pc *profileCmd) runUseCmd(cmd *cobra.Command, args []string) error {
+	// The default profile can be used again even if it was removed
+	name := args[0]
+	if name != config.DefaultProfileName {
+		if err := pc.checkProfile(name); err != nil {
+			return err
+		}
+	}
+
+	if err := pc.config.SetDefaultProfile(name); err != nil {
+		return err
+	}
+
+	fmt.Printf("Commands now use the profile %s unless --project-name is set\n", ansi.Bold(name))
+
+	return nil
+}
+
+func (pc *profileCmd) runRenameCmd(cmd *cobra.Command, args []string) error {
+	if err := pc.config.RenameProfile(args[0], args[1]); err != nil {
+		return err
+	}
+
+	fmt.Printf("Renamed the profile %s to %s\n", args[0], ansi.Bold(args[1]))
+
+	return nil
+}
+
+func (pc *profileCmd) runRemoveCmd(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if err := pc.checkProfile(name); err != nil {
+		return err
+	}
+
+	if err := pc.config.RemoveProfile(name); err != nil {
+		return err
+	}
+
+	fmt.Printf("Removed the profile %s\n", ansi.Bold(name))
+
+	return nil
+}
+
+func (pc *profileCmd) runShowCmd(cmd *cobra.Command, args []string) error {
+	name := pc.config.Profile.ProfileName
+	if len(args) > 0 {
+		name = args[0]
+	}
+
+	if err := pc.checkProfile(name); err != nil {
+		return err
+	}
+
+	profile := config.Profile{ProfileName: name}
+	settings, err := profile.GetSettings(false)
+	if err != nil {
+		return err
+	}
+
+	fields := make([]string, 0, len(settings))
+	for field := range settings {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	fmt.Printf("[%s]\n", name)
+	for _, field := range fields {
+		fmt.Printf("  %s = %s\n", field, settings[field])
+	}
+
+	return nil
+}
+
+// checkProfile returns an error if the config file has no profile of that
+// name
+func (pc *profileCmd) checkProfile(name string) error {
+	for _, profileName := range pc.config.ProfileNames() {
+		if profileName == name {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("profile ‘%s’ does not exist, create it with `stripe login --alias %s`", name, name)
+}
+
+// completeProfileName completes the first argument with the names of the
+// profiles
+func (pc *profileCmd) completeProfileName(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return pc.config.ProfileNames(), cobra.ShellCompDirectiveNoFileComp
+}
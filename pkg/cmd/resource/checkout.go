@@ -0,0 +1,31 @@
+package resource
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+)
+
+// AddCheckoutSubCmds adds custom subcommands to the `checkout` command created
+// automatically as a namespace command.
+func AddCheckoutSubCmds(rootCmd *cobra.Command, cfg *config.Config) error {
+	found := false
+
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Use == "checkout" {
+			found = true
+
+			NewCheckoutCreateCmd(cmd, cfg)
+
+			break
+		}
+	}
+
+	if !found {
+		return errors.New("Could not find checkout command")
+	}
+
+	return nil
+}
@@ -0,0 +1,216 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/checkout"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/open"
+	"github.com/stripe/stripe-cli/pkg/proxy"
+	"github.com/stripe/stripe-cli/pkg/qrcode"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/stripeauth"
+	"github.com/stripe/stripe-cli/pkg/validators"
+	"github.com/stripe/stripe-cli/pkg/websocket"
+)
+
+const checkoutCompletedEvent = "checkout.session.completed"
+
+// errCheckoutCompleted stops listening once the session is completed
+var errCheckoutCompleted = errors.New("checkout session completed")
+
+// CheckoutCreateCmd creates a Checkout Session with test defaults, to demo
+// a price or check its configuration quickly
+type CheckoutCreateCmd struct {
+	cfg *config.Config
+	cmd *cobra.Command
+
+	prices      []string
+	quantity    int64
+	mode        string
+	successURL  string
+	cancelURL   string
+	openBrowser bool
+	listen      bool
+	qr          bool
+	apiBaseURL  string
+}
+
+// NewCheckoutCreateCmd returns a new checkout create command
+func NewCheckoutCreateCmd(parentCmd *cobra.Command, config *config.Config) {
+	cc := &CheckoutCreateCmd{
+		cfg: config,
+	}
+
+	cc.cmd = &cobra.Command{
+		Use:   "create",
+		Args:  validators.NoArgs,
+		Short: "Create a Checkout Session and print its URL",
+		Long: `Create a Checkout Session for one or more prices, and print its URL and a QR
+code to open it on a phone. The mode is guessed from the first price: a
+subscription for recurring prices, a payment otherwise.
+
+With --listen, wait for the checkout.session.completed event of the session,
+to check the whole payment flow.`,
+		Example: `stripe checkout create --price price_1MxYz1234 --open
+  stripe checkout create --price price_1MxYz1234 --price price_1MxYz5678 --quantity 2 --listen`,
+		RunE: cc.runCheckoutCreateCmd,
+	}
+
+	cc.cmd.Flags().StringArrayVar(&cc.prices, "price", []string{}, "ID of a price to check out, can be repeated")
+	cc.cmd.Flags().Int64Var(&cc.quantity, "quantity", 1, "Quantity of each price")
+	cc.cmd.Flags().StringVar(&cc.mode, "mode", "", "Mode of the session: payment or subscription (default: guessed from the price)")
+	cc.cmd.Flags().StringVar(&cc.successURL, "success-url", checkout.DefaultSuccessURL, "URL the customer is sent to after paying")
+	cc.cmd.Flags().StringVar(&cc.cancelURL, "cancel-url", checkout.DefaultCancelURL, "URL the customer is sent to when going back")
+	cc.cmd.Flags().BoolVar(&cc.openBrowser, "open", false, "Open the session in the browser")
+	cc.cmd.Flags().BoolVar(&cc.listen, "listen", false, "Wait for the checkout.session.completed event of the session")
+	cc.cmd.Flags().BoolVar(&cc.qr, "qr", true, "Print a QR code of the URL when printing to a terminal")
+	cc.cmd.MarkFlagRequired("price") // #nosec G104
+
+	// Hidden configuration flags, useful for dev/debugging
+	cc.cmd.Flags().StringVar(&cc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	cc.cmd.Flags().MarkHidden("api-base") // #nosec G104
+
+	parentCmd.AddCommand(cc.cmd)
+}
+
+func (cc *CheckoutCreateCmd) runCheckoutCreateCmd(cmd *cobra.Command, args []string) error {
+	key, err := cc.cfg.Profile.GetAPIKey(false)
+	if err != nil {
+		return err
+	}
+
+	session, err := checkout.CreateSession(cmd.Context(), cc.apiBaseURL, key, checkout.Options{
+		Prices:     cc.prices,
+		Quantity:   cc.quantity,
+		Mode:       cc.mode,
+		SuccessURL: cc.successURL,
+		CancelURL:  cc.cancelURL,
+	}, &cc.cfg.Profile)
+	if err != nil {
+		return err
+	}
+
+	// Start listening before the session can be completed
+	var events chan websocket.IElement
+	if cc.listen {
+		events, err = cc.startListen(cmd.Context(), key)
+		if err != nil {
+			return err
+		}
+	}
+
+	out := cmd.OutOrStdout()
+
+	fmt.Fprintf(out, "Created %s (%s)\n%s\n", session.ID, session.Mode, ansi.Bold(session.URL))
+
+	if cc.qr && out == os.Stdout && term.IsTerminal(int(os.Stdout.Fd())) {
+		code, err := qrcode.Encode(session.URL)
+		if err == nil {
+			code.Render(out) // #nosec G104
+		}
+	}
+
+	if cc.openBrowser {
+		if err := open.Browser(session.URL); err != nil {
+			fmt.Fprintf(out, "Could not open the browser: %s\n", err)
+		}
+	}
+
+	if !cc.listen {
+		return nil
+	}
+
+	return cc.waitForCompletion(cmd.Context(), events, session.ID)
+}
+
+// startListen starts receiving the checkout.session.completed events of the
+// account, and waits until events can be received
+func (cc *CheckoutCreateCmd) startListen(ctx context.Context, key string) (chan websocket.IElement, error) {
+	deviceName, err := cc.cfg.Profile.GetDeviceName()
+	if err != nil {
+		return nil, err
+	}
+
+	outCh := make(chan websocket.IElement)
+
+	p, err := proxy.Init(ctx, &proxy.Config{
+		DeviceName:       deviceName,
+		Key:              key,
+		APIBaseURL:       cc.apiBaseURL,
+		Events:           []string{checkoutCompletedEvent},
+		WebSocketFeature: stripeauth.FeatureWebhooks,
+		Log:              log.StandardLogger(),
+		Proxy:            cc.cfg.Profile.GetProxy(),
+		OutCh:            outCh,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	go p.Run(ctx)
+
+	spinner := ansi.StartNewSpinner("Getting ready to listen...", os.Stderr)
+	defer ansi.StopSpinner(spinner, "", os.Stderr)
+
+	for {
+		select {
+		case el := <-outCh:
+			switch el := el.(type) {
+			case websocket.StateElement:
+				if el.State == websocket.Ready {
+					return outCh, nil
+				}
+			case websocket.ErrorElement:
+				return nil, el.Error
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// waitForCompletion waits for the checkout.session.completed event of the
+// session
+func (cc *CheckoutCreateCmd) waitForCompletion(ctx context.Context, events chan websocket.IElement, sessionID string) error {
+	fmt.Printf("Waiting for %s... (^C to quit)\n", checkoutCompletedEvent)
+
+	visitor := &websocket.Visitor{
+		VisitError: func(ee websocket.ErrorElement) error {
+			return ee.Error
+		},
+		VisitData: func(de websocket.DataElement) error {
+			evt, ok := de.Data.(proxy.StripeEvent)
+			if !ok || evt.ObjectID() != sessionID {
+				return nil
+			}
+
+			color := ansi.Color(os.Stdout)
+			fmt.Printf("%s %s [%s]\n", color.Green("✔"), ansi.Bold(evt.Type), evt.ID)
+
+			return errCheckoutCompleted
+		},
+	}
+
+	for {
+		select {
+		case el := <-events:
+			if err := el.Accept(visitor); err != nil {
+				if err == errCheckoutCompleted {
+					return nil
+				}
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
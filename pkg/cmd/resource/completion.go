@@ -0,0 +1,157 @@
+package resource
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tidwall/gjson"
+
+	"github.com/stripe/stripe-cli/pkg/browse"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/requests"
+)
+
+// completionTimeout bounds the request of the IDs completed in the shell, so
+// that a slow API doesn't hang the shell. It's replaced in tests.
+var completionTimeout = 2 * time.Second
+
+// completionCacheTTL is how long the completed IDs of a list are cached
+const completionCacheTTL = time.Minute
+
+// completionLimit is the number of recent IDs that are completed
+const completionLimit = 20
+
+// completionCacheDir returns the directory the completed IDs are cached in,
+// and is replaced in tests
+var completionCacheDir = func() string {
+	cfg := &config.Config{}
+	return filepath.Join(cfg.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")), "completion-cache")
+}
+
+// cachedCompletions are the completions of a list, with the time they were
+// requested at
+type cachedCompletions struct {
+	FetchedAt   time.Time `json:"fetched_at"`
+	Completions []string  `json:"completions"`
+}
+
+// completeURLParam completes the URL parameters of an operation, like the ID
+// of `stripe customers retrieve cus_<TAB>`, with the IDs of the most recent
+// objects of their list, labeled with what describes them. Completion is
+// silently skipped when the API can't be reached in time.
+func (oc *OperationCmd) completeURLParam(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	listPath := completionListPath(oc.Path, args)
+	if listPath == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	apiKey, err := oc.Profile.GetAPIKey(oc.Livemode)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	completions, err := fetchCompletions(ctx, oc.APIBaseURL, apiKey, listPath)
+	if err != nil {
+		cobra.CompDebugln(fmt.Sprintf("Could not complete IDs from %s: %v", listPath, err), false)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var matches []string
+	for _, completion := range completions {
+		if strings.HasPrefix(completion, toComplete) {
+			matches = append(matches, completion)
+		}
+	}
+
+	return matches, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionListPath returns the path of the list the next URL parameter of
+// path is an ID of, with the previous parameters replaced by args, or an
+// empty string if every parameter was given
+func completionListPath(path string, args []string) string {
+	params := extractURLParams(path)
+	if len(args) >= len(params) {
+		return ""
+	}
+
+	index := 0
+	for i := range args {
+		index += strings.Index(path[index:], params[i]) + len(params[i])
+	}
+	prefix := strings.TrimSuffix(path[:index+strings.Index(path[index:], params[len(args)])], "/")
+
+	return formatURL(prefix, args)
+}
+
+// fetchCompletions returns the IDs of the most recent objects of a list,
+// each followed by a tab and its description, from the cache if they were
+// requested recently
+func fetchCompletions(ctx context.Context, apiBaseURL, apiKey, listPath string) ([]string, error) {
+	h := sha256.Sum256([]byte(apiKey + "\x00" + apiBaseURL + listPath))
+	file := filepath.Join(completionCacheDir(), hex.EncodeToString(h[:])+".json")
+	cacheable := !config.IsStateless()
+
+	if data, err := ioutil.ReadFile(file); cacheable && err == nil {
+		var cached cachedCompletions
+		if err := json.Unmarshal(data, &cached); err == nil && time.Since(cached.FetchedAt) < completionCacheTTL {
+			return cached.Completions, nil
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, completionTimeout)
+	defer cancel()
+
+	req := requests.Base{
+		Method:         http.MethodGet,
+		SuppressOutput: true,
+		APIBaseURL:     apiBaseURL,
+	}
+
+	var params requests.RequestParameters
+	params.AppendData([]string{fmt.Sprintf("limit=%d", completionLimit)})
+
+	resp, err := req.MakeRequest(ctx, apiKey, listPath, &params, true)
+	if err != nil {
+		return nil, err
+	}
+
+	completions := []string{}
+	for _, object := range gjson.GetBytes(resp, "data").Array() {
+		id := object.Get("id").String()
+		if id == "" {
+			continue
+		}
+
+		// Shells show the description after the tab next to the ID
+		if summary := browse.Summary(object); summary != "" {
+			id += "\t" + summary
+		}
+		completions = append(completions, id)
+	}
+
+	// The cache only saves requests, so it doesn't matter if it can't be
+	// written
+	if data, err := json.Marshal(cachedCompletions{FetchedAt: time.Now(), Completions: completions}); cacheable && err == nil {
+		if err := os.MkdirAll(completionCacheDir(), 0700); err == nil {
+			ioutil.WriteFile(file, data, 0600) // #nosec G104
+		}
+	}
+
+	return completions, nil
+}
@@ -0,0 +1,87 @@
+package resource
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+)
+
+func TestCompletionListPath(t *testing.T) {
+	require.Equal(t, "/v1/customers", completionListPath("/v1/customers/{customer}", []string{}))
+	require.Equal(t, "/v1/customers", completionListPath("/v1/customers/{customer}/tax_ids/{id}", []string{}))
+	require.Equal(t, "/v1/customers/cus_123/tax_ids", completionListPath("/v1/customers/{customer}/tax_ids/{id}", []string{"cus_123"}))
+	require.Equal(t, "/v1/invoices", completionListPath("/v1/invoices/{invoice}/pay", []string{}))
+	require.Equal(t, "", completionListPath("/v1/customers/{customer}", []string{"cus_123"}))
+}
+
+func TestCompleteURLParam(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		require.Equal(t, "/v1/customers", r.URL.Path)
+		require.Equal(t, "20", r.URL.Query().Get("limit"))
+		require.Equal(t, "Bearer sk_test_1234", r.Header.Get("Authorization"))
+		w.Write([]byte(`{"object": "list", "data": [
+			{"id": "cus_123", "email": "jenny@example.com"},
+			{"id": "cus_456", "email": null},
+			{"id": "gcus_789", "email": "gift@example.com"}
+		]}`))
+	}))
+	defer ts.Close()
+
+	cacheDir := t.TempDir()
+	original := completionCacheDir
+	defer func() { completionCacheDir = original }()
+	completionCacheDir = func() string { return cacheDir }
+
+	viper.Reset()
+
+	parentCmd := &cobra.Command{Annotations: make(map[string]string)}
+	oc := NewOperationCmd(parentCmd, "retrieve", "/v1/customers/{customer}", http.MethodGet, map[string]string{}, &config.Config{
+		Profile: config.Profile{APIKey: "sk_test_1234"},
+	})
+	oc.APIBaseURL = ts.URL
+
+	completions, directive := oc.Cmd.ValidArgsFunction(oc.Cmd, []string{}, "cus_")
+	require.Equal(t, []string{"cus_123\tjenny@example.com", "cus_456"}, completions)
+	require.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	// The IDs are cached
+	completions, _ = oc.Cmd.ValidArgsFunction(oc.Cmd, []string{}, "")
+	require.Len(t, completions, 3)
+	require.Equal(t, 1, requests)
+
+	completions, _ = oc.Cmd.ValidArgsFunction(oc.Cmd, []string{"cus_123"}, "")
+	require.Empty(t, completions)
+}
+
+func TestCompleteURLParamUnreachable(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error": {"type": "invalid_request_error"}}`))
+	}))
+	defer ts.Close()
+
+	cacheDir := t.TempDir()
+	original := completionCacheDir
+	defer func() { completionCacheDir = original }()
+	completionCacheDir = func() string { return cacheDir }
+
+	viper.Reset()
+
+	parentCmd := &cobra.Command{Annotations: make(map[string]string)}
+	oc := NewOperationCmd(parentCmd, "retrieve", "/v1/customers/{customer}", http.MethodGet, map[string]string{}, &config.Config{
+		Profile: config.Profile{APIKey: "sk_test_1234"},
+	})
+	oc.APIBaseURL = ts.URL
+
+	completions, directive := oc.Cmd.ValidArgsFunction(oc.Cmd, []string{}, "")
+	require.Empty(t, completions)
+	require.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+}
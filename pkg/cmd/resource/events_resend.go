@@ -1,20 +1,62 @@
 package resource
 
 import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/tidwall/gjson"
 
+	"github.com/stripe/stripe-cli/pkg/ansi"
 	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/requests"
+	"github.com/stripe/stripe-cli/pkg/validators"
 )
 
 // EventsResendCmd represents the event resend API operation command. This
-// command is manually defined because it has a custom behavior.
+// command is manually defined because it has a custom behavior: it also
+// resends the events matching filters, to recover from an endpoint outage.
 type EventsResendCmd struct {
 	opCmd *OperationCmd
+
+	eventTypes []string
+	since      time.Duration
+	failedOnly bool
+
+	in  io.Reader
+	out io.Writer
+}
+
+// batch returns whether the events to resend are selected by filters rather
+// than by ID
+func (erc *EventsResendCmd) batch() bool {
+	flags := erc.opCmd.Cmd.Flags()
+	return flags.Changed("type") || flags.Changed("since") || flags.Changed("failed-only")
+}
+
+func (erc *EventsResendCmd) validateArgs(cmd *cobra.Command, args []string) error {
+	if erc.batch() {
+		return validators.NoArgs(cmd, args)
+	}
+
+	return validators.ExactArgs(1)(cmd, args)
 }
 
 func (erc *EventsResendCmd) runEventsResendCmd(cmd *cobra.Command, args []string) error {
+	if erc.batch() {
+		return erc.runBatch(cmd.Context())
+	}
+
 	// If the `webhook-endpoint` flag was not passed, then add
 	// `for_stripecli=true` to the request so the event is replayed to the
 	// Stripe CLI.
@@ -25,6 +67,167 @@ func (erc *EventsResendCmd) runEventsResendCmd(cmd *cobra.Command, args []string
 	return erc.opCmd.runOperationCmd(cmd, args)
 }
 
+// runBatch lists the events matching the filters and resends them,
+// --concurrency at a time, after confirmation.
+func (erc *EventsResendCmd) runBatch(ctx context.Context) error {
+	flags := erc.opCmd.Cmd.Flags()
+
+	concurrency, _ := flags.GetInt("concurrency")
+	if concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+	if erc.since < 0 {
+		return fmt.Errorf("--since must be positive")
+	}
+
+	apiKey, err := erc.opCmd.Profile.GetAPIKey(erc.opCmd.Livemode)
+	if err != nil {
+		return err
+	}
+
+	endpoint, _ := flags.GetString("webhook-endpoint")
+
+	events, err := erc.listEvents(ctx, apiKey)
+	if err != nil {
+		return err
+	}
+
+	printResendPreview(erc.out, events, endpoint)
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	if autoConfirm, _ := flags.GetBool("confirm"); !autoConfirm {
+		fmt.Fprint(erc.out, "\nResend these events? Enter 'yes' to confirm: ")
+
+		input, err := bufio.NewReader(erc.in).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+
+		if strings.ToLower(strings.TrimSpace(input)) != "yes" {
+			fmt.Fprintln(erc.out, "Exiting without resending the events.")
+			return nil
+		}
+	}
+
+	params := []string{"for_stripecli=true"}
+	if endpoint != "" {
+		params = []string{"webhook_endpoint=" + endpoint}
+	}
+
+	color := ansi.Color(erc.out)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	failed := 0
+
+	jobs := make(chan gjson.Result)
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for event := range jobs {
+				id := event.Get("id").String()
+				_, err := requests.Do(ctx, http.MethodPost, erc.opCmd.APIBaseURL, apiKey, fmt.Sprintf("/v1/events/%s/retry", id), params, erc.opCmd.Profile)
+
+				mu.Lock()
+				if err != nil {
+					failed++
+					fmt.Fprintf(erc.out, "%s %s: %s\n", color.Red("✘"), id, err)
+				} else {
+					fmt.Fprintf(erc.out, "%s %s\n", color.Green("✔"), id)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	fmt.Fprintln(erc.out)
+	for _, event := range events {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- event
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(erc.out, "\n%d of %d events resent\n", len(events)-failed, len(events))
+
+	if failed > 0 {
+		return fmt.Errorf("%d event(s) could not be resent", failed)
+	}
+
+	return nil
+}
+
+// listEvents lists the events matching the filters, following the pages of
+// the list
+func (erc *EventsResendCmd) listEvents(ctx context.Context, apiKey string) ([]gjson.Result, error) {
+	params := []string{"limit=100"}
+	for _, eventType := range erc.eventTypes {
+		params = append(params, "types[]="+eventType)
+	}
+	if erc.since > 0 {
+		params = append(params, "created[gte]="+strconv.FormatInt(time.Now().Add(-erc.since).Unix(), 10))
+	}
+	if erc.failedOnly {
+		params = append(params, "delivery_success=false")
+	}
+
+	var events []gjson.Result
+
+	for startingAfter := ""; ; {
+		page := params
+		if startingAfter != "" {
+			page = append(append([]string{}, params...), "starting_after="+startingAfter)
+		}
+
+		list, err := requests.Do(ctx, http.MethodGet, erc.opCmd.APIBaseURL, apiKey, "/v1/events", page, erc.opCmd.Profile)
+		if err != nil {
+			return nil, err
+		}
+
+		data := list.Get("data").Array()
+		events = append(events, data...)
+
+		if !list.Get("has_more").Bool() || len(data) == 0 {
+			return events, nil
+		}
+		startingAfter = data[len(data)-1].Get("id").String()
+	}
+}
+
+// printResendPreview prints the events to resend
+func printResendPreview(out io.Writer, events []gjson.Result, endpoint string) {
+	if len(events) == 0 {
+		fmt.Fprintln(out, "No events match the filters.")
+		return
+	}
+
+	destination := "the Stripe CLI"
+	if endpoint != "" {
+		destination = endpoint
+	}
+
+	fmt.Fprintf(out, "%d event(s) to resend to %s\n\n", len(events), destination)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTYPE\tCREATED\tPENDING WEBHOOKS")
+	for _, event := range events {
+		created := time.Unix(event.Get("created").Int(), 0).Format(time.RFC3339)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\n", event.Get("id").String(), event.Get("type").String(), created, event.Get("pending_webhooks").Int())
+	}
+	w.Flush()
+}
+
 // NewEventsResendCmd returns a new EventsResendCmd.
 func NewEventsResendCmd(parentCmd *cobra.Command, cfg *config.Config) *EventsResendCmd {
 	eventsResendCmd := &EventsResendCmd{
@@ -32,9 +235,27 @@ func NewEventsResendCmd(parentCmd *cobra.Command, cfg *config.Config) *EventsRes
 			"account":          "string",
 			"webhook_endpoint": "string",
 		}, cfg),
+		in:  os.Stdin,
+		out: os.Stdout,
 	}
 
-	eventsResendCmd.opCmd.Cmd.RunE = eventsResendCmd.runEventsResendCmd
+	cmd := eventsResendCmd.opCmd.Cmd
+	cmd.RunE = eventsResendCmd.runEventsResendCmd
+	cmd.Args = eventsResendCmd.validateArgs
+	cmd.Example = `stripe events resend evt_1MxYz1234 --webhook-endpoint we_1MxYz1234
+  stripe events resend --type invoice.payment_failed --since 24h --endpoint we_1MxYz1234 --failed-only`
+
+	cmd.Flags().StringArrayVar(&eventsResendCmd.eventTypes, "type", []string{}, "Resend the events of this type instead of an event by ID (repeatable)")
+	cmd.Flags().DurationVar(&eventsResendCmd.since, "since", 0, "Resend the events created during this duration instead of an event by ID, like 24h")
+	cmd.Flags().BoolVar(&eventsResendCmd.failedOnly, "failed-only", false, "Resend only the events whose delivery to an endpoint failed")
+
+	// --endpoint is short for --webhook-endpoint
+	cmd.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "endpoint" {
+			name = "webhook-endpoint"
+		}
+		return pflag.NormalizedName(name)
+	})
 
 	return eventsResendCmd
 }
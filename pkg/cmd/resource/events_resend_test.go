@@ -1,11 +1,14 @@
 package resource
 
 import (
+	"bytes"
 	"context"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -78,3 +81,68 @@ func TestRunEventsResendCmd_WithWebhookEndpoint(t *testing.T) {
 
 	require.NoError(t, err)
 }
+
+func TestRunEventsResendCmd_Batch(t *testing.T) {
+	var mu sync.Mutex
+	var resent []string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/events":
+			query := r.URL.Query()
+			require.Equal(t, []string{"invoice.payment_failed"}, query["types[]"])
+			require.Equal(t, "false", query.Get("delivery_success"))
+			require.NotEmpty(t, query.Get("created[gte]"))
+
+			if query.Get("starting_after") == "" {
+				w.Write([]byte(`{"data": [{"id": "evt_1", "type": "invoice.payment_failed", "created": 1700000000}], "has_more": true}`))
+			} else {
+				require.Equal(t, "evt_1", query.Get("starting_after"))
+				w.Write([]byte(`{"data": [{"id": "evt_2", "type": "invoice.payment_failed", "created": 1700000100}], "has_more": false}`))
+			}
+		case r.Method == http.MethodPost:
+			r.ParseForm()
+			require.Equal(t, "we_123", r.Form.Get("webhook_endpoint"))
+
+			mu.Lock()
+			resent = append(resent, strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/events/"), "/retry"))
+			mu.Unlock()
+			w.Write([]byte(`{}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	viper.Reset()
+
+	parentCmd := &cobra.Command{Annotations: make(map[string]string)}
+	profile := config.Profile{
+		APIKey: "sk_test_1234",
+	}
+	erc := NewEventsResendCmd(parentCmd, &config.Config{Profile: profile})
+	erc.opCmd.APIBaseURL = ts.URL
+
+	var out bytes.Buffer
+	erc.out = &out
+	erc.in = strings.NewReader("yes\n")
+
+	parentCmd.SetArgs([]string{"resend", "--type", "invoice.payment_failed", "--since", "24h", "--endpoint", "we_123", "--failed-only"})
+	err := parentCmd.ExecuteContext(context.Background())
+
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"evt_1", "evt_2"}, resent)
+	require.Contains(t, out.String(), "2 event(s) to resend to we_123")
+	require.Contains(t, out.String(), "2 of 2 events resent")
+}
+
+func TestRunEventsResendCmd_BatchTakesNoArgs(t *testing.T) {
+	parentCmd := &cobra.Command{Annotations: make(map[string]string), SilenceUsage: true, SilenceErrors: true}
+	NewEventsResendCmd(parentCmd, &config.Config{})
+
+	parentCmd.SetArgs([]string{"resend", "evt_123", "--since", "1h"})
+	err := parentCmd.ExecuteContext(context.Background())
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "does not take any positional arguments")
+}
@@ -0,0 +1,80 @@
+package resource
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/scrub"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+// EventsScrubCmd replaces the personal data of recorded events with fake
+// values, so they can be shared
+type EventsScrubCmd struct {
+	cmd *cobra.Command
+
+	out string
+}
+
+// NewEventsScrubCmd returns a new EventsScrubCmd.
+func NewEventsScrubCmd(parentCmd *cobra.Command) *EventsScrubCmd {
+	esc := &EventsScrubCmd{}
+
+	esc.cmd = &cobra.Command{
+		Use:   "scrub <file>",
+		Args:  validators.ExactArgs(1),
+		Short: "Replace personal data in recorded events with fake values",
+		Long: `Replace the personal data of a file of events, one JSON payload per line, with
+fake values so it can be shared, e.g. in a bug report. Emails, names, phone
+numbers, street addresses, card fingerprints and IP addresses are replaced.
+
+Each value is replaced with the same fake value everywhere it appears, so the
+relationships between objects still show. IDs, amounts, statuses and the
+structure of the payloads are kept. Use - to read from stdin.`,
+		Example: `stripe events scrub recorded.ndjson --out shareable.ndjson
+  stripe events list | jq -c '.data[]' | stripe events scrub -`,
+		RunE: esc.runEventsScrubCmd,
+	}
+
+	esc.cmd.Flags().StringVar(&esc.out, "out", "", "Write the scrubbed events to this file instead of stdout")
+
+	parentCmd.AddCommand(esc.cmd)
+
+	return esc
+}
+
+func (esc *EventsScrubCmd) runEventsScrubCmd(cmd *cobra.Command, args []string) error {
+	var in io.Reader = os.Stdin
+	if args[0] != "-" {
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+
+	var out io.Writer = cmd.OutOrStdout()
+	if esc.out != "" {
+		file, err := os.OpenFile(esc.out, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	count, err := scrub.New().ScrubLines(in, out)
+	if err != nil {
+		return fmt.Errorf("failed to scrub %s: %v", args[0], err)
+	}
+
+	if esc.out != "" {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Scrubbed %d events into %s\n", count, esc.out)
+	}
+
+	return nil
+}
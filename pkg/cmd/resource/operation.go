@@ -10,6 +10,7 @@ import (
 
 	"github.com/stripe/stripe-cli/pkg/ansi"
 	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/docs"
 	"github.com/stripe/stripe-cli/pkg/requests"
 	"github.com/stripe/stripe-cli/pkg/validators"
 )
@@ -96,13 +97,54 @@ func (oc *OperationCmd) runOperationCmd(cmd *cobra.Command, args []string) error
 		}
 
 		// if confirmation is provided, make the request
-		_, err = oc.MakeRequest(cmd.Context(), apiKey, path, &oc.Parameters, false)
-
-		return err
+		return oc.StreamRequest(cmd.Context(), apiKey, path, &oc.Parameters)
 	}
 	// else
-	_, err = oc.MakeRequest(cmd.Context(), apiKey, path, &oc.Parameters, false)
-	return err
+	return oc.StreamRequest(cmd.Context(), apiKey, path, &oc.Parameters)
+}
+
+// help prints the help of the operation, with its request parameters
+// documented from the reference bundled with the CLI. The reference is only
+// loaded for help, so that it doesn't slow down requests.
+func (oc *OperationCmd) help(cmd *cobra.Command, args []string) {
+	cmd.SetUsageTemplate(operationUsageTemplate(oc.URLParams, oc.parametersHelp()))
+	cmd.Parent().HelpFunc()(cmd, args)
+}
+
+// parametersHelp returns the documentation of the request parameters of the
+// operation, or an empty string if the reference doesn't document it
+func (oc *OperationCmd) parametersHelp() string {
+	ref, err := docs.Load()
+	if err != nil {
+		return ""
+	}
+
+	operation, ok := ref.Operation(oc.HTTPVerb, oc.Path)
+	if !ok || len(operation.Parameters) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	ref.RenderParameters(&sb, operation, func(param *docs.Parameter) string {
+		flagName := strings.ReplaceAll(param.Name, "_", "-")
+		if oc.Cmd.Flags().Lookup(flagName) != nil {
+			return "--" + flagName
+		}
+
+		// Parameters without a flag are passed with -d
+		switch {
+		case strings.HasPrefix(param.Type, "array of hash"):
+			return fmt.Sprintf("-d \"%s[0][key]=value\"", param.Name)
+		case strings.HasPrefix(param.Type, "array of"):
+			return fmt.Sprintf("-d \"%s[]=value\"", param.Name)
+		case strings.HasPrefix(param.Type, "hash"):
+			return fmt.Sprintf("-d \"%s[key]=value\"", param.Name)
+		default:
+			return fmt.Sprintf("-d \"%s=value\"", param.Name)
+		}
+	})
+
+	return sb.String()
 }
 
 //
@@ -140,7 +182,12 @@ func NewOperationCmd(parentCmd *cobra.Command, name, path, httpVerb string, prop
 		cmd.Flags().SetAnnotation(flagName, "request", []string{"true"})
 	}
 
-	cmd.SetUsageTemplate(operationUsageTemplate(urlParams))
+	if len(urlParams) > 0 {
+		cmd.ValidArgsFunction = operationCmd.completeURLParam
+	}
+
+	cmd.SetUsageTemplate(operationUsageTemplate(urlParams, ""))
+	cmd.SetHelpFunc(operationCmd.help)
 	cmd.DisableFlagsInUseLine = true
 	operationCmd.Cmd = cmd
 	operationCmd.InitFlags()
@@ -172,7 +219,10 @@ func formatURL(path string, urlParams []string) string {
 	return fmt.Sprintf(format, s...)
 }
 
-func operationUsageTemplate(urlParams []string) string {
+// operationUsageTemplate returns the usage template of an operation. The
+// request parameters are listed from parametersHelp if it isn't empty, or
+// else by the names of their flags.
+func operationUsageTemplate(urlParams []string, parametersHelp string) string {
 	args := strings.Map(func(r rune) rune {
 		switch r {
 		case '{':
@@ -188,6 +238,12 @@ func operationUsageTemplate(urlParams []string) string {
 
 	args += "[--param=value] [-d \"nested[param]=value\"]"
 
+	requestParams := "{{WrappedRequestParamsFlagUsages . | trimTrailingWhitespaces}}"
+	if parametersHelp != "" {
+		// The help is printed as is, even if it looks like a template action
+		requestParams = strings.ReplaceAll(strings.TrimRight(parametersHelp, "\n"), "{{", `{{"{{"}}`)
+	}
+
 	return fmt.Sprintf(`%s{{if .Runnable}}
   {{.UseLine}} %s{{end}}{{if .HasAvailableSubCommands}}
   {{.CommandPath}} [command]{{end}}{{if gt (len .Aliases) 0}}
@@ -202,7 +258,7 @@ func operationUsageTemplate(urlParams []string) string {
   {{rpad .Name .NamePadding }} {{.Short}}{{end}}{{end}}{{end}}{{if .HasAvailableLocalFlags}}
 
 %s
-{{WrappedRequestParamsFlagUsages . | trimTrailingWhitespaces}}
+%s
 
 %s
 {{WrappedNonRequestParamsFlagUsages . | trimTrailingWhitespaces}}{{end}}{{if .HasAvailableInheritedFlags}}
@@ -221,6 +277,7 @@ Use "{{.CommandPath}} [command] --help" for more information about a command.{{e
 		ansi.Bold("Examples:"),
 		ansi.Bold("Available Operations:"),
 		ansi.Bold("Request Parameters:"),
+		requestParams,
 		ansi.Bold("Flags:"),
 		ansi.Bold("Global Flags:"),
 		ansi.Bold("Additional help topics:"),
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -127,3 +128,22 @@ func TestRunOperationCmd_NoAPIKey(t *testing.T) {
 
 	require.Error(t, err, "your API key has not been configured. Use `stripe login` to set your API key")
 }
+
+func TestOperationCmdParametersHelp(t *testing.T) {
+	parentCmd := &cobra.Command{Use: "payment_intents", Annotations: make(map[string]string)}
+	oc := NewOperationCmd(parentCmd, "create", "/v1/payment_intents", http.MethodPost, map[string]string{
+		"amount":   "integer",
+		"currency": "string",
+	}, &config.Config{})
+
+	help := oc.parametersHelp()
+	require.True(t, strings.HasPrefix(help, "  --amount integer, required\n      Amount intended to be collected by this PaymentIntent."))
+	require.Contains(t, help, "  -d \"metadata[key]=value\" hash\n")
+	require.Contains(t, help, "  --expand array of string\n")
+	require.Contains(t, operationUsageTemplate(oc.URLParams, help), "  --currency string, required\n")
+
+	// Operations the reference doesn't document list their flags
+	oc = NewOperationCmd(parentCmd, "frobnicate", "/v1/payment_intents/frobnicate", http.MethodPost, map[string]string{}, &config.Config{})
+	require.Equal(t, "", oc.parametersHelp())
+	require.Contains(t, operationUsageTemplate(oc.URLParams, ""), "{{WrappedRequestParamsFlagUsages . | trimTrailingWhitespaces}}")
+}
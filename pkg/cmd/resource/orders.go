@@ -17,6 +17,8 @@ func AddOrdersSubCmds(rootCmd *cobra.Command, cfg *config.Config) error {
 		if cmd.Use == "orders" {
 			found = true
 
+			LoadOperations(cmd)
+
 			// Remove the autogenerated `create` and `update` command(s).
 			commands := cmd.Commands()
 			for _, c := range commands {
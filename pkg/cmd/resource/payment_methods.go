@@ -0,0 +1,34 @@
+package resource
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+)
+
+// AddPaymentMethodsSubCmds adds custom subcommands to the `payment_methods`
+// command created automatically as a resource command.
+func AddPaymentMethodsSubCmds(rootCmd *cobra.Command, cfg *config.Config) error {
+	found := false
+
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Use == "payment_methods" {
+			found = true
+
+			cmd.Aliases = append(cmd.Aliases, "payment-methods")
+
+			NewPaymentMethodsToggleCmd(cmd, cfg, true)
+			NewPaymentMethodsToggleCmd(cmd, cfg, false)
+
+			break
+		}
+	}
+
+	if !found {
+		return errors.New("Could not find payment_methods command")
+	}
+
+	return nil
+}
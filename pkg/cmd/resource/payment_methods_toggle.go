@@ -0,0 +1,263 @@
+package resource
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/tidwall/gjson"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/requests"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+)
+
+// PaymentMethodsToggleCmd turns payment methods on or off in a payment
+// method configuration, after previewing the change. This command is
+// manually defined because setting the display preferences of several
+// payment methods with raw API params is error-prone.
+type PaymentMethodsToggleCmd struct {
+	Cmd *cobra.Command
+
+	cfg    *config.Config
+	enable bool
+
+	configuration string
+	autoConfirm   bool
+	dryRun        bool
+	apiBaseURL    string
+
+	in  io.Reader
+	out io.Writer
+}
+
+// paymentMethodChange is the change of the display preference of a payment
+// method
+type paymentMethodChange struct {
+	Type      string
+	Current   string
+	New       string
+	Available bool
+}
+
+// NewPaymentMethodsToggleCmd returns the `enable` command if enable is true,
+// or the `disable` command.
+func NewPaymentMethodsToggleCmd(parentCmd *cobra.Command, cfg *config.Config, enable bool) *PaymentMethodsToggleCmd {
+	ptc := &PaymentMethodsToggleCmd{
+		cfg:    cfg,
+		enable: enable,
+		in:     os.Stdin,
+		out:    os.Stdout,
+	}
+
+	verb, title := "disable", "Disable"
+	if enable {
+		verb, title = "enable", "Enable"
+	}
+
+	ptc.Cmd = &cobra.Command{
+		Use:   verb + " <type>[,<type>...]",
+		Args:  cobra.MinimumNArgs(1),
+		Short: title + " payment methods in a payment method configuration",
+		Long: fmt.Sprintf(`%s payment methods, like klarna or affirm, in a payment method
+configuration, or in the default configuration of the account if
+--configuration isn't set. The change is previewed before it's applied.`, title),
+		Example: fmt.Sprintf(`stripe payment-methods %s klarna,affirm
+  stripe payment-methods %s klarna --configuration pmc_1MxYz1234 --confirm
+  stripe payment-methods %s affirm --dry-run`, verb, verb, verb),
+		RunE: ptc.runPaymentMethodsToggleCmd,
+	}
+
+	ptc.Cmd.Flags().StringVar(&ptc.configuration, "configuration", "", "ID of the payment method configuration to change (default: the default configuration)")
+	ptc.Cmd.Flags().BoolVarP(&ptc.autoConfirm, "confirm", "c", false, "Skip the preview prompt and apply the change")
+	ptc.Cmd.Flags().BoolVar(&ptc.dryRun, "dry-run", false, "Preview the change without applying it")
+
+	// Hidden configuration flags, useful for dev/debugging
+	ptc.Cmd.Flags().StringVar(&ptc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	ptc.Cmd.Flags().MarkHidden("api-base") // #nosec G104
+
+	parentCmd.AddCommand(ptc.Cmd)
+
+	return ptc
+}
+
+func (ptc *PaymentMethodsToggleCmd) runPaymentMethodsToggleCmd(cmd *cobra.Command, args []string) error {
+	apiKey, err := ptc.cfg.Profile.GetAPIKey(false)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+
+	configuration, err := ptc.loadConfiguration(ctx, apiKey)
+	if err != nil {
+		return err
+	}
+	id := configuration.Get("id").String()
+
+	preference := "off"
+	if ptc.enable {
+		preference = "on"
+	}
+
+	changes, err := planPaymentMethodChanges(configuration, parsePaymentMethodTypes(args), preference)
+	if err != nil {
+		return err
+	}
+
+	printPaymentMethodChanges(ptc.out, id, changes)
+
+	if len(changes) == 0 || ptc.dryRun {
+		return nil
+	}
+
+	if !ptc.autoConfirm {
+		fmt.Fprint(ptc.out, "\nApply these changes? Enter 'yes' to confirm: ")
+
+		input, err := bufio.NewReader(ptc.in).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+
+		if strings.ToLower(strings.TrimSpace(input)) != "yes" {
+			fmt.Fprintln(ptc.out, "Exiting without applying the changes.")
+			return nil
+		}
+	}
+
+	var data []string
+	for _, change := range changes {
+		data = append(data, fmt.Sprintf("%s[display_preference][preference]=%s", change.Type, change.New))
+	}
+
+	if _, err := requests.Do(ctx, http.MethodPost, ptc.apiBaseURL, apiKey, "/v1/payment_method_configurations/"+id, data, &ptc.cfg.Profile); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(ptc.out, "Updated %s\n", id)
+
+	return nil
+}
+
+// loadConfiguration returns the configuration set with --configuration, or
+// the default configuration of the account.
+func (ptc *PaymentMethodsToggleCmd) loadConfiguration(ctx context.Context, apiKey string) (gjson.Result, error) {
+	if ptc.configuration != "" {
+		return requests.Do(ctx, http.MethodGet, ptc.apiBaseURL, apiKey, "/v1/payment_method_configurations/"+ptc.configuration, nil, &ptc.cfg.Profile)
+	}
+
+	list, err := requests.Do(ctx, http.MethodGet, ptc.apiBaseURL, apiKey, "/v1/payment_method_configurations", []string{"limit=100"}, &ptc.cfg.Profile)
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	for _, configuration := range list.Get("data").Array() {
+		if configuration.Get("is_default").Bool() {
+			return configuration, nil
+		}
+	}
+
+	return gjson.Result{}, fmt.Errorf("the account has no default payment method configuration, set one with --configuration")
+}
+
+// parsePaymentMethodTypes returns the payment method types of args, which
+// are separated by commas or given as several args.
+func parsePaymentMethodTypes(args []string) []string {
+	var types []string
+	seen := make(map[string]bool)
+
+	for _, arg := range args {
+		for _, t := range strings.Split(arg, ",") {
+			t = strings.ToLower(strings.TrimSpace(t))
+			if t != "" && !seen[t] {
+				seen[t] = true
+				types = append(types, t)
+			}
+		}
+	}
+
+	return types
+}
+
+// planPaymentMethodChanges returns the changes setting the display
+// preference of the payment method types to preference in configuration.
+// The types already set to preference are left out.
+func planPaymentMethodChanges(configuration gjson.Result, types []string, preference string) ([]paymentMethodChange, error) {
+	var changes []paymentMethodChange
+
+	known := configurablePaymentMethods(configuration)
+
+	for _, t := range types {
+		// Check the type is known before using it as a path
+		i := sort.SearchStrings(known, t)
+		if i == len(known) || known[i] != t {
+			return nil, fmt.Errorf("unknown payment method ‘%s’ for %s. Payment methods are %s",
+				t, configuration.Get("id").String(), strings.Join(known, ", "))
+		}
+
+		settings := configuration.Get(t)
+
+		current := settings.Get("display_preference.preference").String()
+		if current == preference {
+			continue
+		}
+
+		changes = append(changes, paymentMethodChange{
+			Type:      t,
+			Current:   current,
+			New:       preference,
+			Available: settings.Get("available").Bool(),
+		})
+	}
+
+	return changes, nil
+}
+
+// configurablePaymentMethods returns the payment method types of a
+// configuration, sorted.
+func configurablePaymentMethods(configuration gjson.Result) []string {
+	var types []string
+
+	configuration.ForEach(func(key, value gjson.Result) bool {
+		if value.Get("display_preference").Exists() {
+			types = append(types, key.String())
+		}
+		return true
+	})
+
+	sort.Strings(types)
+
+	return types
+}
+
+// printPaymentMethodChanges prints a preview of changes to the configuration
+// id.
+func printPaymentMethodChanges(out io.Writer, id string, changes []paymentMethodChange) {
+	if len(changes) == 0 {
+		fmt.Fprintf(out, "Nothing to change in %s.\n", id)
+		return
+	}
+
+	color := ansi.Color(out)
+
+	fmt.Fprintf(out, "Changes to %s:\n\n", id)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PAYMENT METHOD\tPREFERENCE")
+	for _, change := range changes {
+		fmt.Fprintf(w, "%s\t%s → %s", change.Type, change.Current, change.New)
+		if change.New == "on" && !change.Available {
+			fmt.Fprintf(w, " %s", color.Faint("(not available on the account, it won't be shown to customers)"))
+		}
+		fmt.Fprintln(w)
+	}
+	w.Flush()
+}
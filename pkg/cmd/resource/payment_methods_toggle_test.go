@@ -0,0 +1,104 @@
+package resource
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+)
+
+const testPaymentMethodConfiguration = `{
+	"id": "pmc_123",
+	"object": "payment_method_configuration",
+	"is_default": true,
+	"affirm": {"available": true, "display_preference": {"preference": "off", "value": "off"}},
+	"card": {"available": true, "display_preference": {"preference": "on", "value": "on"}},
+	"klarna": {"available": false, "display_preference": {"preference": "none", "value": "off"}}
+}`
+
+func TestRunPaymentMethodsToggleCmd(t *testing.T) {
+	var updates []url.Values
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/payment_method_configurations":
+			w.Write([]byte(`{"object": "list", "data": [{"id": "pmc_other", "is_default": false}, ` + testPaymentMethodConfiguration + `]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/payment_method_configurations/pmc_123":
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			vals, err := url.ParseQuery(string(body))
+			require.NoError(t, err)
+			updates = append(updates, vals)
+			w.Write([]byte(testPaymentMethodConfiguration))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	viper.Reset()
+
+	parentCmd := &cobra.Command{Annotations: make(map[string]string)}
+	profile := config.Profile{
+		APIKey: "sk_test_1234",
+	}
+	ptc := NewPaymentMethodsToggleCmd(parentCmd, &config.Config{Profile: profile}, true)
+	ptc.apiBaseURL = ts.URL
+
+	var out bytes.Buffer
+	ptc.out = &out
+	ptc.in = strings.NewReader("yes\n")
+
+	parentCmd.SetArgs([]string{"enable", "affirm,klarna", "card"})
+	err := parentCmd.ExecuteContext(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, updates, 1)
+	require.Equal(t, url.Values{
+		"affirm[display_preference][preference]": []string{"on"},
+		"klarna[display_preference][preference]": []string{"on"},
+	}, updates[0])
+
+	require.Contains(t, out.String(), "Changes to pmc_123:")
+	require.Contains(t, out.String(), "affirm          off → on")
+	require.Contains(t, out.String(), "klarna          none → on (not available on the account")
+	require.NotContains(t, out.String(), "card ")
+	require.Contains(t, out.String(), "Updated pmc_123")
+
+	// Not confirming doesn't apply the changes
+	out.Reset()
+	ptc.in = strings.NewReader("no\n")
+	parentCmd.SetArgs([]string{"enable", "affirm"})
+	err = parentCmd.ExecuteContext(context.Background())
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+	require.Contains(t, out.String(), "Exiting without applying the changes.")
+}
+
+func TestPlanPaymentMethodChanges(t *testing.T) {
+	configuration := gjson.Parse(testPaymentMethodConfiguration)
+
+	changes, err := planPaymentMethodChanges(configuration, []string{"card", "affirm"}, "off")
+	require.NoError(t, err)
+	require.Equal(t, []paymentMethodChange{
+		{Type: "card", Current: "on", New: "off", Available: true},
+	}, changes)
+
+	_, err = planPaymentMethodChanges(configuration, []string{"bitcoin"}, "on")
+	require.EqualError(t, err, "unknown payment method ‘bitcoin’ for pmc_123. Payment methods are affirm, card, klarna")
+}
+
+func TestParsePaymentMethodTypes(t *testing.T) {
+	require.Equal(t, []string{"klarna", "affirm", "card"}, parsePaymentMethodTypes([]string{"klarna, Affirm,", "card,klarna"}))
+}
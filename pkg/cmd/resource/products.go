@@ -0,0 +1,31 @@
+package resource
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+)
+
+// AddProductsSubCmds adds custom subcommands to the `products` command created
+// automatically as a resource command.
+func AddProductsSubCmds(rootCmd *cobra.Command, cfg *config.Config) error {
+	found := false
+
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Use == "products" {
+			found = true
+
+			NewProductsWizardCmd(cmd, cfg)
+
+			break
+		}
+	}
+
+	if !found {
+		return errors.New("Could not find products command")
+	}
+
+	return nil
+}
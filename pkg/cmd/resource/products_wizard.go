@@ -0,0 +1,347 @@
+package resource
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/products"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+// Pricing models offered by the wizard
+const (
+	flatRate        = "Flat rate"
+	tieredGraduated = "Tiered, each tier's rate applies to the units in it (graduated)"
+	tieredVolume    = "Tiered, the rate of the last tier applies to all units (volume)"
+)
+
+// ProductsWizardCmd walks through creating a product and its prices
+type ProductsWizardCmd struct {
+	cfg *config.Config
+	cmd *cobra.Command
+
+	apiBaseURL string
+}
+
+// NewProductsWizardCmd returns a new products wizard command
+func NewProductsWizardCmd(parentCmd *cobra.Command, config *config.Config) {
+	wc := &ProductsWizardCmd{
+		cfg: config,
+	}
+
+	wc.cmd = &cobra.Command{
+		Use:   "wizard",
+		Args:  validators.NoArgs,
+		Short: "Create a product and its prices interactively",
+		Long: `Create a product and its prices by answering prompts: one-time and
+recurring prices, flat or tiered, in any currency, with their tax behavior.
+The IDs are printed as environment variables, to paste into the .env file of
+your app.`,
+		Example: `stripe products wizard`,
+		RunE:    wc.runWizardCmd,
+	}
+
+	// Hidden configuration flags, useful for dev/debugging
+	wc.cmd.Flags().StringVar(&wc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	wc.cmd.Flags().MarkHidden("api-base") // #nosec G104
+
+	parentCmd.AddCommand(wc.cmd)
+}
+
+func (wc *ProductsWizardCmd) runWizardCmd(cmd *cobra.Command, args []string) error {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return errors.New("the wizard is interactive, run it in a terminal")
+	}
+
+	key, err := wc.cfg.Profile.GetAPIKey(false)
+	if err != nil {
+		return err
+	}
+
+	product := &products.Product{}
+
+	product.Name, err = promptText("Product name", "", required)
+	if err != nil {
+		return err
+	}
+
+	product.Description, err = promptText("Description (optional)", "", nil)
+	if err != nil {
+		return err
+	}
+
+	for {
+		price, err := promptPrice()
+		if err != nil {
+			return err
+		}
+		product.Prices = append(product.Prices, *price)
+
+		another, err := selectOption("Add another price?", []string{"No", "Yes"})
+		if err != nil {
+			return err
+		}
+		if another == "No" {
+			break
+		}
+	}
+
+	if err := product.Validate(); err != nil {
+		return err
+	}
+
+	printProductSummary(product)
+
+	confirm, err := selectOption(fmt.Sprintf("Create %s with %d price(s)?", product.Name, len(product.Prices)), []string{"Yes", "No"})
+	if err != nil {
+		return err
+	}
+	if confirm == "No" {
+		return nil
+	}
+
+	result, err := products.Create(cmd.Context(), wc.apiBaseURL, key, product, &wc.cfg.Profile)
+	if result != nil && result.ProductID != "" {
+		color := ansi.Color(os.Stdout)
+		fmt.Printf("%s Created %s\n", color.Green("✔"), ansi.Bold(result.ProductID))
+		for _, id := range result.PriceIDs {
+			fmt.Printf("%s Created %s\n", color.Green("✔"), ansi.Bold(id))
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\nAdd them to the environment of your app:\n\n%s", products.EnvSnippet(product, result))
+
+	return nil
+}
+
+func promptPrice() (*products.Price, error) {
+	price := &products.Price{IntervalCount: 1}
+
+	priceType, err := selectOption("Type of price", []string{"One-time", "Recurring"})
+	if err != nil {
+		return nil, err
+	}
+
+	price.Type = products.OneTime
+	if priceType == "Recurring" {
+		price.Type = products.Recurring
+	}
+
+	price.Currency, err = promptText("Currency", "usd", func(input string) error {
+		if len(strings.TrimSpace(input)) != 3 {
+			return errors.New("use a three-letter ISO code, e.g. usd")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	price.Currency = strings.ToLower(strings.TrimSpace(price.Currency))
+
+	model := flatRate
+
+	if price.Type == products.Recurring {
+		price.Interval, err = selectOption("Billing period", products.Intervals)
+		if err != nil {
+			return nil, err
+		}
+
+		count, err := promptText(fmt.Sprintf("Bill every how many %ss", price.Interval), "1", positiveInteger)
+		if err != nil {
+			return nil, err
+		}
+		price.IntervalCount, _ = strconv.ParseInt(count, 10, 64)
+
+		model, err = selectOption("Pricing model", []string{flatRate, tieredGraduated, tieredVolume})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	amount := amountValidator(price.Currency)
+
+	switch model {
+	case flatRate:
+		value, err := promptText(fmt.Sprintf("Amount in %s, e.g. 19.99", strings.ToUpper(price.Currency)), "", amount)
+		if err != nil {
+			return nil, err
+		}
+		price.UnitAmount, _ = products.ParseAmount(value, price.Currency)
+	default:
+		price.TiersMode = products.Graduated
+		if model == tieredVolume {
+			price.TiersMode = products.Volume
+		}
+
+		price.Tiers, err = promptTiers(price.Currency)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	price.TaxBehavior, err = selectOption("Tax behavior", products.TaxBehaviors)
+	if err != nil {
+		return nil, err
+	}
+
+	price.Nickname, err = promptText("Nickname (optional)", "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return price, nil
+}
+
+func promptTiers(currency string) ([]products.Tier, error) {
+	var tiers []products.Tier
+	var last int64
+
+	amount := amountValidator(currency)
+
+	for {
+		first := last + 1
+
+		upTo, err := promptText(fmt.Sprintf("Tier %d: from %d up to how many units (empty for all the remaining units)", len(tiers)+1, first), "", func(input string) error {
+			if strings.TrimSpace(input) == "" {
+				return nil
+			}
+			n, err := strconv.ParseInt(strings.TrimSpace(input), 10, 64)
+			if err != nil || n < first {
+				return fmt.Errorf("enter a number of units from %d", first)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		unit, err := promptText(fmt.Sprintf("Tier %d: amount per unit in %s", len(tiers)+1, strings.ToUpper(currency)), "", amount)
+		if err != nil {
+			return nil, err
+		}
+
+		flat, err := promptText(fmt.Sprintf("Tier %d: flat fee in %s", len(tiers)+1, strings.ToUpper(currency)), "0", amount)
+		if err != nil {
+			return nil, err
+		}
+
+		tier := products.Tier{}
+		tier.UnitAmount, _ = products.ParseAmount(unit, currency)
+		tier.FlatAmount, _ = products.ParseAmount(flat, currency)
+
+		if strings.TrimSpace(upTo) != "" {
+			tier.UpTo, _ = strconv.ParseInt(strings.TrimSpace(upTo), 10, 64)
+			last = tier.UpTo
+		}
+
+		tiers = append(tiers, tier)
+
+		if tier.UpTo == 0 {
+			return tiers, nil
+		}
+	}
+}
+
+func printProductSummary(product *products.Product) {
+	fmt.Printf("\n%s\n", ansi.Bold(product.Name))
+
+	for _, price := range product.Prices {
+		var sb strings.Builder
+
+		if len(price.Tiers) == 0 {
+			sb.WriteString(products.FormatAmount(price.UnitAmount, price.Currency))
+		} else {
+			fmt.Fprintf(&sb, "%s tiers:", price.TiersMode)
+			for _, tier := range price.Tiers {
+				upTo := "inf"
+				if tier.UpTo > 0 {
+					upTo = strconv.FormatInt(tier.UpTo, 10)
+				}
+				fmt.Fprintf(&sb, " [up to %s: %s/unit", upTo, products.FormatAmount(tier.UnitAmount, price.Currency))
+				if tier.FlatAmount > 0 {
+					fmt.Fprintf(&sb, " + %s", products.FormatAmount(tier.FlatAmount, price.Currency))
+				}
+				sb.WriteString("]")
+			}
+		}
+
+		if price.Type == products.Recurring {
+			fmt.Fprintf(&sb, " every %d %s(s)", price.IntervalCount, price.Interval)
+		} else {
+			sb.WriteString(" once")
+		}
+
+		fmt.Fprintf(&sb, ", tax %s", price.TaxBehavior)
+		if price.Nickname != "" {
+			fmt.Fprintf(&sb, " (%s)", price.Nickname)
+		}
+
+		fmt.Printf("  - %s\n", sb.String())
+	}
+
+	fmt.Println()
+}
+
+func selectOption(label string, options []string) (string, error) {
+	color := ansi.Color(os.Stdout)
+
+	templates := &promptui.SelectTemplates{
+		Selected: color.Green("✔").String() + ansi.Faint(fmt.Sprintf(" %s: {{ . | bold }} ", label)),
+	}
+	prompt := promptui.Select{
+		Label:     label,
+		Items:     options,
+		Templates: templates,
+	}
+
+	_, result, err := prompt.Run()
+	if err != nil {
+		return "", err
+	}
+
+	return result, nil
+}
+
+func promptText(label, defaultValue string, validate promptui.ValidateFunc) (string, error) {
+	prompt := promptui.Prompt{
+		Label:    label,
+		Default:  defaultValue,
+		Validate: validate,
+	}
+
+	return prompt.Run()
+}
+
+func required(input string) error {
+	if strings.TrimSpace(input) == "" {
+		return errors.New("required")
+	}
+	return nil
+}
+
+func positiveInteger(input string) error {
+	if n, err := strconv.ParseInt(strings.TrimSpace(input), 10, 64); err != nil || n < 1 {
+		return errors.New("enter a positive number")
+	}
+	return nil
+}
+
+func amountValidator(currency string) promptui.ValidateFunc {
+	return func(input string) error {
+		_, err := products.ParseAmount(input, currency)
+		return err
+	}
+}
@@ -0,0 +1,31 @@
+package resource
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+)
+
+// AddRefundsSubCmds adds custom subcommands to the `refunds` command created
+// automatically as a resource command.
+func AddRefundsSubCmds(rootCmd *cobra.Command, cfg *config.Config) error {
+	found := false
+
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Use == "refunds" {
+			found = true
+
+			NewRefundsBulkCmd(cmd, cfg)
+
+			break
+		}
+	}
+
+	if !found {
+		return errors.New("Could not find refunds command")
+	}
+
+	return nil
+}
@@ -0,0 +1,223 @@
+package resource
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/refunds"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+// RefundsBulkCmd refunds the charges of a CSV file. This command is manually
+// defined because refunding many charges safely needs previews, batching
+// and resuming, which raw API requests don't offer.
+type RefundsBulkCmd struct {
+	Cmd *cobra.Command
+
+	cfg *config.Config
+	fs  afero.Fs
+
+	from        string
+	reason      string
+	checkpoint  string
+	batchSize   int
+	dryRun      bool
+	autoConfirm bool
+	livemode    bool
+	apiBaseURL  string
+
+	in  io.Reader
+	out io.Writer
+}
+
+// NewRefundsBulkCmd returns the `bulk` command of refunds.
+func NewRefundsBulkCmd(parentCmd *cobra.Command, cfg *config.Config) *RefundsBulkCmd {
+	rbc := &RefundsBulkCmd{
+		cfg: cfg,
+		fs:  afero.NewOsFs(),
+		in:  os.Stdin,
+		out: os.Stdout,
+	}
+
+	rbc.Cmd = &cobra.Command{
+		Use:   "bulk",
+		Args:  validators.NoArgs,
+		Short: "Refund the charges of a CSV file",
+		Long: `Refund the charges of a CSV file, in batches. The file has a header line with
+a charge or payment_intent column, and an optional amount column to refund
+part of a charge, in the smallest currency unit.
+
+The refunds are previewed before they're created. Their progress is saved to
+a checkpoint file after every batch, and each refund is sent with an
+idempotency key, so an interrupted run is resumed by running the same command
+again, without refunding a charge twice.`,
+		Example: `stripe refunds bulk --from charges.csv --reason requested_by_customer --dry-run
+  stripe refunds bulk --from charges.csv --reason duplicate
+  stripe refunds bulk --from charges.csv --live --batch-size 10`,
+		RunE: rbc.runRefundsBulkCmd,
+	}
+
+	rbc.Cmd.Flags().StringVar(&rbc.from, "from", "", "CSV file of the charges to refund")
+	rbc.Cmd.Flags().StringVar(&rbc.reason, "reason", "", "Reason of the refunds: duplicate, fraudulent or requested_by_customer")
+	rbc.Cmd.Flags().StringVar(&rbc.checkpoint, "checkpoint", "", "File to save the progress to (default: the CSV file with a .checkpoint.json extension)")
+	rbc.Cmd.Flags().IntVar(&rbc.batchSize, "batch-size", 25, "Number of refunds created at the same time")
+	rbc.Cmd.Flags().BoolVar(&rbc.dryRun, "dry-run", false, "Preview the refunds without creating them")
+	rbc.Cmd.Flags().BoolVarP(&rbc.autoConfirm, "confirm", "c", false, "Skip the preview prompt and create the refunds")
+	rbc.Cmd.Flags().BoolVar(&rbc.livemode, "live", false, "Refund live charges (default: test)")
+	rbc.Cmd.MarkFlagRequired("from") // #nosec G104
+
+	// Hidden configuration flags, useful for dev/debugging
+	rbc.Cmd.Flags().StringVar(&rbc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	rbc.Cmd.Flags().MarkHidden("api-base") // #nosec G104
+
+	parentCmd.AddCommand(rbc.Cmd)
+
+	return rbc
+}
+
+func (rbc *RefundsBulkCmd) runRefundsBulkCmd(cmd *cobra.Command, args []string) error {
+	if rbc.batchSize < 1 {
+		return fmt.Errorf("--batch-size must be at least 1")
+	}
+
+	if rbc.reason != "" && !isIn(rbc.reason, refunds.Reasons) {
+		return fmt.Errorf("invalid reason ‘%s’, expected one of %s", rbc.reason, strings.Join(refunds.Reasons, ", "))
+	}
+
+	f, err := rbc.fs.Open(rbc.from)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	rows, err := refunds.ReadCSV(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", rbc.from, err)
+	}
+
+	checkpointPath := rbc.checkpoint
+	if checkpointPath == "" {
+		checkpointPath = strings.TrimSuffix(rbc.from, ".csv") + ".checkpoint.json"
+	}
+
+	checkpoint, err := refunds.LoadCheckpoint(rbc.fs, checkpointPath)
+	if err != nil {
+		return err
+	}
+
+	pending := checkpoint.Pending(rows)
+	printRefundsPreview(rbc.out, rows, pending, rbc.reason, rbc.livemode)
+
+	if len(pending) == 0 || rbc.dryRun {
+		return nil
+	}
+
+	if !rbc.autoConfirm {
+		fmt.Fprint(rbc.out, "\nCreate these refunds? Enter 'yes' to confirm: ")
+
+		input, err := bufio.NewReader(rbc.in).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+
+		if strings.ToLower(strings.TrimSpace(input)) != "yes" {
+			fmt.Fprintln(rbc.out, "Exiting without creating the refunds.")
+			return nil
+		}
+	}
+
+	apiKey, err := rbc.cfg.Profile.GetAPIKey(rbc.livemode)
+	if err != nil {
+		return err
+	}
+
+	color := ansi.Color(rbc.out)
+
+	bulk := &refunds.Bulk{
+		BaseURL:    rbc.apiBaseURL,
+		APIKey:     apiKey,
+		Reason:     rbc.reason,
+		BatchSize:  rbc.batchSize,
+		Checkpoint: checkpoint,
+		SaveCheckpoint: func() error {
+			return checkpoint.Save(rbc.fs, checkpointPath)
+		},
+		OnRefund: func(row refunds.Row, refund string, err error) {
+			if err != nil {
+				fmt.Fprintf(rbc.out, "%s %s: %s\n", color.Red("✘"), row.Charge, err)
+				return
+			}
+			fmt.Fprintf(rbc.out, "%s %s: %s\n", color.Green("✔"), row.Charge, refund)
+		},
+	}
+
+	fmt.Fprintln(rbc.out)
+	runErr := bulk.Run(cmd.Context(), rows)
+
+	fmt.Fprintf(rbc.out, "\n%d of %d charges refunded, progress saved to %s\n",
+		len(rows)-len(checkpoint.Pending(rows)), len(rows), checkpointPath)
+
+	if runErr != nil {
+		return runErr
+	}
+
+	if len(checkpoint.Failed) > 0 {
+		return fmt.Errorf("%d refund(s) failed, run the command again to retry them", len(checkpoint.Failed))
+	}
+
+	return nil
+}
+
+// printRefundsPreview prints the refunds still to create out of rows.
+func printRefundsPreview(out io.Writer, rows, pending []refunds.Row, reason string, livemode bool) {
+	mode := "test"
+	if livemode {
+		mode = "live"
+	}
+
+	if done := len(rows) - len(pending); done > 0 {
+		fmt.Fprintf(out, "Resuming: %d of %d charges are already refunded.\n", done, len(rows))
+	}
+
+	if len(pending) == 0 {
+		fmt.Fprintln(out, "Nothing to refund.")
+		return
+	}
+
+	if reason == "" {
+		reason = "none"
+	}
+
+	fmt.Fprintf(out, "%d refund(s) to create in %s mode, reason: %s\n\n", len(pending), mode, reason)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LINE\tCHARGE\tAMOUNT")
+	for _, row := range pending {
+		amount := "full"
+		if row.Amount > 0 {
+			amount = fmt.Sprintf("%d", row.Amount)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", row.Line, row.Charge, amount)
+	}
+	w.Flush()
+}
+
+func isIn(value string, values []string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,88 @@
+package resource
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/refunds"
+)
+
+func TestRunRefundsBulkCmd(t *testing.T) {
+	var mu sync.Mutex
+	var charges []string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/refunds", r.URL.Path)
+		r.ParseForm()
+		mu.Lock()
+		charges = append(charges, r.Form.Get("charge"))
+		mu.Unlock()
+		w.Write([]byte(`{"id": "re_` + r.Form.Get("charge") + `"}`))
+	}))
+	defer ts.Close()
+
+	viper.Reset()
+
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "charges.csv", []byte("charge,amount\nch_1,\nch_2,500\n"), 0644)
+
+	parentCmd := &cobra.Command{Annotations: make(map[string]string)}
+	profile := config.Profile{
+		APIKey: "sk_test_1234",
+	}
+	rbc := NewRefundsBulkCmd(parentCmd, &config.Config{Profile: profile})
+	rbc.apiBaseURL = ts.URL
+	rbc.fs = fs
+
+	var out bytes.Buffer
+	rbc.out = &out
+
+	// The dry run only previews the refunds
+	parentCmd.SetArgs([]string{"bulk", "--from", "charges.csv", "--reason", "requested_by_customer", "--dry-run"})
+	err := parentCmd.ExecuteContext(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, charges)
+	require.Contains(t, out.String(), "2 refund(s) to create in test mode, reason: requested_by_customer")
+	require.Contains(t, out.String(), "3     ch_2    500")
+
+	out.Reset()
+	rbc.dryRun = false
+	rbc.in = strings.NewReader("yes\n")
+	parentCmd.SetArgs([]string{"bulk", "--from", "charges.csv"})
+	err = parentCmd.ExecuteContext(context.Background())
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"ch_1", "ch_2"}, charges)
+	require.Contains(t, out.String(), "2 of 2 charges refunded, progress saved to charges.checkpoint.json")
+
+	checkpoint, err := refunds.LoadCheckpoint(fs, "charges.checkpoint.json")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"ch_1": "re_ch_1", "ch_2": "re_ch_2"}, checkpoint.Refunded)
+
+	// Running the command again resumes from the checkpoint
+	out.Reset()
+	err = parentCmd.ExecuteContext(context.Background())
+	require.NoError(t, err)
+	require.Len(t, charges, 2)
+	require.Contains(t, out.String(), "Resuming: 2 of 2 charges are already refunded.")
+	require.Contains(t, out.String(), "Nothing to refund.")
+}
+
+func TestRunRefundsBulkCmdInvalidReason(t *testing.T) {
+	parentCmd := &cobra.Command{Annotations: make(map[string]string)}
+	NewRefundsBulkCmd(parentCmd, &config.Config{})
+
+	parentCmd.SetArgs([]string{"bulk", "--from", "charges.csv", "--reason", "oops"})
+	err := parentCmd.ExecuteContext(context.Background())
+	require.EqualError(t, err, "invalid reason ‘oops’, expected one of duplicate, fraudulent, requested_by_customer")
+}
@@ -24,6 +24,10 @@ type ResourceCmd struct { //nolint:revive
 	OperationCmds map[string]*OperationCmd
 }
 
+// pendingOperations holds the functions adding the operation commands of the
+// resource commands whose operations haven't been loaded yet
+var pendingOperations = make(map[*cobra.Command]func(*cobra.Command))
+
 //
 // Public functions
 //
@@ -65,6 +69,73 @@ func NewResourceCmd(parentCmd *cobra.Command, resourceName string) *ResourceCmd
 	}
 }
 
+// SetOperations defers adding the operation commands of the resource to
+// addOperations, which is called with the resource's command the first time
+// its operations are loaded with LoadOperations. Creating the commands and
+// flags of every operation is most of the startup time of the CLI, so only
+// those of the invoked resource are created.
+func (rc *ResourceCmd) SetOperations(addOperations func(*cobra.Command)) {
+	pendingOperations[rc.Cmd] = addOperations
+}
+
+// AppendOperations defers adding more operation commands to cmd, after those
+// of SetOperations, e.g. the operations of a newer spec added with `stripe
+// resources update`.
+func AppendOperations(cmd *cobra.Command, addOperations func(*cobra.Command)) {
+	previous, ok := pendingOperations[cmd]
+	if !ok {
+		pendingOperations[cmd] = addOperations
+		return
+	}
+
+	pendingOperations[cmd] = func(cmd *cobra.Command) {
+		previous(cmd)
+		addOperations(cmd)
+	}
+}
+
+// LoadOperations adds the operation commands of cmd and of the resources
+// nested under it that haven't been loaded yet.
+func LoadOperations(cmd *cobra.Command) {
+	if addOperations, ok := pendingOperations[cmd]; ok {
+		delete(pendingOperations, cmd)
+		addOperations(cmd)
+	}
+
+	for _, child := range cmd.Commands() {
+		LoadOperations(child)
+	}
+}
+
+// LoadOperationsForArgs loads the operations of the resource or namespace
+// command args invoke, including through `stripe help` and shell completion
+// requests. Commands that only list resources by name, like the root command,
+// don't need their operations.
+func LoadOperationsForArgs(rootCmd *cobra.Command, args []string) {
+	completing := false
+	if len(args) > 0 {
+		switch args[0] {
+		case cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+			completing = true
+			args = args[1:]
+		case "help":
+			args = args[1:]
+		}
+	}
+
+	cmd, _, err := rootCmd.Find(args)
+	if err != nil {
+		// Partially typed commands are completed from the root command
+		cmd = rootCmd
+	}
+
+	if cmd == rootCmd && !completing {
+		return
+	}
+
+	LoadOperations(cmd)
+}
+
 //
 // Private functions
 //
@@ -5,6 +5,8 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/config"
 )
 
 func TestNewResourceCmd(t *testing.T) {
@@ -19,3 +21,47 @@ func TestNewResourceCmd(t *testing.T) {
 	require.Equal(t, "resource", val)
 	require.Contains(t, rc.Cmd.UsageTemplate(), "Available Operations")
 }
+
+func TestLoadOperations(t *testing.T) {
+	rootCmd := &cobra.Command{Use: "stripe", Annotations: make(map[string]string)}
+	nsCmd := NewNamespaceCmd(rootCmd, "issuing")
+	rc := NewResourceCmd(nsCmd.Cmd, "cards")
+
+	loads := 0
+	rc.SetOperations(func(parentCmd *cobra.Command) {
+		loads++
+		NewOperationCmd(parentCmd, "list", "/v1/issuing/cards", "get", map[string]string{}, &config.Config{})
+	})
+
+	require.False(t, rc.Cmd.HasSubCommands())
+
+	LoadOperations(nsCmd.Cmd)
+	LoadOperations(rc.Cmd)
+
+	require.Equal(t, 1, loads)
+	require.True(t, rc.Cmd.HasSubCommands())
+}
+
+func TestLoadOperationsForArgs(t *testing.T) {
+	rootCmd := &cobra.Command{Use: "stripe", Annotations: make(map[string]string)}
+	customersCmd := NewResourceCmd(rootCmd, "customers")
+	chargesCmd := NewResourceCmd(rootCmd, "charges")
+
+	loaded := make(map[string]bool)
+	for _, rc := range []*ResourceCmd{customersCmd, chargesCmd} {
+		name := rc.Name
+		rc.SetOperations(func(parentCmd *cobra.Command) {
+			loaded[name] = true
+			NewOperationCmd(parentCmd, "list", "/v1/"+name, "get", map[string]string{}, &config.Config{})
+		})
+	}
+
+	LoadOperationsForArgs(rootCmd, []string{"--help"})
+	require.Empty(t, loaded)
+
+	LoadOperationsForArgs(rootCmd, []string{"help", "customers", "list"})
+	require.Equal(t, map[string]bool{"customers": true}, loaded)
+
+	LoadOperationsForArgs(rootCmd, []string{cobra.ShellCompRequestCmd, "cha"})
+	require.Equal(t, map[string]bool{"customers": true, "charges": true}, loaded)
+}
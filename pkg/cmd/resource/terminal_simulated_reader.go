@@ -0,0 +1,165 @@
+package resource
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/terminal"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+// SimulatedReaderCmd creates simulated Terminal readers and takes test
+// payments with them, like the simulated reader of the Dashboard
+type SimulatedReaderCmd struct {
+	cfg *config.Config
+	cmd *cobra.Command
+
+	location          string
+	label             string
+	paymentMethodType string
+	scenario          string
+	number            string
+	amount            int64
+	currency          string
+	apiBaseURL        string
+}
+
+// NewSimulatedReaderCmd returns a new terminal simulated-reader command
+func NewSimulatedReaderCmd(parentCmd *cobra.Command, config *config.Config) {
+	sc := &SimulatedReaderCmd{
+		cfg: config,
+	}
+
+	sc.cmd = &cobra.Command{
+		Use:   "simulated-reader",
+		Args:  validators.NoArgs,
+		Short: "Take test payments with a simulated Terminal reader",
+		Long: `Create a simulated Terminal reader, and take test payments with it without
+a physical reader, presenting test cards that succeed or fail.
+
+Card scenarios: ` + strings.Join(terminal.Scenarios(terminal.PresentCard), ", ") + `
+Interac scenarios: ` + strings.Join(terminal.Scenarios(terminal.PresentInterac), ", "),
+	}
+
+	createCmd := &cobra.Command{
+		Use:     "create",
+		Args:    validators.NoArgs,
+		Short:   "Create a simulated reader",
+		Example: `stripe terminal simulated-reader create --label "Front desk"`,
+		RunE:    sc.runCreateCmd,
+	}
+	createCmd.Flags().StringVar(&sc.location, "location", "", "ID of the location of the reader (default: a new location)")
+	createCmd.Flags().StringVar(&sc.label, "label", "", "Label of the reader")
+
+	payCmd := &cobra.Command{
+		Use:   "pay <reader_id>",
+		Args:  validators.ExactArgs(1),
+		Short: "Process a payment end to end on a simulated reader",
+		Long: `Create a payment intent, have the simulated reader process it, present a test
+payment method, and capture the payment once the reader collected it.`,
+		Example: `stripe terminal simulated-reader pay tmr_123 --amount 1000
+  stripe terminal simulated-reader pay tmr_123 --scenario declined
+  stripe terminal simulated-reader pay tmr_123 --type interac --currency cad`,
+		RunE: sc.runPayCmd,
+	}
+	payCmd.Flags().Int64Var(&sc.amount, "amount", 1000, "Amount of the payment, in the smallest currency unit")
+	payCmd.Flags().StringVar(&sc.currency, "currency", "usd", "Currency of the payment")
+
+	presentCmd := &cobra.Command{
+		Use:   "present <reader_id>",
+		Args:  validators.ExactArgs(1),
+		Short: "Present a test payment method to a simulated reader",
+		Long: `Present a test payment method to a simulated reader, completing the payment
+intent it's processing, e.g. one handed to it by your integration.`,
+		Example: `stripe terminal simulated-reader present tmr_123 --scenario insufficient_funds`,
+		RunE:    sc.runPresentCmd,
+	}
+
+	for _, cmd := range []*cobra.Command{payCmd, presentCmd} {
+		cmd.Flags().StringVar(&sc.paymentMethodType, "type", terminal.PresentCard, "Type of payment method to present: card or interac")
+		cmd.Flags().StringVar(&sc.scenario, "scenario", "success", "Scenario to simulate")
+		cmd.Flags().StringVar(&sc.number, "number", "", "Test number to present, instead of the number of the scenario")
+	}
+
+	sc.cmd.AddCommand(createCmd, payCmd, presentCmd)
+
+	// Hidden configuration flags, useful for dev/debugging
+	sc.cmd.PersistentFlags().StringVar(&sc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	sc.cmd.PersistentFlags().MarkHidden("api-base") // #nosec G104
+
+	parentCmd.AddCommand(sc.cmd)
+}
+
+func (sc *SimulatedReaderCmd) reader() (*terminal.SimulatedReader, error) {
+	key, err := sc.cfg.Profile.GetAPIKey(false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validators.APIKeyNotRestricted(key); err != nil {
+		return nil, err
+	}
+
+	return &terminal.SimulatedReader{BaseURL: sc.apiBaseURL, APIKey: key, Profile: &sc.cfg.Profile}, nil
+}
+
+func (sc *SimulatedReaderCmd) runCreateCmd(cmd *cobra.Command, args []string) error {
+	reader, err := sc.reader()
+	if err != nil {
+		return err
+	}
+
+	id, err := reader.Create(cmd.Context(), sc.location, sc.label)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Created the simulated reader %s, take a payment with `stripe terminal simulated-reader pay %s`\n", ansi.Bold(id), id)
+
+	return nil
+}
+
+func (sc *SimulatedReaderCmd) runPayCmd(cmd *cobra.Command, args []string) error {
+	reader, err := sc.reader()
+	if err != nil {
+		return err
+	}
+
+	result, err := reader.Pay(cmd.Context(), args[0], sc.amount, sc.currency, sc.paymentMethodType, sc.scenario, sc.number)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	color := ansi.Color(out)
+
+	if result.FailureCode != "" {
+		fmt.Fprintf(out, "%s The reader failed to collect %s: %s (%s)\n", color.Red("✘"), result.PaymentIntent, result.FailureMessage, result.FailureCode)
+		fmt.Fprintf(out, "The payment intent is %s\n", ansi.Bold(result.Status))
+		return nil
+	}
+
+	fmt.Fprintf(out, "%s Collected %s, the payment intent is %s\n", color.Green("✔"), result.PaymentIntent, ansi.Bold(result.Status))
+
+	return nil
+}
+
+func (sc *SimulatedReaderCmd) runPresentCmd(cmd *cobra.Command, args []string) error {
+	reader, err := sc.reader()
+	if err != nil {
+		return err
+	}
+
+	if err := reader.Present(cmd.Context(), args[0], sc.paymentMethodType, sc.scenario, sc.number); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Presented a test %s (%s) to %s\n", sc.paymentMethodType, sc.scenario, args[0])
+
+	return nil
+}
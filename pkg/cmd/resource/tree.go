@@ -0,0 +1,287 @@
+package resource
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/spec"
+)
+
+//
+// Public types
+//
+
+// TreeData is the tree of namespace, resource and operation commands built
+// from the OpenAPI spec, by name. The commands of the CLI are generated from
+// it, and `stripe resources update` caches it to add the commands of a newer
+// spec at runtime.
+type TreeData struct {
+	Namespaces map[string]*NamespaceData `json:"namespaces"`
+}
+
+// NamespaceData is the resources of a namespace. Resources that aren't
+// namespaced are in the namespace with the empty name.
+type NamespaceData struct {
+	Resources map[string]*ResourceData `json:"resources"`
+}
+
+// ResourceData is the operations of a resource
+type ResourceData struct {
+	Operations map[string]*OperationData `json:"operations"`
+}
+
+// OperationData is an operation, with the flags of its scalar parameters
+// mapped to their type
+type OperationData struct {
+	Path      string            `json:"path"`
+	HTTPVerb  string            `json:"http_verb"`
+	PropFlags map[string]string `json:"prop_flags"`
+}
+
+var scalarTypes = map[string]bool{
+	"boolean": true,
+	"integer": true,
+	"number":  true,
+	"string":  true,
+}
+
+//
+// Public functions
+//
+
+// NewTreeData returns the tree of commands of the service operations of an
+// OpenAPI spec, leaving out deprecated operations.
+func NewTreeData(stripeAPI *spec.Spec) *TreeData {
+	data := &TreeData{
+		Namespaces: make(map[string]*NamespaceData),
+	}
+
+	// Iterate over every resource schema
+	for name, schema := range stripeAPI.Components.Schemas {
+		// Skip resources that don't have any operations
+		if schema.XStripeOperations == nil {
+			continue
+		}
+
+		nsName, resName := parseSchemaName(name)
+
+		// Iterate over every operation for the resource
+		for _, op := range *schema.XStripeOperations {
+			// We're only implementing "service" operations
+			if op.MethodOn != "service" {
+				continue
+			}
+
+			// If we haven't seen the namespace before, initialize it
+			if _, ok := data.Namespaces[nsName]; !ok {
+				data.Namespaces[nsName] = &NamespaceData{
+					Resources: make(map[string]*ResourceData),
+				}
+			}
+
+			// If we haven't seen the resource before, initialize it
+			resCmdName := GetResourceCmdName(resName)
+			if _, ok := data.Namespaces[nsName].Resources[resCmdName]; !ok {
+				data.Namespaces[nsName].Resources[resCmdName] = &ResourceData{
+					Operations: make(map[string]*OperationData),
+				}
+			}
+
+			// If we haven't seen the operation before, initialize it
+			if _, ok := data.Namespaces[nsName].Resources[resCmdName].Operations[op.MethodName]; !ok {
+				httpString := string(op.Operation)
+				properties := make(map[string]string)
+
+				specOp := stripeAPI.Paths[spec.Path(op.Path)][spec.HTTPVerb(httpString)]
+				if specOp == nil {
+					continue
+				}
+
+				// Skip deprecated methods
+				if specOp.Deprecated != nil && *specOp.Deprecated {
+					continue
+				}
+
+				if strings.ToUpper(httpString) == http.MethodPost {
+					if specOp.RequestBody == nil {
+						continue
+					}
+					requestContent := specOp.RequestBody.Content
+
+					// Requests to the v2 API are JSON encoded
+					media, ok := requestContent["application/x-www-form-urlencoded"]
+					if !ok {
+						media, ok = requestContent["application/json"]
+					}
+
+					if ok && media.Schema != nil {
+						for propName, schema := range media.Schema.Properties {
+							scalarType := getScalarType(schema)
+
+							if scalarType == nil {
+								continue
+							}
+
+							properties[propName] = *scalarType
+						}
+					}
+				} else {
+					for _, param := range specOp.Parameters {
+						// Only create flags for query string parameters
+						if param.In != "query" {
+							continue
+						}
+
+						schema := param.Schema
+						scalarType := getScalarType(schema)
+
+						if scalarType == nil {
+							continue
+						}
+
+						properties[param.Name] = *scalarType
+					}
+				}
+
+				data.Namespaces[nsName].Resources[resCmdName].Operations[op.MethodName] = &OperationData{
+					Path:      op.Path,
+					HTTPVerb:  httpString,
+					PropFlags: properties,
+				}
+			}
+		}
+	}
+
+	return data
+}
+
+// AddCommands adds the namespace, resource and operation commands of the
+// tree that rootCmd doesn't have yet, so that the resources of a newer spec
+// are added to those the CLI was built with. Like those of the generated
+// commands, the operations are added when their resource is invoked, unless
+// an operation of the same name was added in the meantime.
+func (t *TreeData) AddCommands(rootCmd *cobra.Command, cfg *config.Config) {
+	for nsName, nsData := range t.Namespaces {
+		parentCmd := rootCmd
+		if nsName != "" {
+			parentCmd = findSubCmd(rootCmd, nsName)
+			if parentCmd == nil {
+				parentCmd = NewNamespaceCmd(rootCmd, nsName).Cmd
+			}
+		}
+
+		for resName, resData := range nsData.Resources {
+			if len(resData.Operations) == 0 {
+				continue
+			}
+
+			resCmd := findSubCmd(parentCmd, resName)
+			if resCmd == nil {
+				resCmd = NewResourceCmd(parentCmd, resName).Cmd
+			}
+
+			operations := resData.Operations
+			AppendOperations(resCmd, func(parentCmd *cobra.Command) {
+				for opName, opData := range operations {
+					if findSubCmd(parentCmd, opName) == nil {
+						NewOperationCmd(parentCmd, opName, opData.Path, opData.HTTPVerb, opData.PropFlags, cfg)
+					}
+				}
+			})
+		}
+	}
+}
+
+// CountNew returns the number of resources and operations of the tree that
+// rootCmd doesn't have. The operations of rootCmd must have been loaded with
+// LoadOperations.
+func (t *TreeData) CountNew(rootCmd *cobra.Command) (int, int) {
+	resources, operations := 0, 0
+
+	for nsName, nsData := range t.Namespaces {
+		parentCmd := rootCmd
+		if nsName != "" {
+			parentCmd = findSubCmd(rootCmd, nsName)
+		}
+
+		for resName, resData := range nsData.Resources {
+			if len(resData.Operations) == 0 {
+				continue
+			}
+
+			var resCmd *cobra.Command
+			if parentCmd != nil {
+				resCmd = findSubCmd(parentCmd, resName)
+			}
+			if resCmd == nil {
+				resources++
+			}
+
+			for opName := range resData.Operations {
+				if resCmd == nil || findSubCmd(resCmd, opName) == nil {
+					operations++
+				}
+			}
+		}
+	}
+
+	return resources, operations
+}
+
+//
+// Private functions
+//
+
+func findSubCmd(cmd *cobra.Command, name string) *cobra.Command {
+	for _, child := range cmd.Commands() {
+		if child.Name() == name {
+			return child
+		}
+	}
+
+	return nil
+}
+
+func parseSchemaName(name string) (string, string) {
+	if strings.Contains(name, ".") {
+		components := strings.SplitN(name, ".", 2)
+		return components[0], components[1]
+	}
+	return "", name
+}
+
+// getScalarType accepts a schema and returns its scalar type, if it has one.
+//
+// If the schema is monomorphic, it returns its type if it's scalar.
+//
+// If the schema is polymorphic, it returns the first scalar type for the
+// schema, if there is any.
+func getScalarType(schema *spec.Schema) *string {
+	if schema == nil {
+		return nil
+	}
+
+	if len(schema.AnyOf) > 0 {
+		for _, subSchema := range schema.AnyOf {
+			scalarType := getScalarType(subSchema)
+			if scalarType != nil {
+				return scalarType
+			}
+		}
+	} else if scalarTypes[schema.Type] {
+		// Special case for string types that only support the "" (empty
+		// string) value: we consider these to be non-scalar so we don't
+		// generate a flag for those.
+		if schema.Type == "string" {
+			if len(schema.Enum) == 1 && schema.Enum[0] == "" {
+				return nil
+			}
+		}
+		return &schema.Type
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/spec"
+)
+
+const treeSpec = `{
+  "info": {"version": "2024-06-20"},
+  "components": {"schemas": {
+    "customer": {"x-stripeOperations": [
+      {"method_name": "list", "method_on": "service", "operation": "get", "path": "/v1/customers"},
+      {"method_name": "search", "method_on": "service", "operation": "get", "path": "/v1/customers/search"},
+      {"method_name": "retrieve", "method_on": "collection", "operation": "get", "path": "/v1/customers/{customer}"}
+    ]},
+    "billing.meter_event": {"x-stripeOperations": [
+      {"method_name": "create", "method_on": "service", "operation": "post", "path": "/v2/billing/meter_events"}
+    ]}
+  }},
+  "paths": {
+    "/v1/customers": {"get": {"parameters": [
+      {"in": "query", "name": "email", "schema": {"type": "string"}},
+      {"in": "query", "name": "expand", "schema": {"type": "array"}}
+    ]}},
+    "/v1/customers/search": {"get": {"deprecated": true}},
+    "/v2/billing/meter_events": {"post": {"requestBody": {"content": {"application/json": {"schema": {
+      "properties": {"event_name": {"type": "string"}, "payload": {"type": "object"}}
+    }}}}}}
+  }
+}`
+
+func TestNewTreeData(t *testing.T) {
+	stripeAPI, err := spec.ParseSpec([]byte(treeSpec))
+	require.NoError(t, err)
+
+	tree := NewTreeData(stripeAPI)
+
+	require.Len(t, tree.Namespaces, 2)
+	require.Equal(t, map[string]*OperationData{
+		"list": {Path: "/v1/customers", HTTPVerb: "get", PropFlags: map[string]string{"email": "string"}},
+	}, tree.Namespaces[""].Resources["customers"].Operations)
+	require.Equal(t, map[string]*OperationData{
+		"create": {Path: "/v2/billing/meter_events", HTTPVerb: "post", PropFlags: map[string]string{"event_name": "string"}},
+	}, tree.Namespaces["billing"].Resources["meter_events"].Operations)
+}
+
+func TestTreeDataAddCommands(t *testing.T) {
+	rootCmd := &cobra.Command{Use: "stripe", Annotations: make(map[string]string)}
+	customersCmd := NewResourceCmd(rootCmd, "customers")
+	customersCmd.SetOperations(func(parentCmd *cobra.Command) {
+		NewOperationCmd(parentCmd, "list", "/v1/customers", "get", map[string]string{}, &config.Config{})
+	})
+
+	tree := &TreeData{Namespaces: map[string]*NamespaceData{
+		"": {Resources: map[string]*ResourceData{
+			"customers": {Operations: map[string]*OperationData{
+				"list":   {Path: "/v1/customers", HTTPVerb: "get", PropFlags: map[string]string{"email": "string"}},
+				"delete": {Path: "/v1/customers/{customer}", HTTPVerb: "delete", PropFlags: map[string]string{}},
+			}},
+		}},
+		"billing": {Resources: map[string]*ResourceData{
+			"meter_events": {Operations: map[string]*OperationData{
+				"create": {Path: "/v2/billing/meter_events", HTTPVerb: "post", PropFlags: map[string]string{"event_name": "string"}},
+			}},
+		}},
+	}}
+
+	tree.AddCommands(rootCmd, &config.Config{})
+	LoadOperations(rootCmd)
+
+	require.Equal(t, "namespace", rootCmd.Annotations["billing"])
+
+	cmd, _, err := rootCmd.Find([]string{"billing", "meter_events", "create"})
+	require.NoError(t, err)
+	require.Equal(t, "create", cmd.Name())
+	require.NotNil(t, cmd.Flags().Lookup("event-name"))
+
+	// Operations the CLI already has are kept
+	var names []string
+	for _, child := range customersCmd.Cmd.Commands() {
+		names = append(names, child.Name())
+	}
+	require.ElementsMatch(t, []string{"delete", "list"}, names)
+	list, _, err := rootCmd.Find([]string{"customers", "list"})
+	require.NoError(t, err)
+	require.Nil(t, list.Flags().Lookup("email"))
+
+	resources, operations := tree.CountNew(rootCmd)
+	require.Equal(t, 0, resources)
+	require.Equal(t, 0, operations)
+
+	resources, operations = tree.CountNew(&cobra.Command{Use: "stripe"})
+	require.Equal(t, 2, resources)
+	require.Equal(t, 3, operations)
+}
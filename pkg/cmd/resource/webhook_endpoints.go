@@ -0,0 +1,66 @@
+package resource
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/proxy"
+)
+
+// AddWebhookEndpointsSubCmds adds an --events flag to the `create` and
+// `update` commands of the `webhook_endpoints` command created automatically
+// as a resource command, which accepts event presets like preset:payments.
+func AddWebhookEndpointsSubCmds(rootCmd *cobra.Command) error {
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Use != "webhook_endpoints" {
+			continue
+		}
+
+		// Keep the operations loaded lazily, the flag is added once they are
+		addOperations, pending := pendingOperations[cmd]
+		if !pending {
+			addEnabledEventsFlags(cmd)
+			return nil
+		}
+
+		pendingOperations[cmd] = func(cmd *cobra.Command) {
+			addOperations(cmd)
+			addEnabledEventsFlags(cmd)
+		}
+
+		return nil
+	}
+
+	return errors.New("Could not find webhook_endpoints command")
+}
+
+// addEnabledEventsFlags adds the --events flag to the operations setting the
+// enabled events of a webhook endpoint. The events, with their presets
+// expanded, are sent as `enabled_events[]` data.
+func addEnabledEventsFlags(cmd *cobra.Command) {
+	for _, c := range cmd.Commands() {
+		if c.Use != "create" && c.Use != "update" {
+			continue
+		}
+
+		var events []string
+		c.Flags().StringSliceVar(&events, "events", []string{}, "A comma-separated list of events the endpoint receives, or of presets like preset:payments, preset:billing and preset:connect")
+
+		runE := c.RunE
+		c.RunE = func(cmd *cobra.Command, args []string) error {
+			expanded, err := proxy.ExpandEventPresets(events)
+			if err != nil {
+				return err
+			}
+
+			for _, event := range expanded {
+				if err := cmd.Flags().Set("data", "enabled_events[]="+event); err != nil {
+					return err
+				}
+			}
+
+			return runE(cmd, args)
+		}
+	}
+}
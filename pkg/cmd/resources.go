@@ -1,20 +1,56 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 
 	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/cmd/resource"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/spec"
 	"github.com/stripe/stripe-cli/pkg/validators"
 )
 
+// defaultSpecURL is the OpenAPI spec `stripe resources update` downloads
+const defaultSpecURL = "https://raw.githubusercontent.com/stripe/openapi/master/openapi/spec3.sdk.json"
+
+// defaultHelpTemplate is the default help template of cobra, for the
+// subcommands of `stripe resources`, whose help template lists the resources
+const defaultHelpTemplate = `{{with (or .Long .Short)}}{{. | trimTrailingWhitespaces}}
+
+{{end}}{{if or .Runnable .HasSubCommands}}{{.UsageString}}{{end}}`
+
 type resourcesCmd struct {
 	cmd *cobra.Command
+	fs  afero.Fs
+
+	spec  string
+	reset bool
+}
+
+// cachedResources are the resource commands of a newer spec cached by
+// `stripe resources update`
+type cachedResources struct {
+	APIVersion string    `json:"api_version"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	resource.TreeData
 }
 
 func newResourcesCmd() *resourcesCmd {
-	rc := &resourcesCmd{}
+	rc := &resourcesCmd{
+		fs: afero.NewOsFs(),
+	}
 
 	rc.cmd = &cobra.Command{
 		Use:   "resources",
@@ -23,15 +59,152 @@ func newResourcesCmd() *resourcesCmd {
 	}
 	rc.cmd.SetHelpTemplate(getResourcesHelpTemplate())
 
+	updateCmd := &cobra.Command{
+		Use:   "update",
+		Args:  validators.NoArgs,
+		Short: "Update the resource commands to the latest API",
+		Long: `Download the latest OpenAPI spec of the Stripe API, and add the resources and
+operations it has that the CLI doesn't to the resource commands, like
+` + "`stripe <resource> <operation>`" + `. The commands are cached, and used until the
+next update, so new API resources can be used without waiting for a release of
+the CLI.`,
+		Example: `stripe resources update
+  stripe resources update --spec ./spec3.sdk.json
+  stripe resources update --reset`,
+		RunE: rc.runUpdateCmd,
+	}
+	updateCmd.SetHelpTemplate(defaultHelpTemplate)
+	updateCmd.Flags().StringVar(&rc.spec, "spec", defaultSpecURL, "URL or file of the OpenAPI spec")
+	updateCmd.Flags().BoolVar(&rc.reset, "reset", false, "Remove the cached commands, and only use the resource commands the CLI was built with")
+
+	rc.cmd.AddCommand(updateCmd)
+
 	return rc
 }
 
+func (rc *resourcesCmd) runUpdateCmd(cmd *cobra.Command, args []string) error {
+	if config.IsStateless() {
+		return config.ErrStateless
+	}
+
+	path := resourcesCachePath()
+
+	if rc.reset {
+		if err := rc.fs.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		fmt.Println("Removed the cached resource commands, the commands the CLI was built with are used")
+		return nil
+	}
+
+	spinner := ansi.StartNewSpinner("Downloading the OpenAPI spec...", os.Stdout)
+	data, err := rc.readSpec(cmd.Context())
+	ansi.StopSpinner(spinner, "", os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	stripeAPI, err := spec.ParseSpec(data)
+	if err != nil {
+		return err
+	}
+
+	cached := cachedResources{
+		UpdatedAt: time.Now().UTC(),
+		TreeData:  *resource.NewTreeData(stripeAPI),
+	}
+	if stripeAPI.Info != nil {
+		cached.APIVersion = stripeAPI.Info.Version
+	}
+
+	if len(cached.Namespaces) == 0 {
+		return fmt.Errorf("the spec has no resources")
+	}
+
+	// Count what's new compared to the commands the CLI was built with and
+	// those of the previous update
+	rootCmd := cmd.Root()
+	resource.LoadOperations(rootCmd)
+	resources, operations := cached.CountNew(rootCmd)
+
+	encoded, err := json.Marshal(cached)
+	if err != nil {
+		return err
+	}
+
+	if err := rc.fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := afero.WriteFile(rc.fs, path+".tmp", encoded, 0644); err != nil {
+		return err
+	}
+	if err := rc.fs.Rename(path+".tmp", path); err != nil {
+		return err
+	}
+
+	fmt.Printf("Updated the resource commands to API version %s: %d new resource(s) and %d new operation(s)\n", cached.APIVersion, resources, operations)
+
+	return nil
+}
+
+// readSpec returns the OpenAPI spec of --spec, from a URL or a file
+func (rc *resourcesCmd) readSpec(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(rc.spec, "https://") && !strings.HasPrefix(rc.spec, "http://") {
+		return afero.ReadFile(rc.fs, rc.spec)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rc.spec, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not download the OpenAPI spec: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not download the OpenAPI spec: status=%d", resp.StatusCode)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+// resourcesCachePath returns the file the resource commands of `stripe
+// resources update` are cached in
+func resourcesCachePath() string {
+	cfg := &config.Config{}
+	return filepath.Join(cfg.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")), "resources.json")
+}
+
+// addCachedResourcesCmds adds the resource commands cached by `stripe
+// resources update` that the CLI wasn't built with. A cache that can't be
+// read is ignored, so that it doesn't break the CLI.
+func addCachedResourcesCmds(fs afero.Fs, rootCmd *cobra.Command, cfg *config.Config, path string) {
+	data, err := afero.ReadFile(config.StateFs(fs), path)
+	if err != nil {
+		return
+	}
+
+	var cached cachedResources
+	if err := json.Unmarshal(data, &cached); err != nil {
+		log.WithFields(log.Fields{
+			"prefix": "cmd.addCachedResourcesCmds",
+		}).Debugf("Could not read the cached resource commands of %s: %v", path, err)
+		return
+	}
+
+	cached.AddCommands(rootCmd, cfg)
+}
+
 func getResourcesHelpTemplate() string {
 	// This template uses `.Parent` to access subcommands on the root command.
 	return fmt.Sprintf(`%s{{range $index, $cmd := .Parent.Commands}}{{if (or (eq (index $.Parent.Annotations $cmd.Name) "resource") (eq (index $.Parent.Annotations $cmd.Name) "namespace"))}}
   {{rpad $cmd.Name $cmd.NamePadding }} {{$cmd.Short}}{{end}}{{end}}
 
 Use "stripe [command] --help" for more information about a command.
+Use "stripe resources update" to add the resources of the latest API.
 `,
 		ansi.Bold("Available commands:"),
 	)
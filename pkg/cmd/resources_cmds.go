@@ -136,1833 +136,2029 @@ func addAllResourcesCmds(rootCmd *cobra.Command) {
 	rTreasuryTransactionEntrysCmd := resource.NewResourceCmd(nsTreasuryCmd.Cmd, "transaction_entrys")
 	rTreasuryTransactionsCmd := resource.NewResourceCmd(nsTreasuryCmd.Cmd, "transactions")
 
-	// Operation commands
-	resource.NewOperationCmd(r3DSecureCmd.Cmd, "create", "/v1/3d_secure", http.MethodPost, map[string]string{
-		"amount":     "integer",
-		"card":       "string",
-		"currency":   "string",
-		"customer":   "string",
-		"return_url": "string",
-	}, &Config)
-	resource.NewOperationCmd(r3DSecureCmd.Cmd, "retrieve", "/v1/3d_secure/{three_d_secure}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rAccountLinksCmd.Cmd, "create", "/v1/account_links", http.MethodPost, map[string]string{
-		"account":     "string",
-		"collect":     "string",
-		"refresh_url": "string",
-		"return_url":  "string",
-		"type":        "string",
-	}, &Config)
-	resource.NewOperationCmd(rAccountsCmd.Cmd, "capabilities", "/v1/accounts/{account}/capabilities", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rAccountsCmd.Cmd, "create", "/v1/accounts", http.MethodPost, map[string]string{
-		"account_token":    "string",
-		"business_type":    "string",
-		"country":          "string",
-		"default_currency": "string",
-		"email":            "string",
-		"external_account": "string",
-		"type":             "string",
-	}, &Config)
-	resource.NewOperationCmd(rAccountsCmd.Cmd, "delete", "/v1/accounts/{account}", http.MethodDelete, map[string]string{}, &Config)
-	resource.NewOperationCmd(rAccountsCmd.Cmd, "list", "/v1/accounts", http.MethodGet, map[string]string{
-		"created":        "integer",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rAccountsCmd.Cmd, "persons", "/v1/accounts/{account}/persons", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rAccountsCmd.Cmd, "reject", "/v1/accounts/{account}/reject", http.MethodPost, map[string]string{
-		"reason": "string",
-	}, &Config)
-	resource.NewOperationCmd(rAccountsCmd.Cmd, "retrieve", "/v1/account", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rAccountsCmd.Cmd, "update", "/v1/accounts/{account}", http.MethodPost, map[string]string{
-		"account_token":    "string",
-		"business_type":    "string",
-		"default_currency": "string",
-		"email":            "string",
-		"external_account": "string",
-	}, &Config)
-	resource.NewOperationCmd(rApplePayDomainsCmd.Cmd, "create", "/v1/apple_pay/domains", http.MethodPost, map[string]string{
-		"domain_name": "string",
-	}, &Config)
-	resource.NewOperationCmd(rApplePayDomainsCmd.Cmd, "delete", "/v1/apple_pay/domains/{domain}", http.MethodDelete, map[string]string{}, &Config)
-	resource.NewOperationCmd(rApplePayDomainsCmd.Cmd, "list", "/v1/apple_pay/domains", http.MethodGet, map[string]string{
-		"domain_name":    "string",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rApplePayDomainsCmd.Cmd, "retrieve", "/v1/apple_pay/domains/{domain}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rApplicationFeesCmd.Cmd, "list", "/v1/application_fees", http.MethodGet, map[string]string{
-		"charge":         "string",
-		"created":        "integer",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rApplicationFeesCmd.Cmd, "retrieve", "/v1/application_fees/{id}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rBalanceCmd.Cmd, "retrieve", "/v1/balance", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rBalanceTransactionsCmd.Cmd, "list", "/v1/balance_transactions", http.MethodGet, map[string]string{
-		"available_on":   "integer",
-		"created":        "integer",
-		"currency":       "string",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"payout":         "string",
-		"source":         "string",
-		"starting_after": "string",
-		"type":           "string",
-	}, &Config)
-	resource.NewOperationCmd(rBalanceTransactionsCmd.Cmd, "retrieve", "/v1/balance_transactions/{id}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rBankAccountsCmd.Cmd, "delete", "/v1/customers/{customer}/sources/{id}", http.MethodDelete, map[string]string{}, &Config)
-	resource.NewOperationCmd(rBankAccountsCmd.Cmd, "update", "/v1/customers/{customer}/sources/{id}", http.MethodPost, map[string]string{
-		"account_holder_name": "string",
-		"account_holder_type": "string",
-		"address_city":        "string",
-		"address_country":     "string",
-		"address_line1":       "string",
-		"address_line2":       "string",
-		"address_state":       "string",
-		"address_zip":         "string",
-		"exp_month":           "string",
-		"exp_year":            "string",
-		"name":                "string",
-	}, &Config)
-	resource.NewOperationCmd(rBankAccountsCmd.Cmd, "verify", "/v1/customers/{customer}/sources/{id}/verify", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rCapabilitiesCmd.Cmd, "list", "/v1/accounts/{account}/capabilities", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rCapabilitiesCmd.Cmd, "retrieve", "/v1/accounts/{account}/capabilities/{capability}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rCapabilitiesCmd.Cmd, "update", "/v1/accounts/{account}/capabilities/{capability}", http.MethodPost, map[string]string{
-		"requested": "boolean",
-	}, &Config)
-	resource.NewOperationCmd(rCardsCmd.Cmd, "delete", "/v1/customers/{customer}/sources/{id}", http.MethodDelete, map[string]string{}, &Config)
-	resource.NewOperationCmd(rCardsCmd.Cmd, "update", "/v1/customers/{customer}/sources/{id}", http.MethodPost, map[string]string{
-		"account_holder_name": "string",
-		"account_holder_type": "string",
-		"address_city":        "string",
-		"address_country":     "string",
-		"address_line1":       "string",
-		"address_line2":       "string",
-		"address_state":       "string",
-		"address_zip":         "string",
-		"exp_month":           "string",
-		"exp_year":            "string",
-		"name":                "string",
-	}, &Config)
-	resource.NewOperationCmd(rCashBalancesCmd.Cmd, "retrieve", "/v1/customers/{customer}/cash_balance", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rCashBalancesCmd.Cmd, "update", "/v1/customers/{customer}/cash_balance", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rChargesCmd.Cmd, "capture", "/v1/charges/{charge}/capture", http.MethodPost, map[string]string{
-		"amount":                      "integer",
-		"application_fee":             "integer",
-		"application_fee_amount":      "integer",
-		"receipt_email":               "string",
-		"statement_descriptor":        "string",
-		"statement_descriptor_suffix": "string",
-		"transfer_group":              "string",
-	}, &Config)
-	resource.NewOperationCmd(rChargesCmd.Cmd, "create", "/v1/charges", http.MethodPost, map[string]string{
-		"amount":                      "integer",
-		"application_fee":             "integer",
-		"application_fee_amount":      "integer",
-		"capture":                     "boolean",
-		"currency":                    "string",
-		"customer":                    "string",
-		"description":                 "string",
-		"on_behalf_of":                "string",
-		"receipt_email":               "string",
-		"source":                      "string",
-		"statement_descriptor":        "string",
-		"statement_descriptor_suffix": "string",
-		"transfer_group":              "string",
-	}, &Config)
-	resource.NewOperationCmd(rChargesCmd.Cmd, "list", "/v1/charges", http.MethodGet, map[string]string{
-		"created":        "integer",
-		"customer":       "string",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"payment_intent": "string",
-		"starting_after": "string",
-		"transfer_group": "string",
-	}, &Config)
-	resource.NewOperationCmd(rChargesCmd.Cmd, "retrieve", "/v1/charges/{charge}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rChargesCmd.Cmd, "search", "/v1/charges/search", http.MethodGet, map[string]string{
-		"limit": "integer",
-		"page":  "string",
-		"query": "string",
-	}, &Config)
-	resource.NewOperationCmd(rChargesCmd.Cmd, "update", "/v1/charges/{charge}", http.MethodPost, map[string]string{
-		"customer":       "string",
-		"description":    "string",
-		"receipt_email":  "string",
-		"transfer_group": "string",
-	}, &Config)
-	resource.NewOperationCmd(rCountrySpecsCmd.Cmd, "list", "/v1/country_specs", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rCountrySpecsCmd.Cmd, "retrieve", "/v1/country_specs/{country}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rCouponsCmd.Cmd, "create", "/v1/coupons", http.MethodPost, map[string]string{
-		"amount_off":         "integer",
-		"currency":           "string",
-		"duration":           "string",
-		"duration_in_months": "integer",
-		"id":                 "string",
-		"max_redemptions":    "integer",
-		"name":               "string",
-		"percent_off":        "number",
-		"redeem_by":          "integer",
-	}, &Config)
-	resource.NewOperationCmd(rCouponsCmd.Cmd, "delete", "/v1/coupons/{coupon}", http.MethodDelete, map[string]string{}, &Config)
-	resource.NewOperationCmd(rCouponsCmd.Cmd, "list", "/v1/coupons", http.MethodGet, map[string]string{
-		"created":        "integer",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rCouponsCmd.Cmd, "retrieve", "/v1/coupons/{coupon}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rCouponsCmd.Cmd, "update", "/v1/coupons/{coupon}", http.MethodPost, map[string]string{
-		"name": "string",
-	}, &Config)
-	resource.NewOperationCmd(rCreditNoteLineItemsCmd.Cmd, "list", "/v1/credit_notes/{credit_note}/lines", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rCreditNotesCmd.Cmd, "create", "/v1/credit_notes", http.MethodPost, map[string]string{
-		"amount":             "integer",
-		"credit_amount":      "integer",
-		"invoice":            "string",
-		"memo":               "string",
-		"out_of_band_amount": "integer",
-		"reason":             "string",
-		"refund":             "string",
-		"refund_amount":      "integer",
-	}, &Config)
-	resource.NewOperationCmd(rCreditNotesCmd.Cmd, "list", "/v1/credit_notes", http.MethodGet, map[string]string{
-		"customer":       "string",
-		"ending_before":  "string",
-		"invoice":        "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rCreditNotesCmd.Cmd, "preview", "/v1/credit_notes/preview", http.MethodGet, map[string]string{
-		"amount":             "integer",
-		"credit_amount":      "integer",
-		"invoice":            "string",
-		"memo":               "string",
-		"out_of_band_amount": "integer",
-		"reason":             "string",
-		"refund":             "string",
-		"refund_amount":      "integer",
-	}, &Config)
-	resource.NewOperationCmd(rCreditNotesCmd.Cmd, "preview_lines", "/v1/credit_notes/preview/lines", http.MethodGet, map[string]string{
-		"amount":             "integer",
-		"credit_amount":      "integer",
-		"ending_before":      "string",
-		"invoice":            "string",
-		"limit":              "integer",
-		"memo":               "string",
-		"out_of_band_amount": "integer",
-		"reason":             "string",
-		"refund":             "string",
-		"refund_amount":      "integer",
-		"starting_after":     "string",
-	}, &Config)
-	resource.NewOperationCmd(rCreditNotesCmd.Cmd, "retrieve", "/v1/credit_notes/{id}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rCreditNotesCmd.Cmd, "update", "/v1/credit_notes/{id}", http.MethodPost, map[string]string{
-		"memo": "string",
-	}, &Config)
-	resource.NewOperationCmd(rCreditNotesCmd.Cmd, "void_credit_note", "/v1/credit_notes/{id}/void", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rCustomerBalanceTransactionsCmd.Cmd, "create", "/v1/customers/{customer}/balance_transactions", http.MethodPost, map[string]string{
-		"amount":      "integer",
-		"currency":    "string",
-		"description": "string",
-	}, &Config)
-	resource.NewOperationCmd(rCustomerBalanceTransactionsCmd.Cmd, "list", "/v1/customers/{customer}/balance_transactions", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rCustomerBalanceTransactionsCmd.Cmd, "retrieve", "/v1/customers/{customer}/balance_transactions/{transaction}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rCustomerBalanceTransactionsCmd.Cmd, "update", "/v1/customers/{customer}/balance_transactions/{transaction}", http.MethodPost, map[string]string{
-		"description": "string",
-	}, &Config)
-	resource.NewOperationCmd(rCustomersCmd.Cmd, "balance_transactions", "/v1/customers/{customer}/balance_transactions", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rCustomersCmd.Cmd, "create", "/v1/customers", http.MethodPost, map[string]string{
-		"balance":               "integer",
-		"coupon":                "string",
-		"description":           "string",
-		"email":                 "string",
-		"invoice_prefix":        "string",
-		"name":                  "string",
-		"next_invoice_sequence": "integer",
-		"payment_method":        "string",
-		"phone":                 "string",
-		"promotion_code":        "string",
-		"source":                "string",
-		"tax_exempt":            "string",
-		"test_clock":            "string",
-	}, &Config)
-	resource.NewOperationCmd(rCustomersCmd.Cmd, "create_funding_instructions", "/v1/customers/{customer}/funding_instructions", http.MethodPost, map[string]string{
-		"currency":     "string",
-		"funding_type": "string",
-	}, &Config)
-	resource.NewOperationCmd(rCustomersCmd.Cmd, "delete", "/v1/customers/{customer}", http.MethodDelete, map[string]string{}, &Config)
-	resource.NewOperationCmd(rCustomersCmd.Cmd, "delete_discount", "/v1/customers/{customer}/discount", http.MethodDelete, map[string]string{}, &Config)
-	resource.NewOperationCmd(rCustomersCmd.Cmd, "list", "/v1/customers", http.MethodGet, map[string]string{
-		"created":        "integer",
-		"email":          "string",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-		"test_clock":     "string",
-	}, &Config)
-	resource.NewOperationCmd(rCustomersCmd.Cmd, "list_payment_methods", "/v1/customers/{customer}/payment_methods", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-		"type":           "string",
-	}, &Config)
-	resource.NewOperationCmd(rCustomersCmd.Cmd, "retrieve", "/v1/customers/{customer}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rCustomersCmd.Cmd, "retrieve_payment_method", "/v1/customers/{customer}/payment_methods/{payment_method}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rCustomersCmd.Cmd, "search", "/v1/customers/search", http.MethodGet, map[string]string{
-		"limit": "integer",
-		"page":  "string",
-		"query": "string",
-	}, &Config)
-	resource.NewOperationCmd(rCustomersCmd.Cmd, "update", "/v1/customers/{customer}", http.MethodPost, map[string]string{
-		"balance":               "integer",
-		"coupon":                "string",
-		"default_source":        "string",
-		"description":           "string",
-		"email":                 "string",
-		"invoice_prefix":        "string",
-		"name":                  "string",
-		"next_invoice_sequence": "integer",
-		"phone":                 "string",
-		"promotion_code":        "string",
-		"source":                "string",
-		"tax_exempt":            "string",
-		"trial_end":             "string",
-	}, &Config)
-	resource.NewOperationCmd(rDisputesCmd.Cmd, "close", "/v1/disputes/{dispute}/close", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rDisputesCmd.Cmd, "list", "/v1/disputes", http.MethodGet, map[string]string{
-		"charge":         "string",
-		"created":        "integer",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"payment_intent": "string",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rDisputesCmd.Cmd, "retrieve", "/v1/disputes/{dispute}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rDisputesCmd.Cmd, "update", "/v1/disputes/{dispute}", http.MethodPost, map[string]string{
-		"submit": "boolean",
-	}, &Config)
-	resource.NewOperationCmd(rEphemeralKeysCmd.Cmd, "create", "/v1/ephemeral_keys", http.MethodPost, map[string]string{
-		"customer":     "string",
-		"issuing_card": "string",
-	}, &Config)
-	resource.NewOperationCmd(rEphemeralKeysCmd.Cmd, "delete", "/v1/ephemeral_keys/{key}", http.MethodDelete, map[string]string{}, &Config)
-	resource.NewOperationCmd(rEventsCmd.Cmd, "list", "/v1/events", http.MethodGet, map[string]string{
-		"created":          "integer",
-		"delivery_success": "boolean",
-		"ending_before":    "string",
-		"limit":            "integer",
-		"starting_after":   "string",
-		"type":             "string",
-	}, &Config)
-	resource.NewOperationCmd(rEventsCmd.Cmd, "retrieve", "/v1/events/{id}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rExchangeRatesCmd.Cmd, "list", "/v1/exchange_rates", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rExchangeRatesCmd.Cmd, "retrieve", "/v1/exchange_rates/{rate_id}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rExternalAccountsCmd.Cmd, "create", "/v1/accounts/{account}/external_accounts", http.MethodPost, map[string]string{
-		"default_for_currency": "boolean",
-		"external_account":     "string",
-	}, &Config)
-	resource.NewOperationCmd(rExternalAccountsCmd.Cmd, "delete", "/v1/accounts/{account}/external_accounts/{id}", http.MethodDelete, map[string]string{}, &Config)
-	resource.NewOperationCmd(rExternalAccountsCmd.Cmd, "list", "/v1/accounts/{account}/external_accounts", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rExternalAccountsCmd.Cmd, "retrieve", "/v1/accounts/{account}/external_accounts/{id}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rExternalAccountsCmd.Cmd, "update", "/v1/accounts/{account}/external_accounts/{id}", http.MethodPost, map[string]string{
-		"account_holder_name":  "string",
-		"account_holder_type":  "string",
-		"account_type":         "string",
-		"address_city":         "string",
-		"address_country":      "string",
-		"address_line1":        "string",
-		"address_line2":        "string",
-		"address_state":        "string",
-		"address_zip":          "string",
-		"default_for_currency": "boolean",
-		"exp_month":            "string",
-		"exp_year":             "string",
-		"name":                 "string",
-	}, &Config)
-	resource.NewOperationCmd(rFeeRefundsCmd.Cmd, "create", "/v1/application_fees/{id}/refunds", http.MethodPost, map[string]string{
-		"amount": "integer",
-	}, &Config)
-	resource.NewOperationCmd(rFeeRefundsCmd.Cmd, "list", "/v1/application_fees/{id}/refunds", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rFeeRefundsCmd.Cmd, "retrieve", "/v1/application_fees/{fee}/refunds/{id}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rFeeRefundsCmd.Cmd, "update", "/v1/application_fees/{fee}/refunds/{id}", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rFileLinksCmd.Cmd, "create", "/v1/file_links", http.MethodPost, map[string]string{
-		"expires_at": "integer",
-		"file":       "string",
-	}, &Config)
-	resource.NewOperationCmd(rFileLinksCmd.Cmd, "list", "/v1/file_links", http.MethodGet, map[string]string{
-		"created":        "integer",
-		"ending_before":  "string",
-		"expired":        "boolean",
-		"file":           "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rFileLinksCmd.Cmd, "retrieve", "/v1/file_links/{link}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rFileLinksCmd.Cmd, "update", "/v1/file_links/{link}", http.MethodPost, map[string]string{
-		"expires_at": "string",
-	}, &Config)
-	resource.NewOperationCmd(rFilesCmd.Cmd, "create", "/v1/files", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rFilesCmd.Cmd, "list", "/v1/files", http.MethodGet, map[string]string{
-		"created":        "integer",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"purpose":        "string",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rFilesCmd.Cmd, "retrieve", "/v1/files/{file}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rInvoiceitemsCmd.Cmd, "create", "/v1/invoiceitems", http.MethodPost, map[string]string{
-		"amount":              "integer",
-		"currency":            "string",
-		"customer":            "string",
-		"description":         "string",
-		"discountable":        "boolean",
-		"invoice":             "string",
-		"price":               "string",
-		"quantity":            "integer",
-		"subscription":        "string",
-		"unit_amount":         "integer",
-		"unit_amount_decimal": "string",
-	}, &Config)
-	resource.NewOperationCmd(rInvoiceitemsCmd.Cmd, "delete", "/v1/invoiceitems/{invoiceitem}", http.MethodDelete, map[string]string{}, &Config)
-	resource.NewOperationCmd(rInvoiceitemsCmd.Cmd, "list", "/v1/invoiceitems", http.MethodGet, map[string]string{
-		"created":        "integer",
-		"customer":       "string",
-		"ending_before":  "string",
-		"invoice":        "string",
-		"limit":          "integer",
-		"pending":        "boolean",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rInvoiceitemsCmd.Cmd, "retrieve", "/v1/invoiceitems/{invoiceitem}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rInvoiceitemsCmd.Cmd, "update", "/v1/invoiceitems/{invoiceitem}", http.MethodPost, map[string]string{
-		"amount":              "integer",
-		"description":         "string",
-		"discountable":        "boolean",
-		"price":               "string",
-		"quantity":            "integer",
-		"unit_amount":         "integer",
-		"unit_amount_decimal": "string",
-	}, &Config)
-	resource.NewOperationCmd(rInvoicesCmd.Cmd, "create", "/v1/invoices", http.MethodPost, map[string]string{
-		"application_fee_amount":         "integer",
-		"auto_advance":                   "boolean",
-		"collection_method":              "string",
-		"customer":                       "string",
-		"days_until_due":                 "integer",
-		"default_payment_method":         "string",
-		"default_source":                 "string",
-		"description":                    "string",
-		"due_date":                       "integer",
-		"footer":                         "string",
-		"on_behalf_of":                   "string",
-		"pending_invoice_items_behavior": "string",
-		"statement_descriptor":           "string",
-		"subscription":                   "string",
-	}, &Config)
-	resource.NewOperationCmd(rInvoicesCmd.Cmd, "delete", "/v1/invoices/{invoice}", http.MethodDelete, map[string]string{}, &Config)
-	resource.NewOperationCmd(rInvoicesCmd.Cmd, "finalize_invoice", "/v1/invoices/{invoice}/finalize", http.MethodPost, map[string]string{
-		"auto_advance": "boolean",
-	}, &Config)
-	resource.NewOperationCmd(rInvoicesCmd.Cmd, "list", "/v1/invoices", http.MethodGet, map[string]string{
-		"collection_method": "string",
-		"created":           "integer",
-		"customer":          "string",
-		"due_date":          "integer",
-		"ending_before":     "string",
-		"limit":             "integer",
-		"starting_after":    "string",
-		"status":            "string",
-		"subscription":      "string",
-	}, &Config)
-	resource.NewOperationCmd(rInvoicesCmd.Cmd, "mark_uncollectible", "/v1/invoices/{invoice}/mark_uncollectible", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rInvoicesCmd.Cmd, "pay", "/v1/invoices/{invoice}/pay", http.MethodPost, map[string]string{
-		"forgive":          "boolean",
-		"off_session":      "boolean",
-		"paid_out_of_band": "boolean",
-		"payment_method":   "string",
-		"source":           "string",
-	}, &Config)
-	resource.NewOperationCmd(rInvoicesCmd.Cmd, "retrieve", "/v1/invoices/{invoice}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rInvoicesCmd.Cmd, "search", "/v1/invoices/search", http.MethodGet, map[string]string{
-		"limit": "integer",
-		"page":  "string",
-		"query": "string",
-	}, &Config)
-	resource.NewOperationCmd(rInvoicesCmd.Cmd, "send_invoice", "/v1/invoices/{invoice}/send", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rInvoicesCmd.Cmd, "upcoming", "/v1/invoices/upcoming", http.MethodGet, map[string]string{
-		"coupon":                            "string",
-		"customer":                          "string",
-		"schedule":                          "string",
-		"subscription":                      "string",
-		"subscription_billing_cycle_anchor": "string",
-		"subscription_cancel_at":            "integer",
-		"subscription_cancel_at_period_end": "boolean",
-		"subscription_cancel_now":           "boolean",
-		"subscription_proration_behavior":   "string",
-		"subscription_proration_date":       "integer",
-		"subscription_start_date":           "integer",
-		"subscription_trial_end":            "string",
-		"subscription_trial_from_plan":      "boolean",
-	}, &Config)
-	resource.NewOperationCmd(rInvoicesCmd.Cmd, "upcomingLines", "/v1/invoices/upcoming/lines", http.MethodGet, map[string]string{
-		"coupon":                            "string",
-		"customer":                          "string",
-		"ending_before":                     "string",
-		"limit":                             "integer",
-		"schedule":                          "string",
-		"starting_after":                    "string",
-		"subscription":                      "string",
-		"subscription_billing_cycle_anchor": "string",
-		"subscription_cancel_at":            "integer",
-		"subscription_cancel_at_period_end": "boolean",
-		"subscription_cancel_now":           "boolean",
-		"subscription_proration_behavior":   "string",
-		"subscription_proration_date":       "integer",
-		"subscription_start_date":           "integer",
-		"subscription_trial_end":            "string",
-		"subscription_trial_from_plan":      "boolean",
-	}, &Config)
-	resource.NewOperationCmd(rInvoicesCmd.Cmd, "update", "/v1/invoices/{invoice}", http.MethodPost, map[string]string{
-		"application_fee_amount": "integer",
-		"auto_advance":           "boolean",
-		"collection_method":      "string",
-		"days_until_due":         "integer",
-		"default_payment_method": "string",
-		"default_source":         "string",
-		"description":            "string",
-		"due_date":               "integer",
-		"footer":                 "string",
-		"on_behalf_of":           "string",
-		"statement_descriptor":   "string",
-	}, &Config)
-	resource.NewOperationCmd(rInvoicesCmd.Cmd, "void_invoice", "/v1/invoices/{invoice}/void", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rItemsCmd.Cmd, "list", "/v1/checkout/sessions/{session}/line_items", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rLineItemsCmd.Cmd, "list", "/v1/invoices/{invoice}/lines", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rLoginLinksCmd.Cmd, "create", "/v1/accounts/{account}/login_links", http.MethodPost, map[string]string{
-		"redirect_url": "string",
-	}, &Config)
-	resource.NewOperationCmd(rMandatesCmd.Cmd, "retrieve", "/v1/mandates/{mandate}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rOrdersCmd.Cmd, "cancel", "/v1/orders/{id}/cancel", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rOrdersCmd.Cmd, "create", "/v1/orders", http.MethodPost, map[string]string{
-		"currency":    "string",
-		"customer":    "string",
-		"description": "string",
-		"ip_address":  "string",
-	}, &Config)
-	resource.NewOperationCmd(rOrdersCmd.Cmd, "list", "/v1/orders", http.MethodGet, map[string]string{
-		"customer":       "string",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rOrdersCmd.Cmd, "list_line_items", "/v1/orders/{id}/line_items", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rOrdersCmd.Cmd, "reopen", "/v1/orders/{id}/reopen", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rOrdersCmd.Cmd, "retrieve", "/v1/orders/{id}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rOrdersCmd.Cmd, "submit", "/v1/orders/{id}/submit", http.MethodPost, map[string]string{
-		"expected_total": "integer",
-	}, &Config)
-	resource.NewOperationCmd(rOrdersCmd.Cmd, "update", "/v1/orders/{id}", http.MethodPost, map[string]string{
-		"currency":    "string",
-		"customer":    "string",
-		"description": "string",
-		"ip_address":  "string",
-	}, &Config)
-	resource.NewOperationCmd(rPaymentIntentsCmd.Cmd, "apply_customer_balance", "/v1/payment_intents/{intent}/apply_customer_balance", http.MethodPost, map[string]string{
-		"amount":   "integer",
-		"currency": "string",
-	}, &Config)
-	resource.NewOperationCmd(rPaymentIntentsCmd.Cmd, "cancel", "/v1/payment_intents/{intent}/cancel", http.MethodPost, map[string]string{
-		"cancellation_reason": "string",
-	}, &Config)
-	resource.NewOperationCmd(rPaymentIntentsCmd.Cmd, "capture", "/v1/payment_intents/{intent}/capture", http.MethodPost, map[string]string{
-		"amount_to_capture":           "integer",
-		"application_fee_amount":      "integer",
-		"statement_descriptor":        "string",
-		"statement_descriptor_suffix": "string",
-	}, &Config)
-	resource.NewOperationCmd(rPaymentIntentsCmd.Cmd, "confirm", "/v1/payment_intents/{intent}/confirm", http.MethodPost, map[string]string{
-		"error_on_requires_action": "boolean",
-		"mandate":                  "string",
-		"off_session":              "boolean",
-		"payment_method":           "string",
-		"receipt_email":            "string",
-		"return_url":               "string",
-		"setup_future_usage":       "string",
-		"use_stripe_sdk":           "boolean",
-	}, &Config)
-	resource.NewOperationCmd(rPaymentIntentsCmd.Cmd, "create", "/v1/payment_intents", http.MethodPost, map[string]string{
-		"amount":                      "integer",
-		"application_fee_amount":      "integer",
-		"capture_method":              "string",
-		"confirm":                     "boolean",
-		"confirmation_method":         "string",
-		"currency":                    "string",
-		"customer":                    "string",
-		"description":                 "string",
-		"error_on_requires_action":    "boolean",
-		"mandate":                     "string",
-		"off_session":                 "boolean",
-		"on_behalf_of":                "string",
-		"payment_method":              "string",
-		"receipt_email":               "string",
-		"return_url":                  "string",
-		"setup_future_usage":          "string",
-		"statement_descriptor":        "string",
-		"statement_descriptor_suffix": "string",
-		"transfer_group":              "string",
-		"use_stripe_sdk":              "boolean",
-	}, &Config)
-	resource.NewOperationCmd(rPaymentIntentsCmd.Cmd, "increment_authorization", "/v1/payment_intents/{intent}/increment_authorization", http.MethodPost, map[string]string{
-		"amount":                 "integer",
-		"application_fee_amount": "integer",
-		"description":            "string",
-	}, &Config)
-	resource.NewOperationCmd(rPaymentIntentsCmd.Cmd, "list", "/v1/payment_intents", http.MethodGet, map[string]string{
-		"created":        "integer",
-		"customer":       "string",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rPaymentIntentsCmd.Cmd, "retrieve", "/v1/payment_intents/{intent}", http.MethodGet, map[string]string{
-		"client_secret": "string",
-	}, &Config)
-	resource.NewOperationCmd(rPaymentIntentsCmd.Cmd, "search", "/v1/payment_intents/search", http.MethodGet, map[string]string{
-		"limit": "integer",
-		"page":  "string",
-		"query": "string",
-	}, &Config)
-	resource.NewOperationCmd(rPaymentIntentsCmd.Cmd, "update", "/v1/payment_intents/{intent}", http.MethodPost, map[string]string{
-		"amount":                      "integer",
-		"application_fee_amount":      "integer",
-		"currency":                    "string",
-		"customer":                    "string",
-		"description":                 "string",
-		"payment_method":              "string",
-		"receipt_email":               "string",
-		"setup_future_usage":          "string",
-		"statement_descriptor":        "string",
-		"statement_descriptor_suffix": "string",
-		"transfer_group":              "string",
-	}, &Config)
-	resource.NewOperationCmd(rPaymentIntentsCmd.Cmd, "verify_microdeposits", "/v1/payment_intents/{intent}/verify_microdeposits", http.MethodPost, map[string]string{
-		"descriptor_code": "string",
-	}, &Config)
-	resource.NewOperationCmd(rPaymentLinksCmd.Cmd, "create", "/v1/payment_links", http.MethodPost, map[string]string{
-		"allow_promotion_codes":      "boolean",
-		"application_fee_amount":     "integer",
-		"application_fee_percent":    "number",
-		"billing_address_collection": "string",
-		"customer_creation":          "string",
-		"on_behalf_of":               "string",
-		"submit_type":                "string",
-	}, &Config)
-	resource.NewOperationCmd(rPaymentLinksCmd.Cmd, "list", "/v1/payment_links", http.MethodGet, map[string]string{
-		"active":         "boolean",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rPaymentLinksCmd.Cmd, "list_line_items", "/v1/payment_links/{payment_link}/line_items", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rPaymentLinksCmd.Cmd, "retrieve", "/v1/payment_links/{payment_link}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rPaymentLinksCmd.Cmd, "update", "/v1/payment_links/{payment_link}", http.MethodPost, map[string]string{
-		"active":                     "boolean",
-		"allow_promotion_codes":      "boolean",
-		"billing_address_collection": "string",
-		"customer_creation":          "string",
-	}, &Config)
-	resource.NewOperationCmd(rPaymentMethodsCmd.Cmd, "attach", "/v1/payment_methods/{payment_method}/attach", http.MethodPost, map[string]string{
-		"customer": "string",
-	}, &Config)
-	resource.NewOperationCmd(rPaymentMethodsCmd.Cmd, "create", "/v1/payment_methods", http.MethodPost, map[string]string{
-		"customer":       "string",
-		"payment_method": "string",
-		"type":           "string",
-	}, &Config)
-	resource.NewOperationCmd(rPaymentMethodsCmd.Cmd, "detach", "/v1/payment_methods/{payment_method}/detach", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rPaymentMethodsCmd.Cmd, "list", "/v1/payment_methods", http.MethodGet, map[string]string{
-		"customer":       "string",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-		"type":           "string",
-	}, &Config)
-	resource.NewOperationCmd(rPaymentMethodsCmd.Cmd, "retrieve", "/v1/payment_methods/{payment_method}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rPaymentMethodsCmd.Cmd, "update", "/v1/payment_methods/{payment_method}", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rPaymentSourcesCmd.Cmd, "create", "/v1/customers/{customer}/sources", http.MethodPost, map[string]string{
-		"source": "string",
-	}, &Config)
-	resource.NewOperationCmd(rPaymentSourcesCmd.Cmd, "list", "/v1/customers/{customer}/sources", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"object":         "string",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rPaymentSourcesCmd.Cmd, "retrieve", "/v1/customers/{customer}/sources/{id}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rPayoutsCmd.Cmd, "cancel", "/v1/payouts/{payout}/cancel", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rPayoutsCmd.Cmd, "create", "/v1/payouts", http.MethodPost, map[string]string{
-		"amount":               "integer",
-		"currency":             "string",
-		"description":          "string",
-		"destination":          "string",
-		"method":               "string",
-		"source_type":          "string",
-		"statement_descriptor": "string",
-	}, &Config)
-	resource.NewOperationCmd(rPayoutsCmd.Cmd, "list", "/v1/payouts", http.MethodGet, map[string]string{
-		"arrival_date":   "integer",
-		"created":        "integer",
-		"destination":    "string",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-		"status":         "string",
-	}, &Config)
-	resource.NewOperationCmd(rPayoutsCmd.Cmd, "retrieve", "/v1/payouts/{payout}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rPayoutsCmd.Cmd, "reverse", "/v1/payouts/{payout}/reverse", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rPayoutsCmd.Cmd, "update", "/v1/payouts/{payout}", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rPersonsCmd.Cmd, "create", "/v1/accounts/{account}/persons", http.MethodPost, map[string]string{
-		"email":               "string",
-		"first_name":          "string",
-		"first_name_kana":     "string",
-		"first_name_kanji":    "string",
-		"gender":              "string",
-		"id_number":           "string",
-		"id_number_secondary": "string",
-		"last_name":           "string",
-		"last_name_kana":      "string",
-		"last_name_kanji":     "string",
-		"maiden_name":         "string",
-		"nationality":         "string",
-		"person_token":        "string",
-		"phone":               "string",
-		"political_exposure":  "string",
-		"ssn_last_4":          "string",
-	}, &Config)
-	resource.NewOperationCmd(rPersonsCmd.Cmd, "delete", "/v1/accounts/{account}/persons/{person}", http.MethodDelete, map[string]string{}, &Config)
-	resource.NewOperationCmd(rPersonsCmd.Cmd, "list", "/v1/accounts/{account}/persons", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rPersonsCmd.Cmd, "retrieve", "/v1/accounts/{account}/persons/{person}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rPersonsCmd.Cmd, "update", "/v1/accounts/{account}/persons/{person}", http.MethodPost, map[string]string{
-		"email":               "string",
-		"first_name":          "string",
-		"first_name_kana":     "string",
-		"first_name_kanji":    "string",
-		"gender":              "string",
-		"id_number":           "string",
-		"id_number_secondary": "string",
-		"last_name":           "string",
-		"last_name_kana":      "string",
-		"last_name_kanji":     "string",
-		"maiden_name":         "string",
-		"nationality":         "string",
-		"person_token":        "string",
-		"phone":               "string",
-		"political_exposure":  "string",
-		"ssn_last_4":          "string",
-	}, &Config)
-	resource.NewOperationCmd(rPlansCmd.Cmd, "create", "/v1/plans", http.MethodPost, map[string]string{
-		"active":            "boolean",
-		"aggregate_usage":   "string",
-		"amount":            "integer",
-		"amount_decimal":    "string",
-		"billing_scheme":    "string",
-		"currency":          "string",
-		"id":                "string",
-		"interval":          "string",
-		"interval_count":    "integer",
-		"nickname":          "string",
-		"product":           "string",
-		"tiers_mode":        "string",
-		"trial_period_days": "integer",
-		"usage_type":        "string",
-	}, &Config)
-	resource.NewOperationCmd(rPlansCmd.Cmd, "delete", "/v1/plans/{plan}", http.MethodDelete, map[string]string{}, &Config)
-	resource.NewOperationCmd(rPlansCmd.Cmd, "list", "/v1/plans", http.MethodGet, map[string]string{
-		"active":         "boolean",
-		"created":        "integer",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"product":        "string",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rPlansCmd.Cmd, "retrieve", "/v1/plans/{plan}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rPlansCmd.Cmd, "update", "/v1/plans/{plan}", http.MethodPost, map[string]string{
-		"active":            "boolean",
-		"nickname":          "string",
-		"product":           "string",
-		"trial_period_days": "integer",
-	}, &Config)
-	resource.NewOperationCmd(rPricesCmd.Cmd, "create", "/v1/prices", http.MethodPost, map[string]string{
-		"active":              "boolean",
-		"billing_scheme":      "string",
-		"currency":            "string",
-		"lookup_key":          "string",
-		"nickname":            "string",
-		"product":             "string",
-		"tax_behavior":        "string",
-		"tiers_mode":          "string",
-		"transfer_lookup_key": "boolean",
-		"unit_amount":         "integer",
-		"unit_amount_decimal": "string",
-	}, &Config)
-	resource.NewOperationCmd(rPricesCmd.Cmd, "list", "/v1/prices", http.MethodGet, map[string]string{
-		"active":         "boolean",
-		"created":        "integer",
-		"currency":       "string",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"product":        "string",
-		"starting_after": "string",
-		"type":           "string",
-	}, &Config)
-	resource.NewOperationCmd(rPricesCmd.Cmd, "retrieve", "/v1/prices/{price}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rPricesCmd.Cmd, "search", "/v1/prices/search", http.MethodGet, map[string]string{
-		"limit": "integer",
-		"page":  "string",
-		"query": "string",
-	}, &Config)
-	resource.NewOperationCmd(rPricesCmd.Cmd, "update", "/v1/prices/{price}", http.MethodPost, map[string]string{
-		"active":              "boolean",
-		"lookup_key":          "string",
-		"nickname":            "string",
-		"tax_behavior":        "string",
-		"transfer_lookup_key": "boolean",
-	}, &Config)
-	resource.NewOperationCmd(rProductsCmd.Cmd, "create", "/v1/products", http.MethodPost, map[string]string{
-		"active":               "boolean",
-		"caption":              "string",
-		"description":          "string",
-		"id":                   "string",
-		"name":                 "string",
-		"shippable":            "boolean",
-		"statement_descriptor": "string",
-		"tax_code":             "string",
-		"type":                 "string",
-		"unit_label":           "string",
-		"url":                  "string",
-	}, &Config)
-	resource.NewOperationCmd(rProductsCmd.Cmd, "delete", "/v1/products/{id}", http.MethodDelete, map[string]string{}, &Config)
-	resource.NewOperationCmd(rProductsCmd.Cmd, "list", "/v1/products", http.MethodGet, map[string]string{
-		"active":         "boolean",
-		"created":        "integer",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"shippable":      "boolean",
-		"starting_after": "string",
-		"type":           "string",
-		"url":            "string",
-	}, &Config)
-	resource.NewOperationCmd(rProductsCmd.Cmd, "retrieve", "/v1/products/{id}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rProductsCmd.Cmd, "search", "/v1/products/search", http.MethodGet, map[string]string{
-		"limit": "integer",
-		"page":  "string",
-		"query": "string",
-	}, &Config)
-	resource.NewOperationCmd(rProductsCmd.Cmd, "update", "/v1/products/{id}", http.MethodPost, map[string]string{
-		"active":               "boolean",
-		"caption":              "string",
-		"default_price":        "string",
-		"description":          "string",
-		"name":                 "string",
-		"shippable":            "boolean",
-		"statement_descriptor": "string",
-		"tax_code":             "string",
-		"unit_label":           "string",
-		"url":                  "string",
-	}, &Config)
-	resource.NewOperationCmd(rPromotionCodesCmd.Cmd, "create", "/v1/promotion_codes", http.MethodPost, map[string]string{
-		"active":          "boolean",
-		"code":            "string",
-		"coupon":          "string",
-		"customer":        "string",
-		"expires_at":      "integer",
-		"max_redemptions": "integer",
-	}, &Config)
-	resource.NewOperationCmd(rPromotionCodesCmd.Cmd, "list", "/v1/promotion_codes", http.MethodGet, map[string]string{
-		"active":         "boolean",
-		"code":           "string",
-		"coupon":         "string",
-		"created":        "integer",
-		"customer":       "string",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rPromotionCodesCmd.Cmd, "retrieve", "/v1/promotion_codes/{promotion_code}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rPromotionCodesCmd.Cmd, "update", "/v1/promotion_codes/{promotion_code}", http.MethodPost, map[string]string{
-		"active": "boolean",
-	}, &Config)
-	resource.NewOperationCmd(rQuotesCmd.Cmd, "accept", "/v1/quotes/{quote}/accept", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rQuotesCmd.Cmd, "cancel", "/v1/quotes/{quote}/cancel", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rQuotesCmd.Cmd, "create", "/v1/quotes", http.MethodPost, map[string]string{
-		"application_fee_amount":  "integer",
-		"application_fee_percent": "number",
-		"collection_method":       "string",
-		"customer":                "string",
-		"description":             "string",
-		"expires_at":              "integer",
-		"footer":                  "string",
-		"header":                  "string",
-		"on_behalf_of":            "string",
-		"test_clock":              "string",
-	}, &Config)
-	resource.NewOperationCmd(rQuotesCmd.Cmd, "finalize_quote", "/v1/quotes/{quote}/finalize", http.MethodPost, map[string]string{
-		"expires_at": "integer",
-	}, &Config)
-	resource.NewOperationCmd(rQuotesCmd.Cmd, "list", "/v1/quotes", http.MethodGet, map[string]string{
-		"customer":       "string",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-		"status":         "string",
-		"test_clock":     "string",
-	}, &Config)
-	resource.NewOperationCmd(rQuotesCmd.Cmd, "list_computed_upfront_line_items", "/v1/quotes/{quote}/computed_upfront_line_items", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rQuotesCmd.Cmd, "list_line_items", "/v1/quotes/{quote}/line_items", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rQuotesCmd.Cmd, "pdf", "/v1/quotes/{quote}/pdf", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rQuotesCmd.Cmd, "retrieve", "/v1/quotes/{quote}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rQuotesCmd.Cmd, "update", "/v1/quotes/{quote}", http.MethodPost, map[string]string{
-		"application_fee_amount":  "integer",
-		"application_fee_percent": "number",
-		"collection_method":       "string",
-		"customer":                "string",
-		"description":             "string",
-		"expires_at":              "integer",
-		"footer":                  "string",
-		"header":                  "string",
-		"on_behalf_of":            "string",
-	}, &Config)
-	resource.NewOperationCmd(rRefundsCmd.Cmd, "cancel", "/v1/refunds/{refund}/cancel", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rRefundsCmd.Cmd, "create", "/v1/refunds", http.MethodPost, map[string]string{
-		"amount":                 "integer",
-		"charge":                 "string",
-		"instructions_email":     "string",
-		"payment_intent":         "string",
-		"reason":                 "string",
-		"refund_application_fee": "boolean",
-		"reverse_transfer":       "boolean",
-	}, &Config)
-	resource.NewOperationCmd(rRefundsCmd.Cmd, "expire", "/v1/test_helpers/refunds/{refund}/expire", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rRefundsCmd.Cmd, "list", "/v1/refunds", http.MethodGet, map[string]string{
-		"charge":         "string",
-		"created":        "integer",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"payment_intent": "string",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rRefundsCmd.Cmd, "retrieve", "/v1/refunds/{refund}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rRefundsCmd.Cmd, "update", "/v1/refunds/{refund}", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rReviewsCmd.Cmd, "approve", "/v1/reviews/{review}/approve", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rReviewsCmd.Cmd, "list", "/v1/reviews", http.MethodGet, map[string]string{
-		"created":        "integer",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rReviewsCmd.Cmd, "retrieve", "/v1/reviews/{review}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rScheduledQueryRunsCmd.Cmd, "list", "/v1/sigma/scheduled_query_runs", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rScheduledQueryRunsCmd.Cmd, "retrieve", "/v1/sigma/scheduled_query_runs/{scheduled_query_run}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rSetupAttemptsCmd.Cmd, "list", "/v1/setup_attempts", http.MethodGet, map[string]string{
-		"created":        "integer",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"setup_intent":   "string",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rSetupIntentsCmd.Cmd, "cancel", "/v1/setup_intents/{intent}/cancel", http.MethodPost, map[string]string{
-		"cancellation_reason": "string",
-	}, &Config)
-	resource.NewOperationCmd(rSetupIntentsCmd.Cmd, "confirm", "/v1/setup_intents/{intent}/confirm", http.MethodPost, map[string]string{
-		"payment_method": "string",
-		"return_url":     "string",
-	}, &Config)
-	resource.NewOperationCmd(rSetupIntentsCmd.Cmd, "create", "/v1/setup_intents", http.MethodPost, map[string]string{
-		"confirm":        "boolean",
-		"customer":       "string",
-		"description":    "string",
-		"on_behalf_of":   "string",
-		"payment_method": "string",
-		"return_url":     "string",
-		"usage":          "string",
-	}, &Config)
-	resource.NewOperationCmd(rSetupIntentsCmd.Cmd, "list", "/v1/setup_intents", http.MethodGet, map[string]string{
-		"created":        "integer",
-		"customer":       "string",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"payment_method": "string",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rSetupIntentsCmd.Cmd, "retrieve", "/v1/setup_intents/{intent}", http.MethodGet, map[string]string{
-		"client_secret": "string",
-	}, &Config)
-	resource.NewOperationCmd(rSetupIntentsCmd.Cmd, "update", "/v1/setup_intents/{intent}", http.MethodPost, map[string]string{
-		"customer":       "string",
-		"description":    "string",
-		"payment_method": "string",
-	}, &Config)
-	resource.NewOperationCmd(rSetupIntentsCmd.Cmd, "verify_microdeposits", "/v1/setup_intents/{intent}/verify_microdeposits", http.MethodPost, map[string]string{
-		"descriptor_code": "string",
-	}, &Config)
-	resource.NewOperationCmd(rShippingRatesCmd.Cmd, "create", "/v1/shipping_rates", http.MethodPost, map[string]string{
-		"display_name": "string",
-		"tax_behavior": "string",
-		"tax_code":     "string",
-		"type":         "string",
-	}, &Config)
-	resource.NewOperationCmd(rShippingRatesCmd.Cmd, "list", "/v1/shipping_rates", http.MethodGet, map[string]string{
-		"active":         "boolean",
-		"created":        "integer",
-		"currency":       "string",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rShippingRatesCmd.Cmd, "retrieve", "/v1/shipping_rates/{shipping_rate_token}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rShippingRatesCmd.Cmd, "update", "/v1/shipping_rates/{shipping_rate_token}", http.MethodPost, map[string]string{
-		"active": "boolean",
-	}, &Config)
-	resource.NewOperationCmd(rSkusCmd.Cmd, "create", "/v1/skus", http.MethodPost, map[string]string{
-		"active":   "boolean",
-		"currency": "string",
-		"id":       "string",
-		"image":    "string",
-		"price":    "integer",
-		"product":  "string",
-	}, &Config)
-	resource.NewOperationCmd(rSkusCmd.Cmd, "delete", "/v1/skus/{id}", http.MethodDelete, map[string]string{}, &Config)
-	resource.NewOperationCmd(rSkusCmd.Cmd, "list", "/v1/skus", http.MethodGet, map[string]string{
-		"active":         "boolean",
-		"ending_before":  "string",
-		"in_stock":       "boolean",
-		"limit":          "integer",
-		"product":        "string",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rSkusCmd.Cmd, "retrieve", "/v1/skus/{id}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rSkusCmd.Cmd, "update", "/v1/skus/{id}", http.MethodPost, map[string]string{
-		"active":   "boolean",
-		"currency": "string",
-		"image":    "string",
-		"price":    "integer",
-		"product":  "string",
-	}, &Config)
-	resource.NewOperationCmd(rSourcesCmd.Cmd, "create", "/v1/sources", http.MethodPost, map[string]string{
-		"amount":               "integer",
-		"currency":             "string",
-		"customer":             "string",
-		"flow":                 "string",
-		"original_source":      "string",
-		"statement_descriptor": "string",
-		"token":                "string",
-		"type":                 "string",
-		"usage":                "string",
-	}, &Config)
-	resource.NewOperationCmd(rSourcesCmd.Cmd, "detach", "/v1/customers/{customer}/sources/{id}", http.MethodDelete, map[string]string{}, &Config)
-	resource.NewOperationCmd(rSourcesCmd.Cmd, "retrieve", "/v1/sources/{source}", http.MethodGet, map[string]string{
-		"client_secret": "string",
-	}, &Config)
-	resource.NewOperationCmd(rSourcesCmd.Cmd, "source_transactions", "/v1/sources/{source}/source_transactions", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rSourcesCmd.Cmd, "update", "/v1/sources/{source}", http.MethodPost, map[string]string{
-		"amount": "integer",
-	}, &Config)
-	resource.NewOperationCmd(rSourcesCmd.Cmd, "verify", "/v1/sources/{source}/verify", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rSubscriptionItemsCmd.Cmd, "create", "/v1/subscription_items", http.MethodPost, map[string]string{
-		"payment_behavior":   "string",
-		"plan":               "string",
-		"price":              "string",
-		"proration_behavior": "string",
-		"proration_date":     "integer",
-		"quantity":           "integer",
-		"subscription":       "string",
-	}, &Config)
-	resource.NewOperationCmd(rSubscriptionItemsCmd.Cmd, "delete", "/v1/subscription_items/{item}", http.MethodDelete, map[string]string{}, &Config)
-	resource.NewOperationCmd(rSubscriptionItemsCmd.Cmd, "list", "/v1/subscription_items", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-		"subscription":   "string",
-	}, &Config)
-	resource.NewOperationCmd(rSubscriptionItemsCmd.Cmd, "retrieve", "/v1/subscription_items/{item}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rSubscriptionItemsCmd.Cmd, "update", "/v1/subscription_items/{item}", http.MethodPost, map[string]string{
-		"off_session":        "boolean",
-		"payment_behavior":   "string",
-		"plan":               "string",
-		"price":              "string",
-		"proration_behavior": "string",
-		"proration_date":     "integer",
-		"quantity":           "integer",
-	}, &Config)
-	resource.NewOperationCmd(rSubscriptionItemsCmd.Cmd, "usage_record_summaries", "/v1/subscription_items/{subscription_item}/usage_record_summaries", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rSubscriptionSchedulesCmd.Cmd, "cancel", "/v1/subscription_schedules/{schedule}/cancel", http.MethodPost, map[string]string{
-		"invoice_now": "boolean",
-		"prorate":     "boolean",
-	}, &Config)
-	resource.NewOperationCmd(rSubscriptionSchedulesCmd.Cmd, "create", "/v1/subscription_schedules", http.MethodPost, map[string]string{
-		"customer":          "string",
-		"end_behavior":      "string",
-		"from_subscription": "string",
-		"start_date":        "integer",
-	}, &Config)
-	resource.NewOperationCmd(rSubscriptionSchedulesCmd.Cmd, "list", "/v1/subscription_schedules", http.MethodGet, map[string]string{
-		"canceled_at":    "integer",
-		"completed_at":   "integer",
-		"created":        "integer",
-		"customer":       "string",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"released_at":    "integer",
-		"scheduled":      "boolean",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rSubscriptionSchedulesCmd.Cmd, "release", "/v1/subscription_schedules/{schedule}/release", http.MethodPost, map[string]string{
-		"preserve_cancel_date": "boolean",
-	}, &Config)
-	resource.NewOperationCmd(rSubscriptionSchedulesCmd.Cmd, "retrieve", "/v1/subscription_schedules/{schedule}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rSubscriptionSchedulesCmd.Cmd, "update", "/v1/subscription_schedules/{schedule}", http.MethodPost, map[string]string{
-		"end_behavior":       "string",
-		"proration_behavior": "string",
-	}, &Config)
-	resource.NewOperationCmd(rSubscriptionsCmd.Cmd, "cancel", "/v1/subscriptions/{subscription_exposed_id}", http.MethodDelete, map[string]string{}, &Config)
-	resource.NewOperationCmd(rSubscriptionsCmd.Cmd, "create", "/v1/subscriptions", http.MethodPost, map[string]string{
-		"application_fee_percent": "number",
-		"backdate_start_date":     "integer",
-		"billing_cycle_anchor":    "integer",
-		"cancel_at":               "integer",
-		"cancel_at_period_end":    "boolean",
-		"collection_method":       "string",
-		"coupon":                  "string",
-		"customer":                "string",
-		"days_until_due":          "integer",
-		"default_payment_method":  "string",
-		"default_source":          "string",
-		"description":             "string",
-		"off_session":             "boolean",
-		"payment_behavior":        "string",
-		"promotion_code":          "string",
-		"proration_behavior":      "string",
-		"trial_end":               "string",
-		"trial_from_plan":         "boolean",
-		"trial_period_days":       "integer",
-	}, &Config)
-	resource.NewOperationCmd(rSubscriptionsCmd.Cmd, "delete_discount", "/v1/subscriptions/{subscription_exposed_id}/discount", http.MethodDelete, map[string]string{}, &Config)
-	resource.NewOperationCmd(rSubscriptionsCmd.Cmd, "list", "/v1/subscriptions", http.MethodGet, map[string]string{
-		"collection_method":    "string",
-		"created":              "integer",
-		"current_period_end":   "integer",
-		"current_period_start": "integer",
-		"customer":             "string",
-		"ending_before":        "string",
-		"limit":                "integer",
-		"plan":                 "string",
-		"price":                "string",
-		"starting_after":       "string",
-		"status":               "string",
-		"test_clock":           "string",
-	}, &Config)
-	resource.NewOperationCmd(rSubscriptionsCmd.Cmd, "retrieve", "/v1/subscriptions/{subscription_exposed_id}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rSubscriptionsCmd.Cmd, "search", "/v1/subscriptions/search", http.MethodGet, map[string]string{
-		"limit": "integer",
-		"page":  "string",
-		"query": "string",
-	}, &Config)
-	resource.NewOperationCmd(rSubscriptionsCmd.Cmd, "update", "/v1/subscriptions/{subscription_exposed_id}", http.MethodPost, map[string]string{
-		"application_fee_percent": "number",
-		"billing_cycle_anchor":    "string",
-		"cancel_at":               "integer",
-		"cancel_at_period_end":    "boolean",
-		"collection_method":       "string",
-		"coupon":                  "string",
-		"days_until_due":          "integer",
-		"default_payment_method":  "string",
-		"default_source":          "string",
-		"description":             "string",
-		"off_session":             "boolean",
-		"payment_behavior":        "string",
-		"promotion_code":          "string",
-		"proration_behavior":      "string",
-		"proration_date":          "integer",
-		"trial_end":               "string",
-		"trial_from_plan":         "boolean",
-	}, &Config)
-	resource.NewOperationCmd(rTaxCodesCmd.Cmd, "list", "/v1/tax_codes", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rTaxCodesCmd.Cmd, "retrieve", "/v1/tax_codes/{id}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTaxIdsCmd.Cmd, "create", "/v1/customers/{customer}/tax_ids", http.MethodPost, map[string]string{
-		"type":  "string",
-		"value": "string",
-	}, &Config)
-	resource.NewOperationCmd(rTaxIdsCmd.Cmd, "delete", "/v1/customers/{customer}/tax_ids/{id}", http.MethodDelete, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTaxIdsCmd.Cmd, "list", "/v1/customers/{customer}/tax_ids", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rTaxIdsCmd.Cmd, "retrieve", "/v1/customers/{customer}/tax_ids/{id}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTaxRatesCmd.Cmd, "create", "/v1/tax_rates", http.MethodPost, map[string]string{
-		"active":       "boolean",
-		"country":      "string",
-		"description":  "string",
-		"display_name": "string",
-		"inclusive":    "boolean",
-		"jurisdiction": "string",
-		"percentage":   "number",
-		"state":        "string",
-		"tax_type":     "string",
-	}, &Config)
-	resource.NewOperationCmd(rTaxRatesCmd.Cmd, "list", "/v1/tax_rates", http.MethodGet, map[string]string{
-		"active":         "boolean",
-		"created":        "integer",
-		"ending_before":  "string",
-		"inclusive":      "boolean",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rTaxRatesCmd.Cmd, "retrieve", "/v1/tax_rates/{tax_rate}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTaxRatesCmd.Cmd, "update", "/v1/tax_rates/{tax_rate}", http.MethodPost, map[string]string{
-		"active":       "boolean",
-		"country":      "string",
-		"description":  "string",
-		"display_name": "string",
-		"jurisdiction": "string",
-		"state":        "string",
-		"tax_type":     "string",
-	}, &Config)
-	resource.NewOperationCmd(rTokensCmd.Cmd, "create", "/v1/tokens", http.MethodPost, map[string]string{
-		"card":     "string",
-		"customer": "string",
-	}, &Config)
-	resource.NewOperationCmd(rTokensCmd.Cmd, "retrieve", "/v1/tokens/{token}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTopupsCmd.Cmd, "cancel", "/v1/topups/{topup}/cancel", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTopupsCmd.Cmd, "create", "/v1/topups", http.MethodPost, map[string]string{
-		"amount":               "integer",
-		"currency":             "string",
-		"description":          "string",
-		"source":               "string",
-		"statement_descriptor": "string",
-		"transfer_group":       "string",
-	}, &Config)
-	resource.NewOperationCmd(rTopupsCmd.Cmd, "list", "/v1/topups", http.MethodGet, map[string]string{
-		"amount":         "integer",
-		"created":        "integer",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-		"status":         "string",
-	}, &Config)
-	resource.NewOperationCmd(rTopupsCmd.Cmd, "retrieve", "/v1/topups/{topup}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTopupsCmd.Cmd, "update", "/v1/topups/{topup}", http.MethodPost, map[string]string{
-		"description": "string",
-	}, &Config)
-	resource.NewOperationCmd(rTransferReversalsCmd.Cmd, "create", "/v1/transfers/{id}/reversals", http.MethodPost, map[string]string{
-		"amount":                 "integer",
-		"description":            "string",
-		"refund_application_fee": "boolean",
-	}, &Config)
-	resource.NewOperationCmd(rTransferReversalsCmd.Cmd, "list", "/v1/transfers/{id}/reversals", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rTransferReversalsCmd.Cmd, "retrieve", "/v1/transfers/{transfer}/reversals/{id}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTransferReversalsCmd.Cmd, "update", "/v1/transfers/{transfer}/reversals/{id}", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTransfersCmd.Cmd, "create", "/v1/transfers", http.MethodPost, map[string]string{
-		"amount":             "integer",
-		"currency":           "string",
-		"description":        "string",
-		"destination":        "string",
-		"source_transaction": "string",
-		"source_type":        "string",
-		"transfer_group":     "string",
-	}, &Config)
-	resource.NewOperationCmd(rTransfersCmd.Cmd, "list", "/v1/transfers", http.MethodGet, map[string]string{
-		"created":        "integer",
-		"destination":    "string",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-		"transfer_group": "string",
-	}, &Config)
-	resource.NewOperationCmd(rTransfersCmd.Cmd, "retrieve", "/v1/transfers/{transfer}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTransfersCmd.Cmd, "update", "/v1/transfers/{transfer}", http.MethodPost, map[string]string{
-		"description": "string",
-	}, &Config)
-	resource.NewOperationCmd(rUsageRecordSummariesCmd.Cmd, "list", "/v1/subscription_items/{subscription_item}/usage_record_summaries", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rUsageRecordsCmd.Cmd, "create", "/v1/subscription_items/{subscription_item}/usage_records", http.MethodPost, map[string]string{
-		"action":    "string",
-		"quantity":  "integer",
-		"timestamp": "string",
-	}, &Config)
-	resource.NewOperationCmd(rWebhookEndpointsCmd.Cmd, "create", "/v1/webhook_endpoints", http.MethodPost, map[string]string{
-		"api_version": "string",
-		"connect":     "boolean",
-		"description": "string",
-		"url":         "string",
-	}, &Config)
-	resource.NewOperationCmd(rWebhookEndpointsCmd.Cmd, "delete", "/v1/webhook_endpoints/{webhook_endpoint}", http.MethodDelete, map[string]string{}, &Config)
-	resource.NewOperationCmd(rWebhookEndpointsCmd.Cmd, "list", "/v1/webhook_endpoints", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rWebhookEndpointsCmd.Cmd, "retrieve", "/v1/webhook_endpoints/{webhook_endpoint}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rWebhookEndpointsCmd.Cmd, "update", "/v1/webhook_endpoints/{webhook_endpoint}", http.MethodPost, map[string]string{
-		"description": "string",
-		"disabled":    "boolean",
-		"url":         "string",
-	}, &Config)
-	resource.NewOperationCmd(rAppsSecretsCmd.Cmd, "create", "/v1/apps/secrets", http.MethodPost, map[string]string{
-		"name":    "string",
-		"payload": "string",
-	}, &Config)
-	resource.NewOperationCmd(rAppsSecretsCmd.Cmd, "delete_where", "/v1/apps/secrets/delete", http.MethodPost, map[string]string{
-		"name": "string",
-	}, &Config)
-	resource.NewOperationCmd(rAppsSecretsCmd.Cmd, "find", "/v1/apps/secrets/find", http.MethodGet, map[string]string{
-		"name": "string",
-	}, &Config)
-	resource.NewOperationCmd(rAppsSecretsCmd.Cmd, "list", "/v1/apps/secrets", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rBillingPortalConfigurationsCmd.Cmd, "create", "/v1/billing_portal/configurations", http.MethodPost, map[string]string{
-		"default_return_url": "string",
-	}, &Config)
-	resource.NewOperationCmd(rBillingPortalConfigurationsCmd.Cmd, "list", "/v1/billing_portal/configurations", http.MethodGet, map[string]string{
-		"active":         "boolean",
-		"ending_before":  "string",
-		"is_default":     "boolean",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rBillingPortalConfigurationsCmd.Cmd, "retrieve", "/v1/billing_portal/configurations/{configuration}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rBillingPortalConfigurationsCmd.Cmd, "update", "/v1/billing_portal/configurations/{configuration}", http.MethodPost, map[string]string{
-		"active":             "boolean",
-		"default_return_url": "string",
-	}, &Config)
-	resource.NewOperationCmd(rBillingPortalSessionsCmd.Cmd, "create", "/v1/billing_portal/sessions", http.MethodPost, map[string]string{
-		"configuration": "string",
-		"customer":      "string",
-		"locale":        "string",
-		"on_behalf_of":  "string",
-		"return_url":    "string",
-	}, &Config)
-	resource.NewOperationCmd(rCheckoutSessionsCmd.Cmd, "create", "/v1/checkout/sessions", http.MethodPost, map[string]string{
-		"allow_promotion_codes":      "boolean",
-		"billing_address_collection": "string",
-		"cancel_url":                 "string",
-		"client_reference_id":        "string",
-		"customer":                   "string",
-		"customer_creation":          "string",
-		"customer_email":             "string",
-		"expires_at":                 "integer",
-		"locale":                     "string",
-		"mode":                       "string",
-		"submit_type":                "string",
-		"success_url":                "string",
-	}, &Config)
-	resource.NewOperationCmd(rCheckoutSessionsCmd.Cmd, "expire", "/v1/checkout/sessions/{session}/expire", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rCheckoutSessionsCmd.Cmd, "list", "/v1/checkout/sessions", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"payment_intent": "string",
-		"starting_after": "string",
-		"subscription":   "string",
-	}, &Config)
-	resource.NewOperationCmd(rCheckoutSessionsCmd.Cmd, "retrieve", "/v1/checkout/sessions/{session}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rFinancialConnectionsAccountsCmd.Cmd, "disconnect", "/v1/financial_connections/accounts/{account}/disconnect", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rFinancialConnectionsAccountsCmd.Cmd, "list", "/v1/financial_connections/accounts", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"session":        "string",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rFinancialConnectionsAccountsCmd.Cmd, "list_owners", "/v1/financial_connections/accounts/{account}/owners", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"ownership":      "string",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rFinancialConnectionsAccountsCmd.Cmd, "refresh", "/v1/financial_connections/accounts/{account}/refresh", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rFinancialConnectionsAccountsCmd.Cmd, "retrieve", "/v1/financial_connections/accounts/{account}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rFinancialConnectionsSessionsCmd.Cmd, "create", "/v1/financial_connections/sessions", http.MethodPost, map[string]string{
-		"return_url": "string",
-	}, &Config)
-	resource.NewOperationCmd(rFinancialConnectionsSessionsCmd.Cmd, "retrieve", "/v1/financial_connections/sessions/{session}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rIdentityVerificationReportsCmd.Cmd, "list", "/v1/identity/verification_reports", http.MethodGet, map[string]string{
-		"created":              "integer",
-		"ending_before":        "string",
-		"limit":                "integer",
-		"starting_after":       "string",
-		"type":                 "string",
-		"verification_session": "string",
-	}, &Config)
-	resource.NewOperationCmd(rIdentityVerificationReportsCmd.Cmd, "retrieve", "/v1/identity/verification_reports/{report}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rIdentityVerificationSessionsCmd.Cmd, "cancel", "/v1/identity/verification_sessions/{session}/cancel", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rIdentityVerificationSessionsCmd.Cmd, "create", "/v1/identity/verification_sessions", http.MethodPost, map[string]string{
-		"return_url": "string",
-		"type":       "string",
-	}, &Config)
-	resource.NewOperationCmd(rIdentityVerificationSessionsCmd.Cmd, "list", "/v1/identity/verification_sessions", http.MethodGet, map[string]string{
-		"created":        "integer",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-		"status":         "string",
-	}, &Config)
-	resource.NewOperationCmd(rIdentityVerificationSessionsCmd.Cmd, "redact", "/v1/identity/verification_sessions/{session}/redact", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rIdentityVerificationSessionsCmd.Cmd, "retrieve", "/v1/identity/verification_sessions/{session}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rIdentityVerificationSessionsCmd.Cmd, "update", "/v1/identity/verification_sessions/{session}", http.MethodPost, map[string]string{
-		"type": "string",
-	}, &Config)
-	resource.NewOperationCmd(rIssuingAuthorizationsCmd.Cmd, "approve", "/v1/issuing/authorizations/{authorization}/approve", http.MethodPost, map[string]string{
-		"amount": "integer",
-	}, &Config)
-	resource.NewOperationCmd(rIssuingAuthorizationsCmd.Cmd, "decline", "/v1/issuing/authorizations/{authorization}/decline", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rIssuingAuthorizationsCmd.Cmd, "list", "/v1/issuing/authorizations", http.MethodGet, map[string]string{
-		"card":           "string",
-		"cardholder":     "string",
-		"created":        "integer",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-		"status":         "string",
-	}, &Config)
-	resource.NewOperationCmd(rIssuingAuthorizationsCmd.Cmd, "retrieve", "/v1/issuing/authorizations/{authorization}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rIssuingAuthorizationsCmd.Cmd, "update", "/v1/issuing/authorizations/{authorization}", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rIssuingCardholdersCmd.Cmd, "create", "/v1/issuing/cardholders", http.MethodPost, map[string]string{
-		"email":        "string",
-		"name":         "string",
-		"phone_number": "string",
-		"status":       "string",
-		"type":         "string",
-	}, &Config)
-	resource.NewOperationCmd(rIssuingCardholdersCmd.Cmd, "list", "/v1/issuing/cardholders", http.MethodGet, map[string]string{
-		"created":        "integer",
-		"email":          "string",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"phone_number":   "string",
-		"starting_after": "string",
-		"status":         "string",
-		"type":           "string",
-	}, &Config)
-	resource.NewOperationCmd(rIssuingCardholdersCmd.Cmd, "retrieve", "/v1/issuing/cardholders/{cardholder}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rIssuingCardholdersCmd.Cmd, "update", "/v1/issuing/cardholders/{cardholder}", http.MethodPost, map[string]string{
-		"email":        "string",
-		"phone_number": "string",
-		"status":       "string",
-	}, &Config)
-	resource.NewOperationCmd(rIssuingCardsCmd.Cmd, "create", "/v1/issuing/cards", http.MethodPost, map[string]string{
-		"cardholder":         "string",
-		"currency":           "string",
-		"financial_account":  "string",
-		"replacement_for":    "string",
-		"replacement_reason": "string",
-		"status":             "string",
-		"type":               "string",
-	}, &Config)
-	resource.NewOperationCmd(rIssuingCardsCmd.Cmd, "list", "/v1/issuing/cards", http.MethodGet, map[string]string{
-		"cardholder":     "string",
-		"created":        "integer",
-		"ending_before":  "string",
-		"exp_month":      "integer",
-		"exp_year":       "integer",
-		"last4":          "string",
-		"limit":          "integer",
-		"starting_after": "string",
-		"status":         "string",
-		"type":           "string",
-	}, &Config)
-	resource.NewOperationCmd(rIssuingCardsCmd.Cmd, "retrieve", "/v1/issuing/cards/{card}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rIssuingCardsCmd.Cmd, "update", "/v1/issuing/cards/{card}", http.MethodPost, map[string]string{
-		"cancellation_reason": "string",
-		"status":              "string",
-	}, &Config)
-	resource.NewOperationCmd(rIssuingDisputesCmd.Cmd, "create", "/v1/issuing/disputes", http.MethodPost, map[string]string{
-		"transaction": "string",
-	}, &Config)
-	resource.NewOperationCmd(rIssuingDisputesCmd.Cmd, "list", "/v1/issuing/disputes", http.MethodGet, map[string]string{
-		"created":        "integer",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-		"status":         "string",
-		"transaction":    "string",
-	}, &Config)
-	resource.NewOperationCmd(rIssuingDisputesCmd.Cmd, "retrieve", "/v1/issuing/disputes/{dispute}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rIssuingDisputesCmd.Cmd, "submit", "/v1/issuing/disputes/{dispute}/submit", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rIssuingDisputesCmd.Cmd, "update", "/v1/issuing/disputes/{dispute}", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rIssuingTransactionsCmd.Cmd, "list", "/v1/issuing/transactions", http.MethodGet, map[string]string{
-		"card":           "string",
-		"cardholder":     "string",
-		"created":        "integer",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-		"type":           "string",
-	}, &Config)
-	resource.NewOperationCmd(rIssuingTransactionsCmd.Cmd, "retrieve", "/v1/issuing/transactions/{transaction}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rIssuingTransactionsCmd.Cmd, "update", "/v1/issuing/transactions/{transaction}", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rRadarEarlyFraudWarningsCmd.Cmd, "list", "/v1/radar/early_fraud_warnings", http.MethodGet, map[string]string{
-		"charge":         "string",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"payment_intent": "string",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rRadarEarlyFraudWarningsCmd.Cmd, "retrieve", "/v1/radar/early_fraud_warnings/{early_fraud_warning}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rRadarValueListItemsCmd.Cmd, "create", "/v1/radar/value_list_items", http.MethodPost, map[string]string{
-		"value":      "string",
-		"value_list": "string",
-	}, &Config)
-	resource.NewOperationCmd(rRadarValueListItemsCmd.Cmd, "delete", "/v1/radar/value_list_items/{item}", http.MethodDelete, map[string]string{}, &Config)
-	resource.NewOperationCmd(rRadarValueListItemsCmd.Cmd, "list", "/v1/radar/value_list_items", http.MethodGet, map[string]string{
-		"created":        "integer",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-		"value":          "string",
-		"value_list":     "string",
-	}, &Config)
-	resource.NewOperationCmd(rRadarValueListItemsCmd.Cmd, "retrieve", "/v1/radar/value_list_items/{item}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rRadarValueListsCmd.Cmd, "create", "/v1/radar/value_lists", http.MethodPost, map[string]string{
-		"alias":     "string",
-		"item_type": "string",
-		"name":      "string",
-	}, &Config)
-	resource.NewOperationCmd(rRadarValueListsCmd.Cmd, "delete", "/v1/radar/value_lists/{value_list}", http.MethodDelete, map[string]string{}, &Config)
-	resource.NewOperationCmd(rRadarValueListsCmd.Cmd, "list", "/v1/radar/value_lists", http.MethodGet, map[string]string{
-		"alias":          "string",
-		"contains":       "string",
-		"created":        "integer",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rRadarValueListsCmd.Cmd, "retrieve", "/v1/radar/value_lists/{value_list}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rRadarValueListsCmd.Cmd, "update", "/v1/radar/value_lists/{value_list}", http.MethodPost, map[string]string{
-		"alias": "string",
-		"name":  "string",
-	}, &Config)
-	resource.NewOperationCmd(rReportingReportRunsCmd.Cmd, "create", "/v1/reporting/report_runs", http.MethodPost, map[string]string{
-		"report_type": "string",
-	}, &Config)
-	resource.NewOperationCmd(rReportingReportRunsCmd.Cmd, "list", "/v1/reporting/report_runs", http.MethodGet, map[string]string{
-		"created":        "integer",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rReportingReportRunsCmd.Cmd, "retrieve", "/v1/reporting/report_runs/{report_run}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rReportingReportTypesCmd.Cmd, "list", "/v1/reporting/report_types", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rReportingReportTypesCmd.Cmd, "retrieve", "/v1/reporting/report_types/{report_type}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTerminalConfigurationsCmd.Cmd, "create", "/v1/terminal/configurations", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTerminalConfigurationsCmd.Cmd, "delete", "/v1/terminal/configurations/{configuration}", http.MethodDelete, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTerminalConfigurationsCmd.Cmd, "list", "/v1/terminal/configurations", http.MethodGet, map[string]string{
-		"ending_before":      "string",
-		"is_account_default": "boolean",
-		"limit":              "integer",
-		"starting_after":     "string",
-	}, &Config)
-	resource.NewOperationCmd(rTerminalConfigurationsCmd.Cmd, "retrieve", "/v1/terminal/configurations/{configuration}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTerminalConfigurationsCmd.Cmd, "update", "/v1/terminal/configurations/{configuration}", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTerminalConnectionTokensCmd.Cmd, "create", "/v1/terminal/connection_tokens", http.MethodPost, map[string]string{
-		"location": "string",
-	}, &Config)
-	resource.NewOperationCmd(rTerminalLocationsCmd.Cmd, "create", "/v1/terminal/locations", http.MethodPost, map[string]string{
-		"configuration_overrides": "string",
-		"display_name":            "string",
-	}, &Config)
-	resource.NewOperationCmd(rTerminalLocationsCmd.Cmd, "delete", "/v1/terminal/locations/{location}", http.MethodDelete, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTerminalLocationsCmd.Cmd, "list", "/v1/terminal/locations", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rTerminalLocationsCmd.Cmd, "retrieve", "/v1/terminal/locations/{location}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTerminalLocationsCmd.Cmd, "update", "/v1/terminal/locations/{location}", http.MethodPost, map[string]string{
-		"configuration_overrides": "string",
-		"display_name":            "string",
-	}, &Config)
-	resource.NewOperationCmd(rTerminalReadersCmd.Cmd, "cancel_action", "/v1/terminal/readers/{reader}/cancel_action", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTerminalReadersCmd.Cmd, "create", "/v1/terminal/readers", http.MethodPost, map[string]string{
-		"label":             "string",
-		"location":          "string",
-		"registration_code": "string",
-	}, &Config)
-	resource.NewOperationCmd(rTerminalReadersCmd.Cmd, "delete", "/v1/terminal/readers/{reader}", http.MethodDelete, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTerminalReadersCmd.Cmd, "list", "/v1/terminal/readers", http.MethodGet, map[string]string{
-		"device_type":    "string",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"location":       "string",
-		"starting_after": "string",
-		"status":         "string",
-	}, &Config)
-	resource.NewOperationCmd(rTerminalReadersCmd.Cmd, "present_payment_method", "/v1/test_helpers/terminal/readers/{reader}/present_payment_method", http.MethodPost, map[string]string{
-		"type": "string",
-	}, &Config)
-	resource.NewOperationCmd(rTerminalReadersCmd.Cmd, "process_payment_intent", "/v1/terminal/readers/{reader}/process_payment_intent", http.MethodPost, map[string]string{
-		"payment_intent": "string",
-	}, &Config)
-	resource.NewOperationCmd(rTerminalReadersCmd.Cmd, "process_setup_intent", "/v1/terminal/readers/{reader}/process_setup_intent", http.MethodPost, map[string]string{
-		"customer_consent_collected": "boolean",
-		"setup_intent":               "string",
-	}, &Config)
-	resource.NewOperationCmd(rTerminalReadersCmd.Cmd, "retrieve", "/v1/terminal/readers/{reader}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTerminalReadersCmd.Cmd, "set_reader_display", "/v1/terminal/readers/{reader}/set_reader_display", http.MethodPost, map[string]string{
-		"type": "string",
-	}, &Config)
-	resource.NewOperationCmd(rTerminalReadersCmd.Cmd, "update", "/v1/terminal/readers/{reader}", http.MethodPost, map[string]string{
-		"label": "string",
-	}, &Config)
-	resource.NewOperationCmd(rTestHelpersTestClocksCmd.Cmd, "advance", "/v1/test_helpers/test_clocks/{test_clock}/advance", http.MethodPost, map[string]string{
-		"frozen_time": "integer",
-	}, &Config)
-	resource.NewOperationCmd(rTestHelpersTestClocksCmd.Cmd, "create", "/v1/test_helpers/test_clocks", http.MethodPost, map[string]string{
-		"frozen_time": "integer",
-		"name":        "string",
-	}, &Config)
-	resource.NewOperationCmd(rTestHelpersTestClocksCmd.Cmd, "delete", "/v1/test_helpers/test_clocks/{test_clock}", http.MethodDelete, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTestHelpersTestClocksCmd.Cmd, "list", "/v1/test_helpers/test_clocks", http.MethodGet, map[string]string{
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rTestHelpersTestClocksCmd.Cmd, "retrieve", "/v1/test_helpers/test_clocks/{test_clock}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTreasuryCreditReversalsCmd.Cmd, "create", "/v1/treasury/credit_reversals", http.MethodPost, map[string]string{
-		"received_credit": "string",
-	}, &Config)
-	resource.NewOperationCmd(rTreasuryCreditReversalsCmd.Cmd, "list", "/v1/treasury/credit_reversals", http.MethodGet, map[string]string{
-		"ending_before":     "string",
-		"financial_account": "string",
-		"limit":             "integer",
-		"received_credit":   "string",
-		"starting_after":    "string",
-		"status":            "string",
-	}, &Config)
-	resource.NewOperationCmd(rTreasuryCreditReversalsCmd.Cmd, "retrieve", "/v1/treasury/credit_reversals/{credit_reversal}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTreasuryDebitReversalsCmd.Cmd, "create", "/v1/treasury/debit_reversals", http.MethodPost, map[string]string{
-		"received_debit": "string",
-	}, &Config)
-	resource.NewOperationCmd(rTreasuryDebitReversalsCmd.Cmd, "list", "/v1/treasury/debit_reversals", http.MethodGet, map[string]string{
-		"ending_before":     "string",
-		"financial_account": "string",
-		"limit":             "integer",
-		"received_debit":    "string",
-		"resolution":        "string",
-		"starting_after":    "string",
-		"status":            "string",
-	}, &Config)
-	resource.NewOperationCmd(rTreasuryDebitReversalsCmd.Cmd, "retrieve", "/v1/treasury/debit_reversals/{debit_reversal}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTreasuryFinancialAccountsCmd.Cmd, "create", "/v1/treasury/financial_accounts", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTreasuryFinancialAccountsCmd.Cmd, "list", "/v1/treasury/financial_accounts", http.MethodGet, map[string]string{
-		"created":        "integer",
-		"ending_before":  "string",
-		"limit":          "integer",
-		"starting_after": "string",
-	}, &Config)
-	resource.NewOperationCmd(rTreasuryFinancialAccountsCmd.Cmd, "retrieve", "/v1/treasury/financial_accounts/{financial_account}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTreasuryFinancialAccountsCmd.Cmd, "retrieve_features", "/v1/treasury/financial_accounts/{financial_account}/features", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTreasuryFinancialAccountsCmd.Cmd, "update", "/v1/treasury/financial_accounts/{financial_account}", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTreasuryFinancialAccountsCmd.Cmd, "update_features", "/v1/treasury/financial_accounts/{financial_account}/features", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTreasuryInboundTransfersCmd.Cmd, "cancel", "/v1/treasury/inbound_transfers/{inbound_transfer}/cancel", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTreasuryInboundTransfersCmd.Cmd, "create", "/v1/treasury/inbound_transfers", http.MethodPost, map[string]string{
-		"amount":                "integer",
-		"currency":              "string",
-		"description":           "string",
-		"financial_account":     "string",
-		"origin_payment_method": "string",
-		"statement_descriptor":  "string",
-	}, &Config)
-	resource.NewOperationCmd(rTreasuryInboundTransfersCmd.Cmd, "fail", "/v1/test_helpers/treasury/inbound_transfers/{id}/fail", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTreasuryInboundTransfersCmd.Cmd, "list", "/v1/treasury/inbound_transfers", http.MethodGet, map[string]string{
-		"ending_before":     "string",
-		"financial_account": "string",
-		"limit":             "integer",
-		"starting_after":    "string",
-		"status":            "string",
-	}, &Config)
-	resource.NewOperationCmd(rTreasuryInboundTransfersCmd.Cmd, "retrieve", "/v1/treasury/inbound_transfers/{id}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTreasuryInboundTransfersCmd.Cmd, "return_inbound_transfer", "/v1/test_helpers/treasury/inbound_transfers/{id}/return", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTreasuryInboundTransfersCmd.Cmd, "succeed", "/v1/test_helpers/treasury/inbound_transfers/{id}/succeed", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTreasuryOutboundPaymentsCmd.Cmd, "cancel", "/v1/treasury/outbound_payments/{id}/cancel", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTreasuryOutboundPaymentsCmd.Cmd, "create", "/v1/treasury/outbound_payments", http.MethodPost, map[string]string{
-		"amount":                     "integer",
-		"currency":                   "string",
-		"customer":                   "string",
-		"description":                "string",
-		"destination_payment_method": "string",
-		"financial_account":          "string",
-		"statement_descriptor":       "string",
-	}, &Config)
-	resource.NewOperationCmd(rTreasuryOutboundPaymentsCmd.Cmd, "fail", "/v1/test_helpers/treasury/outbound_payments/{id}/fail", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTreasuryOutboundPaymentsCmd.Cmd, "list", "/v1/treasury/outbound_payments", http.MethodGet, map[string]string{
-		"customer":          "string",
-		"ending_before":     "string",
-		"financial_account": "string",
-		"limit":             "integer",
-		"starting_after":    "string",
-		"status":            "string",
-	}, &Config)
-	resource.NewOperationCmd(rTreasuryOutboundPaymentsCmd.Cmd, "post", "/v1/test_helpers/treasury/outbound_payments/{id}/post", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTreasuryOutboundPaymentsCmd.Cmd, "retrieve", "/v1/treasury/outbound_payments/{id}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTreasuryOutboundPaymentsCmd.Cmd, "return_outbound_payment", "/v1/test_helpers/treasury/outbound_payments/{id}/return", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTreasuryOutboundTransfersCmd.Cmd, "cancel", "/v1/treasury/outbound_transfers/{outbound_transfer}/cancel", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTreasuryOutboundTransfersCmd.Cmd, "create", "/v1/treasury/outbound_transfers", http.MethodPost, map[string]string{
-		"amount":                     "integer",
-		"currency":                   "string",
-		"description":                "string",
-		"destination_payment_method": "string",
-		"financial_account":          "string",
-		"statement_descriptor":       "string",
-	}, &Config)
-	resource.NewOperationCmd(rTreasuryOutboundTransfersCmd.Cmd, "fail", "/v1/test_helpers/treasury/outbound_transfers/{outbound_transfer}/fail", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTreasuryOutboundTransfersCmd.Cmd, "list", "/v1/treasury/outbound_transfers", http.MethodGet, map[string]string{
-		"ending_before":     "string",
-		"financial_account": "string",
-		"limit":             "integer",
-		"starting_after":    "string",
-		"status":            "string",
-	}, &Config)
-	resource.NewOperationCmd(rTreasuryOutboundTransfersCmd.Cmd, "post", "/v1/test_helpers/treasury/outbound_transfers/{outbound_transfer}/post", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTreasuryOutboundTransfersCmd.Cmd, "retrieve", "/v1/treasury/outbound_transfers/{outbound_transfer}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTreasuryOutboundTransfersCmd.Cmd, "return_outbound_transfer", "/v1/test_helpers/treasury/outbound_transfers/{outbound_transfer}/return", http.MethodPost, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTreasuryReceivedCreditsCmd.Cmd, "create", "/v1/test_helpers/treasury/received_credits", http.MethodPost, map[string]string{
-		"amount":            "integer",
-		"currency":          "string",
-		"description":       "string",
-		"financial_account": "string",
-		"network":           "string",
-	}, &Config)
-	resource.NewOperationCmd(rTreasuryReceivedCreditsCmd.Cmd, "list", "/v1/treasury/received_credits", http.MethodGet, map[string]string{
-		"ending_before":     "string",
-		"financial_account": "string",
-		"limit":             "integer",
-		"starting_after":    "string",
-		"status":            "string",
-	}, &Config)
-	resource.NewOperationCmd(rTreasuryReceivedCreditsCmd.Cmd, "retrieve", "/v1/treasury/received_credits/{id}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTreasuryReceivedDebitsCmd.Cmd, "create", "/v1/test_helpers/treasury/received_debits", http.MethodPost, map[string]string{
-		"amount":            "integer",
-		"currency":          "string",
-		"description":       "string",
-		"financial_account": "string",
-		"network":           "string",
-	}, &Config)
-	resource.NewOperationCmd(rTreasuryReceivedDebitsCmd.Cmd, "list", "/v1/treasury/received_debits", http.MethodGet, map[string]string{
-		"ending_before":     "string",
-		"financial_account": "string",
-		"limit":             "integer",
-		"starting_after":    "string",
-		"status":            "string",
-	}, &Config)
-	resource.NewOperationCmd(rTreasuryReceivedDebitsCmd.Cmd, "retrieve", "/v1/treasury/received_debits/{id}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTreasuryTransactionEntrysCmd.Cmd, "list", "/v1/treasury/transaction_entries", http.MethodGet, map[string]string{
-		"created":           "integer",
-		"effective_at":      "integer",
-		"ending_before":     "string",
-		"financial_account": "string",
-		"limit":             "integer",
-		"order_by":          "string",
-		"starting_after":    "string",
-		"transaction":       "string",
-	}, &Config)
-	resource.NewOperationCmd(rTreasuryTransactionEntrysCmd.Cmd, "retrieve", "/v1/treasury/transaction_entries/{id}", http.MethodGet, map[string]string{}, &Config)
-	resource.NewOperationCmd(rTreasuryTransactionsCmd.Cmd, "list", "/v1/treasury/transactions", http.MethodGet, map[string]string{
-		"created":           "integer",
-		"ending_before":     "string",
-		"financial_account": "string",
-		"limit":             "integer",
-		"order_by":          "string",
-		"starting_after":    "string",
-		"status":            "string",
-	}, &Config)
-	resource.NewOperationCmd(rTreasuryTransactionsCmd.Cmd, "retrieve", "/v1/treasury/transactions/{id}", http.MethodGet, map[string]string{}, &Config)
+	// Operation commands, added when their resource is invoked
+	r3DSecureCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/3d_secure", http.MethodPost, map[string]string{
+			"amount":     "integer",
+			"card":       "string",
+			"currency":   "string",
+			"customer":   "string",
+			"return_url": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/3d_secure/{three_d_secure}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rAccountLinksCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/account_links", http.MethodPost, map[string]string{
+			"account":     "string",
+			"collect":     "string",
+			"refresh_url": "string",
+			"return_url":  "string",
+			"type":        "string",
+		}, &Config)
+	})
+	rAccountsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "capabilities", "/v1/accounts/{account}/capabilities", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "create", "/v1/accounts", http.MethodPost, map[string]string{
+			"account_token":    "string",
+			"business_type":    "string",
+			"country":          "string",
+			"default_currency": "string",
+			"email":            "string",
+			"external_account": "string",
+			"type":             "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "delete", "/v1/accounts/{account}", http.MethodDelete, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/accounts", http.MethodGet, map[string]string{
+			"created":        "integer",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "persons", "/v1/accounts/{account}/persons", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "reject", "/v1/accounts/{account}/reject", http.MethodPost, map[string]string{
+			"reason": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/account", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/accounts/{account}", http.MethodPost, map[string]string{
+			"account_token":    "string",
+			"business_type":    "string",
+			"default_currency": "string",
+			"email":            "string",
+			"external_account": "string",
+		}, &Config)
+	})
+	rApplePayDomainsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/apple_pay/domains", http.MethodPost, map[string]string{
+			"domain_name": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "delete", "/v1/apple_pay/domains/{domain}", http.MethodDelete, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/apple_pay/domains", http.MethodGet, map[string]string{
+			"domain_name":    "string",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/apple_pay/domains/{domain}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rApplicationFeesCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "list", "/v1/application_fees", http.MethodGet, map[string]string{
+			"charge":         "string",
+			"created":        "integer",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/application_fees/{id}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rBalanceCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/balance", http.MethodGet, map[string]string{}, &Config)
+	})
+	rBalanceTransactionsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "list", "/v1/balance_transactions", http.MethodGet, map[string]string{
+			"available_on":   "integer",
+			"created":        "integer",
+			"currency":       "string",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"payout":         "string",
+			"source":         "string",
+			"starting_after": "string",
+			"type":           "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/balance_transactions/{id}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rBankAccountsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "delete", "/v1/customers/{customer}/sources/{id}", http.MethodDelete, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/customers/{customer}/sources/{id}", http.MethodPost, map[string]string{
+			"account_holder_name": "string",
+			"account_holder_type": "string",
+			"address_city":        "string",
+			"address_country":     "string",
+			"address_line1":       "string",
+			"address_line2":       "string",
+			"address_state":       "string",
+			"address_zip":         "string",
+			"exp_month":           "string",
+			"exp_year":            "string",
+			"name":                "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "verify", "/v1/customers/{customer}/sources/{id}/verify", http.MethodPost, map[string]string{}, &Config)
+	})
+	rCapabilitiesCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "list", "/v1/accounts/{account}/capabilities", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/accounts/{account}/capabilities/{capability}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/accounts/{account}/capabilities/{capability}", http.MethodPost, map[string]string{
+			"requested": "boolean",
+		}, &Config)
+	})
+	rCardsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "delete", "/v1/customers/{customer}/sources/{id}", http.MethodDelete, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/customers/{customer}/sources/{id}", http.MethodPost, map[string]string{
+			"account_holder_name": "string",
+			"account_holder_type": "string",
+			"address_city":        "string",
+			"address_country":     "string",
+			"address_line1":       "string",
+			"address_line2":       "string",
+			"address_state":       "string",
+			"address_zip":         "string",
+			"exp_month":           "string",
+			"exp_year":            "string",
+			"name":                "string",
+		}, &Config)
+	})
+	rCashBalancesCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/customers/{customer}/cash_balance", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/customers/{customer}/cash_balance", http.MethodPost, map[string]string{}, &Config)
+	})
+	rChargesCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "capture", "/v1/charges/{charge}/capture", http.MethodPost, map[string]string{
+			"amount":                      "integer",
+			"application_fee":             "integer",
+			"application_fee_amount":      "integer",
+			"receipt_email":               "string",
+			"statement_descriptor":        "string",
+			"statement_descriptor_suffix": "string",
+			"transfer_group":              "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "create", "/v1/charges", http.MethodPost, map[string]string{
+			"amount":                      "integer",
+			"application_fee":             "integer",
+			"application_fee_amount":      "integer",
+			"capture":                     "boolean",
+			"currency":                    "string",
+			"customer":                    "string",
+			"description":                 "string",
+			"on_behalf_of":                "string",
+			"receipt_email":               "string",
+			"source":                      "string",
+			"statement_descriptor":        "string",
+			"statement_descriptor_suffix": "string",
+			"transfer_group":              "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/charges", http.MethodGet, map[string]string{
+			"created":        "integer",
+			"customer":       "string",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"payment_intent": "string",
+			"starting_after": "string",
+			"transfer_group": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/charges/{charge}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "search", "/v1/charges/search", http.MethodGet, map[string]string{
+			"limit": "integer",
+			"page":  "string",
+			"query": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/charges/{charge}", http.MethodPost, map[string]string{
+			"customer":       "string",
+			"description":    "string",
+			"receipt_email":  "string",
+			"transfer_group": "string",
+		}, &Config)
+	})
+	rCountrySpecsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "list", "/v1/country_specs", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/country_specs/{country}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rCouponsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/coupons", http.MethodPost, map[string]string{
+			"amount_off":         "integer",
+			"currency":           "string",
+			"duration":           "string",
+			"duration_in_months": "integer",
+			"id":                 "string",
+			"max_redemptions":    "integer",
+			"name":               "string",
+			"percent_off":        "number",
+			"redeem_by":          "integer",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "delete", "/v1/coupons/{coupon}", http.MethodDelete, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/coupons", http.MethodGet, map[string]string{
+			"created":        "integer",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/coupons/{coupon}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/coupons/{coupon}", http.MethodPost, map[string]string{
+			"name": "string",
+		}, &Config)
+	})
+	rCreditNoteLineItemsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "list", "/v1/credit_notes/{credit_note}/lines", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+	})
+	rCreditNotesCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/credit_notes", http.MethodPost, map[string]string{
+			"amount":             "integer",
+			"credit_amount":      "integer",
+			"invoice":            "string",
+			"memo":               "string",
+			"out_of_band_amount": "integer",
+			"reason":             "string",
+			"refund":             "string",
+			"refund_amount":      "integer",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/credit_notes", http.MethodGet, map[string]string{
+			"customer":       "string",
+			"ending_before":  "string",
+			"invoice":        "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "preview", "/v1/credit_notes/preview", http.MethodGet, map[string]string{
+			"amount":             "integer",
+			"credit_amount":      "integer",
+			"invoice":            "string",
+			"memo":               "string",
+			"out_of_band_amount": "integer",
+			"reason":             "string",
+			"refund":             "string",
+			"refund_amount":      "integer",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "preview_lines", "/v1/credit_notes/preview/lines", http.MethodGet, map[string]string{
+			"amount":             "integer",
+			"credit_amount":      "integer",
+			"ending_before":      "string",
+			"invoice":            "string",
+			"limit":              "integer",
+			"memo":               "string",
+			"out_of_band_amount": "integer",
+			"reason":             "string",
+			"refund":             "string",
+			"refund_amount":      "integer",
+			"starting_after":     "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/credit_notes/{id}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/credit_notes/{id}", http.MethodPost, map[string]string{
+			"memo": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "void_credit_note", "/v1/credit_notes/{id}/void", http.MethodPost, map[string]string{}, &Config)
+	})
+	rCustomerBalanceTransactionsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/customers/{customer}/balance_transactions", http.MethodPost, map[string]string{
+			"amount":      "integer",
+			"currency":    "string",
+			"description": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/customers/{customer}/balance_transactions", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/customers/{customer}/balance_transactions/{transaction}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/customers/{customer}/balance_transactions/{transaction}", http.MethodPost, map[string]string{
+			"description": "string",
+		}, &Config)
+	})
+	rCustomersCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "balance_transactions", "/v1/customers/{customer}/balance_transactions", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "create", "/v1/customers", http.MethodPost, map[string]string{
+			"balance":               "integer",
+			"coupon":                "string",
+			"description":           "string",
+			"email":                 "string",
+			"invoice_prefix":        "string",
+			"name":                  "string",
+			"next_invoice_sequence": "integer",
+			"payment_method":        "string",
+			"phone":                 "string",
+			"promotion_code":        "string",
+			"source":                "string",
+			"tax_exempt":            "string",
+			"test_clock":            "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "create_funding_instructions", "/v1/customers/{customer}/funding_instructions", http.MethodPost, map[string]string{
+			"currency":     "string",
+			"funding_type": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "delete", "/v1/customers/{customer}", http.MethodDelete, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "delete_discount", "/v1/customers/{customer}/discount", http.MethodDelete, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/customers", http.MethodGet, map[string]string{
+			"created":        "integer",
+			"email":          "string",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+			"test_clock":     "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list_payment_methods", "/v1/customers/{customer}/payment_methods", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+			"type":           "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/customers/{customer}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve_payment_method", "/v1/customers/{customer}/payment_methods/{payment_method}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "search", "/v1/customers/search", http.MethodGet, map[string]string{
+			"limit": "integer",
+			"page":  "string",
+			"query": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/customers/{customer}", http.MethodPost, map[string]string{
+			"balance":               "integer",
+			"coupon":                "string",
+			"default_source":        "string",
+			"description":           "string",
+			"email":                 "string",
+			"invoice_prefix":        "string",
+			"name":                  "string",
+			"next_invoice_sequence": "integer",
+			"phone":                 "string",
+			"promotion_code":        "string",
+			"source":                "string",
+			"tax_exempt":            "string",
+			"trial_end":             "string",
+		}, &Config)
+	})
+	rDisputesCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "close", "/v1/disputes/{dispute}/close", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/disputes", http.MethodGet, map[string]string{
+			"charge":         "string",
+			"created":        "integer",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"payment_intent": "string",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/disputes/{dispute}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/disputes/{dispute}", http.MethodPost, map[string]string{
+			"submit": "boolean",
+		}, &Config)
+	})
+	rEphemeralKeysCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/ephemeral_keys", http.MethodPost, map[string]string{
+			"customer":     "string",
+			"issuing_card": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "delete", "/v1/ephemeral_keys/{key}", http.MethodDelete, map[string]string{}, &Config)
+	})
+	rEventsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "list", "/v1/events", http.MethodGet, map[string]string{
+			"created":          "integer",
+			"delivery_success": "boolean",
+			"ending_before":    "string",
+			"limit":            "integer",
+			"starting_after":   "string",
+			"type":             "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/events/{id}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rExchangeRatesCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "list", "/v1/exchange_rates", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/exchange_rates/{rate_id}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rExternalAccountsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/accounts/{account}/external_accounts", http.MethodPost, map[string]string{
+			"default_for_currency": "boolean",
+			"external_account":     "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "delete", "/v1/accounts/{account}/external_accounts/{id}", http.MethodDelete, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/accounts/{account}/external_accounts", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/accounts/{account}/external_accounts/{id}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/accounts/{account}/external_accounts/{id}", http.MethodPost, map[string]string{
+			"account_holder_name":  "string",
+			"account_holder_type":  "string",
+			"account_type":         "string",
+			"address_city":         "string",
+			"address_country":      "string",
+			"address_line1":        "string",
+			"address_line2":        "string",
+			"address_state":        "string",
+			"address_zip":          "string",
+			"default_for_currency": "boolean",
+			"exp_month":            "string",
+			"exp_year":             "string",
+			"name":                 "string",
+		}, &Config)
+	})
+	rFeeRefundsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/application_fees/{id}/refunds", http.MethodPost, map[string]string{
+			"amount": "integer",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/application_fees/{id}/refunds", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/application_fees/{fee}/refunds/{id}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/application_fees/{fee}/refunds/{id}", http.MethodPost, map[string]string{}, &Config)
+	})
+	rFileLinksCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/file_links", http.MethodPost, map[string]string{
+			"expires_at": "integer",
+			"file":       "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/file_links", http.MethodGet, map[string]string{
+			"created":        "integer",
+			"ending_before":  "string",
+			"expired":        "boolean",
+			"file":           "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/file_links/{link}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/file_links/{link}", http.MethodPost, map[string]string{
+			"expires_at": "string",
+		}, &Config)
+	})
+	rFilesCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/files", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/files", http.MethodGet, map[string]string{
+			"created":        "integer",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"purpose":        "string",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/files/{file}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rInvoiceitemsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/invoiceitems", http.MethodPost, map[string]string{
+			"amount":              "integer",
+			"currency":            "string",
+			"customer":            "string",
+			"description":         "string",
+			"discountable":        "boolean",
+			"invoice":             "string",
+			"price":               "string",
+			"quantity":            "integer",
+			"subscription":        "string",
+			"unit_amount":         "integer",
+			"unit_amount_decimal": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "delete", "/v1/invoiceitems/{invoiceitem}", http.MethodDelete, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/invoiceitems", http.MethodGet, map[string]string{
+			"created":        "integer",
+			"customer":       "string",
+			"ending_before":  "string",
+			"invoice":        "string",
+			"limit":          "integer",
+			"pending":        "boolean",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/invoiceitems/{invoiceitem}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/invoiceitems/{invoiceitem}", http.MethodPost, map[string]string{
+			"amount":              "integer",
+			"description":         "string",
+			"discountable":        "boolean",
+			"price":               "string",
+			"quantity":            "integer",
+			"unit_amount":         "integer",
+			"unit_amount_decimal": "string",
+		}, &Config)
+	})
+	rInvoicesCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/invoices", http.MethodPost, map[string]string{
+			"application_fee_amount":         "integer",
+			"auto_advance":                   "boolean",
+			"collection_method":              "string",
+			"customer":                       "string",
+			"days_until_due":                 "integer",
+			"default_payment_method":         "string",
+			"default_source":                 "string",
+			"description":                    "string",
+			"due_date":                       "integer",
+			"footer":                         "string",
+			"on_behalf_of":                   "string",
+			"pending_invoice_items_behavior": "string",
+			"statement_descriptor":           "string",
+			"subscription":                   "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "delete", "/v1/invoices/{invoice}", http.MethodDelete, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "finalize_invoice", "/v1/invoices/{invoice}/finalize", http.MethodPost, map[string]string{
+			"auto_advance": "boolean",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/invoices", http.MethodGet, map[string]string{
+			"collection_method": "string",
+			"created":           "integer",
+			"customer":          "string",
+			"due_date":          "integer",
+			"ending_before":     "string",
+			"limit":             "integer",
+			"starting_after":    "string",
+			"status":            "string",
+			"subscription":      "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "mark_uncollectible", "/v1/invoices/{invoice}/mark_uncollectible", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "pay", "/v1/invoices/{invoice}/pay", http.MethodPost, map[string]string{
+			"forgive":          "boolean",
+			"off_session":      "boolean",
+			"paid_out_of_band": "boolean",
+			"payment_method":   "string",
+			"source":           "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/invoices/{invoice}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "search", "/v1/invoices/search", http.MethodGet, map[string]string{
+			"limit": "integer",
+			"page":  "string",
+			"query": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "send_invoice", "/v1/invoices/{invoice}/send", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "upcoming", "/v1/invoices/upcoming", http.MethodGet, map[string]string{
+			"coupon":                            "string",
+			"customer":                          "string",
+			"schedule":                          "string",
+			"subscription":                      "string",
+			"subscription_billing_cycle_anchor": "string",
+			"subscription_cancel_at":            "integer",
+			"subscription_cancel_at_period_end": "boolean",
+			"subscription_cancel_now":           "boolean",
+			"subscription_proration_behavior":   "string",
+			"subscription_proration_date":       "integer",
+			"subscription_start_date":           "integer",
+			"subscription_trial_end":            "string",
+			"subscription_trial_from_plan":      "boolean",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "upcomingLines", "/v1/invoices/upcoming/lines", http.MethodGet, map[string]string{
+			"coupon":                            "string",
+			"customer":                          "string",
+			"ending_before":                     "string",
+			"limit":                             "integer",
+			"schedule":                          "string",
+			"starting_after":                    "string",
+			"subscription":                      "string",
+			"subscription_billing_cycle_anchor": "string",
+			"subscription_cancel_at":            "integer",
+			"subscription_cancel_at_period_end": "boolean",
+			"subscription_cancel_now":           "boolean",
+			"subscription_proration_behavior":   "string",
+			"subscription_proration_date":       "integer",
+			"subscription_start_date":           "integer",
+			"subscription_trial_end":            "string",
+			"subscription_trial_from_plan":      "boolean",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/invoices/{invoice}", http.MethodPost, map[string]string{
+			"application_fee_amount": "integer",
+			"auto_advance":           "boolean",
+			"collection_method":      "string",
+			"days_until_due":         "integer",
+			"default_payment_method": "string",
+			"default_source":         "string",
+			"description":            "string",
+			"due_date":               "integer",
+			"footer":                 "string",
+			"on_behalf_of":           "string",
+			"statement_descriptor":   "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "void_invoice", "/v1/invoices/{invoice}/void", http.MethodPost, map[string]string{}, &Config)
+	})
+	rItemsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "list", "/v1/checkout/sessions/{session}/line_items", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+	})
+	rLineItemsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "list", "/v1/invoices/{invoice}/lines", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+	})
+	rLoginLinksCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/accounts/{account}/login_links", http.MethodPost, map[string]string{
+			"redirect_url": "string",
+		}, &Config)
+	})
+	rMandatesCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/mandates/{mandate}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rOrdersCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "cancel", "/v1/orders/{id}/cancel", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "create", "/v1/orders", http.MethodPost, map[string]string{
+			"currency":    "string",
+			"customer":    "string",
+			"description": "string",
+			"ip_address":  "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/orders", http.MethodGet, map[string]string{
+			"customer":       "string",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list_line_items", "/v1/orders/{id}/line_items", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "reopen", "/v1/orders/{id}/reopen", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/orders/{id}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "submit", "/v1/orders/{id}/submit", http.MethodPost, map[string]string{
+			"expected_total": "integer",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/orders/{id}", http.MethodPost, map[string]string{
+			"currency":    "string",
+			"customer":    "string",
+			"description": "string",
+			"ip_address":  "string",
+		}, &Config)
+	})
+	rPaymentIntentsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "apply_customer_balance", "/v1/payment_intents/{intent}/apply_customer_balance", http.MethodPost, map[string]string{
+			"amount":   "integer",
+			"currency": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "cancel", "/v1/payment_intents/{intent}/cancel", http.MethodPost, map[string]string{
+			"cancellation_reason": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "capture", "/v1/payment_intents/{intent}/capture", http.MethodPost, map[string]string{
+			"amount_to_capture":           "integer",
+			"application_fee_amount":      "integer",
+			"statement_descriptor":        "string",
+			"statement_descriptor_suffix": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "confirm", "/v1/payment_intents/{intent}/confirm", http.MethodPost, map[string]string{
+			"error_on_requires_action": "boolean",
+			"mandate":                  "string",
+			"off_session":              "boolean",
+			"payment_method":           "string",
+			"receipt_email":            "string",
+			"return_url":               "string",
+			"setup_future_usage":       "string",
+			"use_stripe_sdk":           "boolean",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "create", "/v1/payment_intents", http.MethodPost, map[string]string{
+			"amount":                      "integer",
+			"application_fee_amount":      "integer",
+			"capture_method":              "string",
+			"confirm":                     "boolean",
+			"confirmation_method":         "string",
+			"currency":                    "string",
+			"customer":                    "string",
+			"description":                 "string",
+			"error_on_requires_action":    "boolean",
+			"mandate":                     "string",
+			"off_session":                 "boolean",
+			"on_behalf_of":                "string",
+			"payment_method":              "string",
+			"receipt_email":               "string",
+			"return_url":                  "string",
+			"setup_future_usage":          "string",
+			"statement_descriptor":        "string",
+			"statement_descriptor_suffix": "string",
+			"transfer_group":              "string",
+			"use_stripe_sdk":              "boolean",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "increment_authorization", "/v1/payment_intents/{intent}/increment_authorization", http.MethodPost, map[string]string{
+			"amount":                 "integer",
+			"application_fee_amount": "integer",
+			"description":            "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/payment_intents", http.MethodGet, map[string]string{
+			"created":        "integer",
+			"customer":       "string",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/payment_intents/{intent}", http.MethodGet, map[string]string{
+			"client_secret": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "search", "/v1/payment_intents/search", http.MethodGet, map[string]string{
+			"limit": "integer",
+			"page":  "string",
+			"query": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/payment_intents/{intent}", http.MethodPost, map[string]string{
+			"amount":                      "integer",
+			"application_fee_amount":      "integer",
+			"currency":                    "string",
+			"customer":                    "string",
+			"description":                 "string",
+			"payment_method":              "string",
+			"receipt_email":               "string",
+			"setup_future_usage":          "string",
+			"statement_descriptor":        "string",
+			"statement_descriptor_suffix": "string",
+			"transfer_group":              "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "verify_microdeposits", "/v1/payment_intents/{intent}/verify_microdeposits", http.MethodPost, map[string]string{
+			"descriptor_code": "string",
+		}, &Config)
+	})
+	rPaymentLinksCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/payment_links", http.MethodPost, map[string]string{
+			"allow_promotion_codes":      "boolean",
+			"application_fee_amount":     "integer",
+			"application_fee_percent":    "number",
+			"billing_address_collection": "string",
+			"customer_creation":          "string",
+			"on_behalf_of":               "string",
+			"submit_type":                "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/payment_links", http.MethodGet, map[string]string{
+			"active":         "boolean",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list_line_items", "/v1/payment_links/{payment_link}/line_items", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/payment_links/{payment_link}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/payment_links/{payment_link}", http.MethodPost, map[string]string{
+			"active":                     "boolean",
+			"allow_promotion_codes":      "boolean",
+			"billing_address_collection": "string",
+			"customer_creation":          "string",
+		}, &Config)
+	})
+	rPaymentMethodsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "attach", "/v1/payment_methods/{payment_method}/attach", http.MethodPost, map[string]string{
+			"customer": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "create", "/v1/payment_methods", http.MethodPost, map[string]string{
+			"customer":       "string",
+			"payment_method": "string",
+			"type":           "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "detach", "/v1/payment_methods/{payment_method}/detach", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/payment_methods", http.MethodGet, map[string]string{
+			"customer":       "string",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+			"type":           "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/payment_methods/{payment_method}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/payment_methods/{payment_method}", http.MethodPost, map[string]string{}, &Config)
+	})
+	rPaymentSourcesCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/customers/{customer}/sources", http.MethodPost, map[string]string{
+			"source": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/customers/{customer}/sources", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"object":         "string",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/customers/{customer}/sources/{id}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rPayoutsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "cancel", "/v1/payouts/{payout}/cancel", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "create", "/v1/payouts", http.MethodPost, map[string]string{
+			"amount":               "integer",
+			"currency":             "string",
+			"description":          "string",
+			"destination":          "string",
+			"method":               "string",
+			"source_type":          "string",
+			"statement_descriptor": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/payouts", http.MethodGet, map[string]string{
+			"arrival_date":   "integer",
+			"created":        "integer",
+			"destination":    "string",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+			"status":         "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/payouts/{payout}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "reverse", "/v1/payouts/{payout}/reverse", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/payouts/{payout}", http.MethodPost, map[string]string{}, &Config)
+	})
+	rPersonsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/accounts/{account}/persons", http.MethodPost, map[string]string{
+			"email":               "string",
+			"first_name":          "string",
+			"first_name_kana":     "string",
+			"first_name_kanji":    "string",
+			"gender":              "string",
+			"id_number":           "string",
+			"id_number_secondary": "string",
+			"last_name":           "string",
+			"last_name_kana":      "string",
+			"last_name_kanji":     "string",
+			"maiden_name":         "string",
+			"nationality":         "string",
+			"person_token":        "string",
+			"phone":               "string",
+			"political_exposure":  "string",
+			"ssn_last_4":          "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "delete", "/v1/accounts/{account}/persons/{person}", http.MethodDelete, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/accounts/{account}/persons", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/accounts/{account}/persons/{person}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/accounts/{account}/persons/{person}", http.MethodPost, map[string]string{
+			"email":               "string",
+			"first_name":          "string",
+			"first_name_kana":     "string",
+			"first_name_kanji":    "string",
+			"gender":              "string",
+			"id_number":           "string",
+			"id_number_secondary": "string",
+			"last_name":           "string",
+			"last_name_kana":      "string",
+			"last_name_kanji":     "string",
+			"maiden_name":         "string",
+			"nationality":         "string",
+			"person_token":        "string",
+			"phone":               "string",
+			"political_exposure":  "string",
+			"ssn_last_4":          "string",
+		}, &Config)
+	})
+	rPlansCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/plans", http.MethodPost, map[string]string{
+			"active":            "boolean",
+			"aggregate_usage":   "string",
+			"amount":            "integer",
+			"amount_decimal":    "string",
+			"billing_scheme":    "string",
+			"currency":          "string",
+			"id":                "string",
+			"interval":          "string",
+			"interval_count":    "integer",
+			"nickname":          "string",
+			"product":           "string",
+			"tiers_mode":        "string",
+			"trial_period_days": "integer",
+			"usage_type":        "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "delete", "/v1/plans/{plan}", http.MethodDelete, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/plans", http.MethodGet, map[string]string{
+			"active":         "boolean",
+			"created":        "integer",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"product":        "string",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/plans/{plan}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/plans/{plan}", http.MethodPost, map[string]string{
+			"active":            "boolean",
+			"nickname":          "string",
+			"product":           "string",
+			"trial_period_days": "integer",
+		}, &Config)
+	})
+	rPricesCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/prices", http.MethodPost, map[string]string{
+			"active":              "boolean",
+			"billing_scheme":      "string",
+			"currency":            "string",
+			"lookup_key":          "string",
+			"nickname":            "string",
+			"product":             "string",
+			"tax_behavior":        "string",
+			"tiers_mode":          "string",
+			"transfer_lookup_key": "boolean",
+			"unit_amount":         "integer",
+			"unit_amount_decimal": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/prices", http.MethodGet, map[string]string{
+			"active":         "boolean",
+			"created":        "integer",
+			"currency":       "string",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"product":        "string",
+			"starting_after": "string",
+			"type":           "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/prices/{price}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "search", "/v1/prices/search", http.MethodGet, map[string]string{
+			"limit": "integer",
+			"page":  "string",
+			"query": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/prices/{price}", http.MethodPost, map[string]string{
+			"active":              "boolean",
+			"lookup_key":          "string",
+			"nickname":            "string",
+			"tax_behavior":        "string",
+			"transfer_lookup_key": "boolean",
+		}, &Config)
+	})
+	rProductsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/products", http.MethodPost, map[string]string{
+			"active":               "boolean",
+			"caption":              "string",
+			"description":          "string",
+			"id":                   "string",
+			"name":                 "string",
+			"shippable":            "boolean",
+			"statement_descriptor": "string",
+			"tax_code":             "string",
+			"type":                 "string",
+			"unit_label":           "string",
+			"url":                  "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "delete", "/v1/products/{id}", http.MethodDelete, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/products", http.MethodGet, map[string]string{
+			"active":         "boolean",
+			"created":        "integer",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"shippable":      "boolean",
+			"starting_after": "string",
+			"type":           "string",
+			"url":            "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/products/{id}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "search", "/v1/products/search", http.MethodGet, map[string]string{
+			"limit": "integer",
+			"page":  "string",
+			"query": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/products/{id}", http.MethodPost, map[string]string{
+			"active":               "boolean",
+			"caption":              "string",
+			"default_price":        "string",
+			"description":          "string",
+			"name":                 "string",
+			"shippable":            "boolean",
+			"statement_descriptor": "string",
+			"tax_code":             "string",
+			"unit_label":           "string",
+			"url":                  "string",
+		}, &Config)
+	})
+	rPromotionCodesCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/promotion_codes", http.MethodPost, map[string]string{
+			"active":          "boolean",
+			"code":            "string",
+			"coupon":          "string",
+			"customer":        "string",
+			"expires_at":      "integer",
+			"max_redemptions": "integer",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/promotion_codes", http.MethodGet, map[string]string{
+			"active":         "boolean",
+			"code":           "string",
+			"coupon":         "string",
+			"created":        "integer",
+			"customer":       "string",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/promotion_codes/{promotion_code}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/promotion_codes/{promotion_code}", http.MethodPost, map[string]string{
+			"active": "boolean",
+		}, &Config)
+	})
+	rQuotesCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "accept", "/v1/quotes/{quote}/accept", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "cancel", "/v1/quotes/{quote}/cancel", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "create", "/v1/quotes", http.MethodPost, map[string]string{
+			"application_fee_amount":  "integer",
+			"application_fee_percent": "number",
+			"collection_method":       "string",
+			"customer":                "string",
+			"description":             "string",
+			"expires_at":              "integer",
+			"footer":                  "string",
+			"header":                  "string",
+			"on_behalf_of":            "string",
+			"test_clock":              "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "finalize_quote", "/v1/quotes/{quote}/finalize", http.MethodPost, map[string]string{
+			"expires_at": "integer",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/quotes", http.MethodGet, map[string]string{
+			"customer":       "string",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+			"status":         "string",
+			"test_clock":     "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list_computed_upfront_line_items", "/v1/quotes/{quote}/computed_upfront_line_items", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list_line_items", "/v1/quotes/{quote}/line_items", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "pdf", "/v1/quotes/{quote}/pdf", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/quotes/{quote}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/quotes/{quote}", http.MethodPost, map[string]string{
+			"application_fee_amount":  "integer",
+			"application_fee_percent": "number",
+			"collection_method":       "string",
+			"customer":                "string",
+			"description":             "string",
+			"expires_at":              "integer",
+			"footer":                  "string",
+			"header":                  "string",
+			"on_behalf_of":            "string",
+		}, &Config)
+	})
+	rRefundsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "cancel", "/v1/refunds/{refund}/cancel", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "create", "/v1/refunds", http.MethodPost, map[string]string{
+			"amount":                 "integer",
+			"charge":                 "string",
+			"instructions_email":     "string",
+			"payment_intent":         "string",
+			"reason":                 "string",
+			"refund_application_fee": "boolean",
+			"reverse_transfer":       "boolean",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "expire", "/v1/test_helpers/refunds/{refund}/expire", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/refunds", http.MethodGet, map[string]string{
+			"charge":         "string",
+			"created":        "integer",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"payment_intent": "string",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/refunds/{refund}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/refunds/{refund}", http.MethodPost, map[string]string{}, &Config)
+	})
+	rReviewsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "approve", "/v1/reviews/{review}/approve", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/reviews", http.MethodGet, map[string]string{
+			"created":        "integer",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/reviews/{review}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rScheduledQueryRunsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "list", "/v1/sigma/scheduled_query_runs", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/sigma/scheduled_query_runs/{scheduled_query_run}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rSetupAttemptsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "list", "/v1/setup_attempts", http.MethodGet, map[string]string{
+			"created":        "integer",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"setup_intent":   "string",
+			"starting_after": "string",
+		}, &Config)
+	})
+	rSetupIntentsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "cancel", "/v1/setup_intents/{intent}/cancel", http.MethodPost, map[string]string{
+			"cancellation_reason": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "confirm", "/v1/setup_intents/{intent}/confirm", http.MethodPost, map[string]string{
+			"payment_method": "string",
+			"return_url":     "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "create", "/v1/setup_intents", http.MethodPost, map[string]string{
+			"confirm":        "boolean",
+			"customer":       "string",
+			"description":    "string",
+			"on_behalf_of":   "string",
+			"payment_method": "string",
+			"return_url":     "string",
+			"usage":          "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/setup_intents", http.MethodGet, map[string]string{
+			"created":        "integer",
+			"customer":       "string",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"payment_method": "string",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/setup_intents/{intent}", http.MethodGet, map[string]string{
+			"client_secret": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/setup_intents/{intent}", http.MethodPost, map[string]string{
+			"customer":       "string",
+			"description":    "string",
+			"payment_method": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "verify_microdeposits", "/v1/setup_intents/{intent}/verify_microdeposits", http.MethodPost, map[string]string{
+			"descriptor_code": "string",
+		}, &Config)
+	})
+	rShippingRatesCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/shipping_rates", http.MethodPost, map[string]string{
+			"display_name": "string",
+			"tax_behavior": "string",
+			"tax_code":     "string",
+			"type":         "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/shipping_rates", http.MethodGet, map[string]string{
+			"active":         "boolean",
+			"created":        "integer",
+			"currency":       "string",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/shipping_rates/{shipping_rate_token}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/shipping_rates/{shipping_rate_token}", http.MethodPost, map[string]string{
+			"active": "boolean",
+		}, &Config)
+	})
+	rSkusCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/skus", http.MethodPost, map[string]string{
+			"active":   "boolean",
+			"currency": "string",
+			"id":       "string",
+			"image":    "string",
+			"price":    "integer",
+			"product":  "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "delete", "/v1/skus/{id}", http.MethodDelete, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/skus", http.MethodGet, map[string]string{
+			"active":         "boolean",
+			"ending_before":  "string",
+			"in_stock":       "boolean",
+			"limit":          "integer",
+			"product":        "string",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/skus/{id}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/skus/{id}", http.MethodPost, map[string]string{
+			"active":   "boolean",
+			"currency": "string",
+			"image":    "string",
+			"price":    "integer",
+			"product":  "string",
+		}, &Config)
+	})
+	rSourcesCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/sources", http.MethodPost, map[string]string{
+			"amount":               "integer",
+			"currency":             "string",
+			"customer":             "string",
+			"flow":                 "string",
+			"original_source":      "string",
+			"statement_descriptor": "string",
+			"token":                "string",
+			"type":                 "string",
+			"usage":                "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "detach", "/v1/customers/{customer}/sources/{id}", http.MethodDelete, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/sources/{source}", http.MethodGet, map[string]string{
+			"client_secret": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "source_transactions", "/v1/sources/{source}/source_transactions", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/sources/{source}", http.MethodPost, map[string]string{
+			"amount": "integer",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "verify", "/v1/sources/{source}/verify", http.MethodPost, map[string]string{}, &Config)
+	})
+	rSubscriptionItemsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/subscription_items", http.MethodPost, map[string]string{
+			"payment_behavior":   "string",
+			"plan":               "string",
+			"price":              "string",
+			"proration_behavior": "string",
+			"proration_date":     "integer",
+			"quantity":           "integer",
+			"subscription":       "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "delete", "/v1/subscription_items/{item}", http.MethodDelete, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/subscription_items", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+			"subscription":   "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/subscription_items/{item}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/subscription_items/{item}", http.MethodPost, map[string]string{
+			"off_session":        "boolean",
+			"payment_behavior":   "string",
+			"plan":               "string",
+			"price":              "string",
+			"proration_behavior": "string",
+			"proration_date":     "integer",
+			"quantity":           "integer",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "usage_record_summaries", "/v1/subscription_items/{subscription_item}/usage_record_summaries", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+	})
+	rSubscriptionSchedulesCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "cancel", "/v1/subscription_schedules/{schedule}/cancel", http.MethodPost, map[string]string{
+			"invoice_now": "boolean",
+			"prorate":     "boolean",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "create", "/v1/subscription_schedules", http.MethodPost, map[string]string{
+			"customer":          "string",
+			"end_behavior":      "string",
+			"from_subscription": "string",
+			"start_date":        "integer",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/subscription_schedules", http.MethodGet, map[string]string{
+			"canceled_at":    "integer",
+			"completed_at":   "integer",
+			"created":        "integer",
+			"customer":       "string",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"released_at":    "integer",
+			"scheduled":      "boolean",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "release", "/v1/subscription_schedules/{schedule}/release", http.MethodPost, map[string]string{
+			"preserve_cancel_date": "boolean",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/subscription_schedules/{schedule}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/subscription_schedules/{schedule}", http.MethodPost, map[string]string{
+			"end_behavior":       "string",
+			"proration_behavior": "string",
+		}, &Config)
+	})
+	rSubscriptionsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "cancel", "/v1/subscriptions/{subscription_exposed_id}", http.MethodDelete, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "create", "/v1/subscriptions", http.MethodPost, map[string]string{
+			"application_fee_percent": "number",
+			"backdate_start_date":     "integer",
+			"billing_cycle_anchor":    "integer",
+			"cancel_at":               "integer",
+			"cancel_at_period_end":    "boolean",
+			"collection_method":       "string",
+			"coupon":                  "string",
+			"customer":                "string",
+			"days_until_due":          "integer",
+			"default_payment_method":  "string",
+			"default_source":          "string",
+			"description":             "string",
+			"off_session":             "boolean",
+			"payment_behavior":        "string",
+			"promotion_code":          "string",
+			"proration_behavior":      "string",
+			"trial_end":               "string",
+			"trial_from_plan":         "boolean",
+			"trial_period_days":       "integer",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "delete_discount", "/v1/subscriptions/{subscription_exposed_id}/discount", http.MethodDelete, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/subscriptions", http.MethodGet, map[string]string{
+			"collection_method":    "string",
+			"created":              "integer",
+			"current_period_end":   "integer",
+			"current_period_start": "integer",
+			"customer":             "string",
+			"ending_before":        "string",
+			"limit":                "integer",
+			"plan":                 "string",
+			"price":                "string",
+			"starting_after":       "string",
+			"status":               "string",
+			"test_clock":           "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/subscriptions/{subscription_exposed_id}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "search", "/v1/subscriptions/search", http.MethodGet, map[string]string{
+			"limit": "integer",
+			"page":  "string",
+			"query": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/subscriptions/{subscription_exposed_id}", http.MethodPost, map[string]string{
+			"application_fee_percent": "number",
+			"billing_cycle_anchor":    "string",
+			"cancel_at":               "integer",
+			"cancel_at_period_end":    "boolean",
+			"collection_method":       "string",
+			"coupon":                  "string",
+			"days_until_due":          "integer",
+			"default_payment_method":  "string",
+			"default_source":          "string",
+			"description":             "string",
+			"off_session":             "boolean",
+			"payment_behavior":        "string",
+			"promotion_code":          "string",
+			"proration_behavior":      "string",
+			"proration_date":          "integer",
+			"trial_end":               "string",
+			"trial_from_plan":         "boolean",
+		}, &Config)
+	})
+	rTaxCodesCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "list", "/v1/tax_codes", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/tax_codes/{id}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rTaxIdsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/customers/{customer}/tax_ids", http.MethodPost, map[string]string{
+			"type":  "string",
+			"value": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "delete", "/v1/customers/{customer}/tax_ids/{id}", http.MethodDelete, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/customers/{customer}/tax_ids", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/customers/{customer}/tax_ids/{id}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rTaxRatesCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/tax_rates", http.MethodPost, map[string]string{
+			"active":       "boolean",
+			"country":      "string",
+			"description":  "string",
+			"display_name": "string",
+			"inclusive":    "boolean",
+			"jurisdiction": "string",
+			"percentage":   "number",
+			"state":        "string",
+			"tax_type":     "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/tax_rates", http.MethodGet, map[string]string{
+			"active":         "boolean",
+			"created":        "integer",
+			"ending_before":  "string",
+			"inclusive":      "boolean",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/tax_rates/{tax_rate}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/tax_rates/{tax_rate}", http.MethodPost, map[string]string{
+			"active":       "boolean",
+			"country":      "string",
+			"description":  "string",
+			"display_name": "string",
+			"jurisdiction": "string",
+			"state":        "string",
+			"tax_type":     "string",
+		}, &Config)
+	})
+	rTokensCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/tokens", http.MethodPost, map[string]string{
+			"card":     "string",
+			"customer": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/tokens/{token}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rTopupsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "cancel", "/v1/topups/{topup}/cancel", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "create", "/v1/topups", http.MethodPost, map[string]string{
+			"amount":               "integer",
+			"currency":             "string",
+			"description":          "string",
+			"source":               "string",
+			"statement_descriptor": "string",
+			"transfer_group":       "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/topups", http.MethodGet, map[string]string{
+			"amount":         "integer",
+			"created":        "integer",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+			"status":         "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/topups/{topup}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/topups/{topup}", http.MethodPost, map[string]string{
+			"description": "string",
+		}, &Config)
+	})
+	rTransferReversalsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/transfers/{id}/reversals", http.MethodPost, map[string]string{
+			"amount":                 "integer",
+			"description":            "string",
+			"refund_application_fee": "boolean",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/transfers/{id}/reversals", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/transfers/{transfer}/reversals/{id}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/transfers/{transfer}/reversals/{id}", http.MethodPost, map[string]string{}, &Config)
+	})
+	rTransfersCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/transfers", http.MethodPost, map[string]string{
+			"amount":             "integer",
+			"currency":           "string",
+			"description":        "string",
+			"destination":        "string",
+			"source_transaction": "string",
+			"source_type":        "string",
+			"transfer_group":     "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/transfers", http.MethodGet, map[string]string{
+			"created":        "integer",
+			"destination":    "string",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+			"transfer_group": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/transfers/{transfer}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/transfers/{transfer}", http.MethodPost, map[string]string{
+			"description": "string",
+		}, &Config)
+	})
+	rUsageRecordSummariesCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "list", "/v1/subscription_items/{subscription_item}/usage_record_summaries", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+	})
+	rUsageRecordsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/subscription_items/{subscription_item}/usage_records", http.MethodPost, map[string]string{
+			"action":    "string",
+			"quantity":  "integer",
+			"timestamp": "string",
+		}, &Config)
+	})
+	rWebhookEndpointsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/webhook_endpoints", http.MethodPost, map[string]string{
+			"api_version": "string",
+			"connect":     "boolean",
+			"description": "string",
+			"url":         "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "delete", "/v1/webhook_endpoints/{webhook_endpoint}", http.MethodDelete, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/webhook_endpoints", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/webhook_endpoints/{webhook_endpoint}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/webhook_endpoints/{webhook_endpoint}", http.MethodPost, map[string]string{
+			"description": "string",
+			"disabled":    "boolean",
+			"url":         "string",
+		}, &Config)
+	})
+	rAppsSecretsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/apps/secrets", http.MethodPost, map[string]string{
+			"name":    "string",
+			"payload": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "delete_where", "/v1/apps/secrets/delete", http.MethodPost, map[string]string{
+			"name": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "find", "/v1/apps/secrets/find", http.MethodGet, map[string]string{
+			"name": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/apps/secrets", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+	})
+	rBillingPortalConfigurationsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/billing_portal/configurations", http.MethodPost, map[string]string{
+			"default_return_url": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/billing_portal/configurations", http.MethodGet, map[string]string{
+			"active":         "boolean",
+			"ending_before":  "string",
+			"is_default":     "boolean",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/billing_portal/configurations/{configuration}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/billing_portal/configurations/{configuration}", http.MethodPost, map[string]string{
+			"active":             "boolean",
+			"default_return_url": "string",
+		}, &Config)
+	})
+	rBillingPortalSessionsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/billing_portal/sessions", http.MethodPost, map[string]string{
+			"configuration": "string",
+			"customer":      "string",
+			"locale":        "string",
+			"on_behalf_of":  "string",
+			"return_url":    "string",
+		}, &Config)
+	})
+	rCheckoutSessionsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/checkout/sessions", http.MethodPost, map[string]string{
+			"allow_promotion_codes":      "boolean",
+			"billing_address_collection": "string",
+			"cancel_url":                 "string",
+			"client_reference_id":        "string",
+			"customer":                   "string",
+			"customer_creation":          "string",
+			"customer_email":             "string",
+			"expires_at":                 "integer",
+			"locale":                     "string",
+			"mode":                       "string",
+			"submit_type":                "string",
+			"success_url":                "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "expire", "/v1/checkout/sessions/{session}/expire", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/checkout/sessions", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"payment_intent": "string",
+			"starting_after": "string",
+			"subscription":   "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/checkout/sessions/{session}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rFinancialConnectionsAccountsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "disconnect", "/v1/financial_connections/accounts/{account}/disconnect", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/financial_connections/accounts", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"session":        "string",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list_owners", "/v1/financial_connections/accounts/{account}/owners", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"ownership":      "string",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "refresh", "/v1/financial_connections/accounts/{account}/refresh", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/financial_connections/accounts/{account}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rFinancialConnectionsSessionsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/financial_connections/sessions", http.MethodPost, map[string]string{
+			"return_url": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/financial_connections/sessions/{session}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rIdentityVerificationReportsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "list", "/v1/identity/verification_reports", http.MethodGet, map[string]string{
+			"created":              "integer",
+			"ending_before":        "string",
+			"limit":                "integer",
+			"starting_after":       "string",
+			"type":                 "string",
+			"verification_session": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/identity/verification_reports/{report}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rIdentityVerificationSessionsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "cancel", "/v1/identity/verification_sessions/{session}/cancel", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "create", "/v1/identity/verification_sessions", http.MethodPost, map[string]string{
+			"return_url": "string",
+			"type":       "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/identity/verification_sessions", http.MethodGet, map[string]string{
+			"created":        "integer",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+			"status":         "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "redact", "/v1/identity/verification_sessions/{session}/redact", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/identity/verification_sessions/{session}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/identity/verification_sessions/{session}", http.MethodPost, map[string]string{
+			"type": "string",
+		}, &Config)
+	})
+	rIssuingAuthorizationsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "approve", "/v1/issuing/authorizations/{authorization}/approve", http.MethodPost, map[string]string{
+			"amount": "integer",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "decline", "/v1/issuing/authorizations/{authorization}/decline", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/issuing/authorizations", http.MethodGet, map[string]string{
+			"card":           "string",
+			"cardholder":     "string",
+			"created":        "integer",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+			"status":         "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/issuing/authorizations/{authorization}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/issuing/authorizations/{authorization}", http.MethodPost, map[string]string{}, &Config)
+	})
+	rIssuingCardholdersCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/issuing/cardholders", http.MethodPost, map[string]string{
+			"email":        "string",
+			"name":         "string",
+			"phone_number": "string",
+			"status":       "string",
+			"type":         "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/issuing/cardholders", http.MethodGet, map[string]string{
+			"created":        "integer",
+			"email":          "string",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"phone_number":   "string",
+			"starting_after": "string",
+			"status":         "string",
+			"type":           "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/issuing/cardholders/{cardholder}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/issuing/cardholders/{cardholder}", http.MethodPost, map[string]string{
+			"email":        "string",
+			"phone_number": "string",
+			"status":       "string",
+		}, &Config)
+	})
+	rIssuingCardsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/issuing/cards", http.MethodPost, map[string]string{
+			"cardholder":         "string",
+			"currency":           "string",
+			"financial_account":  "string",
+			"replacement_for":    "string",
+			"replacement_reason": "string",
+			"status":             "string",
+			"type":               "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/issuing/cards", http.MethodGet, map[string]string{
+			"cardholder":     "string",
+			"created":        "integer",
+			"ending_before":  "string",
+			"exp_month":      "integer",
+			"exp_year":       "integer",
+			"last4":          "string",
+			"limit":          "integer",
+			"starting_after": "string",
+			"status":         "string",
+			"type":           "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/issuing/cards/{card}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/issuing/cards/{card}", http.MethodPost, map[string]string{
+			"cancellation_reason": "string",
+			"status":              "string",
+		}, &Config)
+	})
+	rIssuingDisputesCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/issuing/disputes", http.MethodPost, map[string]string{
+			"transaction": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/issuing/disputes", http.MethodGet, map[string]string{
+			"created":        "integer",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+			"status":         "string",
+			"transaction":    "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/issuing/disputes/{dispute}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "submit", "/v1/issuing/disputes/{dispute}/submit", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/issuing/disputes/{dispute}", http.MethodPost, map[string]string{}, &Config)
+	})
+	rIssuingTransactionsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "list", "/v1/issuing/transactions", http.MethodGet, map[string]string{
+			"card":           "string",
+			"cardholder":     "string",
+			"created":        "integer",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+			"type":           "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/issuing/transactions/{transaction}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/issuing/transactions/{transaction}", http.MethodPost, map[string]string{}, &Config)
+	})
+	rRadarEarlyFraudWarningsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "list", "/v1/radar/early_fraud_warnings", http.MethodGet, map[string]string{
+			"charge":         "string",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"payment_intent": "string",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/radar/early_fraud_warnings/{early_fraud_warning}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rRadarValueListItemsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/radar/value_list_items", http.MethodPost, map[string]string{
+			"value":      "string",
+			"value_list": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "delete", "/v1/radar/value_list_items/{item}", http.MethodDelete, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/radar/value_list_items", http.MethodGet, map[string]string{
+			"created":        "integer",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+			"value":          "string",
+			"value_list":     "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/radar/value_list_items/{item}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rRadarValueListsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/radar/value_lists", http.MethodPost, map[string]string{
+			"alias":     "string",
+			"item_type": "string",
+			"name":      "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "delete", "/v1/radar/value_lists/{value_list}", http.MethodDelete, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/radar/value_lists", http.MethodGet, map[string]string{
+			"alias":          "string",
+			"contains":       "string",
+			"created":        "integer",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/radar/value_lists/{value_list}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/radar/value_lists/{value_list}", http.MethodPost, map[string]string{
+			"alias": "string",
+			"name":  "string",
+		}, &Config)
+	})
+	rReportingReportRunsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/reporting/report_runs", http.MethodPost, map[string]string{
+			"report_type": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/reporting/report_runs", http.MethodGet, map[string]string{
+			"created":        "integer",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/reporting/report_runs/{report_run}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rReportingReportTypesCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "list", "/v1/reporting/report_types", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/reporting/report_types/{report_type}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rTerminalConfigurationsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/terminal/configurations", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "delete", "/v1/terminal/configurations/{configuration}", http.MethodDelete, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/terminal/configurations", http.MethodGet, map[string]string{
+			"ending_before":      "string",
+			"is_account_default": "boolean",
+			"limit":              "integer",
+			"starting_after":     "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/terminal/configurations/{configuration}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/terminal/configurations/{configuration}", http.MethodPost, map[string]string{}, &Config)
+	})
+	rTerminalConnectionTokensCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/terminal/connection_tokens", http.MethodPost, map[string]string{
+			"location": "string",
+		}, &Config)
+	})
+	rTerminalLocationsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/terminal/locations", http.MethodPost, map[string]string{
+			"configuration_overrides": "string",
+			"display_name":            "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "delete", "/v1/terminal/locations/{location}", http.MethodDelete, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/terminal/locations", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/terminal/locations/{location}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/terminal/locations/{location}", http.MethodPost, map[string]string{
+			"configuration_overrides": "string",
+			"display_name":            "string",
+		}, &Config)
+	})
+	rTerminalReadersCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "cancel_action", "/v1/terminal/readers/{reader}/cancel_action", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "create", "/v1/terminal/readers", http.MethodPost, map[string]string{
+			"label":             "string",
+			"location":          "string",
+			"registration_code": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "delete", "/v1/terminal/readers/{reader}", http.MethodDelete, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/terminal/readers", http.MethodGet, map[string]string{
+			"device_type":    "string",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"location":       "string",
+			"starting_after": "string",
+			"status":         "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "present_payment_method", "/v1/test_helpers/terminal/readers/{reader}/present_payment_method", http.MethodPost, map[string]string{
+			"type": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "process_payment_intent", "/v1/terminal/readers/{reader}/process_payment_intent", http.MethodPost, map[string]string{
+			"payment_intent": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "process_setup_intent", "/v1/terminal/readers/{reader}/process_setup_intent", http.MethodPost, map[string]string{
+			"customer_consent_collected": "boolean",
+			"setup_intent":               "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/terminal/readers/{reader}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "set_reader_display", "/v1/terminal/readers/{reader}/set_reader_display", http.MethodPost, map[string]string{
+			"type": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/terminal/readers/{reader}", http.MethodPost, map[string]string{
+			"label": "string",
+		}, &Config)
+	})
+	rTestHelpersTestClocksCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "advance", "/v1/test_helpers/test_clocks/{test_clock}/advance", http.MethodPost, map[string]string{
+			"frozen_time": "integer",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "create", "/v1/test_helpers/test_clocks", http.MethodPost, map[string]string{
+			"frozen_time": "integer",
+			"name":        "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "delete", "/v1/test_helpers/test_clocks/{test_clock}", http.MethodDelete, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/test_helpers/test_clocks", http.MethodGet, map[string]string{
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/test_helpers/test_clocks/{test_clock}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rTreasuryCreditReversalsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/treasury/credit_reversals", http.MethodPost, map[string]string{
+			"received_credit": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/treasury/credit_reversals", http.MethodGet, map[string]string{
+			"ending_before":     "string",
+			"financial_account": "string",
+			"limit":             "integer",
+			"received_credit":   "string",
+			"starting_after":    "string",
+			"status":            "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/treasury/credit_reversals/{credit_reversal}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rTreasuryDebitReversalsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/treasury/debit_reversals", http.MethodPost, map[string]string{
+			"received_debit": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/treasury/debit_reversals", http.MethodGet, map[string]string{
+			"ending_before":     "string",
+			"financial_account": "string",
+			"limit":             "integer",
+			"received_debit":    "string",
+			"resolution":        "string",
+			"starting_after":    "string",
+			"status":            "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/treasury/debit_reversals/{debit_reversal}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rTreasuryFinancialAccountsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/treasury/financial_accounts", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/treasury/financial_accounts", http.MethodGet, map[string]string{
+			"created":        "integer",
+			"ending_before":  "string",
+			"limit":          "integer",
+			"starting_after": "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/treasury/financial_accounts/{financial_account}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve_features", "/v1/treasury/financial_accounts/{financial_account}/features", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update", "/v1/treasury/financial_accounts/{financial_account}", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "update_features", "/v1/treasury/financial_accounts/{financial_account}/features", http.MethodPost, map[string]string{}, &Config)
+	})
+	rTreasuryInboundTransfersCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "cancel", "/v1/treasury/inbound_transfers/{inbound_transfer}/cancel", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "create", "/v1/treasury/inbound_transfers", http.MethodPost, map[string]string{
+			"amount":                "integer",
+			"currency":              "string",
+			"description":           "string",
+			"financial_account":     "string",
+			"origin_payment_method": "string",
+			"statement_descriptor":  "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "fail", "/v1/test_helpers/treasury/inbound_transfers/{id}/fail", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/treasury/inbound_transfers", http.MethodGet, map[string]string{
+			"ending_before":     "string",
+			"financial_account": "string",
+			"limit":             "integer",
+			"starting_after":    "string",
+			"status":            "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/treasury/inbound_transfers/{id}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "return_inbound_transfer", "/v1/test_helpers/treasury/inbound_transfers/{id}/return", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "succeed", "/v1/test_helpers/treasury/inbound_transfers/{id}/succeed", http.MethodPost, map[string]string{}, &Config)
+	})
+	rTreasuryOutboundPaymentsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "cancel", "/v1/treasury/outbound_payments/{id}/cancel", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "create", "/v1/treasury/outbound_payments", http.MethodPost, map[string]string{
+			"amount":                     "integer",
+			"currency":                   "string",
+			"customer":                   "string",
+			"description":                "string",
+			"destination_payment_method": "string",
+			"financial_account":          "string",
+			"statement_descriptor":       "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "fail", "/v1/test_helpers/treasury/outbound_payments/{id}/fail", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/treasury/outbound_payments", http.MethodGet, map[string]string{
+			"customer":          "string",
+			"ending_before":     "string",
+			"financial_account": "string",
+			"limit":             "integer",
+			"starting_after":    "string",
+			"status":            "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "post", "/v1/test_helpers/treasury/outbound_payments/{id}/post", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/treasury/outbound_payments/{id}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "return_outbound_payment", "/v1/test_helpers/treasury/outbound_payments/{id}/return", http.MethodPost, map[string]string{}, &Config)
+	})
+	rTreasuryOutboundTransfersCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "cancel", "/v1/treasury/outbound_transfers/{outbound_transfer}/cancel", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "create", "/v1/treasury/outbound_transfers", http.MethodPost, map[string]string{
+			"amount":                     "integer",
+			"currency":                   "string",
+			"description":                "string",
+			"destination_payment_method": "string",
+			"financial_account":          "string",
+			"statement_descriptor":       "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "fail", "/v1/test_helpers/treasury/outbound_transfers/{outbound_transfer}/fail", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/treasury/outbound_transfers", http.MethodGet, map[string]string{
+			"ending_before":     "string",
+			"financial_account": "string",
+			"limit":             "integer",
+			"starting_after":    "string",
+			"status":            "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "post", "/v1/test_helpers/treasury/outbound_transfers/{outbound_transfer}/post", http.MethodPost, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/treasury/outbound_transfers/{outbound_transfer}", http.MethodGet, map[string]string{}, &Config)
+		resource.NewOperationCmd(parentCmd, "return_outbound_transfer", "/v1/test_helpers/treasury/outbound_transfers/{outbound_transfer}/return", http.MethodPost, map[string]string{}, &Config)
+	})
+	rTreasuryReceivedCreditsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/test_helpers/treasury/received_credits", http.MethodPost, map[string]string{
+			"amount":            "integer",
+			"currency":          "string",
+			"description":       "string",
+			"financial_account": "string",
+			"network":           "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/treasury/received_credits", http.MethodGet, map[string]string{
+			"ending_before":     "string",
+			"financial_account": "string",
+			"limit":             "integer",
+			"starting_after":    "string",
+			"status":            "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/treasury/received_credits/{id}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rTreasuryReceivedDebitsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "create", "/v1/test_helpers/treasury/received_debits", http.MethodPost, map[string]string{
+			"amount":            "integer",
+			"currency":          "string",
+			"description":       "string",
+			"financial_account": "string",
+			"network":           "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "list", "/v1/treasury/received_debits", http.MethodGet, map[string]string{
+			"ending_before":     "string",
+			"financial_account": "string",
+			"limit":             "integer",
+			"starting_after":    "string",
+			"status":            "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/treasury/received_debits/{id}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rTreasuryTransactionEntrysCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "list", "/v1/treasury/transaction_entries", http.MethodGet, map[string]string{
+			"created":           "integer",
+			"effective_at":      "integer",
+			"ending_before":     "string",
+			"financial_account": "string",
+			"limit":             "integer",
+			"order_by":          "string",
+			"starting_after":    "string",
+			"transaction":       "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/treasury/transaction_entries/{id}", http.MethodGet, map[string]string{}, &Config)
+	})
+	rTreasuryTransactionsCmd.SetOperations(func(parentCmd *cobra.Command) {
+		resource.NewOperationCmd(parentCmd, "list", "/v1/treasury/transactions", http.MethodGet, map[string]string{
+			"created":           "integer",
+			"ending_before":     "string",
+			"financial_account": "string",
+			"limit":             "integer",
+			"order_by":          "string",
+			"starting_after":    "string",
+			"status":            "string",
+		}, &Config)
+		resource.NewOperationCmd(parentCmd, "retrieve", "/v1/treasury/transactions/{id}", http.MethodGet, map[string]string{}, &Config)
+	})
 }
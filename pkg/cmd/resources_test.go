@@ -1,9 +1,15 @@
 package cmd
 
 import (
+	"encoding/json"
+	"os"
 	"testing"
 
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/config"
 )
 
 func TestResources(t *testing.T) {
@@ -12,3 +18,60 @@ func TestResources(t *testing.T) {
 	require.Contains(t, output, "Available commands:")
 	require.NoError(t, err)
 }
+
+func TestResourcesUpdate(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "spec3.sdk.json", []byte(`{
+  "info": {"version": "2024-06-20"},
+  "components": {"schemas": {
+    "billing.meter_event": {"x-stripeOperations": [
+      {"method_name": "create", "method_on": "service", "operation": "post", "path": "/v2/billing/meter_events"}
+    ]}
+  }},
+  "paths": {
+    "/v2/billing/meter_events": {"post": {"requestBody": {"content": {"application/json": {"schema": {
+      "properties": {"event_name": {"type": "string"}}
+    }}}}}}
+  }
+}`), 0644))
+
+	rc := newResourcesCmd()
+	rc.fs = fs
+	rc.spec = "spec3.sdk.json"
+	root := &cobra.Command{Use: "stripe", Annotations: make(map[string]string)}
+	root.AddCommand(rc.cmd)
+
+	require.NoError(t, rc.runUpdateCmd(rc.cmd, []string{}))
+
+	path := resourcesCachePath()
+	data, err := afero.ReadFile(fs, path)
+	require.NoError(t, err)
+
+	var cached cachedResources
+	require.NoError(t, json.Unmarshal(data, &cached))
+	require.Equal(t, "2024-06-20", cached.APIVersion)
+	require.Equal(t, "/v2/billing/meter_events", cached.Namespaces["billing"].Resources["meter_events"].Operations["create"].Path)
+
+	addCachedResourcesCmds(fs, root, &config.Config{}, path)
+	cmd, _, err := root.Find([]string{"billing", "meter_events"})
+	require.NoError(t, err)
+	require.Equal(t, "meter_events", cmd.Name())
+
+	rc.reset = true
+	require.NoError(t, rc.runUpdateCmd(rc.cmd, []string{}))
+	_, err = fs.Stat(path)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestAddCachedResourcesCmdsCorrupt(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "resources.json", []byte(`{"namespaces":`), 0644))
+
+	root := &cobra.Command{Use: "stripe", Annotations: make(map[string]string)}
+	addCachedResourcesCmds(fs, root, &config.Config{}, "resources.json")
+	addCachedResourcesCmds(fs, root, &config.Config{}, "missing.json")
+
+	require.Empty(t, root.Commands())
+}
@@ -1,13 +1,16 @@
 //go:generate go run ../gen/gen_resources_cmds.go
 //go:generate go run ../gen/gen_events_list.go
+//go:generate go run -tags docs ../gen/gen_docs.go
 
 package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 	"unicode"
 
 	log "github.com/sirupsen/logrus"
@@ -18,11 +21,11 @@ import (
 	"github.com/stripe/stripe-cli/pkg/cmd/resource"
 	"github.com/stripe/stripe-cli/pkg/config"
 	"github.com/stripe/stripe-cli/pkg/login"
+	"github.com/stripe/stripe-cli/pkg/netproxy"
 	"github.com/stripe/stripe-cli/pkg/plugins"
 	"github.com/stripe/stripe-cli/pkg/requests"
 	"github.com/stripe/stripe-cli/pkg/stripe"
 	"github.com/stripe/stripe-cli/pkg/useragent"
-	"github.com/stripe/stripe-cli/pkg/validators"
 	"github.com/stripe/stripe-cli/pkg/version"
 )
 
@@ -42,6 +45,7 @@ var rootCmd = &cobra.Command{
 		"delete":    "http",
 		"trigger":   "webhooks",
 		"listen":    "webhooks",
+		"webhooks":  "webhooks",
 		"logs":      "stripe",
 		"status":    "stripe",
 		"resources": "resources",
@@ -52,7 +56,53 @@ var rootCmd = &cobra.Command{
 %s`,
 		getLogin(&fs, &Config),
 	),
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateErrorFormat(errorFormat(Config.ErrorFormat, nil)); err != nil {
+			return err
+		}
+		if Config.MaxRPS < 0 {
+			return usageError{err: fmt.Errorf("--max-rps must be at least 0")}
+		}
+		resolver, err := newResolver(&Config)
+		if err != nil {
+			return usageError{err: err}
+		}
+		netproxy.SetResolver(resolver)
+
+		applied, err := applyFlagDefaults(cmd, &Config.Profile)
+		if err != nil {
+			return err
+		}
+
+		// The global flags like --color are read when the config is
+		// initialized, so it's initialized again with their defaults
+		for _, name := range applied {
+			if cmd.Root().PersistentFlags().Lookup(name) != nil {
+				Config.InitConfig()
+				break
+			}
+		}
+
+		stripe.SetTelemetryEnabled(Config.TelemetryEnabled())
+		stripe.SetReadOnly(Config.ReadOnly || Config.Profile.GetReadOnly())
+		stripe.SetLivePolicy(&stripe.LivePolicy{
+			Confirmed: Config.ConfirmLive,
+			Allowlist: Config.Profile.GetLiveAllowlist(),
+		})
+
+		requestLog, err := auditLog(fs, cmd, &Config)
+		if err != nil {
+			return err
+		}
+		stripe.SetAuditLog(requestLog)
+		stripe.SetRateLimiter(stripe.NewRateLimiter(Config.MaxRPS))
+
+		if Config.Mock {
+			if err := useMock(cmd); err != nil {
+				return err
+			}
+		}
+
 		// if getting the config errors, don't fail running the command
 		merchant, _ := Config.Profile.GetAccountID()
 		telemetryMetadata := stripe.GetEventMetadata(cmd.Context())
@@ -60,11 +110,20 @@ var rootCmd = &cobra.Command{
 		telemetryMetadata.SetMerchant(merchant)
 		telemetryMetadata.SetUserAgent(useragent.GetEncodedUserAgent())
 
+		span := stripe.SpanFromContext(cmd.Context())
+		span.SetName(cmd.CommandPath())
+		span.SetAttribute("stripe.command", cmd.CommandPath())
+
+		recordUsage(cmd, &Config)
+		warnKeyExpiration(os.Stderr, cmd, &Config.Profile, time.Now())
+
 		// plugins send their own telemetry due to having richer context than the CLI does
 		if !plugins.IsPluginCommand(cmd) {
 			// record command invocation
 			sendCommandInvocationEvent(cmd.Context())
 		}
+
+		return nil
 	},
 }
 
@@ -88,23 +147,72 @@ func showSuggestion() {
 		os.Args[1], rootCmd.CommandPath(), suggStr))
 }
 
+// newResolver returns the resolver of the connections to Stripe, with the
+// --resolve overrides and the IP version of --ipv4 or --ipv6
+func newResolver(cfg *config.Config) (*netproxy.Resolver, error) {
+	overrides, err := netproxy.ParseResolveOverrides(cfg.Resolve)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := &netproxy.Resolver{Overrides: overrides}
+
+	switch {
+	case cfg.IPv4 && cfg.IPv6:
+		return nil, fmt.Errorf("--ipv4 and --ipv6 cannot be used together")
+	case cfg.IPv4:
+		resolver.IPVersion = netproxy.IPv4
+	case cfg.IPv6:
+		resolver.IPVersion = netproxy.IPv6
+	}
+
+	return resolver, nil
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute(ctx context.Context) {
 	telemetryMetadata := stripe.NewEventMetadata()
 	updatedCtx := stripe.WithEventMetadata(ctx, telemetryMetadata)
 
+	if err := stripe.EnableTracing(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	updatedCtx, span := stripe.StartSpan(updatedCtx, "stripe", stripe.SpanKindInternal)
+
 	rootCmd.SetUsageTemplate(getUsageTemplate())
+	rootCmd.SetFlagErrorFunc(flagUsageError)
 	rootCmd.SetVersionTemplate(version.Template)
-	if err := rootCmd.ExecuteContext(updatedCtx); err != nil {
-		errString := err.Error()
+	resource.LoadOperationsForArgs(rootCmd, os.Args[1:])
+	err := rootCmd.ExecuteContext(updatedCtx)
 
-		isLoginRequiredError := errString == validators.ErrAPIKeyNotConfigured.Error() || errString == validators.ErrDeviceNameNotConfigured.Error()
+	span.SetError(err)
+	span.End()
+
+	// The spans are exported even if the command was interrupted
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	stripe.ShutdownTracing(shutdownCtx)
+	cancel()
+
+	if err != nil {
+		described := describeError(err)
+
+		if errorFormat(Config.ErrorFormat, os.Args[1:]) == errorFormatJSON {
+			printJSONError(os.Stderr, described)
+			os.Exit(described.ExitCode)
+		}
+
+		errString := err.Error()
 
 		switch {
+		case errorWasPrinted(err):
+			// The error was printed already, e.g. as the response of a request
+
 		case requests.IsAPIKeyExpiredError(err):
 			fmt.Fprintln(os.Stderr, "The API key provided has expired. Obtain a new key from the Dashboard or run `stripe login` and try again.")
-		case isLoginRequiredError:
+		case isLoginRequiredError(err) && config.IsStateless():
+			fmt.Fprintf(os.Stderr, "%s. Set STRIPE_API_KEY, since `stripe login` can't store keys when %s is set.\n", errString, config.StatelessEnv)
+		case isLoginRequiredError(err):
 			// capitalize first letter of error because linter
 			errRunes := []rune(errString)
 			errRunes[0] = unicode.ToUpper(errRunes[0])
@@ -117,6 +225,9 @@ func Execute(ctx context.Context) {
 				fmt.Println(err)
 			}
 
+		case errors.Is(err, errStatusNotUp):
+			// The status was printed already
+
 		case strings.Contains(errString, "unknown command"):
 			showSuggestion()
 
@@ -124,7 +235,7 @@ func Execute(ctx context.Context) {
 			fmt.Println(err)
 		}
 
-		os.Exit(1)
+		os.Exit(described.ExitCode)
 	} else {
 		userInput := os.Args[1:]
 		// --color on/off/auto
@@ -135,43 +246,74 @@ func Execute(ctx context.Context) {
 }
 
 func init() {
-	cobra.OnInitialize(Config.InitConfig)
+	cobra.OnInitialize(func() {
+		Config.ProfileNameSet = rootCmd.PersistentFlags().Changed("project-name")
+	}, Config.InitConfig)
 
 	rootCmd.PersistentFlags().StringVar(&Config.Profile.APIKey, "api-key", "", "Your API key to use for the command")
-	rootCmd.PersistentFlags().StringVar(&Config.Color, "color", "", "turn on/off color output (on, off, auto)")
+	rootCmd.PersistentFlags().StringVar(&Config.Color, "color", "", "turn on/off color output (always, never, auto), NO_COLOR also turns it off")
+	rootCmd.PersistentFlags().BoolVar(&Config.ConfirmLive, "confirm-live", false, "Skip the confirmation prompt for API requests that could modify live mode data")
 	rootCmd.PersistentFlags().StringVar(&Config.ProfilesFile, "config", "", "config file (default is $HOME/.config/stripe/config.toml)")
 	rootCmd.PersistentFlags().StringVar(&Config.Profile.DeviceName, "device-name", "", "device name")
+	rootCmd.PersistentFlags().StringVar(&Config.ErrorFormat, "error-format", "", "Print errors in this format, either text or json, in which case they go to stderr (default: text)")
+	rootCmd.PersistentFlags().BoolVar(&Config.IPv4, "ipv4", false, "Only connect to Stripe with IPv4")
+	rootCmd.PersistentFlags().BoolVar(&Config.IPv6, "ipv6", false, "Only connect to Stripe with IPv6")
 	rootCmd.PersistentFlags().StringVar(&Config.LogLevel, "log-level", "info", "log level (debug, info, trace, warn, error)")
+	rootCmd.PersistentFlags().Float64Var(&Config.MaxRPS, "max-rps", stripe.DefaultMaxRPS, "Maximum number of API requests sent per second, shared by the requests sent in parallel, or 0 for no limit. Rate limited requests are retried after a delay either way")
+	rootCmd.PersistentFlags().BoolVar(&Config.Mock, "mock", false, "Send API requests to the stripe-mock server started with `stripe mock start` instead of Stripe")
+	rootCmd.PersistentFlags().BoolVar(&Config.ReadOnly, "read-only", false, "Refuse to send API requests that could modify data, i.e. anything other than GET (default: the profile's \"read_only\" setting)")
+	rootCmd.PersistentFlags().StringArrayVar(&Config.Resolve, "resolve", []string{}, "Connect to a host and port at another IP address, like api.stripe.com:443:10.0.0.5 (repeatable)")
 	rootCmd.PersistentFlags().StringVarP(&Config.Profile.ProfileName, "project-name", "p", "default", "the project name to read from for config")
 	rootCmd.Flags().BoolP("version", "v", false, "Get the version of the Stripe CLI")
 
 	viper.BindPFlag("color", rootCmd.PersistentFlags().Lookup("color"))
 
+	rootCmd.AddCommand(newAccountCmd(&Config).cmd)
+	rootCmd.AddCommand(newAuditCmd(&Config).cmd)
+	rootCmd.AddCommand(newBetasCmd(&Config).cmd)
+	rootCmd.AddCommand(newBrowseCmd(&Config).cmd)
+	rootCmd.AddCommand(newChaosCmd().cmd)
+	rootCmd.AddCommand(newChecklistCmd(&Config).cmd)
 	rootCmd.AddCommand(newCompletionCmd().cmd)
 	rootCmd.AddCommand(newConfigCmd().cmd)
 	rootCmd.AddCommand(newDaemonCmd(&Config).cmd)
+	rootCmd.AddCommand(newDashboardCmd(&Config).cmd)
 	rootCmd.AddCommand(newDeleteCmd().reqs.Cmd)
+	rootCmd.AddCommand(newDocsCmd().cmd)
 	rootCmd.AddCommand(newFeedbackdCmd().cmd)
 	rootCmd.AddCommand(newFixturesCmd(&Config).Cmd)
+	rootCmd.AddCommand(newGenerateCmd().cmd)
 	rootCmd.AddCommand(newGetCmd().reqs.Cmd)
+	rootCmd.AddCommand(newImportCmd(&Config).cmd)
 	rootCmd.AddCommand(newListenCmd().cmd)
 	rootCmd.AddCommand(newLoginCmd().cmd)
 	rootCmd.AddCommand(newLogoutCmd().cmd)
 	rootCmd.AddCommand(newLogsCmd(&Config).Cmd)
+	rootCmd.AddCommand(newMockCmd(&Config).cmd)
 	rootCmd.AddCommand(newOpenCmd().cmd)
 	rootCmd.AddCommand(newPostCmd().reqs.Cmd)
+	rootCmd.AddCommand(newProfileCmd(&Config).cmd)
 	rootCmd.AddCommand(newResourcesCmd().cmd)
 	rootCmd.AddCommand(newSamplesCmd().cmd)
 	rootCmd.AddCommand(newServeCmd().cmd)
 	rootCmd.AddCommand(newStatusCmd().cmd)
+	rootCmd.AddCommand(newSwitchCmd(&Config).cmd)
+	rootCmd.AddCommand(newTelemetryCmd(&Config).cmd)
+	rootCmd.AddCommand(newTestClocksCmd(&Config).cmd)
+	rootCmd.AddCommand(newThreedsCmd(&Config).cmd)
 	rootCmd.AddCommand(newTriggerCmd().cmd)
+	rootCmd.AddCommand(newUpdateCmd().cmd)
 	rootCmd.AddCommand(newVersionCmd().cmd)
+	rootCmd.AddCommand(newVersionsCmd().cmd)
+	rootCmd.AddCommand(newWebhooksCmd().cmd)
+	rootCmd.AddCommand(newWhoamiCmd(&Config).cmd)
 	rootCmd.AddCommand(newPlaybackCmd().cmd)
 	rootCmd.AddCommand(newPostinstallCmd(&Config).cmd)
 	rootCmd.AddCommand(newCommunityCmd().cmd)
 	rootCmd.AddCommand(newPluginCmd().cmd)
 
 	addAllResourcesCmds(rootCmd)
+	addCachedResourcesCmds(afero.NewOsFs(), rootCmd, &Config, resourcesCachePath())
 
 	err := resource.AddEventsSubCmds(rootCmd, &Config)
 	if err != nil {
@@ -183,11 +325,36 @@ func init() {
 		log.Fatal(err)
 	}
 
+	err = resource.AddCheckoutSubCmds(rootCmd, &Config)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	err = resource.AddOrdersSubCmds(rootCmd, &Config)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	err = resource.AddPaymentMethodsSubCmds(rootCmd, &Config)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = resource.AddProductsSubCmds(rootCmd, &Config)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = resource.AddRefundsSubCmds(rootCmd, &Config)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = resource.AddWebhookEndpointsSubCmds(rootCmd)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	// remove autogenerated apps command
 	resource.RemoveAppsCmd(rootCmd)
 
@@ -21,6 +21,8 @@ func newSamplesCmd() *samplesCmd {
 
 	samplesCmd.cmd.AddCommand(samples.NewCreateCmd(&Config).Cmd)
 	samplesCmd.cmd.AddCommand(samples.NewListCmd().Cmd)
+	samplesCmd.cmd.AddCommand(samples.NewDoctorCmd(&Config).Cmd)
+	samplesCmd.cmd.AddCommand(newSamplesUpCmd().cmd)
 
 	return samplesCmd
 }
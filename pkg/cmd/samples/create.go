@@ -21,7 +21,8 @@ type CreateCmd struct {
 	cfg *config.Config
 	Cmd *cobra.Command
 
-	forceRefresh bool
+	forceRefresh     bool
+	skipRequirements bool
 }
 
 // NewCreateCmd creates and returns a create command for samples
@@ -36,13 +37,19 @@ func NewCreateCmd(config *config.Config) *CreateCmd {
 		Short: "Setup and bootstrap a Stripe Sample",
 		Long: `The create command will locally clone a sample, let you select which integration,
 client, and server you want to run. It then automatically bootstraps the
-local configuration to let you get started faster.`,
+local configuration to let you get started faster.
+
+Samples are cached in the samples-cache folder of the config folder, or in the
+folder of the STRIPE_SAMPLES_CACHE environment variable or of the
+samples_cache_dir setting. The cache can be shared by several users, or
+pre-populated and read-only, e.g. in a container image.`,
 		Example: `stripe samples create accept-a-payment
   stripe samples create react-elements-card-payment my-payments-form`,
 		RunE: createCmd.runCreateCmd,
 	}
 
 	createCmd.Cmd.Flags().BoolVar(&createCmd.forceRefresh, "force-refresh", false, "Forcefully refresh the local samples cache")
+	createCmd.Cmd.Flags().BoolVar(&createCmd.skipRequirements, "skip-requirements", false, "Create the sample without checking that your account meets its requirements")
 
 	return createCmd
 }
@@ -72,6 +79,15 @@ func (cc *CreateCmd) runCreateCmd(cmd *cobra.Command, args []string) error {
 	ansi.StopSpinner(spinner, "", os.Stdout)
 	fmt.Printf("%s %s\n", color.Green("✔"), ansi.Faint("Finished downloading"))
 
+	// Samples can require features that aren't available on every
+	// account, e.g. in some countries
+	if !cc.skipRequirements {
+		err = samples.CheckRequirements(cmd.Context(), cc.cfg, selectedSample, sampleConfig, os.Stdout)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Once we've initialized the sample in the local cache
 	// directory, the user needs to select which integration they
 	// want to work with (if selectedSamplelicable) and which language they
@@ -104,6 +120,13 @@ func (cc *CreateCmd) runCreateCmd(cmd *cobra.Command, args []string) error {
 		case samples.DidInitialize:
 		case samples.WillCopy:
 			spinner = ansi.StartNewSpinner(fmt.Sprintf("Copying files over... %s", destination), os.Stdout)
+		case samples.Copying:
+			// Without a spinner, progress would print a line per file
+			if spinner != nil {
+				spinner.Lock()
+				spinner.Suffix = fmt.Sprintf(" Copying files over... %s (%d/%d)", destination, res.Progress.Copied, res.Progress.Total)
+				spinner.Unlock()
+			}
 		case samples.DidCopy:
 			ansi.StopSpinner(spinner, "", os.Stdout)
 			fmt.Printf("%s %s\n", color.Green("✔"), ansi.Faint("Files copied"))
@@ -112,6 +135,10 @@ func (cc *CreateCmd) runCreateCmd(cmd *cobra.Command, args []string) error {
 		case samples.DidConfigure:
 			ansi.StopSpinner(spinner, "", os.Stdout)
 			fmt.Printf("%s %s\n", color.Green("✔"), ansi.Faint("Project configured"))
+		case samples.WillCreateResources:
+			fmt.Println("Creating the resources the sample requires...")
+		case samples.DidCreateResources:
+			fmt.Printf("%s %s\n", color.Green("✔"), ansi.Faint("Resources created"))
 		case samples.Done:
 			fmt.Println("You're all set. To get started: cd", destination)
 			if res.PostInstall != "" {
@@ -0,0 +1,75 @@
+package samples
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/samples"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+// DoctorCmd wraps the `doctor` command for samples, which checks that a
+// project created from a sample is ready to run
+type DoctorCmd struct {
+	cfg *config.Config
+	Cmd *cobra.Command
+}
+
+// NewDoctorCmd creates and returns a doctor command for samples
+func NewDoctorCmd(config *config.Config) *DoctorCmd {
+	doctorCmd := &DoctorCmd{
+		cfg: config,
+	}
+	doctorCmd.Cmd = &cobra.Command{
+		Use:   "doctor [directory]",
+		Args:  validators.MaximumNArgs(1),
+		Short: "Check that a sample created with `stripe samples create` is ready to run",
+		Long: `The doctor command checks a project created with 'stripe samples create': that
+the toolchains of its server and client, like node or python, are installed in
+the versions the sample requires, that its .env has every variable of
+.env.example, and that its webhook secret is still the secret of 'stripe listen'.`,
+		Example: `stripe samples doctor
+  stripe samples doctor accept-a-payment`,
+		RunE: doctorCmd.runDoctorCmd,
+	}
+
+	return doctorCmd
+}
+
+func (dc *DoctorCmd) runDoctorCmd(cmd *cobra.Command, args []string) error {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+
+	checks, err := samples.Doctor(cmd.Context(), dc.cfg, afero.NewOsFs(), dir)
+	if err != nil {
+		return err
+	}
+
+	color := ansi.Color(os.Stdout)
+
+	failed := 0
+	for _, check := range checks {
+		switch check.Status {
+		case samples.CheckPassed:
+			fmt.Printf("%s %s\n", color.Green("✔"), check.Message)
+		case samples.CheckWarning:
+			fmt.Printf("%s %s\n", color.Yellow("!"), check.Message)
+		case samples.CheckFailed:
+			failed++
+			fmt.Printf("%s %s\n", color.Red("✘"), check.Message)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d check(s) failed", failed)
+	}
+
+	return nil
+}
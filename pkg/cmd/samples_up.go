@@ -0,0 +1,292 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/proxy"
+	"github.com/stripe/stripe-cli/pkg/samples"
+	"github.com/stripe/stripe-cli/pkg/stripeauth"
+	"github.com/stripe/stripe-cli/pkg/validators"
+	"github.com/stripe/stripe-cli/pkg/websocket"
+)
+
+const defaultWorkspaceFile = "workspace.yaml"
+
+type samplesUpCmd struct {
+	cmd *cobra.Command
+
+	apiBaseURL string
+}
+
+func newSamplesUpCmd() *samplesUpCmd {
+	uc := &samplesUpCmd{}
+
+	uc.cmd = &cobra.Command{
+		Use:   "up [workspace.yaml]",
+		Args:  validators.MaximumNArgs(1),
+		Short: "Create and run several samples and services together",
+		Long: `The up command runs the services of a workspace file together, e.g. a
+checkout sample and the service consuming its webhooks. Services based on a
+sample are created the first time. A single listen session forwards events
+to the webhook endpoints of every service, and its signing secret is set as
+STRIPE_WEBHOOK_SECRET for all of them, along with the env of the workspace.
+
+The output of the services is prefixed with their name. Press Ctrl+C to stop
+them all.
+
+A workspace file looks like:
+
+  env:
+    DOMAIN: http://localhost:4242
+  services:
+    checkout:
+      sample: accept-a-payment
+      integration: custom-payment-flow
+      client: html
+      server: node
+      run: npm install && npm start
+      webhooks:
+        url: http://localhost:4242/webhook
+        events: [payment_intent.succeeded]
+    fulfillment:
+      path: ./fulfillment
+      run: go run .
+      env:
+        PORT: "4243"
+      webhooks:
+        url: http://localhost:4243/events
+        events: [checkout.session.completed]`,
+		Example: `stripe samples up
+  stripe samples up shop/workspace.yaml`,
+		RunE: uc.runSamplesUpCmd,
+	}
+
+	// Hidden configuration flags, useful for dev/debugging
+	uc.cmd.Flags().StringVar(&uc.apiBaseURL, "api-base", "", "Sets the API base URL")
+	uc.cmd.Flags().MarkHidden("api-base") // #nosec G104
+
+	return uc
+}
+
+func (uc *samplesUpCmd) runSamplesUpCmd(cmd *cobra.Command, args []string) error {
+	path := defaultWorkspaceFile
+	if len(args) > 0 {
+		path = args[0]
+	}
+
+	fs := afero.NewOsFs()
+
+	workspace, err := samples.LoadWorkspace(fs, path)
+	if err != nil {
+		return err
+	}
+
+	ctx := withSIGTERMCancel(cmd.Context(), func() {
+		log.WithFields(log.Fields{
+			"prefix": "cmd.samplesUpCmd.runSamplesUpCmd",
+		}).Debug("Ctrl+C received, stopping the services...")
+	})
+
+	for _, service := range workspace.OrderedServices() {
+		if service.Sample == "" {
+			continue
+		}
+
+		if exists, _ := afero.DirExists(fs, service.Path); exists {
+			continue
+		}
+
+		if err := createWorkspaceSample(ctx, service); err != nil {
+			return err
+		}
+	}
+
+	env, err := uc.workspaceEnv(ctx, workspace)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// A listen error stops the services, and is returned instead of theirs
+	listenErrCh := make(chan error, 1)
+
+	if routes := workspace.EndpointRoutes(); len(routes) > 0 {
+		err := uc.startListen(ctx, workspace, routes, func(err error) {
+			listenErrCh <- err
+			cancel()
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = samples.RunServices(ctx, fs, workspace.OrderedServices(), env, os.Stdout)
+
+	select {
+	case listenErr := <-listenErrCh:
+		return listenErr
+	default:
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	return err
+}
+
+// createWorkspaceSample creates the sample of service in its path, with the
+// integration, client and server set in the workspace file.
+func createWorkspaceSample(ctx context.Context, service *samples.WorkspaceService) error {
+	color := ansi.Color(os.Stdout)
+	spinner := ansi.StartNewSpinner(fmt.Sprintf("Creating %s from %s", service.Name, service.Sample), os.Stdout)
+
+	sampleConfig, err := samples.GetSampleConfig(service.Sample, false)
+	if err != nil {
+		ansi.StopSpinner(spinner, "", os.Stdout)
+		return err
+	}
+
+	err = samples.CheckRequirements(ctx, &Config, service.Sample, sampleConfig, os.Stderr)
+	if err != nil {
+		ansi.StopSpinner(spinner, "", os.Stdout)
+		return err
+	}
+
+	selectedConfig, err := service.SelectedConfig(sampleConfig)
+	if err != nil {
+		ansi.StopSpinner(spinner, "", os.Stdout)
+		return err
+	}
+
+	resultChan := make(chan samples.CreationResult)
+
+	go samples.Create(ctx, &Config, service.Sample, selectedConfig, service.Path, false, resultChan)
+
+	for res := range resultChan {
+		if res.Err != nil {
+			ansi.StopSpinner(spinner, "", os.Stdout)
+			return fmt.Errorf("failed to create %s: %v", service.Name, res.Err)
+		}
+	}
+
+	ansi.StopSpinner(spinner, "", os.Stdout)
+	fmt.Printf("%s %s\n", color.Green("✔"), ansi.Faint(fmt.Sprintf("Created %s in %s", service.Name, relativePath(service.Path))))
+
+	return nil
+}
+
+// workspaceEnv returns the env shared by the services: the account's keys,
+// the signing secret of the listen session and the env of the workspace.
+func (uc *samplesUpCmd) workspaceEnv(ctx context.Context, workspace *samples.Workspace) (map[string]string, error) {
+	env := make(map[string]string)
+
+	key, err := Config.Profile.GetAPIKey(false)
+	if err != nil {
+		return nil, err
+	}
+	env["STRIPE_SECRET_KEY"] = key
+
+	if publishableKey := Config.Profile.GetPublishableKey(); publishableKey != "" {
+		env["STRIPE_PUBLISHABLE_KEY"] = publishableKey
+	}
+
+	if len(workspace.EndpointRoutes()) > 0 {
+		deviceName, err := Config.Profile.GetDeviceName()
+		if err != nil {
+			return nil, err
+		}
+
+		secret, err := proxy.GetSessionSecret(ctx, deviceName, key, uc.apiBaseURL, Config.Profile.GetProxy())
+		if err != nil {
+			return nil, err
+		}
+		env["STRIPE_WEBHOOK_SECRET"] = secret
+	}
+
+	for name, value := range workspace.Env {
+		env[name] = value
+	}
+
+	return env, nil
+}
+
+// startListen starts a listen session forwarding events to the routes of the
+// workspace's services. onError is called if the session fails.
+func (uc *samplesUpCmd) startListen(ctx context.Context, workspace *samples.Workspace, routes []proxy.EndpointRoute, onError func(error)) error {
+	deviceName, err := Config.Profile.GetDeviceName()
+	if err != nil {
+		return err
+	}
+
+	key, err := Config.Profile.GetAPIKey(false)
+	if err != nil {
+		return err
+	}
+
+	logger := log.StandardLogger()
+	proxyOutCh := make(chan websocket.IElement)
+
+	p, err := proxy.Init(ctx, &proxy.Config{
+		DeviceName:       deviceName,
+		Key:              key,
+		EndpointRoutes:   routes,
+		Events:           workspace.Events(),
+		APIBaseURL:       uc.apiBaseURL,
+		WebSocketFeature: stripeauth.FeatureWebhooks,
+		Log:              logger,
+		Proxy:            Config.Profile.GetProxy(),
+		OutCh:            proxyOutCh,
+	})
+	if err != nil {
+		return err
+	}
+
+	go p.Run(ctx)
+
+	visitor := createVisitor(logger, "", false, false)
+
+	go func() {
+		for {
+			select {
+			case el, ok := <-proxyOutCh:
+				if !ok {
+					return
+				}
+
+				if err := el.Accept(visitor); err != nil {
+					onError(err)
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+func relativePath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+
+	rel, err := filepath.Rel(wd, path)
+	if err != nil {
+		return path
+	}
+
+	return rel
+}
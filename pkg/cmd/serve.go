@@ -1,52 +1,244 @@
 package cmd
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net/http"
+	"net/http/httputil"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/gorilla/handlers"
 	"github.com/spf13/cobra"
 
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/localcert"
 	"github.com/stripe/stripe-cli/pkg/validators"
 )
 
 type serveCmd struct {
 	cmd *cobra.Command
+
+	port    string
+	https   bool
+	cert    string
+	key     string
+	spa     bool
+	proxies []string
 }
 
 func newServeCmd() *serveCmd {
-	var port string
-
 	sc := &serveCmd{}
 
 	sc.cmd = &cobra.Command{
 		Use:     "serve",
 		Aliases: []string{"srv"},
 		Short:   "Serve static files locally",
-		Args:    validators.MaximumNArgs(1),
-		Example: "stripe serve /path/to/directory",
-		RunE: func(cmd *cobra.Command, args []string) error {
-			dir := "."
-			if len(args) == 1 {
-				dir = args[0]
-			}
+		Long: `Serve static files locally.
+
+With --https, files are served over HTTPS with a self-signed certificate for localhost, which
+payment flows like the Payment Request API and Apple Pay require. The certificate is generated
+once and cached in the config folder, so it only needs to be trusted once. Use --cert and --key
+to serve with your own certificate instead.
+
+With --spa, index.html is served for the paths that aren't files, for single-page apps routing
+on the client. With --proxy, requests to a path prefix are forwarded to a backend, with their
+path unchanged, so that the client and its backend are served from the same origin.`,
+		Args: validators.MaximumNArgs(1),
+		Example: `stripe serve /path/to/directory
+  stripe serve --https
+  stripe serve --cert localhost.pem --key localhost-key.pem
+  stripe serve client --spa --proxy /api=http://localhost:4242`,
+		RunE: sc.runServeCmd,
+	}
+
+	sc.cmd.Flags().StringVar(&sc.port, "port", "4242", "Provide a custom port to serve content from.")
+	sc.cmd.Flags().BoolVar(&sc.https, "https", false, "Serve over HTTPS with a cached self-signed certificate for localhost")
+	sc.cmd.Flags().StringVar(&sc.cert, "cert", "", "PEM file of the certificate to serve HTTPS with, instead of the self-signed one")
+	sc.cmd.Flags().StringVar(&sc.key, "key", "", "PEM file of the private key of --cert")
+	sc.cmd.Flags().BoolVar(&sc.spa, "spa", false, "Serve index.html for the paths that aren't files, for single-page apps")
+	sc.cmd.Flags().StringArrayVar(&sc.proxies, "proxy", []string{}, "Forward the requests to a path prefix to a backend, like /api=http://localhost:4242 (repeatable)")
+
+	return sc
+}
+
+func (sc *serveCmd) runServeCmd(cmd *cobra.Command, args []string) error {
+	if (sc.cert == "") != (sc.key == "") {
+		return fmt.Errorf("--cert and --key must be used together")
+	}
+
+	dir := "."
+	if len(args) == 1 {
+		dir = args[0]
+	}
+
+	absoluteDir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+
+	routes, err := parseProxyRoutes(sc.proxies)
+	if err != nil {
+		return err
+	}
+
+	tlsConfig, err := sc.tlsConfig()
+	if err != nil {
+		return err
+	}
+
+	scheme := "http"
+	if tlsConfig != nil {
+		scheme = "https"
+	}
+
+	fmt.Printf("Starting server for directory  %s\n", absoluteDir)
+
+	fmt.Println("Starting static file server at address", fmt.Sprintf("%s://localhost:%s", scheme, sc.port))
+
+	var files http.Handler = http.FileServer(http.Dir(absoluteDir))
+	if sc.spa {
+		files = spaHandler(absoluteDir, files)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", files)
+
+	for _, route := range routes {
+		fmt.Printf("Forwarding %s to %s\n", route.prefix, route.target)
+
+		proxy := newServeProxy(route.target)
+		mux.Handle(route.prefix, proxy)
+		mux.Handle(route.prefix+"/", proxy)
+	}
+
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%s", sc.port),
+		Handler:           handlers.LoggingHandler(os.Stdout, mux),
+		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig:         tlsConfig,
+	}
+
+	if tlsConfig != nil {
+		return server.ListenAndServeTLS("", "")
+	}
+
+	return server.ListenAndServe()
+}
+
+// tlsConfig returns the TLS config of the server, nil if it serves plain
+// HTTP
+func (sc *serveCmd) tlsConfig() (*tls.Config, error) {
+	var cert tls.Certificate
+	var err error
+
+	switch {
+	case sc.cert != "":
+		cert, err = tls.LoadX509KeyPair(sc.cert, sc.key)
+	case sc.https:
+		cert, err = sc.selfSignedCert()
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// selfSignedCert returns the self-signed certificate cached in the config
+// folder, explaining how to trust it when it's generated
+func (sc *serveCmd) selfSignedCert() (tls.Certificate, error) {
+	dir := filepath.Join(Config.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")), "serve-cert")
+
+	cert, certPath, generated, err := localcert.LoadOrGenerate(dir)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("could not generate a certificate: %w", err)
+	}
+
+	if generated {
+		fmt.Printf("Generated a self-signed certificate for localhost at %s\n", certPath)
+		fmt.Printf("To have your browser trust it, run once:\n  %s\n", ansi.Bold(localcert.TrustCommand(certPath)))
+	}
 
-			absoluteDir, err := filepath.Abs(dir)
-			if err != nil {
-				return err
+	return cert, nil
+}
+
+// serveProxyRoute forwards the requests to a path prefix to a backend
+type serveProxyRoute struct {
+	prefix string
+	target *url.URL
+}
+
+// parseProxyRoutes parses the values of --proxy, like
+// /api=http://localhost:4242
+func parseProxyRoutes(values []string) ([]serveProxyRoute, error) {
+	routes := make([]serveProxyRoute, 0, len(values))
+
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 || !strings.HasPrefix(parts[0], "/") {
+			return nil, fmt.Errorf("invalid --proxy ‘%s’, use a path prefix and a URL like /api=http://localhost:4242", value)
+		}
+
+		target, err := url.Parse(parts[1])
+		if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+			return nil, fmt.Errorf("invalid --proxy ‘%s’, the backend must be an http or https URL", value)
+		}
+
+		// The files are served at the root
+		prefix := strings.TrimSuffix(parts[0], "/")
+		if prefix == "" {
+			return nil, fmt.Errorf("invalid --proxy ‘%s’, the path prefix cannot be /", value)
+		}
+
+		for _, route := range routes {
+			if route.prefix == prefix {
+				return nil, fmt.Errorf("invalid --proxy ‘%s’, %s is already forwarded to %s", value, prefix, route.target)
 			}
+		}
 
-			fmt.Printf("Starting server for directory  %s\n", absoluteDir)
+		routes = append(routes, serveProxyRoute{
+			prefix: prefix,
+			target: target,
+		})
+	}
+
+	return routes, nil
+}
+
+// newServeProxy returns a reverse proxy to a backend, which sees the requests
+// as sent to its own host
+func newServeProxy(target *url.URL) *httputil.ReverseProxy {
+	proxy := httputil.NewSingleHostReverseProxy(target)
 
-			fmt.Println("Starting static file server at address", fmt.Sprintf("http://localhost:%s", port))
-			http.Handle("/", http.FileServer(http.Dir(absoluteDir)))
-			return http.ListenAndServe(fmt.Sprintf(":%s", port), handlers.LoggingHandler(os.Stdout, http.DefaultServeMux))
-		},
+	director := proxy.Director
+	proxy.Director = func(r *http.Request) {
+		director(r)
+		r.Host = target.Host
 	}
 
-	sc.cmd.Flags().StringVar(&port, "port", "4242", "Provide a custom port to serve content from.")
+	return proxy
+}
 
-	return sc
+// spaHandler serves index.html for the paths that aren't files or
+// directories of dir, and the files otherwise
+func spaHandler(dir string, files http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := filepath.Join(dir, filepath.FromSlash(path.Clean("/"+r.URL.Path)))
+		if _, err := os.Stat(name); os.IsNotExist(err) {
+			http.ServeFile(w, r, filepath.Join(dir, "index.html"))
+			return
+		}
+
+		files.ServeHTTP(w, r)
+	})
 }
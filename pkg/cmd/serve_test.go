@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProxyRoutes(t *testing.T) {
+	routes, err := parseProxyRoutes([]string{"/api/=http://localhost:4242", "/config=https://localhost:4243/base"})
+	require.NoError(t, err)
+	require.Len(t, routes, 2)
+	require.Equal(t, "/api", routes[0].prefix)
+	require.Equal(t, "http://localhost:4242", routes[0].target.String())
+	require.Equal(t, "/config", routes[1].prefix)
+
+	_, err = parseProxyRoutes([]string{"api=http://localhost:4242"})
+	require.EqualError(t, err, "invalid --proxy ‘api=http://localhost:4242’, use a path prefix and a URL like /api=http://localhost:4242")
+
+	_, err = parseProxyRoutes([]string{"/api=localhost:4242"})
+	require.EqualError(t, err, "invalid --proxy ‘/api=localhost:4242’, the backend must be an http or https URL")
+
+	_, err = parseProxyRoutes([]string{"/=http://localhost:4242"})
+	require.EqualError(t, err, "invalid --proxy ‘/=http://localhost:4242’, the path prefix cannot be /")
+
+	_, err = parseProxyRoutes([]string{"/api=http://localhost:4242", "/api/=http://localhost:4243"})
+	require.EqualError(t, err, "invalid --proxy ‘/api/=http://localhost:4243’, /api is already forwarded to http://localhost:4242")
+}
+
+func TestSPAHandler(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "index.html"), []byte("app"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "main.js"), []byte("js"), 0600))
+
+	handler := spaHandler(dir, http.FileServer(http.Dir(dir)))
+
+	for path, body := range map[string]string{
+		"/":              "app",
+		"/main.js":       "js",
+		"/checkout":      "app",
+		"/checkout/done": "app",
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+		require.Equal(t, http.StatusOK, rec.Code, path)
+		require.Equal(t, body, rec.Body.String(), path)
+	}
+}
+
+func TestServeProxy(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Host + r.URL.Path)) // #nosec G104
+	}))
+	defer backend.Close()
+
+	routes, err := parseProxyRoutes([]string{"/api=" + backend.URL})
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	newServeProxy(routes[0].target).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://localhost:4242/api/config", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, routes[0].target.Host+"/api/config", rec.Body.String())
+}
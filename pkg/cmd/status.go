@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"time"
@@ -13,6 +14,10 @@ import (
 	"github.com/stripe/stripe-cli/pkg/version"
 )
 
+// errStatusNotUp makes `stripe status` exit with a non-zero code when a
+// system isn't operational, after printing the status
+var errStatusNotUp = errors.New("a Stripe system isn't operational")
+
 type statusCmd struct {
 	cmd *cobra.Command
 
@@ -29,18 +34,29 @@ func newStatusCmd() *statusCmd {
 		Use:   "status",
 		Args:  validators.NoArgs,
 		Short: "Check the status of the Stripe API",
+		Long: `Check the status of the Stripe API, the Dashboard, Stripe.js and Checkout.js,
+and the incidents being investigated, from status.stripe.com.
+
+The command exits with a non-zero code when a system isn't operational, so it
+can be checked first when webhooks or API calls start failing, e.g. in scripts.`,
 		Example: `stripe status
-  stripe status --poll
-  stripe status --poll --verbose`,
+  stripe status --output json
+  stripe status --watch --verbose`,
 		RunE: sc.runStatusCmd,
 	}
 
-	sc.cmd.Flags().StringVar(&sc.format, "format", "default", "The format to print the status as (either 'default' or 'json')")
+	sc.cmd.Flags().StringVar(&sc.format, "output", "default", "The format to print the status as (either 'default' or 'json')")
 	sc.cmd.Flags().BoolVar(&sc.verbose, "verbose", false, "Show status for all Stripe systems")
-	sc.cmd.Flags().BoolVar(&sc.poll, "poll", false, "Keep polling for status updates")
+	sc.cmd.Flags().BoolVar(&sc.poll, "watch", false, "Keep polling for status updates")
 	sc.cmd.Flags().IntVar(&sc.pollRate, "poll-rate", 60, "How many seconds to wait between status updates (minimum: 5)")
 	sc.cmd.Flags().BoolVar(&sc.hideSpinner, "hide-spinner", false, "Hide the loading spinner when polling")
 
+	// --format and --poll are the former names of --output and --watch
+	sc.cmd.Flags().StringVar(&sc.format, "format", "default", "The format to print the status as (either 'default' or 'json')")
+	sc.cmd.Flags().BoolVar(&sc.poll, "poll", false, "Keep polling for status updates")
+	sc.cmd.Flags().MarkHidden("format") // #nosec G104
+	sc.cmd.Flags().MarkHidden("poll")   // #nosec G104
+
 	return sc
 }
 
@@ -71,6 +87,9 @@ func (sc *statusCmd) runStatusCmd(cmd *cobra.Command, args []string) error {
 		fmt.Println(formattedStatus)
 
 		if !sc.poll {
+			if !stripeStatus.IsUp() {
+				return errStatusNotUp
+			}
 			break
 		}
 
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type switchCmd struct {
+	cmd *cobra.Command
+}
+
+func newSwitchCmd(cfg *config.Config) *switchCmd {
+	pc := newProfileCmd(cfg)
+	sc := &switchCmd{}
+
+	sc.cmd = &cobra.Command{
+		Use:   "switch [name]",
+		Args:  validators.MaximumNArgs(1),
+		Short: "Switch the profile in use, or list the profiles",
+		Long: `Switch the profile commands use when --project-name isn't set, like
+` + "`stripe profile use`" + `. Without a name, list the profiles with the display names
+and IDs of their accounts.`,
+		Example: `stripe switch
+  stripe switch acme-staging`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return pc.runListCmd(cmd, args)
+			}
+
+			return pc.runUseCmd(cmd, args)
+		},
+		ValidArgsFunction: pc.completeProfileName,
+	}
+
+	return sc
+}
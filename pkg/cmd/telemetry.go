@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+// telemetryEvents are the events the CLI sends, with when they're sent
+var telemetryEvents = []struct {
+	name string
+	when string
+}{
+	{"Command Invoked", "for each command, or each method called on `stripe daemon`, with Cobra or gRPC as value"},
+	{"API Request", "for each API request, with its request_id and livemode"},
+	{"Triggered Event", "for each `stripe trigger`, with the name of the event as value"},
+}
+
+type telemetryCmd struct {
+	cmd    *cobra.Command
+	config *config.Config
+}
+
+func newTelemetryCmd(cfg *config.Config) *telemetryCmd {
+	tc := &telemetryCmd{
+		config: cfg,
+	}
+
+	tc.cmd = &cobra.Command{
+		Use:   "telemetry",
+		Args:  validators.NoArgs,
+		Short: "Show and control the telemetry the CLI sends",
+		Long: `The CLI sends usage data to Stripe. Show exactly which events and fields are
+sent with ` + "`stripe telemetry show`" + `, and disable it for every profile with
+` + "`stripe telemetry disable`" + `, which sets telemetry.enabled to false in the config
+file. The STRIPE_CLI_TELEMETRY_OPTOUT and DO_NOT_TRACK environment variables
+disable it too.`,
+	}
+
+	tc.cmd.AddCommand(&cobra.Command{
+		Use:   "show",
+		Args:  validators.NoArgs,
+		Short: "Show whether telemetry is sent, and the events and fields it sends",
+		RunE:  tc.runShowCmd,
+	})
+
+	tc.cmd.AddCommand(&cobra.Command{
+		Use:   "enable",
+		Args:  validators.NoArgs,
+		Short: "Enable telemetry for every profile",
+		RunE:  tc.runEnableCmd,
+	})
+
+	tc.cmd.AddCommand(&cobra.Command{
+		Use:   "disable",
+		Args:  validators.NoArgs,
+		Short: "Disable telemetry for every profile",
+		RunE:  tc.runDisableCmd,
+	})
+
+	return tc
+}
+
+// telemetryDisabledBy returns what disables telemetry, or "" if it's enabled
+func telemetryDisabledBy(cfg *config.Config) string {
+	switch {
+	case stripe.TelemetryOptedOut(os.Getenv("STRIPE_CLI_TELEMETRY_OPTOUT")):
+		return "the STRIPE_CLI_TELEMETRY_OPTOUT environment variable"
+	case stripe.TelemetryOptedOut(os.Getenv("DO_NOT_TRACK")):
+		return "the DO_NOT_TRACK environment variable"
+	case !cfg.TelemetryEnabled():
+		return "telemetry.enabled in the config file"
+	default:
+		return ""
+	}
+}
+
+func (tc *telemetryCmd) runShowCmd(cmd *cobra.Command, args []string) error {
+	metadata := stripe.GetEventMetadata(cmd.Context())
+	if metadata == nil {
+		metadata = stripe.NewEventMetadata()
+		metadata.SetCobraCommandContext(cmd)
+	}
+
+	printTelemetry(os.Stdout, telemetryDisabledBy(tc.config), metadata)
+
+	return nil
+}
+
+// printTelemetry prints the status of telemetry, the events it sends, and the
+// fields of the events of the current command
+func printTelemetry(w io.Writer, disabledBy string, metadata *stripe.CLIAnalyticsEventMetadata) {
+	color := ansi.Color(w)
+
+	if disabledBy != "" {
+		fmt.Fprintf(w, "Telemetry: %s, by %s\n", color.Bold("disabled"), disabledBy)
+	} else {
+		fmt.Fprintf(w, "Telemetry: %s, sent to %s\n", color.Bold("enabled"), stripe.DefaultTelemetryEndpoint)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Events:")
+	for _, event := range telemetryEvents {
+		fmt.Fprintf(w, "  %-16s %s\n", event.name, event.when)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Fields of the API Request event of this command:")
+
+	data := stripe.NewAPIRequestTelemetryEvent(metadata, "req_...", false)
+	fields := make([]string, 0, len(data))
+	for field := range data {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		fmt.Fprintln(w, strings.TrimRight(fmt.Sprintf("  %-18s %s", field, data.Get(field)), " "))
+	}
+}
+
+func (tc *telemetryCmd) runEnableCmd(cmd *cobra.Command, args []string) error {
+	if err := tc.config.SetTelemetryEnabled(true); err != nil {
+		return err
+	}
+
+	if disabledBy := telemetryDisabledBy(tc.config); disabledBy != "" {
+		fmt.Printf("Enabled telemetry in the config file, but it's still disabled by %s\n", disabledBy)
+		return nil
+	}
+
+	fmt.Println("Enabled telemetry")
+
+	return nil
+}
+
+func (tc *telemetryCmd) runDisableCmd(cmd *cobra.Command, args []string) error {
+	if err := tc.config.SetTelemetryEnabled(false); err != nil {
+		return err
+	}
+
+	fmt.Println("Disabled telemetry, the CLI no longer sends usage data for any profile")
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/stripe"
+)
+
+func TestPrintTelemetry(t *testing.T) {
+	metadata := stripe.NewEventMetadata()
+	metadata.SetCommandPath("stripe telemetry show")
+	metadata.SetMerchant("acct_123")
+
+	var out bytes.Buffer
+	printTelemetry(&out, "", metadata)
+
+	require.Contains(t, out.String(), "Telemetry: enabled, sent to "+stripe.DefaultTelemetryEndpoint)
+	require.Contains(t, out.String(), "Triggered Event")
+	require.Contains(t, out.String(), "command_path       stripe telemetry show\n")
+	require.Contains(t, out.String(), "merchant           acct_123\n")
+	require.Contains(t, out.String(), "invocation_id      "+metadata.InvocationID+"\n")
+
+	out.Reset()
+	printTelemetry(&out, "the DO_NOT_TRACK environment variable", metadata)
+	require.Contains(t, out.String(), "Telemetry: disabled, by the DO_NOT_TRACK environment variable")
+}
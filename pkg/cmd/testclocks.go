@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/testclocks"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+const testClockTimeLayout = "2006-01-02 15:04:05 MST"
+
+type testClocksCmd struct {
+	cmd    *cobra.Command
+	config *config.Config
+
+	name       string
+	frozenTime string
+	advanceBy  string
+	to         string
+	wait       bool
+	limit      int
+	apiBaseURL string
+}
+
+func newTestClocksCmd(cfg *config.Config) *testClocksCmd {
+	tc := &testClocksCmd{
+		config: cfg,
+	}
+
+	tc.cmd = &cobra.Command{
+		Use:   "test-clocks",
+		Args:  validators.NoArgs,
+		Short: "Create and advance test clocks to simulate billing cycles",
+		Long: `Create and advance test clocks, which move the customers and subscriptions
+attached to them through time, to test billing cycles without waiting for them.
+
+Times are dates (2025-01-01), dates and times in your time zone
+(2025-01-01T12:00), or Unix timestamps. Durations are like 12h, 32d, 2w, 1mo
+or 1y, months and years following the calendar like billing cycles do.`,
+	}
+
+	createCmd := &cobra.Command{
+		Use:     "create",
+		Args:    validators.NoArgs,
+		Short:   "Create a test clock",
+		Example: `stripe test-clocks create --name "Annual renewal" --frozen-time 2025-01-01`,
+		RunE:    tc.runCreateCmd,
+	}
+	createCmd.Flags().StringVar(&tc.name, "name", "", "Name of the test clock")
+	createCmd.Flags().StringVar(&tc.frozenTime, "frozen-time", "", "Time the test clock starts at (default: now)")
+
+	advanceCmd := &cobra.Command{
+		Use:   "advance <clock_id>",
+		Args:  validators.ExactArgs(1),
+		Short: "Advance a test clock",
+		Long: `Advance a test clock by a duration, or to a time. Advancing is asynchronous:
+pass --wait to wait until the test clock is ready again, and its invoices and
+events were created.`,
+		Example: `stripe test-clocks advance clock_1MxYz1234 --advance-by 32d --wait
+  stripe test-clocks advance clock_1MxYz1234 --to 2025-02-01`,
+		RunE: tc.runAdvanceCmd,
+	}
+	advanceCmd.Flags().StringVar(&tc.advanceBy, "advance-by", "", "Duration to advance the test clock by, e.g. 32d or 1mo")
+	advanceCmd.Flags().StringVar(&tc.to, "to", "", "Time to advance the test clock to")
+	advanceCmd.Flags().BoolVar(&tc.wait, "wait", false, "Wait until the test clock has finished advancing")
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Args:  validators.NoArgs,
+		Short: "List test clocks",
+		RunE:  tc.runListCmd,
+	}
+	listCmd.Flags().IntVar(&tc.limit, "limit", 10, "Maximum number of test clocks to list")
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete <clock_id>",
+		Args:  validators.ExactArgs(1),
+		Short: "Delete a test clock and the objects attached to it",
+		RunE:  tc.runDeleteCmd,
+	}
+
+	tc.cmd.AddCommand(createCmd, advanceCmd, listCmd, deleteCmd)
+
+	// Hidden configuration flags, useful for dev/debugging
+	tc.cmd.PersistentFlags().StringVar(&tc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	tc.cmd.PersistentFlags().MarkHidden("api-base") // #nosec G104
+
+	return tc
+}
+
+func (tc *testClocksCmd) client() (*testclocks.Client, error) {
+	apiKey, err := tc.config.Profile.GetAPIKey(false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &testclocks.Client{BaseURL: tc.apiBaseURL, APIKey: apiKey, Profile: &tc.config.Profile}, nil
+}
+
+func (tc *testClocksCmd) runCreateCmd(cmd *cobra.Command, args []string) error {
+	frozenTime := time.Now()
+	if tc.frozenTime != "" {
+		t, err := testclocks.ParseTime(tc.frozenTime, time.Local)
+		if err != nil {
+			return err
+		}
+		frozenTime = t
+	}
+
+	client, err := tc.client()
+	if err != nil {
+		return err
+	}
+
+	clock, err := client.Create(cmd.Context(), frozenTime, tc.name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Created %s, frozen at %s\n", ansi.Bold(clock.ID), clock.FrozenTime.Local().Format(testClockTimeLayout))
+
+	return nil
+}
+
+func (tc *testClocksCmd) runAdvanceCmd(cmd *cobra.Command, args []string) error {
+	if (tc.advanceBy == "") == (tc.to == "") {
+		return fmt.Errorf("pass either --advance-by or --to")
+	}
+
+	client, err := tc.client()
+	if err != nil {
+		return err
+	}
+
+	var frozenTime time.Time
+
+	if tc.to != "" {
+		frozenTime, err = testclocks.ParseTime(tc.to, time.Local)
+		if err != nil {
+			return err
+		}
+	} else {
+		clock, err := client.Get(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		frozenTime, err = testclocks.AdvanceBy(clock.FrozenTime.In(time.Local), tc.advanceBy)
+		if err != nil {
+			return err
+		}
+	}
+
+	clock, err := client.Advance(cmd.Context(), args[0], frozenTime)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+
+	if !tc.wait {
+		fmt.Fprintf(out, "Advancing %s to %s, check on it with `stripe test-clocks list`\n", clock.ID, frozenTime.Local().Format(testClockTimeLayout))
+		return nil
+	}
+
+	fmt.Fprintf(out, "Advancing %s to %s...\n", clock.ID, frozenTime.Local().Format(testClockTimeLayout))
+
+	clock, err = client.Wait(cmd.Context(), clock.ID)
+	if err != nil {
+		return err
+	}
+
+	color := ansi.Color(out)
+	fmt.Fprintf(out, "%s %s is %s, frozen at %s\n", color.Green("✔"), clock.ID, clock.Status, clock.FrozenTime.Local().Format(testClockTimeLayout))
+
+	return nil
+}
+
+func (tc *testClocksCmd) runListCmd(cmd *cobra.Command, args []string) error {
+	client, err := tc.client()
+	if err != nil {
+		return err
+	}
+
+	clocks, err := client.List(cmd.Context(), tc.limit)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tFROZEN TIME\tSTATUS")
+	for _, clock := range clocks {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", clock.ID, clock.Name, clock.FrozenTime.Local().Format(testClockTimeLayout), clock.Status)
+	}
+
+	return w.Flush()
+}
+
+func (tc *testClocksCmd) runDeleteCmd(cmd *cobra.Command, args []string) error {
+	client, err := tc.client()
+	if err != nil {
+		return err
+	}
+
+	if err := client.Delete(cmd.Context(), args[0]); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Deleted %s\n", args[0])
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/threeds"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type threedsCmd struct {
+	cmd    *cobra.Command
+	config *config.Config
+
+	outcome       string
+	paymentIntent string
+	paymentMethod string
+	amount        int64
+	currency      string
+	apiBaseURL    string
+}
+
+func newThreedsCmd(cfg *config.Config) *threedsCmd {
+	tc := &threedsCmd{
+		config: cfg,
+	}
+
+	tc.cmd = &cobra.Command{
+		Use:   "threeds",
+		Args:  validators.NoArgs,
+		Short: "Simulate 3D Secure authentications in test mode",
+	}
+
+	simulateCmd := &cobra.Command{
+		Use:   "simulate",
+		Args:  validators.NoArgs,
+		Short: "Authenticate a payment with 3D Secure, or fail to",
+		Long: `Confirm a payment intent with a test payment method requiring 3D Secure,
+and complete or fail the challenge like a customer would. The events of the
+payment intent are sent to your webhook endpoints and to ` + "`stripe listen`" + `,
+so you can test how your handlers react to authenticated and failed payments.
+
+A payment intent is created when --payment-intent isn't set.`,
+		Example: `stripe threeds simulate --outcome authenticated
+  stripe threeds simulate --outcome failed --payment-intent pi_1MxYz1234`,
+		RunE: tc.runSimulateCmd,
+	}
+	simulateCmd.Flags().StringVar(&tc.outcome, "outcome", threeds.Authenticated, "Outcome of the challenge: authenticated or failed")
+	simulateCmd.Flags().StringVar(&tc.paymentIntent, "payment-intent", "", "ID of the payment intent to authenticate (default: a new payment intent)")
+	simulateCmd.Flags().StringVar(&tc.paymentMethod, "payment-method", threeds.DefaultPaymentMethod, "Test payment method requiring 3D Secure to confirm the payment intent with")
+	simulateCmd.Flags().Int64Var(&tc.amount, "amount", 2000, "Amount of the new payment intent, in the smallest currency unit")
+	simulateCmd.Flags().StringVar(&tc.currency, "currency", "usd", "Currency of the new payment intent")
+
+	tc.cmd.AddCommand(simulateCmd)
+
+	// Hidden configuration flags, useful for dev/debugging
+	tc.cmd.PersistentFlags().StringVar(&tc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	tc.cmd.PersistentFlags().MarkHidden("api-base") // #nosec G104
+
+	return tc
+}
+
+func (tc *threedsCmd) runSimulateCmd(cmd *cobra.Command, args []string) error {
+	apiKey, err := tc.config.Profile.GetAPIKey(false)
+	if err != nil {
+		return err
+	}
+
+	result, err := threeds.Simulate(cmd.Context(), tc.apiBaseURL, apiKey, threeds.Options{
+		Outcome:       tc.outcome,
+		PaymentIntent: tc.paymentIntent,
+		PaymentMethod: tc.paymentMethod,
+		Amount:        tc.amount,
+		Currency:      tc.currency,
+	}, &tc.config.Profile)
+	if err != nil {
+		return err
+	}
+
+	color := ansi.Color(cmd.OutOrStdout())
+
+	fmt.Fprintf(cmd.OutOrStdout(), "3D Secure %s for %s, the payment intent is %s\n",
+		tc.outcome, result.PaymentIntent, color.Bold(result.Status))
+
+	if len(result.Events) > 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "Events sent: %s\n", strings.Join(result.Events, ", "))
+	}
+
+	return nil
+}
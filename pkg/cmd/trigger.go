@@ -1,15 +1,24 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 
 	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
 	"github.com/stripe/stripe-cli/pkg/fixtures"
 	"github.com/stripe/stripe-cli/pkg/stripe"
-	"github.com/stripe/stripe-cli/pkg/validators"
 	"github.com/stripe/stripe-cli/pkg/version"
 )
 
@@ -23,15 +32,65 @@ type triggerCmd struct {
 	add           []string
 	remove        []string
 	raw           string
+	count         int
+	concurrency   int
+	ignoreLimits  bool
+	cleanup       bool
+	dryRun        bool
+	list          bool
+	every         time.Duration
+	duration      time.Duration
+	rate          string
+	output        string
 	apiBaseURL    string
 }
 
+// outputJSON prints the objects created by triggers as JSON
+const outputJSON = "json"
+
+// triggered is what triggering an event did
+type triggered struct {
+	// requestNames are the names of the requests the fixture sent
+	requestNames []string
+	// resources are the objects the fixture created
+	resources []fixtures.Resource
+	// run is the teardown run recorded for `stripe fixtures teardown`, if
+	// any
+	run *fixtures.TeardownRun
+}
+
+// triggerOutput is a trigger printed with --output json
+type triggerOutput struct {
+	Event       string              `json:"event"`
+	Resources   []fixtures.Resource `json:"resources"`
+	TeardownRun string              `json:"teardown_run,omitempty"`
+	Error       string              `json:"error,omitempty"`
+}
+
+func newTriggerOutput(event string, t triggered, err error) triggerOutput {
+	output := triggerOutput{
+		Event:     event,
+		Resources: t.resources,
+	}
+	if output.Resources == nil {
+		output.Resources = []fixtures.Resource{}
+	}
+	if t.run != nil {
+		output.TeardownRun = t.run.ID
+	}
+	if err != nil {
+		output.Error = strings.TrimSpace(err.Error())
+	}
+
+	return output
+}
+
 func newTriggerCmd() *triggerCmd {
 	tc := &triggerCmd{}
 	tc.fs = afero.NewOsFs()
 	tc.cmd = &cobra.Command{
-		Use:       "trigger <event>",
-		Args:      validators.MaximumNArgs(1),
+		Use:       "trigger <event>...",
+		Args:      cobra.ArbitraryArgs,
 		ValidArgs: fixtures.EventNames(),
 		Short:     "Trigger test webhook events",
 		Long: fmt.Sprintf(`Trigger specific webhook events to be sent. Webhooks events created through
@@ -44,8 +103,17 @@ needed to create the triggered event as well as the corresponding API objects.
 			ansi.Bold("Supported events:"),
 			fixtures.EventList(),
 		),
-		Example: `stripe trigger payment_intent.created`,
-		RunE:    tc.runTriggerCmd,
+		Example: `stripe trigger payment_intent.created
+  stripe trigger customer.created --count 50
+  stripe trigger payment_intent.succeeded customer.created invoice.paid --count 10 --concurrency 4
+  stripe trigger payment_intent.succeeded --every 5s --duration 10m
+  stripe trigger customer.created --rate 20/s --concurrency 10 --cleanup
+  stripe trigger customer.subscription.created --cleanup
+  stripe trigger invoice.paid --dry-run
+  stripe trigger payment_intent.succeeded --output json
+  stripe trigger --list
+  stripe trigger --list invoice.payment_failed`,
+		RunE: tc.runTriggerCmd,
 	}
 
 	tc.cmd.Flags().StringVar(&tc.stripeAccount, "stripe-account", "", "Set a header identifying the connected account")
@@ -54,6 +122,16 @@ needed to create the triggered event as well as the corresponding API objects.
 	tc.cmd.Flags().StringArrayVar(&tc.add, "add", []string{}, "Add params to the trigger")
 	tc.cmd.Flags().StringArrayVar(&tc.remove, "remove", []string{}, "Remove params from the trigger")
 	tc.cmd.Flags().StringVar(&tc.raw, "raw", "", "Raw fixture in string format to replace all default fixtures")
+	tc.cmd.Flags().IntVar(&tc.count, "count", 1, fmt.Sprintf("Trigger each event this many times. The objects created are capped at %d per run and %d per hour, configurable with the trigger_max_objects_per_run and trigger_max_objects_per_hour settings", fixtures.DefaultMaxObjectsPerRun, fixtures.DefaultMaxObjectsPerHour))
+	tc.cmd.Flags().IntVar(&tc.concurrency, "concurrency", 1, "Number of triggers to run in parallel when triggering several events or --count times")
+	tc.cmd.Flags().BoolVar(&tc.ignoreLimits, "ignore-limits", false, "Trigger the events --count times even if that exceeds the limits on objects created")
+	tc.cmd.Flags().BoolVar(&tc.cleanup, "cleanup", false, "Delete the objects created by the trigger once the event fired, for events whose fixture declares teardown requests")
+	tc.cmd.Flags().BoolVar(&tc.dryRun, "dry-run", false, "Print the requests the trigger would send, without sending them")
+	tc.cmd.Flags().DurationVar(&tc.every, "every", 0, "Trigger the events continuously at this interval, like 5s, until --duration elapsed or the command is interrupted. Latency and error stats are printed on exit")
+	tc.cmd.Flags().StringVar(&tc.rate, "rate", "", "Trigger the events continuously at this rate, like 20/s or 30/m, instead of --every")
+	tc.cmd.Flags().DurationVar(&tc.duration, "duration", 0, "How long to trigger the events with --every or --rate, like 10m. Runs until interrupted if not set")
+	tc.cmd.Flags().StringVar(&tc.output, "output", "", "Print the objects created by the triggers in this format, the only supported value is json. Progress is then printed to stderr")
+	tc.cmd.Flags().BoolVar(&tc.list, "list", false, "Describe the supported events, or the given event: what triggering it does, the objects it creates and the parameters you can override")
 
 	// Hidden configuration flags, useful for dev/debugging
 	tc.cmd.Flags().StringVar(&tc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
@@ -65,24 +143,399 @@ needed to create the triggered event as well as the corresponding API objects.
 func (tc *triggerCmd) runTriggerCmd(cmd *cobra.Command, args []string) error {
 	version.CheckLatestVersion()
 
+	if err := fixtures.RegisterPacks(tc.fs, Config.Profile.GetFixturePacks()); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	if tc.output != "" && strings.ToLower(tc.output) != outputJSON {
+		return fmt.Errorf("unsupported output %q, the only supported value is %q", tc.output, outputJSON)
+	}
+	jsonOutput := strings.ToLower(tc.output) == outputJSON
+
+	if jsonOutput && (tc.list || tc.dryRun) {
+		return fmt.Errorf("--output can't be used with --list or --dry-run")
+	}
+
+	if tc.list {
+		return listTriggers(os.Stdout, args)
+	}
+
 	if len(args) == 0 {
 		cmd.Help()
 
 		return nil
 	}
 
+	if tc.dryRun {
+		return tc.printPlans(os.Stdout, args)
+	}
+
 	apiKey, err := Config.Profile.GetAPIKey(false)
 	if err != nil {
 		return err
 	}
 
-	event := args[0]
+	if tc.count < 1 {
+		return fmt.Errorf("--count must be at least 1")
+	}
+
+	if tc.concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	if len(args) > 1 && tc.raw != "" {
+		return fmt.Errorf("--raw can't be used with several events")
+	}
+
+	interval, err := tc.interval()
+	if err != nil {
+		return err
+	}
+
+	if interval > 0 {
+		if tc.count > 1 {
+			return fmt.Errorf("--count can't be used with --every or --rate")
+		}
+		if jsonOutput {
+			return fmt.Errorf("--output can't be used with --every or --rate")
+		}
+
+		ctx := withSIGTERMCancel(cmd.Context(), func() {})
+		return tc.runContinuous(ctx, args, apiKey, interval, tc.duration)
+	}
+
+	if tc.duration > 0 {
+		return fmt.Errorf("--duration requires --every or --rate")
+	}
+
+	// Fixtures print their progress to stdout, which is kept for the JSON
+	stdout := os.Stdout
+	if jsonOutput {
+		os.Stdout = os.Stderr
+		defer func() { os.Stdout = stdout }()
+	}
+
+	if len(args) > 1 || tc.count > 1 {
+		return tc.runBatch(cmd.Context(), args, apiKey, stdout)
+	}
+
+	t, err := tc.trigger(cmd.Context(), args[0], apiKey)
+
+	if jsonOutput {
+		if err := printJSON(stdout, newTriggerOutput(args[0], t, err)); err != nil {
+			return err
+		}
+		return err
+	}
 
-	_, err = fixtures.Trigger(cmd.Context(), event, tc.stripeAccount, tc.apiBaseURL, apiKey, tc.skip, tc.override, tc.add, tc.remove, tc.raw)
 	if err != nil {
 		return err
 	}
 
 	fmt.Println("Trigger succeeded! Check dashboard for event details.")
+	if t.run != nil {
+		fmt.Printf("Run `stripe fixtures teardown %s` to delete the objects it created.\n", t.run.ID)
+	}
+	return nil
+}
+
+// printJSON prints v as indented JSON
+func printJSON(out io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// interval returns the interval between triggers set with --every or
+// --rate, or 0 if they're not set.
+func (tc *triggerCmd) interval() (time.Duration, error) {
+	switch {
+	case tc.every != 0 && tc.rate != "":
+		return 0, fmt.Errorf("only one of --every and --rate can be set")
+	case tc.rate != "":
+		return parseRate(tc.rate)
+	case tc.every < 0:
+		return 0, fmt.Errorf("--every must be positive")
+	case tc.duration < 0:
+		return 0, fmt.Errorf("--duration must be positive")
+	default:
+		return tc.every, nil
+	}
+}
+
+// trigger triggers event. The objects created are then deleted with
+// --cleanup, or otherwise recorded so `stripe fixtures teardown` can delete
+// them later, in which case the recorded run is returned.
+func (tc *triggerCmd) trigger(ctx context.Context, event string, apiKey string) (triggered, error) {
+	fixture, err := fixtures.BuildTrigger(event, tc.stripeAccount, tc.apiBaseURL, apiKey, tc.skip, tc.override, tc.add, tc.remove, tc.raw)
+	if err != nil {
+		return triggered{}, err
+	}
+
+	requestNames, err := fixtures.ExecuteTrigger(ctx, event, fixture)
+	t := triggered{
+		requestNames: requestNames,
+		resources:    fixture.Resources(),
+	}
+	if err != nil || !fixture.HasTeardown() {
+		return t, err
+	}
+
+	run := fixture.PrepareTeardown(event)
+	if len(run.Requests) == 0 {
+		return t, nil
+	}
+
+	if tc.cleanup {
+		return t, fixtures.Teardown(ctx, apiKey, run)
+	}
+
+	t.run = run
+	return t, fixtureRunStore(tc.fs, &Config).Save(run)
+}
+
+// printPlans prints the requests triggering each event would send.
+func (tc *triggerCmd) printPlans(out io.Writer, events []string) error {
+	for i, event := range events {
+		fixture, err := fixtures.BuildTrigger(event, tc.stripeAccount, tc.apiBaseURL, "", tc.skip, tc.override, tc.add, tc.remove, tc.raw)
+		if err != nil {
+			return err
+		}
+
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		fmt.Fprintln(out, ansi.Bold(event))
+
+		if err := printPlan(out, fixture); err != nil {
+			return err
+		}
+	}
+
+	if tc.count > 1 {
+		fmt.Fprintf(out, "\nThese requests would be sent %d times.\n", tc.count)
+	}
+
+	return nil
+}
+
+// triggerResult summarizes the triggers of an event run by runBatch
+type triggerResult struct {
+	event     string
+	succeeded int
+	failed    int
+	duration  time.Duration
+	err       error
+}
+
+// runBatch triggers each event --count times, with up to --concurrency
+// triggers running at once, as long as the objects created stay within the
+// load limits. A summary of the triggers of each event is printed once they
+// all ran.
+func (tc *triggerCmd) runBatch(ctx context.Context, events []string, apiKey string, stdout io.Writer) error {
+	planned := 0
+	for _, event := range events {
+		fixture, err := fixtures.BuildTrigger(event, tc.stripeAccount, tc.apiBaseURL, apiKey, tc.skip, tc.override, tc.add, tc.remove, tc.raw)
+		if err != nil {
+			return err
+		}
+
+		planned += fixture.RequestCount() * tc.count
+	}
+
+	ledger := &fixtures.LoadLedger{
+		Fs:   config.StateFs(tc.fs),
+		Path: filepath.Join(Config.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")), "trigger_usage.json"),
+	}
+
+	if !tc.ignoreLimits {
+		createdLastHour, err := ledger.CreatedLastHour()
+		if err != nil {
+			return err
+		}
+
+		if err := tc.loadLimits().Check(planned, createdLastHour); err != nil {
+			return err
+		}
+	}
+
+	results := make([]*triggerResult, len(events))
+	for i, event := range events {
+		results[i] = &triggerResult{event: event}
+	}
+
+	jobs := make(chan *triggerResult)
+	go func() {
+		defer close(jobs)
+		for n := 0; n < tc.count; n++ {
+			for _, result := range results {
+				select {
+				case jobs <- result:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	created := 0
+	recorded := 0
+	outputs := []triggerOutput{}
+
+	for w := 0; w < tc.concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for result := range jobs {
+				start := time.Now()
+				t, err := tc.trigger(ctx, result.event, apiKey)
+				elapsed := time.Since(start)
+
+				mu.Lock()
+				created += len(t.requestNames)
+				if t.run != nil {
+					recorded++
+				}
+				outputs = append(outputs, newTriggerOutput(result.event, t, err))
+				result.duration += elapsed
+				if err != nil {
+					result.failed++
+					if result.err == nil {
+						result.err = err
+					}
+				} else {
+					result.succeeded++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	// Record the triggers that succeeded even if others failed
+	ledger.Record(created)
+
+	failed := 0
+	for _, result := range results {
+		failed += result.failed
+	}
+
+	if strings.ToLower(tc.output) == outputJSON {
+		if err := printJSON(stdout, outputs); err != nil {
+			return err
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d triggers failed", failed, len(events)*tc.count)
+		}
+		return nil
+	}
+
+	printTriggerSummary(os.Stdout, results)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d triggers failed", failed, len(events)*tc.count)
+	}
+
+	fmt.Println("Check dashboard for event details.")
+	if recorded > 0 {
+		fmt.Printf("Run `stripe fixtures teardown --all` to delete the objects created by %d triggers.\n", recorded)
+	}
+	return nil
+}
+
+// printTriggerSummary prints a table of the triggers of each event and the
+// first error of the events that failed.
+func printTriggerSummary(out io.Writer, results []*triggerResult) {
+	fmt.Fprintln(out)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "EVENT\tSUCCEEDED\tFAILED\tAVG DURATION")
+	for _, result := range results {
+		average := time.Duration(0)
+		if runs := result.succeeded + result.failed; runs > 0 {
+			average = (result.duration / time.Duration(runs)).Round(time.Millisecond)
+		}
+
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\n", result.event, result.succeeded, result.failed, average)
+	}
+	w.Flush()
+
+	color := ansi.Color(out)
+	for _, result := range results {
+		if result.err != nil {
+			fmt.Fprintf(out, "\n%s %s: %v\n", color.Red("✘").String(), result.event, strings.TrimSpace(result.err.Error()))
+		}
+	}
+
+	fmt.Fprintln(out)
+}
+
+// loadLimits returns the profile's load limits, falling back to the defaults.
+func (tc *triggerCmd) loadLimits() fixtures.LoadLimits {
+	perRun, perHour := Config.Profile.GetTriggerLoadLimits()
+	if perRun <= 0 {
+		perRun = fixtures.DefaultMaxObjectsPerRun
+	}
+	if perHour <= 0 {
+		perHour = fixtures.DefaultMaxObjectsPerHour
+	}
+
+	return fixtures.LoadLimits{MaxObjectsPerRun: perRun, MaxObjectsPerHour: perHour}
+}
+
+// listTriggers prints the catalog of the events given, or of every supported
+// event.
+func listTriggers(out io.Writer, events []string) error {
+	var catalog []fixtures.TriggerInfo
+
+	if len(events) == 0 {
+		var err error
+		catalog, err = fixtures.Catalog()
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, event := range events {
+		info, err := fixtures.DescribeTrigger(event)
+		if err != nil {
+			return err
+		}
+		catalog = append(catalog, info)
+	}
+
+	color := ansi.Color(out)
+
+	for i, info := range catalog {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+
+		fmt.Fprintln(out, color.Bold(info.Event))
+		if info.Description != "" {
+			fmt.Fprintf(out, "  %s\n", info.Description)
+		}
+		if len(info.Requires) > 0 {
+			fmt.Fprintf(out, "  %s %s\n", color.Faint("Requires:"), strings.Join(info.Requires, ", "))
+		}
+		if len(info.Creates) > 0 {
+			fmt.Fprintf(out, "  %s %s\n", color.Faint("Creates:"), strings.Join(info.Creates, ", "))
+		}
+		if len(info.Parameters) > 0 {
+			fmt.Fprintf(out, "  %s\n", color.Faint("Parameters (set with --override <name>=<value>):"))
+			w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+			for _, param := range info.Parameters {
+				fmt.Fprintf(w, "    %s\t%s", param.Name, param.Description)
+				if param.Default != "" {
+					fmt.Fprintf(w, " %s", color.Faint(fmt.Sprintf("(default: %s)", param.Default)))
+				}
+				fmt.Fprintln(w)
+			}
+			w.Flush()
+		}
+	}
+
 	return nil
 }
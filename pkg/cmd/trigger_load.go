@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/fixtures"
+)
+
+// rateUnits are the units of --rate
+var rateUnits = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+}
+
+// parseRate returns the interval between triggers of a rate like `20/s` or
+// `30/m`.
+func parseRate(rate string) (time.Duration, error) {
+	invalid := fmt.Errorf("invalid --rate ‘%s’, expected a rate like 20/s, 30/m or 100/h", rate)
+
+	parts := strings.SplitN(rate, "/", 2)
+	if len(parts) != 2 {
+		return 0, invalid
+	}
+
+	count, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil || count <= 0 {
+		return 0, invalid
+	}
+
+	unit, ok := rateUnits[parts[1]]
+	if !ok {
+		return 0, invalid
+	}
+
+	interval := time.Duration(float64(unit) / count)
+	if interval <= 0 {
+		return 0, invalid
+	}
+
+	return interval, nil
+}
+
+// loadResult collects the latencies and errors of the triggers of an event
+// run by runContinuous
+type loadResult struct {
+	event string
+	// objects is the number of objects a trigger of the event creates
+	objects   int
+	latencies []time.Duration
+	failed    int
+	// errors counts the failures by error message
+	errors map[string]int
+}
+
+// runContinuous triggers the events every interval, until duration elapsed
+// or the command is interrupted, then prints the latencies and errors of the
+// triggers. Up to --concurrency triggers run at once: the ticks that come
+// while they're all running are skipped and counted, since the rate can't be
+// kept up.
+func (tc *triggerCmd) runContinuous(ctx context.Context, events []string, apiKey string, interval, duration time.Duration) error {
+	results := make([]*loadResult, len(events))
+	objectsPerRound := 0
+	for i, event := range events {
+		fixture, err := fixtures.BuildTrigger(event, tc.stripeAccount, tc.apiBaseURL, apiKey, tc.skip, tc.override, tc.add, tc.remove, tc.raw)
+		if err != nil {
+			return err
+		}
+
+		results[i] = &loadResult{event: event, objects: fixture.RequestCount(), errors: make(map[string]int)}
+		objectsPerRound += results[i].objects
+	}
+
+	ledger := &fixtures.LoadLedger{
+		Fs:   config.StateFs(tc.fs),
+		Path: filepath.Join(Config.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")), "trigger_usage.json"),
+	}
+
+	createdLastHour := 0
+	if !tc.ignoreLimits {
+		var err error
+		if createdLastHour, err = ledger.CreatedLastHour(); err != nil {
+			return err
+		}
+
+		// Fail early when the whole run would exceed the limits
+		if duration > 0 {
+			rounds := int(duration/interval) + 1
+			if err := tc.loadLimits().Check(objectsPerRound*rounds, createdLastHour); err != nil {
+				return err
+			}
+		}
+	}
+
+	if duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, duration)
+		defer cancel()
+	}
+
+	jobs := make(chan *loadResult, tc.concurrency)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	created := 0
+
+	for w := 0; w < tc.concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for result := range jobs {
+				// Let the triggers running when the run ends finish, rather
+				// than count them as failures
+				start := time.Now()
+				t, err := tc.trigger(context.Background(), result.event, apiKey)
+				elapsed := time.Since(start)
+
+				mu.Lock()
+				created += len(t.requestNames)
+				if err != nil {
+					result.failed++
+					result.errors[strings.TrimSpace(err.Error())]++
+				} else {
+					result.latencies = append(result.latencies, elapsed)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	started := time.Now()
+	skipped := 0
+	// dispatched counts the objects of the triggers sent to the workers,
+	// which the limits apply to before they're created
+	dispatched := 0
+	var limitErr error
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+loop:
+	for {
+		if !tc.ignoreLimits {
+			if err := tc.loadLimits().Check(dispatched+objectsPerRound, createdLastHour); err != nil {
+				limitErr = err
+				break
+			}
+		}
+
+		for _, result := range results {
+			select {
+			case jobs <- result:
+				dispatched += result.objects
+			default:
+				skipped++
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			break loop
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	// Record the triggers that succeeded even if others failed
+	ledger.Record(created) // #nosec G104
+
+	printLoadSummary(os.Stdout, results, time.Since(started), skipped)
+
+	if limitErr != nil {
+		return fmt.Errorf("stopped before exceeding the limits: %v", limitErr)
+	}
+
+	failed := 0
+	total := 0
+	for _, result := range results {
+		failed += result.failed
+		total += result.failed + len(result.latencies)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d triggers failed", failed, total)
+	}
+
+	return nil
+}
+
+// printLoadSummary prints a table of the latencies of the triggers of each
+// event, then their errors, most frequent first.
+func printLoadSummary(out io.Writer, results []*loadResult, elapsed time.Duration, skipped int) {
+	fmt.Fprintln(out)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "EVENT\tSUCCEEDED\tFAILED\tP50\tP95\tMAX")
+	for _, result := range results {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\t%s\n",
+			result.event,
+			len(result.latencies),
+			result.failed,
+			percentile(result.latencies, 0.5),
+			percentile(result.latencies, 0.95),
+			percentile(result.latencies, 1),
+		)
+	}
+	w.Flush()
+
+	fmt.Fprintf(out, "\nRan for %s.", elapsed.Round(time.Second))
+	if skipped > 0 {
+		fmt.Fprintf(out, " %d triggers were skipped because the previous ones were still running, raise --concurrency to keep up with the rate.", skipped)
+	}
+	fmt.Fprintln(out)
+
+	color := ansi.Color(out)
+	for _, result := range results {
+		messages := make([]string, 0, len(result.errors))
+		for message := range result.errors {
+			messages = append(messages, message)
+		}
+		sort.Slice(messages, func(i, j int) bool {
+			if result.errors[messages[i]] != result.errors[messages[j]] {
+				return result.errors[messages[i]] > result.errors[messages[j]]
+			}
+			return messages[i] < messages[j]
+		})
+
+		for _, message := range messages {
+			fmt.Fprintf(out, "\n%s %s (%d×): %s\n", color.Red("✘").String(), result.event, result.errors[message], message)
+		}
+	}
+
+	fmt.Fprintln(out)
+}
+
+// percentile returns the latency below which the fraction p of latencies
+// are, or `-` if there are none.
+func percentile(latencies []time.Duration, p float64) string {
+	if len(latencies) == 0 {
+		return "-"
+	}
+
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	index := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if index < 0 {
+		index = 0
+	}
+
+	return sorted[index].Round(time.Millisecond).String()
+}
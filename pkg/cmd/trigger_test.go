@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/fixtures"
+)
+
+func TestRunBatch(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{"id": "obj_123"}`))
+	}))
+	defer ts.Close()
+
+	tc := newTriggerCmd()
+	tc.fs = afero.NewOsFs()
+	tc.apiBaseURL = ts.URL
+	tc.count = 3
+	tc.concurrency = 2
+	tc.ignoreLimits = true
+
+	err := tc.runBatch(context.Background(), []string{"customer.created", "product.created"}, "sk_test_123", os.Stdout)
+	require.NoError(t, err)
+
+	// customer.created and product.created each make a single request
+	require.Equal(t, int32(6), atomic.LoadInt32(&requests))
+}
+
+func TestRunBatchFailures(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": {"type": "invalid_request_error", "message": "Nope"}}`))
+	}))
+	defer ts.Close()
+
+	tc := newTriggerCmd()
+	tc.fs = afero.NewOsFs()
+	tc.apiBaseURL = ts.URL
+	tc.count = 2
+	tc.concurrency = 4
+	tc.ignoreLimits = true
+
+	err := tc.runBatch(context.Background(), []string{"customer.created"}, "sk_test_123", os.Stdout)
+	require.EqualError(t, err, "2 of 2 triggers failed")
+}
+
+func TestRunBatchJSONOutput(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": "cus_123", "object": "customer"}`))
+	}))
+	defer ts.Close()
+
+	tc := newTriggerCmd()
+	tc.fs = afero.NewOsFs()
+	tc.apiBaseURL = ts.URL
+	tc.count = 2
+	tc.ignoreLimits = true
+	tc.output = "json"
+
+	var out bytes.Buffer
+	err := tc.runBatch(context.Background(), []string{"customer.created"}, "sk_test_123", &out)
+	require.NoError(t, err)
+
+	var outputs []triggerOutput
+	require.NoError(t, json.Unmarshal(out.Bytes(), &outputs))
+	require.Len(t, outputs, 2)
+	for _, output := range outputs {
+		require.Equal(t, "customer.created", output.Event)
+		require.Equal(t, []fixtures.Resource{{Name: "customer", Type: "customer", ID: "cus_123"}}, output.Resources)
+		// The objects created are recorded to be torn down later
+		require.NotEmpty(t, output.TeardownRun)
+		require.Empty(t, output.Error)
+	}
+}
+
+func TestPrintTriggerSummary(t *testing.T) {
+	var out bytes.Buffer
+
+	printTriggerSummary(&out, []*triggerResult{
+		{event: "customer.created", succeeded: 2, duration: 300 * time.Millisecond},
+		{event: "invoice.paid", succeeded: 1, failed: 1, duration: time.Second, err: errors.New("Trigger failed: boom\n")},
+	})
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Equal(t, "EVENT             SUCCEEDED  FAILED  AVG DURATION", lines[0])
+	require.Equal(t, "customer.created  2          0       150ms", lines[1])
+	require.Equal(t, "invoice.paid      1          1       500ms", lines[2])
+	require.Contains(t, lines[4], "invoice.paid: Trigger failed: boom")
+}
+
+func TestParseRate(t *testing.T) {
+	interval, err := parseRate("20/s")
+	require.NoError(t, err)
+	require.Equal(t, 50*time.Millisecond, interval)
+
+	interval, err = parseRate("30/m")
+	require.NoError(t, err)
+	require.Equal(t, 2*time.Second, interval)
+
+	for _, rate := range []string{"20", "0/s", "-1/s", "20/d", "fast/s"} {
+		_, err := parseRate(rate)
+		require.EqualError(t, err, "invalid --rate ‘"+rate+"’, expected a rate like 20/s, 30/m or 100/h")
+	}
+}
+
+func TestRunContinuous(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1)%2 == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": {"type": "invalid_request_error", "message": "Nope"}}`))
+			return
+		}
+		w.Write([]byte(`{"id": "obj_123"}`))
+	}))
+	defer ts.Close()
+
+	tc := newTriggerCmd()
+	tc.fs = afero.NewOsFs()
+	tc.apiBaseURL = ts.URL
+	tc.concurrency = 2
+	tc.ignoreLimits = true
+
+	err := tc.runContinuous(context.Background(), []string{"customer.created"}, "sk_test_123", 20*time.Millisecond, 110*time.Millisecond)
+	require.Error(t, err)
+	require.Regexp(t, `^\d+ of \d+ triggers failed$`, err.Error())
+	require.GreaterOrEqual(t, atomic.LoadInt32(&requests), int32(4))
+}
+
+func TestRunContinuousStopsAtLimits(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{"id": "obj_123"}`))
+	}))
+	defer ts.Close()
+
+	tc := newTriggerCmd()
+	tc.fs = afero.NewOsFs()
+	tc.apiBaseURL = ts.URL
+	tc.concurrency = 1
+
+	// Leave room for 3 objects in the hourly limit
+	ledger := &fixtures.LoadLedger{
+		Fs:   tc.fs,
+		Path: filepath.Join(Config.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")), "trigger_usage.json"),
+	}
+	require.NoError(t, os.MkdirAll(filepath.Dir(ledger.Path), 0700))
+	require.NoError(t, ledger.Record(fixtures.DefaultMaxObjectsPerHour-3))
+
+	err := tc.runContinuous(context.Background(), []string{"customer.created"}, "sk_test_123", time.Millisecond, 0)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "stopped before exceeding the limits")
+	require.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
+
+func TestPrintLoadSummary(t *testing.T) {
+	var out bytes.Buffer
+
+	printLoadSummary(&out, []*loadResult{
+		{event: "customer.created", latencies: []time.Duration{100 * time.Millisecond, 300 * time.Millisecond, 200 * time.Millisecond}},
+		{event: "invoice.paid", failed: 3, errors: map[string]int{"Trigger failed: boom": 2, "Trigger failed: bang": 1}},
+	}, 10*time.Second, 4)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Equal(t, "EVENT             SUCCEEDED  FAILED  P50    P95    MAX", lines[0])
+	require.Equal(t, "customer.created  3          0       200ms  300ms  300ms", lines[1])
+	require.Equal(t, "invoice.paid      0          3       -      -      -", lines[2])
+	require.Contains(t, lines[4], "Ran for 10s. 4 triggers were skipped")
+	require.Contains(t, lines[6], "invoice.paid (2×): Trigger failed: boom")
+	require.Contains(t, lines[8], "invoice.paid (1×): Trigger failed: bang")
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/selfupdate"
+	"github.com/stripe/stripe-cli/pkg/validators"
+	"github.com/stripe/stripe-cli/pkg/version"
+)
+
+// packageManagerPaths are parts of the paths the package managers install
+// the CLI to, by package manager. Those installs are updated with the
+// package manager instead.
+var packageManagerPaths = map[string]string{
+	"/Cellar/":    "Homebrew: brew upgrade stripe/stripe-cli/stripe",
+	"/homebrew/":  "Homebrew: brew upgrade stripe/stripe-cli/stripe",
+	"\\scoop\\":   "Scoop: scoop update stripe",
+	"/usr/bin/":   "the package manager of your system, e.g. apt or yum",
+	"/snap/":      "snap: snap refresh stripe",
+	"/linuxbrew/": "Homebrew: brew upgrade stripe/stripe-cli/stripe",
+}
+
+type updateCmd struct {
+	cmd *cobra.Command
+
+	channel string
+	check   bool
+	force   bool
+}
+
+func newUpdateCmd() *updateCmd {
+	uc := &updateCmd{}
+
+	uc.cmd = &cobra.Command{
+		Use:   "update",
+		Args:  validators.NoArgs,
+		Short: "Update the CLI to its latest release",
+		Long: `Update the CLI to its latest release, for installs that don't use a package
+manager. The checksums of the release are verified against the signature of
+the release, and the binary against the checksums, before it replaces the
+current one.
+
+The stable channel gets the latest release, and the beta channel the latest
+release including prereleases.`,
+		Example: `stripe update
+  stripe update --check
+  stripe update --channel beta`,
+		RunE: uc.runUpdateCmd,
+	}
+
+	uc.cmd.Flags().StringVar(&uc.channel, "channel", selfupdate.ChannelStable, "Release channel to update from (stable or beta)")
+	uc.cmd.Flags().BoolVar(&uc.check, "check", false, "Only check whether an update is available")
+	uc.cmd.Flags().BoolVar(&uc.force, "force", false, "Update even if the CLI was installed with a package manager, or is up to date")
+
+	return uc
+}
+
+func (uc *updateCmd) runUpdateCmd(cmd *cobra.Command, args []string) error {
+	if version.Version == "master" && !uc.force {
+		return fmt.Errorf("this CLI was built from source, update it by building it again or use --force")
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	executable, err = filepath.EvalSymlinks(executable)
+	if err != nil {
+		return err
+	}
+
+	if manager := installedWith(executable); manager != "" && !uc.force {
+		return fmt.Errorf("this CLI was installed with a package manager, update it with %s, or use --force", manager)
+	}
+
+	release, err := selfupdate.Latest(cmd.Context(), uc.channel)
+	if err != nil {
+		return fmt.Errorf("could not look up the latest release: %w", err)
+	}
+
+	current := strings.TrimPrefix(version.Version, "v")
+	if release.Version == current && !uc.force {
+		fmt.Printf("The CLI is up to date (%s)\n", current)
+		return nil
+	}
+
+	if uc.check {
+		fmt.Printf("Version %s is available, you have %s. Run `stripe update` to update.\n", ansi.Bold(release.Version), current)
+		return nil
+	}
+
+	fmt.Printf("Downloading version %s...\n", release.Version)
+
+	binary, err := selfupdate.Download(cmd.Context(), release)
+	if err != nil {
+		return err
+	}
+
+	if err := selfupdate.Replace(executable, binary); err != nil {
+		return err
+	}
+
+	color := ansi.Color(os.Stdout)
+	fmt.Printf("%s Updated %s to version %s\n", color.Green("✔"), executable, ansi.Bold(release.Version))
+
+	return nil
+}
+
+// installedWith returns how to update the CLI with the package manager it
+// was installed with, if any
+func installedWith(executable string) string {
+	for path, manager := range packageManagerPaths {
+		if strings.Contains(executable, path) {
+			return manager
+		}
+	}
+
+	return ""
+}
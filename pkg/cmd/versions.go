@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/apiversions"
+	"github.com/stripe/stripe-cli/pkg/download"
+	"github.com/stripe/stripe-cli/pkg/logtailing"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+// changelogDownloadTimeout is how long downloading a changelog given as a URL
+// may take
+const changelogDownloadTimeout = time.Minute
+
+// maxChangelogSize caps the size of a changelog given as a URL
+const maxChangelogSize = 10 * 1024 * 1024
+
+// usedEndpointsFromLogs is the value of --used-endpoints-from reading the
+// endpoints from the recent request logs of the account instead of a file
+const usedEndpointsFromLogs = "logs"
+
+type versionsCmd struct {
+	cmd *cobra.Command
+}
+
+func newVersionsCmd() *versionsCmd {
+	vc := &versionsCmd{
+		cmd: &cobra.Command{
+			Use:   "versions",
+			Args:  validators.NoArgs,
+			Short: "Plan upgrades between Stripe API versions",
+		},
+	}
+
+	vc.cmd.AddCommand(newVersionsDiffCmd().cmd)
+
+	return vc
+}
+
+type versionsDiffCmd struct {
+	cmd *cobra.Command
+	fs  afero.Fs
+
+	changelog         string
+	usedEndpointsFrom string
+	onlyAffected      bool
+}
+
+func newVersionsDiffCmd() *versionsDiffCmd {
+	vc := &versionsDiffCmd{
+		fs: afero.NewOsFs(),
+	}
+
+	vc.cmd = &cobra.Command{
+		Use:   "diff <from> <to>",
+		Args:  validators.ExactArgs(2),
+		Short: "List the changes between two API versions that affect your integration",
+		Long: `List the changes introduced between two API versions and highlight the ones
+affecting endpoints your integration calls.
+
+The changes are read from the changelog bundled with the CLI, which lists
+the major breaking changes of each API version. Use --changelog to read
+them from another changelog in JSON instead, from a file or URL:
+
+  {"versions": [{"version": "2024-04-10", "changes": [
+    {"description": "...", "breaking": true, "endpoints": ["POST /v1/customers/{id}"]}
+  ]}]}
+
+The endpoints you use are read from request logs captured with
+` + "`stripe logs tail --format JSON > requests.log`" + `. With
+--used-endpoints-from logs, they're read from the ` + fmt.Sprint(logtailing.MaxBackfill) + ` most recent request
+logs of your account instead. Use ./logs to read a file named logs.
+
+The bundled changelog may not list the most recent API versions yet. Use
+--changelog to compare them.`,
+		Example: `stripe versions diff 2020-08-27 2024-04-10 --used-endpoints-from requests.log
+  stripe versions diff 2023-10-16 2024-04-10 --used-endpoints-from logs`,
+		RunE: vc.runVersionsDiffCmd,
+	}
+
+	vc.cmd.Flags().StringVar(&vc.changelog, "changelog", "", "Path or URL of a changelog to read changes from instead of the bundled one")
+	vc.cmd.Flags().StringVar(&vc.usedEndpointsFrom, "used-endpoints-from", "", "Path of request logs captured with `stripe logs tail --format JSON`, or logs to read the recent request logs of your account")
+	vc.cmd.Flags().BoolVar(&vc.onlyAffected, "only-affected", false, "Only list changes affecting the endpoints you use")
+
+	return vc
+}
+
+func (vc *versionsDiffCmd) runVersionsDiffCmd(cmd *cobra.Command, args []string) error {
+	changelog, err := vc.loadChangelog(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	var usage map[string]int
+	if vc.usedEndpointsFrom != "" {
+		usage, err = vc.loadUsage(cmd.Context())
+		if err != nil {
+			return err
+		}
+	} else if vc.onlyAffected {
+		return fmt.Errorf("--only-affected requires --used-endpoints-from")
+	}
+
+	impacts, err := changelog.Diff(args[0], args[1], usage)
+	if err != nil {
+		return err
+	}
+
+	color := ansi.Color(os.Stdout)
+	version := ""
+	affecting := 0
+
+	for _, impact := range impacts {
+		if len(impact.Affected) > 0 {
+			affecting++
+		} else if vc.onlyAffected {
+			continue
+		}
+
+		if impact.Version != version {
+			version = impact.Version
+			fmt.Printf("\n%s\n", ansi.Bold(version))
+		}
+
+		label := "  "
+		if impact.Change.Breaking {
+			label = color.Yellow("! ").String()
+		}
+		fmt.Printf("%s%s\n", label, impact.Change.Description)
+
+		endpoints := make([]string, 0, len(impact.Affected))
+		for endpoint := range impact.Affected {
+			endpoints = append(endpoints, endpoint)
+		}
+		sort.Strings(endpoints)
+
+		for _, endpoint := range endpoints {
+			fmt.Printf("    %s %s (%d requests)\n", color.Red("affects"), endpoint, impact.Affected[endpoint])
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("%d changes between %s and %s", len(impacts), args[0], args[1])
+	if usage != nil {
+		fmt.Printf(", %d affecting endpoints you use", affecting)
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// loadUsage counts the requests made to each endpoint in the request logs of
+// --used-endpoints-from
+func (vc *versionsDiffCmd) loadUsage(ctx context.Context) (map[string]int, error) {
+	if vc.usedEndpointsFrom != usedEndpointsFromLogs {
+		f, err := vc.fs.Open(vc.usedEndpointsFrom)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		return apiversions.ParseRequestLogs(f)
+	}
+
+	key, err := Config.Profile.GetAPIKey(false)
+	if err != nil {
+		return nil, err
+	}
+
+	backfill := &logtailing.Backfill{
+		Key:     key,
+		Filters: &logtailing.LogFilters{},
+		Last:    logtailing.MaxBackfill,
+	}
+
+	elements, err := backfill.Fetch(ctx)
+	if errors.Is(err, logtailing.ErrBackfillUnavailable) {
+		return nil, fmt.Errorf("--used-endpoints-from logs is unavailable: %v. Capture request logs with `stripe logs tail --format JSON > requests.log` and use --used-endpoints-from requests.log instead", err)
+	} else if err != nil {
+		return nil, fmt.Errorf("Error while fetching the recent request logs: %v", err)
+	}
+
+	usage := make(map[string]int)
+	for _, el := range elements {
+		if payload, ok := el.Data.(logtailing.EventPayload); ok {
+			apiversions.CountRequest(usage, payload.Method, payload.URL)
+		}
+	}
+
+	return usage, nil
+}
+
+func (vc *versionsDiffCmd) loadChangelog(ctx context.Context) (*apiversions.Changelog, error) {
+	if vc.changelog == "" {
+		return apiversions.DefaultChangelog()
+	}
+
+	var r io.ReadCloser
+
+	if strings.HasPrefix(vc.changelog, "http://") || strings.HasPrefix(vc.changelog, "https://") {
+		data, err := download.File(ctx, vc.changelog, changelogDownloadTimeout, maxChangelogSize)
+		if err != nil {
+			return nil, err
+		}
+
+		r = ioutil.NopCloser(bytes.NewReader(data))
+	} else {
+		f, err := vc.fs.Open(vc.changelog)
+		if err != nil {
+			return nil, err
+		}
+
+		r = f
+	}
+
+	defer r.Close()
+
+	return apiversions.ParseChangelog(r)
+}
@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/proxy"
+	"github.com/stripe/stripe-cli/pkg/validators"
+	"github.com/stripe/stripe-cli/pkg/webhooks"
+)
+
+type webhooksCmd struct {
+	cmd *cobra.Command
+}
+
+func newWebhooksCmd() *webhooksCmd {
+	wc := &webhooksCmd{
+		cmd: &cobra.Command{
+			Use:   "webhooks",
+			Args:  validators.NoArgs,
+			Short: "Tools for testing webhook endpoints",
+		},
+	}
+
+	wc.cmd.AddCommand(newGenerateVectorsCmd().cmd)
+	wc.cmd.AddCommand(newReceiveCmd().cmd)
+
+	return wc
+}
+
+type generateVectorsCmd struct {
+	cmd *cobra.Command
+
+	secret    string
+	out       string
+	payload   string
+	timestamp int64
+	tolerance time.Duration
+	fs        afero.Fs
+}
+
+func newGenerateVectorsCmd() *generateVectorsCmd {
+	gvc := &generateVectorsCmd{
+		fs: afero.NewOsFs(),
+	}
+
+	gvc.cmd = &cobra.Command{
+		Use:   "generate-vectors",
+		Args:  validators.NoArgs,
+		Short: "Generate signed payloads to test webhook signature verification",
+		Long: `Generate a JSON suite of payloads and Stripe-Signature headers, signed with a
+webhook signing secret, for the unit tests of the code that verifies webhooks.
+Each vector is valid or deliberately invalid: an expired timestamp, a tampered
+body, a signature of the wrong secret, or a malformed header.
+
+Vectors must be verified as of the "now" timestamp of the file, e.g. by mocking
+the clock, with the tolerance of the file.`,
+		Example: `stripe webhooks generate-vectors --secret whsec_test_123 --out vectors.json
+  stripe webhooks generate-vectors --secret whsec_test_123 --payload event.json --timestamp 1700000000`,
+		RunE: gvc.runGenerateVectorsCmd,
+	}
+
+	gvc.cmd.Flags().StringVar(&gvc.secret, "secret", "", "Webhook signing secret to sign the payloads with, starting with whsec_")
+	gvc.cmd.Flags().StringVar(&gvc.out, "out", "", "File to write the vectors to (default: stdout)")
+	gvc.cmd.Flags().StringVar(&gvc.payload, "payload", "", "File of the payload to sign (default: a payment_intent.succeeded event)")
+	gvc.cmd.Flags().Int64Var(&gvc.timestamp, "timestamp", 0, "Unix timestamp to sign the payloads at, for reproducible vectors (default: now)")
+	gvc.cmd.Flags().DurationVar(&gvc.tolerance, "tolerance", webhooks.DefaultTolerance, "Tolerance of the verification code for the age of signatures")
+
+	gvc.cmd.MarkFlagRequired("secret")
+
+	return gvc
+}
+
+func (gvc *generateVectorsCmd) runGenerateVectorsCmd(cmd *cobra.Command, args []string) error {
+	payload := webhooks.DefaultPayload
+	if gvc.payload != "" {
+		data, err := afero.ReadFile(gvc.fs, gvc.payload)
+		if err != nil {
+			return err
+		}
+		payload = string(data)
+	}
+
+	now := time.Now()
+	if gvc.timestamp != 0 {
+		now = time.Unix(gvc.timestamp, 0)
+	}
+
+	vectors, err := webhooks.GenerateVectors(gvc.secret, payload, now, gvc.tolerance)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(vectors, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if gvc.out == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+
+	if err := afero.WriteFile(gvc.fs, gvc.out, data, 0644); err != nil {
+		return err
+	}
+
+	color := ansi.Color(os.Stdout)
+	fmt.Printf("%s Wrote %d vectors to %s\n", color.Green("✔"), len(vectors.Vectors), ansi.Bold(gvc.out))
+
+	return nil
+}
+
+type receiveCmd struct {
+	cmd *cobra.Command
+
+	port       string
+	path       string
+	secret     string
+	skipVerify bool
+	tolerance  time.Duration
+	status     int
+	delay      time.Duration
+	logFile    string
+	apiBaseURL string
+}
+
+func newReceiveCmd() *receiveCmd {
+	rc := &receiveCmd{}
+
+	rc.cmd = &cobra.Command{
+		Use:   "receive",
+		Args:  validators.NoArgs,
+		Short: "Receive webhooks on a local endpoint, to test their delivery",
+		Long: `Run a local webhook endpoint that verifies the Stripe-Signature header of the
+events it receives, prints them, and responds with a configurable status, to
+test the delivery of webhooks before the endpoint of your app exists.
+
+Signatures are verified with the signing secret of stripe listen, unless
+--secret is given, so events can be forwarded to it with:
+
+  stripe listen --forward-to localhost:4242/webhook
+
+Events whose signature is invalid get a 400. Use --status and --delay to see
+how deliveries are retried when the endpoint fails or times out.`,
+		Example: `stripe webhooks receive
+  stripe webhooks receive --port 4242 --secret whsec_test_123
+  stripe webhooks receive --status 500 --delay 5s --log deliveries.ndjson`,
+		RunE: rc.runReceiveCmd,
+	}
+
+	rc.cmd.Flags().StringVar(&rc.port, "port", "4242", "Port to receive webhooks on")
+	rc.cmd.Flags().StringVar(&rc.path, "path", "/webhook", "Path of the endpoint")
+	rc.cmd.Flags().StringVar(&rc.secret, "secret", "", "Webhook signing secret to verify signatures with (default: the signing secret of stripe listen)")
+	rc.cmd.Flags().BoolVar(&rc.skipVerify, "skip-verify", false, "Don't verify signatures")
+	rc.cmd.Flags().DurationVar(&rc.tolerance, "tolerance", webhooks.DefaultTolerance, "Tolerance for the age of signatures")
+	rc.cmd.Flags().IntVar(&rc.status, "status", http.StatusOK, "Status to respond to the verified events with")
+	rc.cmd.Flags().DurationVar(&rc.delay, "delay", 0, "How long to wait before responding, e.g. 5s")
+	rc.cmd.Flags().StringVar(&rc.logFile, "log", "", "File to append the deliveries to, as NDJSON")
+
+	// Hidden configuration flags, useful for dev/debugging
+	rc.cmd.Flags().StringVar(&rc.apiBaseURL, "api-base", "", "Sets the API base URL")
+	rc.cmd.Flags().MarkHidden("api-base") // #nosec G104
+
+	return rc
+}
+
+func (rc *receiveCmd) runReceiveCmd(cmd *cobra.Command, args []string) error {
+	if rc.status < 100 || rc.status > 599 {
+		return fmt.Errorf("invalid --status ‘%d’, it must be an HTTP status", rc.status)
+	}
+	if rc.skipVerify && rc.secret != "" {
+		return fmt.Errorf("--secret cannot be used with --skip-verify")
+	}
+
+	ctx := withSIGTERMCancel(cmd.Context(), func() {
+		log.WithFields(log.Fields{
+			"prefix": "cmd.receiveCmd.runReceiveCmd",
+		}).Debug("Ctrl+C received, cleaning up...")
+	})
+
+	secret := rc.secret
+	if secret == "" && !rc.skipVerify {
+		var err error
+		secret, err = rc.listenSecret(ctx)
+		if err != nil {
+			return fmt.Errorf("could not get the signing secret of stripe listen, use --secret or --skip-verify: %w", err)
+		}
+	}
+
+	receiver := &webhooks.Receiver{
+		Secret:    secret,
+		Tolerance: rc.tolerance,
+		Status:    rc.status,
+		Delay:     rc.delay,
+		Out:       os.Stdout,
+	}
+
+	if rc.logFile != "" {
+		f, err := os.OpenFile(rc.logFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		receiver.Log = f
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(rc.path, receiver)
+
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%s", rc.port),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		server.Close() // #nosec G104
+	}()
+
+	verifying := "without verifying signatures"
+	if secret != "" {
+		verifying = "verifying signatures with " + ansi.Bold(secret)
+	}
+	fmt.Printf("Ready! Receiving webhooks at http://localhost:%s%s, %s (^C to quit)\n", rc.port, rc.path, verifying)
+
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+
+	return nil
+}
+
+// listenSecret returns the webhook signing secret of stripe listen, which
+// signs the events it forwards
+func (rc *receiveCmd) listenSecret(ctx context.Context) (string, error) {
+	deviceName, err := Config.Profile.GetDeviceName()
+	if err != nil {
+		return "", err
+	}
+
+	key, err := Config.Profile.GetAPIKey(false)
+	if err != nil {
+		return "", err
+	}
+
+	return proxy.GetSessionSecret(ctx, deviceName, key, rc.apiBaseURL, Config.Profile.GetProxy())
+}
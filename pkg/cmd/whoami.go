@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type whoamiCmd struct {
+	cmd    *cobra.Command
+	config *config.Config
+}
+
+func newWhoamiCmd(cfg *config.Config) *whoamiCmd {
+	wc := &whoamiCmd{
+		config: cfg,
+	}
+
+	wc.cmd = &cobra.Command{
+		Use:   "whoami",
+		Args:  validators.NoArgs,
+		Short: "Show the account and sandbox the current profile is logged in to",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printWhoami(os.Stdout, &wc.config.Profile)
+		},
+	}
+
+	return wc
+}
+
+// printWhoami prints the profile, and the account, sandbox and expiration of
+// the keys it's logged in with
+func printWhoami(w io.Writer, profile *config.Profile) error {
+	if _, err := profile.GetAPIKey(false); err != nil {
+		return err
+	}
+
+	color := ansi.Color(w)
+	fmt.Fprintf(w, "Profile:  %s\n", color.Bold(profile.ProfileName))
+
+	accountID, _ := profile.GetAccountID()
+	switch displayName := profile.GetDisplayName(); {
+	case displayName != "" && accountID != "":
+		fmt.Fprintf(w, "Account:  %s (%s)\n", displayName, accountID)
+	case accountID != "":
+		fmt.Fprintf(w, "Account:  %s\n", accountID)
+	}
+
+	if sandboxID, sandboxName := profile.GetSandbox(); sandboxID != "" {
+		fmt.Fprintf(w, "Sandbox:  %s (%s)\n", sandboxName, sandboxID)
+	}
+
+	if expiresAt, ok := profile.GetKeyExpiration(); ok {
+		fmt.Fprintf(w, "Keys:     expire on %s\n", expiresAt.Local().Format("January 2, 2006"))
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+)
+
+func TestPrintWhoami(t *testing.T) {
+	t.Setenv("STRIPE_API_KEY", "")
+	t.Setenv("STRIPE_ACCOUNT_ID", "")
+
+	profilesFile := filepath.Join(t.TempDir(), "config.toml")
+	err := ioutil.WriteFile(profilesFile, []byte(`
+[default]
+  account_id = "acct_123"
+  display_name = "Acme"
+  sandbox_id = "acct_sb1"
+  sandbox_name = "staging"
+  test_mode_api_key = "sk_test_1234567890abcdefghij"
+
+[empty]
+  device_name = "st-testing"
+`), 0600)
+	require.NoError(t, err)
+
+	viper.Reset()
+	viper.SetConfigFile(profilesFile)
+	defer viper.Reset()
+
+	var out bytes.Buffer
+	require.NoError(t, printWhoami(&out, &config.Profile{ProfileName: "default"}))
+	require.Equal(t, `Profile:  default
+Account:  Acme (acct_123)
+Sandbox:  staging (acct_sb1)
+`, out.String())
+
+	out.Reset()
+	require.Error(t, printWhoami(&out, &config.Profile{ProfileName: "empty"}))
+	require.Empty(t, out.String())
+}
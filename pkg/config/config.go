@@ -6,7 +6,9 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"sort"
 	"strings"
 	"time"
 
@@ -30,6 +32,20 @@ const ColorOff = "off"
 // ColorAuto represents the auto-state for colors
 const ColorAuto = "auto"
 
+// ColorAlways is an alias of ColorOn, as for other CLIs like ls or grep
+const ColorAlways = "always"
+
+// ColorNever is an alias of ColorOff
+const ColorNever = "never"
+
+// DefaultProfileName is the name of the profile used when no other profile
+// is selected
+const DefaultProfileName = "default"
+
+// defaultProfileField is the config field of the profile selected with
+// `stripe profile use`
+const defaultProfileField = "default_profile"
+
 // IConfig allows us to add more implementations, such as ones for unit tests
 type IConfig interface {
 	GetProfile() *Profile
@@ -45,11 +61,31 @@ type IConfig interface {
 
 // Config handles all overall configuration for the CLI
 type Config struct {
-	Color            string
-	LogLevel         string
-	Profile          Profile
-	ProfilesFile     string
+	Color        string
+	LogLevel     string
+	Profile      Profile
+	ProfilesFile string
+	ReadOnly     bool
+	ConfirmLive  bool
+	// ErrorFormat is the format errors are printed in, text or json
+	ErrorFormat string
+	// MaxRPS is the number of API requests per second sent at most, 0 for
+	// no limit
+	MaxRPS float64
+	// Resolve are the --resolve overrides of the addresses of hosts, like
+	// api.stripe.com:443:10.0.0.5
+	Resolve []string
+	// IPv4 and IPv6 are whether connections are only made with IPv4 or IPv6
+	IPv4 bool
+	IPv6 bool
+	// Mock is whether API requests are sent to the stripe-mock server
+	// started with `stripe mock start`
+	Mock             bool
 	InstalledPlugins []string
+
+	// ProfileNameSet is whether the profile was selected with --project-name,
+	// in which case the profile selected with `stripe profile use` is ignored
+	ProfileNameSet bool
 }
 
 // GetProfile returns the Profile of the config
@@ -83,6 +119,29 @@ func (c *Config) GetConfigFolder(xdgPath string) string {
 	return stripeConfigPath
 }
 
+// GetSamplesCacheDir returns the directory where samples are cached: the
+// STRIPE_SAMPLES_CACHE environment variable, or the samples_cache_dir setting,
+// e.g. for a cache shared by several users or baked into a container image.
+// It defaults to samples-cache in the config folder.
+func (c *Config) GetSamplesCacheDir() string {
+	if dir := os.Getenv("STRIPE_SAMPLES_CACHE"); dir != "" {
+		return dir
+	}
+
+	if err := viper.ReadInConfig(); err == nil {
+		if dir := viper.GetString("samples_cache_dir"); dir != "" {
+			return dir
+		}
+		if c != nil {
+			if dir := viper.GetString(c.Profile.GetConfigField("samples_cache_dir")); dir != "" {
+				return dir
+			}
+		}
+	}
+
+	return filepath.Join(c.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")), "samples-cache")
+}
+
 // InitConfig reads in profiles file and ENV variables if set.
 func (c *Config) InitConfig() {
 	logFormatter := &prefixed.TextFormatter{
@@ -108,9 +167,12 @@ func (c *Config) InitConfig() {
 		log.Fatalf("Unrecognized log level value: %s. Expected one of debug, info, warn, error.", c.LogLevel)
 	}
 
-	if c.ProfilesFile != "" {
+	switch {
+	case IsStateless():
+		// The config file is neither read nor written
+	case c.ProfilesFile != "":
 		viper.SetConfigFile(c.ProfilesFile)
-	} else {
+	default:
 		configFolder := c.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME"))
 		configFile := filepath.Join(configFolder, "config.toml")
 		c.ProfilesFile = configFile
@@ -134,6 +196,10 @@ func (c *Config) InitConfig() {
 		}).Debug("Using profiles file")
 	}
 
+	if !c.ProfileNameSet && c.Profile.ProfileName == DefaultProfileName {
+		c.Profile.ProfileName = c.GetDefaultProfile()
+	}
+
 	if c.Profile.DeviceName == "" {
 		deviceName, err := os.Hostname()
 		if err != nil {
@@ -148,6 +214,9 @@ func (c *Config) InitConfig() {
 		log.Fatalf("%s", err)
 	}
 
+	ansi.ForceColors = false
+	ansi.DisableColors = false
+
 	switch color {
 	case ColorOn:
 		ansi.ForceColors = true
@@ -156,10 +225,28 @@ func (c *Config) InitConfig() {
 		ansi.DisableColors = true
 		logFormatter.DisableColors = true
 	case ColorAuto:
-		// Nothing to do
+		logFormatter.DisableColors = ansi.NoColor()
 	default:
-		log.Fatalf("Unrecognized color value: %s. Expected one of on, off, auto.", c.Color)
+		log.Fatalf("Unrecognized color value: %s. Expected one of always, never, auto.", c.Color)
+	}
+
+	if err := ansi.SetTheme(GetColorTheme()); err != nil {
+		log.Fatalf("%s", err)
+	}
+	if scheme := ansi.CurrentTheme().LogColorScheme(); scheme != nil {
+		logFormatter.SetColorScheme(scheme)
 	}
+
+	ansi.ConfigurePrompts()
+}
+
+// ColorThemeField is the setting of the theme colors are shown with. It's
+// shared by every profile, since it depends on the terminal.
+const ColorThemeField = "color.theme"
+
+// GetColorTheme returns the theme colors are shown with
+func GetColorTheme() string {
+	return viper.GetString(ColorThemeField)
 }
 
 // EditConfig opens the configuration file in the default editor.
@@ -193,7 +280,8 @@ func (c *Config) EditConfig() error {
 	return err
 }
 
-// PrintConfig outputs the contents of the configuration file.
+// PrintConfig outputs the contents of the configuration file, followed by the
+// scopes of the API keys of the profile.
 func (c *Config) PrintConfig() error {
 	if c.Profile.ProfileName == "default" {
 		configFile, err := ioutil.ReadFile(c.ProfilesFile)
@@ -213,6 +301,8 @@ func (c *Config) PrintConfig() error {
 		}
 	}
 
+	c.Profile.printKeyScopes(os.Stdout)
+
 	return nil
 }
 
@@ -224,14 +314,123 @@ func (c *Config) GetInstalledPlugins() []string {
 	return runtimeViper.GetStringSlice("installed_plugins")
 }
 
+// GetPinnedPlugins returns the versions the plugins installed with `stripe
+// plugin install <name>@<version>` are pinned to, by plugin name. `stripe
+// plugin upgrade` keeps them at their version.
+func (c *Config) GetPinnedPlugins() map[string]string {
+	return viper.GetViper().GetStringMapString("pinned_plugins")
+}
+
+// GetPluginPermissions returns the permissions the user granted a plugin
+// whose API requests go through a broker
+func (c *Config) GetPluginPermissions(name string) []string {
+	return viper.GetViper().GetStringSlice("plugin_permissions." + name)
+}
+
+// ProfileNames returns the names of the profiles of the config file, sorted.
+func (c *Config) ProfileNames() []string {
+	var names []string
+
+	for field, value := range viper.GetViper().AllSettings() {
+		if isProfile(value) {
+			names = append(names, field)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// GetDefaultProfile returns the name of the profile used when --project-name
+// isn't set: the one selected with `stripe profile use`, or `default`.
+func (c *Config) GetDefaultProfile() string {
+	if name := viper.GetString(defaultProfileField); name != "" {
+		return name
+	}
+
+	return DefaultProfileName
+}
+
+// SetDefaultProfile selects the profile used when --project-name isn't set.
+func (c *Config) SetDefaultProfile(profileName string) error {
+	if profileName == DefaultProfileName {
+		runtimeViper, err := removeKey(viper.GetViper(), defaultProfileField)
+		if err != nil {
+			return err
+		}
+
+		return syncConfig(runtimeViper)
+	}
+
+	return c.WriteConfigField(defaultProfileField, profileName)
+}
+
+// profileNamePattern matches the names profiles can be given, which are keys
+// of the config file
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ValidateProfileName returns an error if name can't name a profile
+func ValidateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name ‘%s’, use letters, digits, - and _ only", name)
+	}
+
+	return nil
+}
+
+// RenameProfile renames the profile oldName of the config file to newName,
+// keeping it the default profile if it was.
+func (c *Config) RenameProfile(oldName, newName string) error {
+	if err := ValidateProfileName(newName); err != nil {
+		return err
+	}
+
+	runtimeViper := viper.GetViper()
+	settings := runtimeViper.AllSettings()
+
+	if !isProfile(settings[oldName]) {
+		return fmt.Errorf("profile ‘%s’ does not exist", oldName)
+	}
+	if _, ok := settings[newName]; ok {
+		return fmt.Errorf("profile ‘%s’ already exists", newName)
+	}
+
+	profile := settings[oldName].(map[string]interface{})
+	if err := renameProfileKeys(profile, newName); err != nil {
+		return err
+	}
+
+	runtimeViper.Set(newName, profile)
+	if c.GetDefaultProfile() == oldName {
+		runtimeViper.Set(defaultProfileField, newName)
+	}
+
+	runtimeViper, err := removeKey(runtimeViper, oldName)
+	if err != nil {
+		return err
+	}
+
+	return syncConfig(runtimeViper)
+}
+
 // RemoveProfile removes the profile whose name matches the provided
-// profileName from the config file.
+// profileName from the config file. The `default` profile is used again if it
+// was the default profile.
 func (c *Config) RemoveProfile(profileName string) error {
 	runtimeViper := viper.GetViper()
 	var err error
 
+	if c.GetDefaultProfile() == profileName {
+		runtimeViper, err = removeKey(runtimeViper, defaultProfileField)
+		if err != nil {
+			return err
+		}
+	}
+
 	for field, value := range runtimeViper.AllSettings() {
 		if isProfile(value) && field == profileName {
+			deleteProfileKeys(value)
 			runtimeViper, err = removeKey(runtimeViper, field)
 			if err != nil {
 				return err
@@ -244,11 +443,14 @@ func (c *Config) RemoveProfile(profileName string) error {
 
 // RemoveAllProfiles removes all the profiles from the config file.
 func (c *Config) RemoveAllProfiles() error {
-	runtimeViper := viper.GetViper()
-	var err error
+	runtimeViper, err := removeKey(viper.GetViper(), defaultProfileField)
+	if err != nil {
+		return err
+	}
 
 	for field, value := range runtimeViper.AllSettings() {
 		if isProfile(value) {
+			deleteProfileKeys(value)
 			runtimeViper, err = removeKey(runtimeViper, field)
 			if err != nil {
 				return err
@@ -269,6 +471,10 @@ func isProfile(value interface{}) bool {
 // WriteConfigField updates a configuration field and writes the updated
 // configuration to disk.
 func (c *Config) WriteConfigField(field string, value interface{}) error {
+	if IsStateless() {
+		return ErrStateless
+	}
+
 	runtimeViper := viper.GetViper()
 	runtimeViper.Set(field, value)
 
@@ -277,6 +483,10 @@ func (c *Config) WriteConfigField(field string, value interface{}) error {
 
 // syncConfig merges a runtimeViper instance with the config file being used.
 func syncConfig(runtimeViper *viper.Viper) error {
+	if IsStateless() {
+		return ErrStateless
+	}
+
 	runtimeViper.MergeInConfig()
 	profilesFile := viper.ConfigFileUsed()
 	runtimeViper.SetConfigFile(profilesFile)
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 
 	"github.com/spf13/viper"
@@ -18,3 +20,78 @@ func TestRemoveKey(t *testing.T) {
 	require.EqualValues(t, []string{"stay"}, nv.AllKeys())
 	require.ElementsMatch(t, []string{"stay", "remove"}, v.AllKeys())
 }
+
+func TestDefaultProfile(t *testing.T) {
+	profilesFile := filepath.Join(t.TempDir(), "config.toml")
+	err := ioutil.WriteFile(profilesFile, []byte(`
+[default]
+  display_name = "Main"
+[work]
+  display_name = "Work Co"
+`), 0600)
+	require.NoError(t, err)
+
+	viper.Reset()
+	c := &Config{
+		Color:        "auto",
+		LogLevel:     "info",
+		Profile:      Profile{ProfileName: DefaultProfileName},
+		ProfilesFile: profilesFile,
+	}
+	c.InitConfig()
+	require.Equal(t, DefaultProfileName, c.Profile.ProfileName)
+
+	require.NoError(t, c.SetDefaultProfile("work"))
+	c.Profile.ProfileName = DefaultProfileName
+	c.InitConfig()
+	require.Equal(t, "work", c.Profile.ProfileName)
+
+	// --project-name overrides the default profile
+	c.Profile.ProfileName = DefaultProfileName
+	c.ProfileNameSet = true
+	c.InitConfig()
+	require.Equal(t, DefaultProfileName, c.Profile.ProfileName)
+
+	require.NoError(t, c.RenameProfile("work", "acme"))
+	require.NoError(t, viper.ReadInConfig())
+	require.Equal(t, []string{"acme", "default"}, c.ProfileNames())
+	require.Equal(t, "acme", c.GetDefaultProfile())
+	require.Equal(t, "Work Co", viper.GetString("acme.display_name"))
+
+	require.NoError(t, c.RemoveProfile("acme"))
+	viper.Reset()
+	viper.SetConfigFile(profilesFile)
+	require.NoError(t, viper.ReadInConfig())
+	require.Equal(t, []string{"default"}, c.ProfileNames())
+	require.Equal(t, DefaultProfileName, c.GetDefaultProfile())
+}
+
+func TestRenameProfileErrors(t *testing.T) {
+	profilesFile := filepath.Join(t.TempDir(), "config.toml")
+	err := ioutil.WriteFile(profilesFile, []byte(`
+[default]
+  display_name = "Main"
+[work]
+  display_name = "Work Co"
+`), 0600)
+	require.NoError(t, err)
+
+	viper.Reset()
+	viper.SetConfigFile(profilesFile)
+	require.NoError(t, viper.ReadInConfig())
+
+	c := &Config{}
+	require.EqualError(t, c.RenameProfile("nope", "acme"), "profile ‘nope’ does not exist")
+	require.EqualError(t, c.RenameProfile("work", "default"), "profile ‘default’ already exists")
+	require.EqualError(t, c.RenameProfile("work", "acme.staging"), "invalid profile name ‘acme.staging’, use letters, digits, - and _ only")
+}
+
+func TestValidateProfileName(t *testing.T) {
+	for _, name := range []string{"default", "acme-staging", "acme_2"} {
+		require.NoError(t, ValidateProfileName(name))
+	}
+
+	for _, name := range []string{"", "acme.staging", "acme staging", "[acme]"} {
+		require.Error(t, ValidateProfileName(name))
+	}
+}
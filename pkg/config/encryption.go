@@ -0,0 +1,238 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/term"
+)
+
+// KeyStorageEncrypted stores the API keys of a profile in the config file,
+// encrypted with a passphrase, e.g. on headless machines without a keychain
+const KeyStorageEncrypted = "encrypted"
+
+// encryptedPrefix prefixes the config values that are encrypted API keys,
+// followed by the base64 of the salt, nonce and ciphertext
+const encryptedPrefix = "encrypted:"
+
+const (
+	// passphraseEnv is the environment variable of the passphrase
+	passphraseEnv = "STRIPE_CONFIG_PASSPHRASE"
+
+	// passphraseFileEnv is the environment variable of the path of a key file
+	// whose content is the passphrase
+	passphraseFileEnv = "STRIPE_CONFIG_PASSPHRASE_FILE"
+)
+
+const (
+	saltSize = 16
+
+	// scrypt parameters recommended for interactive logins
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// ErrPassphraseRequired is the error returned when API keys are encrypted but
+// no passphrase is set and it can't be prompted
+var ErrPassphraseRequired = fmt.Errorf("the API keys of the config file are encrypted, set the passphrase with %s or %s", passphraseEnv, passphraseFileEnv)
+
+// errWrongPassphrase is the error returned when an API key can't be decrypted
+var errWrongPassphrase = errors.New("could not decrypt the API key, the passphrase is wrong")
+
+// promptPassphrase prompts the passphrase in the terminal, and is replaced in
+// tests
+var promptPassphrase = func(prompt string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", ErrPassphraseRequired
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+	buf, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+
+	return string(buf), nil
+}
+
+// passphraseCache keeps the passphrase and the keys derived from it, so that
+// the passphrase is only prompted once per process
+var passphraseCache = struct {
+	sync.Mutex
+	passphrase string
+	keys       map[string][]byte
+}{}
+
+// getPassphrase returns the passphrase the API keys are encrypted with, from
+// the environment or prompted. A prompted passphrase is confirmed when it's
+// used to encrypt.
+func getPassphrase(confirm bool) (string, error) {
+	if passphrase := os.Getenv(passphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+
+	if path := os.Getenv(passphraseFileEnv); path != "" {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("could not read the passphrase file: %w", err)
+		}
+
+		return strings.TrimSpace(string(content)), nil
+	}
+
+	passphraseCache.Lock()
+	defer passphraseCache.Unlock()
+
+	if passphraseCache.passphrase != "" {
+		return passphraseCache.passphrase, nil
+	}
+
+	passphrase, err := promptPassphrase("Passphrase of the API keys: ")
+	if err != nil {
+		return "", err
+	}
+
+	if confirm {
+		confirmation, err := promptPassphrase("Confirm the passphrase: ")
+		if err != nil {
+			return "", err
+		}
+
+		if confirmation != passphrase {
+			return "", errors.New("the passphrases don't match")
+		}
+	}
+
+	if passphrase == "" {
+		return "", errors.New("the passphrase can't be empty")
+	}
+
+	passphraseCache.passphrase = passphrase
+
+	return passphrase, nil
+}
+
+// forgetPassphrase removes a wrong passphrase from the cache, so that it's
+// prompted again rather than failing every later decryption of the process
+func forgetPassphrase(passphrase string) {
+	passphraseCache.Lock()
+	defer passphraseCache.Unlock()
+
+	if passphraseCache.passphrase == passphrase {
+		passphraseCache.passphrase = ""
+	}
+}
+
+// deriveKey returns the AES-256 key of a passphrase and salt
+func deriveKey(passphrase string, salt []byte) ([]byte, error) {
+	passphraseCache.Lock()
+	defer passphraseCache.Unlock()
+
+	id := passphrase + "\x00" + string(salt)
+	if key, ok := passphraseCache.keys[id]; ok {
+		return key, nil
+	}
+
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	if passphraseCache.keys == nil {
+		passphraseCache.keys = make(map[string][]byte)
+	}
+	passphraseCache.keys[id] = key
+
+	return key, nil
+}
+
+// IsEncryptedKey returns whether a config value is an encrypted API key
+func IsEncryptedKey(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix)
+}
+
+// encryptKey encrypts an API key with the passphrase, with AES-GCM and a key
+// derived with scrypt
+func encryptKey(key string) (string, error) {
+	passphrase, err := getPassphrase(true)
+	if err != nil {
+		return "", err
+	}
+
+	salt := make([]byte, saltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return "", err
+	}
+
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	data := append(append(salt, nonce...), gcm.Seal(nil, nonce, []byte(key), nil)...)
+
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(data), nil
+}
+
+// decryptKey decrypts an API key encrypted by encryptKey
+func decryptKey(value string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedPrefix))
+	if err != nil || len(data) < saltSize {
+		return "", errors.New("the encrypted API key is malformed")
+	}
+
+	passphrase, err := getPassphrase(false)
+	if err != nil {
+		return "", err
+	}
+
+	gcm, err := newGCM(passphrase, data[:saltSize])
+	if err != nil {
+		return "", err
+	}
+
+	data = data[saltSize:]
+	if len(data) < gcm.NonceSize() {
+		return "", errors.New("the encrypted API key is malformed")
+	}
+
+	key, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		forgetPassphrase(passphrase)
+		return "", errWrongPassphrase
+	}
+
+	return string(key), nil
+}
+
+func newGCM(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := deriveKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,134 @@
+package config
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func resetPassphraseCache(t *testing.T) {
+	passphraseCache.passphrase = ""
+	passphraseCache.keys = nil
+	t.Cleanup(func() {
+		passphraseCache.passphrase = ""
+		passphraseCache.keys = nil
+	})
+}
+
+func TestEncryptKey(t *testing.T) {
+	resetPassphraseCache(t)
+	t.Setenv(passphraseEnv, "correct horse battery staple")
+
+	encrypted, err := encryptKey("sk_test_1234567890abcdefghij")
+	require.NoError(t, err)
+	require.True(t, IsEncryptedKey(encrypted))
+	require.NotContains(t, encrypted, "sk_test_")
+
+	key, err := resolveKey(encrypted)
+	require.NoError(t, err)
+	require.Equal(t, "sk_test_1234567890abcdefghij", key)
+
+	t.Setenv(passphraseEnv, "wrong")
+	_, err = resolveKey(encrypted)
+	require.Equal(t, errWrongPassphrase, err)
+
+	_, err = resolveKey("encrypted:bm9wZQ==")
+	require.EqualError(t, err, "the encrypted API key is malformed")
+}
+
+func TestPassphraseFile(t *testing.T) {
+	resetPassphraseCache(t)
+
+	keyFile := filepath.Join(t.TempDir(), "passphrase")
+	require.NoError(t, ioutil.WriteFile(keyFile, []byte("from a key file\n"), 0600))
+	t.Setenv(passphraseFileEnv, keyFile)
+
+	passphrase, err := getPassphrase(false)
+	require.NoError(t, err)
+	require.Equal(t, "from a key file", passphrase)
+}
+
+func TestPromptPassphrase(t *testing.T) {
+	resetPassphraseCache(t)
+
+	prompts := 0
+	original := promptPassphrase
+	promptPassphrase = func(prompt string) (string, error) {
+		prompts++
+		return "prompted", nil
+	}
+	t.Cleanup(func() { promptPassphrase = original })
+
+	// The passphrase is confirmed when encrypting, and only prompted once
+	encrypted, err := encryptKey("sk_test_1234567890abcdefghij")
+	require.NoError(t, err)
+	require.Equal(t, 2, prompts)
+
+	key, err := decryptKey(encrypted)
+	require.NoError(t, err)
+	require.Equal(t, "sk_test_1234567890abcdefghij", key)
+	require.Equal(t, 2, prompts)
+}
+
+func TestPromptPassphraseAgainWhenWrong(t *testing.T) {
+	resetPassphraseCache(t)
+	t.Setenv(passphraseEnv, "prompted")
+
+	encrypted, err := encryptKey("sk_test_1234567890abcdefghij")
+	require.NoError(t, err)
+	t.Setenv(passphraseEnv, "")
+
+	passphrases := []string{"mistyped", "prompted"}
+	original := promptPassphrase
+	promptPassphrase = func(prompt string) (string, error) {
+		passphrase := passphrases[0]
+		passphrases = passphrases[1:]
+		return passphrase, nil
+	}
+	t.Cleanup(func() { promptPassphrase = original })
+
+	_, err = decryptKey(encrypted)
+	require.Equal(t, errWrongPassphrase, err)
+
+	key, err := decryptKey(encrypted)
+	require.NoError(t, err)
+	require.Equal(t, "sk_test_1234567890abcdefghij", key)
+	require.Empty(t, passphrases)
+}
+
+func TestSetKeyStorageEncrypted(t *testing.T) {
+	resetPassphraseCache(t)
+	t.Setenv(passphraseEnv, "correct horse battery staple")
+
+	profilesFile := filepath.Join(t.TempDir(), "config.toml")
+	err := ioutil.WriteFile(profilesFile, []byte(`
+[default]
+  test_mode_api_key = "sk_test_1234567890abcdefghij"
+`), 0600)
+	require.NoError(t, err)
+
+	viper.Reset()
+	viper.SetConfigFile(profilesFile)
+	require.NoError(t, viper.ReadInConfig())
+
+	p := Profile{ProfileName: "default"}
+	require.NoError(t, p.SetKeyStorage(KeyStorageEncrypted))
+
+	content, err := ioutil.ReadFile(profilesFile)
+	require.NoError(t, err)
+	require.NotContains(t, string(content), "sk_test_")
+	require.Contains(t, string(content), `test_mode_api_key = "encrypted:`)
+
+	key, err := p.GetAPIKey(false)
+	require.NoError(t, err)
+	require.Equal(t, "sk_test_1234567890abcdefghij", key)
+
+	require.NoError(t, p.SetKeyStorage(KeyStorageFile))
+
+	content, err = ioutil.ReadFile(profilesFile)
+	require.NoError(t, err)
+	require.Contains(t, string(content), `test_mode_api_key = "sk_test_1234567890abcdefghij"`)
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/viper"
+)
+
+// exportVersion is the version of the format of exported profiles
+const exportVersion = 1
+
+// Export is the portable document of `stripe config export`, which `stripe
+// config import` provisions the profiles of on another machine
+type Export struct {
+	Version  int                               `toml:"version"`
+	Profiles map[string]map[string]interface{} `toml:"profiles"`
+}
+
+// machineFields are the profile fields that only make sense on the machine
+// they're configured on, and aren't exported
+var machineFields = map[string]bool{
+	"device_name": true,
+	"key_storage": true,
+}
+
+// ExportProfiles returns the export of the named profiles. Their API keys are
+// only included if includeSecrets is set, in plain text even if they're
+// stored in the keychain or encrypted.
+func (c *Config) ExportProfiles(names []string, includeSecrets bool) (*Export, error) {
+	export := &Export{
+		Version:  exportVersion,
+		Profiles: make(map[string]map[string]interface{}),
+	}
+
+	for _, name := range names {
+		settings := viper.GetStringMap(name)
+		if len(settings) == 0 {
+			return nil, fmt.Errorf("profile ‘%s’ does not exist", name)
+		}
+
+		profile := make(map[string]interface{})
+		for field, value := range settings {
+			if machineFields[field] {
+				continue
+			}
+
+			if IsKeyField(field) {
+				if !includeSecrets {
+					continue
+				}
+
+				key, err := resolveKey(fmt.Sprint(value))
+				if err != nil {
+					return nil, err
+				}
+				value = key
+			}
+
+			profile[field] = value
+		}
+
+		export.Profiles[name] = profile
+	}
+
+	return export, nil
+}
+
+// ImportProfiles writes the profiles of an export to the config file. Their
+// API keys are stored like the keys written by `stripe login`. Existing
+// profiles are replaced if overwrite is set, or else it's an error.
+func (c *Config) ImportProfiles(export *Export, overwrite bool) error {
+	if export.Version != exportVersion {
+		return fmt.Errorf("unsupported export version %d, expected %d", export.Version, exportVersion)
+	}
+
+	if IsStateless() {
+		return ErrStateless
+	}
+
+	existing := make(map[string]bool)
+	for _, name := range c.ProfileNames() {
+		existing[name] = true
+	}
+
+	names := make([]string, 0, len(export.Profiles))
+	for name := range export.Profiles {
+		if existing[name] && !overwrite {
+			return fmt.Errorf("profile ‘%s’ already exists, import with --force to replace it", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// The config is rewritten from its settings, so that the fields of the
+	// replaced profiles don't linger
+	settings := viper.AllSettings()
+	for _, name := range names {
+		if existing[name] {
+			deleteProfileKeys(settings[name])
+		}
+
+		p := Profile{ProfileName: name}
+		profile := make(map[string]interface{})
+		for field, value := range export.Profiles[name] {
+			if key, ok := value.(string); ok {
+				encoded, err := p.keyValue(field, key)
+				if err != nil {
+					return err
+				}
+				value = encoded
+			}
+
+			profile[field] = value
+		}
+		settings[name] = profile
+	}
+
+	if err := makePath(viper.ConfigFileUsed()); err != nil {
+		return err
+	}
+
+	runtimeViper := viper.New()
+	for field, value := range settings {
+		runtimeViper.Set(field, value)
+	}
+
+	return syncConfig(runtimeViper)
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportProfiles(t *testing.T) {
+	profilesFile := filepath.Join(t.TempDir(), "config.toml")
+	err := ioutil.WriteFile(profilesFile, []byte(`
+[default]
+  account_id = "acct_123"
+  device_name = "laptop"
+  key_storage = "file"
+  test_mode_api_key = "sk_test_1234567890abcdefghij"
+  test_mode_publishable_key = "pk_test_123"
+`), 0600)
+	require.NoError(t, err)
+
+	viper.Reset()
+	viper.SetConfigFile(profilesFile)
+	require.NoError(t, viper.ReadInConfig())
+	defer viper.Reset()
+
+	c := &Config{}
+
+	export, err := c.ExportProfiles([]string{"default"}, false)
+	require.NoError(t, err)
+	require.Equal(t, &Export{
+		Version: 1,
+		Profiles: map[string]map[string]interface{}{
+			"default": {
+				"account_id":                "acct_123",
+				"test_mode_publishable_key": "pk_test_123",
+			},
+		},
+	}, export)
+
+	export, err = c.ExportProfiles([]string{"default"}, true)
+	require.NoError(t, err)
+	require.Equal(t, "sk_test_1234567890abcdefghij", export.Profiles["default"]["test_mode_api_key"])
+
+	_, err = c.ExportProfiles([]string{"nope"}, true)
+	require.EqualError(t, err, "profile ‘nope’ does not exist")
+}
+
+func TestImportProfiles(t *testing.T) {
+	profilesFile := filepath.Join(t.TempDir(), "stripe", "config.toml")
+
+	viper.Reset()
+	viper.SetConfigType("toml")
+	viper.SetConfigFile(profilesFile)
+	defer viper.Reset()
+
+	c := &Config{}
+	export := &Export{
+		Version: 1,
+		Profiles: map[string]map[string]interface{}{
+			"ci": {
+				"account_id":        "acct_123",
+				"test_mode_api_key": "sk_test_1234567890abcdefghij",
+			},
+		},
+	}
+
+	require.NoError(t, c.ImportProfiles(export, false))
+	require.NoError(t, viper.ReadInConfig())
+	require.Equal(t, []string{"ci"}, c.ProfileNames())
+	require.Equal(t, "acct_123", viper.GetString("ci.account_id"))
+
+	require.EqualError(t, c.ImportProfiles(export, false), "profile ‘ci’ already exists, import with --force to replace it")
+
+	export.Profiles["ci"] = map[string]interface{}{"account_id": "acct_456"}
+	require.NoError(t, c.ImportProfiles(export, true))
+	require.NoError(t, viper.ReadInConfig())
+	require.Equal(t, "acct_456", viper.GetString("ci.account_id"))
+	require.False(t, viper.IsSet("ci.test_mode_api_key"))
+
+	export.Version = 2
+	require.EqualError(t, c.ImportProfiles(export, true), "unsupported export version 2, expected 1")
+}
@@ -0,0 +1,260 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// KeyStorageFile stores the API keys of a profile in the config file
+	KeyStorageFile = "file"
+
+	// KeyStorageKeyring stores the API keys of a profile in the keychain of
+	// the OS, with the config file only holding references to them
+	KeyStorageKeyring = "keyring"
+)
+
+// keyringService is the service the API keys are stored under in the keychain
+const keyringService = "Stripe CLI"
+
+// keyringRefPrefix prefixes the config values that reference an API key
+// stored in the keychain, e.g. `keyring:default.test_mode_api_key`
+const keyringRefPrefix = "keyring:"
+
+// ErrKeyringUnavailable is the error returned when the keychain of the OS
+// can't be used to store API keys
+var ErrKeyringUnavailable = errors.New("the OS keychain is not available")
+
+// errKeyNotFound is the error returned when an API key isn't in the keychain
+var errKeyNotFound = errors.New("the API key was not found in the OS keychain")
+
+// keyring stores secrets by account name. It's the keychain of the OS:
+// macOS Keychain, Windows Credential Manager or libsecret.
+type keyring interface {
+	Get(account string) (string, error)
+	Set(account, secret string) error
+	Delete(account string) error
+}
+
+// osKeyring is the keychain the API keys are stored in, replaced in tests
+var osKeyring keyring = systemKeyring{}
+
+// IsKeyField returns whether a profile field holds a secret or restricted API
+// key, the fields stored in the keychain or encrypted when either is used
+func IsKeyField(field string) bool {
+	return strings.HasSuffix(field, "api_key") || field == "secret_key"
+}
+
+// IsKeyringRef returns whether a config value references an API key stored in
+// the keychain rather than being the key itself
+func IsKeyringRef(value string) bool {
+	return strings.HasPrefix(value, keyringRefPrefix)
+}
+
+// RedactConfigValue returns the value of a profile field as shown to users,
+// with API keys redacted
+func RedactConfigValue(field, value string) string {
+	switch {
+	case IsKeyringRef(value):
+		return "(stored in the OS keychain)"
+	case IsEncryptedKey(value):
+		return "(encrypted)"
+	case IsKeyField(field):
+		return RedactKey(value)
+	default:
+		return value
+	}
+}
+
+// GetKeyStorage returns where the API keys of the profile are stored: the
+// key_storage setting of the profile, or else the top-level one. It defaults
+// to the config file.
+func (p *Profile) GetKeyStorage() string {
+	if storage := viper.GetString(p.GetConfigField("key_storage")); storage != "" {
+		return storage
+	}
+
+	if storage := viper.GetString("key_storage"); storage != "" {
+		return storage
+	}
+
+	return KeyStorageFile
+}
+
+// SetKeyStorage moves the API keys of the profile to storage: the config
+// file, the keychain, or the config file encrypted with a passphrase. It's
+// recorded as the storage of the keys written later.
+func (p *Profile) SetKeyStorage(storage string) error {
+	if storage != KeyStorageFile && storage != KeyStorageKeyring && storage != KeyStorageEncrypted {
+		return fmt.Errorf("key storage value not supported: %s", storage)
+	}
+
+	if IsStateless() {
+		return ErrStateless
+	}
+
+	var moved []string
+	for field, value := range viper.GetStringMap(p.ProfileName) {
+		current, ok := value.(string)
+		if !ok || !IsKeyField(field) || keyStorageOf(current) == storage {
+			continue
+		}
+
+		key, err := resolveKey(current)
+		if err != nil {
+			return err
+		}
+
+		encoded, err := encodeKey(storage, p.GetConfigField(field), key)
+		if err != nil {
+			return err
+		}
+		viper.Set(p.GetConfigField(field), encoded)
+
+		if IsKeyringRef(current) {
+			moved = append(moved, current)
+		}
+	}
+
+	viper.Set(p.GetConfigField("key_storage"), storage)
+	if err := viper.WriteConfig(); err != nil {
+		return err
+	}
+
+	// The keys moved out of the keychain are only deleted from it once the
+	// file is written
+	for _, ref := range moved {
+		if err := deleteKey(ref); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// keyValue returns the value an API key field of the profile is written with:
+// the key itself, a reference to it in the keychain, or the encrypted key,
+// depending on the storage of the keys of the profile
+func (p *Profile) keyValue(field, key string) (string, error) {
+	if !IsKeyField(field) {
+		return key, nil
+	}
+
+	return encodeKey(p.GetKeyStorage(), p.GetConfigField(field), key)
+}
+
+// encodeKey returns the config value of an API key in storage
+func encodeKey(storage, account, key string) (string, error) {
+	switch storage {
+	case KeyStorageKeyring:
+		return storeKey(account, key)
+	case KeyStorageEncrypted:
+		return encryptKey(key)
+	default:
+		return key, nil
+	}
+}
+
+// keyStorageOf returns the storage of the API key of a config value
+func keyStorageOf(value string) string {
+	switch {
+	case IsKeyringRef(value):
+		return KeyStorageKeyring
+	case IsEncryptedKey(value):
+		return KeyStorageEncrypted
+	default:
+		return KeyStorageFile
+	}
+}
+
+// storeKey stores an API key in the keychain under account, and returns the
+// reference to it
+func storeKey(account, key string) (string, error) {
+	if err := osKeyring.Set(account, key); err != nil {
+		return "", fmt.Errorf("could not store the API key in the OS keychain: %w", err)
+	}
+
+	return keyringRefPrefix + account, nil
+}
+
+// resolveKey returns the API key of a config value, read from the keychain if
+// the value references it, or decrypted if it's encrypted
+func resolveKey(value string) (string, error) {
+	if IsEncryptedKey(value) {
+		return decryptKey(value)
+	}
+
+	if !IsKeyringRef(value) {
+		return value, nil
+	}
+
+	key, err := osKeyring.Get(strings.TrimPrefix(value, keyringRefPrefix))
+	if err != nil {
+		return "", fmt.Errorf("could not read the API key from the OS keychain: %w", err)
+	}
+
+	return key, nil
+}
+
+// deleteKey deletes the API key a config value references from the keychain,
+// if it references one
+func deleteKey(value string) error {
+	if !IsKeyringRef(value) {
+		return nil
+	}
+
+	err := osKeyring.Delete(strings.TrimPrefix(value, keyringRefPrefix))
+	if err != nil && !errors.Is(err, errKeyNotFound) {
+		return fmt.Errorf("could not delete the API key from the OS keychain: %w", err)
+	}
+
+	return nil
+}
+
+// deleteProfileKeys deletes the API keys the settings of a profile reference
+// from the keychain. Profiles are removed even if the keychain can't be
+// reached, so errors are ignored.
+func deleteProfileKeys(settings interface{}) {
+	profile, ok := settings.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	for _, value := range profile {
+		if ref, ok := value.(string); ok {
+			deleteKey(ref) // #nosec G104
+		}
+	}
+}
+
+// renameProfileKeys moves the API keys the settings of a profile reference in
+// the keychain to the accounts of the profile newName, so that a new profile
+// with the old name doesn't overwrite them
+func renameProfileKeys(settings map[string]interface{}, newName string) error {
+	for field, value := range settings {
+		ref, ok := value.(string)
+		if !ok || !IsKeyringRef(ref) {
+			continue
+		}
+
+		key, err := resolveKey(ref)
+		if err != nil {
+			return err
+		}
+
+		newRef, err := storeKey(newName+"."+field, key)
+		if err != nil {
+			return err
+		}
+		settings[field] = newRef
+
+		if err := deleteKey(ref); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
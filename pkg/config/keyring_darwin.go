@@ -0,0 +1,74 @@
+//go:build darwin
+// +build darwin
+
+package config
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	exec "golang.org/x/sys/execabs"
+)
+
+// securityNotFound is the exit code of `security` for a missing item
+const securityNotFound = 44
+
+// systemKeyring stores secrets in the macOS Keychain with the `security` tool
+type systemKeyring struct{}
+
+func (systemKeyring) Get(account string) (string, error) {
+	out, err := exec.Command("security", "find-generic-password", "-s", keyringService, "-a", account, "-w").Output()
+	if err != nil {
+		return "", securityError(err)
+	}
+
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+func (systemKeyring) Set(account, secret string) error {
+	// The secret is written to the interactive mode of `security` rather than
+	// passed as an argument, so that other processes can't see it
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %s -a %s -X %s\n",
+		securityQuote(keyringService), securityQuote(account), hex.EncodeToString([]byte(secret))))
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return securityError(err)
+	}
+
+	// `security -i` succeeds even if the command fails, and prints its error
+	if len(strings.TrimSpace(string(out))) > 0 {
+		return errors.New(strings.TrimSpace(string(out)))
+	}
+
+	return nil
+}
+
+func (systemKeyring) Delete(account string) error {
+	err := exec.Command("security", "delete-generic-password", "-s", keyringService, "-a", account).Run()
+	if err != nil {
+		return securityError(err)
+	}
+
+	return nil
+}
+
+func securityError(err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == securityNotFound {
+		return errKeyNotFound
+	}
+	if errors.Is(err, exec.ErrNotFound) {
+		return ErrKeyringUnavailable
+	}
+
+	return err
+}
+
+// securityQuote quotes an argument of the interactive mode of `security`
+func securityQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,68 @@
+//go:build linux
+// +build linux
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	exec "golang.org/x/sys/execabs"
+)
+
+// systemKeyring stores secrets with libsecret, in the GNOME Keyring or KWallet,
+// through the `secret-tool` command of libsecret-tools
+type systemKeyring struct{}
+
+func (systemKeyring) Get(account string) (string, error) {
+	out, err := secretTool(nil, "lookup", "service", keyringService, "account", account)
+	if err != nil {
+		return "", err
+	}
+
+	// secret-tool prints nothing and fails for missing secrets
+	if len(out) == 0 {
+		return "", errKeyNotFound
+	}
+
+	return string(out), nil
+}
+
+func (systemKeyring) Set(account, secret string) error {
+	// The secret is written to the input of secret-tool rather than passed as
+	// an argument, so that other processes can't see it
+	_, err := secretTool(strings.NewReader(secret), "store", "--label", fmt.Sprintf("%s (%s)", keyringService, account),
+		"service", keyringService, "account", account)
+
+	return err
+}
+
+func (systemKeyring) Delete(account string) error {
+	_, err := secretTool(nil, "clear", "service", keyringService, "account", account)
+	return err
+}
+
+func secretTool(stdin *strings.Reader, args ...string) ([]byte, error) {
+	if _, err := exec.LookPath("secret-tool"); err != nil {
+		return nil, fmt.Errorf("%w: secret-tool, from libsecret-tools, is not installed", ErrKeyringUnavailable)
+	}
+
+	cmd := exec.Command("secret-tool", args...)
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+
+	out, err := cmd.Output()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if len(out) == 0 && len(exitErr.Stderr) == 0 {
+			return nil, errKeyNotFound
+		}
+
+		return nil, fmt.Errorf("secret-tool %s failed: %s", args[0], strings.TrimSpace(string(exitErr.Stderr)))
+	}
+
+	return out, err
+}
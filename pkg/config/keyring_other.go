@@ -0,0 +1,19 @@
+//go:build !darwin && !linux && !windows
+// +build !darwin,!linux,!windows
+
+package config
+
+// systemKeyring is unavailable on this OS
+type systemKeyring struct{}
+
+func (systemKeyring) Get(account string) (string, error) {
+	return "", ErrKeyringUnavailable
+}
+
+func (systemKeyring) Set(account, secret string) error {
+	return ErrKeyringUnavailable
+}
+
+func (systemKeyring) Delete(account string) error {
+	return ErrKeyringUnavailable
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeKeyring map[string]string
+
+func (k fakeKeyring) Get(account string) (string, error) {
+	secret, ok := k[account]
+	if !ok {
+		return "", errKeyNotFound
+	}
+	return secret, nil
+}
+
+func (k fakeKeyring) Set(account, secret string) error {
+	k[account] = secret
+	return nil
+}
+
+func (k fakeKeyring) Delete(account string) error {
+	if _, ok := k[account]; !ok {
+		return errKeyNotFound
+	}
+	delete(k, account)
+	return nil
+}
+
+func setupKeyringConfig(t *testing.T) (fakeKeyring, string) {
+	keys := fakeKeyring{}
+	original := osKeyring
+	osKeyring = keys
+	t.Cleanup(func() { osKeyring = original })
+
+	profilesFile := filepath.Join(t.TempDir(), "config.toml")
+	err := ioutil.WriteFile(profilesFile, []byte(`
+[default]
+  display_name = "Main"
+  test_mode_api_key = "sk_test_1234567890abcdefghij"
+`), 0600)
+	require.NoError(t, err)
+
+	viper.Reset()
+	viper.SetConfigFile(profilesFile)
+	require.NoError(t, viper.ReadInConfig())
+
+	return keys, profilesFile
+}
+
+func TestSetKeyStorage(t *testing.T) {
+	keys, profilesFile := setupKeyringConfig(t)
+	p := Profile{ProfileName: "default"}
+
+	require.NoError(t, p.SetKeyStorage(KeyStorageKeyring))
+	require.Equal(t, fakeKeyring{"default.test_mode_api_key": "sk_test_1234567890abcdefghij"}, keys)
+
+	content, err := ioutil.ReadFile(profilesFile)
+	require.NoError(t, err)
+	require.NotContains(t, string(content), "sk_test_")
+	require.Contains(t, string(content), `test_mode_api_key = "keyring:default.test_mode_api_key"`)
+
+	key, err := p.GetAPIKey(false)
+	require.NoError(t, err)
+	require.Equal(t, "sk_test_1234567890abcdefghij", key)
+
+	// Keys written later are stored in the keychain too
+	require.NoError(t, p.WriteConfigField("live_mode_api_key", "sk_live_1234567890abcdefghij"))
+	require.Equal(t, "sk_live_1234567890abcdefghij", keys["default.live_mode_api_key"])
+	require.Equal(t, "Main", viper.GetString("default.display_name"))
+
+	require.NoError(t, p.SetKeyStorage(KeyStorageFile))
+	require.Empty(t, keys)
+
+	content, err = ioutil.ReadFile(profilesFile)
+	require.NoError(t, err)
+	require.Contains(t, string(content), `test_mode_api_key = "sk_test_1234567890abcdefghij"`)
+	require.Contains(t, string(content), `live_mode_api_key = "sk_live_1234567890abcdefghij"`)
+
+	require.EqualError(t, p.SetKeyStorage("vault"), "key storage value not supported: vault")
+}
+
+func TestKeyringProfiles(t *testing.T) {
+	keys, _ := setupKeyringConfig(t)
+	c := &Config{}
+	p := Profile{ProfileName: "default"}
+
+	require.NoError(t, p.SetKeyStorage(KeyStorageKeyring))
+
+	require.NoError(t, c.RenameProfile("default", "acme"))
+	require.Equal(t, fakeKeyring{"acme.test_mode_api_key": "sk_test_1234567890abcdefghij"}, keys)
+
+	require.NoError(t, viper.ReadInConfig())
+	require.Equal(t, "keyring:acme.test_mode_api_key", viper.GetString("acme.test_mode_api_key"))
+
+	require.NoError(t, c.RemoveProfile("acme"))
+	require.Empty(t, keys)
+}
+
+func TestGetAPIKeyMissingFromKeyring(t *testing.T) {
+	keys, _ := setupKeyringConfig(t)
+	p := Profile{ProfileName: "default"}
+
+	require.NoError(t, p.SetKeyStorage(KeyStorageKeyring))
+	delete(keys, "default.test_mode_api_key")
+
+	_, err := p.GetAPIKey(false)
+	require.ErrorIs(t, err, errKeyNotFound)
+}
+
+func TestGetSettings(t *testing.T) {
+	_, _ = setupKeyringConfig(t)
+	p := Profile{ProfileName: "default"}
+	require.NoError(t, p.SetKeyStorage(KeyStorageKeyring))
+
+	settings, err := p.GetSettings(false)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"display_name":      "Main",
+		"key_storage":       "keyring",
+		"test_mode_api_key": "(stored in the OS keychain)",
+	}, settings)
+
+	settings, err = p.GetSettings(true)
+	require.NoError(t, err)
+	require.Equal(t, "sk_test_1234567890abcdefghij", settings["test_mode_api_key"])
+
+	require.Equal(t, "sk_test_…ghij", RedactConfigValue("test_mode_api_key", "sk_test_1234567890abcdefghij"))
+	require.Equal(t, "(encrypted)", RedactConfigValue("live_mode_api_key", "encrypted:abc"))
+	require.Equal(t, "Main", RedactConfigValue("display_name", "Main"))
+}
@@ -0,0 +1,120 @@
+//go:build windows
+// +build windows
+
+package config
+
+import (
+	"errors"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+)
+
+var (
+	advapi32       = windows.NewLazySystemDLL("advapi32.dll")
+	procCredReadW  = advapi32.NewProc("CredReadW")
+	procCredWriteW = advapi32.NewProc("CredWriteW")
+	procCredDelete = advapi32.NewProc("CredDeleteW")
+	procCredFree   = advapi32.NewProc("CredFree")
+)
+
+// credential is the CREDENTIALW structure of the Credential Manager
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        windows.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// systemKeyring stores secrets as generic credentials of the Windows
+// Credential Manager
+type systemKeyring struct{}
+
+func (systemKeyring) Get(account string) (string, error) {
+	target, err := windows.UTF16PtrFromString(credentialTarget(account))
+	if err != nil {
+		return "", err
+	}
+
+	var cred *credential
+	ret, _, err := procCredReadW.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred)))
+	if ret == 0 {
+		return "", credentialError(err)
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred))) // #nosec G104
+
+	blob := unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)
+
+	return string(blob), nil
+}
+
+func (systemKeyring) Set(account, secret string) error {
+	target, err := windows.UTF16PtrFromString(credentialTarget(account))
+	if err != nil {
+		return err
+	}
+
+	userName, err := windows.UTF16PtrFromString(account)
+	if err != nil {
+		return err
+	}
+
+	cred := credential{
+		Type:               credTypeGeneric,
+		TargetName:         target,
+		UserName:           userName,
+		CredentialBlobSize: uint32(len(secret)),
+		Persist:            credPersistLocalMachine,
+	}
+	if len(secret) > 0 {
+		blob := []byte(secret)
+		cred.CredentialBlob = &blob[0]
+	}
+
+	ret, _, err := procCredWriteW.Call(uintptr(unsafe.Pointer(&cred)), 0)
+	if ret == 0 {
+		return credentialError(err)
+	}
+
+	return nil
+}
+
+func (systemKeyring) Delete(account string) error {
+	target, err := windows.UTF16PtrFromString(credentialTarget(account))
+	if err != nil {
+		return err
+	}
+
+	ret, _, err := procCredDelete.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0)
+	if ret == 0 {
+		return credentialError(err)
+	}
+
+	return nil
+}
+
+// credentialTarget returns the name of the credential of an account
+func credentialTarget(account string) string {
+	return keyringService + ":" + account
+}
+
+func credentialError(err error) error {
+	if errors.Is(err, windows.ERROR_NOT_FOUND) {
+		return errKeyNotFound
+	}
+
+	return err
+}
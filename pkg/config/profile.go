@@ -5,9 +5,11 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 
+	"github.com/stripe/stripe-cli/pkg/ansi"
 	"github.com/stripe/stripe-cli/pkg/validators"
 )
 
@@ -23,6 +25,20 @@ type Profile struct {
 	TerminalPOSDeviceID    string
 	DisplayName            string
 	AccountID              string
+
+	// KeyExpiresAt is when the keys obtained with `stripe login` expire
+	KeyExpiresAt time.Time
+
+	// SandboxID and SandboxName are the sandbox the test mode keys belong
+	// to, if one was selected with `stripe login`
+	SandboxID   string
+	SandboxName string
+
+	// LiveModeKeyScopes and TestModeKeyScopes are the scopes restricted keys
+	// were found to have by `stripe login --with-key --validate`. They're
+	// nil for secret keys, and when the scopes are unknown.
+	LiveModeKeyScopes []string
+	TestModeKeyScopes []string
 }
 
 // CreateProfile creates a profile when logging in
@@ -38,13 +54,13 @@ func (p *Profile) CreateProfile() error {
 // GetColor gets the color setting for the user based on the flag or the
 // persisted color stored in the config file
 func (p *Profile) GetColor() (string, error) {
-	color := viper.GetString("color")
-	if color != "" {
-		return color, nil
+	// The color key is a table when the config file sets `color.theme`
+	if color, ok := viper.Get("color").(string); ok && color != "" {
+		return normalizeColor(color), nil
 	}
 
-	color = viper.GetString(p.GetConfigField("color"))
-	switch color {
+	color := viper.GetString(p.GetConfigField("color"))
+	switch normalizeColor(color) {
 	case "", ColorAuto:
 		return ColorAuto, nil
 	case ColorOn:
@@ -56,6 +72,56 @@ func (p *Profile) GetColor() (string, error) {
 	}
 }
 
+// normalizeColor returns the on and off values of the always and never
+// aliases of a color setting
+func normalizeColor(color string) string {
+	switch color {
+	case ColorAlways:
+		return ColorOn
+	case ColorNever:
+		return ColorOff
+	default:
+		return color
+	}
+}
+
+// SetColorTheme sets the theme colors are shown with, for every profile
+func (p *Profile) SetColorTheme(theme string) error {
+	if err := ansi.SetTheme(theme); err != nil {
+		return err
+	}
+
+	if IsStateless() {
+		return ErrStateless
+	}
+
+	// The theme is often set before logging in, when there's no config
+	// file yet
+	configFile := viper.ConfigFileUsed()
+	if err := makePath(configFile); err != nil {
+		return err
+	}
+
+	viper.Set(ColorThemeField, strings.ToLower(theme))
+	return viper.WriteConfigAs(configFile)
+}
+
+// UnsetColorTheme shows colors with the default theme again
+func (p *Profile) UnsetColorTheme() error {
+	v, err := removeKey(viper.GetViper(), ColorThemeField)
+	if err != nil {
+		return err
+	}
+
+	if err := p.writeProfile(v); err != nil {
+		return err
+	}
+
+	viper.Set(ColorThemeField, nil)
+
+	return viper.ReadInConfig()
+}
+
 // GetDeviceName returns the configured device name
 func (p *Profile) GetDeviceName() (string, error) {
 	if os.Getenv("STRIPE_DEVICE_NAME") != "" {
@@ -75,6 +141,10 @@ func (p *Profile) GetDeviceName() (string, error) {
 
 // GetAccountID returns the accountId for the given profile.
 func (p *Profile) GetAccountID() (string, error) {
+	if os.Getenv("STRIPE_ACCOUNT_ID") != "" {
+		return os.Getenv("STRIPE_ACCOUNT_ID"), nil
+	}
+
 	if p.AccountID != "" {
 		return p.AccountID, nil
 	}
@@ -119,9 +189,12 @@ func (p *Profile) GetAPIKey(livemode bool) (string, error) {
 
 	// Try to fetch the API key from the configuration file
 	if err := viper.ReadInConfig(); err == nil {
-		key := viper.GetString(p.GetConfigField(livemodeKeyField(livemode)))
+		key, err := resolveKey(viper.GetString(p.GetConfigField(livemodeKeyField(livemode))))
+		if err != nil {
+			return "", err
+		}
 
-		err := validators.APIKey(key)
+		err = validators.APIKey(key)
 		if err != nil {
 			return "", err
 		}
@@ -132,8 +205,27 @@ func (p *Profile) GetAPIKey(livemode bool) (string, error) {
 	return "", validators.ErrAPIKeyNotConfigured
 }
 
+// GetKeyExpiration returns when the API keys of the profile expire, if they
+// were obtained with `stripe login`
+func (p *Profile) GetKeyExpiration() (time.Time, bool) {
+	if err := viper.ReadInConfig(); err != nil {
+		return time.Time{}, false
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, viper.GetString(p.GetConfigField("keys_expire_at")))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return expiresAt, true
+}
+
 // GetPublishableKey returns the publishable key for the user
 func (p *Profile) GetPublishableKey() string {
+	if os.Getenv("STRIPE_PUBLISHABLE_KEY") != "" {
+		return os.Getenv("STRIPE_PUBLISHABLE_KEY")
+	}
+
 	if err := viper.ReadInConfig(); err == nil {
 		if viper.IsSet(p.GetConfigField("publishable_key")) {
 			p.RegisterAlias("test_mode_publishable_key", "publishable_key")
@@ -154,8 +246,22 @@ func (p *Profile) GetDisplayName() string {
 	return ""
 }
 
+// GetSandbox returns the ID and name of the sandbox the test mode keys of the
+// profile belong to, if any
+func (p *Profile) GetSandbox() (string, string) {
+	if err := viper.ReadInConfig(); err == nil {
+		return viper.GetString(p.GetConfigField("sandbox_id")), viper.GetString(p.GetConfigField("sandbox_name"))
+	}
+
+	return "", ""
+}
+
 // GetTerminalPOSDeviceID returns the device id from the config for Terminal quickstart to use
 func (p *Profile) GetTerminalPOSDeviceID() string {
+	if os.Getenv("STRIPE_TERMINAL_POS_DEVICE_ID") != "" {
+		return os.Getenv("STRIPE_TERMINAL_POS_DEVICE_ID")
+	}
+
 	if err := viper.ReadInConfig(); err == nil {
 		return viper.GetString(p.GetConfigField("terminal_pos_device_id"))
 	}
@@ -163,34 +269,251 @@ func (p *Profile) GetTerminalPOSDeviceID() string {
 	return ""
 }
 
+// GetProxy returns the URL of the proxy server configured for the profile,
+// set with `stripe config --set proxy <url>`
+func (p *Profile) GetProxy() string {
+	if err := viper.ReadInConfig(); err == nil {
+		return viper.GetString(p.GetConfigField("proxy"))
+	}
+
+	return ""
+}
+
+// GetReadOnly returns true if the profile is set to read-only mode with
+// `stripe config --set read_only true`
+func (p *Profile) GetReadOnly() bool {
+	if err := viper.ReadInConfig(); err == nil {
+		return viper.GetBool(p.GetConfigField("read_only"))
+	}
+
+	return false
+}
+
+// GetAutoIdempotency returns true if POST requests of the profile are sent
+// with a generated idempotency key and retried by default, set with `stripe
+// config --set auto_idempotency true`
+func (p *Profile) GetAutoIdempotency() bool {
+	if err := viper.ReadInConfig(); err == nil {
+		return viper.GetBool(p.GetConfigField("auto_idempotency"))
+	}
+
+	return false
+}
+
+// GetAuditLog returns the audit log setting of the profile, set with `stripe
+// config --set audit_log true` to record the API requests of the CLI in its
+// config folder, or with a path to record them to
+func (p *Profile) GetAuditLog() string {
+	if err := viper.ReadInConfig(); err == nil {
+		return viper.GetString(p.GetConfigField("audit_log"))
+	}
+
+	return ""
+}
+
+// GetLiveAllowlist returns the live mode operations the profile permits
+// without confirmation, as "METHOD /path" entries, set with the
+// `live_allowlist` array of the config file
+func (p *Profile) GetLiveAllowlist() []string {
+	if err := viper.ReadInConfig(); err == nil {
+		return viper.GetStringSlice(p.GetConfigField("live_allowlist"))
+	}
+
+	return nil
+}
+
+// GetTriggerLoadLimits returns the maximum number of objects `stripe trigger
+// --count` may create in a single run and per hour, set with the
+// `trigger_max_objects_per_run` and `trigger_max_objects_per_hour` settings.
+// Unset limits are returned as 0.
+func (p *Profile) GetTriggerLoadLimits() (perRun int, perHour int) {
+	if err := viper.ReadInConfig(); err == nil {
+		return viper.GetInt(p.GetConfigField("trigger_max_objects_per_run")), viper.GetInt(p.GetConfigField("trigger_max_objects_per_hour"))
+	}
+
+	return 0, 0
+}
+
+// GetFixturePacks returns the directories of the fixture packs installed with
+// `stripe fixtures install`, set with the `fixture_packs` array of the config
+// file
+func (p *Profile) GetFixturePacks() []string {
+	if err := viper.ReadInConfig(); err == nil {
+		return viper.GetStringSlice(p.GetConfigField("fixture_packs"))
+	}
+
+	return nil
+}
+
+// WriteFixturePacks replaces the fixture packs of the profile and writes the
+// updated configuration to disk.
+func (p *Profile) WriteFixturePacks(packs []string) error {
+	if len(packs) == 0 {
+		return p.DeleteConfigField("fixture_packs")
+	}
+
+	runtimeViper := viper.GetViper()
+	runtimeViper.Set(p.GetConfigField("fixture_packs"), packs)
+
+	return p.writeProfile(runtimeViper)
+}
+
+// GetBetas returns the beta version headers the profile opted into with
+// `stripe betas enable`, like feature_beta=v1, set with the `betas` array of
+// the config file
+func (p *Profile) GetBetas() []string {
+	if err := viper.ReadInConfig(); err == nil {
+		return viper.GetStringSlice(p.GetConfigField("betas"))
+	}
+
+	return nil
+}
+
+// GetOpenShortcuts returns the shortcuts of `stripe open` defined in the
+// `open.shortcuts` table of the config file, by name. The shortcuts of the
+// profile take precedence over the top-level ones, which every profile has.
+func (p *Profile) GetOpenShortcuts() map[string]string {
+	shortcuts := make(map[string]string)
+
+	if err := viper.ReadInConfig(); err == nil {
+		for name, url := range viper.GetStringMapString("open.shortcuts") {
+			shortcuts[name] = url
+		}
+		for name, url := range viper.GetStringMapString(p.GetConfigField("open.shortcuts")) {
+			shortcuts[name] = url
+		}
+	}
+
+	return shortcuts
+}
+
+// GetAPIVersion returns the API version the requests of the profile are sent
+// with when they don't set one, set with the `api_version` field of the
+// config file. Without it, requests use the default version of the account.
+func (p *Profile) GetAPIVersion() string {
+	if err := viper.ReadInConfig(); err == nil {
+		return viper.GetString(p.GetConfigField("api_version"))
+	}
+
+	return ""
+}
+
+// WriteBetas replaces the betas of the profile and writes the updated
+// configuration to disk.
+func (p *Profile) WriteBetas(betas []string) error {
+	if len(betas) == 0 {
+		return p.DeleteConfigField("betas")
+	}
+
+	runtimeViper := viper.GetViper()
+	runtimeViper.Set(p.GetConfigField("betas"), betas)
+
+	return p.writeProfile(runtimeViper)
+}
+
+// GetFlagDefault returns the default value of a flag of a command, set in the
+// profile with `stripe config set defaults.<command>.<flag> <value>`, e.g.
+// `defaults.listen.forward-to`. The defaults of the parent commands apply when
+// the command has none, down to `defaults.<flag>` for every command.
+func (p *Profile) GetFlagDefault(commandPath []string, flag string) (interface{}, bool) {
+	for i := len(commandPath); i >= 0; i-- {
+		fields := append([]string{"defaults"}, commandPath[:i]...)
+		value := viper.Get(p.GetConfigField(strings.Join(append(fields, flag), ".")))
+
+		// A table is the defaults of a subcommand named like the flag
+		if _, isTable := value.(map[string]interface{}); value != nil && !isTable {
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+
 // GetConfigField returns the configuration field for the specific profile
 func (p *Profile) GetConfigField(field string) string {
 	return p.ProfileName + "." + field
 }
 
+// GetSettings returns the fields of the profile in the config file. API keys
+// are redacted, unless reveal is set, in which case the keys stored in the
+// keychain or encrypted are read.
+func (p *Profile) GetSettings(reveal bool) (map[string]string, error) {
+	settings := make(map[string]string)
+
+	// AllSettings merges the fields set since the config file was read, which
+	// GetStringMap doesn't
+	fields, _ := viper.AllSettings()[p.ProfileName].(map[string]interface{})
+	for field, value := range fields {
+		str := fmt.Sprint(value)
+
+		if !reveal {
+			settings[field] = RedactConfigValue(field, str)
+			continue
+		}
+
+		if IsKeyField(field) {
+			key, err := resolveKey(str)
+			if err != nil {
+				return nil, err
+			}
+			str = key
+		}
+
+		settings[field] = str
+	}
+
+	return settings, nil
+}
+
 // RegisterAlias registers an alias for a given key.
 func (p *Profile) RegisterAlias(alias, key string) {
 	viper.RegisterAlias(p.GetConfigField(alias), p.GetConfigField(key))
 }
 
 // WriteConfigField updates a configuration field and writes the updated
-// configuration to disk.
+// configuration to disk. API keys are written to the keychain if the profile
+// stores its keys there.
 func (p *Profile) WriteConfigField(field, value string) error {
+	if IsStateless() {
+		return ErrStateless
+	}
+
+	value, err := p.keyValue(field, value)
+	if err != nil {
+		return err
+	}
+
 	viper.Set(p.GetConfigField(field), value)
 	return viper.WriteConfig()
 }
 
 // DeleteConfigField deletes a configuration field.
 func (p *Profile) DeleteConfigField(field string) error {
+	if err := deleteKey(viper.GetString(p.GetConfigField(field))); err != nil {
+		return err
+	}
+
 	v, err := removeKey(viper.GetViper(), p.GetConfigField(field))
 	if err != nil {
 		return err
 	}
 
-	return p.writeProfile(v)
+	if err := p.writeProfile(v); err != nil {
+		return err
+	}
+
+	// Forget the field in memory too, for processes that keep running, like
+	// the daemon
+	viper.Set(p.GetConfigField(field), nil)
+
+	return viper.ReadInConfig()
 }
 
 func (p *Profile) writeProfile(runtimeViper *viper.Viper) error {
+	if IsStateless() {
+		return ErrStateless
+	}
+
 	profilesFile := viper.ConfigFileUsed()
 
 	err := makePath(profilesFile)
@@ -203,7 +526,11 @@ func (p *Profile) writeProfile(runtimeViper *viper.Viper) error {
 	}
 
 	if p.LiveModeAPIKey != "" {
-		runtimeViper.Set(p.GetConfigField("live_mode_api_key"), strings.TrimSpace(p.LiveModeAPIKey))
+		key, err := p.keyValue("live_mode_api_key", strings.TrimSpace(p.LiveModeAPIKey))
+		if err != nil {
+			return err
+		}
+		runtimeViper.Set(p.GetConfigField("live_mode_api_key"), key)
 	}
 
 	if p.LiveModePublishableKey != "" {
@@ -211,7 +538,11 @@ func (p *Profile) writeProfile(runtimeViper *viper.Viper) error {
 	}
 
 	if p.TestModeAPIKey != "" {
-		runtimeViper.Set(p.GetConfigField("test_mode_api_key"), strings.TrimSpace(p.TestModeAPIKey))
+		key, err := p.keyValue("test_mode_api_key", strings.TrimSpace(p.TestModeAPIKey))
+		if err != nil {
+			return err
+		}
+		runtimeViper.Set(p.GetConfigField("test_mode_api_key"), key)
 	}
 
 	if p.TestModePublishableKey != "" {
@@ -226,12 +557,48 @@ func (p *Profile) writeProfile(runtimeViper *viper.Viper) error {
 		runtimeViper.Set(p.GetConfigField("account_id"), strings.TrimSpace(p.AccountID))
 	}
 
+	if !p.KeyExpiresAt.IsZero() {
+		runtimeViper.Set(p.GetConfigField("keys_expire_at"), p.KeyExpiresAt.UTC().Format(time.RFC3339))
+	}
+
+	if p.SandboxID != "" {
+		runtimeViper.Set(p.GetConfigField("sandbox_id"), p.SandboxID)
+		runtimeViper.Set(p.GetConfigField("sandbox_name"), p.SandboxName)
+	}
+
+	if p.LiveModeKeyScopes != nil {
+		runtimeViper.Set(p.GetConfigField("live_mode_key_scopes"), p.LiveModeKeyScopes)
+	}
+
+	if p.TestModeKeyScopes != nil {
+		runtimeViper.Set(p.GetConfigField("test_mode_key_scopes"), p.TestModeKeyScopes)
+	}
+
 	runtimeViper.MergeInConfig()
 
 	// Do this after we merge the old configs in
+	if p.LiveModeAPIKey != "" && p.LiveModeKeyScopes == nil {
+		runtimeViper = p.safeRemove(runtimeViper, "live_mode_key_scopes")
+	}
+
 	if p.TestModeAPIKey != "" {
 		runtimeViper = p.safeRemove(runtimeViper, "secret_key")
 		runtimeViper = p.safeRemove(runtimeViper, "api_key")
+
+		// Keys that aren't obtained with `stripe login` don't expire
+		if p.KeyExpiresAt.IsZero() {
+			runtimeViper = p.safeRemove(runtimeViper, "keys_expire_at")
+		}
+
+		// Nor do other keys belong to the sandbox of the previous keys
+		if p.SandboxID == "" {
+			runtimeViper = p.safeRemove(runtimeViper, "sandbox_id")
+			runtimeViper = p.safeRemove(runtimeViper, "sandbox_name")
+		}
+
+		if p.TestModeKeyScopes == nil {
+			runtimeViper = p.safeRemove(runtimeViper, "test_mode_key_scopes")
+		}
 	}
 
 	if p.TestModePublishableKey != "" {
@@ -264,6 +631,21 @@ func (p *Profile) safeRemove(v *viper.Viper, key string) *viper.Viper {
 	return v
 }
 
+// GetConfiguredKeys returns the API keys stored in the profile, by field
+func (p *Profile) GetConfiguredKeys() map[string]string {
+	keys := make(map[string]string)
+
+	if err := viper.ReadInConfig(); err == nil {
+		for _, field := range []string{"test_mode_api_key", "live_mode_api_key", "api_key", "secret_key"} {
+			if key, err := resolveKey(viper.GetString(p.GetConfigField(field))); err == nil && key != "" {
+				keys[field] = key
+			}
+		}
+	}
+
+	return keys
+}
+
 func livemodeKeyField(livemode bool) string {
 	if livemode {
 		return "live_mode_api_key"
@@ -6,9 +6,12 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
 )
 
 func TestWriteProfile(t *testing.T) {
@@ -107,3 +110,151 @@ func helperLoadBytes(t *testing.T, name string) []byte {
 func cleanUp(file string) {
 	os.Remove(file)
 }
+
+func TestWriteProfileKeyExpiration(t *testing.T) {
+	profilesFile := filepath.Join(t.TempDir(), "config.toml")
+	viper.Reset()
+	viper.SetConfigFile(profilesFile)
+	defer viper.Reset()
+
+	expiresAt := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	p := Profile{
+		ProfileName:    "tests",
+		TestModeAPIKey: "sk_test_123",
+		KeyExpiresAt:   expiresAt,
+	}
+	require.NoError(t, p.writeProfile(viper.New()))
+
+	actual, ok := p.GetKeyExpiration()
+	require.True(t, ok)
+	require.Equal(t, expiresAt, actual)
+
+	// Keys configured without `stripe login` don't expire
+	p.KeyExpiresAt = time.Time{}
+	require.NoError(t, p.writeProfile(viper.New()))
+
+	_, ok = p.GetKeyExpiration()
+	require.False(t, ok)
+}
+
+func TestWriteProfileSandbox(t *testing.T) {
+	profilesFile := filepath.Join(t.TempDir(), "config.toml")
+	viper.Reset()
+	viper.SetConfigFile(profilesFile)
+	defer viper.Reset()
+
+	p := Profile{
+		ProfileName:    "tests",
+		TestModeAPIKey: "sk_test_123",
+		SandboxID:      "acct_sb1",
+		SandboxName:    "staging",
+	}
+	require.NoError(t, p.writeProfile(viper.New()))
+
+	id, name := p.GetSandbox()
+	require.Equal(t, "acct_sb1", id)
+	require.Equal(t, "staging", name)
+
+	// The keys of the account itself don't belong to the sandbox
+	p.SandboxID, p.SandboxName = "", ""
+	require.NoError(t, p.writeProfile(viper.New()))
+
+	id, _ = p.GetSandbox()
+	require.Empty(t, id)
+}
+
+func TestDeleteConfigField(t *testing.T) {
+	setupKeyringConfig(t)
+	p := Profile{ProfileName: "default"}
+
+	require.NoError(t, p.WriteConfigField("color", "off"))
+	require.Equal(t, "off", viper.GetString("default.color"))
+
+	require.NoError(t, p.DeleteConfigField("color"))
+	require.False(t, viper.IsSet("default.color"))
+
+	settings, err := p.GetSettings(false)
+	require.NoError(t, err)
+	require.NotContains(t, settings, "color")
+	require.Equal(t, "Main", settings["display_name"])
+
+	content, err := ioutil.ReadFile(viper.ConfigFileUsed())
+	require.NoError(t, err)
+	require.NotContains(t, string(content), "color")
+}
+
+func TestGetColor(t *testing.T) {
+	setupKeyringConfig(t)
+	p := Profile{ProfileName: "default"}
+
+	color, err := p.GetColor()
+	require.NoError(t, err)
+	require.Equal(t, ColorAuto, color)
+
+	require.NoError(t, p.WriteConfigField("color", ColorNever))
+	color, err = p.GetColor()
+	require.NoError(t, err)
+	require.Equal(t, ColorOff, color)
+
+	viper.Set("color", ColorAlways)
+	color, err = p.GetColor()
+	require.NoError(t, err)
+	require.Equal(t, ColorOn, color)
+}
+
+func TestSetColorTheme(t *testing.T) {
+	_, profilesFile := setupKeyringConfig(t)
+	t.Cleanup(func() { ansi.SetTheme("") }) // #nosec G104
+	p := Profile{ProfileName: "default"}
+
+	require.Error(t, p.SetColorTheme("sepia"))
+
+	require.NoError(t, p.SetColorTheme("Colorblind"))
+	require.Equal(t, "colorblind", GetColorTheme())
+
+	content, err := ioutil.ReadFile(profilesFile)
+	require.NoError(t, err)
+	require.Contains(t, string(content), "[color]\n  theme = \"colorblind\"")
+
+	// The theme doesn't hide the color setting of the profile
+	color, err := p.GetColor()
+	require.NoError(t, err)
+	require.Equal(t, ColorAuto, color)
+
+	require.NoError(t, p.UnsetColorTheme())
+	require.Empty(t, GetColorTheme())
+
+	content, err = ioutil.ReadFile(profilesFile)
+	require.NoError(t, err)
+	require.NotContains(t, string(content), "theme")
+	require.Contains(t, string(content), "display_name = \"Main\"")
+}
+
+func TestGetOpenShortcuts(t *testing.T) {
+	profilesFile := filepath.Join(t.TempDir(), "config.toml")
+	err := ioutil.WriteFile(profilesFile, []byte(`
+[open.shortcuts]
+  payouts = "https://dashboard.stripe.com/{{account}}/payouts"
+  runbook = "https://wiki.example.com/runbooks/stripe"
+
+[default]
+  display_name = "Main"
+
+[default.open.shortcuts]
+  runbook = "https://wiki.example.com/runbooks/payments"
+`), 0600)
+	require.NoError(t, err)
+
+	viper.Reset()
+	viper.SetConfigFile(profilesFile)
+	require.NoError(t, viper.ReadInConfig())
+
+	p := Profile{ProfileName: "default"}
+	require.Equal(t, map[string]string{
+		"payouts": "https://dashboard.stripe.com/{{account}}/payouts",
+		"runbook": "https://wiki.example.com/runbooks/payments",
+	}, p.GetOpenShortcuts())
+
+	other := Profile{ProfileName: "other"}
+	require.Equal(t, "https://wiki.example.com/runbooks/stripe", other.GetOpenShortcuts()["runbook"])
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// keyScopeFields are the API key fields of a profile, and the fields of the
+// scopes of their restricted keys
+var keyScopeFields = []struct {
+	key    string
+	scopes string
+}{
+	{"test_mode_api_key", "test_mode_key_scopes"},
+	{"live_mode_api_key", "live_mode_key_scopes"},
+}
+
+// keyScopes describes the scopes of the API key of a config value: every
+// scope for secret keys, or the scopes stored for restricted keys, if they
+// were validated
+func (p *Profile) keyScopes(value, scopesField string) string {
+	field := p.GetConfigField(scopesField)
+	if viper.IsSet(field) {
+		scopes := viper.GetStringSlice(field)
+		if len(scopes) == 0 {
+			return "none"
+		}
+
+		return strings.Join(scopes, ", ")
+	}
+
+	// Encrypted keys aren't decrypted, so that listing the config doesn't
+	// prompt the passphrase
+	if !IsEncryptedKey(value) {
+		if key, err := resolveKey(value); err == nil && strings.HasPrefix(key, "sk_") {
+			return "all, it's a secret key"
+		}
+	}
+
+	return "unknown, check them with `stripe login --with-key <key> --validate`"
+}
+
+// printKeyScopes prints the scopes of the API keys of the profile, as
+// comments so that the output of `stripe config --list` stays valid TOML
+func (p *Profile) printKeyScopes(w io.Writer) {
+	var lines []string
+	for _, fields := range keyScopeFields {
+		value := viper.GetString(p.GetConfigField(fields.key))
+		if value == "" {
+			continue
+		}
+
+		lines = append(lines, fmt.Sprintf("#   %s: %s", fields.key, p.keyScopes(value, fields.scopes)))
+	}
+
+	if len(lines) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\n# Scopes of the API keys of the %s profile\n", p.ProfileName)
+	fmt.Fprintln(w, strings.Join(lines, "\n"))
+}
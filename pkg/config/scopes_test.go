@@ -0,0 +1,43 @@
+package config
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintKeyScopes(t *testing.T) {
+	profilesFile := filepath.Join(t.TempDir(), "config.toml")
+	viper.Reset()
+	viper.SetConfigFile(profilesFile)
+	defer viper.Reset()
+
+	p := Profile{
+		ProfileName:       "tests",
+		LiveModeAPIKey:    "rk_live_123",
+		LiveModeKeyScopes: []string{"webhooks"},
+		TestModeAPIKey:    "sk_test_123",
+	}
+	require.NoError(t, p.writeProfile(viper.New()))
+	require.NoError(t, viper.ReadInConfig())
+
+	var buf bytes.Buffer
+	p.printKeyScopes(&buf)
+	require.Equal(t, `
+# Scopes of the API keys of the tests profile
+#   test_mode_api_key: all, it's a secret key
+#   live_mode_api_key: webhooks
+`, buf.String())
+
+	// The scopes of a restricted key that wasn't validated are unknown
+	p = Profile{ProfileName: "tests", LiveModeAPIKey: "rk_live_456"}
+	require.NoError(t, p.writeProfile(viper.New()))
+	require.NoError(t, viper.ReadInConfig())
+
+	buf.Reset()
+	p.printKeyScopes(&buf)
+	require.Contains(t, buf.String(), "live_mode_api_key: unknown")
+}
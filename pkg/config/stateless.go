@@ -0,0 +1,38 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"strconv"
+
+	"github.com/spf13/afero"
+)
+
+// StatelessEnv is the environment variable that turns on the stateless mode,
+// for containers and serverless functions with a read-only filesystem. The CLI
+// then never reads or writes the config file, and is configured with
+// environment variables only: STRIPE_API_KEY, STRIPE_DEVICE_NAME,
+// STRIPE_ACCOUNT_ID, STRIPE_PUBLISHABLE_KEY and
+// STRIPE_TERMINAL_POS_DEVICE_ID.
+const StatelessEnv = "STRIPE_CLI_STATELESS"
+
+// ErrStateless is the error returned by the commands that persist state when
+// the stateless mode is on
+var ErrStateless = errors.New("this command stores state in the config folder, which " + StatelessEnv + " disables. Configure the CLI with environment variables like STRIPE_API_KEY instead")
+
+// IsStateless returns whether the stateless mode is on
+func IsStateless() bool {
+	stateless, _ := strconv.ParseBool(os.Getenv(StatelessEnv))
+	return stateless
+}
+
+// StateFs returns the filesystem the state kept in the config folder across
+// runs, like the usage history, is stored in: fs, or a filesystem in memory
+// that's dropped on exit if the stateless mode is on
+func StateFs(fs afero.Fs) afero.Fs {
+	if IsStateless() {
+		return afero.NewMemMapFs()
+	}
+
+	return fs
+}
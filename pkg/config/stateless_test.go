@@ -0,0 +1,53 @@
+package config
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateless(t *testing.T) {
+	t.Setenv(StatelessEnv, "1")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("STRIPE_ACCOUNT_ID", "acct_env")
+
+	profilesFile := filepath.Join(t.TempDir(), "config.toml")
+	err := ioutil.WriteFile(profilesFile, []byte(`
+[default]
+  account_id = "acct_file"
+`), 0600)
+	require.NoError(t, err)
+
+	viper.Reset()
+	defer viper.Reset()
+
+	c := &Config{
+		Color:        "auto",
+		LogLevel:     "info",
+		Profile:      Profile{ProfileName: "default"},
+		ProfilesFile: profilesFile,
+	}
+	c.InitConfig()
+
+	require.Empty(t, c.ProfileNames())
+	require.Equal(t, "", viper.ConfigFileUsed())
+
+	accountID, err := c.Profile.GetAccountID()
+	require.NoError(t, err)
+	require.Equal(t, "acct_env", accountID)
+
+	require.Equal(t, ErrStateless, c.Profile.WriteConfigField("color", "off"))
+	require.Equal(t, ErrStateless, c.Profile.CreateProfile())
+	require.Equal(t, ErrStateless, c.RemoveProfile("default"))
+
+	// State kept across runs is dropped
+	fs := afero.NewOsFs()
+	require.IsType(t, &afero.MemMapFs{}, StateFs(fs))
+
+	t.Setenv(StatelessEnv, "false")
+	require.Equal(t, fs, StateFs(fs))
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+)
+
+// telemetryEnabledField is the setting that disables telemetry when false, in
+// the top-level `[telemetry]` table or in the table of a profile
+const telemetryEnabledField = "telemetry.enabled"
+
+// TelemetryEnabled returns whether the telemetry.enabled setting allows
+// sending telemetry. It's read from the config file on each call, so that a
+// long-running daemon honors it as soon as it changes.
+func (c *Config) TelemetryEnabled() bool {
+	viper.ReadInConfig() // #nosec G104
+
+	for _, field := range []string{telemetryEnabledField, c.Profile.GetConfigField(telemetryEnabledField)} {
+		if viper.IsSet(field) && !viper.GetBool(field) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SetTelemetryEnabled writes the top-level telemetry.enabled setting, which
+// applies to every profile
+func (c *Config) SetTelemetryEnabled(enabled bool) error {
+	if !enabled {
+		runtimeViper := viper.New()
+		for field, value := range viper.AllSettings() {
+			runtimeViper.Set(field, value)
+		}
+		runtimeViper.Set(telemetryEnabledField, false)
+
+		return syncConfig(runtimeViper)
+	}
+
+	runtimeViper, err := removeKey(viper.GetViper(), telemetryEnabledField)
+	if err != nil {
+		return err
+	}
+
+	return syncConfig(runtimeViper)
+}
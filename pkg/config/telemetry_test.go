@@ -0,0 +1,40 @@
+package config
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTelemetryEnabled(t *testing.T) {
+	profilesFile := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, ioutil.WriteFile(profilesFile, []byte(`
+[default]
+  device_name = "st-testing"
+
+[other]
+  [other.telemetry]
+    enabled = false
+`), 0600))
+
+	viper.Reset()
+	viper.SetConfigFile(profilesFile)
+	defer viper.Reset()
+
+	c := &Config{Profile: Profile{ProfileName: "default"}}
+	require.True(t, c.TelemetryEnabled())
+
+	// A profile can disable telemetry for itself
+	other := &Config{Profile: Profile{ProfileName: "other"}}
+	require.False(t, other.TelemetryEnabled())
+
+	require.NoError(t, c.SetTelemetryEnabled(false))
+	require.False(t, c.TelemetryEnabled())
+
+	require.NoError(t, c.SetTelemetryEnabled(true))
+	require.True(t, c.TelemetryEnabled())
+	require.False(t, other.TelemetryEnabled())
+}
@@ -0,0 +1,196 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// usageRetention is how long usage entries are kept
+const usageRetention = 180 * 24 * time.Hour
+
+// usageCompactSize is the size of the usage log past which the entries older
+// than usageRetention are dropped
+const usageCompactSize = 1 << 20
+
+// UsageEntry records a command run with a profile. API keys are redacted.
+type UsageEntry struct {
+	Time    time.Time `json:"time"`
+	Profile string    `json:"profile"`
+	Command string    `json:"command"`
+	Key     string    `json:"key,omitempty"`
+	// KeySource is where the key came from: "profile", "flag" or "env"
+	KeySource string `json:"key_source,omitempty"`
+}
+
+// UsageLog records which profiles and keys commands are run with, one JSON
+// entry per line, so `stripe config report` can tell which are stale.
+type UsageLog struct {
+	Fs   afero.Fs
+	Path string
+
+	// Now returns the current time, and defaults to time.Now
+	Now func() time.Time
+}
+
+// Record appends entry to the log. Its time is set to now.
+func (l *UsageLog) Record(entry UsageEntry) error {
+	entry.Time = l.now()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if info, err := l.Fs.Stat(l.Path); err == nil && info.Size() > usageCompactSize {
+		if err := l.compact(); err != nil {
+			return err
+		}
+	}
+
+	file, err := l.Fs.OpenFile(l.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(data, '\n'))
+
+	return err
+}
+
+// Since returns the entries recorded since t, oldest first.
+func (l *UsageLog) Since(t time.Time) ([]UsageEntry, error) {
+	data, err := afero.ReadFile(l.Fs, l.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []UsageEntry
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var entry UsageEntry
+		// Skip the lines that can't be read, e.g. if a write was interrupted
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+
+		if !entry.Time.Before(t) {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, scanner.Err()
+}
+
+// compact drops the entries older than usageRetention.
+func (l *UsageLog) compact() error {
+	entries, err := l.Since(l.now().Add(-usageRetention))
+	if err != nil {
+		return err
+	}
+
+	var b bytes.Buffer
+	for _, entry := range entries {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		b.Write(append(data, '\n'))
+	}
+
+	return afero.WriteFile(l.Fs, l.Path, b.Bytes(), 0600)
+}
+
+func (l *UsageLog) now() time.Time {
+	if l.Now != nil {
+		return l.Now()
+	}
+
+	return time.Now()
+}
+
+// ProfileUsage summarizes the usage of a profile
+type ProfileUsage struct {
+	Profile  string
+	Runs     int
+	LastUsed time.Time
+	// Commands counts the runs of each command
+	Commands map[string]int
+	// Keys counts the runs with each redacted key
+	Keys map[string]int
+}
+
+// TopCommands returns the n commands run the most, most run first.
+func (u *ProfileUsage) TopCommands(n int) []string {
+	commands := make([]string, 0, len(u.Commands))
+	for command := range u.Commands {
+		commands = append(commands, command)
+	}
+
+	sort.Slice(commands, func(i, j int) bool {
+		if u.Commands[commands[i]] != u.Commands[commands[j]] {
+			return u.Commands[commands[i]] > u.Commands[commands[j]]
+		}
+		return commands[i] < commands[j]
+	})
+
+	if len(commands) > n {
+		commands = commands[:n]
+	}
+
+	return commands
+}
+
+// SummarizeUsage returns the usage of each profile found in entries.
+func SummarizeUsage(entries []UsageEntry) map[string]*ProfileUsage {
+	usage := make(map[string]*ProfileUsage)
+
+	for _, entry := range entries {
+		u, ok := usage[entry.Profile]
+		if !ok {
+			u = &ProfileUsage{
+				Profile:  entry.Profile,
+				Commands: make(map[string]int),
+				Keys:     make(map[string]int),
+			}
+			usage[entry.Profile] = u
+		}
+
+		u.Runs++
+		u.Commands[entry.Command]++
+		if entry.Key != "" {
+			u.Keys[entry.Key]++
+		}
+		if entry.Time.After(u.LastUsed) {
+			u.LastUsed = entry.Time
+		}
+	}
+
+	return usage
+}
+
+// RedactKey returns a redacted version of an API key that's still
+// recognizable: its prefix, like `sk_test_`, and its last 4 characters.
+func RedactKey(key string) string {
+	if len(key) < 12 {
+		return strings.Repeat("*", len(key))
+	}
+
+	prefix := key[:3]
+	if i := strings.Index(key[3:], "_"); i >= 0 && i < 5 {
+		prefix = key[:3+i+1]
+	}
+
+	return prefix + "…" + key[len(key)-4:]
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsageLog(t *testing.T) {
+	now := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	log := &UsageLog{
+		Fs:   afero.NewMemMapFs(),
+		Path: "/config/usage_history.ndjson",
+		Now:  func() time.Time { return now },
+	}
+
+	entries, err := log.Since(now.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	require.NoError(t, log.Record(UsageEntry{Profile: "default", Command: "listen"}))
+	now = now.Add(2 * time.Hour)
+	require.NoError(t, log.Record(UsageEntry{Profile: "default", Command: "trigger", Key: "sk_test_…1234"}))
+
+	entries, err = log.Since(now.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, []UsageEntry{{Time: now, Profile: "default", Command: "trigger", Key: "sk_test_…1234"}}, entries)
+
+	entries, err = log.Since(time.Time{})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+}
+
+func TestUsageLogSkipsCorruptedLines(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "usage.ndjson", []byte("{\"time\":\"2022-03-01T12:00:00Z\",\"profile\":\"default\",\"command\":\"get\"}\n{\"time\":\n"), 0600)
+
+	entries, err := (&UsageLog{Fs: fs, Path: "usage.ndjson"}).Since(time.Time{})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "get", entries[0].Command)
+}
+
+func TestSummarizeUsage(t *testing.T) {
+	t1 := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+
+	usage := SummarizeUsage([]UsageEntry{
+		{Time: t2, Profile: "default", Command: "trigger", Key: "sk_test_…1234"},
+		{Time: t1, Profile: "default", Command: "listen", Key: "sk_test_…1234"},
+		{Time: t1, Profile: "default", Command: "trigger", Key: "rk_live_…9999"},
+		{Time: t1, Profile: "acme", Command: "get"},
+	})
+
+	require.Len(t, usage, 2)
+	require.Equal(t, 3, usage["default"].Runs)
+	require.Equal(t, t2, usage["default"].LastUsed)
+	require.Equal(t, map[string]int{"sk_test_…1234": 2, "rk_live_…9999": 1}, usage["default"].Keys)
+	require.Equal(t, []string{"trigger", "listen"}, usage["default"].TopCommands(3))
+	require.Equal(t, []string{"trigger"}, usage["default"].TopCommands(1))
+	require.Empty(t, usage["acme"].Keys)
+}
+
+func TestRedactKey(t *testing.T) {
+	require.Equal(t, "sk_test_…5678", RedactKey("sk_test_12345678"))
+	require.Equal(t, "rk_live_…abcd", RedactKey("rk_live_0123456789abcd"))
+	require.Equal(t, "sk_…wxyz", RedactKey("sk_0123456789wxyz"))
+	require.Equal(t, "*****", RedactKey("short"))
+}
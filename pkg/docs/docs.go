@@ -0,0 +1,311 @@
+// Package docs renders the reference documentation of resources and event
+// types bundled with the CLI, so it can be read in the terminal without
+// network access.
+package docs
+
+import (
+	_ "embed" // for the bundled reference
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+)
+
+// reference.json is generated from the OpenAPI spec by pkg/gen/gen_docs.go
+//
+//go:embed reference.json
+var referenceJSON []byte
+
+// wrapWidth is the width descriptions are wrapped to
+const wrapWidth = 80
+
+// markdownLink matches the links of descriptions, which are written in
+// Markdown
+var markdownLink = regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
+
+// paragraphSeparator matches the blank lines between paragraphs
+var paragraphSeparator = regexp.MustCompile(`\n\s*\n`)
+
+// Reference is the documentation bundled with the CLI.
+type Reference struct {
+	// APIVersion is the version of the API the reference documents
+	APIVersion string `json:"api_version"`
+	// Resources are the API resources, by name
+	Resources map[string]*Resource `json:"resources"`
+	// Events maps the event types to the resource of their object
+	Events map[string]string `json:"events"`
+	// Operations are the API operations, by HTTP method and path, like
+	// `POST /v1/customers`
+	Operations map[string]*Operation `json:"operations"`
+}
+
+// Resource documents an API resource.
+type Resource struct {
+	Name        string   `json:"-"`
+	Description string   `json:"description"`
+	Fields      []*Field `json:"fields"`
+}
+
+// Field documents a field of a resource.
+type Field struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Nullable    bool   `json:"nullable,omitempty"`
+	Expandable  bool   `json:"expandable,omitempty"`
+}
+
+// Operation documents the request parameters of an API operation.
+type Operation struct {
+	// Parameters are sorted by name, with the required ones first
+	Parameters []*Parameter `json:"parameters"`
+}
+
+// Parameter documents a request parameter of an operation.
+type Parameter struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+// Event documents an event type.
+type Event struct {
+	Type string
+	// Description tells when the event is sent
+	Description string
+	// Resource is the resource of the event's data.object
+	Resource *Resource
+}
+
+var (
+	loadOnce  sync.Once
+	reference *Reference
+	loadErr   error
+)
+
+// Load returns the bundled reference. It's parsed the first time it's used.
+func Load() (*Reference, error) {
+	loadOnce.Do(func() {
+		reference, loadErr = parseReference(referenceJSON)
+	})
+
+	return reference, loadErr
+}
+
+func parseReference(data []byte) (*Reference, error) {
+	var ref Reference
+	if err := json.Unmarshal(data, &ref); err != nil {
+		return nil, fmt.Errorf("failed to parse the bundled documentation: %v", err)
+	}
+
+	for name, resource := range ref.Resources {
+		resource.Name = name
+	}
+
+	return &ref, nil
+}
+
+// ResourceNames returns the names of the documented resources, sorted.
+func (r *Reference) ResourceNames() []string {
+	names := make([]string, 0, len(r.Resources))
+	for name := range r.Resources {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// EventTypes returns the documented event types, sorted.
+func (r *Reference) EventTypes() []string {
+	types := make([]string, 0, len(r.Events))
+	for eventType := range r.Events {
+		types = append(types, eventType)
+	}
+
+	sort.Strings(types)
+
+	return types
+}
+
+// Resource returns the documentation of the resource name. Names can be
+// written with dots or underscores, like `checkout.session` or
+// `checkout_session`.
+func (r *Reference) Resource(name string) (*Resource, error) {
+	if resource, ok := r.Resources[name]; ok {
+		return resource, nil
+	}
+
+	normalized := normalize(name)
+	for _, resource := range r.Resources {
+		if normalize(resource.Name) == normalized {
+			return resource, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no documentation for the resource ‘%s’%s", name, suggest(name, r.ResourceNames()))
+}
+
+// Event returns the documentation of eventType.
+func (r *Reference) Event(eventType string) (*Event, error) {
+	resourceName, ok := r.Events[eventType]
+	if !ok {
+		return nil, fmt.Errorf("no documentation for the event ‘%s’%s", eventType, suggest(eventType, r.EventTypes()))
+	}
+
+	resource := r.Resources[resourceName]
+
+	return &Event{
+		Type:        eventType,
+		Description: describeEvent(eventType, resource),
+		Resource:    resource,
+	}, nil
+}
+
+// Operation returns the documentation of the operation with an HTTP method and
+// path, like `POST /v1/customers/{customer}`.
+func (r *Reference) Operation(method, path string) (*Operation, bool) {
+	operation, ok := r.Operations[fmt.Sprintf("%s %s", strings.ToUpper(method), path)]
+	return operation, ok
+}
+
+// RenderResource writes the documentation of resource to w.
+func (r *Reference) RenderResource(w io.Writer, resource *Resource) {
+	fmt.Fprintln(w, ansi.Bold(resource.Name))
+	fmt.Fprintln(w, wrap(plainText(resource.Description), wrapWidth, ""))
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%s %s\n", ansi.Bold("Fields"), ansi.Faint(fmt.Sprintf("(API version %s)", r.APIVersion)))
+
+	renderFields(w, resource.Fields)
+}
+
+// RenderEvent writes the documentation of event to w.
+func (r *Reference) RenderEvent(w io.Writer, event *Event) {
+	fmt.Fprintln(w, ansi.Bold(event.Type))
+	fmt.Fprintln(w, wrap(event.Description, wrapWidth, ""))
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%s is %s %s %s\n",
+		ansi.Bold("data.object"),
+		article(event.Resource.Name),
+		ansi.Bold(event.Resource.Name),
+		ansi.Faint(fmt.Sprintf("(API version %s)", r.APIVersion)),
+	)
+	fmt.Fprintln(w, wrap(plainText(event.Resource.Description), wrapWidth, ""))
+	fmt.Fprintln(w)
+
+	renderFields(w, event.Resource.Fields)
+}
+
+func renderFields(w io.Writer, fields []*Field) {
+	for _, field := range fields {
+		attributes := []string{field.Type}
+		if field.Nullable {
+			attributes = append(attributes, "nullable")
+		}
+		if field.Expandable {
+			attributes = append(attributes, "expandable")
+		}
+
+		fmt.Fprintf(w, "  %s %s\n", ansi.Bold(field.Name), ansi.Faint(strings.Join(attributes, ", ")))
+		if field.Description != "" {
+			fmt.Fprintln(w, wrap(plainText(field.Description), wrapWidth, "      "))
+		}
+	}
+}
+
+// RenderParameters writes the documentation of the parameters of operation to
+// w. Parameters are named by name, like the flag that sets them.
+func (r *Reference) RenderParameters(w io.Writer, operation *Operation, name func(*Parameter) string) {
+	for _, param := range operation.Parameters {
+		attributes := []string{param.Type}
+		if param.Required {
+			attributes = append(attributes, "required")
+		}
+
+		fmt.Fprintf(w, "  %s %s\n", ansi.Bold(name(param)), ansi.Faint(strings.Join(attributes, ", ")))
+		if param.Description != "" {
+			fmt.Fprintln(w, wrap(plainText(param.Description), wrapWidth, "      "))
+		}
+	}
+}
+
+// plainText replaces the Markdown links of s with their text followed by
+// their URL.
+func plainText(s string) string {
+	return markdownLink.ReplaceAllStringFunc(s, func(link string) string {
+		groups := markdownLink.FindStringSubmatch(link)
+
+		url := groups[2]
+		if strings.HasPrefix(url, "/") {
+			url = "https://stripe.com" + url
+		}
+
+		return fmt.Sprintf("%s (%s)", groups[1], url)
+	})
+}
+
+// wrap wraps the paragraphs of s to width, indenting every line with indent.
+// Paragraphs are separated by blank lines.
+func wrap(s string, width int, indent string) string {
+	var lines []string
+
+	for i, paragraph := range paragraphSeparator.Split(strings.TrimSpace(s), -1) {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+
+		line := indent
+		for _, word := range strings.Fields(paragraph) {
+			if line != indent && len(line)+1+len(word) > width {
+				lines = append(lines, line)
+				line = indent
+			}
+			if line != indent {
+				line += " "
+			}
+			line += word
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func normalize(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), "_", ".")
+}
+
+// suggest returns a hint naming the names closest to name, if any.
+func suggest(name string, names []string) string {
+	var matches []string
+
+	for _, candidate := range names {
+		if strings.Contains(candidate, name) || strings.Contains(name, candidate) {
+			matches = append(matches, candidate)
+		}
+		if len(matches) == 3 {
+			break
+		}
+	}
+
+	if len(matches) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(". Did you mean %s?", strings.Join(matches, ", "))
+}
+
+func article(word string) string {
+	if word != "" && strings.ContainsRune("aeiou", rune(word[0])) {
+		return "an"
+	}
+
+	return "a"
+}
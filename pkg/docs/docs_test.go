@@ -0,0 +1,120 @@
+package docs
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	ref, err := Load()
+	require.NoError(t, err)
+	require.NotEmpty(t, ref.APIVersion)
+	require.Contains(t, ref.ResourceNames(), "subscription")
+
+	for eventType, resource := range ref.Events {
+		require.Contains(t, ref.Resources, resource, eventType)
+	}
+}
+
+func TestEvent(t *testing.T) {
+	ref, err := Load()
+	require.NoError(t, err)
+
+	event, err := ref.Event("payment_intent.succeeded")
+	require.NoError(t, err)
+	require.Equal(t, "payment_intent", event.Resource.Name)
+	require.Equal(t, "Occurs when a PaymentIntent has successfully completed payment.", event.Description)
+
+	event, err = ref.Event("customer.subscription.created")
+	require.NoError(t, err)
+	require.Equal(t, "subscription", event.Resource.Name)
+	require.Equal(t, "Occurs whenever a subscription is created.", event.Description)
+
+	_, err = ref.Event("payment_intent")
+	require.EqualError(t, err, "no documentation for the event ‘payment_intent’. Did you mean payment_intent.amount_capturable_updated, payment_intent.canceled, payment_intent.created?")
+}
+
+func TestResource(t *testing.T) {
+	ref, err := Load()
+	require.NoError(t, err)
+
+	resource, err := ref.Resource("checkout_session")
+	require.NoError(t, err)
+	require.Equal(t, "checkout.session", resource.Name)
+
+	_, err = ref.Resource("unicorn")
+	require.EqualError(t, err, "no documentation for the resource ‘unicorn’")
+}
+
+func TestDescribeEvent(t *testing.T) {
+	resource := &Resource{Name: "issuing.dispute"}
+
+	require.Equal(t, "Occurs whenever an issuing dispute is submitted.", describeEvent("issuing_dispute.submitted", resource))
+	require.Equal(t, "Occurs whenever an issuing dispute has failed.", describeEvent("issuing_dispute.failed", resource))
+	require.Equal(t, "Occurs whenever an issuing dispute reports funds withdrawn.", describeEvent("issuing_dispute.funds_withdrawn", resource))
+}
+
+func TestRenderResource(t *testing.T) {
+	ref := &Reference{APIVersion: "2020-08-27"}
+	resource := &Resource{
+		Name:        "coupon",
+		Description: "A coupon contains information about a percent-off or amount-off discount.\n\nRelated guide: [Coupons](/docs/billing/coupons).",
+		Fields: []*Field{
+			{Name: "amount_off", Type: "integer", Nullable: true, Description: "Amount that will be taken off."},
+			{Name: "id", Type: "string"},
+		},
+	}
+
+	var out bytes.Buffer
+	ref.RenderResource(&out, resource)
+
+	require.Equal(t, `coupon
+A coupon contains information about a percent-off or amount-off discount.
+
+Related guide: Coupons (https://stripe.com/docs/billing/coupons).
+
+Fields (API version 2020-08-27)
+  amount_off integer, nullable
+      Amount that will be taken off.
+  id string
+`, out.String())
+}
+
+func TestOperation(t *testing.T) {
+	ref, err := Load()
+	require.NoError(t, err)
+
+	operation, ok := ref.Operation("post", "/v1/payment_intents")
+	require.True(t, ok)
+	require.Equal(t, "amount", operation.Parameters[0].Name)
+	require.Equal(t, "integer", operation.Parameters[0].Type)
+	require.True(t, operation.Parameters[0].Required)
+
+	_, ok = ref.Operation("post", "/v1/unicorns")
+	require.False(t, ok)
+}
+
+func TestRenderParameters(t *testing.T) {
+	ref := &Reference{APIVersion: "2020-08-27"}
+	operation := &Operation{
+		Parameters: []*Parameter{
+			{Name: "amount", Type: "integer", Required: true, Description: "Amount intended to be collected, in the [smallest currency unit](/docs/currencies)."},
+			{Name: "metadata", Type: `hash | ""`},
+		},
+	}
+
+	var out bytes.Buffer
+	ref.RenderParameters(&out, operation, func(param *Parameter) string { return "--" + param.Name })
+
+	require.Equal(t, `  --amount integer, required
+      Amount intended to be collected, in the smallest currency unit
+      (https://stripe.com/docs/currencies).
+  --metadata hash | ""
+`, out.String())
+}
+
+func TestWrap(t *testing.T) {
+	require.Equal(t, "  one two\n  three\n\n  four", wrap("one two\nthree\n\nfour", 10, "  "))
+}
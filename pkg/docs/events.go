@@ -0,0 +1,97 @@
+package docs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// eventDescriptions tells when the event types whose name doesn't say it all
+// are sent. Other event types are described after their action, like
+// `customer.created`.
+var eventDescriptions = map[string]string{
+	"account.application.authorized":                           "Occurs whenever a user authorizes an application. Sent to the related application only.",
+	"account.application.deauthorized":                         "Occurs whenever a user deauthorizes an application. Sent to the related application only.",
+	"account.updated":                                          "Occurs whenever an account status or property has changed.",
+	"balance.available":                                        "Occurs whenever your Stripe balance has been updated, e.g. when a charge is available to be paid out.",
+	"cash_balance.funds_available":                             "Occurs whenever there is a positive remaining cash balance after Stripe automatically reconciles new funds into the cash balance.",
+	"charge.captured":                                          "Occurs whenever a previously uncaptured charge is captured.",
+	"charge.dispute.closed":                                    "Occurs when a dispute is closed and its status changes to lost, warning_closed, or won.",
+	"charge.dispute.created":                                   "Occurs whenever a customer disputes a charge with their bank.",
+	"charge.dispute.funds_reinstated":                          "Occurs when funds are reinstated to your account after a dispute is closed, including partially refunded payments.",
+	"charge.dispute.funds_withdrawn":                           "Occurs when funds are removed from your account due to a dispute.",
+	"charge.expired":                                           "Occurs whenever an uncaptured charge expires.",
+	"charge.pending":                                           "Occurs whenever a pending charge is created.",
+	"charge.refunded":                                          "Occurs whenever a charge is refunded, including partial refunds.",
+	"checkout.session.async_payment_failed":                    "Occurs when a payment intent using a delayed payment method fails.",
+	"checkout.session.async_payment_succeeded":                 "Occurs when a payment intent using a delayed payment method finally succeeds.",
+	"checkout.session.completed":                               "Occurs when a Checkout Session has been successfully completed.",
+	"customer.source.expiring":                                 "Occurs whenever a card or source will expire at the end of the month.",
+	"customer.subscription.deleted":                            "Occurs whenever a customer's subscription ends.",
+	"customer.subscription.pending_update_applied":             "Occurs whenever a subscription's pending update is applied, and the subscription is updated.",
+	"customer.subscription.pending_update_expired":             "Occurs whenever a subscription's pending update expires before the related invoice is paid.",
+	"customer.subscription.trial_will_end":                     "Occurs three days before a subscription's trial period is scheduled to end, or when a trial is ended immediately with trial_end=now.",
+	"identity.verification_session.processing":                 "Occurs whenever a VerificationSession transitions to processing.",
+	"identity.verification_session.requires_input":             "Occurs whenever a VerificationSession transitions to require user input.",
+	"invoice.finalization_failed":                              "Occurs whenever a draft invoice cannot be finalized.",
+	"invoice.finalized":                                        "Occurs whenever a draft invoice is finalized and updated to be an open invoice.",
+	"invoice.marked_uncollectible":                             "Occurs whenever an invoice is marked uncollectible.",
+	"invoice.paid":                                             "Occurs whenever an invoice payment attempt succeeds or an invoice is marked as paid out-of-band.",
+	"invoice.payment_action_required":                          "Occurs whenever an invoice payment attempt requires further user action to complete.",
+	"invoice.payment_failed":                                   "Occurs whenever an invoice payment attempt fails, due either to a declined payment or to the lack of a stored payment method.",
+	"invoice.payment_succeeded":                                "Occurs whenever an invoice payment attempt succeeds.",
+	"invoice.sent":                                             "Occurs whenever an invoice email is sent out.",
+	"invoice.upcoming":                                         "Occurs a number of days before a subscription is scheduled to create an invoice that is automatically charged. The number of days is set in your subscriptions settings.",
+	"issuing_authorization.request":                            "Represents a synchronous request for authorization of an Issuing card payment, which your integration approves or declines.",
+	"issuing_dispute.funds_reinstated":                         "Occurs whenever funds are reinstated to your account for an Issuing dispute.",
+	"order.payment_failed":                                     "Occurs whenever payment is attempted on an order, and the payment fails.",
+	"order.payment_succeeded":                                  "Occurs whenever payment is attempted on an order, and the payment succeeds.",
+	"payment_intent.amount_capturable_updated":                 "Occurs when a PaymentIntent has funds to be captured.",
+	"payment_intent.partially_funded":                          "Occurs when funds are applied to a customer_balance PaymentIntent and the amount remaining changes.",
+	"payment_intent.payment_failed":                            "Occurs when a PaymentIntent has failed the attempt to create a payment method or a payment.",
+	"payment_intent.processing":                                "Occurs when a PaymentIntent has started processing.",
+	"payment_intent.requires_action":                           "Occurs when a PaymentIntent transitions to the requires_action state.",
+	"payment_intent.succeeded":                                 "Occurs when a PaymentIntent has successfully completed payment.",
+	"payment_method.attached":                                  "Occurs whenever a new payment method is attached to a customer.",
+	"payment_method.automatically_updated":                     "Occurs whenever a payment method's details are automatically updated by the network.",
+	"payment_method.detached":                                  "Occurs whenever a payment method is detached from a customer.",
+	"payout.failed":                                            "Occurs whenever a payout attempt fails.",
+	"payout.paid":                                              "Occurs whenever a payout is expected to be available in the destination account. If the payout fails, a payout.failed event is also sent later.",
+	"review.closed":                                            "Occurs whenever a review is closed. The review's reason field tells why: approved, disputed, refunded, or refunded_as_fraud.",
+	"setup_intent.requires_action":                             "Occurs when a SetupIntent transitions to the requires_action state.",
+	"setup_intent.setup_failed":                                "Occurs when a SetupIntent has failed the attempt to set up a payment method.",
+	"setup_intent.succeeded":                                   "Occurs when a SetupIntent has successfully set up a payment method.",
+	"source.chargeable":                                        "Occurs whenever a source transitions to chargeable.",
+	"source.mandate_notification":                              "Occurs whenever a source mandate notification method is set to manual.",
+	"source.refund_attributes_required":                        "Occurs whenever the refund attributes are required on a receiver source to process a refund or a mispayment.",
+	"subscription_schedule.aborted":                            "Occurs whenever a subscription schedule is canceled because its subscription was canceled due to delinquency.",
+	"subscription_schedule.expiring":                           "Occurs 7 days before a subscription schedule will expire.",
+	"terminal.reader.action_failed":                            "Occurs whenever an action sent to a Terminal reader failed.",
+	"terminal.reader.action_succeeded":                         "Occurs whenever an action sent to a Terminal reader was successful.",
+	"test_helpers.test_clock.advancing":                        "Occurs whenever a test clock starts advancing.",
+	"test_helpers.test_clock.internal_failure":                 "Occurs whenever a test clock fails to advance its frozen time.",
+	"test_helpers.test_clock.ready":                            "Occurs whenever a test clock transitions to a ready status.",
+	"transfer.paid":                                            "Occurs after a transfer is paid.",
+	"treasury.debit_reversal.initial_credit_granted":           "Occurs when an initial credit is granted on a DebitReversal.",
+	"treasury.financial_account.features_status_updated":       "Occurs whenever the statuses of any features of a FinancialAccount are updated.",
+	"treasury.outbound_payment.expected_arrival_date_updated":  "Occurs whenever the expected arrival date of an OutboundPayment updates.",
+	"treasury.outbound_transfer.expected_arrival_date_updated": "Occurs whenever the expected arrival date of an OutboundTransfer updates.",
+}
+
+// describeEvent tells when eventType is sent.
+func describeEvent(eventType string, resource *Resource) string {
+	if description, ok := eventDescriptions[eventType]; ok {
+		return description
+	}
+
+	action := eventType[strings.LastIndex(eventType, ".")+1:]
+	object := strings.NewReplacer("_", " ", ".", " ").Replace(resource.Name)
+
+	switch {
+	case action == "succeeded" || action == "failed":
+		return fmt.Sprintf("Occurs whenever %s %s has %s.", article(object), object, action)
+	case strings.HasSuffix(action, "ed") && !strings.Contains(action, "_"):
+		return fmt.Sprintf("Occurs whenever %s %s is %s.", article(object), object, action)
+	default:
+		return fmt.Sprintf("Occurs whenever %s %s reports %s.", article(object), object, strings.ReplaceAll(action, "_", " "))
+	}
+}
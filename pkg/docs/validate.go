@@ -0,0 +1,186 @@
+package docs
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Validate checks an API response against the resources of the reference,
+// and returns the differences found, like fields the reference doesn't
+// document or values of an unexpected type. Objects of resources the
+// reference doesn't document aren't checked.
+func (r *Reference) Validate(response interface{}) []string {
+	var problems []string
+
+	r.validateObject(response, "", &problems)
+
+	return problems
+}
+
+// validateObject checks an object whose resource is given by its `object`
+// field, or the objects of a list.
+func (r *Reference) validateObject(value interface{}, path string, problems *[]string) {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	name, _ := obj["object"].(string)
+	if name == "list" || name == "search_result" {
+		data, _ := obj["data"].([]interface{})
+		for i, item := range data {
+			r.validateObject(item, fmt.Sprintf("%s[%d]", joinPath(path, "data"), i), problems)
+		}
+		return
+	}
+
+	resource, ok := r.Resources[name]
+	if !ok {
+		return
+	}
+
+	if path == "" {
+		path = name
+	}
+
+	fields := make(map[string]*Field, len(resource.Fields))
+	for _, field := range resource.Fields {
+		fields[field.Name] = field
+	}
+
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fieldPath := joinPath(path, key)
+
+		field, ok := fields[key]
+		if !ok {
+			*problems = append(*problems, fmt.Sprintf("unexpected field ‘%s’", fieldPath))
+			continue
+		}
+
+		r.validateField(field, obj[key], fieldPath, problems)
+	}
+}
+
+func (r *Reference) validateField(field *Field, value interface{}, path string, problems *[]string) {
+	if value == nil {
+		if !field.Nullable {
+			*problems = append(*problems, fmt.Sprintf("‘%s’ is null, expected %s", path, field.Type))
+		}
+		return
+	}
+
+	// Unexpanded fields are the ID of the object
+	if _, ok := value.(string); ok && field.Expandable {
+		return
+	}
+
+	if problem := r.validateValue(field.Type, value, path, problems); problem != "" {
+		*problems = append(*problems, problem)
+	}
+}
+
+// validateValue checks that value is of the type described by typeName, as
+// written by typeName in pkg/gen/gen_docs.go. It returns the problem with
+// value itself, and appends the problems of the objects it contains.
+func (r *Reference) validateValue(typeName string, value interface{}, path string, problems *[]string) string {
+	switch {
+	case typeName == "any":
+		return ""
+	case strings.Contains(typeName, " | "):
+		for _, alternative := range strings.Split(typeName, " | ") {
+			var nested []string
+			if r.validateValue(alternative, value, path, &nested) == "" {
+				*problems = append(*problems, nested...)
+				return ""
+			}
+		}
+	case strings.HasPrefix(typeName, "enum ("):
+		s, ok := value.(string)
+		if !ok {
+			break
+		}
+		for _, v := range strings.Split(strings.TrimSuffix(strings.TrimPrefix(typeName, "enum ("), ")"), ", ") {
+			if s == v {
+				return ""
+			}
+		}
+		return fmt.Sprintf("‘%s’ is ‘%s’, expected one of %s", path, s, strings.TrimPrefix(typeName, "enum "))
+	case strings.HasPrefix(typeName, "array of "):
+		items, ok := value.([]interface{})
+		if !ok {
+			break
+		}
+		for i, item := range items {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			if problem := r.validateValue(strings.TrimPrefix(typeName, "array of "), item, itemPath, problems); problem != "" {
+				*problems = append(*problems, problem)
+			}
+		}
+		return ""
+	case strings.HasPrefix(typeName, "list of "):
+		if _, ok := value.(map[string]interface{}); ok {
+			r.validateObject(value, path, problems)
+			return ""
+		}
+	case typeName == "string":
+		if _, ok := value.(string); ok {
+			return ""
+		}
+	case typeName == "boolean":
+		if _, ok := value.(bool); ok {
+			return ""
+		}
+	case typeName == "integer" || typeName == "timestamp":
+		if n, ok := value.(float64); ok && n == math.Trunc(n) {
+			return ""
+		}
+	case typeName == "number":
+		if _, ok := value.(float64); ok {
+			return ""
+		}
+	default:
+		// hash, and the resources and other objects named by their schema
+		if _, ok := value.(map[string]interface{}); ok {
+			if _, isResource := r.Resources[typeName]; isResource {
+				r.validateObject(value, path, problems)
+			}
+			return ""
+		}
+	}
+
+	return fmt.Sprintf("‘%s’ is %s, expected %s", path, jsonType(value), typeName)
+}
+
+// jsonType names the JSON type of a decoded value
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	case float64:
+		return "a number"
+	case []interface{}:
+		return "an array"
+	case map[string]interface{}:
+		return "an object"
+	default:
+		return "null"
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
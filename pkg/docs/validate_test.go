@@ -0,0 +1,97 @@
+package docs
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var validateReference = &Reference{
+	APIVersion: "2020-08-27",
+	Resources: map[string]*Resource{
+		"customer": {Fields: []*Field{
+			{Name: "id", Type: "string"},
+			{Name: "object", Type: "enum (customer)"},
+			{Name: "balance", Type: "integer"},
+			{Name: "created", Type: "timestamp"},
+			{Name: "email", Type: "string", Nullable: true},
+			{Name: "metadata", Type: "hash"},
+			{Name: "default_source", Type: "card", Nullable: true, Expandable: true},
+			{Name: "preferred_locales", Type: "array of string"},
+			{Name: "tax_exempt", Type: "enum (exempt, none, reverse)"},
+			{Name: "subscriptions", Type: "list of subscription"},
+		}},
+		"card": {Fields: []*Field{
+			{Name: "id", Type: "string"},
+			{Name: "object", Type: "enum (card)"},
+			{Name: "last4", Type: "string"},
+		}},
+		"subscription": {Fields: []*Field{
+			{Name: "id", Type: "string"},
+			{Name: "object", Type: "enum (subscription)"},
+			{Name: "customer", Type: "customer | deleted_customer", Expandable: true},
+		}},
+	},
+}
+
+func validate(t *testing.T, response string) []string {
+	var decoded interface{}
+	require.NoError(t, json.Unmarshal([]byte(response), &decoded))
+
+	return validateReference.Validate(decoded)
+}
+
+func TestValidateMatchingResponse(t *testing.T) {
+	problems := validate(t, `{
+		"id": "cus_123",
+		"object": "customer",
+		"balance": 0,
+		"created": 1600000000,
+		"email": null,
+		"metadata": {"order": "6735"},
+		"default_source": "card_123",
+		"preferred_locales": ["en"],
+		"tax_exempt": "none",
+		"subscriptions": {"object": "list", "data": [{"id": "sub_123", "object": "subscription", "customer": "cus_123"}]}
+	}`)
+	require.Empty(t, problems)
+}
+
+func TestValidateUnexpectedFieldsAndTypes(t *testing.T) {
+	problems := validate(t, `{
+		"id": "cus_123",
+		"object": "customer",
+		"balance": "0",
+		"created": 1.5,
+		"metadata": null,
+		"default_source": {"id": "card_123", "object": "card", "last4": 4242},
+		"preferred_locales": ["en", 1],
+		"tax_exempt": "partial",
+		"test_clock": null
+	}`)
+	require.Equal(t, []string{
+		"‘customer.balance’ is a string, expected integer",
+		"‘customer.created’ is a number, expected timestamp",
+		"‘customer.default_source.last4’ is a number, expected string",
+		"‘customer.metadata’ is null, expected hash",
+		"‘customer.preferred_locales[1]’ is a number, expected string",
+		"‘customer.tax_exempt’ is ‘partial’, expected one of (exempt, none, reverse)",
+		"unexpected field ‘customer.test_clock’",
+	}, problems)
+}
+
+func TestValidateList(t *testing.T) {
+	problems := validate(t, `{
+		"object": "list",
+		"data": [
+			{"id": "cus_123", "object": "customer"},
+			{"id": "cus_456", "object": "customer", "livemode_v2": true}
+		]
+	}`)
+	require.Equal(t, []string{"unexpected field ‘data[1].livemode_v2’"}, problems)
+}
+
+func TestValidateUnknownResource(t *testing.T) {
+	require.Empty(t, validate(t, `{"id": "thing_123", "object": "thing", "anything": 1}`))
+}
@@ -0,0 +1,246 @@
+// Package download downloads and verifies the release assets and archives
+// that the CLI installs, like its own releases, stripe-mock, samples and fixture packs.
+package download
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// maxArchiveFileSize caps the size of the files extracted from an archive
+const maxArchiveFileSize = 100 * 1024 * 1024
+
+// archiveDownloadTimeout is how long downloading an archive may take
+const archiveDownloadTimeout = 5 * time.Minute
+
+// Archive downloads the .tar.gz archive at url and extracts its
+// regular files into path, without the first stripComponents directories of
+// their names like `tar --strip-components`. GitHub archives put the files of
+// the repo in a top-level directory, and are extracted with stripComponents
+// set to 1.
+func Archive(fs afero.Fs, url, path string, stripComponents int) error {
+	client := &http.Client{Timeout: archiveDownloadTimeout}
+
+	resp, err := client.Get(url) // #nosec G107
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+
+	return ExtractTarGz(fs, resp.Body, path, stripComponents)
+}
+
+// ExtractTarGz extracts the regular files of the .tar.gz archive read from r
+// into path, like Archive.
+func ExtractTarGz(fs afero.Fs, r io.Reader, path string, stripComponents int) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := filepath.Clean(filepath.FromSlash(header.Name))
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("the archive contains an invalid path: %s", header.Name)
+		}
+
+		parts := strings.Split(name, string(filepath.Separator))
+		if len(parts) <= stripComponents {
+			continue
+		}
+		name = filepath.Join(parts[stripComponents:]...)
+
+		if header.Size > maxArchiveFileSize {
+			return fmt.Errorf("%s is larger than %d bytes", header.Name, maxArchiveFileSize)
+		}
+
+		target := filepath.Join(path, name)
+		if err := fs.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+
+		// Keep the executable bits, for scripts of the samples
+		f, err := fs.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode)&0755|0644)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.Copy(f, io.LimitReader(tr, maxArchiveFileSize))
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// File downloads the file at url, like a release asset, failing if it
+// takes longer than timeout or is larger than maxSize bytes.
+func File(ctx context.Context, url string, timeout time.Duration, maxSize int64) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("%s is larger than %d MB", url, maxSize/1024/1024)
+	}
+
+	return data, nil
+}
+
+// FindChecksum returns the SHA-256 of name in a checksums file of a release,
+// whose lines are like `<sha256>  <name>`. It returns false if name isn't
+// listed.
+func FindChecksum(checksums []byte, name string) (string, bool) {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[1] == name {
+			return fields[0], true
+		}
+	}
+
+	return "", false
+}
+
+// ChecksumMatches returns whether the SHA-256 of data is checksum, in
+// hexadecimal.
+func ChecksumMatches(data []byte, checksum string) bool {
+	sum := sha256.Sum256(data)
+	return strings.EqualFold(hex.EncodeToString(sum[:]), checksum)
+}
+
+// ReadArchiveFile returns the content of the regular file named name, in any
+// directory, of a release archive. The archive is read as a .zip if
+// archiveName ends with .zip, and as a .tar.gz otherwise.
+func ReadArchiveFile(archive []byte, archiveName, name string) ([]byte, error) {
+	var data []byte
+	var err error
+
+	if strings.HasSuffix(archiveName, ".zip") {
+		data, err = readZipFile(archive, name)
+	} else {
+		data, err = readTarGzFile(archive, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, fmt.Errorf("the archive %s has no %s file", archiveName, name)
+	}
+
+	return data, nil
+}
+
+func readTarGzFile(archive []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if header.Typeflag == tar.TypeReg && filepath.Base(header.Name) == name {
+			return readArchiveEntry(tr, header.Name)
+		}
+	}
+}
+
+func readZipFile(archive []byte, name string) ([]byte, error) {
+	r, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range r.File {
+		if file.FileInfo().IsDir() || filepath.Base(file.Name) != name {
+			continue
+		}
+
+		f, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		return readArchiveEntry(f, file.Name)
+	}
+
+	return nil, nil
+}
+
+// readArchiveEntry reads a file of an archive, up to maxArchiveFileSize
+func readArchiveEntry(r io.Reader, name string) ([]byte, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(r, maxArchiveFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > maxArchiveFileSize {
+		return nil, fmt.Errorf("%s is larger than %d bytes", name, maxArchiveFileSize)
+	}
+
+	return data, nil
+}
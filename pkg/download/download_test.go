@@ -0,0 +1,125 @@
+package download
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/download/downloadtest"
+)
+
+func TestArchive(t *testing.T) {
+	archive := downloadtest.TarGz(t, map[string]string{
+		"accept-a-payment-main/.cli.json":            "accept-a-payment-main/.cli.json",
+		"accept-a-payment-main/server/node/index.js": "accept-a-payment-main/server/node/index.js",
+	})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive)
+	}))
+	defer ts.Close()
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, Archive(fs, ts.URL, "/cache/accept-a-payment", 1))
+
+	content, err := afero.ReadFile(fs, "/cache/accept-a-payment/server/node/index.js")
+	require.NoError(t, err)
+	require.Equal(t, "accept-a-payment-main/server/node/index.js", string(content))
+
+	exists, _ := afero.Exists(fs, "/cache/accept-a-payment/.cli.json")
+	require.True(t, exists)
+}
+
+func TestArchiveNotFound(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	require.Error(t, Archive(afero.NewMemMapFs(), ts.URL, "/cache/sample", 1))
+}
+
+func TestExtractTarGzRejectsTraversal(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	archive := downloadtest.TarGz(t, map[string]string{"../evil.json": "{}"})
+
+	require.Error(t, ExtractTarGz(fs, bytes.NewReader(archive), "/packs/acme", 0))
+
+	exists, _ := afero.Exists(fs, "/packs/evil.json")
+	require.False(t, exists)
+}
+
+func TestFile(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("release asset"))
+	}))
+	defer ts.Close()
+
+	data, err := File(context.Background(), ts.URL+"/asset", time.Minute, 1024)
+	require.NoError(t, err)
+	require.Equal(t, "release asset", string(data))
+
+	_, err = File(context.Background(), ts.URL+"/asset", time.Minute, 4)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is larger than")
+
+	_, err = File(context.Background(), ts.URL+"/missing", time.Minute, 1024)
+	require.EqualError(t, err, "failed to download "+ts.URL+"/missing: 404 Not Found")
+}
+
+func TestFindChecksum(t *testing.T) {
+	checksums := []byte("abc123  stripe_1.7.0_linux_x86_64.tar.gz\ndef456  stripe_1.7.0_windows_x86_64.zip\n")
+
+	checksum, ok := FindChecksum(checksums, "stripe_1.7.0_windows_x86_64.zip")
+	require.True(t, ok)
+	require.Equal(t, "def456", checksum)
+
+	_, ok = FindChecksum(checksums, "stripe_1.7.0_plan9_386.tar.gz")
+	require.False(t, ok)
+}
+
+func TestChecksumMatches(t *testing.T) {
+	sum := sha256.Sum256([]byte("release asset"))
+	checksum := hex.EncodeToString(sum[:])
+
+	require.True(t, ChecksumMatches([]byte("release asset"), checksum))
+	require.True(t, ChecksumMatches([]byte("release asset"), strings.ToUpper(checksum)))
+	require.False(t, ChecksumMatches([]byte("tampered asset"), checksum))
+}
+
+func TestReadArchiveFile(t *testing.T) {
+	tarGz := downloadtest.TarGz(t, map[string]string{
+		"stripe-mock_0.1.0/README.md":   "# stripe-mock",
+		"stripe-mock_0.1.0/stripe-mock": "stripe-mock_0.1.0/stripe-mock",
+	})
+
+	data, err := ReadArchiveFile(tarGz, "stripe-mock_0.1.0_linux_amd64.tar.gz", "stripe-mock")
+	require.NoError(t, err)
+	require.Equal(t, "stripe-mock_0.1.0/stripe-mock", string(data))
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("bin/stripe.exe")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("binary"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	data, err = ReadArchiveFile(buf.Bytes(), "stripe_1.7.0_windows_x86_64.zip", "stripe.exe")
+	require.NoError(t, err)
+	require.Equal(t, "binary", string(data))
+
+	_, err = ReadArchiveFile(tarGz, "stripe-mock_0.1.0_linux_amd64.tar.gz", "stripe")
+	require.EqualError(t, err, "the archive stripe-mock_0.1.0_linux_amd64.tar.gz has no stripe file")
+}
@@ -0,0 +1,39 @@
+// Package downloadtest builds the archives that the tests of downloads, like
+// releases and fixture packs, serve.
+package downloadtest
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TarGz returns a .tar.gz archive of files, by name, with the names in order.
+// The files are executable, like the binaries of releases.
+func TarGz(t testing.TB, files map[string]string) []byte {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	for _, name := range names {
+		content := files[name]
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	return buf.Bytes()
+}
@@ -0,0 +1,226 @@
+package eventtable
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/proxy"
+	"github.com/stripe/stripe-cli/pkg/websocket"
+)
+
+// Config provides the configuration of the interactive view
+type Config struct {
+	// In is the terminal keys are read from. It is switched to raw mode
+	// while the view runs.
+	In *os.File
+	// Out is where the view is rendered
+	Out io.Writer
+
+	// Elements are the events, responses and errors sent by the proxy
+	Elements <-chan websocket.IElement
+	// Resend re-sends an event to the local endpoint
+	Resend func(evt proxy.StripeEvent)
+	// IsNonFatalError returns true for errors that are shown in the status
+	// line instead of ending the session
+	IsNonFatalError func(err error) bool
+}
+
+// resizeInterval is how often the terminal size is checked
+const resizeInterval = 250 * time.Millisecond
+
+// Run renders the interactive view until the user quits, ctx is canceled or
+// Elements is closed.
+func Run(ctx context.Context, cfg Config) error {
+	fd := int(cfg.In.Fd())
+
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("could not switch the terminal to raw mode: %v", err)
+	}
+	defer term.Restore(fd, state)
+
+	// Switch to the alternate screen and hide the cursor
+	fmt.Fprint(cfg.Out, "\x1b[?1049h\x1b[?25l")
+	defer fmt.Fprint(cfg.Out, "\x1b[?25h\x1b[?1049l")
+
+	keys := make(chan Key)
+	go readKeys(cfg.In, keys)
+
+	ticker := time.NewTicker(resizeInterval)
+	defer ticker.Stop()
+
+	table := &Table{}
+	table.SetMessage("Connecting to Stripe...")
+
+	width, height := terminalSize(fd)
+	render := func() {
+		fmt.Fprint(cfg.Out, "\x1b[H\x1b[2J"+table.Render(width, height))
+	}
+	render()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case el, ok := <-cfg.Elements:
+			if !ok {
+				return nil
+			}
+
+			if err := handleElement(table, el, cfg.IsNonFatalError); err != nil {
+				return err
+			}
+		case key := <-keys:
+			switch table.HandleKey(key) {
+			case ActionQuit:
+				return nil
+			case ActionResend:
+				entry := table.Selected()
+				cfg.Resend(entry.Event)
+				table.SetMessage(fmt.Sprintf("Re-sent %s", entry.Event.ID))
+			case ActionCopy:
+				entry := table.Selected()
+				ansi.CopyToClipboard(cfg.Out, entry.Event.ID)
+				table.SetMessage(fmt.Sprintf("Copied %s", entry.Event.ID))
+			}
+		case <-ticker.C:
+			w, h := terminalSize(fd)
+			if w == width && h == height {
+				continue
+			}
+			width, height = w, h
+		}
+
+		render()
+	}
+}
+
+func handleElement(table *Table, el websocket.IElement, isNonFatalError func(error) bool) error {
+	switch e := el.(type) {
+	case websocket.StateElement:
+		switch e.State {
+		case websocket.Ready:
+			if len(e.Data) > 2 && e.Data[2] != "" {
+				table.SetMessage(fmt.Sprintf("Ready! %sYour webhook signing secret is %s. Public tunnel URL: %s", e.Data[0], e.Data[1], e.Data[2]))
+			} else if len(e.Data) > 1 {
+				table.SetMessage(fmt.Sprintf("Ready! %sYour webhook signing secret is %s", e.Data[0], e.Data[1]))
+			}
+		case websocket.Reconnecting:
+			table.SetMessage("Session expired, reconnecting...")
+		}
+	case websocket.DataElement:
+		switch data := e.Data.(type) {
+		case proxy.StripeEvent:
+			table.Add(data, time.Now())
+		case proxy.EndpointResponse:
+			if data.Event != nil && data.Resp != nil {
+				table.SetResponse(data.Event.ID, data.Resp.StatusCode, data.Latency)
+			}
+		}
+	case websocket.ErrorElement:
+		if isNonFatalError != nil && isNonFatalError(e.Error) {
+			table.SetMessage(fmt.Sprintf("Error: %v", e.Error))
+			return nil
+		}
+		return e.Error
+	}
+
+	return nil
+}
+
+// readKeys decodes the keys read from r and sends them to keys until r is
+// closed.
+func readKeys(r io.Reader, keys chan<- Key) {
+	buf := make([]byte, 64)
+
+	for {
+		n, err := r.Read(buf)
+		if err != nil {
+			return
+		}
+
+		for _, key := range decodeKeys(buf[:n]) {
+			keys <- key
+		}
+	}
+}
+
+// escapeSequences maps the escape sequences sent by terminals to keys.
+var escapeSequences = map[string]Key{
+	"\x1b[A":  KeyUp,
+	"\x1b[B":  KeyDown,
+	"\x1bOA":  KeyUp,
+	"\x1bOB":  KeyDown,
+	"\x1b[5~": KeyPageUp,
+	"\x1b[6~": KeyPageDown,
+	"\x1b[H":  KeyHome,
+	"\x1b[F":  KeyEnd,
+	"\x1b[1~": KeyHome,
+	"\x1b[4~": KeyEnd,
+}
+
+// decodeKeys decodes the bytes read from a terminal in raw mode into keys.
+// Unknown escape sequences are dropped.
+func decodeKeys(b []byte) []Key {
+	var keys []Key
+
+	for len(b) > 0 {
+		switch {
+		case b[0] == 0x1b && len(b) == 1:
+			keys = append(keys, KeyEscape)
+			b = b[1:]
+		case b[0] == 0x1b:
+			matched := false
+			for seq, key := range escapeSequences {
+				if len(b) >= len(seq) && string(b[:len(seq)]) == seq {
+					keys = append(keys, key)
+					b = b[len(seq):]
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				// Skip the unknown sequence up to its final byte
+				i := 2
+				for i < len(b) && (b[i] < 0x40 || b[i] > 0x7e) {
+					i++
+				}
+				b = b[min(i+1, len(b)):]
+			}
+		case b[0] == '\r' || b[0] == '\n':
+			keys = append(keys, KeyEnter)
+			b = b[1:]
+		case b[0] == 0x03:
+			keys = append(keys, KeyInterrupt)
+			b = b[1:]
+		default:
+			keys = append(keys, Key(b[0]))
+			b = b[1:]
+		}
+	}
+
+	return keys
+}
+
+func terminalSize(fd int) (int, int) {
+	width, height, err := term.GetSize(fd)
+	if err != nil {
+		return 80, 24
+	}
+
+	return width, height
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
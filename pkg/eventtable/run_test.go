@@ -0,0 +1,48 @@
+package eventtable
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/proxy"
+	"github.com/stripe/stripe-cli/pkg/websocket"
+)
+
+func TestDecodeKeys(t *testing.T) {
+	require.Equal(t, []Key{KeyUp, KeyDown, KeyPageDown, 'q'}, decodeKeys([]byte("\x1b[A\x1b[B\x1b[6~q")))
+	require.Equal(t, []Key{KeyEnter, KeyInterrupt}, decodeKeys([]byte("\r\x03")))
+	require.Equal(t, []Key{KeyEscape}, decodeKeys([]byte("\x1b")))
+	// Ctrl+Up is ignored
+	require.Equal(t, []Key{'r'}, decodeKeys([]byte("\x1b[1;5Ar")))
+}
+
+func TestHandleElement(t *testing.T) {
+	table := &Table{}
+	isNonFatal := func(err error) bool {
+		_, ok := err.(proxy.FailedToPostError)
+		return ok
+	}
+
+	evt := proxy.StripeEvent{ID: "evt_123", Type: "charge.captured"}
+
+	require.NoError(t, handleElement(table, websocket.StateElement{State: websocket.Ready, Data: []string{"", "whsec_123"}}, isNonFatal))
+	require.Equal(t, "Ready! Your webhook signing secret is whsec_123", table.message)
+
+	require.NoError(t, handleElement(table, websocket.DataElement{Data: evt}, isNonFatal))
+	require.NoError(t, handleElement(table, websocket.DataElement{Data: proxy.EndpointResponse{
+		Event:   &evt,
+		Resp:    &http.Response{StatusCode: 500},
+		Latency: time.Second,
+	}}, isNonFatal))
+	require.Equal(t, 500, table.Selected().Status)
+
+	require.NoError(t, handleElement(table, websocket.ErrorElement{Error: proxy.FailedToPostError{Err: errors.New("connection refused")}}, isNonFatal))
+	require.Equal(t, "Error: connection refused", table.message)
+
+	fatal := errors.New("authentication failed")
+	require.Equal(t, fatal, handleElement(table, websocket.ErrorElement{Error: fatal}, isNonFatal))
+}
@@ -0,0 +1,337 @@
+// Package eventtable implements the interactive view of `stripe listen`: a
+// live, scrollable table of the events received, with their full payloads
+// one keypress away.
+package eventtable
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/tidwall/pretty"
+
+	"github.com/stripe/stripe-cli/pkg/proxy"
+)
+
+// Entry is a row of the table: an event and what the local endpoint made of
+// it.
+type Entry struct {
+	Event    proxy.StripeEvent
+	Received time.Time
+
+	// Status is the HTTP status the endpoint responded with, 0 until it
+	// responds
+	Status  int
+	Latency time.Duration
+}
+
+// Action is what the caller should do in response to a key.
+type Action int
+
+const (
+	// ActionNone requires nothing from the caller
+	ActionNone Action = iota
+	// ActionQuit exits the interactive view
+	ActionQuit
+	// ActionResend re-sends the selected event to the local endpoint
+	ActionResend
+	// ActionCopy copies the ID of the selected event
+	ActionCopy
+)
+
+// Key is a key pressed by the user.
+type Key int
+
+// Keys the table responds to. Printable characters are represented by their
+// rune value.
+const (
+	KeyUp Key = -(iota + 1)
+	KeyDown
+	KeyPageUp
+	KeyPageDown
+	KeyHome
+	KeyEnd
+	KeyEnter
+	KeyEscape
+	KeyInterrupt
+)
+
+// Table is the state of the interactive view. It is not safe for concurrent
+// use.
+type Table struct {
+	entries []*Entry
+
+	selected int
+	offset   int
+
+	// detail is true while the payload of the selected event is shown
+	detail       bool
+	detailOffset int
+
+	message string
+
+	// pageSize is the number of rows shown by the last Render
+	pageSize int
+}
+
+// maxEntries is the number of events kept in the table. Older events are
+// dropped.
+const maxEntries = 1000
+
+// Add appends a received event to the table. The selection follows new
+// events while it is on the latest one.
+func (t *Table) Add(evt proxy.StripeEvent, received time.Time) {
+	following := len(t.entries) == 0 || t.selected == len(t.entries)-1
+
+	t.entries = append(t.entries, &Entry{Event: evt, Received: received})
+
+	if len(t.entries) > maxEntries {
+		t.entries = t.entries[1:]
+		if t.selected > 0 {
+			t.selected--
+		}
+		if t.offset > 0 {
+			t.offset--
+		}
+	}
+
+	if following {
+		t.selected = len(t.entries) - 1
+	}
+}
+
+// SetResponse records the response of the local endpoint to the latest entry
+// for the event with the given ID.
+func (t *Table) SetResponse(eventID string, status int, latency time.Duration) {
+	for i := len(t.entries) - 1; i >= 0; i-- {
+		if t.entries[i].Event.ID == eventID {
+			t.entries[i].Status = status
+			t.entries[i].Latency = latency
+			return
+		}
+	}
+}
+
+// SetMessage sets the status line shown at the bottom of the screen.
+func (t *Table) SetMessage(message string) {
+	t.message = message
+}
+
+// Selected returns the selected entry, or nil if the table is empty.
+func (t *Table) Selected() *Entry {
+	if len(t.entries) == 0 {
+		return nil
+	}
+
+	return t.entries[t.selected]
+}
+
+// HandleKey updates the table for a key press and returns what the caller
+// should do about it.
+func (t *Table) HandleKey(key Key) Action {
+	page := t.pageSize
+	if page < 1 {
+		page = 1
+	}
+
+	switch key {
+	case KeyInterrupt, 'q':
+		return ActionQuit
+	case 'r':
+		if t.Selected() != nil {
+			return ActionResend
+		}
+	case 'c':
+		if t.Selected() != nil {
+			return ActionCopy
+		}
+	}
+
+	if t.detail {
+		switch key {
+		case KeyEscape, KeyEnter, 'h':
+			t.detail = false
+		case KeyUp, 'k':
+			t.detailOffset--
+		case KeyDown, 'j':
+			t.detailOffset++
+		case KeyPageUp:
+			t.detailOffset -= page
+		case KeyPageDown, ' ':
+			t.detailOffset += page
+		case KeyHome, 'g':
+			t.detailOffset = 0
+		}
+
+		if t.detailOffset < 0 {
+			t.detailOffset = 0
+		}
+
+		return ActionNone
+	}
+
+	switch key {
+	case KeyEnter, 'l':
+		if t.Selected() != nil {
+			t.detail = true
+			t.detailOffset = 0
+		}
+	case KeyUp, 'k':
+		t.moveSelection(-1)
+	case KeyDown, 'j':
+		t.moveSelection(1)
+	case KeyPageUp:
+		t.moveSelection(-page)
+	case KeyPageDown, ' ':
+		t.moveSelection(page)
+	case KeyHome, 'g':
+		t.moveSelection(-len(t.entries))
+	case KeyEnd, 'G':
+		t.moveSelection(len(t.entries))
+	}
+
+	return ActionNone
+}
+
+// Render returns the screen for a terminal of the given size, as lines
+// separated by CRLF so it renders correctly in raw mode.
+func (t *Table) Render(width, height int) string {
+	var lines []string
+
+	if t.detail {
+		lines = t.renderDetail(height)
+	} else {
+		lines = t.renderTable(height)
+	}
+
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, t.message)
+
+	for i, line := range lines {
+		lines[i] = truncate(line, width)
+	}
+
+	return strings.Join(lines, "\r\n")
+}
+
+func (t *Table) moveSelection(delta int) {
+	if len(t.entries) == 0 {
+		return
+	}
+
+	t.selected += delta
+	if t.selected < 0 {
+		t.selected = 0
+	}
+	if t.selected >= len(t.entries) {
+		t.selected = len(t.entries) - 1
+	}
+}
+
+func (t *Table) renderTable(height int) []string {
+	lines := []string{
+		"↑/↓ select · enter inspect · r re-send · c copy ID · q quit",
+		fmt.Sprintf("%-8s  %-40s  %-30s  %-6s  %s", "TIME", "TYPE", "ID", "STATUS", "LATENCY"),
+	}
+
+	// Title, header and status line
+	t.pageSize = height - 3
+	if t.pageSize < 1 {
+		t.pageSize = 1
+	}
+
+	if t.selected < t.offset {
+		t.offset = t.selected
+	}
+	if t.selected >= t.offset+t.pageSize {
+		t.offset = t.selected - t.pageSize + 1
+	}
+
+	if len(t.entries) == 0 {
+		return append(lines, "Waiting for events...")
+	}
+
+	for i := t.offset; i < len(t.entries) && i < t.offset+t.pageSize; i++ {
+		entry := t.entries[i]
+
+		status, latency := "-", "-"
+		if entry.Status != 0 {
+			status = fmt.Sprintf("%d", entry.Status)
+			latency = fmt.Sprintf("%dms", entry.Latency.Milliseconds())
+		}
+
+		row := fmt.Sprintf("%-8s  %-40s  %-30s  %-6s  %s",
+			entry.Received.Format("15:04:05"),
+			entry.Event.Type,
+			entry.Event.ID,
+			status,
+			latency,
+		)
+
+		if i == t.selected {
+			row = "\x1b[7m" + row + "\x1b[0m"
+		}
+
+		lines = append(lines, row)
+	}
+
+	return lines
+}
+
+func (t *Table) renderDetail(height int) []string {
+	entry := t.Selected()
+
+	lines := []string{
+		fmt.Sprintf("%s %s · esc back · ↑/↓ scroll · r re-send · c copy ID · q quit", entry.Event.Type, entry.Event.ID),
+	}
+
+	payload := strings.Split(strings.TrimRight(string(pretty.Pretty([]byte(entry.Event.Payload))), "\n"), "\n")
+
+	// Title and status line
+	t.pageSize = height - 2
+	if t.pageSize < 1 {
+		t.pageSize = 1
+	}
+
+	maxOffset := len(payload) - t.pageSize
+	if maxOffset < 0 {
+		maxOffset = 0
+	}
+	if t.detailOffset > maxOffset {
+		t.detailOffset = maxOffset
+	}
+
+	end := t.detailOffset + t.pageSize
+	if end > len(payload) {
+		end = len(payload)
+	}
+
+	return append(lines, payload[t.detailOffset:end]...)
+}
+
+// truncate shortens line to width runes, keeping escape sequences of
+// highlighted rows intact.
+func truncate(line string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(line) <= width {
+		return line
+	}
+
+	highlighted := strings.HasPrefix(line, "\x1b[7m")
+	if highlighted {
+		line = strings.TrimSuffix(strings.TrimPrefix(line, "\x1b[7m"), "\x1b[0m")
+	}
+
+	runes := []rune(line)
+	if len(runes) > width {
+		line = string(runes[:width])
+	}
+
+	if highlighted {
+		line = "\x1b[7m" + line + "\x1b[0m"
+	}
+
+	return line
+}
@@ -0,0 +1,100 @@
+package eventtable
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/proxy"
+)
+
+var received = time.Date(2021, 11, 1, 12, 30, 0, 0, time.UTC)
+
+func newTestTable(n int) *Table {
+	table := &Table{}
+	for i := 0; i < n; i++ {
+		table.Add(proxy.StripeEvent{
+			ID:      "evt_" + string(rune('a'+i)),
+			Type:    "customer.created",
+			Payload: `{"id": "evt_` + string(rune('a'+i)) + `", "object": "event"}`,
+		}, received)
+	}
+
+	return table
+}
+
+func TestAddFollowsLatestEvent(t *testing.T) {
+	table := newTestTable(3)
+	require.Equal(t, "evt_c", table.Selected().Event.ID)
+
+	table.HandleKey(KeyUp)
+	require.Equal(t, "evt_b", table.Selected().Event.ID)
+
+	// The selection stays put when it isn't on the latest event
+	table.Add(proxy.StripeEvent{ID: "evt_d"}, received)
+	require.Equal(t, "evt_b", table.Selected().Event.ID)
+
+	table.HandleKey(KeyEnd)
+	table.Add(proxy.StripeEvent{ID: "evt_e"}, received)
+	require.Equal(t, "evt_e", table.Selected().Event.ID)
+}
+
+func TestSetResponse(t *testing.T) {
+	table := newTestTable(2)
+	table.SetResponse("evt_a", 200, 42*time.Millisecond)
+
+	screen := table.Render(120, 10)
+	require.Contains(t, screen, "evt_a                           200     42ms")
+	require.Contains(t, screen, "evt_b                           -       -")
+}
+
+func TestHandleKey(t *testing.T) {
+	table := newTestTable(3)
+
+	require.Equal(t, ActionResend, table.HandleKey('r'))
+	require.Equal(t, ActionCopy, table.HandleKey('c'))
+	require.Equal(t, ActionQuit, table.HandleKey('q'))
+	require.Equal(t, ActionQuit, table.HandleKey(KeyInterrupt))
+
+	table.HandleKey(KeyHome)
+	require.Equal(t, "evt_a", table.Selected().Event.ID)
+	table.HandleKey(KeyUp)
+	require.Equal(t, "evt_a", table.Selected().Event.ID)
+	table.HandleKey('j')
+	require.Equal(t, "evt_b", table.Selected().Event.ID)
+
+	require.Equal(t, ActionNone, (&Table{}).HandleKey('r'))
+}
+
+func TestRenderDetail(t *testing.T) {
+	table := newTestTable(2)
+
+	table.HandleKey(KeyEnter)
+	screen := table.Render(80, 10)
+	require.Contains(t, screen, "customer.created evt_b")
+	require.Contains(t, screen, `"object": "event"`)
+
+	table.HandleKey(KeyEscape)
+	screen = table.Render(80, 10)
+	require.Contains(t, screen, "TIME")
+}
+
+func TestRenderTruncatesAndScrolls(t *testing.T) {
+	table := newTestTable(10)
+	table.SetMessage("Ready!")
+
+	screen := table.Render(60, 6)
+	lines := strings.Split(screen, "\r\n")
+	require.Len(t, lines, 6)
+	require.Equal(t, "Ready!", lines[5])
+
+	// 3 rows fit between the header and the status line, ending with the
+	// selected, latest event
+	require.Contains(t, lines[4], "evt_j")
+	require.Contains(t, lines[2], "evt_h")
+	for _, line := range lines {
+		require.LessOrEqual(t, len([]rune(strings.TrimSuffix(strings.TrimPrefix(line, "\x1b[7m"), "\x1b[0m"))), 60)
+	}
+}
@@ -0,0 +1,254 @@
+// Package explorer implements an interactive, navigable view of a JSON
+// document in the terminal.
+package explorer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+	"github.com/tidwall/gjson"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+)
+
+// Node is a single field or array element of a JSON document.
+type Node struct {
+	// Key is the object key or array index of the node within its parent
+	Key string
+	// Path is the gjson path of the node from the root of the document
+	Path  string
+	Value gjson.Result
+}
+
+// IsContainer returns whether the node is an object or array that can be
+// expanded.
+func (n Node) IsContainer() bool {
+	return n.Value.IsObject() || n.Value.IsArray()
+}
+
+// Summary is a one-line preview of the node's value.
+func (n Node) Summary() string {
+	switch {
+	case n.Value.IsObject():
+		return fmt.Sprintf("{…} %d keys", len(n.Value.Map()))
+	case n.Value.IsArray():
+		return fmt.Sprintf("[…] %d items", len(n.Value.Array()))
+	default:
+		return n.Value.Raw
+	}
+}
+
+// Children returns the direct children of the value at path in json. An empty
+// path designates the root of the document.
+func Children(json string, path string) []Node {
+	value := gjson.Parse(json)
+	if path != "" {
+		value = gjson.Get(json, path)
+	}
+
+	var nodes []Node
+
+	index := 0
+	value.ForEach(func(key, child gjson.Result) bool {
+		k := key.String()
+		if value.IsArray() {
+			k = fmt.Sprint(index)
+			index++
+		}
+
+		nodes = append(nodes, Node{Key: k, Path: JoinPath(path, k), Value: child})
+
+		return true
+	})
+
+	return nodes
+}
+
+// Search returns all the leaf values of json whose path or value contains
+// query, case-insensitively.
+func Search(json string, query string) []Node {
+	query = strings.ToLower(query)
+
+	var matches []Node
+
+	var walk func(path string)
+	walk = func(path string) {
+		for _, node := range Children(json, path) {
+			if node.IsContainer() {
+				walk(node.Path)
+				continue
+			}
+
+			if strings.Contains(strings.ToLower(node.Path), query) || strings.Contains(strings.ToLower(node.Value.String()), query) {
+				matches = append(matches, node)
+			}
+		}
+	}
+	walk("")
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Path < matches[j].Path })
+
+	return matches
+}
+
+// JoinPath appends key to a gjson path, escaping characters that have a
+// special meaning in gjson paths.
+func JoinPath(path string, key string) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if !isSafePathChar(c) {
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(c)
+	}
+
+	if path == "" {
+		return sb.String()
+	}
+
+	return path + "." + sb.String()
+}
+
+// Run opens an interactive explorer on json. Users navigate the tree with the
+// arrow keys, search the current level with `/` and select a value to copy
+// its path. It returns the path of the selected value, or an empty string if
+// the user exited without selecting one.
+func Run(json string) (string, error) {
+	return run(json, os.Stdin, os.Stdout)
+}
+
+//
+// Private functions
+//
+
+const (
+	itemUp        = ".."
+	itemSearchAll = "Search all fields…"
+	itemExit      = "Exit"
+)
+
+type item struct {
+	Label   string
+	Summary string
+	node    *Node
+}
+
+func run(json string, stdin io.ReadCloser, stdout io.WriteCloser) (string, error) {
+	path := ""
+	searching := false
+	var listed []Node
+
+	for {
+		if listed == nil {
+			listed = Children(json, path)
+		}
+
+		items := menuItems(listed, path != "" || searching, searching)
+
+		title := path
+		if title == "" {
+			title = "(root)"
+		}
+
+		prompt := promptui.Select{
+			Label: title,
+			Items: items,
+			Size:  15,
+			Templates: &promptui.SelectTemplates{
+				Active:   "▸ {{ .Label | bold }} {{ .Summary | faint }}",
+				Inactive: "  {{ .Label }} {{ .Summary | faint }}",
+				Selected: ansi.Faint("{{ .Label }}"),
+			},
+			Searcher: func(input string, index int) bool {
+				return strings.Contains(strings.ToLower(items[index].Label), strings.ToLower(input))
+			},
+			HideSelected: true,
+			Stdin:        stdin,
+			Stdout:       stdout,
+		}
+
+		i, _, err := prompt.Run()
+		if err == promptui.ErrInterrupt || err == promptui.ErrEOF {
+			return "", nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		selected := items[i]
+
+		switch {
+		case selected.node == nil && selected.Label == itemExit:
+			return "", nil
+		case selected.node == nil && selected.Label == itemUp:
+			if !searching {
+				path = parentPath(path)
+			}
+			searching = false
+			listed = nil
+		case selected.node == nil && selected.Label == itemSearchAll:
+			query, err := (&promptui.Prompt{Label: "Search", Stdin: stdin, Stdout: stdout}).Run()
+			if err != nil {
+				continue
+			}
+			listed = Search(json, query)
+			searching = true
+		case selected.node.IsContainer():
+			path = selected.node.Path
+			searching = false
+			listed = nil
+		default:
+			ansi.CopyToClipboard(stdout, selected.node.Path)
+			fmt.Fprintf(stdout, "%s = %s\n", ansi.Bold(selected.node.Path), selected.node.Value.Raw)
+
+			return selected.node.Path, nil
+		}
+	}
+}
+
+// menuItems builds the entries of the select prompt for nodes. Search results
+// come from anywhere in the tree, so they're labeled with their full path.
+func menuItems(nodes []Node, canGoUp bool, fullPaths bool) []item {
+	var items []item
+
+	if canGoUp {
+		items = append(items, item{Label: itemUp})
+	}
+
+	for i := range nodes {
+		label := nodes[i].Key
+		if fullPaths {
+			label = nodes[i].Path
+		}
+
+		items = append(items, item{Label: label, Summary: nodes[i].Summary(), node: &nodes[i]})
+	}
+
+	items = append(items, item{Label: itemSearchAll}, item{Label: itemExit})
+
+	return items
+}
+
+// parentPath returns the path of the parent of the node at path, taking
+// escaped separators into account.
+func parentPath(path string) string {
+	for i := len(path) - 1; i >= 0; i-- {
+		if path[i] == '.' && (i == 0 || path[i-1] != '\\') {
+			return path[:i]
+		}
+	}
+
+	return ""
+}
+
+func isSafePathChar(c byte) bool {
+	return c == '_' || c == '-' || c == ':' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') ||
+		(c >= '0' && c <= '9')
+}
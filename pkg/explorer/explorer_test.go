@@ -0,0 +1,56 @@
+package explorer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+const testEvent = `{
+  "id": "evt_123",
+  "data": {
+    "object": {
+      "id": "cus_123",
+      "metadata": {"order.id": "42"},
+      "sources": [{"id": "card_1"}, {"id": "card_2"}]
+    }
+  }
+}`
+
+func TestChildren(t *testing.T) {
+	nodes := Children(testEvent, "")
+	require.Len(t, nodes, 2)
+	require.Equal(t, "id", nodes[0].Key)
+	require.Equal(t, "data", nodes[1].Key)
+	require.True(t, nodes[1].IsContainer())
+
+	nodes = Children(testEvent, "data.object.sources")
+	require.Len(t, nodes, 2)
+	require.Equal(t, "1", nodes[1].Key)
+	require.Equal(t, "data.object.sources.1", nodes[1].Path)
+	require.Equal(t, "[…] 2 items", Node{Value: gjson.Get(testEvent, "data.object.sources")}.Summary())
+}
+
+func TestChildrenEscapesKeys(t *testing.T) {
+	nodes := Children(testEvent, "data.object.metadata")
+	require.Len(t, nodes, 1)
+	require.Equal(t, `data.object.metadata.order\.id`, nodes[0].Path)
+	require.Equal(t, "42", gjson.Get(testEvent, nodes[0].Path).String())
+}
+
+func TestSearch(t *testing.T) {
+	matches := Search(testEvent, "CARD")
+	require.Len(t, matches, 2)
+	require.Equal(t, "data.object.sources.0.id", matches[0].Path)
+	require.Equal(t, "data.object.sources.1.id", matches[1].Path)
+
+	matches = Search(testEvent, "metadata")
+	require.Len(t, matches, 1)
+}
+
+func TestParentPath(t *testing.T) {
+	require.Equal(t, "data.object", parentPath("data.object.id"))
+	require.Equal(t, "data.object.metadata", parentPath(`data.object.metadata.order\.id`))
+	require.Equal(t, "", parentPath("data"))
+}
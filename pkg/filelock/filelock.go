@@ -0,0 +1,16 @@
+// Package filelock locks files shared by several processes of the CLI, like
+// caches, so that they aren't read while another process updates them.
+package filelock
+
+import "os"
+
+// Lock locks f, exclusively to update what it guards or shared to read it. It
+// waits for the processes holding a conflicting lock.
+func Lock(f *os.File, exclusive bool) error {
+	return lockFile(f, exclusive)
+}
+
+// Unlock releases the lock on f
+func Unlock(f *os.File) error {
+	return unlockFile(f)
+}
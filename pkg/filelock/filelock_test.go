@@ -0,0 +1,43 @@
+package filelock
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.lock")
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+	require.NoError(t, Lock(f, true))
+
+	locked := make(chan struct{})
+	go func() {
+		other, err := os.Open(path)
+		require.NoError(t, err)
+		defer other.Close()
+		require.NoError(t, Lock(other, false))
+		close(locked)
+		Unlock(other)
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("the file was locked twice")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	require.NoError(t, Unlock(f))
+
+	select {
+	case <-locked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the file wasn't unlocked")
+	}
+}
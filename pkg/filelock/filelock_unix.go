@@ -0,0 +1,28 @@
+//go:build !windows
+// +build !windows
+
+package filelock
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile locks f with flock, waiting for the processes holding it
+func lockFile(f *os.File, exclusive bool) error {
+	how := syscall.LOCK_SH
+	if exclusive {
+		how = syscall.LOCK_EX
+	}
+
+	for {
+		err := syscall.Flock(int(f.Fd()), how)
+		if err != syscall.EINTR {
+			return err
+		}
+	}
+}
+
+func unlockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,167 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// TriggerParameter is a parameter of a trigger that can be changed with
+// `--override <name>=<value>`
+type TriggerParameter struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Default is the value used unless the parameter is overridden
+	Default string `json:"-"`
+}
+
+// TriggerInfo describes what triggering an event does
+type TriggerInfo struct {
+	Event       string
+	Description string
+	// Creates lists the types of the API objects created, in order
+	Creates    []string
+	Parameters []TriggerParameter
+	// Requires lists what the account must have enabled
+	Requires []string
+}
+
+// Catalog describes every supported trigger event, including those of the
+// registered fixture packs, sorted by name.
+func Catalog() ([]TriggerInfo, error) {
+	catalog := make([]TriggerInfo, 0, len(Events))
+
+	for _, event := range EventNames() {
+		info, err := DescribeTrigger(event)
+		if err != nil {
+			return nil, err
+		}
+
+		catalog = append(catalog, info)
+	}
+
+	return catalog, nil
+}
+
+// DescribeTrigger describes a supported trigger event from the metadata and
+// the requests of its fixture.
+func DescribeTrigger(event string) (TriggerInfo, error) {
+	data, err := readTriggerFile(event)
+	if err != nil {
+		return TriggerInfo{}, err
+	}
+
+	var fxtFile fixtureFile
+	if err := json.Unmarshal(data, &fxtFile); err != nil {
+		return TriggerInfo{}, err
+	}
+
+	info := TriggerInfo{
+		Event:       event,
+		Description: fxtFile.Meta.Description,
+		Requires:    fxtFile.Meta.Requires,
+	}
+
+	seen := make(map[string]bool)
+	for _, fxt := range fxtFile.Fixtures {
+		if object, ok := createdObject(fxt.Method, fxt.Path); ok && !seen[object] {
+			seen[object] = true
+			info.Creates = append(info.Creates, object)
+		}
+	}
+
+	for _, param := range fxtFile.Meta.Parameters {
+		param.Default = parameterDefault(fxtFile.Fixtures, param.Name)
+		info.Parameters = append(info.Parameters, param)
+	}
+
+	return info, nil
+}
+
+// readTriggerFile returns the fixture of a pre-built event or of an event of a
+// registered fixture pack.
+func readTriggerFile(event string) ([]byte, error) {
+	if file, ok := packEvents[event]; ok {
+		return ioutil.ReadFile(file)
+	}
+
+	file, ok := Events[event]
+	if !ok {
+		return nil, fmt.Errorf("The event ‘%s’ is not supported by the Stripe CLI.", event)
+	}
+
+	f, err := triggers.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ioutil.ReadAll(f)
+}
+
+// createdObject returns the type of the object created by a request, if any.
+// Requests creating objects POST to a collection: `/v1/checkout/sessions`
+// creates a checkout.session, and `/v1/customers/${customer:id}/sources` a
+// source.
+func createdObject(method, path string) (string, bool) {
+	if strings.ToLower(method) != "post" {
+		return "", false
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) < 2 {
+		return "", false
+	}
+
+	collection := segments[len(segments)-1]
+	if strings.HasPrefix(collection, "${") || !strings.HasSuffix(collection, "s") {
+		return "", false
+	}
+
+	object := strings.TrimSuffix(collection, "s")
+	if strings.Contains(path, "${") {
+		return object, true
+	}
+
+	// Skip the API version
+	namespace := segments[1 : len(segments)-1]
+
+	return strings.Join(append(namespace, object), "."), true
+}
+
+// parameterDefault returns the value of the parameter in the fixture, where
+// name is written like in `--override`: `<request name>:<key>.<key>`.
+func parameterDefault(fixtures []fixture, name string) string {
+	nameSplit := strings.SplitN(name, ":", 2)
+	if len(nameSplit) != 2 {
+		return ""
+	}
+
+	for _, fxt := range fixtures {
+		if fxt.Name != nameSplit[0] {
+			continue
+		}
+
+		var value interface{} = fxt.Params
+		for _, key := range strings.Split(nameSplit[1], ".") {
+			params, ok := value.(map[string]interface{})
+			if !ok {
+				return ""
+			}
+			value = params[key]
+		}
+
+		switch v := value.(type) {
+		case nil:
+			return ""
+		case string:
+			return v
+		default:
+			encoded, _ := json.Marshal(v)
+			return string(encoded)
+		}
+	}
+
+	return ""
+}
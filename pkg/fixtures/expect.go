@@ -0,0 +1,119 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ExpectationFailure is an expectation of a fixture that the response didn't
+// meet
+type ExpectationFailure struct {
+	Path     string
+	Expected string
+	Actual   string
+}
+
+// ExpectationError is returned by Execute when the response of a fixture
+// doesn't meet its expectations
+type ExpectationError struct {
+	Fixture  string
+	Failures []ExpectationFailure
+}
+
+func (e ExpectationError) Error() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%d expectation(s) failed for %s:", len(e.Failures), e.Fixture)
+	for _, failure := range e.Failures {
+		fmt.Fprintf(&b, "\n  %s\n    - expected: %s\n    + actual:   %s", failure.Path, failure.Expected, failure.Actual)
+	}
+
+	return b.String()
+}
+
+// checkExpectations compares the response of data with its expectations.
+// Expected strings can reference other fixtures, like `${customer:id}`.
+func (fxt *Fixture) checkExpectations(data fixture) error {
+	if len(data.Expect) == 0 {
+		return nil
+	}
+
+	paths := make([]string, 0, len(data.Expect))
+	for path := range data.Expect {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	response := fxt.responses[data.Name]
+
+	var failures []ExpectationFailure
+	for _, path := range paths {
+		expected := data.Expect[path]
+		if s, ok := expected.(string); ok {
+			value, err := fxt.parseQuery(s)
+			if err != nil {
+				return err
+			}
+			expected = value
+		}
+
+		result := response.Get(path)
+
+		var actual interface{}
+		if result.Exists() {
+			if err := json.Unmarshal([]byte(result.Raw), &actual); err != nil {
+				return err
+			}
+		}
+
+		if !equalJSON(expected, actual) {
+			failures = append(failures, ExpectationFailure{
+				Path:     path,
+				Expected: formatJSON(expected),
+				Actual:   formatActual(actual, result.Exists()),
+			})
+		}
+	}
+
+	if len(failures) > 0 {
+		return ExpectationError{Fixture: data.Name, Failures: failures}
+	}
+
+	return nil
+}
+
+// equalJSON compares values decoded from JSON. Expected values are
+// round-tripped through JSON so numbers compare as float64 on both sides.
+func equalJSON(expected, actual interface{}) bool {
+	raw, err := json.Marshal(expected)
+	if err != nil {
+		return false
+	}
+
+	var normalized interface{}
+	if err := json.Unmarshal(raw, &normalized); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(normalized, actual)
+}
+
+func formatJSON(value interface{}) string {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(raw)
+}
+
+func formatActual(value interface{}, exists bool) string {
+	if !exists {
+		return "(missing)"
+	}
+
+	return formatJSON(value)
+}
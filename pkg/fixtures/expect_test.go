@@ -0,0 +1,104 @@
+package fixtures
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+const expectTestFixture = `
+{
+	"_meta": {
+		"template_version": 0
+	},
+	"fixtures": [
+		{
+			"name": "customer",
+			"path": "/v1/customers",
+			"method": "post",
+			"expect": {
+				"object": "customer"
+			}
+		},
+		{
+			"name": "payment_intent",
+			"path": "/v1/payment_intents",
+			"method": "post",
+			"params": {
+				"customer": "${customer:id}"
+			},
+			"expect": {
+				"status": "succeeded",
+				"amount": 2000,
+				"customer": "${customer:id}",
+				"charges.data.0.paid": true,
+				"metadata": {"order": "42"},
+				"canceled_at": null
+			}
+		}
+	]
+}`
+
+func executeExpectFixture(t *testing.T, paymentIntent string) error {
+	fs := afero.NewMemMapFs()
+	ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/v1/customers":
+			res.Write([]byte(`{"id": "cus_123", "object": "customer"}`))
+		case "/v1/payment_intents":
+			res.Write([]byte(paymentIntent))
+		}
+	}))
+	defer ts.Close()
+
+	afero.WriteFile(fs, "expect_test_fixture.json", []byte(expectTestFixture), os.ModePerm)
+	fxt, err := NewFixtureFromFile(fs, apiKey, "", ts.URL, "expect_test_fixture.json", []string{}, []string{}, []string{}, []string{})
+	require.NoError(t, err)
+
+	_, err = fxt.Execute(context.Background())
+	return err
+}
+
+func TestExpectationsMet(t *testing.T) {
+	err := executeExpectFixture(t, `{
+		"id": "pi_123",
+		"status": "succeeded",
+		"amount": 2000,
+		"customer": "cus_123",
+		"charges": {"data": [{"paid": true}]},
+		"metadata": {"order": "42"},
+		"canceled_at": null
+	}`)
+	require.NoError(t, err)
+}
+
+func TestExpectationsFailed(t *testing.T) {
+	err := executeExpectFixture(t, `{
+		"id": "pi_123",
+		"status": "requires_payment_method",
+		"amount": 2000,
+		"customer": "cus_456",
+		"charges": {"data": []},
+		"metadata": {"order": "42"}
+	}`)
+
+	var expectErr ExpectationError
+	require.True(t, errors.As(err, &expectErr))
+	require.Equal(t, "payment_intent", expectErr.Fixture)
+	require.Equal(t, `3 expectation(s) failed for payment_intent:
+  charges.data.0.paid
+    - expected: true
+    + actual:   (missing)
+  customer
+    - expected: "cus_123"
+    + actual:   "cus_456"
+  status
+    - expected: "succeeded"
+    + actual:   "requires_payment_method"`, err.Error())
+}
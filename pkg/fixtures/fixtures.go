@@ -6,10 +6,12 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/imdario/mergo"
@@ -18,20 +20,39 @@ import (
 	"github.com/tidwall/gjson"
 
 	"github.com/stripe/stripe-cli/pkg/requests"
+	"github.com/stripe/stripe-cli/pkg/stripe"
 )
 
 // SupportedVersions is the version number of the fixture template the CLI supports
 const SupportedVersions = 0
 
 type metaFixture struct {
-	Version         int  `json:"template_version"`
-	ExcludeMetadata bool `json:"exclude_metadata"`
+	Version         int                `json:"template_version"`
+	ExcludeMetadata bool               `json:"exclude_metadata"`
+	Description     string             `json:"description,omitempty"`
+	Parameters      []TriggerParameter `json:"parameters,omitempty"`
+	// Requires lists the products the account must have enabled, like
+	// `connect` or `issuing`, or its capabilities, like
+	// `capability:card_payments`
+	Requires []string `json:"requires,omitempty"`
+	// TestClock runs the fixture on a test clock, created before its steps,
+	// which customers are attached to and steps can advance
+	TestClock bool `json:"test_clock,omitempty"`
+	// APIVersion pins the API version the requests of the fixture are sent
+	// with, like the version it was written against
+	APIVersion string `json:"api_version,omitempty"`
+	// PreviewFeatures opts the requests of the fixture into preview features,
+	// like feature_beta=v1
+	PreviewFeatures []string `json:"preview_features,omitempty"`
 }
 
 type fixtureFile struct {
 	Meta     metaFixture       `json:"_meta"`
 	Fixtures []fixture         `json:"fixtures"`
 	Env      map[string]string `json:"env"`
+	// Teardown are the requests deleting the objects created by Fixtures,
+	// run by `stripe trigger --cleanup` and `stripe fixtures teardown`
+	Teardown []fixture `json:"teardown,omitempty"`
 }
 
 type fixture struct {
@@ -40,6 +61,16 @@ type fixture struct {
 	Path              string                 `json:"path"`
 	Method            string                 `json:"method"`
 	Params            map[string]interface{} `json:"params"`
+	// Expect maps paths of the response, like `status` or
+	// `charges.data.0.amount`, to the values they must have
+	Expect map[string]interface{} `json:"expect,omitempty"`
+	// AdvanceClock advances the test clock of the fixture, like `+35d`,
+	// before the request of the step. Steps without a path only advance it.
+	AdvanceClock string `json:"advance_clock,omitempty"`
+	// Account is the connected account the request of the step is sent to
+	// with the Stripe-Account header, like `${connected_account:id}`,
+	// instead of the account the fixture runs on
+	Account string `json:"account,omitempty"`
 }
 
 type fixtureQuery struct {
@@ -59,8 +90,25 @@ type Fixture struct {
 	Additions     map[string]interface{}
 	Removals      map[string]interface{}
 	BaseURL       string
-	responses     map[string]gjson.Result
-	fixture       fixtureFile
+	// Concurrency is the number of steps Execute runs at the same time.
+	// Steps referencing others wait for them, see dependencies.
+	Concurrency int
+	// OnStep is called with the outcome of each step as Execute runs it,
+	// one step at a time even when they run concurrently
+	OnStep     func(StepProgress)
+	responses  map[string]gjson.Result
+	fixture    fixtureFile
+	mu         sync.Mutex
+	progressMu sync.Mutex
+}
+
+// StepProgress is the outcome of a step of a fixture, reported to OnStep
+type StepProgress struct {
+	Name    string
+	Skipped bool
+	// Resource is the object the step created, if any
+	Resource *Resource
+	Err      error
 }
 
 // NewFixtureFromFile creates a to later run steps for populating test data
@@ -250,34 +298,270 @@ func (fxt *Fixture) Remove(removals []string) error {
 	return nil
 }
 
+// RequestCount returns the number of requests Execute sends, i.e. the number
+// of fixtures that aren't skipped
+func (fxt *Fixture) RequestCount() int {
+	count := 0
+	if fxt.usesTestClock() {
+		count++
+	}
+	for _, data := range fxt.fixture.Fixtures {
+		if !isNameIn(data.Name, fxt.Skip) && data.Path != "" {
+			count++
+		}
+	}
+
+	return count
+}
+
 // Execute takes the parsed fixture file and runs through all the requests
 // defined to populate the user's account
 func (fxt *Fixture) Execute(ctx context.Context) ([]string, error) {
+	if err := fxt.Preflight(ctx); err != nil {
+		return nil, err
+	}
+
+	if fxt.usesTestClock() {
+		if err := fxt.createTestClock(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	requestNames := make([]string, len(fxt.fixture.Fixtures))
+
+	if fxt.Concurrency > 1 {
+		if err := fxt.executeConcurrently(ctx, requestNames); err != nil {
+			return nil, err
+		}
+
+		return requestNames, nil
+	}
+
 	for i, data := range fxt.fixture.Fixtures {
 		if isNameIn(data.Name, fxt.Skip) {
 			fmt.Printf("Skipping fixture for: %s\n", data.Name)
+			fxt.reportStep(data, true, nil)
 			continue
 		}
 
-		fmt.Printf("Setting up fixture for: %s\n", data.Name)
-		requestNames[i] = data.Name
-
-		fmt.Printf("Running fixture for: %s\n", data.Name)
-		resp, err := fxt.makeRequest(ctx, data)
-		if err != nil && !errWasExpected(err, data.ExpectedErrorType) {
+		if err := fxt.runStep(ctx, data); err != nil {
 			return nil, err
 		}
 
-		fxt.responses[data.Name] = gjson.ParseBytes(resp)
+		if data.Path != "" {
+			requestNames[i] = data.Name
+		}
 	}
 
 	return requestNames, nil
 }
 
+// runStep advances the test clock if the step asks for it, sends the
+// request of the step and checks the expectations on its response.
+func (fxt *Fixture) runStep(ctx context.Context, data fixture) (err error) {
+	ctx, span := stripe.StartSpan(ctx, "fixture step "+data.Name, stripe.SpanKindInternal)
+	span.SetAttribute("fixture.step", data.Name)
+	if data.Path != "" {
+		span.SetAttribute("fixture.request", strings.ToUpper(data.Method)+" "+data.Path)
+	}
+
+	defer func() {
+		span.SetError(err)
+		span.End()
+		fxt.reportStep(data, false, err)
+	}()
+
+	if data.AdvanceClock != "" {
+		if err := fxt.advanceTestClock(ctx, data); err != nil {
+			return err
+		}
+	}
+
+	if data.Path == "" {
+		return nil
+	}
+
+	fmt.Printf("Setting up fixture for: %s\n", data.Name)
+
+	fmt.Printf("Running fixture for: %s\n", data.Name)
+	resp, err := fxt.makeRequest(ctx, data)
+	if err != nil && !errWasExpected(err, data.ExpectedErrorType) {
+		return err
+	}
+
+	// Steps running concurrently read the responses of the steps they
+	// reference while others are stored
+	fxt.mu.Lock()
+	defer fxt.mu.Unlock()
+
+	fxt.responses[data.Name] = gjson.ParseBytes(resp)
+
+	return fxt.checkExpectations(data)
+}
+
+// reportStep reports the outcome of a step to OnStep, with the object it
+// created
+func (fxt *Fixture) reportStep(data fixture, skipped bool, err error) {
+	if fxt.OnStep == nil {
+		return
+	}
+
+	progress := StepProgress{Name: data.Name, Skipped: skipped, Err: err}
+	if !skipped && err == nil && data.Method == "post" {
+		fxt.mu.Lock()
+		response := fxt.responses[data.Name]
+		fxt.mu.Unlock()
+
+		if id := response.Get("id").String(); id != "" {
+			progress.Resource = &Resource{
+				Name: data.Name,
+				Type: response.Get("object").String(),
+				ID:   id,
+			}
+		}
+	}
+
+	fxt.progressMu.Lock()
+	defer fxt.progressMu.Unlock()
+
+	fxt.OnStep(progress)
+}
+
+// Resource is an object created by a fixture run
+type Resource struct {
+	// Name is the name of the step that created the object
+	Name string `json:"name"`
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// Resources returns the objects created by the POST requests Execute sent,
+// including the test clock of the fixture, in the order of the steps. Steps
+// that didn't run or whose response has no ID are left out.
+func (fxt *Fixture) Resources() []Resource {
+	fxt.mu.Lock()
+	defer fxt.mu.Unlock()
+
+	resources := []Resource{}
+
+	add := func(name string) {
+		response, ok := fxt.responses[name]
+		if !ok || response.Get("id").String() == "" {
+			return
+		}
+
+		resources = append(resources, Resource{
+			Name: name,
+			Type: response.Get("object").String(),
+			ID:   response.Get("id").String(),
+		})
+	}
+
+	if fxt.usesTestClock() {
+		add(testClockName)
+	}
+
+	for _, data := range fxt.fixture.Fixtures {
+		if data.Method == "post" && !isNameIn(data.Name, fxt.Skip) {
+			add(data.Name)
+		}
+	}
+
+	return resources
+}
+
+// PlannedRequest is a request Execute would send, as planned by Plan
+type PlannedRequest struct {
+	Name   string
+	Method string
+	Path   string
+	Params []string
+	// Account is the connected account the request is sent to, if any
+	Account string
+}
+
+// Plan returns the requests Execute would send, without sending them. Since
+// there are no responses, references to other fixtures, like
+// `${customer:id}`, are left as is.
+func (fxt *Fixture) Plan() ([]PlannedRequest, error) {
+	var planned []PlannedRequest
+
+	if fxt.usesTestClock() {
+		planned = append(planned, PlannedRequest{
+			Name:   testClockName,
+			Method: http.MethodPost,
+			Path:   "/v1/test_helpers/test_clocks",
+			Params: []string{"frozen_time=<time of the request>", "name=Stripe CLI fixture"},
+		})
+		fxt.responses[testClockName] = gjson.Parse("{}")
+	}
+
+	for _, data := range fxt.fixture.Fixtures {
+		if isNameIn(data.Name, fxt.Skip) {
+			continue
+		}
+
+		if data.AdvanceClock != "" {
+			if _, err := parseClockAdvance(data.AdvanceClock); err != nil {
+				return nil, err
+			}
+
+			planned = append(planned, PlannedRequest{
+				Name:   data.Name,
+				Method: http.MethodPost,
+				Path:   "/v1/test_helpers/test_clocks/${test_clock:id}/advance",
+				Params: []string{fmt.Sprintf("frozen_time=<frozen time %s>", data.AdvanceClock)},
+			})
+		}
+
+		if data.Path == "" {
+			continue
+		}
+
+		path, err := fxt.parsePath(data)
+		if err != nil {
+			return nil, err
+		}
+
+		params, err := fxt.parseInterface(data.Params)
+		if err != nil {
+			return nil, err
+		}
+
+		if fxt.attachesTestClock(data, path) {
+			params = append(params, "test_clock=${test_clock:id}")
+		}
+
+		if data.Method == "post" && !fxt.fixture.Meta.ExcludeMetadata {
+			params = append([]string{"metadata[_created_by_fixture]=<time of the request>"}, params...)
+		}
+
+		account, err := fxt.stepAccount(data)
+		if err != nil {
+			return nil, err
+		}
+
+		planned = append(planned, PlannedRequest{
+			Name:    data.Name,
+			Method:  strings.ToUpper(data.Method),
+			Path:    path,
+			Params:  params,
+			Account: account,
+		})
+
+		// Later fixtures can reference this one, its fields are then left
+		// unresolved
+		fxt.responses[data.Name] = gjson.Parse("{}")
+	}
+
+	return planned, nil
+}
+
 func errWasExpected(err error, expectedErrorType string) bool {
 	if rerr, ok := err.(requests.RequestError); ok {
-		return rerr.ErrorType == expectedErrorType
+		// Responses without an error type, e.g. from a proxy, are never
+		// expected
+		return expectedErrorType != "" && rerr.ErrorType == expectedErrorType
 	}
 	return false
 }
@@ -292,6 +576,23 @@ func (fxt *Fixture) UpdateEnv() error {
 	return nil
 }
 
+// EnvValues returns the env of the fixture, with its queries resolved
+// against the responses of the fixture just executed
+func (fxt *Fixture) EnvValues() (map[string]string, error) {
+	values := make(map[string]string, len(fxt.fixture.Env))
+
+	for key, value := range fxt.fixture.Env {
+		parsed, err := fxt.parseQuery(value)
+		if err != nil {
+			return nil, err
+		}
+
+		values[key] = parsed
+	}
+
+	return values, nil
+}
+
 func (fxt *Fixture) makeRequest(ctx context.Context, data fixture) ([]byte, error) {
 	var rp requests.RequestParameters
 
@@ -308,19 +609,61 @@ func (fxt *Fixture) makeRequest(ctx context.Context, data fixture) ([]byte, erro
 		Parameters:     rp,
 	}
 
-	path, err := fxt.parsePath(data)
+	fxt.mu.Lock()
+	path, params, err := fxt.prepareRequest(data)
+	fxt.mu.Unlock()
 
 	if err != nil {
 		return make([]byte, 0), err
 	}
 
-	params, err := fxt.createParams(data.Params)
+	return req.MakeRequest(ctx, fxt.APIKey, path, params, true)
+}
 
+// prepareRequest resolves the references in the path and params of data
+func (fxt *Fixture) prepareRequest(data fixture) (string, *requests.RequestParameters, error) {
+	path, err := fxt.parsePath(data)
 	if err != nil {
-		return make([]byte, 0), err
+		return "", nil, err
 	}
 
-	return req.MakeRequest(ctx, fxt.APIKey, path, params, true)
+	params, err := fxt.createParams(data.Params)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if fxt.fixture.Meta.APIVersion != "" {
+		params.SetVersion(fxt.fixture.Meta.APIVersion)
+	}
+	params.AppendPreviewFeatures(fxt.fixture.Meta.PreviewFeatures)
+
+	if fxt.attachesTestClock(data, path) {
+		params.AppendData([]string{"test_clock=" + fxt.responses[testClockName].Get("id").String()})
+	}
+
+	if data.Account != "" {
+		account, err := fxt.parseQuery(data.Account)
+		if err != nil {
+			return "", nil, err
+		}
+		if strings.Contains(account, "${") {
+			return "", nil, fmt.Errorf("the account of %s, %s, isn't set", data.Name, data.Account)
+		}
+
+		params.SetStripeAccount(account)
+	}
+
+	return path, params, nil
+}
+
+// stepAccount returns the account the request of data is sent to, with
+// references to other fixtures left as is.
+func (fxt *Fixture) stepAccount(data fixture) (string, error) {
+	if data.Account == "" {
+		return fxt.StripeAccount, nil
+	}
+
+	return fxt.parseQuery(data.Account)
 }
 
 func (fxt *Fixture) createParams(params interface{}) (*requests.RequestParameters, error) {
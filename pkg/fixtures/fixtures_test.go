@@ -16,6 +16,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/requests"
 )
 
 const testFixture = `
@@ -552,3 +554,258 @@ func priceFixture() *Fixture {
 		},
 	}
 }
+
+func TestCatalog(t *testing.T) {
+	catalog, err := Catalog()
+	require.NoError(t, err)
+	require.Len(t, catalog, len(Events))
+
+	for _, info := range catalog {
+		require.NotEmpty(t, info.Description, info.Event)
+		require.NotEmpty(t, info.Creates, info.Event)
+
+		for _, param := range info.Parameters {
+			// Parameters must exist in the fixture to be overridden
+			require.NotEmpty(t, param.Default, "%s: %s", info.Event, param.Name)
+		}
+	}
+}
+
+func TestDescribeTrigger(t *testing.T) {
+	info, err := DescribeTrigger("invoice.payment_failed")
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"customer", "invoiceitem", "invoice"}, info.Creates)
+	require.Contains(t, info.Parameters, TriggerParameter{
+		Name:        "customer:source",
+		Description: "Token of the test card the payment is attempted with",
+		Default:     "tok_chargeCustomerFail",
+	})
+
+	_, err = DescribeTrigger("invoice.unknown")
+	require.Error(t, err)
+}
+
+func TestCreatedObject(t *testing.T) {
+	for path, expected := range map[string]string{
+		"/v1/customers":                                    "customer",
+		"/v1/checkout/sessions":                            "checkout.session",
+		"/v1/customers/${customer:id}/sources":             "source",
+		"/v1/invoices/${invoice:id}/pay":                   "",
+		"/v1/customers/${customer:id}":                     "",
+		"/v1/payment_methods/pm_card_visa/attach":          "",
+		"/v1/subscription_schedules/${schedule:id}/cancel": "",
+	} {
+		object, ok := createdObject("post", path)
+		require.Equal(t, expected, object, path)
+		require.Equal(t, expected != "", ok, path)
+	}
+
+	_, ok := createdObject("get", "/v1/customers")
+	require.False(t, ok)
+}
+
+func TestPlan(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "test_fixture.json", []byte(testFixture), os.ModePerm)
+
+	fxt, err := NewFixtureFromFile(fs, apiKey, "", "", "test_fixture.json", []string{"capt_bender"}, []string{"char_bender:amount=200"}, []string{}, []string{})
+	require.NoError(t, err)
+
+	planned, err := fxt.Plan()
+	require.NoError(t, err)
+	require.Len(t, planned, 2)
+
+	require.Equal(t, "cust_bender", planned[0].Name)
+	require.Equal(t, "POST", planned[0].Method)
+	require.Equal(t, "/v1/customers", planned[0].Path)
+	require.Equal(t, "metadata[_created_by_fixture]=<time of the request>", planned[0].Params[0])
+
+	require.Equal(t, "char_bender", planned[1].Name)
+	require.Equal(t, "/v1/charges", planned[1].Path)
+	require.ElementsMatch(t, []string{
+		"metadata[_created_by_fixture]=<time of the request>",
+		"customer=${cust_bender:id}",
+		"source=tok_visa",
+		"amount=200",
+		"currency=usd",
+		"capture=false",
+	}, planned[1].Params)
+}
+
+const connectFixture = `
+{
+	"_meta": {
+		"template_version": 0
+	},
+	"fixtures": [
+		{
+			"name": "connected_account",
+			"path": "/v1/accounts",
+			"method": "post"
+		},
+		{
+			"name": "direct_charge",
+			"path": "/v1/payment_intents",
+			"method": "post",
+			"account": "${connected_account:id}",
+			"params": {
+				"amount": 2000
+			}
+		}
+	],
+	"teardown": [
+		{
+			"name": "direct_charge_canceled",
+			"path": "/v1/payment_intents/${direct_charge:id}/cancel",
+			"method": "post",
+			"account": "${connected_account:id}"
+		},
+		{
+			"name": "connected_account_deleted",
+			"path": "/v1/accounts/${connected_account:id}",
+			"method": "delete"
+		}
+	]
+}`
+
+func TestExecuteWithStepAccount(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/v1/accounts":
+			require.Equal(t, "acct_platform", req.Header.Get("Stripe-Account"))
+			res.Write([]byte(`{"id": "acct_connected"}`))
+		case "/v1/payment_intents":
+			require.Equal(t, "acct_connected", req.Header.Get("Stripe-Account"))
+			res.Write([]byte(`{"id": "pi_123"}`))
+		default:
+			t.Errorf("Received an unexpected request URL: %s", req.URL.String())
+		}
+	}))
+	defer ts.Close()
+
+	afero.WriteFile(fs, "connect.json", []byte(connectFixture), os.ModePerm)
+
+	fxt, err := NewFixtureFromFile(fs, apiKey, "acct_platform", ts.URL, "connect.json", []string{}, []string{}, []string{}, []string{})
+	require.NoError(t, err)
+
+	_, err = fxt.Execute(context.Background())
+	require.NoError(t, err)
+
+	run := fxt.PrepareTeardown("connect.json")
+	require.Equal(t, "acct_platform", run.StripeAccount)
+	require.Equal(t, []TeardownRequest{
+		{Name: "direct_charge_canceled", Method: "POST", Path: "/v1/payment_intents/pi_123/cancel", StripeAccount: "acct_connected"},
+		{Name: "connected_account_deleted", Method: "DELETE", Path: "/v1/accounts/acct_connected"},
+	}, run.Requests)
+}
+
+func TestPlanWithStepAccount(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "connect.json", []byte(connectFixture), os.ModePerm)
+
+	fxt, err := NewFixtureFromFile(fs, apiKey, "", "", "connect.json", []string{}, []string{}, []string{}, []string{})
+	require.NoError(t, err)
+
+	planned, err := fxt.Plan()
+	require.NoError(t, err)
+	require.Equal(t, "", planned[0].Account)
+	require.Equal(t, "${connected_account:id}", planned[1].Account)
+}
+
+func TestResources(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/v1/accounts":
+			res.Write([]byte(`{"id": "acct_connected", "object": "account"}`))
+		case "/v1/payment_intents":
+			res.Write([]byte(`{"id": "pi_123", "object": "payment_intent"}`))
+		default:
+			t.Errorf("Received an unexpected request URL: %s", req.URL.String())
+		}
+	}))
+	defer ts.Close()
+
+	afero.WriteFile(fs, "connect.json", []byte(connectFixture), os.ModePerm)
+
+	fxt, err := NewFixtureFromFile(fs, apiKey, "", ts.URL, "connect.json", []string{}, []string{}, []string{}, []string{})
+	require.NoError(t, err)
+	require.Equal(t, []Resource{}, fxt.Resources())
+
+	_, err = fxt.Execute(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, []Resource{
+		{Name: "connected_account", Type: "account", ID: "acct_connected"},
+		{Name: "direct_charge", Type: "payment_intent", ID: "pi_123"},
+	}, fxt.Resources())
+}
+
+func TestExecuteReportsSteps(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.Write([]byte(`{"id": "cus_123", "object": "customer"}`))
+	}))
+	defer ts.Close()
+
+	afero.WriteFile(fs, file, []byte(`{
+  "_meta": {"template_version": 0},
+  "fixtures": [
+    {"name": "customer", "path": "/v1/customers", "method": "post"},
+    {"name": "other_customer", "path": "/v1/customers", "method": "post"},
+    {"name": "customers", "path": "/v1/customers", "method": "get"}
+  ]
+}`), os.ModePerm)
+
+	fxt, err := NewFixtureFromFile(fs, apiKey, "", ts.URL, file, []string{"other_customer"}, []string{}, []string{}, []string{})
+	require.NoError(t, err)
+
+	var steps []StepProgress
+	fxt.OnStep = func(step StepProgress) {
+		steps = append(steps, step)
+	}
+
+	_, err = fxt.Execute(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, []StepProgress{
+		{Name: "customer", Resource: &Resource{Name: "customer", Type: "customer", ID: "cus_123"}},
+		{Name: "other_customer", Skipped: true},
+		{Name: "customers"},
+	}, steps)
+}
+
+func TestMakeRequestPinsAPIVersion(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	var versions []string
+	ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		versions = append(versions, req.Header.Get("Stripe-Version"))
+		res.Write([]byte(`{"id": "cus_12345"}`))
+	}))
+	defer ts.Close()
+
+	afero.WriteFile(fs, file, []byte(`{
+  "_meta": {"template_version": 0, "api_version": "2024-04-10", "preview_features": ["feature_beta"]},
+  "fixtures": [{"name": "customer", "path": "/v1/customers", "method": "post", "params": {}}]
+}`), os.ModePerm)
+
+	fxt, err := NewFixtureFromFile(fs, apiKey, "", ts.URL, file, []string{}, []string{}, []string{}, []string{})
+	require.NoError(t, err)
+
+	_, err = fxt.Execute(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"2024-04-10; feature_beta=v1"}, versions)
+}
+
+func TestErrWasExpected(t *testing.T) {
+	cardError := requests.RequestError{StatusCode: 402, ErrorType: "card_error"}
+
+	require.True(t, errWasExpected(cardError, "card_error"))
+	require.False(t, errWasExpected(cardError, "invalid_request_error"))
+	require.False(t, errWasExpected(cardError, ""))
+	require.False(t, errWasExpected(requests.RequestError{StatusCode: 502}, ""))
+	require.False(t, errWasExpected(errors.New("failed"), "card_error"))
+}
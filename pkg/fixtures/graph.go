@@ -0,0 +1,128 @@
+package fixtures
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// dependencies returns, for each step of the fixture, the indices of the
+// earlier steps it must wait for before running. A step waits for the steps
+// it references, like `${customer:id}` or `customer:balance` in a calc
+// helper. Steps advancing the test clock wait for all the steps before them,
+// and all the steps after them wait for them, since the clock changes the
+// objects attached to it.
+//
+// References are found by name in the step, so a step may wait for a step it
+// doesn't actually reference, but never the other way around.
+func (fxt *Fixture) dependencies() [][]int {
+	steps := fxt.fixture.Fixtures
+	deps := make([][]int, len(steps))
+
+	lastClockAdvance := -1
+	for i, data := range steps {
+		if data.AdvanceClock != "" {
+			for j := 0; j < i; j++ {
+				deps[i] = append(deps[i], j)
+			}
+			lastClockAdvance = i
+			continue
+		}
+
+		references := stepReferences(data)
+		for j := 0; j < i; j++ {
+			if j == lastClockAdvance || strings.Contains(references, steps[j].Name+":") {
+				deps[i] = append(deps[i], j)
+			}
+		}
+	}
+
+	return deps
+}
+
+// stepReferences returns the text of a step that can reference other steps
+func stepReferences(data fixture) string {
+	params, _ := json.Marshal(data.Params)
+	expect, _ := json.Marshal(data.Expect)
+
+	return strings.Join([]string{data.Path, data.Account, string(params), string(expect)}, "\n")
+}
+
+// executeConcurrently runs the steps of the fixture as soon as the steps
+// they depend on are done, with up to Concurrency steps at the same time.
+// The first failing step stops the run.
+func (fxt *Fixture) executeConcurrently(ctx context.Context, requestNames []string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	steps := fxt.fixture.Fixtures
+	deps := fxt.dependencies()
+
+	done := make([]chan struct{}, len(steps))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+
+	slots := make(chan struct{}, fxt.Concurrency)
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	for i, data := range steps {
+		if isNameIn(data.Name, fxt.Skip) {
+			fmt.Printf("Skipping fixture for: %s\n", data.Name)
+			fxt.reportStep(data, true, nil)
+			close(done[i])
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, data fixture) {
+			defer wg.Done()
+
+			for _, j := range deps[i] {
+				select {
+				case <-done[j]:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-slots }()
+
+			if err := fxt.runStep(ctx, data); err != nil {
+				fail(err)
+				return
+			}
+
+			if data.Path != "" {
+				requestNames[i] = data.Name
+			}
+
+			close(done[i])
+		}(i, data)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return ctx.Err()
+}
@@ -0,0 +1,176 @@
+package fixtures
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+const parallelFixture = `
+{
+	"_meta": {
+		"template_version": 0
+	},
+	"fixtures": [
+		{
+			"name": "connected_account",
+			"path": "/v1/accounts",
+			"method": "post"
+		},
+		{
+			"name": "customer",
+			"path": "/v1/customers",
+			"method": "post"
+		},
+		{
+			"name": "payment_intent",
+			"path": "/v1/payment_intents",
+			"method": "post",
+			"params": {
+				"customer": "${customer:id}",
+				"transfer_data": {
+					"destination": "${connected_account:id}"
+				}
+			}
+		},
+		{
+			"name": "renewal",
+			"advance_clock": "+1d"
+		},
+		{
+			"name": "balance",
+			"path": "/v1/balance",
+			"method": "get",
+			"params": {
+				"expand": ["${.calc:customer:balance + 1}"]
+			}
+		}
+	]
+}`
+
+func TestDependencies(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "parallel.json", []byte(parallelFixture), os.ModePerm)
+
+	fxt, err := NewFixtureFromFile(fs, apiKey, "", "", "parallel.json", []string{}, []string{}, []string{}, []string{})
+	require.NoError(t, err)
+
+	require.Equal(t, [][]int{
+		nil,
+		nil,
+		{0, 1},
+		{0, 1, 2},
+		{1, 3},
+	}, fxt.dependencies())
+}
+
+func TestExecuteConcurrently(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	var mu sync.Mutex
+	var requests []string
+
+	// The account and the customer are only answered once both requests
+	// arrived, so the test times out if they're sent one after the other
+	started := make(chan struct{}, 2)
+	bothStarted := make(chan struct{})
+	go func() {
+		<-started
+		<-started
+		close(bothStarted)
+	}()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		requests = append(requests, req.URL.Path)
+		mu.Unlock()
+
+		switch req.URL.Path {
+		case "/v1/accounts":
+			started <- struct{}{}
+			<-bothStarted
+			res.Write([]byte(`{"id": "acct_123"}`))
+		case "/v1/customers":
+			started <- struct{}{}
+			<-bothStarted
+			res.Write([]byte(`{"id": "cus_123"}`))
+		case "/v1/payment_intents":
+			req.ParseForm()
+			require.Equal(t, "cus_123", req.Form.Get("customer"))
+			require.Equal(t, "acct_123", req.Form.Get("transfer_data[destination]"))
+			res.Write([]byte(`{"id": "pi_123"}`))
+		default:
+			t.Errorf("Received an unexpected request URL: %s", req.URL.String())
+		}
+	}))
+	defer ts.Close()
+
+	afero.WriteFile(fs, "parallel.json", []byte(`{
+		"_meta": {"template_version": 0},
+		"fixtures": [
+			{"name": "connected_account", "path": "/v1/accounts", "method": "post"},
+			{"name": "customer", "path": "/v1/customers", "method": "post"},
+			{"name": "skipped", "path": "/v1/products", "method": "post"},
+			{
+				"name": "payment_intent",
+				"path": "/v1/payment_intents",
+				"method": "post",
+				"params": {
+					"customer": "${customer:id}",
+					"transfer_data": {"destination": "${connected_account:id}"}
+				}
+			}
+		]
+	}`), os.ModePerm)
+
+	fxt, err := NewFixtureFromFile(fs, apiKey, "", ts.URL, "parallel.json", []string{"skipped"}, []string{}, []string{}, []string{})
+	require.NoError(t, err)
+	fxt.Concurrency = 2
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	requestNames, err := fxt.Execute(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"connected_account", "customer", "", "payment_intent"}, requestNames)
+
+	require.Len(t, requests, 3)
+	require.ElementsMatch(t, []string{"/v1/accounts", "/v1/customers"}, requests[:2])
+	require.Equal(t, "/v1/payment_intents", requests[2])
+}
+
+func TestExecuteConcurrentlyStopsAtFirstError(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/v1/customers":
+			res.WriteHeader(http.StatusBadRequest)
+			res.Write([]byte(`{"error": {"type": "invalid_request_error", "message": "Invalid email"}}`))
+		default:
+			t.Errorf("Received an unexpected request URL: %s", req.URL.String())
+		}
+	}))
+	defer ts.Close()
+
+	afero.WriteFile(fs, "parallel.json", []byte(`{
+		"_meta": {"template_version": 0},
+		"fixtures": [
+			{"name": "customer", "path": "/v1/customers", "method": "post"},
+			{"name": "payment_method", "path": "/v1/payment_methods/pm_card_visa/attach", "method": "post", "params": {"customer": "${customer:id}"}}
+		]
+	}`), os.ModePerm)
+
+	fxt, err := NewFixtureFromFile(fs, apiKey, "", ts.URL, "parallel.json", []string{}, []string{}, []string{}, []string{})
+	require.NoError(t, err)
+	fxt.Concurrency = 4
+
+	_, err = fxt.Execute(context.Background())
+	require.Error(t, err)
+}
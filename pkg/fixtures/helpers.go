@@ -0,0 +1,383 @@
+package fixtures
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Besides references to earlier responses and environment variables, fixture
+// strings can use helpers generating a value every time the fixture runs, so
+// fixtures can be run repeatedly without colliding on unique values:
+//
+//		${.faker:email}           a unique email address, or another fake value
+//		${.random:int(100,1000)}  a random integer between 100 and 1000
+//		${.random:string(8)}      a random string of 8 lowercase letters and digits
+//		${.random:choice(a,b,c)}  one of the values, picked at random
+//		${.time:now+7d}           a Unix timestamp relative to now
+//		${.calc:price:unit_amount * 2}  arithmetic on numbers and references
+//
+// Helpers are expanded in place, so they can be part of a larger string.
+
+// helperRegexp matches the helpers used in fixture strings
+var helperRegexp = regexp.MustCompile(`\${\.(faker|random|time|calc):([^}]*)}`)
+
+// random generates the values of the helpers, which don't need to be secure
+var random = rand.New(rand.NewSource(time.Now().UnixNano())) // #nosec G404
+
+var (
+	firstNames = []string{"Ada", "Alan", "Grace", "Linus", "Margaret", "Dennis", "Barbara", "Ken", "Frances", "Edsger", "Radia", "Donald"}
+	lastNames  = []string{"Lovelace", "Turing", "Hopper", "Torvalds", "Hamilton", "Ritchie", "Liskov", "Thompson", "Allen", "Dijkstra", "Perlman", "Knuth"}
+	companies  = []string{"Rocket Rides", "Kavholm", "Typographic", "Pasha", "Furever", "Increment", "Atlas Labs", "Sail Co"}
+	words      = []string{"alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf", "hotel", "india", "juliet", "kilo", "lima"}
+)
+
+// fakers generate the values of ${.faker:<name>}
+var fakers = map[string]func() string{
+	"email": func() string {
+		return fmt.Sprintf("%s.%s.%s@example.com", strings.ToLower(pick(firstNames)), strings.ToLower(pick(lastNames)), randomString(6))
+	},
+	"name":       func() string { return pick(firstNames) + " " + pick(lastNames) },
+	"first_name": func() string { return pick(firstNames) },
+	"last_name":  func() string { return pick(lastNames) },
+	"company":    func() string { return pick(companies) },
+	"word":       func() string { return pick(words) },
+	"phone":      func() string { return fmt.Sprintf("+1555%07d", random.Intn(10000000)) },
+	"amount":     func() string { return strconv.Itoa(100 * (1 + random.Intn(100))) },
+	"uuid": func() string {
+		b := make([]byte, 16)
+		random.Read(b)
+		b[6] = (b[6] & 0x0f) | 0x40
+		b[8] = (b[8] & 0x3f) | 0x80
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+	},
+}
+
+// expandHelpers replaces the helpers of value with the values they generate.
+func (fxt *Fixture) expandHelpers(value string) (string, error) {
+	var expandErr error
+
+	expanded := helperRegexp.ReplaceAllStringFunc(value, func(match string) string {
+		groups := helperRegexp.FindStringSubmatch(match)
+
+		result, err := fxt.evalHelper(groups[1], strings.TrimSpace(groups[2]))
+		if err != nil && expandErr == nil {
+			expandErr = fmt.Errorf("invalid helper %s: %v", match, err)
+		}
+
+		return result
+	})
+
+	return expanded, expandErr
+}
+
+func (fxt *Fixture) evalHelper(helper, arg string) (string, error) {
+	switch helper {
+	case "faker":
+		faker, ok := fakers[arg]
+		if !ok {
+			return "", fmt.Errorf("unknown faker, expected one of %s", strings.Join(fakerNames(), ", "))
+		}
+		return faker(), nil
+	case "random":
+		return randomValue(arg)
+	case "time":
+		return timestamp(arg, time.Now())
+	case "calc":
+		result, err := fxt.calc(arg)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatFloat(result, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("unknown helper")
+	}
+}
+
+// randomValue evaluates int(min,max), string(length) and choice(values...).
+func randomValue(arg string) (string, error) {
+	open := strings.Index(arg, "(")
+	if open < 0 || !strings.HasSuffix(arg, ")") {
+		return "", fmt.Errorf("expected int(min,max), string(length) or choice(a,b,...)")
+	}
+
+	function := arg[:open]
+	args := strings.Split(arg[open+1:len(arg)-1], ",")
+	for i := range args {
+		args[i] = strings.TrimSpace(args[i])
+	}
+
+	switch function {
+	case "int":
+		if len(args) != 2 {
+			return "", fmt.Errorf("int expects a minimum and a maximum")
+		}
+		min, err := strconv.Atoi(args[0])
+		if err != nil {
+			return "", err
+		}
+		max, err := strconv.Atoi(args[1])
+		if err != nil {
+			return "", err
+		}
+		if max < min {
+			return "", fmt.Errorf("the maximum is lower than the minimum")
+		}
+		return strconv.Itoa(min + random.Intn(max-min+1)), nil
+	case "string":
+		length, err := strconv.Atoi(args[0])
+		if err != nil || length < 1 {
+			return "", fmt.Errorf("string expects a positive length")
+		}
+		return randomString(length), nil
+	case "choice":
+		return pick(args), nil
+	default:
+		return "", fmt.Errorf("unknown function %s, expected int, string or choice", function)
+	}
+}
+
+// timestamp returns the Unix timestamp of now, optionally offset like in
+// `now+7d` or `now-1h30m`. Offsets are made of numbers followed by s, m, h,
+// d (days) or w (weeks).
+func timestamp(arg string, now time.Time) (string, error) {
+	if !strings.HasPrefix(arg, "now") {
+		return "", fmt.Errorf("expected now, optionally followed by an offset like +7d")
+	}
+
+	offset := strings.TrimSpace(strings.TrimPrefix(arg, "now"))
+	if offset == "" {
+		return strconv.FormatInt(now.Unix(), 10), nil
+	}
+
+	sign := time.Duration(1)
+	switch offset[0] {
+	case '+':
+	case '-':
+		sign = -1
+	default:
+		return "", fmt.Errorf("expected an offset like +7d or -1h")
+	}
+
+	duration, err := parseOffset(strings.TrimSpace(offset[1:]))
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.FormatInt(now.Add(sign*duration).Unix(), 10), nil
+}
+
+func parseOffset(offset string) (time.Duration, error) {
+	units := map[byte]time.Duration{
+		's': time.Second,
+		'm': time.Minute,
+		'h': time.Hour,
+		'd': 24 * time.Hour,
+		'w': 7 * 24 * time.Hour,
+	}
+
+	var total time.Duration
+
+	for offset != "" {
+		i := 0
+		for i < len(offset) && offset[i] >= '0' && offset[i] <= '9' {
+			i++
+		}
+		if i == 0 || i == len(offset) {
+			return 0, fmt.Errorf("invalid offset %q", offset)
+		}
+
+		unit, ok := units[offset[i]]
+		if !ok {
+			return 0, fmt.Errorf("unknown unit %q, expected s, m, h, d or w", offset[i])
+		}
+
+		n, _ := strconv.Atoi(offset[:i])
+		total += time.Duration(n) * unit
+		offset = offset[i+1:]
+	}
+
+	return total, nil
+}
+
+// calc evaluates an arithmetic expression with +, -, *, / and parentheses,
+// where the operands are numbers or references to numeric fields of earlier
+// responses, written <name of fixture>:dot.path.to.field.
+func (fxt *Fixture) calc(expr string) (float64, error) {
+	p := &calcParser{fxt: fxt, expr: expr}
+
+	result, err := p.parseExpr()
+	if err != nil {
+		return 0, err
+	}
+
+	p.skipSpaces()
+	if p.pos < len(p.expr) {
+		return 0, fmt.Errorf("unexpected %q", p.expr[p.pos:])
+	}
+
+	return result, nil
+}
+
+type calcParser struct {
+	fxt  *Fixture
+	expr string
+	pos  int
+}
+
+func (p *calcParser) skipSpaces() {
+	for p.pos < len(p.expr) && p.expr[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// next returns the next operator or parenthesis, or 0 if there is none
+func (p *calcParser) next() byte {
+	p.skipSpaces()
+	if p.pos < len(p.expr) && strings.IndexByte("+-*/()", p.expr[p.pos]) >= 0 {
+		return p.expr[p.pos]
+	}
+
+	return 0
+}
+
+func (p *calcParser) parseExpr() (float64, error) {
+	result, err := p.parseTerm()
+	if err != nil {
+		return 0, err
+	}
+
+	for op := p.next(); op == '+' || op == '-'; op = p.next() {
+		p.pos++
+
+		operand, err := p.parseTerm()
+		if err != nil {
+			return 0, err
+		}
+
+		if op == '+' {
+			result += operand
+		} else {
+			result -= operand
+		}
+	}
+
+	return result, nil
+}
+
+func (p *calcParser) parseTerm() (float64, error) {
+	result, err := p.parseFactor()
+	if err != nil {
+		return 0, err
+	}
+
+	for op := p.next(); op == '*' || op == '/'; op = p.next() {
+		p.pos++
+
+		operand, err := p.parseFactor()
+		if err != nil {
+			return 0, err
+		}
+
+		if op == '*' {
+			result *= operand
+		} else {
+			if operand == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			result /= operand
+		}
+	}
+
+	return result, nil
+}
+
+func (p *calcParser) parseFactor() (float64, error) {
+	switch p.next() {
+	case '-':
+		p.pos++
+		result, err := p.parseFactor()
+		return -result, err
+	case '(':
+		p.pos++
+		result, err := p.parseExpr()
+		if err != nil {
+			return 0, err
+		}
+		if p.next() != ')' {
+			return 0, fmt.Errorf("missing )")
+		}
+		p.pos++
+		return result, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.expr) && p.expr[p.pos] != ' ' && strings.IndexByte("+-*/()", p.expr[p.pos]) < 0 {
+		p.pos++
+	}
+
+	operand := p.expr[start:p.pos]
+	if operand == "" {
+		return 0, fmt.Errorf("missing operand")
+	}
+
+	if n, err := strconv.ParseFloat(operand, 64); err == nil {
+		return n, nil
+	}
+
+	return p.reference(operand)
+}
+
+func (p *calcParser) reference(operand string) (float64, error) {
+	split := strings.SplitN(operand, ":", 2)
+	if len(split) != 2 {
+		return 0, fmt.Errorf("%s is neither a number nor a reference like customer:balance", operand)
+	}
+
+	response, ok := p.fxt.responses[split[0]]
+	if !ok {
+		return 0, fmt.Errorf("an undeclared fixture name was referenced: %s", split[0])
+	}
+
+	value := response.Get(split[1])
+	if !value.Exists() {
+		return 0, fmt.Errorf("%s isn't set", operand)
+	}
+
+	n, err := strconv.ParseFloat(value.String(), 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s isn't a number", operand)
+	}
+
+	return n, nil
+}
+
+func pick(values []string) string {
+	return values[random.Intn(len(values))]
+}
+
+func randomString(length int) string {
+	const alphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = alphabet[random.Intn(len(alphabet))]
+	}
+
+	return string(b)
+}
+
+func fakerNames() []string {
+	names := make([]string, 0, len(fakers))
+	for name := range fakers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,111 @@
+package fixtures
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestExpandHelpersFaker(t *testing.T) {
+	fxt := Fixture{}
+
+	first, err := fxt.parseQuery("${.faker:email}")
+	require.NoError(t, err)
+	require.Regexp(t, `^[a-z]+\.[a-z]+\.[a-z0-9]{6}@example\.com$`, first)
+
+	second, err := fxt.parseQuery("${.faker:email}")
+	require.NoError(t, err)
+	require.NotEqual(t, first, second)
+
+	uuid, err := fxt.parseQuery("${.faker:uuid}")
+	require.NoError(t, err)
+	require.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, uuid)
+
+	_, err = fxt.parseQuery("${.faker:unknown}")
+	require.Error(t, err)
+}
+
+func TestExpandHelpersRandom(t *testing.T) {
+	fxt := Fixture{}
+
+	for i := 0; i < 100; i++ {
+		value, err := fxt.parseQuery("${.random:int(5, 7)}")
+		require.NoError(t, err)
+
+		n, err := strconv.Atoi(value)
+		require.NoError(t, err)
+		require.True(t, n >= 5 && n <= 7)
+	}
+
+	value, err := fxt.parseQuery("order-${.random:string(8)}")
+	require.NoError(t, err)
+	require.Regexp(t, `^order-[a-z0-9]{8}$`, value)
+
+	value, err = fxt.parseQuery("${.random:choice(usd,eur)}")
+	require.NoError(t, err)
+	require.Contains(t, []string{"usd", "eur"}, value)
+
+	_, err = fxt.parseQuery("${.random:int(7,5)}")
+	require.Error(t, err)
+}
+
+func TestTimestamp(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+
+	for arg, expected := range map[string]int64{
+		"now":         1600000000,
+		"now+7d":      1600000000 + 7*24*3600,
+		"now - 1h30m": 1600000000 - 5400,
+		"now+2w":      1600000000 + 14*24*3600,
+	} {
+		value, err := timestamp(arg, now)
+		require.NoError(t, err, arg)
+		require.Equal(t, strconv.FormatInt(expected, 10), value, arg)
+	}
+
+	for _, arg := range []string{"tomorrow", "now+7", "now+7y", "now*2d"} {
+		_, err := timestamp(arg, now)
+		require.Error(t, err, arg)
+	}
+}
+
+func TestExpandHelpersCalc(t *testing.T) {
+	fxt := Fixture{
+		responses: map[string]gjson.Result{
+			"price":    gjson.Parse(`{"unit_amount": 1500, "currency": "usd"}`),
+			"discount": gjson.Parse(`{"amount_off": 250}`),
+		},
+	}
+
+	value, err := fxt.parseQuery("${.calc:price:unit_amount * 2 - discount:amount_off}")
+	require.NoError(t, err)
+	require.Equal(t, "2750", value)
+
+	value, err = fxt.parseQuery("${.calc:(price:unit_amount + 500) / -4}")
+	require.NoError(t, err)
+	require.Equal(t, "-500", value)
+
+	for _, expr := range []string{"price:currency * 2", "customer:balance + 1", "1 / 0", "(1 + 2", "1 +", "2 3"} {
+		_, err := fxt.parseQuery("${.calc:" + expr + "}")
+		require.Error(t, err, expr)
+	}
+}
+
+func TestExpandHelpersWithReferences(t *testing.T) {
+	fxt := Fixture{
+		responses: map[string]gjson.Result{
+			"customer": gjson.Parse(`{"id": "cus_123"}`),
+		},
+	}
+
+	data, err := fxt.parseInterface(map[string]interface{}{
+		"customer":    "${customer:id}",
+		"description": "Order ${.random:string(4)}",
+	})
+	require.NoError(t, err)
+	require.Len(t, data, 2)
+	require.Contains(t, data, "customer=cus_123")
+}
@@ -0,0 +1,181 @@
+package fixtures
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+
+	"github.com/stripe/stripe-cli/pkg/download"
+)
+
+// DefaultIndexURL is the curated index of fixture scenarios searched by
+// `stripe fixtures search`
+const DefaultIndexURL = "https://raw.githubusercontent.com/stripe/stripe-cli-fixtures/main/index.json"
+
+// maxIndexDownloadSize caps the size of the index and of the scenarios
+// downloaded from it
+const maxIndexDownloadSize = 50 * 1024 * 1024
+
+// indexDownloadTimeout is how long downloading the index or a scenario may take
+const indexDownloadTimeout = 2 * time.Minute
+
+// IndexEntry is a fixture scenario of the index
+type IndexEntry struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+	// Official scenarios are maintained by Stripe, the others by the community
+	Official bool `json:"official"`
+	// URL is either a fixture file, or a .tar.gz archive of a fixture pack
+	URL string `json:"url"`
+	// SHA256 is the hex encoded checksum of the file at URL
+	SHA256 string `json:"sha256"`
+}
+
+// Source describes who maintains the scenario
+func (e IndexEntry) Source() string {
+	if e.Official {
+		return "official"
+	}
+
+	return "community"
+}
+
+// Index is a curated list of fixture scenarios
+type Index struct {
+	Fixtures []IndexEntry `json:"fixtures"`
+}
+
+// FetchIndex downloads the index at url
+func FetchIndex(ctx context.Context, url string) (*Index, error) {
+	data, err := download.File(ctx, url, indexDownloadTimeout, maxIndexDownloadSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the fixtures index: %v", err)
+	}
+
+	var index Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse the fixtures index: %v", err)
+	}
+
+	return &index, nil
+}
+
+// Search returns the scenarios whose name, description or tags contain every
+// term of query, official scenarios first. An empty query matches every
+// scenario.
+func (i *Index) Search(query string) []IndexEntry {
+	terms := strings.Fields(strings.ToLower(query))
+
+	var matches []IndexEntry
+	for _, entry := range i.Fixtures {
+		text := strings.ToLower(entry.Name + " " + entry.Description + " " + strings.Join(entry.Tags, " "))
+
+		matched := true
+		for _, term := range terms {
+			if !strings.Contains(text, term) {
+				matched = false
+				break
+			}
+		}
+
+		if matched {
+			matches = append(matches, entry)
+		}
+	}
+
+	sort.SliceStable(matches, func(a, b int) bool {
+		if matches[a].Official != matches[b].Official {
+			return matches[a].Official
+		}
+		return matches[a].Name < matches[b].Name
+	})
+
+	return matches
+}
+
+// Lookup returns the scenario named name
+func (i *Index) Lookup(name string) (IndexEntry, error) {
+	for _, entry := range i.Fixtures {
+		if entry.Name == name {
+			return entry, nil
+		}
+	}
+
+	return IndexEntry{}, fmt.Errorf("no fixture scenario named ‘%s’ in the index, find one with `stripe fixtures search`", name)
+}
+
+// IsIndexName returns whether the source of `stripe fixtures install` is the
+// name of a scenario of the index, rather than a directory or a URL
+func IsIndexName(fs afero.Fs, source string) bool {
+	if isRemotePack(source) || strings.ContainsAny(source, `/\`) {
+		return false
+	}
+
+	exists, _ := afero.Exists(fs, source)
+
+	return !exists
+}
+
+// InstallIndexEntry downloads a scenario of the index into a directory of
+// packsDir named after it, after verifying its checksum, and returns the
+// directory to register it with like InstallPack.
+func InstallIndexEntry(ctx context.Context, fs afero.Fs, entry IndexEntry, packsDir string, installed []string) (string, error) {
+	if entry.SHA256 == "" {
+		return "", fmt.Errorf("the fixture scenario ‘%s’ has no checksum, so it can't be verified", entry.Name)
+	}
+
+	dir := filepath.Join(packsDir, entry.Name)
+	if exists, _ := afero.Exists(fs, dir); exists {
+		return "", fmt.Errorf("a fixture pack named %s is already installed, run `stripe fixtures uninstall %s` first", entry.Name, entry.Name)
+	}
+
+	data, err := download.File(ctx, entry.URL, indexDownloadTimeout, maxIndexDownloadSize)
+	if err != nil {
+		return "", fmt.Errorf("failed to download the fixture scenario ‘%s’: %v", entry.Name, err)
+	}
+
+	if !download.ChecksumMatches(data, entry.SHA256) {
+		return "", fmt.Errorf("the checksum of the fixture scenario ‘%s’ doesn't match the index, it wasn't installed", entry.Name)
+	}
+
+	if isArchive(entry.URL) {
+		err = download.ExtractTarGz(fs, bytes.NewReader(data), dir, 0)
+	} else {
+		err = writeIndexFixture(fs, dir, entry.URL, data)
+	}
+	if err != nil {
+		fs.RemoveAll(dir)
+		return "", err
+	}
+
+	if err := validatePack(fs, dir, installed); err != nil {
+		fs.RemoveAll(dir)
+		return "", err
+	}
+
+	return dir, nil
+}
+
+// writeIndexFixture writes a fixture file downloaded from url into dir,
+// named after the last element of url
+func writeIndexFixture(fs afero.Fs, dir, url string, data []byte) error {
+	name := path.Base(strings.SplitN(url, "?", 2)[0])
+	if filepath.Ext(name) != ".json" {
+		return fmt.Errorf("unsupported fixture scenario %s, expected a .json fixture or a .tar.gz archive", url)
+	}
+
+	if err := fs.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	return afero.WriteFile(fs, filepath.Join(dir, name), data, 0644)
+}
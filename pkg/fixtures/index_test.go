@@ -0,0 +1,110 @@
+package fixtures
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/download/downloadtest"
+)
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+var testIndex = &Index{Fixtures: []IndexEntry{
+	{Name: "smart-retries", Description: "Failed renewals recovered by retries", Tags: []string{"billing", "dunning"}},
+	{Name: "dunning-emails", Description: "Overdue invoices with reminder emails", Tags: []string{"billing", "dunning"}, Official: true},
+	{Name: "connect-payouts", Description: "Payouts to connected accounts", Tags: []string{"connect"}, Official: true},
+}}
+
+func TestIndexSearch(t *testing.T) {
+	var names []string
+	for _, entry := range testIndex.Search("Dunning") {
+		names = append(names, entry.Name)
+	}
+	require.Equal(t, []string{"dunning-emails", "smart-retries"}, names)
+
+	require.Len(t, testIndex.Search("billing retries"), 1)
+	require.Len(t, testIndex.Search(""), 3)
+	require.Empty(t, testIndex.Search("terminal"))
+}
+
+func TestIndexLookup(t *testing.T) {
+	entry, err := testIndex.Lookup("connect-payouts")
+	require.NoError(t, err)
+	require.Equal(t, "official", entry.Source())
+
+	_, err = testIndex.Lookup("connect")
+	require.EqualError(t, err, "no fixture scenario named ‘connect’ in the index, find one with `stripe fixtures search`")
+}
+
+func TestIsIndexName(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, fs.MkdirAll("fixtures", 0755))
+
+	require.True(t, IsIndexName(fs, "dunning-emails"))
+	require.False(t, IsIndexName(fs, "fixtures"))
+	require.False(t, IsIndexName(fs, "./dunning-emails"))
+	require.False(t, IsIndexName(fs, "https://example.com/fixtures.tar.gz"))
+}
+
+func TestInstallIndexEntry(t *testing.T) {
+	resetPacks(t)
+
+	archive := downloadtest.TarGz(t, map[string]string{"invoice.dunning.json": packFixture})
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/dunning.tar.gz":
+			w.Write(archive)
+		case "/invoice.reminder.json":
+			w.Write([]byte(packFixture))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	fs := afero.NewMemMapFs()
+	packsDir := "/config/fixture_packs"
+
+	dir, err := InstallIndexEntry(context.Background(), fs, IndexEntry{Name: "dunning", URL: ts.URL + "/dunning.tar.gz", SHA256: checksum(archive)}, packsDir, nil)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(packsDir, "dunning"), dir)
+	exists, _ := afero.Exists(fs, filepath.Join(dir, "invoice.dunning.json"))
+	require.True(t, exists)
+
+	dir, err = InstallIndexEntry(context.Background(), fs, IndexEntry{Name: "reminder", URL: ts.URL + "/invoice.reminder.json", SHA256: checksum([]byte(packFixture))}, packsDir, []string{dir})
+	require.NoError(t, err)
+	exists, _ = afero.Exists(fs, filepath.Join(dir, "invoice.reminder.json"))
+	require.True(t, exists)
+}
+
+func TestInstallIndexEntryChecksumMismatch(t *testing.T) {
+	resetPacks(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(packFixture))
+	}))
+	defer ts.Close()
+
+	fs := afero.NewMemMapFs()
+
+	_, err := InstallIndexEntry(context.Background(), fs, IndexEntry{Name: "tampered", URL: ts.URL + "/invoice.json", SHA256: checksum([]byte("{}"))}, "/packs", nil)
+	require.EqualError(t, err, "the checksum of the fixture scenario ‘tampered’ doesn't match the index, it wasn't installed")
+
+	exists, _ := afero.Exists(fs, "/packs/tampered")
+	require.False(t, exists)
+
+	_, err = InstallIndexEntry(context.Background(), fs, IndexEntry{Name: "unsigned", URL: ts.URL + "/invoice.json"}, "/packs", nil)
+	require.EqualError(t, err, "the fixture scenario ‘unsigned’ has no checksum, so it can't be verified")
+}
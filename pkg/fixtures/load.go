@@ -0,0 +1,141 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// DefaultMaxObjectsPerRun is the default maximum number of objects a single
+// `stripe trigger --count` run may create
+const DefaultMaxObjectsPerRun = 1000
+
+// DefaultMaxObjectsPerHour is the default maximum number of objects
+// `stripe trigger --count` runs may create in an hour
+const DefaultMaxObjectsPerHour = 5000
+
+// LoadLimits are ceilings on the number of objects created when triggering
+// events repeatedly, to keep a typo in --count from flooding the account.
+type LoadLimits struct {
+	MaxObjectsPerRun  int
+	MaxObjectsPerHour int
+}
+
+// LoadLimitError is returned when a run would create more objects than the
+// load limits allow
+type LoadLimitError struct {
+	Planned int
+	Limit   int
+	// CreatedLastHour is set when the hourly limit is exceeded
+	CreatedLastHour int
+	Hourly          bool
+}
+
+func (e LoadLimitError) Error() string {
+	if e.Hourly {
+		return fmt.Sprintf("this run would create %d objects, but %d were already created in the last hour and the hourly limit is %d. Pass --ignore-limits to run it anyway, or raise the trigger_max_objects_per_hour setting of your profile", e.Planned, e.CreatedLastHour, e.Limit)
+	}
+
+	return fmt.Sprintf("this run would create %d objects, more than the limit of %d per run. Pass --ignore-limits to run it anyway, or raise the trigger_max_objects_per_run setting of your profile", e.Planned, e.Limit)
+}
+
+// Check returns a LoadLimitError if creating planned objects, on top of the
+// createdLastHour objects created in the last hour, exceeds the limits.
+func (l LoadLimits) Check(planned, createdLastHour int) error {
+	if l.MaxObjectsPerRun > 0 && planned > l.MaxObjectsPerRun {
+		return LoadLimitError{Planned: planned, Limit: l.MaxObjectsPerRun}
+	}
+
+	if l.MaxObjectsPerHour > 0 && createdLastHour+planned > l.MaxObjectsPerHour {
+		return LoadLimitError{Planned: planned, Limit: l.MaxObjectsPerHour, CreatedLastHour: createdLastHour, Hourly: true}
+	}
+
+	return nil
+}
+
+// LoadLedger records the objects created by repeated triggers in a file, so
+// the hourly limit applies across runs.
+type LoadLedger struct {
+	Fs   afero.Fs
+	Path string
+
+	// Now returns the current time, and defaults to time.Now
+	Now func() time.Time
+}
+
+type loadLedgerEntry struct {
+	Time    time.Time `json:"time"`
+	Objects int       `json:"objects"`
+}
+
+// CreatedLastHour returns the number of objects recorded in the last hour.
+func (l *LoadLedger) CreatedLastHour() (int, error) {
+	entries, err := l.read()
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, entry := range entries {
+		total += entry.Objects
+	}
+
+	return total, nil
+}
+
+// Record adds objects created now to the ledger, dropping entries older than
+// an hour.
+func (l *LoadLedger) Record(objects int) error {
+	entries, err := l.read()
+	if err != nil {
+		return err
+	}
+
+	entries = append(entries, loadLedgerEntry{Time: l.now(), Objects: objects})
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	return afero.WriteFile(l.Fs, l.Path, data, 0600)
+}
+
+// read returns the entries of the last hour.
+func (l *LoadLedger) read() ([]loadLedgerEntry, error) {
+	data, err := afero.ReadFile(l.Fs, l.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []loadLedgerEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		// A corrupted ledger shouldn't block triggering, start over instead
+		return nil, nil
+	}
+
+	since := l.now().Add(-time.Hour)
+
+	recent := entries[:0]
+	for _, entry := range entries {
+		if entry.Time.After(since) {
+			recent = append(recent, entry)
+		}
+	}
+
+	return recent, nil
+}
+
+func (l *LoadLedger) now() time.Time {
+	if l.Now != nil {
+		return l.Now()
+	}
+
+	return time.Now()
+}
@@ -0,0 +1,56 @@
+package fixtures
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadLimitsCheck(t *testing.T) {
+	limits := LoadLimits{MaxObjectsPerRun: 100, MaxObjectsPerHour: 250}
+
+	require.NoError(t, limits.Check(100, 150))
+	require.Equal(t, LoadLimitError{Planned: 101, Limit: 100}, limits.Check(101, 0))
+	require.Equal(t, LoadLimitError{Planned: 100, Limit: 250, CreatedLastHour: 151, Hourly: true}, limits.Check(100, 151))
+
+	require.NoError(t, LoadLimits{}.Check(50000, 50000))
+}
+
+func TestLoadLedger(t *testing.T) {
+	now := time.Date(2021, 11, 1, 12, 0, 0, 0, time.UTC)
+	ledger := &LoadLedger{
+		Fs:   afero.NewMemMapFs(),
+		Path: "/trigger_usage.json",
+		Now:  func() time.Time { return now },
+	}
+
+	created, err := ledger.CreatedLastHour()
+	require.NoError(t, err)
+	require.Equal(t, 0, created)
+
+	require.NoError(t, ledger.Record(40))
+
+	now = now.Add(30 * time.Minute)
+	require.NoError(t, ledger.Record(60))
+
+	created, err = ledger.CreatedLastHour()
+	require.NoError(t, err)
+	require.Equal(t, 100, created)
+
+	// The first run drops out of the window an hour after it was recorded
+	now = now.Add(31 * time.Minute)
+	created, err = ledger.CreatedLastHour()
+	require.NoError(t, err)
+	require.Equal(t, 60, created)
+}
+
+func TestRequestCount(t *testing.T) {
+	fxt, err := NewFixtureFromRawString(afero.NewMemMapFs(), "sk_test_1234", "", "", testFixture)
+	require.NoError(t, err)
+	require.Equal(t, 3, fxt.RequestCount())
+
+	fxt.Skip = []string{"cust_bender"}
+	require.Equal(t, 2, fxt.RequestCount())
+}
@@ -0,0 +1,234 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/stripe/stripe-cli/pkg/download"
+	"github.com/stripe/stripe-cli/pkg/git"
+)
+
+// packEvents is a mapping of the trigger events of the registered fixture
+// packs and the corresponding json file
+var packEvents = map[string]string{}
+
+// cloneRepo clones the git repository of a pack, and is replaced in tests
+var cloneRepo = git.Operations{}.Clone
+
+// LoadPack returns a mapping of the trigger events of the fixture pack in dir
+// and the corresponding json file. Every fixture file of the directory and its
+// subdirectories is a trigger event named after the file, so
+// `invoice.overdue.json` is triggered with `stripe trigger invoice.overdue`.
+// JSON files that aren't fixtures are ignored.
+func LoadPack(fs afero.Fs, dir string) (map[string]string, error) {
+	events := make(map[string]string)
+
+	err := afero.Walk(fs, dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if path != dir && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if filepath.Ext(path) != ".json" {
+			return nil
+		}
+
+		data, err := afero.ReadFile(fs, path)
+		if err != nil {
+			return err
+		}
+
+		var fxtFile fixtureFile
+		if err := json.Unmarshal(data, &fxtFile); err != nil {
+			return fmt.Errorf("%s is not a valid fixture: %v", path, err)
+		}
+
+		if len(fxtFile.Fixtures) == 0 {
+			return nil
+		}
+
+		event := strings.TrimSuffix(info.Name(), ".json")
+		if other, ok := events[event]; ok {
+			return fmt.Errorf("%s and %s both define the event ‘%s’", other, path, event)
+		}
+
+		events[event] = path
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// RegisterPacks makes the events of the fixture packs in dirs available to
+// Trigger, in addition to the pre-built events. Events can't be defined by
+// more than one pack or replace a pre-built event.
+func RegisterPacks(fs afero.Fs, dirs []string) error {
+	registered := make(map[string]string)
+
+	for _, dir := range dirs {
+		events, err := LoadPack(fs, dir)
+		if err != nil {
+			return fmt.Errorf("failed to load the fixture pack %s: %v", dir, err)
+		}
+
+		for event, file := range events {
+			if _, ok := Events[event]; ok {
+				return fmt.Errorf("the fixture pack %s defines the event ‘%s’, which is already supported by the Stripe CLI", dir, event)
+			}
+
+			if other, ok := registered[event]; ok {
+				return fmt.Errorf("the fixture packs %s and %s both define the event ‘%s’", filepath.Dir(other), dir, event)
+			}
+
+			registered[event] = file
+		}
+	}
+
+	packEvents = registered
+
+	return nil
+}
+
+// InstallPack validates the fixture pack at source and returns the directory
+// to register it with. source is either a local directory, which is used in
+// place, or the URL of a git repository or a .tar.gz archive, which is
+// downloaded to a directory of packsDir named after it. The pack's events
+// mustn't conflict with those of the installed packs.
+func InstallPack(fs afero.Fs, source, packsDir string, installed []string) (string, error) {
+	dir, remote, err := fetchPack(fs, source, packsDir, installed)
+	if err != nil {
+		return "", err
+	}
+
+	err = validatePack(fs, dir, installed)
+	if err != nil {
+		if remote {
+			fs.RemoveAll(dir)
+		}
+		return "", err
+	}
+
+	return dir, nil
+}
+
+// UninstallPack removes the fixture pack given by its name or directory from
+// installed, and returns the remaining packs. Packs that were downloaded are
+// deleted from packsDir.
+func UninstallPack(fs afero.Fs, pack, packsDir string, installed []string) ([]string, error) {
+	abs, _ := filepath.Abs(pack)
+
+	for i, dir := range installed {
+		if dir != abs && filepath.Base(dir) != pack {
+			continue
+		}
+
+		if filepath.Dir(dir) == filepath.Clean(packsDir) {
+			if err := fs.RemoveAll(dir); err != nil {
+				return nil, err
+			}
+		}
+
+		remaining := append([]string{}, installed[:i]...)
+		return append(remaining, installed[i+1:]...), nil
+	}
+
+	return nil, fmt.Errorf("no fixture pack named %s is installed", pack)
+}
+
+func fetchPack(fs afero.Fs, source, packsDir string, installed []string) (dir string, remote bool, err error) {
+	if !isRemotePack(source) {
+		dir, err = filepath.Abs(source)
+		if err != nil {
+			return "", false, err
+		}
+
+		if isDir, _ := afero.IsDir(fs, dir); !isDir {
+			return "", false, fmt.Errorf("%s is not a directory", source)
+		}
+
+		for _, other := range installed {
+			if other == dir {
+				return "", false, fmt.Errorf("the fixture pack %s is already installed", dir)
+			}
+		}
+
+		return dir, false, nil
+	}
+
+	dir = filepath.Join(packsDir, packName(source))
+	if exists, _ := afero.Exists(fs, dir); exists {
+		return "", false, fmt.Errorf("a fixture pack named %s is already installed, run `stripe fixtures uninstall %s` first", filepath.Base(dir), filepath.Base(dir))
+	}
+
+	if isArchive(source) {
+		err = download.Archive(fs, source, dir, 0)
+	} else {
+		err = cloneRepo(dir, source)
+	}
+	if err != nil {
+		fs.RemoveAll(dir)
+		return "", false, err
+	}
+
+	return dir, true, nil
+}
+
+func validatePack(fs afero.Fs, dir string, installed []string) error {
+	events, err := LoadPack(fs, dir)
+	if err != nil {
+		return err
+	}
+
+	if len(events) == 0 {
+		return fmt.Errorf("%s doesn't contain any fixture", dir)
+	}
+
+	previous := packEvents
+	defer func() { packEvents = previous }()
+
+	return RegisterPacks(fs, append(append([]string{}, installed...), dir))
+}
+
+func isRemotePack(source string) bool {
+	for _, prefix := range []string{"http://", "https://", "ssh://", "git@"} {
+		if strings.HasPrefix(source, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isArchive(source string) bool {
+	path := strings.SplitN(source, "?", 2)[0]
+	return strings.HasSuffix(path, ".tar.gz") || strings.HasSuffix(path, ".tgz")
+}
+
+// packName names a remote pack after the last element of its URL, e.g.
+// `acme-fixtures` for https://github.com/acme/acme-fixtures.git.
+func packName(source string) string {
+	name := strings.SplitN(source, "?", 2)[0]
+	name = strings.TrimRight(name, "/")
+	name = name[strings.LastIndexAny(name, "/:")+1:]
+
+	for _, ext := range []string{".git", ".tar.gz", ".tgz"} {
+		name = strings.TrimSuffix(name, ext)
+	}
+
+	return name
+}
@@ -0,0 +1,166 @@
+package fixtures
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/download/downloadtest"
+)
+
+const packFixture = `{
+  "_meta": {"template_version": 0, "description": "Creates an overdue invoice."},
+  "fixtures": [
+    {"name": "customer", "path": "/v1/customers", "method": "post", "params": {}}
+  ]
+}`
+
+func writePack(t *testing.T, fs afero.Fs, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	return dir
+}
+
+func resetPacks(t *testing.T) {
+	t.Cleanup(func() { packEvents = map[string]string{} })
+}
+
+func TestLoadPack(t *testing.T) {
+	fs := afero.NewOsFs()
+	dir := writePack(t, fs, map[string]string{
+		"invoice.overdue.json": packFixture,
+		"package.json":         `{"name": "fixtures"}`,
+		"README.md":            "# Fixtures",
+	})
+	require.NoError(t, fs.MkdirAll(filepath.Join(dir, "billing"), 0755))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "billing", "invoice.voided.json"), []byte(packFixture), 0644))
+	require.NoError(t, fs.MkdirAll(filepath.Join(dir, ".git"), 0755))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, ".git", "invoice.ignored.json"), []byte(packFixture), 0644))
+
+	events, err := LoadPack(fs, dir)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"invoice.overdue": filepath.Join(dir, "invoice.overdue.json"),
+		"invoice.voided":  filepath.Join(dir, "billing", "invoice.voided.json"),
+	}, events)
+}
+
+func TestLoadPackInvalidFixture(t *testing.T) {
+	fs := afero.NewOsFs()
+	dir := writePack(t, fs, map[string]string{"invoice.overdue.json": "{"})
+
+	_, err := LoadPack(fs, dir)
+	require.Error(t, err)
+}
+
+func TestRegisterPacks(t *testing.T) {
+	resetPacks(t)
+
+	fs := afero.NewOsFs()
+	dir := writePack(t, fs, map[string]string{"invoice.overdue.json": packFixture})
+
+	require.NoError(t, RegisterPacks(fs, []string{dir}))
+	require.Contains(t, EventNames(), "invoice.overdue")
+
+	info, err := DescribeTrigger("invoice.overdue")
+	require.NoError(t, err)
+	require.Equal(t, "Creates an overdue invoice.", info.Description)
+	require.Equal(t, []string{"customer"}, info.Creates)
+
+	fixture, err := BuildTrigger("invoice.overdue", "", "", "sk_test_123", nil, nil, nil, nil, "")
+	require.NoError(t, err)
+	require.Equal(t, 1, fixture.RequestCount())
+}
+
+func TestRegisterPacksConflicts(t *testing.T) {
+	resetPacks(t)
+
+	fs := afero.NewOsFs()
+	builtIn := writePack(t, fs, map[string]string{"customer.created.json": packFixture})
+	first := writePack(t, fs, map[string]string{"invoice.overdue.json": packFixture})
+	second := writePack(t, fs, map[string]string{"invoice.overdue.json": packFixture})
+
+	require.Error(t, RegisterPacks(fs, []string{builtIn}))
+	require.Error(t, RegisterPacks(fs, []string{first, second}))
+}
+
+func TestInstallPackDirectory(t *testing.T) {
+	resetPacks(t)
+
+	fs := afero.NewOsFs()
+	dir := writePack(t, fs, map[string]string{"invoice.overdue.json": packFixture})
+
+	installed, err := InstallPack(fs, dir, t.TempDir(), nil)
+	require.NoError(t, err)
+	require.Equal(t, dir, installed)
+	require.Empty(t, EventNames()[len(Events):])
+
+	_, err = InstallPack(fs, dir, t.TempDir(), []string{dir})
+	require.Error(t, err)
+
+	_, err = InstallPack(fs, writePack(t, fs, nil), t.TempDir(), nil)
+	require.Error(t, err)
+}
+
+func TestInstallPackArchive(t *testing.T) {
+	resetPacks(t)
+
+	archive := downloadtest.TarGz(t, map[string]string{"acme-fixtures-main/invoice.overdue.json": packFixture})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive)
+	}))
+	defer ts.Close()
+
+	fs := afero.NewOsFs()
+	packsDir := t.TempDir()
+
+	dir, err := InstallPack(fs, ts.URL+"/acme-fixtures.tar.gz", packsDir, nil)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(packsDir, "acme-fixtures"), dir)
+
+	events, err := LoadPack(fs, dir)
+	require.NoError(t, err)
+	require.Contains(t, events, "invoice.overdue")
+
+	remaining, err := UninstallPack(fs, "acme-fixtures", packsDir, []string{"/other", dir})
+	require.NoError(t, err)
+	require.Equal(t, []string{"/other"}, remaining)
+
+	exists, _ := afero.Exists(fs, dir)
+	require.False(t, exists)
+}
+
+func TestInstallPackGit(t *testing.T) {
+	resetPacks(t)
+
+	original := cloneRepo
+	defer func() { cloneRepo = original }()
+
+	fs := afero.NewOsFs()
+	var cloned string
+	cloneRepo = func(path, url string) error {
+		cloned = url
+		require.NoError(t, fs.MkdirAll(path, 0755))
+		return afero.WriteFile(fs, filepath.Join(path, "invoice.overdue.json"), []byte(packFixture), 0644)
+	}
+
+	packsDir := t.TempDir()
+	dir, err := InstallPack(fs, "git@github.com:acme/acme-fixtures.git", packsDir, nil)
+	require.NoError(t, err)
+	require.Equal(t, "git@github.com:acme/acme-fixtures.git", cloned)
+	require.Equal(t, filepath.Join(packsDir, "acme-fixtures"), dir)
+}
+
+func TestPackName(t *testing.T) {
+	require.Equal(t, "acme-fixtures", packName("https://github.com/acme/acme-fixtures.git"))
+	require.Equal(t, "acme-fixtures", packName("git@github.com:acme-fixtures.git"))
+	require.Equal(t, "acme-fixtures", packName("https://example.com/acme-fixtures.tar.gz?token=123"))
+	require.Equal(t, "acme-fixtures", packName("https://example.com/acme-fixtures/"))
+}
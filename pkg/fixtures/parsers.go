@@ -39,6 +39,9 @@ import (
 //
 // The supported query shapes are simple:
 // 		$<name of fixture>:dot.path.to.field
+//
+// Strings can also use the helpers described in helpers.go, which generate
+// fake data, random values, timestamps and computed values.
 
 // parsePath will inspect the path to see if it has a query in the
 // path for requests that operate on specific objects (for example,
@@ -271,6 +274,11 @@ func findSimilarQueryNames(fxt *Fixture, name string) ([]string, bool) {
 // corresponding value in its place. The supported query format is:
 // 		$<name of fixture>:dot.path.to.field
 func (fxt *Fixture) parseQuery(queryString string) (string, error) {
+	queryString, err := fxt.expandHelpers(queryString)
+	if err != nil {
+		return "", err
+	}
+
 	value := queryString
 
 	if query, isQuery := toFixtureQuery(queryString); isQuery {
@@ -0,0 +1,183 @@
+package fixtures
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/stripe/stripe-cli/pkg/requests"
+)
+
+// capabilityPrefix prefixes the requirements on a capability of the account,
+// like `capability:card_payments`
+const capabilityPrefix = "capability:"
+
+// requirementCheck tells how to check that the account meets a requirement:
+// a GET request to path succeeds only if it does.
+type requirementCheck struct {
+	path         string
+	params       []string
+	instructions string
+}
+
+// requirementChecks are the products fixtures can require with
+// `"requires": [...]` in their _meta
+var requirementChecks = map[string]requirementCheck{
+	"connect": {
+		path:         "/v1/accounts",
+		params:       []string{"limit=1"},
+		instructions: "Sign up for Connect at https://dashboard.stripe.com/test/connect/accounts/overview",
+	},
+	"issuing": {
+		path:         "/v1/issuing/cardholders",
+		params:       []string{"limit=1"},
+		instructions: "Activate Issuing at https://dashboard.stripe.com/test/issuing/overview",
+	},
+	"terminal": {
+		path:         "/v1/terminal/locations",
+		params:       []string{"limit=1"},
+		instructions: "Activate Terminal at https://dashboard.stripe.com/test/terminal",
+	},
+	"tax": {
+		path:         "/v1/tax/settings",
+		instructions: "Set up Stripe Tax at https://dashboard.stripe.com/test/settings/tax",
+	},
+	"treasury": {
+		path:         "/v1/treasury/financial_accounts",
+		params:       []string{"limit=1"},
+		instructions: "Activate Treasury at https://dashboard.stripe.com/test/treasury",
+	},
+}
+
+// MissingRequirement is a requirement of a fixture the account doesn't meet
+type MissingRequirement struct {
+	Name         string
+	Reason       string
+	Instructions string
+}
+
+// PreflightError is returned by Execute when the account doesn't meet the
+// requirements of the fixture
+type PreflightError struct {
+	Missing []MissingRequirement
+}
+
+func (e PreflightError) Error() string {
+	var b strings.Builder
+
+	b.WriteString("the account doesn't meet the requirements of this fixture:")
+	for _, missing := range e.Missing {
+		fmt.Fprintf(&b, "\n  %s: %s", missing.Name, missing.Reason)
+		if missing.Instructions != "" {
+			fmt.Fprintf(&b, "\n    %s", missing.Instructions)
+		}
+	}
+
+	return b.String()
+}
+
+// metRequirements caches the requirements already found to be met, so
+// triggering an event repeatedly checks them once
+var metRequirements sync.Map
+
+// Requirements returns the requirements declared by the fixture.
+func (fxt *Fixture) Requirements() []string {
+	return fxt.fixture.Meta.Requires
+}
+
+// Preflight checks that the account meets the requirements of the fixture,
+// like having Connect or Issuing enabled, so a fixture fails before creating
+// anything rather than with an API error halfway.
+func (fxt *Fixture) Preflight(ctx context.Context) error {
+	var missing []MissingRequirement
+	var account *gjson.Result
+
+	for _, name := range fxt.fixture.Meta.Requires {
+		cacheKey := strings.Join([]string{fxt.BaseURL, fxt.APIKey, fxt.StripeAccount, name}, "\x00")
+		if _, ok := metRequirements.Load(cacheKey); ok {
+			continue
+		}
+
+		var reason, instructions string
+
+		if strings.HasPrefix(name, capabilityPrefix) {
+			if account == nil {
+				resp, err := fxt.get(ctx, "/v1/account", nil)
+				if err != nil {
+					return err
+				}
+				parsed := gjson.ParseBytes(resp)
+				account = &parsed
+			}
+
+			capability := strings.TrimPrefix(name, capabilityPrefix)
+			status := account.Get("capabilities." + capability).String()
+			if status != "active" {
+				if status == "" {
+					status = "not requested"
+				}
+				reason = fmt.Sprintf("the %s capability is %s", capability, status)
+				instructions = "Request it in the settings of the account at https://dashboard.stripe.com/test/settings"
+			}
+		} else {
+			check, ok := requirementChecks[name]
+			if !ok {
+				return fmt.Errorf("unknown fixture requirement ‘%s’. Requirements are %s, or capability:<name>", name, strings.Join(requirementNames(), ", "))
+			}
+
+			_, err := fxt.get(ctx, check.path, check.params)
+			var rerr requests.RequestError
+			switch {
+			case err == nil:
+			case errors.As(err, &rerr) && rerr.StatusCode != http.StatusUnauthorized && rerr.StatusCode < 500:
+				reason = fmt.Sprintf("%s isn't enabled on the account", name)
+				instructions = check.instructions
+			default:
+				return err
+			}
+		}
+
+		if reason != "" {
+			missing = append(missing, MissingRequirement{Name: name, Reason: reason, Instructions: instructions})
+		} else {
+			metRequirements.Store(cacheKey, true)
+		}
+	}
+
+	if len(missing) > 0 {
+		return PreflightError{Missing: missing}
+	}
+
+	return nil
+}
+
+func (fxt *Fixture) get(ctx context.Context, path string, data []string) ([]byte, error) {
+	req := requests.Base{
+		Method:         http.MethodGet,
+		SuppressOutput: true,
+		APIBaseURL:     fxt.BaseURL,
+	}
+
+	var params requests.RequestParameters
+	params.AppendData(data)
+	params.SetStripeAccount(fxt.StripeAccount)
+
+	return req.MakeRequest(ctx, fxt.APIKey, path, &params, true)
+}
+
+func requirementNames() []string {
+	names := make([]string, 0, len(requirementChecks))
+	for name := range requirementChecks {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
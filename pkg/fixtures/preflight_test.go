@@ -0,0 +1,92 @@
+package fixtures
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func preflightFixture(t *testing.T, baseURL string, requires string) *Fixture {
+	raw := `{
+		"_meta": {"template_version": 0, "requires": ` + requires + `},
+		"fixtures": [{"name": "cardholder", "path": "/v1/issuing/cardholders", "method": "post"}]
+	}`
+
+	fxt, err := NewFixtureFromRawString(afero.NewMemMapFs(), apiKey, "", baseURL, raw)
+	require.NoError(t, err)
+
+	return fxt
+}
+
+func TestPreflightMissingRequirements(t *testing.T) {
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		paths = append(paths, req.Method+" "+req.URL.Path)
+
+		switch req.URL.Path {
+		case "/v1/issuing/cardholders":
+			res.WriteHeader(400)
+			res.Write([]byte(`{"error": {"type": "invalid_request_error", "message": "Your account is not set up to use Issuing."}}`))
+		case "/v1/account":
+			res.Write([]byte(`{"id": "acct_123", "capabilities": {"card_payments": "active", "transfers": "inactive"}}`))
+		}
+	}))
+	defer ts.Close()
+
+	fxt := preflightFixture(t, ts.URL, `["issuing", "capability:card_payments", "capability:transfers", "capability:us_bank_account_ach_payments"]`)
+
+	_, err := fxt.Execute(context.Background())
+
+	var preflightErr PreflightError
+	require.True(t, errors.As(err, &preflightErr))
+	require.Equal(t, `the account doesn't meet the requirements of this fixture:
+  issuing: issuing isn't enabled on the account
+    Activate Issuing at https://dashboard.stripe.com/test/issuing/overview
+  capability:transfers: the transfers capability is inactive
+    Request it in the settings of the account at https://dashboard.stripe.com/test/settings
+  capability:us_bank_account_ach_payments: the us_bank_account_ach_payments capability is not requested
+    Request it in the settings of the account at https://dashboard.stripe.com/test/settings`, err.Error())
+
+	// Nothing was created, and the account was fetched once
+	require.Equal(t, []string{"GET /v1/issuing/cardholders", "GET /v1/account"}, paths)
+}
+
+func TestPreflightCachesMetRequirements(t *testing.T) {
+	checks := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodGet {
+			checks++
+		}
+		res.Write([]byte(`{"id": "ich_123"}`))
+	}))
+	defer ts.Close()
+
+	for i := 0; i < 3; i++ {
+		_, err := preflightFixture(t, ts.URL, `["issuing"]`).Execute(context.Background())
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, 1, checks)
+}
+
+func TestPreflightUnknownRequirement(t *testing.T) {
+	err := preflightFixture(t, "http://localhost", `["unicorns"]`).Preflight(context.Background())
+	require.EqualError(t, err, "unknown fixture requirement ‘unicorns’. Requirements are connect, issuing, tax, terminal, treasury, or capability:<name>")
+}
+
+func TestPreflightUnauthorized(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(401)
+		res.Write([]byte(`{"error": {"type": "invalid_request_error", "message": "Invalid API Key provided"}}`))
+	}))
+	defer ts.Close()
+
+	err := preflightFixture(t, ts.URL, `["connect"]`).Preflight(context.Background())
+	require.Error(t, err)
+	require.False(t, errors.As(err, &PreflightError{}))
+}
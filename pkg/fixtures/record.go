@@ -0,0 +1,427 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/tidwall/gjson"
+)
+
+// idPattern matches the IDs of Stripe objects, like `cus_MxYz1234` or
+// `sub_sched_1Mx2Ab3Cd`. Test values like `pm_card_visa` are too short to
+// match, so they're kept as is.
+var idPattern = regexp.MustCompile(`^[a-z]+(_[a-z]+)*_[A-Za-z0-9]{8,}$`)
+
+// Recording is a recording session started with `stripe fixtures record`,
+// which captures the requests of `stripe get`, `stripe post` and `stripe
+// delete` until it's stopped.
+type Recording struct {
+	Out       string         `json:"out"`
+	StartedAt time.Time      `json:"started_at"`
+	Steps     []RecordedStep `json:"steps"`
+}
+
+// RecordedStep is a request captured by a recording session, with its
+// response.
+type RecordedStep struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	// Params are the form data of the request, as `key=value` pairs in the
+	// order they were sent
+	Params   []string        `json:"params,omitempty"`
+	Response json.RawMessage `json:"response"`
+}
+
+// NewRecordedStep returns the step of a request sent with the form-encoded
+// data and answered with body.
+func NewRecordedStep(method, path, data string, body []byte) RecordedStep {
+	step := RecordedStep{
+		Method: strings.ToLower(method),
+		Path:   path,
+	}
+
+	for _, pair := range strings.Split(data, "&") {
+		if pair == "" {
+			continue
+		}
+
+		kv := strings.SplitN(pair, "=", 2)
+		key, err := url.QueryUnescape(kv[0])
+		if err != nil {
+			key = kv[0]
+		}
+
+		value := ""
+		if len(kv) == 2 {
+			if value, err = url.QueryUnescape(kv[1]); err != nil {
+				value = kv[1]
+			}
+		}
+
+		step.Params = append(step.Params, key+"="+value)
+	}
+
+	if json.Valid(body) {
+		step.Response = append(json.RawMessage(nil), body...)
+	}
+
+	return step
+}
+
+// RecordingStore keeps the recording session in progress, if any, in a file.
+type RecordingStore struct {
+	Fs   afero.Fs
+	Path string
+}
+
+// Start starts a recording session writing the fixture to out once stopped.
+func (s *RecordingStore) Start(out string, now time.Time) error {
+	current, err := s.Active()
+	if err != nil {
+		return err
+	}
+	if current != nil {
+		return fmt.Errorf("already recording into %s. Stop with `stripe fixtures record --stop`, or discard it with `stripe fixtures record --discard`", current.Out)
+	}
+
+	return s.save(&Recording{Out: out, StartedAt: now})
+}
+
+// Active returns the recording session in progress, or nil if there's none.
+func (s *RecordingStore) Active() (*Recording, error) {
+	data, err := afero.ReadFile(s.Fs, s.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var recording Recording
+	if err := json.Unmarshal(data, &recording); err != nil {
+		return nil, fmt.Errorf("failed to read the recording session: %v", err)
+	}
+
+	return &recording, nil
+}
+
+// Append adds step to the recording session in progress. It returns false if
+// there's none.
+func (s *RecordingStore) Append(step RecordedStep) (bool, error) {
+	recording, err := s.Active()
+	if err != nil || recording == nil {
+		return false, err
+	}
+
+	recording.Steps = append(recording.Steps, step)
+
+	return true, s.save(recording)
+}
+
+// Stop ends the recording session in progress and returns it.
+func (s *RecordingStore) Stop() (*Recording, error) {
+	recording, err := s.Active()
+	if err != nil {
+		return nil, err
+	}
+	if recording == nil {
+		return nil, fmt.Errorf("no recording in progress. Start one with `stripe fixtures record --out <file>`")
+	}
+
+	return recording, s.Fs.Remove(s.Path)
+}
+
+// Discard ends the recording session in progress without keeping it.
+func (s *RecordingStore) Discard() error {
+	err := s.Fs.Remove(s.Path)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("no recording in progress")
+	}
+
+	return err
+}
+
+func (s *RecordingStore) save(recording *Recording) error {
+	if err := s.Fs.MkdirAll(filepath.Dir(s.Path), 0700); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(recording, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return afero.WriteFile(s.Fs, s.Path, data, 0600)
+}
+
+type recordedFixtureFile struct {
+	Meta     recordedMeta      `json:"_meta"`
+	Fixtures []recordedFixture `json:"fixtures"`
+}
+
+type recordedMeta struct {
+	Version     int    `json:"template_version"`
+	Description string `json:"description"`
+}
+
+type recordedFixture struct {
+	Name   string                 `json:"name"`
+	Path   string                 `json:"path"`
+	Method string                 `json:"method"`
+	Params map[string]interface{} `json:"params,omitempty"`
+}
+
+// Fixture returns the fixture file replaying the recorded steps. The IDs of
+// objects returned by earlier steps are replaced with references to their
+// responses, like `${customer:id}`, so the fixture creates new objects
+// rather than reusing the recorded ones.
+func (r *Recording) Fixture() ([]byte, error) {
+	file := recordedFixtureFile{
+		Meta: recordedMeta{
+			Description: fmt.Sprintf("Recorded with `stripe fixtures record` on %s.", r.StartedAt.Format("2006-01-02")),
+		},
+		Fixtures: []recordedFixture{},
+	}
+
+	references := make(map[string]string)
+	names := make(map[string]int)
+
+	for _, step := range r.Steps {
+		name := stepName(step, names)
+
+		params, err := formParams(step.Params, references)
+		if err != nil {
+			return nil, err
+		}
+
+		file.Fixtures = append(file.Fixtures, recordedFixture{
+			Name:   name,
+			Path:   referencePath(step.Path, references),
+			Method: step.Method,
+			Params: params,
+		})
+
+		indexReferences(name, gjson.ParseBytes(step.Response), references)
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, '\n'), nil
+}
+
+// stepName names a step after the type of the object it returned, like
+// `customer`, numbering the following steps returning the same type, like
+// `customer_2`.
+func stepName(step RecordedStep, names map[string]int) string {
+	response := gjson.ParseBytes(step.Response)
+
+	base := response.Get("object").String()
+	if base == "" || base == "list" || base == "search_result" {
+		// Name lists after their path, like `customers` for /v1/customers
+		segments := strings.Split(strings.Trim(step.Path, "/"), "/")
+		for i := len(segments) - 1; i >= 0; i-- {
+			if !idPattern.MatchString(segments[i]) {
+				base = segments[i]
+				break
+			}
+		}
+	}
+	base = strings.NewReplacer(".", "_", ":", "_").Replace(base)
+	if base == "" {
+		base = "step"
+	}
+
+	names[base]++
+	if names[base] == 1 {
+		return base
+	}
+
+	return fmt.Sprintf("%s_%d", base, names[base])
+}
+
+// indexReferences adds the IDs found in the response of step name to
+// references. The ID of the object returned by a step is preferred to other
+// mentions of it, so it's referenced as `${customer:id}` rather than
+// `${subscription:customer}`.
+func indexReferences(name string, response gjson.Result, references map[string]string) {
+	if id := response.Get("id").String(); idPattern.MatchString(id) {
+		if ref, ok := references[id]; !ok || !strings.HasSuffix(ref, ":id}") {
+			references[id] = fmt.Sprintf("${%s:id}", name)
+		}
+	}
+
+	var walk func(path string, value gjson.Result)
+	walk = func(path string, value gjson.Result) {
+		switch {
+		case value.IsObject() || value.IsArray():
+			value.ForEach(func(key, child gjson.Result) bool {
+				k := key.String()
+				if value.IsArray() {
+					k = strconv.Itoa(int(key.Int()))
+				} else if strings.ContainsAny(k, ".*?|#@\\") {
+					// Keys gjson would read as a path can't be referenced
+					return true
+				}
+				if path != "" {
+					k = path + "." + k
+				}
+				walk(k, child)
+				return true
+			})
+		case value.Type == gjson.String && idPattern.MatchString(value.Str):
+			if _, ok := references[value.Str]; !ok {
+				references[value.Str] = fmt.Sprintf("${%s:%s}", name, path)
+			}
+		}
+	}
+
+	walk("", response)
+}
+
+// referencePath replaces the IDs of path found in references.
+func referencePath(path string, references map[string]string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if ref, ok := references[segment]; ok {
+			segments[i] = ref
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// formParams returns the nested params of a fixture from form data, like
+// `items[0][price]=price_123`, replacing the IDs found in references.
+func formParams(pairs []string, references map[string]string) (map[string]interface{}, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+
+	root := newFormNode()
+
+	for _, pair := range pairs {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) < 2 {
+			return nil, fmt.Errorf("invalid recorded param: %s", pair)
+		}
+
+		value := kv[1]
+		if ref, ok := references[value]; ok {
+			value = ref
+		}
+
+		node := root
+		segments := formKeySegments(kv[0])
+		for i, segment := range segments {
+			if i == len(segments)-1 {
+				if segment == "" {
+					node.values = append(node.values, value)
+				} else {
+					node.child(segment).value = &value
+				}
+				break
+			}
+			node = node.child(segment)
+		}
+	}
+
+	params, ok := root.build().(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid recorded params")
+	}
+
+	return params, nil
+}
+
+// formKeySegments splits a form key, like `items[0][price]`, into its
+// segments, like `items`, `0` and `price`.
+func formKeySegments(key string) []string {
+	i := strings.Index(key, "[")
+	if i < 0 {
+		return []string{key}
+	}
+
+	segments := []string{key[:i]}
+	for _, segment := range strings.Split(strings.TrimSuffix(key[i+1:], "]"), "][") {
+		segments = append(segments, segment)
+	}
+
+	return segments
+}
+
+// formNode is a key of nested form data
+type formNode struct {
+	value    *string
+	values   []string
+	keys     []string
+	children map[string]*formNode
+}
+
+func newFormNode() *formNode {
+	return &formNode{children: make(map[string]*formNode)}
+}
+
+func (n *formNode) child(key string) *formNode {
+	child, ok := n.children[key]
+	if !ok {
+		child = newFormNode()
+		n.children[key] = child
+		n.keys = append(n.keys, key)
+	}
+
+	return child
+}
+
+// build returns the value of n: a string, an array for `key[]` values and
+// numbered keys, or a map.
+func (n *formNode) build() interface{} {
+	if n.value != nil {
+		return *n.value
+	}
+
+	if len(n.values) > 0 {
+		values := make([]interface{}, len(n.values))
+		for i, value := range n.values {
+			values[i] = value
+		}
+		return values
+	}
+
+	indexes := make([]int, 0, len(n.keys))
+	for _, key := range n.keys {
+		index, err := strconv.Atoi(key)
+		if err != nil {
+			indexes = nil
+			break
+		}
+		indexes = append(indexes, index)
+	}
+
+	if len(indexes) > 0 {
+		sort.Ints(indexes)
+		values := make([]interface{}, 0, len(indexes))
+		for _, index := range indexes {
+			values = append(values, n.children[strconv.Itoa(index)].build())
+		}
+		return values
+	}
+
+	m := make(map[string]interface{}, len(n.keys))
+	for _, key := range n.keys {
+		m[key] = n.children[key].build()
+	}
+
+	return m
+}
@@ -0,0 +1,148 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRecordedStep(t *testing.T) {
+	step := NewRecordedStep("POST", "/v1/customers", "name=Jenny+Rosen&expand[]=tax&metadata[order]=a%3Db", []byte(`{"id": "cus_MxYz1234"}`))
+
+	require.Equal(t, "post", step.Method)
+	require.Equal(t, "/v1/customers", step.Path)
+	require.Equal(t, []string{"name=Jenny Rosen", "expand[]=tax", "metadata[order]=a=b"}, step.Params)
+	require.JSONEq(t, `{"id": "cus_MxYz1234"}`, string(step.Response))
+
+	step = NewRecordedStep("GET", "/v1/customers", "", []byte("not json"))
+	require.Empty(t, step.Params)
+	require.Nil(t, step.Response)
+}
+
+func TestRecordingStore(t *testing.T) {
+	store := &RecordingStore{Fs: afero.NewMemMapFs(), Path: "/config/fixture_recording.json"}
+
+	recorded, err := store.Append(RecordedStep{Method: "get", Path: "/v1/balance"})
+	require.NoError(t, err)
+	require.False(t, recorded)
+
+	require.NoError(t, store.Start("scenario.json", time.Now()))
+
+	err = store.Start("other.json", time.Now())
+	require.EqualError(t, err, "already recording into scenario.json. Stop with `stripe fixtures record --stop`, or discard it with `stripe fixtures record --discard`")
+
+	recorded, err = store.Append(RecordedStep{Method: "post", Path: "/v1/customers"})
+	require.NoError(t, err)
+	require.True(t, recorded)
+
+	recording, err := store.Stop()
+	require.NoError(t, err)
+	require.Equal(t, "scenario.json", recording.Out)
+	require.Len(t, recording.Steps, 1)
+
+	active, err := store.Active()
+	require.NoError(t, err)
+	require.Nil(t, active)
+
+	_, err = store.Stop()
+	require.EqualError(t, err, "no recording in progress. Start one with `stripe fixtures record --out <file>`")
+
+	require.NoError(t, store.Start("scenario.json", time.Now()))
+	require.NoError(t, store.Discard())
+	require.EqualError(t, store.Discard(), "no recording in progress")
+}
+
+func TestRecordingFixture(t *testing.T) {
+	recording := &Recording{
+		Out:       "scenario.json",
+		StartedAt: time.Date(2022, 10, 15, 9, 30, 0, 0, time.UTC),
+		Steps: []RecordedStep{
+			NewRecordedStep("POST", "/v1/customers", "name=Jenny+Rosen&payment_method=pm_card_visa",
+				[]byte(`{"id": "cus_MxYz1234", "object": "customer"}`)),
+			NewRecordedStep("POST", "/v1/subscriptions", "customer=cus_MxYz1234&items[0][price]=price_1Abcdefgh&expand[]=latest_invoice",
+				[]byte(`{"id": "sub_1Abcdefgh", "object": "subscription", "customer": "cus_MxYz1234", "latest_invoice": {"id": "in_1Abcdefgh", "object": "invoice"}}`)),
+			NewRecordedStep("POST", "/v1/invoices/in_1Abcdefgh/pay", "",
+				[]byte(`{"id": "in_1Abcdefgh", "object": "invoice"}`)),
+			NewRecordedStep("GET", "/v1/customers", "limit=3",
+				[]byte(`{"object": "list", "data": []}`)),
+			NewRecordedStep("DELETE", "/v1/customers/cus_MxYz1234", "",
+				[]byte(`{"id": "cus_MxYz1234", "object": "customer", "deleted": true}`)),
+		},
+	}
+
+	data, err := recording.Fixture()
+	require.NoError(t, err)
+
+	require.JSONEq(t, `{
+		"_meta": {
+			"template_version": 0,
+			"description": "Recorded with `+"`stripe fixtures record`"+` on 2022-10-15."
+		},
+		"fixtures": [
+			{
+				"name": "customer",
+				"path": "/v1/customers",
+				"method": "post",
+				"params": {"name": "Jenny Rosen", "payment_method": "pm_card_visa"}
+			},
+			{
+				"name": "subscription",
+				"path": "/v1/subscriptions",
+				"method": "post",
+				"params": {
+					"customer": "${customer:id}",
+					"items": [{"price": "price_1Abcdefgh"}],
+					"expand": ["latest_invoice"]
+				}
+			},
+			{
+				"name": "invoice",
+				"path": "/v1/invoices/${subscription:latest_invoice.id}/pay",
+				"method": "post"
+			},
+			{
+				"name": "customers",
+				"path": "/v1/customers",
+				"method": "get",
+				"params": {"limit": "3"}
+			},
+			{
+				"name": "customer_2",
+				"path": "/v1/customers/${customer:id}",
+				"method": "delete"
+			}
+		]
+	}`, string(data))
+
+	// The recorded fixture can be loaded back
+	var file fixtureFile
+	require.NoError(t, json.Unmarshal(data, &file))
+	require.Len(t, file.Fixtures, 5)
+}
+
+func TestFormParams(t *testing.T) {
+	params, err := formParams([]string{
+		"items[1][price]=price_2",
+		"items[0][price]=price_1",
+		"items[0][quantity]=2",
+		"metadata[a]=b",
+		"tags[]=x",
+		"tags[]=y",
+	}, map[string]string{})
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"price": "price_1", "quantity": "2"},
+			map[string]interface{}{"price": "price_2"},
+		},
+		"metadata": map[string]interface{}{"a": "b"},
+		"tags":     []interface{}{"x", "y"},
+	}, params)
+
+	_, err = formParams([]string{"invalid"}, map[string]string{})
+	require.EqualError(t, err, "invalid recorded param: invalid")
+}
@@ -0,0 +1,236 @@
+package fixtures
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+
+	"github.com/stripe/stripe-cli/pkg/requests"
+)
+
+// TeardownRun records the teardown requests of a fixture that ran, with the
+// IDs of the objects it created already in place, so they can be deleted
+// later with `stripe fixtures teardown <id>`.
+type TeardownRun struct {
+	ID            string            `json:"id"`
+	Fixture       string            `json:"fixture"`
+	CreatedAt     time.Time         `json:"created_at"`
+	StripeAccount string            `json:"stripe_account,omitempty"`
+	BaseURL       string            `json:"base_url"`
+	Requests      []TeardownRequest `json:"requests"`
+}
+
+// TeardownRequest is a resolved teardown request.
+type TeardownRequest struct {
+	Name   string   `json:"name"`
+	Method string   `json:"method"`
+	Path   string   `json:"path"`
+	Params []string `json:"params,omitempty"`
+	// StripeAccount is the connected account of the request, when it
+	// differs from the one of the run
+	StripeAccount string `json:"stripe_account,omitempty"`
+}
+
+// HasTeardown returns whether the fixture declares teardown requests, or
+// runs on a test clock, which is deleted with the objects attached to it.
+func (fxt *Fixture) HasTeardown() bool {
+	return len(fxt.fixture.Teardown) > 0 || fxt.usesTestClock()
+}
+
+// PrepareTeardown resolves the teardown requests of the fixture against the
+// responses of the requests Execute sent. Teardown requests referencing
+// fixtures that were skipped or didn't return the queried field are left
+// out, since the objects they would delete weren't created.
+func (fxt *Fixture) PrepareTeardown(name string) *TeardownRun {
+	run := &TeardownRun{
+		ID:            newRunID(time.Now()),
+		Fixture:       name,
+		CreatedAt:     time.Now(),
+		StripeAccount: fxt.StripeAccount,
+		BaseURL:       fxt.BaseURL,
+	}
+
+	for _, data := range fxt.fixture.Teardown {
+		path, err := fxt.parsePath(data)
+		if err != nil || strings.Contains(path, "${") {
+			continue
+		}
+
+		params, err := fxt.parseInterface(data.Params)
+		if err != nil || unresolved(params) {
+			continue
+		}
+
+		request := TeardownRequest{
+			Name:   data.Name,
+			Method: strings.ToUpper(data.Method),
+			Path:   path,
+			Params: params,
+		}
+
+		if data.Account != "" {
+			account, err := fxt.parseQuery(data.Account)
+			if err != nil || strings.Contains(account, "${") {
+				continue
+			}
+			request.StripeAccount = account
+		}
+
+		run.Requests = append(run.Requests, request)
+	}
+
+	// Deleting the test clock last deletes the objects attached to it
+	if clock, ok := fxt.responses[testClockName]; ok && clock.Get("id").String() != "" {
+		run.Requests = append(run.Requests, TeardownRequest{
+			Name:   testClockName,
+			Method: http.MethodDelete,
+			Path:   "/v1/test_helpers/test_clocks/" + clock.Get("id").String(),
+		})
+	}
+
+	return run
+}
+
+// Teardown sends the requests of run in order. Objects that are already gone
+// aren't an error, so a run can be torn down again after a failure.
+func Teardown(ctx context.Context, apiKey string, run *TeardownRun) error {
+	var failed []string
+
+	for _, data := range run.Requests {
+		fmt.Printf("Tearing down fixture for: %s\n", data.Name)
+
+		var params requests.RequestParameters
+		params.AppendData(data.Params)
+		if data.StripeAccount != "" {
+			params.SetStripeAccount(data.StripeAccount)
+		} else {
+			params.SetStripeAccount(run.StripeAccount)
+		}
+
+		req := requests.Base{
+			Method:         data.Method,
+			SuppressOutput: true,
+			APIBaseURL:     run.BaseURL,
+		}
+
+		_, err := req.MakeRequest(ctx, apiKey, data.Path, &params, true)
+		if err != nil && !isMissingResource(err) {
+			failed = append(failed, fmt.Sprintf("%s: %v", data.Name, err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("Teardown failed:\n%s", strings.Join(failed, "\n"))
+	}
+
+	return nil
+}
+
+func isMissingResource(err error) bool {
+	var rerr requests.RequestError
+	if errors.As(err, &rerr) {
+		return rerr.StatusCode == 404 || rerr.ErrorCode == "resource_missing"
+	}
+	return false
+}
+
+func unresolved(params []string) bool {
+	for _, param := range params {
+		if strings.Contains(param, "${") {
+			return true
+		}
+	}
+	return false
+}
+
+func newRunID(now time.Time) string {
+	return fmt.Sprintf("%s-%04x", now.Format("20060102-150405"), rand.Intn(0x10000)) // #nosec G404
+}
+
+// TeardownStore keeps the teardown runs that haven't been torn down yet, one
+// JSON file per run in Dir.
+type TeardownStore struct {
+	Fs  afero.Fs
+	Dir string
+}
+
+// Save records run.
+func (s *TeardownStore) Save(run *TeardownRun) error {
+	if err := s.Fs.MkdirAll(s.Dir, 0700); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return afero.WriteFile(s.Fs, s.path(run.ID), data, 0600)
+}
+
+// Load returns the run id.
+func (s *TeardownStore) Load(id string) (*TeardownRun, error) {
+	data, err := afero.ReadFile(s.Fs, s.path(id))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no fixture run ‘%s’ to tear down. List them with `stripe fixtures teardown`", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var run TeardownRun
+	if err := json.Unmarshal(data, &run); err != nil {
+		return nil, fmt.Errorf("failed to read the fixture run ‘%s’: %v", id, err)
+	}
+
+	return &run, nil
+}
+
+// List returns the recorded runs, oldest first.
+func (s *TeardownStore) List() ([]*TeardownRun, error) {
+	files, err := afero.ReadDir(s.Fs, s.Dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var runs []*TeardownRun
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+
+		run, err := s.Load(strings.TrimSuffix(file.Name(), ".json"))
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].CreatedAt.Before(runs[j].CreatedAt)
+	})
+
+	return runs, nil
+}
+
+// Delete forgets the run id, once it's been torn down.
+func (s *TeardownStore) Delete(id string) error {
+	return s.Fs.Remove(s.path(id))
+}
+
+func (s *TeardownStore) path(id string) string {
+	return filepath.Join(s.Dir, filepath.Base(id)+".json")
+}
@@ -0,0 +1,172 @@
+package fixtures
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+const teardownTestFixture = `
+{
+	"_meta": {
+		"template_version": 0
+	},
+	"fixtures": [
+		{
+			"name": "customer",
+			"path": "/v1/customers",
+			"method": "post"
+		},
+		{
+			"name": "subscription",
+			"path": "/v1/subscriptions",
+			"method": "post",
+			"params": {
+				"customer": "${customer:id}"
+			}
+		}
+	],
+	"teardown": [
+		{
+			"name": "subscription_canceled",
+			"path": "/v1/subscriptions/${subscription:id}",
+			"method": "delete",
+			"params": {
+				"invoice_now": true
+			}
+		},
+		{
+			"name": "customer_deleted",
+			"path": "/v1/customers/${customer:id}",
+			"method": "delete"
+		}
+	]
+}`
+
+func TestPrepareTeardown(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/v1/customers":
+			res.Write([]byte(`{"id": "cus_123"}`))
+		case "/v1/subscriptions":
+			res.Write([]byte(`{"id": "sub_123"}`))
+		}
+	}))
+	defer ts.Close()
+
+	afero.WriteFile(fs, "teardown_test_fixture.json", []byte(teardownTestFixture), os.ModePerm)
+	fxt, err := NewFixtureFromFile(fs, apiKey, "acct_123", ts.URL, "teardown_test_fixture.json", []string{}, []string{}, []string{}, []string{})
+	require.NoError(t, err)
+	require.True(t, fxt.HasTeardown())
+
+	_, err = fxt.Execute(context.Background())
+	require.NoError(t, err)
+
+	run := fxt.PrepareTeardown("customer.subscription.created")
+	require.Equal(t, "customer.subscription.created", run.Fixture)
+	require.Equal(t, "acct_123", run.StripeAccount)
+	require.Equal(t, ts.URL, run.BaseURL)
+	require.Equal(t, []TeardownRequest{
+		{Name: "subscription_canceled", Method: "DELETE", Path: "/v1/subscriptions/sub_123", Params: []string{"invoice_now=true"}},
+		{Name: "customer_deleted", Method: "DELETE", Path: "/v1/customers/cus_123"},
+	}, run.Requests)
+}
+
+func TestPrepareTeardownSkipsObjectsNotCreated(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.Write([]byte(`{"id": "cus_123"}`))
+	}))
+	defer ts.Close()
+
+	afero.WriteFile(fs, "teardown_test_fixture.json", []byte(teardownTestFixture), os.ModePerm)
+	fxt, err := NewFixtureFromFile(fs, apiKey, "", ts.URL, "teardown_test_fixture.json", []string{"subscription"}, []string{}, []string{}, []string{})
+	require.NoError(t, err)
+
+	_, err = fxt.Execute(context.Background())
+	require.NoError(t, err)
+
+	run := fxt.PrepareTeardown("customer.subscription.created")
+	require.Len(t, run.Requests, 1)
+	require.Equal(t, "customer_deleted", run.Requests[0].Name)
+}
+
+func TestTeardown(t *testing.T) {
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		paths = append(paths, req.Method+" "+req.URL.Path)
+		require.Equal(t, "acct_123", req.Header.Get("Stripe-Account"))
+
+		if req.URL.Path == "/v1/subscriptions/sub_123" {
+			res.WriteHeader(404)
+			res.Write([]byte(`{"error": {"type": "invalid_request_error", "code": "resource_missing"}}`))
+			return
+		}
+		res.Write([]byte(`{"deleted": true}`))
+	}))
+	defer ts.Close()
+
+	run := &TeardownRun{
+		StripeAccount: "acct_123",
+		BaseURL:       ts.URL,
+		Requests: []TeardownRequest{
+			{Name: "subscription_canceled", Method: "DELETE", Path: "/v1/subscriptions/sub_123"},
+			{Name: "customer_deleted", Method: "DELETE", Path: "/v1/customers/cus_123"},
+		},
+	}
+
+	err := Teardown(context.Background(), apiKey, run)
+	require.NoError(t, err)
+	require.Equal(t, []string{"DELETE /v1/subscriptions/sub_123", "DELETE /v1/customers/cus_123"}, paths)
+}
+
+func TestTeardownFailure(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(500)
+		res.Write([]byte(`{"error": "Internal Failure Occurred."}`))
+	}))
+	defer ts.Close()
+
+	run := &TeardownRun{
+		BaseURL:  ts.URL,
+		Requests: []TeardownRequest{{Name: "customer_deleted", Method: "DELETE", Path: "/v1/customers/cus_123"}},
+	}
+
+	err := Teardown(context.Background(), apiKey, run)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "customer_deleted")
+}
+
+func TestTeardownStore(t *testing.T) {
+	store := &TeardownStore{Fs: afero.NewMemMapFs(), Dir: "/config/fixture_runs"}
+
+	runs, err := store.List()
+	require.NoError(t, err)
+	require.Empty(t, runs)
+
+	older := &TeardownRun{ID: "older", Fixture: "customer.created", CreatedAt: time.Now().Add(-time.Hour)}
+	newer := &TeardownRun{ID: "newer", Fixture: "plan.created", CreatedAt: time.Now()}
+	require.NoError(t, store.Save(newer))
+	require.NoError(t, store.Save(older))
+
+	runs, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	require.Equal(t, "older", runs[0].ID)
+	require.Equal(t, "newer", runs[1].ID)
+
+	run, err := store.Load("newer")
+	require.NoError(t, err)
+	require.Equal(t, "plan.created", run.Fixture)
+
+	require.NoError(t, store.Delete("newer"))
+	_, err = store.Load("newer")
+	require.EqualError(t, err, "no fixture run ‘newer’ to tear down. List them with `stripe fixtures teardown`")
+}
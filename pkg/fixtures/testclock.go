@@ -0,0 +1,173 @@
+package fixtures
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/stripe/stripe-cli/pkg/requests"
+)
+
+// testClockName is the name the test clock of a fixture is referenced by,
+// like `${test_clock:id}`
+const testClockName = "test_clock"
+
+// testClockPollInterval is how often the status of a test clock being
+// advanced is checked
+var testClockPollInterval = 2 * time.Second
+
+// testClockAdvanceTimeout is how long a test clock can take to advance
+var testClockAdvanceTimeout = 10 * time.Minute
+
+// clockAdvancePattern matches a part of an advance_clock value, like `35d`
+var clockAdvancePattern = regexp.MustCompile(`(\d+)([wdhms])`)
+
+var clockAdvanceUnits = map[string]time.Duration{
+	"w": 7 * 24 * time.Hour,
+	"d": 24 * time.Hour,
+	"h": time.Hour,
+	"m": time.Minute,
+	"s": time.Second,
+}
+
+// parseClockAdvance returns the duration of an advance_clock value, like
+// `+35d` or `+1d12h`.
+func parseClockAdvance(advance string) (time.Duration, error) {
+	value := strings.TrimPrefix(strings.TrimSpace(advance), "+")
+
+	var duration time.Duration
+	matched := 0
+	for _, match := range clockAdvancePattern.FindAllStringSubmatch(value, -1) {
+		n, err := strconv.Atoi(match[1])
+		if err != nil {
+			return 0, err
+		}
+		duration += time.Duration(n) * clockAdvanceUnits[match[2]]
+		matched += len(match[0])
+	}
+
+	if matched == 0 || matched != len(value) || duration <= 0 {
+		return 0, fmt.Errorf("invalid advance_clock ‘%s’, expected a duration like +35d, +1w or +1d12h", advance)
+	}
+
+	return duration, nil
+}
+
+// usesTestClock tells whether the fixture runs on a test clock
+func (fxt *Fixture) usesTestClock() bool {
+	return fxt.fixture.Meta.TestClock
+}
+
+// createTestClock creates the test clock of the fixture, frozen at the
+// current time.
+func (fxt *Fixture) createTestClock(ctx context.Context) error {
+	for _, data := range fxt.fixture.Fixtures {
+		if data.Name == testClockName {
+			return fmt.Errorf("the fixture runs on a test clock, so its steps can't be named %s", testClockName)
+		}
+	}
+
+	fmt.Printf("Setting up fixture for: %s\n", testClockName)
+
+	resp, err := fxt.clockRequest(ctx, http.MethodPost, "/v1/test_helpers/test_clocks", []string{
+		fmt.Sprintf("frozen_time=%d", time.Now().Unix()),
+		"name=Stripe CLI fixture",
+	})
+	if err != nil {
+		return err
+	}
+
+	fxt.responses[testClockName] = resp
+
+	return nil
+}
+
+// advanceTestClock advances the test clock of the fixture by the
+// advance_clock of data, and waits for the clock to be ready, so the events
+// of the objects attached to it fired before the next step runs.
+func (fxt *Fixture) advanceTestClock(ctx context.Context, data fixture) error {
+	clock, ok := fxt.responses[testClockName]
+	if !ok {
+		return fmt.Errorf("%s advances the test clock, but the fixture has no test clock. Set \"test_clock\": true in its _meta", data.Name)
+	}
+
+	advance, err := parseClockAdvance(data.AdvanceClock)
+	if err != nil {
+		return err
+	}
+
+	id := clock.Get("id").String()
+	frozenTime := clock.Get("frozen_time").Int() + int64(advance/time.Second)
+
+	fmt.Printf("Advancing the test clock by %s for: %s\n", data.AdvanceClock, data.Name)
+
+	clock, err = fxt.clockRequest(ctx, http.MethodPost, "/v1/test_helpers/test_clocks/"+id+"/advance", []string{
+		fmt.Sprintf("frozen_time=%d", frozenTime),
+	})
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(testClockAdvanceTimeout)
+	for clock.Get("status").String() != "ready" {
+		if status := clock.Get("status").String(); status == "internal_failure" {
+			return fmt.Errorf("the test clock %s failed to advance", id)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("the test clock %s is still advancing after %s", id, testClockAdvanceTimeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(testClockPollInterval):
+		}
+
+		clock, err = fxt.clockRequest(ctx, http.MethodGet, "/v1/test_helpers/test_clocks/"+id, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	fxt.responses[testClockName] = clock
+
+	return nil
+}
+
+// attachesTestClock tells whether the request of data creates a customer
+// that must be attached to the test clock of the fixture. Subscriptions and
+// invoices follow the clock of their customer.
+func (fxt *Fixture) attachesTestClock(data fixture, path string) bool {
+	if !fxt.usesTestClock() || data.Method != "post" || path != "/v1/customers" {
+		return false
+	}
+
+	_, set := data.Params["test_clock"]
+
+	return !set
+}
+
+func (fxt *Fixture) clockRequest(ctx context.Context, method, path string, data []string) (gjson.Result, error) {
+	req := requests.Base{
+		Method:         method,
+		SuppressOutput: true,
+		APIBaseURL:     fxt.BaseURL,
+	}
+
+	var params requests.RequestParameters
+	params.AppendData(data)
+	params.SetStripeAccount(fxt.StripeAccount)
+
+	resp, err := req.MakeRequest(ctx, fxt.APIKey, path, &params, true)
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	return gjson.ParseBytes(resp), nil
+}
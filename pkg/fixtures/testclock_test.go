@@ -0,0 +1,173 @@
+package fixtures
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+const testClockFixture = `
+{
+	"_meta": {
+		"template_version": 0,
+		"test_clock": true
+	},
+	"fixtures": [
+		{
+			"name": "customer",
+			"path": "/v1/customers",
+			"method": "post"
+		},
+		{
+			"name": "subscription",
+			"path": "/v1/subscriptions",
+			"method": "post",
+			"params": {
+				"customer": "${customer:id}"
+			}
+		},
+		{
+			"name": "renewal",
+			"advance_clock": "+35d"
+		},
+		{
+			"name": "clock",
+			"path": "/v1/test_helpers/test_clocks/${test_clock:id}",
+			"method": "get",
+			"expect": {
+				"frozen_time": 3025000
+			}
+		}
+	]
+}`
+
+func TestParseClockAdvance(t *testing.T) {
+	for advance, expected := range map[string]time.Duration{
+		"+35d":   35 * 24 * time.Hour,
+		"1w":     7 * 24 * time.Hour,
+		"+1d12h": 36 * time.Hour,
+		"+90m":   90 * time.Minute,
+	} {
+		duration, err := parseClockAdvance(advance)
+		require.NoError(t, err)
+		require.Equal(t, expected, duration, advance)
+	}
+
+	for _, advance := range []string{"", "+", "35", "+35y", "+0d", "+1d 2h", "-1d"} {
+		_, err := parseClockAdvance(advance)
+		require.EqualError(t, err, "invalid advance_clock ‘"+advance+"’, expected a duration like +35d, +1w or +1d12h")
+	}
+}
+
+func TestExecuteWithTestClock(t *testing.T) {
+	defer func(interval time.Duration) { testClockPollInterval = interval }(testClockPollInterval)
+	testClockPollInterval = time.Millisecond
+
+	var requests []string
+	polls := 0
+
+	fs := afero.NewMemMapFs()
+	ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		params, err := url.ParseQuery(string(body))
+		require.NoError(t, err)
+
+		requests = append(requests, req.Method+" "+req.URL.Path)
+
+		switch req.Method + " " + req.URL.Path {
+		case "POST /v1/test_helpers/test_clocks":
+			require.NotEmpty(t, params.Get("frozen_time"))
+			res.Write([]byte(`{"id": "clock_123", "frozen_time": 1000, "status": "ready"}`))
+		case "POST /v1/customers":
+			require.Equal(t, "clock_123", params.Get("test_clock"))
+			res.Write([]byte(`{"id": "cus_123"}`))
+		case "POST /v1/subscriptions":
+			require.Empty(t, params.Get("test_clock"))
+			res.Write([]byte(`{"id": "sub_123"}`))
+		case "POST /v1/test_helpers/test_clocks/clock_123/advance":
+			require.Equal(t, "3025000", params.Get("frozen_time"))
+			res.Write([]byte(`{"id": "clock_123", "frozen_time": 1000, "status": "advancing"}`))
+		case "GET /v1/test_helpers/test_clocks/clock_123":
+			polls++
+			if polls < 2 {
+				res.Write([]byte(`{"id": "clock_123", "frozen_time": 1000, "status": "advancing"}`))
+				return
+			}
+			res.Write([]byte(`{"id": "clock_123", "frozen_time": 3025000, "status": "ready"}`))
+		default:
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	afero.WriteFile(fs, "test_clock_fixture.json", []byte(testClockFixture), os.ModePerm)
+	fxt, err := NewFixtureFromFile(fs, apiKey, "", ts.URL, "test_clock_fixture.json", []string{}, []string{}, []string{}, []string{})
+	require.NoError(t, err)
+	require.Equal(t, 4, fxt.RequestCount())
+
+	_, err = fxt.Execute(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		"POST /v1/test_helpers/test_clocks",
+		"POST /v1/customers",
+		"POST /v1/subscriptions",
+		"POST /v1/test_helpers/test_clocks/clock_123/advance",
+		"GET /v1/test_helpers/test_clocks/clock_123",
+		"GET /v1/test_helpers/test_clocks/clock_123",
+		"GET /v1/test_helpers/test_clocks/clock_123",
+	}, requests)
+
+	// The test clock is deleted with the objects attached to it
+	require.True(t, fxt.HasTeardown())
+	run := fxt.PrepareTeardown("renewal")
+	require.Equal(t, []TeardownRequest{
+		{Name: "test_clock", Method: "DELETE", Path: "/v1/test_helpers/test_clocks/clock_123"},
+	}, run.Requests)
+}
+
+func TestAdvanceClockWithoutTestClock(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "fixture.json", []byte(`{
+		"_meta": {"template_version": 0},
+		"fixtures": [{"name": "renewal", "advance_clock": "+35d"}]
+	}`), os.ModePerm)
+
+	fxt, err := NewFixtureFromFile(fs, apiKey, "", "", "fixture.json", []string{}, []string{}, []string{}, []string{})
+	require.NoError(t, err)
+
+	_, err = fxt.Execute(context.Background())
+	require.EqualError(t, err, "renewal advances the test clock, but the fixture has no test clock. Set \"test_clock\": true in its _meta")
+}
+
+func TestPlanWithTestClock(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "test_clock_fixture.json", []byte(testClockFixture), os.ModePerm)
+
+	fxt, err := NewFixtureFromFile(fs, apiKey, "", "", "test_clock_fixture.json", []string{}, []string{}, []string{}, []string{})
+	require.NoError(t, err)
+
+	planned, err := fxt.Plan()
+	require.NoError(t, err)
+	require.Len(t, planned, 5)
+
+	require.Equal(t, "test_clock", planned[0].Name)
+	require.Equal(t, "/v1/test_helpers/test_clocks", planned[0].Path)
+	require.Contains(t, planned[1].Params, "test_clock=${test_clock:id}")
+	require.Equal(t, PlannedRequest{
+		Name:   "renewal",
+		Method: "POST",
+		Path:   "/v1/test_helpers/test_clocks/${test_clock:id}/advance",
+		Params: []string{"frozen_time=<frozen time +35d>"},
+	}, planned[3])
+	require.Equal(t, "/v1/test_helpers/test_clocks/${test_clock:id}", planned[4].Path)
+}
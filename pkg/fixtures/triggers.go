@@ -42,6 +42,7 @@ var Events = map[string]string{
 	"invoice.payment_failed":                   "triggers/invoice.payment_failed.json",
 	"invoice.payment_succeeded":                "triggers/invoice.payment_succeeded.json",
 	"invoice.updated":                          "triggers/invoice.updated.json",
+	"invoice.upcoming":                         "triggers/invoice.upcoming.json",
 	"issuing_authorization.request":            "triggers/issuing_authorization.request.json",
 	"issuing_card.created":                     "triggers/issuing_card.created.json",
 	"issuing_cardholder.created":               "triggers/issuing_cardholder.created.json",
@@ -119,12 +120,16 @@ func EventList() string {
 	return eventList
 }
 
-// EventNames returns an array of all the event names
+// EventNames returns an array of all the event names, including those of the
+// registered fixture packs
 func EventNames() []string {
 	names := []string{}
 	for name := range Events {
 		names = append(names, name)
 	}
+	for name := range packEvents {
+		names = append(names, name)
+	}
 
 	sort.Strings(names)
 
@@ -133,48 +138,56 @@ func EventNames() []string {
 
 // Trigger triggers a Stripe event.
 func Trigger(ctx context.Context, event string, stripeAccount string, baseURL string, apiKey string, skip, override, add, remove []string, raw string) ([]string, error) {
-	var fixture *Fixture
-	var err error
-	fs := afero.NewOsFs()
+	fixture, err := BuildTrigger(event, stripeAccount, baseURL, apiKey, skip, override, add, remove, raw)
+	if err != nil {
+		return nil, err
+	}
 
+	return ExecuteTrigger(ctx, event, fixture)
+}
+
+// ExecuteTrigger runs the fixture built by BuildTrigger for event. The
+// fixture can then be torn down with PrepareTeardown.
+func ExecuteTrigger(ctx context.Context, event string, fixture *Fixture) ([]string, error) {
 	// send event triggered
 	telemetryClient := stripe.GetTelemetryClient(ctx)
 	if telemetryClient != nil {
 		go telemetryClient.SendEvent(ctx, "Triggered Event", event)
 	}
 
-	if len(raw) == 0 {
-		if file, ok := Events[event]; ok {
-			fixture, err = BuildFromFixtureFile(fs, apiKey, stripeAccount, baseURL, file, skip, override, add, remove)
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			exists, _ := afero.Exists(fs, event)
-			if !exists {
-				return nil, fmt.Errorf(fmt.Sprintf("The event ‘%s’ is not supported by the Stripe CLI.", event))
-			}
-
-			fixture, err = BuildFromFixtureFile(fs, apiKey, stripeAccount, baseURL, event, skip, override, add, remove)
-			if err != nil {
-				return nil, err
-			}
-		}
-	} else {
-		fixture, err = BuildFromFixtureString(fs, apiKey, stripeAccount, baseURL, raw)
-		if err != nil {
-			return nil, err
-		}
-	}
-
 	requestNames, err := fixture.Execute(ctx)
 	if err != nil {
-		return nil, fmt.Errorf(fmt.Sprintf("Trigger failed: %s\n", err))
+		return nil, fmt.Errorf("Trigger failed: %w\n", err)
 	}
 
 	return requestNames, nil
 }
 
+// BuildTrigger creates the fixture run by Trigger for an event, without
+// running it
+func BuildTrigger(event string, stripeAccount string, baseURL string, apiKey string, skip, override, add, remove []string, raw string) (*Fixture, error) {
+	fs := afero.NewOsFs()
+
+	if len(raw) != 0 {
+		return BuildFromFixtureString(fs, apiKey, stripeAccount, baseURL, raw)
+	}
+
+	if file, ok := Events[event]; ok {
+		return BuildFromFixtureFile(fs, apiKey, stripeAccount, baseURL, file, skip, override, add, remove)
+	}
+
+	if file, ok := packEvents[event]; ok {
+		return BuildFromFixtureFile(fs, apiKey, stripeAccount, baseURL, file, skip, override, add, remove)
+	}
+
+	exists, _ := afero.Exists(fs, event)
+	if !exists {
+		return nil, fmt.Errorf(fmt.Sprintf("The event ‘%s’ is not supported by the Stripe CLI.", event))
+	}
+
+	return BuildFromFixtureFile(fs, apiKey, stripeAccount, baseURL, event, skip, override, add, remove)
+}
+
 func reverseMap() map[string]string {
 	reversed := make(map[string]string)
 	for name, file := range Events {
@@ -0,0 +1,313 @@
+//go:build docs
+// +build docs
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/stripe/stripe-cli/pkg/spec"
+)
+
+// Reference is the documentation bundled with the CLI for `stripe docs`. It
+// mirrors the types of pkg/docs.
+type Reference struct {
+	APIVersion string                `json:"api_version"`
+	Resources  map[string]*Resource  `json:"resources"`
+	Events     map[string]string     `json:"events"`
+	Operations map[string]*Operation `json:"operations"`
+}
+
+type Resource struct {
+	Description string   `json:"description"`
+	Fields      []*Field `json:"fields"`
+}
+
+type Field struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Nullable    bool   `json:"nullable,omitempty"`
+	Expandable  bool   `json:"expandable,omitempty"`
+}
+
+type Operation struct {
+	Parameters []*Parameter `json:"parameters"`
+}
+
+type Parameter struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+const (
+	pathStripeSpec = "../../api/openapi-spec/spec3.sdk.json"
+
+	pathOutput = "../docs/reference.json"
+)
+
+// eventResources maps the prefixes of the event types that aren't named after
+// the resource of their object.
+var eventResources = map[string]string{
+	"application_fee.refund": "fee_refund",
+	"customer.discount":      "discount",
+	"customer.source":        "source",
+	"source.transaction":     "source_transaction",
+}
+
+func main() {
+	// generate `reference.json` from OpenAPI spec file, to be embedded in
+	// the binary
+	reference, err := getReference()
+	if err != nil {
+		panic(err)
+	}
+
+	data, err := json.Marshal(reference)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("writing %s\n", pathOutput)
+	err = ioutil.WriteFile(pathOutput, data, 0644)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func getReference() (*Reference, error) {
+	api, err := spec.LoadSpec(pathStripeSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	reference := &Reference{
+		APIVersion: api.Info.Version,
+		Resources:  make(map[string]*Resource),
+		Events:     make(map[string]string),
+		Operations: make(map[string]*Operation),
+	}
+
+	// Schemas without a resource ID, like `discount`, are documented when
+	// events are about them
+	schemasByResource := make(map[string]*spec.Schema)
+	for name, schema := range api.Components.Schemas {
+		id := schema.XResourceID
+		if id == "" {
+			id = name
+		}
+		if _, ok := schemasByResource[id]; !ok || schema.XResourceID != "" {
+			schemasByResource[id] = schema
+		}
+	}
+
+	for _, schema := range api.Components.Schemas {
+		if schema.XResourceID == "" || strings.HasPrefix(schema.XResourceID, "deleted_") {
+			continue
+		}
+		reference.Resources[schema.XResourceID] = buildResource(schema)
+	}
+
+	postRequest := api.Paths["/v1/webhook_endpoints"]["post"]
+	requestSchema := postRequest.RequestBody.Content["application/x-www-form-urlencoded"].Schema
+	for _, e := range requestSchema.Properties["enabled_events"].Items.Enum {
+		event := e.(string)
+		if event == "*" {
+			continue
+		}
+
+		resource := eventResource(event, schemasByResource)
+		if resource == "" {
+			fmt.Printf("no resource found for %s\n", event)
+			continue
+		}
+
+		if _, ok := reference.Resources[resource]; !ok {
+			reference.Resources[resource] = buildResource(schemasByResource[resource])
+		}
+		reference.Events[event] = resource
+	}
+
+	for path, verbs := range api.Paths {
+		for verb, operation := range verbs {
+			if operation.Deprecated != nil && *operation.Deprecated {
+				continue
+			}
+			key := fmt.Sprintf("%s %s", strings.ToUpper(string(verb)), path)
+			reference.Operations[key] = buildOperation(operation)
+		}
+	}
+
+	return reference, nil
+}
+
+// buildOperation documents the parameters of an operation: the properties of
+// its request body, or its query string parameters. Descriptions are cut to
+// their first paragraph to keep the reference small.
+func buildOperation(operation *spec.Operation) *Operation {
+	op := &Operation{
+		Parameters: make([]*Parameter, 0),
+	}
+
+	if operation.RequestBody != nil {
+		media, ok := operation.RequestBody.Content["application/x-www-form-urlencoded"]
+		if !ok {
+			media, ok = operation.RequestBody.Content["application/json"]
+		}
+
+		if ok && media.Schema != nil {
+			required := make(map[string]bool)
+			for _, name := range media.Schema.Required {
+				required[name] = true
+			}
+
+			for name, property := range media.Schema.Properties {
+				op.Parameters = append(op.Parameters, &Parameter{
+					Name:        name,
+					Type:        parameterTypeName(property),
+					Description: firstParagraph(property.Description),
+					Required:    required[name],
+				})
+			}
+		}
+	}
+
+	for _, param := range operation.Parameters {
+		if param.In != "query" || param.Schema == nil {
+			continue
+		}
+
+		op.Parameters = append(op.Parameters, &Parameter{
+			Name:        param.Name,
+			Type:        parameterTypeName(param.Schema),
+			Description: firstParagraph(param.Description),
+			Required:    param.Required,
+		})
+	}
+
+	// Required parameters are listed first
+	sort.Slice(op.Parameters, func(i, j int) bool {
+		if op.Parameters[i].Required != op.Parameters[j].Required {
+			return op.Parameters[i].Required
+		}
+		return op.Parameters[i].Name < op.Parameters[j].Name
+	})
+
+	return op
+}
+
+// parameterTypeName describes the type of a parameter like typeName, with the
+// empty string that unsets a parameter written as `""`.
+func parameterTypeName(schema *spec.Schema) string {
+	name := strings.ReplaceAll(typeName(schema), "enum ()", `""`)
+	return strings.ReplaceAll(name, "enum (, ", `enum ("", `)
+}
+
+func firstParagraph(s string) string {
+	return strings.TrimSpace(strings.SplitN(strings.TrimSpace(s), "\n\n", 2)[0])
+}
+
+// eventResource returns the resource of the object of event, e.g.
+// `subscription` for `customer.subscription.created`.
+func eventResource(event string, schemas map[string]*spec.Schema) string {
+	prefix := event[:strings.LastIndex(event, ".")]
+
+	candidates := []string{
+		eventResources[prefix],
+		prefix,
+		// issuing_card.created is about issuing.card
+		strings.Replace(prefix, "_", ".", 1),
+		prefix[strings.LastIndex(prefix, ".")+1:],
+	}
+
+	for _, candidate := range candidates {
+		if _, ok := schemas[candidate]; ok && candidate != "" {
+			return candidate
+		}
+	}
+
+	return ""
+}
+
+func buildResource(schema *spec.Schema) *Resource {
+	resource := &Resource{
+		Description: schema.Description,
+		Fields:      make([]*Field, 0, len(schema.Properties)),
+	}
+
+	expandable := make(map[string]bool)
+	if schema.XExpandableFields != nil {
+		for _, name := range *schema.XExpandableFields {
+			expandable[name] = true
+		}
+	}
+
+	for name, property := range schema.Properties {
+		resource.Fields = append(resource.Fields, &Field{
+			Name:        name,
+			Type:        typeName(property),
+			Description: property.Description,
+			Nullable:    property.Nullable,
+			Expandable:  expandable[name],
+		})
+	}
+
+	sort.Slice(resource.Fields, func(i, j int) bool {
+		return resource.Fields[i].Name < resource.Fields[j].Name
+	})
+
+	return resource
+}
+
+// typeName describes the type of a field, e.g. `string`, `timestamp`,
+// `list of line_item` or `enum (active, canceled)`.
+func typeName(schema *spec.Schema) string {
+	if schema.Ref != "" {
+		return schema.Ref[strings.LastIndex(schema.Ref, "/")+1:]
+	}
+
+	if len(schema.AnyOf) > 0 {
+		// Expandable fields are either an ID or the object, and are described
+		// as the object
+		types := make([]string, 0, len(schema.AnyOf))
+		seen := make(map[string]bool)
+		for _, s := range schema.AnyOf {
+			name := typeName(s)
+			if name == "string" && len(schema.AnyOf) > 1 && schema.XExpansionResources != nil {
+				continue
+			}
+			if !seen[name] {
+				seen[name] = true
+				types = append(types, name)
+			}
+		}
+		return strings.Join(types, " | ")
+	}
+
+	switch {
+	case len(schema.Enum) > 0:
+		values := make([]string, 0, len(schema.Enum))
+		for _, v := range schema.Enum {
+			values = append(values, fmt.Sprint(v))
+		}
+		return fmt.Sprintf("enum (%s)", strings.Join(values, ", "))
+	case schema.Format == "unix-time":
+		return "timestamp"
+	case schema.Type == spec.TypeArray && schema.Items != nil:
+		return "array of " + typeName(schema.Items)
+	case schema.Type == spec.TypeObject && schema.Properties["data"] != nil && schema.Properties["data"].Items != nil:
+		return "list of " + typeName(schema.Properties["data"].Items)
+	case schema.Type == spec.TypeObject:
+		return "hash"
+	case schema.Type == "":
+		return "any"
+	default:
+		return schema.Type
+	}
+}
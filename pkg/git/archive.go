@@ -0,0 +1,22 @@
+package git
+
+import (
+	"strings"
+)
+
+// ArchiveURL returns the URL of a .tar.gz archive of the default branch of a
+// GitHub repo, which can be downloaded over HTTPS where cloning with git
+// isn't possible. It returns false for repos that aren't hosted on GitHub.
+func ArchiveURL(repo string) (string, bool) {
+	path := strings.TrimPrefix(repo, "https://github.com/")
+	if path == repo {
+		return "", false
+	}
+
+	path = strings.TrimSuffix(strings.TrimSuffix(path, "/"), ".git")
+	if strings.Count(path, "/") != 1 {
+		return "", false
+	}
+
+	return "https://github.com/" + path + "/archive/HEAD.tar.gz", true
+}
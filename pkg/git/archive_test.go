@@ -0,0 +1,23 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveURL(t *testing.T) {
+	url, ok := ArchiveURL("https://github.com/stripe-samples/accept-a-payment.git")
+	require.True(t, ok)
+	require.Equal(t, "https://github.com/stripe-samples/accept-a-payment/archive/HEAD.tar.gz", url)
+
+	url, ok = ArchiveURL("https://github.com/stripe-samples/accept-a-payment")
+	require.True(t, ok)
+	require.Equal(t, "https://github.com/stripe-samples/accept-a-payment/archive/HEAD.tar.gz", url)
+
+	_, ok = ArchiveURL("https://gitlab.com/acme/samples.git")
+	require.False(t, ok)
+
+	_, ok = ArchiveURL("git@github.com:stripe-samples/accept-a-payment.git")
+	require.False(t, ok)
+}
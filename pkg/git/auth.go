@@ -0,0 +1,221 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/src-d/go-git.v4/plumbing/transport"
+	githttp "gopkg.in/src-d/go-git.v4/plumbing/transport/http"
+	gitssh "gopkg.in/src-d/go-git.v4/plumbing/transport/ssh"
+)
+
+// AuthError is returned when cloning or pulling a repo fails because git has
+// no credentials for it, or they were refused
+type AuthError struct {
+	Repo string
+	Err  error
+}
+
+func (e AuthError) Error() string {
+	return fmt.Sprintf(`could not authenticate to %s: %v
+Private repositories are cloned with the credentials git uses: set up a git credential helper
+for HTTPS repositories, or add your SSH key to ssh-agent for SSH repositories`, e.Repo, e.Err)
+}
+
+func (e AuthError) Unwrap() error {
+	return e.Err
+}
+
+// NetworkError is returned when cloning or pulling a repo fails because its
+// host couldn't be reached
+type NetworkError struct {
+	Repo string
+	Err  error
+}
+
+func (e NetworkError) Error() string {
+	return fmt.Sprintf("could not reach %s, check your network connection: %v", e.Repo, e.Err)
+}
+
+func (e NetworkError) Unwrap() error {
+	return e.Err
+}
+
+// authOutputRegexp and networkOutputRegexp match the errors the git binary
+// prints when it fails for the lack of credentials, or to reach the host
+var authOutputRegexp = regexp.MustCompile(`(?i)authentication failed|could not read (username|password)|permission denied \(publickey|repository not found|terminal prompts disabled|host key verification failed`)
+var networkOutputRegexp = regexp.MustCompile(`(?i)could not resolve host|failed to connect|connection (refused|timed out|reset)|network is unreachable|operation timed out`)
+
+// classifyError returns an AuthError or a NetworkError for the errors of go-git
+// and of the git binary that are caused by credentials or by the network.
+// Other errors are returned as they are.
+func classifyError(repo string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch {
+	case errors.Is(err, transport.ErrAuthenticationRequired),
+		errors.Is(err, transport.ErrAuthorizationFailed),
+		// Hosts like GitHub report private repos as not found without
+		// credentials
+		errors.Is(err, transport.ErrRepositoryNotFound),
+		strings.Contains(err.Error(), "SSH_AUTH_SOCK"),
+		strings.Contains(err.Error(), "unable to authenticate"),
+		strings.Contains(err.Error(), "knownhosts"),
+		authOutputRegexp.MatchString(err.Error()):
+		return AuthError{Repo: repo, Err: err}
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) || networkOutputRegexp.MatchString(err.Error()) {
+		return NetworkError{Repo: repo, Err: err}
+	}
+
+	return err
+}
+
+// needsAuth returns true if go-git failed because the repo requires
+// credentials, or because ssh-agent isn't running for an SSH repo
+func needsAuth(err error) bool {
+	return errors.Is(err, transport.ErrAuthenticationRequired) ||
+		errors.Is(err, transport.ErrAuthorizationFailed) ||
+		errors.Is(err, transport.ErrRepositoryNotFound) ||
+		(err != nil && strings.Contains(err.Error(), "SSH_AUTH_SOCK"))
+}
+
+// credentials returns the credentials git would use for a repo: those of the
+// git credential helpers for HTTPS repos, and the default SSH keys for SSH
+// repos when ssh-agent, which go-git uses otherwise, isn't running. It
+// returns nil if there are none.
+func credentials(repo string) transport.AuthMethod {
+	endpoint, err := transport.NewEndpoint(repo)
+	if err != nil {
+		return nil
+	}
+
+	switch endpoint.Protocol {
+	case "http", "https":
+		return credentialFill(endpoint)
+	case "ssh":
+		return sshKeyAuth(endpoint.User)
+	default:
+		return nil
+	}
+}
+
+// credentialFill asks the git credential helpers configured by the user for
+// the credentials of an HTTPS repo, like git does, without prompting for
+// them. It returns nil if git isn't installed or has no credentials.
+var credentialFill = func(endpoint *transport.Endpoint) transport.AuthMethod {
+	host := endpoint.Host
+	if endpoint.Port != 0 {
+		host = fmt.Sprintf("%s:%d", endpoint.Host, endpoint.Port)
+	}
+
+	input := fmt.Sprintf("protocol=%s\nhost=%s\npath=%s\n", endpoint.Protocol, host, strings.TrimPrefix(endpoint.Path, "/"))
+	if endpoint.User != "" {
+		input += fmt.Sprintf("username=%s\n", endpoint.User)
+	}
+
+	cmd := exec.Command("git", "credential", "fill")
+	cmd.Stdin = strings.NewReader(input + "\n")
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+
+	return parseCredential(out)
+}
+
+// parseCredential parses the output of `git credential fill`
+func parseCredential(out []byte) transport.AuthMethod {
+	auth := &githttp.BasicAuth{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		switch parts[0] {
+		case "username":
+			auth.Username = parts[1]
+		case "password":
+			auth.Password = parts[1]
+		}
+	}
+
+	if auth.Password == "" {
+		return nil
+	}
+
+	return auth
+}
+
+// sshKeyAuth returns the SSH keys of the user for SSH repos, when ssh-agent
+// isn't running: go-git only uses ssh-agent by default. Keys with a
+// passphrase are skipped, since they can't be used without ssh-agent.
+func sshKeyAuth(user string) transport.AuthMethod {
+	if os.Getenv("SSH_AUTH_SOCK") != "" {
+		return nil
+	}
+
+	if user == "" {
+		user = "git"
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+
+	for _, name := range []string{"id_ed25519", "id_ecdsa", "id_rsa"} {
+		auth, err := gitssh.NewPublicKeysFromFile(user, filepath.Join(home, ".ssh", name), "")
+		if err == nil {
+			return auth
+		}
+	}
+
+	return nil
+}
+
+// withCredentials runs op without credentials, like git for public repos,
+// and runs it again with the credentials of the user if the repo requires
+// them. The error of op is classified with classifyError.
+func withCredentials(repo string, op func(transport.AuthMethod) error) error {
+	err := op(nil)
+	if needsAuth(err) {
+		if auth := credentials(repo); auth != nil {
+			err = op(auth)
+		}
+	}
+
+	return classifyError(redactURL(repo), err)
+}
+
+// redactURL removes the password of a repo URL, for errors
+func redactURL(repo string) string {
+	u, err := url.Parse(repo)
+	if err != nil || u.User == nil {
+		return repo
+	}
+
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), "xxxxx")
+	}
+
+	return u.String()
+}
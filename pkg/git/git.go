@@ -1,7 +1,10 @@
 package git
 
 import (
+	"os"
+
 	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport"
 )
 
 // Operations contains the behaviors of the internal git package
@@ -13,28 +16,51 @@ type Interface interface {
 	Pull(string) error
 }
 
-// Clone clones a repo locally, returns an error if it fails
+// Clone clones a repo locally, returns an error if it fails. Private repos
+// are cloned with the credentials of the user's git credential helpers or
+// SSH agent, and failures caused by credentials or by the network are
+// returned as an AuthError or a NetworkError.
 func (g Operations) Clone(appCachePath, app string) error {
-	_, err := git.PlainClone(appCachePath, false, &git.CloneOptions{
-		URL: app,
-	})
-	if err != nil {
-		return err
-	}
+	return withCredentials(app, func(auth transport.AuthMethod) error {
+		_, err := git.PlainClone(appCachePath, false, &git.CloneOptions{
+			URL:  app,
+			Auth: auth,
+		})
+		if err != nil {
+			// Remove what the failed clone left behind, so it can be
+			// cloned again with credentials
+			os.RemoveAll(appCachePath)
+		}
 
-	return nil
+		return err
+	})
 }
 
 // Pull will update the changes for the provided repo or fails
 func (g Operations) Pull(appCachePath string) error {
+	if isSparse(appCachePath) {
+		return sparsePull(appCachePath)
+	}
+
 	repo, err := git.PlainOpen(appCachePath)
 	if err != nil {
 		return err
 	}
 
-	err = repo.Fetch(&git.FetchOptions{
-		RemoteName: "origin",
-		Force:      true,
+	remote, err := repo.Remote("origin")
+	if err != nil {
+		return err
+	}
+	url := remote.Config().URLs[0]
+
+	var auth transport.AuthMethod
+	err = withCredentials(url, func(a transport.AuthMethod) error {
+		auth = a
+		return repo.Fetch(&git.FetchOptions{
+			RemoteName: "origin",
+			Force:      true,
+			Auth:       auth,
+		})
 	})
 	if err != nil {
 		switch e := err.Error(); e {
@@ -59,9 +85,10 @@ func (g Operations) Pull(appCachePath string) error {
 
 	err = worktree.Pull(&git.PullOptions{
 		Force: true,
+		Auth:  auth,
 	})
 	if err != nil {
-		return err
+		return classifyError(redactURL(url), err)
 	}
 
 	return nil
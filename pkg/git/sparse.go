@@ -0,0 +1,116 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+)
+
+// Sparse is implemented by git backends that can clone a repository without
+// materializing all of its files, and add directories to the working tree
+// later on.
+type Sparse interface {
+	// SparseClone clones a repo locally with only its top-level files checked
+	// out. It returns ErrSparseUnsupported if sparse checkouts aren't
+	// available, in which case Clone should be used instead.
+	SparseClone(string, string) error
+	// SparseCheckout sets the directories checked out in a repo cloned with
+	// SparseClone. It does nothing for repos cloned with Clone.
+	SparseCheckout(string, []string) error
+}
+
+// ErrSparseUnsupported is returned by SparseClone when git isn't installed or
+// is too old to support sparse checkouts
+var ErrSparseUnsupported = errors.New("sparse checkouts require git 2.25 or later")
+
+// minSparseVersion is the first git version with the sparse-checkout command
+var minSparseVersion = [2]int{2, 25}
+
+var gitVersionRegexp = regexp.MustCompile(`git version (\d+)\.(\d+)`)
+
+// SparseClone clones a repo with `git clone --filter=blob:none --sparse`, so
+// only the files that end up checked out are downloaded. Servers that don't
+// support filters send the whole history, and only the checkout is sparse.
+func (g Operations) SparseClone(appCachePath, app string) error {
+	if !supportsSparse() {
+		return ErrSparseUnsupported
+	}
+
+	err := runGit("", "clone", "--quiet", "--filter=blob:none", "--sparse", app, appCachePath)
+	if err != nil {
+		return classifyError(redactURL(app), err)
+	}
+
+	// Older versions of git don't use cone mode for `clone --sparse`, which
+	// `sparse-checkout set` relies on to keep the files of parent directories
+	return runGit(appCachePath, "sparse-checkout", "init", "--cone")
+}
+
+// SparseCheckout checks out dirs, in addition to the top-level files, in a
+// repo cloned with SparseClone.
+func (g Operations) SparseCheckout(appCachePath string, dirs []string) error {
+	if !isSparse(appCachePath) {
+		return nil
+	}
+
+	return runGit(appCachePath, append([]string{"sparse-checkout", "set", "--"}, dirs...)...)
+}
+
+// sparsePull updates a repo cloned with SparseClone. go-git doesn't know about
+// sparse checkouts, and would materialize every file of the repo.
+func sparsePull(appCachePath string) error {
+	err := runGit(appCachePath, "fetch", "--quiet", "--force", "origin")
+	if err != nil {
+		return classifyError(appCachePath, err)
+	}
+
+	return runGit(appCachePath, "reset", "--quiet", "--hard", "@{upstream}")
+}
+
+// isSparse returns true if the repo at path was cloned with SparseClone.
+func isSparse(path string) bool {
+	_, err := os.Stat(filepath.Join(path, ".git", "info", "sparse-checkout"))
+	return err == nil
+}
+
+func supportsSparse() bool {
+	out, err := exec.Command("git", "version").Output()
+	if err != nil {
+		return false
+	}
+
+	match := gitVersionRegexp.FindSubmatch(out)
+	if match == nil {
+		return false
+	}
+
+	major, _ := strconv.Atoi(string(match[1]))
+	minor, _ := strconv.Atoi(string(match[2]))
+
+	return major > minSparseVersion[0] || (major == minSparseVersion[0] && minor >= minSparseVersion[1])
+}
+
+// runGit runs the git binary with args in dir, and returns an error including
+// its output if it fails. git uses the credential helpers and SSH agent of the
+// user, but doesn't prompt for a username and password, which would hang with
+// its output captured.
+func runGit(dir string, args ...string) error {
+	cmd := exec.Command("git", args...) // #nosec G204
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git %s failed: %v: %s", args[0], err, bytes.TrimSpace(output.Bytes()))
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// makeOrigin creates a repo with a sample-like layout to clone from.
+func makeOrigin(t *testing.T) string {
+	origin := filepath.Join(t.TempDir(), "origin")
+
+	files := map[string]string{
+		".cli.json":                        "{}",
+		"README.md":                        "readme",
+		"webhooks/.env.example":            "KEY=",
+		"webhooks/server/node/index.js":    "node",
+		"webhooks/server/ruby/app.rb":      "ruby",
+		"webhooks/client/html/index.html":  "html",
+		"no-webhooks/server/node/index.js": "node",
+	}
+	for path, content := range files {
+		path = filepath.Join(origin, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"config", "uploadpack.allowFilter", "true"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "initial"},
+	} {
+		require.NoError(t, runGit(origin, args...))
+	}
+
+	return origin
+}
+
+func TestSparseClone(t *testing.T) {
+	if !supportsSparse() {
+		t.Skip("git with sparse-checkout support is not installed")
+	}
+
+	origin := makeOrigin(t)
+	clone := filepath.Join(t.TempDir(), "clone")
+
+	g := Operations{}
+
+	require.NoError(t, g.SparseClone(clone, "file://"+origin))
+	require.True(t, isSparse(clone))
+
+	require.FileExists(t, filepath.Join(clone, ".cli.json"))
+	require.FileExists(t, filepath.Join(clone, "README.md"))
+	require.NoDirExists(t, filepath.Join(clone, "webhooks"))
+
+	require.NoError(t, g.SparseCheckout(clone, []string{"webhooks/server/node", "webhooks/client/html"}))
+
+	require.FileExists(t, filepath.Join(clone, "webhooks", ".env.example"))
+	require.FileExists(t, filepath.Join(clone, "webhooks", "server", "node", "index.js"))
+	require.FileExists(t, filepath.Join(clone, "webhooks", "client", "html", "index.html"))
+	require.NoDirExists(t, filepath.Join(clone, "webhooks", "server", "ruby"))
+	require.NoDirExists(t, filepath.Join(clone, "no-webhooks"))
+
+	require.NoError(t, g.Pull(clone))
+	require.NoDirExists(t, filepath.Join(clone, "webhooks", "server", "ruby"))
+}
+
+func TestSparseCheckoutIgnoresFullClones(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	origin := makeOrigin(t)
+
+	require.False(t, isSparse(origin))
+	require.NoError(t, Operations{}.SparseCheckout(origin, []string{"webhooks"}))
+	require.FileExists(t, filepath.Join(origin, "no-webhooks", "server", "node", "index.js"))
+}
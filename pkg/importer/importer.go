@@ -0,0 +1,280 @@
+// Package importer creates prices, coupons and promotion codes in bulk from
+// CSV files, to migrate a catalog from another account or system.
+package importer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/stripe/stripe-cli/pkg/requests"
+)
+
+// Kind is a type of object that can be imported
+type Kind struct {
+	Name string
+	// Path is the API path creating the objects
+	Path string
+	// Required are the parameters every row must have, a value out of each
+	// group
+	Required [][]string
+}
+
+// Kinds are the types of objects that can be imported, by name
+var Kinds = map[string]Kind{
+	"prices": {
+		Name:     "prices",
+		Path:     "/v1/prices",
+		Required: [][]string{{"currency"}, {"product", "product_data[name]"}},
+	},
+	"coupons": {
+		Name:     "coupons",
+		Path:     "/v1/coupons",
+		Required: [][]string{{"percent_off", "amount_off"}},
+	},
+	"promotion_codes": {
+		Name:     "promotion_codes",
+		Path:     "/v1/promotion_codes",
+		Required: [][]string{{"coupon"}},
+	},
+}
+
+// KindNames returns the names of the kinds, sorted
+func KindNames() []string {
+	var names []string
+	for name := range Kinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Row is an object to create, read from a line of the CSV file.
+type Row struct {
+	Line int
+	// Data are the form-encoded parameters of the object, like
+	// unit_amount=1000 or metadata[sku]=A1
+	Data []string
+}
+
+// IdempotencyKey returns the key the object of the row is created with. It's
+// derived from the line and the parameters, so importing the same file again
+// doesn't create the objects twice, while a row fixed after failing is sent
+// with a new key. The API keeps keys for 24 hours.
+func (r Row) IdempotencyKey(kind string) string {
+	sum := sha256.Sum256([]byte(strconv.Itoa(r.Line) + "\n" + strings.Join(r.Data, "\n")))
+
+	return fmt.Sprintf("stripe-cli-import-%s-%s", kind, hex.EncodeToString(sum[:16]))
+}
+
+// ParseMapping parses the column=parameter entries of --map. A column mapped
+// to nothing, like `notes=`, is ignored.
+func ParseMapping(entries []string) (map[string]string, error) {
+	mapping := make(map[string]string)
+
+	for _, entry := range entries {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid mapping ‘%s’, expected column=parameter", entry)
+		}
+		mapping[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+
+	return mapping, nil
+}
+
+// ReadCSV reads the objects of a CSV file. The header line has the names of
+// the parameters, like unit_amount or metadata[sku], or columns renamed to
+// them by mapping. Empty cells aren't sent.
+func ReadCSV(r io.Reader, kind Kind, mapping map[string]string) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("the CSV file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	params := make([]string, len(header))
+	columns := make(map[string]bool)
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		columns[name] = true
+
+		params[i] = name
+		if param, ok := mapping[name]; ok {
+			params[i] = param
+		}
+	}
+
+	var unknown []string
+	for column := range mapping {
+		if !columns[column] {
+			unknown = append(unknown, column)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("the CSV file has no column %s", strings.Join(unknown, ", "))
+	}
+
+	var rows []Row
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row := Row{Line: line}
+		present := make(map[string]bool)
+		for i, value := range record {
+			value = strings.TrimSpace(value)
+			if value == "" || params[i] == "" {
+				continue
+			}
+			row.Data = append(row.Data, params[i]+"="+value)
+			present[params[i]] = true
+		}
+
+		for _, group := range kind.Required {
+			if !anyOf(present, group) {
+				return nil, fmt.Errorf("line %d: missing %s", line, strings.Join(group, " or "))
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("the CSV file has no %s", kind.Name)
+	}
+
+	return rows, nil
+}
+
+func anyOf(present map[string]bool, params []string) bool {
+	for _, param := range params {
+		if present[param] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Result is the outcome of importing a row
+type Result struct {
+	Row Row
+	// ID is the ID of the object created
+	ID  string
+	Err error
+}
+
+// Importer creates the objects of rows.
+type Importer struct {
+	BaseURL string
+	APIKey  string
+	Kind    Kind
+	// Concurrency is the number of objects created at the same time
+	Concurrency int
+
+	// OnResult is called after every row
+	OnResult func(result Result)
+}
+
+// Run creates the objects of rows and returns their results, in the order of
+// the rows. Rows failing to be imported don't stop the run.
+func (im *Importer) Run(ctx context.Context, rows []Row) []Result {
+	results := make([]Result, len(rows))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	jobs := make(chan int)
+
+	for i := 0; i < im.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				result := im.create(ctx, rows[index])
+
+				mu.Lock()
+				results[index] = result
+				if im.OnResult != nil {
+					im.OnResult(result)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i, row := range rows {
+		if ctx.Err() != nil {
+			results[i] = Result{Row: row, Err: ctx.Err()}
+			continue
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+func (im *Importer) create(ctx context.Context, row Row) Result {
+	req := requests.Base{
+		Method:         http.MethodPost,
+		SuppressOutput: true,
+		APIBaseURL:     im.BaseURL,
+	}
+
+	var params requests.RequestParameters
+	params.AppendData(row.Data)
+	params.SetIdempotency(row.IdempotencyKey(im.Kind.Name))
+
+	resp, err := req.MakeRequest(ctx, im.APIKey, im.Kind.Path, &params, true)
+	if err != nil {
+		return Result{Row: row, Err: err}
+	}
+
+	return Result{Row: row, ID: gjson.GetBytes(resp, "id").String()}
+}
+
+// WriteResults writes the results of an import as CSV, with the line, the ID
+// of the object created and the error of each row.
+func WriteResults(w io.Writer, results []Result) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"line", "id", "error"}) // #nosec G104
+
+	for _, result := range results {
+		message := ""
+		if result.Err != nil {
+			message = result.Err.Error()
+		}
+		writer.Write([]string{strconv.Itoa(result.Row.Line), result.ID, message}) // #nosec G104
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
@@ -0,0 +1,109 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCSV(t *testing.T) {
+	mapping, err := ParseMapping([]string{"Price=unit_amount", "SKU = metadata[sku]", "Notes="})
+	require.NoError(t, err)
+
+	file := "product,currency,Price,SKU,Notes\nprod_1,usd,1000,A1,legacy\nprod_2,eur,,B2,\n"
+	rows, err := ReadCSV(strings.NewReader(file), Kinds["prices"], mapping)
+	require.NoError(t, err)
+	require.Equal(t, []Row{
+		{Line: 2, Data: []string{"product=prod_1", "currency=usd", "unit_amount=1000", "metadata[sku]=A1"}},
+		{Line: 3, Data: []string{"product=prod_2", "currency=eur", "metadata[sku]=B2"}},
+	}, rows)
+}
+
+func TestReadCSVErrors(t *testing.T) {
+	_, err := ParseMapping([]string{"unit_amount"})
+	require.EqualError(t, err, "invalid mapping ‘unit_amount’, expected column=parameter")
+
+	_, err = ReadCSV(strings.NewReader(""), Kinds["coupons"], nil)
+	require.EqualError(t, err, "the CSV file is empty")
+
+	_, err = ReadCSV(strings.NewReader("percent_off\n"), Kinds["coupons"], nil)
+	require.EqualError(t, err, "the CSV file has no coupons")
+
+	_, err = ReadCSV(strings.NewReader("coupon\nSUMMER\n"), Kinds["promotion_codes"], map[string]string{"Code": "code"})
+	require.EqualError(t, err, "the CSV file has no column Code")
+
+	_, err = ReadCSV(strings.NewReader("currency,product_data[name]\nusd,Pro\nusd,\n"), Kinds["prices"], nil)
+	require.EqualError(t, err, "line 3: missing product or product_data[name]")
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	row := Row{Line: 2, Data: []string{"percent_off=10"}}
+
+	require.Equal(t, row.IdempotencyKey("coupons"), row.IdempotencyKey("coupons"))
+	require.True(t, strings.HasPrefix(row.IdempotencyKey("coupons"), "stripe-cli-import-coupons-"))
+	require.NotEqual(t, row.IdempotencyKey("coupons"), Row{Line: 3, Data: row.Data}.IdempotencyKey("coupons"))
+	require.NotEqual(t, row.IdempotencyKey("coupons"), Row{Line: 2, Data: []string{"percent_off=15"}}.IdempotencyKey("coupons"))
+}
+
+func TestRun(t *testing.T) {
+	var mu sync.Mutex
+	keys := make(map[string]string)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/coupons", r.URL.Path)
+		r.ParseForm()
+
+		mu.Lock()
+		keys[r.Form.Get("id")] = r.Header.Get("Idempotency-Key")
+		mu.Unlock()
+
+		if r.Form.Get("percent_off") == "200" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": {"message": "Invalid percent_off"}}`))
+			return
+		}
+		w.Write([]byte(`{"id": "` + r.Form.Get("id") + `"}`))
+	}))
+	defer ts.Close()
+
+	rows := []Row{
+		{Line: 2, Data: []string{"id=SUMMER", "percent_off=10"}},
+		{Line: 3, Data: []string{"id=BROKEN", "percent_off=200"}},
+		{Line: 4, Data: []string{"id=WINTER", "percent_off=20"}},
+	}
+
+	var called []int
+	im := &Importer{
+		BaseURL:     ts.URL,
+		APIKey:      "sk_test_123",
+		Kind:        Kinds["coupons"],
+		Concurrency: 2,
+		OnResult: func(result Result) {
+			called = append(called, result.Row.Line)
+		},
+	}
+
+	results := im.Run(context.Background(), rows)
+	require.Len(t, results, 3)
+	require.ElementsMatch(t, []int{2, 3, 4}, called)
+
+	require.Equal(t, "SUMMER", results[0].ID)
+	require.Error(t, results[1].Err)
+	require.Equal(t, "WINTER", results[2].ID)
+	require.Equal(t, rows[0].IdempotencyKey("coupons"), keys["SUMMER"])
+
+	var out bytes.Buffer
+	require.NoError(t, WriteResults(&out, results))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Equal(t, "line,id,error", lines[0])
+	require.Equal(t, "2,SUMMER,", lines[1])
+	require.True(t, strings.HasPrefix(lines[2], "3,,"))
+	require.Equal(t, "4,WINTER,", lines[3])
+}
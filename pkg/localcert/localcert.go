@@ -0,0 +1,137 @@
+// Package localcert generates self-signed certificates for servers running on
+// the local machine, and caches them so they only need to be trusted once.
+package localcert
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+)
+
+// CachedValidity is how long the certificates cached by LoadOrGenerate are
+// valid for
+const CachedValidity = 365 * 24 * time.Hour
+
+// renewBefore is how long before it expires a cached certificate is replaced
+const renewBefore = 30 * 24 * time.Hour
+
+const (
+	certFile = "cert.pem"
+	keyFile  = "key.pem"
+)
+
+// Generate returns a self-signed certificate for localhost, 127.0.0.1 and
+// ::1, valid for validity, as the PEM of the certificate and of its key.
+func Generate(validity time.Duration) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"Stripe CLI"}, CommonName: "localhost"},
+		NotBefore:             now.Add(-time.Minute),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv6loopback, net.IPv4(127, 0, 0, 1)},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return certPEM, keyPEM, nil
+}
+
+// LoadOrGenerate returns the certificate cached in dir, generating and caching
+// a new one if there's none or if it expires soon. It also returns the path
+// of the certificate, for users to trust it, and whether it was generated.
+func LoadOrGenerate(dir string) (tls.Certificate, string, bool, error) {
+	certPath := filepath.Join(dir, certFile)
+	keyPath := filepath.Join(dir, keyFile)
+
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err == nil && !expiresSoon(cert) {
+		return cert, certPath, false, nil
+	}
+
+	certPEM, keyPEM, err := Generate(CachedValidity)
+	if err != nil {
+		return tls.Certificate{}, "", false, err
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return tls.Certificate{}, "", false, err
+	}
+	if err := ioutil.WriteFile(keyPath, keyPEM, 0600); err != nil {
+		return tls.Certificate{}, "", false, err
+	}
+	if err := ioutil.WriteFile(certPath, certPEM, 0644); err != nil { // #nosec G306
+		return tls.Certificate{}, "", false, err
+	}
+
+	cert, err = tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return tls.Certificate{}, "", false, err
+	}
+
+	return cert, certPath, true, nil
+}
+
+// TrustCommand returns the command trusting a certificate for the current
+// user, so that browsers accept it
+func TrustCommand(certPath string) string {
+	switch runtime.GOOS {
+	case "darwin":
+		return "security add-trusted-cert -r trustRoot -k ~/Library/Keychains/login.keychain-db " + certPath
+	case "windows":
+		return "certutil -user -addstore Root " + certPath
+	default:
+		return "sudo cp " + certPath + " /usr/local/share/ca-certificates/stripe-cli.crt && sudo update-ca-certificates"
+	}
+}
+
+// expiresSoon returns whether a certificate expires within renewBefore
+func expiresSoon(cert tls.Certificate) bool {
+	if len(cert.Certificate) == 0 {
+		return true
+	}
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return true
+	}
+
+	return time.Now().Add(renewBefore).After(leaf.NotAfter)
+}
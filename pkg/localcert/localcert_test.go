@@ -0,0 +1,59 @@
+package localcert
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	certPEM, keyPEM, err := Generate(time.Hour)
+	require.NoError(t, err)
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	require.NoError(t, leaf.VerifyHostname("localhost"))
+	require.NoError(t, leaf.VerifyHostname("127.0.0.1"))
+	require.NoError(t, leaf.VerifyHostname("::1"))
+	require.WithinDuration(t, time.Now().Add(time.Hour), leaf.NotAfter, time.Minute)
+}
+
+func TestLoadOrGenerateCaches(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "serve-cert")
+
+	cert, certPath, generated, err := LoadOrGenerate(dir)
+	require.NoError(t, err)
+	require.True(t, generated)
+	require.Equal(t, filepath.Join(dir, "cert.pem"), certPath)
+
+	cached, _, generated, err := LoadOrGenerate(dir)
+	require.NoError(t, err)
+	require.False(t, generated)
+	require.Equal(t, cert.Certificate, cached.Certificate)
+}
+
+func TestLoadOrGenerateRenews(t *testing.T) {
+	dir := t.TempDir()
+
+	// A certificate expiring within renewBefore is replaced
+	certPEM, keyPEM, err := Generate(time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "cert.pem"), certPEM, 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "key.pem"), keyPEM, 0600))
+
+	_, _, generated, err := LoadOrGenerate(dir)
+	require.NoError(t, err)
+	require.True(t, generated)
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, "cert.pem"))
+	require.NoError(t, err)
+	require.NotEqual(t, certPEM, content)
+}
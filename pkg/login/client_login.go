@@ -3,12 +3,14 @@ package login
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"os"
+	"time"
 
 	"github.com/briandowns/spinner"
 
@@ -24,6 +26,9 @@ var canOpenBrowser = open.CanOpenBrowser
 
 const stripeCLIAuthPath = "/stripecli/auth"
 
+// KeyValidity is how long the keys obtained with `stripe login` are valid
+const KeyValidity = 90 * 24 * time.Hour
+
 // Links provides the URLs for the CLI to continue the login flow
 type Links struct {
 	BrowserURL       string `json:"browser_url"`
@@ -41,6 +46,30 @@ type Links struct {
 
 // Login function is used to obtain credentials via stripe dashboard.
 func Login(ctx context.Context, baseURL string, config *config.Config, input io.Reader) error {
+	return login(ctx, baseURL, config, input, false)
+}
+
+// Refresh renews the keys of a profile that's logged in, before they expire.
+// The browser is opened right away, so that a dashboard session that's still
+// signed in only has to confirm the pairing code.
+func Refresh(ctx context.Context, baseURL string, config *config.Config) error {
+	if _, err := config.Profile.GetAPIKey(false); err != nil {
+		return errors.New("this profile is not logged in, run `stripe login` to log in")
+	}
+
+	return login(ctx, baseURL, config, os.Stdin, true)
+}
+
+func login(ctx context.Context, baseURL string, config *config.Config, input io.Reader, refresh bool) error {
+	previousAccountID, _ := config.Profile.GetAccountID()
+
+	// The sandbox is set with --sandbox, or kept when the keys are renewed
+	sandboxName := config.Profile.SandboxName
+	if sandboxID, _ := config.Profile.GetSandbox(); sandboxName == "" && refresh {
+		sandboxName = sandboxID
+	}
+	config.Profile.SandboxID, config.Profile.SandboxName = "", ""
+
 	links, err := GetLinks(ctx, baseURL, config.Profile.DeviceName)
 	if err != nil {
 		return err
@@ -57,8 +86,12 @@ func Login(ctx context.Context, baseURL string, config *config.Config, input io.
 
 		s = ansi.StartNewSpinner("Waiting for confirmation...", os.Stdout)
 	} else {
-		fmt.Printf("Press Enter to open the browser or visit %s (^C to quit)", links.BrowserURL)
-		fmt.Fscanln(input)
+		if refresh {
+			fmt.Printf("Opening the browser to renew your keys, or visit %s (^C to quit)\n", links.BrowserURL)
+		} else {
+			fmt.Printf("Press Enter to open the browser or visit %s (^C to quit)", links.BrowserURL)
+			fmt.Fscanln(input)
+		}
 
 		s = ansi.StartNewSpinner("Waiting for confirmation...", os.Stdout)
 
@@ -75,6 +108,23 @@ func Login(ctx context.Context, baseURL string, config *config.Config, input io.
 		return err
 	}
 
+	// The spinner is stopped while a sandbox is prompted
+	var sandbox *Sandbox
+	if sandboxName == "" && len(response.Sandboxes) > 0 {
+		ansi.StopSpinner(s, "", os.Stdout)
+		sandbox, err = selectSandbox(response.Sandboxes, sandboxName)
+		s = ansi.StartNewSpinner("Configuring the profile...", os.Stdout)
+	} else {
+		sandbox, err = selectSandbox(response.Sandboxes, sandboxName)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := useSandbox(config, response, sandbox); err != nil {
+		return err
+	}
+
 	err = ConfigureProfile(config, response)
 	if err != nil {
 		return err
@@ -87,7 +137,17 @@ func Login(ctx context.Context, baseURL string, config *config.Config, input io.
 	}
 
 	ansi.StopSpinner(s, message, os.Stdout)
-	fmt.Println(ansi.Italic("Please note: this key will expire after 90 days, at which point you'll need to re-authenticate."))
+
+	if sandbox != nil {
+		fmt.Printf("The test mode keys of this profile belong to the sandbox %s (%s)\n", color.Bold(sandbox.Name), sandbox.ID)
+	}
+
+	if refresh && previousAccountID != "" && previousAccountID != response.AccountID {
+		fmt.Printf("%s the profile was logged in to %s and is now logged in to %s\n", color.Yellow("Warning:"), previousAccountID, response.AccountID)
+	}
+
+	fmt.Println(ansi.Italic(fmt.Sprintf("Please note: this key will expire on %s, at which point you'll need to run `stripe login --refresh`.",
+		config.Profile.KeyExpiresAt.Local().Format("January 2, 2006"))))
 	return nil
 }
 
@@ -104,6 +164,7 @@ func ConfigureProfile(config *config.Config, response *PollAPIKeyResponse) error
 	config.Profile.TestModePublishableKey = response.TestModePublishableKey
 	config.Profile.DisplayName = response.AccountDisplayName
 	config.Profile.AccountID = response.AccountID
+	config.Profile.KeyExpiresAt = time.Now().Add(KeyValidity)
 
 	profileErr := config.Profile.CreateProfile()
 	if profileErr != nil {
@@ -157,3 +157,18 @@ func TestGetLinksParseError(t *testing.T) {
 	require.EqualError(t, err, "json: cannot unmarshal number into Go struct field Links.browser_url of type string")
 	require.Empty(t, links)
 }
+
+func TestRefreshNotLoggedIn(t *testing.T) {
+	t.Setenv("STRIPE_API_KEY", "")
+
+	viper.Reset()
+	viper.SetConfigFile(filepath.Join(t.TempDir(), "config.toml"))
+	defer viper.Reset()
+
+	c := &config.Config{
+		Profile: config.Profile{ProfileName: "tests"},
+	}
+
+	err := Refresh(context.Background(), "http://127.0.0.1:0", c)
+	require.EqualError(t, err, "this profile is not logged in, run `stripe login` to log in")
+}
@@ -0,0 +1,220 @@
+package login
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/stripeauth"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+// keyScope is the API access a command needs, checked by listing the
+// resources it reads or creates, which has no side effect. Feature is the
+// scope of the sessions the command authorizes, if any.
+type keyScope struct {
+	Command string
+	Feature string
+	Paths   []string
+}
+
+// keyScopes are the scopes of the commands that restricted keys are checked
+// for with `stripe login --with-key --validate`
+var keyScopes = []keyScope{
+	{"listen", stripeauth.FeatureWebhooks, []string{"/v1/events"}},
+	{"logs tail", stripeauth.FeatureRequestLogs, []string{"/v1/events"}},
+	{"trigger", "", []string{"/v1/customers", "/v1/payment_intents", "/v1/charges"}},
+}
+
+// KeyCheck is the result of validating an API key against the API
+type KeyCheck struct {
+	Account    *Account
+	Livemode   bool
+	Restricted bool
+
+	// MissingScopes are the commands a restricted key lacks access for
+	MissingScopes []string
+
+	// Features are the session scopes a restricted key has
+	Features []string
+}
+
+// KeyLogin configures the profile with an API key rather than logging in with
+// the browser, e.g. in CI. With validate, the key is checked against the API
+// first, and restricted keys are checked for the scopes of the commands that
+// need them.
+func KeyLogin(ctx context.Context, baseURL string, cfg *config.Config, apiKey string, validate bool) error {
+	apiKey = strings.TrimSpace(apiKey)
+	if err := validators.APIKey(apiKey); err != nil {
+		return err
+	}
+
+	var account *Account
+	var features []string
+	if validate {
+		check, err := CheckKey(ctx, baseURL, apiKey)
+		if err != nil {
+			return err
+		}
+
+		printKeyCheck(check)
+		if len(check.MissingScopes) > 0 {
+			return fmt.Errorf("the restricted key lacks the permissions needed by %s", strings.Join(check.MissingScopes, ", "))
+		}
+
+		account = check.Account
+		features = check.Features
+	} else {
+		// The display name is only a nicety without --validate
+		account, _ = GetUserAccount(ctx, baseURL, apiKey)
+	}
+
+	if stripe.IsLiveKey(apiKey) {
+		cfg.Profile.LiveModeAPIKey = apiKey
+		cfg.Profile.LiveModeKeyScopes = features
+	} else {
+		cfg.Profile.TestModeAPIKey = apiKey
+		cfg.Profile.TestModeKeyScopes = features
+	}
+
+	if account != nil {
+		cfg.Profile.AccountID = account.ID
+		cfg.Profile.DisplayName = account.Settings.Dashboard.DisplayName
+	}
+
+	if err := cfg.Profile.CreateProfile(); err != nil {
+		return err
+	}
+
+	message, err := SuccessMessage(ctx, account, baseURL, apiKey)
+	if err != nil {
+		fmt.Printf("> Error verifying the CLI was setup successfully: %s\n", err)
+	} else {
+		fmt.Printf("> %s", message)
+	}
+
+	return nil
+}
+
+// CheckKey verifies an API key against the API: that it's accepted, whether
+// it's a live, test or restricted key, and for restricted keys, which scopes
+// of keyScopes it lacks
+func CheckKey(ctx context.Context, baseURL string, apiKey string) (*KeyCheck, error) {
+	parsedBaseURL, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &stripe.Client{
+		BaseURL: parsedBaseURL,
+		APIKey:  apiKey,
+	}
+
+	check := &KeyCheck{
+		Account:    &Account{},
+		Livemode:   stripe.IsLiveKey(apiKey),
+		Restricted: strings.HasPrefix(apiKey, "rk_"),
+	}
+
+	status, err := getStatus(ctx, client, "/v1/account", check.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case status == http.StatusUnauthorized:
+		return nil, fmt.Errorf("the API key was rejected by Stripe, it may have been rolled or deleted")
+	case status == http.StatusForbidden && check.Restricted:
+		// Restricted keys may not read the account, which only costs the
+		// display name
+	case status != http.StatusOK:
+		return nil, fmt.Errorf("could not validate the API key, the API responded with status %d", status)
+	}
+
+	if !check.Restricted {
+		return check, nil
+	}
+
+	check.Features = []string{}
+	allowed := make(map[string]bool)
+scopes:
+	for _, scope := range keyScopes {
+		for _, path := range scope.Paths {
+			if _, checked := allowed[path]; !checked {
+				status, err := getStatus(ctx, client, path+"?limit=1", nil)
+				if err != nil {
+					return nil, err
+				}
+				allowed[path] = status != http.StatusForbidden
+			}
+
+			if !allowed[path] {
+				check.MissingScopes = append(check.MissingScopes, scope.Command)
+				continue scopes
+			}
+		}
+
+		if scope.Feature != "" {
+			check.Features = append(check.Features, scope.Feature)
+		}
+	}
+
+	return check, nil
+}
+
+// getStatus sends a GET request and returns the status of the response,
+// decoding its body into v if it's successful and v is set
+func getStatus(ctx context.Context, client *stripe.Client, path string, v interface{}) (int, error) {
+	resp, err := client.PerformRequest(ctx, http.MethodGet, path, "", nil)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK && v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			return 0, err
+		}
+	}
+
+	return resp.StatusCode, nil
+}
+
+func printKeyCheck(check *KeyCheck) {
+	color := ansi.Color(os.Stdout)
+
+	mode := "test mode"
+	if check.Livemode {
+		mode = "live mode"
+	}
+
+	kind := "secret"
+	if check.Restricted {
+		kind = "restricted"
+	}
+
+	fmt.Printf("%s The API key is a valid %s %s key\n", color.Green("✔"), mode, kind)
+	if !check.Restricted {
+		return
+	}
+
+	missing := make(map[string]bool)
+	for _, command := range check.MissingScopes {
+		missing[command] = true
+	}
+
+	for _, scope := range keyScopes {
+		if missing[scope.Command] {
+			fmt.Printf("%s It can't be used with `stripe %s`\n", color.Red("✘"), scope.Command)
+		} else {
+			fmt.Printf("%s It can be used with `stripe %s`\n", color.Green("✔"), scope.Command)
+		}
+	}
+}
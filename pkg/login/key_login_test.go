@@ -0,0 +1,95 @@
+package login
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+)
+
+// newKeyServer returns a server that accepts the keys of allowed, and answers
+// with 403 to the paths of forbidden
+func newKeyServer(t *testing.T, allowed map[string]bool, forbidden map[string]bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+
+		key := r.Header.Get("Authorization")[len("Bearer "):]
+		switch {
+		case !allowed[key]:
+			w.WriteHeader(http.StatusUnauthorized)
+		case forbidden[r.URL.Path]:
+			w.WriteHeader(http.StatusForbidden)
+		case r.URL.Path == "/v1/account":
+			account := &Account{ID: "acct_123"}
+			account.Settings.Dashboard.DisplayName = testName
+			json.NewEncoder(w).Encode(account)
+		default:
+			w.Write([]byte(`{"object": "list", "data": []}`))
+		}
+	}))
+}
+
+func TestCheckKey(t *testing.T) {
+	ts := newKeyServer(t, map[string]bool{
+		"sk_test_1234567890abcdefghij": true,
+		"rk_live_1234567890abcdefghij": true,
+	}, map[string]bool{
+		"/v1/account":   true,
+		"/v1/customers": true,
+	})
+	defer ts.Close()
+
+	check, err := CheckKey(context.Background(), ts.URL, "sk_test_1234567890abcdefghij")
+	require.Error(t, err, "secret keys that can't read the account are invalid")
+	require.Nil(t, check)
+
+	check, err = CheckKey(context.Background(), ts.URL, "rk_live_1234567890abcdefghij")
+	require.NoError(t, err)
+	require.True(t, check.Livemode)
+	require.True(t, check.Restricted)
+	require.Equal(t, []string{"trigger"}, check.MissingScopes)
+	require.Equal(t, []string{"webhooks", "request_logs"}, check.Features)
+
+	_, err = CheckKey(context.Background(), ts.URL, "sk_test_rolled7890abcdefghij")
+	require.EqualError(t, err, "the API key was rejected by Stripe, it may have been rolled or deleted")
+}
+
+func TestKeyLogin(t *testing.T) {
+	profilesFile := filepath.Join(t.TempDir(), "config.toml")
+	viper.Reset()
+	viper.SetConfigFile(profilesFile)
+	defer viper.Reset()
+
+	ts := newKeyServer(t, map[string]bool{
+		"sk_test_1234567890abcdefghij": true,
+		"rk_test_1234567890abcdefghij": true,
+	}, map[string]bool{
+		"/v1/events": true,
+	})
+	defer ts.Close()
+
+	c := &config.Config{
+		Profile: config.Profile{ProfileName: "ci"},
+	}
+
+	err := KeyLogin(context.Background(), ts.URL, c, "rk_test_1234567890abcdefghij", true)
+	require.EqualError(t, err, "the restricted key lacks the permissions needed by listen, logs tail")
+
+	err = KeyLogin(context.Background(), ts.URL, c, "sk_test_1234567890abcdefghij\n", true)
+	require.NoError(t, err)
+
+	v := viper.New()
+	v.SetConfigFile(profilesFile)
+	require.NoError(t, v.ReadInConfig())
+	require.Equal(t, "sk_test_1234567890abcdefghij", v.GetString("ci.test_mode_api_key"))
+	require.Equal(t, "acct_123", v.GetString("ci.account_id"))
+	require.Equal(t, testName, v.GetString("ci.display_name"))
+	require.False(t, v.IsSet("ci.test_mode_key_scopes"), "secret keys have every scope")
+}
@@ -25,6 +25,10 @@ type PollAPIKeyResponse struct {
 	LiveModePublishableKey string `json:"livemode_key_publishable"`
 	TestModeAPIKey         string `json:"testmode_key_secret"`
 	TestModePublishableKey string `json:"testmode_key_publishable"`
+
+	// Sandboxes are the sandboxes of the account the user can pick instead
+	// of its test mode
+	Sandboxes []Sandbox `json:"sandboxes"`
 }
 
 // PollForKey polls Stripe at the specified interval until either the API key is available or we've reached the max attempts.
@@ -0,0 +1,101 @@
+package login
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+	"golang.org/x/term"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+)
+
+// Sandbox is a sandbox of the account logged in to, whose test mode keys the
+// profile can use rather than the test mode keys of the account
+type Sandbox struct {
+	ID                     string `json:"id"`
+	Name                   string `json:"name"`
+	TestModeAPIKey         string `json:"testmode_key_secret"`
+	TestModePublishableKey string `json:"testmode_key_publishable"`
+}
+
+// accountTestMode is the prompt item of the test mode of the account itself
+const accountTestMode = "Test mode of the account"
+
+// promptSandbox prompts which of the sandboxes the profile uses, returning -1
+// for the test mode of the account. It's replaced in tests.
+var promptSandbox = func(sandboxes []Sandbox) (int, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return -1, nil
+	}
+
+	items := []string{accountTestMode}
+	for _, sandbox := range sandboxes {
+		items = append(items, sandbox.Name)
+	}
+
+	prompt := promptui.Select{
+		Label: "Which sandbox should the CLI use",
+		Items: items,
+	}
+
+	i, _, err := prompt.Run()
+	if err != nil {
+		return -1, err
+	}
+
+	return i - 1, nil
+}
+
+// selectSandbox returns the sandbox named name, by its name or ID, or else
+// prompts for one if the account has sandboxes. It returns nil for the test
+// mode of the account.
+func selectSandbox(sandboxes []Sandbox, name string) (*Sandbox, error) {
+	if name == "" {
+		if len(sandboxes) == 0 {
+			return nil, nil
+		}
+
+		i, err := promptSandbox(sandboxes)
+		if err != nil || i < 0 {
+			return nil, err
+		}
+
+		return &sandboxes[i], nil
+	}
+
+	names := make([]string, 0, len(sandboxes))
+	for i, sandbox := range sandboxes {
+		if sandbox.Name == name || sandbox.ID == name {
+			return &sandboxes[i], nil
+		}
+		names = append(names, sandbox.Name)
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("the account has no sandbox named ‘%s’", name)
+	}
+
+	return nil, fmt.Errorf("the account has no sandbox named ‘%s’, its sandboxes are: %s", name, strings.Join(names, ", "))
+}
+
+// useSandbox makes the profile use the test mode keys of sandbox rather than
+// the keys of the account
+func useSandbox(cfg *config.Config, response *PollAPIKeyResponse, sandbox *Sandbox) error {
+	if sandbox == nil {
+		return nil
+	}
+
+	if sandbox.TestModeAPIKey == "" {
+		return errors.New("the keys of the sandbox were not returned, log in again")
+	}
+
+	response.TestModeAPIKey = sandbox.TestModeAPIKey
+	response.TestModePublishableKey = sandbox.TestModePublishableKey
+	cfg.Profile.SandboxID = sandbox.ID
+	cfg.Profile.SandboxName = sandbox.Name
+
+	return nil
+}
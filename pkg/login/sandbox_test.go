@@ -0,0 +1,62 @@
+package login
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+)
+
+var testSandboxes = []Sandbox{
+	{ID: "acct_sb1", Name: "staging", TestModeAPIKey: "sk_test_staging"},
+	{ID: "acct_sb2", Name: "qa", TestModeAPIKey: "sk_test_qa"},
+}
+
+func TestSelectSandbox(t *testing.T) {
+	sandbox, err := selectSandbox(testSandboxes, "qa")
+	require.NoError(t, err)
+	require.Equal(t, "acct_sb2", sandbox.ID)
+
+	sandbox, err = selectSandbox(testSandboxes, "acct_sb1")
+	require.NoError(t, err)
+	require.Equal(t, "staging", sandbox.Name)
+
+	_, err = selectSandbox(testSandboxes, "prod")
+	require.EqualError(t, err, "the account has no sandbox named ‘prod’, its sandboxes are: staging, qa")
+
+	_, err = selectSandbox(nil, "prod")
+	require.EqualError(t, err, "the account has no sandbox named ‘prod’")
+
+	sandbox, err = selectSandbox(nil, "")
+	require.NoError(t, err)
+	require.Nil(t, sandbox)
+}
+
+func TestSelectSandboxPrompt(t *testing.T) {
+	defer func(prompt func([]Sandbox) (int, error)) { promptSandbox = prompt }(promptSandbox)
+
+	promptSandbox = func(sandboxes []Sandbox) (int, error) { return 1, nil }
+	sandbox, err := selectSandbox(testSandboxes, "")
+	require.NoError(t, err)
+	require.Equal(t, "qa", sandbox.Name)
+
+	// The test mode of the account
+	promptSandbox = func(sandboxes []Sandbox) (int, error) { return -1, nil }
+	sandbox, err = selectSandbox(testSandboxes, "")
+	require.NoError(t, err)
+	require.Nil(t, sandbox)
+}
+
+func TestUseSandbox(t *testing.T) {
+	c := &config.Config{}
+	response := &PollAPIKeyResponse{TestModeAPIKey: "sk_test_account"}
+
+	require.NoError(t, useSandbox(c, response, nil))
+	require.Equal(t, "sk_test_account", response.TestModeAPIKey)
+
+	require.NoError(t, useSandbox(c, response, &testSandboxes[0]))
+	require.Equal(t, "sk_test_staging", response.TestModeAPIKey)
+	require.Equal(t, "acct_sb1", c.Profile.SandboxID)
+	require.Equal(t, "staging", c.Profile.SandboxName)
+}
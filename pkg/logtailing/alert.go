@@ -0,0 +1,238 @@
+package logtailing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stripe/stripe-cli/pkg/shell"
+)
+
+// alertTimeout bounds how long an alert command or webhook may take
+const alertTimeout = 30 * time.Second
+
+// thresholdPattern matches thresholds like 5xx>10/min, 402>3/30s or 4xx>100/1h
+var thresholdPattern = regexp.MustCompile(`^([1-5](?:xx|XX|\d\d))>(\d+)/(\w+)$`)
+
+// Threshold is an error rate: more than Count request logs matching Status
+// during Window
+type Threshold struct {
+	// Status is a status code like 402, or a class of status codes like 5xx
+	Status string
+	Count  int
+	Window time.Duration
+}
+
+// ParseThreshold parses a threshold like 5xx>10/min. The window is a unit,
+// sec, min or hour, or a duration like 30s or 5m.
+func ParseThreshold(threshold string) (Threshold, error) {
+	invalid := fmt.Errorf("invalid alert threshold ‘%s’, expected a status, a count and a window like 5xx>10/min or 402>3/30s", threshold)
+
+	groups := thresholdPattern.FindStringSubmatch(strings.ReplaceAll(threshold, " ", ""))
+	if groups == nil {
+		return Threshold{}, invalid
+	}
+
+	count, err := strconv.Atoi(groups[2])
+	if err != nil {
+		return Threshold{}, invalid
+	}
+
+	var window time.Duration
+	switch groups[3] {
+	case "s", "sec":
+		window = time.Second
+	case "m", "min":
+		window = time.Minute
+	case "h", "hour":
+		window = time.Hour
+	default:
+		window, err = time.ParseDuration(groups[3])
+		if err != nil || window <= 0 {
+			return Threshold{}, invalid
+		}
+	}
+
+	return Threshold{Status: strings.ToLower(groups[1]), Count: count, Window: window}, nil
+}
+
+// matches returns whether a status counts towards the threshold
+func (t Threshold) matches(status int) bool {
+	s := strconv.Itoa(status)
+	if strings.HasSuffix(t.Status, "xx") {
+		return strings.HasPrefix(s, t.Status[:1])
+	}
+
+	return s == t.Status
+}
+
+// String returns the threshold as written on the command line
+func (t Threshold) String() string {
+	return fmt.Sprintf("%s>%d/%s", t.Status, t.Count, t.Window)
+}
+
+// Alert describes a breached threshold, as posted to the alert webhook
+type Alert struct {
+	Threshold string    `json:"threshold"`
+	Count     int       `json:"count"`
+	Window    string    `json:"window"`
+	Last      Log       `json:"last"`
+	FiredAt   time.Time `json:"fired_at"`
+}
+
+// Alerter tracks the request logs matching a threshold over a sliding window,
+// and runs a command or posts to a webhook when the threshold is breached.
+// Once fired, an alert doesn't fire again until a full window has passed.
+// Windows are measured with the creation time of the request logs, so that
+// the recent logs printed with --since count as when they were made.
+type Alerter struct {
+	threshold Threshold
+	command   string
+	webhook   string
+
+	// Errors of the alert command or webhook are reported to onError
+	onError func(error)
+
+	mu       sync.Mutex
+	times    []time.Time
+	firedAt  time.Time
+	fire     func(Alert)
+	inflight sync.WaitGroup
+}
+
+// NewAlerter returns an Alerter for threshold, running command through the
+// shell, with the alert as JSON on its stdin, and posting the alert to
+// webhook, either of which can be empty.
+func NewAlerter(threshold Threshold, command, webhook string, onError func(error)) *Alerter {
+	a := &Alerter{
+		threshold: threshold,
+		command:   command,
+		webhook:   webhook,
+		onError:   onError,
+	}
+	a.fire = a.notify
+
+	return a
+}
+
+// Observe records a request log, and fires the alert if the threshold is
+// breached. It returns whether the alert fired.
+func (a *Alerter) Observe(log Log) bool {
+	if !a.threshold.matches(log.Status) {
+		return false
+	}
+
+	a.mu.Lock()
+
+	at := log.CreatedAt
+	cutoff := at.Add(-a.threshold.Window)
+
+	// Logs are received in order, so the expired ones are at the start
+	i := 0
+	for i < len(a.times) && !a.times[i].After(cutoff) {
+		i++
+	}
+	a.times = append(a.times[i:], at)
+
+	if len(a.times) <= a.threshold.Count || a.firedAt.After(cutoff) {
+		a.mu.Unlock()
+		return false
+	}
+
+	a.firedAt = at
+	alert := Alert{
+		Threshold: a.threshold.String(),
+		Count:     len(a.times),
+		Window:    a.threshold.Window.String(),
+		Last:      log,
+		FiredAt:   time.Now(),
+	}
+
+	a.mu.Unlock()
+
+	a.inflight.Add(1)
+	go func() {
+		defer a.inflight.Done()
+		a.fire(alert)
+	}()
+
+	return true
+}
+
+// Wait waits for the alerts being sent
+func (a *Alerter) Wait() {
+	a.inflight.Wait()
+}
+
+// notify runs the alert command and posts to the alert webhook
+func (a *Alerter) notify(alert Alert) {
+	// Keep the > of thresholds readable
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(alert); err != nil {
+		a.onError(err)
+		return
+	}
+	payload := bytes.TrimSpace(buf.Bytes())
+
+	ctx, cancel := context.WithTimeout(context.Background(), alertTimeout)
+	defer cancel()
+
+	if a.command != "" {
+		if err := runAlertCommand(ctx, a.command, alert, payload); err != nil {
+			a.onError(fmt.Errorf("alert command failed: %v", err))
+		}
+	}
+
+	if a.webhook != "" {
+		if err := postAlert(ctx, a.webhook, payload); err != nil {
+			a.onError(fmt.Errorf("alert webhook failed: %v", err))
+		}
+	}
+}
+
+// runAlertCommand runs command through the platform's shell, with the alert
+// as JSON on its stdin and in STRIPE_ALERT_* environment variables
+func runAlertCommand(ctx context.Context, command string, alert Alert, payload []byte) error {
+	cmd := shell.Command(ctx, command)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		"STRIPE_ALERT_THRESHOLD="+alert.Threshold,
+		"STRIPE_ALERT_COUNT="+strconv.Itoa(alert.Count),
+		"STRIPE_ALERT_WINDOW="+alert.Window,
+		"STRIPE_ALERT_REQUEST_ID="+alert.Last.RequestID,
+	)
+
+	return cmd.Run()
+}
+
+func postAlert(ctx context.Context, webhook string, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s responded with status %d", webhook, resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package logtailing
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseThreshold(t *testing.T) {
+	threshold, err := ParseThreshold("5xx>10/min")
+	require.NoError(t, err)
+	require.Equal(t, Threshold{Status: "5xx", Count: 10, Window: time.Minute}, threshold)
+
+	threshold, err = ParseThreshold("402 > 3 / 30s")
+	require.NoError(t, err)
+	require.Equal(t, Threshold{Status: "402", Count: 3, Window: 30 * time.Second}, threshold)
+
+	for _, invalid := range []string{"5xx>10", "6xx>1/min", "5xx>ten/min", "5xx>1/fortnight", "5xx>1/0s"} {
+		_, err = ParseThreshold(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestAlerterObserve(t *testing.T) {
+	var mu sync.Mutex
+	var fired []string
+	a := NewAlerter(Threshold{Status: "5xx", Count: 2, Window: time.Minute}, "", "", nil)
+	a.fire = func(alert Alert) {
+		mu.Lock()
+		defer mu.Unlock()
+		fired = append(fired, fmt.Sprintf("%s %d %s", alert.Threshold, alert.Count, alert.Last.RequestID))
+	}
+
+	start := time.Unix(1600000000, 0)
+	at := func(offset time.Duration, status int) bool {
+		return a.Observe(Log{CreatedAt: start.Add(offset), Status: status, RequestID: "req_" + offset.String()})
+	}
+
+	require.False(t, at(0, 500))
+	require.False(t, at(10*time.Second, 200))
+	require.False(t, at(20*time.Second, 502))
+	require.True(t, at(30*time.Second, 503))
+
+	// Doesn't fire again during the window
+	require.False(t, at(40*time.Second, 500))
+
+	// The first errors expired
+	require.False(t, at(100*time.Second, 500))
+	require.False(t, at(110*time.Second, 500))
+	require.True(t, at(115*time.Second, 500))
+
+	a.Wait()
+	require.ElementsMatch(t, []string{"5xx>2/1m0s 3 req_30s", "5xx>2/1m0s 3 req_1m55s"}, fired)
+}
+
+func TestAlerterNotify(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the alert command uses sh")
+	}
+
+	var posted []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posted, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer ts.Close()
+
+	out := filepath.Join(t.TempDir(), "alert")
+
+	var errs []error
+	a := NewAlerter(Threshold{Status: "402", Count: 0, Window: time.Minute}, "cat > "+out+"; echo $STRIPE_ALERT_COUNT >> "+out, ts.URL, func(err error) { errs = append(errs, err) })
+
+	require.True(t, a.Observe(Log{CreatedAt: time.Unix(1600000000, 0), Status: 402, RequestID: "req_123"}))
+	a.Wait()
+
+	require.Empty(t, errs)
+	require.Contains(t, string(posted), `"threshold":"402>0/1m0s"`)
+	require.Contains(t, string(posted), `"request_id":"req_123"`)
+
+	written, err := ioutil.ReadFile(out)
+	require.NoError(t, err)
+	require.Equal(t, string(posted)+"1\n", string(written))
+}
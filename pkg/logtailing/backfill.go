@@ -0,0 +1,160 @@
+package logtailing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/stripe/stripe-cli/pkg/requests"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/websocket"
+)
+
+// MaxBackfill is the maximum number of recent request logs fetched before
+// log tailing starts
+const MaxBackfill = 1000
+
+const backfillPageSize = 100
+
+// maxBackfillPages caps the number of pages of request logs fetched, which
+// client-side filters can skip most of
+const maxBackfillPages = 50
+
+// ErrBackfillUnavailable is returned when Stripe doesn't serve the recent
+// request logs to the CLI, in which case only the new ones can be tailed
+var ErrBackfillUnavailable = errors.New("the recent request logs are not available to the CLI for your account")
+
+// Backfill fetches the request logs made before log tailing starts
+type Backfill struct {
+	APIBaseURL string
+
+	// Key is the API key used to authenticate with Stripe
+	Key string
+
+	// Filters for API request logs, the same as for log tailing
+	Filters *LogFilters
+
+	// Since only fetches the request logs made during this duration, when set
+	Since time.Duration
+
+	// Last only fetches this number of the most recent request logs, when
+	// set. At most MaxBackfill request logs are fetched.
+	Last int
+}
+
+type requestLogsPage struct {
+	Data    []json.RawMessage `json:"data"`
+	HasMore bool              `json:"has_more"`
+}
+
+// Fetch returns the recent request logs matching the filters, oldest first,
+// as the data elements log tailing sends for live request logs.
+func (b *Backfill) Fetch(ctx context.Context) ([]websocket.DataElement, error) {
+	cf, err := newClientFilters(b.Filters)
+	if err != nil {
+		return nil, err
+	}
+
+	filters, err := jsonifyFilters(serverFilters(b.Filters))
+	if err != nil {
+		return nil, fmt.Errorf("Error while converting log filters to JSON encoding: %v", err)
+	}
+
+	baseURL := b.APIBaseURL
+	if baseURL == "" {
+		baseURL = stripe.DefaultAPIBaseURL
+	}
+
+	req := requests.Base{
+		Method:         http.MethodGet,
+		SuppressOutput: true,
+		APIBaseURL:     baseURL,
+	}
+
+	max := b.Last
+	if max <= 0 || max > MaxBackfill {
+		max = MaxBackfill
+	}
+
+	var created string
+	if b.Since > 0 {
+		created = fmt.Sprintf("created[gte]=%d", time.Now().Add(-b.Since).Unix())
+	}
+
+	var elements []websocket.DataElement
+
+	startingAfter := ""
+
+	for pages := 0; len(elements) < max && pages < maxBackfillPages; pages++ {
+		limit := max - len(elements)
+		if limit > backfillPageSize {
+			limit = backfillPageSize
+		}
+
+		var params requests.RequestParameters
+		params.AppendData([]string{
+			"filters=" + filters,
+			"limit=" + strconv.Itoa(limit),
+		})
+
+		if created != "" {
+			params.AppendData([]string{created})
+		}
+
+		if startingAfter != "" {
+			params.AppendData([]string{"starting_after=" + startingAfter})
+		}
+
+		resp, err := req.MakeRequest(ctx, b.Key, "/v1/stripecli/request_logs", &params, true)
+		if err != nil {
+			var reqErr requests.RequestError
+			if errors.As(err, &reqErr) && reqErr.StatusCode == http.StatusNotFound {
+				return nil, ErrBackfillUnavailable
+			}
+			return nil, err
+		}
+
+		var page requestLogsPage
+		if err := json.Unmarshal(resp, &page); err != nil {
+			return nil, fmt.Errorf("Received malformed request logs: %v", err)
+		}
+
+		for _, raw := range page.Data {
+			var payload EventPayload
+			if err := json.Unmarshal(raw, &payload); err != nil {
+				return nil, fmt.Errorf("Received malformed request log: %v", err)
+			}
+
+			startingAfter = payload.RequestID
+
+			// Don't show stripecli/sessions logs since they're generated by the CLI
+			if payload.URL == "/v1/stripecli/sessions" || !cf.matches(payload) {
+				continue
+			}
+
+			elements = append(elements, websocket.DataElement{
+				Data:      payload,
+				Marshaled: string(raw),
+			})
+
+			if len(elements) == max {
+				break
+			}
+		}
+
+		if !page.HasMore || len(page.Data) == 0 {
+			break
+		}
+	}
+
+	// The API lists the most recent request logs first
+	for i, j := 0, len(elements)-1; i < j; i, j = i+1, j-1 {
+		elements[i], elements[j] = elements[j], elements[i]
+	}
+
+	return elements, nil
+}
@@ -0,0 +1,97 @@
+package logtailing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackfillFetch(t *testing.T) {
+	var queries []string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/v1/stripecli/request_logs", r.URL.Path)
+		require.Equal(t, `{"filter_http_method":["POST"]}`, r.URL.Query().Get("filters"))
+
+		created, err := strconv.ParseInt(r.URL.Query().Get("created[gte]"), 10, 64)
+		require.NoError(t, err)
+		require.InDelta(t, time.Now().Add(-time.Hour).Unix(), created, 5)
+
+		queries = append(queries, r.URL.Query().Get("limit")+":"+r.URL.Query().Get("starting_after"))
+
+		// The request logs are listed most recent first
+		if r.URL.Query().Get("starting_after") == "" {
+			fmt.Fprint(w, `{"data": [
+				{"request_id": "req_4", "url": "/v1/charges", "method": "POST", "status": 200, "created_at": 4},
+				{"request_id": "req_3", "url": "/v1/stripecli/sessions", "method": "POST", "status": 200, "created_at": 3}
+			], "has_more": true}`)
+			return
+		}
+
+		fmt.Fprint(w, `{"data": [
+			{"request_id": "req_2", "url": "/v1/customers", "method": "POST", "status": 400, "created_at": 2},
+			{"request_id": "req_1", "url": "/v1/customers", "method": "POST", "status": 200, "created_at": 1}
+		], "has_more": true}`)
+	}))
+	defer ts.Close()
+
+	backfill := &Backfill{
+		APIBaseURL: ts.URL,
+		Key:        "sk_test_123",
+		Filters:    &LogFilters{FilterHTTPMethod: []string{"POST"}},
+		Since:      time.Hour,
+		Last:       2,
+	}
+
+	elements, err := backfill.Fetch(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"2:", "1:req_3"}, queries)
+
+	require.Len(t, elements, 2)
+	require.Equal(t, EventPayload{RequestID: "req_2", URL: "/v1/customers", Method: "POST", Status: 400, CreatedAt: 2}, elements[0].Data)
+	require.Equal(t, EventPayload{RequestID: "req_4", URL: "/v1/charges", Method: "POST", Status: 200, CreatedAt: 4}, elements[1].Data)
+	require.Contains(t, elements[1].Marshaled, `"request_id": "req_4"`)
+}
+
+func TestBackfillFetchError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error": {"message": "Invalid API Key provided"}}`)
+	}))
+	defer ts.Close()
+
+	backfill := &Backfill{
+		APIBaseURL: ts.URL,
+		Key:        "sk_test_123",
+		Filters:    &LogFilters{},
+		Last:       10,
+	}
+
+	_, err := backfill.Fetch(context.Background())
+	require.Error(t, err)
+}
+
+func TestBackfillFetchUnavailable(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": {"type": "invalid_request_error", "message": "Unrecognized request URL"}}`)
+	}))
+	defer ts.Close()
+
+	backfill := &Backfill{
+		APIBaseURL: ts.URL,
+		Key:        "sk_test_123",
+		Filters:    &LogFilters{},
+		Last:       10,
+	}
+
+	_, err := backfill.Fetch(context.Background())
+	require.ErrorIs(t, err, ErrBackfillUnavailable)
+}
@@ -0,0 +1,288 @@
+package logtailing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// exportTimeout bounds how long exporting a request log can take, so that a
+// slow or unreachable destination doesn't hold up the tailing
+const exportTimeout = 5 * time.Second
+
+// Exporter sends request logs to an observability stack, in structured form
+type Exporter interface {
+	Export(ctx context.Context, log Log) error
+	Close() error
+}
+
+// NewExporter returns the exporter for a destination URL:
+//
+//	syslog://host:514      RFC 5424 messages over UDP, or TCP with syslog+tcp://
+//	otlp://host:4318       OpenTelemetry logs with OTLP/HTTP in JSON, or HTTPS with otlps://
+//	datadog://datadoghq.eu Datadog logs intake of the site, default datadoghq.com, with the
+//	                       API key from the DD_API_KEY environment variable
+func NewExporter(destination string) (Exporter, error) {
+	u, err := url.Parse(destination)
+	if err != nil {
+		return nil, fmt.Errorf("invalid export destination ‘%s’: %v", destination, err)
+	}
+
+	switch u.Scheme {
+	case "syslog", "syslog+udp":
+		return newSyslogExporter("udp", hostWithDefaultPort(u, "514"))
+	case "syslog+tcp":
+		return newSyslogExporter("tcp", hostWithDefaultPort(u, "514"))
+	case "otlp":
+		return newOTLPExporter("http://" + hostWithDefaultPort(u, "4318") + otlpPath(u)), nil
+	case "otlps":
+		return newOTLPExporter("https://" + hostWithDefaultPort(u, "4318") + otlpPath(u)), nil
+	case "datadog":
+		site := u.Host
+		if site == "" {
+			site = "datadoghq.com"
+		}
+
+		apiKey := os.Getenv("DD_API_KEY")
+		if apiKey == "" {
+			return nil, errors.New("the DD_API_KEY environment variable must be set to export to Datadog")
+		}
+
+		return newDatadogExporter("https://http-intake.logs."+site+"/api/v2/logs", apiKey), nil
+	default:
+		return nil, fmt.Errorf("unsupported export destination ‘%s’, expected a syslog://, syslog+tcp://, otlp://, otlps:// or datadog:// URL", destination)
+	}
+}
+
+func hostWithDefaultPort(u *url.URL, port string) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+// otlpPath returns the path logs are sent to, /v1/logs unless the URL sets one
+func otlpPath(u *url.URL) string {
+	if u.Path == "" || u.Path == "/" {
+		return "/v1/logs"
+	}
+
+	return u.Path
+}
+
+// severity returns the severity of a request log, from its status
+func severity(log Log) string {
+	switch {
+	case log.Status >= 500:
+		return "error"
+	case log.Status >= 400:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+// summary returns the one line summary of a request log, like
+// `POST /v1/charges 402 req_123`
+func summary(log Log) string {
+	return fmt.Sprintf("%s %s %d %s", log.Method, log.Path, log.Status, log.RequestID)
+}
+
+// syslogExporter sends request logs as RFC 5424 messages, with the JSON of
+// the log as the message
+type syslogExporter struct {
+	network  string
+	conn     net.Conn
+	hostname string
+}
+
+// syslogFacility is the local0 facility
+const syslogFacility = 16
+
+var syslogSeverities = map[string]int{"error": 3, "warning": 4, "info": 6}
+
+func newSyslogExporter(network, addr string) (*syslogExporter, error) {
+	conn, err := net.DialTimeout(network, addr, exportTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to syslog at %s: %v", addr, err)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+
+	return &syslogExporter{network: network, conn: conn, hostname: hostname}, nil
+}
+
+func (e *syslogExporter) Export(ctx context.Context, log Log) error {
+	data, err := json.Marshal(log)
+	if err != nil {
+		return err
+	}
+
+	msg := fmt.Sprintf("<%d>1 %s %s stripe-cli %d request_log - %s",
+		syslogFacility*8+syslogSeverities[severity(log)],
+		log.CreatedAt.UTC().Format(time.RFC3339),
+		e.hostname,
+		os.Getpid(),
+		data,
+	)
+
+	// Messages over TCP are framed with their length, RFC 6587
+	if e.network == "tcp" {
+		msg = strconv.Itoa(len(msg)) + " " + msg
+	}
+
+	e.conn.SetWriteDeadline(time.Now().Add(exportTimeout)) // #nosec G104
+	_, err = e.conn.Write([]byte(msg))
+
+	return err
+}
+
+func (e *syslogExporter) Close() error {
+	return e.conn.Close()
+}
+
+// httpExporter posts request logs as JSON to an HTTP intake
+type httpExporter struct {
+	url     string
+	headers map[string]string
+	body    func(log Log) interface{}
+	client  *http.Client
+}
+
+func (e *httpExporter) Export(ctx context.Context, log Log) error {
+	data, err := json.Marshal(e.body(log))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, exportTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s responded with status %d", e.url, resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (e *httpExporter) Close() error {
+	return nil
+}
+
+// otlpSeverityNumbers are the OpenTelemetry severity numbers of the
+// severities
+var otlpSeverityNumbers = map[string]int{"error": 17, "warning": 13, "info": 9}
+
+func newOTLPExporter(endpoint string) *httpExporter {
+	return &httpExporter{
+		url:    endpoint,
+		client: &http.Client{},
+		body:   otlpLogs,
+	}
+}
+
+// otlpLogs returns the OTLP logs request of a request log, in the JSON
+// encoding of OTLP/HTTP
+func otlpLogs(log Log) interface{} {
+	str := func(key, value string) map[string]interface{} {
+		return map[string]interface{}{"key": key, "value": map[string]interface{}{"stringValue": value}}
+	}
+	integer := func(key string, value int64) map[string]interface{} {
+		return map[string]interface{}{"key": key, "value": map[string]interface{}{"intValue": strconv.FormatInt(value, 10)}}
+	}
+
+	attributes := []map[string]interface{}{
+		str("http.method", log.Method),
+		str("http.target", log.Path),
+		integer("http.status_code", int64(log.Status)),
+		str("stripe.request_id", log.RequestID),
+		{"key": "stripe.livemode", "value": map[string]interface{}{"boolValue": log.Livemode}},
+	}
+	if log.Latency > 0 {
+		attributes = append(attributes, integer("stripe.latency_ms", log.Latency.Milliseconds()))
+	}
+	if log.Account != "" {
+		attributes = append(attributes, str("stripe.account", log.Account))
+	}
+	if log.Error.Type != "" {
+		attributes = append(attributes, str("stripe.error.type", log.Error.Type), str("stripe.error.code", log.Error.Code))
+	}
+
+	sev := severity(log)
+
+	return map[string]interface{}{
+		"resourceLogs": []interface{}{map[string]interface{}{
+			"resource": map[string]interface{}{
+				"attributes": []interface{}{str("service.name", "stripe-cli")},
+			},
+			"scopeLogs": []interface{}{map[string]interface{}{
+				"scope": map[string]interface{}{"name": "stripe-cli/logs-tail"},
+				"logRecords": []interface{}{map[string]interface{}{
+					"timeUnixNano":   strconv.FormatInt(log.CreatedAt.UnixNano(), 10),
+					"severityNumber": otlpSeverityNumbers[sev],
+					"severityText":   strings.ToUpper(sev),
+					"body":           map[string]interface{}{"stringValue": summary(log)},
+					"attributes":     attributes,
+				}},
+			}},
+		}},
+	}
+}
+
+func newDatadogExporter(endpoint string, apiKey string) *httpExporter {
+	return &httpExporter{
+		url:     endpoint,
+		headers: map[string]string{"DD-API-KEY": apiKey},
+		client:  &http.Client{},
+		body:    datadogLogs,
+	}
+}
+
+// datadogLogs returns the Datadog logs intake request of a request log, with
+// its fields as attributes. The status attribute is the severity in Datadog,
+// the HTTP status is status_code.
+func datadogLogs(log Log) interface{} {
+	attributes := map[string]interface{}{}
+
+	data, err := json.Marshal(log)
+	if err == nil {
+		json.Unmarshal(data, &attributes) // #nosec G104
+	}
+
+	attributes["status_code"] = log.Status
+	attributes["status"] = severity(log)
+	attributes["ddsource"] = "stripe-cli"
+	attributes["service"] = "stripe"
+	attributes["message"] = summary(log)
+
+	return []interface{}{attributes}
+}
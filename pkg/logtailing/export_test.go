@@ -0,0 +1,99 @@
+package logtailing
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// receive starts a server decoding the JSON bodies it receives into received
+func receive(t *testing.T, received *[]interface{}, headers *http.Header) string {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var decoded interface{}
+		require.NoError(t, json.Unmarshal(body, &decoded))
+
+		*received = append(*received, decoded)
+		*headers = r.Header
+	}))
+	t.Cleanup(ts.Close)
+
+	return ts.URL
+}
+
+func TestSyslogExporter(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	exporter, err := NewExporter("syslog://" + conn.LocalAddr().String())
+	require.NoError(t, err)
+	defer exporter.Close()
+
+	require.NoError(t, exporter.Export(context.Background(), NewLog(testPayload)))
+
+	buf := make([]byte, 4096)
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+
+	// local0.warning for a 402
+	msg := string(buf[:n])
+	require.Regexp(t, regexp.MustCompile(`^<132>1 2020-09-13T12:26:40Z \S+ stripe-cli \d+ request_log - \{`), msg)
+	require.Contains(t, msg, `"request_id":"req_123"`)
+}
+
+func TestOTLPExporter(t *testing.T) {
+	var received []interface{}
+	var headers http.Header
+	exporter := newOTLPExporter(receive(t, &received, &headers) + "/v1/logs")
+
+	require.NoError(t, exporter.Export(context.Background(), NewLog(testPayload)))
+	require.Len(t, received, 1)
+	require.Equal(t, "application/json", headers.Get("Content-Type"))
+
+	record := received[0].(map[string]interface{})["resourceLogs"].([]interface{})[0].(map[string]interface{})["scopeLogs"].([]interface{})[0].(map[string]interface{})["logRecords"].([]interface{})[0].(map[string]interface{})
+	require.Equal(t, "1600000000000000000", record["timeUnixNano"])
+	require.Equal(t, "WARNING", record["severityText"])
+	require.Equal(t, map[string]interface{}{"stringValue": "POST /v1/payment_intents 402 req_123"}, record["body"])
+	require.Contains(t, record["attributes"], map[string]interface{}{"key": "http.status_code", "value": map[string]interface{}{"intValue": "402"}})
+	require.Contains(t, record["attributes"], map[string]interface{}{"key": "stripe.error.code", "value": map[string]interface{}{"stringValue": "card_declined"}})
+}
+
+func TestDatadogExporter(t *testing.T) {
+	var received []interface{}
+	var headers http.Header
+	exporter := newDatadogExporter(receive(t, &received, &headers), "dd_key")
+
+	require.NoError(t, exporter.Export(context.Background(), NewLog(testPayload)))
+	require.Len(t, received, 1)
+	require.Equal(t, "dd_key", headers.Get("DD-API-KEY"))
+
+	log := received[0].([]interface{})[0].(map[string]interface{})
+	require.Equal(t, "warning", log["status"])
+	require.Equal(t, float64(402), log["status_code"])
+	require.Equal(t, "stripe-cli", log["ddsource"])
+	require.Equal(t, "req_123", log["request_id"])
+	require.Equal(t, "POST /v1/payment_intents 402 req_123", log["message"])
+}
+
+func TestNewExporterInvalid(t *testing.T) {
+	_, err := NewExporter("kafka://localhost:9092")
+	require.EqualError(t, err, "unsupported export destination ‘kafka://localhost:9092’, expected a syslog://, syslog+tcp://, otlp://, otlps:// or datadog:// URL")
+
+	t.Setenv("DD_API_KEY", "")
+	_, err = NewExporter("datadog://")
+	require.EqualError(t, err, "the DD_API_KEY environment variable must be set to export to Datadog")
+
+	exporter, err := NewExporter("otlp://collector")
+	require.NoError(t, err)
+	require.Equal(t, "http://collector:4318/v1/logs", exporter.(*httpExporter).url)
+}
@@ -0,0 +1,115 @@
+package logtailing
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// clientFilters are the log filters the backend doesn't support, applied to
+// the request logs as they're received
+type clientFilters struct {
+	paths      []*regexp.Regexp
+	requestIDs map[string]bool
+}
+
+// isPathPattern returns whether a request path filter is a regular
+// expression, rather than a path the backend filters on
+func isPathPattern(path string) bool {
+	return regexp.QuoteMeta(path) != path
+}
+
+// newClientFilters compiles the filters applied client-side. Request path
+// filters are only applied client-side when at least one of them is a
+// regular expression, which must match the whole path.
+func newClientFilters(filters *LogFilters) (*clientFilters, error) {
+	cf := &clientFilters{
+		requestIDs: make(map[string]bool),
+	}
+
+	if filters == nil {
+		return cf, nil
+	}
+
+	for _, id := range filters.FilterRequestID {
+		cf.requestIDs[id] = true
+	}
+
+	hasPattern := false
+	for _, path := range filters.FilterRequestPath {
+		hasPattern = hasPattern || isPathPattern(path)
+	}
+
+	if !hasPattern {
+		return cf, nil
+	}
+
+	for _, path := range filters.FilterRequestPath {
+		re, err := regexp.Compile("^(?:" + path + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid request path filter ‘%s’: %v", path, err)
+		}
+		cf.paths = append(cf.paths, re)
+	}
+
+	return cf, nil
+}
+
+// matches returns whether a request log matches the client-side filters.
+func (cf *clientFilters) matches(payload EventPayload) bool {
+	if len(cf.requestIDs) > 0 && !cf.requestIDs[payload.RequestID] {
+		return false
+	}
+
+	if len(cf.paths) == 0 {
+		return true
+	}
+
+	for _, re := range cf.paths {
+		if re.MatchString(payload.URL) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// serverFilters returns the filters sent to the backend, without the request
+// path filters applied client-side.
+func serverFilters(filters *LogFilters) *LogFilters {
+	if filters == nil {
+		return &LogFilters{}
+	}
+
+	server := *filters
+
+	for _, path := range filters.FilterRequestPath {
+		if isPathPattern(path) {
+			server.FilterRequestPath = nil
+			break
+		}
+	}
+
+	return &server
+}
+
+// ValidateFilters returns an error if the filters are invalid, like a
+// request path filter that isn't a valid regular expression or a connected
+// account that isn't an account ID.
+func ValidateFilters(filters *LogFilters) error {
+	if filters != nil {
+		if filters.AllConnectedAccounts && len(filters.ConnectedAccounts) > 0 {
+			return errors.New("connected accounts cannot be specified when tailing the request logs of all of them")
+		}
+
+		for _, account := range filters.ConnectedAccounts {
+			if !strings.HasPrefix(account, "acct_") {
+				return fmt.Errorf("invalid connected account ‘%s’, expected an account ID like acct_123", account)
+			}
+		}
+	}
+
+	_, err := newClientFilters(filters)
+	return err
+}
@@ -0,0 +1,70 @@
+package logtailing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientFiltersRequestPathPattern(t *testing.T) {
+	filters := &LogFilters{
+		FilterHTTPMethod:  []string{"POST"},
+		FilterRequestPath: []string{"/v1/payment_intents.*", "/v1/charges"},
+	}
+
+	cf, err := newClientFilters(filters)
+	require.NoError(t, err)
+
+	require.True(t, cf.matches(EventPayload{URL: "/v1/payment_intents"}))
+	require.True(t, cf.matches(EventPayload{URL: "/v1/payment_intents/pi_123/confirm"}))
+	require.True(t, cf.matches(EventPayload{URL: "/v1/charges"}))
+	require.False(t, cf.matches(EventPayload{URL: "/v1/charges/ch_123"}))
+	require.False(t, cf.matches(EventPayload{URL: "/v2/v1/payment_intents"}))
+
+	// The paths are filtered client-side only
+	require.Equal(t, &LogFilters{FilterHTTPMethod: []string{"POST"}}, serverFilters(filters))
+}
+
+func TestClientFiltersRequestPathLiteral(t *testing.T) {
+	filters := &LogFilters{FilterRequestPath: []string{"/v1/charges"}}
+
+	cf, err := newClientFilters(filters)
+	require.NoError(t, err)
+
+	// Literal paths are left to the backend
+	require.True(t, cf.matches(EventPayload{URL: "/v1/customers"}))
+	require.Equal(t, filters, serverFilters(filters))
+}
+
+func TestClientFiltersRequestID(t *testing.T) {
+	filters := &LogFilters{FilterRequestID: []string{"req_123", "req_456"}}
+
+	cf, err := newClientFilters(filters)
+	require.NoError(t, err)
+
+	require.True(t, cf.matches(EventPayload{RequestID: "req_456"}))
+	require.False(t, cf.matches(EventPayload{RequestID: "req_789"}))
+
+	filtersStr, err := jsonifyFilters(serverFilters(filters))
+	require.NoError(t, err)
+	require.Equal(t, "{}", filtersStr)
+}
+
+func TestValidateFilters(t *testing.T) {
+	require.NoError(t, ValidateFilters(&LogFilters{FilterRequestPath: []string{"/v1/(charges|refunds)"}}))
+	require.EqualError(t,
+		ValidateFilters(&LogFilters{FilterRequestPath: []string{"/v1/(charges"}}),
+		"invalid request path filter ‘/v1/(charges’: error parsing regexp: missing closing ): `^(?:/v1/(charges)$`",
+	)
+}
+
+func TestValidateFiltersConnectedAccounts(t *testing.T) {
+	require.NoError(t, ValidateFilters(&LogFilters{ConnectedAccounts: []string{"acct_123", "acct_456"}}))
+	require.NoError(t, ValidateFilters(&LogFilters{AllConnectedAccounts: true}))
+
+	err := ValidateFilters(&LogFilters{ConnectedAccounts: []string{"cus_123"}})
+	require.EqualError(t, err, "invalid connected account ‘cus_123’, expected an account ID like acct_123")
+
+	err = ValidateFilters(&LogFilters{ConnectedAccounts: []string{"acct_123"}, AllConnectedAccounts: true})
+	require.EqualError(t, err, "connected accounts cannot be specified when tailing the request logs of all of them")
+}
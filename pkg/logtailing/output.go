@@ -0,0 +1,92 @@
+package logtailing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Log is a request log as rendered by output templates and the JSON output,
+// e.g. `{{.Status}} {{.Method}} {{.Path}} {{.RequestID}} {{.Latency}}`
+type Log struct {
+	CreatedAt time.Time     `json:"created_at"`
+	Livemode  bool          `json:"livemode"`
+	Account   string        `json:"account,omitempty"`
+	Method    string        `json:"method"`
+	Path      string        `json:"path"`
+	RequestID string        `json:"request_id"`
+	Status    int           `json:"status"`
+	Latency   time.Duration `json:"-"`
+	Error     RedactedError `json:"error"`
+}
+
+// NewLog returns the Log of an event payload
+func NewLog(payload EventPayload) Log {
+	return Log{
+		CreatedAt: time.Unix(int64(payload.CreatedAt), 0),
+		Livemode:  payload.Livemode,
+		Account:   payload.Account,
+		Method:    payload.Method,
+		Path:      payload.URL,
+		RequestID: payload.RequestID,
+		Status:    payload.Status,
+		Latency:   time.Duration(payload.Latency) * time.Millisecond,
+		Error:     payload.Error,
+	}
+}
+
+// MarshalJSON adds the latency in milliseconds to the JSON of a Log, which
+// is easier to consume than a duration
+func (l Log) MarshalJSON() ([]byte, error) {
+	type plainLog Log
+
+	return json.Marshal(struct {
+		plainLog
+		LatencyMs int64 `json:"latency_ms"`
+	}{
+		plainLog:  plainLog(l),
+		LatencyMs: l.Latency.Milliseconds(),
+	})
+}
+
+// IsTemplate returns whether a format is an output template, rather than a
+// named format like JSON
+func IsTemplate(format string) bool {
+	return strings.Contains(format, "{{")
+}
+
+// ParseTemplate parses an output template rendering a Log
+func ParseTemplate(format string) (*template.Template, error) {
+	tmpl, err := template.New("format").Parse(format)
+	if err != nil {
+		return nil, fmt.Errorf("invalid format template: %v", err)
+	}
+
+	// Catch references to fields a Log doesn't have before any log is received
+	if err := tmpl.Execute(ioutil.Discard, Log{}); err != nil {
+		return nil, fmt.Errorf("invalid format template: %v", err)
+	}
+
+	return tmpl, nil
+}
+
+// WriteTemplate renders a request log with an output template, on its own line
+func WriteTemplate(out io.Writer, tmpl *template.Template, payload EventPayload) error {
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, NewLog(payload)); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintln(out, sb.String())
+
+	return err
+}
+
+// WriteJSON writes a request log as a JSON object on its own line
+func WriteJSON(out io.Writer, payload EventPayload) error {
+	return json.NewEncoder(out).Encode(NewLog(payload))
+}
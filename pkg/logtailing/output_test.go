@@ -0,0 +1,58 @@
+package logtailing
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testPayload = EventPayload{
+	CreatedAt: 1600000000,
+	Method:    "POST",
+	RequestID: "req_123",
+	Status:    402,
+	URL:       "/v1/payment_intents",
+	Latency:   250,
+	Error:     RedactedError{Type: "card_error", Code: "card_declined"},
+}
+
+func TestWriteTemplate(t *testing.T) {
+	tmpl, err := ParseTemplate("{{.Status}} {{.Method}} {{.Path}} {{.RequestID}} {{.Latency}} {{.Error.Code}}")
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, WriteTemplate(&out, tmpl, testPayload))
+	require.Equal(t, "402 POST /v1/payment_intents req_123 250ms card_declined\n", out.String())
+}
+
+func TestParseTemplateInvalid(t *testing.T) {
+	_, err := ParseTemplate("{{.Status")
+	require.Error(t, err)
+
+	_, err = ParseTemplate("{{.URL}}")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "can't evaluate field URL")
+}
+
+func TestWriteJSON(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, WriteJSON(&out, testPayload))
+	require.JSONEq(t, `{
+		"created_at": "`+NewLog(testPayload).CreatedAt.Format("2006-01-02T15:04:05Z07:00")+`",
+		"livemode": false,
+		"method": "POST",
+		"path": "/v1/payment_intents",
+		"request_id": "req_123",
+		"status": 402,
+		"latency_ms": 250,
+		"error": {"type": "card_error", "charge": "", "code": "card_declined", "decline_code": "", "message": "", "param": "", "error_insight": ""}
+	}`, out.String())
+	require.Equal(t, 1, bytes.Count(out.Bytes(), []byte("\n")))
+}
+
+func TestIsTemplate(t *testing.T) {
+	require.True(t, IsTemplate("{{.Status}}"))
+	require.False(t, IsTemplate("JSON"))
+	require.False(t, IsTemplate(""))
+}
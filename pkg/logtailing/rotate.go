@@ -0,0 +1,155 @@
+package logtailing
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/spf13/afero"
+)
+
+// sizeUnits are the units of the sizes accepted by ParseSize
+var sizeUnits = []struct {
+	suffix string
+	bytes  int64
+}{
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+// ParseSize parses a size like 50MB, 512KB or 1GB into bytes. A size
+// without a unit is in bytes.
+func ParseSize(size string) (int64, error) {
+	s := strings.ToUpper(strings.TrimSpace(size))
+
+	multiplier := int64(1)
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(s, unit.suffix) {
+			s = strings.TrimSpace(strings.TrimSuffix(s, unit.suffix))
+			multiplier = unit.bytes
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid size ‘%s’, expected a positive size like 50MB, 512KB or 1GB", size)
+	}
+
+	return n * multiplier, nil
+}
+
+// RotatingFile is a file appended to, which is rotated when writing to it
+// would make it larger than its maximum size: the file is renamed with a .1
+// suffix, the file with a .1 suffix gets a .2 suffix, and so on, keeping a
+// number of rotated files.
+type RotatingFile struct {
+	fs      afero.Fs
+	path    string
+	maxSize int64
+	keep    int
+
+	mu   sync.Mutex
+	file afero.File
+	size int64
+}
+
+// OpenRotatingFile opens the file at path to append to it. The file is
+// never rotated if maxSize is 0.
+func OpenRotatingFile(fs afero.Fs, path string, maxSize int64, keep int) (*RotatingFile, error) {
+	f := &RotatingFile{
+		fs:      fs,
+		path:    path,
+		maxSize: maxSize,
+		keep:    keep,
+	}
+
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (f *RotatingFile) open() error {
+	file, err := f.fs.OpenFile(f.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	f.file = file
+	f.size = info.Size()
+
+	return nil
+}
+
+// Write appends p to the file, rotating it first if needed. p is never split
+// across files.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+
+	return n, err
+}
+
+// rotate shifts the rotated files, dropping the oldest one, and starts a new
+// file.
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+
+	if f.keep < 1 {
+		if err := f.fs.Remove(f.path); err != nil {
+			return err
+		}
+		return f.open()
+	}
+
+	f.fs.Remove(f.rotatedPath(f.keep)) // #nosec G104
+
+	for i := f.keep - 1; i >= 1; i-- {
+		if _, err := f.fs.Stat(f.rotatedPath(i)); err == nil {
+			if err := f.fs.Rename(f.rotatedPath(i), f.rotatedPath(i+1)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := f.fs.Rename(f.path, f.rotatedPath(1)); err != nil {
+		return err
+	}
+
+	return f.open()
+}
+
+func (f *RotatingFile) rotatedPath(i int) string {
+	return fmt.Sprintf("%s.%d", f.path, i)
+}
+
+// Close closes the file
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.file.Close()
+}
@@ -0,0 +1,77 @@
+package logtailing
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSize(t *testing.T) {
+	for size, expected := range map[string]int64{
+		"50MB":   50 << 20,
+		"512kb":  512 << 10,
+		"1 GB":   1 << 30,
+		"2048":   2048,
+		"100B":   100,
+		" 3MB  ": 3 << 20,
+	} {
+		n, err := ParseSize(size)
+		require.NoError(t, err, size)
+		require.Equal(t, expected, n, size)
+	}
+
+	for _, size := range []string{"", "MB", "-1MB", "0", "1.5GB", "10TB"} {
+		_, err := ParseSize(size)
+		require.Error(t, err, size)
+	}
+}
+
+func TestRotatingFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "requests.log", []byte("old\n"), 0600)
+
+	f, err := OpenRotatingFile(fs, "requests.log", 10, 2)
+	require.NoError(t, err)
+
+	for _, line := range []string{"line 1\n", "line 2\n", "line 3\n", "line 4\n"} {
+		_, err := f.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Close())
+
+	// Each line would make the file larger than 10 bytes, so the file is
+	// rotated before every write, and only 2 rotated files are kept
+	content, err := afero.ReadFile(fs, "requests.log")
+	require.NoError(t, err)
+	require.Equal(t, "line 4\n", string(content))
+
+	content, err = afero.ReadFile(fs, "requests.log.1")
+	require.NoError(t, err)
+	require.Equal(t, "line 3\n", string(content))
+
+	content, err = afero.ReadFile(fs, "requests.log.2")
+	require.NoError(t, err)
+	require.Equal(t, "line 2\n", string(content))
+
+	exists, _ := afero.Exists(fs, "requests.log.3")
+	require.False(t, exists)
+}
+
+func TestRotatingFileNoRotation(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	f, err := OpenRotatingFile(fs, "requests.log", 0, 5)
+	require.NoError(t, err)
+
+	f.Write([]byte("line 1\n"))
+	f.Write([]byte("line 2\n"))
+	require.NoError(t, f.Close())
+
+	content, err := afero.ReadFile(fs, "requests.log")
+	require.NoError(t, err)
+	require.Equal(t, "line 1\nline 2\n", string(content))
+
+	exists, _ := afero.Exists(fs, "requests.log.1")
+	require.False(t, exists)
+}
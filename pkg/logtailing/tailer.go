@@ -3,6 +3,7 @@ package logtailing
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -14,8 +15,6 @@ import (
 	"github.com/stripe/stripe-cli/pkg/websocket"
 )
 
-const requestLogsWebSocketFeature = "request_logs"
-
 // LogFilters contains all of the potential user-provided filters for log tailing
 type LogFilters struct {
 	FilterAccount        []string `json:"filter_account,omitempty"`
@@ -26,6 +25,15 @@ type LogFilters struct {
 	FilterSource         []string `json:"filter_source,omitempty"`
 	FilterStatusCode     []string `json:"filter_status_code,omitempty"`
 	FilterStatusCodeType []string `json:"filter_status_code_type,omitempty"`
+
+	// ConnectedAccounts are the connected accounts whose request logs, made by
+	// the platform on their behalf, are streamed too. AllConnectedAccounts
+	// streams the request logs of all of them.
+	ConnectedAccounts    []string `json:"connected_accounts,omitempty"`
+	AllConnectedAccounts bool     `json:"all_connected_accounts,omitempty"`
+
+	// FilterRequestID isn't supported by the backend, it's applied client-side
+	FilterRequestID []string `json:"-"`
 }
 
 // Config provides the configuration of a log tailer
@@ -57,6 +65,7 @@ type Tailer struct {
 
 	stripeAuthClient *stripeauth.Client
 	webSocketClient  *websocket.Client
+	clientFilters    *clientFilters
 
 	interruptCh chan os.Signal
 }
@@ -70,6 +79,12 @@ type EventPayload struct {
 	Status    int           `json:"status"`
 	URL       string        `json:"url"`
 	Error     RedactedError `json:"error"`
+
+	// Account is the connected account the request was made on behalf of, if any
+	Account string `json:"account,omitempty"`
+
+	// Latency is the duration of the request in milliseconds, when reported
+	Latency int `json:"latency,omitempty"`
 }
 
 // RedactedError is the mapping for fields in error from an EventPayload
@@ -112,12 +127,21 @@ func (t *Tailer) Run(ctx context.Context) error {
 		State: websocket.Loading,
 	}
 
+	cf, err := newClientFilters(t.cfg.Filters)
+	if err != nil {
+		t.cfg.OutCh <- websocket.ErrorElement{
+			Error: err,
+		}
+		return err
+	}
+	t.clientFilters = cf
+
 	for nAttempts < maxConnectAttempts {
 		session, err := t.createSession(ctx)
 
 		if err != nil {
 			t.cfg.OutCh <- websocket.ErrorElement{
-				Error: fmt.Errorf("Error while authenticating with Stripe: %v", err),
+				Error: fmt.Errorf("Error while authenticating with Stripe: %w", err),
 			}
 			return err
 		}
@@ -192,7 +216,7 @@ func (t *Tailer) createSession(ctx context.Context) (*stripeauth.StripeCLISessio
 
 	exitCh := make(chan struct{})
 
-	filters, err := jsonifyFilters(t.cfg.Filters)
+	filters, err := jsonifyFilters(serverFilters(t.cfg.Filters))
 	if err != nil {
 		return nil, fmt.Errorf("Error while converting log filters to JSON encoding: %v", err)
 	}
@@ -201,13 +225,18 @@ func (t *Tailer) createSession(ctx context.Context) (*stripeauth.StripeCLISessio
 		// Try to authorize at least 5 times before failing. Sometimes we have random
 		// transient errors that we just need to retry for.
 		for i := 0; i <= 5; i++ {
-			session, err = t.stripeAuthClient.Authorize(ctx, t.cfg.DeviceName, requestLogsWebSocketFeature, &filters, nil)
+			session, err = t.stripeAuthClient.Authorize(ctx, t.cfg.DeviceName, stripeauth.FeatureRequestLogs, &filters, nil)
 
 			if err == nil {
 				exitCh <- struct{}{}
 				return
 			}
 
+			// A key without the scope won't be authorized by retrying
+			if errors.As(err, &stripeauth.FeatureNotAuthorizedError{}) {
+				break
+			}
+
 			select {
 			case <-ctx.Done():
 				exitCh <- struct{}{}
@@ -251,6 +280,10 @@ func (t *Tailer) processRequestLogEvent(msg websocket.IncomingMessage) {
 		return
 	}
 
+	if t.clientFilters != nil && !t.clientFilters.matches(payload) {
+		return
+	}
+
 	t.cfg.OutCh <- websocket.DataElement{
 		Data:      payload,
 		Marshaled: requestLogEvent.EventPayload,
@@ -0,0 +1,29 @@
+// Package loopback tells whether addresses and origins are those of the local
+// machine, for the servers the CLI only exposes to local clients.
+package loopback
+
+import (
+	"net"
+	"net/url"
+)
+
+// IsHost returns whether host is localhost or a loopback IP address
+func IsHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// IsOrigin returns whether the origin of a request is a page served by the
+// local machine
+func IsOrigin(origin string) bool {
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	return IsHost(u.Hostname())
+}
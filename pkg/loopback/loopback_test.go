@@ -0,0 +1,24 @@
+package loopback
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsHost(t *testing.T) {
+	require.True(t, IsHost("localhost"))
+	require.True(t, IsHost("127.0.0.1"))
+	require.True(t, IsHost("::1"))
+	require.False(t, IsHost(""))
+	require.False(t, IsHost("0.0.0.0"))
+	require.False(t, IsHost("example.com"))
+}
+
+func TestIsOrigin(t *testing.T) {
+	require.True(t, IsOrigin("http://localhost:3000"))
+	require.True(t, IsOrigin("http://127.0.0.1"))
+	require.True(t, IsOrigin("http://[::1]:8080"))
+	require.False(t, IsOrigin("https://localhost.example.com"))
+	require.False(t, IsOrigin("null"))
+}
@@ -0,0 +1,336 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//
+// Public types
+//
+
+// Registry holds a set of metrics and renders them in the Prometheus text
+// exposition format.
+type Registry struct {
+	mu         sync.Mutex
+	collectors []collector
+}
+
+// CounterVec is a set of counters partitioned by label values.
+type CounterVec struct {
+	desc
+
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+// HistogramVec is a set of histograms partitioned by label values.
+type HistogramVec struct {
+	desc
+
+	buckets []float64
+
+	mu     sync.Mutex
+	values map[string]*histogram
+}
+
+//
+// Public variables
+//
+
+// DefaultRegistry is the registry that metrics declared by the CLI's packages
+// are registered with.
+var DefaultRegistry = NewRegistry()
+
+// DefaultBuckets are the default histogram buckets, in seconds, suitable for
+// measuring the latency of requests to a local server.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30}
+
+//
+// Public functions
+//
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// NewCounterVec creates a CounterVec and registers it with DefaultRegistry.
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	return DefaultRegistry.NewCounterVec(name, help, labels...)
+}
+
+// NewHistogramVec creates a HistogramVec and registers it with DefaultRegistry.
+// If buckets is nil, DefaultBuckets is used.
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	return DefaultRegistry.NewHistogramVec(name, help, buckets, labels...)
+}
+
+// Serve exposes the metrics of DefaultRegistry over HTTP on addr at /metrics.
+// It returns once the listener is bound; requests are served in the background
+// until the process exits.
+func Serve(addr string) (net.Addr, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", DefaultRegistry)
+
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go server.Serve(listener)
+
+	return listener.Addr(), nil
+}
+
+//
+// Public methods
+//
+
+// NewCounterVec creates a CounterVec and registers it with r.
+func (r *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{
+		desc:   desc{name: name, help: help, labels: labels},
+		values: make(map[string]float64),
+	}
+	r.register(c)
+
+	return c
+}
+
+// NewHistogramVec creates a HistogramVec and registers it with r. If buckets
+// is nil, DefaultBuckets is used.
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+
+	h := &HistogramVec{
+		desc:    desc{name: name, help: help, labels: labels},
+		buckets: buckets,
+		values:  make(map[string]*histogram),
+	}
+	r.register(h)
+
+	return h
+}
+
+// ServeHTTP writes all registered metrics in the Prometheus text format.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	r.Write(w)
+}
+
+// Write writes all registered metrics in the Prometheus text format to w.
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.Lock()
+	collectors := make([]collector, len(r.collectors))
+	copy(collectors, r.collectors)
+	r.mu.Unlock()
+
+	for _, c := range collectors {
+		if _, err := io.WriteString(w, c.expose()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Inc increments the counter for the given label values by 1.
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add increments the counter for the given label values by v.
+func (c *CounterVec) Add(v float64, labelValues ...string) {
+	key := c.key(labelValues)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.values[key] += v
+}
+
+// Value returns the current value of the counter for the given label values.
+func (c *CounterVec) Value(labelValues ...string) float64 {
+	key := c.key(labelValues)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.values[key]
+}
+
+// Observe records v in the histogram for the given label values.
+func (h *HistogramVec) Observe(v float64, labelValues ...string) {
+	key := h.key(labelValues)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	hist, ok := h.values[key]
+	if !ok {
+		hist = &histogram{counts: make([]uint64, len(h.buckets))}
+		h.values[key] = hist
+	}
+
+	for i, upperBound := range h.buckets {
+		if v <= upperBound {
+			hist.counts[i]++
+		}
+	}
+	hist.count++
+	hist.sum += v
+}
+
+// ObserveDuration records d, in seconds, in the histogram for the given label
+// values.
+func (h *HistogramVec) ObserveDuration(d time.Duration, labelValues ...string) {
+	h.Observe(d.Seconds(), labelValues...)
+}
+
+//
+// Private types
+//
+
+type collector interface {
+	expose() string
+}
+
+type desc struct {
+	name   string
+	help   string
+	labels []string
+}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+//
+// Private methods
+//
+
+func (r *Registry) register(c collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.collectors = append(r.collectors, c)
+}
+
+func (d *desc) key(labelValues []string) string {
+	if len(labelValues) != len(d.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", d.name, len(d.labels), len(labelValues)))
+	}
+
+	return strings.Join(labelValues, "\xff")
+}
+
+func (d *desc) header(metricType string) string {
+	return fmt.Sprintf("# HELP %s %s\n# TYPE %s %s\n", d.name, escapeHelp(d.help), d.name, metricType)
+}
+
+// labelPairs renders the label set for a key, with extra appended as a
+// pre-formatted pair.
+func (d *desc) labelPairs(key string, extra string) string {
+	var pairs []string
+
+	if len(d.labels) > 0 {
+		for i, value := range strings.Split(key, "\xff") {
+			pairs = append(pairs, fmt.Sprintf(`%s="%s"`, d.labels[i], escapeLabelValue(value)))
+		}
+	}
+
+	if extra != "" {
+		pairs = append(pairs, extra)
+	}
+
+	if len(pairs) == 0 {
+		return ""
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func (c *CounterVec) expose() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var sb strings.Builder
+	sb.WriteString(c.header("counter"))
+
+	for _, key := range sortedKeys(c.values) {
+		fmt.Fprintf(&sb, "%s%s %s\n", c.name, c.labelPairs(key, ""), formatFloat(c.values[key]))
+	}
+
+	return sb.String()
+}
+
+func (h *HistogramVec) expose() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var sb strings.Builder
+	sb.WriteString(h.header("histogram"))
+
+	keys := make([]string, 0, len(h.values))
+	for key := range h.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		hist := h.values[key]
+
+		for i, upperBound := range h.buckets {
+			le := fmt.Sprintf(`le="%s"`, formatFloat(upperBound))
+			fmt.Fprintf(&sb, "%s_bucket%s %d\n", h.name, h.labelPairs(key, le), hist.counts[i])
+		}
+		fmt.Fprintf(&sb, "%s_bucket%s %d\n", h.name, h.labelPairs(key, `le="+Inf"`), hist.count)
+		fmt.Fprintf(&sb, "%s_sum%s %s\n", h.name, h.labelPairs(key, ""), formatFloat(hist.sum))
+		fmt.Fprintf(&sb, "%s_count%s %d\n", h.name, h.labelPairs(key, ""), hist.count)
+	}
+
+	return sb.String()
+}
+
+//
+// Private functions
+//
+
+func sortedKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func escapeHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
+
+func escapeLabelValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`).Replace(s)
+}
@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCounterVec(t *testing.T) {
+	r := NewRegistry()
+	c := r.NewCounterVec("test_events_total", "Events received.", "type")
+
+	c.Inc("charge.succeeded")
+	c.Inc("charge.succeeded")
+	c.Add(3, "customer.created")
+
+	require.Equal(t, float64(2), c.Value("charge.succeeded"))
+	require.Equal(t, float64(3), c.Value("customer.created"))
+	require.Equal(t, float64(0), c.Value("invoice.paid"))
+
+	var buf bytes.Buffer
+	require.NoError(t, r.Write(&buf))
+	require.Equal(t, `# HELP test_events_total Events received.
+# TYPE test_events_total counter
+test_events_total{type="charge.succeeded"} 2
+test_events_total{type="customer.created"} 3
+`, buf.String())
+}
+
+func TestCounterVecWrongLabelCount(t *testing.T) {
+	r := NewRegistry()
+	c := r.NewCounterVec("test_total", "Test.", "a", "b")
+
+	require.Panics(t, func() { c.Inc("only-one") })
+}
+
+func TestHistogramVec(t *testing.T) {
+	r := NewRegistry()
+	h := r.NewHistogramVec("test_duration_seconds", "Durations.", []float64{0.1, 1})
+
+	h.Observe(0.05)
+	h.Observe(0.5)
+	h.Observe(2)
+
+	var buf bytes.Buffer
+	require.NoError(t, r.Write(&buf))
+	require.Equal(t, `# HELP test_duration_seconds Durations.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{le="0.1"} 1
+test_duration_seconds_bucket{le="1"} 2
+test_duration_seconds_bucket{le="+Inf"} 3
+test_duration_seconds_sum 2.55
+test_duration_seconds_count 3
+`, buf.String())
+}
+
+func TestLabelValueEscaping(t *testing.T) {
+	r := NewRegistry()
+	c := r.NewCounterVec("test_total", "Test.", "value")
+
+	c.Inc("say \"hi\"\n")
+
+	var buf bytes.Buffer
+	require.NoError(t, r.Write(&buf))
+	require.Contains(t, buf.String(), `test_total{value="say \"hi\"\n"} 1`)
+}
+
+func TestServe(t *testing.T) {
+	addr, err := Serve("127.0.0.1:0")
+	require.NoError(t, err)
+
+	resp, err := http.Get("http://" + addr.String() + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Contains(t, resp.Header.Get("Content-Type"), "text/plain")
+	require.NotNil(t, body)
+}
@@ -0,0 +1,261 @@
+// Package netproxy dials connections through HTTP CONNECT and SOCKS5 proxy
+// servers, including proxies requiring basic or NTLM authentication, and
+// resolves the addresses of direct connections with the --resolve, --ipv4
+// and --ipv6 flags.
+package netproxy
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/proxy"
+)
+
+// Dialer dials connections through a proxy server
+type Dialer struct {
+	url *url.URL
+}
+
+// New returns a Dialer for the proxy at rawURL. Supported schemes are http,
+// https, socks5 and socks5h. Credentials are read from the URL's user info,
+// with NTLM users written as `DOMAIN\user`.
+func New(rawURL string) (*Dialer, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %v", err)
+	}
+
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q, use http, https, socks5 or socks5h", u.Scheme)
+	}
+
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid proxy URL: missing host")
+	}
+
+	return &Dialer{url: u}, nil
+}
+
+// String returns the proxy URL with the password redacted.
+func (d *Dialer) String() string {
+	return d.url.Redacted()
+}
+
+// DialContext connects to addr through the proxy.
+func (d *Dialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	switch d.url.Scheme {
+	case "socks5", "socks5h":
+		return d.dialSOCKS5(ctx, network, addr)
+	default:
+		return d.dialConnect(ctx, addr)
+	}
+}
+
+// Dial connects to addr through the proxy.
+func (d *Dialer) Dial(network, addr string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, addr)
+}
+
+//
+// Private functions
+//
+
+func (d *Dialer) proxyAddr() string {
+	port := d.url.Port()
+	if port == "" {
+		switch d.url.Scheme {
+		case "https":
+			port = "443"
+		case "http":
+			port = "80"
+		default:
+			port = "1080"
+		}
+	}
+
+	return net.JoinHostPort(d.url.Hostname(), port)
+}
+
+func (d *Dialer) dialSOCKS5(ctx context.Context, network, addr string) (net.Conn, error) {
+	var auth *proxy.Auth
+	if d.url.User != nil {
+		password, _ := d.url.User.Password()
+		auth = &proxy.Auth{User: d.url.User.Username(), Password: password}
+	}
+
+	dialer, err := proxy.SOCKS5("tcp", d.proxyAddr(), auth, directDialer{})
+	if err != nil {
+		return nil, err
+	}
+
+	return dialer.(proxy.ContextDialer).DialContext(ctx, network, addr)
+}
+
+// dialConnect opens a tunnel to addr with an HTTP CONNECT request. If the
+// proxy asks for NTLM authentication, the handshake is performed on a new
+// connection since NTLM authenticates connections rather than requests.
+func (d *Dialer) dialConnect(ctx context.Context, addr string) (net.Conn, error) {
+	header := make(http.Header)
+	if d.url.User != nil {
+		password, _ := d.url.User.Password()
+		credentials := d.url.User.Username() + ":" + password
+		header.Set("Proxy-Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(credentials)))
+	}
+
+	conn, br, err := d.dialProxy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := connect(conn, br, addr, header)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		return conn, nil
+	}
+
+	conn.Close()
+
+	if resp.StatusCode == http.StatusProxyAuthRequired && d.url.User != nil && offersScheme(resp, "NTLM") {
+		return d.dialNTLM(ctx, addr)
+	}
+
+	return nil, fmt.Errorf("proxy %s refused to connect to %s: %s", d.url.Host, addr, resp.Status)
+}
+
+func (d *Dialer) dialNTLM(ctx context.Context, addr string) (net.Conn, error) {
+	conn, br, err := d.dialProxy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fail := func(err error) (net.Conn, error) {
+		conn.Close()
+		return nil, err
+	}
+
+	header := make(http.Header)
+	header.Set("Proxy-Authorization", "NTLM "+base64.StdEncoding.EncodeToString(ntlmNegotiateMessage()))
+
+	resp, err := connect(conn, br, addr, header)
+	if err != nil {
+		return fail(err)
+	}
+
+	challenge, err := ntlmChallengeFromResponse(resp)
+	if err != nil {
+		return fail(err)
+	}
+
+	domain, user := splitDomain(d.url.User.Username())
+	password, _ := d.url.User.Password()
+
+	authenticate, err := ntlmAuthenticateMessage(challenge, domain, user, password)
+	if err != nil {
+		return fail(err)
+	}
+
+	header.Set("Proxy-Authorization", "NTLM "+base64.StdEncoding.EncodeToString(authenticate))
+
+	resp, err = connect(conn, br, addr, header)
+	if err != nil {
+		return fail(err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fail(fmt.Errorf("proxy %s refused to connect to %s: %s", d.url.Host, addr, resp.Status))
+	}
+
+	return conn, nil
+}
+
+func (d *Dialer) dialProxy(ctx context.Context) (net.Conn, *bufio.Reader, error) {
+	conn, err := DialContext(ctx, "tcp", d.proxyAddr())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if d.url.Scheme == "https" {
+		tlsConn := tls.Client(conn, &tls.Config{
+			ServerName: d.url.Hostname(),
+			MinVersion: tls.VersionTLS12,
+		})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, nil, err
+		}
+		conn = tlsConn
+	}
+
+	return conn, bufio.NewReader(conn), nil
+}
+
+// connect sends a CONNECT request for addr and reads the response. The
+// response body is drained so that the connection can be reused.
+func connect(conn net.Conn, br *bufio.Reader, addr string, header http.Header) (*http.Response, error) {
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: header,
+	}
+
+	if err := req.Write(conn); err != nil {
+		return nil, err
+	}
+
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+	}
+
+	return resp, nil
+}
+
+func offersScheme(resp *http.Response, scheme string) bool {
+	for _, v := range resp.Header.Values("Proxy-Authenticate") {
+		if strings.EqualFold(strings.SplitN(v, " ", 2)[0], scheme) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func ntlmChallengeFromResponse(resp *http.Response) ([]byte, error) {
+	for _, v := range resp.Header.Values("Proxy-Authenticate") {
+		parts := strings.SplitN(v, " ", 2)
+		if len(parts) == 2 && strings.EqualFold(parts[0], "NTLM") {
+			return base64.StdEncoding.DecodeString(strings.TrimSpace(parts[1]))
+		}
+	}
+
+	return nil, fmt.Errorf("proxy did not send an NTLM challenge: %s", resp.Status)
+}
+
+func splitDomain(username string) (string, string) {
+	if i := strings.Index(username, `\`); i >= 0 {
+		return username[:i], username[i+1:]
+	}
+
+	return "", username
+}
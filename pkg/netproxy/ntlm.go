@@ -0,0 +1,168 @@
+package netproxy
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"golang.org/x/crypto/md4" // #nosec G501 -- NTLM mandates MD4
+)
+
+// NTLM messages as described in [MS-NLMP]. Only NTLMv2 authentication is
+// supported.
+
+const (
+	ntlmNegotiateUnicode                 = 0x00000001
+	ntlmRequestTarget                    = 0x00000004
+	ntlmNegotiateNTLM                    = 0x00000200
+	ntlmNegotiateAlwaysSign              = 0x00008000
+	ntlmNegotiateExtendedSessionSecurity = 0x00080000
+	ntlmNegotiateTargetInfo              = 0x00800000
+	ntlmNegotiate128                     = 0x20000000
+	ntlmNegotiate56                      = 0x80000000
+
+	ntlmFlags = ntlmNegotiateUnicode | ntlmRequestTarget | ntlmNegotiateNTLM | ntlmNegotiateAlwaysSign |
+		ntlmNegotiateExtendedSessionSecurity | ntlmNegotiateTargetInfo | ntlmNegotiate128 | ntlmNegotiate56
+)
+
+var ntlmSignature = []byte("NTLMSSP\x00")
+
+// windowsEpochOffset is the number of 100ns intervals between 1601-01-01
+// and 1970-01-01.
+const windowsEpochOffset = 116444736000000000
+
+func ntlmNegotiateMessage() []byte {
+	msg := make([]byte, 32)
+	copy(msg, ntlmSignature)
+	binary.LittleEndian.PutUint32(msg[8:], 1)
+	binary.LittleEndian.PutUint32(msg[12:], ntlmFlags)
+	// empty domain and workstation fields
+
+	return msg
+}
+
+type ntlmChallenge struct {
+	flags           uint32
+	serverChallenge []byte
+	targetInfo      []byte
+}
+
+func parseNTLMChallenge(msg []byte) (*ntlmChallenge, error) {
+	if len(msg) < 48 || !bytes.Equal(msg[:8], ntlmSignature) || binary.LittleEndian.Uint32(msg[8:]) != 2 {
+		return nil, errors.New("invalid NTLM challenge message")
+	}
+
+	challenge := &ntlmChallenge{
+		flags:           binary.LittleEndian.Uint32(msg[20:]),
+		serverChallenge: msg[24:32],
+	}
+
+	length := int(binary.LittleEndian.Uint16(msg[40:]))
+	offset := int(binary.LittleEndian.Uint32(msg[44:]))
+	if offset+length > len(msg) {
+		return nil, errors.New("invalid NTLM challenge message")
+	}
+	challenge.targetInfo = msg[offset : offset+length]
+
+	return challenge, nil
+}
+
+func ntlmAuthenticateMessage(challengeMsg []byte, domain, user, password string) ([]byte, error) {
+	challenge, err := parseNTLMChallenge(challengeMsg)
+	if err != nil {
+		return nil, err
+	}
+
+	clientChallenge := make([]byte, 8)
+	if _, err := rand.Read(clientChallenge); err != nil {
+		return nil, err
+	}
+
+	responseKey := ntowfv2(domain, user, password)
+	ntResponse := ntlmv2Response(responseKey, challenge.serverChallenge, clientChallenge, time.Now(), challenge.targetInfo)
+	lmResponse := append(hmacMD5(responseKey, challenge.serverChallenge, clientChallenge), clientChallenge...)
+
+	payloads := [][]byte{
+		lmResponse,
+		ntResponse,
+		encodeUTF16(domain),
+		encodeUTF16(user),
+		nil, // workstation
+		nil, // encrypted random session key
+	}
+
+	const headerLen = 64
+
+	msg := make([]byte, headerLen)
+	copy(msg, ntlmSignature)
+	binary.LittleEndian.PutUint32(msg[8:], 3)
+
+	offset := headerLen
+	for i, payload := range payloads {
+		field := msg[12+8*i:]
+		binary.LittleEndian.PutUint16(field[0:], uint16(len(payload)))
+		binary.LittleEndian.PutUint16(field[2:], uint16(len(payload)))
+		binary.LittleEndian.PutUint32(field[4:], uint32(offset))
+		offset += len(payload)
+	}
+	binary.LittleEndian.PutUint32(msg[60:], challenge.flags&ntlmFlags)
+
+	for _, payload := range payloads {
+		msg = append(msg, payload...)
+	}
+
+	return msg, nil
+}
+
+// ntowfv2 computes the NTLMv2 response key from the user's credentials.
+func ntowfv2(domain, user, password string) []byte {
+	h := md4.New()
+	h.Write(encodeUTF16(password))
+
+	return hmacMD5(h.Sum(nil), encodeUTF16(strings.ToUpper(user)+domain))
+}
+
+// ntlmv2Response computes the NTLMv2 response to a server challenge:
+// NTProofStr followed by the client's blob.
+func ntlmv2Response(responseKey, serverChallenge, clientChallenge []byte, t time.Time, targetInfo []byte) []byte {
+	timestamp := make([]byte, 8)
+	if !t.IsZero() {
+		binary.LittleEndian.PutUint64(timestamp, uint64(t.UnixNano()/100+windowsEpochOffset))
+	}
+
+	var blob []byte
+	blob = append(blob, 0x01, 0x01, 0, 0, 0, 0, 0, 0)
+	blob = append(blob, timestamp...)
+	blob = append(blob, clientChallenge...)
+	blob = append(blob, 0, 0, 0, 0)
+	blob = append(blob, targetInfo...)
+	blob = append(blob, 0, 0, 0, 0)
+
+	return append(hmacMD5(responseKey, serverChallenge, blob), blob...)
+}
+
+func hmacMD5(key []byte, data ...[]byte) []byte {
+	mac := hmac.New(md5.New, key)
+	for _, d := range data {
+		mac.Write(d)
+	}
+
+	return mac.Sum(nil)
+}
+
+func encodeUTF16(s string) []byte {
+	codes := utf16.Encode([]rune(s))
+
+	b := make([]byte, 2*len(codes))
+	for i, c := range codes {
+		binary.LittleEndian.PutUint16(b[2*i:], c)
+	}
+
+	return b
+}
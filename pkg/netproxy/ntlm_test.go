@@ -0,0 +1,27 @@
+package netproxy
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Test vectors from [MS-NLMP] section 4.2.4
+func TestNTLMv2(t *testing.T) {
+	responseKey := ntowfv2("Domain", "User", "Password")
+	require.Equal(t, "0c868a403bfd7a93a3001ef22ef02e3f", hex.EncodeToString(responseKey))
+
+	serverChallenge, _ := hex.DecodeString("0123456789abcdef")
+	clientChallenge, _ := hex.DecodeString("aaaaaaaaaaaaaaaa")
+	targetInfo, _ := hex.DecodeString("02000c0044006f006d00610069006e0001000c0053006500720076006500720000000000")
+
+	response := ntlmv2Response(responseKey, serverChallenge, clientChallenge, time.Time{}, targetInfo)
+	require.Equal(t, "68cd0ab851e51c96aabc927bebef6a1c", hex.EncodeToString(response[:16]))
+}
+
+func TestParseNTLMChallengeRejectsGarbage(t *testing.T) {
+	_, err := parseNTLMChallenge([]byte("not a challenge"))
+	require.EqualError(t, err, "invalid NTLM challenge message")
+}
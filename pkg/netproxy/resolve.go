@@ -0,0 +1,148 @@
+package netproxy
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// IP versions connections are made with
+const (
+	// IPAny connects with either IPv4 or IPv6, as the system prefers
+	IPAny = ""
+	// IPv4 only connects to IPv4 addresses
+	IPv4 = "ipv4"
+	// IPv6 only connects to IPv6 addresses
+	IPv6 = "ipv6"
+)
+
+// Resolver sets the addresses connections to Stripe are made to: the
+// addresses of the --resolve overrides, or the ones of DNS with the IP
+// version of --ipv4 or --ipv6. The zero value dials like net.Dialer.
+type Resolver struct {
+	// Overrides maps a host and port, like api.stripe.com:443, to the IP
+	// address connections to it are made to
+	Overrides map[string]string
+
+	// IPVersion is the IP version connections are made with: IPAny, IPv4
+	// or IPv6
+	IPVersion string
+}
+
+// ParseResolveOverrides parses --resolve overrides written like curl's, as
+// host:port:address. IPv6 addresses can be written in brackets.
+func ParseResolveOverrides(entries []string) (map[string]string, error) {
+	overrides := make(map[string]string, len(entries))
+
+	for _, entry := range entries {
+		parts := strings.SplitN(entry, ":", 3)
+		if len(parts) != 3 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid --resolve ‘%s’, expected host:port:address", entry)
+		}
+
+		host, port, address := strings.ToLower(parts[0]), parts[1], strings.Trim(parts[2], "[]")
+
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return nil, fmt.Errorf("invalid port ‘%s’ of --resolve ‘%s’", port, entry)
+		}
+		if net.ParseIP(address) == nil {
+			return nil, fmt.Errorf("invalid IP address ‘%s’ of --resolve ‘%s’", address, entry)
+		}
+
+		overrides[net.JoinHostPort(host, port)] = address
+	}
+
+	return overrides, nil
+}
+
+var (
+	resolverMu sync.RWMutex
+	resolver   = &Resolver{}
+)
+
+// SetResolver sets the resolver of the connections made with DialContext.
+func SetResolver(r *Resolver) {
+	if r == nil {
+		r = &Resolver{}
+	}
+
+	resolverMu.Lock()
+	defer resolverMu.Unlock()
+
+	resolver = r
+}
+
+func currentResolver() *Resolver {
+	resolverMu.RLock()
+	defer resolverMu.RUnlock()
+
+	return resolver
+}
+
+// DialContext connects to addr directly, with the resolver set with
+// SetResolver. It's the dialer of the connections to the API, to the
+// websocket of `listen` and to proxy servers.
+func DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	network, addr, err := currentResolver().resolve(network, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+
+	return dialer.DialContext(ctx, network, addr)
+}
+
+// resolve returns the network and address addr is dialed with.
+func (r *Resolver) resolve(network, addr string) (string, string, error) {
+	if address, ok := r.Overrides[strings.ToLower(addr)]; ok {
+		_, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return "", "", err
+		}
+		addr = net.JoinHostPort(address, port)
+	}
+
+	if network != "tcp" {
+		return network, addr, nil
+	}
+
+	switch r.IPVersion {
+	case IPv4:
+		network = "tcp4"
+	case IPv6:
+		network = "tcp6"
+	}
+
+	// An override to an address of the other IP version can't be dialed
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		if ip := net.ParseIP(host); ip != nil {
+			switch {
+			case network == "tcp4" && ip.To4() == nil:
+				return "", "", fmt.Errorf("cannot connect to the IPv6 address %s with IPv4 only", addr)
+			case network == "tcp6" && ip.To4() != nil:
+				return "", "", fmt.Errorf("cannot connect to the IPv4 address %s with IPv6 only", addr)
+			}
+		}
+	}
+
+	return network, addr, nil
+}
+
+// directDialer dials with DialContext, for the dialers of golang.org/x/net/proxy
+type directDialer struct{}
+
+func (directDialer) Dial(network, addr string) (net.Conn, error) {
+	return DialContext(context.Background(), network, addr)
+}
+
+func (directDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	return DialContext(ctx, network, addr)
+}
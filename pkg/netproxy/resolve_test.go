@@ -0,0 +1,106 @@
+package netproxy
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseResolveOverrides(t *testing.T) {
+	overrides, err := ParseResolveOverrides([]string{
+		"api.stripe.com:443:10.0.0.5",
+		"Files.Stripe.com:443:[2600:1f18::5]",
+		"stripe.com:8443:2600:1f18::6",
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"api.stripe.com:443":   "10.0.0.5",
+		"files.stripe.com:443": "2600:1f18::5",
+		"stripe.com:8443":      "2600:1f18::6",
+	}, overrides)
+
+	for entry, message := range map[string]string{
+		"api.stripe.com:443":              "invalid --resolve ‘api.stripe.com:443’, expected host:port:address",
+		":443:10.0.0.5":                   "invalid --resolve ‘:443:10.0.0.5’, expected host:port:address",
+		"api.stripe.com:https:10.0.0.5":   "invalid port ‘https’ of --resolve ‘api.stripe.com:https:10.0.0.5’",
+		"api.stripe.com:443:proxy.local":  "invalid IP address ‘proxy.local’ of --resolve ‘api.stripe.com:443:proxy.local’",
+		"api.stripe.com:70000:10.0.0.5":   "invalid port ‘70000’ of --resolve ‘api.stripe.com:70000:10.0.0.5’",
+		"api.stripe.com:443:10.0.0.5:443": "invalid IP address ‘10.0.0.5:443’ of --resolve ‘api.stripe.com:443:10.0.0.5:443’",
+	} {
+		_, err := ParseResolveOverrides([]string{entry})
+		require.EqualError(t, err, message)
+	}
+}
+
+func TestResolverResolve(t *testing.T) {
+	r := &Resolver{Overrides: map[string]string{
+		"api.stripe.com:443":   "10.0.0.5",
+		"files.stripe.com:443": "2600:1f18::5",
+	}}
+
+	network, addr, err := r.resolve("tcp", "API.stripe.com:443")
+	require.NoError(t, err)
+	require.Equal(t, "tcp", network)
+	require.Equal(t, "10.0.0.5:443", addr)
+
+	network, addr, err = r.resolve("tcp", "files.stripe.com:443")
+	require.NoError(t, err)
+	require.Equal(t, "tcp", network)
+	require.Equal(t, "[2600:1f18::5]:443", addr)
+
+	// Only the port of the override is resolved to its address
+	_, addr, err = r.resolve("tcp", "api.stripe.com:80")
+	require.NoError(t, err)
+	require.Equal(t, "api.stripe.com:80", addr)
+
+	r.IPVersion = IPv4
+	network, addr, err = r.resolve("tcp", "dashboard.stripe.com:443")
+	require.NoError(t, err)
+	require.Equal(t, "tcp4", network)
+	require.Equal(t, "dashboard.stripe.com:443", addr)
+
+	_, _, err = r.resolve("tcp", "files.stripe.com:443")
+	require.EqualError(t, err, "cannot connect to the IPv6 address [2600:1f18::5]:443 with IPv4 only")
+
+	r.IPVersion = IPv6
+	network, _, err = r.resolve("tcp", "dashboard.stripe.com:443")
+	require.NoError(t, err)
+	require.Equal(t, "tcp6", network)
+
+	_, _, err = r.resolve("tcp", "api.stripe.com:443")
+	require.EqualError(t, err, "cannot connect to the IPv4 address 10.0.0.5:443 with IPv6 only")
+}
+
+func TestDialContextWithOverride(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		conn.Write([]byte("hello"))
+		conn.Close()
+	}()
+
+	_, port, _ := net.SplitHostPort(ln.Addr().String())
+
+	SetResolver(&Resolver{
+		Overrides: map[string]string{"api.stripe.invalid:" + port: "127.0.0.1"},
+		IPVersion: IPv4,
+	})
+	defer SetResolver(nil)
+
+	conn, err := DialContext(context.Background(), "tcp", "api.stripe.invalid:"+port)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	received, err := ioutil.ReadAll(conn)
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(received))
+}
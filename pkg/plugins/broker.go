@@ -0,0 +1,241 @@
+package plugins
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/stripe/stripe-cli/pkg/stripe"
+)
+
+// brokerTokenPrefix prefixes the tokens plugins authenticate to their broker
+// with, so that they're recognizable when they leak
+const brokerTokenPrefix = "sk_plugin_"
+
+// Broker proxies the API requests of a plugin to Stripe, so that the plugin
+// never sees the key of the user. The plugin authenticates with a token that
+// is only valid while the plugin runs, and may only send the requests its
+// manifest declares. Requests are sent with a stripe.Client, so the read-only
+// mode, live mode policy, audit log, rate limiter and proxy of the CLI apply
+// to them like to its own requests.
+type Broker struct {
+	// Permissions are the requests the plugin may send, as "METHOD /path"
+	// entries. Paths may contain wildcards, e.g. "GET /v1/customers/*".
+	Permissions []string
+
+	token    string
+	client   *stripe.Client
+	listener net.Listener
+	server   *http.Server
+}
+
+// brokerSkippedHeaders are the headers of the requests of a plugin that aren't
+// forwarded to Stripe: the token of the plugin, and the headers the client
+// sets itself
+var brokerSkippedHeaders = map[string]bool{
+	"Accept-Encoding":   true,
+	"Authorization":     true,
+	"Connection":        true,
+	"Content-Length":    true,
+	"Transfer-Encoding": true,
+	"User-Agent":        true,
+}
+
+// StartBroker starts a broker for a plugin with permissions, sending its
+// requests with client
+func StartBroker(permissions []string, client *stripe.Client) (*Broker, error) {
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Broker{
+		Permissions: permissions,
+		token:       brokerTokenPrefix + hex.EncodeToString(secret),
+		client:      client,
+		listener:    listener,
+	}
+
+	b.server = &http.Server{Handler: b.handler(http.HandlerFunc(b.forward))}
+	go b.server.Serve(listener) // #nosec G104
+
+	return b, nil
+}
+
+// Env returns the environment variables the plugin reaches the broker with
+func (b *Broker) Env() []string {
+	return []string{
+		"STRIPE_API_BASE=" + b.URL(),
+		"STRIPE_API_KEY=" + b.token,
+	}
+}
+
+// URL returns the base URL of the broker
+func (b *Broker) URL() string {
+	return "http://" + b.listener.Addr().String()
+}
+
+// Close stops the broker, invalidating its token
+func (b *Broker) Close() error {
+	return b.server.Close()
+}
+
+func (b *Broker) handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(b.tokenOf(r)), []byte(b.token)) != 1 {
+			writeBrokerError(w, http.StatusUnauthorized, "the plugin's token is invalid or expired")
+			return
+		}
+
+		if !b.Allows(r.Method, r.URL.Path) {
+			writeBrokerError(w, http.StatusForbidden, fmt.Sprintf("the plugin is not permitted to send %s %s. Its manifest must declare this permission", r.Method, r.URL.Path))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// forward sends a request of the plugin to Stripe and copies the response
+// back to the plugin
+func (b *Broker) forward(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.RawQuery
+	if r.Method == http.MethodPost {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			writeBrokerError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		params = string(body)
+	}
+
+	resp, err := b.client.PerformRequest(r.Context(), r.Method, r.URL.Path, params, func(req *http.Request) {
+		for name, values := range r.Header {
+			if !brokerSkippedHeaders[http.CanonicalHeaderKey(name)] {
+				req.Header[name] = values
+			}
+		}
+	})
+	if err != nil {
+		var readOnlyErr stripe.ReadOnlyError
+		var liveModeErr stripe.LiveModeNotConfirmedError
+		if errors.As(err, &readOnlyErr) || errors.As(err, &liveModeErr) {
+			writeBrokerError(w, http.StatusForbidden, err.Error())
+		} else {
+			writeBrokerError(w, http.StatusBadGateway, err.Error())
+		}
+		return
+	}
+	defer resp.Body.Close()
+
+	for name, values := range resp.Header {
+		w.Header()[name] = values
+	}
+	w.WriteHeader(resp.StatusCode)
+	io.Copy(w, resp.Body) // #nosec G104
+}
+
+// tokenOf returns the token a request is authenticated with, as a bearer
+// token or as the username of basic auth, like the API accepts keys
+func (b *Broker) tokenOf(r *http.Request) string {
+	if username, _, ok := r.BasicAuth(); ok {
+		return username
+	}
+
+	return strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+}
+
+// Allows returns true if the permissions let the plugin send a request with
+// method to requestPath
+func (b *Broker) Allows(method, requestPath string) bool {
+	return stripe.MatchesOperation(b.Permissions, method, requestPath)
+}
+
+// writeBrokerError responds with an error shaped like the errors of the API,
+// so that the SDKs plugins use surface it
+func writeBrokerError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `{"error":{"type":"invalid_request_error","message":%q}}`, message)
+}
+
+// scrubEnv removes the variables of the CLI's own environment that may hold
+// credentials, like STRIPE_API_KEY, from the environment of a plugin
+func scrubEnv(environ []string) []string {
+	var scrubbed []string
+
+	for _, variable := range environ {
+		if strings.HasPrefix(strings.ToUpper(variable), "STRIPE_") {
+			continue
+		}
+		scrubbed = append(scrubbed, variable)
+	}
+
+	return scrubbed
+}
+
+// ErrPermissionsNotGranted is returned when the user didn't grant a plugin
+// the permissions its manifest declares
+var ErrPermissionsNotGranted = errors.New("the permissions of the plugin were not granted")
+
+// IsBrokered returns whether the plugin runs without the credentials of the
+// CLI, its API requests going through a Broker. The plugins of third parties
+// always are, Stripe's plugins are when they declare permissions.
+func (p *Plugin) IsBrokered() bool {
+	return p.IsThirdParty() || len(p.Permissions) > 0
+}
+
+// ungrantedPermissions returns the permissions of the plugin that are not in
+// granted
+func (p *Plugin) ungrantedPermissions(granted []string) []string {
+	var ungranted []string
+
+	for _, permission := range p.Permissions {
+		found := false
+		for _, g := range granted {
+			if g == permission {
+				found = true
+				break
+			}
+		}
+		if !found {
+			ungranted = append(ungranted, permission)
+		}
+	}
+
+	return ungranted
+}
+
+// promptPermissions asks the user to grant the plugin the permissions it
+// declares, returning ErrPermissionsNotGranted unless they do
+func (p *Plugin) promptPermissions(ungranted []string, in io.Reader, out io.Writer) error {
+	fmt.Fprintf(out, "The plugin %s requests permission to send these API requests with your key:\n", p.Shortname)
+	for _, permission := range ungranted {
+		fmt.Fprintf(out, "  %s\n", permission)
+	}
+	fmt.Fprint(out, "Enter 'yes' to grant them: ")
+
+	input, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	if strings.ToLower(strings.TrimSpace(input)) != "yes" {
+		return ErrPermissionsNotGranted
+	}
+
+	return nil
+}
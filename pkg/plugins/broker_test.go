@@ -0,0 +1,109 @@
+package plugins
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/stripe"
+)
+
+func TestBroker(t *testing.T) {
+	var authorization, stripeAccount, body string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		stripeAccount = r.Header.Get("Stripe-Account")
+		data, _ := ioutil.ReadAll(r.Body)
+		body = string(data)
+		w.Header().Set("Request-Id", "req_123")
+		w.Write([]byte(`{"object":"list"}`))
+	}))
+	defer api.Close()
+
+	apiURL, _ := url.Parse(api.URL)
+	broker, err := StartBroker([]string{"GET /v1/customers", "POST /v1/customers/*"}, &stripe.Client{BaseURL: apiURL, APIKey: "sk_test_123"})
+	require.NoError(t, err)
+	defer broker.Close()
+
+	var token string
+	for _, variable := range broker.Env() {
+		if strings.HasPrefix(variable, "STRIPE_API_KEY=") {
+			token = strings.TrimPrefix(variable, "STRIPE_API_KEY=")
+		}
+	}
+	require.True(t, strings.HasPrefix(token, brokerTokenPrefix))
+
+	send := func(method, path, token string) *http.Response {
+		req, _ := http.NewRequest(method, broker.URL()+path, strings.NewReader("name=Jenny"))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Stripe-Account", "acct_123")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	resp := send(http.MethodGet, "/v1/customers", token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "req_123", resp.Header.Get("Request-Id"))
+	require.Equal(t, "Bearer sk_test_123", authorization)
+	require.Equal(t, "acct_123", stripeAccount)
+
+	resp = send(http.MethodPost, "/v1/customers/cus_123", token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "name=Jenny", body)
+
+	resp = send(http.MethodPost, "/v1/charges", token)
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = send(http.MethodPost, "/v1/customers/..", token)
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = send(http.MethodGet, "/v1/customers", "sk_test_123")
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	stripe.SetReadOnly(true)
+	defer stripe.SetReadOnly(false)
+
+	resp = send(http.MethodPost, "/v1/customers/cus_123", token)
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = send(http.MethodGet, "/v1/customers", token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestScrubEnv(t *testing.T) {
+	env := scrubEnv([]string{"PATH=/bin", "STRIPE_API_KEY=sk_test_123", "stripe_device_name=laptop", "HOME=/root"})
+	require.Equal(t, []string{"PATH=/bin", "HOME=/root"}, env)
+}
+
+func TestUngrantedPermissions(t *testing.T) {
+	plugin := Plugin{Permissions: []string{"GET /v1/customers", "POST /v1/charges"}}
+
+	require.Equal(t, []string{"POST /v1/charges"}, plugin.ungrantedPermissions([]string{"GET /v1/customers"}))
+	require.Empty(t, plugin.ungrantedPermissions(plugin.Permissions))
+}
+
+func TestPromptPermissions(t *testing.T) {
+	plugin := Plugin{Shortname: "deploy"}
+	var out bytes.Buffer
+
+	err := plugin.promptPermissions([]string{"GET /v1/customers"}, strings.NewReader("yes\n"), &out)
+	require.NoError(t, err)
+	require.Contains(t, out.String(), "GET /v1/customers")
+
+	err = plugin.promptPermissions([]string{"GET /v1/customers"}, strings.NewReader("no\n"), &out)
+	require.ErrorIs(t, err, ErrPermissionsNotGranted)
+}
+
+func TestIsBrokered(t *testing.T) {
+	require.False(t, (&Plugin{}).IsBrokered())
+	require.True(t, (&Plugin{ManifestURL: "https://example.com/plugins.toml"}).IsBrokered())
+	require.True(t, (&Plugin{Permissions: []string{"GET /v1/customers"}}).IsBrokered())
+}
@@ -0,0 +1,257 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/rpc"
+	"os"
+
+	hcplugin "github.com/hashicorp/go-plugin"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+)
+
+// hostServiceID is the ID of the stream the CLI serves the Host on. It's far
+// above the IDs the MuxBroker allocates, so that they never collide.
+const hostServiceID = 1 << 30
+
+// hostEnv is set in the environment of the plugins the CLI serves the Host
+// to, so that they don't wait for a Host that older CLIs don't serve
+const hostEnv = "STRIPE_CLI_PLUGIN_HOST"
+
+// ErrNoHost is returned to plugins run by a CLI that doesn't serve the Host
+var ErrNoHost = errors.New("the CLI running the plugin doesn't serve a host")
+
+// Host is the interface the CLI exposes to the plugins it runs, so that they
+// send API requests with its authenticated client and print through its
+// formatting, instead of reimplementing auth, retries and telemetry
+type Host interface {
+	Request(req HostRequest) (HostResponse, error)
+	Profile() (HostProfile, error)
+	Print(output HostOutput) error
+}
+
+// HostRequest is an API request sent through the Host
+type HostRequest struct {
+	Method string
+	// Path is the path of the request, e.g. /v1/customers
+	Path string
+	// Params are the form-encoded parameters of the request
+	Params         string
+	StripeAccount  string
+	IdempotencyKey string
+}
+
+// HostResponse is the response to a HostRequest
+type HostResponse struct {
+	Status int
+	Body   string
+}
+
+// HostProfile contains the non-secret fields of the profile the CLI runs with
+type HostProfile struct {
+	Name        string
+	AccountID   string
+	DisplayName string
+	DeviceName  string
+}
+
+// Kinds of HostOutput
+const (
+	OutputText    = "text"
+	OutputSuccess = "success"
+	OutputWarning = "warning"
+	OutputError   = "error"
+	OutputJSON    = "json"
+)
+
+// HostOutput is output printed through the Host
+type HostOutput struct {
+	// Kind is how the text is formatted, one of the Output constants
+	Kind string
+	Text string
+}
+
+// Server -----------------------------------------------
+
+// HostRPCServer is the RPC server the CLI serves the Host on, conforming to
+// the requirements of net/rpc
+type HostRPCServer struct {
+	Impl Host
+}
+
+// Request sends an API request through the Host
+func (s *HostRPCServer) Request(req HostRequest, resp *HostResponse) error {
+	var err error
+	*resp, err = s.Impl.Request(req)
+	return err
+}
+
+// Profile returns the non-secret fields of the profile
+func (s *HostRPCServer) Profile(args interface{}, resp *HostProfile) error {
+	var err error
+	*resp, err = s.Impl.Profile()
+	return err
+}
+
+// Print prints output through the Host
+func (s *HostRPCServer) Print(output HostOutput, resp *interface{}) error {
+	return s.Impl.Print(output)
+}
+
+// Plugin Client ---------------------------------------------------
+
+// HostRPCClient is the implementation of Host plugins talk to over RPC
+type HostRPCClient struct {
+	client *rpc.Client
+}
+
+// Request sends an API request through the CLI
+func (c *HostRPCClient) Request(req HostRequest) (HostResponse, error) {
+	var resp HostResponse
+	err := c.client.Call("Plugin.Request", req, &resp)
+	return resp, err
+}
+
+// Profile returns the non-secret fields of the profile the CLI runs with
+func (c *HostRPCClient) Profile() (HostProfile, error) {
+	var resp HostProfile
+	err := c.client.Call("Plugin.Profile", new(interface{}), &resp)
+	return resp, err
+}
+
+// Print prints output through the CLI
+func (c *HostRPCClient) Print(output HostOutput) error {
+	return c.client.Call("Plugin.Print", output, new(interface{}))
+}
+
+// ConnectHost connects to the Host of the CLI running the plugin. Plugins
+// call it from RunCommand.
+func (p *CLIPluginV1) ConnectHost() (Host, error) {
+	if p.broker == nil || os.Getenv(hostEnv) == "" {
+		return nil, ErrNoHost
+	}
+
+	conn, err := p.broker.Dial(hostServiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HostRPCClient{client: rpc.NewClient(conn)}, nil
+}
+
+// serveHost serves host to the plugin on broker until done is closed. Every
+// call to Host by the plugin is a new connection.
+func serveHost(broker *hcplugin.MuxBroker, host Host, done <-chan struct{}) {
+	server := rpc.NewServer()
+	if err := server.RegisterName("Plugin", &HostRPCServer{Impl: host}); err != nil {
+		return
+	}
+
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+
+		// Accept times out when the plugin doesn't connect, in which case
+		// it's accepted again
+		conn, err := broker.Accept(hostServiceID)
+		if err != nil {
+			continue
+		}
+
+		go server.ServeConn(conn)
+	}
+}
+
+// CLI implementation ---------------------------------------------------
+
+// cliHost is the Host the CLI serves to a plugin
+type cliHost struct {
+	ctx    context.Context
+	cfg    *config.Config
+	plugin *Plugin
+	out    io.Writer
+}
+
+// Request sends an API request with the key of the profile. The requests of
+// brokered plugins are limited to their permissions.
+func (h *cliHost) Request(req HostRequest) (HostResponse, error) {
+	if h.plugin.IsBrokered() && !stripe.MatchesOperation(h.plugin.Permissions, req.Method, req.Path) {
+		return HostResponse{}, fmt.Errorf("the plugin is not permitted to send %s %s. Its manifest must declare this permission", req.Method, req.Path)
+	}
+
+	client, err := newAPIClient(h.cfg.GetProfile())
+	if err != nil {
+		return HostResponse{}, err
+	}
+
+	resp, err := client.PerformRequest(h.ctx, req.Method, req.Path, req.Params, func(r *http.Request) {
+		if req.StripeAccount != "" {
+			r.Header.Set("Stripe-Account", req.StripeAccount)
+		}
+		if req.IdempotencyKey != "" {
+			r.Header.Set("Idempotency-Key", req.IdempotencyKey)
+		}
+	})
+	if err != nil {
+		return HostResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return HostResponse{}, err
+	}
+
+	return HostResponse{Status: resp.StatusCode, Body: string(body)}, nil
+}
+
+// Profile returns the non-secret fields of the profile
+func (h *cliHost) Profile() (HostProfile, error) {
+	profile := h.cfg.GetProfile()
+
+	accountID, _ := profile.GetAccountID()
+	deviceName, _ := profile.GetDeviceName()
+
+	return HostProfile{
+		Name:        profile.ProfileName,
+		AccountID:   accountID,
+		DisplayName: profile.GetDisplayName(),
+		DeviceName:  deviceName,
+	}, nil
+}
+
+// Print prints output with the formatting of the CLI
+func (h *cliHost) Print(output HostOutput) error {
+	color := ansi.Color(h.out)
+
+	var text string
+
+	switch output.Kind {
+	case OutputText, "":
+		text = output.Text
+	case OutputSuccess:
+		text = color.Green(output.Text).String()
+	case OutputWarning:
+		text = color.Yellow(output.Text).String()
+	case OutputError:
+		text = color.Red(output.Text).String()
+	case OutputJSON:
+		text = ansi.ColorizeJSON(output.Text, false, h.out)
+	default:
+		return fmt.Errorf("unknown output kind ‘%s’", output.Kind)
+	}
+
+	_, err := fmt.Fprintln(h.out, text)
+
+	return err
+}
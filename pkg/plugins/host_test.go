@@ -0,0 +1,101 @@
+package plugins
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	hcplugin "github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+)
+
+type testHost struct {
+	requests []HostRequest
+	outputs  []HostOutput
+}
+
+func (h *testHost) Request(req HostRequest) (HostResponse, error) {
+	h.requests = append(h.requests, req)
+	return HostResponse{Status: 200, Body: `{"id":"cus_123"}`}, nil
+}
+
+func (h *testHost) Profile() (HostProfile, error) {
+	return HostProfile{Name: "default", AccountID: "acct_123"}, nil
+}
+
+func (h *testHost) Print(output HostOutput) error {
+	h.outputs = append(h.outputs, output)
+	return nil
+}
+
+// hostDispatcher is a plugin that uses the Host of the CLI
+type hostDispatcher struct {
+	plugin *CLIPluginV1
+}
+
+func (d *hostDispatcher) RunCommand(args []string) (string, error) {
+	host, err := d.plugin.ConnectHost()
+	if err != nil {
+		return "", err
+	}
+
+	profile, err := host.Profile()
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := host.Request(HostRequest{Method: "GET", Path: "/v1/customers/cus_123"})
+	if err != nil {
+		return "", err
+	}
+
+	return profile.AccountID + " " + resp.Body, host.Print(HostOutput{Kind: OutputSuccess, Text: "done"})
+}
+
+func TestHost(t *testing.T) {
+	os.Setenv(hostEnv, "1")
+	defer os.Unsetenv(hostEnv)
+
+	host := &testHost{}
+	done := make(chan struct{})
+	defer close(done)
+
+	plugin := &CLIPluginV1{Host: host, done: done}
+	plugin.Impl = &hostDispatcher{plugin: plugin}
+
+	client, _ := hcplugin.TestPluginRPCConn(t, map[string]hcplugin.Plugin{"main": plugin}, nil)
+	defer client.Close()
+
+	raw, err := client.Dispense("main")
+	require.NoError(t, err)
+
+	out, err := raw.(Dispatcher).RunCommand(nil)
+	require.NoError(t, err)
+	require.Equal(t, `acct_123 {"id":"cus_123"}`, out)
+	require.Equal(t, []HostRequest{{Method: "GET", Path: "/v1/customers/cus_123"}}, host.requests)
+	require.Equal(t, []HostOutput{{Kind: OutputSuccess, Text: "done"}}, host.outputs)
+}
+
+func TestConnectHostWithoutHost(t *testing.T) {
+	plugin := &CLIPluginV1{}
+
+	_, err := plugin.ConnectHost()
+	require.ErrorIs(t, err, ErrNoHost)
+}
+
+func TestCLIHostRequestChecksPermissions(t *testing.T) {
+	host := &cliHost{plugin: &Plugin{ManifestURL: "https://example.com/plugins.toml", Permissions: []string{"GET /v1/customers"}}}
+
+	_, err := host.Request(HostRequest{Method: "POST", Path: "/v1/charges"})
+	require.EqualError(t, err, "the plugin is not permitted to send POST /v1/charges. Its manifest must declare this permission")
+}
+
+func TestCLIHostPrint(t *testing.T) {
+	var out bytes.Buffer
+	host := &cliHost{out: &out}
+
+	require.NoError(t, host.Print(HostOutput{Kind: OutputText, Text: "hello"}))
+	require.Equal(t, "hello\n", out.String())
+
+	require.Error(t, host.Print(HostOutput{Kind: "blink", Text: "hello"}))
+}
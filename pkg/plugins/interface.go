@@ -65,14 +65,28 @@ func (g *PluginClient) RunCommand(args []string) (string, error) {
 type CLIPluginV1 struct {
 	// Impl Injection
 	Impl Dispatcher
+
+	// Host is served to the plugin by the CLI, see CLIPluginV1.ConnectHost
+	Host Host
+
+	// done stops serving Host
+	done chan struct{}
+
+	// broker is the broker of the plugin's side of the connection
+	broker *hcplugin.MuxBroker
 }
 
 // Server returns the rpc server
-func (p *CLIPluginV1) Server(*hcplugin.MuxBroker) (interface{}, error) {
+func (p *CLIPluginV1) Server(b *hcplugin.MuxBroker) (interface{}, error) {
+	p.broker = b
 	return &DispatcherRPCServer{Impl: p.Impl}, nil
 }
 
-// Client returns the rpc client
-func (CLIPluginV1) Client(b *hcplugin.MuxBroker, c *rpc.Client) (interface{}, error) {
+// Client returns the rpc client, and starts serving the Host to the plugin
+func (p *CLIPluginV1) Client(b *hcplugin.MuxBroker, c *rpc.Client) (interface{}, error) {
+	if p.Host != nil && p.done != nil {
+		go serveHost(b, p.Host, p.done)
+	}
+
 	return &PluginClient{client: c}, nil
 }
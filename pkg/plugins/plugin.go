@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/stripe/stripe-cli/pkg/ansi"
 	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/netproxy"
 	"github.com/stripe/stripe-cli/pkg/requests"
 	"github.com/stripe/stripe-cli/pkg/stripe"
 
@@ -23,6 +25,7 @@ import (
 	hcplugin "github.com/hashicorp/go-plugin"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
+	"golang.org/x/term"
 )
 
 // dev mode vars
@@ -38,6 +41,12 @@ type Plugin struct {
 	Binary           string
 	Releases         []Release `toml:"Release"`
 	MagicCookieValue string
+	// ManifestURL is the manifest the plugin was added from, for the
+	// plugins of third parties
+	ManifestURL string `toml:"ManifestURL,omitempty"`
+	// Permissions are the API requests the plugin may send through its
+	// Broker, as "METHOD /path" entries
+	Permissions []string `toml:"Permissions,omitempty"`
 }
 
 // PluginList contains a list of plugins
@@ -51,10 +60,13 @@ type Release struct {
 	OS      string
 	Version string
 	Sum     string
+	// URL is where the binary is downloaded from, for the plugins of third
+	// parties. Stripe's plugins are downloaded from Stripe.
+	URL string `toml:"URL,omitempty"`
 }
 
 // getPluginInterface computes the correct metadata needed for starting the hcplugin client
-func (p *Plugin) getPluginInterface() (hcplugin.HandshakeConfig, map[int]hcplugin.PluginSet) {
+func (p *Plugin) getPluginInterface(host Host, done chan struct{}) (hcplugin.HandshakeConfig, map[int]hcplugin.PluginSet) {
 	handshakeConfig := hcplugin.HandshakeConfig{
 		MagicCookieKey:   fmt.Sprintf("plugin_%s", p.Shortname),
 		MagicCookieValue: p.MagicCookieValue,
@@ -64,7 +76,7 @@ func (p *Plugin) getPluginInterface() (hcplugin.HandshakeConfig, map[int]hcplugi
 	// we just have one called "main" for each of our plugins for now
 	pluginSetMap := map[int]hcplugin.PluginSet{
 		1: {
-			"main": &CLIPluginV1{},
+			"main": &CLIPluginV1{Host: host, done: done},
 		},
 	}
 
@@ -138,6 +150,18 @@ func (p *Plugin) getChecksum(version string) ([]byte, error) {
 	return decoded, nil
 }
 
+// getReleaseURL returns the download URL of a version of the plugin for the
+// current platform, set for the plugins of third parties
+func (p *Plugin) getReleaseURL(version string) string {
+	for _, pkg := range p.Releases {
+		if pkg.OS == runtime.GOOS && pkg.Arch == runtime.GOARCH && pkg.Version == version {
+			return pkg.URL
+		}
+	}
+
+	return ""
+}
+
 // LookUpLatestVersion gets latest CLI version
 // note: assumes versions are listed in asc order
 func (p *Plugin) LookUpLatestVersion() string {
@@ -158,29 +182,35 @@ func (p *Plugin) LookUpLatestVersion() string {
 func (p *Plugin) Install(ctx context.Context, cfg config.IConfig, fs afero.Fs, version string, baseURL string) error {
 	spinner := ansi.StartNewSpinner(ansi.Faint(fmt.Sprintf("installing '%s' v%s...", p.Shortname, version)), os.Stdout)
 
-	apiKey, err := cfg.GetProfile().GetAPIKey(false)
+	// The plugins of third parties are downloaded from their own URLs,
+	// without an API key
+	pluginDownloadURL := p.getReleaseURL(version)
 
-	if err != nil {
-		ansi.StopSpinner(spinner, ansi.Faint(fmt.Sprintf("could not install plugin '%s': missing API key", p.Shortname)), os.Stdout)
-		return err
-	}
+	if pluginDownloadURL == "" {
+		apiKey, err := cfg.GetProfile().GetAPIKey(false)
 
-	pluginData, err := requests.GetPluginData(ctx, baseURL, stripe.APIVersion, apiKey, cfg.GetProfile())
+		if err != nil {
+			ansi.StopSpinner(spinner, ansi.Faint(fmt.Sprintf("could not install plugin '%s': missing API key", p.Shortname)), os.Stdout)
+			return err
+		}
 
-	if err != nil {
-		ansi.StopSpinner(spinner, ansi.Faint(fmt.Sprintf("could not install plugin '%s'", p.Shortname)), os.Stdout)
+		pluginData, err := requests.GetPluginData(ctx, baseURL, stripe.APIVersion, apiKey, cfg.GetProfile())
+
+		if err != nil {
+			ansi.StopSpinner(spinner, ansi.Faint(fmt.Sprintf("could not install plugin '%s'", p.Shortname)), os.Stdout)
 
-		log.WithFields(log.Fields{
-			"prefix": "plugins.plugin.Install",
-		}).Debugf("install error: %s", err)
+			log.WithFields(log.Fields{
+				"prefix": "plugins.plugin.Install",
+			}).Debugf("install error: %s", err)
 
-		return errors.New("you don't seem to have access to this plugin")
-	}
+			return errors.New("you don't seem to have access to this plugin")
+		}
 
-	pluginDownloadURL := fmt.Sprintf("%s/%s/%s/%s/%s/%s", pluginData.PluginBaseURL, p.Shortname, version, runtime.GOOS, runtime.GOARCH, p.Binary)
+		pluginDownloadURL = fmt.Sprintf("%s/%s/%s/%s/%s/%s", pluginData.PluginBaseURL, p.Shortname, version, runtime.GOOS, runtime.GOARCH, p.Binary)
+	}
 
 	// Pull down bin, verify, and save to disk
-	err = p.downloadAndSavePlugin(cfg, pluginDownloadURL, fs, version)
+	err := p.downloadAndSavePlugin(cfg, pluginDownloadURL, fs, version)
 
 	if err != nil {
 		ansi.StopSpinner(spinner, ansi.Faint(fmt.Sprintf("could not install plugin '%s': %s", p.Shortname, err)), os.Stdout)
@@ -350,7 +380,29 @@ func (p *Plugin) Run(ctx context.Context, config *config.Config, fs afero.Fs, ar
 
 	cmd := exec.Command(pluginBinaryPath)
 
-	handshakeConfig, pluginSetMap := p.getPluginInterface()
+	if p.IsBrokered() {
+		broker, err := p.startBroker(config)
+		if err != nil {
+			return err
+		}
+
+		cmd.Env = scrubEnv(os.Environ())
+		if broker != nil {
+			defer broker.Close()
+			cmd.Env = append(cmd.Env, broker.Env()...)
+		}
+	}
+
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, hostEnv+"=1")
+
+	host := &cliHost{ctx: ctx, cfg: config, plugin: p, out: os.Stdout}
+	done := make(chan struct{})
+	defer close(done)
+
+	handshakeConfig, pluginSetMap := p.getPluginInterface(host, done)
 	timeout, _ := time.ParseDuration("10s")
 
 	pluginLogger := hclog.New(&hclog.LoggerOptions{
@@ -408,3 +460,61 @@ func (p *Plugin) Run(ctx context.Context, config *config.Config, fs afero.Fs, ar
 
 	return nil
 }
+
+// startBroker asks the user to grant the plugin its permissions if they
+// weren't granted yet, and starts the Broker its API requests go through. It
+// returns a nil Broker when the plugin declares no permissions.
+func (p *Plugin) startBroker(config *config.Config) (*Broker, error) {
+	if len(p.Permissions) == 0 {
+		return nil, nil
+	}
+
+	if ungranted := p.ungrantedPermissions(config.GetPluginPermissions(p.Shortname)); len(ungranted) > 0 {
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return nil, fmt.Errorf("%w, run `stripe %s` interactively to grant them", ErrPermissionsNotGranted, p.Shortname)
+		}
+
+		if err := p.promptPermissions(ungranted, os.Stdin, os.Stdout); err != nil {
+			return nil, err
+		}
+
+		if err := config.WriteConfigField("plugin_permissions."+p.Shortname, p.Permissions); err != nil {
+			return nil, err
+		}
+	}
+
+	client, err := newAPIClient(config.GetProfile())
+	if err != nil {
+		return nil, err
+	}
+
+	return StartBroker(p.Permissions, client)
+}
+
+// newAPIClient returns a client sending API requests with the key and through
+// the proxy of profile
+func newAPIClient(profile *config.Profile) (*stripe.Client, error) {
+	apiKey, err := profile.GetAPIKey(false)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL, err := url.Parse(stripe.DefaultAPIBaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &stripe.Client{
+		BaseURL: baseURL,
+		APIKey:  apiKey,
+	}
+
+	if proxyURL := profile.GetProxy(); proxyURL != "" {
+		client.Proxy, err = netproxy.New(proxyURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return client, nil
+}
@@ -0,0 +1,157 @@
+package plugins
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/spf13/afero"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+)
+
+// thirdPartyManifestFile is the manifest of the plugins installed from the
+// manifests of third parties, next to the manifest of Stripe's plugins
+const thirdPartyManifestFile = "plugins-third-party.toml"
+
+var shortnameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// IsThirdParty returns whether the plugin was added from the manifest of a
+// third party, rather than Stripe's
+func (p *Plugin) IsThirdParty() bool {
+	return p.ManifestURL != ""
+}
+
+// InstalledVersion returns the version of the plugin that is installed, ""
+// if it's not installed
+func (p *Plugin) InstalledVersion(config config.IConfig, fs afero.Fs) string {
+	versions, err := afero.Glob(fs, filepath.Join(getPluginsDir(config), p.Shortname, "*.*.*"))
+	if err != nil || len(versions) == 0 {
+		return ""
+	}
+
+	return filepath.Base(versions[0])
+}
+
+// getThirdPartyPlugins returns the plugins added from the manifests of third
+// parties
+func getThirdPartyPlugins(config config.IConfig, fs afero.Fs) ([]Plugin, error) {
+	path := filepath.Join(config.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")), thirdPartyManifestFile)
+
+	file, err := afero.ReadFile(fs, path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var pluginList PluginList
+	if _, err := toml.Decode(string(file), &pluginList); err != nil {
+		return nil, err
+	}
+
+	return pluginList.Plugins, nil
+}
+
+// AddThirdPartyManifest adds the plugins of the manifest at url, which has the
+// format of Stripe's manifest with the download URL of each release, to the
+// plugins that can be installed. It returns the plugins it added.
+func AddThirdPartyManifest(config config.IConfig, fs afero.Fs, url string) ([]Plugin, error) {
+	body, err := FetchRemoteResource(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest PluginList
+	if _, err := toml.Decode(string(body), &manifest); err != nil {
+		return nil, fmt.Errorf("could not read the plugin manifest at %s: %w", url, err)
+	}
+	if len(manifest.Plugins) == 0 {
+		return nil, fmt.Errorf("the plugin manifest at %s has no plugins", url)
+	}
+
+	// Third parties can't replace Stripe's plugins
+	var stripePlugins PluginList
+	configPath := config.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME"))
+	if file, err := afero.ReadFile(fs, filepath.Join(configPath, "plugins.toml")); err == nil {
+		toml.Decode(string(file), &stripePlugins) // #nosec G104
+	}
+
+	for i := range manifest.Plugins {
+		plugin := &manifest.Plugins[i]
+		plugin.ManifestURL = url
+
+		if err := validateThirdPartyPlugin(plugin); err != nil {
+			return nil, err
+		}
+
+		for _, p := range stripePlugins.Plugins {
+			if p.Shortname == plugin.Shortname {
+				return nil, fmt.Errorf("the plugin manifest at %s has a plugin named %s, like a plugin of Stripe", url, plugin.Shortname)
+			}
+		}
+	}
+
+	existing, err := getThirdPartyPlugins(config, fs)
+	if err != nil {
+		return nil, err
+	}
+
+	plugins := manifest.Plugins
+	for _, p := range existing {
+		replaced := false
+		for _, plugin := range manifest.Plugins {
+			if plugin.Shortname == p.Shortname {
+				replaced = true
+			}
+		}
+		if !replaced {
+			plugins = append(plugins, p)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(PluginList{Plugins: plugins}); err != nil {
+		return nil, err
+	}
+
+	if err := afero.WriteFile(fs, filepath.Join(configPath, thirdPartyManifestFile), buf.Bytes(), 0644); err != nil {
+		return nil, err
+	}
+
+	return manifest.Plugins, nil
+}
+
+// validateThirdPartyPlugin checks that the releases of a plugin of a third
+// party can be downloaded and verified
+func validateThirdPartyPlugin(plugin *Plugin) error {
+	if !shortnameRegexp.MatchString(plugin.Shortname) {
+		return fmt.Errorf("invalid plugin name ‘%s’, use lowercase letters, digits and dashes", plugin.Shortname)
+	}
+	if plugin.Binary == "" || filepath.Base(plugin.Binary) != plugin.Binary {
+		return fmt.Errorf("the plugin %s has an invalid binary name ‘%s’", plugin.Shortname, plugin.Binary)
+	}
+
+	for _, permission := range plugin.Permissions {
+		if fields := strings.Fields(permission); len(fields) != 2 || !strings.HasPrefix(fields[1], "/") {
+			return fmt.Errorf("the plugin %s has an invalid permission ‘%s’, use \"METHOD /path\"", plugin.Shortname, permission)
+		}
+	}
+
+	for _, release := range plugin.Releases {
+		if release.URL == "" {
+			return fmt.Errorf("release %s of the plugin %s for %s/%s has no URL", release.Version, plugin.Shortname, release.OS, release.Arch)
+		}
+		if sum, err := hex.DecodeString(release.Sum); err != nil || len(sum) != 32 {
+			return fmt.Errorf("release %s of the plugin %s for %s/%s has no valid SHA-256 checksum", release.Version, plugin.Shortname, release.OS, release.Arch)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package plugins
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func setUpThirdPartyServer(t *testing.T, manifest func(url string) string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/plugins.toml":
+			res.Write([]byte(manifest("http://" + req.Host)))
+		case "/deploy/1.0.0":
+			res.Write([]byte("hello, I am deploy_1.0.0"))
+		default:
+			res.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func thirdPartyManifest(name string) func(url string) string {
+	sum := sha256.Sum256([]byte("hello, I am deploy_1.0.0"))
+
+	return func(url string) string {
+		return fmt.Sprintf(`[[Plugin]]
+  Shortname = "%s"
+  Shortdesc = "Deploy the app"
+  Binary = "stripe-cli-deploy"
+  MagicCookieValue = "cookie"
+
+  [[Plugin.Release]]
+    Arch = "%s"
+    OS = "%s"
+    Version = "1.0.0"
+    Sum = "%s"
+    URL = "%s/deploy/1.0.0"
+`, name, runtime.GOARCH, runtime.GOOS, hex.EncodeToString(sum[:]), url)
+	}
+}
+
+func TestAddThirdPartyManifest(t *testing.T) {
+	fs := setUpFS()
+	config := &TestConfig{}
+	server := setUpThirdPartyServer(t, thirdPartyManifest("deploy"))
+
+	added, err := AddThirdPartyManifest(config, fs, server.URL+"/plugins.toml")
+	require.NoError(t, err)
+	require.Len(t, added, 1)
+	require.Equal(t, server.URL+"/plugins.toml", added[0].ManifestURL)
+
+	plugin, err := LookUpPlugin(context.Background(), config, fs, "deploy")
+	require.NoError(t, err)
+	require.True(t, plugin.IsThirdParty())
+	require.Equal(t, "1.0.0", plugin.LookUpLatestVersion())
+
+	// Stripe's plugins are still listed
+	_, err = LookUpPlugin(context.Background(), config, fs, "appA")
+	require.NoError(t, err)
+}
+
+func TestAddThirdPartyManifestRefusesStripePluginNames(t *testing.T) {
+	fs := setUpFS()
+	config := &TestConfig{}
+	server := setUpThirdPartyServer(t, thirdPartyManifest("appA"))
+
+	_, err := AddThirdPartyManifest(config, fs, server.URL+"/plugins.toml")
+	require.Error(t, err)
+
+	exists, _ := afero.Exists(fs, "/"+thirdPartyManifestFile)
+	require.False(t, exists)
+}
+
+func TestAddThirdPartyManifestRefusesInvalidPlugins(t *testing.T) {
+	fs := setUpFS()
+	config := &TestConfig{}
+	server := setUpThirdPartyServer(t, thirdPartyManifest("../deploy"))
+
+	_, err := AddThirdPartyManifest(config, fs, server.URL+"/plugins.toml")
+	require.EqualError(t, err, "invalid plugin name ‘../deploy’, use lowercase letters, digits and dashes")
+}
+
+func TestInstallThirdPartyPlugin(t *testing.T) {
+	fs := setUpFS()
+	config := &TestConfig{}
+	server := setUpThirdPartyServer(t, thirdPartyManifest("deploy"))
+
+	_, err := AddThirdPartyManifest(config, fs, server.URL+"/plugins.toml")
+	require.NoError(t, err)
+
+	plugin, err := LookUpPlugin(context.Background(), config, fs, "deploy")
+	require.NoError(t, err)
+
+	// No API key is needed for the plugins of third parties
+	err = plugin.Install(context.Background(), config, fs, "1.0.0", "http://stripe.invalid")
+	require.NoError(t, err)
+
+	file := fmt.Sprintf("/plugins/deploy/1.0.0/stripe-cli-deploy%s", GetBinaryExtension())
+	exists, err := afero.Exists(fs, file)
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.Equal(t, "1.0.0", plugin.InstalledVersion(config, fs))
+}
@@ -56,12 +56,22 @@ func GetPluginList(ctx context.Context, config config.IConfig, fs afero.Fs) (Plu
 	configPath := config.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME"))
 	pluginManifestPath := filepath.Join(configPath, "plugins.toml")
 
+	thirdPartyPlugins, err := getThirdPartyPlugins(config, fs)
+	if err != nil {
+		return pluginList, err
+	}
+
 	file, err := afero.ReadFile(fs, pluginManifestPath)
 	if os.IsNotExist(err) {
 		log.Debug("The plugin manifest file does not exist. Downloading...")
 		err = RefreshPluginManifest(ctx, config, fs, stripe.DefaultAPIBaseURL)
 		if err != nil {
 			log.Debug("Could not download plugin manifest")
+
+			// The plugins of third parties don't need Stripe's manifest
+			if len(thirdPartyPlugins) > 0 {
+				return PluginList{Plugins: thirdPartyPlugins}, nil
+			}
 			return pluginList, err
 		}
 		file, err = afero.ReadFile(fs, pluginManifestPath)
@@ -76,6 +86,8 @@ func GetPluginList(ctx context.Context, config config.IConfig, fs afero.Fs) (Plu
 		return pluginList, err
 	}
 
+	pluginList.Plugins = append(pluginList.Plugins, thirdPartyPlugins...)
+
 	return pluginList, nil
 }
 
@@ -0,0 +1,264 @@
+// Package products creates a product and its prices from a specification,
+// including the tiered and recurring prices that are error prone to build
+// from raw parameters.
+package products
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/requests"
+)
+
+// Types of prices
+const (
+	OneTime   = "one_time"
+	Recurring = "recurring"
+)
+
+// Tiers modes of tiered prices
+const (
+	Graduated = "graduated"
+	Volume    = "volume"
+)
+
+// Intervals of recurring prices
+var Intervals = []string{"month", "year", "week", "day"}
+
+// TaxBehaviors are the tax behaviors of prices
+var TaxBehaviors = []string{"unspecified", "exclusive", "inclusive"}
+
+// zeroDecimalCurrencies are the currencies whose amounts have no minor unit
+var zeroDecimalCurrencies = map[string]bool{
+	"bif": true, "clp": true, "djf": true, "gnf": true, "jpy": true, "kmf": true,
+	"krw": true, "mga": true, "pyg": true, "rwf": true, "ugx": true, "vnd": true,
+	"vuv": true, "xaf": true, "xof": true, "xpf": true,
+}
+
+// Product is the specification of a product and its prices
+type Product struct {
+	Name        string
+	Description string
+	Prices      []Price
+}
+
+// Price is the specification of a price
+type Price struct {
+	Type     string
+	Currency string
+	// UnitAmount is the amount in the minor unit of the currency, for
+	// prices that aren't tiered
+	UnitAmount int64
+	// Interval and IntervalCount set how often recurring prices are billed
+	Interval      string
+	IntervalCount int64
+	// TiersMode is Graduated or Volume for tiered prices, which must be
+	// recurring
+	TiersMode   string
+	Tiers       []Tier
+	TaxBehavior string
+	Nickname    string
+}
+
+// Tier is a tier of a tiered price
+type Tier struct {
+	// UpTo is the last quantity of the tier, 0 for the last tier
+	UpTo       int64
+	UnitAmount int64
+	FlatAmount int64
+}
+
+// Result are the IDs of the product and prices created
+type Result struct {
+	ProductID string
+	PriceIDs  []string
+}
+
+// Validate checks that the prices can be created
+func (p *Product) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return errors.New("the product needs a name")
+	}
+	if len(p.Prices) == 0 {
+		return errors.New("the product needs at least one price")
+	}
+
+	for i, price := range p.Prices {
+		if err := price.validate(); err != nil {
+			return fmt.Errorf("price %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *Price) validate() error {
+	if p.Type != OneTime && p.Type != Recurring {
+		return fmt.Errorf("invalid type ‘%s’, expected %s or %s", p.Type, OneTime, Recurring)
+	}
+	if len(p.Currency) != 3 {
+		return fmt.Errorf("invalid currency ‘%s’", p.Currency)
+	}
+
+	if len(p.Tiers) == 0 {
+		return nil
+	}
+
+	if p.Type != Recurring {
+		return errors.New("tiered prices must be recurring")
+	}
+	if p.TiersMode != Graduated && p.TiersMode != Volume {
+		return fmt.Errorf("invalid tiers mode ‘%s’, expected %s or %s", p.TiersMode, Graduated, Volume)
+	}
+
+	var last int64
+	for i, tier := range p.Tiers {
+		isLast := i == len(p.Tiers)-1
+		if isLast != (tier.UpTo == 0) {
+			return errors.New("only the last tier must be unbounded")
+		}
+		if !isLast && tier.UpTo <= last {
+			return fmt.Errorf("tier %d must go up to more than %d", i+1, last)
+		}
+		last = tier.UpTo
+	}
+
+	return nil
+}
+
+// Params returns the parameters creating the price for product
+func (p *Price) Params(product string) []string {
+	params := []string{
+		"product=" + product,
+		"currency=" + strings.ToLower(p.Currency),
+	}
+
+	if p.TaxBehavior != "" {
+		params = append(params, "tax_behavior="+p.TaxBehavior)
+	}
+	if p.Nickname != "" {
+		params = append(params, "nickname="+p.Nickname)
+	}
+
+	if p.Type == Recurring {
+		params = append(params, "recurring[interval]="+p.Interval)
+		if p.IntervalCount > 1 {
+			params = append(params, fmt.Sprintf("recurring[interval_count]=%d", p.IntervalCount))
+		}
+	}
+
+	if len(p.Tiers) == 0 {
+		return append(params, fmt.Sprintf("unit_amount=%d", p.UnitAmount))
+	}
+
+	params = append(params, "billing_scheme=tiered", "tiers_mode="+p.TiersMode)
+	for i, tier := range p.Tiers {
+		upTo := "inf"
+		if tier.UpTo > 0 {
+			upTo = strconv.FormatInt(tier.UpTo, 10)
+		}
+		params = append(params,
+			fmt.Sprintf("tiers[%d][up_to]=%s", i, upTo),
+			fmt.Sprintf("tiers[%d][unit_amount]=%d", i, tier.UnitAmount),
+		)
+		if tier.FlatAmount > 0 {
+			params = append(params, fmt.Sprintf("tiers[%d][flat_amount]=%d", i, tier.FlatAmount))
+		}
+	}
+
+	return params
+}
+
+// Create creates the product and its prices
+func Create(ctx context.Context, baseURL, apiKey string, p *Product, profile *config.Profile) (*Result, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	params := []string{"name=" + p.Name}
+	if p.Description != "" {
+		params = append(params, "description="+p.Description)
+	}
+
+	product, err := requests.Do(ctx, http.MethodPost, baseURL, apiKey, "/v1/products", params, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{ProductID: product.Get("id").String()}
+
+	for _, price := range p.Prices {
+		created, err := requests.Do(ctx, http.MethodPost, baseURL, apiKey, "/v1/prices", price.Params(result.ProductID), profile)
+		if err != nil {
+			return result, err
+		}
+
+		result.PriceIDs = append(result.PriceIDs, created.Get("id").String())
+	}
+
+	return result, nil
+}
+
+var envNameRegexp = regexp.MustCompile(`[^A-Z0-9]+`)
+
+// EnvSnippet returns the IDs of a result as environment variables, named
+// after the nicknames of the prices when they have one
+func EnvSnippet(p *Product, result *Result) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "STRIPE_PRODUCT_ID=%s\n", result.ProductID)
+
+	for i, id := range result.PriceIDs {
+		name := "STRIPE_PRICE_ID"
+		if len(result.PriceIDs) > 1 {
+			suffix := strconv.Itoa(i + 1)
+			if nickname := strings.Trim(envNameRegexp.ReplaceAllString(strings.ToUpper(p.Prices[i].Nickname), "_"), "_"); nickname != "" {
+				suffix = nickname
+			}
+			name += "_" + suffix
+		}
+
+		fmt.Fprintf(&sb, "%s=%s\n", name, id)
+	}
+
+	return sb.String()
+}
+
+// ParseAmount parses an amount in the major unit of currency, like 19.99,
+// into its minor unit
+func ParseAmount(value, currency string) (int64, error) {
+	amount, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || amount < 0 {
+		return 0, fmt.Errorf("invalid amount ‘%s’", value)
+	}
+
+	if zeroDecimalCurrencies[strings.ToLower(currency)] {
+		if amount != math.Trunc(amount) {
+			return 0, fmt.Errorf("%s amounts can't have decimals", strings.ToUpper(currency))
+		}
+		return int64(amount), nil
+	}
+
+	cents := math.Round(amount * 100)
+	if math.Abs(cents-amount*100) > 1e-6 {
+		return 0, fmt.Errorf("invalid amount ‘%s’, use at most 2 decimals", value)
+	}
+
+	return int64(cents), nil
+}
+
+// FormatAmount formats an amount in the minor unit of currency
+func FormatAmount(amount int64, currency string) string {
+	if zeroDecimalCurrencies[strings.ToLower(currency)] {
+		return fmt.Sprintf("%d %s", amount, strings.ToUpper(currency))
+	}
+
+	return fmt.Sprintf("%d.%02d %s", amount/100, amount%100, strings.ToUpper(currency))
+}
@@ -0,0 +1,155 @@
+package products
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParamsFlat(t *testing.T) {
+	price := &Price{
+		Type:          Recurring,
+		Currency:      "USD",
+		UnitAmount:    1999,
+		Interval:      "month",
+		IntervalCount: 3,
+		TaxBehavior:   "exclusive",
+		Nickname:      "Quarterly",
+	}
+
+	require.Equal(t, []string{
+		"product=prod_123",
+		"currency=usd",
+		"tax_behavior=exclusive",
+		"nickname=Quarterly",
+		"recurring[interval]=month",
+		"recurring[interval_count]=3",
+		"unit_amount=1999",
+	}, price.Params("prod_123"))
+}
+
+func TestParamsTiered(t *testing.T) {
+	price := &Price{
+		Type:          Recurring,
+		Currency:      "eur",
+		Interval:      "year",
+		IntervalCount: 1,
+		TiersMode:     Graduated,
+		Tiers: []Tier{
+			{UpTo: 10, UnitAmount: 500, FlatAmount: 1000},
+			{UnitAmount: 400},
+		},
+	}
+
+	require.Equal(t, []string{
+		"product=prod_123",
+		"currency=eur",
+		"recurring[interval]=year",
+		"billing_scheme=tiered",
+		"tiers_mode=graduated",
+		"tiers[0][up_to]=10",
+		"tiers[0][unit_amount]=500",
+		"tiers[0][flat_amount]=1000",
+		"tiers[1][up_to]=inf",
+		"tiers[1][unit_amount]=400",
+	}, price.Params("prod_123"))
+}
+
+func TestValidate(t *testing.T) {
+	tiered := func(tiers ...Tier) Price {
+		return Price{Type: Recurring, Currency: "usd", Interval: "month", TiersMode: Volume, Tiers: tiers}
+	}
+
+	require.NoError(t, (&Product{Name: "Pro", Prices: []Price{tiered(Tier{UpTo: 5}, Tier{})}}).Validate())
+
+	require.EqualError(t, (&Product{Prices: []Price{{Type: OneTime, Currency: "usd"}}}).Validate(), "the product needs a name")
+	require.EqualError(t, (&Product{Name: "Pro"}).Validate(), "the product needs at least one price")
+	require.EqualError(t, (&Product{Name: "Pro", Prices: []Price{{Type: OneTime, Currency: "dollars"}}}).Validate(), "price 1: invalid currency ‘dollars’")
+	require.EqualError(t, (&Product{Name: "Pro", Prices: []Price{tiered(Tier{UpTo: 5}, Tier{UpTo: 10})}}).Validate(), "price 1: only the last tier must be unbounded")
+	require.EqualError(t, (&Product{Name: "Pro", Prices: []Price{tiered(Tier{UpTo: 5}, Tier{UpTo: 5}, Tier{})}}).Validate(), "price 1: tier 2 must go up to more than 5")
+
+	oneTime := tiered(Tier{})
+	oneTime.Type = OneTime
+	require.EqualError(t, (&Product{Name: "Pro", Prices: []Price{oneTime}}).Validate(), "price 1: tiered prices must be recurring")
+}
+
+func TestParseAmount(t *testing.T) {
+	amount, err := ParseAmount("19.99", "usd")
+	require.NoError(t, err)
+	require.Equal(t, int64(1999), amount)
+
+	amount, err = ParseAmount(" 5 ", "eur")
+	require.NoError(t, err)
+	require.Equal(t, int64(500), amount)
+
+	amount, err = ParseAmount("1500", "JPY")
+	require.NoError(t, err)
+	require.Equal(t, int64(1500), amount)
+
+	_, err = ParseAmount("19.999", "usd")
+	require.EqualError(t, err, "invalid amount ‘19.999’, use at most 2 decimals")
+
+	_, err = ParseAmount("15.5", "jpy")
+	require.EqualError(t, err, "JPY amounts can't have decimals")
+
+	_, err = ParseAmount("-1", "usd")
+	require.Error(t, err)
+}
+
+func TestFormatAmount(t *testing.T) {
+	require.Equal(t, "19.05 USD", FormatAmount(1905, "usd"))
+	require.Equal(t, "1500 JPY", FormatAmount(1500, "jpy"))
+}
+
+func TestEnvSnippet(t *testing.T) {
+	single := &Product{Prices: []Price{{}}}
+	require.Equal(t, "STRIPE_PRODUCT_ID=prod_123\nSTRIPE_PRICE_ID=price_1\n",
+		EnvSnippet(single, &Result{ProductID: "prod_123", PriceIDs: []string{"price_1"}}))
+
+	several := &Product{Prices: []Price{{Nickname: "Pro monthly"}, {}}}
+	require.Equal(t, "STRIPE_PRODUCT_ID=prod_123\nSTRIPE_PRICE_ID_PRO_MONTHLY=price_1\nSTRIPE_PRICE_ID_2=price_2\n",
+		EnvSnippet(several, &Result{ProductID: "prod_123", PriceIDs: []string{"price_1", "price_2"}}))
+}
+
+func TestCreate(t *testing.T) {
+	var prices []url.Values
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		params, err := url.ParseQuery(string(body))
+		require.NoError(t, err)
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/products":
+			require.Equal(t, "Pro", params.Get("name"))
+			w.Write([]byte(`{"id": "prod_123"}`))
+		case "POST /v1/prices":
+			prices = append(prices, params)
+			w.Write([]byte(`{"id": "price_` + params.Get("currency") + `"}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	result, err := Create(context.Background(), ts.URL, "sk_test_123", &Product{
+		Name: "Pro",
+		Prices: []Price{
+			{Type: OneTime, Currency: "usd", UnitAmount: 1000},
+			{Type: Recurring, Currency: "eur", UnitAmount: 900, Interval: "month"},
+		},
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, &Result{ProductID: "prod_123", PriceIDs: []string{"price_usd", "price_eur"}}, result)
+
+	require.Len(t, prices, 2)
+	require.Equal(t, "prod_123", prices[0].Get("product"))
+	require.Equal(t, "1000", prices[0].Get("unit_amount"))
+	require.Equal(t, "month", prices[1].Get("recurring[interval]"))
+}
@@ -0,0 +1,32 @@
+package proxy
+
+import (
+	"bytes"
+	"sync"
+)
+
+// maxPooledBufferSize is the capacity above which buffers are left to the
+// garbage collector rather than pooled, so one large event doesn't pin its
+// memory for the rest of the session
+const maxPooledBufferSize = 1024 * 1024
+
+// bufferPool holds the buffers reused when formatting events and reading the
+// responses of local endpoints
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBufferSize {
+		return
+	}
+
+	buf.Reset()
+	bufferPool.Put(buf)
+}
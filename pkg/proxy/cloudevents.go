@@ -0,0 +1,96 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// The CloudEvents content modes events can be forwarded in
+const (
+	// CloudEventsStructured sends the whole CloudEvent, with the Stripe event
+	// as its data, in the body
+	CloudEventsStructured = "structured"
+	// CloudEventsBinary sends the attributes of the CloudEvent in ce-*
+	// headers, and the Stripe event unchanged in the body
+	CloudEventsBinary = "binary"
+)
+
+// cloudEventsSource is the source attribute of the CloudEvents Stripe events
+// are wrapped in
+const cloudEventsSource = "https://api.stripe.com"
+
+// cloudEventsTypePrefix prefixes the Stripe event type in the type attribute,
+// like com.stripe.customer.created
+const cloudEventsTypePrefix = "com.stripe."
+
+// cloudEventAttributes returns the CloudEvents 1.0 context attributes of a
+// Stripe event.
+func cloudEventAttributes(evt *StripeEvent) map[string]string {
+	attributes := map[string]string{
+		"specversion":     "1.0",
+		"id":              evt.ID,
+		"source":          cloudEventsSource,
+		"type":            cloudEventsTypePrefix + evt.Type,
+		"time":            time.Unix(int64(evt.Created), 0).UTC().Format(time.RFC3339),
+		"datacontenttype": "application/json",
+	}
+
+	if object, ok := evt.Data["object"].(map[string]interface{}); ok {
+		if id, ok := object["id"].(string); ok {
+			attributes["subject"] = id
+		}
+	}
+
+	// Extension attribute identifying the connected account of Connect events
+	if evt.Account != "" {
+		attributes["stripeaccount"] = evt.Account
+	}
+
+	return attributes
+}
+
+// toCloudEvent wraps a Stripe event in a CloudEvent in the given content
+// mode. In structured mode, the new body is signed with secret, so that
+// endpoints can still verify the Stripe-Signature header.
+func toCloudEvent(mode string, evt *StripeEvent, body string, headers map[string]string, secret string) (string, map[string]string, error) {
+	attributes := cloudEventAttributes(evt)
+
+	converted := make(map[string]string, len(headers)+len(attributes))
+	for k, v := range headers {
+		if !strings.EqualFold(k, "Content-Type") {
+			converted[k] = v
+		}
+	}
+
+	switch mode {
+	case CloudEventsBinary:
+		for k, v := range attributes {
+			if k == "datacontenttype" {
+				continue
+			}
+			converted["ce-"+k] = v
+		}
+		converted["Content-Type"] = attributes["datacontenttype"]
+
+		return body, converted, nil
+	case CloudEventsStructured:
+		envelope := make(map[string]interface{}, len(attributes)+1)
+		for k, v := range attributes {
+			envelope[k] = v
+		}
+		envelope["data"] = json.RawMessage(body)
+
+		structured, err := json.Marshal(envelope)
+		if err != nil {
+			return "", nil, err
+		}
+
+		converted["Content-Type"] = "application/cloudevents+json; charset=utf-8"
+
+		return string(structured), resignHeaders(converted, secret, string(structured)), nil
+	default:
+		return "", nil, fmt.Errorf("unknown CloudEvents mode ‘%s’, expected %s or %s", mode, CloudEventsStructured, CloudEventsBinary)
+	}
+}
@@ -0,0 +1,82 @@
+package proxy
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var cloudEventsTestEvent = &StripeEvent{
+	ID:      "evt_123",
+	Type:    "customer.created",
+	Created: 1600000000,
+	Account: "acct_123",
+	Data: map[string]interface{}{
+		"object": map[string]interface{}{"id": "cus_123"},
+	},
+}
+
+const cloudEventsTestBody = `{"id":"evt_123","type":"customer.created"}`
+
+func TestToCloudEventBinary(t *testing.T) {
+	headers := map[string]string{
+		"Content-Type":     "application/json; charset=utf-8",
+		"Stripe-Signature": "t=123,v1=hunter2",
+	}
+
+	body, converted, err := toCloudEvent(CloudEventsBinary, cloudEventsTestEvent, cloudEventsTestBody, headers, "whsec_test")
+	require.NoError(t, err)
+	require.Equal(t, cloudEventsTestBody, body)
+	require.Equal(t, map[string]string{
+		"Content-Type":     "application/json",
+		"Stripe-Signature": "t=123,v1=hunter2",
+		"ce-specversion":   "1.0",
+		"ce-id":            "evt_123",
+		"ce-source":        "https://api.stripe.com",
+		"ce-type":          "com.stripe.customer.created",
+		"ce-time":          "2020-09-13T12:26:40Z",
+		"ce-subject":       "cus_123",
+		"ce-stripeaccount": "acct_123",
+	}, converted)
+}
+
+func TestToCloudEventStructured(t *testing.T) {
+	headers := map[string]string{
+		"Content-Type":     "application/json; charset=utf-8",
+		"Stripe-Signature": "t=123,v1=hunter2",
+	}
+
+	body, converted, err := toCloudEvent(CloudEventsStructured, cloudEventsTestEvent, cloudEventsTestBody, headers, "whsec_test")
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"specversion": "1.0",
+		"id": "evt_123",
+		"source": "https://api.stripe.com",
+		"type": "com.stripe.customer.created",
+		"time": "2020-09-13T12:26:40Z",
+		"datacontenttype": "application/json",
+		"subject": "cus_123",
+		"stripeaccount": "acct_123",
+		"data": `+cloudEventsTestBody+`
+	}`, body)
+	require.Equal(t, "application/cloudevents+json; charset=utf-8", converted["Content-Type"])
+
+	// The signature matches the new body
+	signature := converted["Stripe-Signature"]
+	timestamp, err := strconv.ParseInt(strings.TrimPrefix(strings.Split(signature, ",")[0], "t="), 10, 64)
+	require.NoError(t, err)
+	require.Equal(t, signPayload("whsec_test", body, time.Unix(timestamp, 0)), signature)
+
+	var envelope map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal([]byte(body), &envelope))
+	require.JSONEq(t, cloudEventsTestBody, string(envelope["data"]))
+}
+
+func TestToCloudEventUnknownMode(t *testing.T) {
+	_, _, err := toCloudEvent("batched", cloudEventsTestEvent, cloudEventsTestBody, nil, "whsec_test")
+	require.EqualError(t, err, "unknown CloudEvents mode ‘batched’, expected structured or binary")
+}
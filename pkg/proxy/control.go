@@ -0,0 +1,254 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/stripe/stripe-cli/pkg/loopback"
+)
+
+// ControlStatus describes the state of a running proxy, as reported by its
+// control server.
+type ControlStatus struct {
+	Paused   bool     `json:"paused"`
+	Buffered int      `json:"buffered"`
+	Events   []string `json:"events"`
+}
+
+// maxPendingEvents is the number of events buffered while forwarding is
+// paused. Events received once it is reached are dropped.
+var maxPendingEvents = 1000
+
+// pendingEvent is an event received while forwarding is paused.
+type pendingEvent struct {
+	evtCtx  eventContext
+	body    string
+	headers map[string]string
+}
+
+// Pause stops forwarding events. Events received while paused are buffered
+// until Resume is called.
+func (p *Proxy) Pause() {
+	p.controlMu.Lock()
+	defer p.controlMu.Unlock()
+
+	if p.paused {
+		return
+	}
+
+	p.paused = true
+
+	p.cfg.Log.WithFields(log.Fields{
+		"prefix": "proxy.Proxy.Pause",
+	}).Info("Paused forwarding, events are buffered until forwarding is resumed")
+}
+
+// Resume forwards the events buffered while paused, one after another in the
+// order they were received, and resumes forwarding. It returns the number of
+// events flushed.
+func (p *Proxy) Resume() int {
+	p.controlMu.Lock()
+	pending := p.pending
+	p.pending = nil
+	p.paused = false
+	p.controlMu.Unlock()
+
+	p.cfg.Log.WithFields(log.Fields{
+		"prefix": "proxy.Proxy.Resume",
+	}).Infof("Resumed forwarding, flushing %d buffered events", len(pending))
+
+	go p.flush(pending)
+
+	return len(pending)
+}
+
+// flush forwards buffered events one after another, each once the previous
+// one was forwarded.
+func (p *Proxy) flush(pending []pendingEvent) {
+	for _, evt := range pending {
+		evt := evt
+		done := make(chan struct{})
+
+		p.limiter.schedule(func() {
+			defer close(done)
+			p.forwardEvent(evt.evtCtx, evt.body, evt.headers)
+		})
+
+		<-done
+	}
+}
+
+// SetEvents replaces the list of event types the proxy listens for. Endpoints
+// loaded from the webhooks API keep their own list of enabled events.
+func (p *Proxy) SetEvents(events []string) {
+	if len(events) == 0 {
+		events = []string{"*"}
+	}
+
+	p.controlMu.Lock()
+	p.events = convertToMap(events)
+	p.cfg.Events = events
+	p.controlMu.Unlock()
+
+	if !p.cfg.UseConfiguredWebhooks {
+		for _, endpoint := range p.endpointClients {
+			endpoint.setEvents(events)
+		}
+	}
+
+	p.cfg.Log.WithFields(log.Fields{
+		"prefix": "proxy.Proxy.SetEvents",
+		"events": events,
+	}).Info("Updated the list of events to listen for")
+}
+
+// Status returns the current state of the proxy.
+func (p *Proxy) Status() ControlStatus {
+	p.controlMu.Lock()
+	defer p.controlMu.Unlock()
+
+	return ControlStatus{
+		Paused:   p.paused,
+		Buffered: len(p.pending),
+		Events:   p.cfg.Events,
+	}
+}
+
+// ControlHandler returns an HTTP handler to control the proxy while it runs:
+//
+//	GET  /status   returns the ControlStatus
+//	POST /pause    pauses forwarding
+//	POST /resume   flushes buffered events and resumes forwarding
+//	PUT  /events   replaces the event filter with the JSON body {"events": [...]}
+//
+// Requests sent by pages that aren't served by the local machine are
+// rejected.
+func (p *Proxy) ControlHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		writeControlStatus(w, p.Status())
+	})
+
+	mux.HandleFunc("/pause", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		p.Pause()
+		writeControlStatus(w, p.Status())
+	})
+
+	mux.HandleFunc("/resume", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		p.Resume()
+		writeControlStatus(w, p.Status())
+	})
+
+	mux.HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var body struct {
+			Events []string `json:"events"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, "invalid JSON body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		events, err := ExpandEventPresets(body.Events)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		for _, event := range events {
+			if !IsValidEvent(event) {
+				p.cfg.Log.Infof("Warning: You're attempting to listen for \"%s\", which isn't a valid event\n", event)
+			}
+		}
+
+		p.SetEvents(events)
+		writeControlStatus(w, p.Status())
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if origin := r.Header.Get("Origin"); origin != "" && !loopback.IsOrigin(origin) {
+			http.Error(w, "only localhost origins are allowed", http.StatusForbidden)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// ServeControl exposes ControlHandler over HTTP on addr, which must be a
+// loopback address since the control API isn't authenticated. It returns
+// once the listener is bound; requests are served in the background until
+// the process exits.
+func (p *Proxy) ServeControl(addr string) (net.Addr, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	if !loopback.IsHost(host) {
+		return nil, fmt.Errorf("the control API can only listen on a loopback address, like localhost:9108, not ‘%s’", addr)
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	server := &http.Server{
+		Handler:           p.ControlHandler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go server.Serve(listener)
+
+	return listener.Addr(), nil
+}
+
+// dispatch forwards an event, or buffers it if forwarding is paused.
+func (p *Proxy) dispatch(evtCtx eventContext, body string, headers map[string]string) {
+	p.controlMu.Lock()
+	if p.paused {
+		if len(p.pending) >= maxPendingEvents {
+			p.controlMu.Unlock()
+
+			p.cfg.Log.WithFields(log.Fields{
+				"prefix": "proxy.Proxy.dispatch",
+			}).Warnf("Dropped %s [%s]: %d events are already buffered while forwarding is paused", evtCtx.event.Type, evtCtx.event.ID, maxPendingEvents)
+			return
+		}
+
+		p.pending = append(p.pending, pendingEvent{evtCtx: evtCtx, body: body, headers: headers})
+		p.controlMu.Unlock()
+		return
+	}
+	p.controlMu.Unlock()
+
+	p.limiter.schedule(func() {
+		p.forwardEvent(evtCtx, body, headers)
+	})
+}
+
+func writeControlStatus(w http.ResponseWriter, status ControlStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
@@ -0,0 +1,152 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/websocket"
+)
+
+func TestPauseAndResume(t *testing.T) {
+	received := make(chan string, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.URL.Path
+	}))
+	defer ts.Close()
+
+	outCh := make(chan websocket.IElement, 10)
+	p, err := Init(context.Background(), &Config{
+		ForwardURL: ts.URL + "/webhooks",
+		OutCh:      outCh,
+	})
+	require.NoError(t, err)
+
+	evtCtx := eventContext{event: &StripeEvent{ID: "evt_123", Type: "customer.created"}}
+
+	p.Pause()
+	p.dispatch(evtCtx, `{"id": "evt_123"}`, map[string]string{})
+	require.Equal(t, ControlStatus{Paused: true, Buffered: 1, Events: []string{"*"}}, p.Status())
+
+	require.Equal(t, 1, p.Resume())
+	require.Equal(t, "/webhooks", <-received)
+	require.Equal(t, ControlStatus{Paused: false, Buffered: 0, Events: []string{"*"}}, p.Status())
+}
+
+func TestResumeFlushesInOrder(t *testing.T) {
+	received := make(chan string, 20)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		received <- string(body)
+	}))
+	defer ts.Close()
+
+	p, err := Init(context.Background(), &Config{
+		ForwardURL: ts.URL + "/webhooks",
+		OutCh:      make(chan websocket.IElement, 100),
+	})
+	require.NoError(t, err)
+
+	p.Pause()
+	for i := 0; i < 20; i++ {
+		id := fmt.Sprintf("evt_%d", i)
+		p.dispatch(eventContext{event: &StripeEvent{ID: id, Type: "customer.created"}}, id, map[string]string{})
+	}
+	require.Equal(t, 20, p.Resume())
+
+	for i := 0; i < 20; i++ {
+		require.Equal(t, fmt.Sprintf("evt_%d", i), <-received)
+	}
+}
+
+func TestControlHandlerSetEvents(t *testing.T) {
+	p, err := Init(context.Background(), &Config{
+		ForwardURL: "http://localhost:4242",
+		Events:     []string{"customer.created"},
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPut, "/events", strings.NewReader(`{"events": ["charge.captured"]}`))
+	rec := httptest.NewRecorder()
+	p.ControlHandler().ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"paused": false, "buffered": 0, "events": ["charge.captured"]}`, rec.Body.String())
+	require.True(t, p.supportsEventType(&StripeEvent{Type: "charge.captured"}))
+	require.False(t, p.supportsEventType(&StripeEvent{Type: "customer.created"}))
+	require.True(t, p.endpointClients[0].SupportsEventType(false, "charge.captured"))
+	require.False(t, p.endpointClients[0].SupportsEventType(false, "customer.created"))
+}
+
+func TestControlHandlerMethodNotAllowed(t *testing.T) {
+	p, err := Init(context.Background(), &Config{})
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	p.ControlHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pause", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	require.False(t, p.Status().Paused)
+}
+
+func TestControlHandlerRejectsRemoteOrigins(t *testing.T) {
+	p, err := Init(context.Background(), &Config{})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/pause", nil)
+	req.Header.Set("Origin", "https://example.com")
+	rec := httptest.NewRecorder()
+	p.ControlHandler().ServeHTTP(rec, req)
+	require.Equal(t, http.StatusForbidden, rec.Code)
+	require.False(t, p.Status().Paused)
+
+	req = httptest.NewRequest(http.MethodPost, "/pause", nil)
+	req.Header.Set("Origin", "http://localhost:3000")
+	rec = httptest.NewRecorder()
+	p.ControlHandler().ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.True(t, p.Status().Paused)
+}
+
+func TestServeControlRequiresLoopbackAddress(t *testing.T) {
+	p, err := Init(context.Background(), &Config{})
+	require.NoError(t, err)
+
+	_, err = p.ServeControl(":0")
+	require.EqualError(t, err, "the control API can only listen on a loopback address, like localhost:9108, not ‘:0’")
+
+	_, err = p.ServeControl("0.0.0.0:0")
+	require.Error(t, err)
+
+	addr, err := p.ServeControl("127.0.0.1:0")
+	require.NoError(t, err)
+	require.True(t, addr.(*net.TCPAddr).IP.IsLoopback())
+}
+
+func TestDispatchDropsEventsOverTheBufferLimit(t *testing.T) {
+	defer func(max int) { maxPendingEvents = max }(maxPendingEvents)
+	maxPendingEvents = 2
+
+	var logs bytes.Buffer
+	logger := log.New()
+	logger.SetOutput(&logs)
+
+	p, err := Init(context.Background(), &Config{Log: logger})
+	require.NoError(t, err)
+
+	p.Pause()
+	for _, id := range []string{"evt_1", "evt_2", "evt_3"} {
+		p.dispatch(eventContext{event: &StripeEvent{ID: id, Type: "customer.created"}}, `{}`, map[string]string{})
+	}
+
+	require.Equal(t, 2, p.Status().Buffered)
+	require.Contains(t, logs.String(), "Dropped customer.created [evt_3]")
+}
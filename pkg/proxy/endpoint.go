@@ -1,11 +1,12 @@
 package proxy
 
 import (
-	"bytes"
 	"io/ioutil"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -63,7 +64,8 @@ type EndpointClient struct {
 
 	connect bool
 
-	events map[string]bool
+	eventsMu sync.RWMutex
+	events   map[string]bool
 
 	// Optional configuration parameters
 	cfg *EndpointConfig
@@ -76,6 +78,9 @@ func (c *EndpointClient) SupportsEventType(connect bool, eventType string) bool
 		return false
 	}
 
+	c.eventsMu.RLock()
+	defer c.eventsMu.RUnlock()
+
 	// Endpoint supports all events, always return true
 	if c.events["*"] || c.events[eventType] {
 		return true
@@ -84,13 +89,23 @@ func (c *EndpointClient) SupportsEventType(connect bool, eventType string) bool
 	return false
 }
 
+// setEvents replaces the list of events supported by the endpoint.
+func (c *EndpointClient) setEvents(events []string) {
+	c.eventsMu.Lock()
+	defer c.eventsMu.Unlock()
+
+	c.events = convertToMap(events)
+}
+
 // Post sends a message to the local endpoint.
 func (c *EndpointClient) Post(evtCtx eventContext, body string, headers map[string]string) error {
 	c.cfg.Log.WithFields(log.Fields{
 		"prefix": "proxy.EndpointClient.Post",
 	}).Debug("Forwarding event to local endpoint")
 
-	req, err := http.NewRequest(http.MethodPost, c.URL, bytes.NewBuffer([]byte(body)))
+	// The payload is shared by every endpoint the event is forwarded to, and
+	// reading it doesn't copy it
+	req, err := http.NewRequest(http.MethodPost, c.URL, strings.NewReader(body))
 	if err != nil {
 		return err
 	}
@@ -108,8 +123,10 @@ func (c *EndpointClient) Post(evtCtx eventContext, body string, headers map[stri
 		}
 	}
 
+	start := time.Now()
 	resp, err := c.cfg.HTTPClient.Do(req)
 	if err != nil {
+		forwardErrors.Inc()
 		c.cfg.OutCh <- websocket.ErrorElement{
 			Error: FailedToPostError{Err: err},
 		}
@@ -118,6 +135,10 @@ func (c *EndpointClient) Post(evtCtx eventContext, body string, headers map[stri
 
 	defer resp.Body.Close()
 
+	evtCtx.latency = time.Since(start)
+	forwardDuration.ObserveDuration(evtCtx.latency)
+	forwardResponses.Inc(strconv.Itoa(resp.StatusCode))
+
 	c.cfg.ResponseHandler.ProcessResponse(evtCtx, c.URL, resp)
 
 	return nil
@@ -0,0 +1,115 @@
+package proxy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// eventPresetPrefix prefixes the name of a preset in a list of events, like
+// `preset:payments`
+const eventPresetPrefix = "preset:"
+
+// eventPresets are curated lists of the events integrations commonly handle,
+// so users don't have to listen for all events with `*`.
+var eventPresets = map[string][]string{
+	"payments": {
+		"payment_intent.created",
+		"payment_intent.processing",
+		"payment_intent.requires_action",
+		"payment_intent.amount_capturable_updated",
+		"payment_intent.succeeded",
+		"payment_intent.payment_failed",
+		"payment_intent.canceled",
+		"charge.succeeded",
+		"charge.failed",
+		"charge.captured",
+		"charge.refunded",
+		"charge.dispute.created",
+		"charge.dispute.closed",
+		"checkout.session.completed",
+		"checkout.session.async_payment_succeeded",
+		"checkout.session.async_payment_failed",
+		"checkout.session.expired",
+	},
+	"billing": {
+		"customer.created",
+		"customer.updated",
+		"customer.deleted",
+		"customer.subscription.created",
+		"customer.subscription.updated",
+		"customer.subscription.deleted",
+		"customer.subscription.trial_will_end",
+		"invoice.created",
+		"invoice.finalized",
+		"invoice.upcoming",
+		"invoice.paid",
+		"invoice.payment_failed",
+		"invoice.payment_action_required",
+		"invoice.voided",
+		"invoice.marked_uncollectible",
+		"checkout.session.completed",
+	},
+	"connect": {
+		"account.updated",
+		"account.application.authorized",
+		"account.application.deauthorized",
+		"account.external_account.created",
+		"account.external_account.updated",
+		"account.external_account.deleted",
+		"capability.updated",
+		"person.created",
+		"person.updated",
+		"transfer.created",
+		"transfer.reversed",
+		"payout.created",
+		"payout.paid",
+		"payout.failed",
+		"application_fee.created",
+		"application_fee.refunded",
+	},
+}
+
+// EventPresetNames returns the names of the event presets, sorted
+func EventPresetNames() []string {
+	names := make([]string, 0, len(eventPresets))
+	for name := range eventPresets {
+		names = append(names, eventPresetPrefix+name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// ExpandEventPresets replaces the presets in events, like
+// `preset:payments`, with their events. Events listed several times are
+// only kept once.
+func ExpandEventPresets(events []string) ([]string, error) {
+	var expanded []string
+	seen := make(map[string]bool)
+
+	add := func(event string) {
+		if !seen[event] {
+			seen[event] = true
+			expanded = append(expanded, event)
+		}
+	}
+
+	for _, event := range events {
+		if !strings.HasPrefix(event, eventPresetPrefix) {
+			add(event)
+			continue
+		}
+
+		preset, ok := eventPresets[strings.TrimPrefix(event, eventPresetPrefix)]
+		if !ok {
+			return nil, fmt.Errorf("unknown event preset ‘%s’, presets are %s", event, strings.Join(EventPresetNames(), ", "))
+		}
+
+		for _, e := range preset {
+			add(e)
+		}
+	}
+
+	return expanded, nil
+}
@@ -0,0 +1,55 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventPresetsAreValidEvents(t *testing.T) {
+	for name, events := range eventPresets {
+		for _, event := range events {
+			require.True(t, IsValidEvent(event), "%s in preset:%s", event, name)
+		}
+	}
+}
+
+func TestExpandEventPresets(t *testing.T) {
+	events, err := ExpandEventPresets([]string{"invoice.paid", "preset:billing", "charge.failed"})
+	require.NoError(t, err)
+	require.Equal(t, "invoice.paid", events[0])
+	require.Equal(t, "charge.failed", events[len(events)-1])
+	require.Len(t, events, len(eventPresets["billing"])+1)
+
+	events, err = ExpandEventPresets([]string{"*"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"*"}, events)
+
+	_, err = ExpandEventPresets([]string{"preset:taxes"})
+	require.EqualError(t, err, "unknown event preset ‘preset:taxes’, presets are preset:billing, preset:connect, preset:payments")
+}
+
+func TestInitExpandsEventPresets(t *testing.T) {
+	p, err := Init(context.Background(), &Config{
+		ForwardURL: "http://localhost:4242",
+		Events:     []string{"preset:connect"},
+	})
+	require.NoError(t, err)
+	require.True(t, p.supportsEventType(&StripeEvent{Type: "account.updated"}))
+	require.False(t, p.supportsEventType(&StripeEvent{Type: "customer.created"}))
+
+	req := httptest.NewRequest(http.MethodPut, "/events", strings.NewReader(`{"events": ["preset:payments"]}`))
+	rec := httptest.NewRecorder()
+	p.ControlHandler().ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.True(t, p.supportsEventType(&StripeEvent{Type: "payment_intent.succeeded"}))
+
+	req = httptest.NewRequest(http.MethodPut, "/events", strings.NewReader(`{"events": ["preset:nope"]}`))
+	rec = httptest.NewRecorder()
+	p.ControlHandler().ServeHTTP(rec, req)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
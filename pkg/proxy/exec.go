@@ -0,0 +1,93 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	exec "golang.org/x/sys/execabs"
+
+	"github.com/stripe/stripe-cli/pkg/shell"
+)
+
+// ExecResult is the outcome of running the exec command for an event
+type ExecResult struct {
+	Event   *StripeEvent
+	Command string
+	// ExitCode is the exit code of the command, non-zero when it failed
+	ExitCode int
+	// Output is what the command printed to stdout and stderr
+	Output string
+	// Latency is the time the command took to run
+	Latency time.Duration
+}
+
+// FailedToExecError describes a failure to run the exec command for an event
+type FailedToExecError struct {
+	Err error
+}
+
+func (f FailedToExecError) Error() string {
+	return f.Err.Error()
+}
+
+// runExecCommand runs command with the event payload on stdin and details of
+// the event in its environment.
+func runExecCommand(ctx context.Context, command string, evt *StripeEvent, body string, headers map[string]string) (ExecResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	var output bytes.Buffer
+
+	cmd := shell.Command(ctx, command)
+	cmd.Stdin = strings.NewReader(body)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	cmd.Env = append(os.Environ(), execEnv(evt, headers)...)
+
+	start := time.Now()
+	err := cmd.Run()
+
+	result := ExecResult{
+		Event:   evt,
+		Command: command,
+		Output:  output.String(),
+		Latency: time.Since(start),
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && ctx.Err() == nil {
+		result.ExitCode = exitErr.ExitCode()
+		return result, nil
+	}
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return result, fmt.Errorf("%s timed out after %s", command, defaultTimeout)
+	}
+
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+func execEnv(evt *StripeEvent, headers map[string]string) []string {
+	env := []string{
+		"STRIPE_EVENT_ID=" + evt.ID,
+		"STRIPE_EVENT_TYPE=" + evt.Type,
+		"STRIPE_EVENT_ACCOUNT=" + evt.Account,
+	}
+
+	for k, v := range headers {
+		if strings.EqualFold(k, "Stripe-Signature") {
+			env = append(env, "STRIPE_SIGNATURE="+v)
+		}
+	}
+
+	return env
+}
@@ -0,0 +1,45 @@
+package proxy
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunExecCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+
+	evt := &StripeEvent{ID: "evt_123", Type: "customer.created"}
+	headers := map[string]string{"Stripe-Signature": "t=123,v1=hunter2"}
+
+	result, err := runExecCommand(context.Background(), `echo "$STRIPE_EVENT_TYPE $STRIPE_EVENT_ID $STRIPE_SIGNATURE"; cat`, evt, `{"id":"evt_123"}`, headers)
+	require.NoError(t, err)
+	require.Equal(t, 0, result.ExitCode)
+	require.Equal(t, "customer.created evt_123 t=123,v1=hunter2\n{\"id\":\"evt_123\"}", result.Output)
+	require.Equal(t, evt, result.Event)
+}
+
+func TestRunExecCommandFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+
+	evt := &StripeEvent{ID: "evt_123", Type: "customer.created"}
+
+	result, err := runExecCommand(context.Background(), "echo failed >&2; exit 3", evt, `{}`, nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, result.ExitCode)
+	require.Equal(t, "failed\n", result.Output)
+}
+
+func TestInitRejectsExecWithForwardTo(t *testing.T) {
+	_, err := Init(context.Background(), &Config{
+		ExecCmd:    "./handle_event.sh",
+		ForwardURL: "http://localhost:4242",
+	})
+	require.EqualError(t, err, "exec cannot be used together with forward_to, forward_connect_to or load_from_webhooks_api")
+}
@@ -0,0 +1,98 @@
+package proxy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// EventFilter is a condition on the payload of an event, such as
+// `data.object.metadata.tenant==acme`.
+type EventFilter struct {
+	// Path is the path of the field in the event payload, with keys separated
+	// by dots
+	Path string
+	// Op is "==", "!=", or empty to only check that the field is set
+	Op string
+	// Value is the value the field is compared to
+	Value string
+}
+
+// ParseEventFilter parses a filter expression of the form `path==value`,
+// `path!=value` or `path`. The value may be quoted, and the path may start
+// with `$.` as in JSONPath.
+func ParseEventFilter(expr string) (EventFilter, error) {
+	var filter EventFilter
+
+	path := expr
+	for _, op := range []string{"==", "!="} {
+		if i := strings.Index(expr, op); i >= 0 {
+			path = expr[:i]
+			filter.Op = op
+			filter.Value = unquote(strings.TrimSpace(expr[i+len(op):]))
+			break
+		}
+	}
+
+	path = strings.TrimSpace(path)
+	path = strings.TrimPrefix(path, "$.")
+
+	if path == "" {
+		return EventFilter{}, fmt.Errorf("invalid filter %q: expected path==value, path!=value or path", expr)
+	}
+
+	filter.Path = path
+
+	return filter, nil
+}
+
+// Matches returns true if the event payload satisfies the filter.
+func (f EventFilter) Matches(payload string) bool {
+	value := gjson.Get(payload, f.Path)
+
+	switch f.Op {
+	case "==":
+		return value.Exists() && resultString(value) == f.Value
+	case "!=":
+		return !value.Exists() || resultString(value) != f.Value
+	default:
+		return value.Exists() && value.Type != gjson.Null
+	}
+}
+
+func (f EventFilter) String() string {
+	return f.Path + f.Op + f.Value
+}
+
+// matchesFilters returns true if the event payload satisfies every filter.
+func matchesFilters(payload string, filters []EventFilter) bool {
+	for _, filter := range filters {
+		if !filter.Matches(payload) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// resultString returns the value of a field as it would be written in a
+// filter: strings without quotes, and other values as JSON.
+func resultString(value gjson.Result) string {
+	if value.Type == gjson.String {
+		return value.Str
+	}
+
+	return value.Raw
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 {
+		first, last := value[0], value[len(value)-1]
+		if (first == '"' || first == '\'') && first == last {
+			return value[1 : len(value)-1]
+		}
+	}
+
+	return value
+}
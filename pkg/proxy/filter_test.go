@@ -0,0 +1,75 @@
+package proxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const filterPayload = `{
+  "id": "evt_123",
+  "type": "customer.created",
+  "livemode": false,
+  "data": {
+    "object": {
+      "id": "cus_123",
+      "balance": 0,
+      "email": null,
+      "metadata": {"tenant": "acme", "build": "42"}
+    }
+  }
+}`
+
+func TestParseEventFilter(t *testing.T) {
+	filter, err := ParseEventFilter("data.object.metadata.tenant==acme")
+	require.NoError(t, err)
+	require.Equal(t, EventFilter{Path: "data.object.metadata.tenant", Op: "==", Value: "acme"}, filter)
+
+	filter, err = ParseEventFilter(`$.data.object.metadata.tenant != "acme corp"`)
+	require.NoError(t, err)
+	require.Equal(t, EventFilter{Path: "data.object.metadata.tenant", Op: "!=", Value: "acme corp"}, filter)
+
+	filter, err = ParseEventFilter("data.object.metadata.tenant")
+	require.NoError(t, err)
+	require.Equal(t, EventFilter{Path: "data.object.metadata.tenant"}, filter)
+
+	_, err = ParseEventFilter("==acme")
+	require.EqualError(t, err, `invalid filter "==acme": expected path==value, path!=value or path`)
+}
+
+func TestEventFilterMatches(t *testing.T) {
+	tests := []struct {
+		expr    string
+		matches bool
+	}{
+		{"data.object.metadata.tenant==acme", true},
+		{"data.object.metadata.tenant=='acme'", true},
+		{"data.object.metadata.tenant==globex", false},
+		{"data.object.metadata.tenant!=globex", true},
+		{"data.object.metadata.tenant!=acme", false},
+		{"data.object.metadata.missing!=acme", true},
+		{"data.object.metadata.missing==acme", false},
+		{"data.object.balance==0", true},
+		{"livemode==false", true},
+		{"data.object.email==null", true},
+		{"data.object.metadata.tenant", true},
+		{"data.object.email", false},
+		{"data.object.metadata.missing", false},
+	}
+
+	for _, test := range tests {
+		filter, err := ParseEventFilter(test.expr)
+		require.NoError(t, err)
+		require.Equal(t, test.matches, filter.Matches(filterPayload), test.expr)
+	}
+}
+
+func TestMatchesFilters(t *testing.T) {
+	tenant, _ := ParseEventFilter("data.object.metadata.tenant==acme")
+	build, _ := ParseEventFilter("data.object.metadata.build==42")
+	other, _ := ParseEventFilter("type==customer.deleted")
+
+	require.True(t, matchesFilters(filterPayload, nil))
+	require.True(t, matchesFilters(filterPayload, []EventFilter{tenant, build}))
+	require.False(t, matchesFilters(filterPayload, []EventFilter{tenant, other}))
+}
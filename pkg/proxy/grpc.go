@@ -0,0 +1,157 @@
+package proxy
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/stripe/stripe-cli/rpc/eventhandler"
+)
+
+// isGRPCURL returns true if events sent to url are delivered as a gRPC call
+// instead of an HTTP request. The path of the URL is the full gRPC method to
+// call, e.g. grpc://localhost:50052/stripe.cli.eventhandler.v1.EventHandler/Handle.
+func isGRPCURL(url string) bool {
+	return strings.HasPrefix(url, "grpc://") || strings.HasPrefix(url, "grpcs://")
+}
+
+// grpcTransport is an http.RoundTripper that delivers the POST requests of an
+// EndpointClient as calls to the EventHandler service, so gRPC endpoints go
+// through the same path as HTTP ones. The gRPC status of the call is mapped to
+// an HTTP status code.
+type grpcTransport struct {
+	tlsConfig *tls.Config
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+func newGRPCTransport(tlsConfig *tls.Config) *grpcTransport {
+	return &grpcTransport{
+		tlsConfig: tlsConfig,
+		conns:     make(map[string]*grpc.ClientConn),
+	}
+}
+
+// RoundTrip calls the method in the path of the request URL with its body and
+// headers.
+func (t *grpcTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	conn, err := t.conn(req.URL.Scheme, req.URL.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	in := &eventhandler.HandleRequest{
+		Headers: make(map[string]string),
+	}
+
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		in.Payload = string(body)
+	}
+
+	for k := range req.Header {
+		in.Headers[k] = req.Header.Get(k)
+	}
+
+	out := &eventhandler.HandleResponse{}
+
+	err = conn.Invoke(req.Context(), req.URL.Path, in, out)
+
+	st := status.Convert(err)
+	if st.Code() == codes.Unavailable {
+		// The endpoint couldn't be reached, which is reported like a failed
+		// HTTP request rather than a response
+		return nil, err
+	}
+
+	body := out.Body
+	if st.Code() != codes.OK {
+		body = st.Message()
+	}
+
+	code := grpcCodeToHTTPStatus(st.Code())
+
+	return &http.Response{
+		Status:     strconv.Itoa(code) + " " + http.StatusText(code),
+		StatusCode: code,
+		Proto:      "HTTP/2.0",
+		ProtoMajor: 2,
+		Header: http.Header{
+			"Grpc-Status": []string{strconv.Itoa(int(st.Code()))},
+		},
+		Body:          ioutil.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// conn returns the connection to host, dialing it the first time.
+func (t *grpcTransport) conn(scheme, host string) (*grpc.ClientConn, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if conn, ok := t.conns[host]; ok {
+		return conn, nil
+	}
+
+	creds := insecure.NewCredentials()
+	if scheme == "grpcs" {
+		creds = credentials.NewTLS(t.tlsConfig)
+	}
+
+	conn, err := grpc.Dial(host, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+
+	t.conns[host] = conn
+
+	return conn, nil
+}
+
+// grpcCodeToHTTPStatus maps a gRPC status code to the closest HTTP status
+// code, following the mapping of grpc-gateway.
+func grpcCodeToHTTPStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,107 @@
+package proxy
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/stripe/stripe-cli/pkg/websocket"
+	"github.com/stripe/stripe-cli/rpc/eventhandler"
+)
+
+type testEventHandler struct {
+	eventhandler.UnimplementedEventHandlerServer
+
+	handle func(*eventhandler.HandleRequest) (*eventhandler.HandleResponse, error)
+}
+
+func (h *testEventHandler) Handle(ctx context.Context, req *eventhandler.HandleRequest) (*eventhandler.HandleResponse, error) {
+	return h.handle(req)
+}
+
+func startEventHandler(t *testing.T, handle func(*eventhandler.HandleRequest) (*eventhandler.HandleResponse, error)) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	eventhandler.RegisterEventHandlerServer(server, &testEventHandler{handle: handle})
+
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	return "grpc://" + listener.Addr().String() + "/stripe.cli.eventhandler.v1.EventHandler/Handle"
+}
+
+func postToGRPC(t *testing.T, url string) (*http.Response, string, error) {
+	var resp *http.Response
+	var body string
+
+	p := &Proxy{cfg: &Config{}}
+	client := p.newEndpointClient(EndpointRoute{URL: url, EventTypes: []string{"*"}}, nil)
+	client.cfg.ResponseHandler = EndpointResponseHandlerFunc(func(evtCtx eventContext, forwardURL string, r *http.Response) {
+		buf, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		resp = r
+		body = string(buf)
+	})
+	client.cfg.OutCh = make(chan websocket.IElement, 1)
+
+	err := client.Post(eventContext{event: &StripeEvent{ID: "evt_123"}}, `{"id":"evt_123"}`, map[string]string{
+		"Stripe-Signature": "t=123,v1=hunter2",
+	})
+
+	return resp, body, err
+}
+
+func TestGRPCForwarding(t *testing.T) {
+	var received *eventhandler.HandleRequest
+
+	url := startEventHandler(t, func(req *eventhandler.HandleRequest) (*eventhandler.HandleResponse, error) {
+		received = req
+		return &eventhandler.HandleResponse{Body: "handled"}, nil
+	})
+
+	resp, body, err := postToGRPC(t, url)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "handled", body)
+	require.Equal(t, `{"id":"evt_123"}`, received.Payload)
+	require.Equal(t, "t=123,v1=hunter2", received.Headers["Stripe-Signature"])
+}
+
+func TestGRPCForwardingErrorStatus(t *testing.T) {
+	url := startEventHandler(t, func(req *eventhandler.HandleRequest) (*eventhandler.HandleResponse, error) {
+		return nil, status.Error(codes.InvalidArgument, "bad signature")
+	})
+
+	resp, body, err := postToGRPC(t, url)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	require.Equal(t, "bad signature", body)
+	require.Equal(t, "3", resp.Header.Get("Grpc-Status"))
+}
+
+func TestGRPCForwardingUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	listener.Close()
+
+	_, _, err = postToGRPC(t, "grpc://"+addr+"/stripe.cli.eventhandler.v1.EventHandler/Handle")
+	require.Error(t, err)
+}
+
+func TestParseGRPCURL(t *testing.T) {
+	require.Equal(t, "grpc://localhost:50052/stripe.cli.eventhandler.v1.EventHandler/Handle", parseURL("grpc://localhost:50052/stripe.cli.eventhandler.v1.EventHandler/Handle"))
+	require.Equal(t, "grpcs://example.com/stripe.cli.eventhandler.v1.EventHandler/Handle", parseURL("grpcs://example.com/stripe.cli.eventhandler.v1.EventHandler/Handle"))
+}
@@ -0,0 +1,154 @@
+package proxy
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// eventHistorySize is the number of events the proxy keeps to be listed and
+// resent, after which the oldest events are dropped
+const eventHistorySize = 100
+
+// EventDelivery is an attempt to forward an event to a local endpoint.
+type EventDelivery struct {
+	URL string
+
+	// StatusCode is the status of the response of the endpoint, 0 if the
+	// request failed
+	StatusCode int
+
+	// Err is why the request failed, if it did
+	Err string
+
+	Latency   time.Duration
+	Timestamp time.Time
+}
+
+// EventRecord is an event received by the proxy, with the attempts to
+// deliver it.
+type EventRecord struct {
+	Event      StripeEvent
+	ReceivedAt time.Time
+	Deliveries []EventDelivery
+}
+
+// EventNotFoundError is returned when resending an event the proxy didn't
+// receive, or no longer keeps.
+type EventNotFoundError struct {
+	ID string
+}
+
+func (e EventNotFoundError) Error() string {
+	return fmt.Sprintf("event ‘%s’ was not received in this session", e.ID)
+}
+
+// eventHistory keeps the last events received, oldest first.
+type eventHistory struct {
+	mu      sync.Mutex
+	size    int
+	records []*EventRecord
+}
+
+func newEventHistory(size int) *eventHistory {
+	return &eventHistory{size: size}
+}
+
+// add keeps an event, dropping the oldest one if the history is full.
+func (h *eventHistory) add(evt StripeEvent) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.records = append(h.records, &EventRecord{
+		Event:      evt,
+		ReceivedAt: time.Now(),
+	})
+
+	if len(h.records) > h.size {
+		h.records = h.records[len(h.records)-h.size:]
+	}
+}
+
+// recordDelivery adds a delivery to the last event kept with the given id.
+func (h *eventHistory) recordDelivery(id string, delivery EventDelivery) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if record := h.find(id); record != nil {
+		record.Deliveries = append(record.Deliveries, delivery)
+	}
+}
+
+// get returns the last event kept with the given id.
+func (h *eventHistory) get(id string) (StripeEvent, bool) {
+	if h == nil {
+		return StripeEvent{}, false
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	record := h.find(id)
+	if record == nil {
+		return StripeEvent{}, false
+	}
+
+	return record.Event, true
+}
+
+// list returns a copy of the events kept, oldest first.
+func (h *eventHistory) list() []EventRecord {
+	if h == nil {
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	records := make([]EventRecord, 0, len(h.records))
+	for _, record := range h.records {
+		r := *record
+		r.Deliveries = append([]EventDelivery(nil), record.Deliveries...)
+		records = append(records, r)
+	}
+
+	return records
+}
+
+// find must be called with mu held.
+func (h *eventHistory) find(id string) *EventRecord {
+	for i := len(h.records) - 1; i >= 0; i-- {
+		if h.records[i].Event.ID == id {
+			return h.records[i]
+		}
+	}
+
+	return nil
+}
+
+// Events returns the last events received in this session, oldest first,
+// with the attempts to deliver them.
+func (p *Proxy) Events() []EventRecord {
+	return p.history.list()
+}
+
+// ResendEvent forwards an event received in this session to the local
+// endpoints again.
+func (p *Proxy) ResendEvent(id string) error {
+	evt, ok := p.history.get(id)
+	if !ok {
+		return EventNotFoundError{ID: id}
+	}
+
+	p.Resend(evt)
+
+	return nil
+}
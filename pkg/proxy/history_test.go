@@ -0,0 +1,87 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/websocket"
+)
+
+func TestEventHistoryDropsOldest(t *testing.T) {
+	h := newEventHistory(2)
+
+	h.add(StripeEvent{ID: "evt_1"})
+	h.add(StripeEvent{ID: "evt_2"})
+	h.add(StripeEvent{ID: "evt_3"})
+
+	records := h.list()
+	require.Len(t, records, 2)
+	require.Equal(t, "evt_2", records[0].Event.ID)
+	require.Equal(t, "evt_3", records[1].Event.ID)
+
+	_, ok := h.get("evt_1")
+	require.False(t, ok)
+
+	h.recordDelivery("evt_3", EventDelivery{URL: "http://localhost/webhooks", StatusCode: 200})
+	h.recordDelivery("evt_1", EventDelivery{URL: "http://localhost/webhooks", StatusCode: 200})
+
+	records = h.list()
+	require.Empty(t, records[0].Deliveries)
+	require.Equal(t, []EventDelivery{{URL: "http://localhost/webhooks", StatusCode: 200}}, records[1].Deliveries)
+}
+
+func TestResendEvent(t *testing.T) {
+	received := make(chan string, 2)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Get("Stripe-Signature")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+
+	outCh := make(chan websocket.IElement, 10)
+	p, err := Init(context.Background(), &Config{
+		ForwardURL: ts.URL + "/webhooks",
+		OutCh:      outCh,
+	})
+	require.NoError(t, err)
+	p.webhookSecret.Store("whsec_123")
+
+	p.processWebhookEvent(websocket.IncomingMessage{
+		WebhookEvent: &websocket.WebhookEvent{
+			EventPayload: `{"id": "evt_123", "object": "event", "type": "customer.created", "data": {"object": {}}}`,
+			HTTPHeaders:  map[string]string{"Stripe-Signature": "t=123,v1=hunter2"},
+			Type:         "webhook_event",
+			WebhookID:    "wh_123",
+		},
+	})
+	require.Equal(t, "t=123,v1=hunter2", <-received)
+
+	require.NoError(t, p.ResendEvent("evt_123"))
+	require.NotEqual(t, "t=123,v1=hunter2", <-received)
+
+	require.Eventually(t, func() bool {
+		records := p.Events()
+		return len(records) == 1 && len(records[0].Deliveries) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	record := p.Events()[0]
+	require.Equal(t, "customer.created", record.Event.Type)
+	for _, delivery := range record.Deliveries {
+		require.Equal(t, ts.URL+"/webhooks", delivery.URL)
+		require.Equal(t, http.StatusAccepted, delivery.StatusCode)
+	}
+}
+
+func TestResendEventNotFound(t *testing.T) {
+	p, err := Init(context.Background(), &Config{})
+	require.NoError(t, err)
+
+	err = p.ResendEvent("evt_123")
+	require.Equal(t, EventNotFoundError{ID: "evt_123"}, err)
+	require.EqualError(t, err, "event ‘evt_123’ was not received in this session")
+}
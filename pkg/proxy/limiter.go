@@ -0,0 +1,107 @@
+package proxy
+
+import (
+	"sync"
+	"time"
+)
+
+// forwardQueueSize is the number of forwards waiting for the limits, after
+// which scheduling blocks until the oldest one starts
+const forwardQueueSize = 10000
+
+// forwardLimiter bounds the number of concurrent forwards to local endpoints
+// and paces them to a maximum rate. The zero value imposes no limits.
+type forwardLimiter struct {
+	// sem holds one token per in-flight forward. nil means unbounded.
+	sem chan struct{}
+
+	// interval is the minimum spacing between the start of two forwards.
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+
+	// queue holds the forwards waiting for the limits, in the order they
+	// were scheduled
+	queue chan func()
+	start sync.Once
+}
+
+// newForwardLimiter returns a limiter allowing at most maxConcurrency
+// simultaneous forwards and at most ratePerSecond forwards per second. A
+// value of 0 disables the corresponding limit.
+func newForwardLimiter(maxConcurrency int, ratePerSecond float64) *forwardLimiter {
+	l := &forwardLimiter{}
+
+	if maxConcurrency > 0 {
+		l.sem = make(chan struct{}, maxConcurrency)
+	}
+
+	if ratePerSecond > 0 {
+		l.interval = time.Duration(float64(time.Second) / ratePerSecond)
+	}
+
+	return l
+}
+
+// schedule runs forward in the background once the concurrency and rate
+// limits allow it. Forwards start in the order they're scheduled, so that
+// with a concurrency of 1 events are forwarded in the order received.
+func (l *forwardLimiter) schedule(forward func()) {
+	if l.sem == nil && l.interval == 0 {
+		go forward()
+		return
+	}
+
+	l.start.Do(func() {
+		l.queue = make(chan func(), forwardQueueSize)
+		go l.run()
+	})
+
+	l.queue <- forward
+}
+
+// run starts the queued forwards one after another as the limits allow
+func (l *forwardLimiter) run() {
+	for forward := range l.queue {
+		l.acquire()
+
+		go func(forward func()) {
+			defer l.release()
+			forward()
+		}(forward)
+	}
+}
+
+// acquire blocks until a forward is allowed to start. Every call must be
+// paired with a call to release once the forward has completed.
+func (l *forwardLimiter) acquire() {
+	if l.sem != nil {
+		l.sem <- struct{}{}
+	}
+
+	if l.interval > 0 {
+		time.Sleep(l.reserve(time.Now()))
+	}
+}
+
+func (l *forwardLimiter) release() {
+	if l.sem != nil {
+		<-l.sem
+	}
+}
+
+// reserve claims the next free slot and returns how long to wait until it.
+func (l *forwardLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.next.Before(now) {
+		l.next = now
+	}
+
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+
+	return wait
+}
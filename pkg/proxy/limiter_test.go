@@ -0,0 +1,83 @@
+package proxy
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestForwardLimiterUnbounded(t *testing.T) {
+	l := newForwardLimiter(0, 0)
+
+	for i := 0; i < 100; i++ {
+		l.acquire()
+	}
+}
+
+func TestForwardLimiterConcurrency(t *testing.T) {
+	l := newForwardLimiter(2, 0)
+
+	var inFlight, maxInFlight int32
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.acquire()
+			defer l.release()
+
+			n := atomic.AddInt32(&inFlight, 1)
+			for {
+				m := atomic.LoadInt32(&maxInFlight)
+				if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+		}()
+	}
+
+	wg.Wait()
+	require.EqualValues(t, 2, maxInFlight)
+}
+
+func TestForwardLimiterReserve(t *testing.T) {
+	l := newForwardLimiter(0, 10)
+	now := time.Now()
+
+	require.Equal(t, time.Duration(0), l.reserve(now))
+	require.Equal(t, 100*time.Millisecond, l.reserve(now))
+	require.Equal(t, 200*time.Millisecond, l.reserve(now))
+
+	// once the reserved slots are in the past, the next forward starts immediately
+	require.Equal(t, time.Duration(0), l.reserve(now.Add(time.Second)))
+}
+
+func TestForwardLimiterScheduleInOrder(t *testing.T) {
+	l := newForwardLimiter(1, 0)
+
+	var mu sync.Mutex
+	var order []int
+	var wg sync.WaitGroup
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		i := i
+		l.schedule(func() {
+			defer wg.Done()
+			mu.Lock()
+			order = append(order, i)
+			mu.Unlock()
+		})
+	}
+
+	wg.Wait()
+	for i, n := range order {
+		require.Equal(t, i, n)
+	}
+}
@@ -0,0 +1,36 @@
+package proxy
+
+import (
+	"github.com/stripe/stripe-cli/pkg/metrics"
+)
+
+var (
+	eventsReceived = metrics.NewCounterVec(
+		"stripe_listen_events_received_total",
+		"Number of webhook events received from Stripe, by event type.",
+		"type",
+	)
+
+	forwardDuration = metrics.NewHistogramVec(
+		"stripe_listen_forward_duration_seconds",
+		"Time taken by the local endpoint to respond to a forwarded event.",
+		nil,
+	)
+
+	forwardResponses = metrics.NewCounterVec(
+		"stripe_listen_forward_responses_total",
+		"Number of responses from the local endpoint, by status code.",
+		"code",
+	)
+
+	forwardErrors = metrics.NewCounterVec(
+		"stripe_listen_forward_errors_total",
+		"Number of events that could not be delivered to the local endpoint.",
+	)
+
+	watchdogEvents = metrics.NewCounterVec(
+		"stripe_listen_watchdog_events_total",
+		"Number of problems detected by the watchdog, by event.",
+		"event",
+	)
+)
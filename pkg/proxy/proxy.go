@@ -3,21 +3,26 @@ package proxy
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 
 	"github.com/stripe/stripe-cli/pkg/ansi"
 	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/netproxy"
 	"github.com/stripe/stripe-cli/pkg/requests"
 	"github.com/stripe/stripe-cli/pkg/stripe"
 	"github.com/stripe/stripe-cli/pkg/stripeauth"
@@ -50,6 +55,8 @@ type EndpointRoute struct {
 type EndpointResponse struct {
 	Event *StripeEvent
 	Resp  *http.Response
+	// Latency is the time the endpoint took to respond
+	Latency time.Duration
 }
 
 // FailedToReadResponseError describes a failure to read the response from an endpoint
@@ -78,13 +85,55 @@ type Config struct {
 	ForwardConnectURL string
 	// Headers to inject when forwarding Connect events
 	ForwardConnectHeaders []string
+	// URL to which thin events from v2 event destinations are forwarded to
+	ForwardThinURL string
+	// URL to which thin events from Connect accounts are forwarded to
+	ForwardThinConnectURL string
 	// UseConfiguredWebhooks loads webhooks config from user's account
 	UseConfiguredWebhooks bool
-
-	// EndpointsRoutes is a mapping of local webhook endpoint urls to the events they consume
+	// Indicates whether to open a public HTTPS URL that tunnels requests to the host of ForwardURL
+	Tunnel bool
+	// Address of the relay of another machine to receive events from, instead of connecting
+	// to Stripe. Only used with RelaySession.
+	RelayHost string
+	// ID of the relay session to attach to on RelayHost
+	RelaySession string
+	// SHA-256 fingerprint of the certificate of the relay to attach to, which is pinned
+	// instead of verifying the certificate against the system's roots
+	RelayFingerprint string
+	// PEM files of the certificate and key the relay serves TLS with, instead of a
+	// self-signed certificate
+	RelayCert string
+	RelayKey  string
+
+	// EndpointsRoutes is a mapping of local webhook endpoint urls to the events they consume,
+	// forwarded to in addition to ForwardURL and ForwardConnectURL
 	EndpointRoutes []EndpointRoute
 	// List of events to listen and proxy
 	Events []string
+	// List of thin event types to listen for and proxy
+	ThinEvents []string
+	// Indicates whether to fetch the related object of thin events and attach it to the
+	// payload before forwarding
+	FetchRelatedObject bool
+	// Only proxy events whose object metadata contains all of these key/value pairs
+	FilterMetadata map[string]string
+	// Only proxy events whose payload matches all of these filter expressions, e.g.
+	// `data.object.metadata.tenant==acme`
+	Filters []string
+	// Maximum number of events forwarded to local endpoints at the same time, 0 for no limit
+	MaxConcurrency int
+	// Maximum number of events forwarded to local endpoints per second, 0 for no limit
+	RateLimit float64
+	// Shell command the JSON payload of each event is piped through before forwarding. Its output
+	// replaces the payload, and the event is re-signed with the webhook signing secret.
+	TransformCmd string
+	// Shell command run for each event instead of forwarding it over HTTP. The event's JSON payload
+	// is written to its stdin.
+	ExecCmd string
+	// Content mode events are wrapped in CloudEvents 1.0 envelopes in, CloudEventsStructured or
+	// CloudEventsBinary. Events are forwarded as sent by Stripe if empty.
+	CloudEvents string
 
 	// WebSocketFeature is the feature specified for the websocket connection
 	WebSocketFeature string
@@ -98,10 +147,25 @@ type Config struct {
 	UseLatestAPIVersion bool
 	// Indicates whether to skip certificate verification when forwarding webhooks to HTTPS endpoints
 	SkipVerify bool
+	// Path to a PEM-encoded client certificate presented when forwarding webhooks to HTTPS endpoints
+	ForwardClientCert string
+	// Path to the PEM-encoded private key matching ForwardClientCert
+	ForwardClientKey string
+	// Path to a PEM-encoded CA certificate bundle trusted when forwarding webhooks to HTTPS endpoints
+	ForwardCACert string
 	// The logger used to log messages to stdin/err
 	Log *log.Logger
 	// Force use of unencrypted ws:// protocol instead of wss://
 	NoWSS bool
+	// URL of the HTTP or SOCKS5 proxy server to connect to Stripe through. If empty, the proxy
+	// configured in the environment (HTTPS_PROXY) is used.
+	Proxy string
+	// SessionCache, if set, lets the proxy reuse a session released by a previous run instead
+	// of authorizing a new one, and release its own session on exit
+	SessionCache *stripeauth.SessionCache
+	// Watchdog, if set, monitors the liveness of the websocket, the staleness of the events
+	// and the memory usage of the proxy, for forwarders running for a long time
+	Watchdog *WatchdogConfig
 
 	// OutCh is the channel to send logs and statuses to for processing in other packages
 	OutCh chan websocket.IElement
@@ -114,15 +178,67 @@ type Proxy struct {
 	cfg *Config
 
 	endpointClients  []*EndpointClient
+	thinEndpoints    []*EndpointClient
 	stripeAuthClient *stripeauth.Client
 	webSocketClient  *websocket.Client
+	limiter          *forwardLimiter
+	proxyDialer      *netproxy.Dialer
+
+	// tunnelTarget is the origin requests received through the tunnel are
+	// sent to, empty if no tunnel was requested
+	tunnelTarget string
+	tunnelClient *http.Client
+
+	// tunnelID is the ID of the open tunnel, kept when the session is
+	// renewed so that its public URL doesn't change
+	tunnelID atomic.Value
+
+	// relay sends the events received to the machines attached to the
+	// session, if ServeRelay was called
+	relay *relayHub
+
+	// history keeps the last events received, to list and resend them
+	history *eventHistory
+
+	// webhookSecret is the signing secret of the first session, used to
+	// re-sign transformed payloads. It's kept when the session is refreshed,
+	// so that the local endpoints keep verifying the events.
+	webhookSecret atomic.Value
+
+	// sessionSecret is the signing secret of the current session, which the
+	// events received are signed with
+	sessionSecret atomic.Value
 
 	// Events is the supported event types for the command
 	events map[string]bool
+
+	// thinEvents is the supported thin event types for the command
+	thinEvents map[string]bool
+
+	// filters are the conditions on the payload events must match to be proxied
+	filters []EventFilter
+
+	// prepared receives the session authorized by PrepareSession, if it was
+	// called
+	prepared chan preparedSession
+
+	// controlMu guards events, paused and pending, which can be changed
+	// through the control server while the proxy runs
+	controlMu sync.Mutex
+	paused    bool
+	pending   []pendingEvent
+
+	// lastEvent is the time, in Unix nanoseconds, the last event was
+	// received, or the current session started
+	lastEvent int64
 }
 
 const maxConnectAttempts = 3
 
+// sessionRefreshRetry is how long to wait before trying to refresh a session
+// again after failing to
+const sessionRefreshRetry = time.Minute
+
 // IsConnected returns a channel that signals the proxy has finished connecting.
 // can only be called after webSocketClient is initialized
 func (p *Proxy) IsConnected() <-chan struct{} {
@@ -135,24 +251,49 @@ func (p *Proxy) IsConnected() <-chan struct{} {
 // Run sets the websocket connection and starts the Goroutines to forward
 // incoming events to the local endpoint.
 func (p *Proxy) Run(ctx context.Context) error {
+	if p.cfg.RelaySession != "" {
+		return p.runAttached(ctx)
+	}
+
 	defer close(p.cfg.OutCh)
 
 	p.cfg.OutCh <- websocket.StateElement{
 		State: websocket.Loading,
 	}
 
+	var watchdogActions chan watchdogAction
+	if p.cfg.Watchdog != nil {
+		w := p.newWatchdog()
+		watchdogActions = w.actions
+		go w.run(ctx)
+	}
+
 	nAttempts := 0
 
 	for nAttempts < maxConnectAttempts {
-		session, err := p.createSession(ctx)
+		session, err := p.nextSession(ctx)
 
 		if err != nil {
+			p.closeOpenTunnel()
+			p.cfg.OutCh <- websocket.ErrorElement{
+				Error: fmt.Errorf("Error while authenticating with Stripe: %w", err),
+			}
+			return err
+		}
+
+		if err := p.keepTunnel(session); err != nil {
 			p.cfg.OutCh <- websocket.ErrorElement{
-				Error: fmt.Errorf("Error while authenticating with Stripe: %v", err),
+				Error: err,
 			}
 			return err
 		}
 
+		if p.webhookSecret.Load() == nil {
+			p.webhookSecret.Store(session.Secret)
+		}
+		p.sessionSecret.Store(session.Secret)
+		atomic.StoreInt64(&p.lastEvent, time.Now().UnixNano())
+
 		p.webSocketClient = websocket.NewClient(
 			session.WebSocketURL,
 			session.WebSocketID,
@@ -160,8 +301,9 @@ func (p *Proxy) Run(ctx context.Context) error {
 			&websocket.Config{
 				Log:               p.cfg.Log,
 				NoWSS:             p.cfg.NoWSS,
+				Proxy:             p.proxyDialer,
 				ReconnectInterval: time.Duration(session.ReconnectDelay) * time.Second,
-				EventHandler:      websocket.EventHandlerFunc(p.processWebhookEvent),
+				EventHandler:      websocket.EventHandlerFunc(p.processMessage),
 			},
 		)
 
@@ -176,27 +318,66 @@ func (p *Proxy) Run(ctx context.Context) error {
 				displayedAPIVersion = "You are using Stripe API Version [" + session.DefaultVersion + "]. "
 			}
 
+			secret, _ := p.webhookSecret.Load().(string)
 			p.cfg.OutCh <- websocket.StateElement{
 				State: websocket.Ready,
-				Data:  []string{displayedAPIVersion, session.Secret},
+				Data:  []string{displayedAPIVersion, secret, session.TunnelURL},
 			}
 		}()
 
 		go p.webSocketClient.Run(ctx)
 		nAttempts++
 
+		refreshed := make(chan *stripeauth.StripeCLISession, 1)
+		refreshCtx, cancelRefresh := context.WithCancel(ctx)
+		go p.refreshSession(refreshCtx, session, refreshed)
+
 		select {
 		case <-ctx.Done():
+			cancelRefresh()
+			p.closeOpenTunnel()
+			p.stripeAuthClient.ReleaseSession(session)
 			p.cfg.OutCh <- &websocket.StateElement{
 				State: websocket.Done,
 			}
 			return nil
+		case action := <-watchdogActions:
+			cancelRefresh()
+			p.webSocketClient.Stop()
+
+			if action == stopProcess {
+				p.closeOpenTunnel()
+				p.cfg.OutCh <- websocket.ErrorElement{
+					Error: ErrWatchdogRestart,
+				}
+				return ErrWatchdogRestart
+			}
+
+			// Restarting the session isn't a failed attempt to reauthorize
+			nAttempts--
+			p.cfg.OutCh <- &websocket.StateElement{
+				State: websocket.Reconnecting,
+			}
+		case next := <-refreshed:
+			cancelRefresh()
+			p.webSocketClient.Stop()
+
+			// The next iteration connects with the new session, which isn't
+			// a failed attempt to reauthorize either
+			p.prepared = make(chan preparedSession, 1)
+			p.prepared <- preparedSession{session: next}
+			nAttempts--
+			p.cfg.OutCh <- &websocket.StateElement{
+				State: websocket.Reconnecting,
+			}
 		case <-p.webSocketClient.NotifyExpired:
+			cancelRefresh()
 			if nAttempts < maxConnectAttempts {
 				p.cfg.OutCh <- &websocket.StateElement{
 					State: websocket.Reconnecting,
 				}
 			} else {
+				p.closeOpenTunnel()
 				err := fmt.Errorf("Session expired. Terminating after %d failed attempts to reauthorize", nAttempts)
 				p.cfg.OutCh <- websocket.ErrorElement{
 					Error: err,
@@ -209,6 +390,7 @@ func (p *Proxy) Run(ctx context.Context) error {
 	if p.webSocketClient != nil {
 		p.webSocketClient.Stop()
 	}
+	p.closeOpenTunnel()
 
 	log.WithFields(log.Fields{
 		"prefix": "proxy.Proxy.Run",
@@ -217,14 +399,64 @@ func (p *Proxy) Run(ctx context.Context) error {
 	return nil
 }
 
+// refreshSession authorizes the session that replaces session shortly before
+// it expires, while session still receives events, and sends it to
+// refreshed. It retries until it succeeds or ctx is done.
+func (p *Proxy) refreshSession(ctx context.Context, session *stripeauth.StripeCLISession, refreshed chan<- *stripeauth.StripeCLISession) {
+	refreshIn, ok := session.RefreshIn(time.Now())
+	if !ok {
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(refreshIn):
+		}
+
+		next, err := p.createSession(ctx)
+		if err == nil {
+			refreshed <- next
+			return
+		}
+
+		p.cfg.Log.WithFields(log.Fields{
+			"prefix": "proxy.Proxy.refreshSession",
+		}).Debugf("Could not refresh the session, retrying: %v", err)
+
+		refreshIn = sessionRefreshRetry
+	}
+}
+
+// preserveSignature signs the headers of an event received through a
+// refreshed session again, with the secret of the first session, since they
+// were signed with the secret of the new one
+func (p *Proxy) preserveSignature(headers map[string]string, payload string) map[string]string {
+	sessionSecret, _ := p.sessionSecret.Load().(string)
+	secret, _ := p.webhookSecret.Load().(string)
+	if sessionSecret == "" || sessionSecret == secret {
+		return headers
+	}
+
+	return resignHeaders(headers, secret, payload)
+}
+
+// IsValidEvent returns whether event is a known event type, or "*".
+func IsValidEvent(event string) bool {
+	return validEvents[event]
+}
+
 // GetSessionSecret creates a session and returns the webhook signing secret.
-func GetSessionSecret(ctx context.Context, deviceName, key, baseURL string) (string, error) {
+// proxyURL is the proxy server to connect to Stripe through, if any.
+func GetSessionSecret(ctx context.Context, deviceName, key, baseURL, proxyURL string) (string, error) {
 	p, err := Init(ctx, &Config{
 		DeviceName:       deviceName,
 		Key:              key,
 		APIBaseURL:       baseURL,
+		Proxy:            proxyURL,
 		EndpointRoutes:   make([]EndpointRoute, 0),
-		WebSocketFeature: "webhooks",
+		WebSocketFeature: stripeauth.FeatureWebhooks,
 	})
 	if err != nil {
 		log.WithFields(log.Fields{
@@ -244,10 +476,43 @@ func GetSessionSecret(ctx context.Context, deviceName, key, baseURL string) (str
 	return session.Secret, nil
 }
 
+// preparedSession is the result of authorizing a session in the background.
+type preparedSession struct {
+	session *stripeauth.StripeCLISession
+	err     error
+}
+
+// PrepareSession starts authorizing the session in the background, so it
+// overlaps with the rest of the startup. Run uses it for its first connection.
+func (p *Proxy) PrepareSession(ctx context.Context) {
+	p.prepared = make(chan preparedSession, 1)
+
+	go func() {
+		session, err := p.createSession(ctx)
+		p.prepared <- preparedSession{session: session, err: err}
+	}()
+}
+
+// nextSession returns the session prepared by PrepareSession the first time
+// it's called, and authorizes a new session otherwise.
+func (p *Proxy) nextSession(ctx context.Context) (*stripeauth.StripeCLISession, error) {
+	if p.prepared != nil {
+		prepared := <-p.prepared
+		p.prepared = nil
+
+		return prepared.session, prepared.err
+	}
+
+	return p.createSession(ctx)
+}
+
 func (p *Proxy) createSession(ctx context.Context) (*stripeauth.StripeCLISession, error) {
 	var session *stripeauth.StripeCLISession
 
-	var err error
+	filters, err := p.sessionFilters()
+	if err != nil {
+		return nil, err
+	}
 
 	exitCh := make(chan struct{})
 
@@ -256,17 +521,26 @@ func (p *Proxy) createSession(ctx context.Context) (*stripeauth.StripeCLISession
 		// transient errors that we just need to retry for.
 		for i := 0; i <= 5; i++ {
 			devURLMap := stripeauth.DeviceURLMap{
-				ForwardURL:        p.cfg.ForwardURL,
-				ForwardConnectURL: p.cfg.ForwardConnectURL,
+				ForwardURL:            p.cfg.ForwardURL,
+				ForwardConnectURL:     p.cfg.ForwardConnectURL,
+				ForwardThinURL:        p.cfg.ForwardThinURL,
+				ForwardThinConnectURL: p.cfg.ForwardThinConnectURL,
+				TunnelToURL:           p.tunnelTarget,
+				TunnelID:              p.openTunnel(),
 			}
 
-			session, err = p.stripeAuthClient.Authorize(ctx, p.cfg.DeviceName, p.cfg.WebSocketFeature, nil, &devURLMap)
+			session, err = p.stripeAuthClient.Authorize(ctx, p.cfg.DeviceName, p.cfg.WebSocketFeature, filters, &devURLMap)
 
 			if err == nil {
 				exitCh <- struct{}{}
 				return
 			}
 
+			// A key without the scope won't be authorized by retrying
+			if errors.As(err, &stripeauth.FeatureNotAuthorizedError{}) {
+				break
+			}
+
 			select {
 			case <-ctx.Done():
 				exitCh <- struct{}{}
@@ -282,6 +556,23 @@ func (p *Proxy) createSession(ctx context.Context) (*stripeauth.StripeCLISession
 	return session, err
 }
 
+// sessionFilters returns the JSON-encoded filters subscribing the session to
+// thin events, or nil if no thin events were requested.
+func (p *Proxy) sessionFilters() (*string, error) {
+	if len(p.cfg.ThinEvents) == 0 {
+		return nil, nil
+	}
+
+	filters, err := json.Marshal(map[string][]string{"thin_events": p.cfg.ThinEvents})
+	if err != nil {
+		return nil, err
+	}
+
+	encoded := string(filters)
+
+	return &encoded, nil
+}
+
 func (p *Proxy) filterWebhookEvent(msg *websocket.WebhookEvent) bool {
 	if msg.Endpoint.APIVersion != nil && !p.cfg.UseLatestAPIVersion {
 		p.cfg.Log.WithFields(log.Fields{
@@ -312,6 +603,7 @@ func (p *Proxy) formatOutput(format string, eventPayload string) string {
 		p.cfg.Log.Debug("Received malformed event from Stripe, ignoring")
 		return fmt.Sprint(err)
 	}
+
 	switch strings.ToUpper(format) {
 	// The distinction between this and PrintJSON is that this output is stripped of all pretty format.
 	case outputFormatJSON:
@@ -322,6 +614,147 @@ func (p *Proxy) formatOutput(format string, eventPayload string) string {
 	}
 }
 
+// forwardEvent applies the transform command, if any, to an event and
+// forwards it to every endpoint that supports it, or to the exec command.
+func (p *Proxy) forwardEvent(evtCtx eventContext, body string, headers map[string]string) {
+	evt := evtCtx.event
+
+	if evt.IsThin() {
+		p.forwardThinEvent(evtCtx, body, headers)
+		return
+	}
+
+	if p.cfg.TransformCmd != "" {
+		transformed, err := transformPayload(context.Background(), p.cfg.TransformCmd, body)
+		if err != nil {
+			p.cfg.OutCh <- websocket.ErrorElement{
+				Error: FailedToTransformError{Err: fmt.Errorf("event %s: %v", evt.ID, err)},
+			}
+			return
+		}
+
+		secret, _ := p.webhookSecret.Load().(string)
+		body = transformed
+		headers = resignHeaders(headers, secret, body)
+	}
+
+	if p.cfg.ExecCmd != "" {
+		p.exec(evtCtx, body, headers)
+		return
+	}
+
+	if p.cfg.CloudEvents != "" {
+		secret, _ := p.webhookSecret.Load().(string)
+
+		var err error
+		body, headers, err = toCloudEvent(p.cfg.CloudEvents, evt, body, headers, secret)
+		if err != nil {
+			p.cfg.OutCh <- websocket.ErrorElement{
+				Error: FailedToTransformError{Err: fmt.Errorf("event %s: could not convert it to a CloudEvent: %v", evt.ID, err)},
+			}
+			return
+		}
+	}
+
+	p.forwardToEndpoints(p.endpointClients, evtCtx, body, headers)
+}
+
+// forwardToEndpoints posts an event to every endpoint that supports it,
+// concurrently, and returns once they all responded.
+func (p *Proxy) forwardToEndpoints(endpoints []*EndpointClient, evtCtx eventContext, body string, headers map[string]string) {
+	var wg sync.WaitGroup
+
+	for _, endpoint := range endpoints {
+		if endpoint.SupportsEventType(evtCtx.event.IsConnect(), evtCtx.event.Type) {
+			wg.Add(1)
+			go func(endpoint *EndpointClient) {
+				defer wg.Done()
+				p.forward(endpoint, evtCtx, body, headers)
+			}(endpoint)
+		}
+	}
+
+	wg.Wait()
+}
+
+// Resend forwards a previously received event to the local endpoints again,
+// signed with the webhook signing secret of the current session.
+func (p *Proxy) Resend(evt StripeEvent) {
+	secret, _ := p.webhookSecret.Load().(string)
+	headers := resignHeaders(evt.Headers, secret, evt.Payload)
+
+	p.limiter.schedule(func() {
+		p.forwardEvent(eventContext{event: &evt}, evt.Payload, headers)
+	})
+}
+
+// forwardThinEvent attaches the related object to a thin event, if requested,
+// and forwards it to every thin event endpoint that supports it.
+func (p *Proxy) forwardThinEvent(evtCtx eventContext, body string, headers map[string]string) {
+	evt := evtCtx.event
+
+	if p.cfg.FetchRelatedObject && evt.RelatedObject != nil {
+		object, err := fetchRelatedObject(context.Background(), p.apiClient(), evt)
+		if err == nil {
+			body, err = attachRelatedObject(body, object)
+		}
+		if err != nil {
+			p.cfg.OutCh <- websocket.ErrorElement{
+				Error: FailedToFetchRelatedObjectError{Err: fmt.Errorf("event %s, with --fetch-related-object: %v", evt.ID, err)},
+			}
+			return
+		}
+
+		secret, _ := p.webhookSecret.Load().(string)
+		headers = resignHeaders(headers, secret, body)
+	}
+
+	p.forwardToEndpoints(p.thinEndpoints, evtCtx, body, headers)
+}
+
+// apiClient returns a client for requests to the Stripe API made while
+// processing events.
+func (p *Proxy) apiClient() *stripe.Client {
+	apiBaseURL := p.cfg.APIBaseURL
+	if apiBaseURL == "" {
+		apiBaseURL = stripe.DefaultAPIBaseURL
+	}
+
+	baseURL, _ := url.Parse(apiBaseURL)
+
+	return &stripe.Client{
+		BaseURL: baseURL,
+		APIKey:  p.cfg.Key,
+		Proxy:   p.proxyDialer,
+	}
+}
+
+// exec runs the exec command for an event.
+func (p *Proxy) exec(evtCtx eventContext, body string, headers map[string]string) {
+	result, err := runExecCommand(context.Background(), p.cfg.ExecCmd, evtCtx.event, body, headers)
+	if err != nil {
+		p.cfg.OutCh <- websocket.ErrorElement{
+			Error: FailedToExecError{Err: err},
+		}
+		return
+	}
+
+	p.cfg.OutCh <- websocket.DataElement{
+		Data: result,
+	}
+}
+
+// forward posts an event to a local endpoint.
+func (p *Proxy) forward(endpoint *EndpointClient, evtCtx eventContext, body string, headers map[string]string) {
+	if err := endpoint.Post(evtCtx, body, headers); err != nil {
+		p.history.recordDelivery(evtCtx.event.ID, EventDelivery{
+			URL:       endpoint.URL,
+			Err:       err.Error(),
+			Timestamp: time.Now(),
+		})
+	}
+}
+
 func (p *Proxy) processWebhookEvent(msg websocket.IncomingMessage) {
 	if msg.WebhookEvent == nil {
 		p.cfg.Log.Debug("WebSocket specified for Webhooks received non-webhook event")
@@ -329,6 +762,7 @@ func (p *Proxy) processWebhookEvent(msg websocket.IncomingMessage) {
 	}
 
 	webhookEvent := msg.WebhookEvent
+	atomic.StoreInt64(&p.lastEvent, time.Now().UnixNano())
 
 	p.cfg.Log.WithFields(log.Fields{
 		"prefix":                   "proxy.Proxy.processWebhookEvent",
@@ -338,7 +772,12 @@ func (p *Proxy) processWebhookEvent(msg websocket.IncomingMessage) {
 
 	var evt StripeEvent
 
-	err := json.Unmarshal([]byte(webhookEvent.EventPayload), &evt)
+	var err error
+	if isThinEventPayload(webhookEvent.EventPayload) {
+		evt, err = parseThinEvent(webhookEvent.EventPayload)
+	} else {
+		err = json.Unmarshal([]byte(webhookEvent.EventPayload), &evt)
+	}
 	if err != nil {
 		p.cfg.Log.Debug("Received malformed event from Stripe, ignoring")
 		return
@@ -352,6 +791,8 @@ func (p *Proxy) processWebhookEvent(msg websocket.IncomingMessage) {
 	}
 
 	evt.Request = req
+	evt.Payload = webhookEvent.EventPayload
+	evt.Headers = webhookEvent.HTTPHeaders
 
 	p.cfg.Log.WithFields(log.Fields{
 		"prefix":                  "proxy.Proxy.processWebhookEvent",
@@ -362,41 +803,86 @@ func (p *Proxy) processWebhookEvent(msg websocket.IncomingMessage) {
 		"api_version":             getAPIVersionString(msg.Endpoint.APIVersion),
 	}).Trace("Webhook event trace")
 
-	// at this point the message is valid so we can acknowledge it
-	ackMessage := websocket.NewEventAck(webhookEvent.WebhookID, webhookEvent.WebhookConversationID)
-	p.webSocketClient.SendMessage(ackMessage)
+	webhookEvent.HTTPHeaders = p.preserveSignature(webhookEvent.HTTPHeaders, webhookEvent.EventPayload)
+	evt.Headers = webhookEvent.HTTPHeaders
 
-	if p.filterWebhookEvent(webhookEvent) {
+	// at this point the message is valid so we can acknowledge it, unless
+	// it was relayed by another machine
+	if p.webSocketClient != nil {
+		ackMessage := websocket.NewEventAck(webhookEvent.WebhookID, webhookEvent.WebhookConversationID)
+		p.webSocketClient.SendMessage(ackMessage)
+	}
+
+	// Relayed events were already filtered by the API version of the
+	// session that received them
+	if p.cfg.RelaySession == "" && p.filterWebhookEvent(webhookEvent) {
 		return
 	}
 
+	if p.relay != nil {
+		p.relay.publish(webhookEvent)
+	}
+
 	evtCtx := eventContext{
 		webhookID:             webhookEvent.WebhookID,
 		webhookConversationID: webhookEvent.WebhookConversationID,
 		event:                 &evt,
 	}
 
-	if p.events["*"] || p.events[evt.Type] {
+	if !evt.MatchesMetadata(p.cfg.FilterMetadata) {
+		p.cfg.Log.WithFields(log.Fields{
+			"prefix":   "proxy.Proxy.processWebhookEvent",
+			"event_id": evt.ID,
+		}).Debugf("Received event not matching metadata filter, ignoring")
+
+		return
+	}
+
+	if !matchesFilters(webhookEvent.EventPayload, p.filters) {
+		p.cfg.Log.WithFields(log.Fields{
+			"prefix":   "proxy.Proxy.processWebhookEvent",
+			"event_id": evt.ID,
+		}).Debugf("Received event not matching filters, ignoring")
+
+		return
+	}
+
+	if p.supportsEventType(&evt) {
+		eventsReceived.Inc(evt.Type)
+		p.history.add(evt)
+
 		p.cfg.OutCh <- websocket.DataElement{
 			Data:      evt,
 			Marshaled: p.formatOutput(outputFormatJSON, webhookEvent.EventPayload),
 		}
 
-		for _, endpoint := range p.endpointClients {
-			if endpoint.SupportsEventType(evt.IsConnect(), evt.Type) {
-				// TODO: handle errors returned by endpointClients
-				go endpoint.Post(
-					evtCtx,
-					webhookEvent.EventPayload,
-					webhookEvent.HTTPHeaders,
-				)
-			}
-		}
+		p.dispatch(evtCtx, webhookEvent.EventPayload, webhookEvent.HTTPHeaders)
+	}
+}
+
+// supportsEventType returns true if the proxy listens for the event's type.
+func (p *Proxy) supportsEventType(evt *StripeEvent) bool {
+	if evt.IsThin() {
+		return p.thinEvents["*"] || p.thinEvents[evt.Type]
 	}
+
+	p.controlMu.Lock()
+	defer p.controlMu.Unlock()
+
+	return p.events["*"] || p.events[evt.Type]
 }
 
 func (p *Proxy) processEndpointResponse(evtCtx eventContext, forwardURL string, resp *http.Response) {
-	buf, err := ioutil.ReadAll(resp.Body)
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	// Only the beginning of the body is sent back to Stripe. One more byte is
+	// read so truncate knows whether the body was longer, and the rest is
+	// drained so the connection can be reused.
+	_, err := buf.ReadFrom(io.LimitReader(resp.Body, maxBodySize+1))
+	if err == nil {
+		_, err = io.Copy(ioutil.Discard, resp.Body)
+	}
 	if err != nil {
 		p.cfg.OutCh <- websocket.ErrorElement{
 			Error: FailedToReadResponseError{Err: err},
@@ -404,12 +890,20 @@ func (p *Proxy) processEndpointResponse(evtCtx eventContext, forwardURL string,
 		return
 	}
 
-	body := truncate(string(buf), maxBodySize, true)
+	body := truncate(buf.String(), maxBodySize, true)
+
+	p.history.recordDelivery(evtCtx.event.ID, EventDelivery{
+		URL:        forwardURL,
+		StatusCode: resp.StatusCode,
+		Latency:    evtCtx.latency,
+		Timestamp:  time.Now(),
+	})
 
 	p.cfg.OutCh <- websocket.DataElement{
 		Data: EndpointResponse{
-			Event: evtCtx.event,
-			Resp:  resp,
+			Event:   evtCtx.event,
+			Resp:    resp,
+			Latency: evtCtx.latency,
 		},
 	}
 
@@ -460,17 +954,67 @@ func Init(ctx context.Context, cfg *Config) (*Proxy, error) {
 		return nil, errors.New("load_from_webhooks_api requires a location to forward to with forward_to")
 	}
 
+	if cfg.ExecCmd != "" && (cfg.UseConfiguredWebhooks || len(cfg.ForwardURL) > 0 || len(cfg.ForwardConnectURL) > 0 || len(cfg.EndpointRoutes) > 0) {
+		return nil, errors.New("exec cannot be used together with forward_to, forward_connect_to or load_from_webhooks_api")
+	}
+
+	if cfg.CloudEvents != "" && cfg.CloudEvents != CloudEventsStructured && cfg.CloudEvents != CloudEventsBinary {
+		return nil, fmt.Errorf("unknown CloudEvents mode ‘%s’, expected %s or %s", cfg.CloudEvents, CloudEventsStructured, CloudEventsBinary)
+	}
+
+	if cfg.RelaySession != "" {
+		if cfg.RelayHost == "" {
+			return nil, errors.New("relay_session requires the address of the relay with relay_host")
+		}
+		if cfg.Tunnel || cfg.UseConfiguredWebhooks || cfg.FetchRelatedObject {
+			return nil, errors.New("relay_session cannot be used with tunnel, load_from_webhooks_api or fetch_related_object")
+		}
+	}
+
 	// if no events are passed, listen for all events
 	if len(cfg.Events) == 0 {
 		cfg.Events = []string{"*"}
 	} else {
+		events, err := ExpandEventPresets(cfg.Events)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Events = events
+
 		for _, event := range cfg.Events {
-			if _, found := validEvents[event]; !found {
+			if !IsValidEvent(event) {
 				cfg.Log.Infof("Warning: You're attempting to listen for \"%s\", which isn't a valid event\n", event)
 			}
 		}
 	}
 
+	// if thin events are forwarded without a list of types, listen for all of them
+	if len(cfg.ThinEvents) == 0 && (len(cfg.ForwardThinURL) > 0 || len(cfg.ForwardThinConnectURL) > 0) {
+		cfg.ThinEvents = []string{"*"}
+	}
+
+	if cfg.FetchRelatedObject && len(cfg.ThinEvents) == 0 {
+		return nil, errors.New("fetch_related_object requires thin events to be enabled with thin_events or forward_thin_to")
+	}
+
+	filters := make([]EventFilter, 0, len(cfg.Filters))
+	for _, expr := range cfg.Filters {
+		filter, err := ParseEventFilter(expr)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+
+	var tunnelTarget string
+	if cfg.Tunnel {
+		var err error
+		tunnelTarget, err = tunnelOrigin(cfg.ForwardURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// build from --forward-to urls if --forward-connect-to was not provided
 	if len(cfg.ForwardConnectURL) == 0 {
 		cfg.ForwardConnectURL = cfg.ForwardURL
@@ -478,12 +1022,24 @@ func Init(ctx context.Context, cfg *Config) (*Proxy, error) {
 	if len(cfg.ForwardConnectHeaders) == 0 {
 		cfg.ForwardConnectHeaders = cfg.ForwardHeaders
 	}
+	if len(cfg.ForwardThinConnectURL) == 0 {
+		cfg.ForwardThinConnectURL = cfg.ForwardThinURL
+	}
+
+	var proxyDialer *netproxy.Dialer
+	if cfg.Proxy != "" {
+		var err error
+		proxyDialer, err = netproxy.New(cfg.Proxy)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	// build endpoint routes
 	var endpointRoutes []EndpointRoute
 	if cfg.UseConfiguredWebhooks {
 		// build from user's API config
-		endpoints := getEndpointsFromAPI(ctx, cfg.Key, cfg.APIBaseURL)
+		endpoints := getEndpointsFromAPI(ctx, cfg.Key, cfg.APIBaseURL, proxyDialer)
 		if len(endpoints.Data) == 0 {
 			return nil, errors.New("You have not defined any webhook endpoints on your account. Go to the Stripe Dashboard to add some: https://dashboard.stripe.com/test/webhooks")
 		}
@@ -493,6 +1049,8 @@ func Init(ctx context.Context, cfg *Config) (*Proxy, error) {
 			return nil, err
 		}
 	} else {
+		endpointRoutes = append(endpointRoutes, cfg.EndpointRoutes...)
+
 		if len(cfg.ForwardURL) > 0 {
 			// non-connect endpoints
 			endpointRoutes = append(endpointRoutes, EndpointRoute{
@@ -514,52 +1072,89 @@ func Init(ctx context.Context, cfg *Config) (*Proxy, error) {
 		}
 	}
 
+	// build thin event endpoint routes
+	var thinEndpointRoutes []EndpointRoute
+	if len(cfg.ForwardThinURL) > 0 {
+		thinEndpointRoutes = append(thinEndpointRoutes, EndpointRoute{
+			URL:            parseURL(cfg.ForwardThinURL),
+			ForwardHeaders: cfg.ForwardHeaders,
+			Connect:        false,
+			EventTypes:     cfg.ThinEvents,
+		})
+	}
+	if len(cfg.ForwardThinConnectURL) > 0 {
+		thinEndpointRoutes = append(thinEndpointRoutes, EndpointRoute{
+			URL:            parseURL(cfg.ForwardThinConnectURL),
+			ForwardHeaders: cfg.ForwardConnectHeaders,
+			Connect:        true,
+			EventTypes:     cfg.ThinEvents,
+		})
+	}
+
+	tlsConfig, err := buildForwardTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	p := &Proxy{
 		cfg: cfg,
 		stripeAuthClient: stripeauth.NewClient(cfg.Key, &stripeauth.Config{
-			Log:        cfg.Log,
-			APIBaseURL: cfg.APIBaseURL,
+			Log:          cfg.Log,
+			APIBaseURL:   cfg.APIBaseURL,
+			Proxy:        proxyDialer,
+			SessionCache: cfg.SessionCache,
 		}),
-		events: convertToMap(cfg.Events),
+		events:       convertToMap(cfg.Events),
+		thinEvents:   convertToMap(cfg.ThinEvents),
+		filters:      filters,
+		limiter:      newForwardLimiter(cfg.MaxConcurrency, cfg.RateLimit),
+		proxyDialer:  proxyDialer,
+		tunnelTarget: tunnelTarget,
+		tunnelClient: newTunnelClient(tlsConfig),
+		history:      newEventHistory(eventHistorySize),
 	}
 
 	for _, route := range endpointRoutes {
 		// append to endpointClients
-		p.endpointClients = append(p.endpointClients, NewEndpointClient(
-			route.URL,
-			route.ForwardHeaders,
-			route.Connect,
-			route.EventTypes,
-			&EndpointConfig{
-				HTTPClient: &http.Client{
-					CheckRedirect: func(req *http.Request, via []*http.Request) error {
-						return http.ErrUseLastResponse
-					},
-					Timeout: defaultTimeout,
-					Transport: &http.Transport{
-						TLSClientConfig: &tls.Config{
-							CipherSuites: []uint16{
-        						tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA,
-        						tls.TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA,
-    						},
-    						PreferServerCipherSuites: true,
-    						InsecureSkipVerify:       true,
-    						MinVersion:               tls.VersionTLS11,
-    						MaxVersion:               tls.VersionTLS11,
-						
-						},
-					},
-				},
-				Log:             p.cfg.Log,
-				ResponseHandler: EndpointResponseHandlerFunc(p.processEndpointResponse),
-				OutCh:           p.cfg.OutCh,
-			},
-		))
+		p.endpointClients = append(p.endpointClients, p.newEndpointClient(route, tlsConfig))
+	}
+
+	for _, route := range thinEndpointRoutes {
+		p.thinEndpoints = append(p.thinEndpoints, p.newEndpointClient(route, tlsConfig))
 	}
 
 	return p, nil
 }
 
+// newEndpointClient returns a client forwarding events to route.
+func (p *Proxy) newEndpointClient(route EndpointRoute, tlsConfig *tls.Config) *EndpointClient {
+	var transport http.RoundTripper = &http.Transport{
+		TLSClientConfig: tlsConfig,
+	}
+	if isGRPCURL(route.URL) {
+		transport = newGRPCTransport(tlsConfig)
+	}
+
+	return NewEndpointClient(
+		route.URL,
+		route.ForwardHeaders,
+		route.Connect,
+		route.EventTypes,
+		&EndpointConfig{
+			HTTPClient: &http.Client{
+				CheckRedirect: func(req *http.Request, via []*http.Request) error {
+					return http.ErrUseLastResponse
+				},
+				Timeout:   defaultTimeout,
+				Transport: transport,
+			},
+			Log:             p.cfg.Log,
+			ResponseHandler: EndpointResponseHandlerFunc(p.processEndpointResponse),
+			OutCh:           p.cfg.OutCh,
+		},
+	)
+}
+
 // ExtractRequestData takes an interface with request data from a Stripe event payload
 // and properly parses it into a StripeRequest struct before returning it
 func ExtractRequestData(data interface{}) (StripeRequest, error) {
@@ -598,6 +1193,7 @@ type eventContext struct {
 	webhookID             string
 	webhookConversationID string
 	event                 *StripeEvent
+	latency               time.Duration
 }
 
 //
@@ -627,24 +1223,21 @@ func truncate(str string, maxByteLength int, ellipsis bool) string {
 		return str
 	}
 
-	bytes := []byte(str)
-
 	if ellipsis && maxByteLength > 3 {
 		maxByteLength -= 3
 	} else {
 		ellipsis = false
 	}
 
-	for maxByteLength > 0 && maxByteLength < len(bytes) && isUTF8ContinuationByte(bytes[maxByteLength]) {
+	for maxByteLength > 0 && maxByteLength < len(str) && isUTF8ContinuationByte(str[maxByteLength]) {
 		maxByteLength--
 	}
 
-	result := string(bytes[0:maxByteLength])
 	if ellipsis {
-		result += "..."
+		return str[:maxByteLength] + "..."
 	}
 
-	return result
+	return str[:maxByteLength]
 }
 
 func isUTF8ContinuationByte(b byte) bool {
@@ -666,6 +1259,10 @@ func parseURL(url string) string {
 		url = "localhost" + url
 	}
 
+	if isGRPCURL(url) {
+		return url
+	}
+
 	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
 		// Add the protocol if it's not already there
 		url = "http://" + url
@@ -674,12 +1271,54 @@ func parseURL(url string) string {
 	return url
 }
 
-func getEndpointsFromAPI(ctx context.Context, secretKey, apiBaseURL string) requests.WebhookEndpointList {
+// buildForwardTLSConfig builds the TLS configuration used by the endpoint
+// clients when forwarding webhooks to HTTPS endpoints.
+func buildForwardTLSConfig(cfg *Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: cfg.SkipVerify, // #nosec G402
+	}
+
+	if cfg.ForwardClientCert != "" || cfg.ForwardClientKey != "" {
+		if cfg.ForwardClientCert == "" || cfg.ForwardClientKey == "" {
+			return nil, errors.New("forward_client_cert and forward_client_key must be provided together")
+		}
+
+		cert, err := tls.LoadX509KeyPair(cfg.ForwardClientCert, cfg.ForwardClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("Could not load the client certificate %s of --forward-client-cert with the key %s of --forward-client-key: %v", cfg.ForwardClientCert, cfg.ForwardClientKey, err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if cfg.ForwardCACert != "" {
+		caCert, err := ioutil.ReadFile(cfg.ForwardCACert)
+		if err != nil {
+			return nil, fmt.Errorf("Could not read the CA certificate %s of --forward-ca-cert: %v", cfg.ForwardCACert, err)
+		}
+
+		// Trust the custom CA in addition to the system roots
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil || rootCAs == nil {
+			rootCAs = x509.NewCertPool()
+		}
+
+		if !rootCAs.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("No valid PEM certificates found in %s of --forward-ca-cert", cfg.ForwardCACert)
+		}
+
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	return tlsConfig, nil
+}
+
+func getEndpointsFromAPI(ctx context.Context, secretKey, apiBaseURL string, proxy *netproxy.Dialer) requests.WebhookEndpointList {
 	if apiBaseURL == "" {
 		apiBaseURL = stripe.DefaultAPIBaseURL
 	}
 
-	return requests.WebhookEndpointsList(ctx, apiBaseURL, stripe.APIVersion, secretKey, &config.Profile{})
+	return requests.WebhookEndpointsList(ctx, apiBaseURL, stripe.APIVersion, secretKey, &config.Profile{}, proxy)
 }
 
 func buildEndpointRoutes(endpoints requests.WebhookEndpointList, forwardURL, forwardConnectURL string, forwardHeaders []string, forwardConnectHeaders []string) ([]EndpointRoute, error) {
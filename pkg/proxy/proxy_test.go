@@ -2,9 +2,20 @@ package proxy
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
 	"net/url"
+	"path/filepath"
 	"testing"
+	"time"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 
 	"github.com/stripe/stripe-cli/pkg/requests"
@@ -45,6 +56,20 @@ func TestTruncate(t *testing.T) {
 	require.Equal(t, "Hello, ...", truncate("Hello, 世界", 12, true))
 }
 
+func TestFormatOutput(t *testing.T) {
+	p := &Proxy{cfg: &Config{Log: log.StandardLogger()}}
+
+	// Keys are sorted and HTML characters are escaped
+	output := p.formatOutput(outputFormatJSON, `{
+  "id": "evt_123",
+  "object": "event",
+  "data": {"object": {"description": "<a & b>"}}
+}`)
+	require.Equal(t, `{"data":{"object":{"description":"\u003ca \u0026 b\u003e"}},"id":"evt_123","object":"event"}`+"\n", output)
+
+	require.Contains(t, p.formatOutput(outputFormatJSON, `{"id":`), "unexpected end of JSON input")
+}
+
 func TestBuildEndpointRoutes(t *testing.T) {
 	localURL := "http://localhost"
 
@@ -180,6 +205,50 @@ func TestForwardToAndForwardConnectTo(t *testing.T) {
 	require.EqualValues(t, true, p.endpointClients[1].connect)
 }
 
+func TestEndpointRoutes(t *testing.T) {
+	cfg := Config{
+		EndpointRoutes: []EndpointRoute{
+			{URL: "http://localhost:4242/webhook", EventTypes: []string{"payment_intent.succeeded"}},
+			{URL: "http://localhost:4243/connect", Connect: true, EventTypes: []string{"account.updated"}},
+		},
+	}
+	p, err := Init(context.Background(), &cfg)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(p.endpointClients))
+	require.True(t, p.endpointClients[0].SupportsEventType(false, "payment_intent.succeeded"))
+	require.False(t, p.endpointClients[0].SupportsEventType(false, "account.updated"))
+	require.True(t, p.endpointClients[1].SupportsEventType(true, "account.updated"))
+}
+
+func TestForwardThinTo(t *testing.T) {
+	cfg := Config{
+		ForwardURL:     "http://localhost:4242",
+		ForwardThinURL: "http://localhost:4242/thin",
+	}
+	p, err := Init(context.Background(), &cfg)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(p.endpointClients))
+	require.Equal(t, 2, len(p.thinEndpoints))
+	require.EqualValues(t, "http://localhost:4242/thin", p.thinEndpoints[0].URL)
+	require.EqualValues(t, false, p.thinEndpoints[0].connect)
+	require.EqualValues(t, "http://localhost:4242/thin", p.thinEndpoints[1].URL)
+	require.EqualValues(t, true, p.thinEndpoints[1].connect)
+	require.True(t, p.thinEvents["*"])
+
+	filters, err := p.sessionFilters()
+	require.NoError(t, err)
+	require.Equal(t, `{"thin_events":["*"]}`, *filters)
+}
+
+func TestFetchRelatedObjectRequiresThinEvents(t *testing.T) {
+	cfg := Config{
+		ForwardURL:         "http://localhost:4242",
+		FetchRelatedObject: true,
+	}
+	_, err := Init(context.Background(), &cfg)
+	require.Error(t, err)
+}
+
 func TestExtractRequestData(t *testing.T) {
 	t.Run("null", func(t *testing.T) {
 		evt := StripeEvent{}
@@ -210,3 +279,81 @@ func TestExtractRequestData(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestBuildForwardTLSConfig(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		tlsConfig, err := buildForwardTLSConfig(&Config{SkipVerify: true})
+		require.NoError(t, err)
+		require.True(t, tlsConfig.InsecureSkipVerify)
+		require.Empty(t, tlsConfig.Certificates)
+		require.Nil(t, tlsConfig.RootCAs)
+	})
+	t.Run("cert without key", func(t *testing.T) {
+		_, err := buildForwardTLSConfig(&Config{ForwardClientCert: "client.pem"})
+		require.Error(t, err)
+	})
+	t.Run("missing client cert file", func(t *testing.T) {
+		_, err := buildForwardTLSConfig(&Config{ForwardClientCert: "missing-cert.pem", ForwardClientKey: "missing-key.pem"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "missing-cert.pem of --forward-client-cert")
+		require.Contains(t, err.Error(), "missing-key.pem of --forward-client-key")
+	})
+	t.Run("missing CA file", func(t *testing.T) {
+		caPath := filepath.Join(t.TempDir(), "ca.pem")
+		_, err := buildForwardTLSConfig(&Config{ForwardCACert: caPath})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), caPath+" of --forward-ca-cert")
+	})
+	t.Run("invalid CA file", func(t *testing.T) {
+		caPath := filepath.Join(t.TempDir(), "ca.pem")
+		require.NoError(t, ioutil.WriteFile(caPath, []byte("not a certificate"), 0600))
+
+		_, err := buildForwardTLSConfig(&Config{ForwardCACert: caPath})
+		require.Error(t, err)
+	})
+	t.Run("client cert and CA", func(t *testing.T) {
+		dir := t.TempDir()
+		certPEM, keyPEM := generateTestCertificate(t)
+
+		certPath := filepath.Join(dir, "cert.pem")
+		keyPath := filepath.Join(dir, "key.pem")
+		require.NoError(t, ioutil.WriteFile(certPath, certPEM, 0600))
+		require.NoError(t, ioutil.WriteFile(keyPath, keyPEM, 0600))
+
+		tlsConfig, err := buildForwardTLSConfig(&Config{
+			ForwardClientCert: certPath,
+			ForwardClientKey:  keyPath,
+			ForwardCACert:     certPath,
+		})
+		require.NoError(t, err)
+		require.False(t, tlsConfig.InsecureSkipVerify)
+		require.Len(t, tlsConfig.Certificates, 1)
+		require.NotNil(t, tlsConfig.RootCAs)
+	})
+}
+
+func generateTestCertificate(t *testing.T) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return certPEM, keyPEM
+}
@@ -0,0 +1,361 @@
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/stripe/stripe-cli/pkg/localcert"
+	"github.com/stripe/stripe-cli/pkg/websocket"
+)
+
+// relaySubscriberBuffer is the number of events kept for an attached machine
+// that's slow to read them, after which its events are dropped
+const relaySubscriberBuffer = 100
+
+// maxRelayMessageSize caps the size of a message read from the relay
+const maxRelayMessageSize = 10 * 1024 * 1024
+
+// relayCertValidity is how long the self-signed certificates of relays are
+// valid for. They're generated each time a relay starts.
+const relayCertValidity = 7 * 24 * time.Hour
+
+// RelayInfo describes a relay served by ServeRelay, for the machines that
+// attach to it
+type RelayInfo struct {
+	// Addr is the address the relay is bound to
+	Addr net.Addr
+	// SessionID is the ID of the relay session machines attach with
+	SessionID string
+	// Fingerprint is the SHA-256 fingerprint of the certificate of the relay,
+	// which attached machines pin
+	Fingerprint string
+}
+
+// relayHello is the first message of the relay stream, with the signing
+// secret of the session the events are signed with. It's only sent over TLS,
+// to machines that know the ID of the session.
+type relayHello struct {
+	Type   string `json:"type"` // always "relay_session"
+	Secret string `json:"secret"`
+}
+
+// relayHub fans the events received by a session out to the machines
+// attached to it through the relay. Events are relayed as received from
+// Stripe, with their signature headers, so attached machines verify them
+// with the signing secret of the session.
+type relayHub struct {
+	sessionID string
+	secret    func() string
+	log       *log.Logger
+
+	mu          sync.Mutex
+	subscribers map[chan []byte]bool
+}
+
+func newRelaySessionID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return "relay_" + hex.EncodeToString(b), nil
+}
+
+// publish sends an event to every attached machine.
+func (h *relayHub) publish(evt *websocket.WebhookEvent) {
+	msg, err := json.Marshal(evt)
+	if err != nil {
+		h.log.Debugf("Could not relay event: %v", err)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subscribers {
+		select {
+		case ch <- msg:
+		default:
+			h.log.WithFields(log.Fields{
+				"prefix": "proxy.relayHub.publish",
+			}).Warn("An attached machine is too slow to receive events, dropping one")
+		}
+	}
+}
+
+func (h *relayHub) subscribe() chan []byte {
+	ch := make(chan []byte, relaySubscriberBuffer)
+
+	h.mu.Lock()
+	h.subscribers[ch] = true
+	h.mu.Unlock()
+
+	return ch
+}
+
+func (h *relayHub) unsubscribe(ch chan []byte) {
+	h.mu.Lock()
+	delete(h.subscribers, ch)
+	h.mu.Unlock()
+}
+
+func (h *relayHub) attached() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.subscribers)
+}
+
+// ServeHTTP streams the events of the session to an attached machine, one
+// JSON message per line, on GET /sessions/<session ID>/events.
+func (h *relayHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	sessionID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/sessions/"), "/events")
+	if subtle.ConstantTimeCompare([]byte(sessionID), []byte(h.sessionID)) != 1 {
+		http.Error(w, "unknown relay session", http.StatusNotFound)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	ch := h.subscribe()
+	defer h.unsubscribe(ch)
+
+	h.log.WithFields(log.Fields{
+		"prefix": "proxy.relayHub.ServeHTTP",
+	}).Infof("%s attached to the relay", r.RemoteAddr)
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(relayHello{Type: "relay_session", Secret: h.secret()}); err != nil {
+		return
+	}
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			h.log.WithFields(log.Fields{
+				"prefix": "proxy.relayHub.ServeHTTP",
+			}).Infof("%s detached from the relay", r.RemoteAddr)
+			return
+		case msg := <-ch:
+			if _, err := w.Write(append(msg, '\n')); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// ServeRelay relays the events received by the proxy to the machines
+// attached to it on addr, so teammates can receive them without API keys of
+// their own. The relay serves TLS, with the certificate of RelayCert and
+// RelayKey or a self-signed one, since the signing secret of the session and
+// the events go through it. Requests are served in the background until the
+// process exits.
+func (p *Proxy) ServeRelay(addr string) (*RelayInfo, error) {
+	sessionID, err := newRelaySessionID()
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := relayCertificate(p.cfg.RelayCert, p.cfg.RelayKey)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	p.relay = &relayHub{
+		sessionID: sessionID,
+		secret: func() string {
+			secret, _ := p.webhookSecret.Load().(string)
+			return secret
+		},
+		log:         p.cfg.Log,
+		subscribers: make(map[chan []byte]bool),
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/sessions/", p.relay)
+
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	tlsListener := tls.NewListener(listener, &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	})
+
+	go server.Serve(tlsListener)
+
+	return &RelayInfo{
+		Addr:        listener.Addr(),
+		SessionID:   sessionID,
+		Fingerprint: certFingerprint(cert.Certificate[0]),
+	}, nil
+}
+
+// relayCertificate returns the certificate of certFile and keyFile, or a
+// self-signed certificate if they're not set
+func relayCertificate(certFile, keyFile string) (tls.Certificate, error) {
+	if certFile != "" || keyFile != "" {
+		return tls.LoadX509KeyPair(certFile, keyFile)
+	}
+
+	certPEM, keyPEM, err := localcert.Generate(relayCertValidity)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return tls.X509KeyPair(certPEM, keyPEM)
+}
+
+// certFingerprint returns the SHA-256 fingerprint of a DER certificate, in
+// hexadecimal
+func certFingerprint(der []byte) string {
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:])
+}
+
+// relayTLSConfig returns the TLS config machines attach to a relay with. The
+// certificate of the relay is pinned with its fingerprint, since relays
+// usually serve a self-signed certificate, or verified against the system's
+// roots if there's no fingerprint.
+func relayTLSConfig(fingerprint string) *tls.Config {
+	if fingerprint == "" {
+		return &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	fingerprint = strings.ToLower(strings.ReplaceAll(fingerprint, ":", ""))
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// The certificate is verified by VerifyPeerCertificate instead
+		InsecureSkipVerify: true, // #nosec G402
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 || subtle.ConstantTimeCompare([]byte(certFingerprint(rawCerts[0])), []byte(fingerprint)) != 1 {
+				return errors.New("the certificate of the relay doesn't match its fingerprint")
+			}
+			return nil
+		},
+	}
+}
+
+// runAttached receives the events of a session relayed by another machine,
+// instead of connecting to Stripe, and forwards them like the events of its
+// own session.
+func (p *Proxy) runAttached(ctx context.Context) error {
+	defer close(p.cfg.OutCh)
+
+	p.cfg.OutCh <- websocket.StateElement{
+		State: websocket.Loading,
+	}
+
+	err := p.receiveRelayedEvents(ctx)
+
+	if ctx.Err() != nil {
+		p.cfg.OutCh <- &websocket.StateElement{
+			State: websocket.Done,
+		}
+		return nil
+	}
+
+	p.cfg.OutCh <- websocket.ErrorElement{
+		Error: err,
+	}
+
+	return err
+}
+
+func (p *Proxy) receiveRelayedEvents(ctx context.Context) error {
+	relayURL := fmt.Sprintf("https://%s/sessions/%s/events", p.cfg.RelayHost, p.cfg.RelaySession)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, relayURL, nil)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: relayTLSConfig(p.cfg.RelayFingerprint),
+		},
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("Could not attach to the relay: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Could not attach to the relay: %s", resp.Status)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), maxRelayMessageSize)
+
+	if !scanner.Scan() {
+		return errors.New("Could not attach to the relay: the relay closed the connection")
+	}
+
+	var hello relayHello
+	if err := json.Unmarshal(scanner.Bytes(), &hello); err != nil || hello.Type != "relay_session" {
+		return errors.New("Could not attach to the relay: unexpected response from the relay")
+	}
+
+	p.webhookSecret.Store(hello.Secret)
+
+	p.cfg.OutCh <- websocket.StateElement{
+		State: websocket.Ready,
+		Data:  []string{"Attached to relay session " + p.cfg.RelaySession + ". ", hello.Secret, ""},
+	}
+
+	for scanner.Scan() {
+		var msg websocket.IncomingMessage
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			p.cfg.Log.Debug("Received malformed message from the relay, ignoring")
+			continue
+		}
+
+		p.processWebhookEvent(msg)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("Lost the connection to the relay: %v", err)
+	}
+
+	return errors.New("The relay session ended")
+}
@@ -0,0 +1,153 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/websocket"
+)
+
+func TestRelay(t *testing.T) {
+	type received struct {
+		body      string
+		signature string
+	}
+
+	receivedCh := make(chan received, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		receivedCh <- received{body: string(body), signature: r.Header.Get("Stripe-Signature")}
+	}))
+	defer ts.Close()
+
+	host, err := Init(context.Background(), &Config{
+		OutCh: make(chan websocket.IElement, 10),
+	})
+	require.NoError(t, err)
+	host.webhookSecret.Store("whsec_123")
+
+	relay, err := host.ServeRelay("127.0.0.1:0")
+	require.NoError(t, err)
+	require.Regexp(t, "^relay_[0-9a-f]{32}$", relay.SessionID)
+	require.Regexp(t, "^[0-9a-f]{64}$", relay.Fingerprint)
+
+	outCh := make(chan websocket.IElement, 10)
+	attached, err := Init(context.Background(), &Config{
+		ForwardURL:       ts.URL + "/webhooks",
+		RelayHost:        relay.Addr.String(),
+		RelaySession:     relay.SessionID,
+		RelayFingerprint: relay.Fingerprint,
+		OutCh:            outCh,
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go attached.Run(ctx)
+
+	require.Equal(t, websocket.StateElement{State: websocket.Loading}, <-outCh)
+	ready := (<-outCh).(websocket.StateElement)
+	require.Equal(t, websocket.Ready, ready.State)
+	require.Equal(t, "whsec_123", ready.Data[1])
+
+	require.Eventually(t, func() bool { return host.relay.attached() == 1 }, time.Second, 10*time.Millisecond)
+
+	payload := `{"id": "evt_123", "object": "event", "type": "customer.created", "data": {"object": {}}}`
+	host.processWebhookEvent(websocket.IncomingMessage{
+		WebhookEvent: &websocket.WebhookEvent{
+			EventPayload: payload,
+			HTTPHeaders:  map[string]string{"Stripe-Signature": "t=123,v1=hunter2"},
+			Type:         "webhook_event",
+			WebhookID:    "wh_123",
+		},
+	})
+
+	// The event is forwarded as received by the host, so its signature is
+	// still valid
+	select {
+	case r := <-receivedCh:
+		require.Equal(t, payload, r.body)
+		require.Equal(t, "t=123,v1=hunter2", r.signature)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the relayed event wasn't forwarded")
+	}
+
+	for el := range outCh {
+		if de, ok := el.(websocket.DataElement); ok {
+			if _, ok := de.Data.(EndpointResponse); ok {
+				break
+			}
+		}
+	}
+}
+
+func TestRelayUnknownSession(t *testing.T) {
+	host, err := Init(context.Background(), &Config{})
+	require.NoError(t, err)
+	host.webhookSecret.Store("whsec_123")
+
+	relay, err := host.ServeRelay("127.0.0.1:0")
+	require.NoError(t, err)
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: relayTLSConfig(relay.Fingerprint)}}
+	resp, err := client.Get(fmt.Sprintf("https://%s/sessions/relay_oops/events", relay.Addr))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	require.NotContains(t, string(body), "whsec_123")
+}
+
+func TestRelayRequiresTLS(t *testing.T) {
+	host, err := Init(context.Background(), &Config{})
+	require.NoError(t, err)
+	host.webhookSecret.Store("whsec_123")
+
+	relay, err := host.ServeRelay("127.0.0.1:0")
+	require.NoError(t, err)
+
+	resp, err := http.Get(fmt.Sprintf("http://%s/sessions/%s/events", relay.Addr, relay.SessionID))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	require.NotContains(t, string(body), "whsec_123")
+}
+
+func TestRelayWrongFingerprint(t *testing.T) {
+	host, err := Init(context.Background(), &Config{})
+	require.NoError(t, err)
+
+	relay, err := host.ServeRelay("127.0.0.1:0")
+	require.NoError(t, err)
+
+	attached, err := Init(context.Background(), &Config{
+		RelayHost:        relay.Addr.String(),
+		RelaySession:     relay.SessionID,
+		RelayFingerprint: strings.Repeat("0", 64),
+		OutCh:            make(chan websocket.IElement, 10),
+	})
+	require.NoError(t, err)
+
+	err = attached.receiveRelayedEvents(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "the certificate of the relay doesn't match its fingerprint")
+}
+
+func TestInitRelaySessionRequiresHost(t *testing.T) {
+	_, err := Init(context.Background(), &Config{RelaySession: "relay_123"})
+	require.EqualError(t, err, "relay_session requires the address of the relay with relay_host")
+}
@@ -0,0 +1,74 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/stripeauth"
+	"github.com/stripe/stripe-cli/pkg/websocket"
+)
+
+func TestPreserveSignature(t *testing.T) {
+	p, err := Init(context.Background(), &Config{
+		OutCh: make(chan websocket.IElement, 10),
+	})
+	require.NoError(t, err)
+
+	headers := map[string]string{"Stripe-Signature": "t=123,v1=hunter2", "Content-Type": "application/json"}
+	payload := `{"id": "evt_123"}`
+
+	// Events of the first session are forwarded as received
+	p.webhookSecret.Store("whsec_first")
+	p.sessionSecret.Store("whsec_first")
+	require.Equal(t, headers, p.preserveSignature(headers, payload))
+
+	// After a refresh they're signed with the secret of the first session
+	p.sessionSecret.Store("whsec_refreshed")
+	signed := p.preserveSignature(headers, payload)
+	require.Equal(t, "application/json", signed["Content-Type"])
+	require.True(t, strings.HasPrefix(signed["Stripe-Signature"], "t="))
+
+	var timestamp int64
+	_, err = fmt.Sscanf(signed["Stripe-Signature"], "t=%d,", &timestamp)
+	require.NoError(t, err)
+	require.Equal(t, signPayload("whsec_first", payload, time.Unix(timestamp, 0)), signed["Stripe-Signature"])
+}
+
+func TestRefreshSession(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/stripecli/sessions", r.URL.Path)
+		w.Write([]byte(`{"secret": "whsec_refreshed", "websocket_id": "ws_2", "websocket_url": "wss://example.com"}`))
+	}))
+	defer ts.Close()
+
+	p, err := Init(context.Background(), &Config{
+		APIBaseURL: ts.URL,
+		OutCh:      make(chan websocket.IElement, 10),
+	})
+	require.NoError(t, err)
+
+	refreshed := make(chan *stripeauth.StripeCLISession, 1)
+
+	// Sessions that don't expire aren't refreshed
+	p.refreshSession(context.Background(), &stripeauth.StripeCLISession{}, refreshed)
+	require.Empty(t, refreshed)
+
+	// Sessions that are about to expire are refreshed right away
+	expiring := &stripeauth.StripeCLISession{ExpiresAt: time.Now().Add(time.Minute).Unix()}
+	p.refreshSession(context.Background(), expiring, refreshed)
+
+	select {
+	case next := <-refreshed:
+		require.Equal(t, "ws_2", next.WebSocketID)
+		require.Equal(t, "whsec_refreshed", next.Secret)
+	default:
+		t.Fatal("the session wasn't refreshed")
+	}
+}
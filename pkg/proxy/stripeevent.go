@@ -1,6 +1,9 @@
 package proxy
 
-import "fmt"
+import (
+	"fmt"
+	"net/url"
+)
 
 // StripeEvent is a representation of a Stripe `event` object
 // we define RequestData as an interface for backwards compatibility
@@ -12,10 +15,17 @@ type StripeEvent struct {
 	Data            map[string]interface{} `json:"data"`
 	ID              string                 `json:"id"`
 	Livemode        bool                   `json:"livemode"`
+	Object          string                 `json:"object"`
 	PendingWebhooks int                    `json:"pending_webhooks"`
 	Type            string                 `json:"type"`
 	RequestData     interface{}            `json:"request"`
 	Request         StripeRequest
+	// RelatedObject references the object a thin event is about
+	RelatedObject *RelatedObject `json:"related_object,omitempty"`
+	// Payload is the raw JSON payload of the event as received from Stripe
+	Payload string `json:"-"`
+	// Headers are the HTTP headers Stripe sent along with the event
+	Headers map[string]string `json:"-"`
 }
 
 // StripeRequest is a representation of the Request field in a Stripe `event` object
@@ -29,6 +39,38 @@ func (e *StripeEvent) IsConnect() bool {
 	return e.Account != ""
 }
 
+// IsThin returns true if the event is a thin event sent by a v2 event
+// destination.
+func (e *StripeEvent) IsThin() bool {
+	return e.Object == thinEventObject
+}
+
+// MatchesMetadata returns true if the metadata of the event's object contains
+// every key/value pair in filters. An empty filter matches every event.
+func (e *StripeEvent) MatchesMetadata(filters map[string]string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+
+	object, ok := e.Data["object"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	metadata, ok := object["metadata"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	for k, v := range filters {
+		if value, ok := metadata[k].(string); !ok || value != v {
+			return false
+		}
+	}
+
+	return true
+}
+
 // URLForEventID builds a full URL from a StripeEvent ID.
 func (e *StripeEvent) URLForEventID() string {
 	return fmt.Sprintf("%s/events/%s", baseDashboardURL(e.Livemode, e.Account), e.ID)
@@ -39,6 +81,55 @@ func (e *StripeEvent) URLForEventType() string {
 	return fmt.Sprintf("%s/events?type=%s", baseDashboardURL(e.Livemode, e.Account), e.Type)
 }
 
+// dashboardPaths maps object types to the Dashboard page listing them.
+var dashboardPaths = map[string]string{
+	"account":        "connect/accounts",
+	"charge":         "payments",
+	"coupon":         "coupons",
+	"customer":       "customers",
+	"dispute":        "disputes",
+	"invoice":        "invoices",
+	"payment_intent": "payments",
+	"payout":         "payouts",
+	"price":          "prices",
+	"product":        "products",
+	"subscription":   "subscriptions",
+}
+
+// ObjectType returns the type of the event's object, e.g. `customer`, or an
+// empty string if the event has no object.
+func (e *StripeEvent) ObjectType() string {
+	object, _ := e.Data["object"].(map[string]interface{})
+	objectType, _ := object["object"].(string)
+
+	return objectType
+}
+
+// ObjectID returns the ID of the event's object, or an empty string if the
+// event has no object or the object has no ID.
+func (e *StripeEvent) ObjectID() string {
+	object, _ := e.Data["object"].(map[string]interface{})
+	id, _ := object["id"].(string)
+
+	return id
+}
+
+// URLForObject builds the Dashboard URL of the event's object. Objects
+// without a dedicated page link to a Dashboard search for their ID. It
+// returns an empty string if the event has no object ID.
+func (e *StripeEvent) URLForObject() string {
+	id := e.ObjectID()
+	if id == "" {
+		return ""
+	}
+
+	if path, ok := dashboardPaths[e.ObjectType()]; ok {
+		return fmt.Sprintf("%s/%s/%s", baseDashboardURL(e.Livemode, e.Account), path, id)
+	}
+
+	return fmt.Sprintf("%s/search?query=%s", baseDashboardURL(e.Livemode, e.Account), url.QueryEscape(id))
+}
+
 func baseDashboardURL(livemode bool, account string) string {
 	maybeTest := ""
 	if !livemode {
@@ -14,6 +14,38 @@ func TestIsConnect(t *testing.T) {
 	require.True(t, evt2.IsConnect())
 }
 
+func TestMatchesMetadata(t *testing.T) {
+	evt := &StripeEvent{
+		ID:   "evt_123",
+		Type: "charge.succeeded",
+		Data: map[string]interface{}{
+			"object": map[string]interface{}{
+				"id": "ch_123",
+				"metadata": map[string]interface{}{
+					"order_source": "webstore",
+					"team":         "payments",
+				},
+			},
+		},
+	}
+	require.True(t, evt.MatchesMetadata(nil))
+	require.True(t, evt.MatchesMetadata(map[string]string{"order_source": "webstore"}))
+	require.True(t, evt.MatchesMetadata(map[string]string{"order_source": "webstore", "team": "payments"}))
+	require.False(t, evt.MatchesMetadata(map[string]string{"order_source": "pos"}))
+	require.False(t, evt.MatchesMetadata(map[string]string{"order_source": "webstore", "team": "billing"}))
+	require.False(t, evt.MatchesMetadata(map[string]string{"region": "eu"}))
+
+	evtWithoutMetadata := &StripeEvent{
+		ID:   "evt_456",
+		Type: "balance.available",
+		Data: map[string]interface{}{
+			"object": map[string]interface{}{"object": "balance"},
+		},
+	}
+	require.True(t, evtWithoutMetadata.MatchesMetadata(map[string]string{}))
+	require.False(t, evtWithoutMetadata.MatchesMetadata(map[string]string{"order_source": "webstore"}))
+}
+
 func TestUrlForEventID(t *testing.T) {
 	evt := &StripeEvent{ID: "evt_123", Livemode: false, Type: "customer.created"}
 	require.Equal(t, "https://dashboard.stripe.com/test/events/evt_123", evt.URLForEventID())
@@ -41,3 +73,30 @@ func TestURLForEventType(t *testing.T) {
 	evt = &StripeEvent{ID: "evt_123", Livemode: true, Type: "customer.created", Account: "acct_123"}
 	require.Equal(t, "https://dashboard.stripe.com/acct_123/events?type=customer.created", evt.URLForEventType())
 }
+
+func TestURLForObject(t *testing.T) {
+	customerEvt := &StripeEvent{
+		ID:   "evt_123",
+		Type: "customer.created",
+		Data: map[string]interface{}{
+			"object": map[string]interface{}{"id": "cus_123", "object": "customer"},
+		},
+	}
+	require.Equal(t, "cus_123", customerEvt.ObjectID())
+	require.Equal(t, "customer", customerEvt.ObjectType())
+	require.Equal(t, "https://dashboard.stripe.com/test/customers/cus_123", customerEvt.URLForObject())
+
+	connectEvt := &StripeEvent{
+		ID:       "evt_123",
+		Type:     "checkout.session.completed",
+		Account:  "acct_123",
+		Livemode: true,
+		Data: map[string]interface{}{
+			"object": map[string]interface{}{"id": "cs_123", "object": "checkout.session"},
+		},
+	}
+	require.Equal(t, "https://dashboard.stripe.com/acct_123/search?query=cs_123", connectEvt.URLForObject())
+
+	noObjectEvt := &StripeEvent{ID: "evt_123", Type: "balance.available"}
+	require.Equal(t, "", noObjectEvt.URLForObject())
+}
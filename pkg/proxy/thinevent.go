@@ -0,0 +1,135 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/stripe/stripe-cli/pkg/stripe"
+)
+
+// thinEventObject is the `object` value of thin events sent by v2 event
+// destinations.
+const thinEventObject = "v2.core.event"
+
+// RelatedObject is a reference to the object a thin event is about.
+type RelatedObject struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// FailedToFetchRelatedObjectError describes a failure to fetch the related
+// object of a thin event before forwarding it
+type FailedToFetchRelatedObjectError struct {
+	Err error
+}
+
+func (f FailedToFetchRelatedObjectError) Error() string {
+	return f.Err.Error()
+}
+
+// thinEvent is the payload of a thin event. Unlike snapshot events, its
+// creation date is an RFC 3339 timestamp and it carries a reference to the
+// related object instead of a copy of it.
+type thinEvent struct {
+	ID            string         `json:"id"`
+	Object        string         `json:"object"`
+	Type          string         `json:"type"`
+	Created       string         `json:"created"`
+	Context       string         `json:"context"`
+	Livemode      bool           `json:"livemode"`
+	RelatedObject *RelatedObject `json:"related_object"`
+}
+
+// isThinEventPayload returns true if payload is a thin event.
+func isThinEventPayload(payload string) bool {
+	var object struct {
+		Object string `json:"object"`
+	}
+
+	if err := json.Unmarshal([]byte(payload), &object); err != nil {
+		return false
+	}
+
+	return object.Object == thinEventObject
+}
+
+// parseThinEvent decodes a thin event payload into a StripeEvent.
+func parseThinEvent(payload string) (StripeEvent, error) {
+	var thin thinEvent
+
+	if err := json.Unmarshal([]byte(payload), &thin); err != nil {
+		return StripeEvent{}, err
+	}
+
+	evt := StripeEvent{
+		Account:       thin.Context,
+		ID:            thin.ID,
+		Livemode:      thin.Livemode,
+		Object:        thin.Object,
+		Type:          thin.Type,
+		RelatedObject: thin.RelatedObject,
+	}
+
+	if created, err := time.Parse(time.RFC3339, thin.Created); err == nil {
+		evt.Created = int(created.Unix())
+	}
+
+	return evt, nil
+}
+
+// fetchRelatedObject retrieves the related object of a thin event from the
+// Stripe API.
+func fetchRelatedObject(ctx context.Context, client *stripe.Client, evt *StripeEvent) (json.RawMessage, error) {
+	if evt.RelatedObject == nil || evt.RelatedObject.URL == "" {
+		return nil, fmt.Errorf("event %s has no related object", evt.ID)
+	}
+
+	resp, err := client.PerformRequest(ctx, http.MethodGet, evt.RelatedObject.URL, "", func(req *http.Request) {
+		if evt.Account != "" {
+			req.Header.Set("Stripe-Account", evt.Account)
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s for event %s failed: %w", evt.RelatedObject.URL, evt.ID, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s for event %s failed with status %d", evt.RelatedObject.URL, evt.ID, resp.StatusCode)
+	}
+
+	if !json.Valid(body) {
+		return nil, fmt.Errorf("fetching %s for event %s returned invalid JSON", evt.RelatedObject.URL, evt.ID)
+	}
+
+	return body, nil
+}
+
+// attachRelatedObject adds object to the thin event payload under the
+// `related_object_data` key.
+func attachRelatedObject(payload string, object json.RawMessage) (string, error) {
+	var fields map[string]json.RawMessage
+
+	if err := json.Unmarshal([]byte(payload), &fields); err != nil {
+		return "", err
+	}
+
+	fields["related_object_data"] = object
+
+	attached, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+
+	return string(attached), nil
+}
@@ -0,0 +1,98 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/stripe"
+)
+
+const testThinEventPayload = `{
+  "id": "evt_test_65R",
+  "object": "v2.core.event",
+  "type": "v1.billing.meter.error_report_triggered",
+  "created": "2024-09-17T06:20:52.246Z",
+  "context": "acct_123",
+  "livemode": false,
+  "related_object": {
+    "id": "mtr_test_123",
+    "type": "billing.meter",
+    "url": "/v1/billing/meters/mtr_test_123"
+  }
+}`
+
+func TestIsThinEventPayload(t *testing.T) {
+	require.True(t, isThinEventPayload(testThinEventPayload))
+	require.False(t, isThinEventPayload(`{"id": "evt_123", "object": "event"}`))
+	require.False(t, isThinEventPayload(`not json`))
+}
+
+func TestParseThinEvent(t *testing.T) {
+	evt, err := parseThinEvent(testThinEventPayload)
+	require.NoError(t, err)
+	require.True(t, evt.IsThin())
+	require.True(t, evt.IsConnect())
+	require.Equal(t, "evt_test_65R", evt.ID)
+	require.Equal(t, "v1.billing.meter.error_report_triggered", evt.Type)
+	require.Equal(t, "acct_123", evt.Account)
+	require.Equal(t, 1726554052, evt.Created)
+	require.Equal(t, &RelatedObject{
+		ID:   "mtr_test_123",
+		Type: "billing.meter",
+		URL:  "/v1/billing/meters/mtr_test_123",
+	}, evt.RelatedObject)
+}
+
+func TestFetchRelatedObject(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/v1/billing/meters/mtr_test_123", r.URL.Path)
+		require.Equal(t, "Bearer sk_test_123", r.Header.Get("Authorization"))
+		require.Equal(t, "acct_123", r.Header.Get("Stripe-Account"))
+		w.Write([]byte(`{"id": "mtr_test_123", "object": "billing.meter"}`))
+	}))
+	defer ts.Close()
+
+	baseURL, _ := url.Parse(ts.URL)
+	client := &stripe.Client{BaseURL: baseURL, APIKey: "sk_test_123"}
+
+	evt, err := parseThinEvent(testThinEventPayload)
+	require.NoError(t, err)
+
+	object, err := fetchRelatedObject(context.Background(), client, &evt)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"id": "mtr_test_123", "object": "billing.meter"}`, string(object))
+}
+
+func TestFetchRelatedObjectError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": {"message": "No such meter"}}`))
+	}))
+	defer ts.Close()
+
+	baseURL, _ := url.Parse(ts.URL)
+	client := &stripe.Client{BaseURL: baseURL, APIKey: "sk_test_123"}
+
+	evt, err := parseThinEvent(testThinEventPayload)
+	require.NoError(t, err)
+
+	_, err = fetchRelatedObject(context.Background(), client, &evt)
+	require.EqualError(t, err, "fetching /v1/billing/meters/mtr_test_123 for event evt_test_65R failed with status 404")
+}
+
+func TestAttachRelatedObject(t *testing.T) {
+	attached, err := attachRelatedObject(testThinEventPayload, json.RawMessage(`{"id": "mtr_test_123"}`))
+	require.NoError(t, err)
+
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(attached), &fields))
+	require.Equal(t, "evt_test_65R", fields["id"])
+	require.Equal(t, map[string]interface{}{"id": "mtr_test_123"}, fields["related_object_data"])
+}
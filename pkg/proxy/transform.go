@@ -0,0 +1,85 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/stripe/stripe-cli/pkg/shell"
+)
+
+// transformTimeout bounds how long a --transform-cmd command may run for a
+// single event.
+const transformTimeout = 30 * time.Second
+
+// FailedToTransformError describes a failure to transform an event payload
+// before forwarding it
+type FailedToTransformError struct {
+	Err error
+}
+
+func (f FailedToTransformError) Error() string {
+	return f.Err.Error()
+}
+
+// transformPayload pipes payload through command and returns what the command
+// printed to stdout, which must be valid JSON.
+func transformPayload(ctx context.Context, command string, payload string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, transformTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := shell.Command(ctx, command)
+	cmd.Stdin = strings.NewReader(payload)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("transform command failed: %v: %s", err, msg)
+		}
+		return "", fmt.Errorf("transform command failed: %v", err)
+	}
+
+	transformed := bytes.TrimSpace(stdout.Bytes())
+	if !json.Valid(transformed) {
+		return "", fmt.Errorf("transform command did not output valid JSON")
+	}
+
+	return string(transformed), nil
+}
+
+// signPayload computes a Stripe-Signature header value for payload, so that
+// endpoints verifying signatures accept transformed payloads.
+func signPayload(secret string, payload string, t time.Time) string {
+	timestamp := t.Unix()
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("%d.%s", timestamp, payload)))
+
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
+
+// resignHeaders returns a copy of headers with the Stripe-Signature header
+// replaced by one matching payload.
+func resignHeaders(headers map[string]string, secret string, payload string) map[string]string {
+	signed := make(map[string]string, len(headers))
+
+	for k, v := range headers {
+		if strings.EqualFold(k, "Stripe-Signature") {
+			continue
+		}
+		signed[k] = v
+	}
+
+	signed["Stripe-Signature"] = signPayload(secret, payload, time.Now())
+
+	return signed
+}
@@ -0,0 +1,51 @@
+package proxy
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransformPayload(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+
+	transformed, err := transformPayload(context.Background(), `sed 's/jenny@example.com/redacted/'`, `{"email":"jenny@example.com"}`)
+	require.NoError(t, err)
+	require.Equal(t, `{"email":"redacted"}`, transformed)
+}
+
+func TestTransformPayloadErrors(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+
+	_, err := transformPayload(context.Background(), "echo oops >&2; exit 1", `{}`)
+	require.EqualError(t, err, "transform command failed: exit status 1: oops")
+
+	_, err = transformPayload(context.Background(), "echo not json", `{}`)
+	require.EqualError(t, err, "transform command did not output valid JSON")
+}
+
+func TestSignPayload(t *testing.T) {
+	// https://stripe.com/docs/webhooks/signatures#verify-manually
+	signature := signPayload("whsec_test", `{"id":"evt_123"}`, time.Unix(1600000000, 0))
+	require.Equal(t, "t=1600000000,v1=0050992d1c829b59a20304a8abff9014aa8e52dfee14a2b9c5d8477bc8a277b0", signature)
+}
+
+func TestResignHeaders(t *testing.T) {
+	headers := map[string]string{
+		"Content-Type":     "application/json",
+		"stripe-signature": "t=123,v1=hunter2",
+	}
+
+	signed := resignHeaders(headers, "whsec_test", `{}`)
+	require.Equal(t, "application/json", signed["Content-Type"])
+	require.NotContains(t, signed, "stripe-signature")
+	require.Regexp(t, `^t=\d+,v1=[0-9a-f]{64}$`, signed["Stripe-Signature"])
+	require.Equal(t, "t=123,v1=hunter2", headers["stripe-signature"])
+}
@@ -0,0 +1,210 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/stripe/stripe-cli/pkg/stripeauth"
+	"github.com/stripe/stripe-cli/pkg/websocket"
+)
+
+// closeTunnelTimeout is how long closing the tunnel may take when the proxy
+// stops
+const closeTunnelTimeout = 5 * time.Second
+
+// maxTunnelResponseSize caps the size of the responses sent back through the
+// tunnel
+const maxTunnelResponseSize = 10 * 1024 * 1024
+
+// hopByHopHeaders are not forwarded through the tunnel in either direction
+var hopByHopHeaders = map[string]bool{
+	"connection":          true,
+	"keep-alive":          true,
+	"proxy-authenticate":  true,
+	"proxy-authorization": true,
+	"te":                  true,
+	"trailer":             true,
+	"transfer-encoding":   true,
+	"upgrade":             true,
+}
+
+// tunnelOrigin returns the origin (scheme and host) of forwardURL, which
+// requests received by the public URL of the tunnel are sent to with their
+// own path.
+func tunnelOrigin(forwardURL string) (string, error) {
+	if forwardURL == "" {
+		return "", errors.New("tunnel requires forward_to to be set")
+	}
+
+	if isGRPCURL(forwardURL) {
+		return "", errors.New("tunnel can only be used with HTTP endpoints")
+	}
+
+	parsed, err := url.Parse(parseURL(forwardURL))
+	if err != nil {
+		return "", err
+	}
+
+	return parsed.Scheme + "://" + parsed.Host, nil
+}
+
+// newTunnelClient returns the client requests received through the tunnel
+// are sent to the local endpoint with.
+func newTunnelClient(tlsConfig *tls.Config) *http.Client {
+	return &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+		Timeout: defaultTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig: tlsConfig,
+		},
+	}
+}
+
+// processMessage dispatches the messages received through the websocket.
+func (p *Proxy) processMessage(msg websocket.IncomingMessage) {
+	if msg.TunnelRequest != nil {
+		p.processTunnelRequest(msg.TunnelRequest)
+		return
+	}
+
+	p.processWebhookEvent(msg)
+}
+
+// processTunnelRequest sends a request received by the public URL of the
+// tunnel to the local endpoint, and sends its response back to Stripe.
+func (p *Proxy) processTunnelRequest(req *websocket.TunnelRequest) {
+	p.cfg.Log.WithFields(log.Fields{
+		"prefix":            "proxy.Proxy.processTunnelRequest",
+		"tunnel_request_id": req.TunnelRequestID,
+	}).Debugf("Processing tunnel request")
+
+	status, body, headers, err := p.doTunnelRequest(req)
+	if err != nil {
+		p.cfg.Log.WithFields(log.Fields{
+			"prefix": "proxy.Proxy.processTunnelRequest",
+		}).Errorf("Failed to forward %s %s through the tunnel: %v", req.Method, req.Path, err)
+
+		status = http.StatusBadGateway
+		body = []byte(err.Error())
+		headers = map[string]string{"Content-Type": "text/plain; charset=utf-8"}
+	} else {
+		p.cfg.Log.WithFields(log.Fields{
+			"prefix": "proxy.Proxy.processTunnelRequest",
+		}).Infof("Tunnel %s %s [%d]", req.Method, req.Path, status)
+	}
+
+	if p.webSocketClient != nil {
+		p.webSocketClient.SendMessage(websocket.NewTunnelResponse(req.TunnelRequestID, status, body, headers))
+	}
+}
+
+func (p *Proxy) doTunnelRequest(req *websocket.TunnelRequest) (int, []byte, map[string]string, error) {
+	path := req.Path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	httpReq, err := http.NewRequest(req.Method, p.tunnelTarget+path, bytes.NewReader(req.Body))
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	for k, v := range req.HTTPHeaders {
+		if hopByHopHeaders[strings.ToLower(k)] {
+			continue
+		}
+
+		if strings.ToLower(k) == "host" {
+			httpReq.Host = v
+		} else {
+			httpReq.Header.Set(k, v)
+		}
+	}
+
+	resp, err := p.tunnelClient.Do(httpReq)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxTunnelResponseSize+1))
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	if len(body) > maxTunnelResponseSize {
+		return 0, nil, nil, fmt.Errorf("response is larger than %d bytes", maxTunnelResponseSize)
+	}
+
+	headers := make(map[string]string)
+	for k := range resp.Header {
+		if !hopByHopHeaders[strings.ToLower(k)] {
+			headers[k] = resp.Header.Get(k)
+		}
+	}
+
+	return resp.StatusCode, body, headers, nil
+}
+
+// keepTunnel records the tunnel of a new session as the open tunnel, closing
+// the previous one if the session didn't keep it. It fails if a tunnel was
+// requested and Stripe didn't open one.
+func (p *Proxy) keepTunnel(session *stripeauth.StripeCLISession) error {
+	if p.tunnelTarget == "" {
+		return nil
+	}
+
+	if session.TunnelURL == "" {
+		p.closeOpenTunnel()
+		return errors.New("Stripe didn't open a public URL for --tunnel. Tunnels may not be available to your account yet")
+	}
+
+	if previous := p.openTunnel(); previous != session.TunnelID {
+		p.closeTunnel(previous)
+	}
+	p.tunnelID.Store(session.TunnelID)
+
+	return nil
+}
+
+// openTunnel returns the ID of the open tunnel, if any
+func (p *Proxy) openTunnel() string {
+	tunnelID, _ := p.tunnelID.Load().(string)
+	return tunnelID
+}
+
+// closeOpenTunnel tears down the open tunnel, if any.
+func (p *Proxy) closeOpenTunnel() {
+	if tunnelID := p.openTunnel(); tunnelID != "" {
+		p.closeTunnel(tunnelID)
+		p.tunnelID.Store("")
+	}
+}
+
+// closeTunnel tears down the public tunnel of the session, if any.
+func (p *Proxy) closeTunnel(tunnelID string) {
+	if tunnelID == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), closeTunnelTimeout)
+	defer cancel()
+
+	if err := p.stripeAuthClient.CloseTunnel(ctx, tunnelID); err != nil {
+		p.cfg.Log.WithFields(log.Fields{
+			"prefix": "proxy.Proxy.closeTunnel",
+		}).Debugf("Failed to close the tunnel: %v", err)
+	}
+}
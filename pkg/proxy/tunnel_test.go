@@ -0,0 +1,117 @@
+package proxy
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/stripeauth"
+	"github.com/stripe/stripe-cli/pkg/websocket"
+)
+
+func TestTunnelOrigin(t *testing.T) {
+	origin, err := tunnelOrigin("localhost:3000/webhook")
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:3000", origin)
+
+	origin, err = tunnelOrigin("https://example.test:8443/hooks/stripe")
+	require.NoError(t, err)
+	require.Equal(t, "https://example.test:8443", origin)
+
+	_, err = tunnelOrigin("")
+	require.EqualError(t, err, "tunnel requires forward_to to be set")
+
+	_, err = tunnelOrigin("grpc://localhost:50052/stripe.cli.eventhandler.v1.EventHandler/Handle")
+	require.EqualError(t, err, "tunnel can only be used with HTTP endpoints")
+}
+
+func TestDoTunnelRequest(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/callback", r.URL.Path)
+		require.Equal(t, "state=1", r.URL.RawQuery)
+		require.Equal(t, "partner", r.Header.Get("X-Sender"))
+		require.Empty(t, r.Header.Get("Upgrade"))
+		require.Equal(t, `{"ok":true}`, string(body))
+
+		w.Header().Set("X-Handled", "yes")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("created"))
+	}))
+	defer ts.Close()
+
+	p := &Proxy{
+		cfg:          &Config{},
+		tunnelTarget: ts.URL,
+		tunnelClient: newTunnelClient(nil),
+	}
+
+	status, body, headers, err := p.doTunnelRequest(&websocket.TunnelRequest{
+		TunnelRequestID: "tr_123",
+		Method:          http.MethodPost,
+		Path:            "/callback?state=1",
+		HTTPHeaders:     map[string]string{"X-Sender": "partner", "Upgrade": "websocket"},
+		Body:            []byte(`{"ok":true}`),
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusCreated, status)
+	require.Equal(t, "created", string(body))
+	require.Equal(t, "yes", headers["X-Handled"])
+}
+
+func TestDoTunnelRequestUnreachable(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	ts.Close()
+
+	p := &Proxy{
+		cfg:          &Config{},
+		tunnelTarget: ts.URL,
+		tunnelClient: newTunnelClient(nil),
+	}
+
+	_, _, _, err := p.doTunnelRequest(&websocket.TunnelRequest{Method: http.MethodGet, Path: "/"})
+	require.Error(t, err)
+}
+
+func TestKeepTunnel(t *testing.T) {
+	var closed []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodDelete, r.Method)
+		closed = append(closed, strings.TrimPrefix(r.URL.Path, "/v1/stripecli/tunnels/"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	p := &Proxy{
+		cfg:              &Config{Log: log.StandardLogger()},
+		tunnelTarget:     "http://localhost:3000",
+		stripeAuthClient: stripeauth.NewClient("sk_test_123", &stripeauth.Config{APIBaseURL: ts.URL}),
+	}
+
+	require.NoError(t, p.keepTunnel(&stripeauth.StripeCLISession{TunnelID: "tun_123", TunnelURL: "https://tun-123.example.com"}))
+	require.Equal(t, "tun_123", p.openTunnel())
+
+	// A renewed session keeping the tunnel leaves it open
+	require.NoError(t, p.keepTunnel(&stripeauth.StripeCLISession{TunnelID: "tun_123", TunnelURL: "https://tun-123.example.com"}))
+	require.Empty(t, closed)
+
+	// A renewed session with a new tunnel closes the previous one
+	require.NoError(t, p.keepTunnel(&stripeauth.StripeCLISession{TunnelID: "tun_456", TunnelURL: "https://tun-456.example.com"}))
+	require.Equal(t, []string{"tun_123"}, closed)
+	require.Equal(t, "tun_456", p.openTunnel())
+
+	// A session without a tunnel fails, and closes the open one
+	err := p.keepTunnel(&stripeauth.StripeCLISession{})
+	require.EqualError(t, err, "Stripe didn't open a public URL for --tunnel. Tunnels may not be available to your account yet")
+	require.Equal(t, []string{"tun_123", "tun_456"}, closed)
+	require.Empty(t, p.openTunnel())
+}
@@ -0,0 +1,204 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Watchdog events, logged in the watchdog_event field
+const (
+	watchdogUnresponsive   = "websocket_unresponsive"
+	watchdogStale          = "events_stale"
+	watchdogMemoryExceeded = "memory_exceeded"
+)
+
+// defaultWatchdogInterval is how often the watchdog checks the proxy
+const defaultWatchdogInterval = 30 * time.Second
+
+// defaultUnresponsiveAfter is how long the websocket may go without receiving
+// a message or a pong before the watchdog resets it. Pongs are expected every
+// few seconds.
+const defaultUnresponsiveAfter = 2 * time.Minute
+
+// ErrWatchdogRestart is returned by Run when the watchdog stops a supervised
+// process, for its supervisor to restart it
+var ErrWatchdogRestart = errors.New("the watchdog stopped the process to be restarted by its supervisor")
+
+// WatchdogConfig configures the watchdog of a long-lived proxy, which
+// monitors the liveness of its websocket, the staleness of the events it
+// receives and its memory usage.
+type WatchdogConfig struct {
+	// Interval between the checks, 30s by default
+	Interval time.Duration
+	// UnresponsiveAfter is how long the websocket may go without receiving
+	// anything before its connection is reset, 2m by default
+	UnresponsiveAfter time.Duration
+	// StaleAfter is how long the proxy may go without receiving an event
+	// before its session is restarted, 0 to never restart it
+	StaleAfter time.Duration
+	// MaxMemory is the heap size, in bytes, above which the process is
+	// stopped when Supervised, and its memory released otherwise. 0 for no
+	// limit.
+	MaxMemory uint64
+	// Supervised indicates whether a supervisor, like systemd or Kubernetes,
+	// restarts the process when it exits
+	Supervised bool
+}
+
+// IsSupervised returns whether the process runs under a supervisor that
+// restarts it when it exits: systemd, supervisord or Kubernetes, or any
+// other supervisor setting STRIPE_CLI_SUPERVISED=true.
+func IsSupervised() bool {
+	if supervised := os.Getenv("STRIPE_CLI_SUPERVISED"); supervised != "" {
+		return strings.EqualFold(supervised, "true") || supervised == "1"
+	}
+
+	for _, env := range []string{"INVOCATION_ID", "SUPERVISOR_ENABLED", "KUBERNETES_SERVICE_HOST"} {
+		if os.Getenv(env) != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// watchdogAction is what the watchdog asks Run to do
+type watchdogAction int
+
+const (
+	restartSession watchdogAction = iota
+	stopProcess
+)
+
+// watchdog checks the proxy at regular intervals
+type watchdog struct {
+	cfg *WatchdogConfig
+	log *log.Logger
+
+	// lastActivity returns when the websocket last received something, or
+	// the zero time if it isn't connected
+	lastActivity func() time.Time
+	// resetWebSocket reconnects the websocket
+	resetWebSocket func()
+	// lastEvent returns when the last event was received, or when the
+	// session started
+	lastEvent func() time.Time
+	// memory returns the heap size of the process
+	memory func() uint64
+
+	actions chan watchdogAction
+}
+
+func newWatchdog(cfg *WatchdogConfig, logger *log.Logger) *watchdog {
+	if cfg.Interval == 0 {
+		cfg.Interval = defaultWatchdogInterval
+	}
+
+	if cfg.UnresponsiveAfter == 0 {
+		cfg.UnresponsiveAfter = defaultUnresponsiveAfter
+	}
+
+	return &watchdog{
+		cfg: cfg,
+		log: logger,
+		memory: func() uint64 {
+			var m runtime.MemStats
+			runtime.ReadMemStats(&m)
+			return m.HeapAlloc
+		},
+		actions: make(chan watchdogAction, 1),
+	}
+}
+
+// newWatchdog returns the watchdog of the proxy
+func (p *Proxy) newWatchdog() *watchdog {
+	w := newWatchdog(p.cfg.Watchdog, p.cfg.Log)
+
+	w.lastActivity = func() time.Time {
+		if p.webSocketClient == nil {
+			return time.Time{}
+		}
+		return p.webSocketClient.LastActivity()
+	}
+	w.resetWebSocket = func() {
+		if p.webSocketClient != nil {
+			p.webSocketClient.Reset()
+		}
+	}
+	w.lastEvent = func() time.Time {
+		return time.Unix(0, atomic.LoadInt64(&p.lastEvent))
+	}
+
+	return w
+}
+
+// run checks the proxy every interval until ctx is done
+func (w *watchdog) run(ctx context.Context) {
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			w.check(now)
+		}
+	}
+}
+
+// check runs the checks once, resetting the websocket, or asking Run to
+// restart the session or to stop
+func (w *watchdog) check(now time.Time) {
+	if last := w.lastActivity(); !last.IsZero() && now.Sub(last) > w.cfg.UnresponsiveAfter {
+		w.report(watchdogUnresponsive, "reset_websocket", log.Fields{"silent_for": now.Sub(last).Round(time.Second).String()})
+		w.resetWebSocket()
+	}
+
+	if w.cfg.StaleAfter > 0 {
+		if last := w.lastEvent(); !last.IsZero() && now.Sub(last) > w.cfg.StaleAfter {
+			w.report(watchdogStale, "restart_session", log.Fields{"last_event": last.Format(time.RFC3339)})
+			w.act(restartSession)
+		}
+	}
+
+	if w.cfg.MaxMemory > 0 {
+		if heap := w.memory(); heap > w.cfg.MaxMemory {
+			fields := log.Fields{"heap_bytes": heap, "max_bytes": w.cfg.MaxMemory}
+
+			if w.cfg.Supervised {
+				w.report(watchdogMemoryExceeded, "stop_process", fields)
+				w.act(stopProcess)
+			} else {
+				w.report(watchdogMemoryExceeded, "release_memory", fields)
+				debug.FreeOSMemory()
+			}
+		}
+	}
+}
+
+// act asks Run to act, unless it hasn't acted on the previous request yet
+func (w *watchdog) act(action watchdogAction) {
+	select {
+	case w.actions <- action:
+	default:
+	}
+}
+
+func (w *watchdog) report(event, action string, fields log.Fields) {
+	watchdogEvents.Inc(event)
+
+	fields["prefix"] = "proxy.watchdog"
+	fields["watchdog_event"] = event
+	fields["watchdog_action"] = action
+
+	w.log.WithFields(fields).Warn("Watchdog: " + strings.ReplaceAll(event, "_", " ") + ", " + strings.ReplaceAll(action, "_", " "))
+}
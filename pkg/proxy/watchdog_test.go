@@ -0,0 +1,107 @@
+package proxy
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWatchdog(cfg *WatchdogConfig, out *bytes.Buffer) *watchdog {
+	logger := log.New()
+	logger.SetOutput(out)
+	logger.SetFormatter(&log.JSONFormatter{})
+
+	w := newWatchdog(cfg, logger)
+	w.lastActivity = func() time.Time { return time.Time{} }
+	w.resetWebSocket = func() {}
+	w.lastEvent = func() time.Time { return time.Time{} }
+	w.memory = func() uint64 { return 0 }
+
+	return w
+}
+
+func TestWatchdogHealthy(t *testing.T) {
+	var out bytes.Buffer
+	w := newTestWatchdog(&WatchdogConfig{StaleAfter: time.Hour, MaxMemory: 1 << 30}, &out)
+
+	now := time.Now()
+	w.lastActivity = func() time.Time { return now.Add(-5 * time.Second) }
+	w.lastEvent = func() time.Time { return now.Add(-10 * time.Minute) }
+	w.memory = func() uint64 { return 1 << 20 }
+
+	w.check(now)
+
+	require.Empty(t, out.String())
+	require.Empty(t, w.actions)
+}
+
+func TestWatchdogUnresponsiveWebSocket(t *testing.T) {
+	var out bytes.Buffer
+	w := newTestWatchdog(&WatchdogConfig{}, &out)
+
+	now := time.Now()
+	resets := 0
+	w.lastActivity = func() time.Time { return now.Add(-3 * time.Minute) }
+	w.resetWebSocket = func() { resets++ }
+
+	w.check(now)
+
+	require.Equal(t, 1, resets)
+	require.Contains(t, out.String(), `"watchdog_event":"websocket_unresponsive"`)
+	require.Contains(t, out.String(), `"watchdog_action":"reset_websocket"`)
+	require.Empty(t, w.actions)
+}
+
+func TestWatchdogStaleEvents(t *testing.T) {
+	var out bytes.Buffer
+	w := newTestWatchdog(&WatchdogConfig{StaleAfter: time.Hour}, &out)
+
+	now := time.Now()
+	w.lastEvent = func() time.Time { return now.Add(-2 * time.Hour) }
+
+	w.check(now)
+	// The session isn't restarted twice before Run acts on the first restart
+	w.check(now)
+
+	require.Contains(t, out.String(), `"watchdog_event":"events_stale"`)
+	require.Len(t, w.actions, 1)
+	require.Equal(t, restartSession, <-w.actions)
+}
+
+func TestWatchdogMemoryExceeded(t *testing.T) {
+	var out bytes.Buffer
+	w := newTestWatchdog(&WatchdogConfig{MaxMemory: 1 << 20}, &out)
+	w.memory = func() uint64 { return 2 << 20 }
+
+	w.check(time.Now())
+
+	require.Contains(t, out.String(), `"watchdog_action":"release_memory"`)
+	require.Empty(t, w.actions)
+
+	out.Reset()
+	w.cfg.Supervised = true
+	w.check(time.Now())
+
+	require.Contains(t, out.String(), `"watchdog_action":"stop_process"`)
+	require.Equal(t, stopProcess, <-w.actions)
+}
+
+func TestIsSupervised(t *testing.T) {
+	for _, env := range []string{"STRIPE_CLI_SUPERVISED", "INVOCATION_ID", "SUPERVISOR_ENABLED", "KUBERNETES_SERVICE_HOST"} {
+		t.Setenv(env, "")
+	}
+	require.False(t, IsSupervised())
+
+	t.Setenv("INVOCATION_ID", "c0ffee")
+	require.True(t, IsSupervised())
+
+	t.Setenv("STRIPE_CLI_SUPERVISED", "false")
+	require.False(t, IsSupervised())
+
+	t.Setenv("STRIPE_CLI_SUPERVISED", "true")
+	t.Setenv("INVOCATION_ID", "")
+	require.True(t, IsSupervised())
+}
@@ -0,0 +1,475 @@
+// Package qrcode encodes text as QR codes and renders them in terminals, for
+// the URLs that are easier to open on a phone, like those of Checkout.
+//
+// Codes are encoded in byte mode with the low error correction level, which
+// is enough for codes displayed on screens and keeps them small.
+package qrcode
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrTooLong is returned when the text doesn't fit in the largest supported
+// version of QR codes
+var ErrTooLong = errors.New("the text is too long for a QR code")
+
+// quietZone is the light border around the code, in modules. The standard
+// asks for 4, 2 keeps codes within 80 columns and is enough for phones.
+const quietZone = 2
+
+// blockSpec describes the error correction blocks of a version: the number
+// of error correction codewords of each block, and the number of blocks and
+// of their data codewords in each of the two groups
+type blockSpec struct {
+	ecLen                   int
+	group1Blocks, group1Len int
+	group2Blocks, group2Len int
+}
+
+// lowBlockSpecs are the blocks of the low error correction level, by version
+var lowBlockSpecs = []blockSpec{
+	1:  {7, 1, 19, 0, 0},
+	2:  {10, 1, 34, 0, 0},
+	3:  {15, 1, 55, 0, 0},
+	4:  {20, 1, 80, 0, 0},
+	5:  {26, 1, 108, 0, 0},
+	6:  {18, 2, 68, 0, 0},
+	7:  {20, 2, 78, 0, 0},
+	8:  {24, 2, 97, 0, 0},
+	9:  {30, 2, 116, 0, 0},
+	10: {18, 2, 68, 2, 69},
+	11: {20, 4, 81, 0, 0},
+	12: {24, 2, 92, 2, 93},
+	13: {26, 4, 107, 0, 0},
+	14: {30, 3, 115, 1, 116},
+	15: {22, 5, 87, 1, 88},
+	16: {24, 5, 98, 1, 99},
+	17: {28, 1, 107, 5, 108},
+	18: {30, 5, 120, 1, 121},
+	19: {28, 3, 113, 4, 114},
+	20: {28, 3, 107, 5, 108},
+	21: {28, 4, 116, 4, 117},
+	22: {28, 2, 111, 7, 112},
+	23: {30, 4, 121, 5, 122},
+	24: {30, 6, 117, 4, 118},
+	25: {26, 8, 106, 4, 107},
+}
+
+// maxVersion is the largest supported version
+var maxVersion = len(lowBlockSpecs) - 1
+
+func (s blockSpec) dataLen() int {
+	return s.group1Blocks*s.group1Len + s.group2Blocks*s.group2Len
+}
+
+// Code is an encoded QR code
+type Code struct {
+	Version int
+	// Size is the number of modules on each side
+	Size int
+
+	modules    [][]bool
+	isFunction [][]bool
+}
+
+// Dark returns whether the module at x, y is dark
+func (c *Code) Dark(x, y int) bool {
+	if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+		return false
+	}
+
+	return c.modules[y][x]
+}
+
+// Encode encodes text as a QR code of the smallest version it fits in
+func Encode(text string) (*Code, error) {
+	data := []byte(text)
+
+	version := 0
+	for v := 1; v <= maxVersion; v++ {
+		if 4+countBits(v)+8*len(data) <= 8*lowBlockSpecs[v].dataLen() {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, ErrTooLong
+	}
+
+	spec := lowBlockSpecs[version]
+
+	// Byte mode, the length of the data, then the data
+	var bits bitBuffer
+	bits.append(0x4, 4)
+	bits.append(len(data), countBits(version))
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+
+	capacity := 8 * spec.dataLen()
+	terminator := capacity - len(bits)
+	if terminator > 4 {
+		terminator = 4
+	}
+	bits.append(0, terminator)
+	bits.append(0, (8-len(bits)%8)%8)
+	for pad := 0xEC; len(bits) < capacity; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+
+	c := &Code{Version: version, Size: 4*version + 17}
+	c.modules = newGrid(c.Size)
+	c.isFunction = newGrid(c.Size)
+
+	c.drawFunctionPatterns()
+	c.drawCodewords(interleave(bits.bytes(), spec))
+
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormatBits(mask)
+		if penalty := c.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			best, bestPenalty = mask, penalty
+		}
+		c.applyMask(mask) // masks are their own inverse
+	}
+	c.applyMask(best)
+	c.drawFormatBits(best)
+
+	return c, nil
+}
+
+// Render writes the code to a terminal, two rows of modules per line using
+// half blocks. The colors are set explicitly, so that the code scans on dark
+// and light terminals alike.
+func (c *Code) Render(w io.Writer) error {
+	var sb strings.Builder
+
+	for y := -quietZone; y < c.Size+quietZone; y += 2 {
+		for x := -quietZone; x < c.Size+quietZone; x++ {
+			fg, bg := 97, 107
+			if c.Dark(x, y) {
+				fg = 30
+			}
+			switch {
+			case y+1 >= c.Size+quietZone:
+				// Past the bottom of the code
+				bg = 49
+			case c.Dark(x, y+1):
+				bg = 40
+			}
+			fmt.Fprintf(&sb, "\x1b[%d;%dm▀", fg, bg)
+		}
+		sb.WriteString("\x1b[0m\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
+
+// countBits is the length of the character count indicator of byte mode
+func countBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+	return 16
+}
+
+func newGrid(size int) [][]bool {
+	grid := make([][]bool, size)
+	for i := range grid {
+		grid[i] = make([]bool, size)
+	}
+	return grid
+}
+
+func (c *Code) setFunction(x, y int, dark bool) {
+	c.modules[y][x] = dark
+	c.isFunction[y][x] = true
+}
+
+func (c *Code) drawFunctionPatterns() {
+	for i := 0; i < c.Size; i++ {
+		c.setFunction(6, i, i%2 == 0)
+		c.setFunction(i, 6, i%2 == 0)
+	}
+
+	c.drawFinder(3, 3)
+	c.drawFinder(c.Size-4, 3)
+	c.drawFinder(3, c.Size-4)
+
+	positions := alignmentPositions(c.Version)
+	last := len(positions) - 1
+	for i, x := range positions {
+		for j, y := range positions {
+			// Skip the corners of the finders
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			c.drawAlignment(x, y)
+		}
+	}
+
+	// Reserve the format bits, drawn once the mask is chosen
+	c.drawFormatBits(0)
+	c.drawVersionBits()
+}
+
+// drawFinder draws a finder pattern and its separator centered on x, y
+func (c *Code) drawFinder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || yy < 0 || xx >= c.Size || yy >= c.Size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			c.setFunction(xx, yy, dist != 2 && dist != 4)
+		}
+	}
+}
+
+// drawAlignment draws an alignment pattern centered on x, y
+func (c *Code) drawAlignment(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			c.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// formatBits returns the format information of the low error correction
+// level with mask, error corrected with its BCH code
+func formatBits(mask int) int {
+	// The low level is 01
+	data := 1<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+// versionBits returns the version information, error corrected with its
+// BCH code
+func versionBits(version int) int {
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	return version<<12 | rem
+}
+
+func (c *Code) drawFormatBits(mask int) {
+	bits := formatBits(mask)
+	bit := func(i int) bool { return (bits>>i)&1 != 0 }
+
+	// Around the top left finder
+	for i := 0; i <= 5; i++ {
+		c.setFunction(8, i, bit(i))
+	}
+	c.setFunction(8, 7, bit(6))
+	c.setFunction(8, 8, bit(7))
+	c.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		c.setFunction(14-i, 8, bit(i))
+	}
+
+	// Next to the top right and bottom left finders
+	for i := 0; i < 8; i++ {
+		c.setFunction(c.Size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunction(8, c.Size-15+i, bit(i))
+	}
+	c.setFunction(8, c.Size-8, true)
+}
+
+func (c *Code) drawVersionBits() {
+	if c.Version < 7 {
+		return
+	}
+
+	bits := versionBits(c.Version)
+	for i := 0; i < 18; i++ {
+		dark := (bits>>i)&1 != 0
+		a, b := c.Size-11+i%3, i/3
+		c.setFunction(a, b, dark)
+		c.setFunction(b, a, dark)
+	}
+}
+
+// alignmentPositions returns the coordinates of the centers of the
+// alignment patterns of a version, on both axes
+func alignmentPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+
+	count := version/7 + 2
+	step := (version*4 + count*2 + 1) / (count*2 - 2) * 2
+	size := 4*version + 17
+
+	positions := make([]int, count)
+	positions[0] = 6
+	for i, pos := count-1, size-7; i >= 1; i, pos = i-1, pos-step {
+		positions[i] = pos
+	}
+
+	return positions
+}
+
+// drawCodewords places the codewords in the modules that aren't function
+// modules, in the zigzag order of the standard
+func (c *Code) drawCodewords(codewords []byte) {
+	i := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < c.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = c.Size - 1 - vert
+				}
+				if !c.isFunction[y][x] && i < len(codewords)*8 {
+					c.modules[y][x] = (codewords[i>>3]>>(7-i&7))&1 != 0
+					i++
+				}
+			}
+		}
+	}
+}
+
+func (c *Code) applyMask(mask int) {
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.isFunction[y][x] {
+				continue
+			}
+
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+
+			if invert {
+				c.modules[y][x] = !c.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty scores how hard the code is to scan with the rules of the
+// standard, to choose the mask of the lowest score
+func (c *Code) penalty() int {
+	penalty := 0
+	dark := 0
+
+	finderLike := [][]bool{
+		{true, false, true, true, true, false, true, false, false, false, false},
+		{false, false, false, false, true, false, true, true, true, false, true},
+	}
+
+	for i := 0; i < c.Size; i++ {
+		rowRun, colRun := 1, 1
+		for j := 0; j < c.Size; j++ {
+			if c.modules[i][j] {
+				dark++
+			}
+
+			// Runs of 5 or more modules of the same color
+			if j > 0 {
+				if c.modules[i][j] == c.modules[i][j-1] {
+					rowRun++
+					if rowRun == 5 {
+						penalty += 3
+					} else if rowRun > 5 {
+						penalty++
+					}
+				} else {
+					rowRun = 1
+				}
+
+				if c.modules[j][i] == c.modules[j-1][i] {
+					colRun++
+					if colRun == 5 {
+						penalty += 3
+					} else if colRun > 5 {
+						penalty++
+					}
+				} else {
+					colRun = 1
+				}
+			}
+
+			// 2x2 blocks of the same color
+			if i > 0 && j > 0 {
+				m := c.modules[i][j]
+				if m == c.modules[i-1][j] && m == c.modules[i][j-1] && m == c.modules[i-1][j-1] {
+					penalty += 3
+				}
+			}
+
+			// Patterns looking like finders
+			for _, pattern := range finderLike {
+				if j+len(pattern) > c.Size {
+					continue
+				}
+				rowMatch, colMatch := true, true
+				for k, want := range pattern {
+					rowMatch = rowMatch && c.modules[i][j+k] == want
+					colMatch = colMatch && c.modules[j+k][i] == want
+				}
+				if rowMatch {
+					penalty += 40
+				}
+				if colMatch {
+					penalty += 40
+				}
+			}
+		}
+	}
+
+	// The balance of dark and light modules
+	total := c.Size * c.Size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	penalty += k * 10
+
+	return penalty
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
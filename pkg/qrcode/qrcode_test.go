@@ -0,0 +1,107 @@
+package qrcode
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReedSolomon(t *testing.T) {
+	// The example of the standard: HELLO WORLD in version 1-M
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+
+	ec := reedSolomonRemainder(data, reedSolomonDivisor(10))
+	require.Equal(t, []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}, ec)
+}
+
+func TestFormatBits(t *testing.T) {
+	require.Equal(t, 0x77C4, formatBits(0)) // 111011111000100
+	require.Equal(t, 0x6976, formatBits(7)) // 110100101110110
+}
+
+func TestVersionBits(t *testing.T) {
+	require.Equal(t, 0x07C94, versionBits(7))
+}
+
+func TestAlignmentPositions(t *testing.T) {
+	require.Empty(t, alignmentPositions(1))
+	require.Equal(t, []int{6, 18}, alignmentPositions(2))
+	require.Equal(t, []int{6, 22, 38}, alignmentPositions(7))
+	require.Equal(t, []int{6, 26, 46, 66}, alignmentPositions(14))
+	require.Equal(t, []int{6, 32, 58, 84, 110}, alignmentPositions(25))
+}
+
+func TestBlockSpecs(t *testing.T) {
+	for version := 1; version <= maxVersion; version++ {
+		spec := lowBlockSpecs[version]
+
+		// The codewords fill the modules that aren't function modules, but
+		// for the remainder bits
+		modules := (16*version+128)*version + 64
+		if version >= 2 {
+			count := version/7 + 2
+			modules -= (25*count-10)*count - 55
+			if version >= 7 {
+				modules -= 36
+			}
+		}
+
+		blocks := spec.group1Blocks + spec.group2Blocks
+		require.Equal(t, modules/8, spec.dataLen()+blocks*spec.ecLen, "version %d", version)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	code, err := Encode("https://checkout.stripe.com/c/pay/cs_test_a1b2c3")
+	require.NoError(t, err)
+	require.Equal(t, 3, code.Version)
+	require.Equal(t, 29, code.Size)
+
+	// Finder patterns in three corners
+	for _, corner := range [][2]int{{0, 0}, {code.Size - 7, 0}, {0, code.Size - 7}} {
+		for i := 0; i < 7; i++ {
+			require.True(t, code.Dark(corner[0]+i, corner[1]))
+			require.True(t, code.Dark(corner[0], corner[1]+i))
+		}
+		require.False(t, code.Dark(corner[0]+1, corner[1]+1))
+		require.True(t, code.Dark(corner[0]+3, corner[1]+3))
+	}
+
+	// The two copies of the format bits match
+	for i := 0; i < 8; i++ {
+		require.Equal(t, code.Dark(code.Size-1-i, 8), formatBit(code, i))
+	}
+
+	long, err := Encode(strings.Repeat("a", 400))
+	require.NoError(t, err)
+	require.Equal(t, 13, long.Version)
+
+	_, err = Encode(strings.Repeat("a", 2000))
+	require.Equal(t, ErrTooLong, err)
+}
+
+// formatBit reads bit i of the format bits next to the top left finder
+func formatBit(code *Code, i int) bool {
+	switch {
+	case i <= 5:
+		return code.Dark(8, i)
+	case i == 6:
+		return code.Dark(8, 7)
+	default:
+		return code.Dark(8, 8)
+	}
+}
+
+func TestRender(t *testing.T) {
+	code, err := Encode("stripe")
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, code.Render(&out))
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, (code.Size+2*quietZone+1)/2)
+	require.Equal(t, code.Size+2*quietZone, strings.Count(lines[0], "▀"))
+}
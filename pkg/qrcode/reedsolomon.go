@@ -0,0 +1,112 @@
+package qrcode
+
+// bitBuffer is a sequence of bits, most significant first
+type bitBuffer []bool
+
+// append appends the n low bits of value
+func (b *bitBuffer) append(value, n int) {
+	for i := n - 1; i >= 0; i-- {
+		*b = append(*b, (value>>i)&1 != 0)
+	}
+}
+
+// bytes packs the bits into bytes. Its length must be a multiple of 8.
+func (b bitBuffer) bytes() []byte {
+	result := make([]byte, len(b)/8)
+	for i, bit := range b {
+		if bit {
+			result[i/8] |= 1 << (7 - i%8)
+		}
+	}
+	return result
+}
+
+// interleave splits the data codewords into the blocks of spec, computes
+// their error correction codewords, and interleaves them as the standard
+// places them
+func interleave(data []byte, spec blockSpec) []byte {
+	var blocks [][]byte
+	for i := 0; i < spec.group1Blocks; i++ {
+		blocks = append(blocks, data[:spec.group1Len])
+		data = data[spec.group1Len:]
+	}
+	for i := 0; i < spec.group2Blocks; i++ {
+		blocks = append(blocks, data[:spec.group2Len])
+		data = data[spec.group2Len:]
+	}
+
+	divisor := reedSolomonDivisor(spec.ecLen)
+
+	var ecBlocks [][]byte
+	for _, block := range blocks {
+		ecBlocks = append(ecBlocks, reedSolomonRemainder(block, divisor))
+	}
+
+	var result []byte
+
+	maxLen := spec.group1Len
+	if spec.group2Blocks > 0 {
+		maxLen = spec.group2Len
+	}
+	for i := 0; i < maxLen; i++ {
+		for _, block := range blocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+
+	for i := 0; i < spec.ecLen; i++ {
+		for _, ec := range ecBlocks {
+			result = append(result, ec[i])
+		}
+	}
+
+	return result
+}
+
+// reedSolomonDivisor returns the coefficients of the generator polynomial
+// of degree, highest first, without the leading 1
+func reedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+
+	return result
+}
+
+// reedSolomonRemainder returns the error correction codewords of data
+func reedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, coef := range divisor {
+			result[i] ^= gfMultiply(coef, factor)
+		}
+	}
+
+	return result
+}
+
+// gfMultiply multiplies in GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1
+func gfMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>i)&1) * int(x)
+	}
+	return byte(z)
+}
@@ -0,0 +1,336 @@
+package query
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// eval evaluates a node on a value. Like JMESPath, fields of values that
+// aren't objects, and indexes of values that aren't arrays, are null rather
+// than errors.
+func eval(n node, value interface{}) (interface{}, error) {
+	switch n.typ {
+	case nCurrent:
+		return value, nil
+	case nField:
+		if obj, ok := value.(*object); ok {
+			return obj.values[n.value.(string)], nil
+		}
+		return nil, nil
+	case nLiteral:
+		return n.value, nil
+	case nSubexpression, nIndexExpression, nPipe:
+		left, err := eval(n.children[0], value)
+		if err != nil {
+			return nil, err
+		}
+		return eval(n.children[1], left)
+	case nIndex:
+		array, ok := value.([]interface{})
+		if !ok {
+			return nil, nil
+		}
+		index := n.value.(int)
+		if index < 0 {
+			index += len(array)
+		}
+		if index < 0 || index >= len(array) {
+			return nil, nil
+		}
+		return array[index], nil
+	case nSlice:
+		array, ok := value.([]interface{})
+		if !ok {
+			return nil, nil
+		}
+		return slice(array, n.value.([3]*int)), nil
+	case nFlatten:
+		left, err := eval(n.children[0], value)
+		if err != nil {
+			return nil, err
+		}
+		array, ok := left.([]interface{})
+		if !ok {
+			return nil, nil
+		}
+		flattened := []interface{}{}
+		for _, element := range array {
+			if inner, ok := element.([]interface{}); ok {
+				flattened = append(flattened, inner...)
+			} else {
+				flattened = append(flattened, element)
+			}
+		}
+		return flattened, nil
+	case nProjection:
+		left, err := eval(n.children[0], value)
+		if err != nil {
+			return nil, err
+		}
+		array, ok := left.([]interface{})
+		if !ok {
+			return nil, nil
+		}
+		return project(array, n.children[1])
+	case nValueProjection:
+		left, err := eval(n.children[0], value)
+		if err != nil {
+			return nil, err
+		}
+		obj, ok := left.(*object)
+		if !ok {
+			return nil, nil
+		}
+		values := make([]interface{}, len(obj.keys))
+		for i, key := range obj.keys {
+			values[i] = obj.values[key]
+		}
+		return project(values, n.children[1])
+	case nFilterProjection:
+		left, err := eval(n.children[0], value)
+		if err != nil {
+			return nil, err
+		}
+		array, ok := left.([]interface{})
+		if !ok {
+			return nil, nil
+		}
+		var matches []interface{}
+		for _, element := range array {
+			condition, err := eval(n.children[2], element)
+			if err != nil {
+				return nil, err
+			}
+			if truthy(condition) {
+				matches = append(matches, element)
+			}
+		}
+		return project(matches, n.children[1])
+	case nComparator:
+		left, err := eval(n.children[0], value)
+		if err != nil {
+			return nil, err
+		}
+		right, err := eval(n.children[1], value)
+		if err != nil {
+			return nil, err
+		}
+		return compare(n.value.(tokenType), left, right), nil
+	case nOr, nAnd:
+		left, err := eval(n.children[0], value)
+		if err != nil {
+			return nil, err
+		}
+		if truthy(left) == (n.typ == nOr) {
+			return left, nil
+		}
+		return eval(n.children[1], value)
+	case nNot:
+		result, err := eval(n.children[0], value)
+		if err != nil {
+			return nil, err
+		}
+		return !truthy(result), nil
+	case nMultiSelectList:
+		if value == nil {
+			return nil, nil
+		}
+		list := make([]interface{}, len(n.children))
+		for i, child := range n.children {
+			result, err := eval(child, value)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = result
+		}
+		return list, nil
+	case nMultiSelectHash:
+		if value == nil {
+			return nil, nil
+		}
+		obj := newObject()
+		for i, key := range n.value.([]string) {
+			result, err := eval(n.children[i], value)
+			if err != nil {
+				return nil, err
+			}
+			obj.set(key, result)
+		}
+		return obj, nil
+	case nFunction:
+		args := make([]interface{}, len(n.children))
+		for i, child := range n.children {
+			if child.typ == nExpressionRef {
+				args[i] = child.children[0]
+				continue
+			}
+			arg, err := eval(child, value)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = arg
+		}
+		return call(n.value.(string), args)
+	}
+
+	return nil, nil
+}
+
+// project evaluates a node on each element of a projection, leaving out the
+// null results
+func project(elements []interface{}, n node) (interface{}, error) {
+	results := []interface{}{}
+	for _, element := range elements {
+		result, err := eval(n, element)
+		if err != nil {
+			return nil, err
+		}
+		if result != nil {
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
+
+func slice(array []interface{}, parts [3]*int) []interface{} {
+	length := len(array)
+
+	step := 1
+	if parts[2] != nil {
+		step = *parts[2]
+	}
+
+	bound := func(part *int, defaultValue int) int {
+		if part == nil {
+			return defaultValue
+		}
+		i := *part
+		if i < 0 {
+			i += length
+		}
+		if i < 0 {
+			if step < 0 {
+				return -1
+			}
+			return 0
+		}
+		if i >= length {
+			if step < 0 {
+				return length - 1
+			}
+			return length
+		}
+		return i
+	}
+
+	result := []interface{}{}
+	if step > 0 {
+		for i := bound(parts[0], 0); i < bound(parts[1], length); i += step {
+			result = append(result, array[i])
+		}
+	} else {
+		for i := bound(parts[0], length-1); i > bound(parts[1], -1); i += step {
+			result = append(result, array[i])
+		}
+	}
+
+	return result
+}
+
+// truthy returns whether a value is true in a filter or with ||, && and !:
+// false, null, and empty strings, arrays and objects are false
+func truthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case []interface{}:
+		return len(v) > 0
+	case *object:
+		return len(v.keys) > 0
+	default:
+		return true
+	}
+}
+
+// number returns the value of a number
+func number(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		f, err := strconv.ParseFloat(string(v), 64)
+		return f, err == nil
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
+
+// compare compares two values. Only numbers can be ordered, other ordering
+// comparisons are null.
+func compare(op tokenType, left, right interface{}) interface{} {
+	switch op {
+	case tEQ:
+		return equal(left, right)
+	case tNE:
+		return !equal(left, right)
+	}
+
+	l, lok := number(left)
+	r, rok := number(right)
+	if !lok || !rok {
+		return nil
+	}
+
+	switch op {
+	case tLT:
+		return l < r
+	case tLTE:
+		return l <= r
+	case tGT:
+		return l > r
+	default:
+		return l >= r
+	}
+}
+
+func equal(left, right interface{}) bool {
+	if l, ok := number(left); ok {
+		r, ok := number(right)
+		return ok && l == r
+	}
+
+	switch l := left.(type) {
+	case []interface{}:
+		r, ok := right.([]interface{})
+		if !ok || len(l) != len(r) {
+			return false
+		}
+		for i := range l {
+			if !equal(l[i], r[i]) {
+				return false
+			}
+		}
+		return true
+	case *object:
+		r, ok := right.(*object)
+		if !ok || len(l.keys) != len(r.keys) {
+			return false
+		}
+		for _, key := range l.keys {
+			value, ok := r.values[key]
+			if !ok || !equal(l.values[key], value) {
+				return false
+			}
+		}
+		return true
+	default:
+		return left == right
+	}
+}
@@ -0,0 +1,282 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type function struct {
+	arity int
+	call  func(args []interface{}) (interface{}, error)
+}
+
+var functions map[string]function
+
+// The functions are set in init as sort_by evaluates expressions, which calls
+// functions
+func init() {
+	functions = map[string]function{
+		"length":      {1, length},
+		"keys":        {1, keys},
+		"values":      {1, values},
+		"contains":    {2, contains},
+		"starts_with": {2, startsWith},
+		"ends_with":   {2, endsWith},
+		"join":        {2, join},
+		"to_string":   {1, toString},
+		"sort":        {1, sortValues},
+		"sort_by":     {2, sortBy},
+	}
+}
+
+// checkFunctions returns an error if the query calls a function that doesn't
+// exist, or with the wrong number of arguments
+func checkFunctions(expression string, n node) error {
+	if n.typ == nFunction {
+		name := n.value.(string)
+
+		fn, ok := functions[name]
+		if !ok {
+			return SyntaxError{Expression: expression, Offset: strings.Index(expression, name+"("), msg: fmt.Sprintf("unknown function %s()", name)}
+		}
+		if len(n.children) != fn.arity {
+			return SyntaxError{Expression: expression, Offset: strings.Index(expression, name+"("), msg: fmt.Sprintf("%s() takes %d arguments, got %d", name, fn.arity, len(n.children))}
+		}
+	}
+
+	for _, child := range n.children {
+		if err := checkFunctions(expression, child); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func call(name string, args []interface{}) (interface{}, error) {
+	return functions[name].call(args)
+}
+
+// typeName returns the JMESPath type of a value, for errors
+func typeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case *object:
+		return "object"
+	case node:
+		return "expression"
+	default:
+		return "number"
+	}
+}
+
+func invalidArgument(name string, value interface{}) error {
+	return fmt.Errorf("invalid argument of type %s for %s()", typeName(value), name)
+}
+
+func length(args []interface{}) (interface{}, error) {
+	switch v := args[0].(type) {
+	case string:
+		return len([]rune(v)), nil
+	case []interface{}:
+		return len(v), nil
+	case *object:
+		return len(v.keys), nil
+	}
+
+	return nil, invalidArgument("length", args[0])
+}
+
+func keys(args []interface{}) (interface{}, error) {
+	obj, ok := args[0].(*object)
+	if !ok {
+		return nil, invalidArgument("keys", args[0])
+	}
+
+	result := make([]interface{}, len(obj.keys))
+	for i, key := range obj.keys {
+		result[i] = key
+	}
+
+	return result, nil
+}
+
+func values(args []interface{}) (interface{}, error) {
+	obj, ok := args[0].(*object)
+	if !ok {
+		return nil, invalidArgument("values", args[0])
+	}
+
+	result := make([]interface{}, len(obj.keys))
+	for i, key := range obj.keys {
+		result[i] = obj.values[key]
+	}
+
+	return result, nil
+}
+
+func contains(args []interface{}) (interface{}, error) {
+	switch v := args[0].(type) {
+	case string:
+		s, ok := args[1].(string)
+		return ok && strings.Contains(v, s), nil
+	case []interface{}:
+		for _, element := range v {
+			if equal(element, args[1]) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	return nil, invalidArgument("contains", args[0])
+}
+
+func startsWith(args []interface{}) (interface{}, error) {
+	s, ok := args[0].(string)
+	prefix, pok := args[1].(string)
+	if !ok || !pok {
+		return nil, invalidArgument("starts_with", args[0])
+	}
+
+	return strings.HasPrefix(s, prefix), nil
+}
+
+func endsWith(args []interface{}) (interface{}, error) {
+	s, ok := args[0].(string)
+	suffix, sok := args[1].(string)
+	if !ok || !sok {
+		return nil, invalidArgument("ends_with", args[0])
+	}
+
+	return strings.HasSuffix(s, suffix), nil
+}
+
+func join(args []interface{}) (interface{}, error) {
+	separator, ok := args[0].(string)
+	if !ok {
+		return nil, invalidArgument("join", args[0])
+	}
+
+	array, ok := args[1].([]interface{})
+	if !ok {
+		return nil, invalidArgument("join", args[1])
+	}
+
+	parts := make([]string, len(array))
+	for i, element := range array {
+		s, ok := element.(string)
+		if !ok {
+			return nil, invalidArgument("join", element)
+		}
+		parts[i] = s
+	}
+
+	return strings.Join(parts, separator), nil
+}
+
+func toString(args []interface{}) (interface{}, error) {
+	if s, ok := args[0].(string); ok {
+		return s, nil
+	}
+
+	data, err := marshal(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+// less returns whether a sorts before b, for arrays of numbers or of strings
+func less(name string, a, b interface{}) (bool, error) {
+	if x, ok := number(a); ok {
+		if y, ok := number(b); ok {
+			return x < y, nil
+		}
+	}
+
+	if x, ok := a.(string); ok {
+		if y, ok := b.(string); ok {
+			return x < y, nil
+		}
+	}
+
+	if _, ok := number(a); !ok {
+		if _, ok := a.(string); !ok {
+			return false, invalidArgument(name, a)
+		}
+	}
+
+	return false, invalidArgument(name, b)
+}
+
+func sortValues(args []interface{}) (interface{}, error) {
+	array, ok := args[0].([]interface{})
+	if !ok {
+		return nil, invalidArgument("sort", args[0])
+	}
+
+	sorted := append([]interface{}{}, array...)
+
+	var err error
+	sort.SliceStable(sorted, func(i, j int) bool {
+		l, e := less("sort", sorted[i], sorted[j])
+		if e != nil {
+			err = e
+		}
+		return l
+	})
+
+	return sorted, err
+}
+
+func sortBy(args []interface{}) (interface{}, error) {
+	array, ok := args[0].([]interface{})
+	if !ok {
+		return nil, invalidArgument("sort_by", args[0])
+	}
+
+	expr, ok := args[1].(node)
+	if !ok {
+		return nil, fmt.Errorf("sort_by() expects an expression like &created as its second argument")
+	}
+
+	sortKeys := make([]interface{}, len(array))
+	for i, element := range array {
+		key, err := eval(expr, element)
+		if err != nil {
+			return nil, err
+		}
+		sortKeys[i] = key
+	}
+
+	indexes := make([]int, len(array))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	var err error
+	sort.SliceStable(indexes, func(i, j int) bool {
+		l, e := less("sort_by", sortKeys[indexes[i]], sortKeys[indexes[j]])
+		if e != nil {
+			err = e
+		}
+		return l
+	})
+
+	sorted := make([]interface{}, len(array))
+	for i, index := range indexes {
+		sorted[i] = array[index]
+	}
+
+	return sorted, err
+}
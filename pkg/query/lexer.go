@@ -0,0 +1,211 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type tokenType int
+
+const (
+	tEOF tokenType = iota
+	tIdentifier
+	tQuotedIdentifier
+	tRawString
+	tLiteral
+	tNumber
+	tDot
+	tStar
+	tComma
+	tColon
+	tLbracket
+	tRbracket
+	tFlatten
+	tFilter
+	tLbrace
+	tRbrace
+	tLparen
+	tRparen
+	tPipe
+	tOr
+	tAnd
+	tNot
+	tCurrent
+	tEQ
+	tNE
+	tLT
+	tLTE
+	tGT
+	tGTE
+	tExpref
+)
+
+type token struct {
+	typ   tokenType
+	value string
+	pos   int
+}
+
+// SyntaxError is an error in a query expression
+type SyntaxError struct {
+	Expression string
+	Offset     int
+	msg        string
+}
+
+func (e SyntaxError) Error() string {
+	return fmt.Sprintf("invalid query at position %d: %s\n  %s\n  %s^", e.Offset, e.msg, e.Expression, strings.Repeat(" ", e.Offset))
+}
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentifierChar(c byte) bool {
+	return isIdentifierStart(c) || (c >= '0' && c <= '9')
+}
+
+// tokenize splits an expression into its tokens, ending with tEOF
+func tokenize(expression string) ([]token, error) {
+	var tokens []token
+
+	syntaxError := func(pos int, msg string) error {
+		return SyntaxError{Expression: expression, Offset: pos, msg: msg}
+	}
+
+	for i := 0; i < len(expression); {
+		c := expression[i]
+		start := i
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+			continue
+		case isIdentifierStart(c):
+			for i < len(expression) && isIdentifierChar(expression[i]) {
+				i++
+			}
+			tokens = append(tokens, token{tIdentifier, expression[start:i], start})
+			continue
+		case c == '-' || (c >= '0' && c <= '9'):
+			i++
+			for i < len(expression) && expression[i] >= '0' && expression[i] <= '9' {
+				i++
+			}
+			if _, err := strconv.Atoi(expression[start:i]); err != nil {
+				return nil, syntaxError(start, "invalid number")
+			}
+			tokens = append(tokens, token{tNumber, expression[start:i], start})
+			continue
+		case c == '"' || c == '\'' || c == '`':
+			end := i + 1
+			for end < len(expression) && expression[end] != c {
+				if expression[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(expression) {
+				return nil, syntaxError(start, "unterminated "+string(c))
+			}
+			raw := expression[start+1 : end]
+			i = end + 1
+
+			switch c {
+			case '"':
+				var value string
+				if err := json.Unmarshal([]byte(expression[start:i]), &value); err != nil {
+					return nil, syntaxError(start, "invalid quoted identifier")
+				}
+				tokens = append(tokens, token{tQuotedIdentifier, value, start})
+			case '\'':
+				tokens = append(tokens, token{tRawString, strings.ReplaceAll(raw, `\'`, `'`), start})
+			default:
+				literal := strings.ReplaceAll(raw, "\\`", "`")
+				if !json.Valid([]byte(literal)) {
+					return nil, syntaxError(start, "invalid JSON literal")
+				}
+				tokens = append(tokens, token{tLiteral, literal, start})
+			}
+			continue
+		}
+
+		next := byte(0)
+		if i+1 < len(expression) {
+			next = expression[i+1]
+		}
+
+		typ := tEOF
+		width := 1
+		switch c {
+		case '.':
+			typ = tDot
+		case '*':
+			typ = tStar
+		case ',':
+			typ = tComma
+		case ':':
+			typ = tColon
+		case ']':
+			typ = tRbracket
+		case '{':
+			typ = tLbrace
+		case '}':
+			typ = tRbrace
+		case '(':
+			typ = tLparen
+		case ')':
+			typ = tRparen
+		case '@':
+			typ = tCurrent
+		case '[':
+			switch next {
+			case ']':
+				typ, width = tFlatten, 2
+			case '?':
+				typ, width = tFilter, 2
+			default:
+				typ = tLbracket
+			}
+		case '|':
+			typ = tPipe
+			if next == '|' {
+				typ, width = tOr, 2
+			}
+		case '&':
+			typ = tExpref
+			if next == '&' {
+				typ, width = tAnd, 2
+			}
+		case '!':
+			typ = tNot
+			if next == '=' {
+				typ, width = tNE, 2
+			}
+		case '=':
+			if next != '=' {
+				return nil, syntaxError(start, "expected ==")
+			}
+			typ, width = tEQ, 2
+		case '<':
+			typ = tLT
+			if next == '=' {
+				typ, width = tLTE, 2
+			}
+		case '>':
+			typ = tGT
+			if next == '=' {
+				typ, width = tGTE, 2
+			}
+		default:
+			return nil, syntaxError(start, fmt.Sprintf("unexpected character %q", c))
+		}
+
+		tokens = append(tokens, token{typ, expression[start : start+width], start})
+		i += width
+	}
+
+	return append(tokens, token{tEOF, "", len(expression)}), nil
+}
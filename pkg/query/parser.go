@@ -0,0 +1,486 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+)
+
+type nodeType int
+
+const (
+	nCurrent nodeType = iota
+	nField
+	nSubexpression
+	nIndexExpression
+	nIndex
+	nSlice
+	nProjection
+	nValueProjection
+	nFilterProjection
+	nFlatten
+	nComparator
+	nOr
+	nAnd
+	nNot
+	nLiteral
+	nMultiSelectList
+	nMultiSelectHash
+	nPipe
+	nFunction
+	nExpressionRef
+)
+
+type node struct {
+	typ      nodeType
+	value    interface{}
+	children []node
+}
+
+// bindingPowers are the binding powers of the tokens, the tokens with higher
+// powers binding more tightly to the expressions on their left
+var bindingPowers = map[tokenType]int{
+	tPipe:     1,
+	tOr:       2,
+	tAnd:      3,
+	tEQ:       5,
+	tNE:       5,
+	tLT:       5,
+	tLTE:      5,
+	tGT:       5,
+	tGTE:      5,
+	tFlatten:  9,
+	tStar:     20,
+	tFilter:   21,
+	tDot:      40,
+	tNot:      45,
+	tLbrace:   50,
+	tLbracket: 55,
+	tLparen:   60,
+}
+
+// projectionStop is the binding power under which a token ends the right
+// side of a projection
+const projectionStop = 10
+
+type parser struct {
+	expression string
+	tokens     []token
+	index      int
+}
+
+func parse(expression string) (node, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return node{}, err
+	}
+
+	p := &parser{expression: expression, tokens: tokens}
+
+	n, err := p.parseExpression(0)
+	if err != nil {
+		return node{}, err
+	}
+
+	if p.current().typ != tEOF {
+		return node{}, p.unexpected(p.current())
+	}
+
+	return n, nil
+}
+
+func (p *parser) current() token {
+	return p.tokens[p.index]
+}
+
+func (p *parser) lookahead(n int) token {
+	if p.index+n >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.index+n]
+}
+
+func (p *parser) advance() token {
+	tok := p.tokens[p.index]
+	if p.index < len(p.tokens)-1 {
+		p.index++
+	}
+	return tok
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	msg := fmt.Sprintf(format, args...)
+	if p.current().typ == tEOF {
+		msg = "unexpected end of the query"
+	}
+
+	return SyntaxError{Expression: p.expression, Offset: p.current().pos, msg: msg}
+}
+
+// unexpected returns the error of a token that can't be parsed where it is
+func (p *parser) unexpected(tok token) error {
+	msg := fmt.Sprintf("unexpected %q", tok.value)
+	if tok.typ == tEOF {
+		msg = "unexpected end of the query"
+	}
+
+	return SyntaxError{Expression: p.expression, Offset: tok.pos, msg: msg}
+}
+
+func (p *parser) expect(typ tokenType, what string) error {
+	if p.current().typ != typ {
+		return p.errorf("expected %s", what)
+	}
+	p.advance()
+	return nil
+}
+
+func (p *parser) parseExpression(bindingPower int) (node, error) {
+	left, err := p.nud(p.advance())
+	if err != nil {
+		return node{}, err
+	}
+
+	for bindingPower < bindingPowers[p.current().typ] {
+		left, err = p.led(p.advance(), left)
+		if err != nil {
+			return node{}, err
+		}
+	}
+
+	return left, nil
+}
+
+// nud parses the expressions starting with tok
+func (p *parser) nud(tok token) (node, error) {
+	current := node{typ: nCurrent}
+
+	switch tok.typ {
+	case tIdentifier, tQuotedIdentifier:
+		return node{typ: nField, value: tok.value}, nil
+	case tRawString:
+		return node{typ: nLiteral, value: tok.value}, nil
+	case tLiteral:
+		value, err := decode([]byte(tok.value))
+		if err != nil {
+			return node{}, err
+		}
+		return node{typ: nLiteral, value: value}, nil
+	case tCurrent:
+		return current, nil
+	case tStar:
+		right, err := p.parseProjectionRHS(bindingPowers[tStar])
+		if err != nil {
+			return node{}, err
+		}
+		return node{typ: nValueProjection, children: []node{current, right}}, nil
+	case tFilter:
+		return p.parseFilter(current)
+	case tFlatten:
+		right, err := p.parseProjectionRHS(bindingPowers[tFlatten])
+		if err != nil {
+			return node{}, err
+		}
+		return node{typ: nProjection, children: []node{{typ: nFlatten, children: []node{current}}, right}}, nil
+	case tLbrace:
+		return p.parseMultiSelectHash()
+	case tLbracket:
+		switch {
+		case p.current().typ == tNumber || p.current().typ == tColon:
+			right, err := p.parseIndexExpression()
+			if err != nil {
+				return node{}, err
+			}
+			return p.projectIfSlice(current, right)
+		case p.current().typ == tStar && p.lookahead(1).typ == tRbracket:
+			p.advance()
+			p.advance()
+			right, err := p.parseProjectionRHS(bindingPowers[tStar])
+			if err != nil {
+				return node{}, err
+			}
+			return node{typ: nProjection, children: []node{current, right}}, nil
+		default:
+			return p.parseMultiSelectList()
+		}
+	case tExpref:
+		expr, err := p.parseExpression(0)
+		if err != nil {
+			return node{}, err
+		}
+		return node{typ: nExpressionRef, children: []node{expr}}, nil
+	case tNot:
+		expr, err := p.parseExpression(bindingPowers[tNot])
+		if err != nil {
+			return node{}, err
+		}
+		return node{typ: nNot, children: []node{expr}}, nil
+	case tLparen:
+		expr, err := p.parseExpression(0)
+		if err != nil {
+			return node{}, err
+		}
+		if err := p.expect(tRparen, ")"); err != nil {
+			return node{}, err
+		}
+		return expr, nil
+	}
+
+	return node{}, p.unexpected(tok)
+}
+
+// led parses the expressions continuing left with tok
+func (p *parser) led(tok token, left node) (node, error) {
+	switch tok.typ {
+	case tDot:
+		if p.current().typ == tStar {
+			p.advance()
+			right, err := p.parseProjectionRHS(bindingPowers[tDot])
+			if err != nil {
+				return node{}, err
+			}
+			return node{typ: nValueProjection, children: []node{left, right}}, nil
+		}
+		right, err := p.parseDotRHS(bindingPowers[tDot])
+		if err != nil {
+			return node{}, err
+		}
+		return node{typ: nSubexpression, children: []node{left, right}}, nil
+	case tPipe:
+		right, err := p.parseExpression(bindingPowers[tPipe])
+		if err != nil {
+			return node{}, err
+		}
+		return node{typ: nPipe, children: []node{left, right}}, nil
+	case tOr, tAnd:
+		right, err := p.parseExpression(bindingPowers[tok.typ])
+		if err != nil {
+			return node{}, err
+		}
+		typ := nOr
+		if tok.typ == tAnd {
+			typ = nAnd
+		}
+		return node{typ: typ, children: []node{left, right}}, nil
+	case tEQ, tNE, tLT, tLTE, tGT, tGTE:
+		right, err := p.parseExpression(bindingPowers[tok.typ])
+		if err != nil {
+			return node{}, err
+		}
+		return node{typ: nComparator, value: tok.typ, children: []node{left, right}}, nil
+	case tLparen:
+		if left.typ != nField {
+			return node{}, p.errorf("unexpected (")
+		}
+		var args []node
+		for p.current().typ != tRparen {
+			arg, err := p.parseExpression(0)
+			if err != nil {
+				return node{}, err
+			}
+			args = append(args, arg)
+			if p.current().typ == tComma {
+				p.advance()
+			} else if p.current().typ != tRparen {
+				return node{}, p.errorf("expected , or )")
+			}
+		}
+		p.advance()
+		return node{typ: nFunction, value: left.value, children: args}, nil
+	case tFilter:
+		return p.parseFilter(left)
+	case tFlatten:
+		right, err := p.parseProjectionRHS(bindingPowers[tFlatten])
+		if err != nil {
+			return node{}, err
+		}
+		return node{typ: nProjection, children: []node{{typ: nFlatten, children: []node{left}}, right}}, nil
+	case tLbracket:
+		if p.current().typ == tNumber || p.current().typ == tColon {
+			right, err := p.parseIndexExpression()
+			if err != nil {
+				return node{}, err
+			}
+			return p.projectIfSlice(left, right)
+		}
+		if err := p.expect(tStar, "a number, : or *"); err != nil {
+			return node{}, err
+		}
+		if err := p.expect(tRbracket, "]"); err != nil {
+			return node{}, err
+		}
+		right, err := p.parseProjectionRHS(bindingPowers[tStar])
+		if err != nil {
+			return node{}, err
+		}
+		return node{typ: nProjection, children: []node{left, right}}, nil
+	}
+
+	return node{}, p.unexpected(tok)
+}
+
+// parseIndexExpression parses [0] or a slice like [1:5:2], after the [
+func (p *parser) parseIndexExpression() (node, error) {
+	if p.current().typ == tColon || p.lookahead(1).typ == tColon {
+		return p.parseSlice()
+	}
+
+	index, _ := strconv.Atoi(p.advance().value)
+	if err := p.expect(tRbracket, "]"); err != nil {
+		return node{}, err
+	}
+
+	return node{typ: nIndex, value: index}, nil
+}
+
+func (p *parser) parseSlice() (node, error) {
+	var parts [3]*int
+	part := 0
+
+	for p.current().typ != tRbracket {
+		switch p.current().typ {
+		case tColon:
+			part++
+			if part > 2 {
+				return node{}, p.errorf("too many colons in slice")
+			}
+		case tNumber:
+			n, _ := strconv.Atoi(p.current().value)
+			parts[part] = &n
+		default:
+			return node{}, p.errorf("expected a number or :")
+		}
+		p.advance()
+	}
+	p.advance()
+
+	if parts[2] != nil && *parts[2] == 0 {
+		return node{}, p.errorf("slice step cannot be 0")
+	}
+
+	return node{typ: nSlice, value: parts}, nil
+}
+
+// projectIfSlice returns the index expression of left, projected if it's a
+// slice
+func (p *parser) projectIfSlice(left, right node) (node, error) {
+	indexExpr := node{typ: nIndexExpression, children: []node{left, right}}
+	if right.typ != nSlice {
+		return indexExpr, nil
+	}
+
+	rhs, err := p.parseProjectionRHS(bindingPowers[tStar])
+	if err != nil {
+		return node{}, err
+	}
+
+	return node{typ: nProjection, children: []node{indexExpr, rhs}}, nil
+}
+
+// parseFilter parses a filter like [?status=='paid'], after the [?
+func (p *parser) parseFilter(left node) (node, error) {
+	condition, err := p.parseExpression(0)
+	if err != nil {
+		return node{}, err
+	}
+	if err := p.expect(tRbracket, "]"); err != nil {
+		return node{}, err
+	}
+
+	right := node{typ: nCurrent}
+	if p.current().typ != tFlatten {
+		right, err = p.parseProjectionRHS(bindingPowers[tFilter])
+		if err != nil {
+			return node{}, err
+		}
+	}
+
+	return node{typ: nFilterProjection, children: []node{left, right, condition}}, nil
+}
+
+func (p *parser) parseDotRHS(bindingPower int) (node, error) {
+	switch p.current().typ {
+	case tIdentifier, tQuotedIdentifier, tStar:
+		return p.parseExpression(bindingPower)
+	case tLbracket:
+		p.advance()
+		return p.parseMultiSelectList()
+	case tLbrace:
+		p.advance()
+		return p.parseMultiSelectHash()
+	}
+
+	return node{}, p.errorf("expected a field, [ or { after .")
+}
+
+// parseProjectionRHS parses the expression applied to each element of a
+// projection
+func (p *parser) parseProjectionRHS(bindingPower int) (node, error) {
+	current := p.current()
+
+	switch {
+	case bindingPowers[current.typ] < projectionStop:
+		return node{typ: nCurrent}, nil
+	case current.typ == tLbracket, current.typ == tFilter:
+		return p.parseExpression(bindingPower)
+	case current.typ == tDot:
+		p.advance()
+		return p.parseDotRHS(bindingPower)
+	}
+
+	return node{}, p.unexpected(current)
+}
+
+// parseMultiSelectList parses [a, b], after the [
+func (p *parser) parseMultiSelectList() (node, error) {
+	var exprs []node
+
+	for {
+		expr, err := p.parseExpression(0)
+		if err != nil {
+			return node{}, err
+		}
+		exprs = append(exprs, expr)
+
+		if p.current().typ == tRbracket {
+			p.advance()
+			return node{typ: nMultiSelectList, children: exprs}, nil
+		}
+		if err := p.expect(tComma, ", or ]"); err != nil {
+			return node{}, err
+		}
+	}
+}
+
+// parseMultiSelectHash parses {key: expression, ...}, after the {
+func (p *parser) parseMultiSelectHash() (node, error) {
+	var keys []string
+	var exprs []node
+
+	for {
+		key := p.current()
+		if key.typ != tIdentifier && key.typ != tQuotedIdentifier {
+			return node{}, p.errorf("expected a key")
+		}
+		p.advance()
+
+		if err := p.expect(tColon, ":"); err != nil {
+			return node{}, err
+		}
+
+		expr, err := p.parseExpression(0)
+		if err != nil {
+			return node{}, err
+		}
+
+		keys = append(keys, key.value)
+		exprs = append(exprs, expr)
+
+		if p.current().typ == tRbrace {
+			p.advance()
+			return node{typ: nMultiSelectHash, value: keys, children: exprs}, nil
+		}
+		if err := p.expect(tComma, ", or }"); err != nil {
+			return node{}, err
+		}
+	}
+}
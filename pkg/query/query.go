@@ -0,0 +1,178 @@
+// Package query evaluates JMESPath expressions on JSON documents, like the
+// API responses printed with --query, so fields can be extracted from them
+// without jq.
+//
+// Expressions support fields, sub-expressions, indexes and slices, list,
+// object and flatten projections, filters with comparisons, ||, && and !,
+// pipes, multi-select lists and hashes, literals, and the functions
+// length, keys, values, contains, starts_with, ends_with, join, to_string,
+// sort and sort_by.
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Query is a compiled query expression
+type Query struct {
+	expression string
+	ast        node
+}
+
+// Compile parses a query expression, returning a SyntaxError if it's invalid
+func Compile(expression string) (*Query, error) {
+	ast, err := parse(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkFunctions(expression, ast); err != nil {
+		return nil, err
+	}
+
+	return &Query{expression: expression, ast: ast}, nil
+}
+
+// String returns the expression of the query
+func (q *Query) String() string {
+	return q.expression
+}
+
+// Search evaluates the query on a JSON document, and returns its result as
+// indented JSON. The fields of objects keep the order of the document.
+func (q *Query) Search(data []byte) ([]byte, error) {
+	value, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := eval(q.ast, value)
+	if err != nil {
+		return nil, fmt.Errorf("could not evaluate query: %w", err)
+	}
+
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(result); err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
+
+// object is a JSON object that keeps the order of its fields
+type object struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newObject() *object {
+	return &object{values: map[string]interface{}{}}
+}
+
+func (o *object) set(key string, value interface{}) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// MarshalJSON encodes the object with its fields in order
+func (o *object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		k, err := marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+
+		v, err := marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(v)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// marshal encodes a value without escaping HTML characters, like the API
+func marshal(value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(value); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// decode decodes a JSON document, with its objects as *object and its numbers
+// as json.Number
+func decode(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	value, err := decodeValue(dec)
+	if err != nil {
+		return nil, fmt.Errorf("could not query a response that isn't JSON: %w", err)
+	}
+
+	return value, nil
+}
+
+func decodeValue(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		obj := newObject()
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := decodeValue(dec)
+			if err != nil {
+				return nil, err
+			}
+
+			obj.set(key.(string), value)
+		}
+		_, err := dec.Token()
+		return obj, err
+	case json.Delim('['):
+		array := []interface{}{}
+		for dec.More() {
+			value, err := decodeValue(dec)
+			if err != nil {
+				return nil, err
+			}
+
+			array = append(array, value)
+		}
+		_, err := dec.Token()
+		return array, err
+	default:
+		return tok, nil
+	}
+}
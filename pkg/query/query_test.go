@@ -0,0 +1,108 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const customers = `{
+  "object": "list",
+  "data": [
+    {"id": "cus_1", "email": "jenny@example.com", "balance": 0, "metadata": {"plan": "pro"}, "tags": ["a", "b"]},
+    {"id": "cus_2", "email": null, "balance": -500, "metadata": {}, "tags": ["c"]},
+    {"id": "cus_3", "email": "sam@example.com", "balance": 1200, "metadata": {"plan": "basic"}, "tags": []}
+  ],
+  "has_more": false,
+  "url": "/v1/customers"
+}`
+
+func search(t *testing.T, expression, data string) string {
+	q, err := Compile(expression)
+	require.NoError(t, err)
+
+	result, err := q.Search([]byte(data))
+	require.NoError(t, err)
+
+	return string(result)
+}
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   string
+	}{
+		{"url", `"/v1/customers"`},
+		{"data[0].id", `"cus_1"`},
+		{"data[-1].id", `"cus_3"`},
+		{"data[].id", `["cus_1","cus_2","cus_3"]`},
+		{"data[*].metadata.plan", `["pro","basic"]`},
+		{"data[:2].id", `["cus_1","cus_2"]`},
+		{"data[::-1].id", `["cus_3","cus_2","cus_1"]`},
+		{"data[].tags[]", `["a","b","c"]`},
+		{"data[0].metadata.*", `["pro"]`},
+		{"data[?balance > `0`].id", `["cus_3"]`},
+		{"data[?email == 'sam@example.com'].id | [0]", `"cus_3"`},
+		{"data[?!email].id", `["cus_2"]`},
+		{"data[?email && balance >= `0`].id", `["cus_1","cus_3"]`},
+		{"data[?contains(tags, 'c')].id", `["cus_2"]`},
+		{"data[?starts_with(id, 'cus_1')] | length(@)", `1`},
+		{"missing || `\"default\"`", `"default"`},
+		{"length(data)", `3`},
+		{"keys(data[0])", `["id","email","balance","metadata","tags"]`},
+		{"join(', ', data[].id)", `"cus_1, cus_2, cus_3"`},
+		{"sort_by(data, &balance)[].id", `["cus_2","cus_1","cus_3"]`},
+		{"data[0].[id, balance]", `["cus_1",0]`},
+		{"data[].{id: id, email: email}", `[{"id":"cus_1","email":"jenny@example.com"},{"id":"cus_2","email":null},{"id":"cus_3","email":"sam@example.com"}]`},
+		{`data[0]."metadata".plan`, `"pro"`},
+		{"data[0].balance.missing", `null`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			require.JSONEq(t, tt.expected, search(t, tt.expression, customers))
+		})
+	}
+}
+
+func TestSearchKeepsFieldOrder(t *testing.T) {
+	result := search(t, "data[0].{zeta: id, alpha: email}", customers)
+	require.Equal(t, "{\n  \"zeta\": \"cus_1\",\n  \"alpha\": \"jenny@example.com\"\n}\n", result)
+
+	result = search(t, "@", `{"b": 1, "a": 1.50, "c": "<&>"}`)
+	require.Equal(t, "{\n  \"b\": 1,\n  \"a\": 1.50,\n  \"c\": \"<&>\"\n}\n", result)
+}
+
+func TestCompileSyntaxErrors(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   string
+	}{
+		{"data[", "invalid query at position 5: unexpected end of the query"},
+		{"data[].{id id}", "invalid query at position 11: expected :"},
+		{"data ^ 2", "invalid query at position 5: unexpected character '^'"},
+		{"'unterminated", "invalid query at position 0: unterminated '"},
+		{"count(data)", "invalid query at position 0: unknown function count()"},
+		{"length(data, id)", "invalid query at position 0: length() takes 1 arguments, got 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			_, err := Compile(tt.expression)
+			require.Error(t, err)
+			require.IsType(t, SyntaxError{}, err)
+			require.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
+
+func TestSearchErrors(t *testing.T) {
+	q, err := Compile("length(data[0].balance)")
+	require.NoError(t, err)
+
+	_, err = q.Search([]byte(customers))
+	require.EqualError(t, err, "could not evaluate query: invalid argument of type number for length()")
+
+	_, err = q.Search([]byte("not json"))
+	require.Error(t, err)
+}
@@ -0,0 +1,292 @@
+// Package refunds refunds charges in bulk from a CSV file, in batches, with
+// idempotency keys and a checkpoint file, so an interrupted run can be
+// resumed without refunding a charge twice.
+package refunds
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/tidwall/gjson"
+
+	"github.com/stripe/stripe-cli/pkg/requests"
+)
+
+// Reasons are the reasons a refund can be created with
+var Reasons = []string{"duplicate", "fraudulent", "requested_by_customer"}
+
+// batchPause is the pause between batches, so bulk refunds don't use up the
+// rate limit of the account
+var batchPause = time.Second
+
+// Row is a refund to create, read from a line of the CSV file.
+type Row struct {
+	Line int
+	// Charge is the ID of the charge or of the payment intent to refund
+	Charge string
+	// Amount is the amount to refund, in the smallest currency unit. The
+	// whole charge is refunded when it's 0.
+	Amount int64
+}
+
+// ReadCSV reads the refunds of a CSV file. The file has a header line with a
+// `charge` or `payment_intent` column, and an optional `amount` column.
+func ReadCSV(r io.Reader) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("the CSV file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	chargeColumn, amountColumn := -1, -1
+	for i, name := range header {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "charge", "payment_intent":
+			chargeColumn = i
+		case "amount":
+			amountColumn = i
+		}
+	}
+	if chargeColumn == -1 {
+		return nil, errors.New("the CSV file has no charge or payment_intent column")
+	}
+
+	var rows []Row
+	seen := make(map[string]int)
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row := Row{
+			Line:   line,
+			Charge: strings.TrimSpace(record[chargeColumn]),
+		}
+
+		if !strings.HasPrefix(row.Charge, "ch_") && !strings.HasPrefix(row.Charge, "py_") && !strings.HasPrefix(row.Charge, "pi_") {
+			return nil, fmt.Errorf("line %d: ‘%s’ is neither a charge nor a payment intent ID", line, row.Charge)
+		}
+
+		if previous, ok := seen[row.Charge]; ok {
+			return nil, fmt.Errorf("line %d: %s is already refunded on line %d", line, row.Charge, previous)
+		}
+		seen[row.Charge] = line
+
+		if amountColumn != -1 {
+			if amount := strings.TrimSpace(record[amountColumn]); amount != "" {
+				row.Amount, err = strconv.ParseInt(amount, 10, 64)
+				if err != nil || row.Amount <= 0 {
+					return nil, fmt.Errorf("line %d: invalid amount ‘%s’, expected a positive amount in the smallest currency unit", line, amount)
+				}
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("the CSV file has no refunds")
+	}
+
+	return rows, nil
+}
+
+// Checkpoint is the progress of a bulk refund, saved after every batch.
+type Checkpoint struct {
+	// RunID prefixes the idempotency keys of the refunds, so resuming a run
+	// sends the same keys
+	RunID string `json:"run_id"`
+	// Refunded maps the charges refunded to their refund
+	Refunded map[string]string `json:"refunded"`
+	// Failed maps the charges that failed to be refunded to the error. They
+	// are retried when the run is resumed.
+	Failed map[string]string `json:"failed"`
+}
+
+// LoadCheckpoint reads the checkpoint at path, or returns a new checkpoint
+// if there's none.
+func LoadCheckpoint(fs afero.Fs, path string) (*Checkpoint, error) {
+	data, err := afero.ReadFile(fs, path)
+	if os.IsNotExist(err) {
+		id := make([]byte, 8)
+		if _, err := rand.Read(id); err != nil {
+			return nil, err
+		}
+
+		return &Checkpoint{
+			RunID:    hex.EncodeToString(id),
+			Refunded: make(map[string]string),
+			Failed:   make(map[string]string),
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("the checkpoint file %s is invalid: %w", path, err)
+	}
+
+	if checkpoint.Refunded == nil {
+		checkpoint.Refunded = make(map[string]string)
+	}
+	if checkpoint.Failed == nil {
+		checkpoint.Failed = make(map[string]string)
+	}
+
+	return &checkpoint, nil
+}
+
+// Save writes the checkpoint to path
+func (c *Checkpoint) Save(fs afero.Fs, path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return afero.WriteFile(fs, path, data, 0644)
+}
+
+// Pending returns the rows that aren't refunded yet
+func (c *Checkpoint) Pending(rows []Row) []Row {
+	var pending []Row
+	for _, row := range rows {
+		if _, ok := c.Refunded[row.Charge]; !ok {
+			pending = append(pending, row)
+		}
+	}
+
+	return pending
+}
+
+// Bulk refunds rows in batches.
+type Bulk struct {
+	BaseURL string
+	APIKey  string
+	Reason  string
+	// BatchSize is the number of refunds created at the same time
+	BatchSize int
+
+	Checkpoint *Checkpoint
+	// SaveCheckpoint is called after every batch
+	SaveCheckpoint func() error
+
+	// OnRefund is called after every refund, with the refund ID or the error
+	OnRefund func(row Row, refund string, err error)
+}
+
+// Run refunds the rows that aren't in the checkpoint yet. Rows failing to be
+// refunded don't stop the run, they're recorded in the checkpoint.
+func (b *Bulk) Run(ctx context.Context, rows []Row) error {
+	pending := b.Checkpoint.Pending(rows)
+
+	for start := 0; start < len(pending); start += b.BatchSize {
+		if start > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(batchPause):
+			}
+		}
+
+		end := start + b.BatchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+
+		for _, row := range pending[start:end] {
+			wg.Add(1)
+			go func(row Row) {
+				defer wg.Done()
+
+				refund, err := b.refund(ctx, row)
+
+				mu.Lock()
+				defer mu.Unlock()
+
+				if err != nil {
+					b.Checkpoint.Failed[row.Charge] = err.Error()
+				} else {
+					b.Checkpoint.Refunded[row.Charge] = refund
+					delete(b.Checkpoint.Failed, row.Charge)
+				}
+
+				if b.OnRefund != nil {
+					b.OnRefund(row, refund, err)
+				}
+			}(row)
+		}
+
+		wg.Wait()
+
+		if err := b.SaveCheckpoint(); err != nil {
+			return err
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *Bulk) refund(ctx context.Context, row Row) (string, error) {
+	req := requests.Base{
+		Method:         http.MethodPost,
+		SuppressOutput: true,
+		APIBaseURL:     b.BaseURL,
+	}
+
+	var params requests.RequestParameters
+	if strings.HasPrefix(row.Charge, "pi_") {
+		params.AppendData([]string{"payment_intent=" + row.Charge})
+	} else {
+		params.AppendData([]string{"charge=" + row.Charge})
+	}
+	if row.Amount > 0 {
+		params.AppendData([]string{fmt.Sprintf("amount=%d", row.Amount)})
+	}
+	if b.Reason != "" {
+		params.AppendData([]string{"reason=" + b.Reason})
+	}
+	params.AppendData([]string{"metadata[bulk_refund_run]=" + b.Checkpoint.RunID})
+
+	// The key is the same when the run is resumed, so a refund sent before
+	// an interruption isn't created twice
+	params.SetIdempotency(fmt.Sprintf("stripe-cli-bulk-refund-%s-%s", b.Checkpoint.RunID, row.Charge))
+
+	resp, err := req.MakeRequest(ctx, b.APIKey, "/v1/refunds", &params, true)
+	if err != nil {
+		return "", err
+	}
+
+	return gjson.GetBytes(resp, "id").String(), nil
+}
@@ -0,0 +1,121 @@
+package refunds
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCSV(t *testing.T) {
+	rows, err := ReadCSV(strings.NewReader("customer,charge,amount\ncus_1,ch_1,\ncus_2, pi_2 ,500\n"))
+	require.NoError(t, err)
+	require.Equal(t, []Row{
+		{Line: 2, Charge: "ch_1"},
+		{Line: 3, Charge: "pi_2", Amount: 500},
+	}, rows)
+
+	for csv, expected := range map[string]string{
+		"":                          "the CSV file is empty",
+		"charge\n":                  "the CSV file has no refunds",
+		"id,amount\nch_1,1\n":       "the CSV file has no charge or payment_intent column",
+		"charge\ncus_1\n":           "line 2: ‘cus_1’ is neither a charge nor a payment intent ID",
+		"charge\nch_1\nch_1\n":      "line 3: ch_1 is already refunded on line 2",
+		"charge,amount\nch_1,-5\n":  "line 2: invalid amount ‘-5’, expected a positive amount in the smallest currency unit",
+		"charge,amount\nch_1,5.0\n": "line 2: invalid amount ‘5.0’, expected a positive amount in the smallest currency unit",
+	} {
+		_, err := ReadCSV(strings.NewReader(csv))
+		require.EqualError(t, err, expected, csv)
+	}
+}
+
+func TestLoadCheckpoint(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	checkpoint, err := LoadCheckpoint(fs, "charges.checkpoint.json")
+	require.NoError(t, err)
+	require.Len(t, checkpoint.RunID, 16)
+
+	checkpoint.Refunded["ch_1"] = "re_1"
+	require.NoError(t, checkpoint.Save(fs, "charges.checkpoint.json"))
+
+	loaded, err := LoadCheckpoint(fs, "charges.checkpoint.json")
+	require.NoError(t, err)
+	require.Equal(t, checkpoint, loaded)
+
+	require.Equal(t, []Row{{Line: 3, Charge: "ch_2"}}, loaded.Pending([]Row{{Line: 2, Charge: "ch_1"}, {Line: 3, Charge: "ch_2"}}))
+}
+
+func TestBulkRun(t *testing.T) {
+	defer func(pause time.Duration) { batchPause = pause }(batchPause)
+	batchPause = 0
+
+	var mu sync.Mutex
+	keys := make(map[string]string)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/refunds", r.URL.Path)
+		r.ParseForm()
+
+		charge := r.Form.Get("charge") + r.Form.Get("payment_intent")
+
+		mu.Lock()
+		keys[charge] = r.Header.Get("Idempotency-Key")
+		mu.Unlock()
+
+		require.Equal(t, "duplicate", r.Form.Get("reason"))
+		require.Equal(t, "run_123", r.Form.Get("metadata[bulk_refund_run]"))
+
+		if charge == "ch_3" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": {"type": "invalid_request_error", "code": "charge_already_refunded", "message": "Charge ch_3 has already been refunded."}}`))
+			return
+		}
+
+		w.Write([]byte(`{"id": "re_` + charge + `"}`))
+	}))
+	defer ts.Close()
+
+	checkpoint := &Checkpoint{
+		RunID:    "run_123",
+		Refunded: map[string]string{"ch_1": "re_1"},
+		Failed:   map[string]string{},
+	}
+	saves := 0
+
+	bulk := &Bulk{
+		BaseURL:        ts.URL,
+		APIKey:         "sk_test_123",
+		Reason:         "duplicate",
+		BatchSize:      2,
+		Checkpoint:     checkpoint,
+		SaveCheckpoint: func() error { saves++; return nil },
+	}
+
+	err := bulk.Run(context.Background(), []Row{
+		{Line: 2, Charge: "ch_1"},
+		{Line: 3, Charge: "pi_2", Amount: 500},
+		{Line: 4, Charge: "ch_3"},
+		{Line: 5, Charge: "ch_4"},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, 2, saves)
+	require.Equal(t, map[string]string{
+		"ch_1": "re_1",
+		"pi_2": "re_pi_2",
+		"ch_4": "re_ch_4",
+	}, checkpoint.Refunded)
+	require.Equal(t, map[string]string{
+		"ch_3": "Request failed, status=400, body={\"error\": {\"type\": \"invalid_request_error\", \"code\": \"charge_already_refunded\", \"message\": \"Charge ch_3 has already been refunded.\"}}",
+	}, checkpoint.Failed)
+
+	require.NotContains(t, keys, "ch_1")
+	require.Equal(t, "stripe-cli-bulk-refund-run_123-pi_2", keys["pi_2"])
+}
@@ -13,13 +13,18 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/stripe/stripe-cli/pkg/ansi"
 	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/docs"
+	"github.com/stripe/stripe-cli/pkg/netproxy"
 	"github.com/stripe/stripe-cli/pkg/stripe"
 
 	"github.com/spf13/cobra"
+	"github.com/tidwall/gjson"
 )
 
 // RequestParameters captures the structure of the parameters that can be sent to Stripe
@@ -31,7 +36,14 @@ type RequestParameters struct {
 	idempotency   string
 	limit         string
 	version       string
-	stripeAccount string
+	// previewFeatures are the beta version headers of --preview-feature, like
+	// feature_beta=v1
+	previewFeatures []string
+	stripeAccount   string
+	headers         []string
+	jsonBody        string
+	filePath        string
+	fileFields      []string
 }
 
 // AppendData appends data to the request parameters.
@@ -59,13 +71,33 @@ func (r *RequestParameters) SetVersion(value string) {
 	r.version = value
 }
 
+// AppendPreviewFeatures opts the request into preview features, like
+// feature_beta or feature_beta=v2, whose beta version headers are appended to
+// the `Stripe-Version` header.
+func (r *RequestParameters) AppendPreviewFeatures(features []string) {
+	r.previewFeatures = append(r.previewFeatures, features...)
+}
+
+// AppendHeaders appends custom headers, like "Stripe-Context: acct_123", to
+// the request.
+func (r *RequestParameters) AppendHeaders(headers []string) {
+	r.headers = append(r.headers, headers...)
+}
+
 // RequestError captures the response of the request that resulted in an error
 type RequestError struct {
 	msg        string
 	StatusCode int
 	ErrorType  string
 	ErrorCode  string
+	Message    string
+	DocURL     string
+	RequestID  string
 	Body       interface{} // the raw response body
+
+	// Printed is set when the response was printed as the output of the
+	// command, so that the error isn't printed again
+	Printed bool
 }
 
 func (e RequestError) Error() string {
@@ -102,8 +134,31 @@ type Base struct {
 
 	Livemode bool
 
-	autoConfirm bool
-	showHeaders bool
+	// Proxy server requests are sent through. If nil, the proxy setting of
+	// the profile is used, if any.
+	Proxy *netproxy.Dialer
+
+	// OnResponse, if set, is called with each request StreamRequest sends,
+	// with its form-encoded data, and its response, e.g. to record them into
+	// a fixture
+	OnResponse func(method, path, data string, statusCode int, body []byte)
+
+	autoConfirm      bool
+	showHeaders      bool
+	validateResponse bool
+	all              bool
+	output           string
+	query            string
+	autoIdempotent   bool
+	bulk             string
+	bulkResults      string
+	concurrency      int
+	asCurl           bool
+	asCode           string
+	cache            bool
+	apiVersionFamily string
+	watch            bool
+	watchInterval    time.Duration
 }
 
 var confirmationCommands = map[string]bool{http.MethodDelete: true}
@@ -131,14 +186,12 @@ func (rb *Base) RunRequestsCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	path, err := createOrNormalizePath(args[0])
+	path, err := rb.normalizeRequestPath(args[0])
 	if err != nil {
 		return err
 	}
 
-	_, err = rb.MakeRequest(cmd.Context(), apiKey, path, &rb.Parameters, false)
-
-	return err
+	return rb.StreamRequest(cmd.Context(), apiKey, path, &rb.Parameters)
 }
 
 // InitFlags initialize shared flags for all requests commands
@@ -151,15 +204,51 @@ func (rb *Base) InitFlags() {
 	rb.Cmd.Flags().StringArrayVarP(&rb.Parameters.expand, "expand", "e", []string{}, "Response attributes to expand inline")
 	rb.Cmd.Flags().StringVarP(&rb.Parameters.idempotency, "idempotency", "i", "", "Set the idempotency key for the request, prevents replaying the same requests within 24 hours")
 	rb.Cmd.Flags().StringVarP(&rb.Parameters.version, "stripe-version", "v", "", "Set the Stripe API version to use for your request")
+	if rb.Cmd.Flags().Lookup("api-version") == nil {
+		rb.Cmd.Flags().StringVar(&rb.Parameters.version, "api-version", "", "Set the Stripe API version to use for your request, like --stripe-version (default: the api_version of the profile, or else the version of the account)")
+	}
+	if rb.Cmd.Flags().Lookup("preview-feature") == nil {
+		rb.Cmd.Flags().StringArrayVar(&rb.Parameters.previewFeatures, "preview-feature", []string{}, "Opt the request into a preview feature, like feature_beta or feature_beta=v2 (default version: v1). Can be repeated")
+	}
 	rb.Cmd.Flags().StringVar(&rb.Parameters.stripeAccount, "stripe-account", "", "Set a header identifying the connected account")
+	if rb.Cmd.Flags().Lookup("header") == nil {
+		rb.Cmd.Flags().StringArrayVarP(&rb.Parameters.headers, "header", "H", []string{}, `Set a custom header on the request, e.g. "Stripe-Context: acct_123". Can be repeated`)
+	}
 	rb.Cmd.Flags().BoolVarP(&rb.showHeaders, "show-headers", "s", false, "Show response headers")
 	rb.Cmd.Flags().BoolVar(&rb.Livemode, "live", false, "Make a live request (default: test)")
 	rb.Cmd.Flags().BoolVar(&rb.DarkStyle, "dark-style", false, "Use a darker color scheme better suited for lighter command-lines")
+	if rb.Cmd.Flags().Lookup("output") == nil {
+		rb.Cmd.Flags().StringVarP(&rb.output, "output", "o", "", `How the response is printed
+Acceptable values:
+	'json'   - Print the response as JSON (default)
+	'yaml'   - Print the response as YAML
+	'table'  - Print the objects of lists as a table with their main fields, and objects as a table of their fields
+	'csv'    - Print the objects of lists, or the object, as CSV with their fields that aren't objects or arrays
+	'ndjson' - Print the objects of lists one per line`)
+	}
+	if rb.Cmd.Flags().Lookup("query") == nil {
+		rb.Cmd.Flags().StringVarP(&rb.query, "query", "q", "", "Print the result of a JMESPath query on the response, e.g. 'data[].{id:id,email:email}'")
+	}
+	if rb.Cmd.Flags().Lookup("as-curl") == nil {
+		rb.Cmd.Flags().BoolVar(&rb.asCurl, "as-curl", false, "Print the request as a curl command instead of sending it. The API key is read from $STRIPE_SECRET_KEY")
+	}
+	if rb.Cmd.Flags().Lookup("as-code") == nil {
+		rb.Cmd.Flags().StringVar(&rb.asCode, "as-code", "", "Print the request as code of a Stripe SDK instead of sending it: node, python, ruby or go. The API key is read from $STRIPE_SECRET_KEY")
+	}
+	if rb.Cmd.Flags().Lookup("api-version-family") == nil {
+		rb.Cmd.Flags().StringVar(&rb.apiVersionFamily, "api-version-family", "", `API the request is sent to: v1, or v2 for the endpoints of newer products.
+v2 requests are sent as JSON and paths without a version are v2 endpoints (default: the version of the path)`)
+	}
+	rb.Cmd.Flags().BoolVar(&rb.validateResponse, "validate-response", false, "Warn about fields and types of the response that don't match the API schemas bundled with the CLI")
 
 	// Conditionally add flags for GET requests. I'm doing it here to keep `limit`, `start_after` and `ending_before` unexported
 	if rb.Method == http.MethodGet {
 		if rb.Cmd.Flags().Lookup("limit") == nil {
-			rb.Cmd.Flags().StringVarP(&rb.Parameters.limit, "limit", "l", "", "How many objects to be returned, between 1 and 100 (default is 10)")
+			rb.Cmd.Flags().StringVarP(&rb.Parameters.limit, "limit", "l", "", "How many objects to be returned (default is 10). Above 100, the pages of the list are followed")
+		}
+
+		if rb.Cmd.Flags().Lookup("all") == nil {
+			rb.Cmd.Flags().BoolVar(&rb.all, "all", false, "Follow the pages of the list and return all of its objects, as a single JSON array")
 		}
 
 		if rb.Cmd.Flags().Lookup("starting-after") == nil {
@@ -169,6 +258,45 @@ func (rb *Base) InitFlags() {
 		if rb.Cmd.Flags().Lookup("ending-before") == nil {
 			rb.Cmd.Flags().StringVarP(&rb.Parameters.endingBefore, "ending-before", "b", "", "Retrieve the previous page in the list. This is a cursor for pagination and should be an object ID")
 		}
+
+		if rb.Cmd.Flags().Lookup("watch") == nil {
+			rb.Cmd.Flags().BoolVar(&rb.watch, "watch", false, "Request the object every --interval until interrupted, and print the fields that changed")
+			rb.Cmd.Flags().DurationVar(&rb.watchInterval, "interval", defaultWatchInterval, "Time between the requests of --watch")
+		}
+
+		if rb.Cmd.Flags().Lookup("cache") == nil {
+			rb.Cmd.Flags().BoolVar(&rb.cache, "cache", false, `Cache the response, and send its ETag with the next identical request so that the
+cached response is printed if the resource didn't change`)
+		}
+	}
+
+	if rb.Method == http.MethodPost {
+		if rb.Cmd.Flags().Lookup("file-path") == nil {
+			rb.Cmd.Flags().StringVar(&rb.Parameters.filePath, "file-path", "", "Upload a file as multipart/form-data, e.g. to /v1/files. Uploads are sent to files.stripe.com")
+		}
+
+		if rb.Cmd.Flags().Lookup("file") == nil {
+			rb.Cmd.Flags().StringArrayVar(&rb.Parameters.fileFields, "file", []string{}, "Form field sent with the file of --file-path, e.g. purpose=dispute_evidence")
+		}
+
+		if rb.Cmd.Flags().Lookup("auto-idempotency") == nil {
+			rb.Cmd.Flags().BoolVar(&rb.autoIdempotent, "auto-idempotency", false, `Send the request with a generated idempotency key, and retry it on network errors,
+rate limits and server errors. Defaults to the auto_idempotency setting of the profile`)
+		}
+	}
+
+	if rb.Method == http.MethodPost || rb.Method == http.MethodDelete {
+		if rb.Cmd.Flags().Lookup("bulk") == nil {
+			rb.Cmd.Flags().StringVar(&rb.bulk, "bulk", "", `Send a request for each row of a CSV file, whose columns are the parameters, or of an NDJSON file.
+{column} in the path is replaced by the value of the row, e.g. /v1/customers/{id}`)
+			rb.Cmd.Flags().IntVar(&rb.concurrency, "concurrency", defaultConcurrency, "Number of requests of --bulk sent at the same time")
+			rb.Cmd.Flags().StringVar(&rb.bulkResults, "results", "", "File to write the status and created ID of each row of --bulk to (default: the file with a .results.csv extension)")
+		}
+
+		if rb.Cmd.Flags().Lookup("json-body") == nil {
+			rb.Cmd.Flags().StringVar(&rb.Parameters.jsonBody, "json-body", "", `JSON body of the request, inline, from a file with @path, or from stdin with -.
+It's converted to form-encoded data, or sent as is to v2 endpoints`)
+		}
 	}
 
 	// Hidden configuration flags, useful for dev/debugging
@@ -194,37 +322,189 @@ func (rb *Base) MakeMultiPartRequest(ctx context.Context, apiKey, path string, p
 
 // MakeRequest will make a request to the Stripe API with the specific variables given to it
 func (rb *Base) MakeRequest(ctx context.Context, apiKey, path string, params *RequestParameters, errOnStatus bool) ([]byte, error) {
-	data, err := rb.buildDataForRequest(params)
+	if err := validatePreviewFeatures(params.previewFeatures); err != nil {
+		return []byte{}, err
+	}
+
+	data, configure, err := rb.buildRequestBody(path, params)
 	if err != nil {
 		return []byte{}, err
 	}
 
-	return rb.performRequest(ctx, apiKey, path, params, data, errOnStatus, nil)
+	return rb.performRequest(ctx, apiKey, path, params, data, errOnStatus, configure)
 }
 
-func (rb *Base) performRequest(ctx context.Context, apiKey, path string, params *RequestParameters, data string, errOnStatus bool, additionalConfigure func(req *http.Request)) ([]byte, error) {
-	parsedBaseURL, err := url.Parse(rb.APIBaseURL)
+// Do sends a request to the Stripe API without printing it, with the
+// parameters in data like `amount=2000`, and returns its parsed response.
+// The request uses the API version, preview features and read-only mode of
+// the profile, if any. Responses with an error status are returned as a
+// RequestError.
+func Do(ctx context.Context, method, baseURL, apiKey, path string, data []string, profile *config.Profile) (gjson.Result, error) {
+	req := Base{
+		Method:         method,
+		SuppressOutput: true,
+		APIBaseURL:     baseURL,
+		Profile:        profile,
+	}
+
+	var params RequestParameters
+	params.AppendData(data)
+
+	resp, err := req.MakeRequest(ctx, apiKey, path, &params, true)
 	if err != nil {
-		return []byte{}, err
+		return gjson.Result{}, err
 	}
 
-	client := &stripe.Client{
-		BaseURL: parsedBaseURL,
-		APIKey:  apiKey,
-		Verbose: rb.showHeaders,
+	return gjson.ParseBytes(resp), nil
+}
+
+// StreamRequest makes a request like MakeRequest, but prints the response
+// while it is received rather than once it was read entirely, so responses of
+// any size are printed without holding them in memory. The response isn't
+// returned.
+func (rb *Base) StreamRequest(ctx context.Context, apiKey, path string, params *RequestParameters) error {
+	if rb.SuppressOutput {
+		_, err := rb.MakeRequest(ctx, apiKey, path, params, false)
+		return err
 	}
 
-	configure := func(req *http.Request) {
-		rb.setIdempotencyHeader(req, params)
-		rb.setStripeAccountHeader(req, params)
-		rb.setVersionHeader(req, params)
-		if additionalConfigure != nil {
-			additionalConfigure(req)
+	if rb.Cmd != nil && rb.Cmd.Flags().Changed("api-version") && rb.Cmd.Flags().Changed("stripe-version") {
+		return fmt.Errorf("--api-version and --stripe-version cannot be used together")
+	}
+
+	if err := validatePreviewFeatures(params.previewFeatures); err != nil {
+		return err
+	}
+
+	if rb.printsSnippet() {
+		return rb.writeSnippet(os.Stdout, path, params)
+	}
+
+	if err := validateOutput(rb.output); err != nil {
+		return err
+	}
+
+	if err := rb.validatePagination(params); err != nil {
+		return err
+	}
+
+	if err := rb.validateAPIVersionFamily(path, params); err != nil {
+		return err
+	}
+
+	if err := rb.validateWatch(params); err != nil {
+		return err
+	}
+
+	if _, err := rb.compileQuery(); err != nil {
+		return err
+	}
+
+	if rb.bulk != "" {
+		return rb.runBulk(ctx, apiKey, path, params, os.Stdout)
+	}
+
+	if rb.watch {
+		return rb.watchResource(ctx, apiKey, path, params, os.Stdout)
+	}
+
+	if rb.paginates(params) {
+		return rb.paginate(ctx, apiKey, path, params, os.Stdout)
+	}
+
+	return rb.streamRequest(ctx, apiKey, path, params, os.Stdout)
+}
+
+func (rb *Base) streamRequest(ctx context.Context, apiKey, path string, params *RequestParameters, out io.Writer) error {
+	data, configure, err := rb.buildRequestBody(path, params)
+	if err != nil {
+		return err
+	}
+
+	resp, err := rb.sendRequest(ctx, apiKey, path, params, data, configure)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 401 {
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		return compileRequestErrorFor(path, resp, body)
+	}
+
+	format := rb.outputFormat()
+
+	// Errors are read to be returned, the other responses are streamed
+	if rb.OnResponse == nil && !rb.validateResponse && format == outputJSON && rb.query == "" && resp.StatusCode < 400 {
+		return ansi.ColorizeJSONStream(out, resp.Body, rb.DarkStyle)
+	}
+
+	var body bytes.Buffer
+	if resp.StatusCode >= 300 || (format == outputJSON && rb.query == "") {
+		// Errors are always printed as JSON, without the query
+		if err := ansi.ColorizeJSONStream(out, io.TeeReader(resp.Body, &body), rb.DarkStyle); err != nil {
+			return err
+		}
+	} else {
+		if _, err := io.Copy(&body, resp.Body); err != nil {
+			return err
 		}
+		if err := rb.writeResult(out, body.Bytes(), format); err != nil {
+			return err
+		}
+	}
+
+	if rb.validateResponse && resp.StatusCode < 300 {
+		warnResponseProblems(os.Stderr, body.Bytes())
 	}
 
-	resp, err := client.PerformRequest(ctx, rb.Method, path, data, configure)
+	if rb.OnResponse != nil {
+		rb.OnResponse(rb.Method, path, data, resp.StatusCode, body.Bytes())
+	}
+
+	if resp.StatusCode >= 400 {
+		requestError := compileRequestErrorFor(path, resp, body.Bytes())
+		requestError.Printed = true
+		return requestError
+	}
 
+	return nil
+}
+
+// warnResponseProblems prints the differences between a response and the
+// API schemas bundled with the CLI, e.g. when the API version of the account
+// isn't the version the CLI was built for.
+func warnResponseProblems(w io.Writer, body []byte) {
+	color := ansi.Color(w)
+
+	reference, err := docs.Load()
+	if err != nil {
+		fmt.Fprintf(w, "%s could not validate the response: %v\n", color.Yellow("Warning"), err)
+		return
+	}
+
+	var response interface{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		fmt.Fprintf(w, "%s could not validate the response: %v\n", color.Yellow("Warning"), err)
+		return
+	}
+
+	problems := reference.Validate(response)
+	if len(problems) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "%s the response doesn't match the schemas of API version %s:\n", color.Yellow("Warning"), reference.APIVersion)
+	for _, problem := range problems {
+		fmt.Fprintf(w, "  - %s\n", problem)
+	}
+}
+
+func (rb *Base) performRequest(ctx context.Context, apiKey, path string, params *RequestParameters, data string, errOnStatus bool, additionalConfigure func(req *http.Request)) ([]byte, error) {
+	resp, err := rb.sendRequest(ctx, apiKey, path, params, data, additionalConfigure)
 	if err != nil {
 		return []byte{}, err
 	}
@@ -233,7 +513,7 @@ func (rb *Base) performRequest(ctx context.Context, apiKey, path string, params
 	body, err := ioutil.ReadAll(resp.Body)
 
 	if resp.StatusCode == 401 || (errOnStatus && resp.StatusCode >= 300) {
-		requestError := compileRequestError(body, resp.StatusCode)
+		requestError := compileRequestErrorFor(path, resp, body)
 		return []byte{}, requestError
 	}
 
@@ -249,10 +529,92 @@ func (rb *Base) performRequest(ctx context.Context, apiKey, path string, params
 	return body, nil
 }
 
+// sendRequest sends a request to the Stripe API and returns its response,
+// whose body the caller must close.
+func (rb *Base) sendRequest(ctx context.Context, apiKey, path string, params *RequestParameters, data string, additionalConfigure func(req *http.Request)) (*http.Response, error) {
+	send, err := rb.requestSender(ctx, apiKey, path, params, data, additionalConfigure)
+	if err != nil {
+		return nil, err
+	}
+
+	if rb.autoIdempotency() {
+		return sendWithRetries(ctx, params, os.Stderr, send)
+	}
+
+	return send(params)
+}
+
+// proxyDialer returns the proxy server requests are sent through: Proxy, or
+// else the proxy setting of the profile.
+func (rb *Base) proxyDialer() (*netproxy.Dialer, error) {
+	if rb.Proxy != nil || rb.Profile == nil {
+		return rb.Proxy, nil
+	}
+
+	if proxyURL := rb.Profile.GetProxy(); proxyURL != "" {
+		return netproxy.New(proxyURL)
+	}
+
+	return nil, nil
+}
+
+// requestSender returns a function sending a request to the Stripe API with
+// the headers of the parameters it's called with, so it can be retried.
+func (rb *Base) requestSender(ctx context.Context, apiKey, path string, params *RequestParameters, data string, additionalConfigure func(req *http.Request)) (func(*RequestParameters) (*http.Response, error), error) {
+	parsedBaseURL, err := url.Parse(rb.APIBaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	headers, err := parseCustomHeaders(params.headers)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy, err := rb.proxyDialer()
+	if err != nil {
+		return nil, err
+	}
+
+	client := &stripe.Client{
+		BaseURL: parsedBaseURL,
+		APIKey:  apiKey,
+		Verbose: rb.showHeaders,
+		Proxy:   proxy,
+	}
+
+	return func(params *RequestParameters) (*http.Response, error) {
+		configure := func(req *http.Request) {
+			rb.setIdempotencyHeader(req, params)
+			rb.setStripeAccountHeader(req, params)
+			rb.setVersionHeader(req, params)
+			for name, values := range headers {
+				req.Header[name] = values
+			}
+			if additionalConfigure != nil {
+				additionalConfigure(req)
+			}
+		}
+
+		if rb.cachesResponses() {
+			return sendCached(rb.responseCacheKey(apiKey, path, data, params), func(configureCache func(*http.Request)) (*http.Response, error) {
+				return client.PerformRequest(ctx, rb.Method, path, data, func(req *http.Request) {
+					configure(req)
+					configureCache(req)
+				})
+			})
+		}
+
+		return client.PerformRequest(ctx, rb.Method, path, data, configure)
+	}, nil
+}
+
 func compileRequestError(body []byte, statusCode int) RequestError {
 	type requestErrorContent struct {
-		Code string `json:"code"`
-		Type string `json:"type"`
+		Code    string `json:"code"`
+		Type    string `json:"type"`
+		Message string `json:"message"`
+		DocURL  string `json:"doc_url"`
 	}
 
 	type requestErrorBody struct {
@@ -266,6 +628,8 @@ func compileRequestError(body []byte, statusCode int) RequestError {
 		StatusCode: statusCode,
 		ErrorType:  errorBody.Content.Type,
 		ErrorCode:  errorBody.Content.Code,
+		Message:    errorBody.Content.Message,
+		DocURL:     errorBody.Content.DocURL,
 		Body:       string(body),
 	}
 }
@@ -345,7 +709,7 @@ func (rb *Base) buildMultiPartRequest(params *RequestParameters) (*bytes.Buffer,
 				return nil, "", err
 			}
 			defer file.Close()
-			part, err := mp.CreateFormFile(key, val)
+			part, err := mp.CreateFormFile(key, filepath.Base(val))
 			if err != nil {
 				return nil, "", err
 			}
@@ -402,23 +766,71 @@ func (rb *Base) setIdempotencyHeader(request *http.Request, params *RequestParam
 }
 
 func (rb *Base) setVersionHeader(request *http.Request, params *RequestParameters) {
-	if params.version != "" {
-		request.Header.Set("Stripe-Version", params.version)
+	if version := rb.stripeVersion(params); version != "" {
+		request.Header.Set("Stripe-Version", version)
 	}
 }
 
+// withBetas appends the beta version headers enabled with `stripe betas
+// enable` to an API version, like "2022-08-01; feature_beta=v1", unless the
+// version already sets them. Without a version, the betas apply to the
+// default API version of the account.
+func withBetas(version string, betas []string) string {
+	parts := []string{}
+	set := map[string]bool{}
+	for _, part := range strings.Split(version, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		parts = append(parts, part)
+		set[strings.SplitN(part, "=", 2)[0]] = true
+	}
+
+	for _, beta := range betas {
+		if !set[strings.SplitN(beta, "=", 2)[0]] {
+			parts = append(parts, beta)
+		}
+	}
+
+	return strings.Join(parts, "; ")
+}
+
 func (rb *Base) setStripeAccountHeader(request *http.Request, params *RequestParameters) {
 	if params.stripeAccount != "" {
 		request.Header.Set("Stripe-Account", params.stripeAccount)
 	}
 }
 
+// parseCustomHeaders parses the headers of --header, like "Name: value".
+// Headers set more than once are sent with every value.
+func parseCustomHeaders(headers []string) (http.Header, error) {
+	parsed := http.Header{}
+
+	for _, header := range headers {
+		parts := strings.SplitN(header, ":", 2)
+		name := strings.TrimSpace(parts[0])
+		if len(parts) != 2 || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("invalid header ‘%s’, expected a name and a value like \"Stripe-Context: acct_123\"", header)
+		}
+
+		parsed.Add(name, strings.TrimSpace(parts[1]))
+	}
+
+	return parsed, nil
+}
+
 func (rb *Base) confirmCommand() (bool, error) {
 	reader := bufio.NewReader(os.Stdin)
 	return rb.getUserConfirmation(reader)
 }
 
 func (rb *Base) getUserConfirmation(reader *bufio.Reader) (bool, error) {
+	// Snippets are printed without sending the request
+	if rb.printsSnippet() {
+		return true, nil
+	}
+
 	if _, needsConfirmation := confirmationCommands[rb.Method]; needsConfirmation && !rb.autoConfirm {
 		confirmationPrompt := fmt.Sprintf("Are you sure you want to perform the command: %s?\nEnter 'yes' to confirm: ", rb.Method)
 		fmt.Print(confirmationPrompt)
@@ -453,11 +865,11 @@ func createOrNormalizePath(arg string) (string, error) {
 }
 
 func normalizePath(path string) string {
-	if strings.HasPrefix(path, "/v1/") {
+	if strings.HasPrefix(path, "/v1/") || strings.HasPrefix(path, "/v2/") {
 		return path
 	}
 
-	if strings.HasPrefix(path, "v1/") {
+	if strings.HasPrefix(path, "v1/") || strings.HasPrefix(path, "v2/") {
 		return "/" + path
 	}
 
@@ -2,6 +2,7 @@ package requests
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"io/ioutil"
@@ -113,6 +114,61 @@ func TestMakeRequest(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestDo(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+
+		if r.URL.Path == "/v1/customers/cus_missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": {"type": "invalid_request_error", "message": "No such customer"}}`))
+			return
+		}
+
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/v1/customers", r.URL.Path)
+		require.Equal(t, "jenny@example.com", r.PostForm.Get("email"))
+		w.Write([]byte(`{"id": "cus_123", "email": "jenny@example.com"}`))
+	}))
+	defer ts.Close()
+
+	customer, err := Do(context.Background(), http.MethodPost, ts.URL, "sk_test_1234", "/v1/customers", []string{"email=jenny@example.com"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "cus_123", customer.Get("id").String())
+
+	_, err = Do(context.Background(), http.MethodGet, ts.URL, "sk_test_1234", "/v1/customers/cus_missing", nil, nil)
+	require.Error(t, err)
+	require.Equal(t, "invalid_request_error", err.(RequestError).ErrorType)
+}
+
+func TestMakeRequest_CustomHeaders(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+
+		require.Equal(t, "acct_123/acct_456", r.Header.Get("Stripe-Context"))
+		require.Equal(t, []string{"feature_a=v1", "feature_b=v2"}, r.Header.Values("Stripe-Beta"))
+	}))
+	defer ts.Close()
+
+	rb := Base{APIBaseURL: ts.URL}
+	rb.Method = http.MethodGet
+
+	params := &RequestParameters{}
+	params.AppendHeaders([]string{"Stripe-Context: acct_123/acct_456", "Stripe-Beta:feature_a=v1", "stripe-beta: feature_b=v2"})
+
+	_, err := rb.MakeRequest(context.Background(), "sk_test_1234", "/foo/bar", params, true)
+	require.NoError(t, err)
+}
+
+func TestMakeRequest_ErrOnInvalidHeader(t *testing.T) {
+	rb := Base{APIBaseURL: "http://localhost"}
+	rb.Method = http.MethodGet
+
+	params := &RequestParameters{headers: []string{"Stripe-Context acct_123"}}
+
+	_, err := rb.MakeRequest(context.Background(), "sk_test_1234", "/foo/bar", params, true)
+	require.EqualError(t, err, `invalid header ‘Stripe-Context acct_123’, expected a name and a value like "Stripe-Context: acct_123"`)
+}
+
 func TestMakeRequest_ErrOnStatus(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -156,6 +212,106 @@ func TestMakeRequest_ErrOnAPIKeyExpired(t *testing.T) {
 	require.Contains(t, err.Error(), "Request failed, status=401, body=")
 }
 
+func TestStreamRequest(t *testing.T) {
+	// Larger than the buffers the response is copied with
+	body := `{"object": "list", "data": [` + strings.Repeat(`{"id": "cus_123"},`, 10000) + `{"id": "cus_456"}]}`
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/customers", r.URL.Path)
+		require.Equal(t, "limit=100", r.URL.RawQuery)
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(body))
+	}))
+	defer ts.Close()
+
+	rb := Base{APIBaseURL: ts.URL}
+	rb.Method = http.MethodGet
+
+	var out bytes.Buffer
+	err := rb.streamRequest(context.Background(), "sk_test_1234", "/v1/customers", &RequestParameters{limit: "100"}, &out)
+	require.NoError(t, err)
+	require.Equal(t, body, out.String())
+}
+
+func TestStreamRequest_OnResponse(t *testing.T) {
+	body := `{"id": "cus_123", "object": "customer"}`
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(body))
+	}))
+	defer ts.Close()
+
+	var recorded []string
+	rb := Base{APIBaseURL: ts.URL}
+	rb.Method = http.MethodPost
+	rb.OnResponse = func(method, path, data string, statusCode int, respBody []byte) {
+		recorded = append(recorded, method, path, data, fmt.Sprint(statusCode), string(respBody))
+	}
+
+	var out bytes.Buffer
+	err := rb.streamRequest(context.Background(), "sk_test_1234", "/v1/customers", &RequestParameters{data: []string{"name=Jenny Rosen"}, expand: []string{"tax"}}, &out)
+	require.NoError(t, err)
+	require.Equal(t, body, out.String())
+	require.Equal(t, []string{"POST", "/v1/customers", "name=Jenny+Rosen&expand[]=tax", "200", body}, recorded)
+}
+
+func TestStreamRequest_ErrOnStatus(t *testing.T) {
+	body := `{"error": {"code": "resource_missing", "type": "invalid_request_error", "message": "No such customer: 'cus_123'", "doc_url": "https://stripe.com/docs/error-codes/resource-missing"}}`
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Request-Id", "req_123")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(body))
+	}))
+	defer ts.Close()
+
+	rb := Base{APIBaseURL: ts.URL}
+	rb.Method = http.MethodGet
+
+	var out bytes.Buffer
+	err := rb.streamRequest(context.Background(), "sk_test_1234", "/v1/customers/cus_123", &RequestParameters{}, &out)
+
+	var reqErr RequestError
+	require.ErrorAs(t, err, &reqErr)
+	require.Equal(t, http.StatusNotFound, reqErr.StatusCode)
+	require.Equal(t, "resource_missing", reqErr.ErrorCode)
+	require.Equal(t, "No such customer: 'cus_123'", reqErr.Message)
+	require.Equal(t, "https://stripe.com/docs/error-codes/resource-missing", reqErr.DocURL)
+	require.Equal(t, "req_123", reqErr.RequestID)
+	require.True(t, reqErr.Printed)
+	require.Equal(t, body, out.String())
+}
+
+func TestWarnResponseProblems(t *testing.T) {
+	var out bytes.Buffer
+	warnResponseProblems(&out, []byte(`{"id": "cus_123", "object": "customer", "balance": "0", "unknown_field": true}`))
+	require.Contains(t, out.String(), "the response doesn't match the schemas of API version")
+	require.Contains(t, out.String(), "  - ‘customer.balance’ is a string, expected integer\n")
+	require.Contains(t, out.String(), "  - unexpected field ‘customer.unknown_field’\n")
+
+	out.Reset()
+	warnResponseProblems(&out, []byte(`{"id": "cus_123", "object": "customer", "balance": 0}`))
+	require.Empty(t, out.String())
+}
+
+func TestStreamRequest_ErrOnAPIKeyExpired(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error": {"code": "api_key_expired", "type": "invalid_request_error"}}`))
+	}))
+	defer ts.Close()
+
+	rb := Base{APIBaseURL: ts.URL}
+	rb.Method = http.MethodGet
+
+	var out bytes.Buffer
+	err := rb.streamRequest(context.Background(), "sk_test_1234", "/v1/customers", &RequestParameters{}, &out)
+	require.True(t, IsAPIKeyExpiredError(err))
+	require.Empty(t, out.String())
+}
+
 func TestMakeMultiPartRequest(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -245,6 +401,8 @@ func TestNormalizePath(t *testing.T) {
 	require.Equal(t, "/v1/charges", normalizePath("v1/charges"))
 	require.Equal(t, "/v1/charges", normalizePath("/charges"))
 	require.Equal(t, "/v1/charges", normalizePath("charges"))
+	require.Equal(t, "/v2/billing/meter_events", normalizePath("/v2/billing/meter_events"))
+	require.Equal(t, "/v2/billing/meter_events", normalizePath("v2/billing/meter_events"))
 }
 
 func TestCreateOrNormalizePath(t *testing.T) {
@@ -297,3 +455,11 @@ func TestIsAPIKeyExpiredError(t *testing.T) {
 		require.False(t, IsAPIKeyExpiredError(fmt.Errorf("other")))
 	})
 }
+
+func TestWithBetas(t *testing.T) {
+	require.Equal(t, "", withBetas("", nil))
+	require.Equal(t, "2022-08-01", withBetas("2022-08-01", nil))
+	require.Equal(t, "feature_beta=v1", withBetas("", []string{"feature_beta=v1"}))
+	require.Equal(t, "2022-08-01; feature_beta=v1; other_beta=v2", withBetas("2022-08-01", []string{"feature_beta=v1", "other_beta=v2"}))
+	require.Equal(t, "2022-08-01; feature_beta=v3; other_beta=v2", withBetas("2022-08-01;feature_beta=v3", []string{"feature_beta=v1", "other_beta=v2"}))
+}
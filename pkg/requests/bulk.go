@@ -0,0 +1,343 @@
+package requests
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+)
+
+// defaultConcurrency is the number of requests of --bulk sent at the same
+// time, which stays under the rate limit of test mode
+const defaultConcurrency = 4
+
+// bulkRow is a request of --bulk, read from a line of the file
+type bulkRow struct {
+	line int
+	// data are the form-encoded fields of the request
+	data []string
+	// fields are the top-level values of the line, for the {column}
+	// placeholders of the path
+	fields map[string]string
+}
+
+// bulkResult is the outcome of the request of a row, written to the results
+// file
+type bulkResult struct {
+	line   int
+	status int
+	id     string
+	err    error
+}
+
+var placeholderRegexp = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// readBulkRows reads the requests of a --bulk file: NDJSON for .ndjson and
+// .jsonl files, and CSV otherwise
+func readBulkRows(path string) ([]bulkRow, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ndjson", ".jsonl":
+		return readNDJSONRows(f)
+	default:
+		return readCSVRows(f)
+	}
+}
+
+// readCSVRows reads the requests of a CSV file, whose header line has the
+// names of the parameters, like email or metadata[order_id]. Empty cells
+// aren't sent.
+func readCSVRows(r io.Reader) ([]bulkRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("the CSV file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i, name := range header {
+		header[i] = strings.TrimSpace(name)
+	}
+
+	var rows []bulkRow
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row := bulkRow{line: line, fields: make(map[string]string)}
+		for i, value := range record {
+			if value == "" || header[i] == "" {
+				continue
+			}
+			row.data = append(row.data, header[i]+"="+value)
+			row.fields[header[i]] = value
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// readNDJSONRows reads the requests of an NDJSON file, one JSON object per
+// line, flattened like the JSON body of --json-body
+func readNDJSONRows(r io.Reader) ([]bulkRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+
+	var rows []bulkRow
+
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		data, err := jsonToFormData(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+
+		var obj map[string]interface{}
+		dec := json.NewDecoder(bytes.NewReader(text))
+		dec.UseNumber()
+		if err := dec.Decode(&obj); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+
+		row := bulkRow{line: line, data: data, fields: make(map[string]string)}
+		for key, value := range obj {
+			switch v := value.(type) {
+			case string, json.Number, bool:
+				row.fields[key] = fmt.Sprintf("%v", v)
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// expandPath replaces the {column} placeholders of a path with the values of
+// a row, e.g. /v1/customers/{id}, and removes these values from its data
+func expandPath(path string, row bulkRow) (string, []string, error) {
+	used := make(map[string]bool)
+
+	var missing string
+	expanded := placeholderRegexp.ReplaceAllStringFunc(path, func(placeholder string) string {
+		name := strings.Trim(placeholder, "{}")
+		value, ok := row.fields[name]
+		if !ok && missing == "" {
+			missing = name
+		}
+		used[name] = true
+		return value
+	})
+	if missing != "" {
+		return "", nil, fmt.Errorf("no value for {%s} in the path", missing)
+	}
+
+	var data []string
+	for _, datum := range row.data {
+		name := strings.SplitN(datum, "=", 2)[0]
+		if !used[name] {
+			data = append(data, datum)
+		}
+	}
+
+	return expanded, data, nil
+}
+
+// bulkResultsPath returns the default results file of a --bulk file, next to
+// it with a .results.csv extension
+func bulkResultsPath(path string) string {
+	return strings.TrimSuffix(path, filepath.Ext(path)) + ".results.csv"
+}
+
+// runBulk sends a request for each row of the --bulk file, with the
+// parameters of the command and those of the row, --concurrency at a time.
+// Requests are retried on rate limits and errors like with
+// --auto-idempotency, and their outcome is written to the results file. The
+// rate limiter of the client paces them, and pauses them all when one of them
+// is rate limited.
+func (rb *Base) runBulk(ctx context.Context, apiKey, path string, params *RequestParameters, out io.Writer) error {
+	if rb.concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+	if params.jsonBody != "" || params.filePath != "" {
+		return fmt.Errorf("--bulk cannot be used with --json-body or --file-path")
+	}
+
+	rows, err := readBulkRows(rb.bulk)
+	if err != nil {
+		return fmt.Errorf("could not read %s: %w", rb.bulk, err)
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("%s has no requests", rb.bulk)
+	}
+
+	resultsPath := rb.bulkResults
+	if resultsPath == "" {
+		resultsPath = bulkResultsPath(rb.bulk)
+	}
+
+	resultsFile, err := os.Create(resultsPath)
+	if err != nil {
+		return err
+	}
+	defer resultsFile.Close()
+
+	results := csv.NewWriter(resultsFile)
+	results.Write([]string{"line", "status", "id", "error"}) // #nosec G104
+
+	color := ansi.Color(out)
+
+	var mu sync.Mutex
+	succeeded := 0
+	record := func(result bulkResult) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		status, message := "", ""
+		if result.status != 0 {
+			status = strconv.Itoa(result.status)
+		}
+		if result.err != nil {
+			message = result.err.Error()
+			fmt.Fprintf(out, "%s line %d: %s\n", color.Red("✘"), result.line, message)
+		} else {
+			succeeded++
+			fmt.Fprintf(out, "%s line %d: %s\n", color.Green("✔"), result.line, result.id)
+		}
+
+		results.Write([]string{strconv.Itoa(result.line), status, result.id, message}) // #nosec G104
+		results.Flush()
+	}
+
+	jobs := make(chan bulkRow)
+	var wg sync.WaitGroup
+
+	for i := 0; i < rb.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for row := range jobs {
+				record(rb.sendBulkRow(ctx, apiKey, path, params, row))
+			}
+		}()
+	}
+
+	for _, row := range rows {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- row
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := results.Error(); err != nil {
+		return fmt.Errorf("could not write %s: %w", resultsPath, err)
+	}
+
+	fmt.Fprintf(out, "\n%d of %d requests succeeded, results saved to %s\n", succeeded, len(rows), resultsPath)
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if succeeded < len(rows) {
+		return fmt.Errorf("%d request(s) failed, see %s", len(rows)-succeeded, resultsPath)
+	}
+
+	return nil
+}
+
+// sendBulkRow sends the request of a row. The idempotency key of the request
+// is derived from --idempotency when it's set, so the file can be sent again
+// without repeating the requests that succeeded.
+func (rb *Base) sendBulkRow(ctx context.Context, apiKey, path string, params *RequestParameters, row bulkRow) bulkResult {
+	result := bulkResult{line: row.line}
+
+	rowPath, rowData, err := expandPath(path, row)
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	rowParams := *params
+	rowParams.data = append(append([]string{}, params.data...), rowData...)
+	if params.idempotency != "" {
+		rowParams.idempotency = fmt.Sprintf("%s-%d", params.idempotency, row.line)
+	}
+
+	data, configure, err := rb.buildRequestBody(rowPath, &rowParams)
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	send, err := rb.requestSender(ctx, apiKey, rowPath, &rowParams, data, configure)
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	resp, err := sendWithRetries(ctx, &rowParams, ioutil.Discard, send)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	result.status = resp.StatusCode
+	result.id = gjson.GetBytes(body, "id").String()
+
+	if resp.StatusCode >= 300 {
+		message := gjson.GetBytes(body, "error.message").String()
+		if message == "" {
+			message = http.StatusText(resp.StatusCode)
+		}
+		result.err = fmt.Errorf("status=%d: %s", resp.StatusCode, message)
+	}
+
+	return result
+}
@@ -0,0 +1,158 @@
+package requests
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCSVRows(t *testing.T) {
+	rows, err := readCSVRows(strings.NewReader("email, name,metadata[source]\njenny@example.com,Jenny,\"import, 2024\"\njohn@example.com,,\n"))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	require.Equal(t, 2, rows[0].line)
+	require.Equal(t, []string{"email=jenny@example.com", "name=Jenny", "metadata[source]=import, 2024"}, rows[0].data)
+	require.Equal(t, 3, rows[1].line)
+	require.Equal(t, []string{"email=john@example.com"}, rows[1].data)
+
+	_, err = readCSVRows(strings.NewReader(""))
+	require.EqualError(t, err, "the CSV file is empty")
+}
+
+func TestReadNDJSONRows(t *testing.T) {
+	rows, err := readNDJSONRows(strings.NewReader(`{"id": "cus_123", "metadata": {"plan": "pro"}}
+
+{"id": "cus_456", "balance": 100}
+`))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	require.Equal(t, 1, rows[0].line)
+	require.Equal(t, []string{"id=cus_123", "metadata[plan]=pro"}, rows[0].data)
+	require.Equal(t, "cus_123", rows[0].fields["id"])
+	require.Equal(t, 3, rows[1].line)
+	require.Equal(t, "100", rows[1].fields["balance"])
+
+	_, err = readNDJSONRows(strings.NewReader("{\"id\": \"cus_123\"}\n[1]\n"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "line 2:")
+}
+
+func TestExpandPath(t *testing.T) {
+	row := bulkRow{
+		line:   2,
+		data:   []string{"id=cus_123", "name=Jenny"},
+		fields: map[string]string{"id": "cus_123", "name": "Jenny"},
+	}
+
+	path, data, err := expandPath("/v1/customers/{id}", row)
+	require.NoError(t, err)
+	require.Equal(t, "/v1/customers/cus_123", path)
+	require.Equal(t, []string{"name=Jenny"}, data)
+
+	_, _, err = expandPath("/v1/customers/{customer}", row)
+	require.EqualError(t, err, "no value for {customer} in the path")
+}
+
+func TestRunBulk(t *testing.T) {
+	var delays []time.Duration
+	noSleep(t, &delays)
+
+	var mu sync.Mutex
+	var bodies []string
+	var keys []string
+	rateLimited := false
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/customers", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+
+		email := r.PostForm.Get("email")
+		switch {
+		case email == "rate@example.com" && !rateLimited:
+			rateLimited = true
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case email == "":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": {"message": "Missing email"}}`))
+		default:
+			bodies = append(bodies, r.PostForm.Encode())
+			w.Write([]byte(`{"id": "cus_` + strings.Split(email, "@")[0] + `"}`))
+		}
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	input := filepath.Join(dir, "customers.csv")
+	require.NoError(t, ioutil.WriteFile(input, []byte("email,name\njenny@example.com,Jenny\nrate@example.com,\n,Nobody\n"), 0600))
+
+	rb := Base{Method: http.MethodPost, Cmd: &cobra.Command{}}
+	rb.InitFlags()
+	rb.APIBaseURL = ts.URL
+	require.NoError(t, rb.Cmd.Flags().Set("bulk", input))
+	require.NoError(t, rb.Cmd.Flags().Set("concurrency", "2"))
+	require.NoError(t, rb.Cmd.Flags().Set("idempotency", "import"))
+
+	params := &RequestParameters{data: []string{"metadata[source]=import"}, idempotency: "import"}
+
+	var out bytes.Buffer
+	err := rb.runBulk(context.Background(), "sk_test_1234", "/v1/customers", params, &out)
+	require.EqualError(t, err, "1 request(s) failed, see "+filepath.Join(dir, "customers.results.csv"))
+	require.Contains(t, out.String(), "line 2: cus_jenny")
+	require.Contains(t, out.String(), "line 4: status=400: Missing email")
+	require.Contains(t, out.String(), "2 of 3 requests succeeded")
+
+	sort.Strings(bodies)
+	require.Equal(t, []string{
+		"email=jenny%40example.com&metadata%5Bsource%5D=import&name=Jenny",
+		"email=rate%40example.com&metadata%5Bsource%5D=import",
+	}, bodies)
+
+	require.Contains(t, keys, "import-2")
+	require.Contains(t, keys, "import-3")
+	require.Contains(t, keys, "import-4")
+	require.Contains(t, delays, time.Second)
+
+	results, err := ioutil.ReadFile(filepath.Join(dir, "customers.results.csv"))
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(results)), "\n")
+	require.Equal(t, "line,status,id,error", lines[0])
+	sort.Strings(lines[1:])
+	require.Equal(t, []string{
+		"2,200,cus_jenny,",
+		"3,200,cus_rate,",
+		"4,400,,status=400: Missing email",
+	}, lines[1:])
+}
+
+func TestRunBulkValidation(t *testing.T) {
+	rb := Base{Method: http.MethodPost, Cmd: &cobra.Command{}}
+	rb.InitFlags()
+	require.NoError(t, rb.Cmd.Flags().Set("bulk", "customers.csv"))
+	require.NoError(t, rb.Cmd.Flags().Set("concurrency", "0"))
+
+	err := rb.runBulk(context.Background(), "sk_test_1234", "/v1/customers", &RequestParameters{}, ioutil.Discard)
+	require.EqualError(t, err, "--concurrency must be at least 1")
+
+	require.NoError(t, rb.Cmd.Flags().Set("concurrency", "1"))
+	err = rb.runBulk(context.Background(), "sk_test_1234", "/v1/customers", &RequestParameters{jsonBody: "{}"}, ioutil.Discard)
+	require.EqualError(t, err, "--bulk cannot be used with --json-body or --file-path")
+}
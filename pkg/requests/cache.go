@@ -0,0 +1,140 @@
+package requests
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+)
+
+// cacheHitHeader is set on the responses served from the cache of --cache
+const cacheHitHeader = "Stripe-CLI-Cache"
+
+// responseCacheDir returns the directory of the responses cached with
+// --cache, and is replaced in tests
+var responseCacheDir = func() string {
+	cfg := &config.Config{}
+	return filepath.Join(cfg.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")), "response-cache")
+}
+
+// cachedResponse is a response cached with its ETag
+type cachedResponse struct {
+	ETag        string `json:"etag"`
+	ContentType string `json:"content_type"`
+	Body        string `json:"body"`
+}
+
+// cachesResponses returns whether the responses of GET requests are cached
+// with --cache
+func (rb *Base) cachesResponses() bool {
+	return rb.cache && rb.Method == http.MethodGet
+}
+
+// responseCacheKey identifies a request in the cache: its API key, URL,
+// parameters and headers, so that responses aren't shared between accounts
+// or API versions
+func (rb *Base) responseCacheKey(apiKey, path, data string, params *RequestParameters) string {
+	h := sha256.New()
+	for _, part := range append([]string{
+		apiKey,
+		rb.Method,
+		rb.APIBaseURL + path,
+		data,
+		params.stripeAccount,
+		rb.stripeVersion(params),
+	}, params.headers...) {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// sendCached sends a request with the ETag of its cached response in
+// If-None-Match, and returns the cached response when the API answers that it
+// didn't change with a 304. Responses with an ETag are cached.
+func sendCached(key string, send func(configure func(*http.Request)) (*http.Response, error)) (*http.Response, error) {
+	path := filepath.Join(responseCacheDir(), key+".json")
+
+	var cached *cachedResponse
+	if data, err := ioutil.ReadFile(path); err == nil {
+		cached = &cachedResponse{}
+		if err := json.Unmarshal(data, cached); err != nil {
+			cached = nil
+		}
+	}
+
+	resp, err := send(func(req *http.Request) {
+		if cached != nil && cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		resp.Body.Close()
+
+		resp.StatusCode = http.StatusOK
+		resp.Status = "200 OK"
+		resp.Header.Set("Content-Type", cached.ContentType)
+		resp.Header.Set(cacheHitHeader, "hit")
+		resp.Body = ioutil.NopCloser(strings.NewReader(cached.Body))
+		resp.ContentLength = int64(len(cached.Body))
+
+		return resp, nil
+	}
+
+	etag := resp.Header.Get("ETag")
+	if resp.StatusCode != http.StatusOK || etag == "" {
+		return resp, nil
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	if err := saveCachedResponse(path, &cachedResponse{
+		ETag:        etag,
+		ContentType: resp.Header.Get("Content-Type"),
+		Body:        string(body),
+	}); err != nil {
+		log.WithFields(log.Fields{
+			"prefix": "requests.sendCached",
+		}).Debugf("Could not cache the response: %v", err)
+	}
+
+	return resp, nil
+}
+
+func saveCachedResponse(path string, cached *cachedResponse) error {
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return err
+	}
+
+	// Responses can have personal data, so only the user can read them
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,65 @@
+package requests
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendCached(t *testing.T) {
+	dir := t.TempDir()
+	defaultDir := responseCacheDir
+	responseCacheDir = func() string { return dir }
+	defer func() { responseCacheDir = defaultDir }()
+
+	var ifNoneMatch []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ifNoneMatch = append(ifNoneMatch, r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == `W/"abc"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `W/"abc"`)
+		w.Write([]byte(`{"object": "balance"}`))
+	}))
+	defer ts.Close()
+
+	rb := Base{Method: http.MethodGet, Cmd: &cobra.Command{}}
+	rb.InitFlags()
+	rb.APIBaseURL = ts.URL
+	require.NoError(t, rb.Cmd.Flags().Set("cache", "true"))
+
+	for i := 0; i < 2; i++ {
+		resp, err := rb.sendRequest(context.Background(), "sk_test_1234", "/v1/balance", &RequestParameters{}, "", nil)
+		require.NoError(t, err)
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, `{"object": "balance"}`, string(body))
+		require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	}
+	require.Equal(t, []string{"", `W/"abc"`}, ifNoneMatch)
+
+	// Another account doesn't share the cached response
+	resp, err := rb.sendRequest(context.Background(), "sk_test_5678", "/v1/balance", &RequestParameters{}, "", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, "", ifNoneMatch[2])
+}
+
+func TestSendCachedDisabled(t *testing.T) {
+	rb := Base{Method: http.MethodGet, Cmd: &cobra.Command{}}
+	rb.InitFlags()
+	require.False(t, rb.cachesResponses())
+
+	rb = Base{Method: http.MethodPost, Cmd: &cobra.Command{}, cache: true}
+	require.False(t, rb.cachesResponses())
+}
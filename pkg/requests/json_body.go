@@ -0,0 +1,136 @@
+package requests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+)
+
+// isV2Path returns whether a path is an endpoint of the v2 API, whose requests
+// are JSON encoded rather than form encoded
+func isV2Path(path string) bool {
+	return strings.HasPrefix(path, "/v2/")
+}
+
+// loadJSONBody returns the JSON body of --json-body: inline JSON, a file with
+// @path, or stdin with -
+func loadJSONBody(value string, stdin io.Reader) ([]byte, error) {
+	var body []byte
+	var err error
+
+	switch {
+	case value == "-":
+		body, err = ioutil.ReadAll(stdin)
+	case strings.HasPrefix(value, "@"):
+		body, err = ioutil.ReadFile(strings.TrimPrefix(value, "@"))
+	default:
+		body = []byte(value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the JSON body: %v", err)
+	}
+
+	if !json.Valid(body) {
+		return nil, fmt.Errorf("the JSON body isn't valid JSON")
+	}
+
+	return bytes.TrimSpace(body), nil
+}
+
+// jsonToFormData flattens a JSON object into the form-encoded fields of the
+// v1 API, like metadata[order_id]=6735 and items[0][price]=price_123. Nulls
+// and empty objects are sent as empty values, which unset them.
+func jsonToFormData(body []byte) ([]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var obj map[string]interface{}
+	if err := dec.Decode(&obj); err != nil {
+		return nil, fmt.Errorf("the JSON body must be an object: %v", err)
+	}
+
+	var data []string
+	flattenJSON("", obj, &data)
+
+	return data, nil
+}
+
+func flattenJSON(key string, value interface{}, data *[]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 && key != "" {
+			*data = append(*data, key+"=")
+			return
+		}
+
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			if key == "" {
+				flattenJSON(k, v[k], data)
+			} else {
+				flattenJSON(key+"["+k+"]", v[k], data)
+			}
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			*data = append(*data, key+"=")
+			return
+		}
+
+		for i, item := range v {
+			flattenJSON(fmt.Sprintf("%s[%d]", key, i), item, data)
+		}
+	case nil:
+		*data = append(*data, key+"=")
+	default:
+		*data = append(*data, fmt.Sprintf("%s=%v", key, v))
+	}
+}
+
+// buildRequestBody returns the data of a request and how to configure it.
+// File uploads are sent as multipart/form-data.
+// The POST requests to the v2 API are sent as JSON. Otherwise the JSON body of
+// --json-body is converted to form-encoded fields, followed by those of
+// --data.
+func (rb *Base) buildRequestBody(path string, params *RequestParameters) (string, func(*http.Request), error) {
+	if rb.isFileUpload(path, params) {
+		return rb.buildUploadBody(path, params)
+	}
+
+	if rb.Method == http.MethodPost && isV2Path(path) {
+		return rb.buildV2RequestBody(params)
+	}
+
+	if params.jsonBody == "" {
+		data, err := rb.buildDataForRequest(params)
+		return data, nil, err
+	}
+
+	body, err := loadJSONBody(params.jsonBody, os.Stdin)
+	if err != nil {
+		return "", nil, err
+	}
+
+	data, err := jsonToFormData(body)
+	if err != nil {
+		return "", nil, err
+	}
+
+	withJSON := *params
+	withJSON.data = append(data, params.data...)
+
+	encoded, err := rb.buildDataForRequest(&withJSON)
+
+	return encoded, nil, err
+}
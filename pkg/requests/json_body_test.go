@@ -0,0 +1,109 @@
+package requests
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONToFormData(t *testing.T) {
+	data, err := jsonToFormData([]byte(`{
+		"amount": 2000,
+		"confirm": true,
+		"currency": "usd",
+		"description": null,
+		"items": [{"price": "price_123", "quantity": 2}],
+		"metadata": {"order_id": "6735", "tags": []},
+		"shipping": {}
+	}`))
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"amount=2000",
+		"confirm=true",
+		"currency=usd",
+		"description=",
+		"items[0][price]=price_123",
+		"items[0][quantity]=2",
+		"metadata[order_id]=6735",
+		"metadata[tags]=",
+		"shipping=",
+	}, data)
+
+	_, err = jsonToFormData([]byte(`["not", "an", "object"]`))
+	require.Error(t, err)
+}
+
+func TestLoadJSONBody(t *testing.T) {
+	body, err := loadJSONBody(`{"a": "b"}`, nil)
+	require.NoError(t, err)
+	require.Equal(t, `{"a": "b"}`, string(body))
+
+	body, err = loadJSONBody("-", strings.NewReader("{\"a\": \"b\"}\n"))
+	require.NoError(t, err)
+	require.Equal(t, `{"a": "b"}`, string(body))
+
+	file := filepath.Join(t.TempDir(), "body.json")
+	require.NoError(t, ioutil.WriteFile(file, []byte(`{"a": "b"}`), 0644))
+	body, err = loadJSONBody("@"+file, nil)
+	require.NoError(t, err)
+	require.Equal(t, `{"a": "b"}`, string(body))
+
+	_, err = loadJSONBody(`{"a": `, nil)
+	require.EqualError(t, err, "the JSON body isn't valid JSON")
+}
+
+func TestMakeRequest_JSONBody(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+
+		reqBody, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		require.Equal(t, "application/x-www-form-urlencoded", r.Header.Get("Content-Type"))
+		require.Equal(t, "metadata[a]=b&name=Jenny", string(reqBody))
+	}))
+	defer ts.Close()
+
+	rb := Base{APIBaseURL: ts.URL}
+	rb.Method = http.MethodPost
+
+	params := &RequestParameters{
+		data:     []string{"name=Jenny"},
+		jsonBody: `{"metadata": {"a": "b"}}`,
+	}
+
+	_, err := rb.MakeRequest(context.Background(), "sk_test_1234", "/v1/customers", params, true)
+	require.NoError(t, err)
+}
+
+func TestMakeRequest_JSONBodyV2(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+
+		reqBody, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		require.Equal(t, "/v2/billing/meter_events", r.URL.Path)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.Equal(t, `{"event_name": "api_call", "payload": {"value": "1"}}`, string(reqBody))
+	}))
+	defer ts.Close()
+
+	rb := Base{APIBaseURL: ts.URL}
+	rb.Method = http.MethodPost
+
+	params := &RequestParameters{jsonBody: `{"event_name": "api_call", "payload": {"value": "1"}}`}
+
+	_, err := rb.MakeRequest(context.Background(), "sk_test_1234", "/v2/billing/meter_events", params, true)
+	require.NoError(t, err)
+
+	params.data = []string{"identifier=abc"}
+	_, err = rb.MakeRequest(context.Background(), "sk_test_1234", "/v2/billing/meter_events", params, true)
+	require.EqualError(t, err, "--data and --expand cannot be used with --json-body for v2 endpoints, set them in the JSON body")
+}
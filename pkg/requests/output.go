@@ -0,0 +1,383 @@
+package requests
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/query"
+)
+
+// Output formats of --output. JSON is printed by default.
+const (
+	outputJSON   = "json"
+	outputYAML   = "yaml"
+	outputTable  = "table"
+	outputCSV    = "csv"
+	outputNDJSON = "ndjson"
+)
+
+var outputFormats = []string{outputJSON, outputYAML, outputTable, outputCSV, outputNDJSON}
+
+// preferredColumns are the fields shown first in tables and CSV, when the
+// objects have them
+var preferredColumns = []string{"id", "name", "email", "description", "amount", "currency", "status", "customer", "created"}
+
+// maxTableColumns is the number of columns tables are filled up to, when the
+// objects have few of the preferred columns
+const maxTableColumns = 6
+
+// maxTableCellWidth is the width table cells are truncated to
+const maxTableCellWidth = 40
+
+// validateOutput returns an error if the output format isn't supported
+func validateOutput(output string) error {
+	if output == "" {
+		return nil
+	}
+
+	for _, format := range outputFormats {
+		if strings.ToLower(output) == format {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unsupported output %q, expected one of %s", output, strings.Join(outputFormats, ", "))
+}
+
+// outputFormat returns the output format of --output, json by default
+func (rb *Base) outputFormat() string {
+	if rb.output == "" {
+		return outputJSON
+	}
+
+	return strings.ToLower(rb.output)
+}
+
+// compileQuery returns the query of --query, or nil if it isn't set
+func (rb *Base) compileQuery() (*query.Query, error) {
+	if rb.query == "" {
+		return nil, nil
+	}
+
+	return query.Compile(rb.query)
+}
+
+// writeResult prints a response, or the result of --query on it, in an output
+// format
+func (rb *Base) writeResult(out io.Writer, body []byte, format string) error {
+	q, err := rb.compileQuery()
+	if err != nil {
+		return err
+	}
+
+	if q != nil {
+		body, err = q.Search(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	if format == outputJSON {
+		return ansi.ColorizeJSONStream(out, bytes.NewReader(body), rb.DarkStyle)
+	}
+
+	return writeOutput(out, body, format)
+}
+
+// writeOutput prints a response in an output format other than JSON. The
+// objects of lists are printed as the rows of tables and CSV, and one per
+// line with ndjson.
+func writeOutput(out io.Writer, body []byte, format string) error {
+	switch format {
+	case outputYAML:
+		return writeYAML(out, body)
+	case outputNDJSON:
+		objects, isList := listObjects(body)
+		if !isList {
+			objects = []json.RawMessage{body}
+		}
+		for _, obj := range objects {
+			if err := writeListObject(out, obj, true, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	case outputTable, outputCSV:
+		objects, isList := listObjects(body)
+
+		var rows []row
+		for _, obj := range objects {
+			r, err := parseRow(obj)
+			if err != nil {
+				return err
+			}
+			rows = append(rows, r)
+		}
+
+		if !isList {
+			r, err := parseRow(body)
+			if err != nil {
+				return err
+			}
+
+			if format == outputTable {
+				return writeObjectTable(out, r)
+			}
+			rows = []row{r}
+		}
+
+		if format == outputTable {
+			return writeTable(out, rows)
+		}
+		return writeCSV(out, rows)
+	default:
+		_, err := out.Write(body)
+		return err
+	}
+}
+
+// listObjects returns the objects of a list, of search results, or of the
+// JSON array printed by paginate, and whether the body is one of them
+func listObjects(body []byte) ([]json.RawMessage, bool) {
+	trimmed := bytes.TrimSpace(body)
+
+	if bytes.HasPrefix(trimmed, []byte("[")) {
+		var objects []json.RawMessage
+		if err := json.Unmarshal(trimmed, &objects); err == nil {
+			return objects, true
+		}
+	}
+
+	var page struct {
+		Object string            `json:"object"`
+		Data   []json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(trimmed, &page); err == nil && (page.Object == "list" || page.Object == "search_result") {
+		return page.Data, true
+	}
+
+	return nil, false
+}
+
+// writeYAML prints a JSON body as YAML, keeping the order of its fields
+func writeYAML(out io.Writer, body []byte) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(body, &doc); err != nil {
+		return err
+	}
+	blockStyle(&doc)
+
+	enc := yaml.NewEncoder(out)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+
+	return enc.Close()
+}
+
+// blockStyle resets the JSON flow and quoting styles of the nodes, so they're
+// printed in the usual YAML block style. Strings that would otherwise be read
+// as another type are still quoted.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// row is an object printed as a row of a table or CSV, with the fields in the
+// order of the response
+type row struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func parseRow(obj json.RawMessage) (row, error) {
+	r := row{values: map[string]interface{}{}}
+
+	dec := json.NewDecoder(bytes.NewReader(obj))
+	dec.UseNumber()
+
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return r, fmt.Errorf("tables and CSV can only be printed for objects and lists of objects")
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return r, err
+		}
+		key := tok.(string)
+
+		var value interface{}
+		if err := dec.Decode(&value); err != nil {
+			return r, err
+		}
+
+		r.keys = append(r.keys, key)
+		r.values[key] = value
+	}
+
+	return r, nil
+}
+
+// isScalar returns whether a value can be printed in a cell
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	default:
+		return true
+	}
+}
+
+// selectColumns returns the columns of rows: the preferred columns the rows
+// have, then their other fields that are never objects or arrays, until there
+// are limit columns, or all of them if limit is 0
+func selectColumns(rows []row, limit int) []string {
+	var order []string
+	scalar := map[string]bool{}
+	present := map[string]bool{}
+
+	for _, r := range rows {
+		for _, key := range r.keys {
+			value := r.values[key]
+
+			if _, seen := scalar[key]; !seen {
+				order = append(order, key)
+				scalar[key] = true
+			}
+			if !isScalar(value) {
+				scalar[key] = false
+			} else if value != nil {
+				present[key] = true
+			}
+		}
+	}
+
+	var columns []string
+	selected := map[string]bool{}
+	for _, key := range preferredColumns {
+		if scalar[key] && present[key] {
+			columns = append(columns, key)
+			selected[key] = true
+		}
+	}
+
+	for _, key := range order {
+		if limit > 0 && len(columns) >= limit {
+			break
+		}
+		if scalar[key] && present[key] && !selected[key] && key != "object" {
+			columns = append(columns, key)
+		}
+	}
+
+	return columns
+}
+
+// cell returns the text of a value
+func cell(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+
+	return fmt.Sprint(value)
+}
+
+func truncateCell(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+
+	runes := []rune(text)
+	if len(runes) <= maxTableCellWidth {
+		return text
+	}
+
+	return string(runes[:maxTableCellWidth-1]) + "…"
+}
+
+// writeTable prints the objects of a list as a table, with a column for each
+// of their preferred fields
+func writeTable(out io.Writer, rows []row) error {
+	columns := selectColumns(rows, maxTableColumns)
+	if len(columns) == 0 {
+		_, err := fmt.Fprintln(out, "No objects")
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = strings.ToUpper(column)
+	}
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+
+	for _, r := range rows {
+		cells := make([]string, len(columns))
+		for i, column := range columns {
+			cells[i] = truncateCell(cell(r.values[column]))
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+
+	return w.Flush()
+}
+
+// writeObjectTable prints a single object as a table of its fields and
+// values. Objects and arrays are printed as JSON.
+func writeObjectTable(out io.Writer, r row) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "FIELD\tVALUE")
+	for _, key := range r.keys {
+		value := r.values[key]
+
+		text := cell(value)
+		if !isScalar(value) {
+			data, err := json.Marshal(value)
+			if err != nil {
+				return err
+			}
+			text = string(data)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\n", key, truncateCell(text))
+	}
+
+	return w.Flush()
+}
+
+// writeCSV prints objects as CSV, with a column for each of their fields that
+// are never objects or arrays
+func writeCSV(out io.Writer, rows []row) error {
+	columns := selectColumns(rows, 0)
+
+	w := csv.NewWriter(out)
+	if err := w.Write(columns); err != nil {
+		return err
+	}
+
+	for _, r := range rows {
+		cells := make([]string, len(columns))
+		for i, column := range columns {
+			cells[i] = cell(r.values[column])
+		}
+		if err := w.Write(cells); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+
+	return w.Error()
+}
@@ -0,0 +1,107 @@
+package requests
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testCustomerList = `{
+  "object": "list",
+  "url": "/v1/customers",
+  "has_more": false,
+  "data": [
+    {"id": "cus_1", "object": "customer", "email": "jenny@example.com", "name": "Jenny Rosen", "created": 1680000000, "metadata": {"plan": "pro"}, "description": "A customer whose description is much too long for a table"},
+    {"id": "cus_2", "object": "customer", "email": "bob@example.com", "name": "Bob, Jr.", "created": 1680000100, "metadata": {}, "description": null}
+  ]
+}`
+
+func TestValidateOutput(t *testing.T) {
+	require.NoError(t, validateOutput(""))
+	require.NoError(t, validateOutput("YAML"))
+	require.EqualError(t, validateOutput("xml"), `unsupported output "xml", expected one of json, yaml, table, csv, ndjson`)
+}
+
+func TestWriteOutputTable(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeOutput(&out, []byte(testCustomerList), outputTable))
+
+	require.Equal(t, `ID     NAME         EMAIL              DESCRIPTION                               CREATED
+cus_1  Jenny Rosen  jenny@example.com  A customer whose description is much to…  1680000000
+cus_2  Bob, Jr.     bob@example.com                                              1680000100
+`, out.String())
+}
+
+func TestWriteOutputObjectTable(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeOutput(&out, []byte(`{"id": "cus_1", "object": "customer", "metadata": {"plan": "pro"}, "deleted": true}`), outputTable))
+
+	require.Equal(t, `FIELD     VALUE
+id        cus_1
+object    customer
+metadata  {"plan":"pro"}
+deleted   true
+`, out.String())
+}
+
+func TestWriteOutputCSV(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeOutput(&out, []byte(testCustomerList), outputCSV))
+
+	require.Equal(t, `id,name,email,description,created
+cus_1,Jenny Rosen,jenny@example.com,A customer whose description is much too long for a table,1680000000
+cus_2,"Bob, Jr.",bob@example.com,,1680000100
+`, out.String())
+}
+
+func TestWriteOutputYAML(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeOutput(&out, []byte(`{"id": "cus_1", "created": 1680000000, "metadata": {"enabled": "true", "count": "3"}, "tags": ["a", "b"], "description": null}`), outputYAML))
+
+	require.Equal(t, `id: cus_1
+created: 1680000000
+metadata:
+  enabled: "true"
+  count: "3"
+tags:
+  - a
+  - b
+description: null
+`, out.String())
+}
+
+func TestWriteOutputNDJSON(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeOutput(&out, []byte(`[{"id": "cus_1"}, {"id": "cus_2"}]`), outputNDJSON))
+	require.Equal(t, "{\"id\":\"cus_1\"}\n{\"id\":\"cus_2\"}\n", out.String())
+
+	out.Reset()
+	require.NoError(t, writeOutput(&out, []byte(`{"id": "cus_1"}`), outputNDJSON))
+	require.Equal(t, "{\"id\":\"cus_1\"}\n", out.String())
+}
+
+func TestWriteResultQuery(t *testing.T) {
+	rb := Base{query: "data[].{id: id, email: email}"}
+
+	var out bytes.Buffer
+	require.NoError(t, rb.writeResult(&out, []byte(testCustomerList), outputJSON))
+	require.Equal(t, `[
+  {
+    "id": "cus_1",
+    "email": "jenny@example.com"
+  },
+  {
+    "id": "cus_2",
+    "email": "bob@example.com"
+  }
+]
+`, out.String())
+
+	out.Reset()
+	require.NoError(t, rb.writeResult(&out, []byte(testCustomerList), outputCSV))
+	require.Equal(t, "id,email\ncus_1,jenny@example.com\ncus_2,bob@example.com\n", out.String())
+
+	rb.query = "data[?"
+	require.Error(t, rb.writeResult(&out, []byte(testCustomerList), outputJSON))
+}
@@ -0,0 +1,257 @@
+package requests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// maxPageSize is the largest page the API returns
+const maxPageSize = 100
+
+// listPage is a page of a list or of search results, or of a list of the v2
+// API, whose next page is at next_page_url
+type listPage struct {
+	Data        []json.RawMessage `json:"data"`
+	HasMore     bool              `json:"has_more"`
+	NextPage    *string           `json:"next_page"`
+	NextPageURL *string           `json:"next_page_url"`
+}
+
+// paginates returns whether a request pages through a list: with --all, or
+// with a limit larger than a page
+func (rb *Base) paginates(params *RequestParameters) bool {
+	if rb.Method != http.MethodGet {
+		return false
+	}
+
+	if rb.all {
+		return true
+	}
+
+	return listLimit(params) > maxPageSize
+}
+
+// listLimit returns the number of objects requested, from --limit or from a
+// limit request parameter like the --limit of `stripe customers list`
+func listLimit(params *RequestParameters) int {
+	if params.limit != "" {
+		limit, _ := strconv.Atoi(params.limit)
+		return limit
+	}
+
+	for _, datum := range params.data {
+		if strings.HasPrefix(datum, "limit=") {
+			limit, _ := strconv.Atoi(strings.TrimPrefix(datum, "limit="))
+			return limit
+		}
+	}
+
+	return 0
+}
+
+// validatePagination returns an error if the pagination flags can't be used
+// together
+func (rb *Base) validatePagination(params *RequestParameters) error {
+	if rb.paginates(params) && params.endingBefore != "" {
+		return fmt.Errorf("--ending-before cannot be used with --all or a --limit larger than %d", maxPageSize)
+	}
+
+	return nil
+}
+
+// paginate follows the pages of a list, with starting_after, of search
+// results, with page, or of a v2 list, with next_page_url, and prints their
+// objects to out as a single JSON array, or one per line with --output
+// ndjson. It stops after the number of objects of --limit, if it's set, even
+// with --all.
+//
+// With the other output formats, or with --query, the objects are collected
+// and printed once all the pages were received, the query being evaluated on
+// the array of all the objects.
+func (rb *Base) paginate(ctx context.Context, apiKey, path string, params *RequestParameters, out io.Writer) error {
+	format := rb.outputFormat()
+	if (format != outputJSON && format != outputNDJSON) || rb.query != "" {
+		var objects bytes.Buffer
+		if err := rb.followPages(ctx, apiKey, path, params, &objects, false); err != nil {
+			return err
+		}
+		return rb.writeResult(out, objects.Bytes(), format)
+	}
+
+	return rb.followPages(ctx, apiKey, path, params, out, format == outputNDJSON)
+}
+
+// followPages requests the pages of a list, and prints their objects as a
+// JSON array, or one per line with ndjson
+func (rb *Base) followPages(ctx context.Context, apiKey, path string, params *RequestParameters, out io.Writer, ndjson bool) error {
+	limit := listLimit(params)
+
+	// Pagination parameters passed as request parameters, like the --limit of
+	// `stripe customers list`, are replaced by those of each page
+	page := *params
+	page.data = nil
+	for _, datum := range params.data {
+		switch {
+		case strings.HasPrefix(datum, "limit="):
+		case strings.HasPrefix(datum, "starting_after="):
+			page.startingAfter = strings.TrimPrefix(datum, "starting_after=")
+		default:
+			page.data = append(page.data, datum)
+		}
+	}
+
+	baseData := page.data
+	written := 0
+
+	pageSize := maxPageSize
+	if isV2Path(path) {
+		pageSize = maxV2PageSize
+	}
+
+	// The next pages of v2 lists are requested at their URL, which has the
+	// page token and the parameters of the first page
+	pagePath := path
+	nextPageURL := false
+
+	if !ndjson {
+		fmt.Fprint(out, "[")
+	}
+
+	for {
+		if !nextPageURL {
+			page.limit = strconv.Itoa(pageSize)
+			if limit > 0 && limit-written < pageSize {
+				page.limit = strconv.Itoa(limit - written)
+			}
+		}
+
+		result, err := rb.fetchPage(ctx, apiKey, pagePath, &page)
+		if err != nil {
+			return err
+		}
+
+		for _, obj := range result.Data {
+			if limit > 0 && written >= limit {
+				break
+			}
+			if err := writeListObject(out, obj, ndjson, written == 0); err != nil {
+				return err
+			}
+			written++
+		}
+
+		if (limit > 0 && written >= limit) || len(result.Data) == 0 {
+			break
+		}
+
+		if result.NextPageURL != nil && *result.NextPageURL != "" {
+			next, err := url.Parse(*result.NextPageURL)
+			if err != nil {
+				return fmt.Errorf("cannot paginate %s: invalid next_page_url: %v", path, err)
+			}
+			form, err := parseForm(next.RawQuery)
+			if err != nil {
+				return fmt.Errorf("cannot paginate %s: invalid next_page_url: %v", path, err)
+			}
+
+			pagePath = next.Path
+			page.data = nil
+			for _, pair := range form {
+				page.data = append(page.data, pair[0]+"="+pair[1])
+			}
+			page.limit = ""
+			page.startingAfter = ""
+			nextPageURL = true
+			continue
+		}
+
+		if !result.HasMore {
+			break
+		}
+
+		if result.NextPage != nil && *result.NextPage != "" {
+			page.data = append(append([]string{}, baseData...), "page="+*result.NextPage)
+			continue
+		}
+
+		var last struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(result.Data[len(result.Data)-1], &last); err != nil || last.ID == "" {
+			return fmt.Errorf("cannot paginate %s: its objects have no ID", path)
+		}
+		page.startingAfter = last.ID
+	}
+
+	if !ndjson {
+		if written > 0 {
+			fmt.Fprint(out, "\n")
+		}
+		fmt.Fprintln(out, "]")
+	}
+
+	return nil
+}
+
+// fetchPage requests a page of a list
+func (rb *Base) fetchPage(ctx context.Context, apiKey, path string, params *RequestParameters) (*listPage, error) {
+	data, err := rb.buildDataForRequest(params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := rb.sendRequest(ctx, apiKey, path, params, data, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 300 {
+		return nil, compileRequestErrorFor(path, resp, body)
+	}
+
+	var page listPage
+	if err := json.Unmarshal(body, &page); err != nil || page.Data == nil {
+		return nil, fmt.Errorf("cannot paginate %s: it doesn't return a list", path)
+	}
+
+	return &page, nil
+}
+
+// writeListObject writes an object of a paginated list, on its own line with
+// ndjson, and as an element of a JSON array otherwise
+func writeListObject(out io.Writer, obj json.RawMessage, ndjson bool, first bool) error {
+	var buf bytes.Buffer
+
+	if ndjson {
+		if err := json.Compact(&buf, obj); err != nil {
+			return err
+		}
+		buf.WriteByte('\n')
+	} else {
+		if !first {
+			buf.WriteByte(',')
+		}
+		buf.WriteString("\n  ")
+		if err := json.Indent(&buf, obj, "  ", "  "); err != nil {
+			return err
+		}
+	}
+
+	_, err := out.Write(buf.Bytes())
+
+	return err
+}
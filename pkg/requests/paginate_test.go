@@ -0,0 +1,181 @@
+package requests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// listServer serves a list of total customers, cus_0 to cus_<total-1>,
+// paginated with starting_after, and records the limit of each request
+func listServer(t *testing.T, total int, limits *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		*limits = append(*limits, query.Get("limit"))
+
+		limit, err := strconv.Atoi(query.Get("limit"))
+		require.NoError(t, err)
+
+		start := 0
+		if after := query.Get("starting_after"); after != "" {
+			fmt.Sscanf(after, "cus_%d", &start)
+			start++
+		}
+
+		data := []map[string]string{}
+		for i := start; i < total && i < start+limit; i++ {
+			data = append(data, map[string]string{"id": fmt.Sprintf("cus_%d", i)})
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"object":   "list",
+			"data":     data,
+			"has_more": start+len(data) < total,
+		})
+	}))
+}
+
+func TestPaginateAll(t *testing.T) {
+	var limits []string
+	ts := listServer(t, 250, &limits)
+	defer ts.Close()
+
+	rb := Base{APIBaseURL: ts.URL, Method: http.MethodGet, all: true}
+	params := &RequestParameters{}
+	require.True(t, rb.paginates(params))
+
+	var out bytes.Buffer
+	err := rb.paginate(context.Background(), "sk_test_1234", "/v1/customers", params, &out)
+	require.NoError(t, err)
+
+	var customers []map[string]string
+	require.NoError(t, json.Unmarshal(out.Bytes(), &customers))
+	require.Len(t, customers, 250)
+	require.Equal(t, "cus_0", customers[0]["id"])
+	require.Equal(t, "cus_249", customers[249]["id"])
+	require.Equal(t, []string{"100", "100", "100"}, limits)
+}
+
+func TestPaginateLimit(t *testing.T) {
+	var limits []string
+	ts := listServer(t, 1000, &limits)
+	defer ts.Close()
+
+	rb := Base{APIBaseURL: ts.URL, Method: http.MethodGet, output: "ndjson"}
+	params := &RequestParameters{limit: "150"}
+	require.True(t, rb.paginates(params))
+
+	var out bytes.Buffer
+	err := rb.paginate(context.Background(), "sk_test_1234", "/v1/customers", params, &out)
+	require.NoError(t, err)
+
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	require.Len(t, lines, 150)
+	require.Equal(t, `{"id":"cus_149"}`, string(lines[149]))
+	require.Equal(t, []string{"100", "50"}, limits)
+}
+
+func TestPaginateLimitRequestParameter(t *testing.T) {
+	var limits []string
+	ts := listServer(t, 1000, &limits)
+	defer ts.Close()
+
+	rb := Base{APIBaseURL: ts.URL, Method: http.MethodGet}
+	params := &RequestParameters{data: []string{"limit=120", "starting_after=cus_9"}}
+	require.True(t, rb.paginates(params))
+
+	var out bytes.Buffer
+	err := rb.paginate(context.Background(), "sk_test_1234", "/v1/customers", params, &out)
+	require.NoError(t, err)
+
+	var customers []map[string]string
+	require.NoError(t, json.Unmarshal(out.Bytes(), &customers))
+	require.Len(t, customers, 120)
+	require.Equal(t, "cus_10", customers[0]["id"])
+	require.Equal(t, []string{"100", "20"}, limits)
+}
+
+func TestPaginateSearch(t *testing.T) {
+	var pages []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+
+		if page == "" {
+			w.Write([]byte(`{"object":"search_result","data":[{"id":"cus_1"}],"has_more":true,"next_page":"page_2"}`))
+			return
+		}
+		w.Write([]byte(`{"object":"search_result","data":[{"id":"cus_2"}],"has_more":false,"next_page":null}`))
+	}))
+	defer ts.Close()
+
+	rb := Base{APIBaseURL: ts.URL, Method: http.MethodGet, all: true}
+	params := &RequestParameters{data: []string{"query=email:'jenny@example.com'"}}
+
+	var out bytes.Buffer
+	err := rb.paginate(context.Background(), "sk_test_1234", "/v1/customers/search", params, &out)
+	require.NoError(t, err)
+
+	require.Equal(t, "[\n  {\n    \"id\": \"cus_1\"\n  },\n  {\n    \"id\": \"cus_2\"\n  }\n]\n", out.String())
+	require.Equal(t, []string{"", "page_2"}, pages)
+}
+
+func TestPaginateNotAList(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"cus_1","object":"customer"}`))
+	}))
+	defer ts.Close()
+
+	rb := Base{APIBaseURL: ts.URL, Method: http.MethodGet, all: true}
+
+	var out bytes.Buffer
+	err := rb.paginate(context.Background(), "sk_test_1234", "/v1/customers/cus_1", &RequestParameters{}, &out)
+	require.EqualError(t, err, "cannot paginate /v1/customers/cus_1: it doesn't return a list")
+}
+
+func TestValidatePagination(t *testing.T) {
+	rb := Base{Method: http.MethodGet}
+	require.NoError(t, rb.validatePagination(&RequestParameters{limit: "10", endingBefore: "cus_1"}))
+	require.NoError(t, rb.validatePagination(&RequestParameters{limit: "500"}))
+
+	rb.all = true
+	require.EqualError(t, rb.validatePagination(&RequestParameters{endingBefore: "cus_1"}), "--ending-before cannot be used with --all or a --limit larger than 100")
+}
+
+func TestPaginateCSV(t *testing.T) {
+	var limits []string
+	ts := listServer(t, 150, &limits)
+	defer ts.Close()
+
+	rb := Base{APIBaseURL: ts.URL, Method: http.MethodGet, all: true, output: "csv"}
+
+	var out bytes.Buffer
+	err := rb.paginate(context.Background(), "sk_test_1234", "/v1/customers", &RequestParameters{}, &out)
+	require.NoError(t, err)
+
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	require.Len(t, lines, 151)
+	require.Equal(t, "id", string(lines[0]))
+	require.Equal(t, "cus_149", string(lines[150]))
+}
+
+func TestPaginateQuery(t *testing.T) {
+	var limits []string
+	ts := listServer(t, 150, &limits)
+	defer ts.Close()
+
+	rb := Base{APIBaseURL: ts.URL, Method: http.MethodGet, all: true, query: "[-1].id"}
+
+	var out bytes.Buffer
+	err := rb.paginate(context.Background(), "sk_test_1234", "/v1/customers", &RequestParameters{}, &out)
+	require.NoError(t, err)
+
+	require.Equal(t, "\"cus_149\"\n", out.String())
+}
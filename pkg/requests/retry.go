@@ -0,0 +1,163 @@
+package requests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxRetries is the number of times a request sent with --auto-idempotency is
+// retried
+const maxRetries = 3
+
+const (
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 8 * time.Second
+	// maxRetryAfter caps the delays of Retry-After headers
+	maxRetryAfter = time.Minute
+)
+
+// sleep waits between retries, and is replaced in tests
+var sleep = func(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// autoIdempotency returns whether a request is sent with a generated
+// idempotency key and retried: with --auto-idempotency, or with the
+// auto_idempotency setting of the profile unless the flag is set to false
+func (rb *Base) autoIdempotency() bool {
+	if rb.Method != http.MethodPost || rb.Cmd == nil {
+		return false
+	}
+
+	flag := rb.Cmd.Flags().Lookup("auto-idempotency")
+	if flag == nil {
+		return false
+	}
+	if flag.Changed {
+		return rb.autoIdempotent
+	}
+
+	return rb.Profile != nil && rb.Profile.GetAutoIdempotency()
+}
+
+// sendWithRetries sends a request with send, and retries it on network errors,
+// rate limits and server errors, with an exponential backoff or after the
+// delay of the Retry-After header. Every attempt sends the same idempotency
+// key, so the API doesn't perform the request twice.
+func sendWithRetries(ctx context.Context, params *RequestParameters, stderr io.Writer, send func(*RequestParameters) (*http.Response, error)) (*http.Response, error) {
+	withKey := *params
+	if withKey.idempotency == "" {
+		withKey.idempotency = "stripe-cli-" + uuid.NewString()
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := send(&withKey)
+
+		retry, reason := shouldRetry(resp, err)
+		if !retry || attempt == maxRetries {
+			if err != nil && retry {
+				return nil, fmt.Errorf("%w (idempotency key %s)", err, withKey.idempotency)
+			}
+			return resp, err
+		}
+
+		delay := backoff(attempt)
+		if resp != nil {
+			if after, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+				delay = after
+			}
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		fmt.Fprintf(stderr, "Retrying in %s after %s (%d/%d)\n", delay.Round(time.Millisecond), reason, attempt+1, maxRetries)
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// shouldRetry returns whether a request can be retried, and why
+func shouldRetry(resp *http.Response, err error) (bool, string) {
+	if err != nil {
+		// Only errors sending the request are retried, not e.g. the
+		// read-only mode of the profile refusing it
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+			return true, "a network error"
+		}
+		return false, ""
+	}
+
+	reason := fmt.Sprintf("status=%d", resp.StatusCode)
+
+	switch resp.Header.Get("Stripe-Should-Retry") {
+	case "true":
+		return true, reason
+	case "false":
+		return false, ""
+	}
+
+	if resp.StatusCode == http.StatusConflict || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		return true, reason
+	}
+
+	return false, ""
+}
+
+// backoff returns the delay before a retry, doubling with each attempt, with
+// some jitter so concurrent scripts don't retry together
+func backoff(attempt int) time.Duration {
+	delay := retryBaseDelay << attempt
+	if delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+
+	// #nosec G404 -- the jitter doesn't need to be cryptographically secure
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// retryAfter parses a Retry-After header, either a number of seconds or a
+// date
+func retryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(header); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(header); err == nil {
+		delay = time.Until(date)
+	} else {
+		return 0, false
+	}
+
+	if delay < 0 {
+		delay = 0
+	}
+	if delay > maxRetryAfter {
+		delay = maxRetryAfter
+	}
+
+	return delay, true
+}
@@ -0,0 +1,150 @@
+package requests
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func noSleep(t *testing.T, delays *[]time.Duration) {
+	original := sleep
+	t.Cleanup(func() { sleep = original })
+
+	sleep = func(ctx context.Context, d time.Duration) error {
+		*delays = append(*delays, d)
+		return nil
+	}
+}
+
+func TestMakeRequest_AutoIdempotency(t *testing.T) {
+	var delays []time.Duration
+	noSleep(t, &delays)
+
+	var keys []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+
+		switch len(keys) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Write([]byte(`{"id": "cus_123"}`))
+		}
+	}))
+	defer ts.Close()
+
+	rb := Base{Method: http.MethodPost, Cmd: &cobra.Command{}, SuppressOutput: true}
+	rb.InitFlags()
+	rb.APIBaseURL = ts.URL
+	require.NoError(t, rb.Cmd.Flags().Set("auto-idempotency", "true"))
+
+	body, err := rb.MakeRequest(context.Background(), "sk_test_1234", "/v1/customers", &RequestParameters{}, true)
+	require.NoError(t, err)
+	require.Equal(t, `{"id": "cus_123"}`, string(body))
+
+	require.Len(t, keys, 3)
+	require.True(t, strings.HasPrefix(keys[0], "stripe-cli-"))
+	require.Equal(t, keys[0], keys[1])
+	require.Equal(t, keys[0], keys[2])
+
+	require.Len(t, delays, 2)
+	require.Equal(t, 2*time.Second, delays[1])
+}
+
+func TestMakeRequest_WithoutAutoIdempotency(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		require.Empty(t, r.Header.Get("Idempotency-Key"))
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	rb := Base{Method: http.MethodPost, Cmd: &cobra.Command{}, SuppressOutput: true}
+	rb.InitFlags()
+	rb.APIBaseURL = ts.URL
+
+	_, err := rb.MakeRequest(context.Background(), "sk_test_1234", "/v1/customers", &RequestParameters{}, true)
+	require.Error(t, err)
+	require.Equal(t, 1, requests)
+}
+
+func TestSendWithRetries(t *testing.T) {
+	var delays []time.Duration
+	noSleep(t, &delays)
+
+	attempts := 0
+	send := func(params *RequestParameters) (*http.Response, error) {
+		attempts++
+		require.Equal(t, "my-key", params.idempotency)
+
+		rec := httptest.NewRecorder()
+		rec.WriteHeader(http.StatusInternalServerError)
+		return rec.Result(), nil
+	}
+
+	var stderr bytes.Buffer
+	resp, err := sendWithRetries(context.Background(), &RequestParameters{idempotency: "my-key"}, &stderr, send)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	require.Equal(t, maxRetries+1, attempts)
+	require.Len(t, delays, maxRetries)
+	require.Contains(t, stderr.String(), "after status=500 (3/3)")
+}
+
+func TestShouldRetry(t *testing.T) {
+	response := func(status int, shouldRetry string) *http.Response {
+		resp := &http.Response{StatusCode: status, Header: http.Header{}}
+		if shouldRetry != "" {
+			resp.Header.Set("Stripe-Should-Retry", shouldRetry)
+		}
+		return resp
+	}
+
+	for _, tt := range []struct {
+		resp     *http.Response
+		expected bool
+	}{
+		{response(http.StatusOK, ""), false},
+		{response(http.StatusBadRequest, ""), false},
+		{response(http.StatusBadRequest, "true"), true},
+		{response(http.StatusConflict, ""), true},
+		{response(http.StatusTooManyRequests, ""), true},
+		{response(http.StatusInternalServerError, ""), true},
+		{response(http.StatusInternalServerError, "false"), false},
+	} {
+		retry, _ := shouldRetry(tt.resp, nil)
+		require.Equal(t, tt.expected, retry, tt.resp.StatusCode)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt := 0; attempt < 10; attempt++ {
+		delay := backoff(attempt)
+		require.LessOrEqual(t, delay, retryMaxDelay)
+		require.GreaterOrEqual(t, delay, retryBaseDelay/2)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	delay, ok := retryAfter("3")
+	require.True(t, ok)
+	require.Equal(t, 3*time.Second, delay)
+
+	delay, ok = retryAfter("3600")
+	require.True(t, ok)
+	require.Equal(t, maxRetryAfter, delay)
+
+	_, ok = retryAfter("soon")
+	require.False(t, ok)
+}
@@ -0,0 +1,678 @@
+package requests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/stripe/stripe-cli/pkg/stripe"
+)
+
+// apiKeyEnvVar is the environment variable snippets read the API key from,
+// so that they can be shared without leaking it
+const apiKeyEnvVar = "STRIPE_SECRET_KEY"
+
+// snippetLanguages are the languages of --as-code
+var snippetLanguages = []string{"node", "python", "ruby", "go"}
+
+// snippetRequest is a request resolved like it would be sent, for snippets
+type snippetRequest struct {
+	method string
+	url    string
+	path   string
+	// form are the form-encoded parameters of the request, in order
+	form [][2]string
+	// json is the body of requests to the v2 API
+	json []byte
+	// files are the fields of file uploads, whose files are prefixed with @
+	files [][2]string
+	// headers are the Stripe and custom headers of the request, in order
+	headers [][2]string
+
+	version       string
+	stripeAccount string
+	idempotency   string
+	custom        [][2]string
+}
+
+// printsSnippet returns whether the request is printed as a snippet with
+// --as-curl or --as-code rather than sent
+func (rb *Base) printsSnippet() bool {
+	return rb.asCurl || rb.asCode != ""
+}
+
+func (rb *Base) validateSnippet() error {
+	if rb.asCurl && rb.asCode != "" {
+		return fmt.Errorf("--as-curl and --as-code cannot be used together")
+	}
+
+	if rb.asCode != "" {
+		for _, language := range snippetLanguages {
+			if rb.asCode == language {
+				return nil
+			}
+		}
+		return fmt.Errorf("unsupported language ‘%s’ for --as-code, expected one of: %s", rb.asCode, strings.Join(snippetLanguages, ", "))
+	}
+
+	return nil
+}
+
+// writeSnippet prints the request as a curl command with --as-curl, or as
+// code of a Stripe SDK with --as-code
+func (rb *Base) writeSnippet(out io.Writer, path string, params *RequestParameters) error {
+	if err := rb.validateSnippet(); err != nil {
+		return err
+	}
+
+	req, err := rb.resolveSnippetRequest(path, params)
+	if err != nil {
+		return err
+	}
+
+	if rb.asCurl {
+		_, err = io.WriteString(out, curlSnippet(req))
+		return err
+	}
+
+	if len(req.files) > 0 {
+		return fmt.Errorf("--as-code doesn't support file uploads, use --as-curl")
+	}
+
+	var snippet string
+	switch rb.asCode {
+	case "node":
+		snippet = nodeSnippet(req)
+	case "python":
+		snippet = pythonSnippet(req)
+	case "ruby":
+		snippet = rubySnippet(req)
+	case "go":
+		snippet = goSnippet(req)
+	}
+
+	_, err = io.WriteString(out, snippet)
+	return err
+}
+
+// resolveSnippetRequest builds the request like sendRequest would send it,
+// with its parameters and headers
+func (rb *Base) resolveSnippetRequest(path string, params *RequestParameters) (*snippetRequest, error) {
+	req := &snippetRequest{
+		method:        rb.Method,
+		path:          path,
+		stripeAccount: params.stripeAccount,
+		idempotency:   params.idempotency,
+	}
+
+	if err := validatePreviewFeatures(params.previewFeatures); err != nil {
+		return nil, err
+	}
+	req.version = rb.stripeVersion(params)
+
+	if _, err := parseCustomHeaders(params.headers); err != nil {
+		return nil, err
+	}
+	for _, header := range params.headers {
+		parts := strings.SplitN(header, ":", 2)
+		req.custom = append(req.custom, [2]string{strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])})
+	}
+
+	for _, header := range [][2]string{
+		{"Stripe-Version", req.version},
+		{"Stripe-Account", req.stripeAccount},
+		{"Idempotency-Key", req.idempotency},
+	} {
+		if header[1] != "" {
+			req.headers = append(req.headers, header)
+		}
+	}
+	req.headers = append(req.headers, req.custom...)
+
+	req.url = strings.TrimSuffix(rb.APIBaseURL, "/") + path
+
+	// The files of uploads are referenced rather than read
+	if rb.isFileUpload(path, params) {
+		if params.jsonBody != "" {
+			return nil, fmt.Errorf("--json-body cannot be used to upload files")
+		}
+		if rb.APIBaseURL == stripe.DefaultAPIBaseURL {
+			req.url = stripe.DefaultFilesAPIBaseURL + path
+		}
+		for _, datum := range uploadData(path, params) {
+			parts := strings.SplitN(datum, "=", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("Invalid data argument: %s", datum)
+			}
+			req.files = append(req.files, [2]string{parts[0], parts[1]})
+		}
+		return req, nil
+	}
+
+	data, configure, err := rb.buildRequestBody(path, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if configure != nil {
+		httpReq := &http.Request{Header: http.Header{}}
+		configure(httpReq)
+		if httpReq.Header.Get("Content-Type") == "application/json" {
+			req.json = []byte(data)
+			return req, nil
+		}
+	}
+
+	req.form, err = parseForm(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// parseForm parses form-encoded data, keeping the order of its parameters
+func parseForm(data string) ([][2]string, error) {
+	var form [][2]string
+
+	for _, pair := range strings.Split(data, "&") {
+		if pair == "" {
+			continue
+		}
+
+		parts := strings.SplitN(pair, "=", 2)
+		key, err := url.QueryUnescape(parts[0])
+		if err != nil {
+			return nil, err
+		}
+
+		var value string
+		if len(parts) == 2 {
+			if value, err = url.QueryUnescape(parts[1]); err != nil {
+				return nil, err
+			}
+		}
+
+		form = append(form, [2]string{key, value})
+	}
+
+	return form, nil
+}
+
+var shellSafeRegexp = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// shellQuote quotes an argument of a shell command, if needed
+func shellQuote(s string) string {
+	if shellSafeRegexp.MatchString(s) {
+		return s
+	}
+
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// curlSnippet returns the request as a curl command
+func curlSnippet(req *snippetRequest) string {
+	args := []string{"curl " + shellQuote(req.url), fmt.Sprintf(`-u "$%s:"`, apiKeyEnvVar)}
+
+	switch {
+	case req.method == http.MethodGet && len(req.form) > 0:
+		args = append(args, "-G")
+	case req.method == http.MethodGet:
+	case req.method == http.MethodPost && (len(req.form) > 0 || len(req.json) > 0 || len(req.files) > 0):
+	default:
+		args = append(args, "-X "+req.method)
+	}
+
+	for _, header := range req.headers {
+		args = append(args, "-H "+shellQuote(header[0]+": "+header[1]))
+	}
+
+	for _, pair := range req.form {
+		if strings.ContainsAny(pair[1], "&=+%# \t\r\n") || !isPrintableASCII(pair[1]) {
+			args = append(args, "--data-urlencode "+shellQuote(pair[0]+"="+pair[1]))
+		} else {
+			args = append(args, "-d "+shellQuote(pair[0]+"="+pair[1]))
+		}
+	}
+
+	if len(req.json) > 0 {
+		args = append(args, "-H "+shellQuote("Content-Type: application/json"), "-d "+shellQuote(string(req.json)))
+	}
+
+	for _, pair := range req.files {
+		args = append(args, "-F "+shellQuote(pair[0]+"="+pair[1]))
+	}
+
+	return strings.Join(args, " \\\n  ") + "\n"
+}
+
+func isPrintableASCII(s string) bool {
+	for _, r := range s {
+		if r < 0x20 || r > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// paramNode is a parameter of a request, nested like the SDKs take them:
+// form-encoded keys like metadata[order_id] and items[0][price] are objects
+// and arrays
+type paramNode struct {
+	// value is the value of scalars: a string, a json.Number, a bool or nil
+	value  interface{}
+	keys   []string
+	fields map[string]*paramNode
+	items  []*paramNode
+	array  bool
+}
+
+func newObjectNode() *paramNode {
+	return &paramNode{fields: make(map[string]*paramNode)}
+}
+
+func (n *paramNode) isObject() bool {
+	return n.fields != nil
+}
+
+// child returns the child of an object or array node for a key segment,
+// creating it if needed. An empty segment appends to arrays.
+func (n *paramNode) child(segment string, next string) *paramNode {
+	create := func() *paramNode {
+		if next == "" || isIndex(next) {
+			return &paramNode{array: true}
+		}
+		return newObjectNode()
+	}
+
+	if n.array {
+		index, err := strconv.Atoi(segment)
+		if segment == "" || err != nil || index >= len(n.items) {
+			child := create()
+			n.items = append(n.items, child)
+			return child
+		}
+		return n.items[index]
+	}
+
+	if child, ok := n.fields[segment]; ok {
+		return child
+	}
+
+	child := create()
+	n.keys = append(n.keys, segment)
+	n.fields[segment] = child
+	return child
+}
+
+func isIndex(segment string) bool {
+	_, err := strconv.Atoi(segment)
+	return err == nil
+}
+
+// keySegments splits a form-encoded key like items[0][price] into its
+// segments
+func keySegments(key string) []string {
+	open := strings.Index(key, "[")
+	if open == -1 || !strings.HasSuffix(key, "]") {
+		return []string{key}
+	}
+
+	segments := []string{key[:open]}
+	return append(segments, strings.Split(key[open+1:len(key)-1], "][")...)
+}
+
+// formParams nests form-encoded parameters
+func formParams(form [][2]string) *paramNode {
+	root := newObjectNode()
+
+	for _, pair := range form {
+		segments := keySegments(pair[0])
+		node := root
+		for i, segment := range segments {
+			if i == len(segments)-1 {
+				// Values of arrays of scalars are appended, like expand[]
+				if node.array {
+					node.items = append(node.items, &paramNode{value: pair[1]})
+				} else if _, ok := node.fields[segment]; !ok {
+					node.keys = append(node.keys, segment)
+					node.fields[segment] = &paramNode{value: pair[1]}
+				} else {
+					node.fields[segment].value = pair[1]
+				}
+				break
+			}
+			node = node.child(segment, segments[i+1])
+		}
+	}
+
+	return root
+}
+
+// jsonParams nests the parameters of a JSON body, with their keys sorted
+func jsonParams(body []byte) (*paramNode, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return jsonNode(value), nil
+}
+
+func jsonNode(value interface{}) *paramNode {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		node := newObjectNode()
+		for key := range v {
+			node.keys = append(node.keys, key)
+		}
+		sort.Strings(node.keys)
+		for _, key := range node.keys {
+			node.fields[key] = jsonNode(v[key])
+		}
+		return node
+	case []interface{}:
+		node := &paramNode{array: true}
+		for _, item := range v {
+			node.items = append(node.items, jsonNode(item))
+		}
+		return node
+	default:
+		return &paramNode{value: v}
+	}
+}
+
+// snippetParams returns the parameters of the request, nested
+func snippetParams(req *snippetRequest) *paramNode {
+	if len(req.json) > 0 {
+		if node, err := jsonParams(req.json); err == nil && node.isObject() {
+			return node
+		}
+	}
+
+	return formParams(req.form)
+}
+
+// literalStyle is how a language writes literals
+type literalStyle struct {
+	indent string
+	key    func(string) string
+	str    func(string) string
+	null   string
+	yes    string
+	no     string
+}
+
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+var jsStyle = literalStyle{
+	indent: "  ",
+	key: func(k string) string {
+		if identifierRegexp.MatchString(k) {
+			return k + ": "
+		}
+		return strconv.Quote(k) + ": "
+	},
+	str:  strconv.Quote,
+	null: "null", yes: "true", no: "false",
+}
+
+var pythonStyle = literalStyle{
+	indent: "    ",
+	key:    func(k string) string { return strconv.Quote(k) + ": " },
+	str:    strconv.Quote,
+	null:   "None", yes: "True", no: "False",
+}
+
+var rubyStyle = literalStyle{
+	indent: "  ",
+	key: func(k string) string {
+		if identifierRegexp.MatchString(k) {
+			return k + ": "
+		}
+		return rubyQuote(k) + " => "
+	},
+	str:  rubyQuote,
+	null: "nil", yes: "true", no: "false",
+}
+
+// rubyQuote quotes a Ruby string, without interpolation
+func rubyQuote(s string) string {
+	quoted := strconv.Quote(s)
+	for _, sequence := range []string{"#{", "#@", "#$"} {
+		quoted = strings.ReplaceAll(quoted, sequence, `\`+sequence)
+	}
+	return quoted
+}
+
+// literal returns a parameter as a literal of a language, indented at depth
+func (s literalStyle) literal(n *paramNode, depth int) string {
+	indent := strings.Repeat(s.indent, depth)
+
+	switch {
+	case n.isObject():
+		if len(n.keys) == 0 {
+			return "{}"
+		}
+		var b strings.Builder
+		b.WriteString("{\n")
+		for _, key := range n.keys {
+			fmt.Fprintf(&b, "%s%s%s%s,\n", indent, s.indent, s.key(key), s.literal(n.fields[key], depth+1))
+		}
+		b.WriteString(indent + "}")
+		return b.String()
+	case n.array:
+		if len(n.items) == 0 {
+			return "[]"
+		}
+		var b strings.Builder
+		b.WriteString("[\n")
+		for _, item := range n.items {
+			fmt.Fprintf(&b, "%s%s%s,\n", indent, s.indent, s.literal(item, depth+1))
+		}
+		b.WriteString(indent + "]")
+		return b.String()
+	}
+
+	switch v := n.value.(type) {
+	case nil:
+		return s.null
+	case bool:
+		if v {
+			return s.yes
+		}
+		return s.no
+	case json.Number:
+		return v.String()
+	default:
+		return s.str(fmt.Sprint(v))
+	}
+}
+
+// headersNode returns the custom headers of a request as an object
+func headersNode(headers [][2]string) *paramNode {
+	node := newObjectNode()
+	for _, header := range headers {
+		if _, ok := node.fields[header[0]]; !ok {
+			node.keys = append(node.keys, header[0])
+		}
+		node.fields[header[0]] = &paramNode{value: header[1]}
+	}
+	return node
+}
+
+func nodeSnippet(req *snippetRequest) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "// npm install stripe\nconst stripe = require('stripe')(process.env.%s);\n\n", apiKeyEnvVar)
+
+	options := newObjectNode()
+	for _, option := range [][2]string{
+		{"apiVersion", req.version},
+		{"stripeAccount", req.stripeAccount},
+		{"idempotencyKey", req.idempotency},
+	} {
+		if option[1] != "" {
+			options.keys = append(options.keys, option[0])
+			options.fields[option[0]] = &paramNode{value: option[1]}
+		}
+	}
+	if len(req.custom) > 0 {
+		options.keys = append(options.keys, "additionalHeaders")
+		options.fields["additionalHeaders"] = headersNode(req.custom)
+	}
+
+	fmt.Fprintf(&b, "stripe\n  .rawRequest(%s, %s, %s", strconv.Quote(req.method), strconv.Quote(req.path), jsStyle.literal(snippetParams(req), 1))
+	if len(options.keys) > 0 {
+		fmt.Fprintf(&b, ", %s", jsStyle.literal(options, 1))
+	}
+	b.WriteString(")\n  .then((response) => console.log(response));\n")
+
+	return b.String()
+}
+
+func pythonSnippet(req *snippetRequest) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# pip install stripe\nimport os\n\nfrom stripe import StripeClient\n\nclient = StripeClient(os.environ[%s])\n\n", strconv.Quote(apiKeyEnvVar))
+
+	fmt.Fprintf(&b, "response = client.raw_request(\n    %s,\n    %s,\n", strconv.Quote(strings.ToLower(req.method)), strconv.Quote(req.path))
+
+	params := snippetParams(req)
+	for _, key := range params.keys {
+		value := pythonStyle.literal(params.fields[key], 1)
+		if identifierRegexp.MatchString(key) {
+			fmt.Fprintf(&b, "    %s=%s,\n", key, value)
+		} else {
+			fmt.Fprintf(&b, "    **{%s: %s},\n", strconv.Quote(key), value)
+		}
+	}
+
+	for _, option := range [][2]string{
+		{"stripe_version", req.version},
+		{"stripe_account", req.stripeAccount},
+		{"idempotency_key", req.idempotency},
+	} {
+		if option[1] != "" {
+			fmt.Fprintf(&b, "    %s=%s,\n", option[0], strconv.Quote(option[1]))
+		}
+	}
+	if len(req.custom) > 0 {
+		fmt.Fprintf(&b, "    headers=%s,\n", pythonStyle.literal(headersNode(req.custom), 1))
+	}
+
+	b.WriteString(")\nprint(client.deserialize(response))\n")
+
+	return b.String()
+}
+
+func rubySnippet(req *snippetRequest) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# gem install stripe\nrequire 'stripe'\n\nclient = Stripe::StripeClient.new(ENV[%s])\n\n", rubyQuote(apiKeyEnvVar))
+
+	fmt.Fprintf(&b, "response = client.raw_request(\n  :%s,\n  %s,\n  params: %s,\n", strings.ToLower(req.method), rubyQuote(req.path), rubyStyle.literal(snippetParams(req), 1))
+
+	opts := newObjectNode()
+	for _, option := range [][2]string{
+		{"stripe_version", req.version},
+		{"stripe_account", req.stripeAccount},
+		{"idempotency_key", req.idempotency},
+	} {
+		if option[1] != "" {
+			opts.keys = append(opts.keys, option[0])
+			opts.fields[option[0]] = &paramNode{value: option[1]}
+		}
+	}
+	if len(req.custom) > 0 {
+		opts.keys = append(opts.keys, "headers")
+		opts.fields["headers"] = headersNode(req.custom)
+	}
+	if len(opts.keys) > 0 {
+		fmt.Fprintf(&b, "  opts: %s,\n", rubyStyle.literal(opts, 1))
+	}
+
+	b.WriteString(")\nputs client.deserialize(response.http_body)\n")
+
+	return b.String()
+}
+
+// goString returns a Go string literal, raw if possible
+func goString(s string) string {
+	if !strings.Contains(s, "`") && isPrintableASCII(strings.ReplaceAll(s, "\n", "")) {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
+
+func goSnippet(req *snippetRequest) string {
+	var b strings.Builder
+
+	methods := map[string]string{
+		http.MethodGet:    "http.MethodGet",
+		http.MethodPost:   "http.MethodPost",
+		http.MethodDelete: "http.MethodDelete",
+	}
+	method, ok := methods[req.method]
+	if !ok {
+		method = strconv.Quote(req.method)
+	}
+
+	content := string(req.json)
+	if len(req.json) == 0 {
+		keys := make([]string, len(req.form))
+		values := make([]string, len(req.form))
+		for i, pair := range req.form {
+			keys[i], values[i] = pair[0], pair[1]
+		}
+		content = encode(keys, values)
+	}
+
+	b.WriteString(`// go get github.com/stripe/stripe-go/v81
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/stripe/stripe-go/v81"
+)
+
+func main() {
+`)
+	fmt.Fprintf(&b, "\tstripe.Key = os.Getenv(%s)\n\n", strconv.Quote(apiKeyEnvVar))
+	b.WriteString("\tparams := &stripe.RawParams{}\n")
+
+	if req.stripeAccount != "" {
+		fmt.Fprintf(&b, "\tparams.SetStripeAccount(%s)\n", strconv.Quote(req.stripeAccount))
+	}
+	if req.idempotency != "" {
+		fmt.Fprintf(&b, "\tparams.SetIdempotencyKey(%s)\n", strconv.Quote(req.idempotency))
+	}
+	headers := req.custom
+	if req.version != "" {
+		headers = append([][2]string{{"Stripe-Version", req.version}}, headers...)
+	}
+	if len(headers) > 0 {
+		b.WriteString("\tparams.Headers = http.Header{}\n")
+		for _, header := range headers {
+			fmt.Fprintf(&b, "\tparams.Headers.Add(%s, %s)\n", strconv.Quote(header[0]), strconv.Quote(header[1]))
+		}
+	}
+
+	fmt.Fprintf(&b, "\n\tresp, err := stripe.RawRequest(%s, %s, %s, params)\n", method, strconv.Quote(req.path), goString(content))
+	b.WriteString("\tif err != nil {\n\t\tpanic(err)\n\t}\n\tfmt.Println(string(resp.RawJSON))\n}\n")
+
+	return b.String()
+}
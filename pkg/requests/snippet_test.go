@@ -0,0 +1,137 @@
+package requests
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func snippetBase(t *testing.T, method string, flags map[string]string) *Base {
+	rb := &Base{Method: method, Cmd: &cobra.Command{}}
+	rb.InitFlags()
+	for name, value := range flags {
+		require.NoError(t, rb.Cmd.Flags().Set(name, value))
+	}
+	return rb
+}
+
+func TestWriteSnippetCurl(t *testing.T) {
+	rb := snippetBase(t, http.MethodPost, map[string]string{"as-curl": "true"})
+
+	params := &RequestParameters{
+		data:    []string{"email=jenny@example.com", "metadata[order_id]=6735", "name=Jenny Rosen"},
+		expand:  []string{"tax"},
+		version: "2024-06-20",
+		headers: []string{"Stripe-Context: acct_123"},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, rb.writeSnippet(&out, "/v1/customers", params))
+	require.Equal(t, `curl https://api.stripe.com/v1/customers \
+  -u "$STRIPE_SECRET_KEY:" \
+  -H 'Stripe-Version: 2024-06-20' \
+  -H 'Stripe-Context: acct_123' \
+  -d email=jenny@example.com \
+  -d 'metadata[order_id]=6735' \
+  --data-urlencode 'name=Jenny Rosen' \
+  -d 'expand[]=tax'
+`, out.String())
+}
+
+func TestWriteSnippetCurlMethods(t *testing.T) {
+	var out bytes.Buffer
+
+	rb := snippetBase(t, http.MethodGet, map[string]string{"as-curl": "true"})
+	require.NoError(t, rb.writeSnippet(&out, "/v1/customers", &RequestParameters{limit: "3"}))
+	require.Equal(t, "curl https://api.stripe.com/v1/customers \\\n  -u \"$STRIPE_SECRET_KEY:\" \\\n  -G \\\n  -d limit=3\n", out.String())
+
+	out.Reset()
+	rb = snippetBase(t, http.MethodDelete, map[string]string{"as-curl": "true"})
+	require.NoError(t, rb.writeSnippet(&out, "/v1/customers/cus_123", &RequestParameters{}))
+	require.Equal(t, "curl https://api.stripe.com/v1/customers/cus_123 \\\n  -u \"$STRIPE_SECRET_KEY:\" \\\n  -X DELETE\n", out.String())
+
+	out.Reset()
+	rb = snippetBase(t, http.MethodPost, map[string]string{"as-curl": "true"})
+	params := &RequestParameters{fileFields: []string{"purpose=dispute_evidence"}, filePath: "./evidence.pdf"}
+	require.NoError(t, rb.writeSnippet(&out, "/v1/files", params))
+	require.Equal(t, "curl https://files.stripe.com/v1/files \\\n  -u \"$STRIPE_SECRET_KEY:\" \\\n  -F purpose=dispute_evidence \\\n  -F file=@./evidence.pdf\n", out.String())
+}
+
+func TestWriteSnippetCode(t *testing.T) {
+	params := &RequestParameters{
+		data:          []string{"email=jenny@example.com", "items[0][price]=price_123", "items[0][quantity]=2"},
+		expand:        []string{"tax"},
+		stripeAccount: "acct_123",
+	}
+
+	tests := map[string][]string{
+		"node": {
+			"require('stripe')(process.env.STRIPE_SECRET_KEY)",
+			`.rawRequest("POST", "/v1/customers", {`,
+			"    items: [\n      {\n        price: \"price_123\",\n        quantity: \"2\",\n      },\n    ],",
+			`stripeAccount: "acct_123",`,
+		},
+		"python": {
+			`StripeClient(os.environ["STRIPE_SECRET_KEY"])`,
+			"client.raw_request(\n    \"post\",\n    \"/v1/customers\",\n    email=\"jenny@example.com\",",
+			"    expand=[\n        \"tax\",\n    ],",
+			`    stripe_account="acct_123",`,
+		},
+		"ruby": {
+			`Stripe::StripeClient.new(ENV["STRIPE_SECRET_KEY"])`,
+			"  :post,\n  \"/v1/customers\",\n  params: {\n    email: \"jenny@example.com\",",
+			"  opts: {\n    stripe_account: \"acct_123\",\n  },",
+		},
+		"go": {
+			`stripe.Key = os.Getenv("STRIPE_SECRET_KEY")`,
+			`params.SetStripeAccount("acct_123")`,
+			"stripe.RawRequest(http.MethodPost, \"/v1/customers\", `email=jenny%40example.com&items[0][price]=price_123&items[0][quantity]=2&expand[]=tax`, params)",
+		},
+	}
+
+	for language, expected := range tests {
+		t.Run(language, func(t *testing.T) {
+			rb := snippetBase(t, http.MethodPost, map[string]string{"as-code": language})
+
+			var out bytes.Buffer
+			require.NoError(t, rb.writeSnippet(&out, "/v1/customers", params))
+			for _, snippet := range expected {
+				require.Contains(t, out.String(), snippet)
+			}
+		})
+	}
+}
+
+func TestWriteSnippetJSONBody(t *testing.T) {
+	rb := snippetBase(t, http.MethodPost, map[string]string{"as-code": "python"})
+	params := &RequestParameters{jsonBody: `{"event_name": "api_call", "payload": {"value": 1, "live": false, "customer": null}}`}
+
+	var out bytes.Buffer
+	require.NoError(t, rb.writeSnippet(&out, "/v2/billing/meter_events", params))
+	require.Contains(t, out.String(), "    event_name=\"api_call\",\n    payload={\n        \"customer\": None,\n        \"live\": False,\n        \"value\": 1,\n    },\n")
+}
+
+func TestWriteSnippetErrors(t *testing.T) {
+	rb := snippetBase(t, http.MethodPost, map[string]string{"as-code": "java"})
+	err := rb.writeSnippet(&bytes.Buffer{}, "/v1/customers", &RequestParameters{})
+	require.EqualError(t, err, "unsupported language ‘java’ for --as-code, expected one of: node, python, ruby, go")
+
+	rb = snippetBase(t, http.MethodPost, map[string]string{"as-code": "node", "as-curl": "true"})
+	err = rb.writeSnippet(&bytes.Buffer{}, "/v1/customers", &RequestParameters{})
+	require.EqualError(t, err, "--as-curl and --as-code cannot be used together")
+
+	rb = snippetBase(t, http.MethodPost, map[string]string{"as-code": "node"})
+	err = rb.writeSnippet(&bytes.Buffer{}, "/v1/files", &RequestParameters{filePath: "./evidence.pdf"})
+	require.EqualError(t, err, "--as-code doesn't support file uploads, use --as-curl")
+}
+
+func TestSnippetSkipsConfirmation(t *testing.T) {
+	rb := snippetBase(t, http.MethodDelete, map[string]string{"as-curl": "true"})
+
+	confirmed, err := rb.getUserConfirmation(nil)
+	require.NoError(t, err)
+	require.True(t, confirmed)
+}
@@ -0,0 +1,87 @@
+package requests
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/stripe/stripe-cli/pkg/stripe"
+)
+
+// filesPath is the endpoint of the Files API, whose file field is a file to
+// upload
+const filesPath = "/v1/files"
+
+// isFileUpload returns whether a request uploads a file as
+// multipart/form-data: with --file-path, or with the file field of
+// `stripe files create`
+func (rb *Base) isFileUpload(path string, params *RequestParameters) bool {
+	if rb.Method != http.MethodPost {
+		return false
+	}
+
+	if params.filePath != "" {
+		return true
+	}
+
+	if path == filesPath {
+		for _, datum := range params.data {
+			if strings.HasPrefix(datum, "file=") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// buildUploadBody returns the multipart/form-data body of a file upload, with
+// the fields of --file and --data, and the file of --file-path. Uploads are
+// sent to files.stripe.com, unless the API base URL was changed.
+func (rb *Base) buildUploadBody(path string, params *RequestParameters) (string, func(*http.Request), error) {
+	if params.jsonBody != "" {
+		return "", nil, fmt.Errorf("--json-body cannot be used to upload files")
+	}
+
+	upload := *params
+	upload.data = uploadData(path, params)
+
+	body, contentType, err := rb.buildMultiPartRequest(&upload)
+	if err != nil {
+		return "", nil, err
+	}
+
+	filesBaseURL, err := url.Parse(stripe.DefaultFilesAPIBaseURL)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return body.String(), func(req *http.Request) {
+		req.Header.Set("Content-Type", contentType)
+
+		if rb.APIBaseURL == stripe.DefaultAPIBaseURL {
+			req.URL.Scheme = filesBaseURL.Scheme
+			req.URL.Host = filesBaseURL.Host
+			req.Host = filesBaseURL.Host
+		}
+	}, nil
+}
+
+// uploadData returns the fields of a file upload, whose files are prefixed
+// with @
+func uploadData(path string, params *RequestParameters) []string {
+	data := append([]string{}, params.fileFields...)
+	for _, datum := range params.data {
+		// The file field of `stripe files create` is the path of the file
+		if path == filesPath && strings.HasPrefix(datum, "file=") && !strings.HasPrefix(datum, "file=@") {
+			datum = "file=@" + strings.TrimPrefix(datum, "file=")
+		}
+		data = append(data, datum)
+	}
+	if params.filePath != "" {
+		data = append(data, "file=@"+params.filePath)
+	}
+
+	return data
+}
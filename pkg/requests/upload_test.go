@@ -0,0 +1,85 @@
+package requests
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/stripe"
+)
+
+func TestMakeRequest_FileUpload(t *testing.T) {
+	evidence := filepath.Join(t.TempDir(), "evidence.pdf")
+	require.NoError(t, ioutil.WriteFile(evidence, []byte("%PDF-1.4"), 0600))
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/files", r.URL.Path)
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+
+		require.Equal(t, "dispute_evidence", r.FormValue("purpose"))
+		require.Equal(t, "true", r.FormValue("file_link_data[create]"))
+
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		defer file.Close()
+		require.Equal(t, "evidence.pdf", header.Filename)
+
+		content, err := ioutil.ReadAll(file)
+		require.NoError(t, err)
+		require.Equal(t, "%PDF-1.4", string(content))
+
+		w.Write([]byte(`{"id": "file_123"}`))
+	}))
+	defer ts.Close()
+
+	rb := Base{APIBaseURL: ts.URL, Method: http.MethodPost, SuppressOutput: true}
+	params := &RequestParameters{
+		filePath:   evidence,
+		fileFields: []string{"purpose=dispute_evidence"},
+		data:       []string{"file_link_data[create]=true"},
+	}
+
+	body, err := rb.MakeRequest(context.Background(), "sk_test_1234", "/v1/files", params, true)
+	require.NoError(t, err)
+	require.Equal(t, `{"id": "file_123"}`, string(body))
+}
+
+func TestIsFileUpload(t *testing.T) {
+	rb := Base{Method: http.MethodPost}
+	require.True(t, rb.isFileUpload("/v1/files", &RequestParameters{data: []string{"purpose=identity_document", "file=./id.png"}}))
+	require.True(t, rb.isFileUpload("/v1/customers", &RequestParameters{filePath: "./photo.png"}))
+	require.False(t, rb.isFileUpload("/v1/customers", &RequestParameters{data: []string{"file=./id.png"}}))
+
+	rb.Method = http.MethodGet
+	require.False(t, rb.isFileUpload("/v1/files", &RequestParameters{filePath: "./photo.png"}))
+}
+
+func TestBuildUploadBody(t *testing.T) {
+	id := filepath.Join(t.TempDir(), "id.png")
+	require.NoError(t, ioutil.WriteFile(id, []byte("png"), 0600))
+
+	rb := Base{APIBaseURL: stripe.DefaultAPIBaseURL, Method: http.MethodPost}
+
+	// The file field of `stripe files create` is a path
+	body, configure, err := rb.buildUploadBody("/v1/files", &RequestParameters{data: []string{"purpose=identity_document", "file=" + id}})
+	require.NoError(t, err)
+	require.Contains(t, body, `name="file"; filename="id.png"`)
+
+	req, err := http.NewRequest(http.MethodPost, "https://api.stripe.com/v1/files", nil)
+	require.NoError(t, err)
+	configure(req)
+	require.Equal(t, "files.stripe.com", req.URL.Host)
+	require.Contains(t, req.Header.Get("Content-Type"), "multipart/form-data; boundary=")
+
+	_, _, err = rb.buildUploadBody("/v1/files", &RequestParameters{filePath: id, jsonBody: `{}`})
+	require.EqualError(t, err, "--json-body cannot be used to upload files")
+
+	_, _, err = rb.buildUploadBody("/v1/files", &RequestParameters{filePath: filepath.Join(t.TempDir(), "missing.pdf")})
+	require.True(t, os.IsNotExist(err))
+}
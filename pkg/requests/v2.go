@@ -0,0 +1,160 @@
+package requests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// The API version families of --api-version-family
+const (
+	apiVersionFamilyV1 = "v1"
+	apiVersionFamilyV2 = "v2"
+)
+
+// maxV2PageSize is the largest page the v2 API returns
+const maxV2PageSize = 20
+
+// validateAPIVersionFamily returns an error if --api-version-family isn't the
+// family of the path, or if flags of the v1 API are used with a v2 endpoint
+func (rb *Base) validateAPIVersionFamily(path string, params *RequestParameters) error {
+	switch rb.apiVersionFamily {
+	case "":
+	case apiVersionFamilyV1:
+		if isV2Path(path) {
+			return fmt.Errorf("%s is not a v1 endpoint", path)
+		}
+	case apiVersionFamilyV2:
+		if !isV2Path(path) {
+			return fmt.Errorf("%s is not a v2 endpoint", path)
+		}
+	default:
+		return fmt.Errorf("unsupported --api-version-family ‘%s’, expected v1 or v2", rb.apiVersionFamily)
+	}
+
+	if isV2Path(path) && (params.startingAfter != "" || params.endingBefore != "") {
+		return fmt.Errorf("--starting-after and --ending-before cannot be used with v2 endpoints, which are paginated with --all")
+	}
+
+	return nil
+}
+
+// normalizeRequestPath returns the path of the argument of a request command.
+// With --api-version-family v2, paths without a version are v2 endpoints.
+func (rb *Base) normalizeRequestPath(arg string) (string, error) {
+	if rb.apiVersionFamily != apiVersionFamilyV2 {
+		return createOrNormalizePath(arg)
+	}
+
+	path := "/" + strings.TrimPrefix(arg, "/")
+	if strings.HasPrefix(path, "/v1/") {
+		return "", fmt.Errorf("%s is not a v2 endpoint", arg)
+	}
+	if !strings.HasPrefix(path, "/v2/") {
+		path = "/v2" + path
+	}
+
+	return path, nil
+}
+
+// buildV2RequestBody returns the JSON body of a POST request to the v2 API:
+// the body of --json-body as is, or the parameters of --data nested into JSON
+// objects and arrays, like metadata[order_id]=6735 into
+// {"metadata": {"order_id": "6735"}}. Values of --data are sent as strings.
+func (rb *Base) buildV2RequestBody(params *RequestParameters) (string, func(*http.Request), error) {
+	configure := func(req *http.Request) {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if params.jsonBody != "" {
+		if len(params.data) > 0 || len(params.expand) > 0 {
+			return "", nil, fmt.Errorf("--data and --expand cannot be used with --json-body for v2 endpoints, set them in the JSON body")
+		}
+
+		body, err := loadJSONBody(params.jsonBody, os.Stdin)
+		if err != nil {
+			return "", nil, err
+		}
+
+		return string(body), configure, nil
+	}
+
+	if len(params.expand) > 0 {
+		return "", nil, fmt.Errorf("--expand cannot be used with v2 endpoints, set the include parameter with --data instead")
+	}
+
+	body, err := formToJSON(params.data)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return string(body), configure, nil
+}
+
+// formToJSON nests form-encoded parameters like items[0][price]=price_123
+// into a JSON object
+func formToJSON(data []string) ([]byte, error) {
+	form := make([][2]string, 0, len(data))
+	for _, datum := range data {
+		parts := strings.SplitN(datum, "=", 2)
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("Invalid data argument: %s", datum)
+		}
+		form = append(form, [2]string{parts[0], parts[1]})
+	}
+
+	return json.Marshal(formParams(form))
+}
+
+// MarshalJSON encodes the parameters as JSON, keeping the order of their keys
+func (n *paramNode) MarshalJSON() ([]byte, error) {
+	switch {
+	case n.isObject():
+		var buf bytes.Buffer
+		buf.WriteByte('{')
+		for i, key := range n.keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			encodedKey, err := json.Marshal(key)
+			if err != nil {
+				return nil, err
+			}
+			value, err := json.Marshal(n.fields[key])
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(encodedKey)
+			buf.WriteByte(':')
+			buf.Write(value)
+		}
+		buf.WriteByte('}')
+		return buf.Bytes(), nil
+	case n.array:
+		items := n.items
+		if items == nil {
+			items = []*paramNode{}
+		}
+		return json.Marshal(items)
+	default:
+		return json.Marshal(n.value)
+	}
+}
+
+// compileRequestErrorFor returns the error of the response to a request to
+// path. The errors of the v2 API are identified by their type, like
+// temporary_session_expired, and usually have no code, so their type is used
+// as their code.
+func compileRequestErrorFor(path string, resp *http.Response, body []byte) RequestError {
+	requestError := compileRequestError(body, resp.StatusCode)
+	requestError.RequestID = resp.Header.Get("Request-Id")
+
+	if isV2Path(path) && requestError.ErrorCode == "" {
+		requestError.ErrorCode = requestError.ErrorType
+	}
+
+	return requestError
+}
@@ -0,0 +1,173 @@
+package requests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormToJSON(t *testing.T) {
+	body, err := formToJSON([]string{
+		"event_name=api_call",
+		"payload[value]=1",
+		"payload[stripe_customer_id]=cus_123",
+		"include[]=events",
+		"include[]=metadata",
+		"items[0][price]=price_123",
+		"items[0][quantity]=2",
+	})
+	require.NoError(t, err)
+	require.Equal(t, `{"event_name":"api_call","payload":{"value":"1","stripe_customer_id":"cus_123"},"include":["events","metadata"],"items":[{"price":"price_123","quantity":"2"}]}`, string(body))
+
+	body, err = formToJSON(nil)
+	require.NoError(t, err)
+	require.Equal(t, `{}`, string(body))
+
+	_, err = formToJSON([]string{"event_name"})
+	require.EqualError(t, err, "Invalid data argument: event_name")
+}
+
+func TestMakeRequest_DataV2(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqBody, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		require.Equal(t, "/v2/billing/meter_events", r.URL.Path)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.Equal(t, `{"event_name":"api_call","payload":{"value":"1"}}`, string(reqBody))
+		w.Write([]byte(`{"object": "billing.meter_event"}`))
+	}))
+	defer ts.Close()
+
+	rb := Base{APIBaseURL: ts.URL, Method: http.MethodPost, SuppressOutput: true}
+	params := &RequestParameters{data: []string{"event_name=api_call", "payload[value]=1"}}
+
+	_, err := rb.MakeRequest(context.Background(), "sk_test_1234", "/v2/billing/meter_events", params, true)
+	require.NoError(t, err)
+
+	params.expand = []string{"payload"}
+	_, err = rb.MakeRequest(context.Background(), "sk_test_1234", "/v2/billing/meter_events", params, true)
+	require.EqualError(t, err, "--expand cannot be used with v2 endpoints, set the include parameter with --data instead")
+}
+
+func TestMakeRequest_ErrorV2(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": {"type": "temporary_session_expired", "message": "Session expired"}}`))
+	}))
+	defer ts.Close()
+
+	rb := Base{APIBaseURL: ts.URL, Method: http.MethodGet, SuppressOutput: true}
+
+	_, err := rb.MakeRequest(context.Background(), "sk_test_1234", "/v2/core/events", &RequestParameters{}, true)
+	require.Error(t, err)
+	reqErr, ok := err.(RequestError)
+	require.True(t, ok)
+	require.Equal(t, "temporary_session_expired", reqErr.ErrorType)
+	require.Equal(t, "temporary_session_expired", reqErr.ErrorCode)
+
+	_, err = rb.MakeRequest(context.Background(), "sk_test_1234", "/v1/customers", &RequestParameters{}, true)
+	require.Error(t, err)
+	require.Equal(t, "", err.(RequestError).ErrorCode)
+}
+
+func TestPaginateV2(t *testing.T) {
+	var queries []string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v2/core/events", r.URL.Path)
+		queries = append(queries, r.URL.RawQuery)
+
+		start := 0
+		if page := r.URL.Query().Get("page"); page != "" {
+			start, _ = strconv.Atoi(page)
+		}
+
+		data := []map[string]string{}
+		for i := start; i < 45 && i < start+20; i++ {
+			data = append(data, map[string]string{"id": fmt.Sprintf("evt_%d", i)})
+		}
+
+		var next interface{}
+		if start+20 < 45 {
+			next = fmt.Sprintf("/v2/core/events?object_id=mtr_123&page=%d", start+20)
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data":          data,
+			"next_page_url": next,
+		})
+	}))
+	defer ts.Close()
+
+	rb := Base{APIBaseURL: ts.URL, Method: http.MethodGet, all: true}
+	params := &RequestParameters{data: []string{"object_id=mtr_123"}}
+
+	var out bytes.Buffer
+	require.NoError(t, rb.paginate(context.Background(), "sk_test_1234", "/v2/core/events", params, &out))
+
+	var events []map[string]string
+	require.NoError(t, json.Unmarshal(out.Bytes(), &events))
+	require.Len(t, events, 45)
+	require.Equal(t, "evt_44", events[44]["id"])
+	require.Equal(t, []string{
+		"object_id=mtr_123&limit=20",
+		"object_id=mtr_123&page=20",
+		"object_id=mtr_123&page=40",
+	}, queries)
+
+	// Pages are truncated to the limit
+	queries = nil
+	out.Reset()
+	params.limit = "30"
+	require.NoError(t, rb.paginate(context.Background(), "sk_test_1234", "/v2/core/events", params, &out))
+	require.NoError(t, json.Unmarshal(out.Bytes(), &events))
+	require.Len(t, events, 30)
+	require.Len(t, queries, 2)
+}
+
+func TestNormalizeRequestPath(t *testing.T) {
+	rb := Base{}
+
+	path, err := rb.normalizeRequestPath("core/events")
+	require.NoError(t, err)
+	require.Equal(t, "/v1/core/events", path)
+
+	rb.apiVersionFamily = "v2"
+	for _, arg := range []string{"core/events", "/core/events", "v2/core/events", "/v2/core/events"} {
+		path, err = rb.normalizeRequestPath(arg)
+		require.NoError(t, err)
+		require.Equal(t, "/v2/core/events", path)
+	}
+
+	_, err = rb.normalizeRequestPath("/v1/customers")
+	require.EqualError(t, err, "/v1/customers is not a v2 endpoint")
+}
+
+func TestValidateAPIVersionFamily(t *testing.T) {
+	rb := Base{Method: http.MethodGet, Cmd: &cobra.Command{}}
+	rb.InitFlags()
+
+	require.NoError(t, rb.validateAPIVersionFamily("/v2/core/events", &RequestParameters{}))
+	require.EqualError(t, rb.validateAPIVersionFamily("/v2/core/events", &RequestParameters{startingAfter: "evt_1"}),
+		"--starting-after and --ending-before cannot be used with v2 endpoints, which are paginated with --all")
+
+	require.NoError(t, rb.Cmd.Flags().Set("api-version-family", "v1"))
+	require.EqualError(t, rb.validateAPIVersionFamily("/v2/core/events", &RequestParameters{}), "/v2/core/events is not a v1 endpoint")
+
+	require.NoError(t, rb.Cmd.Flags().Set("api-version-family", "v2"))
+	require.NoError(t, rb.validateAPIVersionFamily("/v2/core/events", &RequestParameters{}))
+	require.EqualError(t, rb.validateAPIVersionFamily("/v1/customers", &RequestParameters{}), "/v1/customers is not a v2 endpoint")
+
+	require.NoError(t, rb.Cmd.Flags().Set("api-version-family", "v3"))
+	require.EqualError(t, rb.validateAPIVersionFamily("/v1/customers", &RequestParameters{}), "unsupported --api-version-family ‘v3’, expected v1 or v2")
+}
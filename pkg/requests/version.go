@@ -0,0 +1,49 @@
+package requests
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// previewFeaturePattern matches the preview features of --preview-feature,
+// like feature_beta or feature_beta=v2
+var previewFeaturePattern = regexp.MustCompile(`^[a-z0-9_]+(=v\d+)?$`)
+
+// validatePreviewFeatures returns an error if a preview feature isn't a beta
+// name, optionally followed by its version
+func validatePreviewFeatures(features []string) error {
+	for _, feature := range features {
+		if !previewFeaturePattern.MatchString(feature) {
+			return fmt.Errorf("invalid preview feature ‘%s’, expected a beta name like feature_beta, optionally followed by its version like feature_beta=v2", feature)
+		}
+	}
+
+	return nil
+}
+
+// stripeVersion returns the Stripe-Version header of a request: the API
+// version of --api-version, or else the default API version of the profile,
+// followed by the preview features of --preview-feature and then the betas
+// the profile opted into. Preview features without a version are sent as v1.
+func (rb *Base) stripeVersion(params *RequestParameters) string {
+	version := params.version
+
+	var betas []string
+	if rb.Profile != nil {
+		if version == "" {
+			version = rb.Profile.GetAPIVersion()
+		}
+		betas = rb.Profile.GetBetas()
+	}
+
+	features := make([]string, 0, len(params.previewFeatures))
+	for _, feature := range params.previewFeatures {
+		if !strings.Contains(feature, "=") {
+			feature += "=v1"
+		}
+		features = append(features, feature)
+	}
+
+	return withBetas(withBetas(version, features), betas)
+}
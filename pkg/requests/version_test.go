@@ -0,0 +1,133 @@
+package requests
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/netproxy"
+)
+
+func TestStripeVersion(t *testing.T) {
+	rb := Base{Method: http.MethodGet}
+
+	require.Equal(t, "", rb.stripeVersion(&RequestParameters{}))
+	require.Equal(t, "2024-04-10", rb.stripeVersion(&RequestParameters{version: "2024-04-10"}))
+	require.Equal(t, "2024-04-10; feature_beta=v1; other_beta=v3", rb.stripeVersion(&RequestParameters{
+		version:         "2024-04-10",
+		previewFeatures: []string{"feature_beta", "other_beta=v3"},
+	}))
+}
+
+func TestStripeVersionProfileDefaults(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, ioutil.WriteFile(configFile, []byte(`[default]
+api_version = "2023-10-16"
+betas = ["feature_beta=v1", "profile_beta=v2"]
+`), 0600))
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.SetConfigFile(configFile)
+
+	rb := Base{Method: http.MethodGet, Profile: &config.Profile{ProfileName: "default"}}
+
+	require.Equal(t, "2023-10-16; feature_beta=v1; profile_beta=v2", rb.stripeVersion(&RequestParameters{}))
+
+	// The version and preview features of the request take precedence
+	require.Equal(t, "2024-04-10; feature_beta=v2; profile_beta=v2", rb.stripeVersion(&RequestParameters{
+		version:         "2024-04-10",
+		previewFeatures: []string{"feature_beta=v2"},
+	}))
+}
+
+func TestDoProfileDefaults(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, ioutil.WriteFile(configFile, []byte(`[default]
+api_version = "2023-10-16"
+betas = ["profile_beta=v2"]
+`), 0600))
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.SetConfigFile(configFile)
+
+	var versions []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		versions = append(versions, r.Header.Get("Stripe-Version"))
+		w.Write([]byte(`{"id": "cus_123"}`))
+	}))
+	defer ts.Close()
+
+	_, err := Do(context.Background(), http.MethodGet, ts.URL, "sk_test_1234", "/v1/customers/cus_123", nil, &config.Profile{ProfileName: "default"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"2023-10-16; profile_beta=v2"}, versions)
+}
+
+func TestProxyDialerProfileDefault(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, ioutil.WriteFile(configFile, []byte(`[default]
+proxy = "http://proxy.example.com:8080"
+`), 0600))
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.SetConfigFile(configFile)
+
+	rb := Base{Profile: &config.Profile{ProfileName: "default"}}
+	dialer, err := rb.proxyDialer()
+	require.NoError(t, err)
+	require.Equal(t, "http://proxy.example.com:8080", dialer.String())
+
+	// The proxy of the command takes precedence
+	rb.Proxy, err = netproxy.New("socks5://localhost:1080")
+	require.NoError(t, err)
+	dialer, err = rb.proxyDialer()
+	require.NoError(t, err)
+	require.Equal(t, "socks5://localhost:1080", dialer.String())
+
+	dialer, err = (&Base{}).proxyDialer()
+	require.NoError(t, err)
+	require.Nil(t, dialer)
+}
+
+func TestPreviewFeatureFlags(t *testing.T) {
+	var versions []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		versions = append(versions, r.Header.Get("Stripe-Version"))
+		w.Write([]byte(`{"id": "cus_123"}`))
+	}))
+	defer ts.Close()
+
+	rb := Base{Method: http.MethodGet, Cmd: &cobra.Command{}, SuppressOutput: true}
+	rb.InitFlags()
+	rb.APIBaseURL = ts.URL
+	require.NoError(t, rb.Cmd.Flags().Set("api-version", "2024-04-10"))
+	require.NoError(t, rb.Cmd.Flags().Set("preview-feature", "feature_beta"))
+
+	_, err := rb.MakeRequest(context.Background(), "sk_test_1234", "/v1/customers/cus_123", &rb.Parameters, true)
+	require.NoError(t, err)
+	require.Equal(t, []string{"2024-04-10; feature_beta=v1"}, versions)
+
+	require.NoError(t, rb.Cmd.Flags().Set("preview-feature", "Feature Beta"))
+	_, err = rb.MakeRequest(context.Background(), "sk_test_1234", "/v1/customers/cus_123", &rb.Parameters, true)
+	require.EqualError(t, err, "invalid preview feature ‘Feature Beta’, expected a beta name like feature_beta, optionally followed by its version like feature_beta=v2")
+}
+
+func TestStreamRequestVersionFlagsConflict(t *testing.T) {
+	rb := Base{Method: http.MethodGet, Cmd: &cobra.Command{}}
+	rb.InitFlags()
+	require.NoError(t, rb.Cmd.Flags().Set("api-version", "2024-04-10"))
+	require.NoError(t, rb.Cmd.Flags().Set("stripe-version", "2023-10-16"))
+
+	err := rb.StreamRequest(context.Background(), "sk_test_1234", "/v1/customers", &rb.Parameters)
+	require.EqualError(t, err, "--api-version and --stripe-version cannot be used together")
+}
@@ -0,0 +1,219 @@
+package requests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+)
+
+// defaultWatchInterval is the time between the requests of --watch
+const defaultWatchInterval = 2 * time.Second
+
+// watchNow returns the time changes are printed with, and is replaced in tests
+var watchNow = time.Now
+
+// fieldChange is a field of an object that changed between two requests of
+// --watch. Old is empty for fields that were added, New for fields that were
+// removed.
+type fieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// validateWatch returns an error if --watch can't be used with the other flags
+func (rb *Base) validateWatch(params *RequestParameters) error {
+	if !rb.watch {
+		return nil
+	}
+
+	if rb.paginates(params) || rb.output != "" || rb.query != "" {
+		return fmt.Errorf("--watch cannot be used with --all, --output or --query")
+	}
+	if rb.watchInterval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	return nil
+}
+
+// watchResource requests an object every --interval until interrupted, and
+// prints it once, then only its fields that changed, with the time the change
+// was seen. Failed requests that can be retried are reported and the object
+// is requested again at the next interval.
+func (rb *Base) watchResource(ctx context.Context, apiKey, path string, params *RequestParameters, out io.Writer) error {
+	ctx = withSIGTERMCancel(ctx)
+
+	data, configure, err := rb.buildRequestBody(path, params)
+	if err != nil {
+		return err
+	}
+
+	color := ansi.Color(out)
+
+	var previous map[string]string
+	for {
+		body, retry, err := rb.fetchWatched(ctx, apiKey, path, params, data, configure)
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case err != nil && !retry:
+			return err
+		case err != nil:
+			fmt.Fprintf(os.Stderr, "%s %v, retrying in %s\n", color.Yellow("Warning"), err, rb.watchInterval)
+		default:
+			fields, err := flattenFields(body)
+			if err != nil {
+				return err
+			}
+
+			if previous == nil {
+				fmt.Fprint(out, ansi.ColorizeJSON(string(body), rb.DarkStyle, out))
+				fmt.Fprintf(out, "\nWatching %s every %s, press Ctrl+C to stop\n", path, rb.watchInterval)
+			}
+
+			timestamp := watchNow().Format("15:04:05")
+			for _, change := range diffFields(previous, fields) {
+				switch {
+				case change.Old == "":
+					fmt.Fprintf(out, "%s %s %s: %s\n", timestamp, color.Green("+"), change.Field, change.New)
+				case change.New == "":
+					fmt.Fprintf(out, "%s %s %s: %s\n", timestamp, color.Red("-"), change.Field, change.Old)
+				default:
+					fmt.Fprintf(out, "%s %s %s: %s → %s\n", timestamp, color.Yellow("~"), change.Field, change.Old, change.New)
+				}
+			}
+
+			previous = fields
+		}
+
+		if err := sleep(ctx, rb.watchInterval); err != nil {
+			return nil
+		}
+	}
+}
+
+// fetchWatched requests the watched object, and returns whether the request
+// can be retried if it failed
+func (rb *Base) fetchWatched(ctx context.Context, apiKey, path string, params *RequestParameters, data string, configure func(*http.Request)) ([]byte, bool, error) {
+	resp, err := rb.sendRequest(ctx, apiKey, path, params, data, configure)
+	if err != nil {
+		retry, _ := shouldRetry(nil, err)
+		return nil, retry, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, err
+	}
+
+	if resp.StatusCode >= 300 {
+		retry, _ := shouldRetry(resp, nil)
+		return nil, retry, compileRequestErrorFor(path, resp, body)
+	}
+
+	return body, false, nil
+}
+
+// flattenFields flattens a JSON object into its fields, like
+// charges.data[0].status, mapped to their value
+func flattenFields(body []byte) (map[string]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return nil, fmt.Errorf("the response isn't JSON: %v", err)
+	}
+
+	fields := make(map[string]string)
+	flattenField("", value, fields)
+
+	return fields, nil
+}
+
+func flattenField(field string, value interface{}, fields map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 && field != "" {
+			fields[field] = "{}"
+			return
+		}
+		for key, child := range v {
+			if field == "" {
+				flattenField(key, child, fields)
+			} else {
+				flattenField(field+"."+key, child, fields)
+			}
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			fields[field] = "[]"
+			return
+		}
+		for i, child := range v {
+			flattenField(fmt.Sprintf("%s[%d]", field, i), child, fields)
+		}
+	case nil:
+		fields[field] = "null"
+	case string:
+		if v == "" {
+			fields[field] = `""`
+		} else {
+			fields[field] = v
+		}
+	default:
+		fields[field] = fmt.Sprint(v)
+	}
+}
+
+// diffFields returns the fields that changed between two requests, sorted
+func diffFields(previous, current map[string]string) []fieldChange {
+	if previous == nil {
+		return nil
+	}
+
+	var changes []fieldChange
+	for field, value := range previous {
+		if current[field] != value {
+			changes = append(changes, fieldChange{Field: field, Old: value, New: current[field]})
+		}
+	}
+	for field, value := range current {
+		if _, ok := previous[field]; !ok {
+			changes = append(changes, fieldChange{Field: field, New: value})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+
+	return changes
+}
+
+func withSIGTERMCancel(ctx context.Context) context.Context {
+	// Create a context that will be canceled when Ctrl+C is pressed
+	ctx, cancel := context.WithCancel(ctx)
+
+	interruptCh := make(chan os.Signal, 1)
+	signal.Notify(interruptCh, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		<-interruptCh
+		cancel()
+	}()
+	return ctx
+}
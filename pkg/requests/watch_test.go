@@ -0,0 +1,116 @@
+package requests
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchResource(t *testing.T) {
+	responses := []struct {
+		status int
+		body   string
+	}{
+		{http.StatusOK, `{"id": "pi_123", "status": "requires_action", "amount": 2000, "last_payment_error": null}`},
+		{http.StatusOK, `{"id": "pi_123", "status": "requires_action", "amount": 2000, "last_payment_error": null}`},
+		{http.StatusServiceUnavailable, `{"error": {"type": "api_error"}}`},
+		{http.StatusOK, `{"id": "pi_123", "status": "succeeded", "amount": 2000, "latest_charge": "ch_123"}`},
+	}
+	requests := 0
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/payment_intents/pi_123", r.URL.Path)
+		response := responses[requests]
+		requests++
+		w.WriteHeader(response.status)
+		w.Write([]byte(response.body))
+	}))
+	defer ts.Close()
+
+	// Stop watching once every response was served
+	var delays []time.Duration
+	original := sleep
+	defer func() { sleep = original }()
+	sleep = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		if requests == len(responses) {
+			return context.Canceled
+		}
+		return nil
+	}
+
+	originalNow := watchNow
+	defer func() { watchNow = originalNow }()
+	watchNow = func() time.Time { return time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC) }
+
+	rb := Base{Method: http.MethodGet, Cmd: &cobra.Command{}}
+	rb.InitFlags()
+	rb.APIBaseURL = ts.URL
+	require.NoError(t, rb.Cmd.Flags().Set("watch", "true"))
+	require.NoError(t, rb.Cmd.Flags().Set("interval", "1s"))
+
+	var out bytes.Buffer
+	require.NoError(t, rb.watchResource(context.Background(), "sk_test_1234", "/v1/payment_intents/pi_123", &RequestParameters{}, &out))
+
+	require.Contains(t, out.String(), `"status": "requires_action"`)
+	require.Contains(t, out.String(), "Watching /v1/payment_intents/pi_123 every 1s, press Ctrl+C to stop\n"+
+		"15:04:05 - last_payment_error: null\n"+
+		"15:04:05 + latest_charge: ch_123\n"+
+		"15:04:05 ~ status: requires_action → succeeded\n")
+	require.Equal(t, []time.Duration{time.Second, time.Second, time.Second, time.Second}, delays)
+}
+
+func TestWatchResourceNotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": {"type": "invalid_request_error", "code": "resource_missing"}}`))
+	}))
+	defer ts.Close()
+
+	rb := Base{Method: http.MethodGet, APIBaseURL: ts.URL, watch: true, watchInterval: time.Second}
+
+	err := rb.watchResource(context.Background(), "sk_test_1234", "/v1/payment_intents/pi_123", &RequestParameters{}, &bytes.Buffer{})
+	require.Error(t, err)
+	require.Equal(t, "resource_missing", err.(RequestError).ErrorCode)
+}
+
+func TestValidateWatch(t *testing.T) {
+	rb := Base{Method: http.MethodGet, watch: true, watchInterval: time.Second}
+	require.NoError(t, rb.validateWatch(&RequestParameters{}))
+
+	rb.all = true
+	require.EqualError(t, rb.validateWatch(&RequestParameters{}), "--watch cannot be used with --all, --output or --query")
+
+	rb.all = false
+	rb.watchInterval = 0
+	require.EqualError(t, rb.validateWatch(&RequestParameters{}), "--interval must be positive")
+}
+
+func TestDiffFields(t *testing.T) {
+	previous, err := flattenFields([]byte(`{"status": "open", "lines": {"data": [{"amount": 100}]}, "metadata": {}, "description": ""}`))
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"status":               "open",
+		"lines.data[0].amount": "100",
+		"metadata":             "{}",
+		"description":          `""`,
+	}, previous)
+
+	current, err := flattenFields([]byte(`{"status": "paid", "lines": {"data": [{"amount": 100}, {"amount": 50}]}, "metadata": {"order": "1"}, "description": ""}`))
+	require.NoError(t, err)
+
+	require.Equal(t, []fieldChange{
+		{Field: "lines.data[1].amount", New: "50"},
+		{Field: "metadata", Old: "{}"},
+		{Field: "metadata.order", New: "1"},
+		{Field: "status", Old: "open", New: "paid"},
+	}, diffFields(previous, current))
+
+	require.Nil(t, diffFields(nil, current))
+}
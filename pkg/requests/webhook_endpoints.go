@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/netproxy"
 )
 
 // WebhookEndpointList contains the list of webhook endpoints for the account
@@ -17,14 +18,19 @@ type WebhookEndpointList struct {
 
 // WebhookEndpoint contains the data for each webhook endpoint
 type WebhookEndpoint struct {
+	ID            string   `json:"id"`
 	Application   string   `json:"application"`
 	EnabledEvents []string `json:"enabled_events"`
 	URL           string   `json:"url"`
 	Status        string   `json:"status"`
+	// Secret is the endpoint's signing secret, only returned when the endpoint is created
+	Secret string `json:"secret"`
 }
 
-// WebhookEndpointsList returns all the webhook endpoints on a users' account
-func WebhookEndpointsList(ctx context.Context, baseURL, apiVersion, apiKey string, profile *config.Profile) WebhookEndpointList {
+// WebhookEndpointsList returns all the webhook endpoints on a users' account.
+// The request is sent through proxy, if set, or else through the proxy of the
+// profile.
+func WebhookEndpointsList(ctx context.Context, baseURL, apiVersion, apiKey string, profile *config.Profile, proxy *netproxy.Dialer) WebhookEndpointList {
 	params := &RequestParameters{
 		data:    []string{"limit=30"},
 		version: apiVersion,
@@ -35,6 +41,7 @@ func WebhookEndpointsList(ctx context.Context, baseURL, apiVersion, apiKey strin
 		Method:         http.MethodGet,
 		SuppressOutput: true,
 		APIBaseURL:     baseURL,
+		Proxy:          proxy,
 	}
 	resp, _ := base.MakeRequest(ctx, apiKey, "/v1/webhook_endpoints", params, true)
 	data := WebhookEndpointList{}
@@ -77,3 +84,64 @@ func WebhookEndpointCreate(ctx context.Context, baseURL, apiVersion, apiKey, url
 	}
 	return nil
 }
+
+// WebhookEndpointRegister creates a new webhook endpoint receiving the given
+// events and returns it, including its signing secret
+func WebhookEndpointRegister(ctx context.Context, baseURL, apiVersion, apiKey, url, description string, events []string, profile *config.Profile) (WebhookEndpoint, error) {
+	if strings.TrimSpace(url) == "" {
+		return WebhookEndpoint{}, fmt.Errorf("url cannot be empty")
+	}
+
+	if len(events) == 0 {
+		events = []string{"*"}
+	}
+
+	data := []string{fmt.Sprintf("url=%s", url)}
+	for _, event := range events {
+		data = append(data, fmt.Sprintf("enabled_events[]=%s", event))
+	}
+	if description != "" {
+		data = append(data, fmt.Sprintf("description=%s", description))
+	}
+
+	params := &RequestParameters{
+		data:    data,
+		version: apiVersion,
+	}
+
+	base := &Base{
+		Profile:        profile,
+		Method:         http.MethodPost,
+		SuppressOutput: true,
+		APIBaseURL:     baseURL,
+	}
+	resp, err := base.MakeRequest(ctx, apiKey, "/v1/webhook_endpoints", params, true)
+	if err != nil {
+		return WebhookEndpoint{}, err
+	}
+
+	endpoint := WebhookEndpoint{}
+	if err := json.Unmarshal(resp, &endpoint); err != nil {
+		return WebhookEndpoint{}, err
+	}
+
+	return endpoint, nil
+}
+
+// WebhookEndpointDisable disables the webhook endpoint with the given ID
+func WebhookEndpointDisable(ctx context.Context, baseURL, apiVersion, apiKey, id string, profile *config.Profile) error {
+	params := &RequestParameters{
+		data:    []string{"disabled=true"},
+		version: apiVersion,
+	}
+
+	base := &Base{
+		Profile:        profile,
+		Method:         http.MethodPost,
+		SuppressOutput: true,
+		APIBaseURL:     baseURL,
+	}
+	_, err := base.MakeRequest(ctx, apiKey, fmt.Sprintf("/v1/webhook_endpoints/%s", id), params, true)
+
+	return err
+}
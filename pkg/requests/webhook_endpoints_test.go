@@ -0,0 +1,45 @@
+package requests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+)
+
+func TestWebhookEndpointRegister(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/v1/webhook_endpoints", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "https://staging.example.com/webhook", r.PostForm.Get("url"))
+		require.Equal(t, []string{"charge.captured", "charge.updated"}, r.PostForm["enabled_events[]"])
+
+		w.Write([]byte(`{"id": "we_123", "url": "https://staging.example.com/webhook", "status": "enabled", "secret": "whsec_123"}`))
+	}))
+	defer ts.Close()
+
+	endpoint, err := WebhookEndpointRegister(context.Background(), ts.URL, "2019-03-14", "sk_test_123", "https://staging.example.com/webhook", "", []string{"charge.captured", "charge.updated"}, &config.Profile{})
+	require.NoError(t, err)
+	require.Equal(t, "we_123", endpoint.ID)
+	require.Equal(t, "whsec_123", endpoint.Secret)
+}
+
+func TestWebhookEndpointDisable(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/v1/webhook_endpoints/we_123", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "true", r.PostForm.Get("disabled"))
+
+		w.Write([]byte(`{"id": "we_123", "status": "disabled"}`))
+	}))
+	defer ts.Close()
+
+	err := WebhookEndpointDisable(context.Background(), ts.URL, "2019-03-14", "sk_test_123", "we_123", &config.Profile{})
+	require.NoError(t, err)
+}
@@ -0,0 +1,217 @@
+// Package rpcclient is a client for the gRPC server started by `stripe daemon`,
+// for Go programs embedding Stripe CLI functionality.
+//
+//	client, err := rpcclient.DialConfig(ctx, configLine)
+//	if err != nil {
+//		return err
+//	}
+//	defer client.Close()
+//
+//	requests, err := client.Trigger(ctx, &rpcv1.TriggerRequest{Event: "payment_intent.succeeded"})
+package rpcclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	rpcv1 "github.com/stripe/stripe-cli/rpc/v1"
+)
+
+// authHeader must be sent with every request, the daemon rejects requests
+// without it
+const authHeader = "sec-x-stripe-cli"
+
+// Config is where the daemon listens, as printed by `stripe daemon` when it
+// starts
+type Config struct {
+	Host string `json:"host,omitempty"`
+	Port int    `json:"port,omitempty"`
+	Pipe string `json:"pipe,omitempty"`
+}
+
+// ParseConfig parses the JSON line printed by `stripe daemon` when it starts
+func ParseConfig(line []byte) (Config, error) {
+	var cfg Config
+	if err := json.Unmarshal(line, &cfg); err != nil {
+		return Config{}, fmt.Errorf("invalid daemon config: %v", err)
+	}
+
+	if cfg.Pipe == "" && cfg.Port == 0 {
+		return Config{}, errors.New("invalid daemon config: it has neither a port nor a pipe")
+	}
+
+	return cfg, nil
+}
+
+// Client is a client for the daemon. Besides its typed helpers, it embeds
+// the generated client of the rpc.v1 API for the other methods.
+type Client struct {
+	rpcv1.StripeCLIClient
+
+	conn *grpc.ClientConn
+}
+
+// Dial connects to the daemon listening on addr, like localhost:12111.
+// opts are added to the default options, e.g. to set a custom dialer.
+func Dial(ctx context.Context, addr string, opts ...grpc.DialOption) (*Client, error) {
+	opts = append([]grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(authUnaryInterceptor),
+		grpc.WithStreamInterceptor(authStreamInterceptor),
+	}, opts...)
+
+	conn, err := grpc.DialContext(ctx, addr, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		StripeCLIClient: rpcv1.NewStripeCLIClient(conn),
+		conn:            conn,
+	}, nil
+}
+
+// DialPipe connects to the daemon listening on a Windows named pipe, like
+// \\.\pipe\stripe-cli
+func DialPipe(ctx context.Context, name string, opts ...grpc.DialOption) (*Client, error) {
+	opts = append([]grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return dialPipe(ctx, name)
+		}),
+	}, opts...)
+
+	return Dial(ctx, "passthrough:///"+name, opts...)
+}
+
+// DialConfig connects to the daemon that printed the config line when it
+// started
+func DialConfig(ctx context.Context, line []byte, opts ...grpc.DialOption) (*Client, error) {
+	cfg, err := ParseConfig(line)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Pipe != "" {
+		return DialPipe(ctx, cfg.Pipe, opts...)
+	}
+
+	return Dial(ctx, net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)), opts...)
+}
+
+// Close closes the connection to the daemon
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Trigger triggers an event, and returns the requests made to trigger it
+func (c *Client) Trigger(ctx context.Context, req *rpcv1.TriggerRequest) ([]string, error) {
+	resp, err := c.StripeCLIClient.Trigger(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Requests, nil
+}
+
+// ListenHandlers are called with the messages of a Listen stream. Nil
+// handlers are skipped.
+type ListenHandlers struct {
+	OnState            func(rpcv1.ListenResponse_State)
+	OnEvent            func(*rpcv1.StripeEvent)
+	OnEndpointResponse func(*rpcv1.ListenResponse_EndpointResponse)
+}
+
+// Listen receives webhook events until ctx is canceled or the daemon ends
+// the stream. It returns nil if the stream ended normally.
+func (c *Client) Listen(ctx context.Context, req *rpcv1.ListenRequest, h ListenHandlers) error {
+	stream, err := c.StripeCLIClient.Listen(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return streamError(ctx, err)
+		}
+
+		switch content := resp.Content.(type) {
+		case *rpcv1.ListenResponse_State_:
+			if h.OnState != nil {
+				h.OnState(content.State)
+			}
+		case *rpcv1.ListenResponse_StripeEvent:
+			if h.OnEvent != nil {
+				h.OnEvent(content.StripeEvent)
+			}
+		case *rpcv1.ListenResponse_EndpointResponse_:
+			if h.OnEndpointResponse != nil {
+				h.OnEndpointResponse(content.EndpointResponse)
+			}
+		}
+	}
+}
+
+// LogsTailHandlers are called with the messages of a LogsTail stream. Nil
+// handlers are skipped.
+type LogsTailHandlers struct {
+	OnState func(rpcv1.LogsTailResponse_State)
+	OnLog   func(*rpcv1.LogsTailResponse_Log)
+}
+
+// LogsTail receives API request logs until ctx is canceled or the daemon
+// ends the stream. It returns nil if the stream ended normally.
+func (c *Client) LogsTail(ctx context.Context, req *rpcv1.LogsTailRequest, h LogsTailHandlers) error {
+	stream, err := c.StripeCLIClient.LogsTail(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return streamError(ctx, err)
+		}
+
+		switch content := resp.Content.(type) {
+		case *rpcv1.LogsTailResponse_State_:
+			if h.OnState != nil {
+				h.OnState(content.State)
+			}
+		case *rpcv1.LogsTailResponse_Log_:
+			if h.OnLog != nil {
+				h.OnLog(content.Log)
+			}
+		}
+	}
+}
+
+// streamError returns nil for the errors ending a stream normally
+func streamError(ctx context.Context, err error) error {
+	if err == io.EOF || ctx.Err() != nil {
+		return nil
+	}
+
+	return err
+}
+
+func withAuth(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, authHeader, "1")
+}
+
+func authUnaryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(withAuth(ctx), method, req, reply, cc, opts...)
+}
+
+func authStreamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(withAuth(ctx), desc, cc, method, opts...)
+}
@@ -0,0 +1,108 @@
+package rpcclient
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	rpcv1 "github.com/stripe/stripe-cli/rpc/v1"
+)
+
+type fakeServer struct {
+	rpcv1.UnimplementedStripeCLIServer
+}
+
+func (s *fakeServer) Trigger(ctx context.Context, req *rpcv1.TriggerRequest) (*rpcv1.TriggerResponse, error) {
+	return &rpcv1.TriggerResponse{Requests: []string{req.Event + ":payment_intent"}}, nil
+}
+
+func (s *fakeServer) Listen(req *rpcv1.ListenRequest, stream rpcv1.StripeCLI_ListenServer) error {
+	stream.Send(&rpcv1.ListenResponse{Content: &rpcv1.ListenResponse_State_{State: rpcv1.ListenResponse_STATE_READY}})
+	stream.Send(&rpcv1.ListenResponse{Content: &rpcv1.ListenResponse_StripeEvent{StripeEvent: &rpcv1.StripeEvent{Id: "evt_123", Type: "customer.created"}}})
+
+	return nil
+}
+
+// requireAuth rejects the requests without the auth header, like the daemon
+func requireAuth(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if len(md[authHeader]) == 0 {
+		return status.Errorf(codes.Unauthenticated, "%s header is not supplied", authHeader)
+	}
+
+	return nil
+}
+
+func dialFakeServer(t *testing.T) *Client {
+	lis := bufconn.Listen(1024 * 1024)
+
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			if err := requireAuth(ctx); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.StreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := requireAuth(ss.Context()); err != nil {
+				return err
+			}
+			return handler(srv, ss)
+		}),
+	)
+	rpcv1.RegisterStripeCLIServer(server, &fakeServer{})
+
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	client, err := Dial(context.Background(), "bufnet", grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return lis.Dial()
+	}))
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+
+	return client
+}
+
+func TestTrigger(t *testing.T) {
+	client := dialFakeServer(t)
+
+	requests, err := client.Trigger(context.Background(), &rpcv1.TriggerRequest{Event: "payment_intent.succeeded"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"payment_intent.succeeded:payment_intent"}, requests)
+}
+
+func TestListen(t *testing.T) {
+	client := dialFakeServer(t)
+
+	var states []rpcv1.ListenResponse_State
+	var events []string
+
+	err := client.Listen(context.Background(), &rpcv1.ListenRequest{}, ListenHandlers{
+		OnState: func(state rpcv1.ListenResponse_State) { states = append(states, state) },
+		OnEvent: func(evt *rpcv1.StripeEvent) { events = append(events, evt.Id) },
+	})
+	require.NoError(t, err)
+	require.Equal(t, []rpcv1.ListenResponse_State{rpcv1.ListenResponse_STATE_READY}, states)
+	require.Equal(t, []string{"evt_123"}, events)
+}
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`{"host":"::1","port":12111}`))
+	require.NoError(t, err)
+	require.Equal(t, Config{Host: "::1", Port: 12111}, cfg)
+
+	cfg, err = ParseConfig([]byte(`{"pipe":"\\\\.\\pipe\\stripe-cli"}`))
+	require.NoError(t, err)
+	require.Equal(t, Config{Pipe: `\\.\pipe\stripe-cli`}, cfg)
+
+	_, err = ParseConfig([]byte(`{}`))
+	require.EqualError(t, err, "invalid daemon config: it has neither a port nor a pipe")
+}
@@ -0,0 +1,14 @@
+//go:build !windows
+// +build !windows
+
+package rpcclient
+
+import (
+	"context"
+	"errors"
+	"net"
+)
+
+func dialPipe(ctx context.Context, name string) (net.Conn, error) {
+	return nil, errors.New("named pipes are only supported on Windows")
+}
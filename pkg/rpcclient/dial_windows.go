@@ -0,0 +1,15 @@
+//go:build windows
+// +build windows
+
+package rpcclient
+
+import (
+	"context"
+	"net"
+
+	"github.com/Microsoft/go-winio"
+)
+
+func dialPipe(ctx context.Context, name string) (net.Conn, error) {
+	return winio.DialPipeContext(ctx, name)
+}
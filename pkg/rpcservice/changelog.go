@@ -0,0 +1,190 @@
+package rpcservice
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/stripe/stripe-cli/pkg/version"
+	"github.com/stripe/stripe-cli/rpc"
+	rpcv1 "github.com/stripe/stripe-cli/rpc/v1"
+)
+
+// releases is the history of the rpc.v1 API, newest first. The additions of each release are
+// listed from the `since` options of the methods, messages, fields, and enum values added after
+// 1.0.0, and its other changes from the `release` options of v1/stripe_cli.proto.
+var releases = changelogReleases(rpcv1.File_v1_stripe_cli_proto)
+
+// APIVersion is the version of the rpc.v1 API served by the daemon, its latest release. Annotate
+// what's added to the API with the version of its release, bumping the minor version.
+var APIVersion = releases[0].Version
+
+// Changelog returns the version of the RPC API and the history of changes to it
+func (srv *RPCService) Changelog(ctx context.Context, req *rpcv1.ChangelogRequest) (*rpcv1.ChangelogResponse, error) {
+	return &rpcv1.ChangelogResponse{
+		Version:    APIVersion,
+		Releases:   releases,
+		Deprecated: deprecatedElements(rpcv1.File_v1_stripe_cli_proto, rpc.File_commands_proto),
+	}, nil
+}
+
+// changelogReleases returns the releases of the API whose services are declared in file, newest
+// first
+func changelogReleases(file protoreflect.FileDescriptor) []*rpcv1.ChangelogResponse_Release {
+	changes := make(map[string][]string)
+
+	walkElements(func(d protoreflect.Descriptor) {
+		if since, change := addition(d); since != "" {
+			changes[since] = append(changes[since], change)
+		}
+	}, file)
+
+	for _, release := range proto.GetExtension(file.Options(), rpcv1.E_Release).([]*rpcv1.ChangelogResponse_Release) {
+		changes[release.Version] = append(changes[release.Version], release.Changes...)
+	}
+
+	versions := make([]string, 0, len(changes))
+	for v := range changes {
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return version.Compare(versions[i], versions[j]) > 0
+	})
+
+	releases := make([]*rpcv1.ChangelogResponse_Release, 0, len(versions))
+	for _, v := range versions {
+		releases = append(releases, &rpcv1.ChangelogResponse_Release{Version: v, Changes: changes[v]})
+	}
+
+	return releases
+}
+
+// addition returns the version of the API that added d, from its `since` option, and the
+// description of the addition. The version is empty if d has no `since` option.
+func addition(d protoreflect.Descriptor) (string, string) {
+	switch d := d.(type) {
+	case protoreflect.MethodDescriptor:
+		since := proto.GetExtension(d.Options(), rpcv1.E_MethodSince).(string)
+		return since, fmt.Sprintf("Add the %s method", d.Name())
+	case protoreflect.MessageDescriptor:
+		since := proto.GetExtension(d.Options(), rpcv1.E_MessageSince).(string)
+		return since, fmt.Sprintf("Add the %s message", localName(d))
+	case protoreflect.FieldDescriptor:
+		since := proto.GetExtension(d.Options(), rpcv1.E_FieldSince).(string)
+		return since, fmt.Sprintf("Add the %s field of %s", d.Name(), localName(d.Parent()))
+	case protoreflect.EnumValueDescriptor:
+		since := proto.GetExtension(d.Options(), rpcv1.E_EnumValueSince).(string)
+		return since, fmt.Sprintf("Add the %s value of %s", d.Name(), localName(d.Parent()))
+	default:
+		return "", ""
+	}
+}
+
+// localName returns the name of d without its package, like ListenEventsResponse.Event
+func localName(d protoreflect.Descriptor) string {
+	return strings.TrimPrefix(string(d.FullName()), string(d.ParentFile().Package())+".")
+}
+
+// deprecatedElements returns the full names of all services, methods, messages, fields, and enum
+// values marked as deprecated that are reachable from the services declared in files.
+func deprecatedElements(files ...protoreflect.FileDescriptor) []string {
+	deprecated := make(map[string]bool)
+
+	walkElements(func(d protoreflect.Descriptor) {
+		if isDeprecated(d) {
+			deprecated[string(d.FullName())] = true
+		}
+	}, files...)
+
+	names := make([]string, 0, len(deprecated))
+	for name := range deprecated {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// walkElements calls visit with all services, methods, messages, fields, enums, and enum values
+// reachable from the services declared in files, once each, in the order they're declared.
+func walkElements(visit func(protoreflect.Descriptor), files ...protoreflect.FileDescriptor) {
+	seen := make(map[protoreflect.FullName]bool)
+
+	var visitEnum func(protoreflect.EnumDescriptor)
+	var visitMessage func(protoreflect.MessageDescriptor)
+
+	visitEnum = func(ed protoreflect.EnumDescriptor) {
+		if seen[ed.FullName()] {
+			return
+		}
+		seen[ed.FullName()] = true
+
+		visit(ed)
+
+		values := ed.Values()
+		for i := 0; i < values.Len(); i++ {
+			visit(values.Get(i))
+		}
+	}
+
+	visitMessage = func(md protoreflect.MessageDescriptor) {
+		if seen[md.FullName()] {
+			return
+		}
+		seen[md.FullName()] = true
+
+		visit(md)
+
+		fields := md.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			fd := fields.Get(i)
+			visit(fd)
+			if fd.Message() != nil {
+				visitMessage(fd.Message())
+			}
+			if fd.Enum() != nil {
+				visitEnum(fd.Enum())
+			}
+		}
+	}
+
+	for _, file := range files {
+		services := file.Services()
+		for i := 0; i < services.Len(); i++ {
+			sd := services.Get(i)
+			visit(sd)
+
+			methods := sd.Methods()
+			for j := 0; j < methods.Len(); j++ {
+				md := methods.Get(j)
+				visit(md)
+				visitMessage(md.Input())
+				visitMessage(md.Output())
+			}
+		}
+	}
+}
+
+func isDeprecated(d protoreflect.Descriptor) bool {
+	switch opts := d.Options().(type) {
+	case *descriptorpb.ServiceOptions:
+		return opts.GetDeprecated()
+	case *descriptorpb.MethodOptions:
+		return opts.GetDeprecated()
+	case *descriptorpb.MessageOptions:
+		return opts.GetDeprecated()
+	case *descriptorpb.FieldOptions:
+		return opts.GetDeprecated()
+	case *descriptorpb.EnumOptions:
+		return opts.GetDeprecated()
+	case *descriptorpb.EnumValueOptions:
+		return opts.GetDeprecated()
+	default:
+		return false
+	}
+}
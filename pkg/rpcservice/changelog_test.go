@@ -0,0 +1,61 @@
+package rpcservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stripe/stripe-cli/pkg/version"
+	"github.com/stripe/stripe-cli/rpc"
+	rpcv1 "github.com/stripe/stripe-cli/rpc/v1"
+
+	"google.golang.org/grpc"
+)
+
+func TestChangelogReturnsCurrentVersion(t *testing.T) {
+	ctx := withAuth(context.Background())
+
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := rpcv1.NewStripeCLIClient(conn)
+
+	resp, err := client.Changelog(ctx, &rpcv1.ChangelogRequest{})
+	if err != nil {
+		t.Fatalf("Changelog failed: %v", err)
+	}
+
+	assert.Equal(t, APIVersion, resp.Version)
+	assert.NotEmpty(t, resp.Releases)
+	assert.Equal(t, APIVersion, resp.Releases[0].Version)
+	assert.Contains(t, resp.Deprecated, "rpc.StripeCLI")
+	assert.NotContains(t, resp.Deprecated, "rpc.v1.StripeCLI")
+}
+
+func TestDeprecatedElementsOnlyListsAnnotatedElements(t *testing.T) {
+	assert.Empty(t, deprecatedElements(rpcv1.File_v1_stripe_cli_proto))
+	assert.Equal(t, []string{"rpc.StripeCLI"}, deprecatedElements(rpc.File_commands_proto))
+}
+
+func TestChangelogReleasesListAnnotatedAdditionsAndReleaseNotes(t *testing.T) {
+	changes := make(map[string][]string)
+	for i, release := range releases {
+		if i > 0 {
+			assert.Equal(t, 1, version.Compare(releases[i-1].Version, release.Version), "releases must be newest first")
+		}
+		changes[release.Version] = release.Changes
+	}
+
+	assert.Equal(t, []string{"Add the ListenEvents method", "Add the ListenResend method"}, changes["1.4.0"])
+	assert.Equal(t, []string{"Add the payload field of ListenEventsResponse.Event"}, changes["1.6.0"])
+	assert.Equal(t, []string{
+		"Add the filter_request_ids field of LogsTailRequest",
+		"Add the connected_accounts field of LogsTailRequest",
+		"Add the all_connected_accounts field of LogsTailRequest",
+		"Fix the filter_request_statuses filter of LogsTail, which filtered on the opposite status",
+	}, changes["1.2.0"])
+	assert.Contains(t, changes["1.0.0"], "Deprecate the unversioned rpc.StripeCLI service")
+}
@@ -0,0 +1,57 @@
+package rpcservice
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/stripe/stripe-cli/rpc"
+)
+
+//go:embed dashboard/*
+var dashboardFiles embed.FS
+
+// dashboardCSP is the content security policy of the dashboard, whose page
+// only loads its own files and calls the gateway
+const dashboardCSP = "default-src 'self'; frame-ancestors 'none'"
+
+// dashboardHandler serves the files of the dashboard, the Listen request it
+// starts with at /dashboard/listen.json, and the methods of the gateway it
+// calls under /v1/
+func (srv *RPCService) dashboardHandler(gw http.Handler) http.Handler {
+	files, _ := fs.Sub(dashboardFiles, "dashboard")
+	fileServer := http.FileServer(http.FS(files))
+
+	listen := srv.cfg.DashboardListen
+	if listen == nil {
+		listen = &rpc.ListenRequest{}
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(gatewayPrefix, gw)
+	mux.HandleFunc("/dashboard/listen.json", func(w http.ResponseWriter, r *http.Request) {
+		data, err := protojson.Marshal(listen)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data) // #nosec G104
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Security-Policy", dashboardCSP)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		fileServer.ServeHTTP(w, r)
+	})
+
+	return mux
+}
@@ -0,0 +1,331 @@
+"use strict";
+
+// The page calls the methods of the HTTP/JSON gateway of `stripe daemon`,
+// which requires the same header as gRPC clients
+const headers = { "Content-Type": "application/json", "sec-x-stripe-cli": "1" };
+
+// maxLogs is the number of request logs kept by the page
+const maxLogs = 500;
+
+// retryDelay is the delay before a stream that ended is started again
+const retryDelay = 5000;
+
+const state = {
+  tab: "events",
+  query: "",
+  events: [],
+  logs: [],
+  selected: null,
+};
+
+async function call(method, body) {
+  const resp = await fetch("/v1/" + method, { method: "POST", headers, body: JSON.stringify(body || {}) });
+  const data = await resp.json();
+  if (data.error) {
+    throw new Error(data.error.message);
+  }
+  return data;
+}
+
+// stream calls a streaming method, and calls onMessage with each message of
+// the stream, which the gateway writes as a line of JSON
+async function stream(method, body, onMessage) {
+  const resp = await fetch("/v1/" + method, { method: "POST", headers, body: JSON.stringify(body || {}) });
+  const reader = resp.body.getReader();
+  const decoder = new TextDecoder();
+  let buffer = "";
+
+  for (;;) {
+    const { done, value } = await reader.read();
+    if (done) {
+      break;
+    }
+
+    buffer += decoder.decode(value, { stream: true });
+    const lines = buffer.split("\n");
+    buffer = lines.pop();
+
+    for (const line of lines) {
+      if (line.trim() === "") {
+        continue;
+      }
+      const message = JSON.parse(line);
+      if (message.error) {
+        throw new Error(message.error.message);
+      }
+      onMessage(message);
+    }
+  }
+}
+
+function setStatus(id, name, text, className) {
+  const el = document.getElementById(id);
+  el.textContent = name + ": " + text;
+  el.className = "status " + (className || "");
+}
+
+function streamState(value) {
+  return (value || "").replace("STATE_", "").toLowerCase();
+}
+
+async function runListen() {
+  try {
+    const req = await (await fetch("/dashboard/listen.json")).json();
+    await stream("Listen", req, (message) => {
+      if (message.state) {
+        const value = streamState(message.state);
+        setStatus("listen-status", "listen", value, value === "ready" ? "ready" : "");
+      } else {
+        refreshEvents();
+      }
+    });
+    setStatus("listen-status", "listen", "stopped", "failed");
+  } catch (err) {
+    setStatus("listen-status", "listen", err.message, "failed");
+  }
+  setTimeout(runListen, retryDelay);
+}
+
+async function runLogsTail() {
+  try {
+    await stream("LogsTail", {}, (message) => {
+      if (message.state) {
+        const value = streamState(message.state);
+        setStatus("logs-status", "logs", value, value === "ready" ? "ready" : "");
+      } else if (message.log) {
+        state.logs.unshift(message.log);
+        state.logs.length = Math.min(state.logs.length, maxLogs);
+        if (state.tab === "logs") {
+          renderList();
+        }
+      }
+    });
+    setStatus("logs-status", "logs", "stopped", "failed");
+  } catch (err) {
+    setStatus("logs-status", "logs", err.message, "failed");
+  }
+  setTimeout(runLogsTail, retryDelay);
+}
+
+async function refreshEvents() {
+  try {
+    const resp = await call("ListenEvents");
+    state.events = (resp.events || []).reverse();
+  } catch (err) {
+    return;
+  }
+
+  if (state.tab === "events") {
+    renderList();
+    if (state.selected && state.selected.kind === "event") {
+      const event = state.events.find((e) => e.id === state.selected.item.id);
+      if (event) {
+        state.selected.item = event;
+        renderDetail();
+      }
+    }
+  }
+}
+
+function formatTime(seconds) {
+  return new Date(Number(seconds) * 1000).toLocaleTimeString();
+}
+
+function lastDelivery(event) {
+  const deliveries = event.deliveries || [];
+  return deliveries[deliveries.length - 1];
+}
+
+function deliveryOK(delivery) {
+  const status = Number(delivery.status || 0);
+  return !delivery.error && status >= 200 && status < 300;
+}
+
+function matches(item) {
+  if (state.query === "") {
+    return true;
+  }
+  return JSON.stringify(item).toLowerCase().includes(state.query);
+}
+
+function cell(row, text, className) {
+  const td = document.createElement("td");
+  td.textContent = text;
+  if (className) {
+    td.className = className;
+  }
+  row.appendChild(td);
+}
+
+function renderHead(columns) {
+  const head = document.getElementById("list-head");
+  head.textContent = "";
+  const row = document.createElement("tr");
+  for (const column of columns) {
+    const th = document.createElement("th");
+    th.textContent = column;
+    row.appendChild(th);
+  }
+  head.appendChild(row);
+}
+
+function renderList() {
+  const body = document.getElementById("list-body");
+  body.textContent = "";
+
+  const items = (state.tab === "events" ? state.events : state.logs).filter(matches);
+
+  if (state.tab === "events") {
+    renderHead(["Received", "Type", "ID", "Delivery"]);
+  } else {
+    renderHead(["Time", "Method", "Status", "Path", "Request ID"]);
+  }
+
+  for (const item of items) {
+    const row = document.createElement("tr");
+    if (state.selected && state.selected.item === item) {
+      row.className = "selected";
+    }
+
+    if (state.tab === "events") {
+      const delivery = lastDelivery(item);
+      cell(row, formatTime(item.receivedAt));
+      cell(row, item.type);
+      cell(row, item.id);
+      if (!delivery) {
+        cell(row, "pending");
+      } else if (deliveryOK(delivery)) {
+        cell(row, String(delivery.status), "ok");
+      } else {
+        cell(row, delivery.error || String(delivery.status), "error");
+      }
+      row.onclick = () => select({ kind: "event", item });
+    } else {
+      const status = Number(item.status || 0);
+      cell(row, formatTime(item.createdAt));
+      cell(row, item.method);
+      cell(row, String(status), status >= 200 && status < 300 ? "ok" : "error");
+      cell(row, item.url);
+      cell(row, item.requestId);
+      row.onclick = () => select({ kind: "log", item });
+    }
+
+    body.appendChild(row);
+  }
+
+  const empty = document.getElementById("empty");
+  empty.hidden = items.length > 0;
+  if (state.query !== "") {
+    empty.textContent = "Nothing matches the search.";
+  } else {
+    empty.textContent = state.tab === "events" ? "Waiting for events…" : "Waiting for request logs…";
+  }
+}
+
+function select(selected) {
+  state.selected = selected;
+  renderList();
+  renderDetail();
+}
+
+function element(tag, text, className) {
+  const el = document.createElement(tag);
+  if (text !== undefined) {
+    el.textContent = text;
+  }
+  if (className) {
+    el.className = className;
+  }
+  return el;
+}
+
+function prettyJSON(value) {
+  try {
+    return JSON.stringify(typeof value === "string" ? JSON.parse(value) : value, null, 2);
+  } catch (err) {
+    return String(value);
+  }
+}
+
+function renderDetail() {
+  const detail = document.getElementById("detail");
+  detail.textContent = "";
+
+  if (!state.selected) {
+    detail.appendChild(element("p", "Select a row to inspect it.", "empty"));
+    return;
+  }
+
+  const { kind, item } = state.selected;
+
+  if (kind === "log") {
+    detail.appendChild(element("h2", item.method + " " + item.url));
+    detail.appendChild(element("p", item.requestId, "subtitle"));
+    detail.appendChild(element("pre", prettyJSON(item)));
+    return;
+  }
+
+  detail.appendChild(element("h2", item.type));
+  detail.appendChild(element("p", item.id, "subtitle"));
+
+  const resend = element("button", "Resend", "action");
+  const message = element("span", "", "message");
+  resend.onclick = async () => {
+    resend.disabled = true;
+    message.textContent = "";
+    try {
+      await call("ListenResend", { eventId: item.id });
+      message.textContent = "Resent";
+      message.className = "message ok";
+      refreshEvents();
+    } catch (err) {
+      message.textContent = err.message;
+      message.className = "message error";
+    }
+    resend.disabled = false;
+  };
+  detail.appendChild(resend);
+  detail.appendChild(message);
+
+  detail.appendChild(element("h2", "Deliveries"));
+  const table = element("table");
+  const head = element("tr");
+  for (const column of ["Time", "URL", "Status", "Latency"]) {
+    head.appendChild(element("th", column));
+  }
+  table.appendChild(head);
+  for (const delivery of item.deliveries || []) {
+    const row = element("tr");
+    cell(row, formatTime(delivery.timestamp));
+    cell(row, delivery.url);
+    cell(row, delivery.error || String(delivery.status), deliveryOK(delivery) ? "ok" : "error");
+    cell(row, Number(delivery.latencyMs || 0) + " ms");
+    table.appendChild(row);
+  }
+  detail.appendChild(table);
+
+  detail.appendChild(element("h2", "Payload"));
+  detail.appendChild(element("pre", prettyJSON(item.payload || "{}")));
+}
+
+for (const tab of document.querySelectorAll(".tab")) {
+  tab.onclick = () => {
+    for (const other of document.querySelectorAll(".tab")) {
+      other.classList.toggle("active", other === tab);
+    }
+    state.tab = tab.dataset.tab;
+    state.selected = null;
+    renderList();
+    renderDetail();
+  };
+}
+
+document.getElementById("search").oninput = (e) => {
+  state.query = e.target.value.trim().toLowerCase();
+  renderList();
+};
+
+renderList();
+runListen();
+runLogsTail();
+setInterval(refreshEvents, 3000);
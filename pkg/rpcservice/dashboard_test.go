@@ -0,0 +1,56 @@
+package rpcservice
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stripe/stripe-cli/rpc"
+)
+
+func TestDashboardHandler(t *testing.T) {
+	srv := New(&Config{
+		Dashboard:       true,
+		DashboardListen: &rpc.ListenRequest{ForwardTo: "localhost:4242/webhooks", Events: []string{"invoice.paid"}},
+	}, nil)
+
+	gw := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("gateway " + r.URL.Path))
+	})
+
+	ts := httptest.NewServer(srv.dashboardHandler(gw))
+	defer ts.Close()
+
+	get := func(path string) (*http.Response, string) {
+		resp, err := http.Get(ts.URL + path)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, err := ioutil.ReadAll(resp.Body)
+		assert.NoError(t, err)
+
+		return resp, string(body)
+	}
+
+	resp, body := get("/")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, dashboardCSP, resp.Header.Get("Content-Security-Policy"))
+	assert.Contains(t, body, "<title>Stripe CLI dashboard</title>")
+
+	resp, body = get("/dashboard.js")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, body, "ListenResend")
+
+	resp, body = get("/dashboard/listen.json")
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.JSONEq(t, `{"forwardTo": "localhost:4242/webhooks", "events": ["invoice.paid"]}`, body)
+
+	_, body = get("/v1/ListenEvents")
+	assert.Equal(t, "gateway /v1/ListenEvents", body)
+
+	resp, _ = get("/missing.js")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -19,7 +19,7 @@ import (
 
 // EventsResend resends an event given an event ID
 func (srv *RPCService) EventsResend(ctx context.Context, req *rpc.EventsResendRequest) (*rpc.EventsResendResponse, error) {
-	apiKey, err := srv.cfg.UserCfg.Profile.GetAPIKey(req.Live)
+	apiKey, err := srv.userConfig().Profile.GetAPIKey(req.Live)
 	if err != nil {
 		return nil, status.Error(codes.Unauthenticated, err.Error())
 	}
@@ -27,7 +27,14 @@ func TestFixturesReturnsData(t *testing.T) {
 		Fixture: `{
   "_meta": {
     "template_version": 0,
-    "exclude_metadata": false
+    "exclude_metadata": false,
+    "description": "Creates a customer.",
+    "parameters": [
+      {
+        "name": "customer:description",
+        "description": "Description of the customer"
+      }
+    ]
   },
   "fixtures": [
     {
@@ -40,7 +47,16 @@ func TestFixturesReturnsData(t *testing.T) {
       }
     }
   ],
-  "env": null
+  "env": null,
+  "teardown": [
+    {
+      "name": "customer_deleted",
+      "expected_error_type": "",
+      "path": "/v1/customers/${customer:id}",
+      "method": "delete",
+      "params": null
+    }
+  ]
 }`,
 	}
 
@@ -0,0 +1,287 @@
+package rpcservice
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/stripe/stripe-cli/pkg/loopback"
+	rpcv1 "github.com/stripe/stripe-cli/rpc/v1"
+)
+
+// gatewayPrefix prefixes the paths of the methods of the gateway, like
+// /v1/Version
+const gatewayPrefix = "/v1/"
+
+// gatewayMaxBody is the maximum size of the JSON body of a gateway request
+const gatewayMaxBody = 1 << 20
+
+// gatewayBufSize is the size of the buffer of the in-memory connection of the
+// gateway to the gRPC server
+const gatewayBufSize = 1024 * 1024
+
+// gatewayService is the service the gateway exposes
+var gatewayService = rpcv1.File_v1_stripe_cli_proto.Services().ByName("StripeCLI")
+
+// gatewayStatuses are the HTTP statuses of the gRPC errors of the gateway.
+// Other codes are internal server errors.
+var gatewayStatuses = map[codes.Code]int{
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.Aborted:            http.StatusConflict,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.Canceled:           499,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+}
+
+// gateway is an HTTP/JSON adapter of the rpc.v1 service, for tools without a
+// gRPC stack. Methods are called with a POST of their request as JSON to
+// /v1/<method>, with the same header as gRPC clients. Streaming methods
+// respond with one JSON message per line.
+type gateway struct {
+	conn *grpc.ClientConn
+}
+
+// newGateway serves the gRPC server on an in-memory listener, and returns the
+// gateway calling it through that listener
+func (srv *RPCService) newGateway() (*gateway, error) {
+	lis := bufconn.Listen(gatewayBufSize)
+	go srv.grpcServer.Serve(lis) // #nosec G104
+
+	creds := grpc.WithInsecure()
+	if srv.tlsConfig != nil {
+		// The connection is in memory, so there's no peer to verify
+		creds = grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+			InsecureSkipVerify: true, // #nosec G402
+			MinVersion:         tls.VersionTLS12,
+		}))
+	}
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		creds,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gateway{conn: conn}, nil
+}
+
+func (gw *gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if origin := r.Header.Get("Origin"); origin != "" {
+		if !loopback.IsOrigin(origin) {
+			writeGatewayError(w, status.Error(codes.PermissionDenied, "only localhost origins are allowed"))
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, "+requiredHeader)
+		w.Header().Add("Vary", "Origin")
+	}
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST, OPTIONS")
+		writeGatewayError(w, status.Errorf(codes.Unimplemented, "%s is not supported, use POST", r.Method))
+		return
+	}
+
+	var method protoreflect.MethodDescriptor
+	if strings.HasPrefix(r.URL.Path, gatewayPrefix) {
+		method = gatewayService.Methods().ByName(protoreflect.Name(strings.TrimPrefix(r.URL.Path, gatewayPrefix)))
+	}
+	if method == nil {
+		writeGatewayError(w, status.Errorf(codes.NotFound, "unknown method %s", r.URL.Path))
+		return
+	}
+
+	// Checked by the interceptors too, but the header isn't forwarded as is
+	if r.Header.Get(requiredHeader) == "" {
+		writeGatewayError(w, status.Errorf(codes.Unauthenticated, "%s header is not supplied", requiredHeader))
+		return
+	}
+
+	req, err := newGatewayMessage(method.Input())
+	if err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, gatewayMaxBody))
+	if err != nil {
+		writeGatewayError(w, status.Error(codes.InvalidArgument, err.Error()))
+		return
+	}
+
+	if len(strings.TrimSpace(string(body))) > 0 {
+		if err := protojson.Unmarshal(body, req); err != nil {
+			writeGatewayError(w, status.Errorf(codes.InvalidArgument, "invalid request: %v", err))
+			return
+		}
+	}
+
+	ctx := metadata.NewOutgoingContext(r.Context(), metadata.Pairs(requiredHeader, "1"))
+	fullMethod := fmt.Sprintf("/%s/%s", gatewayService.FullName(), method.Name())
+
+	if method.IsStreamingServer() {
+		gw.serveStream(ctx, w, method, fullMethod, req)
+		return
+	}
+
+	resp, err := newGatewayMessage(method.Output())
+	if err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+
+	if err := gw.conn.Invoke(ctx, fullMethod, req, resp); err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+
+	data, err := protojson.Marshal(resp)
+	if err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data) // #nosec G104
+}
+
+// serveStream calls a server-streaming method and writes each message of the
+// stream as a line of JSON. An error after the first message is written as a
+// last line, since the status is already sent.
+func (gw *gateway) serveStream(ctx context.Context, w http.ResponseWriter, method protoreflect.MethodDescriptor, fullMethod string, req proto.Message) {
+	stream, err := gw.conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, fullMethod)
+	if err == nil {
+		err = stream.SendMsg(req)
+	}
+	if err == nil {
+		err = stream.CloseSend()
+	}
+	if err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+
+	flusher, _ := w.(http.Flusher)
+	started := false
+
+	for {
+		resp, err := newGatewayMessage(method.Output())
+		if err == nil {
+			err = stream.RecvMsg(resp)
+		}
+
+		if errors.Is(err, io.EOF) {
+			if !started {
+				w.Header().Set("Content-Type", "application/x-ndjson")
+			}
+			return
+		}
+
+		if err != nil {
+			if !started {
+				writeGatewayError(w, err)
+				return
+			}
+
+			fmt.Fprintf(w, "%s\n", gatewayErrorJSON(status.Convert(err)))
+			return
+		}
+
+		data, err := protojson.Marshal(resp)
+		if err != nil {
+			return
+		}
+
+		if !started {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			started = true
+		}
+
+		fmt.Fprintf(w, "%s\n", data)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
+
+// newGatewayMessage returns a new message of the generated type of desc
+func newGatewayMessage(desc protoreflect.MessageDescriptor) (proto.Message, error) {
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(desc.FullName())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return mt.New().Interface(), nil
+}
+
+// writeGatewayError writes a gRPC error as the JSON error of a response
+func writeGatewayError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+
+	code, ok := gatewayStatuses[st.Code()]
+	if !ok {
+		code = http.StatusInternalServerError
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(gatewayErrorJSON(st)) // #nosec G104
+}
+
+// gatewayError is the JSON body of the errors of the gateway, like
+// {"error": {"code": "NotFound", "message": "..."}}
+type gatewayError struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// gatewayErrorJSON returns the JSON of an error
+func gatewayErrorJSON(st *status.Status) []byte {
+	var body gatewayError
+	body.Error.Code = st.Code().String()
+	body.Error.Message = st.Message()
+
+	data, _ := json.Marshal(body)
+
+	return data
+}
@@ -0,0 +1,157 @@
+package rpcservice
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/logtailing"
+	"github.com/stripe/stripe-cli/pkg/websocket"
+)
+
+func newTestGateway(t *testing.T) *httptest.Server {
+	srv := New(&Config{
+		UserCfg: &config.Config{
+			Profile: config.Profile{
+				APIKey:     "sk_test_12345",
+				DeviceName: "rpc_test_device_name",
+			},
+		},
+	}, nil)
+	registerServices(srv.grpcServer, srv)
+
+	gw, err := srv.newGateway()
+	if err != nil {
+		t.Fatalf("Failed to create the gateway: %v", err)
+	}
+
+	ts := httptest.NewServer(gw)
+	t.Cleanup(func() {
+		ts.Close()
+		gw.conn.Close()
+		srv.grpcServer.Stop()
+	})
+
+	return ts
+}
+
+func gatewayRequest(t *testing.T, ts *httptest.Server, method, path, body string, header map[string]string) *http.Response {
+	req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+	assert.NoError(t, err)
+	for name, value := range header {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+
+	return resp
+}
+
+func TestGatewayUnary(t *testing.T) {
+	ts := newTestGateway(t)
+
+	resp := gatewayRequest(t, ts, http.MethodPost, "/v1/Version", "{}", map[string]string{requiredHeader: "1"})
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	var body map[string]string
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "master", body["version"])
+}
+
+func TestGatewayRequiresHeader(t *testing.T) {
+	ts := newTestGateway(t)
+
+	resp := gatewayRequest(t, ts, http.MethodPost, "/v1/Version", "", nil)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	var body gatewayError
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "Unauthenticated", body.Error.Code)
+}
+
+func TestGatewayUnknownMethod(t *testing.T) {
+	ts := newTestGateway(t)
+
+	resp := gatewayRequest(t, ts, http.MethodPost, "/v1/Nope", "", map[string]string{requiredHeader: "1"})
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestGatewayInvalidJSON(t *testing.T) {
+	ts := newTestGateway(t)
+
+	resp := gatewayRequest(t, ts, http.MethodPost, "/v1/Trigger", `{"nope": 1}`, map[string]string{requiredHeader: "1"})
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGatewayCORS(t *testing.T) {
+	ts := newTestGateway(t)
+
+	resp := gatewayRequest(t, ts, http.MethodOptions, "/v1/Version", "", map[string]string{"Origin": "http://localhost:3000"})
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "http://localhost:3000", resp.Header.Get("Access-Control-Allow-Origin"))
+
+	resp = gatewayRequest(t, ts, http.MethodPost, "/v1/Version", "", map[string]string{"Origin": "https://example.com", requiredHeader: "1"})
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+}
+
+func TestGatewayStream(t *testing.T) {
+	createTailer = func(cfg *logtailing.Config) ITailer {
+		run = func(ctx context.Context) error {
+			cfg.OutCh <- websocket.StateElement{
+				State: websocket.Ready,
+			}
+			cfg.OutCh <- websocket.StateElement{
+				State: websocket.Done,
+			}
+			<-ctx.Done()
+			return nil
+		}
+		return &mockTailer{
+			OutCh: cfg.OutCh,
+		}
+	}
+
+	ts := newTestGateway(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ts.URL+"/v1/LogsTail", strings.NewReader("{}"))
+	assert.NoError(t, err)
+	req.Header.Set(requiredHeader, "1")
+
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+
+	scanner := bufio.NewScanner(resp.Body)
+	assert.True(t, scanner.Scan())
+	assert.JSONEq(t, `{"state": "STATE_READY"}`, scanner.Text())
+	assert.True(t, scanner.Scan())
+	assert.JSONEq(t, `{"state": "STATE_DONE"}`, scanner.Text())
+}
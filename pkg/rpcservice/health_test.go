@@ -0,0 +1,63 @@
+package rpcservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+)
+
+func TestHealthCheckWithoutHeader(t *testing.T) {
+	ctx := context.Background()
+
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	for _, service := range []string{"", "rpc.v1.StripeCLI", "rpc.StripeCLI"} {
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		assert.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+	}
+}
+
+func TestReflectionListsServices(t *testing.T) {
+	ctx := context.Background()
+
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	assert.NoError(t, err)
+
+	err = stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	})
+	assert.NoError(t, err)
+
+	resp, err := stream.Recv()
+	assert.NoError(t, err)
+
+	var services []string
+	for _, service := range resp.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+	assert.Contains(t, services, "rpc.v1.StripeCLI")
+	assert.Contains(t, services, "grpc.health.v1.Health")
+}
+
+func TestIsStandardService(t *testing.T) {
+	assert.True(t, isStandardService("/grpc.health.v1.Health/Check"))
+	assert.True(t, isStandardService("/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo"))
+	assert.False(t, isStandardService("/rpc.v1.StripeCLI/Version"))
+}
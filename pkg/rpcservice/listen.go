@@ -11,12 +11,11 @@ import (
 	"google.golang.org/protobuf/types/known/structpb"
 
 	"github.com/stripe/stripe-cli/pkg/proxy"
+	"github.com/stripe/stripe-cli/pkg/stripeauth"
 	"github.com/stripe/stripe-cli/pkg/websocket"
 	"github.com/stripe/stripe-cli/rpc"
 )
 
-const webhooksWebSocketFeature = "webhooks"
-
 var httpMethodMap = map[string]rpc.ListenResponse_EndpointResponse_Data_HttpMethod{
 	http.MethodDelete: rpc.ListenResponse_EndpointResponse_Data_HTTP_METHOD_DELETE,
 	http.MethodGet:    rpc.ListenResponse_EndpointResponse_Data_HTTP_METHOD_GET,
@@ -34,12 +33,12 @@ var createProxy = func(ctx context.Context, cfg *proxy.Config) (IProxy, error) {
 
 // Listen returns a stream of webhook events and forwards them to a local endpoint
 func (srv *RPCService) Listen(req *rpc.ListenRequest, stream rpc.StripeCLI_ListenServer) error {
-	deviceName, err := srv.cfg.UserCfg.Profile.GetDeviceName()
+	deviceName, err := srv.userConfig().Profile.GetDeviceName()
 	if err != nil {
 		return status.Error(codes.Unauthenticated, err.Error())
 	}
 
-	key, err := srv.cfg.UserCfg.Profile.GetAPIKey(req.Live)
+	key, err := srv.userConfig().Profile.GetAPIKey(req.Live)
 	if err != nil {
 		return status.Error(codes.Unauthenticated, err.Error())
 	}
@@ -59,7 +58,7 @@ func (srv *RPCService) Listen(req *rpc.ListenRequest, stream rpc.StripeCLI_Liste
 		ForwardConnectURL:     req.ForwardConnectTo,
 		ForwardConnectHeaders: req.ConnectHeaders,
 		UseConfiguredWebhooks: req.UseConfiguredWebhooks,
-		WebSocketFeature:      webhooksWebSocketFeature,
+		WebSocketFeature:      stripeauth.FeatureWebhooks,
 		UseLatestAPIVersion:   req.Latest,
 		SkipVerify:            req.SkipVerify,
 		Log:                   logger,
@@ -74,6 +73,12 @@ func (srv *RPCService) Listen(req *rpc.ListenRequest, stream rpc.StripeCLI_Liste
 	if err != nil {
 		return err
 	}
+
+	if store, ok := p.(eventStore); ok {
+		srv.addListener(store)
+		defer srv.removeListener(store)
+	}
+
 	go p.Run(ctx)
 
 	for {
@@ -84,6 +89,11 @@ func (srv *RPCService) Listen(req *rpc.ListenRequest, stream rpc.StripeCLI_Liste
 				return err
 			}
 		case <-stream.Context().Done():
+			// Let the client know no more events will come
+			if srv.streams.isDraining() {
+				websocket.StateElement{State: websocket.Done}.Accept(proxyVisitor) // #nosec G104
+				return errShuttingDown
+			}
 			return stream.Context().Err()
 		}
 	}
@@ -93,7 +103,7 @@ func createProxyVisitor(stream *rpc.StripeCLI_ListenServer) *websocket.Visitor {
 	return &websocket.Visitor{
 		VisitError: func(ee websocket.ErrorElement) error {
 			switch ee.Error.(type) {
-			case proxy.FailedToPostError, proxy.FailedToReadResponseError:
+			case proxy.FailedToPostError, proxy.FailedToReadResponseError, proxy.FailedToTransformError, proxy.FailedToFetchRelatedObjectError:
 				// These errors shouldn't end the stream
 				(*stream).Send(buildEndpointResponseErrorResp(ee.Error))
 				return nil
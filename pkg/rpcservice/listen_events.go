@@ -0,0 +1,112 @@
+package rpcservice
+
+import (
+	"context"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/stripe/stripe-cli/pkg/proxy"
+	rpcv1 "github.com/stripe/stripe-cli/rpc/v1"
+)
+
+// eventStore is implemented by the proxies of Listen streams that keep the
+// events they receive
+type eventStore interface {
+	Events() []proxy.EventRecord
+	ResendEvent(id string) error
+}
+
+// addListener registers the proxy of a running Listen stream
+func (srv *RPCService) addListener(store eventStore) {
+	srv.listenersMu.Lock()
+	defer srv.listenersMu.Unlock()
+
+	if srv.listeners == nil {
+		srv.listeners = make(map[eventStore]bool)
+	}
+	srv.listeners[store] = true
+}
+
+// removeListener unregisters the proxy of a Listen stream once it ends
+func (srv *RPCService) removeListener(store eventStore) {
+	srv.listenersMu.Lock()
+	defer srv.listenersMu.Unlock()
+
+	delete(srv.listeners, store)
+}
+
+// activeListeners returns the proxies of the running Listen streams
+func (srv *RPCService) activeListeners() []eventStore {
+	srv.listenersMu.Lock()
+	defer srv.listenersMu.Unlock()
+
+	stores := make([]eventStore, 0, len(srv.listeners))
+	for store := range srv.listeners {
+		stores = append(stores, store)
+	}
+
+	return stores
+}
+
+// ListenEvents returns the last events received by the running Listen streams
+func (srv *RPCService) ListenEvents(ctx context.Context, req *rpcv1.ListenEventsRequest) (*rpcv1.ListenEventsResponse, error) {
+	var records []proxy.EventRecord
+	for _, store := range srv.activeListeners() {
+		records = append(records, store.Events()...)
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].ReceivedAt.Before(records[j].ReceivedAt)
+	})
+
+	events := make([]*rpcv1.ListenEventsResponse_Event, 0, len(records))
+	for _, record := range records {
+		events = append(events, buildListenEvent(record))
+	}
+
+	return &rpcv1.ListenEventsResponse{Events: events}, nil
+}
+
+// ListenResend forwards an event received by a running Listen stream to its
+// local endpoints again
+func (srv *RPCService) ListenResend(ctx context.Context, req *rpcv1.ListenResendRequest) (*rpcv1.ListenResendResponse, error) {
+	if req.EventId == "" {
+		return nil, status.Error(codes.InvalidArgument, "event_id is required")
+	}
+
+	stores := srv.activeListeners()
+	if len(stores) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "no Listen stream is running")
+	}
+
+	for _, store := range stores {
+		if err := store.ResendEvent(req.EventId); err == nil {
+			return &rpcv1.ListenResendResponse{}, nil
+		}
+	}
+
+	return nil, status.Error(codes.NotFound, proxy.EventNotFoundError{ID: req.EventId}.Error())
+}
+
+func buildListenEvent(record proxy.EventRecord) *rpcv1.ListenEventsResponse_Event {
+	deliveries := make([]*rpcv1.ListenEventsResponse_Delivery, 0, len(record.Deliveries))
+	for _, delivery := range record.Deliveries {
+		deliveries = append(deliveries, &rpcv1.ListenEventsResponse_Delivery{
+			Url:       delivery.URL,
+			Status:    int64(delivery.StatusCode),
+			Error:     delivery.Err,
+			LatencyMs: delivery.Latency.Milliseconds(),
+			Timestamp: delivery.Timestamp.Unix(),
+		})
+	}
+
+	return &rpcv1.ListenEventsResponse_Event{
+		Id:         record.Event.ID,
+		Type:       record.Event.Type,
+		ReceivedAt: record.ReceivedAt.Unix(),
+		Deliveries: deliveries,
+		Payload:    record.Event.Payload,
+	}
+}
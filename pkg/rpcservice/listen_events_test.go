@@ -0,0 +1,113 @@
+package rpcservice
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/stripe/stripe-cli/pkg/proxy"
+	rpcv1 "github.com/stripe/stripe-cli/rpc/v1"
+)
+
+type mockEventProxy struct {
+	mu      sync.Mutex
+	resent  []string
+	records []proxy.EventRecord
+}
+
+func (mp *mockEventProxy) Run(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (mp *mockEventProxy) Events() []proxy.EventRecord {
+	return mp.records
+}
+
+func (mp *mockEventProxy) ResendEvent(id string) error {
+	for _, record := range mp.records {
+		if record.Event.ID == id {
+			mp.mu.Lock()
+			mp.resent = append(mp.resent, id)
+			mp.mu.Unlock()
+			return nil
+		}
+	}
+
+	return proxy.EventNotFoundError{ID: id}
+}
+
+func TestListenEventsAndResend(t *testing.T) {
+	ctx := withAuth(context.Background())
+
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := rpcv1.NewStripeCLIClient(conn)
+
+	_, err = client.ListenResend(ctx, &rpcv1.ListenResendRequest{EventId: "evt_123"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	receivedAt := time.Unix(1600000000, 0)
+	mp := &mockEventProxy{
+		records: []proxy.EventRecord{
+			{
+				Event:      proxy.StripeEvent{ID: "evt_123", Type: "customer.created", Payload: `{"id": "evt_123"}`},
+				ReceivedAt: receivedAt,
+				Deliveries: []proxy.EventDelivery{
+					{URL: "http://localhost:4242/webhooks", StatusCode: 200, Latency: 15 * time.Millisecond, Timestamp: receivedAt},
+					{URL: "http://localhost:4242/webhooks", Err: "connection refused", Timestamp: receivedAt},
+				},
+			},
+		},
+	}
+
+	createProxy = func(ctx context.Context, cfg *proxy.Config) (IProxy, error) {
+		return mp, nil
+	}
+
+	listenCtx, cancel := context.WithCancel(ctx)
+	_, err = client.Listen(listenCtx, &rpcv1.ListenRequest{})
+	assert.Nil(t, err)
+
+	var resp *rpcv1.ListenEventsResponse
+	assert.Eventually(t, func() bool {
+		resp, err = client.ListenEvents(ctx, &rpcv1.ListenEventsRequest{})
+		return err == nil && len(resp.Events) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	event := resp.Events[0]
+	assert.Equal(t, "evt_123", event.Id)
+	assert.Equal(t, "customer.created", event.Type)
+	assert.Equal(t, int64(1600000000), event.ReceivedAt)
+	assert.Len(t, event.Deliveries, 2)
+	assert.Equal(t, int64(200), event.Deliveries[0].Status)
+	assert.Equal(t, int64(15), event.Deliveries[0].LatencyMs)
+	assert.Equal(t, "connection refused", event.Deliveries[1].Error)
+	assert.Equal(t, `{"id": "evt_123"}`, event.Payload)
+
+	_, err = client.ListenResend(ctx, &rpcv1.ListenResendRequest{EventId: "evt_123"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"evt_123"}, mp.resent)
+
+	_, err = client.ListenResend(ctx, &rpcv1.ListenResendRequest{EventId: "evt_456"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.ListenResend(ctx, &rpcv1.ListenResendRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// The events of a stream are no longer listed once it ends
+	cancel()
+	assert.Eventually(t, func() bool {
+		resp, err = client.ListenEvents(ctx, &rpcv1.ListenEventsRequest{})
+		return err == nil && len(resp.Events) == 0
+	}, time.Second, 10*time.Millisecond)
+}
@@ -24,7 +24,7 @@ func (srv *RPCService) LoginStatus(ctx context.Context, req *rpc.LoginStatusRequ
 		return nil, err
 	}
 
-	err = configureProfile(srv.cfg.UserCfg, response)
+	err = configureProfile(srv.userConfig(), response)
 	if err != nil {
 		return nil, err
 	}
@@ -24,17 +24,20 @@ var createTailer = func(cfg *logtailing.Config) ITailer {
 
 // LogsTail returns a stream of API logs
 func (srv *RPCService) LogsTail(req *rpc.LogsTailRequest, stream rpc.StripeCLI_LogsTailServer) error {
-	deviceName, err := srv.cfg.UserCfg.Profile.GetDeviceName()
+	deviceName, err := srv.userConfig().Profile.GetDeviceName()
 	if err != nil {
 		return status.Error(codes.Unauthenticated, err.Error())
 	}
 
-	key, err := srv.cfg.UserCfg.Profile.GetAPIKey(false)
+	key, err := srv.userConfig().Profile.GetAPIKey(false)
 	if err != nil {
 		return status.Error(codes.Unauthenticated, err.Error())
 	}
 
 	filters := getFiltersFromReq(req)
+	if err := logtailing.ValidateFilters(filters); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
 
 	logtailingVisitor := createVisitor(&stream)
 
@@ -67,6 +70,11 @@ func (srv *RPCService) LogsTail(req *rpc.LogsTailRequest, stream rpc.StripeCLI_L
 				return err
 			}
 		case <-stream.Context().Done():
+			// Let the client know no more events will come
+			if srv.streams.isDraining() {
+				websocket.StateElement{State: websocket.Done}.Accept(logtailingVisitor) // #nosec G104
+				return errShuttingDown
+			}
 			return stream.Context().Err()
 		}
 	}
@@ -172,9 +180,9 @@ func getFiltersFromReq(req *rpc.LogsTailRequest) *logtailing.LogFilters {
 	for i, v := range filterRequestStatusRaw {
 		switch v {
 		case rpc.LogsTailRequest_REQUEST_STATUS_FAILED:
-			filterRequestStatus[i] = "SUCCEEDED"
-		case rpc.LogsTailRequest_REQUEST_STATUS_SUCCEEDED:
 			filterRequestStatus[i] = "FAILED"
+		case rpc.LogsTailRequest_REQUEST_STATUS_SUCCEEDED:
+			filterRequestStatus[i] = "SUCCEEDED"
 		}
 	}
 
@@ -211,5 +219,8 @@ func getFiltersFromReq(req *rpc.LogsTailRequest) *logtailing.LogFilters {
 		FilterSource:         filterSource,
 		FilterStatusCode:     req.FilterStatusCodes,
 		FilterStatusCodeType: filterStatusCodeType,
+		FilterRequestID:      req.FilterRequestIds,
+		ConnectedAccounts:    req.ConnectedAccounts,
+		AllConnectedAccounts: req.AllConnectedAccounts,
 	}
 }
@@ -169,3 +169,40 @@ func TestLogsTailReturnsError(t *testing.T) {
 	assert.Equal(t, status.Error(codes.Unknown, "my error").Error(), err.Error())
 	assert.Nil(t, resp)
 }
+
+func TestGetFiltersFromReq(t *testing.T) {
+	filters := getFiltersFromReq(&rpc.LogsTailRequest{
+		FilterRequestStatuses: []rpc.LogsTailRequest_RequestStatus{
+			rpc.LogsTailRequest_REQUEST_STATUS_FAILED,
+			rpc.LogsTailRequest_REQUEST_STATUS_SUCCEEDED,
+		},
+		FilterRequestPaths: []string{"/v1/payment_intents.*"},
+		FilterRequestIds:   []string{"req_123"},
+		ConnectedAccounts:  []string{"acct_123"},
+	})
+
+	assert.Equal(t, []string{"FAILED", "SUCCEEDED"}, filters.FilterRequestStatus)
+	assert.Equal(t, []string{"/v1/payment_intents.*"}, filters.FilterRequestPath)
+	assert.Equal(t, []string{"req_123"}, filters.FilterRequestID)
+	assert.Equal(t, []string{"acct_123"}, filters.ConnectedAccounts)
+}
+
+func TestLogsTailRejectsInvalidFilters(t *testing.T) {
+	ctx := withAuth(context.Background())
+
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := rpc.NewStripeCLIClient(conn)
+
+	logsTailClient, err := client.LogsTail(ctx, &rpc.LogsTailRequest{
+		ConnectedAccounts: []string{"cus_123"},
+	})
+	assert.Nil(t, err)
+
+	resp, err := logsTailClient.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Nil(t, resp)
+}
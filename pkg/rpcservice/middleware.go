@@ -50,7 +50,7 @@ func authorize(ctx context.Context) error {
 }
 
 // Populate the context with:
-// 1. The telemetry client from the RPC Service
+// 1. The telemetry client from the RPC Service, unless telemetry is disabled
 // 2. The event metadata
 func updateContextWithTelemetry(ctx context.Context, methodName string, server *RPCService) context.Context {
 	// If the context is nil for whatever reason, create an empty one
@@ -59,7 +59,7 @@ func updateContextWithTelemetry(ctx context.Context, methodName string, server *
 	}
 
 	// if getting the config errors, don't fail running the command
-	merchant, _ := server.cfg.UserCfg.Profile.GetAccountID()
+	merchant, _ := server.userConfig().Profile.GetAccountID()
 	useragent := getUserAgentFromGrpcMetadata(ctx)
 
 	telemetryMetadata := stripe.NewEventMetadata()
@@ -67,7 +67,12 @@ func updateContextWithTelemetry(ctx context.Context, methodName string, server *
 	telemetryMetadata.SetCommandPath(methodName)
 	telemetryMetadata.SetUserAgent(useragent)
 
-	newCtx := stripe.WithEventMetadata(stripe.WithTelemetryClient(ctx, server.TelemetryClient), telemetryMetadata)
+	// The config is checked on each call, as the daemon outlives changes to it
+	if server.userConfig().TelemetryEnabled() {
+		ctx = stripe.WithTelemetryClient(ctx, server.TelemetryClient)
+	}
+
+	newCtx := stripe.WithEventMetadata(ctx, telemetryMetadata)
 	return newCtx
 }
 
@@ -97,12 +102,35 @@ func serverStreamInterceptor(
 	log.WithFields(log.Fields{
 		"prefix": "gRPC",
 	}).Debugf("Streaming method invoked: %v", info.FullMethod)
-	wrappedStream := newWrappedStream(stream, info.FullMethod, srv.(*RPCService))
+	// Tools probe and discover the server without the required header
+	if isStandardService(info.FullMethod) {
+		return handler(srv, stream)
+	}
+	server := serviceFromServer(srv)
+	wrappedStream := newWrappedStream(stream, info.FullMethod, server)
 	if err := authorize(wrappedStream.Context()); err != nil {
 		return err
 	}
-	sendCommandInvocationEvent(wrappedStream.Context())
-	return handler(srv, wrappedStream)
+
+	// The context of the stream is canceled when the server shuts down
+	ctx, done, err := server.streams.start(wrappedStream.Context())
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	if timeout := server.methodTimeout(info.FullMethod, true); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	sendCommandInvocationEvent(ctx)
+	err = handler(srv, &WrappedServerStream{stream, ctx})
+	if err != nil && server.streams.isDraining() {
+		return errShuttingDown
+	}
+	return err
 }
 
 // Middleware for unary requests
@@ -115,10 +143,21 @@ func serverUnaryInterceptor(
 	log.WithFields(log.Fields{
 		"prefix": "gRPC",
 	}).Debugf("Unary method invoked: %v, req: %v", info.FullMethod, req)
-	newCtx := updateContextWithTelemetry(ctx, info.FullMethod, info.Server.(*RPCService))
+	if isStandardService(info.FullMethod) {
+		return handler(ctx, req)
+	}
+	server := serviceFromServer(info.Server)
+	newCtx := updateContextWithTelemetry(ctx, info.FullMethod, server)
 	if err := authorize(newCtx); err != nil {
 		return nil, err
 	}
+
+	if timeout := server.methodTimeout(info.FullMethod, false); timeout > 0 {
+		var cancel context.CancelFunc
+		newCtx, cancel = context.WithTimeout(newCtx, timeout)
+		defer cancel()
+	}
+
 	go sendCommandInvocationEvent(newCtx)
 	return handler(newCtx, req)
 }
@@ -3,6 +3,8 @@ package rpcservice
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 
 	"google.golang.org/grpc"
@@ -10,6 +12,7 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/stripe/stripe-cli/pkg/config"
@@ -88,6 +91,23 @@ func TestUpdateContextWithTelemetry(t *testing.T) {
 	assert.Equal(t, stripe.GetTelemetryClient(newCtx), telemetryClient)
 }
 
+func TestUpdateContextWithTelemetryDisabled(t *testing.T) {
+	profilesFile := filepath.Join(t.TempDir(), "config.toml")
+	err := ioutil.WriteFile(profilesFile, []byte("[telemetry]\n  enabled = false\n"), 0600)
+	assert.NoError(t, err)
+
+	viper.Reset()
+	viper.SetConfigFile(profilesFile)
+	defer viper.Reset()
+
+	rpcService := New(&Config{UserCfg: &config.Config{}}, &stripe.NoOpTelemetryClient{})
+
+	newCtx := updateContextWithTelemetry(context.Background(), "method", rpcService)
+
+	assert.NotNil(t, stripe.GetEventMetadata(newCtx))
+	assert.Nil(t, stripe.GetTelemetryClient(newCtx))
+}
+
 func TestGetUserAgentFromGRPCMetadata(t *testing.T) {
 	// No grpc metadata
 	assert.Equal(t, getUserAgentFromGrpcMetadata(context.Background()), "")
@@ -0,0 +1,17 @@
+//go:build !windows
+// +build !windows
+
+package rpcservice
+
+import (
+	"errors"
+	"net"
+)
+
+// errPipeUnsupported is returned when listening on a named pipe outside of
+// Windows
+var errPipeUnsupported = errors.New("named pipes are only supported on Windows")
+
+func listenPipe(name string) (net.Listener, error) {
+	return nil, errPipeUnsupported
+}
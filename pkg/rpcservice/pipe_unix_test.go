@@ -0,0 +1,15 @@
+//go:build !windows
+// +build !windows
+
+package rpcservice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListenPipeUnsupported(t *testing.T) {
+	_, err := listenPipe(`\\.\pipe\stripe-cli`)
+	require.Equal(t, errPipeUnsupported, err)
+}
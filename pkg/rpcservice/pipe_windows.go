@@ -0,0 +1,29 @@
+//go:build windows
+// +build windows
+
+package rpcservice
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/Microsoft/go-winio"
+	"golang.org/x/sys/windows"
+)
+
+// listenPipe listens on a named pipe only the current user can connect to.
+// Connections from the network are denied, so the pipe is never reachable
+// from other machines either.
+func listenPipe(name string) (net.Listener, error) {
+	token := windows.GetCurrentProcessToken()
+
+	user, err := token.GetTokenUser()
+	if err != nil {
+		return nil, fmt.Errorf("could not get the current user: %v", err)
+	}
+
+	return winio.ListenPipe(name, &winio.PipeConfig{
+		// Deny network users (NU), and allow the current user full access
+		SecurityDescriptor: fmt.Sprintf("D:P(D;;GA;;;NU)(A;;GA;;;%s)", user.User.Sid.String()),
+	})
+}
@@ -0,0 +1,164 @@
+package rpcservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/viper"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+	rpcv1 "github.com/stripe/stripe-cli/rpc/v1"
+)
+
+// GetProfile returns a profile of the config file, the one in use if no name
+// is given
+func (srv *RPCService) GetProfile(ctx context.Context, req *rpcv1.GetProfileRequest) (*rpcv1.GetProfileResponse, error) {
+	name, err := srv.checkProfile(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := srv.buildProfile(name, req.RevealSecrets)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rpcv1.GetProfileResponse{Profile: profile}, nil
+}
+
+// ListProfiles returns the profiles of the config file
+func (srv *RPCService) ListProfiles(ctx context.Context, req *rpcv1.ListProfilesRequest) (*rpcv1.ListProfilesResponse, error) {
+	names := srv.userConfig().ProfileNames()
+
+	profiles := make([]*rpcv1.Profile, 0, len(names))
+	for _, name := range names {
+		profile, err := srv.buildProfile(name, false)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return &rpcv1.ListProfilesResponse{Profiles: profiles}, nil
+}
+
+// SetProfileField sets or unsets a field of a profile, the one in use if no
+// name is given
+func (srv *RPCService) SetProfileField(ctx context.Context, req *rpcv1.SetProfileFieldRequest) (*rpcv1.SetProfileFieldResponse, error) {
+	if req.Field == "" {
+		return nil, status.Error(codes.InvalidArgument, "field is required")
+	}
+
+	name, err := srv.checkProfile(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := config.Profile{ProfileName: name}
+
+	switch {
+	case req.Field == "key_storage" && req.Unset:
+		if err := profile.SetKeyStorage(config.KeyStorageFile); err != nil {
+			return nil, profileError(err)
+		}
+		err = profile.DeleteConfigField(req.Field)
+	case req.Field == "key_storage":
+		err = profile.SetKeyStorage(req.Value)
+	case req.Unset:
+		err = profile.DeleteConfigField(req.Field)
+	default:
+		err = profile.WriteConfigField(req.Field, req.Value)
+	}
+	if err != nil {
+		return nil, profileError(err)
+	}
+
+	return &rpcv1.SetProfileFieldResponse{}, nil
+}
+
+// UseProfile switches the profile the daemon uses for the following calls,
+// and for commands too if persist is set
+func (srv *RPCService) UseProfile(ctx context.Context, req *rpcv1.UseProfileRequest) (*rpcv1.UseProfileResponse, error) {
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	// The default profile can be used again even if it was removed
+	if req.Name != config.DefaultProfileName {
+		if _, err := srv.checkProfile(req.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	srv.userCfgMu.Lock()
+	userCfg := *srv.userCfg
+	userCfg.Profile = config.Profile{
+		ProfileName: req.Name,
+		DeviceName:  srv.userCfg.Profile.DeviceName,
+	}
+	userCfg.ProfileNameSet = true
+	srv.userCfg = &userCfg
+	srv.userCfgMu.Unlock()
+
+	if req.Persist {
+		if err := userCfg.SetDefaultProfile(req.Name); err != nil {
+			return nil, profileError(err)
+		}
+	}
+
+	profile, err := srv.buildProfile(req.Name, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rpcv1.UseProfileResponse{Profile: profile}, nil
+}
+
+// checkProfile returns the name of the profile, the one in use if name is
+// empty, or a NotFound error if the config file has no profile of that name
+func (srv *RPCService) checkProfile(name string) (string, error) {
+	userCfg := srv.userConfig()
+	if name == "" {
+		name = userCfg.Profile.ProfileName
+	}
+
+	for _, profileName := range userCfg.ProfileNames() {
+		if profileName == name {
+			return name, nil
+		}
+	}
+
+	return "", status.Error(codes.NotFound, fmt.Sprintf("profile ‘%s’ does not exist", name))
+}
+
+func (srv *RPCService) buildProfile(name string, reveal bool) (*rpcv1.Profile, error) {
+	profile := config.Profile{ProfileName: name}
+
+	fields, err := profile.GetSettings(reveal)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	_, sandboxName := profile.GetSandbox()
+
+	return &rpcv1.Profile{
+		Name:        name,
+		InUse:       name == srv.userConfig().Profile.ProfileName,
+		AccountId:   viper.GetString(profile.GetConfigField("account_id")),
+		DisplayName: profile.GetDisplayName(),
+		SandboxName: sandboxName,
+		Fields:      fields,
+	}, nil
+}
+
+// profileError returns the error of a change to the config file
+func profileError(err error) error {
+	if errors.Is(err, config.ErrStateless) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	return err
+}
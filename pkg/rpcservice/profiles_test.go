@@ -0,0 +1,124 @@
+package rpcservice
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+	rpcv1 "github.com/stripe/stripe-cli/rpc/v1"
+)
+
+func setupProfiles(t *testing.T) *RPCService {
+	profilesFile := filepath.Join(t.TempDir(), "config.toml")
+	err := ioutil.WriteFile(profilesFile, []byte(`
+[default]
+  account_id = "acct_123"
+  display_name = "Main"
+  test_mode_api_key = "sk_test_1234567890abcdefghij"
+
+[acme]
+  account_id = "acct_456"
+  sandbox_name = "staging"
+  test_mode_api_key = "sk_test_abcdefghij1234567890"
+`), 0600)
+	assert.NoError(t, err)
+
+	viper.Reset()
+	viper.SetConfigFile(profilesFile)
+	assert.NoError(t, viper.ReadInConfig())
+	t.Cleanup(viper.Reset)
+
+	return New(&Config{
+		UserCfg: &config.Config{
+			Profile: config.Profile{
+				ProfileName: "default",
+				DeviceName:  "rpc_test_device_name",
+			},
+		},
+	}, nil)
+}
+
+func TestGetProfile(t *testing.T) {
+	srv := setupProfiles(t)
+
+	resp, err := srv.GetProfile(context.Background(), &rpcv1.GetProfileRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, "default", resp.Profile.Name)
+	assert.True(t, resp.Profile.InUse)
+	assert.Equal(t, "acct_123", resp.Profile.AccountId)
+	assert.Equal(t, "Main", resp.Profile.DisplayName)
+	assert.Equal(t, "sk_test_…ghij", resp.Profile.Fields["test_mode_api_key"])
+
+	resp, err = srv.GetProfile(context.Background(), &rpcv1.GetProfileRequest{Name: "acme", RevealSecrets: true})
+	assert.Nil(t, err)
+	assert.False(t, resp.Profile.InUse)
+	assert.Equal(t, "staging", resp.Profile.SandboxName)
+	assert.Equal(t, "sk_test_abcdefghij1234567890", resp.Profile.Fields["test_mode_api_key"])
+
+	_, err = srv.GetProfile(context.Background(), &rpcv1.GetProfileRequest{Name: "oops"})
+	assert.Equal(t, status.Error(codes.NotFound, "profile ‘oops’ does not exist"), err)
+}
+
+func TestListProfiles(t *testing.T) {
+	srv := setupProfiles(t)
+
+	resp, err := srv.ListProfiles(context.Background(), &rpcv1.ListProfilesRequest{})
+	assert.Nil(t, err)
+	assert.Len(t, resp.Profiles, 2)
+	assert.Equal(t, "acme", resp.Profiles[0].Name)
+	assert.Equal(t, "sk_test_…7890", resp.Profiles[0].Fields["test_mode_api_key"])
+	assert.Equal(t, "default", resp.Profiles[1].Name)
+	assert.True(t, resp.Profiles[1].InUse)
+}
+
+func TestSetProfileField(t *testing.T) {
+	srv := setupProfiles(t)
+
+	_, err := srv.SetProfileField(context.Background(), &rpcv1.SetProfileFieldRequest{Name: "acme", Field: "color", Value: "off"})
+	assert.Nil(t, err)
+	assert.Equal(t, "off", viper.GetString("acme.color"))
+
+	_, err = srv.SetProfileField(context.Background(), &rpcv1.SetProfileFieldRequest{Name: "acme", Field: "color", Unset: true})
+	assert.Nil(t, err)
+	assert.False(t, viper.IsSet("acme.color"))
+
+	_, err = srv.SetProfileField(context.Background(), &rpcv1.SetProfileFieldRequest{Value: "off"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = srv.SetProfileField(context.Background(), &rpcv1.SetProfileFieldRequest{Name: "oops", Field: "color", Value: "off"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestUseProfile(t *testing.T) {
+	srv := setupProfiles(t)
+
+	resp, err := srv.UseProfile(context.Background(), &rpcv1.UseProfileRequest{Name: "acme"})
+	assert.Nil(t, err)
+	assert.Equal(t, "acme", resp.Profile.Name)
+	assert.True(t, resp.Profile.InUse)
+
+	// The following calls use the profile
+	assert.Equal(t, "acme", srv.userConfig().Profile.ProfileName)
+	assert.Equal(t, "rpc_test_device_name", srv.userConfig().Profile.DeviceName)
+
+	key, err := srv.userConfig().Profile.GetAPIKey(false)
+	assert.Nil(t, err)
+	assert.Equal(t, "sk_test_abcdefghij1234567890", key)
+
+	// Commands keep using the default profile unless persist is set
+	assert.Empty(t, viper.GetString("default_profile"))
+
+	_, err = srv.UseProfile(context.Background(), &rpcv1.UseProfileRequest{Name: "acme", Persist: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "acme", viper.GetString("default_profile"))
+
+	_, err = srv.UseProfile(context.Background(), &rpcv1.UseProfileRequest{Name: "oops"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
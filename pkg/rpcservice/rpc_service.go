@@ -2,20 +2,33 @@ package rpcservice
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net"
+	"net/http"
 	"os"
+	"strings"
+	"sync"
 	"syscall"
+	"time"
 
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/netutil"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/reflect/protoreflect"
 
 	"github.com/stripe/stripe-cli/pkg/config"
 	"github.com/stripe/stripe-cli/pkg/stripe"
 	"github.com/stripe/stripe-cli/rpc"
+	rpcv1 "github.com/stripe/stripe-cli/rpc/v1"
 )
 
 // Config provides the configuration for the RPC service.
@@ -23,6 +36,43 @@ type Config struct {
 	// Port is the port number to listen to on localhost
 	Port int
 
+	// Pipe is the name of a Windows named pipe to listen to instead of a port,
+	// like \\.\pipe\stripe-cli. Only the current user can connect to it.
+	Pipe string
+
+	// TLSCert and TLSKey are the PEM files of the certificate and key the
+	// server uses for TLS instead of plaintext
+	TLSCert string
+	TLSKey  string
+
+	// TLSSelfSigned makes the server use TLS with a self-signed certificate
+	// generated when it starts, printed for clients to trust
+	TLSSelfSigned bool
+
+	// Gateway also serves the HTTP/JSON gateway of the rpc.v1 service, on
+	// GatewayPort or else an available port
+	Gateway     bool
+	GatewayPort int
+
+	// Dashboard serves a web UI on the gateway, showing the events forwarded
+	// by Listen and the request logs of LogsTail. It starts a Listen stream
+	// with DashboardListen.
+	Dashboard       bool
+	DashboardListen *rpc.ListenRequest
+
+	// Ready is called with the config of the server once it listens,
+	// instead of printing it
+	Ready func(output ConfigOutput)
+
+	// MaxClients is the number of clients that can be connected at once, and
+	// MaxStreams the number of streams that can run at once. 0 is no limit.
+	MaxClients int
+	MaxStreams int
+
+	// MethodTimeouts are the timeouts of methods by name, like LoginStatus,
+	// instead of the default ones. 0 is no timeout.
+	MethodTimeouts map[string]time.Duration
+
 	// Info, error, etc. logger. Unrelated to API request logs.
 	Log *log.Logger
 
@@ -36,6 +86,24 @@ type RPCService struct {
 
 	grpcServer *grpc.Server
 
+	// tlsConfig is the TLS config of the server, if it uses TLS
+	tlsConfig *tls.Config
+
+	// certPEM is the self-signed certificate of the server, if any
+	certPEM string
+
+	// listeners are the proxies of the running Listen streams, whose events
+	// can be listed and resent
+	listenersMu sync.Mutex
+	listeners   map[eventStore]bool
+
+	// streams are the running streams, canceled when the server shuts down
+	streams streamTracker
+
+	// userCfg is the config of the profile in use, which UseProfile replaces
+	userCfgMu sync.RWMutex
+	userCfg   *config.Config
+
 	// TelemetryClient to use for sending telemetry events
 	TelemetryClient stripe.TelemetryClient
 }
@@ -44,10 +112,24 @@ type RPCService struct {
 // out for clients to parse.
 type ConfigOutput struct {
 	// Host is the IP address of the gRPC server
-	Host string `json:"host"`
+	Host string `json:"host,omitempty"`
 
 	// Port is port number of the gRPC server
-	Port int `json:"port"`
+	Port int `json:"port,omitempty"`
+
+	// Pipe is the name of the named pipe of the gRPC server, instead of Host
+	// and Port
+	Pipe string `json:"pipe,omitempty"`
+
+	// GatewayPort is the port number of the HTTP/JSON gateway, if it's served
+	GatewayPort int `json:"gateway_port,omitempty"`
+
+	// TLS is whether clients must connect with TLS
+	TLS bool `json:"tls,omitempty"`
+
+	// Certificate is the PEM of the self-signed certificate of the server,
+	// for clients to trust
+	Certificate string `json:"certificate,omitempty"`
 }
 
 // New creates a new RPC service
@@ -59,41 +141,193 @@ func New(
 		cfg.Log = &log.Logger{Out: ioutil.Discard}
 	}
 
-	grpcServer := grpc.NewServer(
+	opts := []grpc.ServerOption{
 		grpc.UnaryInterceptor(serverUnaryInterceptor),
 		grpc.StreamInterceptor(serverStreamInterceptor),
-	)
+	}
+
+	tlsCfg, certPEM, err := tlsConfig(cfg)
+	if err != nil {
+		cfg.Log.Fatalf("Failed to set up TLS for the gRPC server: %v", err)
+	}
+	if tlsCfg != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsCfg)))
+	}
+
+	for method := range cfg.MethodTimeouts {
+		if gatewayService.Methods().ByName(protoreflect.Name(method)) == nil {
+			cfg.Log.Fatalf("Failed to set the timeout of ‘%s’, which isn't a method of the gRPC server", method)
+		}
+	}
 
 	return &RPCService{
 		cfg:             cfg,
-		grpcServer:      grpcServer,
+		grpcServer:      grpc.NewServer(opts...),
+		tlsConfig:       tlsCfg,
+		certPEM:         certPEM,
+		userCfg:         cfg.UserCfg,
+		streams:         streamTracker{max: cfg.MaxStreams},
 		TelemetryClient: telemetryClient,
 	}
 }
 
-// Run starts a gRPC server on localhost
+// Run starts a gRPC server on localhost, or on a named pipe. It returns once
+// ctx is done and the server has shut down.
 func (srv *RPCService) Run(ctx context.Context) {
-	lis := srv.createListener()
+	var lis net.Listener
+
+	output := ConfigOutput{
+		TLS:         srv.usesTLS(),
+		Certificate: srv.certPEM,
+	}
+
+	if srv.cfg.Pipe != "" {
+		lis = srv.createPipeListener()
+		output.Pipe = srv.cfg.Pipe
+	} else {
+		lis = srv.createListener(srv.cfg.Port)
+		output.Host, output.Port = srv.tcpAddr(lis)
+	}
+
+	if srv.cfg.MaxClients > 0 {
+		lis = netutil.LimitListener(lis, srv.cfg.MaxClients)
+	}
+
+	registerServices(srv.grpcServer, srv)
 
+	var gatewayServer *http.Server
+	if srv.cfg.Gateway || srv.cfg.Dashboard {
+		gatewayLis := srv.createListener(srv.cfg.GatewayPort)
+		_, output.GatewayPort = srv.tcpAddr(gatewayLis)
+
+		gatewayServer = srv.newGatewayServer()
+		go srv.serveGateway(gatewayServer, gatewayLis)
+	}
+
+	if srv.cfg.Ready != nil {
+		srv.cfg.Ready(output)
+	} else {
+		srv.printConfig(output)
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		<-ctx.Done()
+		srv.shutdown(gatewayServer)
+		close(stopped)
+	}()
+
+	if err := srv.grpcServer.Serve(lis); err != nil {
+		srv.cfg.Log.Fatalf("Failed to serve gRPC server on %s: %v", lis.Addr().String(), err)
+	}
+
+	<-stopped
+}
+
+// tcpAddr returns the host and port of a TCP listener
+func (srv *RPCService) tcpAddr(lis net.Listener) (string, int) {
 	addr, ok := lis.Addr().(*net.TCPAddr)
 	if !ok {
 		srv.cfg.Log.Fatalf("Failed to get the TCP address of the gRPC server")
 	}
-	srv.printConfig(ConfigOutput{
-		Host: addr.IP.String(),
-		Port: addr.Port,
-	})
 
-	rpc.RegisterStripeCLIServer(srv.grpcServer, srv)
+	return addr.IP.String(), addr.Port
+}
 
-	if err := srv.grpcServer.Serve(lis); err != nil {
-		srv.cfg.Log.Fatalf("Failed to serve gRPC server on %s: %v", lis.Addr().String(), err)
+// newGatewayServer returns the HTTP server of the HTTP/JSON gateway of the
+// gRPC server, with TLS if the gRPC server uses it
+func (srv *RPCService) newGatewayServer() *http.Server {
+	gw, err := srv.newGateway()
+	if err != nil {
+		srv.cfg.Log.Fatalf("Failed to start the HTTP gateway: %v", err)
+	}
+
+	var handler http.Handler = gw
+	if srv.cfg.Dashboard {
+		handler = srv.dashboardHandler(gw)
+	}
+
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	if srv.tlsConfig != nil {
+		server.TLSConfig = srv.tlsConfig.Clone()
+	}
+
+	return server
+}
+
+// serveGateway serves the HTTP/JSON gateway on lis, until it's shut down
+func (srv *RPCService) serveGateway(server *http.Server, lis net.Listener) {
+	var err error
+	if server.TLSConfig != nil {
+		err = server.ServeTLS(lis, "", "")
+	} else {
+		err = server.Serve(lis)
+	}
+
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		srv.cfg.Log.Fatalf("Failed to serve the HTTP gateway on %s: %v", lis.Addr().String(), err)
+	}
+}
+
+// usesTLS returns whether the server uses TLS instead of plaintext
+func (srv *RPCService) usesTLS() bool {
+	return srv.tlsConfig != nil
+}
+
+// userConfig returns the config of the profile in use
+func (srv *RPCService) userConfig() *config.Config {
+	srv.userCfgMu.RLock()
+	defer srv.userCfgMu.RUnlock()
+
+	return srv.userCfg
+}
+
+// registerServices registers srv as both the versioned rpc.v1 service and the legacy, unversioned
+// service so that existing clients keep working. The standard health and reflection services are
+// registered too, so that tools like grpcurl can discover the methods and probe readiness.
+func registerServices(grpcServer *grpc.Server, srv *RPCService) {
+	rpcv1.RegisterStripeCLIServer(grpcServer, v1Server{srv})
+	rpc.RegisterStripeCLIServer(grpcServer, srv) //nolint:staticcheck
+	reflection.Register(grpcServer)
+
+	healthServer := health.NewServer()
+	for service := range grpcServer.GetServiceInfo() {
+		healthServer.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
 	}
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
 }
 
-func (srv *RPCService) createListener() net.Listener {
+// isStandardService returns whether a method belongs to the health or reflection services, which
+// don't run CLI commands
+func isStandardService(fullMethod string) bool {
+	for _, service := range []string{healthpb.Health_ServiceDesc.ServiceName, reflectionpb.ServerReflection_ServiceDesc.ServiceName} {
+		if strings.HasPrefix(fullMethod, "/"+service+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// serviceFromServer returns the RPCService behind a registered gRPC service implementation.
+func serviceFromServer(server interface{}) *RPCService {
+	switch s := server.(type) {
+	case *RPCService:
+		return s
+	case v1Server:
+		return s.RPCService
+	default:
+		panic(fmt.Sprintf("unexpected gRPC service implementation %T", server))
+	}
+}
+
+func (srv *RPCService) createListener(port int) net.Listener {
 	// if port is 0, an available port is automatically chosen
-	address := fmt.Sprintf("[%s]:%d", net.IPv6loopback.String(), srv.cfg.Port)
+	address := fmt.Sprintf("[%s]:%d", net.IPv6loopback.String(), port)
 
 	lis, err := net.Listen("tcp", address)
 	if err != nil {
@@ -120,6 +354,14 @@ func (srv *RPCService) createListener() net.Listener {
 	return lis
 }
 
+func (srv *RPCService) createPipeListener() net.Listener {
+	lis, err := listenPipe(srv.cfg.Pipe)
+	if err != nil {
+		srv.cfg.Log.Fatalf("Failed to listen on %s: %v", srv.cfg.Pipe, err)
+	}
+	return lis
+}
+
 func (srv *RPCService) printConfig(configOutput ConfigOutput) {
 	if configOutputMarshalled, err := json.Marshal(configOutput); err != nil {
 		srv.cfg.Log.Fatalf("Failed to write server config to stderr: %v", err)
@@ -2,11 +2,14 @@ package rpcservice
 
 import (
 	"context"
+	"encoding/json"
 	"log"
 	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
 
 	"github.com/stripe/stripe-cli/pkg/config"
-	"github.com/stripe/stripe-cli/rpc"
 
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/test/bufconn"
@@ -27,7 +30,7 @@ func init() {
 		},
 	}, nil)
 
-	rpc.RegisterStripeCLIServer(srv.grpcServer, srv)
+	registerServices(srv.grpcServer, srv)
 
 	go func() {
 		if err := srv.grpcServer.Serve(lis); err != nil {
@@ -44,3 +47,17 @@ func withAuth(ctx context.Context) context.Context {
 	md := metadata.New(map[string]string{requiredHeader: "1"})
 	return metadata.NewOutgoingContext(ctx, md)
 }
+
+func TestConfigOutput(t *testing.T) {
+	tcp, err := json.Marshal(ConfigOutput{Host: "::1", Port: 12111})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"host": "::1", "port": 12111}`, string(tcp))
+
+	pipe, err := json.Marshal(ConfigOutput{Pipe: `\\.\pipe\stripe-cli`})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"pipe": "\\\\.\\pipe\\stripe-cli"}`, string(pipe))
+
+	tls, err := json.Marshal(ConfigOutput{Host: "::1", Port: 12111, TLS: true, Certificate: "PEM"})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"host": "::1", "port": 12111, "tls": true, "certificate": "PEM"}`, string(tls))
+}
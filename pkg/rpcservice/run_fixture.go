@@ -0,0 +1,61 @@
+package rpcservice
+
+import (
+	"github.com/stripe/stripe-cli/pkg/fixtures"
+	rpcv1 "github.com/stripe/stripe-cli/rpc/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RunFixture runs a fixture, streaming each of its steps with the object it created
+func (srv *RPCService) RunFixture(req *rpcv1.RunFixtureRequest, stream rpcv1.StripeCLI_RunFixtureServer) error {
+	if req.Fixture == "" && req.Raw == "" {
+		return status.Error(codes.InvalidArgument, "either fixture or raw must be set")
+	}
+
+	apiKey, err := srv.userConfig().Profile.GetAPIKey(false)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	fixture, err := fixtures.BuildTrigger(
+		req.Fixture,
+		req.StripeAccount,
+		baseURL,
+		apiKey,
+		req.Skip,
+		req.Override,
+		req.Add,
+		req.Remove,
+		req.Raw,
+	)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// The stream ends with the error of a failing step, returned by Execute
+	var sendErr error
+	fixture.OnStep = func(step fixtures.StepProgress) {
+		if step.Err != nil || sendErr != nil {
+			return
+		}
+
+		resp := &rpcv1.RunFixtureResponse{
+			Name:    step.Name,
+			Skipped: step.Skipped,
+		}
+		if step.Resource != nil {
+			resp.ResourceType = step.Resource.Type
+			resp.ResourceId = step.Resource.ID
+		}
+
+		sendErr = stream.Send(resp)
+	}
+
+	if _, err := fixture.Execute(stream.Context()); err != nil {
+		return err
+	}
+
+	return sendErr
+}
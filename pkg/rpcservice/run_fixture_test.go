@@ -0,0 +1,84 @@
+package rpcservice
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	rpcv1 "github.com/stripe/stripe-cli/rpc/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRunFixtureStreamsSteps(t *testing.T) {
+	ctx := withAuth(context.Background())
+
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := rpcv1.NewStripeCLIClient(conn)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		switch url := req.URL.String(); url {
+		case customerPath:
+			res.Write([]byte(`{"id": "cust_12345", "object": "customer"}`))
+		case customerWithIDPath:
+			// Do nothing, we just want to verify this request came in
+		default:
+			t.Errorf("Received an unexpected request URL: %s", req.URL.String())
+		}
+	}))
+	defer ts.Close()
+
+	baseURL = ts.URL
+
+	stream, err := client.RunFixture(ctx, &rpcv1.RunFixtureRequest{
+		Fixture: "customer.deleted",
+	})
+	assert.Nil(t, err)
+
+	var steps []*rpcv1.RunFixtureResponse
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.Nil(t, err)
+		if err != nil {
+			break
+		}
+		steps = append(steps, resp)
+	}
+
+	assert.Len(t, steps, 2)
+	assert.Equal(t, "customer", steps[0].Name)
+	assert.Equal(t, "customer", steps[0].ResourceType)
+	assert.Equal(t, "cust_12345", steps[0].ResourceId)
+	assert.Equal(t, "customer_deleted", steps[1].Name)
+	assert.Empty(t, steps[1].ResourceId)
+}
+
+func TestRunFixtureRequiresFixture(t *testing.T) {
+	ctx := withAuth(context.Background())
+
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := rpcv1.NewStripeCLIClient(conn)
+
+	stream, err := client.RunFixture(ctx, &rpcv1.RunFixtureRequest{})
+	assert.Nil(t, err)
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
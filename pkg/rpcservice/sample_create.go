@@ -2,9 +2,11 @@ package rpcservice
 
 import (
 	"context"
+	"strings"
 
 	"github.com/stripe/stripe-cli/pkg/samples"
 	"github.com/stripe/stripe-cli/rpc"
+	rpcv1 "github.com/stripe/stripe-cli/rpc/v1"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -23,7 +25,7 @@ func (srv *RPCService) SampleCreate(ctx context.Context, req *rpc.SampleCreateRe
 	resultChan := make(chan samples.CreationResult)
 	go createSample(
 		ctx,
-		srv.cfg.UserCfg,
+		srv.userConfig(),
 		req.SampleName,
 		selectedConfig,
 		req.Path,
@@ -46,6 +48,71 @@ func (srv *RPCService) SampleCreate(ctx context.Context, req *rpc.SampleCreateRe
 	return nil, status.Error(codes.Internal, "An unknown error occurred")
 }
 
+// sampleCreateStates are the states of the stream of SampleCreateStream
+var sampleCreateStates = map[samples.CreationStatus]rpcv1.SampleCreateStreamResponse_State{
+	samples.WillInitialize: rpcv1.SampleCreateStreamResponse_STATE_INITIALIZING,
+	samples.DidInitialize:  rpcv1.SampleCreateStreamResponse_STATE_INITIALIZED,
+	samples.WillCopy:       rpcv1.SampleCreateStreamResponse_STATE_COPYING,
+	samples.Copying:        rpcv1.SampleCreateStreamResponse_STATE_COPYING,
+	samples.DidCopy:        rpcv1.SampleCreateStreamResponse_STATE_COPIED,
+	samples.WillConfigure:  rpcv1.SampleCreateStreamResponse_STATE_CONFIGURING,
+	samples.DidConfigure:   rpcv1.SampleCreateStreamResponse_STATE_CONFIGURED,
+	samples.Done:           rpcv1.SampleCreateStreamResponse_STATE_DONE,
+}
+
+// SampleCreateStream creates a sample like SampleCreate, streaming the progress of the creation.
+func (srv *RPCService) SampleCreateStream(req *rpcv1.SampleCreateRequest, stream rpcv1.StripeCLI_SampleCreateStreamServer) error {
+	legacyReq := &rpc.SampleCreateRequest{}
+	if err := convertMessage(req, legacyReq); err != nil {
+		return err
+	}
+
+	selectedConfig, err := getSelectedConfig(legacyReq)
+	if err != nil {
+		return err
+	}
+
+	resultChan := make(chan samples.CreationResult)
+	go createSample(
+		stream.Context(),
+		srv.userConfig(),
+		req.SampleName,
+		selectedConfig,
+		req.Path,
+		req.ForceRefresh,
+		resultChan,
+	)
+
+	// Let the creation finish sending its results if the stream ends early
+	defer func() {
+		for range resultChan {
+		}
+	}()
+
+	for res := range resultChan {
+		if res.Err != nil {
+			return res.Err
+		}
+
+		err := stream.Send(&rpcv1.SampleCreateStreamResponse{
+			State:       sampleCreateStates[res.State],
+			CopiedFiles: int32(res.Progress.Copied),
+			TotalFiles:  int32(res.Progress.Total),
+			Path:        res.Path,
+			PostInstall: res.PostInstall,
+		})
+		if err != nil {
+			return err
+		}
+
+		if res.State == samples.Done {
+			return nil
+		}
+	}
+
+	return status.Error(codes.Internal, "An unknown error occurred")
+}
+
 func getSelectedConfig(req *rpc.SampleCreateRequest) (*samples.SelectedConfig, error) {
 	// Validate the selected integration exists
 	sampleConfig, err := getSampleConfig(req.SampleName, req.ForceRefresh)
@@ -67,11 +134,17 @@ func getSelectedConfig(req *rpc.SampleCreateRequest) (*samples.SelectedConfig, e
 	// Set the sample configuration that we will create
 	selectedClient := "" // Empty string means there's only one option
 	if selectedIntegration.HasMultipleClients() {
+		if !isOption(selectedIntegration.Clients, req.Client) {
+			return nil, status.Errorf(codes.InvalidArgument, "Failed to find the client %s, expected one of %s", req.Client, strings.Join(selectedIntegration.Clients, ", "))
+		}
 		selectedClient = req.Client
 	}
 
 	selectedServer := "" // Empty string means there's only one option
 	if selectedIntegration.HasMultipleServers() {
+		if !isOption(selectedIntegration.Servers, req.Server) {
+			return nil, status.Errorf(codes.InvalidArgument, "Failed to find the server %s, expected one of %s", req.Server, strings.Join(selectedIntegration.Servers, ", "))
+		}
 		selectedServer = req.Server
 	}
 
@@ -81,3 +154,14 @@ func getSelectedConfig(req *rpc.SampleCreateRequest) (*samples.SelectedConfig, e
 		Server:      selectedServer,
 	}, nil
 }
+
+// isOption returns whether value is one of the options of the integration
+func isOption(options []string, value string) bool {
+	for _, option := range options {
+		if option == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -3,6 +3,7 @@ package rpcservice
 import (
 	"context"
 	"errors"
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -10,8 +11,11 @@ import (
 	"github.com/stripe/stripe-cli/pkg/config"
 	"github.com/stripe/stripe-cli/pkg/samples"
 	"github.com/stripe/stripe-cli/rpc"
+	rpcv1 "github.com/stripe/stripe-cli/rpc/v1"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestSampleCreateSucceeds(t *testing.T) {
@@ -207,3 +211,100 @@ func TestSampleCreateFailsWhenCreateSampleFails(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Nil(t, resp)
 }
+
+func TestSampleCreateStreamSendsProgress(t *testing.T) {
+	getSampleConfig = func(sampleName string, forceRefresh bool) (*samples.SampleConfig, error) {
+		return &samples.SampleConfig{
+			Integrations: []samples.SampleConfigIntegration{
+				{
+					Name:    "foo",
+					Clients: []string{"foo-client-1"},
+					Servers: []string{"foo-server-1", "foo-server-2"},
+				},
+			},
+		}, nil
+	}
+
+	createSample = func(
+		ctx context.Context,
+		config *config.Config,
+		sampleName string,
+		selectedConfig *samples.SelectedConfig,
+		destination string,
+		forceRefresh bool,
+		resultChan chan<- samples.CreationResult) {
+		defer close(resultChan)
+		resultChan <- samples.CreationResult{State: samples.WillInitialize}
+		resultChan <- samples.CreationResult{State: samples.Copying, Progress: samples.CopyProgress{Copied: 1, Total: 2}}
+		resultChan <- samples.CreationResult{
+			State:       samples.Done,
+			Path:        "my path",
+			PostInstall: "my post install message",
+		}
+	}
+
+	ctx := withAuth(context.Background())
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := rpcv1.NewStripeCLIClient(conn)
+
+	stream, err := client.SampleCreateStream(ctx, &rpcv1.SampleCreateRequest{
+		SampleName:      "accept-a-card-payment",
+		IntegrationName: "foo",
+		Server:          "foo-server-2",
+		Path:            "my path",
+	})
+	assert.Nil(t, err)
+
+	resp, err := stream.Recv()
+	assert.Nil(t, err)
+	assert.Equal(t, rpcv1.SampleCreateStreamResponse_STATE_INITIALIZING, resp.State)
+
+	resp, err = stream.Recv()
+	assert.Nil(t, err)
+	assert.Equal(t, rpcv1.SampleCreateStreamResponse_STATE_COPYING, resp.State)
+	assert.Equal(t, int32(1), resp.CopiedFiles)
+	assert.Equal(t, int32(2), resp.TotalFiles)
+
+	resp, err = stream.Recv()
+	assert.Nil(t, err)
+	assert.Equal(t, rpcv1.SampleCreateStreamResponse_STATE_DONE, resp.State)
+	assert.Equal(t, "my path", resp.Path)
+	assert.Equal(t, "my post install message", resp.PostInstall)
+
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestSampleCreateFailsWithUnknownServer(t *testing.T) {
+	getSampleConfig = func(sampleName string, forceRefresh bool) (*samples.SampleConfig, error) {
+		return &samples.SampleConfig{
+			Integrations: []samples.SampleConfigIntegration{
+				{
+					Name:    "foo",
+					Servers: []string{"foo-server-1", "foo-server-2"},
+				},
+			},
+		}, nil
+	}
+
+	ctx := withAuth(context.Background())
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := rpc.NewStripeCLIClient(conn)
+
+	resp, err := client.SampleCreate(ctx, &rpc.SampleCreateRequest{
+		SampleName:      "accept-a-card-payment",
+		IntegrationName: "foo",
+		Server:          "bar",
+	})
+
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,145 @@
+package rpcservice
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// drainTimeout is how long the server waits for the running calls to finish
+// when it shuts down, before closing their connections
+const drainTimeout = 10 * time.Second
+
+// defaultUnaryTimeout is how long a unary method may run, unless it's in
+// methodTimeouts
+const defaultUnaryTimeout = time.Minute
+
+// methodTimeouts are the timeouts of the unary methods that wait for more
+// than defaultUnaryTimeout, by method name. Streaming methods run until the
+// client cancels them, unless Config.MethodTimeouts sets a timeout.
+var methodTimeouts = map[string]time.Duration{
+	// Waits for the user to log in in the browser
+	"LoginStatus": 10 * time.Minute,
+
+	// Clones the repository of the sample
+	"SampleCreate": 10 * time.Minute,
+}
+
+// errShuttingDown ends the streams that are running when the server shuts
+// down
+var errShuttingDown = status.Error(codes.Unavailable, "the daemon is shutting down")
+
+// streamTracker keeps the running streams, to limit them and cancel them
+// when the server shuts down
+type streamTracker struct {
+	mu       sync.Mutex
+	max      int
+	nextID   uint64
+	cancels  map[uint64]context.CancelFunc
+	draining bool
+}
+
+// start registers a stream, returning the context it runs with and the
+// function to call once it ends. It fails if the server is shutting down, or
+// if max streams are running.
+func (t *streamTracker) start(ctx context.Context) (context.Context, func(), error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.draining {
+		return nil, nil, errShuttingDown
+	}
+
+	if t.max > 0 && len(t.cancels) >= t.max {
+		return nil, nil, status.Errorf(codes.ResourceExhausted, "the daemon already runs %d streams, which is its limit", t.max)
+	}
+
+	if t.cancels == nil {
+		t.cancels = make(map[uint64]context.CancelFunc)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	id := t.nextID
+	t.nextID++
+	t.cancels[id] = cancel
+
+	return ctx, func() {
+		t.mu.Lock()
+		delete(t.cancels, id)
+		t.mu.Unlock()
+
+		cancel()
+	}, nil
+}
+
+// drain refuses new streams and cancels the running ones
+func (t *streamTracker) drain() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.draining = true
+	for _, cancel := range t.cancels {
+		cancel()
+	}
+}
+
+// isDraining returns whether the server is shutting down
+func (t *streamTracker) isDraining() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.draining
+}
+
+// shutdown ends the running streams, which send a final message to their
+// clients, waits for the running unary calls to finish, and stops the server
+func (srv *RPCService) shutdown(gatewayServer *http.Server) {
+	srv.cfg.Log.WithFields(log.Fields{
+		"prefix": "rpcservice.RPCService.shutdown",
+	}).Debug("Shutting down, finishing the running calls")
+
+	srv.streams.drain()
+
+	stopped := make(chan struct{})
+	go func() {
+		srv.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	if gatewayServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+		gatewayServer.Shutdown(ctx) // #nosec G104
+		cancel()
+	}
+
+	select {
+	case <-stopped:
+	case <-time.After(drainTimeout):
+		srv.grpcServer.Stop()
+	}
+}
+
+// methodTimeout returns the timeout of a method, 0 for none
+func (srv *RPCService) methodTimeout(fullMethod string, streaming bool) time.Duration {
+	name := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+
+	if timeout, ok := srv.cfg.MethodTimeouts[name]; ok {
+		return timeout
+	}
+
+	if streaming {
+		return 0
+	}
+
+	if timeout, ok := methodTimeouts[name]; ok {
+		return timeout
+	}
+
+	return defaultUnaryTimeout
+}
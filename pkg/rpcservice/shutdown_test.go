@@ -0,0 +1,101 @@
+package rpcservice
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/proxy"
+	rpcv1 "github.com/stripe/stripe-cli/rpc/v1"
+)
+
+func TestStreamTrackerLimit(t *testing.T) {
+	tracker := streamTracker{max: 1}
+
+	ctx, done, err := tracker.start(context.Background())
+	assert.Nil(t, err)
+
+	_, _, err = tracker.start(context.Background())
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	done()
+	assert.Equal(t, context.Canceled, ctx.Err())
+
+	ctx, _, err = tracker.start(context.Background())
+	assert.Nil(t, err)
+
+	tracker.drain()
+	assert.True(t, tracker.isDraining())
+	assert.Equal(t, context.Canceled, ctx.Err())
+
+	_, _, err = tracker.start(context.Background())
+	assert.Equal(t, errShuttingDown, err)
+}
+
+func TestMethodTimeout(t *testing.T) {
+	srv := New(&Config{
+		UserCfg:        &config.Config{},
+		MethodTimeouts: map[string]time.Duration{"Listen": time.Hour, "Version": 0},
+	}, nil)
+
+	assert.Equal(t, defaultUnaryTimeout, srv.methodTimeout("/rpc.v1.StripeCLI/Trigger", false))
+	assert.Equal(t, 10*time.Minute, srv.methodTimeout("/rpc.v1.StripeCLI/LoginStatus", false))
+	assert.Equal(t, time.Duration(0), srv.methodTimeout("/rpc.v1.StripeCLI/Version", false))
+	assert.Equal(t, time.Duration(0), srv.methodTimeout("/rpc.v1.StripeCLI/LogsTail", true))
+	assert.Equal(t, time.Hour, srv.methodTimeout("/rpc.StripeCLI/Listen", true))
+}
+
+func TestShutdownEndsStreams(t *testing.T) {
+	srv := New(&Config{
+		UserCfg: &config.Config{
+			Profile: config.Profile{
+				APIKey:     "sk_test_12345",
+				DeviceName: "rpc_test_device_name",
+			},
+		},
+	}, nil)
+	registerServices(srv.grpcServer, srv)
+
+	shutdownLis := bufconn.Listen(bufSize)
+	go srv.grpcServer.Serve(shutdownLis) // #nosec G104
+
+	ctx := withAuth(context.Background())
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return shutdownLis.Dial()
+	}), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := rpcv1.NewStripeCLIClient(conn)
+
+	createProxy = func(ctx context.Context, cfg *proxy.Config) (IProxy, error) {
+		return &mockEventProxy{}, nil
+	}
+
+	listenClient, err := client.Listen(ctx, &rpcv1.ListenRequest{})
+	assert.Nil(t, err)
+
+	// Wait for the stream to run before shutting down
+	assert.Eventually(t, func() bool {
+		_, err := client.ListenEvents(ctx, &rpcv1.ListenEventsRequest{})
+		return err == nil && len(srv.activeListeners()) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	srv.shutdown(nil)
+
+	resp, err := listenClient.Recv()
+	assert.Nil(t, err)
+	assert.Equal(t, rpcv1.ListenResponse_STATE_DONE, resp.GetState())
+
+	_, err = listenClient.Recv()
+	assert.Equal(t, errShuttingDown.Error(), err.Error())
+}
@@ -0,0 +1,54 @@
+package rpcservice
+
+import (
+	"crypto/tls"
+	"time"
+
+	"github.com/stripe/stripe-cli/pkg/localcert"
+)
+
+// selfSignedValidity is how long the self-signed certificates are valid for.
+// They're generated each time the server starts.
+const selfSignedValidity = 24 * time.Hour
+
+// tlsConfig returns the TLS config of the server, and the PEM of the
+// certificate if it's self-signed, so that clients can trust it. The config
+// is nil if the server doesn't use TLS.
+func tlsConfig(cfg *Config) (*tls.Config, string, error) {
+	var cert tls.Certificate
+	var certPEM string
+	var err error
+
+	switch {
+	case cfg.TLSSelfSigned:
+		cert, certPEM, err = generateSelfSignedCert()
+	case cfg.TLSCert != "" || cfg.TLSKey != "":
+		cert, err = tls.LoadX509KeyPair(cfg.TLSCert, cfg.TLSKey)
+	default:
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}, certPEM, nil
+}
+
+// generateSelfSignedCert generates a certificate for the loopback addresses
+// the server listens on, and returns it with its PEM
+func generateSelfSignedCert() (tls.Certificate, string, error) {
+	certPEM, keyPEM, err := localcert.Generate(selfSignedValidity)
+	if err != nil {
+		return tls.Certificate{}, "", err
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return tls.Certificate{}, "", err
+	}
+
+	return cert, string(certPEM), nil
+}
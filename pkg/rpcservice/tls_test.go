@@ -0,0 +1,62 @@
+package rpcservice
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+	rpcv1 "github.com/stripe/stripe-cli/rpc/v1"
+)
+
+func TestSelfSignedTLS(t *testing.T) {
+	srv := New(&Config{
+		TLSSelfSigned: true,
+		UserCfg:       &config.Config{},
+	}, nil)
+	assert.True(t, srv.usesTLS())
+	assert.Contains(t, srv.certPEM, "-----BEGIN CERTIFICATE-----")
+
+	tlsLis := bufconn.Listen(bufSize)
+	registerServices(srv.grpcServer, srv)
+	go srv.grpcServer.Serve(tlsLis) // #nosec G104
+	defer srv.grpcServer.Stop()
+
+	pool := x509.NewCertPool()
+	assert.True(t, pool.AppendCertsFromPEM([]byte(srv.certPEM)))
+	creds := credentials.NewTLS(&tls.Config{RootCAs: pool, ServerName: "localhost", MinVersion: tls.VersionTLS12})
+
+	ctx := withAuth(context.Background())
+	dialer := func(context.Context, string) (net.Conn, error) {
+		return tlsLis.Dial()
+	}
+
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(dialer), grpc.WithTransportCredentials(creds))
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := rpcv1.NewStripeCLIClient(conn).Version(ctx, &rpcv1.VersionRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, "master", resp.GetVersion())
+}
+
+func TestTLSConfigPlaintext(t *testing.T) {
+	tlsCfg, certPEM, err := tlsConfig(&Config{})
+	assert.NoError(t, err)
+	assert.Nil(t, tlsCfg)
+	assert.Empty(t, certPEM)
+}
+
+func TestTLSConfigMissingFiles(t *testing.T) {
+	_, _, err := tlsConfig(&Config{TLSCert: "missing.pem", TLSKey: "missing-key.pem"})
+	assert.Error(t, err)
+}
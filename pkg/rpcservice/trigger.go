@@ -15,7 +15,7 @@ var baseURL = stripe.DefaultAPIBaseURL
 
 // Trigger triggers a Stripe event.
 func (srv *RPCService) Trigger(ctx context.Context, req *rpc.TriggerRequest) (*rpc.TriggerResponse, error) {
-	apiKey, err := srv.cfg.UserCfg.Profile.GetAPIKey(false)
+	apiKey, err := srv.userConfig().Profile.GetAPIKey(false)
 	if err != nil {
 		return nil, status.Error(codes.Unauthenticated, err.Error())
 	}
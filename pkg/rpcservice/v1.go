@@ -0,0 +1,260 @@
+package rpcservice
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/stripe/stripe-cli/rpc"
+	rpcv1 "github.com/stripe/stripe-cli/rpc/v1"
+)
+
+// v1Server serves the rpc.v1 API. The methods it shares with the legacy service are implemented
+// once, with the legacy messages, and adapted to the rpc.v1 messages, which have the same fields.
+type v1Server struct {
+	*RPCService
+}
+
+// convertMessage copies src into dst, a message with the same fields in the other package, like
+// rpc.VersionRequest and rpcv1.VersionRequest
+func convertMessage(src, dst proto.Message) error {
+	data, err := proto.Marshal(src)
+	if err != nil {
+		return err
+	}
+
+	return proto.Unmarshal(data, dst)
+}
+
+// callLegacy converts req into legacyReq, makes call with it, and converts the response of call
+// into resp
+func callLegacy(req, legacyReq, resp proto.Message, call func() (proto.Message, error)) error {
+	if err := convertMessage(req, legacyReq); err != nil {
+		return err
+	}
+
+	legacyResp, err := call()
+	if err != nil {
+		return err
+	}
+
+	return convertMessage(legacyResp, resp)
+}
+
+// EventsResend implements rpcv1.StripeCLIServer
+func (srv v1Server) EventsResend(ctx context.Context, req *rpcv1.EventsResendRequest) (*rpcv1.EventsResendResponse, error) {
+	legacyReq, resp := &rpc.EventsResendRequest{}, &rpcv1.EventsResendResponse{}
+
+	err := callLegacy(req, legacyReq, resp, func() (proto.Message, error) {
+		return srv.RPCService.EventsResend(ctx, legacyReq)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Fixture implements rpcv1.StripeCLIServer
+func (srv v1Server) Fixture(ctx context.Context, req *rpcv1.FixtureRequest) (*rpcv1.FixtureResponse, error) {
+	legacyReq, resp := &rpc.FixtureRequest{}, &rpcv1.FixtureResponse{}
+
+	err := callLegacy(req, legacyReq, resp, func() (proto.Message, error) {
+		return srv.RPCService.Fixture(ctx, legacyReq)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Login implements rpcv1.StripeCLIServer
+func (srv v1Server) Login(ctx context.Context, req *rpcv1.LoginRequest) (*rpcv1.LoginResponse, error) {
+	legacyReq, resp := &rpc.LoginRequest{}, &rpcv1.LoginResponse{}
+
+	err := callLegacy(req, legacyReq, resp, func() (proto.Message, error) {
+		return srv.RPCService.Login(ctx, legacyReq)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// LoginStatus implements rpcv1.StripeCLIServer
+func (srv v1Server) LoginStatus(ctx context.Context, req *rpcv1.LoginStatusRequest) (*rpcv1.LoginStatusResponse, error) {
+	legacyReq, resp := &rpc.LoginStatusRequest{}, &rpcv1.LoginStatusResponse{}
+
+	err := callLegacy(req, legacyReq, resp, func() (proto.Message, error) {
+		return srv.RPCService.LoginStatus(ctx, legacyReq)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// SampleConfigs implements rpcv1.StripeCLIServer
+func (srv v1Server) SampleConfigs(ctx context.Context, req *rpcv1.SampleConfigsRequest) (*rpcv1.SampleConfigsResponse, error) {
+	legacyReq, resp := &rpc.SampleConfigsRequest{}, &rpcv1.SampleConfigsResponse{}
+
+	err := callLegacy(req, legacyReq, resp, func() (proto.Message, error) {
+		return srv.RPCService.SampleConfigs(ctx, legacyReq)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// SampleCreate implements rpcv1.StripeCLIServer
+func (srv v1Server) SampleCreate(ctx context.Context, req *rpcv1.SampleCreateRequest) (*rpcv1.SampleCreateResponse, error) {
+	legacyReq, resp := &rpc.SampleCreateRequest{}, &rpcv1.SampleCreateResponse{}
+
+	err := callLegacy(req, legacyReq, resp, func() (proto.Message, error) {
+		return srv.RPCService.SampleCreate(ctx, legacyReq)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// SamplesList implements rpcv1.StripeCLIServer
+func (srv v1Server) SamplesList(ctx context.Context, req *rpcv1.SamplesListRequest) (*rpcv1.SamplesListResponse, error) {
+	legacyReq, resp := &rpc.SamplesListRequest{}, &rpcv1.SamplesListResponse{}
+
+	err := callLegacy(req, legacyReq, resp, func() (proto.Message, error) {
+		return srv.RPCService.SamplesList(ctx, legacyReq)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Trigger implements rpcv1.StripeCLIServer
+func (srv v1Server) Trigger(ctx context.Context, req *rpcv1.TriggerRequest) (*rpcv1.TriggerResponse, error) {
+	legacyReq, resp := &rpc.TriggerRequest{}, &rpcv1.TriggerResponse{}
+
+	err := callLegacy(req, legacyReq, resp, func() (proto.Message, error) {
+		return srv.RPCService.Trigger(ctx, legacyReq)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// TriggersList implements rpcv1.StripeCLIServer
+func (srv v1Server) TriggersList(ctx context.Context, req *rpcv1.TriggersListRequest) (*rpcv1.TriggersListResponse, error) {
+	legacyReq, resp := &rpc.TriggersListRequest{}, &rpcv1.TriggersListResponse{}
+
+	err := callLegacy(req, legacyReq, resp, func() (proto.Message, error) {
+		return srv.RPCService.TriggersList(ctx, legacyReq)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Version implements rpcv1.StripeCLIServer
+func (srv v1Server) Version(ctx context.Context, req *rpcv1.VersionRequest) (*rpcv1.VersionResponse, error) {
+	legacyReq, resp := &rpc.VersionRequest{}, &rpcv1.VersionResponse{}
+
+	err := callLegacy(req, legacyReq, resp, func() (proto.Message, error) {
+		return srv.RPCService.Version(ctx, legacyReq)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// WebhookEndpointCreate implements rpcv1.StripeCLIServer
+func (srv v1Server) WebhookEndpointCreate(ctx context.Context, req *rpcv1.WebhookEndpointCreateRequest) (*rpcv1.WebhookEndpointCreateResponse, error) {
+	legacyReq, resp := &rpc.WebhookEndpointCreateRequest{}, &rpcv1.WebhookEndpointCreateResponse{}
+
+	err := callLegacy(req, legacyReq, resp, func() (proto.Message, error) {
+		return srv.RPCService.WebhookEndpointCreate(ctx, legacyReq)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// WebhookEndpointsList implements rpcv1.StripeCLIServer
+func (srv v1Server) WebhookEndpointsList(ctx context.Context, req *rpcv1.WebhookEndpointsListRequest) (*rpcv1.WebhookEndpointsListResponse, error) {
+	legacyReq, resp := &rpc.WebhookEndpointsListRequest{}, &rpcv1.WebhookEndpointsListResponse{}
+
+	err := callLegacy(req, legacyReq, resp, func() (proto.Message, error) {
+		return srv.RPCService.WebhookEndpointsList(ctx, legacyReq)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Listen implements rpcv1.StripeCLIServer
+func (srv v1Server) Listen(req *rpcv1.ListenRequest, stream rpcv1.StripeCLI_ListenServer) error {
+	legacyReq := &rpc.ListenRequest{}
+	if err := convertMessage(req, legacyReq); err != nil {
+		return err
+	}
+
+	return srv.RPCService.Listen(legacyReq, v1ListenStream{stream})
+}
+
+// v1ListenStream sends the responses of the legacy Listen method on an rpc.v1 stream
+type v1ListenStream struct {
+	rpcv1.StripeCLI_ListenServer
+}
+
+// Send implements rpc.StripeCLI_ListenServer
+func (s v1ListenStream) Send(resp *rpc.ListenResponse) error {
+	v1Resp := &rpcv1.ListenResponse{}
+	if err := convertMessage(resp, v1Resp); err != nil {
+		return err
+	}
+
+	return s.StripeCLI_ListenServer.Send(v1Resp)
+}
+
+// LogsTail implements rpcv1.StripeCLIServer
+func (srv v1Server) LogsTail(req *rpcv1.LogsTailRequest, stream rpcv1.StripeCLI_LogsTailServer) error {
+	legacyReq := &rpc.LogsTailRequest{}
+	if err := convertMessage(req, legacyReq); err != nil {
+		return err
+	}
+
+	return srv.RPCService.LogsTail(legacyReq, v1LogsTailStream{stream})
+}
+
+// v1LogsTailStream sends the responses of the legacy LogsTail method on an rpc.v1 stream
+type v1LogsTailStream struct {
+	rpcv1.StripeCLI_LogsTailServer
+}
+
+// Send implements rpc.StripeCLI_LogsTailServer
+func (s v1LogsTailStream) Send(resp *rpc.LogsTailResponse) error {
+	v1Resp := &rpcv1.LogsTailResponse{}
+	if err := convertMessage(resp, v1Resp); err != nil {
+		return err
+	}
+
+	return s.StripeCLI_LogsTailServer.Send(v1Resp)
+}
@@ -0,0 +1,125 @@
+package rpcservice
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/rpc"
+	rpcv1 "github.com/stripe/stripe-cli/rpc/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestV1ServesTheRPCsOfTheDeprecatedService checks the methods rpc.v1.StripeCLI shares with
+// rpc.StripeCLI return the same responses through both services.
+func TestV1ServesTheRPCsOfTheDeprecatedService(t *testing.T) {
+	ctx := withAuth(context.Background())
+
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := rpc.NewStripeCLIClient(conn)
+	v1Client := rpcv1.NewStripeCLIClient(conn)
+
+	tests := []struct {
+		name string
+		call func(ctx context.Context) (proto.Message, error)
+		v1   func(ctx context.Context) (proto.Message, error)
+	}{
+		{
+			name: "Version",
+			call: func(ctx context.Context) (proto.Message, error) {
+				return client.Version(ctx, &rpc.VersionRequest{})
+			},
+			v1: func(ctx context.Context) (proto.Message, error) {
+				return v1Client.Version(ctx, &rpcv1.VersionRequest{})
+			},
+		},
+		{
+			name: "Fixture",
+			call: func(ctx context.Context) (proto.Message, error) {
+				return client.Fixture(ctx, &rpc.FixtureRequest{Event: "customer.created"})
+			},
+			v1: func(ctx context.Context) (proto.Message, error) {
+				return v1Client.Fixture(ctx, &rpcv1.FixtureRequest{Event: "customer.created"})
+			},
+		},
+		{
+			name: "TriggersList",
+			call: func(ctx context.Context) (proto.Message, error) {
+				return client.TriggersList(ctx, &rpc.TriggersListRequest{})
+			},
+			v1: func(ctx context.Context) (proto.Message, error) {
+				return v1Client.TriggersList(ctx, &rpcv1.TriggersListRequest{})
+			},
+		},
+		{
+			name: "EventsResend without event ID",
+			call: func(ctx context.Context) (proto.Message, error) {
+				return client.EventsResend(ctx, &rpc.EventsResendRequest{})
+			},
+			v1: func(ctx context.Context) (proto.Message, error) {
+				return v1Client.EventsResend(ctx, &rpcv1.EventsResendRequest{})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected, expectedErr := tt.call(ctx)
+			actual, err := tt.v1(ctx)
+
+			assert.Equal(t, status.Code(expectedErr), status.Code(err))
+			if expectedErr == nil {
+				require.NoError(t, err)
+
+				// The rpc.v1 messages have the same fields as the legacy ones
+				converted := expected.ProtoReflect().New().Interface()
+				require.NoError(t, convertMessage(actual, converted))
+				assert.True(t, proto.Equal(expected, converted), "expected %v, got %v", expected, converted)
+			}
+		})
+	}
+}
+
+func TestV1VersionReturnsCLIVersion(t *testing.T) {
+	ctx := withAuth(context.Background())
+
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := rpcv1.NewStripeCLIClient(conn)
+
+	resp, err := client.Version(ctx, &rpcv1.VersionRequest{})
+	if err != nil {
+		t.Fatalf("Version failed: %v", err)
+	}
+
+	assert.Equal(t, "master", resp.Version)
+}
+
+func TestV1RejectsRequestIfHeaderAbsent(t *testing.T) {
+	ctx := context.Background()
+
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := rpcv1.NewStripeCLIClient(conn)
+
+	_, err = client.Version(ctx, &rpcv1.VersionRequest{})
+	expected := status.Errorf(codes.Unauthenticated, fmt.Sprintf("%s header is not supplied", requiredHeader))
+	assert.Equal(t, expected, err)
+}
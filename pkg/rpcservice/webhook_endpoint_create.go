@@ -10,7 +10,7 @@ import (
 
 // WebhookEndpointCreate create a new webhook endpoint
 func (srv *RPCService) WebhookEndpointCreate(ctx context.Context, req *rpc.WebhookEndpointCreateRequest) (*rpc.WebhookEndpointCreateResponse, error) {
-	userConfig := srv.cfg.UserCfg
+	userConfig := srv.userConfig()
 	livemode := false
 
 	key, err := userConfig.Profile.GetAPIKey(livemode)
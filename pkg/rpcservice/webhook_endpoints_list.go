@@ -10,7 +10,7 @@ import (
 
 // WebhookEndpointsList returns a list of webhook endpoints.
 func (srv *RPCService) WebhookEndpointsList(ctx context.Context, req *rpc.WebhookEndpointsListRequest) (*rpc.WebhookEndpointsListResponse, error) {
-	userConfig := srv.cfg.UserCfg
+	userConfig := srv.userConfig()
 	livemode := false
 
 	key, err := userConfig.Profile.GetAPIKey(livemode)
@@ -18,7 +18,7 @@ func (srv *RPCService) WebhookEndpointsList(ctx context.Context, req *rpc.Webhoo
 		return nil, err
 	}
 
-	endpoints := requests.WebhookEndpointsList(ctx, stripe.DefaultAPIBaseURL, stripe.APIVersion, key, &userConfig.Profile)
+	endpoints := requests.WebhookEndpointsList(ctx, stripe.DefaultAPIBaseURL, stripe.APIVersion, key, &userConfig.Profile, nil)
 
 	formattedEndpoints := make([]*rpc.WebhookEndpointsListResponse_WebhookEndpointData, 0, len(endpoints.Data))
 	for _, v := range endpoints.Data {
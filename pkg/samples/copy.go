@@ -0,0 +1,196 @@
+package samples
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/spf13/afero"
+)
+
+// maxCopyWorkers caps the number of files copied concurrently. Copying is
+// mostly bound by disk I/O, so more workers than this doesn't help.
+const maxCopyWorkers = 8
+
+// copyBufferSize is the size of the buffers files are copied through
+const copyBufferSize = 128 * 1024
+
+// CopyProgress reports how many of the files of a sample have been copied
+type CopyProgress struct {
+	Copied int
+	Total  int
+}
+
+// copyEntry is a single file, directory or symlink to copy
+type copyEntry struct {
+	src  string
+	dst  string
+	mode os.FileMode
+}
+
+// copyPlan lists everything to copy. Directories are kept apart so they can be
+// created before any file is copied into them.
+type copyPlan struct {
+	dirs  []copyEntry
+	files []copyEntry
+}
+
+// add walks src and adds everything under it to the plan, mirrored under dst.
+func (p *copyPlan) add(fs afero.Fs, src, dst string) error {
+	return afero.Walk(fs, src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+
+		entry := copyEntry{src: path, dst: filepath.Join(dst, rel), mode: info.Mode()}
+
+		if info.IsDir() {
+			p.dirs = append(p.dirs, entry)
+		} else {
+			p.files = append(p.files, entry)
+		}
+
+		return nil
+	})
+}
+
+var copyBuffers = sync.Pool{
+	New: func() interface{} {
+		buf := make([]byte, copyBufferSize)
+		return &buf
+	},
+}
+
+// execute creates the directories of the plan, then copies its files with a
+// bounded pool of workers. progress, if set, is called from a single
+// goroutine after each file is copied. The first error stops the copy.
+func (p *copyPlan) execute(ctx context.Context, fs afero.Fs, progress func(CopyProgress)) error {
+	for _, dir := range p.dirs {
+		if err := fs.MkdirAll(dir.dst, dir.mode.Perm()|0700); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan copyEntry)
+	results := make(chan error)
+
+	workers := runtime.NumCPU()
+	if workers > maxCopyWorkers {
+		workers = maxCopyWorkers
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for entry := range jobs {
+				results <- copyFile(fs, entry)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for _, file := range p.files {
+			select {
+			case jobs <- file:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var firstErr error
+	status := CopyProgress{Total: len(p.files)}
+
+	for err := range results {
+		if firstErr != nil {
+			continue
+		}
+
+		if err != nil {
+			firstErr = err
+			cancel()
+			continue
+		}
+
+		status.Copied++
+		if progress != nil {
+			progress(status)
+		}
+	}
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return ctx.Err()
+}
+
+// copyFile copies a single file or symlink, keeping its permissions.
+func copyFile(fs afero.Fs, entry copyEntry) error {
+	if entry.mode&os.ModeSymlink != 0 {
+		return copySymlink(fs, entry)
+	}
+
+	in, err := fs.Open(entry.src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := fs.OpenFile(entry.dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, entry.mode.Perm())
+	if err != nil {
+		return err
+	}
+
+	buf := copyBuffers.Get().(*[]byte)
+	defer copyBuffers.Put(buf)
+
+	if _, err := io.CopyBuffer(out, in, *buf); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
+
+// copySymlink recreates a symlink when the filesystem supports it, and copies
+// the file it points to otherwise.
+func copySymlink(fs afero.Fs, entry copyEntry) error {
+	reader, canRead := fs.(afero.LinkReader)
+	linker, canLink := fs.(afero.Linker)
+
+	if canRead && canLink {
+		target, err := reader.ReadlinkIfPossible(entry.src)
+		if err != nil {
+			return err
+		}
+
+		return linker.SymlinkIfPossible(target, entry.dst)
+	}
+
+	info, err := fs.Stat(entry.src)
+	if err != nil {
+		return err
+	}
+
+	return copyFile(fs, copyEntry{src: entry.src, dst: entry.dst, mode: info.Mode()})
+}
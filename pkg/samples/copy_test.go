@@ -0,0 +1,120 @@
+package samples
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCopy(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	repo := "/cache/foo"
+
+	afero.WriteFile(fs, filepath.Join(repo, "README.md"), []byte("readme"), 0644)
+	afero.WriteFile(fs, filepath.Join(repo, "webhooks", ".env.example"), []byte("KEY="), 0644)
+	afero.WriteFile(fs, filepath.Join(repo, "webhooks", "server", "node", "server.js"), []byte("server"), 0644)
+	afero.WriteFile(fs, filepath.Join(repo, "webhooks", "server", "node", "lib", "util.js"), []byte("util"), 0644)
+	afero.WriteFile(fs, filepath.Join(repo, "webhooks", "server", "ruby", "server.rb"), []byte("ruby"), 0644)
+	afero.WriteFile(fs, filepath.Join(repo, "webhooks", "client", "html", "index.html"), []byte("index"), 0644)
+	afero.WriteFile(fs, filepath.Join(repo, "webhooks", "server", "node", "run.sh"), []byte("run"), 0755)
+
+	sample := Samples{
+		Fs:   fs,
+		repo: repo,
+		SelectedConfig: SelectedConfig{
+			Integration: &SampleConfigIntegration{
+				Name:    "webhooks",
+				Clients: []string{"html"},
+				Servers: []string{"node", "ruby"},
+			},
+			Client: "html",
+			Server: "node",
+		},
+	}
+
+	var updates []CopyProgress
+	err := sample.Copy(context.Background(), "/target", func(progress CopyProgress) {
+		updates = append(updates, progress)
+	})
+	assert.Nil(t, err)
+
+	expected := map[string]string{
+		"/target/README.md":          "readme",
+		"/target/.env.example":       "KEY=",
+		"/target/server/server.js":   "server",
+		"/target/server/lib/util.js": "util",
+		"/target/server/run.sh":      "run",
+		"/target/client/index.html":  "index",
+	}
+	for path, content := range expected {
+		data, err := afero.ReadFile(fs, path)
+		assert.Nil(t, err, path)
+		assert.Equal(t, content, string(data))
+	}
+
+	exists, _ := afero.Exists(fs, "/target/server/server.rb")
+	assert.False(t, exists)
+
+	info, err := fs.Stat("/target/server/run.sh")
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+
+	assert.Len(t, updates, len(expected))
+	assert.Equal(t, CopyProgress{Copied: len(expected), Total: len(expected)}, updates[len(updates)-1])
+}
+
+func TestCopyManyFiles(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	for i := 0; i < 500; i++ {
+		afero.WriteFile(fs, fmt.Sprintf("/src/dir%d/file%d", i%10, i), []byte(fmt.Sprint(i)), 0644)
+	}
+
+	var plan copyPlan
+	assert.Nil(t, plan.add(fs, "/src", "/dst"))
+	assert.Len(t, plan.files, 500)
+
+	copied := 0
+	err := plan.execute(context.Background(), fs, func(progress CopyProgress) {
+		copied = progress.Copied
+		assert.Equal(t, 500, progress.Total)
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 500, copied)
+
+	for i := 0; i < 500; i++ {
+		data, err := afero.ReadFile(fs, fmt.Sprintf("/dst/dir%d/file%d", i%10, i))
+		assert.Nil(t, err)
+		assert.Equal(t, fmt.Sprint(i), string(data))
+	}
+}
+
+func TestCopyFailsWithMissingSource(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	var plan copyPlan
+	err := plan.add(fs, "/missing", "/dst")
+	assert.NotNil(t, err)
+}
+
+func TestCopyStopsWhenCanceled(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	for i := 0; i < 100; i++ {
+		afero.WriteFile(fs, fmt.Sprintf("/src/file%d", i), []byte("data"), 0644)
+	}
+
+	var plan copyPlan
+	assert.Nil(t, plan.add(fs, "/src", "/dst"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := plan.execute(ctx, fs, nil)
+	assert.Equal(t, context.Canceled, err)
+}
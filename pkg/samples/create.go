@@ -28,6 +28,9 @@ const (
 	// WillCopy means the downloaded sample will be copied to the target path
 	WillCopy
 
+	// Copying means files of the sample are being copied, and is sent with the progress of the copy
+	Copying
+
 	// DidCopy means the downloaded sample has finished being copied to the target path
 	DidCopy
 
@@ -37,6 +40,12 @@ const (
 	// DidConfigure means the .env of the sample has finished being configured with the user's Stripe account details
 	DidConfigure
 
+	// WillCreateResources means the Stripe objects the sample requires will be created in the user's account
+	WillCreateResources
+
+	// DidCreateResources means the Stripe objects the sample requires have finished being created
+	DidCreateResources
+
 	// Done means sample creation is complete
 	Done
 )
@@ -46,6 +55,7 @@ type CreationResult struct {
 	State       CreationStatus
 	Path        string
 	PostInstall string
+	Progress    CopyProgress
 	Err         error
 }
 
@@ -116,9 +126,18 @@ func Create(
 
 	sample.SelectedConfig = *selectedConfig
 
+	// Check out the files of the selected options, in case only
+	// part of the sample was cloned
+	err = sample.Checkout()
+	if err != nil {
+		resultChan <- CreationResult{Err: err}
+		return
+	}
+
 	// Setup to intercept ctrl+c
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt)
+	defer signal.Stop(c)
 
 	go func() {
 		<-c
@@ -139,7 +158,9 @@ func Create(
 
 	// Perform the copy of the sample given the selected options
 	// from the selections above
-	err = sample.Copy(targetPath)
+	err = sample.Copy(ctx, targetPath, func(progress CopyProgress) {
+		resultChan <- CreationResult{State: Copying, Progress: progress}
+	})
 	if err != nil {
 		resultChan <- CreationResult{Err: err}
 		return
@@ -157,5 +178,17 @@ func Create(
 
 	resultChan <- CreationResult{State: DidConfigure}
 
+	if sample.SampleConfig.HasRequiredResources() {
+		resultChan <- CreationResult{State: WillCreateResources}
+
+		err = sample.CreateRequiredResources(ctx, targetPath)
+		if err != nil {
+			resultChan <- CreationResult{Err: err}
+			return
+		}
+
+		resultChan <- CreationResult{State: DidCreateResources}
+	}
+
 	resultChan <- CreationResult{State: Done, Path: targetPath, PostInstall: sample.PostInstall()}
 }
@@ -0,0 +1,277 @@
+package samples
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/spf13/afero"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/stripeauth"
+	"github.com/stripe/stripe-cli/pkg/version"
+)
+
+// CheckStatus is the outcome of a check of `stripe samples doctor`
+type CheckStatus int
+
+const (
+	// CheckPassed means the project is set up correctly for the check
+	CheckPassed CheckStatus = iota
+
+	// CheckWarning means the project may not work, e.g. when a check couldn't be run
+	CheckWarning
+
+	// CheckFailed means the project won't work until it's fixed
+	CheckFailed
+)
+
+// DoctorCheck is a check of a project created from a sample
+type DoctorCheck struct {
+	Name    string
+	Status  CheckStatus
+	Message string
+}
+
+// toolchain is how the version of a toolchain samples are built with is
+// found
+type toolchain struct {
+	// commands are the commands printing the version, the first one
+	// installed is used
+	commands [][]string
+	// markers are the files of a server or client built with the toolchain
+	markers []string
+}
+
+var toolchains = map[string]toolchain{
+	"node":   {commands: [][]string{{"node", "--version"}}, markers: []string{"package.json"}},
+	"python": {commands: [][]string{{"python3", "--version"}, {"python", "--version"}}, markers: []string{"requirements.txt", "Pipfile", "pyproject.toml"}},
+	"java":   {commands: [][]string{{"java", "-version"}}, markers: []string{"pom.xml", "build.gradle"}},
+	"ruby":   {commands: [][]string{{"ruby", "--version"}}, markers: []string{"Gemfile"}},
+	"php":    {commands: [][]string{{"php", "--version"}}, markers: []string{"composer.json"}},
+	"go":     {commands: [][]string{{"go", "version"}}, markers: []string{"go.mod"}},
+	"dotnet": {commands: [][]string{{"dotnet", "--version"}}, markers: []string{"global.json"}},
+}
+
+var versionRegexp = regexp.MustCompile(`\d+(\.\d+)*`)
+
+// toolchainVersion returns the version of an installed toolchain, and is
+// replaced in tests
+var toolchainVersion = func(name string) (string, error) {
+	var err error
+	for _, command := range toolchains[name].commands {
+		var out []byte
+		// #nosec G204 -- the commands are constants
+		out, err = exec.Command(command[0], command[1:]...).CombinedOutput()
+		if err != nil {
+			continue
+		}
+
+		if version := versionRegexp.FindString(string(out)); version != "" {
+			// Java 8 and before are versioned 1.8.0
+			if name == "java" {
+				version = strings.TrimPrefix(version, "1.")
+			}
+			return version, nil
+		}
+		return "", fmt.Errorf("could not parse the version of %s: %s", name, strings.TrimSpace(string(out)))
+	}
+
+	return "", err
+}
+
+// webhookSecret returns the webhook signing secret of `stripe listen` for an
+// API key, and is replaced in tests
+var webhookSecret = func(ctx context.Context, apiKey, deviceName string) (string, error) {
+	session, err := stripeauth.NewClient(apiKey, nil).Authorize(ctx, deviceName, stripeauth.FeatureWebhooks, nil, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return session.Secret, nil
+}
+
+// Doctor checks a project created by `stripe samples create`: that the
+// toolchains of its server and client are installed, in the versions the
+// .cli.json of the sample requires, that its .env is complete, and that its
+// webhook secret is still the one of `stripe listen`.
+func Doctor(ctx context.Context, cfg *config.Config, fs afero.Fs, dir string) ([]DoctorCheck, error) {
+	configFile, err := afero.ReadFile(fs, filepath.Join(dir, ".cli.json"))
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a sample created with `stripe samples create`: %v", dir, err)
+	}
+
+	var sc SampleConfig
+	if err := json.Unmarshal(configFile, &sc); err != nil {
+		return nil, fmt.Errorf("could not read the .cli.json of %s: %v", dir, err)
+	}
+
+	var checks []DoctorCheck
+
+	for _, name := range detectToolchains(fs, dir) {
+		checks = append(checks, checkToolchain(name, sc.Toolchains[name]))
+	}
+
+	checks = append(checks, checkDotEnv(ctx, cfg, fs, dir)...)
+
+	return checks, nil
+}
+
+// detectToolchains returns the toolchains the server and client of a project
+// are built with, from the files they contain, or those of the project itself
+// if it has no server or client
+func detectToolchains(fs afero.Fs, dir string) []string {
+	found := make(map[string]bool)
+
+	detect := func(path string) {
+		for name, tc := range toolchains {
+			for _, marker := range tc.markers {
+				if exists, _ := afero.Exists(fs, filepath.Join(path, marker)); exists {
+					found[name] = true
+				}
+			}
+		}
+	}
+
+	detect(filepath.Join(dir, "server"))
+	detect(filepath.Join(dir, "client"))
+	if len(found) == 0 {
+		detect(dir)
+	}
+
+	names := make([]string, 0, len(found))
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func checkToolchain(name, required string) DoctorCheck {
+	check := DoctorCheck{Name: name}
+
+	installed, err := toolchainVersion(name)
+	if err != nil {
+		check.Status = CheckFailed
+		check.Message = fmt.Sprintf("%s is not installed", name)
+		if required != "" {
+			check.Message += fmt.Sprintf(", the sample requires %s", required)
+		}
+		return check
+	}
+
+	if required == "" {
+		check.Message = fmt.Sprintf("%s %s is installed", name, installed)
+		return check
+	}
+
+	minimum := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(required), ">="))
+	if version.Compare(installed, minimum) < 0 {
+		check.Status = CheckFailed
+		check.Message = fmt.Sprintf("%s %s is installed, but the sample requires %s", name, installed, required)
+		return check
+	}
+
+	check.Message = fmt.Sprintf("%s %s is installed, the sample requires %s", name, installed, required)
+	return check
+}
+
+// checkDotEnv checks that the .env of the server has every variable of the
+// .env.example of the sample, and that its webhook secret is valid
+func checkDotEnv(ctx context.Context, cfg *config.Config, fs afero.Fs, dir string) []DoctorCheck {
+	example, err := readDotEnv(fs, filepath.Join(dir, ".env.example"))
+	if err != nil {
+		// Samples without a .env.example aren't configured with one
+		return nil
+	}
+
+	envPath := filepath.Join(dir, ".env")
+	if exists, _ := afero.DirExists(fs, filepath.Join(dir, "server")); exists {
+		envPath = filepath.Join(dir, "server", ".env")
+	}
+
+	env, err := readDotEnv(fs, envPath)
+	if err != nil {
+		return []DoctorCheck{{
+			Name:    ".env",
+			Status:  CheckFailed,
+			Message: fmt.Sprintf("could not read %s, copy .env.example to it and fill it in: %v", envPath, err),
+		}}
+	}
+
+	var missing []string
+	for key := range example {
+		if env[key] == "" {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+
+	checks := []DoctorCheck{{Name: ".env", Message: fmt.Sprintf("%s has every variable of .env.example", envPath)}}
+	if len(missing) > 0 {
+		checks[0].Status = CheckFailed
+		checks[0].Message = fmt.Sprintf("%s is missing %s", envPath, strings.Join(missing, ", "))
+	}
+
+	if _, ok := example["STRIPE_WEBHOOK_SECRET"]; ok && env["STRIPE_WEBHOOK_SECRET"] != "" {
+		checks = append(checks, checkWebhookSecret(ctx, cfg, env))
+	}
+
+	return checks
+}
+
+func readDotEnv(fs afero.Fs, path string) (map[string]string, error) {
+	file, err := fs.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return godotenv.Parse(file)
+}
+
+// checkWebhookSecret checks that the webhook secret of the .env is the one
+// `stripe listen` signs events with, for the secret key of the .env
+func checkWebhookSecret(ctx context.Context, cfg *config.Config, env map[string]string) DoctorCheck {
+	check := DoctorCheck{Name: "webhook secret"}
+
+	apiKey := env["STRIPE_SECRET_KEY"]
+	if apiKey == "" {
+		var err error
+		if apiKey, err = cfg.Profile.GetAPIKey(false); err != nil {
+			check.Status = CheckWarning
+			check.Message = fmt.Sprintf("could not check STRIPE_WEBHOOK_SECRET: %v", err)
+			return check
+		}
+	}
+
+	deviceName, err := cfg.Profile.GetDeviceName()
+	if err != nil {
+		check.Status = CheckWarning
+		check.Message = fmt.Sprintf("could not check STRIPE_WEBHOOK_SECRET: %v", err)
+		return check
+	}
+
+	secret, err := webhookSecret(ctx, apiKey, deviceName)
+	if err != nil {
+		check.Status = CheckWarning
+		check.Message = fmt.Sprintf("could not check STRIPE_WEBHOOK_SECRET: %v", err)
+		return check
+	}
+
+	if secret != env["STRIPE_WEBHOOK_SECRET"] {
+		check.Status = CheckFailed
+		check.Message = "STRIPE_WEBHOOK_SECRET isn't the secret of `stripe listen` anymore, set it to the output of `stripe listen --print-secret`"
+		return check
+	}
+
+	check.Message = "STRIPE_WEBHOOK_SECRET is the secret of `stripe listen`"
+	return check
+}
@@ -0,0 +1,75 @@
+package samples
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+)
+
+func fakeToolchains(t *testing.T, versions map[string]string, secret string) {
+	originalVersion, originalSecret := toolchainVersion, webhookSecret
+	t.Cleanup(func() { toolchainVersion, webhookSecret = originalVersion, originalSecret })
+
+	toolchainVersion = func(name string) (string, error) {
+		if version, ok := versions[name]; ok {
+			return version, nil
+		}
+		return "", errors.New("executable file not found in $PATH")
+	}
+	webhookSecret = func(ctx context.Context, apiKey, deviceName string) (string, error) {
+		assert.Equal(t, "sk_test_1234567890", apiKey)
+		return secret, nil
+	}
+}
+
+func TestDoctor(t *testing.T) {
+	fakeToolchains(t, map[string]string{"node": "16.20.0"}, "whsec_456")
+
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "/project/.cli.json", []byte(`{"name": "accept-a-payment", "toolchains": {"node": ">=18", "python": ">=3.8"}}`), 0644)
+	afero.WriteFile(fs, "/project/.env.example", []byte("STRIPE_SECRET_KEY=\nSTRIPE_WEBHOOK_SECRET=\nDOMAIN=http://localhost:4242\n"), 0644)
+	afero.WriteFile(fs, "/project/server/requirements.txt", []byte("stripe\n"), 0644)
+	afero.WriteFile(fs, "/project/server/.env", []byte("STRIPE_SECRET_KEY=sk_test_1234567890\nSTRIPE_WEBHOOK_SECRET=whsec_123\n"), 0644)
+	afero.WriteFile(fs, "/project/client/package.json", []byte("{}"), 0644)
+
+	cfg := &config.Config{Profile: config.Profile{DeviceName: "st-laptop"}}
+
+	checks, err := Doctor(context.Background(), cfg, fs, "/project")
+	assert.Nil(t, err)
+	assert.Equal(t, []DoctorCheck{
+		{Name: "node", Status: CheckFailed, Message: "node 16.20.0 is installed, but the sample requires >=18"},
+		{Name: "python", Status: CheckFailed, Message: "python is not installed, the sample requires >=3.8"},
+		{Name: ".env", Status: CheckFailed, Message: "/project/server/.env is missing DOMAIN"},
+		{Name: "webhook secret", Status: CheckFailed, Message: "STRIPE_WEBHOOK_SECRET isn't the secret of `stripe listen` anymore, set it to the output of `stripe listen --print-secret`"},
+	}, checks)
+}
+
+func TestDoctorPasses(t *testing.T) {
+	fakeToolchains(t, map[string]string{"go": "1.21.0"}, "whsec_123")
+
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "/project/.cli.json", []byte(`{"name": "accept-a-payment", "toolchains": {"go": "1.17"}}`), 0644)
+	afero.WriteFile(fs, "/project/.env.example", []byte("STRIPE_SECRET_KEY=\nSTRIPE_WEBHOOK_SECRET=\n"), 0644)
+	afero.WriteFile(fs, "/project/server/go.mod", []byte("module server\n"), 0644)
+	afero.WriteFile(fs, "/project/server/.env", []byte("STRIPE_SECRET_KEY=sk_test_1234567890\nSTRIPE_WEBHOOK_SECRET=whsec_123\n"), 0644)
+
+	cfg := &config.Config{Profile: config.Profile{DeviceName: "st-laptop"}}
+
+	checks, err := Doctor(context.Background(), cfg, fs, "/project")
+	assert.Nil(t, err)
+	for _, check := range checks {
+		assert.Equal(t, CheckPassed, check.Status, check.Message)
+	}
+	assert.Len(t, checks, 3)
+}
+
+func TestDoctorNotASample(t *testing.T) {
+	_, err := Doctor(context.Background(), &config.Config{}, afero.NewMemMapFs(), "/project")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "/project is not a sample created with `stripe samples create`")
+}
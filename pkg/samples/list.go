@@ -54,12 +54,29 @@ func (s *Samples) getFromCacheOrGithub(noNetwork bool) error {
 		return err
 	}
 
+	unlock, err := s.lockCache(listPath, true)
+	readOnly := err == errReadOnlyCache
+	if err != nil && !readOnly {
+		return err
+	}
+	if !readOnly {
+		defer unlock()
+	}
+
 	if _, err := s.Fs.Stat(listPath); os.IsNotExist(err) {
+		if readOnly {
+			return fmt.Errorf("the list of samples is not in the samples cache, which is read-only")
+		}
 		err = s.Git.Clone(listPath, sampleListGithubURL)
+		if err != nil {
+			err = s.downloadArchive(listPath, sampleListGithubURL, err)
+		}
 		if err != nil {
 			return err
 		}
-	} else if !noNetwork {
+	} else if !noNetwork && !readOnly && !s.isRepo(listPath) {
+		s.updateArchive(listPath, sampleListGithubURL)
+	} else if !noNetwork && !readOnly {
 		err := s.Git.Pull(listPath)
 		if err != nil {
 			if err != nil {
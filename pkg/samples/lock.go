@@ -0,0 +1,56 @@
+package samples
+
+import (
+	"errors"
+	"os"
+	"syscall"
+
+	"github.com/spf13/afero"
+
+	"github.com/stripe/stripe-cli/pkg/filelock"
+)
+
+// errReadOnlyCache is returned when an entry of the samples cache can't be
+// locked because the cache is read-only, e.g. baked into a container image.
+// The cached samples are used as they are then.
+var errReadOnlyCache = errors.New("the samples cache is read-only")
+
+// lockCache locks an entry of the samples cache with a lock file next to it,
+// exclusively to update it or shared to read it, so that processes and users
+// sharing the cache don't read a sample while another one updates it. It
+// waits for the processes holding the lock, and returns a function that
+// releases it. Caches that aren't on the OS filesystem aren't locked.
+func (s *Samples) lockCache(path string, exclusive bool) (func(), error) {
+	if _, ok := s.Fs.(*afero.OsFs); !ok {
+		return func() {}, nil
+	}
+
+	lockPath := path + ".lock"
+
+	// The lock file is writable by everyone, for caches shared by several
+	// users
+	f, err := os.OpenFile(lockPath, os.O_RDWR|os.O_CREATE, 0666) // #nosec G302
+	if err == nil {
+		f.Chmod(0666) // #nosec G104 G302
+	} else if os.IsPermission(err) || errors.Is(err, syscall.EROFS) {
+		// The lock file of a read-only cache may have been created when
+		// it was populated
+		f, err = os.Open(lockPath) // #nosec G304
+		if err != nil {
+			return nil, errReadOnlyCache
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := filelock.Lock(f, exclusive); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return func() {
+		filelock.Unlock(f) // #nosec G104
+		f.Close()
+	}, nil
+}
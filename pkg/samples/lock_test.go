@@ -0,0 +1,67 @@
+package samples
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockCache(t *testing.T) {
+	sample := Samples{Fs: afero.NewOsFs()}
+	path := filepath.Join(t.TempDir(), "accept-a-payment")
+
+	unlock, err := sample.lockCache(path, true)
+	assert.Nil(t, err)
+
+	locked := make(chan struct{})
+	go func() {
+		unlock, err := sample.lockCache(path, false)
+		assert.Nil(t, err)
+		close(locked)
+		unlock()
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("the cache was locked twice")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	unlock()
+
+	select {
+	case <-locked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the cache wasn't unlocked")
+	}
+}
+
+func TestLockCacheReadOnly(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("root can write to read-only directories")
+	}
+
+	dir := t.TempDir()
+	assert.Nil(t, os.Chmod(dir, 0555))
+	defer os.Chmod(dir, 0755)
+
+	sample := Samples{Fs: afero.NewOsFs()}
+
+	_, err := sample.lockCache(filepath.Join(dir, "accept-a-payment"), true)
+	assert.Equal(t, errReadOnlyCache, err)
+}
+
+func TestLockCacheMemMapFs(t *testing.T) {
+	sample := Samples{Fs: afero.NewMemMapFs()}
+
+	unlock, err := sample.lockCache("/cache/accept-a-payment", true)
+	assert.Nil(t, err)
+	unlock()
+
+	exists, _ := afero.Exists(sample.Fs, "/cache/accept-a-payment.lock")
+	assert.False(t, exists)
+}
@@ -9,10 +9,10 @@ import (
 	"github.com/spf13/afero"
 )
 
-// cacheFolder is the local directory where we place local copies of samples
+// cacheFolder is the local directory where we place local copies of samples,
+// set with STRIPE_SAMPLES_CACHE or the samples_cache_dir setting
 func (s *Samples) cacheFolder() (string, error) {
-	configPath := s.Config.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME"))
-	cachePath := filepath.Join(configPath, "samples-cache")
+	cachePath := s.Config.GetSamplesCacheDir()
 
 	if _, err := s.Fs.Stat(cachePath); os.IsNotExist(err) {
 		err := s.Fs.MkdirAll(cachePath, os.ModePerm)
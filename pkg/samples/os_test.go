@@ -45,6 +45,23 @@ func TestCacheFolder(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestCacheFolderFromEnv(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	viper.SetFs(fs)
+	t.Setenv("STRIPE_SAMPLES_CACHE", "/opt/stripe/samples")
+
+	sample := Samples{
+		Fs: fs,
+	}
+
+	path, err := sample.cacheFolder()
+	pathExists, _ := afero.Exists(fs, path)
+
+	assert.Equal(t, "/opt/stripe/samples", path)
+	assert.True(t, pathExists)
+	assert.Nil(t, err)
+}
+
 func TestAppCacheFolder(t *testing.T) {
 	fs := afero.NewMemMapFs()
 	viper.SetFs(fs)
@@ -0,0 +1,164 @@
+package samples
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+)
+
+// apiBaseURL is the base URL the account is retrieved from, and is replaced
+// in tests
+var apiBaseURL = stripe.DefaultAPIBaseURL
+
+// SampleRequirements are the account requirements a sample declares in its
+// .cli.json, for features that don't work on every account
+type SampleRequirements struct {
+	// Countries are the only countries of accounts the sample works for
+	Countries []string `json:"countries"`
+	// UnsupportedCountries are the countries of accounts the sample doesn't work for
+	UnsupportedCountries []string `json:"unsupportedCountries"`
+	// Currencies are the default currencies of accounts the sample works for
+	Currencies []string `json:"currencies"`
+	// Capabilities must be active on the account, e.g. card_payments
+	Capabilities []string `json:"capabilities"`
+	// Guidance is shown when the account doesn't meet the requirements
+	Guidance string `json:"guidance"`
+	// Strict blocks creating the sample when the account doesn't meet the
+	// requirements, rather than warning about it
+	Strict bool `json:"strict"`
+}
+
+// Account is the part of the account the requirements of samples are
+// checked against
+type Account struct {
+	Country         string            `json:"country"`
+	DefaultCurrency string            `json:"default_currency"`
+	Capabilities    map[string]string `json:"capabilities"`
+}
+
+// RetrieveAccount retrieves the account of an API key
+func RetrieveAccount(ctx context.Context, baseURL, apiKey string) (*Account, error) {
+	parsedBaseURL, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &stripe.Client{
+		BaseURL: parsedBaseURL,
+		APIKey:  apiKey,
+	}
+
+	resp, err := client.PerformRequest(ctx, http.MethodGet, "/v1/account", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request failed, status=%d", resp.StatusCode)
+	}
+
+	account := &Account{}
+	if err := json.NewDecoder(resp.Body).Decode(account); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+func containsFold(s []string, e string) bool {
+	for _, a := range s {
+		if strings.EqualFold(a, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// Unmet returns the requirements the account doesn't meet, as sentences
+func (r *SampleRequirements) Unmet(account *Account) []string {
+	var unmet []string
+
+	country := strings.ToUpper(account.Country)
+	if len(r.Countries) > 0 && !containsFold(r.Countries, country) {
+		unmet = append(unmet, fmt.Sprintf("it's only available for accounts in %s, and your account is in %s", strings.Join(r.Countries, ", "), country))
+	}
+	if containsFold(r.UnsupportedCountries, country) {
+		unmet = append(unmet, fmt.Sprintf("it isn't available for accounts in %s", country))
+	}
+
+	if len(r.Currencies) > 0 && !containsFold(r.Currencies, account.DefaultCurrency) {
+		unmet = append(unmet, fmt.Sprintf("it only supports %s, and the default currency of your account is %s", strings.Join(r.Currencies, ", "), account.DefaultCurrency))
+	}
+
+	for _, capability := range r.Capabilities {
+		if status := account.Capabilities[capability]; status != "active" {
+			if status == "" {
+				status = "not requested"
+			}
+			unmet = append(unmet, fmt.Sprintf("it requires the %s capability, which is %s on your account", capability, status))
+		}
+	}
+
+	return unmet
+}
+
+// CheckRequirements checks the account of the profile against the
+// requirements of a sample before it's created. It returns an error if the
+// requirements are strict and the account doesn't meet them, and otherwise
+// prints a warning to w.
+func CheckRequirements(ctx context.Context, cfg *config.Config, sampleName string, sc *SampleConfig, w io.Writer) error {
+	r := sc.Requirements
+	if r == nil {
+		return nil
+	}
+
+	account, err := retrieveProfileAccount(ctx, cfg)
+	if err != nil {
+		color := ansi.Color(w)
+		fmt.Fprintf(w, "%s could not check that %s works with your account: %v\n", color.Yellow("Warning"), sampleName, err)
+		return nil
+	}
+
+	return r.report(sampleName, r.Unmet(account), w)
+}
+
+func retrieveProfileAccount(ctx context.Context, cfg *config.Config) (*Account, error) {
+	apiKey, err := cfg.Profile.GetAPIKey(false)
+	if err != nil {
+		return nil, err
+	}
+
+	return RetrieveAccount(ctx, apiBaseURL, apiKey)
+}
+
+func (r *SampleRequirements) report(sampleName string, unmet []string, w io.Writer) error {
+	if len(unmet) == 0 {
+		return nil
+	}
+
+	var details strings.Builder
+	for _, reason := range unmet {
+		fmt.Fprintf(&details, "  - %s\n", reason)
+	}
+	if r.Guidance != "" {
+		fmt.Fprintf(&details, "%s\n", r.Guidance)
+	}
+
+	if r.Strict {
+		return fmt.Errorf("%s can't be created for your account:\n%s", sampleName, strings.TrimSuffix(details.String(), "\n"))
+	}
+
+	color := ansi.Color(w)
+	fmt.Fprintf(w, "%s %s may not work with your account:\n%s", color.Yellow("Warning"), sampleName, details.String())
+
+	return nil
+}
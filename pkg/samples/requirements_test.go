@@ -0,0 +1,91 @@
+package samples
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+)
+
+func TestRequirementsUnmet(t *testing.T) {
+	account := &Account{
+		Country:         "IN",
+		DefaultCurrency: "inr",
+		Capabilities:    map[string]string{"card_payments": "active", "transfers": "pending"},
+	}
+
+	requirements := SampleRequirements{
+		Countries:    []string{"US", "GB"},
+		Currencies:   []string{"usd", "gbp"},
+		Capabilities: []string{"card_payments", "transfers", "link_payments"},
+	}
+
+	assert.Equal(t, []string{
+		"it's only available for accounts in US, GB, and your account is in IN",
+		"it only supports usd, gbp, and the default currency of your account is inr",
+		"it requires the transfers capability, which is pending on your account",
+		"it requires the link_payments capability, which is not requested on your account",
+	}, requirements.Unmet(account))
+
+	requirements = SampleRequirements{UnsupportedCountries: []string{"in"}}
+	assert.Equal(t, []string{"it isn't available for accounts in IN"}, requirements.Unmet(account))
+
+	account.Country = "US"
+	assert.Empty(t, requirements.Unmet(account))
+}
+
+func TestCheckRequirements(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/account", r.URL.Path)
+		w.Write([]byte(`{"id": "acct_123", "country": "IN", "default_currency": "inr", "capabilities": {}}`))
+	}))
+	defer ts.Close()
+
+	original := apiBaseURL
+	defer func() { apiBaseURL = original }()
+	apiBaseURL = ts.URL
+
+	cfg := &config.Config{Profile: config.Profile{APIKey: "sk_test_1234567890"}}
+	sc := &SampleConfig{Requirements: &SampleRequirements{
+		UnsupportedCountries: []string{"IN"},
+		Guidance:             "Try the checkout-one-time-payments sample instead.",
+	}}
+
+	var out bytes.Buffer
+	err := CheckRequirements(context.Background(), cfg, "subscription-use-cases", sc, &out)
+	assert.Nil(t, err)
+	assert.Equal(t, "Warning subscription-use-cases may not work with your account:\n  - it isn't available for accounts in IN\nTry the checkout-one-time-payments sample instead.\n", out.String())
+
+	out.Reset()
+	sc.Requirements.Strict = true
+	err = CheckRequirements(context.Background(), cfg, "subscription-use-cases", sc, &out)
+	assert.EqualError(t, err, "subscription-use-cases can't be created for your account:\n  - it isn't available for accounts in IN\nTry the checkout-one-time-payments sample instead.")
+	assert.Empty(t, out.String())
+
+	sc.Requirements = nil
+	assert.Nil(t, CheckRequirements(context.Background(), cfg, "subscription-use-cases", sc, &out))
+}
+
+func TestCheckRequirementsAccountError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+
+	original := apiBaseURL
+	defer func() { apiBaseURL = original }()
+	apiBaseURL = ts.URL
+
+	cfg := &config.Config{Profile: config.Profile{APIKey: "sk_test_1234567890"}}
+	sc := &SampleConfig{Requirements: &SampleRequirements{Strict: true, Countries: []string{"US"}}}
+
+	var out bytes.Buffer
+	err := CheckRequirements(context.Background(), cfg, "accept-a-payment", sc, &out)
+	assert.Nil(t, err)
+	assert.Equal(t, "Warning could not check that accept-a-payment works with your account: request failed, status=401\n", out.String())
+}
@@ -0,0 +1,100 @@
+package samples
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/joho/godotenv"
+	"github.com/spf13/afero"
+
+	"github.com/stripe/stripe-cli/pkg/fixtures"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+)
+
+// HasRequiredResources returns true if the sample declares Stripe objects
+// to create in the user's account
+func (sc *SampleConfig) HasRequiredResources() bool {
+	return len(sc.RequiredResources) > 0
+}
+
+// CreateRequiredResources runs the required resources fixture of the sample
+// in test mode, and writes the values of its env to the .env of the sample
+// at sampleLocation.
+func (s *Samples) CreateRequiredResources(ctx context.Context, sampleLocation string) error {
+	if !s.SampleConfig.HasRequiredResources() {
+		return nil
+	}
+
+	apiKey, err := s.Config.Profile.GetAPIKey(false)
+	if err != nil {
+		return err
+	}
+
+	baseURL := s.APIBaseURL
+	if baseURL == "" {
+		baseURL = stripe.DefaultAPIBaseURL
+	}
+
+	fxt, err := fixtures.NewFixtureFromRawString(s.Fs, apiKey, "", baseURL, string(s.SampleConfig.RequiredResources))
+	if err != nil {
+		return err
+	}
+
+	_, err = fxt.Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	values, err := fxt.EnvValues()
+	if err != nil {
+		return err
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	return s.writeDotEnv(s.dotEnvPath(sampleLocation), values)
+}
+
+// dotEnvPath returns the path of the .env of the sample, which is in its
+// server folder if it has one
+func (s *Samples) dotEnvPath(sampleLocation string) string {
+	if s.SelectedConfig.Integration != nil && s.SelectedConfig.Integration.hasServers() {
+		return filepath.Join(sampleLocation, "server", ".env")
+	}
+
+	return filepath.Join(sampleLocation, ".env")
+}
+
+// writeDotEnv merges values into the .env file at path, creating it if it
+// doesn't exist
+func (s *Samples) writeDotEnv(path string, values map[string]string) error {
+	dotenv := map[string]string{}
+
+	exists, _ := afero.Exists(s.Fs, path)
+	if exists {
+		file, err := s.Fs.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		dotenv, err = godotenv.Parse(file)
+		if err != nil {
+			return err
+		}
+	}
+
+	for key, value := range values {
+		dotenv[key] = value
+	}
+
+	content, err := godotenv.Marshal(dotenv)
+	if err != nil {
+		return err
+	}
+
+	return afero.WriteFile(s.Fs, path, []byte(content+"\n"), os.ModePerm)
+}
@@ -0,0 +1,123 @@
+package samples
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/joho/godotenv"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+)
+
+const requiredResources = `{
+  "_meta": {"template_version": 0},
+  "fixtures": [
+    {
+      "name": "product",
+      "path": "/v1/products",
+      "method": "post",
+      "params": {"name": "Pasha photo book"}
+    },
+    {
+      "name": "price_monthly",
+      "path": "/v1/prices",
+      "method": "post",
+      "params": {"product": "${product:id}", "unit_amount": 500, "currency": "usd", "recurring": {"interval": "month"}}
+    },
+    {
+      "name": "price_yearly",
+      "path": "/v1/prices",
+      "method": "post",
+      "params": {"product": "${product:id}", "unit_amount": 5000, "currency": "usd", "recurring": {"interval": "year"}}
+    },
+    {
+      "name": "coupon",
+      "path": "/v1/coupons",
+      "method": "post",
+      "params": {"percent_off": 20, "duration": "once"}
+    }
+  ],
+  "env": {
+    "MONTHLY_PRICE_ID": "${price_monthly:id}",
+    "YEARLY_PRICE_ID": "${price_yearly:id}",
+    "COUPON_ID": "${coupon:id}"
+  }
+}`
+
+func TestCreateRequiredResources(t *testing.T) {
+	var mu sync.Mutex
+	var prices []string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+
+		switch r.URL.Path {
+		case "/v1/products":
+			w.Write([]byte(`{"id": "prod_123"}`))
+		case "/v1/prices":
+			require.Equal(t, "prod_123", r.Form.Get("product"))
+
+			mu.Lock()
+			prices = append(prices, r.Form.Get("recurring[interval]"))
+			mu.Unlock()
+
+			w.Write([]byte(`{"id": "price_` + r.Form.Get("recurring[interval]") + `"}`))
+		case "/v1/coupons":
+			w.Write([]byte(`{"id": "coupon_123"}`))
+		default:
+			t.Errorf("Received an unexpected request URL: %s", r.URL.String())
+		}
+	}))
+	defer ts.Close()
+
+	fs := afero.NewMemMapFs()
+	envFile := filepath.Join("sample", "server", ".env")
+	afero.WriteFile(fs, envFile, []byte("STRIPE_SECRET_KEY=sk_test_123\n"), os.ModePerm)
+
+	sample := Samples{
+		Config:     &config.Config{Profile: config.Profile{APIKey: "sk_test_1234567890"}},
+		Fs:         fs,
+		APIBaseURL: ts.URL,
+		SampleConfig: SampleConfig{
+			RequiredResources: []byte(requiredResources),
+		},
+		SelectedConfig: SelectedConfig{
+			Integration: &SampleConfigIntegration{Servers: []string{"node"}},
+		},
+	}
+
+	err := sample.CreateRequiredResources(context.Background(), "sample")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"month", "year"}, prices)
+
+	file, err := fs.Open(envFile)
+	require.NoError(t, err)
+	defer file.Close()
+
+	dotenv, err := godotenv.Parse(file)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"STRIPE_SECRET_KEY": "sk_test_123",
+		"MONTHLY_PRICE_ID":  "price_month",
+		"YEARLY_PRICE_ID":   "price_year",
+		"COUPON_ID":         "coupon_123",
+	}, dotenv)
+}
+
+func TestCreateRequiredResourcesWithoutResources(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	sample := Samples{Fs: fs}
+
+	err := sample.CreateRequiredResources(context.Background(), "sample")
+	require.NoError(t, err)
+
+	exists, _ := afero.Exists(fs, filepath.Join("sample", ".env"))
+	require.False(t, exists)
+}
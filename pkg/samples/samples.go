@@ -9,24 +9,36 @@ import (
 	"path/filepath"
 
 	"github.com/joho/godotenv"
-	"github.com/otiai10/copy"
 	"github.com/spf13/afero"
 	"gopkg.in/src-d/go-git.v4"
 
 	log "github.com/sirupsen/logrus"
 
 	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/download"
 	g "github.com/stripe/stripe-cli/pkg/git"
 	gitpkg "github.com/stripe/stripe-cli/pkg/git"
 	"github.com/stripe/stripe-cli/pkg/stripeauth"
 )
 
+// archiveURL returns the URL of the tarball of a repo, and is replaced in tests
+var archiveURL = gitpkg.ArchiveURL
+
 // SampleConfig contains all the configuration options for a sample
 type SampleConfig struct {
 	Name            string                    `json:"name"`
 	ConfigureDotEnv bool                      `json:"configureDotEnv"`
 	PostInstall     map[string]string         `json:"postInstall"`
 	Integrations    []SampleConfigIntegration `json:"integrations"`
+	Requirements    *SampleRequirements       `json:"requirements"`
+	// RequiredResources is a fixture creating the Stripe objects the sample
+	// needs, like a product and its prices. Its steps can reference each
+	// other, like `${product:id}`, and the values of its env are written to
+	// the .env of the sample.
+	RequiredResources json.RawMessage `json:"requiredResources,omitempty"`
+	// Toolchains are the versions of the toolchains the servers and clients
+	// of the sample require, like {"node": ">=18"}
+	Toolchains map[string]string `json:"toolchains"`
 }
 
 // HasIntegrations returns true if the sample has multiple integrations
@@ -113,6 +125,10 @@ type Samples struct {
 	SampleConfig SampleConfig
 
 	SelectedConfig SelectedConfig
+
+	// APIBaseURL is the URL the required resources of the sample are
+	// created on, and defaults to stripe.DefaultAPIBaseURL
+	APIBaseURL string
 }
 
 // Initialize get the sample ready for the user to copy. It:
@@ -142,15 +158,36 @@ func (s *Samples) Initialize(app string) error {
 		return err
 	}
 
+	unlock, err := s.lockCache(appPath, true)
+	readOnly := err == errReadOnlyCache
+	if err != nil && !readOnly {
+		return err
+	}
+	if !readOnly {
+		defer unlock()
+	}
+
 	if _, err := s.Fs.Stat(appPath); os.IsNotExist(err) {
+		if readOnly {
+			return fmt.Errorf("Sample %s is not in the samples cache, which is read-only", app)
+		}
 		sampleData, ok := list[app]
 		if !ok {
 			return fmt.Errorf("Sample %s does not exist", app)
 		}
-		err = s.Git.Clone(appPath, sampleData.GitRepo())
+		err = s.clone(appPath, sampleData.GitRepo())
 		if err != nil {
 			return err
 		}
+	} else if readOnly {
+		// The cached copy of the sample can't be updated
+		log.WithFields(log.Fields{
+			"prefix": "samples.Samples.Initialize",
+		}).Debugf("Using %s without updating it, since the samples cache is read-only", appPath)
+	} else if !s.isRepo(appPath) {
+		if sampleData, ok := list[app]; ok {
+			s.updateArchive(appPath, sampleData.GitRepo())
+		}
 	} else {
 		err := s.Git.Pull(appPath)
 		if err != nil {
@@ -180,6 +217,131 @@ func (s *Samples) Initialize(app string) error {
 	return nil
 }
 
+// clone clones the sample repo, with only its top-level files checked out if
+// the git backend supports sparse checkouts. The directories of the selected
+// integration are checked out later by Checkout. If git fails, for example
+// because it isn't installed or the git protocol is blocked, a tarball of the
+// repo is downloaded over HTTPS instead, unless the repo is private and git
+// has no credentials for it, since the tarball can't be downloaded either.
+func (s *Samples) clone(appPath, repo string) error {
+	err := s.gitClone(appPath, repo)
+
+	var authErr gitpkg.AuthError
+	if errors.As(err, &authErr) {
+		s.Fs.RemoveAll(appPath)
+		return err
+	}
+
+	if err != nil {
+		return s.downloadArchive(appPath, repo, err)
+	}
+
+	return nil
+}
+
+func (s *Samples) gitClone(appPath, repo string) error {
+	if sparse, ok := s.Git.(gitpkg.Sparse); ok {
+		err := sparse.SparseClone(appPath, repo)
+		if err != gitpkg.ErrSparseUnsupported {
+			return err
+		}
+	}
+
+	return s.Git.Clone(appPath, repo)
+}
+
+// downloadArchive downloads a tarball of repo into path, after cloning it
+// failed with cloneErr.
+func (s *Samples) downloadArchive(path, repo string, cloneErr error) error {
+	url, ok := archiveURL(repo)
+	if !ok {
+		return cloneErr
+	}
+
+	log.WithFields(log.Fields{
+		"prefix": "samples.Samples.downloadArchive",
+	}).Debugf("Failed to clone %s, downloading %s instead: %v", repo, url, cloneErr)
+
+	// Remove what the failed clone left behind
+	s.Fs.RemoveAll(path)
+
+	err := download.Archive(s.Fs, url, path, 1)
+	if err != nil {
+		s.Fs.RemoveAll(path)
+		return fmt.Errorf("%v, and downloading %s failed: %v", cloneErr, url, err)
+	}
+
+	return nil
+}
+
+// updateArchive downloads again a repo that was downloaded as a tarball
+// rather than cloned, since it can't be pulled. The cached copy is kept if
+// the download fails.
+func (s *Samples) updateArchive(path, repo string) {
+	url, ok := archiveURL(repo)
+	if !ok {
+		return
+	}
+
+	staging := path + ".download"
+	s.Fs.RemoveAll(staging)
+
+	err := download.Archive(s.Fs, url, staging, 1)
+	if err == nil {
+		err = s.Fs.RemoveAll(path)
+	}
+	if err == nil {
+		err = s.Fs.Rename(staging, path)
+	}
+	if err != nil {
+		s.Fs.RemoveAll(staging)
+
+		log.WithFields(log.Fields{
+			"prefix": "samples.Samples.updateArchive",
+		}).Debugf("Failed to update %s, using the cached copy: %v", path, err)
+	}
+}
+
+// isRepo returns false for repos that were downloaded as a tarball.
+func (s *Samples) isRepo(path string) bool {
+	exists, _ := afero.DirExists(s.Fs, filepath.Join(path, ".git"))
+	return exists
+}
+
+// Checkout makes sure the directories of the selected configuration are
+// checked out in the local cache, for samples that were cloned sparsely.
+func (s *Samples) Checkout() error {
+	sparse, ok := s.Git.(gitpkg.Sparse)
+	if !ok {
+		return nil
+	}
+
+	integration := s.SelectedConfig.Integration.name()
+
+	var dirs []string
+	if s.SelectedConfig.Integration.hasServers() {
+		dirs = append(dirs, filepath.ToSlash(filepath.Join(integration, "server", s.SelectedConfig.Server)))
+	}
+	if s.SelectedConfig.Integration.hasClients() {
+		dirs = append(dirs, filepath.ToSlash(filepath.Join(integration, "client", s.SelectedConfig.Client)))
+	}
+	if len(dirs) == 0 && integration != "" {
+		dirs = append(dirs, integration)
+	}
+
+	unlock, err := s.lockCache(s.repo, true)
+	if err == errReadOnlyCache {
+		// Samples in read-only caches must have been checked out entirely
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return sparse.SparseCheckout(s.repo, dirs)
+}
+
 // Copy will copy all of the files from the selected configuration above oves.
 // This has a few different behaviors, depending on the configuration.
 // Ultimately, we want the user to do as minimal of folder traversing as
@@ -198,9 +360,22 @@ func (s *Samples) Initialize(app string) error {
 //   the server top-level (example above)
 // * If the user selects an integration, mirror the structure above for the
 //   selected integration (example above)
-func (s *Samples) Copy(target string) error {
+//
+// The files to copy are listed up front, then copied concurrently. progress,
+// if set, is called after each file is copied.
+func (s *Samples) Copy(ctx context.Context, target string, progress func(CopyProgress)) error {
 	integration := s.SelectedConfig.Integration.name()
 
+	unlock, err := s.lockCache(s.repo, false)
+	if err != nil && err != errReadOnlyCache {
+		return err
+	}
+	if err == nil {
+		defer unlock()
+	}
+
+	var plan copyPlan
+
 	if s.SelectedConfig.Integration.hasServers() {
 		// empty string is a valid option
 		if s.SelectedConfig.Server != "" && !contains(s.SelectedConfig.Integration.Servers, s.SelectedConfig.Server) {
@@ -215,7 +390,7 @@ func (s *Samples) Copy(target string) error {
 		serverSource := filepath.Join(s.repo, integration, "server", s.SelectedConfig.Server)
 		serverDestination := filepath.Join(target, "server")
 
-		err := copy.Copy(serverSource, serverDestination)
+		err := plan.add(s.Fs, serverSource, serverDestination)
 		if err != nil {
 			return err
 		}
@@ -235,7 +410,7 @@ func (s *Samples) Copy(target string) error {
 		clientSource := filepath.Join(s.repo, integration, "client", s.SelectedConfig.Client)
 		clientDestination := filepath.Join(target, "client")
 
-		err := copy.Copy(clientSource, clientDestination)
+		err := plan.add(s.Fs, clientSource, clientDestination)
 		if err != nil {
 			return err
 		}
@@ -247,7 +422,7 @@ func (s *Samples) Copy(target string) error {
 	}
 
 	for _, file := range filesSource {
-		err = copy.Copy(filepath.Join(s.repo, integration, file), filepath.Join(target, file))
+		err = plan.add(s.Fs, filepath.Join(s.repo, integration, file), filepath.Join(target, file))
 		if err != nil {
 			return err
 		}
@@ -260,13 +435,13 @@ func (s *Samples) Copy(target string) error {
 	}
 
 	for _, file := range filesSource {
-		err = copy.Copy(filepath.Join(s.repo, file), filepath.Join(target, file))
+		err = plan.add(s.Fs, filepath.Join(s.repo, file), filepath.Join(target, file))
 		if err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return plan.execute(ctx, s.Fs, progress)
 }
 
 // ConfigureDotEnv takes the .env.example from the provided location and
@@ -307,7 +482,7 @@ func (s *Samples) ConfigureDotEnv(ctx context.Context, sampleLocation string) er
 
 		authClient := stripeauth.NewClient(apiKey, nil)
 
-		authSession, err := authClient.Authorize(ctx, deviceName, "webhooks", nil, nil)
+		authSession, err := authClient.Authorize(ctx, deviceName, stripeauth.FeatureWebhooks, nil, nil)
 		if err != nil {
 			return err
 		}
@@ -349,6 +524,12 @@ func (s *Samples) DeleteCache(sample string) error {
 		return err
 	}
 
+	unlock, err := s.lockCache(appPath, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	err = s.Fs.RemoveAll(appPath)
 	if err != nil {
 		return err
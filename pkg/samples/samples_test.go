@@ -1,13 +1,21 @@
 package samples
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+
+	gitpkg "github.com/stripe/stripe-cli/pkg/git"
 )
 
 type mockGit struct {
@@ -118,3 +126,159 @@ func TestInitializeFailsWithNonexistentSample(t *testing.T) {
 	err := sample.Initialize(name)
 	assert.Equal(t, errors.New("Sample foo does not exist"), err)
 }
+
+type mockSparseGit struct {
+	mockGit
+
+	sparseClones int
+	checkedOut   []string
+	unsupported  bool
+}
+
+func (mg *mockSparseGit) SparseClone(appCachePath, app string) error {
+	if mg.unsupported {
+		return gitpkg.ErrSparseUnsupported
+	}
+
+	mg.sparseClones++
+
+	return mg.mockGit.Clone(appCachePath, app)
+}
+
+func (mg *mockSparseGit) SparseCheckout(appCachePath string, dirs []string) error {
+	mg.checkedOut = dirs
+	return nil
+}
+
+func TestInitializeClonesSparsely(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	git := &mockSparseGit{mockGit: mockGit{fs: fs}}
+
+	sample := Samples{
+		Fs:  fs,
+		Git: git,
+		SamplesList: map[string]*SampleData{
+			"accept-a-payment": {
+				Name: "accept-a-payment",
+				URL:  "https://github.com/stripe-samples/accept-a-payment",
+			},
+		},
+	}
+
+	err := sample.Initialize("accept-a-payment")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, git.sparseClones)
+
+	sample.SelectedConfig = SelectedConfig{
+		Integration: &sample.SampleConfig.Integrations[0],
+		Client:      "html",
+		Server:      "node",
+	}
+
+	err = sample.Checkout()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"webhooks/server/node", "webhooks/client/html"}, git.checkedOut)
+}
+
+func TestInitializeFallsBackToFullClone(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	git := &mockSparseGit{mockGit: mockGit{fs: fs}, unsupported: true}
+
+	sample := Samples{
+		Fs:  fs,
+		Git: git,
+		SamplesList: map[string]*SampleData{
+			"accept-a-payment": {
+				Name: "accept-a-payment",
+				URL:  "https://github.com/stripe-samples/accept-a-payment",
+			},
+		},
+	}
+
+	err := sample.Initialize("accept-a-payment")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, git.sparseClones)
+	assert.ElementsMatch(t, sample.SampleConfig.IntegrationNames(), []string{"webhooks", "no-webhooks"})
+}
+
+type failingGit struct{}
+
+func (failingGit) Clone(appCachePath, _ string) error {
+	return errors.New("git protocol blocked")
+}
+
+func (failingGit) Pull(appCachePath string) error {
+	return errors.New("git protocol blocked")
+}
+
+func TestInitializeFallsBackToArchive(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	config := `{"name": "foo"}`
+	tw.WriteHeader(&tar.Header{Name: "accept-a-payment-main/.cli.json", Mode: 0644, Size: int64(len(config)), Typeflag: tar.TypeReg})
+	tw.Write([]byte(config))
+	tw.Close()
+	gz.Close()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/stripe-samples/accept-a-payment.tar.gz", r.URL.Path)
+		w.Write(buf.Bytes())
+	}))
+	defer ts.Close()
+
+	original := archiveURL
+	defer func() { archiveURL = original }()
+	archiveURL = func(repo string) (string, bool) {
+		return ts.URL + strings.TrimSuffix(strings.TrimPrefix(repo, "https://github.com"), ".git") + ".tar.gz", true
+	}
+
+	fs := afero.NewMemMapFs()
+	sample := Samples{
+		Fs:  fs,
+		Git: failingGit{},
+		SamplesList: map[string]*SampleData{
+			"accept-a-payment": {
+				Name: "accept-a-payment",
+				URL:  "https://github.com/stripe-samples/accept-a-payment",
+			},
+		},
+	}
+
+	err := sample.Initialize("accept-a-payment")
+	assert.Nil(t, err)
+	assert.Equal(t, "foo", sample.SampleConfig.Name)
+}
+
+type privateGit struct{}
+
+func (privateGit) Clone(appCachePath, repo string) error {
+	return gitpkg.AuthError{Repo: repo, Err: errors.New("authentication required")}
+}
+
+func (privateGit) Pull(appCachePath string) error {
+	return nil
+}
+
+func TestInitializeDoesNotDownloadPrivateRepos(t *testing.T) {
+	original := archiveURL
+	defer func() { archiveURL = original }()
+	archiveURL = func(repo string) (string, bool) {
+		t.Fatal("the archive of a private repo was downloaded")
+		return "", false
+	}
+
+	sample := Samples{
+		Fs:  afero.NewMemMapFs(),
+		Git: privateGit{},
+		SamplesList: map[string]*SampleData{
+			"internal-sample": {
+				Name: "internal-sample",
+				URL:  "https://github.com/acme/internal-sample",
+			},
+		},
+	}
+
+	err := sample.Initialize("internal-sample")
+	assert.True(t, errors.As(err, &gitpkg.AuthError{}))
+}
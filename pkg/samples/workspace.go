@@ -0,0 +1,507 @@
+package samples
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+
+	"github.com/stripe/stripe-cli/pkg/proxy"
+	"github.com/stripe/stripe-cli/pkg/shell"
+)
+
+// serviceStopTimeout is how long services have to shut down once stopped
+const serviceStopTimeout = 5 * time.Second
+
+// Workspace is a set of samples and services that `stripe samples up` runs
+// together. It's described by a YAML file like:
+//
+//	env:
+//	  DOMAIN: http://localhost:4242
+//	services:
+//	  checkout:
+//	    sample: accept-a-payment
+//	    integration: custom-payment-flow
+//	    client: html
+//	    server: node
+//	    run: npm install && npm start
+//	    webhooks:
+//	      url: http://localhost:4242/webhook
+//	      events: [payment_intent.succeeded]
+//	  fulfillment:
+//	    path: ./fulfillment
+//	    run: go run .
+//	    env:
+//	      PORT: "4243"
+//	    webhooks:
+//	      url: http://localhost:4243/events
+//	      events: [checkout.session.completed]
+//
+// Services with a sample are created in their path the first time the
+// workspace is brought up, and other services run from existing code.
+type Workspace struct {
+	// Env is set for every service
+	Env map[string]string `yaml:"env"`
+	// Services are the services of the workspace, by name
+	Services map[string]*WorkspaceService `yaml:"services"`
+}
+
+// WorkspaceService is a service of a workspace.
+type WorkspaceService struct {
+	// Name is the key of the service in the workspace
+	Name string `yaml:"-"`
+
+	// Sample is the name of the sample the service is created from, if any
+	Sample string `yaml:"sample"`
+	// Integration, Client and Server select the parts of the sample to create.
+	// They can be left out when the sample has a single option.
+	Integration string `yaml:"integration"`
+	Client      string `yaml:"client"`
+	Server      string `yaml:"server"`
+
+	// Path is where the service is, relative to the workspace file. It
+	// defaults to a directory named after the service.
+	Path string `yaml:"path"`
+	// Dir is the directory Run is run in, relative to Path. It defaults to
+	// `server` for services created from a sample with a server.
+	Dir string `yaml:"dir"`
+	// Run is the shell command starting the service
+	Run string `yaml:"run"`
+	// Env is set for the service, in addition to the workspace's env
+	Env map[string]string `yaml:"env"`
+
+	// Webhooks are the events forwarded to the service, if any
+	Webhooks *WorkspaceWebhooks `yaml:"webhooks"`
+}
+
+// WorkspaceWebhooks configures the events forwarded to a service.
+type WorkspaceWebhooks struct {
+	// URL is the local endpoint events are forwarded to
+	URL string `yaml:"url"`
+	// ConnectURL is the local endpoint Connect events are forwarded to, if any
+	ConnectURL string `yaml:"connect_url"`
+	// Events are the event types forwarded, all of them if empty
+	Events []string `yaml:"events"`
+}
+
+// LoadWorkspace reads and validates the workspace file at path. The paths of
+// the services are made absolute.
+func LoadWorkspace(fs afero.Fs, path string) (*Workspace, error) {
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var workspace Workspace
+	if err := yaml.Unmarshal(data, &workspace); err != nil {
+		return nil, fmt.Errorf("%s is not a valid workspace file: %v", path, err)
+	}
+
+	root, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(workspace.Services) == 0 {
+		return nil, fmt.Errorf("%s doesn't define any services", path)
+	}
+
+	for name, service := range workspace.Services {
+		if service == nil {
+			return nil, fmt.Errorf("the service %s is empty", name)
+		}
+
+		service.Name = name
+
+		if err := service.validate(); err != nil {
+			return nil, fmt.Errorf("invalid service %s: %v", name, err)
+		}
+
+		if service.Path == "" {
+			service.Path = name
+		}
+		if !filepath.IsAbs(service.Path) {
+			service.Path = filepath.Join(root, service.Path)
+		}
+	}
+
+	return &workspace, nil
+}
+
+func (s *WorkspaceService) validate() error {
+	if s.Sample == "" && s.Run == "" {
+		return fmt.Errorf("either sample or run must be set")
+	}
+
+	if s.Webhooks == nil {
+		return nil
+	}
+
+	if s.Webhooks.URL == "" && s.Webhooks.ConnectURL == "" {
+		return fmt.Errorf("webhooks require a url or a connect_url")
+	}
+
+	for _, u := range []string{s.Webhooks.URL, s.Webhooks.ConnectURL} {
+		if u == "" {
+			continue
+		}
+
+		parsed, err := url.Parse(u)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("%s is not an http(s) URL", u)
+		}
+	}
+
+	return nil
+}
+
+// OrderedServices returns the services sorted by name.
+func (w *Workspace) OrderedServices() []*WorkspaceService {
+	services := make([]*WorkspaceService, 0, len(w.Services))
+	for _, service := range w.Services {
+		services = append(services, service)
+	}
+
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].Name < services[j].Name
+	})
+
+	return services
+}
+
+// EndpointRoutes returns the routes of the events forwarded to the services,
+// so that a single listen session serves the whole workspace.
+func (w *Workspace) EndpointRoutes() []proxy.EndpointRoute {
+	var routes []proxy.EndpointRoute
+
+	for _, service := range w.OrderedServices() {
+		if service.Webhooks == nil {
+			continue
+		}
+
+		events := service.Webhooks.Events
+		if len(events) == 0 {
+			events = []string{"*"}
+		}
+
+		if service.Webhooks.URL != "" {
+			routes = append(routes, proxy.EndpointRoute{URL: service.Webhooks.URL, EventTypes: events})
+		}
+		if service.Webhooks.ConnectURL != "" {
+			routes = append(routes, proxy.EndpointRoute{URL: service.Webhooks.ConnectURL, Connect: true, EventTypes: events})
+		}
+	}
+
+	return routes
+}
+
+// Events returns the event types the workspace's services receive, or nil
+// if one of them receives every event.
+func (w *Workspace) Events() []string {
+	seen := make(map[string]bool)
+	var events []string
+
+	for _, route := range w.EndpointRoutes() {
+		for _, event := range route.EventTypes {
+			if event == "*" {
+				return nil
+			}
+
+			if !seen[event] {
+				seen[event] = true
+				events = append(events, event)
+			}
+		}
+	}
+
+	sort.Strings(events)
+
+	return events
+}
+
+// SelectedConfig returns the parts of sampleConfig the service is created
+// with. Unlike `stripe samples create`, it doesn't prompt for the options
+// that aren't set, and fails if the sample has several of them.
+func (s *WorkspaceService) SelectedConfig(sampleConfig *SampleConfig) (*SelectedConfig, error) {
+	if len(sampleConfig.Integrations) == 0 {
+		return nil, fmt.Errorf("the sample %s doesn't have any integration", s.Sample)
+	}
+
+	var selected SelectedConfig
+
+	if s.Integration == "" {
+		if sampleConfig.HasIntegrations() {
+			return nil, fmt.Errorf("the sample %s has several integrations, set integration to one of %s", s.Sample, strings.Join(sampleConfig.IntegrationNames(), ", "))
+		}
+		selected.Integration = &sampleConfig.Integrations[0]
+	} else {
+		for i, integration := range sampleConfig.Integrations {
+			if integration.Name == s.Integration {
+				selected.Integration = &sampleConfig.Integrations[i]
+			}
+		}
+		if selected.Integration == nil {
+			return nil, fmt.Errorf("the sample %s doesn't have the integration %s, expected one of %s", s.Sample, s.Integration, strings.Join(sampleConfig.IntegrationNames(), ", "))
+		}
+	}
+
+	var err error
+
+	selected.Client, err = selectOption("client", s.Client, selected.Integration.Clients)
+	if err != nil {
+		return nil, fmt.Errorf("the sample %s %v", s.Sample, err)
+	}
+
+	selected.Server, err = selectOption("server", s.Server, selected.Integration.Servers)
+	if err != nil {
+		return nil, fmt.Errorf("the sample %s %v", s.Sample, err)
+	}
+
+	return &selected, nil
+}
+
+// selectOption returns value if it's one of options. Like the prompts of
+// `stripe samples create`, it returns an empty string when there's a single
+// option.
+func selectOption(kind, value string, options []string) (string, error) {
+	if value == "" {
+		if len(options) > 1 {
+			return "", fmt.Errorf("has several %ss, set %s to one of %s", kind, kind, strings.Join(options, ", "))
+		}
+		return "", nil
+	}
+
+	if !contains(options, value) {
+		return "", fmt.Errorf("doesn't have the %s %s, expected one of %s", kind, value, strings.Join(options, ", "))
+	}
+
+	if len(options) == 1 {
+		return "", nil
+	}
+
+	return value, nil
+}
+
+// WorkDir returns the directory the service runs in. Samples with a server
+// are run from their server directory, where their .env is written.
+func (s *WorkspaceService) WorkDir(fs afero.Fs) string {
+	if s.Dir != "" {
+		return filepath.Join(s.Path, s.Dir)
+	}
+
+	if s.Sample != "" {
+		server := filepath.Join(s.Path, "server")
+		if isDir, _ := afero.IsDir(fs, server); isDir {
+			return server
+		}
+	}
+
+	return s.Path
+}
+
+// Environ returns the environment the service runs with: base, overridden by
+// shared and then by the service's env. Values can reference variables set
+// before them, like `WEBHOOK_URL: ${DOMAIN}/webhook`.
+func (s *WorkspaceService) Environ(base []string, shared map[string]string) []string {
+	env := make(map[string]string)
+	var order []string
+
+	set := func(key, value string) {
+		if _, ok := env[key]; !ok {
+			order = append(order, key)
+		}
+		env[key] = value
+	}
+
+	for _, kv := range base {
+		split := strings.SplitN(kv, "=", 2)
+		if len(split) == 2 {
+			set(split[0], split[1])
+		}
+	}
+
+	for _, vars := range []map[string]string{shared, s.Env} {
+		keys := make([]string, 0, len(vars))
+		for key := range vars {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			set(key, os.Expand(vars[key], func(name string) string { return env[name] }))
+		}
+	}
+
+	environ := make([]string, 0, len(order))
+	for _, key := range order {
+		environ = append(environ, key+"="+env[key])
+	}
+
+	return environ
+}
+
+// RunServices runs the services of the workspace that have a run command
+// until ctx is canceled or one of them exits. The lines they output are
+// written to out prefixed with the name of the service.
+func RunServices(ctx context.Context, fs afero.Fs, services []*WorkspaceService, shared map[string]string, out io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var outMu sync.Mutex
+	errCh := make(chan error, len(services))
+	running := 0
+
+	width := 0
+	for _, service := range services {
+		if len(service.Name) > width {
+			width = len(service.Name)
+		}
+	}
+
+	for _, service := range services {
+		if service.Run == "" {
+			continue
+		}
+
+		prefix := fmt.Sprintf("%-*s | ", width, service.Name)
+		w := &prefixWriter{out: out, prefix: prefix, mu: &outMu}
+
+		cmdCtx, cancelCmd := withStopTimeout(ctx)
+		defer cancelCmd()
+
+		cmd := shell.Command(cmdCtx, service.Run)
+		cmd.Dir = service.WorkDir(fs)
+		cmd.Env = service.Environ(os.Environ(), shared)
+		cmd.Stdout = w
+		cmd.Stderr = w
+		setProcessGroup(cmd)
+
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("failed to start %s: %v", service.Name, err)
+		}
+
+		running++
+
+		exited := make(chan struct{})
+		go stopOnDone(ctx, cmd, exited)
+
+		go func(service *WorkspaceService) {
+			err := cmd.Wait()
+			close(exited)
+			w.Flush()
+
+			if ctx.Err() != nil {
+				errCh <- nil
+			} else if err != nil {
+				errCh <- fmt.Errorf("%s exited: %v", service.Name, err)
+			} else {
+				errCh <- fmt.Errorf("%s exited", service.Name)
+			}
+		}(service)
+	}
+
+	if running == 0 {
+		return fmt.Errorf("none of the services has a run command")
+	}
+
+	// Stop every service as soon as one of them exits
+	err := <-errCh
+	cancel()
+
+	for i := 1; i < running; i++ {
+		<-errCh
+	}
+
+	return err
+}
+
+// withStopTimeout returns a context done serviceStopTimeout after ctx, so
+// that commands run with it are killed if they haven't shut down by then.
+func withStopTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	stopCtx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		select {
+		case <-stopCtx.Done():
+			return
+		case <-ctx.Done():
+		}
+
+		select {
+		case <-stopCtx.Done():
+		case <-time.After(serviceStopTimeout):
+			cancel()
+		}
+	}()
+
+	return stopCtx, cancel
+}
+
+// stopOnDone stops cmd and the processes it started once ctx is done. They
+// are asked to shut down, and killed if they haven't exited after
+// serviceStopTimeout.
+func stopOnDone(ctx context.Context, cmd *exec.Cmd, exited <-chan struct{}) {
+	select {
+	case <-exited:
+		return
+	case <-ctx.Done():
+	}
+
+	signalProcessGroup(cmd, false)
+
+	select {
+	case <-exited:
+	case <-time.After(serviceStopTimeout):
+		signalProcessGroup(cmd, true)
+	}
+}
+
+// prefixWriter writes the lines written to it to out, prefixed with prefix.
+// Writers sharing mu don't interleave their lines.
+type prefixWriter struct {
+	out    io.Writer
+	prefix string
+	mu     *sync.Mutex
+
+	buf []byte
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+
+		w.writeLine(w.buf[:i+1])
+		w.buf = w.buf[i+1:]
+	}
+}
+
+// Flush writes the last line if it didn't end with a newline.
+func (w *prefixWriter) Flush() {
+	if len(w.buf) > 0 {
+		w.writeLine(append(w.buf, '\n'))
+		w.buf = nil
+	}
+}
+
+func (w *prefixWriter) writeLine(line []byte) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.out.Write(append([]byte(w.prefix), line...))
+}
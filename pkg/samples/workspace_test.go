@@ -0,0 +1,160 @@
+package samples
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stripe/stripe-cli/pkg/proxy"
+)
+
+const workspaceFile = `
+env:
+  DOMAIN: http://localhost:4242
+services:
+  checkout:
+    sample: accept-a-payment
+    integration: custom-payment-flow
+    server: node
+    run: npm start
+    webhooks:
+      url: http://localhost:4242/webhook
+      events: [payment_intent.succeeded, checkout.session.completed]
+  fulfillment:
+    path: ./services/fulfillment
+    run: go run .
+    env:
+      PORT: "4243"
+      WEBHOOK_URL: ${DOMAIN}/events
+    webhooks:
+      url: http://localhost:4243/events
+      connect_url: http://localhost:4243/connect
+      events: [checkout.session.completed]
+  worker:
+    path: /srv/worker
+    run: ./worker
+`
+
+func TestLoadWorkspace(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "/shop/workspace.yaml", []byte(workspaceFile), 0644)
+
+	workspace, err := LoadWorkspace(fs, "/shop/workspace.yaml")
+	assert.Nil(t, err)
+
+	services := workspace.OrderedServices()
+	assert.Len(t, services, 3)
+	assert.Equal(t, "checkout", services[0].Name)
+	assert.Equal(t, filepath.Join("/shop", "checkout"), services[0].Path)
+	assert.Equal(t, filepath.Join("/shop", "services", "fulfillment"), services[1].Path)
+	assert.Equal(t, "/srv/worker", services[2].Path)
+
+	assert.Equal(t, []proxy.EndpointRoute{
+		{URL: "http://localhost:4242/webhook", EventTypes: []string{"payment_intent.succeeded", "checkout.session.completed"}},
+		{URL: "http://localhost:4243/events", EventTypes: []string{"checkout.session.completed"}},
+		{URL: "http://localhost:4243/connect", Connect: true, EventTypes: []string{"checkout.session.completed"}},
+	}, workspace.EndpointRoutes())
+	assert.Equal(t, []string{"checkout.session.completed", "payment_intent.succeeded"}, workspace.Events())
+
+	workspace.Services["worker"].Webhooks = &WorkspaceWebhooks{URL: "http://localhost:4244"}
+	assert.Nil(t, workspace.Events())
+}
+
+func TestLoadWorkspaceInvalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"no services":     "env:\n  A: b\n",
+		"empty service":   "services:\n  checkout:\n",
+		"nothing to run":  "services:\n  checkout:\n    path: ./checkout\n",
+		"no webhook url":  "services:\n  checkout:\n    run: npm start\n    webhooks:\n      events: [charge.succeeded]\n",
+		"bad webhook url": "services:\n  checkout:\n    run: npm start\n    webhooks:\n      url: localhost:4242\n",
+		"invalid yaml":    "services: [",
+	} {
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "workspace.yaml", []byte(content), 0644)
+
+		_, err := LoadWorkspace(fs, "workspace.yaml")
+		assert.NotNil(t, err, name)
+	}
+}
+
+func TestWorkspaceServiceSelectedConfig(t *testing.T) {
+	sampleConfig := &SampleConfig{
+		Integrations: []SampleConfigIntegration{
+			{Name: "prebuilt-checkout", Clients: []string{"html"}, Servers: []string{"node", "ruby"}},
+			{Name: "custom-payment-flow", Clients: []string{"html", "react"}, Servers: []string{"node"}},
+		},
+	}
+
+	service := &WorkspaceService{Sample: "accept-a-payment", Integration: "prebuilt-checkout", Server: "ruby"}
+	selected, err := service.SelectedConfig(sampleConfig)
+	assert.Nil(t, err)
+	assert.Equal(t, "prebuilt-checkout", selected.Integration.Name)
+	assert.Equal(t, "", selected.Client)
+	assert.Equal(t, "ruby", selected.Server)
+
+	for _, service := range []*WorkspaceService{
+		{Sample: "accept-a-payment"},
+		{Sample: "accept-a-payment", Integration: "unknown"},
+		{Sample: "accept-a-payment", Integration: "prebuilt-checkout"},
+		{Sample: "accept-a-payment", Integration: "custom-payment-flow", Client: "vue"},
+	} {
+		_, err := service.SelectedConfig(sampleConfig)
+		assert.NotNil(t, err)
+	}
+}
+
+func TestWorkspaceServiceEnviron(t *testing.T) {
+	service := &WorkspaceService{
+		Env: map[string]string{
+			"PORT":        "4243",
+			"WEBHOOK_URL": "${DOMAIN}/events",
+		},
+	}
+
+	environ := service.Environ(
+		[]string{"PATH=/usr/bin", "PORT=3000"},
+		map[string]string{"DOMAIN": "http://localhost:4242", "STRIPE_WEBHOOK_SECRET": "whsec_123"},
+	)
+
+	assert.Equal(t, []string{
+		"PATH=/usr/bin",
+		"PORT=4243",
+		"DOMAIN=http://localhost:4242",
+		"STRIPE_WEBHOOK_SECRET=whsec_123",
+		"WEBHOOK_URL=http://localhost:4242/events",
+	}, environ)
+}
+
+func TestWorkspaceServiceWorkDir(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	fs.MkdirAll(filepath.Join("/shop", "checkout", "server"), 0755)
+
+	assert.Equal(t, filepath.Join("/shop", "checkout", "server"), (&WorkspaceService{Sample: "accept-a-payment", Path: "/shop/checkout"}).WorkDir(fs))
+	assert.Equal(t, filepath.Join("/shop", "checkout", "api"), (&WorkspaceService{Sample: "accept-a-payment", Path: "/shop/checkout", Dir: "api"}).WorkDir(fs))
+	assert.Equal(t, "/shop/checkout", (&WorkspaceService{Path: "/shop/checkout"}).WorkDir(fs))
+}
+
+func TestRunServices(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the services are run with sh")
+	}
+
+	dir := t.TempDir()
+	services := []*WorkspaceService{
+		{Name: "api", Path: dir, Run: "echo $GREETING from api; sleep 10"},
+		{Name: "worker", Path: dir, Run: "sleep 0.5; printf 'working'; exit 3"},
+	}
+
+	var out bytes.Buffer
+	err := RunServices(context.Background(), afero.NewOsFs(), services, map[string]string{"GREETING": "hello"}, &out)
+	assert.EqualError(t, err, "worker exited: exit status 3")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.ElementsMatch(t, []string{"api    | hello from api", "worker | working"}, lines)
+}
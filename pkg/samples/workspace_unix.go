@@ -0,0 +1,26 @@
+//go:build !windows
+// +build !windows
+
+package samples
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup runs cmd in its own process group, so that the processes
+// it starts can be stopped with it.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// signalProcessGroup sends sig to the process group of cmd, or kills it if
+// kill is set.
+func signalProcessGroup(cmd *exec.Cmd, kill bool) {
+	sig := syscall.SIGTERM
+	if kill {
+		sig = syscall.SIGKILL
+	}
+
+	syscall.Kill(-cmd.Process.Pid, sig) // #nosec G104
+}
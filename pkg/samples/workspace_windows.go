@@ -0,0 +1,18 @@
+//go:build windows
+// +build windows
+
+package samples
+
+import (
+	"os/exec"
+)
+
+// setProcessGroup does nothing on Windows, where processes are stopped one at
+// a time.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// signalProcessGroup kills the process of cmd, as Windows doesn't support
+// signals for graceful shutdowns.
+func signalProcessGroup(cmd *exec.Cmd, kill bool) {
+	cmd.Process.Kill() // #nosec G104
+}
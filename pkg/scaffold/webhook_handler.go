@@ -0,0 +1,186 @@
+// Package scaffold generates starter code for integrating with Stripe.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/iancoleman/strcase"
+	"github.com/spf13/afero"
+)
+
+//go:embed templates/*
+var templates embed.FS
+
+// webhookHandlerLanguages maps supported languages to the file generated for
+// them.
+var webhookHandlerLanguages = map[string]string{
+	"go":     "handler.go",
+	"node":   "handler.js",
+	"python": "handler.py",
+	"ruby":   "handler.rb",
+}
+
+// goObjectTypes overrides the stripe-go type of event objects whose type
+// can't be derived from the event name. An empty value means there is no
+// single type and the object is left untyped.
+var goObjectTypes = map[string]string{
+	"account.external_account": "",
+	"application_fee.refund":   "FeeRefund",
+	"charge.dispute":           "Dispute",
+	"charge.refund":            "Refund",
+	"customer.discount":        "Discount",
+	"customer.source":          "",
+	"customer.subscription":    "Subscription",
+	"customer.tax_id":          "TaxID",
+	"invoiceitem":              "InvoiceItem",
+}
+
+// WebhookHandler describes a webhook handler to generate
+type WebhookHandler struct {
+	// Lang is the language to generate the handler in, one of
+	// WebhookHandlerLanguages()
+	Lang string
+	// Events are the event types the handler dispatches
+	Events []string
+	// Package is the name of the Go package of the handler
+	Package string
+}
+
+// WebhookHandlerLanguages returns the languages handlers can be generated in.
+func WebhookHandlerLanguages() []string {
+	langs := make([]string, 0, len(webhookHandlerLanguages))
+	for lang := range webhookHandlerLanguages {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	return langs
+}
+
+// Generate writes the handler to outDir and returns the path of the created
+// file. Existing files are only overwritten if force is true.
+func (h WebhookHandler) Generate(fs afero.Fs, outDir string, force bool) (string, error) {
+	fileName, ok := webhookHandlerLanguages[h.Lang]
+	if !ok {
+		return "", fmt.Errorf("unsupported language %q, supported languages are: %s", h.Lang, strings.Join(WebhookHandlerLanguages(), ", "))
+	}
+
+	if len(h.Events) == 0 {
+		return "", fmt.Errorf("at least one event type is required")
+	}
+
+	code, err := h.render()
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(outDir, fileName)
+
+	if !force {
+		exists, err := afero.Exists(fs, path)
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			return "", fmt.Errorf("%s already exists, use --force to overwrite it", path)
+		}
+	}
+
+	if err := fs.MkdirAll(outDir, 0755); err != nil {
+		return "", err
+	}
+
+	if err := afero.WriteFile(fs, path, code, 0644); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+//
+// Private types
+//
+
+// handledEvent holds the identifiers generated code uses for an event type.
+type handledEvent struct {
+	Type string
+
+	GoFunc string
+	GoType string
+
+	CamelFunc   string
+	CamelObject string
+
+	SnakeFunc   string
+	SnakeObject string
+}
+
+//
+// Private functions
+//
+
+func (h WebhookHandler) render() ([]byte, error) {
+	tmpl, err := template.ParseFS(templates, fmt.Sprintf("templates/webhook_handler.%s.tmpl", h.Lang))
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := h.Package
+	if pkg == "" {
+		pkg = "webhooks"
+	}
+
+	events := make([]handledEvent, 0, len(h.Events))
+	for _, eventType := range h.Events {
+		events = append(events, newHandledEvent(eventType))
+	}
+
+	var buf bytes.Buffer
+
+	err = tmpl.Execute(&buf, struct {
+		Package string
+		Events  []handledEvent
+	}{pkg, events})
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func newHandledEvent(eventType string) handledEvent {
+	// the object's resource is everything before the action, e.g.
+	// `checkout.session` for `checkout.session.completed`
+	resource := eventType
+	if i := strings.LastIndex(eventType, "."); i >= 0 {
+		resource = eventType[:i]
+	}
+
+	goType, ok := goObjectTypes[resource]
+	if !ok {
+		goType = strcase.ToCamel(strings.ReplaceAll(resource, ".", "_"))
+	}
+
+	object := resource
+	if i := strings.LastIndex(resource, "."); i >= 0 {
+		object = resource[i+1:]
+	}
+
+	name := strings.ReplaceAll(eventType, ".", "_")
+
+	return handledEvent{
+		Type:        eventType,
+		GoFunc:      "handle" + strcase.ToCamel(name),
+		GoType:      goType,
+		CamelFunc:   "handle" + strcase.ToCamel(name),
+		CamelObject: strcase.ToLowerCamel(object),
+		SnakeFunc:   "handle_" + strcase.ToSnake(name),
+		SnakeObject: strcase.ToSnake(object),
+	}
+}
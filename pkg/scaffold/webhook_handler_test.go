@@ -0,0 +1,66 @@
+package scaffold
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateWebhookHandlerGo(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	handler := WebhookHandler{
+		Lang:    "go",
+		Events:  []string{"payment_intent.succeeded", "customer.subscription.deleted", "checkout.session.completed"},
+		Package: "hooks",
+	}
+
+	path, err := handler.Generate(fs, "webhooks", false)
+	require.NoError(t, err)
+	require.Equal(t, "webhooks/handler.go", path)
+
+	code, err := afero.ReadFile(fs, path)
+	require.NoError(t, err)
+	require.Contains(t, string(code), "package hooks")
+	require.Contains(t, string(code), `case "payment_intent.succeeded":`)
+	require.Contains(t, string(code), "func handlePaymentIntentSucceeded(event stripe.Event, obj stripe.PaymentIntent) error")
+	require.Contains(t, string(code), "func handleCustomerSubscriptionDeleted(event stripe.Event, obj stripe.Subscription) error")
+	require.Contains(t, string(code), "func handleCheckoutSessionCompleted(event stripe.Event, obj stripe.CheckoutSession) error")
+}
+
+func TestGenerateWebhookHandlerOtherLanguages(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	events := []string{"invoice.paid"}
+
+	for lang, want := range map[string]string{
+		"node":   "async function handleInvoicePaid(event, invoice)",
+		"python": "def handle_invoice_paid(event, invoice):",
+		"ruby":   "def self.handle_invoice_paid(event, invoice)",
+	} {
+		path, err := WebhookHandler{Lang: lang, Events: events}.Generate(fs, lang, false)
+		require.NoError(t, err)
+
+		code, err := afero.ReadFile(fs, path)
+		require.NoError(t, err)
+		require.Contains(t, string(code), want)
+	}
+}
+
+func TestGenerateWebhookHandlerErrors(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	_, err := WebhookHandler{Lang: "cobol", Events: []string{"invoice.paid"}}.Generate(fs, ".", false)
+	require.EqualError(t, err, `unsupported language "cobol", supported languages are: go, node, python, ruby`)
+
+	_, err = WebhookHandler{Lang: "go"}.Generate(fs, ".", false)
+	require.EqualError(t, err, "at least one event type is required")
+
+	require.NoError(t, afero.WriteFile(fs, "handler.go", []byte("existing"), 0644))
+
+	_, err = WebhookHandler{Lang: "go", Events: []string{"invoice.paid"}}.Generate(fs, ".", false)
+	require.EqualError(t, err, "handler.go already exists, use --force to overwrite it")
+
+	_, err = WebhookHandler{Lang: "go", Events: []string{"invoice.paid"}}.Generate(fs, ".", true)
+	require.NoError(t, err)
+}
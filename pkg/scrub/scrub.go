@@ -0,0 +1,303 @@
+// Package scrub replaces the personal data of event payloads with fake
+// values, so recorded events can be shared, e.g. in bug reports.
+//
+// Values are replaced consistently: the same email address is replaced with
+// the same fake address everywhere it appears, so the relationships between
+// objects still show. IDs, amounts, statuses and the structure of the
+// payloads are kept as is.
+package scrub
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// kind is a category of personal data
+type kind int
+
+const (
+	kindNone kind = iota
+	kindEmail
+	kindName
+	kindPhone
+	kindStreet
+	kindUnit
+	kindCity
+	kindPostalCode
+	kindFingerprint
+	kindIP
+)
+
+// fieldKinds maps the names of the fields holding personal data to the kind
+// of their value
+var fieldKinds = map[string]kind{
+	"email":               kindEmail,
+	"receipt_email":       kindEmail,
+	"billing_email":       kindEmail,
+	"name":                kindName,
+	"first_name":          kindName,
+	"last_name":           kindName,
+	"maiden_name":         kindName,
+	"first_name_kana":     kindName,
+	"first_name_kanji":    kindName,
+	"last_name_kana":      kindName,
+	"last_name_kanji":     kindName,
+	"account_holder_name": kindName,
+	"phone":               kindPhone,
+	"fingerprint":         kindFingerprint,
+	"ip":                  kindIP,
+	"ip_address":          kindIP,
+}
+
+// addressKinds maps the fields of address hashes to the kind of their value.
+// The state and the country are kept, since they often matter to debug taxes
+// and payment methods.
+var addressKinds = map[string]kind{
+	"line1":       kindStreet,
+	"line2":       kindUnit,
+	"city":        kindCity,
+	"town":        kindCity,
+	"postal_code": kindPostalCode,
+}
+
+// emailPattern matches the email addresses found in free text
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+
+// Scrubber replaces personal data with fake values. The fake values are
+// numbered in the order the real values are met, so a Scrubber must be used
+// for all the payloads that are shared together.
+type Scrubber struct {
+	fakes  map[kind]map[string]string
+	counts map[kind]int
+}
+
+// New returns a Scrubber.
+func New() *Scrubber {
+	return &Scrubber{
+		fakes:  make(map[kind]map[string]string),
+		counts: make(map[kind]int),
+	}
+}
+
+// Scrub returns payload, a JSON document, with its personal data replaced.
+// The order of the keys is kept.
+func (s *Scrubber) Scrub(payload []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+
+	var out bytes.Buffer
+	if err := s.scrubValue(dec, &out, kindNone, false); err != nil {
+		return nil, err
+	}
+
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after the JSON document")
+	}
+
+	return out.Bytes(), nil
+}
+
+// ScrubLines scrubs the newline-delimited JSON documents read from r, and
+// writes them to w. Blank lines are skipped. It returns the number of
+// documents scrubbed.
+func (s *Scrubber) ScrubLines(r io.Reader, w io.Writer) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	count := 0
+	line := 0
+	for scanner.Scan() {
+		line++
+
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		scrubbed, err := s.Scrub(text)
+		if err != nil {
+			return count, fmt.Errorf("line %d: %v", line, err)
+		}
+
+		if _, err := fmt.Fprintf(w, "%s\n", scrubbed); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return count, scanner.Err()
+}
+
+// scrubValue copies the next value of dec to out. Strings are replaced if k
+// is a kind of personal data; inAddress tells whether the value is in an
+// address hash.
+func (s *Scrubber) scrubValue(dec *json.Decoder, out *bytes.Buffer, k kind, inAddress bool) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch t := token.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			return s.scrubObject(dec, out, inAddress)
+		case '[':
+			return s.scrubArray(dec, out, k, inAddress)
+		default:
+			return fmt.Errorf("unexpected %s", t)
+		}
+	case string:
+		if k != kindNone && t != "" {
+			t = s.fake(k, t)
+		} else {
+			// Free text, like descriptions, sometimes mentions emails
+			t = emailPattern.ReplaceAllStringFunc(t, func(email string) string {
+				return s.fake(kindEmail, email)
+			})
+		}
+		return writeString(out, t)
+	case json.Number:
+		out.WriteString(t.String())
+	case bool:
+		fmt.Fprint(out, t)
+	case nil:
+		out.WriteString("null")
+	}
+
+	return nil
+}
+
+func (s *Scrubber) scrubObject(dec *json.Decoder, out *bytes.Buffer, inAddress bool) error {
+	out.WriteByte('{')
+
+	for i := 0; dec.More(); i++ {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key := token.(string)
+
+		if i > 0 {
+			out.WriteByte(',')
+		}
+		if err := writeString(out, key); err != nil {
+			return err
+		}
+		out.WriteByte(':')
+
+		k := fieldKinds[key]
+		if inAddress {
+			k = addressKinds[key]
+		}
+
+		if err := s.scrubValue(dec, out, k, isAddressField(key)); err != nil {
+			return err
+		}
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	out.WriteByte('}')
+
+	return nil
+}
+
+func (s *Scrubber) scrubArray(dec *json.Decoder, out *bytes.Buffer, k kind, inAddress bool) error {
+	out.WriteByte('[')
+
+	for i := 0; dec.More(); i++ {
+		if i > 0 {
+			out.WriteByte(',')
+		}
+		if err := s.scrubValue(dec, out, k, inAddress); err != nil {
+			return err
+		}
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	out.WriteByte(']')
+
+	return nil
+}
+
+// isAddressField tells whether key holds an address hash, like `address`,
+// `address_kana` or `registered_address`.
+func isAddressField(key string) bool {
+	if key == "ip_address" {
+		return false
+	}
+
+	return key == "address" || strings.HasPrefix(key, "address_") || strings.HasSuffix(key, "_address")
+}
+
+// fake returns the fake value replacing value, the same one every time.
+func (s *Scrubber) fake(k kind, value string) string {
+	fakes, ok := s.fakes[k]
+	if !ok {
+		fakes = make(map[string]string)
+		s.fakes[k] = fakes
+	}
+
+	if fake, ok := fakes[value]; ok {
+		return fake
+	}
+
+	s.counts[k]++
+	fake := generate(k, s.counts[k], value)
+	fakes[value] = fake
+
+	return fake
+}
+
+// generate returns the nth fake value of kind k
+func generate(k kind, n int, value string) string {
+	switch k {
+	case kindEmail:
+		return fmt.Sprintf("person%d@example.com", n)
+	case kindName:
+		return fmt.Sprintf("Name %d", n)
+	case kindPhone:
+		return fmt.Sprintf("+1555%07d", n)
+	case kindStreet:
+		return fmt.Sprintf("%d Example Street", n)
+	case kindUnit:
+		return fmt.Sprintf("Unit %d", n)
+	case kindCity:
+		return fmt.Sprintf("City %d", n)
+	case kindPostalCode:
+		return fmt.Sprintf("%05d", n)
+	case kindFingerprint:
+		// Keep the length of the fingerprint, which some integrations check
+		if len(value) > 2 {
+			return fmt.Sprintf("fp%0*d", len(value)-2, n)
+		}
+		return fmt.Sprintf("fp%d", n)
+	case kindIP:
+		// 10.0.0.0/8 is private, so the fake addresses are never anyone's
+		return fmt.Sprintf("10.%d.%d.%d", n>>16&0xff, n>>8&0xff, n&0xff)
+	default:
+		return value
+	}
+}
+
+func writeString(out *bytes.Buffer, s string) error {
+	enc := json.NewEncoder(out)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		return err
+	}
+
+	// Encode terminates the value with a newline
+	out.Truncate(out.Len() - 1)
+
+	return nil
+}
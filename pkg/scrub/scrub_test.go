@@ -0,0 +1,66 @@
+package scrub
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScrub(t *testing.T) {
+	s := New()
+
+	scrubbed, err := s.Scrub([]byte(`{
+		"id": "cus_123",
+		"email": "jenny@example.org",
+		"name": "Jenny Rosen",
+		"phone": "+14155550123",
+		"description": "Customer for jenny@example.org",
+		"balance": 1.50,
+		"delinquent": false,
+		"discount": null,
+		"address": {"line1": "1 Main St", "line2": "", "city": "San Francisco", "state": "CA", "postal_code": "94111", "country": "US"},
+		"invoice_settings": {"footer": "<b>Thanks</b>"}
+	}`))
+	require.NoError(t, err)
+	require.Equal(t, `{"id":"cus_123","email":"person1@example.com","name":"Name 1","phone":"+15550000001","description":"Customer for person1@example.com","balance":1.50,"delinquent":false,"discount":null,"address":{"line1":"1 Example Street","line2":"","city":"City 1","state":"CA","postal_code":"00001","country":"US"},"invoice_settings":{"footer":"<b>Thanks</b>"}}`, string(scrubbed))
+}
+
+func TestScrubIsConsistent(t *testing.T) {
+	s := New()
+
+	first, err := s.Scrub([]byte(`{"card": {"fingerprint": "Xt5EWLLDS7FJjR1c"}, "billing_details": {"email": "jenny@example.org"}}`))
+	require.NoError(t, err)
+	require.Equal(t, `{"card":{"fingerprint":"fp00000000000001"},"billing_details":{"email":"person1@example.com"}}`, string(first))
+
+	second, err := s.Scrub([]byte(`{"receipt_email": "jenny@example.org", "other": {"fingerprint": "Xt5EWLLDS7FJjR1c"}, "email": "bob@example.org"}`))
+	require.NoError(t, err)
+	require.Equal(t, `{"receipt_email":"person1@example.com","other":{"fingerprint":"fp00000000000001"},"email":"person2@example.com"}`, string(second))
+}
+
+func TestScrubKeepsIPAddressOutOfAddresses(t *testing.T) {
+	scrubbed, err := New().Scrub([]byte(`{"online": {"ip_address": "203.0.113.7", "user_agent": "curl"}, "shipping": {"address": {"line1": "1 Main St"}}}`))
+	require.NoError(t, err)
+	require.Equal(t, `{"online":{"ip_address":"10.0.0.1","user_agent":"curl"},"shipping":{"address":{"line1":"1 Example Street"}}}`, string(scrubbed))
+}
+
+func TestScrubLines(t *testing.T) {
+	in := strings.NewReader("{\"email\": \"jenny@example.org\"}\n\n[{\"email\": \"jenny@example.org\"}]\n")
+
+	var out bytes.Buffer
+	count, err := New().ScrubLines(in, &out)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+	require.Equal(t, "{\"email\":\"person1@example.com\"}\n[{\"email\":\"person1@example.com\"}]\n", out.String())
+}
+
+func TestScrubLinesInvalidJSON(t *testing.T) {
+	var out bytes.Buffer
+	_, err := New().ScrubLines(strings.NewReader("{\"id\": \"evt_1\"}\nnot json\n"), &out)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "line 2")
+
+	_, err = New().ScrubLines(strings.NewReader("{} {}\n"), &out)
+	require.EqualError(t, err, "line 1: unexpected data after the JSON document")
+}
@@ -0,0 +1,260 @@
+// Package selfupdate updates the CLI to its latest release, for the users who
+// installed it without a package manager.
+package selfupdate
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v28/github"
+
+	"github.com/stripe/stripe-cli/pkg/download"
+)
+
+// Channels of the releases
+const (
+	ChannelStable = "stable"
+	ChannelBeta   = "beta"
+)
+
+// PublicKey is the base64 ed25519 public key the checksums of the releases
+// are signed with, set at build time with -ldflags "-X" by the GoReleaser
+// configs. Releases whose checksums aren't signed with it are refused, and
+// builds without it can't update themselves.
+var PublicKey = ""
+
+// ErrNoPublicKey is returned by Download when the CLI was built without the
+// public key of the releases, so that releases can't be verified
+var ErrNoPublicKey = errors.New("this build of the CLI can't verify releases, install the latest version from https://stripe.com/docs/stripe-cli instead")
+
+// maxBinarySize caps the size of the downloaded assets of a release
+const maxBinarySize = 200 * 1024 * 1024
+
+// downloadTimeout is how long downloading an asset may take
+const downloadTimeout = 5 * time.Minute
+
+// newGitHubClient returns the client the releases are looked up with
+var newGitHubClient = func() *github.Client {
+	return github.NewClient(nil)
+}
+
+// Release is a release of the CLI
+type Release struct {
+	// Version is the version of the release, without the v of its tag
+	Version    string
+	Prerelease bool
+	// Assets are the download URLs of the files of the release, by name
+	Assets map[string]string
+}
+
+// Latest returns the latest release of a channel: the latest stable release,
+// or for the beta channel the latest release, prereleases included
+func Latest(ctx context.Context, channel string) (*Release, error) {
+	client := newGitHubClient()
+
+	var release *github.RepositoryRelease
+
+	switch channel {
+	case ChannelStable:
+		latest, _, err := client.Repositories.GetLatestRelease(ctx, "stripe", "stripe-cli")
+		if err != nil {
+			return nil, err
+		}
+		release = latest
+	case ChannelBeta:
+		releases, _, err := client.Repositories.ListReleases(ctx, "stripe", "stripe-cli", &github.ListOptions{PerPage: 10})
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range releases {
+			if !r.GetDraft() {
+				release = r
+				break
+			}
+		}
+		if release == nil {
+			return nil, errors.New("there are no releases")
+		}
+	default:
+		return nil, fmt.Errorf("unknown channel ‘%s’, use %s or %s", channel, ChannelStable, ChannelBeta)
+	}
+
+	assets := make(map[string]string)
+	for _, asset := range release.Assets {
+		assets[asset.GetName()] = asset.GetBrowserDownloadURL()
+	}
+
+	return &Release{
+		Version:    strings.TrimPrefix(release.GetTagName(), "v"),
+		Prerelease: release.GetPrerelease(),
+		Assets:     assets,
+	}, nil
+}
+
+// assetNames returns the names of the archive of the CLI for a platform and
+// of the checksums file listing it, as GoReleaser names them
+func assetNames(version, goos, goarch string) (string, string, error) {
+	osNames := map[string]string{"linux": "linux", "darwin": "mac-os", "windows": "windows"}
+	archNames := map[string]string{"amd64": "x86_64", "386": "i386", "arm64": "arm64"}
+
+	osName, ok := osNames[goos]
+	if !ok {
+		return "", "", fmt.Errorf("there are no releases for %s", goos)
+	}
+	archName, ok := archNames[goarch]
+	if !ok {
+		return "", "", fmt.Errorf("there are no releases for %s/%s", goos, goarch)
+	}
+
+	ext := "tar.gz"
+	if goos == "windows" {
+		ext = "zip"
+	}
+
+	checksums := "stripe-" + osName + "-checksums.txt"
+	if goos == "darwin" {
+		checksums = "stripe-mac-checksums.txt"
+	}
+
+	return fmt.Sprintf("stripe_%s_%s_%s.%s", version, osName, archName, ext), checksums, nil
+}
+
+// Download downloads the binary of a release for the current platform,
+// verifying the signature of the checksums of the release with PublicKey,
+// and the archive against the checksums
+func Download(ctx context.Context, release *Release) ([]byte, error) {
+	if PublicKey == "" {
+		return nil, ErrNoPublicKey
+	}
+
+	archiveName, checksumsName, err := assetNames(release.Version, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return nil, err
+	}
+
+	archiveURL, ok := release.Assets[archiveName]
+	if !ok {
+		return nil, fmt.Errorf("release %s has no %s", release.Version, archiveName)
+	}
+	checksumsURL, ok := release.Assets[checksumsName]
+	if !ok {
+		return nil, fmt.Errorf("release %s has no %s, it can't be verified", release.Version, checksumsName)
+	}
+
+	checksums, err := download.File(ctx, checksumsURL, downloadTimeout, maxBinarySize)
+	if err != nil {
+		return nil, err
+	}
+
+	signatureURL, ok := release.Assets[checksumsName+".sig"]
+	if !ok {
+		return nil, fmt.Errorf("release %s has no signature of %s, it can't be verified", release.Version, checksumsName)
+	}
+
+	signature, err := download.File(ctx, signatureURL, downloadTimeout, maxBinarySize)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifySignature(checksums, signature, PublicKey); err != nil {
+		return nil, err
+	}
+
+	expected, ok := download.FindChecksum(checksums, archiveName)
+	if !ok {
+		return nil, fmt.Errorf("the checksums of the release don't list %s", archiveName)
+	}
+
+	archive, err := download.File(ctx, archiveURL, downloadTimeout, maxBinarySize)
+	if err != nil {
+		return nil, err
+	}
+
+	if !download.ChecksumMatches(archive, expected) {
+		return nil, fmt.Errorf("the checksum of %s doesn't match the checksums of the release", archiveName)
+	}
+
+	binary := "stripe"
+	if strings.HasSuffix(archiveName, ".zip") {
+		binary = "stripe.exe"
+	}
+
+	return download.ReadArchiveFile(archive, archiveName, binary)
+}
+
+// Replace replaces the executable at path with binary. The new binary is
+// written next to it and renamed over it, so that the executable is never
+// left half-written.
+func Replace(path string, binary []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+
+	tmp, err := ioutil.TempFile(dir, ".stripe-update-")
+	if err != nil {
+		return fmt.Errorf("could not write to %s, you may need to run the update with more permissions: %w", dir, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode()|0111); err != nil {
+		return err
+	}
+
+	// Windows doesn't allow replacing a running executable, but it allows
+	// renaming it
+	if runtime.GOOS == "windows" {
+		old := path + ".old"
+		os.Remove(old) // #nosec G104
+		if err := os.Rename(path, old); err != nil {
+			return err
+		}
+		if err := os.Rename(tmp.Name(), path); err != nil {
+			os.Rename(old, path) // #nosec G104
+			return err
+		}
+		return nil
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// verifySignature verifies the ed25519 signature of data, which may be raw or
+// base64-encoded
+func verifySignature(data, signature []byte, publicKey string) error {
+	key, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return errors.New("the public key of the releases is invalid")
+	}
+
+	if len(signature) != ed25519.SignatureSize {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+		if err == nil {
+			signature = decoded
+		}
+	}
+
+	if !ed25519.Verify(ed25519.PublicKey(key), data, signature) {
+		return errors.New("the signature of the checksums of the release is invalid")
+	}
+
+	return nil
+}
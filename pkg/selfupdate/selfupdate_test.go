@@ -0,0 +1,156 @@
+package selfupdate
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/google/go-github/v28/github"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/download/downloadtest"
+)
+
+func TestAssetNames(t *testing.T) {
+	archive, checksums, err := assetNames("1.7.0", "darwin", "arm64")
+	require.NoError(t, err)
+	require.Equal(t, "stripe_1.7.0_mac-os_arm64.tar.gz", archive)
+	require.Equal(t, "stripe-mac-checksums.txt", checksums)
+
+	archive, checksums, err = assetNames("1.7.0", "windows", "386")
+	require.NoError(t, err)
+	require.Equal(t, "stripe_1.7.0_windows_i386.zip", archive)
+	require.Equal(t, "stripe-windows-checksums.txt", checksums)
+
+	_, _, err = assetNames("1.7.0", "plan9", "amd64")
+	require.EqualError(t, err, "there are no releases for plan9")
+}
+
+func TestLatest(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/stripe/stripe-cli/releases/latest":
+			w.Write([]byte(`{"tag_name":"v1.7.0","assets":[{"name":"stripe-linux-checksums.txt","browser_download_url":"https://example.com/checksums"}]}`)) // #nosec G104
+		case "/repos/stripe/stripe-cli/releases":
+			w.Write([]byte(`[{"tag_name":"v1.8.0-rc1","draft":true},{"tag_name":"v1.8.0-beta","prerelease":true}]`)) // #nosec G104
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	defer func(f func() *github.Client) { newGitHubClient = f }(newGitHubClient)
+	newGitHubClient = func() *github.Client {
+		client := github.NewClient(nil)
+		client.BaseURL, _ = url.Parse(ts.URL + "/")
+		return client
+	}
+
+	release, err := Latest(context.Background(), ChannelStable)
+	require.NoError(t, err)
+	require.Equal(t, "1.7.0", release.Version)
+	require.Equal(t, "https://example.com/checksums", release.Assets["stripe-linux-checksums.txt"])
+
+	release, err = Latest(context.Background(), ChannelBeta)
+	require.NoError(t, err)
+	require.Equal(t, "1.8.0-beta", release.Version)
+	require.True(t, release.Prerelease)
+
+	_, err = Latest(context.Background(), "nightly")
+	require.EqualError(t, err, "unknown channel ‘nightly’, use stable or beta")
+}
+
+func TestDownload(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("releases are .zip archives on Windows")
+	}
+
+	archiveName, checksumsName, err := assetNames("1.7.0", runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		t.Skip(err)
+	}
+
+	archive := downloadtest.TarGz(t, map[string]string{"stripe": "new binary"})
+	sum := sha256.Sum256(archive)
+	checksums := []byte(fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), archiveName))
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, checksums))
+
+	files := map[string][]byte{
+		"/archive":   archive,
+		"/checksums": checksums,
+		"/signature": []byte(signature),
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(files[r.URL.Path]) // #nosec G104
+	}))
+	defer ts.Close()
+
+	release := &Release{
+		Version: "1.7.0",
+		Assets: map[string]string{
+			archiveName:            ts.URL + "/archive",
+			checksumsName:          ts.URL + "/checksums",
+			checksumsName + ".sig": ts.URL + "/signature",
+		},
+	}
+
+	defer func(key string) { PublicKey = key }(PublicKey)
+	PublicKey = ""
+
+	_, err = Download(context.Background(), release)
+	require.Equal(t, ErrNoPublicKey, err)
+
+	PublicKey = base64.StdEncoding.EncodeToString(publicKey)
+
+	binary, err := Download(context.Background(), release)
+	require.NoError(t, err)
+	require.Equal(t, "new binary", string(binary))
+
+	otherKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	PublicKey = base64.StdEncoding.EncodeToString(otherKey)
+
+	_, err = Download(context.Background(), release)
+	require.EqualError(t, err, "the signature of the checksums of the release is invalid")
+
+	PublicKey = base64.StdEncoding.EncodeToString(publicKey)
+	delete(release.Assets, checksumsName+".sig")
+
+	_, err = Download(context.Background(), release)
+	require.EqualError(t, err, fmt.Sprintf("release 1.7.0 has no signature of %s, it can't be verified", checksumsName))
+
+	release.Assets[checksumsName+".sig"] = ts.URL + "/signature"
+	files["/archive"] = downloadtest.TarGz(t, map[string]string{"stripe": "tampered binary"})
+
+	_, err = Download(context.Background(), release)
+	require.EqualError(t, err, fmt.Sprintf("the checksum of %s doesn't match the checksums of the release", archiveName))
+}
+
+func TestReplace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stripe")
+	require.NoError(t, ioutil.WriteFile(path, []byte("old binary"), 0755))
+
+	require.NoError(t, Replace(path, []byte("new binary")))
+
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "new binary", string(content))
+
+	files, err := ioutil.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+}
@@ -0,0 +1,74 @@
+// Package service installs long-running CLI commands as services managed by
+// the operating system: systemd user units on Linux, and Windows services.
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"runtime"
+
+	exec "golang.org/x/sys/execabs"
+)
+
+var execCommand = exec.Command
+
+var nameRegexp = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// ErrUnsupported is returned on platforms services can't be installed on
+var ErrUnsupported = errors.New("services can only be installed with systemd on Linux, or on Windows")
+
+// Config describes a service running a command of the CLI
+type Config struct {
+	// Name identifies the service, and is used to uninstall it
+	Name string
+	// Description is shown by the service manager
+	Description string
+	// Executable is the absolute path of the CLI binary
+	Executable string
+	// Args are the arguments the CLI is run with
+	Args []string
+}
+
+// Install registers the service with the service manager of the system and
+// starts it. The service starts again on boot, and is restarted when it fails.
+func Install(cfg Config) error {
+	if err := validateName(cfg.Name); err != nil {
+		return err
+	}
+
+	return install(cfg)
+}
+
+// Uninstall stops the service and removes it from the service manager.
+func Uninstall(name string) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
+
+	return uninstall(name)
+}
+
+// StatusCommand returns a command users can run to check on the service.
+func StatusCommand(name string) string {
+	if runtime.GOOS == "windows" {
+		return fmt.Sprintf("sc.exe query %s", name)
+	}
+
+	return fmt.Sprintf("systemctl --user status %s", name)
+}
+
+// Run calls run, in a way the service manager can stop when the process was
+// started as a service. ctx is canceled when the service is asked to stop.
+func Run(ctx context.Context, run func(context.Context) error) error {
+	return runService(ctx, run)
+}
+
+func validateName(name string) error {
+	if !nameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid service name %q: only letters, digits, '_', '.' and '-' are allowed", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+//go:build !windows
+// +build !windows
+
+package service
+
+import (
+	"context"
+	"runtime"
+)
+
+func install(cfg Config) error {
+	if runtime.GOOS != "linux" {
+		return ErrUnsupported
+	}
+
+	return installSystemd(cfg)
+}
+
+func uninstall(name string) error {
+	if runtime.GOOS != "linux" {
+		return ErrUnsupported
+	}
+
+	return uninstallSystemd(name)
+}
+
+// runService simply calls run: systemd stops services with SIGTERM, which
+// commands already handle.
+func runService(ctx context.Context, run func(context.Context) error) error {
+	return run(ctx)
+}
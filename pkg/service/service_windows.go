@@ -0,0 +1,127 @@
+//go:build windows
+// +build windows
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+// restartDelay is how long Windows waits before restarting a failed service
+const restartDelay = 5 * time.Second
+
+func install(cfg Config) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("could not connect to the service manager, installing services requires an administrator prompt: %v", err)
+	}
+	defer m.Disconnect()
+
+	if s, err := m.OpenService(cfg.Name); err == nil {
+		s.Close()
+		return fmt.Errorf("service %s is already installed", cfg.Name)
+	}
+
+	s, err := m.CreateService(cfg.Name, cfg.Executable, mgr.Config{
+		DisplayName: cfg.Name,
+		Description: cfg.Description,
+		StartType:   mgr.StartAutomatic,
+	}, cfg.Args...)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	err = s.SetRecoveryActions([]mgr.RecoveryAction{
+		{Type: mgr.ServiceRestart, Delay: restartDelay},
+	}, 0)
+	if err != nil {
+		return err
+	}
+
+	return s.Start()
+}
+
+func uninstall(name string) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("could not connect to the service manager, uninstalling services requires an administrator prompt: %v", err)
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(name)
+	if err != nil {
+		return fmt.Errorf("service %s is not installed", name)
+	}
+	defer s.Close()
+
+	// The service may not be running
+	s.Control(svc.Stop) // #nosec G104
+
+	return s.Delete()
+}
+
+// runService reports the status of the process to the service manager when
+// it was started as a service, which otherwise kills it after a timeout.
+func runService(ctx context.Context, run func(context.Context) error) error {
+	isService, err := svc.IsWindowsService()
+	if err != nil || !isService {
+		return run(ctx)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	h := &handler{ctx: ctx, cancel: cancel, run: run}
+
+	// The name is ignored for services running in their own process
+	if err := svc.Run("", h); err != nil {
+		return err
+	}
+
+	return h.err
+}
+
+type handler struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	run    func(context.Context) error
+	err    error
+}
+
+func (h *handler) Execute(args []string, requests <-chan svc.ChangeRequest, status chan<- svc.Status) (bool, uint32) {
+	status <- svc.Status{State: svc.StartPending}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- h.run(h.ctx)
+	}()
+
+	status <- svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown}
+
+	for {
+		select {
+		case err := <-done:
+			h.err = err
+			if err != nil {
+				// A service-specific exit code makes Windows apply the
+				// recovery actions
+				return true, 1
+			}
+			return false, 0
+		case req := <-requests:
+			switch req.Cmd {
+			case svc.Interrogate:
+				status <- req.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				status <- svc.Status{State: svc.StopPending}
+				h.cancel()
+			}
+		}
+	}
+}
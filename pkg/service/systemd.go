@@ -0,0 +1,113 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// systemdUnitDir returns the directory of the systemd units of the current
+// user, $XDG_CONFIG_HOME/systemd/user.
+func systemdUnitDir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "systemd", "user"), nil
+}
+
+// systemdUnit renders the unit file of the service.
+func systemdUnit(cfg Config) string {
+	execStart := make([]string, 0, len(cfg.Args)+1)
+	for _, arg := range append([]string{cfg.Executable}, cfg.Args...) {
+		execStart = append(execStart, quoteSystemdArg(arg))
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintln(&b, "[Unit]")
+	fmt.Fprintf(&b, "Description=%s\n", cfg.Description)
+	fmt.Fprintln(&b, "Wants=network-online.target")
+	fmt.Fprintln(&b, "After=network-online.target")
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "[Service]")
+	fmt.Fprintf(&b, "ExecStart=%s\n", strings.Join(execStart, " "))
+	fmt.Fprintln(&b, "Restart=on-failure")
+	fmt.Fprintln(&b, "RestartSec=5")
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "[Install]")
+	fmt.Fprintln(&b, "WantedBy=default.target")
+
+	return b.String()
+}
+
+// quoteSystemdArg quotes an argument of ExecStart, escaping the characters
+// systemd would otherwise interpret: backslashes, quotes, specifiers (%) and
+// variables ($).
+func quoteSystemdArg(arg string) string {
+	arg = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "%", "%%", "$", "$$").Replace(arg)
+	return `"` + arg + `"`
+}
+
+func installSystemd(cfg Config) error {
+	dir, err := systemdUnitDir()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	// The arguments may include an API key
+	unitPath := filepath.Join(dir, cfg.Name+".service")
+	if err := ioutil.WriteFile(unitPath, []byte(systemdUnit(cfg)), 0600); err != nil {
+		return err
+	}
+
+	if err := systemctl("daemon-reload"); err != nil {
+		return err
+	}
+
+	return systemctl("enable", "--now", cfg.Name+".service")
+}
+
+func uninstallSystemd(name string) error {
+	dir, err := systemdUnitDir()
+	if err != nil {
+		return err
+	}
+
+	unitPath := filepath.Join(dir, name+".service")
+	if _, err := os.Stat(unitPath); os.IsNotExist(err) {
+		return fmt.Errorf("service %s is not installed", name)
+	}
+
+	if err := systemctl("disable", "--now", name+".service"); err != nil {
+		return err
+	}
+
+	if err := os.Remove(unitPath); err != nil {
+		return err
+	}
+
+	return systemctl("daemon-reload")
+}
+
+func systemctl(args ...string) error {
+	cmd := execCommand("systemctl", append([]string{"--user"}, args...)...)
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("systemctl %s failed: %v: %s", args[0], err, bytes.TrimSpace(output.Bytes()))
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystemdUnit(t *testing.T) {
+	unit := systemdUnit(Config{
+		Name:        "stripe-listen",
+		Description: "Stripe CLI listen (project default)",
+		Executable:  "/usr/local/bin/stripe",
+		Args:        []string{"listen", "--forward-to=localhost:3000/webhook"},
+	})
+
+	require.Equal(t, `[Unit]
+Description=Stripe CLI listen (project default)
+Wants=network-online.target
+After=network-online.target
+
+[Service]
+ExecStart="/usr/local/bin/stripe" "listen" "--forward-to=localhost:3000/webhook"
+Restart=on-failure
+RestartSec=5
+
+[Install]
+WantedBy=default.target
+`, unit)
+}
+
+func TestQuoteSystemdArg(t *testing.T) {
+	require.Equal(t, `"plain"`, quoteSystemdArg("plain"))
+	require.Equal(t, `"with space"`, quoteSystemdArg("with space"))
+	require.Equal(t, `"--filter=data.object.metadata.tenant==\"acme\""`, quoteSystemdArg(`--filter=data.object.metadata.tenant=="acme"`))
+	require.Equal(t, `"C:\\path"`, quoteSystemdArg(`C:\path`))
+	require.Equal(t, `"100%% $$HOME"`, quoteSystemdArg("100% $HOME"))
+}
+
+func TestValidateName(t *testing.T) {
+	require.NoError(t, validateName("stripe-listen"))
+	require.NoError(t, validateName("stripe_listen.staging"))
+	require.Error(t, validateName(""))
+	require.Error(t, validateName("../stripe-listen"))
+	require.Error(t, validateName("stripe listen"))
+}
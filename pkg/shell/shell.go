@@ -0,0 +1,20 @@
+// Package shell runs user-provided commands through the platform's shell.
+package shell
+
+import (
+	"context"
+	"runtime"
+
+	exec "golang.org/x/sys/execabs"
+)
+
+// Command returns a command running command through the platform's shell,
+// so that users can pass pipelines and arguments in a single string. The
+// command is killed if ctx is done before it exits.
+func Command(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command) // #nosec G204
+	}
+
+	return exec.CommandContext(ctx, "sh", "-c", command) // #nosec G204
+}
@@ -0,0 +1,37 @@
+package shell
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("tr is not available on Windows")
+	}
+
+	out, err := Command(context.Background(), "echo hello | tr a-z A-Z").Output()
+
+	require.NoError(t, err)
+	require.Equal(t, "HELLO", strings.TrimSpace(string(out)))
+}
+
+func TestCommandKilledWhenContextDone(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sleep is not available on Windows")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := Command(ctx, "sleep 10").Run()
+
+	require.Error(t, err)
+	require.Less(t, time.Since(start), 5*time.Second)
+}
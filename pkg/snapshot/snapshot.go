@@ -0,0 +1,246 @@
+// Package snapshot captures the settings of a Stripe account that drift
+// between accounts, like webhook endpoints and payout schedules, so the
+// settings of an account can be compared with a snapshot of another one.
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/tidwall/gjson"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/requests"
+)
+
+// Version is the version of the snapshot format
+const Version = 1
+
+// Snapshot is the settings of an account at a point in time.
+type Snapshot struct {
+	Version          int               `json:"snapshot_version"`
+	TakenAt          time.Time         `json:"taken_at"`
+	Account          string            `json:"account"`
+	WebhookEndpoints []WebhookEndpoint `json:"webhook_endpoints"`
+	Branding         Branding          `json:"branding"`
+	PayoutSchedule   PayoutSchedule    `json:"payout_schedule"`
+	// PaymentMethods are the payment methods enabled on the account, sorted
+	PaymentMethods []string `json:"payment_methods"`
+}
+
+// WebhookEndpoint is the configuration of a webhook endpoint. IDs and
+// secrets are left out, since they differ between accounts anyway.
+type WebhookEndpoint struct {
+	URL           string   `json:"url"`
+	Description   string   `json:"description,omitempty"`
+	Status        string   `json:"status"`
+	APIVersion    string   `json:"api_version,omitempty"`
+	Connect       bool     `json:"connect,omitempty"`
+	EnabledEvents []string `json:"enabled_events"`
+}
+
+// Branding is the branding settings of the account
+type Branding struct {
+	Icon           string `json:"icon,omitempty"`
+	Logo           string `json:"logo,omitempty"`
+	PrimaryColor   string `json:"primary_color,omitempty"`
+	SecondaryColor string `json:"secondary_color,omitempty"`
+}
+
+// PayoutSchedule is the schedule of the automatic payouts of the account
+type PayoutSchedule struct {
+	Interval      string `json:"interval,omitempty"`
+	DelayDays     int64  `json:"delay_days,omitempty"`
+	WeeklyAnchor  string `json:"weekly_anchor,omitempty"`
+	MonthlyAnchor int64  `json:"monthly_anchor,omitempty"`
+}
+
+// Take returns a snapshot of the settings of the account of apiKey.
+func Take(ctx context.Context, baseURL, apiKey string, profile *config.Profile) (*Snapshot, error) {
+	account, err := requests.Do(ctx, http.MethodGet, baseURL, apiKey, "/v1/account", nil, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{
+		Version: Version,
+		TakenAt: time.Now().UTC(),
+		Account: account.Get("id").String(),
+		Branding: Branding{
+			Icon:           account.Get("settings.branding.icon").String(),
+			Logo:           account.Get("settings.branding.logo").String(),
+			PrimaryColor:   account.Get("settings.branding.primary_color").String(),
+			SecondaryColor: account.Get("settings.branding.secondary_color").String(),
+		},
+		PayoutSchedule: PayoutSchedule{
+			Interval:      account.Get("settings.payouts.schedule.interval").String(),
+			DelayDays:     account.Get("settings.payouts.schedule.delay_days").Int(),
+			WeeklyAnchor:  account.Get("settings.payouts.schedule.weekly_anchor").String(),
+			MonthlyAnchor: account.Get("settings.payouts.schedule.monthly_anchor").Int(),
+		},
+		WebhookEndpoints: []WebhookEndpoint{},
+		PaymentMethods:   []string{},
+	}
+
+	// Payment methods are enabled through the `<method>_payments`
+	// capabilities, like `card_payments`
+	account.Get("capabilities").ForEach(func(key, value gjson.Result) bool {
+		if strings.HasSuffix(key.String(), "_payments") && value.String() == "active" {
+			snapshot.PaymentMethods = append(snapshot.PaymentMethods, strings.TrimSuffix(key.String(), "_payments"))
+		}
+		return true
+	})
+	sort.Strings(snapshot.PaymentMethods)
+
+	startingAfter := ""
+	for {
+		params := []string{"limit=100"}
+		if startingAfter != "" {
+			params = append(params, "starting_after="+startingAfter)
+		}
+
+		list, err := requests.Do(ctx, http.MethodGet, baseURL, apiKey, "/v1/webhook_endpoints", params, profile)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, endpoint := range list.Get("data").Array() {
+			events := []string{}
+			for _, event := range endpoint.Get("enabled_events").Array() {
+				events = append(events, event.String())
+			}
+			sort.Strings(events)
+
+			snapshot.WebhookEndpoints = append(snapshot.WebhookEndpoints, WebhookEndpoint{
+				URL:           endpoint.Get("url").String(),
+				Description:   endpoint.Get("description").String(),
+				Status:        endpoint.Get("status").String(),
+				APIVersion:    endpoint.Get("api_version").String(),
+				Connect:       endpoint.Get("application").Exists() && endpoint.Get("application").Type != gjson.Null,
+				EnabledEvents: events,
+			})
+
+			startingAfter = endpoint.Get("id").String()
+		}
+
+		if !list.Get("has_more").Bool() || startingAfter == "" {
+			break
+		}
+	}
+
+	sort.Slice(snapshot.WebhookEndpoints, func(i, j int) bool {
+		return snapshot.WebhookEndpoints[i].URL < snapshot.WebhookEndpoints[j].URL
+	})
+
+	return snapshot, nil
+}
+
+// Load reads the snapshot saved at path.
+func Load(fs afero.Fs, path string) (*Snapshot, error) {
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("%s isn't an account snapshot: %v", path, err)
+	}
+
+	if snapshot.Version == 0 {
+		return nil, fmt.Errorf("%s isn't an account snapshot", path)
+	}
+	if snapshot.Version > Version {
+		return nil, fmt.Errorf("%s was taken by a newer version of the Stripe CLI, upgrade to compare with it", path)
+	}
+
+	return &snapshot, nil
+}
+
+// Marshal returns the snapshot as indented JSON.
+func (s *Snapshot) Marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, '\n'), nil
+}
+
+// Change is a setting that differs between two snapshots. Old is empty for
+// settings that were added, New for settings that were removed.
+type Change struct {
+	Setting string
+	Old     string
+	New     string
+}
+
+// Diff returns the settings that differ from a snapshot to another one,
+// sorted by setting.
+func Diff(from, to *Snapshot) []Change {
+	oldSettings := from.settings()
+	newSettings := to.settings()
+
+	var changes []Change
+	for setting, value := range oldSettings {
+		if newSettings[setting] != value {
+			changes = append(changes, Change{Setting: setting, Old: value, New: newSettings[setting]})
+		}
+	}
+	for setting, value := range newSettings {
+		if _, ok := oldSettings[setting]; !ok {
+			changes = append(changes, Change{Setting: setting, New: value})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Setting < changes[j].Setting
+	})
+
+	return changes
+}
+
+// settings flattens the snapshot into settings, like
+// `payout_schedule.interval`, mapped to their value. Empty settings are left
+// out, so a setting that's unset shows as removed.
+func (s *Snapshot) settings() map[string]string {
+	settings := make(map[string]string)
+
+	set := func(setting, value string) {
+		if value != "" && value != "0" && value != "false" {
+			settings[setting] = value
+		}
+	}
+
+	set("branding.icon", s.Branding.Icon)
+	set("branding.logo", s.Branding.Logo)
+	set("branding.primary_color", s.Branding.PrimaryColor)
+	set("branding.secondary_color", s.Branding.SecondaryColor)
+
+	set("payout_schedule.interval", s.PayoutSchedule.Interval)
+	set("payout_schedule.delay_days", fmt.Sprint(s.PayoutSchedule.DelayDays))
+	set("payout_schedule.weekly_anchor", s.PayoutSchedule.WeeklyAnchor)
+	set("payout_schedule.monthly_anchor", fmt.Sprint(s.PayoutSchedule.MonthlyAnchor))
+
+	for _, method := range s.PaymentMethods {
+		set("payment_methods."+method, "enabled")
+	}
+
+	for _, endpoint := range s.WebhookEndpoints {
+		prefix := fmt.Sprintf("webhook_endpoints[%s].", endpoint.URL)
+
+		set(prefix+"status", endpoint.Status)
+		set(prefix+"description", endpoint.Description)
+		set(prefix+"api_version", endpoint.APIVersion)
+		set(prefix+"connect", fmt.Sprint(endpoint.Connect))
+		set(prefix+"enabled_events", strings.Join(endpoint.EnabledEvents, ", "))
+	}
+
+	return settings
+}
@@ -0,0 +1,105 @@
+package snapshot
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTake(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/account":
+			w.Write([]byte(`{
+				"id": "acct_123",
+				"capabilities": {"card_payments": "active", "sepa_debit_payments": "inactive", "transfers": "active", "bancontact_payments": "active"},
+				"settings": {
+					"branding": {"icon": "file_123", "logo": null, "primary_color": "#ff0000", "secondary_color": null},
+					"payouts": {"schedule": {"interval": "weekly", "delay_days": 7, "weekly_anchor": "monday"}}
+				}
+			}`))
+		case "/v1/webhook_endpoints":
+			if r.URL.Query().Get("starting_after") == "" {
+				w.Write([]byte(`{"has_more": true, "data": [
+					{"id": "we_2", "url": "https://example.com/z", "status": "enabled", "enabled_events": ["invoice.paid", "charge.succeeded"], "application": null}
+				]}`))
+			} else {
+				require.Equal(t, "we_2", r.URL.Query().Get("starting_after"))
+				w.Write([]byte(`{"has_more": false, "data": [
+					{"id": "we_1", "url": "https://example.com/a", "status": "disabled", "enabled_events": ["*"], "application": "ca_123", "api_version": "2020-08-27"}
+				]}`))
+			}
+		default:
+			t.Fatalf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	snapshot, err := Take(context.Background(), ts.URL, "sk_test_123", nil)
+	require.NoError(t, err)
+
+	require.Equal(t, Version, snapshot.Version)
+	require.Equal(t, "acct_123", snapshot.Account)
+	require.Equal(t, Branding{Icon: "file_123", PrimaryColor: "#ff0000"}, snapshot.Branding)
+	require.Equal(t, PayoutSchedule{Interval: "weekly", DelayDays: 7, WeeklyAnchor: "monday"}, snapshot.PayoutSchedule)
+	require.Equal(t, []string{"bancontact", "card"}, snapshot.PaymentMethods)
+	require.Equal(t, []WebhookEndpoint{
+		{URL: "https://example.com/a", Status: "disabled", APIVersion: "2020-08-27", Connect: true, EnabledEvents: []string{"*"}},
+		{URL: "https://example.com/z", Status: "enabled", EnabledEvents: []string{"charge.succeeded", "invoice.paid"}},
+	}, snapshot.WebhookEndpoints)
+}
+
+func TestDiff(t *testing.T) {
+	from := &Snapshot{
+		Version:        Version,
+		Branding:       Branding{PrimaryColor: "#ff0000"},
+		PayoutSchedule: PayoutSchedule{Interval: "daily", DelayDays: 2},
+		PaymentMethods: []string{"card", "sepa_debit"},
+		WebhookEndpoints: []WebhookEndpoint{
+			{URL: "https://example.com/hook", Status: "enabled", EnabledEvents: []string{"charge.succeeded"}},
+		},
+	}
+	to := &Snapshot{
+		Version:        Version,
+		Branding:       Branding{PrimaryColor: "#ff0000", Logo: "file_123"},
+		PayoutSchedule: PayoutSchedule{Interval: "weekly", DelayDays: 2, WeeklyAnchor: "friday"},
+		PaymentMethods: []string{"card"},
+		WebhookEndpoints: []WebhookEndpoint{
+			{URL: "https://example.com/hook", Status: "enabled", EnabledEvents: []string{"charge.succeeded", "invoice.paid"}},
+		},
+	}
+
+	require.Empty(t, Diff(from, from))
+	require.Equal(t, []Change{
+		{Setting: "branding.logo", New: "file_123"},
+		{Setting: "payment_methods.sepa_debit", Old: "enabled"},
+		{Setting: "payout_schedule.interval", Old: "daily", New: "weekly"},
+		{Setting: "payout_schedule.weekly_anchor", New: "friday"},
+		{Setting: "webhook_endpoints[https://example.com/hook].enabled_events", Old: "charge.succeeded", New: "charge.succeeded, invoice.paid"},
+	}, Diff(from, to))
+}
+
+func TestLoad(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	snapshot := &Snapshot{Version: Version, Account: "acct_123", PaymentMethods: []string{"card"}}
+	data, err := snapshot.Marshal()
+	require.NoError(t, err)
+	require.NoError(t, afero.WriteFile(fs, "acct.json", data, 0644))
+
+	loaded, err := Load(fs, "acct.json")
+	require.NoError(t, err)
+	require.Equal(t, snapshot, loaded)
+
+	require.NoError(t, afero.WriteFile(fs, "other.json", []byte(`{"fixtures": []}`), 0644))
+	_, err = Load(fs, "other.json")
+	require.EqualError(t, err, "other.json isn't an account snapshot")
+
+	require.NoError(t, afero.WriteFile(fs, "newer.json", []byte(`{"snapshot_version": 99}`), 0644))
+	_, err = Load(fs, "newer.json")
+	require.EqualError(t, err, "newer.json was taken by a newer version of the Stripe CLI, upgrade to compare with it")
+}
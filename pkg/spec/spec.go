@@ -111,16 +111,17 @@ type Schema struct {
 	// for anything right now.
 	AdditionalProperties interface{} `json:"additionalProperties,omitempty"`
 
-	AnyOf      []*Schema          `json:"anyOf,omitempty"`
-	Enum       []interface{}      `json:"enum,omitempty"`
-	Format     string             `json:"format,omitempty"`
-	Items      *Schema            `json:"items,omitempty"`
-	MaxLength  int                `json:"maxLength,omitempty"`
-	Nullable   bool               `json:"nullable,omitempty"`
-	Pattern    string             `json:"pattern,omitempty"`
-	Properties map[string]*Schema `json:"properties,omitempty"`
-	Required   []string           `json:"required,omitempty"`
-	Type       string             `json:"type,omitempty"`
+	AnyOf       []*Schema          `json:"anyOf,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Enum        []interface{}      `json:"enum,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	MaxLength   int                `json:"maxLength,omitempty"`
+	Nullable    bool               `json:"nullable,omitempty"`
+	Pattern     string             `json:"pattern,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Type        string             `json:"type,omitempty"`
 
 	// Ref is populated if this JSON Schema is actually a JSON reference, and
 	// it defines the location of the actual schema definition.
@@ -248,9 +249,14 @@ func LoadSpec(specPath string) (*Spec, error) {
 		return nil, err
 	}
 
+	return ParseSpec(data)
+}
+
+// ParseSpec parses and returns an OpenAPI spec, e.g. one that was downloaded.
+func ParseSpec(data []byte) (*Spec, error) {
 	var stripeSpec Spec
 
-	err = json.Unmarshal(data, &stripeSpec)
+	err := json.Unmarshal(data, &stripeSpec)
 	if err != nil {
 		return nil, fmt.Errorf("error decoding spec: %v", err)
 	}
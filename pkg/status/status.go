@@ -12,12 +12,30 @@ import (
 	"github.com/stripe/stripe-cli/pkg/ansi"
 )
 
+// statusURL is the endpoint of the status site returning the current
+// statuses
+var statusURL = "https://status.stripe.com/current"
+
 // Response contains the structure of system statuses from Stripe
 type Response struct {
 	Statuses    statuses `json:"statuses"`
 	LargeStatus string   `json:"largestatus"`
 	Message     string   `json:"message"`
 	Time        string   `json:"time"`
+	// Incidents are the incidents that aren't resolved yet
+	Incidents []Incident `json:"incidents"`
+}
+
+// Incident is an incident reported on the status site
+type Incident struct {
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	URL    string `json:"url"`
+}
+
+// IsUp returns whether all the systems are operational
+func (r *Response) IsUp() bool {
+	return r.LargeStatus == "up"
 }
 
 type statuses struct {
@@ -35,19 +53,25 @@ type statuses struct {
 func GetStatus() (Response, error) {
 	var status Response
 
-	resp, err := http.Get("https://status.stripe.com/current")
+	resp, err := http.Get(statusURL) // #nosec G107
 	if err != nil {
 		return status, err
 	}
 
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return status, fmt.Errorf("could not get the status from %s: %s", statusURL, resp.Status)
+	}
+
 	respBytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return status, err
 	}
 
-	json.Unmarshal(respBytes, &status)
+	if err := json.Unmarshal(respBytes, &status); err != nil {
+		return status, fmt.Errorf("could not read the status from %s: %w", statusURL, err)
+	}
 
 	return status, nil
 }
@@ -68,6 +92,10 @@ func (r *Response) getMap(verbose bool) map[string]interface{} {
 		}
 	}
 
+	if len(r.Incidents) > 0 {
+		responseObject["incidents"] = r.Incidents
+	}
+
 	return responseObject
 }
 
@@ -90,7 +118,8 @@ func (r *Response) FormattedMessage(format string, verbose bool) (string, error)
 %s API
 %s Dashboard
 %s Stripe.js
-%s Checkout.js{{end}}
+%s Checkout.js{{end}}{{range .incidents}}
+%s {{.Title}}{{if .Status}} ({{.Status}}){{end}}{{if .URL}} {{.URL}}{{end}}{{end}}
 As of: %s`,
 		emojifiedStatus(r.LargeStatus),
 		ansi.Bold(r.Message),
@@ -98,6 +127,7 @@ As of: %s`,
 		emojifiedStatus(r.Statuses.Dashboard),
 		emojifiedStatus(r.Statuses.Stripejs),
 		emojifiedStatus(r.Statuses.Checkoutjs),
+		emojifiedStatus("degraded"),
 		ansi.Italic(r.Time),
 	)
 
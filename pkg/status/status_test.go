@@ -1,6 +1,8 @@
 package status
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -109,3 +111,45 @@ func TestEmojification(t *testing.T) {
 	require.Equal(t, "✘", emojifiedStatus("down"))
 	require.Equal(t, "", emojifiedStatus("foo"))
 }
+
+func TestFormatDefaultIncidents(t *testing.T) {
+	response := buildResponse()
+	response.LargeStatus = "degraded"
+	response.Message = "Elevated API error rates"
+	response.Incidents = []Incident{
+		{Title: "Elevated API error rates", Status: "investigating", URL: "https://status.stripe.com/incidents/123"},
+	}
+
+	expected := `! Elevated API error rates
+! Elevated API error rates (investigating) https://status.stripe.com/incidents/123
+As of: July 21, 4:00 +0:00`
+
+	formatted, _ := response.FormattedMessage("default", false)
+	require.Equal(t, expected, formatted)
+	require.False(t, response.IsUp())
+
+	formatted, _ = response.FormattedMessage("json", false)
+	require.Contains(t, formatted, `"title": "Elevated API error rates"`)
+}
+
+func TestGetStatus(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"largestatus":"up","message":"All systems operational","statuses":{"api":"up"}}`)) // #nosec G104
+	}))
+	defer ts.Close()
+
+	defer func(url string) { statusURL = url }(statusURL)
+	statusURL = ts.URL
+
+	status, err := GetStatus()
+	require.NoError(t, err)
+	require.True(t, status.IsUp())
+	require.Equal(t, "up", status.Statuses.API)
+	require.Empty(t, status.Incidents)
+
+	statusURL = ts.URL + "/missing"
+	ts.Config.Handler = http.NotFoundHandler()
+
+	_, err = GetStatus()
+	require.EqualError(t, err, "could not get the status from "+statusURL+": 404 Not Found")
+}
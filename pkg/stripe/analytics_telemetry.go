@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/go-querystring/query"
@@ -61,6 +62,9 @@ type AnalyticsTelemetryClient struct {
 type NoOpTelemetryClient struct {
 }
 
+// telemetryDisabled is set when telemetry is disabled in the config
+var telemetryDisabled int32
+
 //
 // Public functions
 //
@@ -93,8 +97,13 @@ func WithTelemetryClient(ctx context.Context, client TelemetryClient) context.Co
 	return context.WithValue(ctx, telemetryClientKey{}, client)
 }
 
-// GetTelemetryClient returns the CLIAnalyticsEventMetadata from the provided context
+// GetTelemetryClient returns the CLIAnalyticsEventMetadata from the provided context.
+// It returns nil when telemetry is disabled, so that no event is sent.
 func GetTelemetryClient(ctx context.Context) TelemetryClient {
+	if !IsTelemetryEnabled() {
+		return nil
+	}
+
 	client := ctx.Value(telemetryClientKey{})
 	if client != nil {
 		return client.(TelemetryClient)
@@ -102,6 +111,46 @@ func GetTelemetryClient(ctx context.Context) TelemetryClient {
 	return nil
 }
 
+// SetTelemetryEnabled enables or disables telemetry. While it is disabled,
+// GetTelemetryClient returns no client.
+func SetTelemetryEnabled(enabled bool) {
+	var value int32
+	if !enabled {
+		value = 1
+	}
+
+	atomic.StoreInt32(&telemetryDisabled, value)
+}
+
+// IsTelemetryEnabled returns true unless telemetry is disabled.
+func IsTelemetryEnabled() bool {
+	return atomic.LoadInt32(&telemetryDisabled) == 0
+}
+
+// NewTelemetryEvent returns the fields of a telemetry event, as they're sent
+// to r.stripe.com
+func NewTelemetryEvent(metadata *CLIAnalyticsEventMetadata, eventName string, eventValue string) url.Values {
+	data, _ := query.Values(metadata)
+
+	data.Set("client_id", "stripe-cli")
+	data.Set("event_id", uuid.NewString())
+	data.Set("event_name", eventName)
+	data.Set("event_value", eventValue)
+	data.Set("created", fmt.Sprint((time.Now().Unix())))
+
+	return data
+}
+
+// NewAPIRequestTelemetryEvent returns the fields of the telemetry event of an
+// API request
+func NewAPIRequestTelemetryEvent(metadata *CLIAnalyticsEventMetadata, requestID string, livemode bool) url.Values {
+	data := NewTelemetryEvent(metadata, "API Request", "")
+	data.Set("request_id", requestID)
+	data.Set("livemode", strconv.FormatBool(livemode))
+
+	return data
+}
+
 // SetCobraCommandContext sets the telemetry values for the command being executed.
 func (e *CLIAnalyticsEventMetadata) SetCobraCommandContext(cmd *cobra.Command) {
 	e.CommandPath = cmd.CommandPath()
@@ -139,17 +188,7 @@ func (a *AnalyticsTelemetryClient) SendAPIRequestEvent(ctx context.Context, requ
 	defer a.wg.Done()
 	telemetryMetadata := GetEventMetadata(ctx)
 	if telemetryMetadata != nil {
-		data, _ := query.Values(telemetryMetadata)
-
-		data.Set("client_id", "stripe-cli")
-		data.Set("request_id", requestID)
-		data.Set("livemode", strconv.FormatBool(livemode))
-		data.Set("event_id", uuid.NewString())
-		data.Set("event_name", "API Request")
-		data.Set("event_value", "")
-		data.Set("created", fmt.Sprint((time.Now().Unix())))
-
-		return a.sendData(ctx, data)
+		return a.sendData(ctx, NewAPIRequestTelemetryEvent(telemetryMetadata, requestID, livemode))
 	}
 	return nil, nil
 }
@@ -160,15 +199,7 @@ func (a *AnalyticsTelemetryClient) SendEvent(ctx context.Context, eventName stri
 	defer a.wg.Done()
 	telemetryMetadata := GetEventMetadata(ctx)
 	if telemetryMetadata != nil {
-		data, _ := query.Values(telemetryMetadata)
-
-		data.Set("client_id", "stripe-cli")
-		data.Set("event_id", uuid.NewString())
-		data.Set("event_name", eventName)
-		data.Set("event_value", eventValue)
-		data.Set("created", fmt.Sprint((time.Now().Unix())))
-
-		resp, err := a.sendData(ctx, data)
+		resp, err := a.sendData(ctx, NewTelemetryEvent(telemetryMetadata, eventName, eventValue))
 		// Don't throw exception if we fail to send the event
 		if err != nil {
 			log.Debugf("Error while sending telemetry data: %v\n", err)
@@ -190,3 +190,16 @@ func TestTelemetryOptedOut(t *testing.T) {
 	require.True(t, stripe.TelemetryOptedOut("True"))
 	require.True(t, stripe.TelemetryOptedOut("TRUE"))
 }
+
+func TestGetTelemetryClient_TelemetryDisabled(t *testing.T) {
+	telemetryClient := &stripe.NoOpTelemetryClient{}
+	ctx := stripe.WithTelemetryClient(context.Background(), telemetryClient)
+
+	stripe.SetTelemetryEnabled(false)
+	defer stripe.SetTelemetryEnabled(true)
+	require.False(t, stripe.IsTelemetryEnabled())
+	require.Nil(t, stripe.GetTelemetryClient(ctx))
+
+	stripe.SetTelemetryEnabled(true)
+	require.Equal(t, telemetryClient, stripe.GetTelemetryClient(ctx))
+}
@@ -0,0 +1,268 @@
+package stripe
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+// redactedValue replaces the values of sensitive params in the audit log
+const redactedValue = "[REDACTED]"
+
+// secretValuePrefixes are the prefixes of values redacted whatever their
+// param, like an API key sent to create a webhook endpoint
+var secretValuePrefixes = []string{"sk_", "rk_", "whsec_"}
+
+// AuditEntry records an API request sent by the CLI. The values of sensitive
+// params, like card numbers, are redacted.
+type AuditEntry struct {
+	Time      time.Time              `json:"time"`
+	Profile   string                 `json:"profile,omitempty"`
+	Command   string                 `json:"command,omitempty"`
+	Method    string                 `json:"method"`
+	Path      string                 `json:"path"`
+	Params    map[string]interface{} `json:"params,omitempty"`
+	Account   string                 `json:"account,omitempty"`
+	Status    int                    `json:"status,omitempty"`
+	RequestID string                 `json:"request_id,omitempty"`
+	Livemode  bool                   `json:"livemode"`
+	// Error is set when no response was received
+	Error string `json:"error,omitempty"`
+}
+
+// AuditLog is an append-only log of the API requests sent by the CLI, one
+// JSON entry per line. Entries are never rewritten or dropped.
+type AuditLog struct {
+	Fs   afero.Fs
+	Path string
+
+	// Profile and Command are recorded with each request
+	Profile string
+	Command string
+
+	// Now returns the current time, and defaults to time.Now
+	Now func() time.Time
+
+	mu sync.Mutex
+}
+
+var (
+	auditLogMu sync.RWMutex
+	auditLog   *AuditLog
+)
+
+// SetAuditLog sets the log the Client records its requests to, or disables
+// the audit log if l is nil.
+func SetAuditLog(l *AuditLog) {
+	auditLogMu.Lock()
+	defer auditLogMu.Unlock()
+
+	auditLog = l
+}
+
+func currentAuditLog() *AuditLog {
+	auditLogMu.RLock()
+	defer auditLogMu.RUnlock()
+
+	return auditLog
+}
+
+// Record appends entry to the log. Its time, profile and command are set.
+func (l *AuditLog) Record(entry AuditEntry) error {
+	entry.Time = l.now()
+	entry.Profile = l.Profile
+	entry.Command = l.Command
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := l.Fs.OpenFile(l.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(data, '\n'))
+
+	return err
+}
+
+// Since returns the entries recorded since t, oldest first.
+func (l *AuditLog) Since(t time.Time) ([]AuditEntry, error) {
+	data, err := afero.ReadFile(l.Fs, l.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []AuditEntry
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		var entry AuditEntry
+		// Skip the lines that can't be read, e.g. if a write was interrupted
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+
+		if !entry.Time.Before(t) {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, scanner.Err()
+}
+
+func (l *AuditLog) now() time.Time {
+	if l.Now != nil {
+		return l.Now()
+	}
+
+	return time.Now()
+}
+
+// recordAudit adds a request to the audit log, if it's enabled. Failing to
+// record it doesn't fail the request, which was already sent.
+func recordAudit(req *http.Request, params string, resp *http.Response, livemode bool, reqErr error) {
+	l := currentAuditLog()
+	if l == nil {
+		return
+	}
+
+	entry := AuditEntry{
+		Method:   req.Method,
+		Path:     req.URL.Path,
+		Account:  req.Header.Get("Stripe-Account"),
+		Livemode: livemode,
+	}
+
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+		entry.Params = redactJSONParams(params)
+	} else {
+		entry.Params = redactFormParams(params)
+	}
+
+	if resp != nil {
+		entry.Status = resp.StatusCode
+		entry.RequestID = resp.Header.Get("Request-Id")
+	}
+	if reqErr != nil {
+		entry.Error = reqErr.Error()
+	}
+
+	if err := l.Record(entry); err != nil {
+		log.Warnf("Error while writing the audit log %s: %v", l.Path, err)
+	}
+}
+
+// redactFormParams returns the form-encoded params, like
+// `card[number]=4242&metadata[order]=6735`, with sensitive values redacted.
+// Params set more than once, like `expand[]`, are lists.
+func redactFormParams(params string) map[string]interface{} {
+	values, err := url.ParseQuery(params)
+	if err != nil || len(values) == 0 {
+		return nil
+	}
+
+	redacted := make(map[string]interface{}, len(values))
+	for key, list := range values {
+		for i := range list {
+			list[i] = redactValue(key, list[i])
+		}
+
+		if len(list) == 1 {
+			redacted[key] = list[0]
+		} else {
+			redacted[key] = list
+		}
+	}
+
+	return redacted
+}
+
+// redactJSONParams returns the JSON params of v2 requests with sensitive
+// values redacted
+func redactJSONParams(params string) map[string]interface{} {
+	var values map[string]interface{}
+	if err := json.Unmarshal([]byte(params), &values); err != nil || len(values) == 0 {
+		return nil
+	}
+
+	redactJSONValue("", values)
+
+	return values
+}
+
+func redactJSONValue(key string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			v[k] = redactJSONValue(k, child)
+		}
+		return v
+	case []interface{}:
+		for i, child := range v {
+			v[i] = redactJSONValue(key, child)
+		}
+		return v
+	case string:
+		return redactValue(key, v)
+	default:
+		if isSensitiveParam(key) {
+			return redactedValue
+		}
+		return v
+	}
+}
+
+func redactValue(key, value string) string {
+	if isSensitiveParam(key) {
+		return redactedValue
+	}
+
+	for _, prefix := range secretValuePrefixes {
+		if strings.HasPrefix(value, prefix) {
+			return redactedValue
+		}
+	}
+
+	return value
+}
+
+// isSensitiveParam returns true if the values of a param, like card[number]
+// or individual[id_number], are secrets or personal data
+func isSensitiveParam(key string) bool {
+	name := strings.ToLower(key)
+	if i := strings.LastIndex(strings.TrimSuffix(name, "[]"), "["); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.Trim(name, "[]")
+
+	switch {
+	case name == "number", name == "cvc", name == "iban", strings.HasPrefix(name, "ssn"):
+		return true
+	case strings.HasSuffix(name, "_number"):
+		return true
+	case strings.Contains(name, "secret"), strings.Contains(name, "password"):
+		return true
+	}
+
+	return false
+}
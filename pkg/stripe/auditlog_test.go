@@ -0,0 +1,121 @@
+package stripe
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLogRecordsRequests(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Request-Id", "req_123")
+		w.WriteHeader(http.StatusPaymentRequired)
+	}))
+	defer ts.Close()
+
+	now := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	log := &AuditLog{
+		Fs:      afero.NewMemMapFs(),
+		Path:    "/config/audit_log.ndjson",
+		Profile: "default",
+		Command: "charges create",
+		Now:     func() time.Time { return now },
+	}
+	SetAuditLog(log)
+	defer SetAuditLog(nil)
+
+	baseURL, _ := url.Parse(ts.URL)
+	client := Client{BaseURL: baseURL, APIKey: "sk_live_1234"}
+
+	params := url.Values{}
+	params.Add("amount", "2000")
+	params.Add("card[number]", "4242424242424242")
+	params.Add("card[cvc]", "123")
+	params.Add("expand[]", "customer")
+	params.Add("expand[]", "invoice")
+
+	resp, err := client.PerformRequest(context.Background(), http.MethodPost, "/v1/charges", params.Encode(), func(r *http.Request) {
+		r.Header.Set("Stripe-Account", "acct_123")
+	})
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	entries, err := log.Since(now)
+	require.NoError(t, err)
+	require.Equal(t, []AuditEntry{{
+		Time:    now,
+		Profile: "default",
+		Command: "charges create",
+		Method:  http.MethodPost,
+		Path:    "/v1/charges",
+		Params: map[string]interface{}{
+			"amount":       "2000",
+			"card[number]": "[REDACTED]",
+			"card[cvc]":    "[REDACTED]",
+			"expand[]":     []interface{}{"customer", "invoice"},
+		},
+		Account:   "acct_123",
+		Status:    http.StatusPaymentRequired,
+		RequestID: "req_123",
+		Livemode:  true,
+	}}, entries)
+}
+
+func TestAuditLogRecordsFailedRequests(t *testing.T) {
+	log := &AuditLog{Fs: afero.NewMemMapFs(), Path: "audit_log.ndjson"}
+	SetAuditLog(log)
+	defer SetAuditLog(nil)
+
+	baseURL, _ := url.Parse("http://127.0.0.1:1")
+	client := Client{BaseURL: baseURL}
+
+	_, err := client.PerformRequest(context.Background(), http.MethodGet, "/v1/customers", "", nil)
+	require.Error(t, err)
+
+	entries, err := log.Since(time.Time{})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, 0, entries[0].Status)
+	require.NotEmpty(t, entries[0].Error)
+}
+
+func TestAuditLogSkipsCorruptedLines(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "audit_log.ndjson", []byte("{\"method\":\"GET\",\"path\":\"/v1/customers\"}\n{\"method\":\"PO"), 0600))
+
+	entries, err := (&AuditLog{Fs: fs, Path: "audit_log.ndjson"}).Since(time.Time{})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "/v1/customers", entries[0].Path)
+}
+
+func TestRedactJSONParams(t *testing.T) {
+	params := redactJSONParams(`{"name":"Jo","webhook_secret":"abc","api_key":"rk_live_123","bank_account":{"account_number":"000123","country":"US"},"amount":100}`)
+
+	require.Equal(t, map[string]interface{}{
+		"name":           "Jo",
+		"webhook_secret": "[REDACTED]",
+		"api_key":        "[REDACTED]",
+		"bank_account": map[string]interface{}{
+			"account_number": "[REDACTED]",
+			"country":        "US",
+		},
+		"amount": float64(100),
+	}, params)
+}
+
+func TestIsSensitiveParam(t *testing.T) {
+	for _, key := range []string{"card[number]", "card[cvc]", "individual[id_number]", "individual[ssn_last_4]", "bank_account[routing_number]", "client_secret", "password"} {
+		require.True(t, isSensitiveParam(key), key)
+	}
+
+	for _, key := range []string{"amount", "metadata[order]", "expand[]", "card[exp_month]", "customer"} {
+		require.False(t, isSensitiveParam(key), key)
+	}
+}
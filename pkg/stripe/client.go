@@ -2,8 +2,9 @@ package stripe
 
 import (
 	"context"
-	"crypto/tls"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"net/url"
@@ -13,6 +14,7 @@ import (
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/stripe/stripe-cli/pkg/netproxy"
 	"github.com/stripe/stripe-cli/pkg/useragent"
 )
 
@@ -46,6 +48,10 @@ type Client struct {
 	// Defaults to the standard set of relevant for Stripe headers.
 	VerbosePrintableHeaders []string
 
+	// Proxy server requests are sent through. If nil, the proxy configured
+	// in the environment (HTTPS_PROXY) is used.
+	Proxy *netproxy.Dialer
+
 	// Cached HTTP client, lazily created the first time the Client is used to
 	// send a request.
 	httpClient *http.Client
@@ -58,16 +64,102 @@ func (c *Client) PerformRequest(ctx context.Context, method, path string, params
 		return nil, err
 	}
 
-	url = c.BaseURL.ResolveReference(url)
+	if err := CheckReadOnly(method, url.Path); err != nil {
+		return nil, err
+	}
+
+	if err := checkLivePolicy(c.APIKey, method, url.Path); err != nil {
+		return nil, err
+	}
+
+	baseURL := c.BaseURL
+	if mockURL := MockBaseURL(); mockURL != nil {
+		baseURL = mockURL
+	}
+
+	url = baseURL.ResolveReference(url)
+
+	ctx, span := StartSpan(ctx, method+" "+url.Path, SpanKindClient)
+	defer span.End()
+	span.SetAttribute("http.method", method)
+	span.SetAttribute("http.url", url.Scheme+"://"+url.Host+url.Path)
+
+	if method != http.MethodPost {
+		url.RawQuery = params
+	}
+
+	if c.httpClient == nil {
+		c.httpClient = newHTTPClient(c.Verbose, c.VerbosePrintableHeaders, os.Getenv("STRIPE_CLI_UNIX_SOCKET"), c.Proxy)
+	}
+
+	livemode := strings.Contains(c.APIKey, "live")
+	limiter := currentRateLimiter()
+
+	var resp *http.Response
+	for attempt := 0; ; attempt++ {
+		if limiter != nil {
+			if err := limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		req, err := c.newRequest(ctx, method, url.String(), params, configure)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err = c.httpClient.Do(req)
+		if err != nil {
+			span.SetError(err)
+			recordAudit(req, params, nil, livemode, err)
+			return nil, err
+		}
+
+		recordAudit(req, params, resp, livemode, nil)
+
+		if limiter == nil {
+			break
+		}
+
+		delay, retry := limiter.Observe(resp)
+		if !retry || attempt == maxRateLimitRetries {
+			break
+		}
+
+		log.WithFields(log.Fields{
+			"prefix": "stripe.Client.PerformRequest",
+			"reason": resp.Header.Get("Stripe-Rate-Limited-Reason"),
+		}).Debugf("%s %s was rate limited, retrying in %s (%d/%d)", method, url.Path, delay.Round(time.Millisecond), attempt+1, maxRateLimitRetries)
+		span.SetAttribute("stripe.rate_limit_retries", attempt+1)
+
+		// Drain the response so that its connection is reused
+		io.Copy(ioutil.Discard, resp.Body) // #nosec G104
+		resp.Body.Close()
+	}
+
+	// RequestID of the API Request
+	requestID := resp.Header.Get("Request-Id")
 
+	span.SetAttribute("http.status_code", resp.StatusCode)
+	span.SetAttribute("stripe.request_id", requestID)
+	span.SetAttribute("stripe.livemode", livemode)
+	if resp.StatusCode >= 400 {
+		span.SetError(fmt.Errorf("%s %s returned %d", method, url.Path, resp.StatusCode))
+	}
+	go sendTelemetryEvent(ctx, requestID, livemode)
+	return resp, nil
+}
+
+// newRequest returns a request to url with the params and headers of
+// PerformRequest. A new one is created for each attempt, since sending a
+// request consumes its body.
+func (c *Client) newRequest(ctx context.Context, method, url, params string, configure func(*http.Request)) (*http.Request, error) {
 	var body io.Reader
 	if method == http.MethodPost {
 		body = strings.NewReader(params)
-	} else {
-		url.RawQuery = params
 	}
 
-	req, err := http.NewRequest(method, url.String(), body)
+	req, err := http.NewRequest(method, url, body)
 	if err != nil {
 		return nil, err
 	}
@@ -85,24 +177,11 @@ func (c *Client) PerformRequest(ctx context.Context, method, path string, params
 		configure(req)
 	}
 
-	if c.httpClient == nil {
-		c.httpClient = newHTTPClient(c.Verbose, c.VerbosePrintableHeaders, os.Getenv("STRIPE_CLI_UNIX_SOCKET"))
-	}
-
 	if ctx != nil {
 		req = req.WithContext(ctx)
 	}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	// RequestID of the API Request
-	requestID := resp.Header.Get("Request-Id")
-	livemode := strings.Contains(c.APIKey, "live")
-	go sendTelemetryEvent(ctx, requestID, livemode)
-	return resp, nil
+	return req, nil
 }
 
 func sendTelemetryEvent(ctx context.Context, requestID string, livemode bool) {
@@ -119,7 +198,7 @@ func sendTelemetryEvent(ctx context.Context, requestID string, livemode bool) {
 	}
 }
 
-func newHTTPClient(verbose bool, printableHeaders []string, unixSocket string) *http.Client {
+func newHTTPClient(verbose bool, printableHeaders []string, unixSocket string, proxy *netproxy.Dialer) *http.Client {
 	var httpTransport http.RoundTripper
 
 	if unixSocket != "" {
@@ -130,41 +209,21 @@ func newHTTPClient(verbose bool, printableHeaders []string, unixSocket string) *
 			return net.Dial("unix", unixSocket)
 		}
 		httpTransport = &http.Transport{
-			TLSClientConfig: &tls.Config{
-							CipherSuites: []uint16{
-        						tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA,
-        						tls.TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA,
-    						},
-    						PreferServerCipherSuites: true,
-    						InsecureSkipVerify:       true,
-    						MinVersion:               tls.VersionTLS11,
-    						MaxVersion:               tls.VersionTLS11,
-						
-						},
 			DialContext:           dialContext,
 			DialTLS:               dialFunc,
 			ResponseHeaderTimeout: 30 * time.Second,
 			ExpectContinueTimeout: 10 * time.Second,
 			TLSHandshakeTimeout:   10 * time.Second,
 		}
+	} else if proxy != nil {
+		httpTransport = &http.Transport{
+			DialContext:         proxy.DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+		}
 	} else {
 		httpTransport = &http.Transport{
-			TLSClientConfig: &tls.Config{
-							CipherSuites: []uint16{
-        						tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA,
-        						tls.TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA,
-    						},
-    						PreferServerCipherSuites: true,
-    						InsecureSkipVerify:       true,
-    						MinVersion:               tls.VersionTLS11,
-    						MaxVersion:               tls.VersionTLS11,
-						
-						},
-			Proxy: http.ProxyFromEnvironment,
-			DialContext: (&net.Dialer{
-				Timeout:   30 * time.Second,
-				KeepAlive: 30 * time.Second,
-			}).DialContext,
+			Proxy:               http.ProxyFromEnvironment,
+			DialContext:         netproxy.DialContext,
 			TLSHandshakeTimeout: 10 * time.Second,
 		}
 	}
@@ -0,0 +1,164 @@
+package stripe
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"golang.org/x/term"
+)
+
+// LivePolicy guards requests that may modify data and are sent with a live
+// mode key. Such requests need to be confirmed interactively, unless they are
+// confirmed up front or allowlisted.
+type LivePolicy struct {
+	// Confirmed skips the confirmation prompt, as set by --confirm-live
+	Confirmed bool
+
+	// Allowlist contains the live operations permitted without confirmation,
+	// as "METHOD /path" entries. Paths may contain wildcards, e.g.
+	// "POST /v1/customers/*".
+	Allowlist []string
+
+	// In and Out are used to prompt for confirmation. They default to
+	// os.Stdin and os.Stderr, so that the prompt doesn't mix with the output
+	// of the command.
+	In  io.Reader
+	Out io.Writer
+
+	mu     sync.Mutex
+	reader *bufio.Reader
+}
+
+// LiveModeNotConfirmedError is returned when a request that may modify live
+// mode data was not confirmed.
+type LiveModeNotConfirmedError struct {
+	Method string
+	Path   string
+}
+
+func (e LiveModeNotConfirmedError) Error() string {
+	return fmt.Sprintf("refusing to send %s %s with a live mode key: the request was not confirmed. Pass --confirm-live, or add \"%s %s\" to the live_allowlist setting of your profile, to allow it", e.Method, e.Path, e.Method, e.Path)
+}
+
+var (
+	livePolicyMu sync.Mutex
+	livePolicy   *LivePolicy
+)
+
+// SetLivePolicy sets the policy applied to requests sent with a live mode
+// key. A nil policy lets all requests through.
+func SetLivePolicy(policy *LivePolicy) {
+	livePolicyMu.Lock()
+	defer livePolicyMu.Unlock()
+
+	livePolicy = policy
+}
+
+// IsLiveKey returns true if key is a live mode secret or restricted key.
+func IsLiveKey(key string) bool {
+	return strings.HasPrefix(key, "sk_live_") || strings.HasPrefix(key, "rk_live_")
+}
+
+// Allows returns true if the policy lets a request with method to path be
+// sent with a live mode key, prompting for confirmation if needed. Once the
+// user confirms, the remaining requests of the command are let through
+// without asking again.
+func (p *LivePolicy) Allows(method, path string) (bool, error) {
+	method = strings.ToUpper(method)
+
+	if MatchesOperation(p.Allowlist, method, path) {
+		return true, nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.Confirmed {
+		return true, nil
+	}
+
+	in := p.In
+	if in == nil {
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return false, nil
+		}
+		in = os.Stdin
+	}
+
+	out := p.Out
+	if out == nil {
+		out = os.Stderr
+	}
+
+	if p.reader == nil {
+		p.reader = bufio.NewReader(in)
+	}
+
+	fmt.Fprintf(out, "You are about to send %s %s with a live mode key, which may modify live data.\nEnter 'yes' to confirm this and any further live requests of this command: ", method, path)
+
+	input, err := p.reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+
+	p.Confirmed = strings.ToLower(strings.TrimSpace(input)) == "yes"
+
+	return p.Confirmed, nil
+}
+
+// MatchesOperation returns true if a request with method to requestPath
+// matches one of entries, which are "METHOD /path" operations like those of
+// the live mode allowlist. The method may be "*", and paths may contain
+// wildcards, e.g. "GET /v1/customers/*". Paths that aren't clean, like
+// /v1/customers/.., never match, since wildcards would otherwise let them
+// escape the matching paths.
+func MatchesOperation(entries []string, method, requestPath string) bool {
+	if path.Clean(requestPath) != requestPath {
+		return false
+	}
+
+	for _, entry := range entries {
+		fields := strings.Fields(entry)
+		if len(fields) != 2 {
+			continue
+		}
+
+		if fields[0] != "*" && !strings.EqualFold(fields[0], method) {
+			continue
+		}
+
+		if matched, _ := path.Match(fields[1], requestPath); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkLivePolicy returns a LiveModeNotConfirmedError if the live policy
+// doesn't let a request with method to path be sent with key.
+func checkLivePolicy(key, method, path string) error {
+	livePolicyMu.Lock()
+	policy := livePolicy
+	livePolicyMu.Unlock()
+
+	if policy == nil || !IsLiveKey(key) || !isMutating(method, path) {
+		return nil
+	}
+
+	allowed, err := policy.Allows(method, path)
+	if err != nil {
+		return err
+	}
+
+	if !allowed {
+		return LiveModeNotConfirmedError{Method: strings.ToUpper(method), Path: path}
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package stripe
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLivePolicyAllowlist(t *testing.T) {
+	policy := &LivePolicy{
+		Allowlist: []string{"POST /v1/customers/*", "* /v1/products"},
+		In:        strings.NewReader(""),
+		Out:       &bytes.Buffer{},
+	}
+
+	allowed, err := policy.Allows(http.MethodPost, "/v1/customers/cus_123")
+	require.NoError(t, err)
+	require.True(t, allowed)
+
+	allowed, err = policy.Allows(http.MethodDelete, "/v1/products")
+	require.NoError(t, err)
+	require.True(t, allowed)
+
+	allowed, err = policy.Allows(http.MethodDelete, "/v1/customers/cus_123")
+	require.NoError(t, err)
+	require.False(t, allowed)
+}
+
+func TestMatchesOperation(t *testing.T) {
+	entries := []string{"GET /v1/customers/*", "* /v1/products", "malformed"}
+
+	require.True(t, MatchesOperation(entries, "get", "/v1/customers/cus_123"))
+	require.True(t, MatchesOperation(entries, http.MethodDelete, "/v1/products"))
+	require.False(t, MatchesOperation(entries, http.MethodPost, "/v1/customers/cus_123"))
+	require.False(t, MatchesOperation(entries, http.MethodGet, "/v1/customers/.."))
+	require.False(t, MatchesOperation(entries, http.MethodGet, "/v1/customers/cus_123/"))
+}
+
+func TestLivePolicyPrompt(t *testing.T) {
+	out := &bytes.Buffer{}
+	policy := &LivePolicy{
+		In:  strings.NewReader("yes\n"),
+		Out: out,
+	}
+
+	allowed, err := policy.Allows(http.MethodPost, "/v1/charges")
+	require.NoError(t, err)
+	require.True(t, allowed)
+	require.Contains(t, out.String(), "POST /v1/charges")
+
+	// Further requests don't prompt again
+	out.Reset()
+	allowed, err = policy.Allows(http.MethodPost, "/v1/refunds")
+	require.NoError(t, err)
+	require.True(t, allowed)
+	require.Empty(t, out.String())
+}
+
+func TestLivePolicyPromptDeclined(t *testing.T) {
+	policy := &LivePolicy{
+		In:  strings.NewReader("no\n"),
+		Out: &bytes.Buffer{},
+	}
+
+	allowed, err := policy.Allows(http.MethodPost, "/v1/charges")
+	require.NoError(t, err)
+	require.False(t, allowed)
+}
+
+func TestPerformRequest_LivePolicy(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+	}))
+	defer ts.Close()
+
+	SetLivePolicy(&LivePolicy{
+		In:  strings.NewReader(""),
+		Out: &bytes.Buffer{},
+	})
+	defer SetLivePolicy(nil)
+
+	baseURL, _ := url.Parse(ts.URL)
+
+	testClient := Client{BaseURL: baseURL, APIKey: "sk_test_123"}
+	resp, err := testClient.PerformRequest(context.Background(), http.MethodPost, "/v1/charges", "", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	liveClient := Client{BaseURL: baseURL, APIKey: "sk_live_123"}
+	resp, err = liveClient.PerformRequest(context.Background(), http.MethodGet, "/v1/charges", "", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	_, err = liveClient.PerformRequest(context.Background(), http.MethodPost, "/v1/charges", "", nil)
+	require.Equal(t, LiveModeNotConfirmedError{Method: http.MethodPost, Path: "/v1/charges"}, err)
+
+	require.Equal(t, []string{"POST /v1/charges", "GET /v1/charges"}, requests)
+}
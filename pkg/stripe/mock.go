@@ -0,0 +1,29 @@
+package stripe
+
+import (
+	"net/url"
+	"sync"
+)
+
+var (
+	mockMu      sync.RWMutex
+	mockBaseURL *url.URL
+)
+
+// SetMockBaseURL sends the requests of all Clients to a stripe-mock server
+// at baseURL instead of their BaseURL, or to their BaseURL again if it's nil.
+func SetMockBaseURL(baseURL *url.URL) {
+	mockMu.Lock()
+	defer mockMu.Unlock()
+
+	mockBaseURL = baseURL
+}
+
+// MockBaseURL returns the base URL of the stripe-mock server requests are
+// sent to, nil if they're sent to Stripe.
+func MockBaseURL() *url.URL {
+	mockMu.RLock()
+	defer mockMu.RUnlock()
+
+	return mockBaseURL
+}
@@ -0,0 +1,34 @@
+package stripe
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPerformRequest_Mock(t *testing.T) {
+	var requests []string
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+	}))
+	defer mock.Close()
+
+	mockURL, _ := url.Parse(mock.URL)
+	SetMockBaseURL(mockURL)
+	defer SetMockBaseURL(nil)
+
+	baseURL, _ := url.Parse(DefaultAPIBaseURL)
+	client := Client{
+		BaseURL: baseURL,
+	}
+
+	resp, err := client.PerformRequest(context.Background(), http.MethodGet, "/v1/customers", "", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	require.Equal(t, []string{"GET /v1/customers"}, requests)
+}
@@ -0,0 +1,233 @@
+package stripe
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultMaxRPS is the number of API requests per second the CLI sends at
+// most unless --max-rps is set, which is the rate limit of test mode
+const DefaultMaxRPS = 25
+
+const (
+	// maxRateLimitRetries is the number of times a rate limited request is
+	// sent again
+	maxRateLimitRetries = 3
+
+	// minRPS is the rate the limiter never slows down below
+	minRPS = 1
+
+	// rpsRecovery is how much the rate increases after each request that
+	// isn't rate limited, until it's back to the maximum
+	rpsRecovery = 0.5
+
+	rateLimitBaseDelay = 500 * time.Millisecond
+	rateLimitMaxDelay  = 8 * time.Second
+	// maxRateLimitDelay caps the delays of Retry-After headers
+	maxRateLimitDelay = time.Minute
+)
+
+// RateLimiter paces the API requests sent by the Client, and is shared by all
+// the goroutines of the process, like the requests of --bulk, of fixtures or
+// of `listen`. It's a token bucket refilled up to maxRPS tokens per second.
+// When a request is rate limited, the rate is halved, every request waits for
+// the Retry-After delay, and the rate recovers as requests succeed.
+type RateLimiter struct {
+	// maxRPS is the maximum rate, 0 for no limit. Rate limited requests are
+	// still delayed and retried then.
+	maxRPS float64
+
+	// Now returns the current time, and defaults to time.Now
+	Now func() time.Time
+
+	// Sleep waits between requests, and defaults to a timer stopped when
+	// the context is done
+	Sleep func(ctx context.Context, d time.Duration) error
+
+	mu  sync.Mutex
+	rps float64
+	// tokens are the requests that can be sent at once. They're negative
+	// when requests wait for the bucket to refill.
+	tokens float64
+	// last is when the bucket was last refilled, or the end of a pause when
+	// it's in the future
+	last time.Time
+	// limited is the number of rate limited requests in a row
+	limited int
+}
+
+// NewRateLimiter returns a limiter sending at most maxRPS requests per second,
+// or sending them as fast as possible if maxRPS is 0.
+func NewRateLimiter(maxRPS float64) *RateLimiter {
+	return &RateLimiter{
+		maxRPS: maxRPS,
+		rps:    maxRPS,
+		tokens: math.Max(maxRPS, 1),
+	}
+}
+
+var (
+	rateLimiterMu sync.RWMutex
+	rateLimiter   *RateLimiter
+)
+
+// SetRateLimiter sets the limiter the Client paces its requests with, or
+// disables it if l is nil.
+func SetRateLimiter(l *RateLimiter) {
+	rateLimiterMu.Lock()
+	defer rateLimiterMu.Unlock()
+
+	rateLimiter = l
+}
+
+func currentRateLimiter() *RateLimiter {
+	rateLimiterMu.RLock()
+	defer rateLimiterMu.RUnlock()
+
+	return rateLimiter
+}
+
+// Wait blocks until a request can be sent, or until ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	delay := l.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return l.sleep(ctx, delay)
+}
+
+// Observe adjusts the rate to the response of a request. If the request was
+// rate limited, it returns how long it waits before being sent again, and
+// whether it can be.
+func (l *RateLimiter) Observe(resp *http.Response) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		l.limited = 0
+		if l.rps < l.maxRPS {
+			l.rps = math.Min(l.rps+rpsRecovery, l.maxRPS)
+		}
+
+		return 0, false
+	}
+
+	delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"))
+	if !ok {
+		delay = rateLimitBaseDelay << l.limited
+		if delay > rateLimitMaxDelay {
+			delay = rateLimitMaxDelay
+		}
+	}
+	l.limited++
+
+	if l.maxRPS > 0 {
+		l.rps = math.Max(l.rps/2, minRPS)
+	}
+
+	// Every request waits for the end of the pause, and the bucket is
+	// empty then
+	now := l.now()
+	if until := now.Add(delay); until.After(l.last) {
+		l.last = until
+	}
+	l.tokens = math.Min(l.tokens, 0)
+
+	return l.last.Sub(now), resp.Header.Get("Stripe-Should-Retry") != "false"
+}
+
+// RPS returns the number of requests per second the limiter currently sends at
+// most, 0 for no limit.
+func (l *RateLimiter) RPS() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.rps
+}
+
+// reserve takes a token from the bucket and returns how long to wait until
+// it's available.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	if l.rps <= 0 {
+		return l.last.Sub(now)
+	}
+
+	if now.After(l.last) {
+		burst := math.Max(l.rps, 1)
+		l.tokens = math.Min(l.tokens+now.Sub(l.last).Seconds()*l.rps, burst)
+		l.last = now
+	}
+
+	l.tokens--
+
+	wait := l.last.Sub(now)
+	if l.tokens < 0 {
+		wait += time.Duration(-l.tokens / l.rps * float64(time.Second))
+	}
+
+	return wait
+}
+
+func (l *RateLimiter) now() time.Time {
+	if l.Now != nil {
+		return l.Now()
+	}
+
+	return time.Now()
+}
+
+func (l *RateLimiter) sleep(ctx context.Context, d time.Duration) error {
+	if l.Sleep != nil {
+		return l.Sleep(ctx, d)
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// parseRetryAfter parses a Retry-After header, either a number of seconds or a
+// date
+func parseRetryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(header); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(header); err == nil {
+		delay = time.Until(date)
+	} else {
+		return 0, false
+	}
+
+	if delay < 0 {
+		delay = 0
+	}
+	if delay > maxRateLimitDelay {
+		delay = maxRateLimitDelay
+	}
+
+	return delay, true
+}
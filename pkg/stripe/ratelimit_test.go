@@ -0,0 +1,199 @@
+package stripe
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRateLimiter returns a limiter whose clock only moves when it sleeps
+func fakeRateLimiter(maxRPS float64, delays *[]time.Duration) *RateLimiter {
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	var mu sync.Mutex
+
+	l := NewRateLimiter(maxRPS)
+	l.Now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	l.Sleep = func(ctx context.Context, d time.Duration) error {
+		mu.Lock()
+		defer mu.Unlock()
+		*delays = append(*delays, d)
+		now = now.Add(d)
+		return nil
+	}
+
+	return l
+}
+
+func rateLimitedResponse(retryAfter string) *http.Response {
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	if retryAfter != "" {
+		resp.Header.Set("Retry-After", retryAfter)
+	}
+	return resp
+}
+
+func TestRateLimiterPacesRequests(t *testing.T) {
+	var delays []time.Duration
+	l := fakeRateLimiter(2, &delays)
+
+	for i := 0; i < 4; i++ {
+		require.NoError(t, l.Wait(context.Background()))
+	}
+
+	// The first two requests are sent at once, and the next ones 2 per second
+	require.Equal(t, []time.Duration{500 * time.Millisecond, 500 * time.Millisecond}, delays)
+}
+
+func TestRateLimiterIsShared(t *testing.T) {
+	l := NewRateLimiter(5)
+	now := time.Now()
+	l.Now = func() time.Time { return now }
+
+	var mu sync.Mutex
+	var waits []time.Duration
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wait := l.reserve()
+			mu.Lock()
+			waits = append(waits, wait)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(waits, func(i, j int) bool { return waits[i] < waits[j] })
+	require.Equal(t, []time.Duration{
+		0, 0, 0, 0, 0,
+		200 * time.Millisecond, 400 * time.Millisecond, 600 * time.Millisecond, 800 * time.Millisecond, time.Second,
+	}, waits)
+}
+
+func TestRateLimiterBacksOff(t *testing.T) {
+	var delays []time.Duration
+	l := fakeRateLimiter(10, &delays)
+	require.NoError(t, l.Wait(context.Background()))
+
+	delay, retry := l.Observe(rateLimitedResponse("3"))
+	require.True(t, retry)
+	require.Equal(t, 3*time.Second, delay)
+	require.Equal(t, 5.0, l.RPS())
+
+	// Every request waits for the pause, and the rate is halved then
+	require.NoError(t, l.Wait(context.Background()))
+	require.NoError(t, l.Wait(context.Background()))
+	require.Equal(t, []time.Duration{3*time.Second + 200*time.Millisecond, 200 * time.Millisecond}, delays)
+
+	// Without Retry-After, the delay doubles with each rate limited request
+	delay, _ = l.Observe(rateLimitedResponse(""))
+	require.Equal(t, time.Second, delay)
+	require.Equal(t, 2.5, l.RPS())
+
+	// The rate recovers as requests succeed
+	for i := 0; i < 20; i++ {
+		_, retry = l.Observe(&http.Response{StatusCode: http.StatusOK})
+		require.False(t, retry)
+	}
+	require.Equal(t, 10.0, l.RPS())
+
+	resp := rateLimitedResponse("")
+	resp.Header.Set("Stripe-Should-Retry", "false")
+	_, retry = l.Observe(resp)
+	require.False(t, retry)
+}
+
+func TestRateLimiterWithoutLimit(t *testing.T) {
+	var delays []time.Duration
+	l := fakeRateLimiter(0, &delays)
+
+	for i := 0; i < 100; i++ {
+		require.NoError(t, l.Wait(context.Background()))
+	}
+	require.Empty(t, delays)
+
+	l.Observe(rateLimitedResponse("2"))
+	require.Equal(t, 0.0, l.RPS())
+
+	require.NoError(t, l.Wait(context.Background()))
+	require.Equal(t, []time.Duration{2 * time.Second}, delays)
+}
+
+func TestRateLimiterWaitCanceled(t *testing.T) {
+	l := NewRateLimiter(1)
+	l.Observe(rateLimitedResponse("30"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, l.Wait(ctx), context.Canceled)
+}
+
+func TestPerformRequest_RateLimited(t *testing.T) {
+	var delays []time.Duration
+	SetRateLimiter(fakeRateLimiter(DefaultMaxRPS, &delays))
+	defer SetRateLimiter(nil)
+
+	var bodies []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		bodies = append(bodies, string(body))
+
+		if len(bodies) < 3 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"id": "cus_123"}`))
+	}))
+	defer ts.Close()
+
+	baseURL, _ := url.Parse(ts.URL)
+	client := Client{BaseURL: baseURL}
+
+	resp, err := client.PerformRequest(context.Background(), http.MethodPost, "/v1/customers", "email=jenny%40example.com", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, []string{"email=jenny%40example.com", "email=jenny%40example.com", "email=jenny%40example.com"}, bodies)
+	require.Len(t, delays, 2)
+	require.GreaterOrEqual(t, delays[0], time.Second)
+}
+
+func TestPerformRequest_RateLimitedRetriesExhausted(t *testing.T) {
+	var delays []time.Duration
+	SetRateLimiter(fakeRateLimiter(0, &delays))
+	defer SetRateLimiter(nil)
+
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	baseURL, _ := url.Parse(ts.URL)
+	client := Client{BaseURL: baseURL}
+
+	resp, err := client.PerformRequest(context.Background(), http.MethodGet, "/v1/customers", "", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	require.Equal(t, maxRateLimitRetries+1, requests)
+	require.Equal(t, []time.Duration{500 * time.Millisecond, time.Second, 2 * time.Second}, delays)
+}
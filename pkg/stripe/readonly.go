@@ -0,0 +1,79 @@
+package stripe
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// ReadOnlyError is returned when a request that could modify data is sent
+// while read-only mode is enabled.
+type ReadOnlyError struct {
+	Method string
+	Path   string
+}
+
+func (e ReadOnlyError) Error() string {
+	return fmt.Sprintf("refusing to send %s %s: read-only mode is enabled and only allows GET requests. Remove the --read-only flag, or the read_only setting of your profile, to make changes", e.Method, e.Path)
+}
+
+// readOnly is non-zero when read-only mode is enabled.
+var readOnly int32
+
+// nonMutatingPaths are non-GET requests that don't modify account data. They
+// are needed to authenticate the CLI and to open `listen` and `logs tail`
+// sessions, so they are still sent in read-only mode.
+var nonMutatingPaths = map[string]bool{
+	"/stripecli/auth":        true,
+	"/v1/stripecli/sessions": true,
+}
+
+// nonMutatingPrefixes are like nonMutatingPaths, for paths that end with an
+// ID, such as closing the tunnel of a `listen --tunnel` session.
+var nonMutatingPrefixes = []string{
+	"/v1/stripecli/tunnels/",
+}
+
+// SetReadOnly enables or disables read-only mode. While it is enabled, the
+// Client refuses to send any request other than GET.
+func SetReadOnly(enabled bool) {
+	var value int32
+	if enabled {
+		value = 1
+	}
+
+	atomic.StoreInt32(&readOnly, value)
+}
+
+// IsReadOnly returns true if read-only mode is enabled.
+func IsReadOnly() bool {
+	return atomic.LoadInt32(&readOnly) != 0
+}
+
+// CheckReadOnly returns a ReadOnlyError if read-only mode forbids sending a
+// request with method to path.
+func CheckReadOnly(method, path string) error {
+	if !IsReadOnly() || !isMutating(method, path) {
+		return nil
+	}
+
+	return ReadOnlyError{Method: strings.ToUpper(method), Path: path}
+}
+
+// isMutating returns true if a request with method to path may modify data.
+func isMutating(method, path string) bool {
+	method = strings.ToUpper(method)
+
+	if method == http.MethodGet || method == http.MethodHead || nonMutatingPaths[path] {
+		return false
+	}
+
+	for _, prefix := range nonMutatingPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return false
+		}
+	}
+
+	return true
+}
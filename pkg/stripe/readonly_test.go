@@ -0,0 +1,47 @@
+package stripe
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPerformRequest_ReadOnly(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+	}))
+	defer ts.Close()
+
+	SetReadOnly(true)
+	defer SetReadOnly(false)
+
+	baseURL, _ := url.Parse(ts.URL)
+	client := Client{
+		BaseURL: baseURL,
+	}
+
+	resp, err := client.PerformRequest(context.Background(), http.MethodGet, "/v1/customers", "", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	resp, err = client.PerformRequest(context.Background(), http.MethodPost, "/v1/stripecli/sessions", "", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	resp, err = client.PerformRequest(context.Background(), http.MethodDelete, "/v1/stripecli/tunnels/tun_123", "", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	_, err = client.PerformRequest(context.Background(), http.MethodPost, "/v1/customers", "name=Jenny", nil)
+	require.Equal(t, ReadOnlyError{Method: http.MethodPost, Path: "/v1/customers"}, err)
+
+	_, err = client.PerformRequest(context.Background(), "delete", "/v1/customers/cus_123", "", nil)
+	require.Equal(t, ReadOnlyError{Method: http.MethodDelete, Path: "/v1/customers/cus_123"}, err)
+
+	require.Equal(t, []string{"GET /v1/customers", "POST /v1/stripecli/sessions", "DELETE /v1/stripecli/tunnels/tun_123"}, requests)
+}
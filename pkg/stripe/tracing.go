@@ -0,0 +1,467 @@
+package stripe
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/stripe/stripe-cli/pkg/version"
+)
+
+// TracingEndpointEnv opts into tracing. It's the OTLP/HTTP endpoint of a
+// collector, like http://localhost:4318, that the spans of commands, API
+// requests, websocket connections and fixture steps are exported to.
+const TracingEndpointEnv = "STRIPE_CLI_OTLP_ENDPOINT"
+
+const (
+	// otlpHeadersEnv are the headers sent to the collector, like
+	// `api-key=secret,team=payments`, as for OpenTelemetry SDKs
+	otlpHeadersEnv = "OTEL_EXPORTER_OTLP_HEADERS"
+
+	// serviceNameEnv is the name of the service of the spans
+	serviceNameEnv = "OTEL_SERVICE_NAME"
+
+	// traceparentEnv is the W3C trace context the spans of the command are
+	// part of, so that a script or test running the CLI can link its spans
+	traceparentEnv = "TRACEPARENT"
+)
+
+const defaultServiceName = "stripe-cli"
+
+// tracesPath is the path of the traces of an OTLP/HTTP endpoint
+const tracesPath = "/v1/traces"
+
+// exportInterval is the time between exports of the ended spans, for
+// long-running commands like listen
+var exportInterval = 5 * time.Second
+
+// maxQueuedSpans is the number of ended spans kept until they're exported,
+// after which new spans are dropped
+const maxQueuedSpans = 2048
+
+// SpanKind is the kind of a span, with the values of OTLP
+type SpanKind int
+
+// Kinds of spans
+const (
+	SpanKindInternal SpanKind = 1
+	SpanKindClient   SpanKind = 3
+)
+
+type spanContext struct {
+	traceID [16]byte
+	spanID  [8]byte
+}
+
+func (sc spanContext) isValid() bool {
+	return sc.traceID != [16]byte{} && sc.spanID != [8]byte{}
+}
+
+// Span is an operation of a command, exported to the collector when it ends.
+// The methods of a nil Span do nothing, which is what StartSpan returns when
+// tracing is disabled.
+type Span struct {
+	tracer   *tracer
+	kind     SpanKind
+	sc       spanContext
+	parentID [8]byte
+	start    time.Time
+
+	mu    sync.Mutex
+	name  string
+	attrs []spanAttribute
+	err   error
+	end   time.Time
+	ended bool
+}
+
+type spanAttribute struct {
+	key   string
+	value interface{}
+}
+
+type spanKey struct{}
+
+// tracer exports the spans of the CLI to an OTLP/HTTP collector
+type tracer struct {
+	endpoint    string
+	headers     map[string]string
+	serviceName string
+	parent      spanContext
+	client      *http.Client
+
+	mu    sync.Mutex
+	spans []*Span
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+var (
+	tracerMu     sync.RWMutex
+	activeTracer *tracer
+)
+
+// EnableTracing starts exporting spans if TracingEndpointEnv is set. Spans
+// are exported in the background, and when ShutdownTracing is called.
+func EnableTracing() error {
+	endpoint := os.Getenv(TracingEndpointEnv)
+	if endpoint == "" {
+		return nil
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid %s ‘%s’, expected a URL like http://localhost:4318", TracingEndpointEnv, endpoint)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = tracesPath
+	}
+
+	t := &tracer{
+		endpoint:    u.String(),
+		headers:     parseOTLPHeaders(os.Getenv(otlpHeadersEnv)),
+		serviceName: defaultServiceName,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	if name := os.Getenv(serviceNameEnv); name != "" {
+		t.serviceName = name
+	}
+	if parent, ok := parseTraceparent(os.Getenv(traceparentEnv)); ok {
+		t.parent = parent
+	}
+
+	go t.run()
+
+	tracerMu.Lock()
+	activeTracer = t
+	tracerMu.Unlock()
+
+	return nil
+}
+
+// ShutdownTracing exports the spans that ended and stops tracing
+func ShutdownTracing(ctx context.Context) {
+	tracerMu.Lock()
+	t := activeTracer
+	activeTracer = nil
+	tracerMu.Unlock()
+
+	if t == nil {
+		return
+	}
+
+	close(t.stop)
+	<-t.done
+
+	t.export(ctx)
+}
+
+func currentTracer() *tracer {
+	tracerMu.RLock()
+	defer tracerMu.RUnlock()
+
+	return activeTracer
+}
+
+// StartSpan starts a span, child of the span of ctx if any, and returns a
+// context with it. It returns ctx and a nil Span if tracing is disabled.
+func StartSpan(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	t := currentTracer()
+	if t == nil {
+		return ctx, nil
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	span := &Span{
+		tracer: t,
+		kind:   kind,
+		name:   name,
+		start:  time.Now(),
+	}
+
+	parent := t.parent
+	if p := SpanFromContext(ctx); p != nil {
+		parent = p.sc
+	}
+
+	if parent.isValid() {
+		span.sc.traceID = parent.traceID
+		span.parentID = parent.spanID
+	} else {
+		rand.Read(span.sc.traceID[:]) // #nosec G104
+	}
+	rand.Read(span.sc.spanID[:]) // #nosec G104
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// SpanFromContext returns the span of ctx, or nil
+func SpanFromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+
+	span, _ := ctx.Value(spanKey{}).(*Span)
+
+	return span
+}
+
+// SetName renames the span
+func (s *Span) SetName(name string) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.name = name
+}
+
+// SetAttribute sets an attribute of the span. Values are strings, bools,
+// ints or floats.
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.attrs {
+		if s.attrs[i].key == key {
+			s.attrs[i].value = value
+			return
+		}
+	}
+	s.attrs = append(s.attrs, spanAttribute{key, value})
+}
+
+// SetError marks the span as failed with err, if it's not nil
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.err = err
+}
+
+// End ends the span, which is then exported
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	s.mu.Unlock()
+
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+
+	if len(s.tracer.spans) < maxQueuedSpans {
+		s.tracer.spans = append(s.tracer.spans, s)
+	}
+}
+
+func (t *tracer) run() {
+	defer close(t.done)
+
+	ticker := time.NewTicker(exportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.stop:
+			return
+		case <-ticker.C:
+			t.export(context.Background())
+		}
+	}
+}
+
+// export sends the spans that ended to the collector. Failures are only
+// logged, tracing never fails a command.
+func (t *tracer) export(ctx context.Context) {
+	t.mu.Lock()
+	spans := t.spans
+	t.spans = nil
+	t.mu.Unlock()
+
+	if len(spans) == 0 {
+		return
+	}
+
+	body, err := json.Marshal(t.otlpRequest(spans))
+	if err != nil {
+		log.Debugf("Error while encoding spans: %v", err)
+		return
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(body))
+	if err != nil {
+		log.Debugf("Error while exporting spans: %v", err)
+		return
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range t.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		log.Debugf("Error while exporting spans: %v", err)
+		return
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		log.Debugf("Error while exporting spans: %s returned %d", t.endpoint, resp.StatusCode)
+	}
+}
+
+// otlpRequest returns the OTLP/JSON export request of spans
+func (t *tracer) otlpRequest(spans []*Span) map[string]interface{} {
+	encoded := make([]map[string]interface{}, 0, len(spans))
+
+	for _, s := range spans {
+		s.mu.Lock()
+
+		span := map[string]interface{}{
+			"traceId":           hex.EncodeToString(s.sc.traceID[:]),
+			"spanId":            hex.EncodeToString(s.sc.spanID[:]),
+			"name":              s.name,
+			"kind":              int(s.kind),
+			"startTimeUnixNano": strconv.FormatInt(s.start.UnixNano(), 10),
+			"endTimeUnixNano":   strconv.FormatInt(s.end.UnixNano(), 10),
+			"attributes":        otlpAttributes(s.attrs),
+		}
+		if s.parentID != [8]byte{} {
+			span["parentSpanId"] = hex.EncodeToString(s.parentID[:])
+		}
+		if s.err != nil {
+			span["status"] = map[string]interface{}{"code": 2, "message": s.err.Error()}
+		}
+
+		s.mu.Unlock()
+
+		encoded = append(encoded, span)
+	}
+
+	return map[string]interface{}{
+		"resourceSpans": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{
+					"attributes": otlpAttributes([]spanAttribute{
+						{"service.name", t.serviceName},
+						{"service.version", version.Version},
+					}),
+				},
+				"scopeSpans": []interface{}{
+					map[string]interface{}{
+						"scope": map[string]interface{}{"name": "github.com/stripe/stripe-cli"},
+						"spans": encoded,
+					},
+				},
+			},
+		},
+	}
+}
+
+func otlpAttributes(attrs []spanAttribute) []interface{} {
+	encoded := make([]interface{}, 0, len(attrs))
+
+	for _, attr := range attrs {
+		var value map[string]interface{}
+
+		switch v := attr.value.(type) {
+		case string:
+			value = map[string]interface{}{"stringValue": v}
+		case bool:
+			value = map[string]interface{}{"boolValue": v}
+		case int:
+			value = map[string]interface{}{"intValue": strconv.Itoa(v)}
+		case int64:
+			value = map[string]interface{}{"intValue": strconv.FormatInt(v, 10)}
+		case float64:
+			value = map[string]interface{}{"doubleValue": v}
+		default:
+			value = map[string]interface{}{"stringValue": fmt.Sprintf("%v", v)}
+		}
+
+		encoded = append(encoded, map[string]interface{}{"key": attr.key, "value": value})
+	}
+
+	return encoded
+}
+
+// parseOTLPHeaders parses headers like `api-key=secret,team=payments`
+func parseOTLPHeaders(value string) map[string]string {
+	headers := make(map[string]string)
+
+	for _, pair := range strings.Split(value, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			continue
+		}
+
+		name, err := url.QueryUnescape(strings.TrimSpace(parts[0]))
+		if err != nil {
+			continue
+		}
+		headerValue, err := url.QueryUnescape(strings.TrimSpace(parts[1]))
+		if err != nil {
+			continue
+		}
+		headers[name] = headerValue
+	}
+
+	return headers
+}
+
+// parseTraceparent parses a W3C trace context, like
+// 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+func parseTraceparent(value string) (spanContext, bool) {
+	var sc spanContext
+
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return sc, false
+	}
+
+	if _, err := hex.Decode(sc.traceID[:], []byte(parts[1])); err != nil {
+		return sc, false
+	}
+	if _, err := hex.Decode(sc.spanID[:], []byte(parts[2])); err != nil {
+		return sc, false
+	}
+
+	return sc, sc.isValid()
+}
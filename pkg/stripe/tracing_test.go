@@ -0,0 +1,142 @@
+package stripe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type exportedSpan struct {
+	TraceID      string `json:"traceId"`
+	SpanID       string `json:"spanId"`
+	ParentSpanID string `json:"parentSpanId"`
+	Name         string `json:"name"`
+	Kind         int    `json:"kind"`
+	Attributes   []struct {
+		Key   string                 `json:"key"`
+		Value map[string]interface{} `json:"value"`
+	} `json:"attributes"`
+	Status *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"status"`
+}
+
+func (s exportedSpan) attribute(key string) interface{} {
+	for _, attr := range s.Attributes {
+		if attr.Key == key {
+			for _, value := range attr.Value {
+				return value
+			}
+		}
+	}
+
+	return nil
+}
+
+func TestTracing(t *testing.T) {
+	var exported []exportedSpan
+	var headers http.Header
+
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/traces", r.URL.Path)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		headers = r.Header
+
+		var body struct {
+			ResourceSpans []struct {
+				ScopeSpans []struct {
+					Spans []exportedSpan `json:"spans"`
+				} `json:"scopeSpans"`
+			} `json:"resourceSpans"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		for _, rs := range body.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				exported = append(exported, ss.Spans...)
+			}
+		}
+	}))
+	defer collector.Close()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Request-Id", "req_123")
+		w.WriteHeader(http.StatusPaymentRequired)
+	}))
+	defer api.Close()
+
+	os.Setenv(TracingEndpointEnv, collector.URL)
+	os.Setenv(otlpHeadersEnv, "x-api-key=secret%20key")
+	os.Setenv(traceparentEnv, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	defer os.Unsetenv(TracingEndpointEnv)
+	defer os.Unsetenv(otlpHeadersEnv)
+	defer os.Unsetenv(traceparentEnv)
+
+	require.NoError(t, EnableTracing())
+
+	ctx, root := StartSpan(context.Background(), "stripe", SpanKindInternal)
+	root.SetName("stripe customers create")
+
+	baseURL, _ := url.Parse(api.URL)
+	client := Client{BaseURL: baseURL, APIKey: "sk_test_123"}
+	resp, err := client.PerformRequest(ctx, http.MethodPost, "/v1/customers?expand[]=x", "", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	root.SetError(errors.New("failed"))
+	root.End()
+
+	ShutdownTracing(context.Background())
+
+	require.Equal(t, "secret key", headers.Get("x-api-key"))
+	require.Len(t, exported, 2)
+
+	request, command := exported[0], exported[1]
+
+	require.Equal(t, "stripe customers create", command.Name)
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", command.TraceID)
+	require.Equal(t, "00f067aa0ba902b7", command.ParentSpanID)
+	require.Equal(t, 2, command.Status.Code)
+	require.Equal(t, "failed", command.Status.Message)
+
+	require.Equal(t, "POST /v1/customers", request.Name)
+	require.Equal(t, int(SpanKindClient), request.Kind)
+	require.Equal(t, command.TraceID, request.TraceID)
+	require.Equal(t, command.SpanID, request.ParentSpanID)
+	require.Equal(t, "req_123", request.attribute("stripe.request_id"))
+	require.Equal(t, "402", request.attribute("http.status_code"))
+	require.Equal(t, api.URL+"/v1/customers", request.attribute("http.url"))
+	require.Equal(t, 2, request.Status.Code)
+
+	// Once tracing is shut down, spans aren't recorded
+	_, span := StartSpan(context.Background(), "stripe", SpanKindInternal)
+	require.Nil(t, span)
+	span.SetAttribute("key", "value")
+	span.End()
+}
+
+func TestEnableTracingInvalidEndpoint(t *testing.T) {
+	os.Setenv(TracingEndpointEnv, "localhost:4318")
+	defer os.Unsetenv(TracingEndpointEnv)
+
+	require.EqualError(t, EnableTracing(), "invalid STRIPE_CLI_OTLP_ENDPOINT ‘localhost:4318’, expected a URL like http://localhost:4318")
+}
+
+func TestParseTraceparent(t *testing.T) {
+	_, ok := parseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	require.True(t, ok)
+
+	_, ok = parseTraceparent("00-00000000000000000000000000000000-00f067aa0ba902b7-01")
+	require.False(t, ok)
+
+	_, ok = parseTraceparent("garbage")
+	require.False(t, ok)
+}
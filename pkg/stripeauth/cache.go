@@ -0,0 +1,192 @@
+package stripeauth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+
+	"github.com/stripe/stripe-cli/pkg/filelock"
+)
+
+// DefaultSessionCacheTTL is how long a released CLI session can be reused
+const DefaultSessionCacheTTL = 2 * time.Minute
+
+// SessionCache keeps the CLI sessions released by exiting processes in a file
+// for a short time, so restarting `stripe listen` can skip the authorization
+// round trip. The file is locked while it's updated, so processes sharing it
+// never take the same session.
+type SessionCache struct {
+	Fs   afero.Fs
+	Path string
+
+	// TTL defaults to DefaultSessionCacheTTL
+	TTL time.Duration
+	// Now returns the current time, and defaults to time.Now
+	Now func() time.Time
+
+	mu sync.Mutex
+}
+
+type sessionCacheEntry struct {
+	Expires time.Time         `json:"expires"`
+	Session *StripeCLISession `json:"session"`
+}
+
+// sessionCacheKey identifies the parameters of an authorization request. The
+// API key is hashed along with the rest so it isn't written to the cache.
+func sessionCacheKey(apiKey, apiBaseURL, deviceName, websocketFeature string, filters *string, devURLMap *DeviceURLMap) string {
+	parts := []string{apiKey, apiBaseURL, deviceName, websocketFeature}
+
+	if filters != nil {
+		parts = append(parts, *filters)
+	} else {
+		parts = append(parts, "")
+	}
+
+	if devURLMap != nil {
+		parts = append(parts, devURLMap.ForwardURL, devURLMap.ForwardConnectURL, devURLMap.ForwardThinURL, devURLMap.ForwardThinConnectURL, devURLMap.TunnelToURL)
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// Take removes the session cached for key from the cache and returns it, or
+// returns nil if there is none or it expired. Sessions are taken rather than
+// read so two processes never share a websocket.
+func (c *SessionCache) Take(key string) *StripeCLISession {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	unlock, err := c.lock()
+	if err != nil {
+		// Without the lock, another process could take the session too
+		return nil
+	}
+	defer unlock()
+
+	entries := c.read()
+
+	entry, ok := entries[key]
+	if !ok {
+		return nil
+	}
+
+	delete(entries, key)
+	if err := c.write(entries); err != nil {
+		// The session couldn't be removed, so another process could take it
+		// too
+		return nil
+	}
+
+	return entry.Session
+}
+
+// Put caches session under key.
+func (c *SessionCache) Put(key string, session *StripeCLISession) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	unlock, err := c.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	entries := c.read()
+	entries[key] = sessionCacheEntry{
+		Expires: c.now().Add(c.ttl()),
+		Session: session,
+	}
+
+	return c.write(entries)
+}
+
+// lock locks the cache file exclusively with a lock file next to it, waiting
+// for the processes holding it, and returns a function that releases it.
+// Caches that aren't on the OS filesystem are only locked by mu.
+func (c *SessionCache) lock() (func(), error) {
+	if _, ok := c.Fs.(*afero.OsFs); !ok {
+		return func() {}, nil
+	}
+
+	f, err := os.OpenFile(c.Path+".lock", os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := filelock.Lock(f, true); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return func() {
+		filelock.Unlock(f) // #nosec G104
+		f.Close()
+	}, nil
+}
+
+// read returns the entries that haven't expired. A missing or corrupted cache
+// is treated as empty.
+func (c *SessionCache) read() map[string]sessionCacheEntry {
+	entries := make(map[string]sessionCacheEntry)
+
+	data, err := afero.ReadFile(c.Fs, c.Path)
+	if err != nil {
+		return entries
+	}
+
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return make(map[string]sessionCacheEntry)
+	}
+
+	now := c.now()
+	for key, entry := range entries {
+		if !entry.Expires.After(now) {
+			delete(entries, key)
+		}
+	}
+
+	return entries
+}
+
+func (c *SessionCache) write(entries map[string]sessionCacheEntry) error {
+	if len(entries) == 0 {
+		err := c.Fs.Remove(c.Path)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	// The cache holds webhook signing secrets
+	return afero.WriteFile(c.Fs, c.Path, data, 0600)
+}
+
+func (c *SessionCache) ttl() time.Duration {
+	if c.TTL > 0 {
+		return c.TTL
+	}
+
+	return DefaultSessionCacheTTL
+}
+
+func (c *SessionCache) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+
+	return time.Now()
+}
@@ -0,0 +1,184 @@
+package stripeauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/filelock"
+)
+
+func TestSessionCacheTake(t *testing.T) {
+	now := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache := &SessionCache{
+		Fs:   afero.NewMemMapFs(),
+		Path: "/listen_sessions.json",
+		Now:  func() time.Time { return now },
+	}
+
+	require.Nil(t, cache.Take("key"))
+
+	require.NoError(t, cache.Put("key", &StripeCLISession{WebSocketID: "ws_123"}))
+
+	session := cache.Take("key")
+	require.NotNil(t, session)
+	require.Equal(t, "ws_123", session.WebSocketID)
+
+	// Sessions can only be taken once
+	require.Nil(t, cache.Take("key"))
+}
+
+func TestSessionCacheTakeWaitsForLock(t *testing.T) {
+	cache := &SessionCache{
+		Fs:   afero.NewOsFs(),
+		Path: filepath.Join(t.TempDir(), "listen_sessions.json"),
+	}
+	require.NoError(t, cache.Put("key", &StripeCLISession{WebSocketID: "ws_123"}))
+
+	// Another process updating the cache holds its lock
+	f, err := os.OpenFile(cache.Path+".lock", os.O_RDWR, 0600)
+	require.NoError(t, err)
+	defer f.Close()
+	require.NoError(t, filelock.Lock(f, true))
+
+	taken := make(chan *StripeCLISession)
+	go func() {
+		taken <- cache.Take("key")
+	}()
+
+	select {
+	case <-taken:
+		t.Fatal("the session was taken while the cache was locked")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	require.NoError(t, filelock.Unlock(f))
+
+	select {
+	case session := <-taken:
+		require.Equal(t, "ws_123", session.WebSocketID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the cache wasn't unlocked")
+	}
+}
+
+func TestSessionCacheExpires(t *testing.T) {
+	now := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache := &SessionCache{
+		Fs:   afero.NewMemMapFs(),
+		Path: "/listen_sessions.json",
+		Now:  func() time.Time { return now },
+	}
+
+	require.NoError(t, cache.Put("key", &StripeCLISession{WebSocketID: "ws_123"}))
+
+	now = now.Add(DefaultSessionCacheTTL)
+	require.Nil(t, cache.Take("key"))
+}
+
+func TestSessionCacheKey(t *testing.T) {
+	filters := `{"thin_events":["*"]}`
+
+	key := sessionCacheKey("sk_test_123", "https://api.stripe.com", "device", "webhooks", nil, nil)
+
+	require.Equal(t, key, sessionCacheKey("sk_test_123", "https://api.stripe.com", "device", "webhooks", nil, nil))
+	require.NotContains(t, key, "sk_test_123")
+	require.NotEqual(t, key, sessionCacheKey("sk_test_456", "https://api.stripe.com", "device", "webhooks", nil, nil))
+	require.NotEqual(t, key, sessionCacheKey("sk_test_123", "https://api.stripe.com", "device", "webhooks", &filters, nil))
+	require.NotEqual(t, key, sessionCacheKey("sk_test_123", "https://api.stripe.com", "device", "webhooks", nil, &DeviceURLMap{ForwardURL: "http://localhost:3000"}))
+}
+
+func TestAuthorizeReusesReleasedSession(t *testing.T) {
+	authorizations := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations++
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(StripeCLISession{WebSocketID: "ws_123", Secret: "whsec_123"})
+	}))
+	defer ts.Close()
+
+	client := NewClient("sk_test_123", &Config{
+		APIBaseURL: ts.URL,
+		SessionCache: &SessionCache{
+			Fs:   afero.NewMemMapFs(),
+			Path: "/listen_sessions.json",
+		},
+	})
+
+	session, err := client.Authorize(context.Background(), "my-device", "webhooks", nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, authorizations)
+
+	// The session is still in use, so it can't be reused yet
+	_, err = client.Authorize(context.Background(), "my-device", "webhooks", nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, authorizations)
+
+	client.ReleaseSession(session)
+
+	reused, err := client.Authorize(context.Background(), "my-device", "webhooks", nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, authorizations)
+	require.Equal(t, "ws_123", reused.WebSocketID)
+	require.Equal(t, "whsec_123", reused.Secret)
+
+	// Sessions for other parameters aren't reused
+	client.ReleaseSession(reused)
+
+	_, err = client.Authorize(context.Background(), "other-device", "webhooks", nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, authorizations)
+}
+
+func TestReleaseSessionSkipsTunnels(t *testing.T) {
+	cache := &SessionCache{
+		Fs:   afero.NewMemMapFs(),
+		Path: "/listen_sessions.json",
+	}
+	client := NewClient("sk_test_123", &Config{SessionCache: cache})
+
+	client.ReleaseSession(&StripeCLISession{WebSocketID: "ws_123", TunnelID: "tun_123", cacheKey: "key"})
+	require.Nil(t, cache.Take("key"))
+}
+
+func TestAuthorizeSkipsExpiringSession(t *testing.T) {
+	authorizations := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations++
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(StripeCLISession{
+			WebSocketID: "ws_123",
+			ExpiresAt:   time.Now().Add(time.Minute).Unix(),
+		})
+	}))
+	defer ts.Close()
+
+	client := NewClient("sk_test_123", &Config{
+		APIBaseURL: ts.URL,
+		SessionCache: &SessionCache{
+			Fs:   afero.NewMemMapFs(),
+			Path: "/listen_sessions.json",
+		},
+	})
+
+	session, err := client.Authorize(context.Background(), "my-device", "webhooks", nil, nil)
+	require.NoError(t, err)
+	client.ReleaseSession(session)
+
+	// The released session would have to be refreshed right away
+	_, err = client.Authorize(context.Background(), "my-device", "webhooks", nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, authorizations)
+}
@@ -7,14 +7,18 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/stripe/stripe-cli/pkg/netproxy"
 	"github.com/stripe/stripe-cli/pkg/stripe"
 )
 
 const stripeCLISessionPath = "/v1/stripecli/sessions"
 
+const stripeCLITunnelsPath = "/v1/stripecli/tunnels/"
+
 //
 // Public types
 //
@@ -26,6 +30,12 @@ type Config struct {
 	HTTPClient *http.Client
 
 	APIBaseURL string
+
+	// Proxy server the request is sent through, if any
+	Proxy *netproxy.Dialer
+
+	// SessionCache, if set, provides sessions released by previous runs
+	SessionCache *SessionCache
 }
 
 // Client is the client used to initiate new CLI sessions with Stripe.
@@ -39,8 +49,17 @@ type Client struct {
 // DeviceURLMap is a mapping of the urls that the device is listening
 // for forwarded events on.
 type DeviceURLMap struct {
-	ForwardURL        string
-	ForwardConnectURL string
+	ForwardURL            string
+	ForwardConnectURL     string
+	ForwardThinURL        string
+	ForwardThinConnectURL string
+
+	// TunnelToURL is the local URL a public tunnel is requested for, if any
+	TunnelToURL string
+
+	// TunnelID is the tunnel of the session being renewed, if any. The new
+	// session keeps it, so that its public URL doesn't change.
+	TunnelID string
 }
 
 // Authorize sends a request to Stripe to initiate a new CLI session.
@@ -49,6 +68,22 @@ func (c *Client) Authorize(ctx context.Context, deviceName string, websocketFeat
 		"prefix": "stripeauth.client.Authorize",
 	}).Debug("Authenticating with Stripe...")
 
+	cacheKey := sessionCacheKey(c.apiKey, c.cfg.APIBaseURL, deviceName, websocketFeature, filters, devURLMap)
+
+	if c.cfg.SessionCache != nil {
+		// Sessions about to expire would have to be refreshed right away
+		if session := c.cfg.SessionCache.Take(cacheKey); session != nil && !session.expiresSoon(time.Now()) {
+			c.cfg.Log.WithFields(log.Fields{
+				"prefix":       "stripeauth.client.Authorize",
+				"websocket_id": session.WebSocketID,
+			}).Debug("Reusing cached session")
+
+			session.cacheKey = cacheKey
+
+			return session, nil
+		}
+	}
+
 	parsedBaseURL, err := url.Parse(c.cfg.APIBaseURL)
 	if err != nil {
 		return nil, err
@@ -70,9 +105,26 @@ func (c *Client) Authorize(ctx context.Context, deviceName string, websocketFeat
 		form.Add("forward_connect_to_url", devURLMap.ForwardConnectURL)
 	}
 
+	if devURLMap != nil && len(devURLMap.ForwardThinURL) > 0 {
+		form.Add("forward_thin_to_url", devURLMap.ForwardThinURL)
+	}
+
+	if devURLMap != nil && len(devURLMap.ForwardThinConnectURL) > 0 {
+		form.Add("forward_thin_connect_to_url", devURLMap.ForwardThinConnectURL)
+	}
+
+	if devURLMap != nil && len(devURLMap.TunnelToURL) > 0 {
+		form.Add("tunnel_to_url", devURLMap.TunnelToURL)
+
+		if len(devURLMap.TunnelID) > 0 {
+			form.Add("tunnel_id", devURLMap.TunnelID)
+		}
+	}
+
 	client := &stripe.Client{
 		BaseURL: parsedBaseURL,
 		APIKey:  c.apiKey,
+		Proxy:   c.cfg.Proxy,
 	}
 
 	resp, err := client.PerformRequest(ctx, http.MethodPost, stripeCLISessionPath, form.Encode(), nil)
@@ -87,9 +139,12 @@ func (c *Client) Authorize(ctx context.Context, deviceName string, websocketFeat
 		return nil, err
 	}
 
+	if resp.StatusCode == http.StatusForbidden {
+		return nil, FeatureNotAuthorizedError{Feature: websocketFeature}
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		err := fmt.Errorf("Authorization failed, status=%d, body=%s", resp.StatusCode, body)
-		return nil, err
+		return nil, AuthorizationError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	var session *StripeCLISession
@@ -106,11 +161,64 @@ func (c *Client) Authorize(ctx context.Context, deviceName string, websocketFeat
 		"display_connect_filter_warning": session.DisplayConnectFilterWarning,
 		"default_version":                session.DefaultVersion,
 		"latest_version":                 session.LatestVersion,
+		"tunnel_url":                     session.TunnelURL,
+		"expires_at":                     session.ExpiresAt,
 	}).Debug("Got successful response from Stripe")
 
+	session.cacheKey = cacheKey
+
 	return session, nil
 }
 
+// ReleaseSession hands a session that is no longer used to the session cache,
+// so the next run with the same parameters can reuse it. Sessions with a
+// tunnel aren't cached since the tunnel is closed on exit.
+func (c *Client) ReleaseSession(session *StripeCLISession) {
+	if c.cfg.SessionCache == nil || session == nil || session.cacheKey == "" || session.TunnelID != "" {
+		return
+	}
+
+	if err := c.cfg.SessionCache.Put(session.cacheKey, session); err != nil {
+		c.cfg.Log.WithFields(log.Fields{
+			"prefix": "stripeauth.client.ReleaseSession",
+		}).Debugf("Could not cache session: %v", err)
+	}
+}
+
+// CloseTunnel sends a request to Stripe to tear down the public tunnel of a
+// CLI session.
+func (c *Client) CloseTunnel(ctx context.Context, tunnelID string) error {
+	c.cfg.Log.WithFields(log.Fields{
+		"prefix":    "stripeauth.client.CloseTunnel",
+		"tunnel_id": tunnelID,
+	}).Debug("Closing tunnel...")
+
+	parsedBaseURL, err := url.Parse(c.cfg.APIBaseURL)
+	if err != nil {
+		return err
+	}
+
+	client := &stripe.Client{
+		BaseURL: parsedBaseURL,
+		APIKey:  c.apiKey,
+		Proxy:   c.cfg.Proxy,
+	}
+
+	resp, err := client.PerformRequest(ctx, http.MethodDelete, stripeCLITunnelsPath+url.PathEscape(tunnelID), "", nil)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("Closing the tunnel failed, status=%d, body=%s", resp.StatusCode, body)
+	}
+
+	return nil
+}
+
 // NewClient returns a new Client.
 func NewClient(key string, cfg *Config) *Client {
 	if cfg == nil {
@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -48,6 +49,21 @@ func TestAuthorize(t *testing.T) {
 	require.Equal(t, "2020-08-27", session.LatestVersion)
 }
 
+func TestAuthorizeFeatureNotAuthorized(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer ts.Close()
+
+	client := NewClient("rk_test_123", &Config{
+		APIBaseURL: ts.URL,
+	})
+	session, err := client.Authorize(context.Background(), "my-device", FeatureRequestLogs, nil, nil)
+	require.Nil(t, session)
+	require.Equal(t, FeatureNotAuthorizedError{Feature: FeatureRequestLogs}, err)
+	require.Contains(t, err.Error(), "stripe logs tail")
+}
+
 func TestUserAgent(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -107,3 +123,109 @@ func TestAuthorizeWithURLDeviceMap(t *testing.T) {
 
 	client.Authorize(context.Background(), "my-device", "webhooks", nil, &devURLMap)
 }
+
+func TestAuthorizeWithThinURLDeviceMap(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+
+		require.Equal(t, "http://localhost:3000/thin", r.FormValue("forward_thin_to_url"))
+		require.Equal(t, "http://localhost:3000/connect/thin", r.FormValue("forward_thin_connect_to_url"))
+		require.Equal(t, `{"thin_events":["*"]}`, r.FormValue("filters"))
+	}))
+	defer ts.Close()
+
+	client := NewClient("sk_test_123", &Config{
+		APIBaseURL: ts.URL,
+	})
+
+	filters := `{"thin_events":["*"]}`
+	devURLMap := DeviceURLMap{
+		ForwardThinURL:        "http://localhost:3000/thin",
+		ForwardThinConnectURL: "http://localhost:3000/connect/thin",
+	}
+
+	client.Authorize(context.Background(), "my-device", "webhooks", &filters, &devURLMap)
+}
+
+func TestAuthorizeWithTunnel(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "http://localhost:3000", r.FormValue("tunnel_to_url"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(StripeCLISession{
+			TunnelID:  "tun_123",
+			TunnelURL: "https://tun-123.example.com",
+		})
+	}))
+	defer ts.Close()
+
+	client := NewClient("sk_test_123", &Config{
+		APIBaseURL: ts.URL,
+	})
+
+	session, err := client.Authorize(context.Background(), "my-device", "webhooks", nil, &DeviceURLMap{
+		TunnelToURL: "http://localhost:3000",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "tun_123", session.TunnelID)
+	require.Equal(t, "https://tun-123.example.com", session.TunnelURL)
+}
+
+func TestAuthorizeKeepsTunnel(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "http://localhost:3000", r.FormValue("tunnel_to_url"))
+		require.Equal(t, "tun_123", r.FormValue("tunnel_id"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(StripeCLISession{
+			TunnelID:  "tun_123",
+			TunnelURL: "https://tun-123.example.com",
+		})
+	}))
+	defer ts.Close()
+
+	client := NewClient("sk_test_123", &Config{
+		APIBaseURL: ts.URL,
+	})
+
+	session, err := client.Authorize(context.Background(), "my-device", "webhooks", nil, &DeviceURLMap{
+		TunnelToURL: "http://localhost:3000",
+		TunnelID:    "tun_123",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "https://tun-123.example.com", session.TunnelURL)
+}
+
+func TestCloseTunnel(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodDelete, r.Method)
+		require.Equal(t, "/v1/stripecli/tunnels/tun_123", r.URL.Path)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := NewClient("sk_test_123", &Config{
+		APIBaseURL: ts.URL,
+	})
+
+	err := client.CloseTunnel(context.Background(), "tun_123")
+	require.NoError(t, err)
+}
+
+func TestSessionRefreshIn(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	_, ok := (&StripeCLISession{}).RefreshIn(now)
+	require.False(t, ok)
+
+	refreshIn, ok := (&StripeCLISession{ExpiresAt: now.Add(time.Hour).Unix()}).RefreshIn(now)
+	require.True(t, ok)
+	require.Equal(t, time.Hour-SessionRefreshMargin, refreshIn)
+
+	refreshIn, ok = (&StripeCLISession{ExpiresAt: now.Add(time.Minute).Unix()}).RefreshIn(now)
+	require.True(t, ok)
+	require.Zero(t, refreshIn)
+}
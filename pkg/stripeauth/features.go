@@ -0,0 +1,49 @@
+package stripeauth
+
+import "fmt"
+
+const (
+	// FeatureWebhooks is the scope of the sessions receiving webhook events,
+	// like the sessions of `stripe listen`
+	FeatureWebhooks = "webhooks"
+
+	// FeatureRequestLogs is the scope of the sessions of `stripe logs tail`,
+	// receiving request logs
+	FeatureRequestLogs = "request_logs"
+)
+
+// Features are the scopes sessions can be authorized for
+var Features = []string{FeatureWebhooks, FeatureRequestLogs}
+
+// featureCommands are the commands that need each feature
+var featureCommands = map[string]string{
+	FeatureWebhooks:    "stripe listen",
+	FeatureRequestLogs: "stripe logs tail",
+}
+
+// AuthorizationError is the error returned when Stripe refuses to create a
+// session, like when the API key is invalid
+type AuthorizationError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e AuthorizationError) Error() string {
+	return fmt.Sprintf("Authorization failed, status=%d, body=%s", e.StatusCode, e.Body)
+}
+
+// FeatureNotAuthorizedError is the error returned when the API key isn't
+// allowed the feature a session is requested for, like a restricted key
+// without that permission
+type FeatureNotAuthorizedError struct {
+	Feature string
+}
+
+func (e FeatureNotAuthorizedError) Error() string {
+	command := featureCommands[e.Feature]
+	if command == "" {
+		command = "this command"
+	}
+
+	return fmt.Sprintf("the API key is not authorized for the %s scope that %s needs, use a secret key or a restricted key with this permission", e.Feature, command)
+}
@@ -1,5 +1,11 @@
 package stripeauth
 
+import "time"
+
+// SessionRefreshMargin is how long before a session expires it's replaced
+// by a new one
+const SessionRefreshMargin = 10 * time.Minute
+
 // StripeCLISession is the API resource returned by Stripe when initiating
 // a new CLI session.
 type StripeCLISession struct {
@@ -11,4 +17,35 @@ type StripeCLISession struct {
 	WebSocketURL                string `json:"websocket_url"`
 	DefaultVersion              string `json:"default_version"`
 	LatestVersion               string `json:"latest_version"`
+	TunnelID                    string `json:"tunnel_id"`
+	TunnelURL                   string `json:"tunnel_url"`
+
+	// ExpiresAt is the Unix time the secret and websocket URL of the
+	// session expire, if they do
+	ExpiresAt int64 `json:"expires_at,omitempty"`
+
+	// cacheKey identifies the authorization request the session was created
+	// for, to release it to the session cache
+	cacheKey string
+}
+
+// RefreshIn returns how long until the session should be replaced by a new
+// one, SessionRefreshMargin before it expires, or false if it doesn't expire
+func (s *StripeCLISession) RefreshIn(now time.Time) (time.Duration, bool) {
+	if s.ExpiresAt == 0 {
+		return 0, false
+	}
+
+	refreshIn := time.Unix(s.ExpiresAt, 0).Sub(now) - SessionRefreshMargin
+	if refreshIn < 0 {
+		refreshIn = 0
+	}
+
+	return refreshIn, true
+}
+
+// expiresSoon returns whether the session is due to be refreshed
+func (s *StripeCLISession) expiresSoon(now time.Time) bool {
+	refreshIn, ok := s.RefreshIn(now)
+	return ok && refreshIn == 0
 }
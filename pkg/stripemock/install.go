@@ -0,0 +1,93 @@
+package stripemock
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/stripe/stripe-cli/pkg/download"
+)
+
+// releasesURL is where the releases of stripe-mock are downloaded from
+var releasesURL = "https://github.com/stripe/stripe-mock/releases/download"
+
+// downloadTimeout is how long downloading a release may take
+const downloadTimeout = 5 * time.Minute
+
+// maxDownloadSize caps the size of the downloaded assets of a release
+const maxDownloadSize = 200 * 1024 * 1024
+
+// binaryPath returns where the binary of Version is installed in dir
+func binaryPath(dir string) string {
+	binary := "stripe-mock"
+	if runtime.GOOS == "windows" {
+		binary += ".exe"
+	}
+
+	return filepath.Join(dir, Version, binary)
+}
+
+// archiveName returns the name of the release archive for the platform
+func archiveName() string {
+	ext := "tar.gz"
+	if runtime.GOOS == "windows" {
+		ext = "zip"
+	}
+
+	return fmt.Sprintf("stripe-mock_%s_%s_%s.%s", Version, runtime.GOOS, runtime.GOARCH, ext)
+}
+
+// install downloads the release of Version to dir, unless it's already
+// installed, verifying it against the checksums of the release. It returns
+// the path of the binary.
+func install(ctx context.Context, dir string) (string, error) {
+	binary := binaryPath(dir)
+	if _, err := os.Stat(binary); err == nil {
+		return binary, nil
+	}
+
+	name := archiveName()
+	baseURL := fmt.Sprintf("%s/v%s/", releasesURL, Version)
+
+	checksums, err := download.File(ctx, baseURL+fmt.Sprintf("stripe-mock_%s_checksums.txt", Version), downloadTimeout, maxDownloadSize)
+	if err != nil {
+		return "", err
+	}
+
+	expected, ok := download.FindChecksum(checksums, name)
+	if !ok {
+		return "", fmt.Errorf("stripe-mock %s has no release for %s/%s", Version, runtime.GOOS, runtime.GOARCH)
+	}
+
+	archive, err := download.File(ctx, baseURL+name, downloadTimeout, maxDownloadSize)
+	if err != nil {
+		return "", err
+	}
+
+	if !download.ChecksumMatches(archive, expected) {
+		return "", fmt.Errorf("the checksum of %s doesn't match the checksums of the release", name)
+	}
+
+	data, err := download.ReadArchiveFile(archive, name, filepath.Base(binary))
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(binary), 0755); err != nil {
+		return "", err
+	}
+
+	if err := ioutil.WriteFile(binary, data, 0755); err != nil { // #nosec G306
+		return "", err
+	}
+
+	if err := os.Chmod(binary, 0755); err != nil { // #nosec G302
+		return "", err
+	}
+
+	return binary, nil
+}
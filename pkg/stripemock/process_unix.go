@@ -0,0 +1,21 @@
+//go:build !windows
+// +build !windows
+
+package stripemock
+
+import (
+	"os"
+	"syscall"
+
+	exec "golang.org/x/sys/execabs"
+)
+
+// detach runs cmd in its own session, so it keeps running after the CLI
+// exits and doesn't get the signals of the terminal
+func detach(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+}
+
+func terminate(process *os.Process) error {
+	return process.Signal(syscall.SIGTERM)
+}
@@ -0,0 +1,21 @@
+//go:build windows
+// +build windows
+
+package stripemock
+
+import (
+	"os"
+	"syscall"
+
+	exec "golang.org/x/sys/execabs"
+)
+
+// detach runs cmd in a new process group, so it keeps running after the CLI
+// exits and doesn't get the Ctrl+C of the console
+func detach(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
+
+func terminate(process *os.Process) error {
+	return process.Kill()
+}
@@ -0,0 +1,233 @@
+// Package stripemock manages a stripe-mock server running in the background,
+// which API requests can be sent to for offline development and
+// deterministic CI runs.
+package stripemock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	exec "golang.org/x/sys/execabs"
+)
+
+// Version is the version of stripe-mock that is downloaded and run
+const Version = "0.144.0"
+
+// DefaultPort is the port stripe-mock serves HTTP on by default
+const DefaultPort = 12111
+
+// APIKey is the key requests are sent to stripe-mock with when no key is
+// configured. stripe-mock accepts any test mode key.
+const APIKey = "sk_test_stripe_mock"
+
+// containerName is the name of the container stripe-mock runs in with Docker
+const containerName = "stripe-cli-mock"
+
+// startTimeout is how long stripe-mock may take to accept connections
+const startTimeout = 30 * time.Second
+
+const stateFile = "state.json"
+
+var execCommand = exec.Command
+
+// ErrNotRunning is returned when stripe-mock isn't running
+var ErrNotRunning = errors.New("stripe-mock isn't running, start it with `stripe mock start`")
+
+// State describes a running stripe-mock server
+type State struct {
+	Version string `json:"version"`
+	Port    int    `json:"port"`
+	// PID is the process of stripe-mock when it runs as a binary
+	PID int `json:"pid,omitempty"`
+	// Container is the container of stripe-mock when it runs with Docker
+	Container string    `json:"container,omitempty"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// URL returns the base URL of the server
+func (s *State) URL() string {
+	return fmt.Sprintf("http://localhost:%d", s.Port)
+}
+
+// StartOptions configure how stripe-mock is started
+type StartOptions struct {
+	// Port is the port to serve HTTP on, DefaultPort if it's 0
+	Port int
+	// Docker runs the stripe/stripe-mock image instead of a binary
+	Docker bool
+}
+
+// Start starts stripe-mock in the background, downloading it to dir first if
+// needed, and waits until it accepts connections. dir also keeps the state
+// of the server, to stop it later.
+func Start(ctx context.Context, dir string, opts StartOptions) (*State, error) {
+	if state, err := Status(dir); err == nil {
+		return nil, fmt.Errorf("stripe-mock is already running at %s", state.URL())
+	}
+
+	port := opts.Port
+	if port == 0 {
+		port = DefaultPort
+	}
+
+	state := &State{
+		Version:   Version,
+		Port:      port,
+		StartedAt: time.Now(),
+	}
+
+	if opts.Docker {
+		out, err := execCommand("docker", "run", "--detach", "--rm",
+			"--name", containerName,
+			"--publish", fmt.Sprintf("%d:%d", port, DefaultPort),
+			"stripe/stripe-mock:v"+Version,
+		).CombinedOutput()
+		if err != nil {
+			return nil, fmt.Errorf("could not run the stripe-mock container: %s", out)
+		}
+
+		state.Container = containerName
+	} else {
+		binary, err := install(ctx, dir)
+		if err != nil {
+			return nil, err
+		}
+
+		logFile, err := os.OpenFile(filepath.Join(dir, "stripe-mock.log"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, err
+		}
+		defer logFile.Close()
+
+		cmd := execCommand(binary, "-http-port", strconv.Itoa(port)) // #nosec G204
+		cmd.Stdout = logFile
+		cmd.Stderr = logFile
+		detach(cmd)
+
+		if err := cmd.Start(); err != nil {
+			return nil, err
+		}
+
+		state.PID = cmd.Process.Pid
+		cmd.Process.Release() // #nosec G104
+	}
+
+	if err := writeState(dir, state); err != nil {
+		stop(state) // #nosec G104
+		return nil, err
+	}
+
+	deadline := time.Now().Add(startTimeout)
+	for !listening(state.Port) {
+		if time.Now().After(deadline) {
+			Stop(dir) // #nosec G104
+			return nil, fmt.Errorf("stripe-mock didn't accept connections on port %d within %s, see %s", port, startTimeout, filepath.Join(dir, "stripe-mock.log"))
+		}
+
+		select {
+		case <-ctx.Done():
+			Stop(dir) // #nosec G104
+			return nil, ctx.Err()
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+
+	return state, nil
+}
+
+// Stop stops the stripe-mock server started with Start
+func Stop(dir string) error {
+	state, err := readState(dir)
+	if err != nil {
+		return err
+	}
+
+	if err := stop(state); err != nil && listening(state.Port) {
+		return err
+	}
+
+	return os.Remove(filepath.Join(dir, stateFile))
+}
+
+// Status returns the state of the stripe-mock server started with Start, or
+// ErrNotRunning if it's not running
+func Status(dir string) (*State, error) {
+	state, err := readState(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if !listening(state.Port) {
+		return nil, ErrNotRunning
+	}
+
+	return state, nil
+}
+
+func stop(state *State) error {
+	if state.Container != "" {
+		out, err := execCommand("docker", "stop", state.Container).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("could not stop the stripe-mock container: %s", out)
+		}
+
+		return nil
+	}
+
+	process, err := os.FindProcess(state.PID)
+	if err != nil {
+		return err
+	}
+
+	return terminate(process)
+}
+
+// listening returns whether a server accepts connections on port
+func listening(port int) bool {
+	conn, err := net.DialTimeout("tcp", fmt.Sprintf("localhost:%d", port), time.Second)
+	if err != nil {
+		return false
+	}
+
+	conn.Close()
+
+	return true
+}
+
+func readState(dir string) (*State, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, stateFile))
+	if os.IsNotExist(err) {
+		return nil, ErrNotRunning
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
+
+func writeState(dir string, state *State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(dir, stateFile), data, 0600)
+}
@@ -0,0 +1,103 @@
+package stripemock
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/download/downloadtest"
+)
+
+func TestInstall(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("releases are .zip archives on Windows")
+	}
+
+	archive := downloadtest.TarGz(t, map[string]string{"stripe-mock": "binary"})
+	sum := sha256.Sum256(archive)
+	checksums := fmt.Sprintf("0000  stripe-mock_%s_plan9_386.tar.gz\n%s  %s\n", Version, hex.EncodeToString(sum[:]), archiveName())
+
+	downloads := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads++
+		switch r.URL.Path {
+		case fmt.Sprintf("/v%s/stripe-mock_%s_checksums.txt", Version, Version):
+			w.Write([]byte(checksums)) // #nosec G104
+		case fmt.Sprintf("/v%s/%s", Version, archiveName()):
+			w.Write(archive) // #nosec G104
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	defer func(url string) { releasesURL = url }(releasesURL)
+	releasesURL = ts.URL
+
+	dir := t.TempDir()
+
+	binary, err := install(context.Background(), dir)
+	require.NoError(t, err)
+	require.Equal(t, binaryPath(dir), binary)
+
+	content, err := ioutil.ReadFile(binary)
+	require.NoError(t, err)
+	require.Equal(t, "binary", string(content))
+
+	// The installed binary is reused
+	_, err = install(context.Background(), dir)
+	require.NoError(t, err)
+	require.Equal(t, 2, downloads)
+}
+
+func TestInstallChecksumMismatch(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == fmt.Sprintf("/v%s/stripe-mock_%s_checksums.txt", Version, Version) {
+			fmt.Fprintf(w, "%064d  %s\n", 0, archiveName())
+			return
+		}
+		w.Write([]byte("tampered")) // #nosec G104
+	}))
+	defer ts.Close()
+
+	defer func(url string) { releasesURL = url }(releasesURL)
+	releasesURL = ts.URL
+
+	_, err := install(context.Background(), t.TempDir())
+	require.EqualError(t, err, fmt.Sprintf("the checksum of %s doesn't match the checksums of the release", archiveName()))
+}
+
+func TestStatus(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := Status(dir)
+	require.Equal(t, ErrNotRunning, err)
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	port := lis.Addr().(*net.TCPAddr).Port
+	require.NoError(t, writeState(dir, &State{Version: Version, Port: port, PID: 1, StartedAt: time.Now()}))
+
+	state, err := Status(dir)
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("http://localhost:%d", port), state.URL())
+
+	_, err = Start(context.Background(), dir, StartOptions{Port: port})
+	require.EqualError(t, err, "stripe-mock is already running at "+state.URL())
+
+	lis.Close()
+
+	_, err = Status(dir)
+	require.Equal(t, ErrNotRunning, err)
+}
@@ -0,0 +1,255 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/requests"
+)
+
+// Payment method types a simulated reader can be presented
+const (
+	PresentCard    = "card"
+	PresentInterac = "interac"
+)
+
+// simulatedRegistrationCode registers a simulated reader instead of a
+// physical one
+const simulatedRegistrationCode = "simulated-wpe"
+
+// actionPollInterval is how often the action of a reader is checked
+var actionPollInterval = time.Second
+
+// actionPollTimeout is how long the action of a reader may take to complete
+var actionPollTimeout = 30 * time.Second
+
+// cardScenarios are the test card numbers that simulate the outcomes of
+// presenting a card to a reader
+var cardScenarios = map[string]string{
+	"success":            "4242424242424242",
+	"declined":           "4000000000000002",
+	"insufficient_funds": "4000000000009995",
+	"lost_card":          "4000000000009987",
+	"stolen_card":        "4000000000009979",
+	"expired_card":       "4000000000000069",
+}
+
+// interacScenarios are the test Interac numbers that simulate the outcomes
+// of presenting an Interac card to a reader
+var interacScenarios = map[string]string{
+	"success": "4506445006931933",
+}
+
+// Scenarios returns the scenarios a payment method type can be presented
+// with, sorted
+func Scenarios(paymentMethodType string) []string {
+	scenarios := cardScenarios
+	if paymentMethodType == PresentInterac {
+		scenarios = interacScenarios
+	}
+
+	var names []string
+	for name := range scenarios {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// SimulatedReader sends the requests of simulated readers to the API
+type SimulatedReader struct {
+	BaseURL string
+	APIKey  string
+	Profile *config.Profile
+}
+
+// PaymentResult is the outcome of a payment processed by a simulated reader
+type PaymentResult struct {
+	PaymentIntent string
+	Status        string
+	// FailureCode and FailureMessage explain why the reader failed to
+	// collect the payment, for the failure scenarios
+	FailureCode    string
+	FailureMessage string
+}
+
+// Create registers a simulated reader at location. A location is created
+// when it's empty. It returns the ID of the reader.
+func (s *SimulatedReader) Create(ctx context.Context, location, label string) (string, error) {
+	if location == "" {
+		created, err := requests.Do(ctx, http.MethodPost, s.BaseURL, s.APIKey, "/v1/terminal/locations", []string{
+			"display_name=Simulated readers (created by Stripe CLI)",
+			"address[line1]=510 Townsend St",
+			"address[city]=San Francisco",
+			"address[state]=CA",
+			"address[postal_code]=94103",
+			"address[country]=US",
+		}, s.Profile)
+		if err != nil {
+			return "", err
+		}
+
+		location = created.Get("id").String()
+	}
+
+	data := []string{
+		"registration_code=" + simulatedRegistrationCode,
+		"location=" + location,
+	}
+	if label != "" {
+		data = append(data, "label="+label)
+	}
+
+	reader, err := requests.Do(ctx, http.MethodPost, s.BaseURL, s.APIKey, "/v1/terminal/readers", data, s.Profile)
+	if err != nil {
+		return "", err
+	}
+
+	return reader.Get("id").String(), nil
+}
+
+// Present presents a test payment method to the reader, completing the
+// payment intent it is processing. number overrides the test number of the
+// scenario.
+func (s *SimulatedReader) Present(ctx context.Context, reader, paymentMethodType, scenario, number string) error {
+	if number == "" {
+		var err error
+		number, err = scenarioNumber(paymentMethodType, scenario)
+		if err != nil {
+			return err
+		}
+	}
+
+	present := paymentMethodType + "_present"
+
+	_, err := requests.Do(ctx, http.MethodPost, s.BaseURL, s.APIKey, "/v1/test_helpers/terminal/readers/"+reader+"/present_payment_method", []string{
+		"type=" + present,
+		present + "[number]=" + number,
+	}, s.Profile)
+
+	return err
+}
+
+// Pay processes a payment of amount on the reader end to end: it creates a
+// payment intent, hands it to the reader, presents a test payment method,
+// and captures the payment once the reader collected it.
+func (s *SimulatedReader) Pay(ctx context.Context, reader string, amount int64, currency, paymentMethodType, scenario, number string) (*PaymentResult, error) {
+	if number == "" {
+		if _, err := scenarioNumber(paymentMethodType, scenario); err != nil {
+			return nil, err
+		}
+	}
+
+	data := []string{
+		fmt.Sprintf("amount=%d", amount),
+		"currency=" + currency,
+		"payment_method_types[]=" + paymentMethodType + "_present",
+		"description=(created by Stripe CLI)",
+	}
+	// Interac payments are captured automatically
+	if paymentMethodType == PresentCard {
+		data = append(data, "capture_method=manual")
+	}
+
+	intent, err := requests.Do(ctx, http.MethodPost, s.BaseURL, s.APIKey, "/v1/payment_intents", data, s.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	id := intent.Get("id").String()
+
+	_, err = requests.Do(ctx, http.MethodPost, s.BaseURL, s.APIKey, "/v1/terminal/readers/"+reader+"/process_payment_intent", []string{
+		"payment_intent=" + id,
+	}, s.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Present(ctx, reader, paymentMethodType, scenario, number); err != nil {
+		return nil, err
+	}
+
+	action, err := s.waitForAction(ctx, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PaymentResult{PaymentIntent: id}
+
+	if action.Get("status").String() == "failed" {
+		result.FailureCode = action.Get("failure_code").String()
+		result.FailureMessage = action.Get("failure_message").String()
+	}
+
+	intent, err = requests.Do(ctx, http.MethodGet, s.BaseURL, s.APIKey, "/v1/payment_intents/"+id, nil, s.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	if intent.Get("status").String() == "requires_capture" {
+		intent, err = requests.Do(ctx, http.MethodPost, s.BaseURL, s.APIKey, "/v1/payment_intents/"+id+"/capture", nil, s.Profile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result.Status = intent.Get("status").String()
+
+	return result, nil
+}
+
+// waitForAction waits for the action of the reader to complete, and returns
+// it
+func (s *SimulatedReader) waitForAction(ctx context.Context, reader string) (gjson.Result, error) {
+	deadline := time.Now().Add(actionPollTimeout)
+
+	for {
+		r, err := requests.Do(ctx, http.MethodGet, s.BaseURL, s.APIKey, "/v1/terminal/readers/"+reader, nil, s.Profile)
+		if err != nil {
+			return gjson.Result{}, err
+		}
+
+		action := r.Get("action")
+		if action.Get("status").String() != "in_progress" {
+			return action, nil
+		}
+
+		if time.Now().After(deadline) {
+			return gjson.Result{}, fmt.Errorf("the action of %s is still in progress after %s", reader, actionPollTimeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			return gjson.Result{}, ctx.Err()
+		case <-time.After(actionPollInterval):
+		}
+	}
+}
+
+// scenarioNumber returns the test number simulating a scenario
+func scenarioNumber(paymentMethodType, scenario string) (string, error) {
+	var scenarios map[string]string
+
+	switch paymentMethodType {
+	case PresentCard:
+		scenarios = cardScenarios
+	case PresentInterac:
+		scenarios = interacScenarios
+	default:
+		return "", fmt.Errorf("invalid payment method type ‘%s’, expected %s or %s", paymentMethodType, PresentCard, PresentInterac)
+	}
+
+	number, ok := scenarios[scenario]
+	if !ok {
+		return "", fmt.Errorf("unknown %s scenario ‘%s’, expected one of %v, or pass --number", paymentMethodType, scenario, Scenarios(paymentMethodType))
+	}
+
+	return number, nil
+}
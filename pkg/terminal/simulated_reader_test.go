@@ -0,0 +1,98 @@
+package terminal
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newSimulatedReaderServer(t *testing.T) *httptest.Server {
+	actionPollInterval = time.Millisecond
+
+	number := ""
+	polls := 0
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		params, err := url.ParseQuery(string(body))
+		require.NoError(t, err)
+
+		declined := number == cardScenarios["declined"]
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/payment_intents":
+			require.Equal(t, "card_present", params.Get("payment_method_types[]"))
+			require.Equal(t, "manual", params.Get("capture_method"))
+			w.Write([]byte(`{"id": "pi_123", "status": "requires_payment_method"}`))
+		case "POST /v1/terminal/readers/tmr_123/process_payment_intent":
+			require.Equal(t, "pi_123", params.Get("payment_intent"))
+			w.Write([]byte(`{"id": "tmr_123", "action": {"status": "in_progress"}}`))
+		case "POST /v1/test_helpers/terminal/readers/tmr_123/present_payment_method":
+			require.Equal(t, "card_present", params.Get("type"))
+			number = params.Get("card_present[number]")
+			w.Write([]byte(`{"id": "tmr_123", "action": {"status": "in_progress"}}`))
+		case "GET /v1/terminal/readers/tmr_123":
+			polls++
+			switch {
+			case polls < 2:
+				w.Write([]byte(`{"id": "tmr_123", "action": {"status": "in_progress"}}`))
+			case declined:
+				w.Write([]byte(`{"id": "tmr_123", "action": {"status": "failed", "failure_code": "card_declined", "failure_message": "Your card was declined."}}`))
+			default:
+				w.Write([]byte(`{"id": "tmr_123", "action": {"status": "succeeded"}}`))
+			}
+		case "GET /v1/payment_intents/pi_123":
+			if declined {
+				w.Write([]byte(`{"id": "pi_123", "status": "requires_payment_method"}`))
+			} else {
+				w.Write([]byte(`{"id": "pi_123", "status": "requires_capture"}`))
+			}
+		case "POST /v1/payment_intents/pi_123/capture":
+			w.Write([]byte(`{"id": "pi_123", "status": "succeeded"}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+}
+
+func TestSimulatedReaderPay(t *testing.T) {
+	ts := newSimulatedReaderServer(t)
+	defer ts.Close()
+
+	reader := &SimulatedReader{BaseURL: ts.URL, APIKey: "sk_test_123"}
+
+	result, err := reader.Pay(context.Background(), "tmr_123", 1000, "usd", PresentCard, "success", "")
+	require.NoError(t, err)
+	require.Equal(t, &PaymentResult{PaymentIntent: "pi_123", Status: "succeeded"}, result)
+}
+
+func TestSimulatedReaderPayDeclined(t *testing.T) {
+	ts := newSimulatedReaderServer(t)
+	defer ts.Close()
+
+	reader := &SimulatedReader{BaseURL: ts.URL, APIKey: "sk_test_123"}
+
+	result, err := reader.Pay(context.Background(), "tmr_123", 1000, "usd", PresentCard, "declined", "")
+	require.NoError(t, err)
+	require.Equal(t, "requires_payment_method", result.Status)
+	require.Equal(t, "card_declined", result.FailureCode)
+}
+
+func TestScenarioNumber(t *testing.T) {
+	number, err := scenarioNumber(PresentInterac, "success")
+	require.NoError(t, err)
+	require.Equal(t, "4506445006931933", number)
+
+	_, err = scenarioNumber(PresentInterac, "declined")
+	require.EqualError(t, err, "unknown interac scenario ‘declined’, expected one of [success], or pass --number")
+
+	_, err = scenarioNumber("wechat", "success")
+	require.Error(t, err)
+}
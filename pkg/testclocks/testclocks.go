@@ -0,0 +1,206 @@
+// Package testclocks creates and advances test clocks, which simulate the
+// passing of time for the billing objects attached to them, so that the
+// billing cycles of subscriptions can be tested without waiting for them.
+package testclocks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/requests"
+)
+
+const basePath = "/v1/test_helpers/test_clocks"
+
+// Statuses of test clocks
+const (
+	StatusReady           = "ready"
+	StatusAdvancing       = "advancing"
+	StatusInternalFailure = "internal_failure"
+)
+
+// pollInterval is how often an advancing test clock is checked
+var pollInterval = 2 * time.Second
+
+// pollTimeout is how long a test clock may take to finish advancing
+var pollTimeout = 10 * time.Minute
+
+// Clock is a test clock
+type Clock struct {
+	ID         string
+	Name       string
+	FrozenTime time.Time
+	Status     string
+}
+
+// Client sends the requests of test clocks to the API
+type Client struct {
+	BaseURL string
+	APIKey  string
+	Profile *config.Profile
+}
+
+// Create creates a test clock frozen at frozenTime
+func (c *Client) Create(ctx context.Context, frozenTime time.Time, name string) (*Clock, error) {
+	data := []string{fmt.Sprintf("frozen_time=%d", frozenTime.Unix())}
+	if name != "" {
+		data = append(data, "name="+name)
+	}
+
+	clock, err := requests.Do(ctx, http.MethodPost, c.BaseURL, c.APIKey, basePath, data, c.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseClock(clock), nil
+}
+
+// Get retrieves the test clock id
+func (c *Client) Get(ctx context.Context, id string) (*Clock, error) {
+	clock, err := requests.Do(ctx, http.MethodGet, c.BaseURL, c.APIKey, basePath+"/"+id, nil, c.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseClock(clock), nil
+}
+
+// List lists up to limit test clocks, newest first
+func (c *Client) List(ctx context.Context, limit int) ([]*Clock, error) {
+	list, err := requests.Do(ctx, http.MethodGet, c.BaseURL, c.APIKey, basePath, []string{fmt.Sprintf("limit=%d", limit)}, c.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	clocks := []*Clock{}
+	for _, clock := range list.Get("data").Array() {
+		clocks = append(clocks, parseClock(clock))
+	}
+
+	return clocks, nil
+}
+
+// Delete deletes the test clock id, and the objects attached to it
+func (c *Client) Delete(ctx context.Context, id string) error {
+	_, err := requests.Do(ctx, http.MethodDelete, c.BaseURL, c.APIKey, basePath+"/"+id, nil, c.Profile)
+	return err
+}
+
+// Advance starts advancing the test clock id to frozenTime. The clock is
+// advancing until its status is ready again.
+func (c *Client) Advance(ctx context.Context, id string, frozenTime time.Time) (*Clock, error) {
+	clock, err := requests.Do(ctx, http.MethodPost, c.BaseURL, c.APIKey, basePath+"/"+id+"/advance", []string{
+		fmt.Sprintf("frozen_time=%d", frozenTime.Unix()),
+	}, c.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseClock(clock), nil
+}
+
+// Wait waits for the test clock id to finish advancing
+func (c *Client) Wait(ctx context.Context, id string) (*Clock, error) {
+	deadline := time.Now().Add(pollTimeout)
+
+	for {
+		clock, err := c.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		switch clock.Status {
+		case StatusReady:
+			return clock, nil
+		case StatusInternalFailure:
+			return clock, fmt.Errorf("%s failed to advance", id)
+		}
+
+		if time.Now().After(deadline) {
+			return clock, fmt.Errorf("%s is still advancing after %s", id, pollTimeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func parseClock(clock gjson.Result) *Clock {
+	return &Clock{
+		ID:         clock.Get("id").String(),
+		Name:       clock.Get("name").String(),
+		FrozenTime: time.Unix(clock.Get("frozen_time").Int(), 0),
+		Status:     clock.Get("status").String(),
+	}
+}
+
+var durationRegexp = regexp.MustCompile(`^(\d+)(mo|y|w|d)$`)
+
+// AdvanceBy returns the time a duration after from. Besides the durations of
+// Go, like 12h, durations may be in days (32d), weeks (2w), or calendar
+// months (1mo) and years (1y), which keep the day of the month like billing
+// cycles do.
+func AdvanceBy(from time.Time, duration string) (time.Time, error) {
+	if match := durationRegexp.FindStringSubmatch(duration); match != nil {
+		n, err := strconv.Atoi(match[1])
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		switch match[2] {
+		case "y":
+			return from.AddDate(n, 0, 0), nil
+		case "mo":
+			return from.AddDate(0, n, 0), nil
+		case "w":
+			return from.AddDate(0, 0, 7*n), nil
+		default:
+			return from.AddDate(0, 0, n), nil
+		}
+	}
+
+	d, err := time.ParseDuration(duration)
+	if err != nil || d <= 0 {
+		return time.Time{}, fmt.Errorf("invalid duration ‘%s’, use e.g. 12h, 32d, 2w, 1mo or 1y", duration)
+	}
+
+	return from.Add(d), nil
+}
+
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseTime parses a time given as a date (2025-01-01), a date and time
+// (2025-01-01T12:00, in loc unless it has a zone), or a Unix timestamp
+func ParseTime(value string, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if timestamp, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(timestamp, 0), nil
+	}
+
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time ‘%s’, use e.g. 2025-01-01, 2025-01-01T12:00 or a Unix timestamp", value)
+}
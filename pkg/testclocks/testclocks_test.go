@@ -0,0 +1,95 @@
+package testclocks
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdvanceBy(t *testing.T) {
+	from := time.Date(2025, time.January, 31, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]time.Time{
+		"12h": time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC),
+		"32d": time.Date(2025, time.March, 4, 12, 0, 0, 0, time.UTC),
+		"2w":  time.Date(2025, time.February, 14, 12, 0, 0, 0, time.UTC),
+		"1mo": time.Date(2025, time.March, 3, 12, 0, 0, 0, time.UTC),
+		"1y":  time.Date(2026, time.January, 31, 12, 0, 0, 0, time.UTC),
+	}
+
+	for duration, expected := range tests {
+		actual, err := AdvanceBy(from, duration)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual, duration)
+	}
+
+	_, err := AdvanceBy(from, "soon")
+	require.EqualError(t, err, "invalid duration ‘soon’, use e.g. 12h, 32d, 2w, 1mo or 1y")
+
+	_, err = AdvanceBy(from, "-1h")
+	require.Error(t, err)
+}
+
+func TestParseTime(t *testing.T) {
+	tests := map[string]time.Time{
+		"2025-01-01":           time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+		"2025-01-01T12:30":     time.Date(2025, time.January, 1, 12, 30, 0, 0, time.UTC),
+		"2025-01-01T12:30:00Z": time.Date(2025, time.January, 1, 12, 30, 0, 0, time.UTC),
+		"1735689600":           time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	for value, expected := range tests {
+		actual, err := ParseTime(value, time.UTC)
+		require.NoError(t, err)
+		require.True(t, expected.Equal(actual), value)
+	}
+
+	_, err := ParseTime("tomorrow", time.UTC)
+	require.Error(t, err)
+}
+
+func TestAdvanceAndWait(t *testing.T) {
+	pollInterval = time.Millisecond
+	polls := 0
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		params, err := url.ParseQuery(string(body))
+		require.NoError(t, err)
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/test_helpers/test_clocks/clock_123/advance":
+			require.Equal(t, "1738411200", params.Get("frozen_time"))
+			w.Write([]byte(`{"id": "clock_123", "status": "advancing", "frozen_time": 1735732800}`))
+		case "GET /v1/test_helpers/test_clocks/clock_123":
+			polls++
+			if polls < 3 {
+				w.Write([]byte(`{"id": "clock_123", "status": "advancing", "frozen_time": 1735732800}`))
+			} else {
+				w.Write([]byte(`{"id": "clock_123", "status": "ready", "frozen_time": 1738411200}`))
+			}
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client := &Client{BaseURL: ts.URL, APIKey: "sk_test_123"}
+
+	clock, err := client.Advance(context.Background(), "clock_123", time.Unix(1738411200, 0))
+	require.NoError(t, err)
+	require.Equal(t, StatusAdvancing, clock.Status)
+
+	clock, err = client.Wait(context.Background(), "clock_123")
+	require.NoError(t, err)
+	require.Equal(t, StatusReady, clock.Status)
+	require.Equal(t, int64(1738411200), clock.FrozenTime.Unix())
+	require.Equal(t, 3, polls)
+}
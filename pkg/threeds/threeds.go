@@ -0,0 +1,253 @@
+// Package threeds runs 3D Secure authentications of payment intents end to
+// end in test mode, completing the challenge the way a customer would, so
+// the events of authenticated and failed payments can be sent to webhook
+// handlers without going through a browser.
+package threeds
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/requests"
+)
+
+const (
+	// Authenticated is the outcome of a challenge completed by the customer
+	Authenticated = "authenticated"
+
+	// Failed is the outcome of a challenge failed by the customer
+	Failed = "failed"
+)
+
+// DefaultPaymentMethod is the test payment method used to confirm payment
+// intents, which requires 3D Secure authentication on every payment
+const DefaultPaymentMethod = "pm_card_threeDSecureRequired"
+
+// returnURL is where the customer would be sent back to after the challenge.
+// It's never loaded, but confirming with redirects requires one.
+const returnURL = "https://stripe.com/docs/testing#regulatory-cards"
+
+// pollInterval is how often the payment intent is checked after the
+// challenge is completed
+var pollInterval = time.Second
+
+// pollTimeout is how long the payment intent can take to leave the
+// requires_action status after the challenge is completed
+var pollTimeout = 30 * time.Second
+
+// Options are the options of a simulation.
+type Options struct {
+	// Outcome is Authenticated or Failed
+	Outcome string
+
+	// PaymentIntent is the payment intent to authenticate. A payment intent
+	// is created when it's empty.
+	PaymentIntent string
+
+	// PaymentMethod confirms the payment intent. It must be a test payment
+	// method requiring 3D Secure.
+	PaymentMethod string
+
+	// Amount and Currency of the payment intent created when PaymentIntent
+	// is empty
+	Amount   int64
+	Currency string
+
+	// Client sends the requests to the challenge page. http.DefaultClient is
+	// used when it's nil.
+	Client *http.Client
+}
+
+// Result is the outcome of a simulation.
+type Result struct {
+	PaymentIntent string
+	Status        string
+	// Events are the types of the events of the payment intent sent during
+	// the simulation, oldest first
+	Events []string
+}
+
+// Simulate confirms a payment intent with a payment method requiring 3D
+// Secure, completes or fails the challenge, and waits for the payment intent
+// to reflect the outcome.
+func Simulate(ctx context.Context, baseURL, apiKey string, opts Options, profile *config.Profile) (*Result, error) {
+	if opts.Outcome != Authenticated && opts.Outcome != Failed {
+		return nil, fmt.Errorf("invalid outcome ‘%s’, expected %s or %s", opts.Outcome, Authenticated, Failed)
+	}
+
+	if opts.PaymentMethod == "" {
+		opts.PaymentMethod = DefaultPaymentMethod
+	}
+
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+
+	startedAt := time.Now().Unix()
+
+	id := opts.PaymentIntent
+	if id == "" {
+		created, err := requests.Do(ctx, http.MethodPost, baseURL, apiKey, "/v1/payment_intents", []string{
+			fmt.Sprintf("amount=%d", opts.Amount),
+			"currency=" + opts.Currency,
+			"payment_method_types[]=card",
+			"description=(created by Stripe CLI)",
+		}, profile)
+		if err != nil {
+			return nil, err
+		}
+
+		id = created.Get("id").String()
+	}
+
+	intent, err := requests.Do(ctx, http.MethodPost, baseURL, apiKey, "/v1/payment_intents/"+id+"/confirm", []string{
+		"payment_method=" + opts.PaymentMethod,
+		"return_url=" + returnURL,
+	}, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	redirect := intent.Get("next_action.redirect_to_url.url").String()
+	if intent.Get("status").String() != "requires_action" || redirect == "" {
+		return nil, fmt.Errorf("%s doesn't require 3D Secure authentication (status: %s). Use a test payment method requiring it, like %s",
+			id, intent.Get("status").String(), DefaultPaymentMethod)
+	}
+
+	if err := completeChallenge(ctx, opts.Client, redirect, opts.Outcome == Authenticated); err != nil {
+		return nil, err
+	}
+
+	intent, err = waitForOutcome(ctx, baseURL, apiKey, id, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		PaymentIntent: id,
+		Status:        intent.Get("status").String(),
+	}
+
+	if !matchesOutcome(intent, opts.Outcome) {
+		return result, fmt.Errorf("the challenge of %s was completed, but the payment intent is %s instead of %s",
+			id, result.Status, opts.Outcome)
+	}
+
+	result.Events, err = events(ctx, baseURL, apiKey, id, startedAt, profile)
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// completeChallenge completes or fails the 3D Secure challenge at the
+// redirect URL of a payment intent. In test mode the challenge is a page
+// with buttons to complete or fail the authentication, which send the
+// customer to the completion URL of the challenge.
+func completeChallenge(ctx context.Context, client *http.Client, redirect string, authenticated bool) error {
+	u, err := url.Parse(redirect)
+	if err != nil {
+		return err
+	}
+
+	if !strings.Contains(u.Path, "/authenticate/") {
+		return fmt.Errorf("unsupported 3D Secure challenge page %s", redirect)
+	}
+
+	u.Path = strings.Replace(u.Path, "/authenticate/", "/complete/", 1)
+
+	query := u.Query()
+	query.Set("authenticated", fmt.Sprintf("%t", authenticated))
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("failed to complete the 3D Secure challenge: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// waitForOutcome waits for the payment intent id to leave the
+// requires_action status.
+func waitForOutcome(ctx context.Context, baseURL, apiKey, id string, profile *config.Profile) (gjson.Result, error) {
+	deadline := time.Now().Add(pollTimeout)
+
+	for {
+		intent, err := requests.Do(ctx, http.MethodGet, baseURL, apiKey, "/v1/payment_intents/"+id, nil, profile)
+		if err != nil {
+			return gjson.Result{}, err
+		}
+
+		if intent.Get("status").String() != "requires_action" {
+			return intent, nil
+		}
+
+		if time.Now().After(deadline) {
+			return gjson.Result{}, fmt.Errorf("%s still requires action %s after the challenge was completed", id, pollTimeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			return gjson.Result{}, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// matchesOutcome tells whether the payment intent reflects the outcome of
+// the challenge. A failed authentication returns the payment intent to
+// requires_payment_method.
+func matchesOutcome(intent gjson.Result, outcome string) bool {
+	status := intent.Get("status").String()
+
+	if outcome == Failed {
+		return status == "requires_payment_method" &&
+			intent.Get("last_payment_error.code").String() == "payment_intent_authentication_failure"
+	}
+
+	return status == "succeeded" || status == "requires_capture" || status == "processing"
+}
+
+// events returns the types of the events of the payment intent id, and of
+// its charges, created since startedAt, oldest first.
+func events(ctx context.Context, baseURL, apiKey, id string, startedAt int64, profile *config.Profile) ([]string, error) {
+	list, err := requests.Do(ctx, http.MethodGet, baseURL, apiKey, "/v1/events", []string{
+		"limit=100",
+		fmt.Sprintf("created[gte]=%d", startedAt),
+	}, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	types := []string{}
+
+	data := list.Get("data").Array()
+	// Events are listed newest first
+	for i := len(data) - 1; i >= 0; i-- {
+		object := data[i].Get("data.object")
+		if object.Get("id").String() == id || object.Get("payment_intent").String() == id {
+			types = append(types, data[i].Get("type").String())
+		}
+	}
+
+	return types, nil
+}
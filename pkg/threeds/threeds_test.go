@@ -0,0 +1,109 @@
+package threeds
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T, outcome *string) *httptest.Server {
+	status := "requires_payment_method"
+	authenticated := ""
+
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		params, err := url.ParseQuery(string(body))
+		require.NoError(t, err)
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/payment_intents":
+			require.Equal(t, "2000", params.Get("amount"))
+			w.Write([]byte(`{"id": "pi_123", "status": "requires_payment_method"}`))
+		case "POST /v1/payment_intents/pi_123/confirm":
+			require.Equal(t, DefaultPaymentMethod, params.Get("payment_method"))
+			require.NotEmpty(t, params.Get("return_url"))
+			status = "requires_action"
+			w.Write([]byte(`{"id": "pi_123", "status": "requires_action", "next_action": {"type": "redirect_to_url", "redirect_to_url": {"url": "` +
+				ts.URL + `/redirect/authenticate/src_123?client_secret=src_client_secret_123"}}}`))
+		case "POST /redirect/complete/src_123":
+			require.Equal(t, "src_client_secret_123", r.URL.Query().Get("client_secret"))
+			authenticated = r.URL.Query().Get("authenticated")
+			w.Write([]byte(`<html></html>`))
+		case "GET /v1/payment_intents/pi_123":
+			switch {
+			case authenticated == "":
+				w.Write([]byte(`{"id": "pi_123", "status": "` + status + `"}`))
+			case *outcome == Authenticated:
+				w.Write([]byte(`{"id": "pi_123", "status": "succeeded"}`))
+			default:
+				w.Write([]byte(`{"id": "pi_123", "status": "requires_payment_method", "last_payment_error": {"code": "payment_intent_authentication_failure"}}`))
+			}
+		case "GET /v1/events":
+			w.Write([]byte(`{"data": [
+				{"type": "charge.succeeded", "data": {"object": {"id": "ch_123", "payment_intent": "pi_123"}}},
+				{"type": "payment_intent.succeeded", "data": {"object": {"id": "pi_123"}}},
+				{"type": "customer.created", "data": {"object": {"id": "cus_123"}}},
+				{"type": "payment_intent.requires_action", "data": {"object": {"id": "pi_123"}}}
+			]}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	return ts
+}
+
+func TestSimulateAuthenticated(t *testing.T) {
+	outcome := Authenticated
+	ts := newTestServer(t, &outcome)
+	defer ts.Close()
+
+	result, err := Simulate(context.Background(), ts.URL, "sk_test_123", Options{
+		Outcome:  Authenticated,
+		Amount:   2000,
+		Currency: "usd",
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, &Result{
+		PaymentIntent: "pi_123",
+		Status:        "succeeded",
+		Events:        []string{"payment_intent.requires_action", "payment_intent.succeeded", "charge.succeeded"},
+	}, result)
+}
+
+func TestSimulateFailed(t *testing.T) {
+	outcome := Failed
+	ts := newTestServer(t, &outcome)
+	defer ts.Close()
+
+	result, err := Simulate(context.Background(), ts.URL, "sk_test_123", Options{
+		Outcome:       Failed,
+		PaymentIntent: "pi_123",
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "requires_payment_method", result.Status)
+}
+
+func TestSimulateUnexpectedOutcome(t *testing.T) {
+	outcome := Failed
+	ts := newTestServer(t, &outcome)
+	defer ts.Close()
+
+	_, err := Simulate(context.Background(), ts.URL, "sk_test_123", Options{
+		Outcome:       Authenticated,
+		PaymentIntent: "pi_123",
+	}, nil)
+	require.EqualError(t, err, "the challenge of pi_123 was completed, but the payment intent is requires_payment_method instead of authenticated")
+}
+
+func TestSimulateInvalidOutcome(t *testing.T) {
+	_, err := Simulate(context.Background(), "", "sk_test_123", Options{Outcome: "maybe"}, nil)
+	require.EqualError(t, err, "invalid outcome ‘maybe’, expected authenticated or failed")
+}
@@ -1,12 +1,21 @@
 package validators
 
 import (
-	"errors"
 	"fmt"
 
 	"github.com/spf13/cobra"
 )
 
+// ArgsError is returned when a command is run with the wrong number of
+// positional arguments
+type ArgsError struct {
+	Message string
+}
+
+func (e ArgsError) Error() string {
+	return e.Message
+}
+
 func getCommandPath(cmd *cobra.Command) string {
 	var commandPath string
 	if cmd.Annotations["scope"] == "plugin" {
@@ -28,7 +37,7 @@ func NoArgs(cmd *cobra.Command, args []string) error {
 	)
 
 	if len(args) > 0 {
-		return errors.New(errorMessage)
+		return ArgsError{Message: errorMessage}
 	}
 
 	return nil
@@ -53,7 +62,7 @@ func ExactArgs(num int) cobra.PositionalArgs {
 		)
 
 		if len(args) != num {
-			return errors.New(errorMessage)
+			return ArgsError{Message: errorMessage}
 		}
 		return nil
 	}
@@ -78,7 +87,28 @@ func MaximumNArgs(num int) cobra.PositionalArgs {
 		)
 
 		if len(args) > num {
-			return errors.New(errorMessage)
+			return ArgsError{Message: errorMessage}
+		}
+		return nil
+	}
+}
+
+// RangeArgs is a validator for commands to print an error when the number of
+// provided args is outside of the range
+func RangeArgs(min, max int) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		commandPath := getCommandPath(cmd)
+
+		errorMessage := fmt.Sprintf(
+			"`%s` requires between %d and %d positional arguments. See `%s --help` for supported flags and usage",
+			commandPath,
+			min,
+			max,
+			commandPath,
+		)
+
+		if len(args) < min || len(args) > max {
+			return ArgsError{Message: errorMessage}
 		}
 		return nil
 	}
@@ -46,3 +46,21 @@ func TestExactArgsTooManyMoreThan1(t *testing.T) {
 	result := ExactArgs(2)(c, args)
 	require.EqualError(t, result, "`c` requires exactly 2 positional arguments. See `c --help` for supported flags and usage")
 }
+
+func TestRangeArgs(t *testing.T) {
+	c := &cobra.Command{Use: "c"}
+	args := []string{"foo", "bar"}
+
+	result := RangeArgs(1, 2)(c, args)
+	require.Nil(t, result)
+}
+
+func TestRangeArgsOutOfRange(t *testing.T) {
+	c := &cobra.Command{Use: "c"}
+
+	result := RangeArgs(1, 2)(c, []string{})
+	require.EqualError(t, result, "`c` requires between 1 and 2 positional arguments. See `c --help` for supported flags and usage")
+
+	result = RangeArgs(1, 2)(c, []string{"foo", "bar", "baz"})
+	require.EqualError(t, result, "`c` requires between 1 and 2 positional arguments. See `c --help` for supported flags and usage")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/google/go-github/v28/github"
@@ -31,16 +32,70 @@ func CheckLatestVersion() {
 
 		ansi.StopSpinner(s, "", os.Stdout)
 
-		if needsToUpgrade(Version, latest) {
-			fmt.Println(ansi.Italic("A newer version of the Stripe CLI is available, please update to:"), ansi.Italic(latest))
+		printUpgradeNotice(Version, latest)
+	}
+}
+
+// CheckLatestVersionInBackground starts looking up the latest release of the
+// CLI without blocking. The returned function prints the upgrade notice if
+// the lookup has finished by the time it's called.
+func CheckLatestVersionInBackground() func() {
+	// master is the dev version, we don't want to check against that every time
+	if Version == "master" {
+		return func() {}
+	}
+
+	latestCh := make(chan string, 1)
+
+	go func() {
+		latestCh <- getLatestVersion()
+	}()
+
+	return func() {
+		select {
+		case latest := <-latestCh:
+			printUpgradeNotice(Version, latest)
+		default:
 		}
 	}
 }
 
+func printUpgradeNotice(version, latest string) {
+	if needsToUpgrade(version, latest) {
+		fmt.Println(ansi.Italic("A newer version of the Stripe CLI is available, please update to:"), ansi.Italic(latest))
+	}
+}
+
 func needsToUpgrade(version, latest string) bool {
 	return latest != "" && (strings.TrimPrefix(latest, "v") != strings.TrimPrefix(version, "v"))
 }
 
+// Compare compares two dotted numeric versions, like 18.2.0 and v16,
+// returning -1, 0 or 1. Missing components count as 0.
+func Compare(a, b string) int {
+	aParts := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bParts := strings.Split(strings.TrimPrefix(b, "v"), ".")
+
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var x, y int
+		if i < len(aParts) {
+			x, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			y, _ = strconv.Atoi(bParts[i])
+		}
+
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+
+	return 0
+}
+
 func getLatestVersion() string {
 	client := github.NewClient(nil)
 	rep, _, err := client.Repositories.GetLatestRelease(context.Background(), "stripe", "stripe-cli")
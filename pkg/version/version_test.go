@@ -13,3 +13,12 @@ func TestNeedsToUpgrade(t *testing.T) {
 	require.True(t, needsToUpgrade("4.2.4.2", "v4.2.4.3"))
 	require.True(t, needsToUpgrade("v4.2.4.2", "v4.2.4.3"))
 }
+
+func TestCompare(t *testing.T) {
+	require.Equal(t, 0, Compare("18", "18.0.0"))
+	require.Equal(t, 0, Compare("v8.1.0", "8.1"))
+	require.Equal(t, 1, Compare("18.2.0", "16"))
+	require.Equal(t, 1, Compare("2.60.1", "2.60.0"))
+	require.Equal(t, -1, Compare("3.8.10", "3.10"))
+	require.Equal(t, -1, Compare("5.38", "5.38.1"))
+}
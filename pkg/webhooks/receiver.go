@@ -0,0 +1,183 @@
+package webhooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+)
+
+const receiverTimeLayout = "2006-01-02 15:04:05"
+
+// Delivery is a webhook received by a Receiver, as logged to its NDJSON log
+type Delivery struct {
+	Time    time.Time `json:"time"`
+	EventID string    `json:"event_id,omitempty"`
+	Type    string    `json:"type,omitempty"`
+	// Verified is whether the signature was verified, false when the
+	// receiver has no secret
+	Verified bool   `json:"verified"`
+	Error    string `json:"error,omitempty"`
+	// Status is the status the receiver responded with
+	Status  int             `json:"status"`
+	DelayMS int64           `json:"delay_ms,omitempty"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// Receiver is a webhook endpoint, for testing the delivery of webhooks before
+// the endpoint of an app exists. It verifies the signatures of the events it
+// receives, prints them, and responds with a configurable status.
+type Receiver struct {
+	// Secret is the webhook signing secret the signatures are verified
+	// with. They're not verified if it's empty.
+	Secret string
+
+	// Tolerance is the tolerance for the age of signatures
+	Tolerance time.Duration
+
+	// Status is the status to respond to the verified events with, 200 if
+	// it's 0. Events whose signature is invalid get a 400.
+	Status int
+
+	// Delay is how long to wait before responding
+	Delay time.Duration
+
+	// Out is where the events are printed
+	Out io.Writer
+
+	// Log is where deliveries are logged as NDJSON, if not nil
+	Log io.Writer
+
+	mu  sync.Mutex
+	now func() time.Time
+}
+
+// ServeHTTP receives a webhook
+func (rc *Receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "webhooks are POST requests", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now
+	if rc.now != nil {
+		now = rc.now
+	}
+
+	delivery := Delivery{
+		Time:    now(),
+		Status:  rc.Status,
+		DelayMS: rc.Delay.Milliseconds(),
+	}
+	if delivery.Status == 0 {
+		delivery.Status = http.StatusOK
+	}
+
+	var event struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+	}
+
+	if json.Valid(body) {
+		delivery.Payload = body
+		json.Unmarshal(body, &event) // #nosec G104
+		delivery.EventID = event.ID
+		delivery.Type = event.Type
+	} else {
+		delivery.Error = "the payload is not JSON"
+		delivery.Status = http.StatusBadRequest
+	}
+
+	if delivery.Error == "" && rc.Secret != "" {
+		if err := Verify(string(body), r.Header.Get("Stripe-Signature"), rc.Secret, delivery.Time, rc.Tolerance); err != nil {
+			delivery.Error = err.Error()
+			delivery.Status = http.StatusBadRequest
+		} else {
+			delivery.Verified = true
+		}
+	}
+
+	rc.print(delivery)
+	rc.log(delivery)
+
+	if rc.Delay > 0 {
+		select {
+		case <-time.After(rc.Delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if delivery.Error != "" {
+		http.Error(w, delivery.Error, delivery.Status)
+		return
+	}
+
+	w.WriteHeader(delivery.Status)
+}
+
+// print pretty-prints a delivery to Out
+func (rc *Receiver) print(delivery Delivery) {
+	if rc.Out == nil {
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	color := ansi.Color(rc.Out)
+	localTime := color.Faint(delivery.Time.Local().Format(receiverTimeLayout))
+
+	eventType := delivery.Type
+	if eventType == "" {
+		eventType = "(unknown type)"
+	}
+	fmt.Fprintf(rc.Out, "%s   --> %s [%s]\n", localTime, ansi.Bold(eventType), delivery.EventID)
+
+	if delivery.Payload != nil {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, delivery.Payload, "", "  "); err == nil {
+			fmt.Fprintln(rc.Out, ansi.ColorizeJSON(indented.String(), false, rc.Out))
+		}
+	}
+
+	result := color.Faint("signature not verified").String()
+	switch {
+	case delivery.Error != "":
+		result = color.Red(delivery.Error).String()
+	case delivery.Verified:
+		result = color.Green("signature verified").String()
+	}
+
+	fmt.Fprintf(rc.Out, "%s  <--  [%d] %s\n", localTime, ansi.ColorizeStatusFor(rc.Out, delivery.Status), result)
+}
+
+// log writes a delivery to Log as a line of JSON
+func (rc *Receiver) log(delivery Delivery) {
+	if rc.Log == nil {
+		return
+	}
+
+	line, err := json.Marshal(delivery)
+	if err != nil {
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.Log.Write(append(line, '\n')) // #nosec G104
+}
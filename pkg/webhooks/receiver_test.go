@@ -0,0 +1,78 @@
+package webhooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func receive(rc *Receiver, payload, header string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(payload))
+	if header != "" {
+		req.Header.Set("Stripe-Signature", header)
+	}
+
+	rec := httptest.NewRecorder()
+	rc.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestReceiver(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	var out, log bytes.Buffer
+
+	rc := &Receiver{
+		Secret:    "whsec_abc",
+		Tolerance: DefaultTolerance,
+		Status:    http.StatusAccepted,
+		Out:       &out,
+		Log:       &log,
+		now:       func() time.Time { return now },
+	}
+
+	rec := receive(rc, DefaultPayload, "t=1700000000,v1="+Sign("whsec_abc", DefaultPayload, 1700000000))
+	require.Equal(t, http.StatusAccepted, rec.Code)
+
+	rec = receive(rc, DefaultPayload, "t=1700000000,v1="+Sign("whsec_other", DefaultPayload, 1700000000))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = receive(rc, "not json", "")
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	require.Contains(t, out.String(), "payment_intent.succeeded [evt_test_webhook_vectors]")
+	require.Contains(t, out.String(), "signature verified")
+	require.Contains(t, out.String(), ErrNoValidSignature.Error())
+
+	lines := strings.Split(strings.TrimSpace(log.String()), "\n")
+	require.Len(t, lines, 3)
+
+	var delivery Delivery
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &delivery))
+	require.Equal(t, "evt_test_webhook_vectors", delivery.EventID)
+	require.Equal(t, "payment_intent.succeeded", delivery.Type)
+	require.True(t, delivery.Verified)
+	require.Equal(t, http.StatusAccepted, delivery.Status)
+	require.JSONEq(t, DefaultPayload, string(delivery.Payload))
+
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &delivery))
+	require.False(t, delivery.Verified)
+	require.Equal(t, ErrNoValidSignature.Error(), delivery.Error)
+	require.Equal(t, http.StatusBadRequest, delivery.Status)
+}
+
+func TestReceiverWithoutSecret(t *testing.T) {
+	rc := &Receiver{Delay: 10 * time.Millisecond}
+
+	start := time.Now()
+	rec := receive(rc, DefaultPayload, "")
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+}
@@ -0,0 +1,200 @@
+// Package webhooks generates signed webhook payloads for testing the code
+// that verifies them.
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultTolerance is the tolerance of the Stripe SDKs for the age of
+// signatures
+const DefaultTolerance = 300 * time.Second
+
+// DefaultPayload is the event vectors are generated for when no payload is
+// given
+const DefaultPayload = `{
+  "id": "evt_test_webhook_vectors",
+  "object": "event",
+  "api_version": "2020-08-27",
+  "created": 1600000000,
+  "data": {
+    "object": {
+      "id": "pi_test_webhook_vectors",
+      "object": "payment_intent",
+      "amount": 2000,
+      "currency": "usd",
+      "status": "succeeded"
+    }
+  },
+  "livemode": false,
+  "type": "payment_intent.succeeded"
+}`
+
+// Vector is a payload and a Stripe-Signature header that verification code
+// must accept, or reject
+type Vector struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Payload     string `json:"payload"`
+	Header      string `json:"header"`
+	// Timestamp is the timestamp of the signature
+	Timestamp int64 `json:"timestamp"`
+	// Valid is whether the signature must be accepted
+	Valid bool `json:"valid"`
+}
+
+// Vectors is a suite of test vectors for the signatures of a secret
+type Vectors struct {
+	Secret string `json:"secret"`
+	// Now is the time vectors must be verified at, in seconds since the
+	// epoch, e.g. by mocking the clock
+	Now int64 `json:"now"`
+	// Tolerance is the tolerance in seconds vectors must be verified with
+	Tolerance int64    `json:"tolerance"`
+	Vectors   []Vector `json:"vectors"`
+}
+
+// Sign computes the v1 signature of a payload, like Stripe signs webhooks
+func Sign(secret, payload string, timestamp int64) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("%d.%s", timestamp, payload)))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// GenerateVectors generates valid and invalid signatures of payload for
+// secret, signed at now, for verification code using tolerance
+func GenerateVectors(secret, payload string, now time.Time, tolerance time.Duration) (*Vectors, error) {
+	if !strings.HasPrefix(secret, "whsec_") {
+		return nil, errors.New("the secret must be a webhook signing secret starting with whsec_")
+	}
+	if tolerance <= 0 {
+		return nil, errors.New("the tolerance must be positive")
+	}
+
+	t := now.Unix()
+	expired := t - int64(tolerance/time.Second) - 60
+	otherSecret := secret + "_wrong"
+	tampered := strings.Replace(payload, "\"", "\"tampered_", 1)
+	if tampered == payload {
+		tampered = payload + " "
+	}
+
+	header := func(timestamp int64, signatures ...string) string {
+		parts := []string{fmt.Sprintf("t=%d", timestamp)}
+		return strings.Join(append(parts, signatures...), ",")
+	}
+	v1 := func(secret string, timestamp int64, payload string) string {
+		return "v1=" + Sign(secret, payload, timestamp)
+	}
+
+	vectors := []Vector{
+		{
+			Name:        "valid",
+			Description: "A valid signature",
+			Payload:     payload,
+			Header:      header(t, v1(secret, t, payload)),
+			Timestamp:   t,
+			Valid:       true,
+		},
+		{
+			Name:        "valid_multiple_signatures",
+			Description: "A valid signature after the signature of another secret, like while a secret is rolled",
+			Payload:     payload,
+			Header:      header(t, v1(otherSecret, t, payload), v1(secret, t, payload)),
+			Timestamp:   t,
+			Valid:       true,
+		},
+		{
+			Name:        "valid_with_v0_signature",
+			Description: "A valid v1 signature with a v0 signature, which must be ignored",
+			Payload:     payload,
+			Header:      header(t, v1(secret, t, payload), "v0="+Sign(otherSecret, payload, t)),
+			Timestamp:   t,
+			Valid:       true,
+		},
+		{
+			Name:        "expired_timestamp",
+			Description: "A valid signature whose timestamp is older than the tolerance",
+			Payload:     payload,
+			Header:      header(expired, v1(secret, expired, payload)),
+			Timestamp:   expired,
+			Valid:       false,
+		},
+		{
+			Name:        "tampered_body",
+			Description: "The signature of the payload, for a payload that was modified",
+			Payload:     tampered,
+			Header:      header(t, v1(secret, t, payload)),
+			Timestamp:   t,
+			Valid:       false,
+		},
+		{
+			Name:        "tampered_timestamp",
+			Description: "The signature of the payload, with a timestamp that was modified",
+			Payload:     payload,
+			Header:      header(t-1, v1(secret, t, payload)),
+			Timestamp:   t - 1,
+			Valid:       false,
+		},
+		{
+			Name:        "wrong_secret",
+			Description: "A signature computed with another secret",
+			Payload:     payload,
+			Header:      header(t, v1(otherSecret, t, payload)),
+			Timestamp:   t,
+			Valid:       false,
+		},
+		{
+			Name:        "v0_signature_only",
+			Description: "A valid signature with the v0 scheme, which isn't accepted",
+			Payload:     payload,
+			Header:      header(t, "v0="+Sign(secret, payload, t)),
+			Timestamp:   t,
+			Valid:       false,
+		},
+		{
+			Name:        "missing_signature",
+			Description: "A header without signatures",
+			Payload:     payload,
+			Header:      header(t),
+			Timestamp:   t,
+			Valid:       false,
+		},
+		{
+			Name:        "missing_timestamp",
+			Description: "A header without a timestamp",
+			Payload:     payload,
+			Header:      v1(secret, t, payload),
+			Timestamp:   t,
+			Valid:       false,
+		},
+		{
+			Name:        "malformed_header",
+			Description: "A header that isn't a Stripe-Signature header",
+			Payload:     payload,
+			Header:      "not a signature header",
+			Valid:       false,
+		},
+		{
+			Name:        "empty_header",
+			Description: "An empty header",
+			Payload:     payload,
+			Header:      "",
+			Valid:       false,
+		},
+	}
+
+	return &Vectors{
+		Secret:    secret,
+		Now:       t,
+		Tolerance: int64(tolerance / time.Second),
+		Vectors:   vectors,
+	}, nil
+}
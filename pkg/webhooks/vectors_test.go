@@ -0,0 +1,83 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// verify verifies a signature like the Stripe SDKs do
+func verify(payload, header, secret string, now time.Time, tolerance time.Duration) bool {
+	var timestamp int64
+	var signatures []string
+
+	for _, part := range strings.Split(header, ",") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return false
+		}
+		switch kv[0] {
+		case "t":
+			t, err := strconv.ParseInt(kv[1], 10, 64)
+			if err != nil {
+				return false
+			}
+			timestamp = t
+		case "v1":
+			signatures = append(signatures, kv[1])
+		}
+	}
+
+	if timestamp == 0 || len(signatures) == 0 {
+		return false
+	}
+	if now.Sub(time.Unix(timestamp, 0)) > tolerance {
+		return false
+	}
+
+	expected := Sign(secret, payload, timestamp)
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestSign(t *testing.T) {
+	require.Equal(t, "ac8c9ce4374bfefbb12ee48f8429b22c7a412293e1ff6a9ca09f5a163f4e0595", Sign("whsec_abc", DefaultPayload, 1700000000))
+}
+
+func TestGenerateVectors(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	vectors, err := GenerateVectors("whsec_test_123", DefaultPayload, now, DefaultTolerance)
+	require.NoError(t, err)
+	require.Equal(t, int64(1700000000), vectors.Now)
+	require.Equal(t, int64(300), vectors.Tolerance)
+
+	names := make(map[string]bool)
+	for _, vector := range vectors.Vectors {
+		require.False(t, names[vector.Name], "duplicate vector %s", vector.Name)
+		names[vector.Name] = true
+
+		require.Equal(t, vector.Valid, verify(vector.Payload, vector.Header, vectors.Secret, now, DefaultTolerance), vector.Name)
+	}
+
+	for _, name := range []string{"valid", "expired_timestamp", "tampered_body", "wrong_secret"} {
+		require.True(t, names[name], name)
+	}
+}
+
+func TestGenerateVectorsErrors(t *testing.T) {
+	_, err := GenerateVectors("sk_test_123", DefaultPayload, time.Now(), DefaultTolerance)
+	require.EqualError(t, err, "the secret must be a webhook signing secret starting with whsec_")
+
+	_, err = GenerateVectors("whsec_test_123", DefaultPayload, time.Now(), 0)
+	require.EqualError(t, err, "the tolerance must be positive")
+}
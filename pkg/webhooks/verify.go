@@ -0,0 +1,72 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Errors of Verify
+var (
+	ErrNoHeader         = errors.New("the request has no Stripe-Signature header")
+	ErrNoTimestamp      = errors.New("the Stripe-Signature header has no timestamp")
+	ErrNoSignature      = errors.New("the Stripe-Signature header has no v1 signature")
+	ErrExpired          = errors.New("the timestamp of the signature is older than the tolerance")
+	ErrNoValidSignature = errors.New("no signature of the Stripe-Signature header matches the secret")
+)
+
+// Verify verifies the Stripe-Signature header of a payload like the Stripe
+// SDKs do: one of its v1 signatures must be the signature of the payload with
+// secret, and its timestamp must not be older than tolerance at now.
+func Verify(payload, header, secret string, now time.Time, tolerance time.Duration) error {
+	if header == "" {
+		return ErrNoHeader
+	}
+
+	var timestamp int64
+	var signatures []string
+
+	for _, part := range strings.Split(header, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+
+		switch kv[0] {
+		case "t":
+			t, err := strconv.ParseInt(kv[1], 10, 64)
+			if err != nil {
+				return ErrNoTimestamp
+			}
+			timestamp = t
+		case "v1":
+			signatures = append(signatures, kv[1])
+		}
+	}
+
+	if timestamp == 0 {
+		return ErrNoTimestamp
+	}
+	if len(signatures) == 0 {
+		return ErrNoSignature
+	}
+
+	expected := Sign(secret, payload, timestamp)
+	valid := false
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			valid = true
+		}
+	}
+	if !valid {
+		return ErrNoValidSignature
+	}
+
+	if tolerance > 0 && now.Sub(time.Unix(timestamp, 0)) > tolerance {
+		return ErrExpired
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package webhooks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyVectors(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	vectors, err := GenerateVectors("whsec_test_123", DefaultPayload, now, DefaultTolerance)
+	require.NoError(t, err)
+
+	for _, vector := range vectors.Vectors {
+		err := Verify(vector.Payload, vector.Header, vectors.Secret, now, DefaultTolerance)
+		require.Equal(t, vector.Valid, err == nil, vector.Name)
+	}
+}
+
+func TestVerifyErrors(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	header := "t=1700000000,v1=" + Sign("whsec_abc", DefaultPayload, 1700000000)
+
+	require.NoError(t, Verify(DefaultPayload, header, "whsec_abc", now, DefaultTolerance))
+	require.Equal(t, ErrNoHeader, Verify(DefaultPayload, "", "whsec_abc", now, DefaultTolerance))
+	require.Equal(t, ErrNoTimestamp, Verify(DefaultPayload, "v1=abc", "whsec_abc", now, DefaultTolerance))
+	require.Equal(t, ErrNoSignature, Verify(DefaultPayload, "t=1700000000", "whsec_abc", now, DefaultTolerance))
+	require.Equal(t, ErrNoValidSignature, Verify(DefaultPayload, header, "whsec_other", now, DefaultTolerance))
+	require.Equal(t, ErrExpired, Verify(DefaultPayload, header, "whsec_abc", now.Add(time.Hour), DefaultTolerance))
+}
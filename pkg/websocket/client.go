@@ -10,11 +10,14 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	ws "github.com/gorilla/websocket"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/stripe/stripe-cli/pkg/netproxy"
+	"github.com/stripe/stripe-cli/pkg/stripe"
 	"github.com/stripe/stripe-cli/pkg/useragent"
 )
 
@@ -28,6 +31,10 @@ type Config struct {
 
 	Dialer *ws.Dialer
 
+	// Proxy server the connection is made through when Dialer is nil. If
+	// nil, the proxy configured in the environment (HTTPS_PROXY) is used.
+	Proxy *netproxy.Dialer
+
 	Log *log.Logger
 
 	// Force use of unencrypted ws:// protocol instead of wss://
@@ -81,8 +88,13 @@ type Client struct {
 
 	conn        *ws.Conn
 	done        chan struct{}
+	reset       chan struct{}
 	isConnected bool
 
+	// lastActivity is the time, in Unix nanoseconds, a message or a pong was
+	// last received
+	lastActivity int64
+
 	NotifyExpired chan struct{}
 	notifyClose   chan error
 	send          chan *OutgoingMessage
@@ -158,12 +170,21 @@ func (c *Client) Run(ctx context.Context) {
 			}).Debug("Disconnected from Stripe")
 			c.Close(ws.CloseGoingAway, "Server closed the connection")
 			c.wg.Wait()
+			reconnects.Inc("disconnected")
 		case <-time.After(c.cfg.ReconnectInterval):
 			c.cfg.Log.WithFields(log.Fields{
 				"prefix": "websocket.Client.Run",
 			}).Debug("Resetting the connection")
 			c.Close(ws.CloseNormalClosure, "Resetting the connection")
 			c.wg.Wait()
+			reconnects.Inc("reset")
+		case <-c.reset:
+			c.cfg.Log.WithFields(log.Fields{
+				"prefix": "websocket.Client.Run",
+			}).Debug("Resetting the connection on request")
+			c.Close(ws.CloseGoingAway, "Resetting the connection")
+			c.wg.Wait()
+			reconnects.Inc("requested")
 		}
 	}
 }
@@ -188,6 +209,35 @@ func (c *Client) Close(closeCode int, text string) {
 	}
 }
 
+// LastActivity returns when a message or a pong was last received from
+// Stripe, or the zero time if the client never connected.
+func (c *Client) LastActivity() time.Time {
+	nanos := atomic.LoadInt64(&c.lastActivity)
+	if nanos == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, nanos)
+}
+
+// Reset closes the connection and reconnects with the same websocket
+// session, e.g. when it stopped responding. It has no effect while the client
+// isn't connected.
+func (c *Client) Reset() {
+	if !c.isConnected {
+		return
+	}
+
+	select {
+	case c.reset <- struct{}{}:
+	default:
+	}
+}
+
+func (c *Client) touch() {
+	atomic.StoreInt64(&c.lastActivity, time.Now().UnixNano())
+}
+
 // Stop stops listening for incoming webhook events.
 func (c *Client) Stop() {
 	close(c.done)
@@ -234,7 +284,13 @@ var ErrUnknownID = errors.New(unknownIDMessage)
 // connect makes a single attempt to connect to the websocket URL. It returns
 // the success of the attempt.
 
-func (c *Client) connect(ctx context.Context) error {
+func (c *Client) connect(ctx context.Context) (err error) {
+	ctx, span := stripe.StartSpan(ctx, "websocket connect", stripe.SpanKindClient)
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
+
 	header := http.Header{}
 	// Disable compression by requiring "identity"
 	header.Set("Accept-Encoding", "identity")
@@ -249,6 +305,9 @@ func (c *Client) connect(ctx context.Context) error {
 
 	url = url + "?websocket_feature=" + c.WebSocketAuthorizedFeature
 
+	span.SetAttribute("websocket.url", url)
+	span.SetAttribute("stripe.websocket_feature", c.WebSocketAuthorizedFeature)
+
 	c.cfg.Log.WithFields(log.Fields{
 		"prefix": "websocket.Client.connect",
 		"url":    url,
@@ -271,6 +330,7 @@ func (c *Client) connect(ctx context.Context) error {
 	defer resp.Body.Close()
 
 	c.changeConnection(conn)
+	c.touch()
 	c.isConnected = true
 
 	c.wg = &sync.WaitGroup{}
@@ -314,6 +374,8 @@ func (c *Client) readPump() {
 			"prefix": "websocket.Client.readPump",
 		}).Debug("Received pong message")
 
+		c.touch()
+
 		err := c.conn.SetReadDeadline(time.Now().Add(c.cfg.PongWait))
 		if err != nil {
 			c.cfg.Log.Debug("SetReadDeadline error: ", err)
@@ -357,6 +419,8 @@ func (c *Client) readPump() {
 			return
 		}
 
+		c.touch()
+
 		c.cfg.Log.WithFields(log.Fields{
 			"prefix":  "websocket.Client.readPump",
 			"message": string(data),
@@ -476,7 +540,7 @@ func NewClient(url string, webSocketID string, websocketAuthorizedFeature string
 	}
 
 	if cfg.Dialer == nil {
-		cfg.Dialer = newWebSocketDialer(os.Getenv("STRIPE_CLI_UNIX_SOCKET"))
+		cfg.Dialer = newWebSocketDialer(os.Getenv("STRIPE_CLI_UNIX_SOCKET"), cfg.Proxy)
 	}
 
 	if cfg.Log == nil {
@@ -513,6 +577,7 @@ func NewClient(url string, webSocketID string, websocketAuthorizedFeature string
 		WebSocketAuthorizedFeature: websocketAuthorizedFeature,
 		cfg:                        cfg,
 		done:                       make(chan struct{}),
+		reset:                      make(chan struct{}, 1),
 		send:                       make(chan *OutgoingMessage),
 		NotifyExpired:              make(chan struct{}),
 	}
@@ -546,7 +611,7 @@ var nullEventHandler = EventHandlerFunc(func(IncomingMessage) {})
 // Private functions
 //
 
-func newWebSocketDialer(unixSocket string) *ws.Dialer {
+func newWebSocketDialer(unixSocket string, proxy *netproxy.Dialer) *ws.Dialer {
 	var dialer *ws.Dialer
 
 	if unixSocket != "" {
@@ -558,9 +623,16 @@ func newWebSocketDialer(unixSocket string) *ws.Dialer {
 			NetDial:          dialFunc,
 			Subprotocols:     subprotocols[:],
 		}
+	} else if proxy != nil {
+		dialer = &ws.Dialer{
+			HandshakeTimeout: 10 * time.Second,
+			NetDialContext:   proxy.DialContext,
+			Subprotocols:     subprotocols[:],
+		}
 	} else {
 		dialer = &ws.Dialer{
 			HandshakeTimeout: 10 * time.Second,
+			NetDialContext:   netproxy.DialContext,
 			Proxy:            http.ProxyFromEnvironment,
 			Subprotocols:     subprotocols[:],
 		}
@@ -3,6 +3,7 @@ package websocket
 import (
 	"context"
 	"encoding/json"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -13,6 +14,8 @@ import (
 	ws "github.com/gorilla/websocket"
 	// log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/netproxy"
 )
 
 func TestClientWebhookEventHandler(t *testing.T) {
@@ -228,3 +231,94 @@ func TestClientExpiredError(t *testing.T) {
 
 	wg.Wait()
 } */
+
+func TestClientReset(t *testing.T) {
+	upgrader := ws.Upgrader{}
+	connections := make(chan struct{}, 2)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer c.Close()
+
+		connections <- struct{}{}
+
+		for {
+			if _, _, err := c.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+
+	defer ts.Close()
+
+	url := "ws" + strings.TrimPrefix(ts.URL, "http")
+
+	client := NewClient(url, "websocket-random-id", "webhook-payloads", &Config{
+		CloseDelayPeriod: 10 * time.Millisecond,
+	})
+	require.True(t, client.LastActivity().IsZero())
+
+	go client.Run(context.Background())
+
+	defer client.Stop()
+
+	select {
+	case <-connections:
+	case <-time.After(500 * time.Millisecond):
+		require.FailNow(t, "Timed out waiting for the client to connect")
+	}
+
+	<-client.Connected()
+	require.False(t, client.LastActivity().IsZero())
+
+	client.Reset()
+
+	select {
+	case <-connections:
+	case <-time.After(500 * time.Millisecond):
+		require.FailNow(t, "Timed out waiting for the client to reconnect")
+	}
+}
+
+func TestClientResolveOverride(t *testing.T) {
+	var port string
+	upgrader := ws.Upgrader{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The host is still the one of the URL, only its address changes
+		require.Equal(t, "stripe-cli.invalid:"+port, r.Host)
+		c, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer c.Close()
+
+		msg, err := json.Marshal(WebhookEvent{EventPayload: "{}", Type: "webhook_event"})
+		require.NoError(t, err)
+		require.NoError(t, c.WriteMessage(ws.TextMessage, msg))
+	}))
+	defer ts.Close()
+
+	_, port, _ = net.SplitHostPort(ts.Listener.Addr().String())
+	netproxy.SetResolver(&netproxy.Resolver{Overrides: map[string]string{"stripe-cli.invalid:" + port: "127.0.0.1"}})
+	defer netproxy.SetResolver(nil)
+
+	rcvMsgChan := make(chan WebhookEvent)
+	client := NewClient(
+		"ws://stripe-cli.invalid:"+port,
+		"websocket-random-id",
+		"webhook-payloads",
+		&Config{
+			EventHandler: EventHandlerFunc(func(msg IncomingMessage) {
+				rcvMsgChan <- *msg.WebhookEvent
+			}),
+		},
+	)
+
+	go client.Run(context.Background())
+	defer client.Stop()
+
+	select {
+	case rcvMsg := <-rcvMsgChan:
+		require.Equal(t, "{}", rcvMsg.EventPayload)
+	case <-time.After(500 * time.Millisecond):
+		require.FailNow(t, "Timed out waiting for response from test server")
+	}
+}
@@ -9,6 +9,9 @@ import (
 type IncomingMessage struct {
 	*WebhookEvent
 	*RequestLogEvent
+
+	// TunnelRequest isn't embedded so the fields of WebhookEvent stay promoted
+	TunnelRequest *TunnelRequest
 }
 
 // UnmarshalJSON deserializes incoming messages sent by Stripe into the
@@ -36,6 +39,13 @@ func (m *IncomingMessage) UnmarshalJSON(data []byte) error {
 		}
 
 		m.RequestLogEvent = &evt
+	case "tunnel_request":
+		var req TunnelRequest
+		if err := json.Unmarshal(data, &req); err != nil {
+			return err
+		}
+
+		m.TunnelRequest = &req
 	default:
 		return fmt.Errorf("Unexpected message type: %s", incomingMessageTypeOnly.Type)
 	}
@@ -49,6 +59,8 @@ func (m OutgoingMessage) MarshalJSON() ([]byte, error) {
 		return json.Marshal(m.WebhookResponse)
 	} else if m.EventAck != nil {
 		return json.Marshal(m.EventAck)
+	} else if m.TunnelResponse != nil {
+		return json.Marshal(m.TunnelResponse)
 	}
 
 	return json.Marshal(nil)
@@ -77,4 +89,8 @@ func NewEventAck(eventID, webhookConversationID string) *OutgoingMessage {
 type OutgoingMessage struct {
 	*WebhookResponse
 	*EventAck
+
+	// TunnelResponse isn't embedded so the fields of WebhookResponse stay
+	// promoted
+	TunnelResponse *TunnelResponse
 }
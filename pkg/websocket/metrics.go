@@ -0,0 +1,11 @@
+package websocket
+
+import (
+	"github.com/stripe/stripe-cli/pkg/metrics"
+)
+
+var reconnects = metrics.NewCounterVec(
+	"stripe_listen_websocket_reconnects_total",
+	"Number of times the websocket connection to Stripe was re-established, by reason.",
+	"reason",
+)
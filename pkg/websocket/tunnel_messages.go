@@ -0,0 +1,36 @@
+package websocket
+
+// TunnelRequest represents incoming request messages sent by Stripe when the
+// public URL of a tunnel receives an HTTP request. Path includes the query
+// string, and Body is base64 encoded so binary bodies survive the trip.
+type TunnelRequest struct {
+	TunnelRequestID string            `json:"tunnel_request_id"`
+	Method          string            `json:"method"`
+	Path            string            `json:"path"`
+	HTTPHeaders     map[string]string `json:"http_headers"`
+	Body            []byte            `json:"body"`
+	Type            string            `json:"type"`
+}
+
+// TunnelResponse represents outgoing response messages sent to Stripe for a
+// TunnelRequest.
+type TunnelResponse struct {
+	TunnelRequestID string            `json:"tunnel_request_id"`
+	Status          int               `json:"status"`
+	HTTPHeaders     map[string]string `json:"http_headers"`
+	Body            []byte            `json:"body"`
+	Type            string            `json:"type"`
+}
+
+// NewTunnelResponse returns a new TunnelResponse message.
+func NewTunnelResponse(tunnelRequestID string, status int, body []byte, headers map[string]string) *OutgoingMessage {
+	return &OutgoingMessage{
+		TunnelResponse: &TunnelResponse{
+			TunnelRequestID: tunnelRequestID,
+			Status:          status,
+			Body:            body,
+			HTTPHeaders:     headers,
+			Type:            "tunnel_response",
+		},
+	}
+}
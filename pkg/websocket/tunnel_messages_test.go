@@ -0,0 +1,45 @@
+package websocket
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestUnmarshalTunnelRequest(t *testing.T) {
+	var data = `{"type": "tunnel_request", "tunnel_request_id": "tr_123", "method": "POST", "path": "/callback?state=1", "http_headers": {"Content-Type": "application/json"}, "body": "eyJvayI6dHJ1ZX0="}`
+
+	var msg IncomingMessage
+	err := json.Unmarshal([]byte(data), &msg)
+	require.NoError(t, err)
+
+	require.NotNil(t, msg.TunnelRequest)
+	require.Nil(t, msg.WebhookEvent)
+
+	require.Equal(t, "tr_123", msg.TunnelRequest.TunnelRequestID)
+	require.Equal(t, "POST", msg.TunnelRequest.Method)
+	require.Equal(t, "/callback?state=1", msg.TunnelRequest.Path)
+	require.Equal(t, "application/json", msg.TunnelRequest.HTTPHeaders["Content-Type"])
+	require.Equal(t, `{"ok":true}`, string(msg.TunnelRequest.Body))
+}
+
+func TestMarshalTunnelResponse(t *testing.T) {
+	msg := NewTunnelResponse(
+		"tr_123",
+		201,
+		[]byte("created"),
+		map[string]string{"Response-Header": "bar"},
+	)
+
+	buf, err := json.Marshal(msg)
+	require.NoError(t, err)
+
+	json := string(buf)
+	require.Equal(t, "tunnel_response", gjson.Get(json, "type").String())
+	require.Equal(t, "tr_123", gjson.Get(json, "tunnel_request_id").String())
+	require.Equal(t, int64(201), gjson.Get(json, "status").Int())
+	require.Equal(t, "Y3JlYXRlZA==", gjson.Get(json, "body").String())
+	require.Equal(t, "bar", gjson.Get(json, "http_headers.Response-Header").String())
+}
@@ -44,7 +44,7 @@ var file_commands_proto_rawDesc = []byte{
 	0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x5f, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x63,
 	0x72, 0x65, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x77, 0x65, 0x62,
 	0x68, 0x6f, 0x6f, 0x6b, 0x5f, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x5f, 0x6c,
-	0x69, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0xa5, 0x07, 0x0a, 0x09, 0x53, 0x74,
+	0x69, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0xaa, 0x07, 0x0a, 0x09, 0x53, 0x74,
 	0x72, 0x69, 0x70, 0x65, 0x43, 0x4c, 0x49, 0x12, 0x43, 0x0a, 0x0c, 0x45, 0x76, 0x65, 0x6e, 0x74,
 	0x73, 0x52, 0x65, 0x73, 0x65, 0x6e, 0x64, 0x12, 0x18, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x76,
 	0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
@@ -103,9 +103,10 @@ var file_commands_proto_rawDesc = []byte{
 	0x6e, 0x74, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
 	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x45, 0x6e, 0x64, 0x70,
 	0x6f, 0x69, 0x6e, 0x74, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x42, 0x22, 0x5a, 0x20, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x73, 0x74, 0x72, 0x69, 0x70, 0x65, 0x2f, 0x73, 0x74, 0x72, 0x69, 0x70, 0x65, 0x2d, 0x63, 0x6c,
-	0x69, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x1a, 0x03, 0x88, 0x02, 0x01, 0x42, 0x22, 0x5a, 0x20, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x74, 0x72, 0x69, 0x70, 0x65, 0x2f, 0x73, 0x74, 0x72, 0x69,
+	0x70, 0x65, 0x2d, 0x63, 0x6c, 0x69, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var file_commands_proto_goTypes = []interface{}{
@@ -223,6 +224,8 @@ const _ = grpc.SupportPackageIsVersion6
 // StripeCLIClient is the client API for StripeCLI service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+//
+// Deprecated: Do not use.
 type StripeCLIClient interface {
 	// Resend an event given an event ID. Like `stripe events resend`.
 	EventsResend(ctx context.Context, in *EventsResendRequest, opts ...grpc.CallOption) (*EventsResendResponse, error)
@@ -260,6 +263,7 @@ type stripeCLIClient struct {
 	cc grpc.ClientConnInterface
 }
 
+// Deprecated: Do not use.
 func NewStripeCLIClient(cc grpc.ClientConnInterface) StripeCLIClient {
 	return &stripeCLIClient{cc}
 }
@@ -437,6 +441,8 @@ func (c *stripeCLIClient) WebhookEndpointsList(ctx context.Context, in *WebhookE
 }
 
 // StripeCLIServer is the server API for StripeCLI service.
+//
+// Deprecated: Do not use.
 type StripeCLIServer interface {
 	// Resend an event given an event ID. Like `stripe events resend`.
 	EventsResend(context.Context, *EventsResendRequest) (*EventsResendResponse, error)
@@ -517,6 +523,7 @@ func (*UnimplementedStripeCLIServer) WebhookEndpointsList(context.Context, *Webh
 	return nil, status.Errorf(codes.Unimplemented, "method WebhookEndpointsList not implemented")
 }
 
+// Deprecated: Do not use.
 func RegisterStripeCLIServer(s *grpc.Server, srv StripeCLIServer) {
 	s.RegisterService(&_StripeCLI_serviceDesc, srv)
 }
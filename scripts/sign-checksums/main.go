@@ -0,0 +1,42 @@
+// Command sign-checksums signs the checksums file of a release, so that
+// `stripe update` can verify the release against selfupdate.PublicKey. It's
+// run by GoReleaser with the base64 ed25519 private key of the releases in
+// RELEASE_SIGNING_KEY, and writes the base64 signature.
+//
+//	go run ./scripts/sign-checksums <checksums> <signature>
+package main
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: sign-checksums <checksums> <signature>")
+	}
+
+	key, err := base64.StdEncoding.DecodeString(os.Getenv("RELEASE_SIGNING_KEY"))
+	if err != nil || len(key) != ed25519.PrivateKeySize {
+		return fmt.Errorf("RELEASE_SIGNING_KEY must be a base64 ed25519 private key")
+	}
+
+	checksums, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	signature := ed25519.Sign(ed25519.PrivateKey(key), checksums)
+
+	return ioutil.WriteFile(args[1], []byte(base64.StdEncoding.EncodeToString(signature)), 0644) // #nosec G306
+}